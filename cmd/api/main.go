@@ -1,26 +1,39 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/chaos"
 	"github.com/carllix/matchaciee-backend/internal/config"
 	"github.com/carllix/matchaciee-backend/internal/database"
+	"github.com/carllix/matchaciee-backend/internal/database/migrations"
 	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/carllix/matchaciee-backend/internal/routes"
+	"github.com/carllix/matchaciee-backend/internal/scheduler"
 	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/startup"
 	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/swagger"
+	"github.com/google/uuid"
 )
 
 // @title Matchaciee API
@@ -34,6 +47,11 @@ import (
 // @name Authorization
 // @description Enter your bearer token in the format: Bearer {token}
 
+// @securityDefinitions.apikey KioskKey
+// @in header
+// @name X-Kiosk-Key
+// @description API key issued to a kiosk by an admin
+
 // @tag.name Auth
 // @tag.description Authentication endpoints for user registration, login, and token management
 
@@ -52,6 +70,9 @@ import (
 // @tag.name Webhooks
 // @tag.description Webhook endpoints for payment notifications
 
+// @tag.name Status
+// @tag.description Public service status for the status page
+
 func main() {
 	// Load configuration
 	cfg, err := config.Load()
@@ -59,47 +80,96 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	log.Printf("Starting %s in %s mode...", cfg.AppName, cfg.Env)
+	// Log JSON lines from LOG_LEVEL up; the standard log package writes
+	// through the same logger
+	logger, err := logging.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	logger.Info("Starting server", "app", cfg.AppName, "env", cfg.Env)
 
 	// Connect to database
-	if err := database.Connect(cfg); err != nil {
+	if err := database.Connect(cfg, logger); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer func() {
 		if err := database.Close(); err != nil {
-			log.Printf("Error closing database: %v", err)
+			logger.Error("Error closing database", logging.Err(err))
 		}
 	}()
 
+	// Verify dependencies before serving; hard failures block production boots
+	if err := runStartupChecks(cfg, logger); err != nil {
+		log.Fatalf("Startup checks failed: %v", err)
+	}
+
+	// Track database health to shed low-priority traffic under load
+	dbMonitor := metrics.NewDBMonitor(cfg.LoadShedWindow)
+	if err := dbMonitor.Register(database.GetDB()); err != nil {
+		log.Fatalf("Failed to register database metrics: %v", err)
+	}
+	loadShedding := middleware.LoadSheddingConfig{
+		MaxDBLatency:   cfg.LoadShedDBLatency,
+		MaxDBErrorRate: cfg.LoadShedErrorRate,
+		MinQueries:     20,
+		RetryAfter:     cfg.LoadShedWindow,
+	}
+	statusTracker := metrics.NewStatusTracker(24 * time.Hour)
+
 	// Initialize app
-	app := fiber.New(fiber.Config{
+	appConfig := fiber.Config{
 		AppName:      cfg.AppName,
 		ErrorHandler: errorHandler,
-	})
+		// Leave room for the multipart envelope around the largest upload
+		BodyLimit: max(fiber.DefaultBodyLimit, (cfg.MaxUploadMB+1)<<20),
+	}
+	// Rate limits and the webhook allowlist see the client behind the proxy
+	middleware.TrustProxies(&appConfig, cfg.ProxyHeader, cfg.TrustedProxies)
+	app := fiber.New(appConfig)
 
 	// Global middleware
+	app.Use(middleware.RequestIDMiddleware())
+	app.Use(middleware.RequestLogMiddleware(logger))
 	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
-	}))
+	app.Use(middleware.LocaleMiddleware())
+
+	// Count requests per client app in memory; flushed to the database below
+	usageCollector := metrics.NewUsageCollector(logger)
+	app.Use(middleware.UsageMiddleware(usageCollector))
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: joinOrigins(cfg.AllowedOrigins),
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		AllowOrigins:  joinOrigins(cfg.AllowedOrigins),
+		AllowHeaders:  "Origin, Content-Type, Accept, Accept-Language, Authorization, If-None-Match, X-Client-Name, X-Request-ID",
+		AllowMethods:  "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		ExposeHeaders: "ETag, X-QR-Code-URL, X-Request-ID",
 	}))
 
+	// Staging fault injection runs ahead of every route it can affect
+	var chaosInjector *chaos.Injector
+	if cfg.ChaosEnabled {
+		logger.Warn("Chaos endpoints enabled, faults can be injected via /api/v1/chaos")
+		chaosInjector = chaos.NewInjector()
+		app.Use(chaosInjector.Middleware())
+	}
+
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		dbConnected := database.IsConnected()
+		dbStats := dbMonitor.Stats()
+		shedding := loadShedding.IsOverloaded(dbStats)
 		status := "ok"
-		if !dbConnected {
+		if !dbConnected || shedding {
 			status = "degraded"
 		}
 
 		return c.JSON(fiber.Map{
-			"status":   status,
-			"service":  cfg.AppName,
-			"database": dbConnected,
+			"status":                status,
+			"service":               cfg.AppName,
+			"database":              dbConnected,
+			"database_latency_ms":   dbStats.AverageLatency.Milliseconds(),
+			"database_error_rate":   dbStats.ErrorRate,
+			"shedding_low_priority": shedding,
 		})
 	})
 
@@ -113,48 +183,443 @@ func main() {
 
 	// Swagger documentation
 	app.Get("/swagger/*", swagger.HandlerDefault)
+	app.Static("/uploads", cfg.UploadDir)
 
 	// Initialize dependencies
 	db := database.GetDB()
 	jwtUtil := utils.NewJWTUtil(cfg.JWTSecret, cfg.JWTExpiry, cfg.RefreshTokenExpiry)
 
+	// Money and dates in receipts and notifications follow the store locale
+	formatter, err := utils.NewFormatter(cfg.StoreLocale, cfg.StoreTimezone)
+	if err != nil {
+		log.Fatalf("Failed to initialize formatter: %v", err)
+	}
+
+	orderNumbers, err := utils.NewOrderNumberFormat(cfg.OrderNumberPrefix, cfg.OrderNumberDate, cfg.OrderNumberWidth, cfg.OrderNumberReset, cfg.StoreTimezone)
+	if err != nil {
+		log.Fatalf("Failed to initialize order number format: %v", err)
+	}
+
+	// Emails are logged instead of sent until SMTP is configured
+	var mailer utils.Mailer = utils.NewLogMailer(logger)
+	if cfg.SMTPHost != "" {
+		mailer, err = utils.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		if err != nil {
+			log.Fatalf("Failed to initialize mailer: %v", err)
+		}
+	}
+
+	// WhatsApp messages are logged instead of sent until the Cloud API is configured
+	var whatsApp utils.WhatsAppSender = utils.NewLogWhatsAppSender(logger)
+	if cfg.WhatsAppToken != "" {
+		whatsApp = utils.NewCloudWhatsAppSender(cfg.WhatsAppToken, cfg.WhatsAppPhoneID)
+	}
+
+	// Payment alerts go to the configured admin emails and Slack channel
+	var slack utils.SlackSender
+	if cfg.AlertSlackURL != "" {
+		slack = utils.NewSlackWebhookSender(cfg.AlertSlackURL)
+	}
+
+	// Order events reach realtime clients on every instance unless running local-only
+	var broker realtime.Broker = realtime.NewLocalBroker()
+	if cfg.RealtimeBroker == "postgres" {
+		broker = realtime.NewPostgresBroker(db, cfg.GetDSN(), logger)
+	}
+	defer func() {
+		if err := broker.Close(); err != nil {
+			logger.Error("Error closing realtime broker", logging.Err(err))
+		}
+	}()
+
+	// Public status reports states only; details stay on /health
+	statusChecks := []metrics.Check{
+		{Name: "database", State: func() metrics.ComponentState {
+			if !database.IsConnected() {
+				return metrics.StateDown
+			}
+			if loadShedding.IsOverloaded(dbMonitor.Stats()) {
+				return metrics.StateDegraded
+			}
+			return metrics.StateOperational
+		}},
+		{Name: "realtime", State: func() metrics.ComponentState {
+			if pg, ok := broker.(*realtime.PostgresBroker); ok && !pg.Listening() {
+				return metrics.StateDegraded
+			}
+			return metrics.StateOperational
+		}},
+	}
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
 	productRepo := repositories.NewProductRepository(db)
-	orderRepo := repositories.NewOrderRepository(db)
+	orderRepo := repositories.NewOrderRepository(db, orderNumbers)
 	paymentRepo := repositories.NewPaymentRepository(db)
+	refundRepo := repositories.NewRefundRepository(db)
+	paymentLinkRepo := repositories.NewPaymentLinkRepository(db)
+	walletRepo := repositories.NewWalletRepository(db)
+	giftCardRepo := repositories.NewGiftCardRepository(db)
+	voucherRepo := repositories.NewVoucherRepository(db)
+	campaignRepo := repositories.NewCampaignRepository(db)
+	customerRepo := repositories.NewCustomerRepository(db)
+	rewardRepo := repositories.NewRewardRepository(db)
+	loyaltyRepo := repositories.NewLoyaltyRepository(db)
+	webhookEventRepo := repositories.NewWebhookEventRepository(db)
+	reservationRepo := repositories.NewStockReservationRepository(db)
+	reportRepo := repositories.NewReportRepository(db)
+	pricingRepo := repositories.NewSourcePricingRepository(db)
+	settingRepo := repositories.NewSettingRepository(db)
+	emailTemplateRepo := repositories.NewEmailTemplateRepository(db)
+	catalogRepo := repositories.NewCatalogRepository(db)
+	jobLockRepo := repositories.NewJobLockRepository(db)
+	rateLimitRepo := repositories.NewRateLimitRepository(db)
+	trashRepo := repositories.NewTrashRepository(db)
+	integrityRepo := repositories.NewIntegrityRepository(db)
+	mediaRepo := repositories.NewMediaRepository(db)
+	cartRepo := repositories.NewCartRepository(db)
+	usageRepo := repositories.NewUsageRepository(db)
+	loginCodeRepo := repositories.NewLoginCodeRepository(db)
+	promotionRepo := repositories.NewPromotionRepository(db)
+	tableRepo := repositories.NewDiningTableRepository(db)
+	summaryRepo := repositories.NewDailySummaryRepository(db)
+	kioskRepo := repositories.NewKioskRepository(db)
+	printJobRepo := repositories.NewPrintJobRepository(db)
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, refreshTokenRepo, jwtUtil)
 	categoryService := services.NewCategoryService(categoryRepo)
 	productService := services.NewProductService(productRepo, categoryRepo)
-	orderService := services.NewOrderService(orderRepo, productRepo, userRepo)
+	menuService := services.NewMenuService(categoryRepo, productRepo, catalogRepo)
+	settingsService := services.NewSettingsService(settingRepo)
+	pickupCodes := utils.NewPickupCodeSigner(cfg.JWTSecret)
+	orderService := services.NewOrderService(orderRepo, productRepo, userRepo, reservationRepo, pricingRepo, promotionRepo, voucherRepo, loyaltyRepo, tableRepo, settingsService, pickupCodes, broker, logger, services.OrderConfig{
+		ReservationTTL: cfg.StockReservationTTL,
+		PaymentExpiry:  cfg.PaymentExpiry,
+		RushAfter:      cfg.RushAfter,
+
+		PreorderWindow:   time.Duration(cfg.PreorderMaxDays) * 24 * time.Hour,
+		PreorderLeadTime: cfg.PreorderLeadTime,
+		ShareTokenTTL:    cfg.ShareTokenTTL,
+		Charges: map[models.OrderType]services.OrderTypeCharges{
+			models.OrderTypeDineIn:   {TaxRate: cfg.DineInTax / 100, ServiceChargeRate: cfg.DineInService / 100},
+			models.OrderTypeTakeaway: {TaxRate: cfg.TakeawayTax / 100, ServiceChargeRate: cfg.TakeawayService / 100},
+			models.OrderTypeDelivery: {TaxRate: cfg.DeliveryTax / 100, ServiceChargeRate: cfg.DeliveryService / 100, DeliveryFee: cfg.DeliveryFee},
+		},
+		Location: formatter.Location(),
+	})
+	gatewayFees, err := services.ParseGatewayFees(cfg.GatewayFees)
+	if err != nil {
+		log.Fatalf("GATEWAY_FEES is invalid: %v", err)
+	}
+	reportService := services.NewReportService(reportRepo, services.ReportConfig{
+		GatewayFees:     gatewayFees,
+		PayoutDelayDays: cfg.PayoutDelayDays,
+	})
+	pricingService := services.NewPricingService(pricingRepo)
+	promotionService := services.NewPromotionService(promotionRepo, productRepo, categoryRepo)
+	tableService := services.NewTableService(tableRepo, cfg.FrontendURL)
+	summaryService := services.NewDailySummaryService(summaryRepo, userRepo)
+	kioskService := services.NewKioskService(kioskRepo, cfg.KioskOfflineAfter)
+	receiptService := services.NewReceiptService(settingsService, formatter, pickupCodes)
+	emailTemplateService := services.NewEmailTemplateService(emailTemplateRepo, mailer, formatter)
+	trashService := services.NewTrashService(trashRepo, productRepo, categoryRepo)
+	integrityService := services.NewIntegrityService(integrityRepo, logger)
+	qrCodeService := services.NewQRCodeService(orderRepo, tableRepo, settingsService, cfg.FrontendURL)
+	mediaService := services.NewMediaService(mediaRepo, logger, services.MediaSettings{
+		Dir:          cfg.UploadDir,
+		BaseURL:      cfg.APIURL,
+		QuotaBytes:   int64(cfg.StorageQuotaMB) << 20,
+		MaxFileBytes: int64(cfg.MaxUploadMB) << 20,
+		CleanupAfter: time.Duration(cfg.MediaCleanupDays) * 24 * time.Hour,
+	})
+	cartService := services.NewCartService(cartRepo, productRepo, userRepo, orderService, logger)
+	usageService := services.NewUsageService(usageRepo)
+	loginCodeSettings := services.LoginCodeSettings{
+		Expiry:        cfg.OTPExpiry,
+		MaxAttempts:   cfg.OTPMaxAttempts,
+		RequestLimit:  cfg.OTPRequestLimit,
+		RequestWindow: cfg.OTPRequestWindow,
+		Secret:        cfg.JWTSecret,
+	}
+	if cfg.OTPEnabled {
+		loginCodeSettings.Channels = cfg.OTPChannels
+	}
+	notificationService := services.NewNotificationService(
+		orderRepo,
+		userRepo,
+		paymentRepo,
+		settingsService,
+		receiptService,
+		emailTemplateService,
+		whatsApp,
+		broker,
+		formatter,
+		logger,
+		cfg.FrontendURL,
+	)
+	printService := services.NewPrintService(
+		printJobRepo,
+		orderRepo,
+		settingsService,
+		receiptService,
+		broker,
+		logger,
+		cfg.PrintStation,
+		map[models.OrderType]string{
+			models.OrderTypeDineIn:   cfg.DineInStation,
+			models.OrderTypeTakeaway: cfg.TakeawayStation,
+			models.OrderTypeDelivery: cfg.DeliveryStation,
+		},
+	)
+	loginCodeService := services.NewLoginCodeService(userRepo, loginCodeRepo, refreshTokenRepo, emailTemplateService, whatsApp, jwtUtil, formatter, logger, loginCodeSettings)
+	alertService := services.NewAlertService(mailer, slack, services.AlertConfig{
+		Emails:            cfg.AlertEmails,
+		SignatureFailures: cfg.AlertSigFailures,
+		SignatureWindow:   cfg.AlertSigWindow,
+	})
 	paymentService := services.NewPaymentService(
 		paymentRepo,
+		refundRepo,
 		orderRepo,
+		userRepo,
+		reservationRepo,
+		walletRepo,
+		giftCardRepo,
+		loyaltyRepo,
+		settingsService,
+		alertService,
+		broker,
+		logger,
+		services.PaymentConfig{
+			Provider:            models.PaymentMethod(cfg.PaymentProvider),
+			MidtransServerKey:   cfg.MidtransServerKey,
+			MidtransClientKey:   cfg.MidtransClientKey,
+			MidtransEnvironment: cfg.MidtransEnvironment,
+			MidtransFinishURL:   cfg.MidtransFinishURL,
+			MidtransUnfinishURL: cfg.MidtransUnfinishURL,
+			MidtransErrorURL:    cfg.MidtransErrorURL,
+			StripeSecretKey:     cfg.StripeSecretKey,
+			StripeWebhookSecret: cfg.StripeWebhookSecret,
+			XenditSecretKey:     cfg.XenditSecretKey,
+			XenditCallbackToken: cfg.XenditCallbackToken,
+			PaymentExpiry:       cfg.PaymentExpiry,
+			CancelExpiredOrders: cfg.CancelExpiredOrders,
+			FrontendURL:         cfg.FrontendURL,
+			GatewayFees:         gatewayFees,
+		},
+	)
+	webhookService := services.NewWebhookService(webhookEventRepo, paymentService, logger)
+	walletService := services.NewWalletService(walletRepo, userRepo)
+	giftCardService := services.NewGiftCardService(giftCardRepo, userRepo)
+	voucherService := services.NewVoucherService(voucherRepo, userRepo)
+	campaignService := services.NewCampaignService(campaignRepo, userRepo, mailer, whatsApp, cfg.CampaignBatchSize)
+	customerService := services.NewCustomerService(customerRepo)
+	rewardService := services.NewRewardService(rewardRepo, loyaltyRepo, userRepo, categoryRepo)
+	loyaltyService := services.NewLoyaltyService(loyaltyRepo, userRepo, settingsService, emailTemplateService, formatter, logger)
+	paymentLinkService := services.NewPaymentLinkService(paymentLinkRepo, orderRepo, userRepo, paymentService, cfg.APIURL, cfg.PaymentLinkTTL)
+	var selftestProductID uuid.UUID
+	if cfg.SelftestToken != "" {
+		if selftestProductID, err = uuid.Parse(cfg.SelftestProductID); err != nil {
+			log.Fatalf("SELFTEST_PRODUCT_ID is not a valid product ID: %v", err)
+		}
+	}
+	selftestService := services.NewSelftestService(
+		orderService,
+		paymentService,
+		orderRepo,
+		paymentRepo,
+		reservationRepo,
+		logger,
+		selftestProductID,
 		cfg.MidtransServerKey,
-		cfg.MidtransClientKey,
-		cfg.MidtransEnvironment,
 	)
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, loginCodeService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	productHandler := handlers.NewProductHandler(productService)
-	orderHandler := handlers.NewOrderHandler(orderService)
-	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	menuHandler := handlers.NewMenuHandler(menuService)
+	orderHandler := handlers.NewOrderHandler(orderService, formatter.Location())
+	paymentHandler := handlers.NewPaymentHandler(paymentService, webhookService, formatter.Location(), logger)
+	paymentLinkHandler := handlers.NewPaymentLinkHandler(paymentLinkService, logger)
+	walletHandler := handlers.NewWalletHandler(walletService, paymentService, logger)
+	giftCardHandler := handlers.NewGiftCardHandler(giftCardService, paymentService, logger)
+	voucherHandler := handlers.NewVoucherHandler(voucherService, logger)
+	campaignHandler := handlers.NewCampaignHandler(campaignService, logger)
+	customerHandler := handlers.NewCustomerHandler(customerService, logger)
+	rewardHandler := handlers.NewRewardHandler(rewardService, logger)
+	loyaltyHandler := handlers.NewLoyaltyHandler(loyaltyService, paymentService, logger)
+	reportHandler := handlers.NewReportHandler(reportService)
+	pricingHandler := handlers.NewPricingHandler(pricingService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	tableHandler := handlers.NewTableHandler(tableService)
+	summaryHandler := handlers.NewDailySummaryHandler(summaryService, formatter.Location())
+	kioskHandler := handlers.NewKioskHandler(kioskService)
+	settingsHandler := handlers.NewSettingsHandler(settingsService, receiptService)
+	emailTemplateHandler := handlers.NewEmailTemplateHandler(emailTemplateService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	integrityHandler := handlers.NewIntegrityHandler(integrityService)
+	qrCodeHandler := handlers.NewQRCodeHandler(qrCodeService)
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+	selftestHandler := handlers.NewSelftestHandler(selftestService, cfg.SelftestToken)
+	cartHandler := handlers.NewCartHandler(cartService)
+	printJobHandler := handlers.NewPrintJobHandler(printService, cfg.PrintAgentToken)
+	statusHandler := handlers.NewStatusHandler(statusTracker, statusChecks)
+	usageHandler := handlers.NewUsageHandler(usageService)
+	webhookEventHandler := handlers.NewWebhookEventHandler(webhookService)
+
+	// Rate limits count per instance unless their counters are shared through
+	// Postgres. The global limit always counts in memory: it sees every request,
+	// and a database round trip for each would add to the load that load
+	// shedding is trying to take off the database.
+	localRateLimiter := middleware.NewRateLimiter(nil, logger)
+	rateLimiter := localRateLimiter
+	if cfg.RateLimitStore == "postgres" {
+		rateLimiter = middleware.NewRateLimiter(rateLimitRepo, logger)
+	}
+
+	// The Midtrans webhook is signed, but only Midtrans needs to reach it
+	webhookAllowlist, err := middleware.ParseIPAllowlist(cfg.WebhookAllowedIPs)
+	if err != nil {
+		log.Fatalf("MIDTRANS_WEBHOOK_ALLOWED_IPS is invalid: %v", err)
+	}
+	midtransWebhookGuards := []fiber.Handler{
+		middleware.IPAllowlistMiddleware(webhookAllowlist, logger),
+		rateLimiter.Limit("midtrans_webhook", cfg.WebhookRateLimit, cfg.WebhookRateWindow),
+	}
+
+	// Catalog list endpoints answer 304 until a category or product changes
+	catalogETag := middleware.ETagMiddleware(menuService.CatalogVersion)
+	shedLowPriority := middleware.LoadSheddingMiddleware(dbMonitor, loadShedding)
+	kioskAuth := middleware.KioskAuthMiddleware(kioskService)
+
+	// Registered after the health, docs and upload endpoints so those are not counted
+	app.Use(localRateLimiter.Limit("global", cfg.GlobalRateLimit, cfg.GlobalRateWindow))
 
 	// Setup routes
-	routes.SetupAuthRoutes(app, authHandler, jwtUtil)
-	routes.SetupProductRoutes(app, categoryHandler, productHandler, jwtUtil)
-	routes.SetupOrderRoutes(app, orderHandler, jwtUtil)
-	routes.SetupPaymentRoutes(app, paymentHandler)
+	routes.SetupAuthRoutes(app, authHandler, jwtUtil, rateLimiter.Limit("login", cfg.LoginRateLimit, cfg.LoginRateWindow))
+	routes.SetupProductRoutes(app, categoryHandler, productHandler, jwtUtil, catalogETag, shedLowPriority)
+	routes.SetupMenuRoutes(app, menuHandler, catalogETag, shedLowPriority)
+	routes.SetupOrderRoutes(app, orderHandler, jwtUtil,
+		rateLimiter.Limit("order_lookup", cfg.OrderLookupLimit, cfg.OrderLookupWindow),
+		rateLimiter.Limit("guest_order", cfg.GuestOrderRateLimit, cfg.GuestOrderRateWindow),
+		kioskAuth,
+	)
+	routes.SetupPaymentRoutes(app, paymentHandler, jwtUtil, midtransWebhookGuards...)
+	routes.SetupPaymentLinkRoutes(app, paymentLinkHandler, jwtUtil)
+	routes.SetupWalletRoutes(app, walletHandler, jwtUtil)
+	routes.SetupGiftCardRoutes(app, giftCardHandler, jwtUtil)
+	routes.SetupVoucherRoutes(app, voucherHandler, jwtUtil)
+	routes.SetupCampaignRoutes(app, campaignHandler, jwtUtil)
+	routes.SetupRewardRoutes(app, rewardHandler, jwtUtil)
+	routes.SetupLoyaltyRoutes(app, loyaltyHandler, jwtUtil)
+	routes.SetupReportRoutes(app, reportHandler, customerHandler, summaryHandler, jwtUtil, shedLowPriority)
+	routes.SetupCustomerRoutes(app, customerHandler, jwtUtil, shedLowPriority)
+	routes.SetupPricingRoutes(app, pricingHandler, jwtUtil)
+	routes.SetupPromotionRoutes(app, promotionHandler, jwtUtil)
+	routes.SetupTableRoutes(app, tableHandler, jwtUtil)
+	routes.SetupKioskRoutes(app, kioskHandler, jwtUtil, kioskAuth)
+	routes.SetupDailySummaryRoutes(app, summaryHandler, jwtUtil)
+	routes.SetupSettingsRoutes(app, settingsHandler, jwtUtil)
+	routes.SetupEmailTemplateRoutes(app, emailTemplateHandler, jwtUtil)
+	routes.SetupTrashRoutes(app, trashHandler, jwtUtil)
+	routes.SetupIntegrityRoutes(app, integrityHandler, jwtUtil)
+	routes.SetupWebhookEventRoutes(app, webhookEventHandler, jwtUtil)
+	routes.SetupQRCodeRoutes(app, qrCodeHandler, jwtUtil)
+	routes.SetupMediaRoutes(app, mediaHandler, jwtUtil)
+	routes.SetupSelftestRoutes(app, selftestHandler)
+	routes.SetupCartRoutes(app, cartHandler, jwtUtil)
+	routes.SetupPrintJobRoutes(app, printJobHandler)
+	routes.SetupStatusRoutes(app, statusHandler)
+	routes.SetupUsageRoutes(app, usageHandler, jwtUtil, shedLowPriority)
+	if chaosInjector != nil {
+		routes.SetupChaosRoutes(app, handlers.NewChaosHandler(chaosInjector), jwtUtil)
+	}
+	// Simulated settlements are signed with the server key, so live keys must
+	// never reach the simulator even when ENV was left at its default
+	if !cfg.IsProduction() && cfg.MidtransEnvironment == "sandbox" {
+		simulatorService := services.NewPaymentSimulatorService(paymentRepo, orderRepo, paymentService, cfg.MidtransServerKey, cfg.MidtransEnvironment)
+		routes.SetupPaymentSimulatorRoutes(app, handlers.NewPaymentSimulatorHandler(simulatorService, logger), jwtUtil)
+	}
+
+	// Background jobs run on whichever instance holds the job's lease
+	jobs := scheduler.NewScheduler(jobLockRepo, instanceID(), logger)
+	jobs.Every("refresh_token_cleanup", time.Hour, func(ctx context.Context) error {
+		return refreshTokenRepo.DeleteExpiredTokens()
+	})
+	jobs.Every("kiosk_cart_cleanup", time.Hour, func(ctx context.Context) error {
+		_, err := cartRepo.DeleteStaleSessionCarts(time.Now().Add(-24 * time.Hour))
+		return err
+	})
+	jobs.Every("rate_limit_cleanup", time.Hour, func(ctx context.Context) error {
+		_, err := rateLimitRepo.DeleteExpired()
+		return err
+	})
+	jobs.Every("login_code_cleanup", time.Hour, func(ctx context.Context) error {
+		_, err := loginCodeService.CleanupExpired()
+		return err
+	})
+	jobs.Every("order_rush_escalation", time.Minute, func(ctx context.Context) error {
+		_, err := orderService.RushDelayedOrders()
+		return err
+	})
+	jobs.Every("payment_expiry", time.Minute, func(ctx context.Context) error {
+		_, err := paymentService.ExpireStalePayments()
+		return err
+	})
+	jobs.Every("scheduled_order_release", time.Minute, func(ctx context.Context) error {
+		_, err := orderService.ReleaseScheduledOrders()
+		return err
+	})
+	jobs.Every("campaign_delivery", time.Minute, func(ctx context.Context) error {
+		_, err := campaignService.RunDeliveries()
+		return err
+	})
+	jobs.Every("points_expiry", time.Hour, func(ctx context.Context) error {
+		_, err := loyaltyService.ExpirePoints()
+		return err
+	})
+	jobs.Every("points_expiry_warning", time.Hour, func(ctx context.Context) error {
+		_, err := loyaltyService.SendExpiryWarnings()
+		return err
+	})
+	jobs.Every("member_stats_refresh", time.Hour, func(ctx context.Context) error {
+		_, err := customerService.RefreshStats()
+		return err
+	})
+	jobs.Every("order_integrity_check", 24*time.Hour, func(ctx context.Context) error {
+		_, err := integrityService.RunCheck()
+		return err
+	})
+	jobs.Every("media_cleanup", 24*time.Hour, func(ctx context.Context) error {
+		_, err := mediaService.Cleanup()
+		return err
+	})
+	jobs.Every("print_job_cleanup", 24*time.Hour, func(ctx context.Context) error {
+		_, err := printService.CleanupPrinted()
+		return err
+	})
+	jobs.Start()
+
+	// Every instance flushes its own usage counts, unlike the leased jobs above
+	usageCtx, stopUsage := context.WithCancel(context.Background())
+	usageFlushed := make(chan struct{})
+	go func() {
+		defer close(usageFlushed)
+		usageCollector.Run(usageCtx, time.Minute, usageService.Record)
+	}()
+
+	// Tracking links and receipts are sent, and tickets printed, off the request path as orders change
+	notifyCtx, stopNotifications := context.WithCancel(context.Background())
+	go notificationService.Run(notifyCtx)
+	go printService.Run(notifyCtx)
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.AppPort)
-	log.Printf("Server listening on port %s", cfg.AppPort)
+	logger.Info("Server listening", "port", cfg.AppPort)
 
 	// Channel to listen for interrupt signals
 	quit := make(chan os.Signal, 1)
@@ -169,11 +634,15 @@ func main() {
 
 	// Block until we receive a signal
 	<-quit
-	log.Println("Gracefully shutting down...")
+	logger.Info("Gracefully shutting down")
+	jobs.Stop()
 	if err := app.Shutdown(); err != nil {
-		log.Printf("Error during shutdown: %v", err)
+		logger.Error("Error during shutdown", logging.Err(err))
 	}
-	log.Println("Server stopped")
+	stopNotifications()
+	stopUsage()
+	<-usageFlushed
+	logger.Info("Server stopped")
 }
 
 func errorHandler(c *fiber.Ctx, err error) error {
@@ -186,11 +655,56 @@ func errorHandler(c *fiber.Ctx, err error) error {
 	}
 
 	return c.Status(code).JSON(fiber.Map{
-		"success": false,
-		"error":   message,
+		"success":    false,
+		"error":      message,
+		"request_id": utils.RequestID(c),
 	})
 }
 
+// runStartupChecks logs a readiness report and returns an error when a hard
+// check fails in production. Elsewhere failures are only logged so local
+// development works without every dependency.
+func runStartupChecks(cfg *config.Config, logger *slog.Logger) error {
+	expectedVersion, err := migrations.LatestVersion()
+	if err != nil {
+		return fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	report := startup.Run(ctx, []startup.Check{
+		startup.SchemaVersionCheck(database.GetDB(), expectedVersion),
+		startup.MidtransCheck(&http.Client{Timeout: 5 * time.Second}, startup.MidtransBaseURL(cfg.MidtransEnvironment), cfg.MidtransServerKey),
+		startup.StorageCheck(cfg.UploadDir),
+	})
+	report.Log(logger)
+
+	failures := report.HardFailures()
+	if len(failures) == 0 {
+		return nil
+	}
+	if !cfg.IsProduction() {
+		logger.Warn("Continuing despite failed startup checks outside production", "failed", len(failures))
+		return nil
+	}
+
+	names := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		names = append(names, failure.Name)
+	}
+	return fmt.Errorf("hard checks failed: %s", strings.Join(names, ", "))
+}
+
+// instanceID identifies this process when holding job locks
+func instanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString())
+}
+
 func joinOrigins(origins []string) string {
 	return strings.Join(origins, ", ")
 }
@@ -0,0 +1,243 @@
+// Command migrate applies the SQL migrations embedded in the build to the
+// configured database. The API never changes the schema on its own: run
+// "migrate up" before starting a build that needs new migrations, and its
+// startup checks refuse to boot in production against an older schema.
+// "migrate create <name>" adds the next numbered pair of migration files.
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/carllix/matchaciee-backend/internal/config"
+	"github.com/carllix/matchaciee-backend/internal/database/migrations"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: migrate [flags] <command>
+
+Commands:
+  up               Apply pending migrations, all of them unless -steps is set
+  down             Roll back the last migration, or the last -steps migrations
+  status           Show the applied version and the migrations still pending
+  version          Show the applied version and whether it is dirty
+  force <version>  Record the schema as being at version without running any
+                   migration, after a dirty schema was fixed by hand; 0 means
+                   no migration is applied
+  create <name>    Add empty up and down files for the next migration to -dir,
+                   named in lowercase words joined by underscores
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	steps := flag.Int("steps", 0, "number of migrations to apply or roll back")
+	dir := flag.String("dir", "internal/database/migrations", "directory create adds migration files to")
+	flag.Usage = usage
+	flag.Parse()
+
+	logger, err := logging.New(os.Stderr, "info")
+	if err != nil {
+		panic(err)
+	}
+
+	command := flag.Arg(0)
+	args := 1
+	if command == "force" || command == "create" {
+		args = 2
+	}
+	if flag.NArg() != args || *steps < 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	switch command {
+	case "up", "down", "status", "version", "force", "create":
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Creating files needs no database
+	if command == "create" {
+		if err := createMigration(*dir, flag.Arg(1)); err != nil {
+			fatal(logger, "Failed to create migration", err)
+		}
+		return
+	}
+
+	var forceVersion int
+	if command == "force" {
+		forceVersion, err = parseForceVersion(flag.Arg(1))
+		if err != nil {
+			fatal(logger, "Invalid version to force", err)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal(logger, "Failed to load configuration", err)
+	}
+
+	db, err := sql.Open("pgx", cfg.GetDSN())
+	if err != nil {
+		fatal(logger, "Failed to connect to database", err)
+	}
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		fatal(logger, "Failed to prepare migrations", err)
+	}
+	defer func() {
+		if sourceErr, dbErr := m.Close(); sourceErr != nil || dbErr != nil {
+			logger.Error("Error closing migrator", "source_error", sourceErr, "database_error", dbErr)
+		}
+	}()
+
+	switch command {
+	case "up":
+		if *steps > 0 {
+			err = m.Steps(*steps)
+		} else {
+			err = m.Up()
+		}
+	case "down":
+		// Rolling back drops data, so only ever go back a counted number of steps
+		err = m.Steps(-max(*steps, 1))
+	case "force":
+		err = m.Force(forceVersion)
+		if err == nil {
+			logger.Warn("Forced schema version", "version", max(forceVersion, 0))
+		}
+	case "version":
+		if err := printVersion(m); err != nil {
+			fatal(logger, "Failed to read migration version", err)
+		}
+		return
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		err = nil
+	}
+	if err != nil {
+		fatal(logger, "Migration failed", err, "command", command)
+	}
+
+	if err := printStatus(m); err != nil {
+		fatal(logger, "Failed to read migration status", err)
+	}
+}
+
+// fatal logs msg with err and exits, the way log.Fatalf would
+func fatal(logger *slog.Logger, msg string, err error, args ...any) {
+	logger.Error(msg, append(args, logging.Err(err))...)
+	os.Exit(1)
+}
+
+// parseForceVersion accepts the version of an embedded migration, or 0 for a
+// schema without any migration applied
+func parseForceVersion(arg string) (int, error) {
+	version, err := strconv.Atoi(arg)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%q is not a migration version", arg)
+	}
+	if version == 0 {
+		return database.NilVersion, nil
+	}
+
+	list, err := migrations.List()
+	if err != nil {
+		return 0, err
+	}
+	for _, migration := range list {
+		if migration.Version == uint(version) {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("no migration has version %d", version)
+}
+
+// migrationName is the form of the name part of a migration file
+var migrationName = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// createMigration writes empty up and down files numbered one past the
+// highest migration in dir
+func createMigration(dir, name string) error {
+	if !migrationName.MatchString(name) {
+		return fmt.Errorf("%q must be lowercase words joined by underscores", name)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	var latest uint64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+		if version, err := strconv.ParseUint(prefix, 10, 64); err == nil {
+			latest = max(latest, version)
+		}
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", latest+1, name))
+	for _, path := range []string{base + ".up.sql", base + ".down.sql"} {
+		// O_EXCL so an existing migration is never overwritten
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+// printVersion reports the version the schema is at
+func printVersion(m *migrate.Migrate) error {
+	status, err := migrations.GetStatus(m)
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		fmt.Printf("%d (dirty)\n", status.Version)
+		return nil
+	}
+	fmt.Println(status.Version)
+	return nil
+}
+
+// printStatus reports the schema version and lists what is left to apply
+func printStatus(m *migrate.Migrate) error {
+	status, err := migrations.GetStatus(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Schema version: %d of %d\n", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Printf("Version %d is dirty: a migration failed halfway. Fix the schema by hand, then record the version it is at with \"migrate force <version>\".\n", status.Version)
+	}
+	if len(status.Pending) == 0 {
+		fmt.Println("No pending migrations")
+		return nil
+	}
+	fmt.Printf("Pending migrations (%d):\n", len(status.Pending))
+	for _, migration := range status.Pending {
+		fmt.Printf("  %06d_%s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
@@ -15,6 +15,160 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/selftest": {
+            "post": {
+                "description": "Exercise the order lifecycle end to end against the sandbox product: place a guest order, settle it through a signed payment notification, move it to ready and completed, then place and cancel a second order. The synthetic orders are deleted afterwards. Returns 200 when every step passed and 500 with the same report otherwise, so deploy pipelines can gate on the status code. Requires the X-Selftest-Token header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Run the deployment self-test",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Self-test token",
+                        "name": "X-Selftest-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Self-test passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SelftestSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid self-test token",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Self-test is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Self-test failed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SelftestFailureResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/code/login": {
+            "post": {
+                "description": "Exchange a one-time login code for an access and refresh token, like a password login. Only the latest code works, it expires after a few minutes and stops working after too many wrong guesses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login with a code",
+                "parameters": [
+                    {
+                        "description": "Login code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.LoginWithCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or channel not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired login code",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/code/request": {
+            "post": {
+                "description": "Send a one-time login code to a member's email or WhatsApp number, for logging in without a password. The response is the same whether or not an account matches. Only a few codes are sent per account within a time window; further requests succeed without sending one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request a login code",
+                "parameters": [
+                    {
+                        "description": "Where to send the code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RequestLoginCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login code sent",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or channel not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests from this client",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
                 "description": "Authenticate user with email and password",
@@ -169,6 +323,67 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the authenticated user's name, phone, preferred language or notification channel. The language (id or en) is used for notifications and API messages; without one, Accept-Language is used. notify_via (email or whatsapp) picks where order updates go; WhatsApp needs a phone number and falls back to email without one. Returns a new access token carrying the updated language.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Update current user profile",
+                "parameters": [
+                    {
+                        "description": "Profile fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateProfileSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
             }
         },
         "/auth/refresh": {
@@ -281,9 +496,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
+        "/campaigns": {
             "get": {
-                "description": "Get a list of all categories with optional filtering",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of campaigns, latest send time first. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,22 +511,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Campaigns"
                 ],
-                "summary": "Get all categories",
+                "summary": "List campaigns",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Filter to show only active categories",
-                        "name": "active_only",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "sending",
+                            "sent",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Categories retrieved successfully",
+                        "description": "Campaigns retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.CategoriesSuccessResponse"
+                            "$ref": "#/definitions/docs.CampaignsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "500": {
@@ -323,7 +581,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product category (Admin only)",
+                "description": "Compose a message to every member of a segment: all members, lapsed members with no completed order in inactive_days, or new members without a completed order. It sends right away, or at send_at when given. Members are picked when it starts sending and get it on the channel they chose in notify_via, a batch at a time. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -331,29 +589,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Campaigns"
                 ],
-                "summary": "Create a new category",
+                "summary": "Create a campaign",
                 "parameters": [
                     {
-                        "description": "Category details",
+                        "description": "Message, segment and send time",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateCategoryRequest"
+                            "$ref": "#/definitions/docs.CreateCampaignRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Category created successfully",
+                        "description": "Campaign scheduled",
                         "schema": {
-                            "$ref": "#/definitions/docs.CategorySuccessResponse"
+                            "$ref": "#/definitions/docs.CampaignSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Validation error or send time in the past",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
@@ -370,12 +628,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Category slug already exists",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -385,9 +637,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/slug/{slug}": {
+        "/campaigns/{id}": {
             "get": {
-                "description": "Get a single category by its URL-friendly slug",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one campaign with its delivery stats: how many members it went to, how many messages are still pending, sent (per channel) or failed. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -395,77 +652,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Campaigns"
                 ],
-                "summary": "Get category by slug",
+                "summary": "Get a campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category slug",
-                        "name": "slug",
+                        "description": "Campaign UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category retrieved successfully",
+                        "description": "Campaign retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.CategorySuccessResponse"
+                            "$ref": "#/definitions/docs.CampaignSuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Category not found",
+                    "400": {
+                        "description": "Invalid campaign ID format",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/categories/{id}": {
-            "get": {
-                "description": "Get a single category by its UUID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Categories"
-                ],
-                "summary": "Get category by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Category UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Category retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/docs.CategorySuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid category ID format",
+                    "403": {
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Category not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -477,14 +702,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/campaigns/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing category by its UUID (Admin only)",
+                "description": "Stop a scheduled campaign, or one still sending. Members who already got the message keep it; the rest are not sent. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -492,38 +719,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Campaigns"
                 ],
-                "summary": "Update a category",
+                "summary": "Cancel a campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category UUID",
+                        "description": "Campaign UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Category update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.UpdateCategoryRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category updated successfully",
+                        "description": "Campaign cancelled",
                         "schema": {
-                            "$ref": "#/definitions/docs.CategorySuccessResponse"
+                            "$ref": "#/definitions/docs.CampaignSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error or invalid ID format",
+                        "description": "Invalid campaign ID format",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "401": {
@@ -539,13 +757,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Category not found",
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Category slug already exists",
+                        "description": "Campaign already sent or cancelled",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -557,14 +775,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/cart": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a category by its UUID (Admin only)",
+                "description": "Get the cart with a price preview at current prices. Members get their own cart; staff get the kiosk session cart named by the X-Kiosk-Session header. If an item can no longer be ordered, preview is omitted and preview_error explains why.",
                 "consumes": [
                     "application/json"
                 ],
@@ -572,27 +792,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Cart"
                 ],
-                "summary": "Delete a category",
+                "summary": "Get cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category deleted successfully",
+                        "description": "Cart retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                            "$ref": "#/definitions/docs.CartSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID format",
+                        "description": "Missing kiosk session",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -603,18 +822,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin only",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Category not found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -622,16 +829,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of all orders with optional filtering. Admin/Barista only.",
+                "description": "Remove every item from the cart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -639,54 +844,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Cart"
                 ],
-                "summary": "Get all orders",
+                "summary": "Clear cart",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page (max 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "pending",
-                            "preparing",
-                            "ready",
-                            "completed",
-                            "cancelled"
-                        ],
-                        "type": "string",
-                        "description": "Filter by order status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "guest",
-                            "member",
-                            "kiosk"
-                        ],
                         "type": "string",
-                        "description": "Filter by order source",
-                        "name": "source",
-                        "in": "query"
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Cart cleared",
                         "schema": {
-                            "$ref": "#/definitions/docs.OrdersSuccessResponse"
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
                         }
                     },
                     "401": {
@@ -695,12 +868,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin/Barista only",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -708,14 +875,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/cart/checkout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new order for an authenticated member user",
+                "description": "Convert the cart into a pending order at current prices and empty it. Members default to their own name; kiosk checkouts must send customer_name. Kiosk carts become kiosk orders. Send scheduled_for to check out as a pre-order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -723,29 +892,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Cart"
                 ],
-                "summary": "Create an order (authenticated)",
+                "summary": "Check out cart",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
+                    },
                     {
                         "description": "Order details",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateOrderRequest"
+                            "$ref": "#/definitions/docs.CheckoutCartRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Order created successfully",
+                        "description": "Order created from cart",
                         "schema": {
                             "$ref": "#/definitions/docs.OrderSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error, product not available, or invalid customization",
+                        "description": "Validation error, empty cart, an item can no longer be ordered, promo code cannot be applied, table is not taking orders, or scheduled time is not available",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
@@ -756,6 +930,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Checkout already in progress, insufficient stock, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -765,9 +945,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/guest": {
+        "/cart/items": {
             "post": {
-                "description": "Create a new order without authentication. Order can be tracked via the returned order UUID.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a product with its options to the cart, creating the cart on first use. Adding the same product with the same options and notes increases the quantity instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -775,25 +960,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Cart"
                 ],
-                "summary": "Create a guest order",
+                "summary": "Add item to cart",
                 "parameters": [
                     {
-                        "description": "Order details",
+                        "type": "string",
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Item to add",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateOrderRequest"
+                            "$ref": "#/definitions/docs.CreateOrderItemRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Order created successfully",
+                    "200": {
+                        "description": "Item added",
                         "schema": {
-                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                            "$ref": "#/definitions/docs.CartSuccessResponse"
                         }
                     },
                     "400": {
@@ -802,6 +993,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -811,14 +1014,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/me": {
-            "get": {
+        "/cart/items/{itemId}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of the authenticated user's orders",
+                "description": "Change the quantity or notes of a cart item.",
                 "consumes": [
                     "application/json"
                 ],
@@ -826,30 +1029,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Cart"
                 ],
-                "summary": "Get my orders",
+                "summary": "Update cart item",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page (max 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Cart item UUID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity and notes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateCartItemRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Item updated",
                         "schema": {
-                            "$ref": "#/definitions/docs.OrdersSuccessResponse"
+                            "$ref": "#/definitions/docs.CartSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
                     },
                     "401": {
@@ -858,6 +1075,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Cart item not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -865,16 +1088,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders/number/{number}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single order by its order number (e.g., MC-250107-001). Admin only.",
+                "description": "Remove an item from the cart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -882,39 +1103,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Cart"
                 ],
-                "summary": "Get order by order number",
+                "summary": "Remove cart item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order number (e.g., MC-250107-001)",
-                        "name": "number",
+                        "description": "Kiosk session ID (staff only)",
+                        "name": "X-Kiosk-Session",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cart item UUID",
+                        "name": "itemId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
+                        "description": "Item removed",
                         "schema": {
-                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                            "$ref": "#/definitions/docs.CartSuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid cart item ID format",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin only",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Cart item not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -928,9 +1155,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/track/{uuid}": {
+        "/categories": {
             "get": {
-                "description": "Track an order by its UUID. This is public and used for guest order tracking.",
+                "description": "Get a list of all categories with optional filtering. With tree=true, categories are nested under their parents.",
                 "consumes": [
                     "application/json"
                 ],
@@ -938,36 +1165,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Categories"
                 ],
-                "summary": "Track a guest order",
+                "summary": "Get all categories",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Filter to show only active categories",
+                        "name": "active_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return categories as a nested tree",
+                        "name": "tree",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Order UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/docs.OrderSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid order ID format",
+                        "description": "Categories retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.CategoriesSuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
+                    "304": {
+                        "description": "Categories not modified"
                     },
                     "500": {
                         "description": "Internal server error",
@@ -976,16 +1205,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single order by its UUID. Members can only view their own orders, Admin/Barista can view any order.",
+                "description": "Create a new product category (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -993,29 +1220,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Categories"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Create a new category",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Order retrieved successfully",
+                    "201": {
+                        "description": "Category created successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                            "$ref": "#/definitions/docs.CategorySuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format",
+                        "description": "Validation error, parent not found, or nesting too deep",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
                     },
                     "401": {
@@ -1025,13 +1254,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied",
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
+                    "409": {
+                        "description": "Category slug already exists",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1045,9 +1274,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/payment": {
-            "post": {
-                "description": "Create a Midtrans payment token for an order. Returns a redirect URL and token for Snap payment.",
+        "/categories/reorder": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the display order of categories in one call. IDs are listed in their new order (Admin only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1055,33 +1289,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Categories"
                 ],
-                "summary": "Create payment token",
+                "summary": "Reorder categories",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category UUIDs in display order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReorderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment token created successfully",
+                        "description": "Categories reordered successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.PaymentSuccessResponse"
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID or payment already exists",
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1095,14 +1343,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update the status of an order. Admin/Barista only. Status transitions must follow: pending -\u003e preparing -\u003e ready -\u003e completed, or pending -\u003e cancelled.",
+        "/categories/slug/{slug}": {
+            "get": {
+                "description": "Get a single category by its URL-friendly slug",
                 "consumes": [
                     "application/json"
                 ],
@@ -1110,54 +1353,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Categories"
                 ],
-                "summary": "Update order status",
+                "summary": "Get category by slug",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order UUID",
-                        "name": "id",
+                        "description": "Category slug",
+                        "name": "slug",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New order status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.UpdateOrderStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order status updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/docs.OrderSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error, invalid ID format, or invalid status transition",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - Admin/Barista only",
+                        "description": "Category retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.CategorySuccessResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1171,9 +1387,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/categories/{id}": {
             "get": {
-                "description": "Get a list of all products with optional filtering",
+                "description": "Get a single category by its UUID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,38 +1397,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Get all products",
+                "summary": "Get category by ID",
                 "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "Include soft-deleted products",
-                        "name": "include_deleted",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter to show only available products",
-                        "name": "available_only",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter by category UUID",
-                        "name": "category_id",
-                        "in": "query"
+                        "description": "Category UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products retrieved successfully",
+                        "description": "Category retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProductsSuccessResponse"
+                            "$ref": "#/definitions/docs.CategorySuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid category_id format or category not found",
+                        "description": "Invalid category ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1225,13 +1436,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product with optional customizations (Admin only)",
+                "description": "Update an existing category by its UUID (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1239,29 +1450,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Create a new product",
+                "summary": "Update a category",
                 "parameters": [
                     {
-                        "description": "Product details",
+                        "type": "string",
+                        "description": "Category UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category update details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateProductRequest"
+                            "$ref": "#/definitions/docs.UpdateCategoryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product created successfully",
+                    "200": {
+                        "description": "Category updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                            "$ref": "#/definitions/docs.CategorySuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error or category not found",
+                        "description": "Validation error, invalid ID format, or invalid parent",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
@@ -1278,8 +1496,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
                     "409": {
-                        "description": "Product slug already exists",
+                        "description": "Category slug already exists",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1291,16 +1515,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/customizations/{customizationId}": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing product customization by its UUID (Admin only)",
+                "description": "Soft delete a category by its UUID (Admin only). Pass reassign_to to move its products to another category; otherwise the products stay attached and are hidden until the category is restored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1308,38 +1530,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Update a product customization",
+                "summary": "Soft delete a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Customization UUID",
-                        "name": "customizationId",
+                        "description": "Category UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Customization update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.UpdateCustomizationRequest"
-                        }
+                        "type": "string",
+                        "description": "Category UUID to move the products to",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Customization updated successfully",
+                        "description": "Category deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.CustomizationSuccessResponse"
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error or invalid ID format",
+                        "description": "Invalid category ID format or invalid reassignment target",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "401": {
@@ -1354,6 +1573,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1361,14 +1586,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/categories/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product customization by its UUID (Admin only)",
+                "description": "Restore a previously soft-deleted category by its UUID (Admin only). Products still attached to it become visible again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1376,27 +1603,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Delete a product customization",
+                "summary": "Restore a soft-deleted category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Customization UUID",
-                        "name": "customizationId",
+                        "description": "Category UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Customization deleted successfully",
+                        "description": "Category restored successfully",
                         "schema": {
                             "$ref": "#/definitions/docs.MessageSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid customization ID format",
+                        "description": "Invalid category ID format or category is not deleted",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1413,6 +1640,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1422,9 +1655,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/slug/{slug}": {
+        "/chaos": {
             "get": {
-                "description": "Get a single product by its URL-friendly slug",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the failure modes currently injected on this instance. Only available when CHAOS_ENABLED is set outside production. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1432,43 +1670,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Get product by slug",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Chaos"
                 ],
+                "summary": "Get injected faults (staging only)",
                 "responses": {
                     "200": {
-                        "description": "Product retrieved successfully",
+                        "description": "Active faults",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                            "$ref": "#/definitions/docs.ChaosFaultsSuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Get a single product by its UUID",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the injected faults: added latency on paths with a prefix, failing Midtrans payment token creation, and rejecting Midtrans webhook signatures. Faults clear themselves after duration_seconds. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1476,52 +1708,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Chaos"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Inject faults (staging only)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Faults to inject",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateChaosFaultsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product retrieved successfully",
+                        "description": "Faults injected",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                            "$ref": "#/definitions/docs.ChaosFaultsSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Validation error",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing product by its UUID (Admin only)",
+                "description": "Stop injecting every fault on this instance. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1529,38 +1763,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Update a product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.UpdateProductRequest"
-                        }
-                    }
+                    "Chaos"
                 ],
+                "summary": "Clear injected faults (staging only)",
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
+                        "description": "Faults cleared",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProductSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error, invalid ID format, or category not found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
                         }
                     },
                     "401": {
@@ -1574,17 +1784,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Product slug already exists",
+                    }
+                }
+            }
+        },
+        "/currency": {
+            "get": {
+                "description": "Get the currency prices are set and charged in: its ISO code, the symbol shown in front of amounts and how many decimal places amounts are rounded to. Public at /currency so customer apps can show prices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get currency settings",
+                "responses": {
+                    "200": {
+                        "description": "Currency settings retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.CurrencySettingsSuccessResponse"
                         }
                     },
                     "500": {
@@ -1594,42 +1815,98 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/customers/segments/{name}/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a product by its UUID (Admin only). Product will be hidden but preserved for historical orders.",
+                "description": "List the active members of a segment for marketing, biggest spenders first: all members, lapsed members without a completed order in inactive_days, or new members without a completed order. Narrow it down by last order date, total spend or favorite category (including its subcategories). Order figures come from member stats rebuilt every hour, so they may trail the latest orders; stats_refreshed_at tells when they were built. Use format=csv to download a spreadsheet instead of JSON. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Products"
+                    "Customers"
                 ],
-                "summary": "Soft delete a product",
+                "summary": "Export a customer segment",
                 "parameters": [
                     {
+                        "enum": [
+                            "all",
+                            "lapsed",
+                            "new"
+                        ],
                         "type": "string",
-                        "description": "Product UUID",
-                        "name": "id",
+                        "description": "Segment name",
+                        "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Days without a completed order for lapsed members",
+                        "name": "inactive_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last completed order on or after this date (YYYY-MM-DD)",
+                        "name": "last_order_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last completed order on or before this date (YYYY-MM-DD)",
+                        "name": "last_order_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum total spend",
+                        "name": "min_spend",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum total spend",
+                        "name": "max_spend",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Favorite category UUID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "Segment exported successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                            "$ref": "#/definitions/docs.CustomerSegmentExportSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Invalid criteria or format",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1647,7 +1924,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Segment not found",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1661,14 +1938,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/customizations": {
+        "/dev/payments/{id}/simulate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new customization option to a product (Admin only). Product must be marked as customizable.",
+                "description": "Sign a Midtrans notification with the given outcome for a pending payment and process it exactly as the webhook would: settlement sends the order to the kitchen, cancel, expire and deny cancel it. Lets end-to-end tests pay without the Midtrans sandbox. Only available outside production with Midtrans sandbox keys. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1676,36 +1953,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Dev"
                 ],
-                "summary": "Add customization to a product",
+                "summary": "Simulate a Midtrans notification (non-production only)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product UUID",
+                        "description": "Payment UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Customization details",
+                        "description": "Outcome to simulate",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateCustomizationRequest"
+                            "$ref": "#/definitions/docs.SimulatePaymentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Customization added successfully",
+                    "200": {
+                        "description": "Notification processed",
                         "schema": {
-                            "$ref": "#/definitions/docs.CustomizationSuccessResponse"
+                            "$ref": "#/definitions/docs.PaymentSimulationSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error, invalid ID format, or product is not customizable",
+                        "description": "Invalid payment ID, validation error, or a payment that is not a Midtrans payment",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
@@ -1717,13 +1994,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Admin only",
+                        "description": "Forbidden - Admin only, or Midtrans is not in sandbox mode",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment is no longer pending",
                         "schema": {
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
@@ -1737,14 +2020,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/restore": {
-            "post": {
+        "/email-templates": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Restore a previously soft-deleted product by its UUID (Admin only)",
+                "description": "List the active version of every notification email template in each language, with the variables each one accepts. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1752,29 +2035,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Restore a soft-deleted product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Email Templates"
                 ],
+                "summary": "Get email templates",
                 "responses": {
                     "200": {
-                        "description": "Product restored successfully",
-                        "schema": {
-                            "$ref": "#/definitions/docs.MessageSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Email templates retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                            "$ref": "#/definitions/docs.EmailTemplateListSuccessResponse"
                         }
                     },
                     "401": {
@@ -1789,12 +2057,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1804,9 +2066,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/webhooks/midtrans": {
-            "post": {
-                "description": "Process payment notification from Midtrans. This endpoint is called by Midtrans when payment status changes.",
+        "/email-templates/{key}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save new content for a template as a new version and make it active. Placeholders use the {{variable}} syntax and must be one of the template's variables. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1814,129 +2081,16297 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Webhooks"
+                    "Email Templates"
                 ],
-                "summary": "Handle Midtrans webhook",
+                "summary": "Update email template",
                 "parameters": [
                     {
-                        "description": "Midtrans notification payload",
-                        "name": "notification",
+                        "enum": [
+                            "order_confirmation",
+                            "order_ready",
+                            "password_reset",
+                            "login_code",
+                            "order_receipt",
+                            "points_expiring"
+                        ],
+                        "type": "string",
+                        "description": "Template key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Template language, defaults to the store locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Template content",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.MidtransWebhookRequest"
+                            "$ref": "#/definitions/docs.UpdateEmailTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Webhook processed successfully",
+                        "description": "Email template updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/docs.WebhookSuccessResponse"
+                            "$ref": "#/definitions/docs.EmailTemplateSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or invalid amount",
+                        "description": "Validation error, unknown variable or invalid locale",
                         "schema": {
-                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid signature",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Payment not found",
+                        "description": "Email template not found",
                         "schema": {
-                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "docs.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIs..."
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIs..."
-                },
-                "user": {
-                    "$ref": "#/definitions/docs.UserResponse"
-                }
-            }
         },
-        "docs.AuthSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/docs.AuthResponse"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+        "/email-templates/{key}/test-send": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the active version of a template to the given address. Variables that are not supplied are filled with sample values. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email Templates"
+                ],
+                "summary": "Send test email",
+                "parameters": [
+                    {
+                        "enum": [
+                            "order_confirmation",
+                            "order_ready",
+                            "password_reset",
+                            "login_code",
+                            "order_receipt",
+                            "points_expiring"
+                        ],
+                        "type": "string",
+                        "description": "Template key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Template language, defaults to the store locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Recipient and variables",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.TestSendEmailTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Test email sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid locale",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Email template not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Mail server rejected the email",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "docs.CategoriesListResponse": {
-            "type": "object",
-            "properties": {
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.CategoryResponse"
+        "/email-templates/{key}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every saved version of a template, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email Templates"
+                ],
+                "summary": "Get email template versions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "order_confirmation",
+                            "order_ready",
+                            "password_reset",
+                            "login_code",
+                            "order_receipt",
+                            "points_expiring"
+                        ],
+                        "type": "string",
+                        "description": "Template key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Template language, defaults to the store locale",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email template versions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.EmailTemplateListSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid locale",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Email template not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
                     }
-                },
-                "count": {
-                    "type": "integer",
-                    "example": 5
                 }
             }
         },
-        "docs.CategoriesSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/docs.CategoriesListResponse"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+        "/email-templates/{key}/versions/{version}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make an earlier (or later) saved version the one used for sending. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Email Templates"
+                ],
+                "summary": "Activate email template version",
+                "parameters": [
+                    {
+                        "enum": [
+                            "order_confirmation",
+                            "order_ready",
+                            "password_reset",
+                            "login_code",
+                            "order_receipt",
+                            "points_expiring"
+                        ],
+                        "type": "string",
+                        "description": "Template key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Template language, defaults to the store locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email template version activated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.EmailTemplateSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid version or locale",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Email template version not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of gift cards, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "List gift cards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "active",
+                            "voided"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift cards retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an active gift card without payment, such as for a giveaway or to replace a lost card. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Issue a gift card",
+                "parameters": [
+                    {
+                        "description": "Amount and optional recipient",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.IssueGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Gift card issued",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/code/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look a gift card up by the code printed on it, typed in any case with or without dashes, to check its balance and history. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Check a gift card balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift card retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gift card not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one gift card with its balance and the orders it paid for. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Get a gift card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift card retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid gift card ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gift card not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/{id}/void": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop an active gift card from being redeemed, such as when it is reported stolen. Orders it already paid for are not affected. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Void a gift card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for voiding",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.VoidGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift card voided",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid gift card ID or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gift card not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Gift card is not active",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrity/anomalies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List anomalies found by the daily order integrity check: items whose product snapshot no longer matches any product, and orders whose subtotal or total does not add up. Open anomalies only unless include_resolved is set. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integrity"
+                ],
+                "summary": "List order anomalies",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include resolved anomalies",
+                        "name": "include_resolved",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Anomalies retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AnomaliesSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrity/anomalies/{id}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark an anomaly as reviewed. Resolved anomalies are not reported again. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integrity"
+                ],
+                "summary": "Resolve an order anomaly",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Anomaly UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Anomaly resolved",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid anomaly ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Anomaly not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Anomaly already resolved",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrity/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run the order integrity check now instead of waiting for the daily run. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integrity"
+                ],
+                "summary": "Run the order integrity check",
+                "responses": {
+                    "200": {
+                        "description": "Integrity check completed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.IntegrityCheckSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kiosks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all kiosks by name with whether they are online, i.e. sent a heartbeat recently. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "List kiosks",
+                "responses": {
+                    "200": {
+                        "description": "Kiosks retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskListSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a self-service kiosk and issue its API key. The key is only returned in this response; configure it on the kiosk as the X-Kiosk-Key header. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Register a kiosk",
+                "parameters": [
+                    {
+                        "description": "Kiosk details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Kiosk created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Kiosk name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kiosks/heartbeat": {
+            "post": {
+                "security": [
+                    {
+                        "KioskKey": []
+                    }
+                ],
+                "description": "Report that the kiosk is running. Kiosks should call this every minute; admins see a kiosk as offline once heartbeats stop for KIOSK_OFFLINE_AFTER.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Send a kiosk heartbeat",
+                "parameters": [
+                    {
+                        "description": "Kiosk app details",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskHeartbeatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Heartbeat recorded",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid kiosk key",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kiosks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single kiosk by its UUID. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Get a kiosk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kiosk UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kiosk retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kiosk ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Kiosk not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a kiosk or switch it off. A switched-off kiosk is refused until it is switched back on; its API key stays the same. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Update a kiosk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kiosk UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kiosk details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kiosk updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid kiosk ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Kiosk not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Kiosk name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a kiosk so its API key stops working. Its orders are kept. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Delete a kiosk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kiosk UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kiosk deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kiosk ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Kiosk not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kiosks/{id}/key": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a new API key, e.g. when the kiosk is replaced or its key leaked. The old key stops working at once. The new key is only returned in this response. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosks"
+                ],
+                "summary": "Regenerate a kiosk's API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kiosk UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Key regenerated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KioskSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kiosk ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Kiosk not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/gift-cards": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a payment page with the configured gateway to buy a digital gift card. The code is returned straight away but can only be redeemed once the gateway settles the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Buy a gift card",
+                "parameters": [
+                    {
+                        "description": "Amount and optional recipient",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardPurchaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Gift card purchase started",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardPurchaseSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/points": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated member's points balance, what it is worth at the current point value, when the points expire with those expiring soon flagged, and a paginated statement of points earned, spent, given back and expired, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loyalty"
+                ],
+                "summary": "Get my loyalty points",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Points retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PointsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/rewards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the rewards the member can swap points for, cheapest first, with their points balance. Redeemable tells whether a reward is in stock and affordable.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Browse the rewards catalog",
+                "responses": {
+                    "200": {
+                        "description": "Rewards retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardCatalogSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/rewards/{id}/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Spend loyalty points on a reward from the catalog. The member gets a single-use voucher on the reward's terms, which they use at checkout by passing its code as voucher_code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Redeem a reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reward UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reward redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardRedemptionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reward ID format or not enough points",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reward not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reward switched off or out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/stamps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated member's stamp card: the stamps collected toward the next free drink, how many more are needed, and the free-drink vouchers full cards were swapped for, newest first. Each qualifying drink on a completed order earns a stamp. Redeem a reward at checkout by passing its code as voucher_code; it covers the dearest qualifying drink on the order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loyalty"
+                ],
+                "summary": "Get my stamp card",
+                "responses": {
+                    "200": {
+                        "description": "Stamp card retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.StampCardSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/vouchers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the vouchers granted to the member, newest first. Redeemable ones can be used at checkout by passing their code as voucher_code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "List my vouchers",
+                "responses": {
+                    "200": {
+                        "description": "Vouchers retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MyVouchersSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wallet": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated member's wallet balance with a paginated statement of top-ups, payments, refunds and adjustments, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get my wallet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/wallet/top-ups": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a payment page with the configured gateway to add money to the authenticated member's wallet. The balance is credited once the gateway settles the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Top up my wallet",
+                "parameters": [
+                    {
+                        "description": "Amount to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletTopUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Top-up started",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletTopUpSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Member only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG, WebP or GIF image and get the URL to use as a product or category image. Uploads that would exceed the storage quota are rejected. Admin only.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload an image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Image uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MediaFileSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "File missing or not a supported image",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large or storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/cleanup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete images that no product or category uses and that were uploaded longer ago than the cleanup period, instead of waiting for the daily run. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Clean up unused images",
+                "responses": {
+                    "200": {
+                        "description": "Media cleanup completed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MediaCleanupSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get storage used by uploaded images against the quota, and how much is held by images no product or category uses. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get media storage usage",
+                "responses": {
+                    "200": {
+                        "description": "Storage usage retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.StorageUsageSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/menu": {
+            "get": {
+                "description": "Get active categories with their available products and customizations in one call. The version is the catalog version and matches the ETag; send it back in If-None-Match to get a 304 when nothing changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu"
+                ],
+                "summary": "Get full menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MenuSuccessResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Menu not modified"
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of all orders with optional filtering. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get all orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "pending",
+                            "preparing",
+                            "ready",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "guest",
+                            "member",
+                            "kiosk",
+                            "partner",
+                            "phone",
+                            "catering"
+                        ],
+                        "type": "string",
+                        "description": "Filter by order source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dine_in",
+                            "takeaway",
+                            "delivery"
+                        ],
+                        "type": "string",
+                        "description": "Filter by order type",
+                        "name": "order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orders placed on or after this day in the store timezone (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orders placed on or before this day in the store timezone (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrdersSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order source, type or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new order for an authenticated member user. An optional promo_code is checked against its expiry, usage limits, minimum spend and product scope, and the discount is recorded on the order. Send scheduled_for to pre-order for a later time: the order stays scheduled, with its stock held, until it is released to the pending queue shortly before that time, and can be paid from then on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create an order (authenticated)",
+                "parameters": [
+                    {
+                        "description": "Order details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/bulk-status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Complete or cancel several orders in one call. Admin only. Each order is validated against the usual status transitions and reported individually, so ready orders can be completed and stale pending orders cancelled without failing the whole batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Bulk update order status",
+                "parameters": [
+                    {
+                        "description": "Order IDs and target status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.BulkUpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-order results",
+                        "schema": {
+                            "$ref": "#/definitions/docs.BulkUpdateOrderStatusSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/close-day": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Finalize a business day in the store timezone, today by default. The day's totals are saved as a daily summary and its orders can no longer change status. Orders still open are flagged and listed for follow-up. A day can only be closed once. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Close the business day",
+                "parameters": [
+                    {
+                        "description": "Business day to close",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CloseDayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Business day closed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.DailySummarySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid business date or day not started yet",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Business day already closed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/guest": {
+            "post": {
+                "description": "Create a new order without authentication. Order can be tracked via the returned order UUID. An optional email address or WhatsApp number (in international format) receives the tracking link right away and the receipt once the order is completed and paid. Send scheduled_for to pre-order for a later time; the order can be paid once it is released to the pending queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a guest order",
+                "parameters": [
+                    {
+                        "description": "Order details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateGuestOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/kiosk": {
+            "post": {
+                "security": [
+                    {
+                        "KioskKey": []
+                    }
+                ],
+                "description": "Place an order from a self-service kiosk, authenticated with the kiosk's API key. customer_name is optional; without it the order gets a queue number, restarting every day, and is called by it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a kiosk order",
+                "parameters": [
+                    {
+                        "description": "Order details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateKioskOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, product not available, invalid customization, or promo code cannot be applied",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid kiosk key",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/lookup": {
+            "post": {
+                "description": "Find a guest order again by its order number plus the phone number or customer name given at checkout, for guests who lost the tracking link. Returns the same payload as tracking by UUID. Attempts are rate limited per IP, and a wrong phone or name is reported as not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Look up a guest order",
+                "parameters": [
+                    {
+                        "description": "Order number and phone or name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.LookupOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the authenticated user's orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get my orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrdersSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/number/{number}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single order by its order number (e.g., MC-250107-001). Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by order number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order number (e.g., MC-250107-001)",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get active orders grouped by status, rush orders first and then oldest first, with item customizations flattened and the time elapsed since each order was placed. Built for the preparation screen. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get the kitchen queue",
+                "responses": {
+                    "200": {
+                        "description": "Kitchen queue retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.KitchenQueueSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/staff": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enter an order taken outside the app (kiosk, partner, phone, or catering). Send scheduled_for to enter a pre-order. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create an order on behalf of a customer",
+                "parameters": [
+                    {
+                        "description": "Order details and source",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateStaffOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/status/{token}": {
+            "get": {
+                "description": "Public status page for an order, reached with the share_token returned when the order is placed instead of the order UUID. The token expires a while after the order is due and is revoked once the order completes. Prices, contact details and the order ID are left out so the link can be shared.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order status by share token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderStatusSuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Status link is invalid or has expired",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events feed for the kitchen display. Emits an order.created event for every new order and an order.status_changed event for every status transition, including those made by payment notifications.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Stream order events (Admin/Barista)",
+                "responses": {
+                    "200": {
+                        "description": "Event stream; each data line is an order event",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_services.OrderEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/track/{uuid}": {
+            "get": {
+                "description": "Track an order by its UUID. This is public and used for guest order tracking. Paid orders waiting for pickup carry the pickup_code to show at the counter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Track a guest order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/verify-pickup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scan the pickup code from the customer's tracking page or receipt to hand over the order. The code is signed per order, so a ready order is only completed when the code matches it. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Verify pickup code",
+                "parameters": [
+                    {
+                        "description": "Scanned pickup code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.VerifyPickupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order picked up",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid pickup code",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not ready, was already picked up, or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single order by its UUID. Members can only view their own orders, Admin/Barista can view any order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take ownership of a pending or preparing order so no other barista prepares the same ticket. Once claimed, only the claiming barista (or an admin) can change its status. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Claim an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order claimed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format or order is no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is claimed by another staff member",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/gift-card": {
+            "post": {
+                "description": "Take money off a pending order's amount due from an active gift card. Leave the amount out to use as much as the card and the order allow. When the card covers the rest of the order it is paid and goes to the kitchen; otherwise pay the remainder through checkout as usual.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Redeem a gift card against an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gift card code and optional amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RedeemGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Gift card redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GiftCardRedemptionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment, the card is not usable, or the amount is too high",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/items": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the items of a pending order and recalculate subtotal, tax and total at current prices. A promo code redeemed at checkout stays applied, so the new items must still qualify for it. Send the full list of items the order should have; omitted items are removed. Rejected once a payment has been started or the order has moved past pending. Members can edit their own orders; admins and baristas can edit any order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Edit the items of a pending order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New order items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateOrderItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order items updated",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, product not available, invalid customization, or promo code cannot be applied",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Order belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can no longer be edited or insufficient stock for a capped item",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payment": {
+            "post": {
+                "description": "Start a payment for an order with the configured gateway. Returns the provider, a token (the Snap token for Midtrans, the Checkout Session ID for Stripe, the invoice ID for Xendit) and the URL of the hosted payment page. The amount charged is the order total plus the tip; send tip_amount to add or change the tip chosen at checkout. Calling it again while the payment page is still open returns the same page with reused set; after a tip change the old page is closed and a new one started.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Create payment token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional tip",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreatePaymentTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment token created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID, validation error, payment already exists, or a currency the gateway cannot charge",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reserved stock expired and the item sold out",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payment-link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived link that opens the hosted payment page of a pending order, to send over WhatsApp to a customer who ordered by phone. The link works until the order is paid and expires after PAYMENT_LINK_TTL or at the order's payment deadline, whichever is sooner. The URL is only returned here. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Create a payment link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Payment link created",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentLinkSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments/cash": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Settle a pending order with cash taken at the counter. The amount tendered must cover the order total plus tip; the change to hand back is returned. The order moves to preparing, as after a Midtrans settlement. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Record a cash payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cash tendered",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CashPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cash payment recorded",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CashPaymentSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID, validation error, or not enough cash tendered",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment or an item sold out",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments/points": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Spend loyalty points on the authenticated member's pending order, at the point value in the loyalty settings. The points are taken and the amount due lowered together. When the points cover the rest of the order it is paid and goes to the kitchen; otherwise pay the remainder through checkout as usual. Points come back if the order is cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loyalty"
+                ],
+                "summary": "Pay an order with my points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Points to spend",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RedeemPointsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Points redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PointsRedemptionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the member's order",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment, too few points, points worth more than the amount due, or points are not accepted",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments/qris": {
+            "post": {
+                "description": "Charge the order through the Midtrans Core API as a QRIS code for a kiosk to show on its own screen, instead of redirecting to the hosted payment page. Render qr_string as a QR code or show qr_image_url, then poll /payments/{id}/status until the payment settles or expires. Calling it again while the code is still valid returns the same code with reused set; the amount, tip and errors work as for /orders/{id}/payment. Any hosted payment page still open for the order is closed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Charge an order by QRIS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional tip",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreatePaymentTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QRIS code created",
+                        "schema": {
+                            "$ref": "#/definitions/docs.QRISChargeSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID, validation error, payment already exists, QRIS not available with the configured gateway, or a currency the gateway cannot charge",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reserved stock expired and the item sold out",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments/wallet": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay the authenticated member's pending order in full from their wallet balance. The order goes to the kitchen straight away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Pay an order from my wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order paid from wallet",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletPaymentSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not the member's order",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment, an item sold out, or the balance is too low",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/priority": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rush an order to the front of its kitchen queue column, or set it back to normal. Only pending and preparing orders can be changed. Orders in preparation for longer than ORDER_RUSH_AFTER are rushed automatically. Admin/Barista only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order priority",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New priority",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateOrderPriorityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order priority updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid ID format, or order is already ready",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order's business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/reorder": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place a new pending order with the items of one of the member's past orders, at current prices. Items whose product was removed or is unavailable, whose chosen options are no longer offered, or that are out of stock are skipped and listed in skipped_items. previous_total is the original order's total.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Reorder a past order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created from past order",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReorderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format or none of the items can be reordered",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Order belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotFullErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of an order. Admin/Barista only. Status transitions must follow: pending -\u003e preparing -\u003e ready -\u003e completed, or pending -\u003e cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New order status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order status updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OrderSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid ID format, or invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin/Barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is claimed by another staff member, or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recompute an order's item subtotals, subtotal, tax and total from its item snapshots, the tax rate and the recorded source fee, and list every stored amount that differs. Read only; useful after a pricing bug is fixed. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Verify order totals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification result",
+                        "schema": {
+                            "$ref": "#/definitions/docs.VerifyTotalsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pay/{token}": {
+            "get": {
+                "description": "Redirect to the hosted payment page of the order behind a payment link. Opening the link does not use it up: an open page for the same amount is reused rather than starting a second one, so link previews and a customer who closed the page get the same page back. The link stops working once the order no longer awaits payment.",
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Open a payment link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the payment link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the payment page"
+                    },
+                    "404": {
+                        "description": "Payment link is invalid or has expired",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment or an item sold out",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Payment deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of payments across all orders, newest first, for daily checks. A pending status also matches payments the gateway has not reported on yet. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "List payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "settlement",
+                            "expire",
+                            "cancel",
+                            "deny",
+                            "refund",
+                            "partial_refund"
+                        ],
+                        "type": "string",
+                        "description": "Filter by transaction status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by payment type as the gateway reports it, such as qris, gopay or cash",
+                        "name": "payment_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by order number",
+                        "name": "order_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payments started on or after this day in the store timezone (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payments started on or before this day in the store timezone (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payments retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a payment page the customer has not paid yet, at the gateway and here, so it cannot settle after the order was given up on. The order is cancelled with it unless it is being paid another way. The gateway is checked first; a payment that already went through is recorded as paid and reported as a conflict. Cash payments cannot be cancelled. Admin and barista only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Cancel a pending gateway payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentCancellationSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID, or a payment that cannot be cancelled with the gateway",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin or barista only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "The gateway could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give part or all of a settled payment back, through the gateway it was paid with, as cash handed back at the counter, or to the wallet it was paid from. Send amount to refund part of the payment, such as one unavailable item; leave it out to refund the remaining balance. The refund is recorded with the reason and the admin who issued it. Once the payment is refunded in full, an order still being prepared or waiting for pickup is cancelled and its stock returned; completed orders stay completed. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Refund a payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund amount and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RefundPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Payment refunded",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RefundSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID, validation error, amount over the refundable balance, or a refund the gateway does not support",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment is not settled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "The gateway rejected the refund",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/status": {
+            "get": {
+                "description": "Get the status of a payment and of the order it pays for, for a kiosk to poll while the customer pays. The status is the last one the gateway reported through its webhook; a payment not yet reported on is pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get payment status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment status retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentStatusSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look the payment up at the gateway and apply its current status the same way the webhook would: a settled payment sends the order to the kitchen, a failed one cancels it. Use it when a webhook was lost and the customer has paid but the order is still pending. Nothing changes when the gateway agrees with what is recorded. Cash payments cannot be verified. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Verify a payment with its gateway",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment verified",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentVerificationSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID, a payment that cannot be checked with the gateway, or an amount that does not match",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found, or unknown to the gateway",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "The gateway could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/points/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a member's points balance with a paginated statement, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loyalty"
+                ],
+                "summary": "Get a member's loyalty points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Points retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PointsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/sources": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the price adjustment and flat fee for each order source that can carry its own pricing. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Get source pricing",
+                "responses": {
+                    "200": {
+                        "description": "Source pricing retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SourcePricingListSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing/sources/{source}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the price adjustment (percent applied to every unit price) and flat fee for an order source. Guest and member orders always pay list price. Applies to orders created afterwards. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing"
+                ],
+                "summary": "Update source pricing",
+                "parameters": [
+                    {
+                        "enum": [
+                            "kiosk",
+                            "partner",
+                            "phone",
+                            "catering"
+                        ],
+                        "type": "string",
+                        "description": "Order source",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateSourcePricingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Source pricing updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SourcePricingSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid order source",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print-jobs/next": {
+            "get": {
+                "description": "Hand the oldest waiting ESC/POS ticket of a station to the calling print agent. The payload is base64 encoded and is sent to the printer as is. The agent must acknowledge the job within two minutes, otherwise it is handed out again; after five attempts it is marked failed. Returns 204 when there is nothing to print. Requires the X-Print-Agent-Token header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Print Jobs"
+                ],
+                "summary": "Take the next print job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Print agent token",
+                        "name": "X-Print-Agent-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Printer station (defaults to the main station)",
+                        "name": "station",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Print job taken",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PrintJobSuccessResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Nothing to print"
+                    },
+                    "401": {
+                        "description": "Invalid print agent token",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Print agents are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print-jobs/{id}/ack": {
+            "post": {
+                "description": "Report whether a ticket was printed. Failed tickets are queued again until they run out of attempts. Requires the X-Print-Agent-Token header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Print Jobs"
+                ],
+                "summary": "Acknowledge a print job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Print agent token",
+                        "name": "X-Print-Agent-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Print job ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Print outcome",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.AckPrintJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Print job acknowledged",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid print agent token",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Print job not found or print agents are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Print job is not being printed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Get a list of all products with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter to show only available products",
+                        "name": "available_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category UUID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProductsSuccessResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Products not modified"
+                    },
+                    "400": {
+                        "description": "Invalid category_id format or category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product with optional customizations (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Product slug already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/customizations/{customizationId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing product customization by its UUID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product customization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customization UUID",
+                        "name": "customizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customization update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateCustomizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customization updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CustomizationSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product customization by its UUID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product customization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customization UUID",
+                        "name": "customizationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customization deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid customization ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/reorder": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the display order of products in one call. IDs are listed in their new order (Admin only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Reorder products",
+                "parameters": [
+                    {
+                        "description": "Product UUIDs in display order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products reordered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/slug/{slug}": {
+            "get": {
+                "description": "Get a single product by its URL-friendly slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Get a single product by its UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing product by its UUID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProductSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid ID format, or category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Product slug already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a product by its UUID (Admin only). Product will be hidden but preserved for historical orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Soft delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/customizations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new customization option to a product (Admin only). Product must be marked as customizable.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Add customization to a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customization details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateCustomizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Customization added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CustomizationSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid ID format, or product is not customizable",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a previously soft-deleted product by its UUID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Restore a soft-deleted product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all promotions, newest first, with how many non-cancelled orders redeemed each. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "List promotions",
+                "responses": {
+                    "200": {
+                        "description": "Promotions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionListSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a promo code customers can redeem at checkout. Codes are stored in upper case. Without product_ids or category_ids the discount applies to the whole order; otherwise only to items in scope, including subcategories. An automatic promotion applies to every qualifying order without its code being entered. Stackable promotions combine with each other; any other promotion is applied on its own, and without an entered code the order gets whichever discount is larger. Days, start_time and end_time (HH:MM, store time) limit a promotion to certain hours such as a happy hour; an end before the start runs past midnight. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Create a promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid discount, period or hours, or product/category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promotion code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single promotion by its UUID. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the terms and scope of a promotion. Orders that already redeemed it keep their discount. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid discount, period or hours, or product/category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promotion code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a promotion so its code can no longer be redeemed. Orders that redeemed it keep the code and discount. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sum up the non-cancelled orders that applied a promotion: how often it was used, by how many members, the discount it gave and the revenue of those orders. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get promotion usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion usage retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromotionStatsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/qr-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render a QR code in the store colors for printing on signage. The menu target links to the public menu, table to the ordering link of a table from /tables, and payment to the payment page of a pending order. The encoded link is returned in the X-QR-Code-URL header. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "QR Codes"
+                ],
+                "summary": "Generate a QR code",
+                "parameters": [
+                    {
+                        "description": "QR code target and format",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.GenerateQRCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order or table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/abandoned": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Orders created but never paid in an inclusive date range, against all orders created, per order source and per hour of the day, to quantify drop-off in the payment flow (for example on kiosks). An order is abandoned when it was cancelled, or is still pending past its payment deadline, without a settled payment. Payment attempted counts abandoned orders that started a payment. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Abandoned orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Abandoned orders retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AbandonedOrdersSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/customers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank active members with a completed order by total spend, number of orders or most recent order, with their average order value, first and last order and average days between orders. Order figures come from member stats rebuilt every hour; stats_refreshed_at tells when they were built. Use format=csv to download every ranked member as a spreadsheet, ignoring page and limit. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Customer lifetime value",
+                "parameters": [
+                    {
+                        "enum": [
+                            "spend",
+                            "orders",
+                            "recent"
+                        ],
+                        "type": "string",
+                        "default": "spend",
+                        "description": "Ranking",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customers ranked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CustomerValueReportSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort or format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/fulfillment-times": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Median (p50) and 90th percentile minutes orders spent waiting for payment (queued to preparing), in preparation (preparing to ready), waiting for pickup (ready to completed) and overall (queued to completed), per day and over an inclusive date range, to spot slow shifts. Orders count on the day they were queued and towards a stage only once they finished it; percentiles are null for a stage no order finished. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Fulfillment times",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fulfillment times retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.FulfillmentTimesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/inventory-usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stock consumed per stock-tracked product in an inclusive date range, with current stock, units held by unpaid orders, average daily usage and the projected days until the available stock runs out at that pace, to plan purchasing. Products running out soonest come first; days until stockout is null for products nothing was consumed of. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Inventory usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inventory usage retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.InventoryUsageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/revenue-by-category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Item revenue on paid orders in an inclusive date range per top-level category (for example drinks, food and merch), with quantity, order count and share of revenue. Sales in subcategories roll up to their top-level category, and items without a category are listed as Uncategorized. Pass parent_id to compare the subcategories of one category instead. Revenue is before order discounts. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Revenue by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category to break down into its subcategories (UUID)",
+                        "name": "parent_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revenue by category retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RevenueByCategorySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date, date range or category ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/revenue/sources": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paid revenue broken down by order source (guest, member, kiosk, partner, phone, catering) for an inclusive date range, with the gateway fees recorded on their payments and the revenue net of them. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Revenue by order source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revenue breakdown retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RevenueBySourceSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Paid revenue, order count, average ticket and tax collected per day, week (starting Monday) or month for an inclusive date range, with totals. Every period the range touches is listed, including those without orders. Defaults to daily figures for the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Period length",
+                        "name": "granularity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales report retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SalesReportSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date, date range or granularity",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/settlements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gateway payments settled in an inclusive date range with the estimated gateway fee, expected payout date and order reference, for reconciling bank payouts. Wallet top-ups and gift card purchases are listed without an order number; cash payments and orders paid from the wallet, a gift card or points are left out. Use format=csv to download a spreadsheet instead of JSON. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Settlement export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First settlement date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last settlement date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settlements retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SettlementReportSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date, date range or format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/tax": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Taxable sales and tax collected on paid orders over a calendar month, per applied tax rate and per day, for accounting. Taxable sales are the subtotal after discounts plus the service charge. Orders are grouped by the rate they were charged, so a rate change within the month is listed as two rates. Every day of the month is listed, including those without orders. Defaults to the current month. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Tax report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax report retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TaxReportSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/tips": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tips paid on orders for an inclusive date range, grouped by the staff member who claimed each order so they can be distributed. Tips on unclaimed orders are listed with a null staff member. Tips are not part of revenue. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Tips by staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tips report retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TipsReportSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/top-products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Products on paid orders in an inclusive date range ranked by quantity sold, with order count, revenue before order discounts and how often each customization option was chosen (for example Oat Milk on 40% of lattes). Defaults to the top 10 over the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Best sellers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Best sellers retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TopProductsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/z-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End-of-day summary of a business day in the store timezone, today by default: gross sales, discounts, tax, tips, cash, gateway and stored-value (wallet, gift card, points) payments settled, refunds issued and cancelled orders. A closed day returns the snapshot saved when it was closed, which no longer changes; a day still open is totalled as it stands with closed set to false. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Z-report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Z-report retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.DailySummarySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or day not started yet",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rewards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the whole rewards catalog, including rewards that are switched off, cheapest first, with how many times members bought each. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "List rewards",
+                "responses": {
+                    "200": {
+                        "description": "Rewards retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardListSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a reward members can swap loyalty points for. A free drink or merch is a free_item reward with the category it covers (including subcategories), where discount_value is the percentage taken off the dearest item from it; a discount is a percentage or fixed reward. The voucher a member gets stays valid for valid_days, or forever when 0. Leave stock out for no limit. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Create a reward",
+                "parameters": [
+                    {
+                        "description": "Reward details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reward created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid discount or category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rewards/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single reward by its UUID. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Get a reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reward UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reward retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reward ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reward not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the terms of a reward. Vouchers members already bought with it keep theirs. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Update a reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reward UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reward details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reward updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RewardSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, invalid discount or category not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reward not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a reward from the catalog. Vouchers members already bought with it can still be used. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rewards"
+                ],
+                "summary": "Delete a reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reward UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reward deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reward ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reward not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/currency": {
+            "get": {
+                "description": "Get the currency prices are set and charged in: its ISO code, the symbol shown in front of amounts and how many decimal places amounts are rounded to. Public at /currency so customer apps can show prices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get currency settings",
+                "responses": {
+                    "200": {
+                        "description": "Currency settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CurrencySettingsSuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the currency prices are set and charged in. New orders record it and are charged in it; existing prices and orders are not converted. Midtrans only charges IDR. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update currency settings",
+                "parameters": [
+                    {
+                        "description": "Currency settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CurrencySettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Currency settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CurrencySettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/deposits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the down payment asked of large orders: the percentage of the total paid first, and the total from which it applies. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get deposit settings",
+                "responses": {
+                    "200": {
+                        "description": "Deposit settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.DepositSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ask orders totalling at least min_total to pay percent of their total first. They wait in deposit_paid until the balance is paid, and only then go to the kitchen. Send a percent of zero to stop taking deposits. Orders already placed keep their deposit. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update deposit settings",
+                "parameters": [
+                    {
+                        "description": "Deposit settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.DepositSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deposit settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.DepositSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/loyalty": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how members earn and spend loyalty points. Zero means points are not earned or not spent. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get loyalty settings",
+                "responses": {
+                    "200": {
+                        "description": "Loyalty settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.LoyaltySettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set how much a member spends on completed orders to earn a point, and how much a point takes off an order. Send zero to stop earning or spending points. Points already earned keep their count; their value follows the new rate. Set expiry_months to expire points that long after they were earned, oldest first, and expiry_warning_days to email members that many days before; zero keeps points forever or sends no warnings. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update loyalty settings",
+                "parameters": [
+                    {
+                        "description": "Loyalty settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.LoyaltySettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loyalty settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.LoyaltySettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get which emails are sent to customers. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get notification settings",
+                "responses": {
+                    "200": {
+                        "description": "Notification settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.NotificationSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the notification settings. With email_receipts on, members and guests who left an email address or WhatsApp number get their receipt once their order is completed and paid. With ready_notifications on, customers are told when their order is ready for pickup, on the member's preferred channel or by WhatsApp for guests who left a number and email otherwise. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update notification settings",
+                "parameters": [
+                    {
+                        "description": "Notification settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.NotificationSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.NotificationSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the payment methods offered on the Midtrans payment page. An empty list offers every method active on the Midtrans account. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get payment settings",
+                "responses": {
+                    "200": {
+                        "description": "Payment settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the payment methods offered on the Midtrans payment page, in the order they are shown, for example to turn off credit cards or put QRIS first. Methods must also be active on the Midtrans account. Payment pages already opened keep their methods. Send an empty list to offer every method. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update payment settings",
+                "parameters": [
+                    {
+                        "description": "Payment settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PaymentSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/qr-code": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the colors generated QR codes are drawn in. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get QR code settings",
+                "responses": {
+                    "200": {
+                        "description": "QR code settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.QRCodeSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the QR code colors. Empty colors fall back to the defaults. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update QR code settings",
+                "parameters": [
+                    {
+                        "description": "QR code settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.QRCodeSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.QRCodeSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the header, footer, logo, tax ID and promotional message printed on receipts. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get receipt settings",
+                "responses": {
+                    "200": {
+                        "description": "Receipt settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReceiptSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the receipt settings. Applies to every receipt rendered afterwards. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update receipt settings",
+                "parameters": [
+                    {
+                        "description": "Receipt settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReceiptSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReceiptSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/receipt/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render a sample order as a receipt. Send settings in the body to preview them before saving; an empty body uses the saved settings. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain",
+                    "text/html",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Preview receipt",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "html",
+                            "escpos"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Unsaved receipt settings",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReceiptSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered receipt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/stamps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stamp card: how many stamps earn a free drink, which category's items earn stamps, and the value cap and lifetime of the free-drink voucher. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get stamp card settings",
+                "responses": {
+                    "200": {
+                        "description": "Stamp card settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.StampSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give members a stamp for every item of category_id, or a category nested under it, on their completed orders; leave category_id out to stamp every item. Each time a member collects goal stamps they get a voucher for one free item of that category, worth at most reward_max_value (zero for no cap) and valid for reward_valid_days (zero for no expiry). Send a goal of zero to stop stamping; collected stamps and issued rewards are kept. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update stamp card settings",
+                "parameters": [
+                    {
+                        "description": "Stamp card settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.StampSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stamp card settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.StampSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/timeslots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the most orders and drinks the kitchen takes per 15-minute pickup timeslot. Zero means no limit. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get timeslot capacity",
+                "responses": {
+                    "200": {
+                        "description": "Timeslot settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotSettingsSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the timeslot capacity. New orders are refused once their pickup timeslot holds max_orders orders or max_drinks drinks, counting pre-orders at their scheduled time and other orders when they are placed; the response then lists the next timeslots that can still take the order. Zero turns a limit off. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update timeslot capacity",
+                "parameters": [
+                    {
+                        "description": "Timeslot settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timeslot settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TimeslotSettingsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Get data for the public status page: overall status, uptime of the answering instance, the state of each dependency, and incidents from the last 24 hours. Only states are reported, never latencies or error details. Incidents are recorded when the status is polled, so poll regularly for accurate history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Get public service status",
+                "responses": {
+                    "200": {
+                        "description": "Service status",
+                        "schema": {
+                            "$ref": "#/definitions/docs.StatusSuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all tables by number with their QR code links. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "List tables",
+                "responses": {
+                    "200": {
+                        "description": "Tables retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableListSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a table customers can order from. The response includes the link to print as the table's QR code, e.g. through POST /qr-codes. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Create a table",
+                "parameters": [
+                    {
+                        "description": "Table details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Table created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Table number already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/scan/{token}": {
+            "get": {
+                "description": "Look up the table behind the token in a scanned QR code. Send the returned id as table_id when placing the order so it is served to this table.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Resolve a table QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the table QR code",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ScannedTableSuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single table by its UUID. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Get a table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a table or stop taking orders from it. Its QR code keeps working. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Update a table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Table details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error or invalid table ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Table number already exists",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a table so its QR code stops working. Its orders keep the table number. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Delete a table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.MessageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/{id}/token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give the table a new QR token, e.g. after a photo of its code was shared. Previously printed codes stop working and must be replaced. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Regenerate a table's QR token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token regenerated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TableSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List soft-deleted products and categories, most recently deleted first. Filter with type. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List soft-deleted items",
+                "parameters": [
+                    {
+                        "enum": [
+                            "products",
+                            "categories"
+                        ],
+                        "type": "string",
+                        "description": "Item type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trash retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.TrashSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown item type",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{type}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete a trashed product or category. Order items keep their product name snapshot; products of a purged category become uncategorized. Check the impact endpoint first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Permanently delete a soft-deleted item",
+                "parameters": [
+                    {
+                        "enum": [
+                            "products",
+                            "categories"
+                        ],
+                        "type": "string",
+                        "description": "Item type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item permanently deleted",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or unknown item type",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{type}/{id}/impact": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count what permanently deleting a trashed item would affect: order items and orders referencing a product, or child categories and products of a category. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Preview a permanent delete",
+                "parameters": [
+                    {
+                        "enum": [
+                            "products",
+                            "categories"
+                        ],
+                        "type": "string",
+                        "description": "Item type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purge impact retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PurgeImpactSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or unknown item type",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{type}/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a trashed product or category. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a soft-deleted item",
+                "parameters": [
+                    {
+                        "enum": [
+                            "products",
+                            "categories"
+                        ],
+                        "type": "string",
+                        "description": "Item type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or unknown item type",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Request counts, error rates and latency per API client, with a breakdown by route, for an inclusive date range. Clients identify themselves with the X-Client-Name header (for example kiosk, mobile or web); requests without it are attributed by User-Agent. Defaults to the last 30 days. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "API usage per client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this client",
+                        "name": "client",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usage retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.UsageSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or date range",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of vouchers, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "List vouchers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "redeemed",
+                            "revoked"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by member UUID",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vouchers retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.VouchersSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or member ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant a single-use voucher to each listed member, or to every member of a segment: all members, lapsed members with no completed order in inactive_days, or new members without a completed order. Each member gets their own code, which only they can redeem at checkout. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Issue vouchers",
+                "parameters": [
+                    {
+                        "description": "Recipients and voucher terms",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.IssueVouchersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Vouchers issued",
+                        "schema": {
+                            "$ref": "#/definitions/docs.IssueVouchersSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error, unknown member or empty segment",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one voucher with its audit trail: who issued it, the order that redeemed it, releases from cancelled orders and any revocation. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Get a voucher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voucher UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Voucher retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.VoucherSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid voucher ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voucher not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop an active voucher from being redeemed, such as when it was issued by mistake. A voucher already used on an order stays with it. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vouchers"
+                ],
+                "summary": "Revoke a voucher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Voucher UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for revoking",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RevokeVoucherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Voucher revoked",
+                        "schema": {
+                            "$ref": "#/definitions/docs.VoucherSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid voucher ID or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voucher not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Voucher is not active",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a member's wallet balance with a paginated statement, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get a member's wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{userId}/adjustments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Credit or debit a member's wallet by hand, such as goodwill credit after a complaint. Send a negative amount to debit; a debit larger than the balance is refused. The note and the admin are kept on the statement entry. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Adjust a member's wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signed amount and note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Wallet adjusted",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WalletTransactionSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient wallet balance",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List payment webhooks as they were received, newest first, with the result of processing them. Secret headers are redacted. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List received webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "midtrans",
+                            "stripe",
+                            "xendit"
+                        ],
+                        "type": "string",
+                        "description": "Filter by gateway",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "processed",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by processing result",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook events retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookEventsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid provider or status",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook-events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one payment webhook with its raw body, headers and processing result. Secret headers are redacted. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a received webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook event UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook event retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookEventSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook event ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook event not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook-events/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Process a stored webhook again, for example after fixing the bug that rejected it. Signatures are still verified, against the time the webhook first arrived. The event is returned with the result of this attempt. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay a received webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook event UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook event replayed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookEventSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook event ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook event not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/midtrans": {
+            "post": {
+                "description": "Process payment notification from Midtrans. This endpoint is called by Midtrans when payment status changes. Every notification is stored before processing and can be replayed from /webhook-events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Handle Midtrans webhook",
+                "parameters": [
+                    {
+                        "description": "Midtrans notification payload",
+                        "name": "notification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.MidtransWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or invalid amount",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller IP is not in MIDTRANS_WEBHOOK_ALLOWED_IPS",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many webhooks from this IP",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/stripe": {
+            "post": {
+                "description": "Process a Checkout Session event from Stripe. The raw body is verified against the Stripe-Signature header with the endpoint's signing secret. Events other than checkout.session.* are acknowledged and ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Handle Stripe webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stripe webhook signature",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or invalid amount",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/xendit": {
+            "post": {
+                "description": "Process an invoice callback from Xendit. The x-callback-token header must match the verification token from the Xendit dashboard.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Handle Xendit invoice callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Xendit callback verification token",
+                        "name": "x-callback-token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or invalid amount",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid callback token",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.WebhookErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/orders/{uuid}": {
+            "get": {
+                "description": "Upgrade to a WebSocket that sends the current order status, then every status transition as it happens. Public like the tracking endpoint: the order UUID acts as the access key.",
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Stream order status over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching to WebSocket; each message is an order event",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_services.OrderEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID format",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "426": {
+                        "description": "WebSocket upgrade required",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SwaggerErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "docs.AbandonedBySource": {
+            "type": "object",
+            "properties": {
+                "abandoned_count": {
+                    "type": "integer",
+                    "example": 37
+                },
+                "abandoned_value": {
+                    "type": "number",
+                    "example": 1650000
+                },
+                "drop_off_rate": {
+                    "type": "number",
+                    "example": 11.94
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.AbandonedHour"
+                    }
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "order_source": {
+                    "type": "string",
+                    "enum": [
+                        "guest",
+                        "member",
+                        "kiosk",
+                        "partner",
+                        "phone",
+                        "catering"
+                    ],
+                    "example": "kiosk"
+                },
+                "payment_attempted": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "docs.AbandonedHour": {
+            "type": "object",
+            "properties": {
+                "abandoned_count": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "abandoned_value": {
+                    "type": "number",
+                    "example": 412000
+                },
+                "drop_off_rate": {
+                    "type": "number",
+                    "example": 14.06
+                },
+                "hour": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "payment_attempted": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "docs.AbandonedOrdersResponse": {
+            "type": "object",
+            "properties": {
+                "drop_off_rate": {
+                    "type": "number",
+                    "example": 4.68
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.AbandonedHour"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.AbandonedBySource"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "total_abandoned": {
+                    "type": "integer",
+                    "example": 58
+                },
+                "total_abandoned_value": {
+                    "type": "number",
+                    "example": 2610000
+                },
+                "total_orders": {
+                    "type": "integer",
+                    "example": 1240
+                }
+            }
+        },
+        "docs.AbandonedOrdersSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.AbandonedOrdersResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.AckPrintJobRequest": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Printer out of paper"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "printed",
+                        "failed"
+                    ],
+                    "example": "failed"
+                }
+            }
+        },
+        "docs.AnomaliesSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.AnomalyResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.AnomalyResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "Total 60500.00 does not equal subtotal 55000.00 + tax 5500.00 + source fee 1000.00"
+                },
+                "detected_at": {
+                    "type": "string",
+                    "example": "2025-01-07T02:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "unlinked_item",
+                        "item_subtotal_mismatch",
+                        "subtotal_mismatch",
+                        "total_mismatch"
+                    ],
+                    "example": "total_mismatch"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2025-01-07T09:15:00+07:00"
+                }
+            }
+        },
+        "docs.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                },
+                "user": {
+                    "$ref": "#/definitions/docs.UserResponse"
+                }
+            }
+        },
+        "docs.AuthSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.AuthResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.BulkOrderStatusResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid status transition"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.BulkUpdateOrderStatusRequest": {
+            "type": "object",
+            "properties": {
+                "order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "completed"
+                }
+            }
+        },
+        "docs.BulkUpdateOrderStatusResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.BulkOrderStatusResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "docs.BulkUpdateOrderStatusSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.BulkUpdateOrderStatusResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CampaignListResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CampaignResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "docs.CampaignResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:01:00+07:00"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-09T17:00:00+07:00"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:01:30+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440010"
+                },
+                "inactive_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Your favourite matcha latte is waiting. Drop by this week!"
+                },
+                "segment": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "lapsed",
+                        "new"
+                    ],
+                    "example": "lapsed"
+                },
+                "send_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:00:00+07:00"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:00:30+07:00"
+                },
+                "stats": {
+                    "$ref": "#/definitions/docs.CampaignStats"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "sending",
+                        "sent",
+                        "cancelled"
+                    ],
+                    "example": "sending"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "We miss you"
+                }
+            }
+        },
+        "docs.CampaignStats": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "recipients": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "sent": {
+                    "type": "integer",
+                    "example": 98
+                }
+            }
+        },
+        "docs.CampaignSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CampaignResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign scheduled"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CampaignsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CampaignListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderItemCustomization"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Less ice"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Iced Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "docs.CartResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CartItemResponse"
+                    }
+                },
+                "preview": {
+                    "$ref": "#/definitions/docs.OrderPreviewResponse"
+                },
+                "preview_error": {
+                    "type": "string",
+                    "example": "product not available: Hojicha Latte"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00+07:00"
+                }
+            }
+        },
+        "docs.CartSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CartResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Item added to cart"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CashPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount_tendered": {
+                    "type": "number",
+                    "example": 50000
+                }
+            }
+        },
+        "docs.CashPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount_due": {
+                    "type": "number",
+                    "example": 41500
+                },
+                "amount_tendered": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "change": {
+                    "type": "number",
+                    "example": 8500
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "preparing"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "docs.CashPaymentSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CashPaymentResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Cash payment recorded"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CatalogReward": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/docs.RewardCategory"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Any matcha drink, any size"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed",
+                        "free_item"
+                    ],
+                    "example": "free_item"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440011"
+                },
+                "in_stock": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Free Matcha Latte"
+                },
+                "points_cost": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "redeemable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "valid_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "docs.CategoriesListResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CategoryResponse"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "docs.CategoriesSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CategoriesListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Delicious matcha beverages"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-drinks"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                }
+            }
+        },
+        "docs.CategoryRevenue": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "category_name": {
+                    "type": "string",
+                    "example": "Drinks"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 255
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 14250000
+                },
+                "share": {
+                    "type": "number",
+                    "example": 68.5
+                }
+            }
+        },
+        "docs.CategorySuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CategoryResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.ChaosFaultsResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T17:10:00+07:00"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "latency_path_prefix": {
+                    "type": "string",
+                    "example": "/api/v1/menu"
+                },
+                "midtrans_failure": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "webhook_signature_failure": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.ChaosFaultsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ChaosFaultsResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Faults injected"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CheckoutCartRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Pick up at 10:30"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "takeaway"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "table_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "voucher_code": {
+                    "type": "string",
+                    "example": "VC-7KQM-X3TD"
+                }
+            }
+        },
+        "docs.ClientUsage": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number",
+                    "example": 38.2
+                },
+                "client": {
+                    "type": "string",
+                    "example": "kiosk"
+                },
+                "client_errors": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "error_rate": {
+                    "type": "number",
+                    "example": 0.0117
+                },
+                "last_seen": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "max_latency_ms": {
+                    "type": "integer",
+                    "example": 950
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.RouteUsage"
+                    }
+                },
+                "server_errors": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "docs.CloseDayRequest": {
+            "type": "object",
+            "properties": {
+                "business_date": {
+                    "type": "string",
+                    "example": "2025-01-07"
+                }
+            }
+        },
+        "docs.CreateCampaignRequest": {
+            "type": "object",
+            "properties": {
+                "inactive_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Your favourite matcha latte is waiting. Drop by this week!"
+                },
+                "segment": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "lapsed",
+                        "new"
+                    ],
+                    "example": "lapsed"
+                },
+                "send_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:00:00+07:00"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "We miss you"
+                }
+            }
+        },
+        "docs.CreateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Delicious matcha beverages"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-drinks"
+                }
+            }
+        },
+        "docs.CreateCustomizationRequest": {
+            "type": "object",
+            "properties": {
+                "customization_type": {
+                    "type": "string",
+                    "example": "sweetness"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "option_name": {
+                    "type": "string",
+                    "example": "Less Sugar"
+                },
+                "price_modifier": {
+                    "type": "number",
+                    "example": 0
+                }
+            }
+        },
+        "docs.CreateGuestOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CreateOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Please call when ready"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "dine_in"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "table_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                }
+            }
+        },
+        "docs.CreateKioskOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CreateOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Less ice"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "takeaway"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                }
+            }
+        },
+        "docs.CreateOrderItemRequest": {
+            "type": "object",
+            "properties": {
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderItemCustomization"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Extra ice please"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "docs.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CreateOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Please call when ready"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "dine_in"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "table_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "voucher_code": {
+                    "type": "string",
+                    "example": "VC-7KQM-X3TD"
+                }
+            }
+        },
+        "docs.CreatePaymentTokenRequest": {
+            "type": "object",
+            "properties": {
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                }
+            }
+        },
+        "docs.CreateProductRequest": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number",
+                    "example": 35000
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CreateCustomizationRequest"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Creamy matcha latte"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/matcha.jpg"
+                },
+                "is_available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_customizable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "preparation_time": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-latte"
+                },
+                "stock_quantity": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "docs.CreateStaffOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CreateOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Deliver to the front desk"
+                },
+                "order_source": {
+                    "type": "string",
+                    "enum": [
+                        "kiosk",
+                        "partner",
+                        "phone",
+                        "catering"
+                    ],
+                    "example": "phone"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "dine_in"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "table_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                }
+            }
+        },
+        "docs.CurrencySettings": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "Rp"
+                }
+            }
+        },
+        "docs.CurrencySettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CurrencySettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CustomerSegmentEntry": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "favorite_category": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-06-01T10:00:00+07:00"
+                },
+                "last_order_at": {
+                    "type": "string",
+                    "example": "2024-11-20T08:15:00+07:00"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "total_spend": {
+                    "type": "number",
+                    "example": 672000
+                }
+            }
+        },
+        "docs.CustomerSegmentExport": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CustomerSegmentEntry"
+                    }
+                },
+                "segment": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "lapsed",
+                        "new"
+                    ],
+                    "example": "lapsed"
+                },
+                "stats_refreshed_at": {
+                    "type": "string",
+                    "example": "2025-01-10T09:00:00+07:00"
+                }
+            }
+        },
+        "docs.CustomerSegmentExportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CustomerSegmentExport"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CustomerValue": {
+            "type": "object",
+            "properties": {
+                "average_order_value": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "days_between_orders": {
+                    "type": "number",
+                    "example": 6.97
+                },
+                "email": {
+                    "type": "string",
+                    "example": "dewi@example.com"
+                },
+                "first_order_at": {
+                    "type": "string",
+                    "example": "2024-03-02T09:40:00+07:00"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Dewi Lestari"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "joined_at": {
+                    "type": "string",
+                    "example": "2024-03-02T09:15:00+07:00"
+                },
+                "last_order_at": {
+                    "type": "string",
+                    "example": "2025-01-20T08:05:00+07:00"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_spend": {
+                    "type": "number",
+                    "example": 2640000
+                }
+            }
+        },
+        "docs.CustomerValueReport": {
+            "type": "object",
+            "properties": {
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CustomerValue"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sort_by": {
+                    "type": "string",
+                    "enum": [
+                        "spend",
+                        "orders",
+                        "recent"
+                    ],
+                    "example": "spend"
+                },
+                "stats_refreshed_at": {
+                    "type": "string",
+                    "example": "2025-01-21T10:00:00+07:00"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1240
+                }
+            }
+        },
+        "docs.CustomerValueReportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CustomerValueReport"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.CustomizationPopularity": {
+            "type": "object",
+            "properties": {
+                "customization_type": {
+                    "type": "string",
+                    "example": "milk"
+                },
+                "option_name": {
+                    "type": "string",
+                    "example": "Oat Milk"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 52
+                },
+                "share": {
+                    "type": "number",
+                    "example": 40
+                }
+            }
+        },
+        "docs.CustomizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "customization_type": {
+                    "type": "string",
+                    "example": "sweetness"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "option_name": {
+                    "type": "string",
+                    "example": "Less Sugar"
+                },
+                "price_modifier": {
+                    "type": "number",
+                    "example": 0
+                }
+            }
+        },
+        "docs.CustomizationSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.CustomizationResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.DailySummaryResponse": {
+            "type": "object",
+            "properties": {
+                "business_date": {
+                    "type": "string",
+                    "example": "2025-01-07"
+                },
+                "cancelled_amount": {
+                    "type": "number",
+                    "example": 132000
+                },
+                "cancelled_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "cash_amount": {
+                    "type": "number",
+                    "example": 640000
+                },
+                "closed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "closed_at": {
+                    "type": "string",
+                    "example": "2025-01-07T23:05:00+07:00"
+                },
+                "closed_by": {
+                    "$ref": "#/definitions/docs.StaffSummary"
+                },
+                "completed_count": {
+                    "type": "integer",
+                    "example": 52
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "flagged_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FlaggedOrder"
+                    }
+                },
+                "gateway_amount": {
+                    "type": "number",
+                    "example": 1790000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "incomplete_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 58
+                },
+                "paid_count": {
+                    "type": "integer",
+                    "example": 53
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2025-01-08T00:00:00+07:00"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2025-01-07T00:00:00+07:00"
+                },
+                "refund_amount": {
+                    "type": "number",
+                    "example": 38500
+                },
+                "refund_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "service_charge": {
+                    "type": "number",
+                    "example": 0
+                },
+                "stored_value_amount": {
+                    "type": "number",
+                    "example": 144000
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 2385000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 234000
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "total": {
+                    "type": "number",
+                    "example": 2574000
+                }
+            }
+        },
+        "docs.DailySummarySuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.DailySummaryResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.DepositSettings": {
+            "type": "object",
+            "properties": {
+                "min_total": {
+                    "type": "number",
+                    "example": 1000000
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "docs.DepositSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.DepositSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.EmailTemplateListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.EmailTemplateResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.EmailTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "html_body": {
+                    "type": "string",
+                    "example": "\u003cp\u003eHi {{customer_name}}, thanks for your order!\u003c/p\u003e"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "key": {
+                    "type": "string",
+                    "example": "order_confirmation"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Your Matchaciee order {{order_number}}"
+                },
+                "text_body": {
+                    "type": "string",
+                    "example": "Hi {{customer_name}}, thanks for your order!"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "customer_name",
+                        "order_number",
+                        "total",
+                        "order_url"
+                    ]
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "docs.EmailTemplateSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.EmailTemplateResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.FlaggedOrder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T21:45:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-042"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "preparing"
+                },
+                "total": {
+                    "type": "number",
+                    "example": 49500
+                }
+            }
+        },
+        "docs.FulfillmentDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FulfillmentStage"
+                    }
+                }
+            }
+        },
+        "docs.FulfillmentStage": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer",
+                    "example": 86
+                },
+                "p50_minutes": {
+                    "type": "number",
+                    "example": 6.5
+                },
+                "p90_minutes": {
+                    "type": "number",
+                    "example": 14.25
+                },
+                "stage": {
+                    "type": "string",
+                    "enum": [
+                        "payment",
+                        "preparation",
+                        "pickup",
+                        "total"
+                    ],
+                    "example": "preparation"
+                }
+            }
+        },
+        "docs.FulfillmentTimesResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FulfillmentDay"
+                    }
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "overall": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FulfillmentStage"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                }
+            }
+        },
+        "docs.FulfillmentTimesSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.FulfillmentTimesResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.GenerateQRCodeRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "png",
+                        "svg"
+                    ],
+                    "example": "png"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 512
+                },
+                "table_id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "menu",
+                        "table",
+                        "payment"
+                    ],
+                    "example": "table"
+                }
+            }
+        },
+        "docs.GiftCardListResponse": {
+            "type": "object",
+            "properties": {
+                "gift_cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.GiftCardResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "docs.GiftCardPurchaseRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "recipient_email": {
+                    "type": "string",
+                    "example": "dewi@example.com"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Dewi"
+                }
+            }
+        },
+        "docs.GiftCardPurchaseResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "gift_card": {
+                    "$ref": "#/definitions/docs.GiftCardResponse"
+                },
+                "redirect_url": {
+                    "type": "string",
+                    "example": "https://app.sandbox.midtrans.com/snap/v2/vtweb/..."
+                }
+            }
+        },
+        "docs.GiftCardPurchaseSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.GiftCardPurchaseResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Gift card purchase started"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.GiftCardRedemptionEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:05:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "released_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:20:00+07:00"
+                }
+            }
+        },
+        "docs.GiftCardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "amount_due": {
+                    "type": "number",
+                    "example": 22500
+                },
+                "card_balance": {
+                    "type": "number",
+                    "example": 80000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GC-7KQM-X3TD-98WP"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440008"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "docs.GiftCardRedemptionSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.GiftCardRedemptionResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Gift card redeemed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.GiftCardResponse": {
+            "type": "object",
+            "properties": {
+                "activated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:01:30+07:00"
+                },
+                "balance": {
+                    "type": "number",
+                    "example": 80000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GC-7KQM-X3TD-98WP"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440007"
+                },
+                "initial_amount": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "recipient_email": {
+                    "type": "string",
+                    "example": "dewi@example.com"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Dewi"
+                },
+                "redemptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.GiftCardRedemptionEntry"
+                    }
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "GIFT-12-1736240000"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "active",
+                        "voided"
+                    ],
+                    "example": "active"
+                },
+                "void_reason": {
+                    "type": "string",
+                    "example": "Reported stolen"
+                },
+                "voided_at": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                }
+            }
+        },
+        "docs.GiftCardSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.GiftCardResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Gift card issued"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.GiftCardsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.GiftCardListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.IntegrityCheckResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2025-01-07T09:00:00+07:00"
+                },
+                "new_anomalies": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "open_anomalies": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "docs.IntegrityCheckSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.IntegrityCheckResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Integrity check completed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.InventoryUsage": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 22
+                },
+                "consumed": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "daily_usage": {
+                    "type": "number",
+                    "example": 6
+                },
+                "days_until_stockout": {
+                    "type": "number",
+                    "example": 3.67
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Matcha Cheesecake"
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "stock_quantity": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "docs.InventoryUsageResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-30"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.InventoryUsage"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                }
+            }
+        },
+        "docs.InventoryUsageSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.InventoryUsageResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.IssueGiftCardRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "recipient_email": {
+                    "type": "string",
+                    "example": "dewi@example.com"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Dewi"
+                }
+            }
+        },
+        "docs.IssueVouchersRequest": {
+            "type": "object",
+            "properties": {
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "fixed"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-02-07T23:59:59+07:00"
+                },
+                "inactive_days": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 30000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 0
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Sorry your order MC-250107-001 was late"
+                },
+                "segment": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "lapsed",
+                        "new"
+                    ],
+                    "example": "lapsed"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440003"
+                    ]
+                }
+            }
+        },
+        "docs.IssueVouchersResponse": {
+            "type": "object",
+            "properties": {
+                "issued": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "vouchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.VoucherResponse"
+                    }
+                }
+            }
+        },
+        "docs.IssueVouchersSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.IssueVouchersResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Vouchers issued"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.KioskHeartbeatRequest": {
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "2.4.1"
+                }
+            }
+        },
+        "docs.KioskListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.KioskResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.KioskRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Front entrance"
+                }
+            }
+        },
+        "docs.KioskResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string",
+                    "example": "4f1c9e0b7a2d4c6e8f0a1b3c5d7e9f2a4b6c8d0e1f3a5b7c9d2e4f6a8b0c1d3e"
+                },
+                "app_version": {
+                    "type": "string",
+                    "example": "2.4.1"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_ip": {
+                    "type": "string",
+                    "example": "192.168.1.20"
+                },
+                "last_seen_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Front entrance"
+                },
+                "online": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                }
+            }
+        },
+        "docs.KioskSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.KioskResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.KitchenQueueItem": {
+            "type": "object",
+            "properties": {
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Milk: Oat Milk",
+                        "Sweetness: Less Sugar"
+                    ]
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Less ice"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "docs.KitchenQueueOrder": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "$ref": "#/definitions/docs.StaffSummary"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00+07:00"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "elapsed_seconds": {
+                    "type": "integer",
+                    "example": 420
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.KitchenQueueItem"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Takeaway"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_source": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "order_type": {
+                    "type": "string",
+                    "example": "dine_in"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "normal",
+                        "rush"
+                    ],
+                    "example": "rush"
+                },
+                "queue_number": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "preparing"
+                },
+                "table_number": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "docs.KitchenQueueResponse": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:07:00+07:00"
+                },
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.KitchenQueueOrder"
+                    }
+                },
+                "preparing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.KitchenQueueOrder"
+                    }
+                },
+                "ready": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.KitchenQueueOrder"
+                    }
+                }
+            }
+        },
+        "docs.KitchenQueueSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.KitchenQueueResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "docs.LoginWithCodeRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "whatsapp"
+                    ],
+                    "example": "email"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "482913"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                }
+            }
+        },
+        "docs.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                }
+            }
+        },
+        "docs.LookupOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                }
+            }
+        },
+        "docs.LoyaltySettings": {
+            "type": "object",
+            "properties": {
+                "expiry_months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "expiry_warning_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "point_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "spend_per_point": {
+                    "type": "number",
+                    "example": 10000
+                }
+            }
+        },
+        "docs.LoyaltySettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.LoyaltySettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MeResponse": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/docs.UserResponse"
+                }
+            }
+        },
+        "docs.MeSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.MeResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MediaCleanupResponse": {
+            "type": "object",
+            "properties": {
+                "cleaned_at": {
+                    "type": "string",
+                    "example": "2025-01-07T03:00:00+07:00"
+                },
+                "deleted_files": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "freed_bytes": {
+                    "type": "integer",
+                    "example": 4194304
+                }
+            }
+        },
+        "docs.MediaCleanupSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.MediaCleanupResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Media cleanup completed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MediaFileResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "original_name": {
+                    "type": "string",
+                    "example": "matcha-latte.jpg"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 184320
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://api.matchaciee.com/uploads/9b2f6a1e-4c1d-4f3e-9a8b-2c7d5e6f1a2b.jpg"
+                }
+            }
+        },
+        "docs.MediaFileSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.MediaFileResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Image uploaded successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MenuCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Our signature matcha drinks"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.matchaciee.com/matcha-drinks.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MenuProduct"
+                    }
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-drinks"
+                }
+            }
+        },
+        "docs.MenuCustomization": {
+            "type": "object",
+            "properties": {
+                "customization_type": {
+                    "type": "string",
+                    "example": "milk"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "option_name": {
+                    "type": "string",
+                    "example": "Oat Milk"
+                },
+                "price_modifier": {
+                    "type": "number",
+                    "example": 5000
+                }
+            }
+        },
+        "docs.MenuProduct": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number",
+                    "example": 35000
+                },
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MenuCustomization"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ceremonial grade matcha with steamed milk"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://cdn.matchaciee.com/matcha-latte.jpg"
+                },
+                "is_customizable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "preparation_time": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-latte"
+                },
+                "sold_out": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.MenuResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MenuCategory"
+                    }
+                },
+                "uncategorized": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MenuProduct"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "42"
+                }
+            }
+        },
+        "docs.MenuSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.MenuResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Operation completed successfully"
+                }
+            }
+        },
+        "docs.MessageSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.MessageResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.MidtransRefund": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07 10:15:00"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Drink spilled before pickup"
+                },
+                "refund_amount": {
+                    "type": "string",
+                    "example": "42500.00"
+                },
+                "refund_key": {
+                    "type": "string",
+                    "example": "RF-550e8400-e29b-41d4-a716-446655440002"
+                }
+            }
+        },
+        "docs.MidtransWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "fraud_status": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "gross_amount": {
+                    "type": "string",
+                    "example": "77000.00"
+                },
+                "merchant_id": {
+                    "type": "string",
+                    "example": "G123456789"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "MC-250107-001-1234567890"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "gopay"
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MidtransRefund"
+                    }
+                },
+                "settlement_time": {
+                    "type": "string",
+                    "example": "2025-01-07 10:05:00"
+                },
+                "signature_key": {
+                    "type": "string",
+                    "example": "..."
+                },
+                "status_code": {
+                    "type": "string",
+                    "example": "200"
+                },
+                "status_message": {
+                    "type": "string",
+                    "example": "Success"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "settlement"
+                },
+                "transaction_time": {
+                    "type": "string",
+                    "example": "2025-01-07 10:00:00"
+                }
+            }
+        },
+        "docs.MyVouchersSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.VoucherResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.NotificationSettings": {
+            "type": "object",
+            "properties": {
+                "email_receipts": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ready_notifications": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.NotificationSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.NotificationSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.OrderItemCustomization": {
+            "type": "object",
+            "properties": {
+                "customization_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "option_name": {
+                    "type": "string",
+                    "example": "Less Sugar"
+                }
+            }
+        },
+        "docs.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "customizations": {},
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Extra ice"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 70000
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 35000
+                }
+            }
+        },
+        "docs.OrderListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "docs.OrderPreviewItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Iced Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 90000
+                },
+                "unit_price": {
+                    "type": "number",
+                    "example": 45000
+                }
+            }
+        },
+        "docs.OrderPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "delivery_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderPreviewItem"
+                    }
+                },
+                "order_type": {
+                    "type": "string",
+                    "example": "takeaway"
+                },
+                "price_adjustment_percent": {
+                    "type": "number",
+                    "example": 0
+                },
+                "service_charge": {
+                    "type": "number",
+                    "example": 0
+                },
+                "source_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 90000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 9000
+                },
+                "total": {
+                    "type": "number",
+                    "example": 99000
+                }
+            }
+        },
+        "docs.OrderPromotionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "HAPPYHOUR"
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 14000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Happy Hour"
+                }
+            }
+        },
+        "docs.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "$ref": "#/definitions/docs.StaffSummary"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "customer_email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "customer_phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                },
+                "delivery_discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "delivery_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "deposit_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "deposit_paid_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:05:00Z"
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 14000
+                },
+                "flagged_at": {
+                    "type": "string",
+                    "example": "2025-01-07T23:05:00Z"
+                },
+                "gift_card_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Please call when ready"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_source": {
+                    "type": "string",
+                    "enum": [
+                        "guest",
+                        "member",
+                        "kiosk",
+                        "partner",
+                        "phone",
+                        "catering"
+                    ],
+                    "example": "member"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "takeaway"
+                },
+                "payment_due": {
+                    "type": "number",
+                    "example": 61600
+                },
+                "payment_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                },
+                "pickup_code": {
+                    "type": "string",
+                    "example": "MC-250107-001.K3J9D2F7QX"
+                },
+                "points_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "points_redeemed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "price_adjustment_percent": {
+                    "type": "number",
+                    "example": 20
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "normal",
+                        "rush"
+                    ],
+                    "example": "normal"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderPromotionResponse"
+                    }
+                },
+                "queue_number": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00+07:00"
+                },
+                "service_charge": {
+                    "type": "number",
+                    "example": 0
+                },
+                "share_token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "source_fee": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 70000
+                },
+                "table_number": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 5600
+                },
+                "tip_amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "total": {
+                    "type": "number",
+                    "example": 61600
+                },
+                "user": {
+                    "$ref": "#/definitions/docs.UserSummary"
+                }
+            }
+        },
+        "docs.OrderStatusItem": {
+            "type": "object",
+            "properties": {
+                "product_name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "docs.OrderStatusResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-08T10:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OrderStatusItem"
+                    }
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_type": {
+                    "type": "string",
+                    "enum": [
+                        "dine_in",
+                        "takeaway",
+                        "delivery"
+                    ],
+                    "example": "takeaway"
+                },
+                "queue_number": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-01-08T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "pending",
+                        "deposit_paid",
+                        "preparing",
+                        "ready",
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "preparing"
+                },
+                "table_number": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "docs.OrderStatusSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.OrderStatusResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.OrderSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.OrderResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.OrderTotals": {
+            "type": "object",
+            "properties": {
+                "delivery_discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "delivery_fee": {
+                    "type": "number",
+                    "example": 0
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "service_charge": {
+                    "type": "number",
+                    "example": 0
+                },
+                "source_fee": {
+                    "type": "number",
+                    "example": 4500
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "total": {
+                    "type": "number",
+                    "example": 59500
+                }
+            }
+        },
+        "docs.OrdersSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.OrderListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentCancellationResponse": {
+            "type": "object",
+            "properties": {
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "cancelled"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "cancel"
+                }
+            }
+        },
+        "docs.PaymentCancellationSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentCancellationResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Payment cancelled"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentLinkResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 42500
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://api.matchaciee.com/api/v1/pay/9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
+        "docs.PaymentLinkSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentLinkResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Payment link created"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PaymentResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "docs.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "checkout_channel": {
+                    "type": "string",
+                    "enum": [
+                        "hosted",
+                        "qris"
+                    ],
+                    "example": "qris"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00+07:00"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "gateway_fee": {
+                    "type": "number",
+                    "example": 297.5
+                },
+                "gross_amount": {
+                    "type": "number",
+                    "example": 42500
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "midtrans",
+                        "stripe",
+                        "xendit",
+                        "cash",
+                        "wallet",
+                        "gift_card",
+                        "points"
+                    ],
+                    "example": "midtrans"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "qris"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "MC-250107-001-1736240000"
+                },
+                "settlement_time": {
+                    "type": "string",
+                    "example": "2025-01-07T10:01:30+07:00"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "9aed5972-5b6a-401e-894b-a32c91ed1a3a"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "settlement",
+                        "expire",
+                        "cancel",
+                        "deny",
+                        "refund",
+                        "partial_refund"
+                    ],
+                    "example": "settlement"
+                },
+                "transaction_time": {
+                    "type": "string",
+                    "example": "2025-01-07T10:01:00+07:00"
+                }
+            }
+        },
+        "docs.PaymentSettings": {
+            "type": "object",
+            "properties": {
+                "enabled_payments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "other_qris",
+                        "gopay",
+                        "shopeepay",
+                        "bank_transfer"
+                    ]
+                }
+            }
+        },
+        "docs.PaymentSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentSimulationResponse": {
+            "type": "object",
+            "properties": {
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "preparing"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "settlement"
+                }
+            }
+        },
+        "docs.PaymentSimulationSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentSimulationResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Notification processed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentStatusResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "settlement",
+                        "expire",
+                        "cancel",
+                        "deny",
+                        "refund",
+                        "partial_refund"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "docs.PaymentStatusSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentStatusResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentTokenResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentTokenResponse": {
+            "type": "object",
+            "properties": {
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "provider": {
+                    "type": "string",
+                    "enum": [
+                        "midtrans",
+                        "stripe",
+                        "xendit"
+                    ],
+                    "example": "midtrans"
+                },
+                "redirect_url": {
+                    "type": "string",
+                    "example": "https://app.sandbox.midtrans.com/snap/v2/vtweb/..."
+                },
+                "reused": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "token": {
+                    "type": "string",
+                    "example": "66e4fa55-fdac-4ef9-91b5-733b97d1b862"
+                }
+            }
+        },
+        "docs.PaymentVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "preparing"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "previous_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "settlement"
+                }
+            }
+        },
+        "docs.PaymentVerificationSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentVerificationResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Payment verified"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PaymentsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PaymentListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PointTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "integer",
+                    "example": 350
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:45:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440009"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "earn",
+                        "redeem",
+                        "refund",
+                        "expire"
+                    ],
+                    "example": "earn"
+                }
+            }
+        },
+        "docs.PointsExpiryResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-09T10:30:00+07:00"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "soon": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PointsRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "amount_due": {
+                    "type": "number",
+                    "example": 22500
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "points_balance": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "docs.PointsRedemptionSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PointsRedemptionResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Points redeemed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PointsResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "example": 350
+                },
+                "balance_value": {
+                    "type": "number",
+                    "example": 35000
+                },
+                "expiring": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PointsExpiryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "point_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PointTransactionResponse"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440006"
+                }
+            }
+        },
+        "docs.PointsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PointsResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PrintJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "payload": {
+                    "type": "string",
+                    "format": "base64",
+                    "example": "G0AbYQFNYXRjaGFjaWVlCg=="
+                },
+                "station": {
+                    "type": "string",
+                    "example": "counter"
+                }
+            }
+        },
+        "docs.PrintJobSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PrintJob"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Print job taken"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number",
+                    "example": 35000
+                },
+                "category": {
+                    "$ref": "#/definitions/docs.CategoryResponse"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CustomizationResponse"
+                    }
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Creamy matcha latte"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/matcha.jpg"
+                },
+                "is_available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_customizable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "preparation_time": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "matcha-latte"
+                },
+                "stock_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                }
+            }
+        },
+        "docs.ProductSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ProductResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.ProductsListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ProductResponse"
+                    }
+                }
+            }
+        },
+        "docs.ProductsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ProductsListResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PromotionListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PromotionResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PromotionRequest": {
+            "type": "object",
+            "properties": {
+                "automatic": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "sun",
+                            "mon",
+                            "tue",
+                            "wed",
+                            "thu",
+                            "fri",
+                            "sat"
+                        ]
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "20% off all matcha drinks"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed",
+                        "free_delivery"
+                    ],
+                    "example": "percentage"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 20
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "16:00"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00+07:00"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Month"
+                },
+                "per_customer_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+                    ]
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00+07:00"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "docs.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "automatic": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PromotionScopeItem"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-20T09:00:00Z"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "20% off all matcha drinks"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 20
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "16:00"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9b2d4f60-8c1e-4a7b-b5d3-2f6e8a0c1d4e"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Month"
+                },
+                "per_customer_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PromotionScopeItem"
+                    }
+                },
+                "redemptions": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00+07:00"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-20T09:00:00Z"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "docs.PromotionScopeItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                }
+            }
+        },
+        "docs.PromotionStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average_order_value": {
+                    "type": "number",
+                    "example": 92000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MATCHA20"
+                },
+                "customers": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "discount_given": {
+                    "type": "number",
+                    "example": 630000
+                },
+                "first_redeemed_at": {
+                    "type": "string",
+                    "example": "2025-02-01T08:12:00Z"
+                },
+                "last_redeemed_at": {
+                    "type": "string",
+                    "example": "2025-02-27T17:45:00Z"
+                },
+                "promotion_id": {
+                    "type": "string",
+                    "example": "9b2d4f60-8c1e-4a7b-b5d3-2f6e8a0c1d4e"
+                },
+                "redemptions": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 3864000
+                }
+            }
+        },
+        "docs.PromotionStatsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PromotionStatsResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PromotionSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PromotionResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.PurgeImpactResponse": {
+            "type": "object",
+            "properties": {
+                "active_reservations": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "child_categories": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "customizations": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "effects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Order items keep the product name and price but lose the link to the product",
+                        "Customizations are deleted"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Iced Matcha Latte"
+                },
+                "order_items": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "products": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "type": {
+                    "type": "string",
+                    "example": "products"
+                }
+            }
+        },
+        "docs.PurgeImpactSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.PurgeImpactResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.QRCodeSettings": {
+            "type": "object",
+            "properties": {
+                "background_color": {
+                    "type": "string",
+                    "example": "#FFFFFF"
+                },
+                "foreground_color": {
+                    "type": "string",
+                    "example": "#2F5D3A"
+                }
+            }
+        },
+        "docs.QRCodeSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.QRCodeSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.QRISChargeResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 42500
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "qr_image_url": {
+                    "type": "string",
+                    "example": "https://api.sandbox.midtrans.com/v2/qris/b0d0ab5a-0ea1-4a1e-8bda-9a6d7d7a1b7e/qr-code"
+                },
+                "qr_string": {
+                    "type": "string",
+                    "example": "00020101021226620014COM.GO-JEK.WWW011993600914..."
+                },
+                "reused": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.QRISChargeSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.QRISChargeResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.ReceiptSettings": {
+            "type": "object",
+            "properties": {
+                "footer_text": {
+                    "type": "string",
+                    "example": "Thank you for your order!"
+                },
+                "header_text": {
+                    "type": "string",
+                    "example": "Matchaciee\nJl. Ganesha No. 10, Bandung"
+                },
+                "logo_url": {
+                    "type": "string",
+                    "example": "https://cdn.matchaciee.com/logo.png"
+                },
+                "paper_width": {
+                    "type": "integer",
+                    "enum": [
+                        32,
+                        48
+                    ],
+                    "example": 32
+                },
+                "promo_message": {
+                    "type": "string",
+                    "example": "Buy 5 lattes, get 1 free!"
+                },
+                "tax_id": {
+                    "type": "string",
+                    "example": "01.234.567.8-901.000"
+                }
+            }
+        },
+        "docs.ReceiptSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ReceiptSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RedeemGiftCardRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GC-7KQM-X3TD-98WP"
+                }
+            }
+        },
+        "docs.RedeemPointsRequest": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "docs.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                }
+            }
+        },
+        "docs.RefundPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 18000
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Drink spilled before pickup"
+                }
+            }
+        },
+        "docs.RefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 42500
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:15:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "order_status": {
+                    "type": "string",
+                    "example": "cancelled"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Drink spilled before pickup"
+                },
+                "refundable_balance": {
+                    "type": "number",
+                    "example": 0
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "refund"
+                }
+            }
+        },
+        "docs.RefundSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RefundResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Payment refunded"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "id"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                }
+            }
+        },
+        "docs.ReorderRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docs.ReorderResponse": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/docs.OrderResponse"
+                },
+                "previous_total": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "skipped_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ReorderSkippedItem"
+                    }
+                }
+            }
+        },
+        "docs.ReorderSkippedItem": {
+            "type": "object",
+            "properties": {
+                "product_name": {
+                    "type": "string",
+                    "example": "Hojicha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "removed",
+                        "unavailable",
+                        "customization_unavailable",
+                        "insufficient_stock"
+                    ],
+                    "example": "unavailable"
+                }
+            }
+        },
+        "docs.ReorderSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ReorderResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RequestLoginCodeRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "whatsapp"
+                    ],
+                    "example": "email"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+6281234567890"
+                }
+            }
+        },
+        "docs.RevenueByCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CategoryRevenue"
+                    }
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "total_revenue": {
+                    "type": "number",
+                    "example": 20800000
+                }
+            }
+        },
+        "docs.RevenueByCategorySuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RevenueByCategoryResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RevenueBySourceResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.SourceRevenue"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "total_gateway_fees": {
+                    "type": "number",
+                    "example": 64680
+                },
+                "total_net_revenue": {
+                    "type": "number",
+                    "example": 9175320
+                },
+                "total_orders": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_revenue": {
+                    "type": "number",
+                    "example": 9240000
+                }
+            }
+        },
+        "docs.RevenueBySourceSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RevenueBySourceResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RevokeVoucherRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Issued to the wrong member"
+                }
+            }
+        },
+        "docs.RewardCatalogResponse": {
+            "type": "object",
+            "properties": {
+                "points_balance": {
+                    "type": "integer",
+                    "example": 820
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CatalogReward"
+                    }
+                }
+            }
+        },
+        "docs.RewardCatalogSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RewardCatalogResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RewardCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matcha Drinks"
+                }
+            }
+        },
+        "docs.RewardListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.RewardResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RewardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "points_balance": {
+                    "type": "integer",
+                    "example": 320
+                },
+                "points_spent": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "voucher": {
+                    "$ref": "#/definitions/docs.VoucherResponse"
+                }
+            }
+        },
+        "docs.RewardRedemptionSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RewardRedemptionResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Reward redeemed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RewardRequest": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Any matcha drink, any size"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed",
+                        "free_item"
+                    ],
+                    "example": "free_item"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Free Matcha Latte"
+                },
+                "points_cost": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "valid_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "docs.RewardResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/docs.RewardCategory"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Any matcha drink, any size"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed",
+                        "free_item"
+                    ],
+                    "example": "free_item"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 100
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440011"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Free Matcha Latte"
+                },
+                "points_cost": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "redemptions": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "valid_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "docs.RewardSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.RewardResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.RouteUsage": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "error_rate": {
+                    "type": "number",
+                    "example": 0.0125
+                },
+                "last_seen": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00Z"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/v1/orders/:id"
+                }
+            }
+        },
+        "docs.SalesPeriod": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "number",
+                    "example": 58928.57
+                },
+                "discount": {
+                    "type": "number",
+                    "example": 120000
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 4950000
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 4620000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 450000
+                }
+            }
+        },
+        "docs.SalesReportResponse": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "number",
+                    "example": 58888.89
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "granularity": {
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "week",
+                        "month"
+                    ],
+                    "example": "week"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.SalesPeriod"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "total_orders": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "total_revenue": {
+                    "type": "number",
+                    "example": 21200000
+                },
+                "total_tax": {
+                    "type": "number",
+                    "example": 1927000
+                }
+            }
+        },
+        "docs.SalesReportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.SalesReportResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.ScannedTableResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "docs.ScannedTableSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.ScannedTableResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SelftestFailureResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.SelftestResponse"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Self-test failed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.SelftestResponse": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 318
+                },
+                "passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.SelftestStep"
+                    }
+                }
+            }
+        },
+        "docs.SelftestStep": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "name": {
+                    "type": "string",
+                    "example": "pay"
+                },
+                "passed": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SelftestSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.SelftestResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Self-test passed"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.Settlement": {
+            "type": "object",
+            "properties": {
+                "fee": {
+                    "type": "number",
+                    "example": 350
+                },
+                "gross_amount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "net_amount": {
+                    "type": "number",
+                    "example": 49650
+                },
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250107-001"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "qris"
+                },
+                "payout_date": {
+                    "type": "string",
+                    "example": "2025-01-08"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "midtrans"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "MC-250107-001-1736240000"
+                },
+                "refunded": {
+                    "type": "number",
+                    "example": 0
+                },
+                "settled_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:32:00+07:00"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "9aed5972-5b6a-401e-894b-a32c91ed1a3a"
+                }
+            }
+        },
+        "docs.SettlementReportResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "settlements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.Settlement"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "total_fees": {
+                    "type": "number",
+                    "example": 41300
+                },
+                "total_gross": {
+                    "type": "number",
+                    "example": 4250000
+                },
+                "total_net": {
+                    "type": "number",
+                    "example": 4158700
+                },
+                "total_refunded": {
+                    "type": "number",
+                    "example": 50000
+                }
+            }
+        },
+        "docs.SettlementReportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.SettlementReportResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SimulatePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "settlement",
+                        "cancel",
+                        "expire",
+                        "deny"
+                    ],
+                    "example": "settlement"
+                }
+            }
+        },
+        "docs.SourcePricingListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.SourcePricingResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SourcePricingResponse": {
+            "type": "object",
+            "properties": {
+                "flat_fee": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "order_source": {
+                    "type": "string",
+                    "example": "partner"
+                },
+                "price_adjustment_percent": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "docs.SourcePricingSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.SourcePricingResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SourceRevenue": {
+            "type": "object",
+            "properties": {
+                "gateway_fees": {
+                    "type": "number",
+                    "example": 6468
+                },
+                "net_revenue": {
+                    "type": "number",
+                    "example": 917532
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "order_source": {
+                    "type": "string",
+                    "example": "partner"
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 924000
+                },
+                "subtotal": {
+                    "type": "number",
+                    "example": 840000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 84000
+                }
+            }
+        },
+        "docs.StaffSummary": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string",
+                    "example": "Rina Barista"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                }
+            }
+        },
+        "docs.StaffTips": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "staff": {
+                    "$ref": "#/definitions/docs.StaffSummary"
+                },
+                "tips": {
+                    "type": "number",
+                    "example": 145000
+                }
+            }
+        },
+        "docs.StampCardResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "goal": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.VoucherResponse"
+                    }
+                },
+                "stamps": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "docs.StampCardSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.StampCardResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.StampSettings": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "goal": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "reward_max_value": {
+                    "type": "number",
+                    "example": 45000
+                },
+                "reward_valid_days": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "docs.StampSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.StampSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.StatusComponent": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "operational"
+                }
+            }
+        },
+        "docs.StatusIncident": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string",
+                    "example": "realtime"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2025-01-07T09:14:30+07:00"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-01-07T09:12:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "degraded"
+                }
+            }
+        },
+        "docs.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:00:00+07:00"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.StatusComponent"
+                    }
+                },
+                "incidents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.StatusIncident"
+                    }
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-01-07T08:00:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "operational"
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 7200
+                }
+            }
+        },
+        "docs.StatusSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.StatusResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.StorageUsageResponse": {
+            "type": "object",
+            "properties": {
+                "cleanup_after_days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "file_count": {
+                    "type": "integer",
+                    "example": 1240
+                },
+                "near_quota": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quota_bytes": {
+                    "type": "integer",
+                    "example": 524288000
+                },
+                "unreferenced_bytes": {
+                    "type": "integer",
+                    "example": 30408704
+                },
+                "unreferenced_files": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "used_bytes": {
+                    "type": "integer",
+                    "example": 432013312
+                },
+                "used_percent": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "docs.StorageUsageSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.StorageUsageResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SwaggerErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Error message"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c9a52-7a0e-4c7b-9a45-2f6f1d0b8e21"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.SwaggerSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.SwaggerValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c9a52-7a0e-4c7b-9a45-2f6f1d0b8e21"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.TableListSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TableResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TableRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "number": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "docs.TableResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "number": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://matchaciee.com/menu?table=9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
+        "docs.TableSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TableResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TaxDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 41
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 225500
+                },
+                "taxable_sales": {
+                    "type": "number",
+                    "example": 2050000
+                }
+            }
+        },
+        "docs.TaxRateSummary": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer",
+                    "example": 1240
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 11
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 6820000
+                },
+                "taxable_sales": {
+                    "type": "number",
+                    "example": 62000000
+                }
+            }
+        },
+        "docs.TaxReportResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TaxDay"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-01"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TaxRateSummary"
+                    }
+                },
+                "total_orders": {
+                    "type": "integer",
+                    "example": 1240
+                },
+                "total_tax": {
+                    "type": "number",
+                    "example": 6820000
+                },
+                "total_taxable_sales": {
+                    "type": "number",
+                    "example": 62000000
+                }
+            }
+        },
+        "docs.TaxReportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TaxReportResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TestSendEmailTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "to": {
+                    "type": "string",
+                    "example": "admin@matchaciee.com"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docs.Timeslot": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:30:00+07:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:15:00+07:00"
+                }
+            }
+        },
+        "docs.TimeslotFullData": {
+            "type": "object",
+            "properties": {
+                "next_available": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.Timeslot"
+                    }
+                }
+            }
+        },
+        "docs.TimeslotFullErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TimeslotFullData"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Pickup timeslot is fully booked"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "docs.TimeslotSettings": {
+            "type": "object",
+            "properties": {
+                "max_drinks": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "max_orders": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "docs.TimeslotSettingsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TimeslotSettings"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TipsReportResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.StaffTips"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "tipped_orders": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_tips": {
+                    "type": "number",
+                    "example": 360000
+                }
+            }
+        },
+        "docs.TipsReportSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TipsReportResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TopProduct": {
+            "type": "object",
+            "properties": {
+                "customizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CustomizationPopularity"
+                    }
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 112
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "product_name": {
+                    "type": "string",
+                    "example": "Matcha Latte"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 130
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 5850000
+                }
+            }
+        },
+        "docs.TopProductsResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TopProduct"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                }
+            }
+        },
+        "docs.TopProductsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TopProductsResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.TotalsDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "type": "number",
+                    "example": 59500
+                },
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "item_subtotal",
+                        "subtotal",
+                        "tax",
+                        "total"
+                    ],
+                    "example": "total"
+                },
+                "item_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "stored": {
+                    "type": "number",
+                    "example": 60500
+                }
+            }
+        },
+        "docs.TrashItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Matcha"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2025-01-07T17:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Iced Matcha Latte"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "iced-matcha-latte"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "products"
+                }
+            }
+        },
+        "docs.TrashResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TrashItem"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TrashItem"
+                    }
+                }
+            }
+        },
+        "docs.TrashSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/docs.TrashResponse"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.UpdateCartItemRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Less ice"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "docs.CategoryResponse": {
+        "docs.UpdateCategoryRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
+                "clear_parent": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "description": {
                     "type": "string",
-                    "example": "Delicious matcha beverages"
+                    "example": "Updated description"
                 },
                 "display_order": {
                     "type": "integer",
-                    "example": 1
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": 2
                 },
                 "image_url": {
                     "type": "string",
@@ -1948,60 +18383,44 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
-                    "example": "Matcha Drinks"
+                    "example": "Matcha Drinks Updated"
                 },
-                "slug": {
+                "parent_id": {
                     "type": "string",
-                    "example": "matcha-drinks"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "updated_at": {
+                "slug": {
                     "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
-                }
-            }
-        },
-        "docs.CategorySuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/docs.CategoryResponse"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "matcha-drinks-updated"
                 }
             }
         },
-        "docs.CreateCategoryRequest": {
+        "docs.UpdateChaosFaultsRequest": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Delicious matcha beverages"
+                "duration_seconds": {
+                    "type": "integer",
+                    "example": 600
                 },
-                "display_order": {
+                "latency_ms": {
                     "type": "integer",
-                    "example": 1
+                    "example": 2000
                 },
-                "image_url": {
+                "latency_path_prefix": {
                     "type": "string",
-                    "example": "https://example.com/image.jpg"
+                    "example": "/api/v1/menu"
                 },
-                "is_active": {
+                "midtrans_failure": {
                     "type": "boolean",
                     "example": true
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Matcha Drinks"
-                },
-                "slug": {
-                    "type": "string",
-                    "example": "matcha-drinks"
+                "webhook_signature_failure": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "docs.CreateCustomizationRequest": {
+        "docs.UpdateCustomizationRequest": {
             "type": "object",
             "properties": {
                 "customization_type": {
@@ -2010,84 +18429,97 @@ const docTemplate = `{
                 },
                 "display_order": {
                     "type": "integer",
-                    "example": 1
+                    "example": 2
                 },
                 "option_name": {
                     "type": "string",
-                    "example": "Less Sugar"
+                    "example": "Extra Sugar"
                 },
                 "price_modifier": {
                     "type": "number",
-                    "example": 0
+                    "example": 5000
                 }
             }
         },
-        "docs.CreateOrderItemRequest": {
+        "docs.UpdateEmailTemplateRequest": {
             "type": "object",
             "properties": {
-                "customizations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.OrderItemCustomization"
-                    }
-                },
-                "notes": {
+                "html_body": {
                     "type": "string",
-                    "example": "Extra ice please"
+                    "example": "\u003cp\u003eHi {{customer_name}}, thanks for your order!\u003c/p\u003e"
                 },
-                "product_id": {
+                "subject": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Your Matchaciee order {{order_number}}"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "example": 2
+                "text_body": {
+                    "type": "string",
+                    "example": "Hi {{customer_name}}, thanks for your order!"
                 }
             }
         },
-        "docs.CreateOrderRequest": {
+        "docs.UpdateOrderItemsRequest": {
             "type": "object",
             "properties": {
-                "customer_name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
                 "items": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/docs.CreateOrderItemRequest"
                     }
-                },
-                "notes": {
+                }
+            }
+        },
+        "docs.UpdateOrderPriorityRequest": {
+            "type": "object",
+            "properties": {
+                "priority": {
                     "type": "string",
-                    "example": "Please call when ready"
+                    "enum": [
+                        "normal",
+                        "rush"
+                    ],
+                    "example": "rush"
                 }
             }
         },
-        "docs.CreateProductRequest": {
+        "docs.UpdateOrderStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "preparing",
+                        "ready",
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "preparing"
+                }
+            }
+        },
+        "docs.UpdateProductRequest": {
             "type": "object",
             "properties": {
                 "base_price": {
                     "type": "number",
-                    "example": 35000
+                    "example": 40000
                 },
                 "category_id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "customizations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.CreateCustomizationRequest"
-                    }
+                "clear_stock_limit": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "description": {
                     "type": "string",
-                    "example": "Creamy matcha latte"
+                    "example": "Premium matcha latte"
                 },
                 "display_order": {
                     "type": "integer",
-                    "example": 1
+                    "example": 2
                 },
                 "image_url": {
                     "type": "string",
@@ -2103,94 +18535,72 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
-                    "example": "Matcha Latte"
+                    "example": "Matcha Latte Premium"
                 },
                 "preparation_time": {
                     "type": "integer",
-                    "example": 5
+                    "example": 7
                 },
                 "slug": {
                     "type": "string",
-                    "example": "matcha-latte"
+                    "example": "matcha-latte-premium"
+                },
+                "stock_quantity": {
+                    "type": "integer",
+                    "example": 50
                 }
             }
         },
-        "docs.CustomizationResponse": {
+        "docs.UpdateProfileRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
-                },
-                "customization_type": {
-                    "type": "string",
-                    "example": "sweetness"
-                },
-                "display_order": {
-                    "type": "integer",
-                    "example": 1
+                "clear_locale": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "id": {
+                "full_name": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "John Doe"
                 },
-                "option_name": {
+                "locale": {
                     "type": "string",
-                    "example": "Less Sugar"
-                },
-                "price_modifier": {
-                    "type": "number",
-                    "example": 0
-                }
-            }
-        },
-        "docs.CustomizationSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/docs.CustomizationResponse"
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "id"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "docs.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
+                "notify_via": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "enum": [
+                        "email",
+                        "whatsapp"
+                    ],
+                    "example": "whatsapp"
                 },
-                "password": {
+                "phone": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "+6281234567890"
                 }
             }
         },
-        "docs.LogoutRequest": {
+        "docs.UpdateProfileResponse": {
             "type": "object",
             "properties": {
-                "refresh_token": {
+                "token": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIs..."
-                }
-            }
-        },
-        "docs.MeResponse": {
-            "type": "object",
-            "properties": {
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
                 "user": {
                     "$ref": "#/definitions/docs.UserResponse"
                 }
             }
         },
-        "docs.MeSuccessResponse": {
+        "docs.UpdateProfileSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.MeResponse"
+                    "$ref": "#/definitions/docs.UpdateProfileResponse"
                 },
                 "success": {
                     "type": "boolean",
@@ -2198,20 +18608,43 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.MessageResponse": {
+        "docs.UpdateSourcePricingRequest": {
             "type": "object",
             "properties": {
-                "message": {
+                "flat_fee": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "price_adjustment_percent": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "docs.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ClientUsage"
+                    }
+                },
+                "end_date": {
                     "type": "string",
-                    "example": "Operation completed successfully"
+                    "example": "2025-01-07"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-01"
                 }
             }
         },
-        "docs.MessageSuccessResponse": {
+        "docs.UsageSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.MessageResponse"
+                    "$ref": "#/definitions/docs.UsageResponse"
                 },
                 "success": {
                     "type": "boolean",
@@ -2219,119 +18652,161 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.MidtransWebhookRequest": {
+        "docs.UserResponse": {
             "type": "object",
             "properties": {
-                "currency": {
+                "email": {
                     "type": "string",
-                    "example": "IDR"
+                    "example": "user@example.com"
                 },
-                "fraud_status": {
+                "full_name": {
                     "type": "string",
-                    "example": "accept"
+                    "example": "John Doe"
                 },
-                "gross_amount": {
+                "id": {
                     "type": "string",
-                    "example": "77000.00"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "merchant_id": {
+                "locale": {
                     "type": "string",
-                    "example": "G123456789"
+                    "example": "id"
                 },
-                "order_id": {
+                "notify_via": {
                     "type": "string",
-                    "example": "MC-250107-001-1234567890"
+                    "enum": [
+                        "email",
+                        "whatsapp"
+                    ],
+                    "example": "email"
                 },
-                "payment_type": {
+                "phone": {
                     "type": "string",
-                    "example": "gopay"
+                    "example": "+6281234567890"
                 },
-                "settlement_time": {
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                }
+            }
+        },
+        "docs.UserSummary": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string",
-                    "example": "2025-01-07 10:05:00"
+                    "example": "john@example.com"
                 },
-                "signature_key": {
+                "full_name": {
                     "type": "string",
-                    "example": "..."
+                    "example": "John Doe"
                 },
-                "status_code": {
+                "id": {
                     "type": "string",
-                    "example": "200"
-                },
-                "status_message": {
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "docs.VerifyPickupRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string",
-                    "example": "Success"
+                    "example": "MC-250107-001.K3J9D2F7QX"
+                }
+            }
+        },
+        "docs.VerifyTotalsResponse": {
+            "type": "object",
+            "properties": {
+                "consistent": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "transaction_id": {
-                    "type": "string",
-                    "example": "1234567890"
+                "discrepancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TotalsDiscrepancy"
+                    }
                 },
-                "transaction_status": {
+                "order_id": {
                     "type": "string",
-                    "example": "settlement"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "transaction_time": {
+                "order_number": {
                     "type": "string",
-                    "example": "2025-01-07 10:00:00"
+                    "example": "MC-250107-001"
+                },
+                "recomputed": {
+                    "$ref": "#/definitions/docs.OrderTotals"
+                },
+                "stored": {
+                    "$ref": "#/definitions/docs.OrderTotals"
                 }
             }
         },
-        "docs.OrderItemCustomization": {
+        "docs.VerifyTotalsSuccessResponse": {
             "type": "object",
             "properties": {
-                "customization_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "data": {
+                    "$ref": "#/definitions/docs.VerifyTotalsResponse"
                 },
-                "option_name": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "docs.VoidGiftCardRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
                     "type": "string",
-                    "example": "Less Sugar"
+                    "example": "Reported stolen"
                 }
             }
         },
-        "docs.OrderItemResponse": {
+        "docs.VoucherEventEntry": {
             "type": "object",
             "properties": {
-                "customizations": {},
-                "id": {
+                "action": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "enum": [
+                        "issued",
+                        "redeemed",
+                        "released",
+                        "revoked"
+                    ],
+                    "example": "redeemed"
                 },
-                "notes": {
+                "actor_name": {
                     "type": "string",
-                    "example": "Extra ice"
+                    "example": "John Doe"
                 },
-                "product_name": {
+                "created_at": {
                     "type": "string",
-                    "example": "Matcha Latte"
+                    "example": "2025-01-10T12:00:00+07:00"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "example": 2
+                "note": {
+                    "type": "string",
+                    "example": "Sorry your order MC-250107-001 was late"
                 },
-                "subtotal": {
-                    "type": "number",
-                    "example": 70000
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "unit_price": {
-                    "type": "number",
-                    "example": 35000
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250110-014"
                 }
             }
         },
-        "docs.OrderListResponse": {
+        "docs.VoucherListResponse": {
             "type": "object",
             "properties": {
                 "limit": {
                     "type": "integer",
                     "example": 20
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.OrderResponse"
-                    }
-                },
                 "page": {
                     "type": "integer",
                     "example": 1
@@ -2339,83 +18814,129 @@ const docTemplate = `{
                 "total": {
                     "type": "integer",
                     "example": 100
+                },
+                "vouchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.VoucherResponse"
+                    }
                 }
             }
         },
-        "docs.OrderResponse": {
+        "docs.VoucherMember": {
             "type": "object",
             "properties": {
-                "completed_at": {
-                    "type": "string"
-                },
-                "created_at": {
+                "email": {
                     "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
+                    "example": "john@example.com"
                 },
-                "customer_name": {
+                "full_name": {
                     "type": "string",
                     "example": "John Doe"
                 },
                 "id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                }
+            }
+        },
+        "docs.VoucherResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "VC-7KQM-X3TD"
                 },
-                "items": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-07T18:00:00+07:00"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed",
+                        "free_item"
+                    ],
+                    "example": "fixed"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.OrderItemResponse"
+                        "$ref": "#/definitions/docs.VoucherEventEntry"
                     }
                 },
-                "notes": {
+                "expires_at": {
                     "type": "string",
-                    "example": "Please call when ready"
+                    "example": "2025-02-07T23:59:59+07:00"
                 },
-                "order_number": {
+                "id": {
                     "type": "string",
-                    "example": "MC-250107-001"
+                    "example": "550e8400-e29b-41d4-a716-446655440009"
                 },
-                "order_source": {
-                    "type": "string",
-                    "example": "member"
+                "max_discount": {
+                    "type": "number",
+                    "example": 30000
                 },
-                "status": {
-                    "type": "string",
-                    "example": "pending"
+                "member": {
+                    "$ref": "#/definitions/docs.VoucherMember"
                 },
-                "subtotal": {
+                "min_spend": {
                     "type": "number",
-                    "example": 70000
+                    "example": 0
                 },
-                "tax": {
-                    "type": "number",
-                    "example": 7000
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "total": {
-                    "type": "number",
-                    "example": 77000
+                "order_number": {
+                    "type": "string",
+                    "example": "MC-250110-014"
                 },
-                "user": {
-                    "$ref": "#/definitions/docs.UserSummary"
-                }
-            }
-        },
-        "docs.OrderSuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/docs.OrderResponse"
+                "reason": {
+                    "type": "string",
+                    "example": "Sorry your order MC-250107-001 was late"
                 },
-                "success": {
+                "redeemable": {
                     "type": "boolean",
-                    "example": true
+                    "example": false
+                },
+                "redeemed_at": {
+                    "type": "string",
+                    "example": "2025-01-10T12:00:00+07:00"
+                },
+                "revoke_reason": {
+                    "type": "string",
+                    "example": "Issued to the wrong member"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2025-01-09T09:00:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "redeemed",
+                        "revoked"
+                    ],
+                    "example": "redeemed"
                 }
             }
         },
-        "docs.OrdersSuccessResponse": {
+        "docs.VoucherSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.OrderListResponse"
+                    "$ref": "#/definitions/docs.VoucherResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Voucher revoked"
                 },
                 "success": {
                     "type": "boolean",
@@ -2423,11 +18944,11 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.PaymentSuccessResponse": {
+        "docs.VouchersSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.PaymentTokenResponse"
+                    "$ref": "#/definitions/docs.VoucherListResponse"
                 },
                 "success": {
                     "type": "boolean",
@@ -2435,93 +18956,57 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.PaymentTokenResponse": {
+        "docs.WalletAdjustmentRequest": {
             "type": "object",
             "properties": {
-                "payment_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "redirect_url": {
-                    "type": "string",
-                    "example": "https://app.sandbox.midtrans.com/snap/v2/vtweb/..."
+                "amount": {
+                    "type": "number",
+                    "example": 15000
                 },
-                "token": {
+                "note": {
                     "type": "string",
-                    "example": "66e4fa55-fdac-4ef9-91b5-733b97d1b862"
+                    "example": "Goodwill credit for a late order"
                 }
             }
         },
-        "docs.ProductResponse": {
+        "docs.WalletPaymentResponse": {
             "type": "object",
             "properties": {
-                "base_price": {
+                "amount": {
                     "type": "number",
-                    "example": 35000
-                },
-                "category": {
-                    "$ref": "#/definitions/docs.CategoryResponse"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
-                },
-                "customizations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.CustomizationResponse"
-                    }
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Creamy matcha latte"
-                },
-                "display_order": {
-                    "type": "integer",
-                    "example": 1
+                    "example": 42500
                 },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "balance": {
+                    "type": "number",
+                    "example": 57500
                 },
-                "image_url": {
+                "order_id": {
                     "type": "string",
-                    "example": "https://example.com/matcha.jpg"
-                },
-                "is_available": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "is_customizable": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "name": {
+                "order_number": {
                     "type": "string",
-                    "example": "Matcha Latte"
-                },
-                "preparation_time": {
-                    "type": "integer",
-                    "example": 5
+                    "example": "MC-250107-001"
                 },
-                "slug": {
+                "order_status": {
                     "type": "string",
-                    "example": "matcha-latte"
+                    "example": "preparing"
                 },
-                "updated_at": {
+                "payment_id": {
                     "type": "string",
-                    "example": "2025-01-07T10:00:00Z"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "docs.ProductSuccessResponse": {
+        "docs.WalletPaymentSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.ProductResponse"
+                    "$ref": "#/definitions/docs.WalletPaymentResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Order paid from wallet"
                 },
                 "success": {
                     "type": "boolean",
@@ -2529,26 +19014,42 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.ProductsListResponse": {
+        "docs.WalletResponse": {
             "type": "object",
             "properties": {
-                "count": {
+                "balance": {
+                    "type": "number",
+                    "example": 57500
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
                     "type": "integer",
-                    "example": 10
+                    "example": 1
                 },
-                "products": {
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "transactions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.ProductResponse"
+                        "$ref": "#/definitions/docs.WalletTransactionResponse"
                     }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440006"
                 }
             }
         },
-        "docs.ProductsSuccessResponse": {
+        "docs.WalletSuccessResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/docs.ProductsListResponse"
+                    "$ref": "#/definitions/docs.WalletResponse"
                 },
                 "success": {
                     "type": "boolean",
@@ -2556,231 +19057,228 @@ const docTemplate = `{
                 }
             }
         },
-        "docs.RefreshTokenRequest": {
+        "docs.WalletTopUpRequest": {
             "type": "object",
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIs..."
+                "amount": {
+                    "type": "number",
+                    "example": 100000
                 }
             }
         },
-        "docs.RegisterRequest": {
+        "docs.WalletTopUpResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "amount": {
+                    "type": "number",
+                    "example": 100000
                 },
-                "full_name": {
+                "expires_at": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "2025-01-07T10:30:00+07:00"
                 },
-                "password": {
+                "id": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "550e8400-e29b-41d4-a716-446655440004"
                 },
-                "phone": {
+                "provider": {
                     "type": "string",
-                    "example": "+6281234567890"
-                }
-            }
-        },
-        "docs.SwaggerErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "enum": [
+                        "midtrans",
+                        "stripe",
+                        "xendit"
+                    ],
+                    "example": "midtrans"
+                },
+                "redirect_url": {
                     "type": "string",
-                    "example": "Error message"
+                    "example": "https://app.sandbox.midtrans.com/snap/v2/vtweb/..."
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "reference": {
+                    "type": "string",
+                    "example": "TOPUP-12-1736240000"
                 }
             }
         },
-        "docs.SwaggerValidationErrorResponse": {
+        "docs.WalletTopUpSuccessResponse": {
             "type": "object",
             "properties": {
-                "details": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "data": {
+                    "$ref": "#/definitions/docs.WalletTopUpResponse"
                 },
-                "error": {
+                "message": {
                     "type": "string",
-                    "example": "Validation failed"
+                    "example": "Top-up started"
                 },
                 "success": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 }
             }
         },
-        "docs.UpdateCategoryRequest": {
+        "docs.WalletTransactionResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "amount": {
+                    "type": "number",
+                    "example": -42500
+                },
+                "balance_after": {
+                    "type": "number",
+                    "example": 57500
+                },
+                "created_at": {
                     "type": "string",
-                    "example": "Updated description"
+                    "example": "2025-01-07T10:15:00+07:00"
                 },
-                "display_order": {
-                    "type": "integer",
-                    "example": 2
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440005"
                 },
-                "image_url": {
+                "note": {
                     "type": "string",
-                    "example": "https://example.com/image.jpg"
+                    "example": "Goodwill credit for a late order"
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "order_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
                 },
-                "name": {
+                "order_number": {
                     "type": "string",
-                    "example": "Matcha Drinks Updated"
+                    "example": "MC-250107-001"
                 },
-                "slug": {
+                "type": {
                     "type": "string",
-                    "example": "matcha-drinks-updated"
+                    "enum": [
+                        "topup",
+                        "payment",
+                        "refund",
+                        "adjustment"
+                    ],
+                    "example": "payment"
                 }
             }
         },
-        "docs.UpdateCustomizationRequest": {
+        "docs.WalletTransactionSuccessResponse": {
             "type": "object",
             "properties": {
-                "customization_type": {
-                    "type": "string",
-                    "example": "sweetness"
-                },
-                "display_order": {
-                    "type": "integer",
-                    "example": 2
+                "data": {
+                    "$ref": "#/definitions/docs.WalletTransactionResponse"
                 },
-                "option_name": {
+                "message": {
                     "type": "string",
-                    "example": "Extra Sugar"
+                    "example": "Wallet adjusted"
                 },
-                "price_modifier": {
-                    "type": "number",
-                    "example": 5000
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "docs.UpdateOrderStatusRequest": {
+        "docs.WebhookErrorResponse": {
             "type": "object",
             "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Error message"
+                },
                 "status": {
                     "type": "string",
-                    "enum": [
-                        "pending",
-                        "preparing",
-                        "ready",
-                        "completed",
-                        "cancelled"
-                    ],
-                    "example": "preparing"
+                    "example": "error"
                 }
             }
         },
-        "docs.UpdateProductRequest": {
+        "docs.WebhookEventListResponse": {
             "type": "object",
             "properties": {
-                "base_price": {
-                    "type": "number",
-                    "example": 40000
-                },
-                "category_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Premium matcha latte"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.WebhookEventResponse"
+                    }
                 },
-                "display_order": {
+                "limit": {
                     "type": "integer",
-                    "example": 2
-                },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://example.com/matcha.jpg"
-                },
-                "is_available": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "is_customizable": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Matcha Latte Premium"
+                    "example": 20
                 },
-                "preparation_time": {
+                "page": {
                     "type": "integer",
-                    "example": 7
+                    "example": 1
                 },
-                "slug": {
-                    "type": "string",
-                    "example": "matcha-latte-premium"
+                "total": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "docs.UserResponse": {
+        "docs.WebhookEventResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "{\"order_id\":\"MC-250107-001-1736240000\",\"transaction_status\":\"settlement\"}"
                 },
-                "full_name": {
+                "error": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "payment not found"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
                 "id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
                 },
-                "phone": {
+                "processed_at": {
                     "type": "string",
-                    "example": "+6281234567890"
+                    "example": "2025-01-07T10:05:01+07:00"
                 },
-                "role": {
+                "provider": {
                     "type": "string",
-                    "example": "member"
+                    "example": "midtrans"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2025-01-07T10:05:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
                 }
             }
         },
-        "docs.UserSummary": {
+        "docs.WebhookEventSuccessResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                "data": {
+                    "$ref": "#/definitions/docs.WebhookEventResponse"
                 },
-                "full_name": {
+                "message": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "Webhook event replayed"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "docs.WebhookErrorResponse": {
+        "docs.WebhookEventsSuccessResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Error message"
+                "data": {
+                    "$ref": "#/definitions/docs.WebhookEventListResponse"
                 },
-                "status": {
-                    "type": "string",
-                    "example": "error"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -2792,6 +19290,103 @@ const docTemplate = `{
                     "example": "success"
                 }
             }
+        },
+        "github_com_carllix_matchaciee-backend_internal_models.OrderSource": {
+            "type": "string",
+            "enum": [
+                "guest",
+                "member",
+                "kiosk",
+                "partner",
+                "phone",
+                "catering"
+            ],
+            "x-enum-varnames": [
+                "OrderSourceGuest",
+                "OrderSourceMember",
+                "OrderSourceKiosk",
+                "OrderSourcePartner",
+                "OrderSourcePhone",
+                "OrderSourceCatering"
+            ]
+        },
+        "github_com_carllix_matchaciee-backend_internal_models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "scheduled",
+                "pending",
+                "deposit_paid",
+                "preparing",
+                "ready",
+                "completed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusScheduled",
+                "OrderStatusPending",
+                "OrderStatusDepositPaid",
+                "OrderStatusPreparing",
+                "OrderStatusReady",
+                "OrderStatusCompleted",
+                "OrderStatusCancelled"
+            ]
+        },
+        "github_com_carllix_matchaciee-backend_internal_models.Priority": {
+            "type": "string",
+            "enum": [
+                "normal",
+                "rush"
+            ],
+            "x-enum-varnames": [
+                "PriorityNormal",
+                "PriorityRush"
+            ]
+        },
+        "github_com_carllix_matchaciee-backend_internal_services.OrderEvent": {
+            "type": "object",
+            "properties": {
+                "assigned_to": {
+                    "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_services.StaffSummary"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "order_source": {
+                    "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_models.OrderSource"
+                },
+                "previous_status": {
+                    "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_models.OrderStatus"
+                },
+                "priority": {
+                    "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_models.Priority"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_carllix_matchaciee-backend_internal_models.OrderStatus"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_carllix_matchaciee-backend_internal_services.StaffSummary": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -2800,6 +19395,12 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "KioskKey": {
+            "description": "API key issued to a kiosk by an admin",
+            "type": "apiKey",
+            "name": "X-Kiosk-Key",
+            "in": "header"
         }
     },
     "tags": [
@@ -2826,6 +19427,10 @@ const docTemplate = `{
         {
             "description": "Webhook endpoints for payment notifications",
             "name": "Webhooks"
+        },
+        {
+            "description": "Public service status for the status page",
+            "name": "Status"
         }
     ]
 }`
@@ -15,14 +15,16 @@ type SwaggerSuccessResponse struct {
 }
 
 type SwaggerErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Error   string `json:"error" example:"Error message"`
+	Success   bool   `json:"success" example:"false"`
+	Error     string `json:"error" example:"Error message"`
+	RequestID string `json:"request_id" example:"3f1c9a52-7a0e-4c7b-9a45-2f6f1d0b8e21"`
 }
 
 type SwaggerValidationErrorResponse struct {
-	Success bool              `json:"success" example:"false"`
-	Error   string            `json:"error" example:"Validation failed"`
-	Details map[string]string `json:"details"`
+	Success   bool              `json:"success" example:"false"`
+	Error     string            `json:"error" example:"Validation failed"`
+	Details   map[string]string `json:"details"`
+	RequestID string            `json:"request_id" example:"3f1c9a52-7a0e-4c7b-9a45-2f6f1d0b8e21"`
 }
 
 // Auth DTOs
@@ -31,6 +33,7 @@ type RegisterRequest struct {
 	Password string `json:"password" example:"password123"`
 	FullName string `json:"full_name" example:"John Doe"`
 	Phone    string `json:"phone,omitempty" example:"+6281234567890"`
+	Locale   string `json:"locale,omitempty" example:"id" enums:"id,en"`
 }
 
 type LoginRequest struct {
@@ -38,6 +41,19 @@ type LoginRequest struct {
 	Password string `json:"password" example:"password123"`
 }
 
+type RequestLoginCodeRequest struct {
+	Channel string `json:"channel" example:"email" enums:"email,whatsapp"`
+	Email   string `json:"email,omitempty" example:"user@example.com"`
+	Phone   string `json:"phone,omitempty" example:"+6281234567890"`
+}
+
+type LoginWithCodeRequest struct {
+	Channel string `json:"channel" example:"email" enums:"email,whatsapp"`
+	Email   string `json:"email,omitempty" example:"user@example.com"`
+	Phone   string `json:"phone,omitempty" example:"+6281234567890"`
+	Code    string `json:"code" example:"482913"`
+}
+
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIs..."`
 }
@@ -47,11 +63,13 @@ type LogoutRequest struct {
 }
 
 type UserResponse struct {
-	ID       uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Email    string    `json:"email" example:"user@example.com"`
-	FullName string    `json:"full_name" example:"John Doe"`
-	Phone    *string   `json:"phone,omitempty" example:"+6281234567890"`
-	Role     string    `json:"role" example:"member"`
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email     string    `json:"email" example:"user@example.com"`
+	FullName  string    `json:"full_name" example:"John Doe"`
+	Phone     *string   `json:"phone,omitempty" example:"+6281234567890"`
+	Locale    *string   `json:"locale,omitempty" example:"id"`
+	NotifyVia *string   `json:"notify_via,omitempty" example:"email" enums:"email,whatsapp"`
+	Role      string    `json:"role" example:"member"`
 }
 
 type AuthResponse struct {
@@ -74,35 +92,66 @@ type MeSuccessResponse struct {
 	Data    MeResponse `json:"data"`
 }
 
+type UpdateProfileRequest struct {
+	FullName    *string `json:"full_name,omitempty" example:"John Doe"`
+	Phone       *string `json:"phone,omitempty" example:"+6281234567890"`
+	Locale      *string `json:"locale,omitempty" example:"id" enums:"id,en"`
+	ClearLocale bool    `json:"clear_locale,omitempty" example:"false"`
+	NotifyVia   *string `json:"notify_via,omitempty" example:"whatsapp" enums:"email,whatsapp"`
+}
+
+type UpdateProfileResponse struct {
+	User  UserResponse `json:"user"`
+	Token string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type UpdateProfileSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    UpdateProfileResponse `json:"data"`
+}
+
 // Category DTOs
 type CreateCategoryRequest struct {
-	Name         string  `json:"name" example:"Matcha Drinks"`
-	Slug         string  `json:"slug,omitempty" example:"matcha-drinks"`
-	Description  *string `json:"description,omitempty" example:"Delicious matcha beverages"`
-	DisplayOrder int     `json:"display_order,omitempty" example:"1"`
-	IsActive     *bool   `json:"is_active,omitempty" example:"true"`
-	ImageURL     *string `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name         string     `json:"name" example:"Matcha Drinks"`
+	Slug         string     `json:"slug,omitempty" example:"matcha-drinks"`
+	Description  *string    `json:"description,omitempty" example:"Delicious matcha beverages"`
+	DisplayOrder int        `json:"display_order,omitempty" example:"1"`
+	IsActive     *bool      `json:"is_active,omitempty" example:"true"`
+	ImageURL     *string    `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
 }
 
 type UpdateCategoryRequest struct {
-	Name         *string `json:"name,omitempty" example:"Matcha Drinks Updated"`
-	Slug         *string `json:"slug,omitempty" example:"matcha-drinks-updated"`
-	Description  *string `json:"description,omitempty" example:"Updated description"`
-	ImageURL     *string `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
-	DisplayOrder *int    `json:"display_order,omitempty" example:"2"`
-	IsActive     *bool   `json:"is_active,omitempty" example:"true"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ClearParent  bool       `json:"clear_parent,omitempty" example:"false"`
+	Name         *string    `json:"name,omitempty" example:"Matcha Drinks Updated"`
+	Slug         *string    `json:"slug,omitempty" example:"matcha-drinks-updated"`
+	Description  *string    `json:"description,omitempty" example:"Updated description"`
+	ImageURL     *string    `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	DisplayOrder *int       `json:"display_order,omitempty" example:"2"`
+	IsActive     *bool      `json:"is_active,omitempty" example:"true"`
 }
 
 type CategoryResponse struct {
-	ID           uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name         string    `json:"name" example:"Matcha Drinks"`
-	Slug         string    `json:"slug" example:"matcha-drinks"`
-	Description  *string   `json:"description,omitempty" example:"Delicious matcha beverages"`
-	ImageURL     *string   `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
-	DisplayOrder int       `json:"display_order" example:"1"`
-	IsActive     bool      `json:"is_active" example:"true"`
-	CreatedAt    string    `json:"created_at" example:"2025-01-07T10:00:00Z"`
-	UpdatedAt    string    `json:"updated_at" example:"2025-01-07T10:00:00Z"`
+	ID           uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Name         string     `json:"name" example:"Matcha Drinks"`
+	Slug         string     `json:"slug" example:"matcha-drinks"`
+	Description  *string    `json:"description,omitempty" example:"Delicious matcha beverages"`
+	ImageURL     *string    `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	DisplayOrder int        `json:"display_order" example:"1"`
+	IsActive     bool       `json:"is_active" example:"true"`
+	CreatedAt    string     `json:"created_at" example:"2025-01-07T10:00:00Z"`
+	UpdatedAt    string     `json:"updated_at" example:"2025-01-07T10:00:00Z"`
+}
+
+type ReorderRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+type CategoryTreeNode struct {
+	CategoryResponse
+	Children []CategoryTreeNode `json:"children"`
 }
 
 type CategorySuccessResponse struct {
@@ -146,6 +195,7 @@ type CreateProductRequest struct {
 	IsAvailable     *bool                        `json:"is_available,omitempty" example:"true"`
 	IsCustomizable  *bool                        `json:"is_customizable,omitempty" example:"true"`
 	ImageURL        *string                      `json:"image_url,omitempty" example:"https://example.com/matcha.jpg"`
+	StockQuantity   *int                         `json:"stock_quantity,omitempty" example:"50"`
 	Customizations  []CreateCustomizationRequest `json:"customizations,omitempty"`
 }
 
@@ -160,6 +210,8 @@ type UpdateProductRequest struct {
 	IsCustomizable  *bool      `json:"is_customizable,omitempty" example:"true"`
 	PreparationTime *int       `json:"preparation_time,omitempty" example:"7"`
 	DisplayOrder    *int       `json:"display_order,omitempty" example:"2"`
+	StockQuantity   *int       `json:"stock_quantity,omitempty" example:"50"`
+	ClearStockLimit bool       `json:"clear_stock_limit,omitempty" example:"false"`
 }
 
 type CustomizationResponse struct {
@@ -183,6 +235,7 @@ type ProductResponse struct {
 	IsAvailable     bool                    `json:"is_available" example:"true"`
 	IsCustomizable  bool                    `json:"is_customizable" example:"true"`
 	ImageURL        *string                 `json:"image_url,omitempty" example:"https://example.com/matcha.jpg"`
+	StockQuantity   *int                    `json:"stock_quantity,omitempty" example:"50"`
 	DeletedAt       *string                 `json:"deleted_at,omitempty"`
 	Customizations  []CustomizationResponse `json:"customizations,omitempty"`
 	CreatedAt       string                  `json:"created_at" example:"2025-01-07T10:00:00Z"`
@@ -222,16 +275,73 @@ type CreateOrderItemRequest struct {
 	Customizations []OrderItemCustomization `json:"customizations,omitempty"`
 }
 
+type UpdateOrderItemsRequest struct {
+	Items []CreateOrderItemRequest `json:"items"`
+}
+
 type CreateOrderRequest struct {
 	CustomerName string                   `json:"customer_name" example:"John Doe"`
+	OrderType    string                   `json:"order_type,omitempty" example:"dine_in" enums:"dine_in,takeaway,delivery"`
+	TableID      *string                  `json:"table_id,omitempty" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Notes        *string                  `json:"notes,omitempty" example:"Please call when ready"`
+	PromoCode    *string                  `json:"promo_code,omitempty" example:"MATCHA20"`
+	VoucherCode  *string                  `json:"voucher_code,omitempty" example:"VC-7KQM-X3TD"`
+	TipAmount    float64                  `json:"tip_amount,omitempty" example:"5000"`
+	ScheduledFor *string                  `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
+	Items        []CreateOrderItemRequest `json:"items"`
+}
+
+type CreateGuestOrderRequest struct {
+	CustomerName string                   `json:"customer_name" example:"John Doe"`
+	OrderType    string                   `json:"order_type,omitempty" example:"dine_in" enums:"dine_in,takeaway,delivery"`
+	TableID      *string                  `json:"table_id,omitempty" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
 	Notes        *string                  `json:"notes,omitempty" example:"Please call when ready"`
+	PromoCode    *string                  `json:"promo_code,omitempty" example:"MATCHA20"`
+	TipAmount    float64                  `json:"tip_amount,omitempty" example:"5000"`
+	ScheduledFor *string                  `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
 	Items        []CreateOrderItemRequest `json:"items"`
+	Email        *string                  `json:"email,omitempty" example:"john@example.com"`
+	Phone        *string                  `json:"phone,omitempty" example:"+6281234567890"`
+}
+
+type CreateStaffOrderRequest struct {
+	CustomerName string                   `json:"customer_name" example:"John Doe"`
+	OrderType    string                   `json:"order_type,omitempty" example:"dine_in" enums:"dine_in,takeaway,delivery"`
+	TableID      *string                  `json:"table_id,omitempty" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Notes        *string                  `json:"notes,omitempty" example:"Deliver to the front desk"`
+	PromoCode    *string                  `json:"promo_code,omitempty" example:"MATCHA20"`
+	TipAmount    float64                  `json:"tip_amount,omitempty" example:"5000"`
+	ScheduledFor *string                  `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
+	Items        []CreateOrderItemRequest `json:"items"`
+	OrderSource  string                   `json:"order_source" example:"phone" enums:"kiosk,partner,phone,catering"`
+}
+
+type CreateKioskOrderRequest struct {
+	CustomerName *string                  `json:"customer_name,omitempty" example:"John Doe"`
+	OrderType    string                   `json:"order_type,omitempty" example:"takeaway" enums:"dine_in,takeaway,delivery"`
+	Notes        *string                  `json:"notes,omitempty" example:"Less ice"`
+	PromoCode    *string                  `json:"promo_code,omitempty" example:"MATCHA20"`
+	Items        []CreateOrderItemRequest `json:"items"`
+}
+
+type LookupOrderRequest struct {
+	OrderNumber  string `json:"order_number" example:"MC-250107-001"`
+	Phone        string `json:"phone,omitempty" example:"+6281234567890"`
+	CustomerName string `json:"customer_name,omitempty" example:"John Doe"`
+}
+
+type VerifyPickupRequest struct {
+	Code string `json:"code" example:"MC-250107-001.K3J9D2F7QX"`
 }
 
 type UpdateOrderStatusRequest struct {
 	Status string `json:"status" example:"preparing" enums:"pending,preparing,ready,completed,cancelled"`
 }
 
+type UpdateOrderPriorityRequest struct {
+	Priority string `json:"priority" example:"rush" enums:"normal,rush"`
+}
+
 type UserSummary struct {
 	ID       uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	FullName string    `json:"full_name" example:"John Doe"`
@@ -248,20 +358,54 @@ type OrderItemResponse struct {
 	Notes          *string   `json:"notes,omitempty" example:"Extra ice"`
 }
 
+type OrderPromotionResponse struct {
+	Code     string  `json:"code" example:"HAPPYHOUR"`
+	Name     *string `json:"name,omitempty" example:"Happy Hour"`
+	Discount float64 `json:"discount" example:"14000"`
+}
+
 type OrderResponse struct {
-	ID           uuid.UUID           `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	OrderNumber  string              `json:"order_number" example:"MC-250107-001"`
-	CustomerName string              `json:"customer_name" example:"John Doe"`
-	Status       string              `json:"status" example:"pending"`
-	OrderSource  string              `json:"order_source" example:"member"`
-	Subtotal     float64             `json:"subtotal" example:"70000"`
-	Tax          float64             `json:"tax" example:"7000"`
-	Total        float64             `json:"total" example:"77000"`
-	Notes        *string             `json:"notes,omitempty" example:"Please call when ready"`
-	Items        []OrderItemResponse `json:"items"`
-	User         *UserSummary        `json:"user,omitempty"`
-	CreatedAt    string              `json:"created_at" example:"2025-01-07T10:00:00Z"`
-	CompletedAt  *string             `json:"completed_at,omitempty"`
+	ID                     uuid.UUID                `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber            string                   `json:"order_number" example:"MC-250107-001"`
+	CustomerName           string                   `json:"customer_name" example:"John Doe"`
+	CustomerEmail          *string                  `json:"customer_email,omitempty" example:"john@example.com"`
+	CustomerPhone          *string                  `json:"customer_phone,omitempty" example:"+6281234567890"`
+	Status                 string                   `json:"status" example:"pending"`
+	OrderSource            string                   `json:"order_source" example:"member" enums:"guest,member,kiosk,partner,phone,catering"`
+	OrderType              string                   `json:"order_type" example:"takeaway" enums:"dine_in,takeaway,delivery"`
+	Priority               string                   `json:"priority" example:"normal" enums:"normal,rush"`
+	TableNumber            *string                  `json:"table_number,omitempty" example:"12"`
+	QueueNumber            *int                     `json:"queue_number,omitempty" example:"42"`
+	Subtotal               float64                  `json:"subtotal" example:"70000"`
+	PromoCode              *string                  `json:"promo_code,omitempty" example:"MATCHA20"`
+	Discount               float64                  `json:"discount,omitempty" example:"14000"`
+	ServiceCharge          float64                  `json:"service_charge,omitempty" example:"0"`
+	Tax                    float64                  `json:"tax" example:"5600"`
+	Total                  float64                  `json:"total" example:"61600"`
+	Currency               string                   `json:"currency" example:"IDR"`
+	TipAmount              float64                  `json:"tip_amount,omitempty" example:"5000"`
+	GiftCardAmount         float64                  `json:"gift_card_amount,omitempty" example:"0"`
+	PointsRedeemed         int                      `json:"points_redeemed,omitempty" example:"0"`
+	PointsAmount           float64                  `json:"points_amount,omitempty" example:"0"`
+	Promotions             []OrderPromotionResponse `json:"promotions,omitempty"`
+	DepositAmount          float64                  `json:"deposit_amount,omitempty" example:"0"`
+	DepositPaidAt          *string                  `json:"deposit_paid_at,omitempty" example:"2025-01-07T10:05:00Z"`
+	PaymentDue             *float64                 `json:"payment_due,omitempty" example:"61600"`
+	PriceAdjustmentPercent float64                  `json:"price_adjustment_percent,omitempty" example:"20"`
+	SourceFee              float64                  `json:"source_fee,omitempty" example:"5000"`
+	DeliveryFee            float64                  `json:"delivery_fee,omitempty" example:"0"`
+	DeliveryDiscount       float64                  `json:"delivery_discount,omitempty" example:"0"`
+	Notes                  *string                  `json:"notes,omitempty" example:"Please call when ready"`
+	Items                  []OrderItemResponse      `json:"items"`
+	User                   *UserSummary             `json:"user,omitempty"`
+	AssignedTo             *StaffSummary            `json:"assigned_to,omitempty"`
+	ScheduledFor           *string                  `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
+	PaymentExpiresAt       *string                  `json:"payment_expires_at,omitempty" example:"2025-01-07T10:30:00Z"`
+	PickupCode             *string                  `json:"pickup_code,omitempty" example:"MC-250107-001.K3J9D2F7QX"`
+	ShareToken             *string                  `json:"share_token,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt              string                   `json:"created_at" example:"2025-01-07T10:00:00Z"`
+	CompletedAt            *string                  `json:"completed_at,omitempty"`
+	FlaggedAt              *string                  `json:"flagged_at,omitempty" example:"2025-01-07T23:05:00Z"`
 }
 
 type OrderSuccessResponse struct {
@@ -269,6 +413,78 @@ type OrderSuccessResponse struct {
 	Data    OrderResponse `json:"data"`
 }
 
+type OrderStatusItem struct {
+	ProductName string `json:"product_name" example:"Matcha Latte"`
+	Quantity    int    `json:"quantity" example:"2"`
+}
+
+type OrderStatusResponse struct {
+	OrderNumber  string            `json:"order_number" example:"MC-250107-001"`
+	CustomerName string            `json:"customer_name" example:"John Doe"`
+	Status       string            `json:"status" example:"preparing" enums:"scheduled,pending,deposit_paid,preparing,ready,completed,cancelled"`
+	OrderType    string            `json:"order_type" example:"takeaway" enums:"dine_in,takeaway,delivery"`
+	TableNumber  *string           `json:"table_number,omitempty" example:"12"`
+	QueueNumber  *int              `json:"queue_number,omitempty" example:"42"`
+	Items        []OrderStatusItem `json:"items"`
+	ScheduledFor *string           `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00Z"`
+	CreatedAt    string            `json:"created_at" example:"2025-01-07T10:00:00Z"`
+	ExpiresAt    string            `json:"expires_at" example:"2025-01-08T10:00:00Z"`
+}
+
+type OrderStatusSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    OrderStatusResponse `json:"data"`
+}
+
+type ReorderSkippedItem struct {
+	ProductName string `json:"product_name" example:"Hojicha Latte"`
+	Quantity    int    `json:"quantity" example:"1"`
+	Reason      string `json:"reason" example:"unavailable" enums:"removed,unavailable,customization_unavailable,insufficient_stock"`
+}
+
+type ReorderResponse struct {
+	Order         OrderResponse        `json:"order"`
+	SkippedItems  []ReorderSkippedItem `json:"skipped_items"`
+	PreviousTotal float64              `json:"previous_total" example:"55000"`
+}
+
+type ReorderSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    ReorderResponse `json:"data"`
+}
+
+type TotalsDiscrepancy struct {
+	Field    string  `json:"field" example:"total" enums:"item_subtotal,subtotal,tax,total"`
+	ItemID   string  `json:"item_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Stored   float64 `json:"stored" example:"60500"`
+	Expected float64 `json:"expected" example:"59500"`
+}
+
+type OrderTotals struct {
+	Subtotal         float64 `json:"subtotal" example:"50000"`
+	Discount         float64 `json:"discount" example:"0"`
+	ServiceCharge    float64 `json:"service_charge" example:"0"`
+	Tax              float64 `json:"tax" example:"5000"`
+	SourceFee        float64 `json:"source_fee" example:"4500"`
+	DeliveryFee      float64 `json:"delivery_fee" example:"0"`
+	DeliveryDiscount float64 `json:"delivery_discount" example:"0"`
+	Total            float64 `json:"total" example:"59500"`
+}
+
+type VerifyTotalsResponse struct {
+	OrderID       string              `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber   string              `json:"order_number" example:"MC-250107-001"`
+	Stored        OrderTotals         `json:"stored"`
+	Recomputed    OrderTotals         `json:"recomputed"`
+	Consistent    bool                `json:"consistent" example:"false"`
+	Discrepancies []TotalsDiscrepancy `json:"discrepancies"`
+}
+
+type VerifyTotalsSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    VerifyTotalsResponse `json:"data"`
+}
+
 type OrderListResponse struct {
 	Orders []OrderResponse `json:"orders"`
 	Total  int64           `json:"total" example:"100"`
@@ -281,11 +497,155 @@ type OrdersSuccessResponse struct {
 	Data    OrderListResponse `json:"data"`
 }
 
+type StaffSummary struct {
+	ID       uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	FullName string    `json:"full_name" example:"Rina Barista"`
+}
+
+type KitchenQueueItem struct {
+	ProductName    string   `json:"product_name" example:"Matcha Latte"`
+	Quantity       int      `json:"quantity" example:"2"`
+	Customizations []string `json:"customizations,omitempty" example:"Milk: Oat Milk,Sweetness: Less Sugar"`
+	Notes          string   `json:"notes,omitempty" example:"Less ice"`
+}
+
+type KitchenQueueOrder struct {
+	ID             uuid.UUID          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber    string             `json:"order_number" example:"MC-250107-001"`
+	CustomerName   string             `json:"customer_name" example:"John Doe"`
+	OrderSource    string             `json:"order_source" example:"member"`
+	OrderType      string             `json:"order_type" example:"dine_in"`
+	TableNumber    string             `json:"table_number,omitempty" example:"12"`
+	QueueNumber    int                `json:"queue_number,omitempty" example:"42"`
+	Status         string             `json:"status" example:"preparing"`
+	Priority       string             `json:"priority" example:"rush" enums:"normal,rush"`
+	Notes          string             `json:"notes,omitempty" example:"Takeaway"`
+	ScheduledFor   string             `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
+	AssignedTo     *StaffSummary      `json:"assigned_to,omitempty"`
+	Items          []KitchenQueueItem `json:"items"`
+	CreatedAt      string             `json:"created_at" example:"2025-01-07T10:00:00+07:00"`
+	ElapsedSeconds int64              `json:"elapsed_seconds" example:"420"`
+}
+
+type KitchenQueueResponse struct {
+	Pending     []KitchenQueueOrder `json:"pending"`
+	Preparing   []KitchenQueueOrder `json:"preparing"`
+	Ready       []KitchenQueueOrder `json:"ready"`
+	GeneratedAt string              `json:"generated_at" example:"2025-01-07T10:07:00+07:00"`
+}
+
+type KitchenQueueSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    KitchenQueueResponse `json:"data"`
+}
+
+type BulkUpdateOrderStatusRequest struct {
+	OrderIDs []uuid.UUID `json:"order_ids"`
+	Status   string      `json:"status" example:"completed" enums:"completed,cancelled"`
+}
+
+type BulkOrderStatusResult struct {
+	OrderID     uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber string    `json:"order_number,omitempty" example:"MC-250107-001"`
+	Success     bool      `json:"success" example:"true"`
+	Status      string    `json:"status,omitempty" example:"completed"`
+	Error       string    `json:"error,omitempty" example:"invalid status transition"`
+}
+
+type BulkUpdateOrderStatusResponse struct {
+	Results   []BulkOrderStatusResult `json:"results"`
+	Succeeded int                     `json:"succeeded" example:"4"`
+	Failed    int                     `json:"failed" example:"1"`
+}
+
+type BulkUpdateOrderStatusSuccessResponse struct {
+	Success bool                          `json:"success" example:"true"`
+	Data    BulkUpdateOrderStatusResponse `json:"data"`
+}
+
 // Payment DTOs
+type CreatePaymentTokenRequest struct {
+	TipAmount *float64 `json:"tip_amount,omitempty" example:"5000"`
+}
+
 type PaymentTokenResponse struct {
 	PaymentID   uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Provider    string    `json:"provider" example:"midtrans" enums:"midtrans,stripe,xendit"`
 	Token       string    `json:"token" example:"66e4fa55-fdac-4ef9-91b5-733b97d1b862"`
 	RedirectURL string    `json:"redirect_url" example:"https://app.sandbox.midtrans.com/snap/v2/vtweb/..."`
+	Reused      bool      `json:"reused" example:"false"`
+}
+
+type QRISChargeResponse struct {
+	PaymentID  uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	QRString   string    `json:"qr_string" example:"00020101021226620014COM.GO-JEK.WWW011993600914..."`
+	QRImageURL string    `json:"qr_image_url,omitempty" example:"https://api.sandbox.midtrans.com/v2/qris/b0d0ab5a-0ea1-4a1e-8bda-9a6d7d7a1b7e/qr-code"`
+	Amount     float64   `json:"amount" example:"42500"`
+	ExpiresAt  string    `json:"expires_at" example:"2025-01-07T10:30:00+07:00"`
+	Reused     bool      `json:"reused" example:"false"`
+}
+
+type QRISChargeSuccessResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    QRISChargeResponse `json:"data"`
+}
+
+type PaymentStatusResponse struct {
+	PaymentID         uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransactionStatus string    `json:"transaction_status" example:"pending" enums:"pending,settlement,expire,cancel,deny,refund,partial_refund"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderStatus       string    `json:"order_status" example:"pending"`
+	ExpiresAt         *string   `json:"expires_at,omitempty" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type PaymentStatusSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    PaymentStatusResponse `json:"data"`
+}
+
+type PaymentLinkResponse struct {
+	ID          uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	OrderID     uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber string    `json:"order_number" example:"MC-250107-001"`
+	Amount      float64   `json:"amount" example:"42500"`
+	URL         string    `json:"url" example:"https://api.matchaciee.com/api/v1/pay/9f86d081884c7d659a2feaa0c55ad015"`
+	ExpiresAt   string    `json:"expires_at" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type PaymentLinkSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message,omitempty" example:"Payment link created"`
+	Data    PaymentLinkResponse `json:"data"`
+}
+
+type PaymentResponse struct {
+	ID                uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber       string    `json:"order_number" example:"MC-250107-001"`
+	Reference         string    `json:"reference" example:"MC-250107-001-1736240000"`
+	Method            string    `json:"method" example:"midtrans" enums:"midtrans,stripe,xendit,cash,wallet,gift_card,points"`
+	CheckoutChannel   *string   `json:"checkout_channel,omitempty" example:"qris" enums:"hosted,qris"`
+	PaymentType       *string   `json:"payment_type,omitempty" example:"qris"`
+	TransactionID     *string   `json:"transaction_id,omitempty" example:"9aed5972-5b6a-401e-894b-a32c91ed1a3a"`
+	TransactionStatus string    `json:"transaction_status" example:"settlement" enums:"pending,settlement,expire,cancel,deny,refund,partial_refund"`
+	GrossAmount       float64   `json:"gross_amount" example:"42500"`
+	GatewayFee        *float64  `json:"gateway_fee,omitempty" example:"297.5"`
+	TransactionTime   *string   `json:"transaction_time,omitempty" example:"2025-01-07T10:01:00+07:00"`
+	SettlementTime    *string   `json:"settlement_time,omitempty" example:"2025-01-07T10:01:30+07:00"`
+	ExpiresAt         *string   `json:"expires_at,omitempty" example:"2025-01-07T10:30:00+07:00"`
+	CreatedAt         string    `json:"created_at" example:"2025-01-07T10:00:00+07:00"`
+}
+
+type PaymentListResponse struct {
+	Payments []PaymentResponse `json:"payments"`
+	Total    int64             `json:"total" example:"100"`
+	Page     int               `json:"page" example:"1"`
+	Limit    int               `json:"limit" example:"20"`
+}
+
+type PaymentsSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    PaymentListResponse `json:"data"`
 }
 
 type PaymentSuccessResponse struct {
@@ -293,37 +653,1662 @@ type PaymentSuccessResponse struct {
 	Data    PaymentTokenResponse `json:"data"`
 }
 
-type MidtransWebhookRequest struct {
-	TransactionTime   string  `json:"transaction_time" example:"2025-01-07 10:00:00"`
-	TransactionStatus string  `json:"transaction_status" example:"settlement"`
-	TransactionID     string  `json:"transaction_id" example:"1234567890"`
-	StatusMessage     string  `json:"status_message" example:"Success"`
-	StatusCode        string  `json:"status_code" example:"200"`
-	SignatureKey      string  `json:"signature_key" example:"..."`
-	PaymentType       string  `json:"payment_type" example:"gopay"`
-	OrderID           string  `json:"order_id" example:"MC-250107-001-1234567890"`
-	MerchantID        string  `json:"merchant_id" example:"G123456789"`
-	GrossAmount       string  `json:"gross_amount" example:"77000.00"`
-	FraudStatus       string  `json:"fraud_status" example:"accept"`
-	Currency          string  `json:"currency" example:"IDR"`
-	SettlementTime    *string `json:"settlement_time,omitempty" example:"2025-01-07 10:05:00"`
+type CashPaymentRequest struct {
+	AmountTendered float64 `json:"amount_tendered" example:"50000"`
 }
 
-type WebhookSuccessResponse struct {
-	Status string `json:"status" example:"success"`
+type CashPaymentResponse struct {
+	PaymentID      uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID        uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber    string    `json:"order_number" example:"MC-250107-001"`
+	OrderStatus    string    `json:"order_status" example:"preparing"`
+	AmountDue      float64   `json:"amount_due" example:"41500"`
+	AmountTendered float64   `json:"amount_tendered" example:"50000"`
+	Change         float64   `json:"change" example:"8500"`
 }
 
-type WebhookErrorResponse struct {
-	Status  string `json:"status" example:"error"`
-	Message string `json:"message" example:"Error message"`
+type CashPaymentSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message,omitempty" example:"Cash payment recorded"`
+	Data    CashPaymentResponse `json:"data"`
 }
 
-// Generic message response
-type MessageResponse struct {
-	Message string `json:"message" example:"Operation completed successfully"`
+// Wallet DTOs
+type WalletTopUpRequest struct {
+	Amount float64 `json:"amount" example:"100000"`
 }
 
-type MessageSuccessResponse struct {
+type WalletTopUpResponse struct {
+	ID          uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	Reference   string    `json:"reference" example:"TOPUP-12-1736240000"`
+	Provider    string    `json:"provider" example:"midtrans" enums:"midtrans,stripe,xendit"`
+	Amount      float64   `json:"amount" example:"100000"`
+	RedirectURL string    `json:"redirect_url" example:"https://app.sandbox.midtrans.com/snap/v2/vtweb/..."`
+	ExpiresAt   string    `json:"expires_at" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type WalletTopUpSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message,omitempty" example:"Top-up started"`
+	Data    WalletTopUpResponse `json:"data"`
+}
+
+type WalletPaymentResponse struct {
+	PaymentID   uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID     uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber string    `json:"order_number" example:"MC-250107-001"`
+	OrderStatus string    `json:"order_status" example:"preparing"`
+	Amount      float64   `json:"amount" example:"42500"`
+	Balance     float64   `json:"balance" example:"57500"`
+}
+
+type WalletPaymentSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Message string                `json:"message,omitempty" example:"Order paid from wallet"`
+	Data    WalletPaymentResponse `json:"data"`
+}
+
+type WalletAdjustmentRequest struct {
+	Amount float64 `json:"amount" example:"15000"`
+	Note   string  `json:"note" example:"Goodwill credit for a late order"`
+}
+
+type WalletTransactionResponse struct {
+	ID           uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	Type         string    `json:"type" example:"payment" enums:"topup,payment,refund,adjustment"`
+	Amount       float64   `json:"amount" example:"-42500"`
+	BalanceAfter float64   `json:"balance_after" example:"57500"`
+	OrderID      *string   `json:"order_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber  *string   `json:"order_number,omitempty" example:"MC-250107-001"`
+	Note         *string   `json:"note,omitempty" example:"Goodwill credit for a late order"`
+	CreatedAt    string    `json:"created_at" example:"2025-01-07T10:15:00+07:00"`
+}
+
+type WalletTransactionSuccessResponse struct {
+	Success bool                      `json:"success" example:"true"`
+	Message string                    `json:"message,omitempty" example:"Wallet adjusted"`
+	Data    WalletTransactionResponse `json:"data"`
+}
+
+type WalletResponse struct {
+	UserID       uuid.UUID                   `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440006"`
+	Balance      float64                     `json:"balance" example:"57500"`
+	Transactions []WalletTransactionResponse `json:"transactions"`
+	Total        int64                       `json:"total" example:"2"`
+	Page         int                         `json:"page" example:"1"`
+	Limit        int                         `json:"limit" example:"20"`
+}
+
+type WalletSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    WalletResponse `json:"data"`
+}
+
+// Gift card DTOs
+type GiftCardPurchaseRequest struct {
+	Amount         float64 `json:"amount" example:"100000"`
+	RecipientName  *string `json:"recipient_name,omitempty" example:"Dewi"`
+	RecipientEmail *string `json:"recipient_email,omitempty" example:"dewi@example.com"`
+}
+
+type IssueGiftCardRequest struct {
+	Amount         float64 `json:"amount" example:"50000"`
+	RecipientName  *string `json:"recipient_name,omitempty" example:"Dewi"`
+	RecipientEmail *string `json:"recipient_email,omitempty" example:"dewi@example.com"`
+}
+
+type VoidGiftCardRequest struct {
+	Reason string `json:"reason" example:"Reported stolen"`
+}
+
+type RedeemGiftCardRequest struct {
+	Code   string   `json:"code" example:"GC-7KQM-X3TD-98WP"`
+	Amount *float64 `json:"amount,omitempty" example:"20000"`
+}
+
+type GiftCardRedemptionEntry struct {
+	ID          uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440008"`
+	OrderID     uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber string    `json:"order_number" example:"MC-250107-001"`
+	Amount      float64   `json:"amount" example:"20000"`
+	ReleasedAt  *string   `json:"released_at,omitempty" example:"2025-01-07T10:20:00+07:00"`
+	CreatedAt   string    `json:"created_at" example:"2025-01-07T10:05:00+07:00"`
+}
+
+type GiftCardResponse struct {
+	ID             uuid.UUID                 `json:"id" example:"550e8400-e29b-41d4-a716-446655440007"`
+	Code           string                    `json:"code" example:"GC-7KQM-X3TD-98WP"`
+	InitialAmount  float64                   `json:"initial_amount" example:"100000"`
+	Balance        float64                   `json:"balance" example:"80000"`
+	Status         string                    `json:"status" example:"active" enums:"pending,active,voided"`
+	RecipientName  *string                   `json:"recipient_name,omitempty" example:"Dewi"`
+	RecipientEmail *string                   `json:"recipient_email,omitempty" example:"dewi@example.com"`
+	Reference      *string                   `json:"reference,omitempty" example:"GIFT-12-1736240000"`
+	ActivatedAt    *string                   `json:"activated_at,omitempty" example:"2025-01-07T10:01:30+07:00"`
+	VoidedAt       *string                   `json:"voided_at,omitempty" example:"2025-01-08T09:00:00+07:00"`
+	VoidReason     *string                   `json:"void_reason,omitempty" example:"Reported stolen"`
+	Redemptions    []GiftCardRedemptionEntry `json:"redemptions,omitempty"`
+	CreatedAt      string                    `json:"created_at" example:"2025-01-07T10:00:00+07:00"`
+}
+
+type GiftCardSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message,omitempty" example:"Gift card issued"`
+	Data    GiftCardResponse `json:"data"`
+}
+
+type GiftCardListResponse struct {
+	GiftCards []GiftCardResponse `json:"gift_cards"`
+	Total     int64              `json:"total" example:"100"`
+	Page      int                `json:"page" example:"1"`
+	Limit     int                `json:"limit" example:"20"`
+}
+
+type GiftCardsSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    GiftCardListResponse `json:"data"`
+}
+
+type GiftCardPurchaseResponse struct {
+	GiftCard    GiftCardResponse `json:"gift_card"`
+	RedirectURL string           `json:"redirect_url" example:"https://app.sandbox.midtrans.com/snap/v2/vtweb/..."`
+	ExpiresAt   string           `json:"expires_at" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type GiftCardPurchaseSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Message string                   `json:"message,omitempty" example:"Gift card purchase started"`
+	Data    GiftCardPurchaseResponse `json:"data"`
+}
+
+type GiftCardRedemptionResponse struct {
+	ID          uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440008"`
+	OrderID     uuid.UUID  `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber string     `json:"order_number" example:"MC-250107-001"`
+	OrderStatus string     `json:"order_status" example:"pending"`
+	Code        string     `json:"code" example:"GC-7KQM-X3TD-98WP"`
+	Amount      float64    `json:"amount" example:"20000"`
+	CardBalance float64    `json:"card_balance" example:"80000"`
+	AmountDue   float64    `json:"amount_due" example:"22500"`
+	PaymentID   *uuid.UUID `json:"payment_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type GiftCardRedemptionSuccessResponse struct {
+	Success bool                       `json:"success" example:"true"`
+	Message string                     `json:"message,omitempty" example:"Gift card redeemed"`
+	Data    GiftCardRedemptionResponse `json:"data"`
+}
+
+// Voucher DTOs
+type IssueVouchersRequest struct {
+	UserIDs       []string `json:"user_ids,omitempty" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Segment       string   `json:"segment,omitempty" example:"lapsed" enums:"all,lapsed,new"`
+	InactiveDays  int      `json:"inactive_days,omitempty" example:"60"`
+	Reason        string   `json:"reason" example:"Sorry your order MC-250107-001 was late"`
+	DiscountType  string   `json:"discount_type" example:"fixed" enums:"percentage,fixed"`
+	DiscountValue float64  `json:"discount_value" example:"25000"`
+	MaxDiscount   *float64 `json:"max_discount,omitempty" example:"30000"`
+	MinSpend      float64  `json:"min_spend" example:"0"`
+	ExpiresAt     *string  `json:"expires_at,omitempty" example:"2025-02-07T23:59:59+07:00"`
+}
+
+type RevokeVoucherRequest struct {
+	Reason string `json:"reason" example:"Issued to the wrong member"`
+}
+
+type VoucherMember struct {
+	ID       uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	FullName string    `json:"full_name" example:"John Doe"`
+	Email    string    `json:"email" example:"john@example.com"`
+}
+
+type VoucherEventEntry struct {
+	Action      string     `json:"action" example:"redeemed" enums:"issued,redeemed,released,revoked"`
+	ActorName   *string    `json:"actor_name,omitempty" example:"John Doe"`
+	OrderID     *uuid.UUID `json:"order_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber *string    `json:"order_number,omitempty" example:"MC-250110-014"`
+	Note        *string    `json:"note,omitempty" example:"Sorry your order MC-250107-001 was late"`
+	CreatedAt   string     `json:"created_at" example:"2025-01-10T12:00:00+07:00"`
+}
+
+type VoucherResponse struct {
+	ID            uuid.UUID           `json:"id" example:"550e8400-e29b-41d4-a716-446655440009"`
+	Code          string              `json:"code" example:"VC-7KQM-X3TD"`
+	Reason        string              `json:"reason" example:"Sorry your order MC-250107-001 was late"`
+	DiscountType  string              `json:"discount_type" example:"fixed" enums:"percentage,fixed,free_item"`
+	DiscountValue float64             `json:"discount_value" example:"25000"`
+	MaxDiscount   *float64            `json:"max_discount,omitempty" example:"30000"`
+	MinSpend      float64             `json:"min_spend" example:"0"`
+	ExpiresAt     *string             `json:"expires_at,omitempty" example:"2025-02-07T23:59:59+07:00"`
+	Status        string              `json:"status" example:"redeemed" enums:"active,redeemed,revoked"`
+	Redeemable    bool                `json:"redeemable" example:"false"`
+	Member        *VoucherMember      `json:"member,omitempty"`
+	OrderID       *uuid.UUID          `json:"order_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber   *string             `json:"order_number,omitempty" example:"MC-250110-014"`
+	RedeemedAt    *string             `json:"redeemed_at,omitempty" example:"2025-01-10T12:00:00+07:00"`
+	RevokedAt     *string             `json:"revoked_at,omitempty" example:"2025-01-09T09:00:00+07:00"`
+	RevokeReason  *string             `json:"revoke_reason,omitempty" example:"Issued to the wrong member"`
+	Events        []VoucherEventEntry `json:"events,omitempty"`
+	CreatedAt     string              `json:"created_at" example:"2025-01-07T18:00:00+07:00"`
+}
+
+type VoucherSuccessResponse struct {
 	Success bool            `json:"success" example:"true"`
-	Data    MessageResponse `json:"data"`
+	Message string          `json:"message,omitempty" example:"Voucher revoked"`
+	Data    VoucherResponse `json:"data"`
+}
+
+type MyVouchersSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    []VoucherResponse `json:"data"`
+}
+
+type VoucherListResponse struct {
+	Vouchers []VoucherResponse `json:"vouchers"`
+	Total    int64             `json:"total" example:"100"`
+	Page     int               `json:"page" example:"1"`
+	Limit    int               `json:"limit" example:"20"`
+}
+
+type VouchersSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    VoucherListResponse `json:"data"`
+}
+
+type IssueVouchersResponse struct {
+	Issued   int               `json:"issued" example:"1"`
+	Vouchers []VoucherResponse `json:"vouchers"`
+}
+
+type IssueVouchersSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Message string                `json:"message,omitempty" example:"Vouchers issued"`
+	Data    IssueVouchersResponse `json:"data"`
+}
+
+// Campaign DTOs
+type CreateCampaignRequest struct {
+	Title        string  `json:"title" example:"We miss you"`
+	Message      string  `json:"message" example:"Your favourite matcha latte is waiting. Drop by this week!"`
+	Segment      string  `json:"segment" example:"lapsed" enums:"all,lapsed,new"`
+	InactiveDays int     `json:"inactive_days,omitempty" example:"30"`
+	SendAt       *string `json:"send_at,omitempty" example:"2025-01-10T09:00:00+07:00"`
+}
+
+type CampaignStats struct {
+	Recipients int64            `json:"recipients" example:"120"`
+	Pending    int64            `json:"pending" example:"20"`
+	Sent       int64            `json:"sent" example:"98"`
+	Failed     int64            `json:"failed" example:"2"`
+	Channels   map[string]int64 `json:"channels"`
+}
+
+type CampaignResponse struct {
+	ID           uuid.UUID      `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	Title        string         `json:"title" example:"We miss you"`
+	Message      string         `json:"message" example:"Your favourite matcha latte is waiting. Drop by this week!"`
+	Segment      string         `json:"segment" example:"lapsed" enums:"all,lapsed,new"`
+	InactiveDays int            `json:"inactive_days,omitempty" example:"30"`
+	Status       string         `json:"status" example:"sending" enums:"scheduled,sending,sent,cancelled"`
+	SendAt       string         `json:"send_at" example:"2025-01-10T09:00:00+07:00"`
+	CreatedBy    *string        `json:"created_by,omitempty" example:"John Doe"`
+	StartedAt    *string        `json:"started_at,omitempty" example:"2025-01-10T09:00:30+07:00"`
+	FinishedAt   *string        `json:"finished_at,omitempty" example:"2025-01-10T09:01:30+07:00"`
+	CancelledAt  *string        `json:"cancelled_at,omitempty" example:"2025-01-10T09:01:00+07:00"`
+	Stats        *CampaignStats `json:"stats,omitempty"`
+	CreatedAt    string         `json:"created_at" example:"2025-01-09T17:00:00+07:00"`
+}
+
+type CampaignSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message,omitempty" example:"Campaign scheduled"`
+	Data    CampaignResponse `json:"data"`
+}
+
+type CampaignListResponse struct {
+	Campaigns []CampaignResponse `json:"campaigns"`
+	Total     int64              `json:"total" example:"100"`
+	Page      int                `json:"page" example:"1"`
+	Limit     int                `json:"limit" example:"20"`
+}
+
+type CampaignsSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    CampaignListResponse `json:"data"`
+}
+
+// Reward DTOs
+type RewardRequest struct {
+	Name          string   `json:"name" example:"Free Matcha Latte"`
+	Description   *string  `json:"description,omitempty" example:"Any matcha drink, any size"`
+	PointsCost    int      `json:"points_cost" example:"500"`
+	DiscountType  string   `json:"discount_type" example:"free_item" enums:"percentage,fixed,free_item"`
+	DiscountValue float64  `json:"discount_value" example:"100"`
+	MaxDiscount   *float64 `json:"max_discount,omitempty" example:"45000"`
+	MinSpend      float64  `json:"min_spend" example:"0"`
+	CategoryID    *string  `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	ValidDays     int      `json:"valid_days" example:"30"`
+	Stock         *int     `json:"stock,omitempty" example:"50"`
+	IsActive      *bool    `json:"is_active,omitempty" example:"true"`
+}
+
+type RewardCategory struct {
+	ID   uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Name string    `json:"name" example:"Matcha Drinks"`
+}
+
+type RewardResponse struct {
+	ID            uuid.UUID       `json:"id" example:"550e8400-e29b-41d4-a716-446655440011"`
+	Name          string          `json:"name" example:"Free Matcha Latte"`
+	Description   *string         `json:"description,omitempty" example:"Any matcha drink, any size"`
+	PointsCost    int             `json:"points_cost" example:"500"`
+	DiscountType  string          `json:"discount_type" example:"free_item" enums:"percentage,fixed,free_item"`
+	DiscountValue float64         `json:"discount_value" example:"100"`
+	MaxDiscount   *float64        `json:"max_discount,omitempty" example:"45000"`
+	MinSpend      float64         `json:"min_spend" example:"0"`
+	Category      *RewardCategory `json:"category,omitempty"`
+	ValidDays     int             `json:"valid_days" example:"30"`
+	Stock         *int            `json:"stock,omitempty" example:"50"`
+	IsActive      bool            `json:"is_active" example:"true"`
+	Redemptions   int64           `json:"redemptions" example:"12"`
+	CreatedAt     string          `json:"created_at" example:"2025-01-01T10:00:00+07:00"`
+	UpdatedAt     string          `json:"updated_at" example:"2025-01-01T10:00:00+07:00"`
+}
+
+type RewardSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    RewardResponse `json:"data"`
+}
+
+type RewardListSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    []RewardResponse `json:"data"`
+}
+
+type CatalogReward struct {
+	ID            uuid.UUID       `json:"id" example:"550e8400-e29b-41d4-a716-446655440011"`
+	Name          string          `json:"name" example:"Free Matcha Latte"`
+	Description   *string         `json:"description,omitempty" example:"Any matcha drink, any size"`
+	PointsCost    int             `json:"points_cost" example:"500"`
+	DiscountType  string          `json:"discount_type" example:"free_item" enums:"percentage,fixed,free_item"`
+	DiscountValue float64         `json:"discount_value" example:"100"`
+	MaxDiscount   *float64        `json:"max_discount,omitempty" example:"45000"`
+	MinSpend      float64         `json:"min_spend" example:"0"`
+	Category      *RewardCategory `json:"category,omitempty"`
+	ValidDays     int             `json:"valid_days" example:"30"`
+	InStock       bool            `json:"in_stock" example:"true"`
+	Redeemable    bool            `json:"redeemable" example:"true"`
+}
+
+type RewardCatalogResponse struct {
+	PointsBalance int             `json:"points_balance" example:"820"`
+	Rewards       []CatalogReward `json:"rewards"`
+}
+
+type RewardCatalogSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    RewardCatalogResponse `json:"data"`
+}
+
+type RewardRedemptionResponse struct {
+	Voucher       VoucherResponse `json:"voucher"`
+	PointsSpent   int             `json:"points_spent" example:"500"`
+	PointsBalance int             `json:"points_balance" example:"320"`
+}
+
+type RewardRedemptionSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Message string                   `json:"message,omitempty" example:"Reward redeemed"`
+	Data    RewardRedemptionResponse `json:"data"`
+}
+
+// Customer DTOs
+type CustomerSegmentEntry struct {
+	ID               uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	FullName         string    `json:"full_name" example:"John Doe"`
+	Email            string    `json:"email" example:"john@example.com"`
+	Phone            *string   `json:"phone,omitempty" example:"+628123456789"`
+	JoinedAt         string    `json:"joined_at" example:"2024-06-01T10:00:00+07:00"`
+	OrderCount       int       `json:"order_count" example:"14"`
+	TotalSpend       float64   `json:"total_spend" example:"672000"`
+	LastOrderAt      *string   `json:"last_order_at,omitempty" example:"2024-11-20T08:15:00+07:00"`
+	FavoriteCategory *string   `json:"favorite_category,omitempty" example:"Matcha Drinks"`
+}
+
+type CustomerSegmentExport struct {
+	Segment          string                 `json:"segment" example:"lapsed" enums:"all,lapsed,new"`
+	StatsRefreshedAt *string                `json:"stats_refreshed_at,omitempty" example:"2025-01-10T09:00:00+07:00"`
+	Count            int                    `json:"count" example:"1"`
+	Customers        []CustomerSegmentEntry `json:"customers"`
+}
+
+type CustomerSegmentExportSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    CustomerSegmentExport `json:"data"`
+}
+
+type CustomerValue struct {
+	Rank              int      `json:"rank" example:"1"`
+	ID                string   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FullName          string   `json:"full_name" example:"Dewi Lestari"`
+	Email             string   `json:"email" example:"dewi@example.com"`
+	Phone             *string  `json:"phone,omitempty" example:"+6281234567890"`
+	JoinedAt          string   `json:"joined_at" example:"2024-03-02T09:15:00+07:00"`
+	OrderCount        int      `json:"order_count" example:"48"`
+	TotalSpend        float64  `json:"total_spend" example:"2640000"`
+	AverageOrderValue float64  `json:"average_order_value" example:"55000"`
+	FirstOrderAt      string   `json:"first_order_at" example:"2024-03-02T09:40:00+07:00"`
+	LastOrderAt       string   `json:"last_order_at" example:"2025-01-20T08:05:00+07:00"`
+	DaysBetweenOrders *float64 `json:"days_between_orders,omitempty" example:"6.97"`
+}
+
+type CustomerValueReport struct {
+	SortBy           string          `json:"sort_by" example:"spend" enums:"spend,orders,recent"`
+	StatsRefreshedAt *string         `json:"stats_refreshed_at,omitempty" example:"2025-01-21T10:00:00+07:00"`
+	Customers        []CustomerValue `json:"customers"`
+	Total            int64           `json:"total" example:"1240"`
+	Page             int             `json:"page" example:"1"`
+	Limit            int             `json:"limit" example:"20"`
+}
+
+type CustomerValueReportSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    CustomerValueReport `json:"data"`
+}
+
+// Loyalty DTOs
+type RedeemPointsRequest struct {
+	Points int `json:"points" example:"200"`
+}
+
+type PointsRedemptionResponse struct {
+	OrderID       uuid.UUID  `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber   string     `json:"order_number" example:"MC-250107-001"`
+	OrderStatus   string     `json:"order_status" example:"pending"`
+	Points        int        `json:"points" example:"200"`
+	Amount        float64    `json:"amount" example:"20000"`
+	PointsBalance int        `json:"points_balance" example:"150"`
+	AmountDue     float64    `json:"amount_due" example:"22500"`
+	PaymentID     *uuid.UUID `json:"payment_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type PointsRedemptionSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Message string                   `json:"message,omitempty" example:"Points redeemed"`
+	Data    PointsRedemptionResponse `json:"data"`
+}
+
+type PointTransactionResponse struct {
+	ID           uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440009"`
+	Type         string    `json:"type" example:"earn" enums:"earn,redeem,refund,expire"`
+	Points       int       `json:"points" example:"4"`
+	BalanceAfter int       `json:"balance_after" example:"350"`
+	OrderID      *string   `json:"order_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber  *string   `json:"order_number,omitempty" example:"MC-250107-001"`
+	Note         *string   `json:"note,omitempty"`
+	CreatedAt    string    `json:"created_at" example:"2025-01-07T10:45:00+07:00"`
+}
+
+type PointsExpiryResponse struct {
+	Points    int    `json:"points" example:"50"`
+	ExpiresAt string `json:"expires_at" example:"2026-01-09T10:30:00+07:00"`
+	Soon      bool   `json:"soon" example:"true"`
+}
+
+type PointsResponse struct {
+	UserID       uuid.UUID                  `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440006"`
+	Balance      int                        `json:"balance" example:"350"`
+	PointValue   float64                    `json:"point_value" example:"100"`
+	BalanceValue float64                    `json:"balance_value" example:"35000"`
+	Expiring     []PointsExpiryResponse     `json:"expiring,omitempty"`
+	Transactions []PointTransactionResponse `json:"transactions"`
+	Total        int64                      `json:"total" example:"12"`
+	Page         int                        `json:"page" example:"1"`
+	Limit        int                        `json:"limit" example:"20"`
+}
+
+type PointsSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    PointsResponse `json:"data"`
+}
+
+type StampCardResponse struct {
+	Enabled   bool              `json:"enabled" example:"true"`
+	Stamps    int               `json:"stamps" example:"7"`
+	Goal      int               `json:"goal" example:"10"`
+	Remaining int               `json:"remaining" example:"3"`
+	Rewards   []VoucherResponse `json:"rewards"`
+}
+
+type StampCardSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    StampCardResponse `json:"data"`
+}
+
+type RefundPaymentRequest struct {
+	Amount *float64 `json:"amount,omitempty" example:"18000"`
+	Reason string   `json:"reason" example:"Drink spilled before pickup"`
+}
+
+type RefundResponse struct {
+	ID                uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	PaymentID         uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber       string    `json:"order_number" example:"MC-250107-001"`
+	OrderStatus       string    `json:"order_status" example:"cancelled"`
+	TransactionStatus string    `json:"transaction_status" example:"refund"`
+	Amount            float64   `json:"amount" example:"42500"`
+	RefundableBalance float64   `json:"refundable_balance" example:"0"`
+	Reason            string    `json:"reason" example:"Drink spilled before pickup"`
+	CreatedAt         string    `json:"created_at" example:"2025-01-07T10:15:00+07:00"`
+}
+
+type RefundSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Message string         `json:"message,omitempty" example:"Payment refunded"`
+	Data    RefundResponse `json:"data"`
+}
+
+type PaymentVerificationResponse struct {
+	PaymentID         uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber       string    `json:"order_number" example:"MC-250107-001"`
+	PreviousStatus    *string   `json:"previous_status,omitempty" example:"pending"`
+	TransactionStatus string    `json:"transaction_status" example:"settlement"`
+	OrderStatus       string    `json:"order_status" example:"preparing"`
+	Changed           bool      `json:"changed" example:"true"`
+}
+
+type PaymentVerificationSuccessResponse struct {
+	Success bool                        `json:"success" example:"true"`
+	Message string                      `json:"message,omitempty" example:"Payment verified"`
+	Data    PaymentVerificationResponse `json:"data"`
+}
+
+type PaymentCancellationResponse struct {
+	PaymentID         uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber       string    `json:"order_number" example:"MC-250107-001"`
+	TransactionStatus string    `json:"transaction_status" example:"cancel"`
+	OrderStatus       string    `json:"order_status" example:"cancelled"`
+}
+
+type PaymentCancellationSuccessResponse struct {
+	Success bool                        `json:"success" example:"true"`
+	Message string                      `json:"message,omitempty" example:"Payment cancelled"`
+	Data    PaymentCancellationResponse `json:"data"`
+}
+
+type SimulatePaymentRequest struct {
+	Status string `json:"status" example:"settlement" enums:"settlement,cancel,expire,deny"`
+}
+
+type PaymentSimulationResponse struct {
+	PaymentID         uuid.UUID `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID           uuid.UUID `json:"order_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OrderNumber       string    `json:"order_number" example:"MC-250107-001"`
+	TransactionStatus string    `json:"transaction_status" example:"settlement"`
+	OrderStatus       string    `json:"order_status" example:"preparing"`
+}
+
+type PaymentSimulationSuccessResponse struct {
+	Success bool                      `json:"success" example:"true"`
+	Message string                    `json:"message,omitempty" example:"Notification processed"`
+	Data    PaymentSimulationResponse `json:"data"`
+}
+
+type MidtransWebhookRequest struct {
+	TransactionTime   string           `json:"transaction_time" example:"2025-01-07 10:00:00"`
+	TransactionStatus string           `json:"transaction_status" example:"settlement"`
+	TransactionID     string           `json:"transaction_id" example:"1234567890"`
+	StatusMessage     string           `json:"status_message" example:"Success"`
+	StatusCode        string           `json:"status_code" example:"200"`
+	SignatureKey      string           `json:"signature_key" example:"..."`
+	PaymentType       string           `json:"payment_type" example:"gopay"`
+	OrderID           string           `json:"order_id" example:"MC-250107-001-1234567890"`
+	MerchantID        string           `json:"merchant_id" example:"G123456789"`
+	GrossAmount       string           `json:"gross_amount" example:"77000.00"`
+	FraudStatus       string           `json:"fraud_status" example:"accept"`
+	Currency          string           `json:"currency" example:"IDR"`
+	SettlementTime    *string          `json:"settlement_time,omitempty" example:"2025-01-07 10:05:00"`
+	Refunds           []MidtransRefund `json:"refunds,omitempty"`
+}
+
+type MidtransRefund struct {
+	RefundKey    string `json:"refund_key" example:"RF-550e8400-e29b-41d4-a716-446655440002"`
+	RefundAmount string `json:"refund_amount" example:"42500.00"`
+	Reason       string `json:"reason" example:"Drink spilled before pickup"`
+	CreatedAt    string `json:"created_at" example:"2025-01-07 10:15:00"`
+}
+
+type WebhookEventResponse struct {
+	ID          uuid.UUID         `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Provider    string            `json:"provider" example:"midtrans"`
+	Headers     map[string]string `json:"headers"`
+	Body        string            `json:"body" example:"{\"order_id\":\"MC-250107-001-1736240000\",\"transaction_status\":\"settlement\"}"`
+	Status      string            `json:"status" example:"failed"`
+	Error       *string           `json:"error,omitempty" example:"payment not found"`
+	Attempts    int               `json:"attempts" example:"1"`
+	ProcessedAt *string           `json:"processed_at,omitempty" example:"2025-01-07T10:05:01+07:00"`
+	ReceivedAt  string            `json:"received_at" example:"2025-01-07T10:05:00+07:00"`
+}
+
+type WebhookEventSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message,omitempty" example:"Webhook event replayed"`
+	Data    WebhookEventResponse `json:"data"`
+}
+
+type WebhookEventListResponse struct {
+	Events []WebhookEventResponse `json:"events"`
+	Total  int64                  `json:"total" example:"42"`
+	Page   int                    `json:"page" example:"1"`
+	Limit  int                    `json:"limit" example:"20"`
+}
+
+type WebhookEventsSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Data    WebhookEventListResponse `json:"data"`
+}
+
+type WebhookSuccessResponse struct {
+	Status string `json:"status" example:"success"`
+}
+
+type WebhookErrorResponse struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"Error message"`
+}
+
+// Generic message response
+type MessageResponse struct {
+	Message string `json:"message" example:"Operation completed successfully"`
+}
+
+type MessageSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    MessageResponse `json:"data"`
+}
+
+// Menu DTOs
+type MenuCustomization struct {
+	ID                string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CustomizationType string  `json:"customization_type" example:"milk"`
+	OptionName        string  `json:"option_name" example:"Oat Milk"`
+	PriceModifier     float64 `json:"price_modifier" example:"5000"`
+	DisplayOrder      int     `json:"display_order" example:"1"`
+}
+
+type MenuProduct struct {
+	ID              string              `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name            string              `json:"name" example:"Matcha Latte"`
+	Slug            string              `json:"slug" example:"matcha-latte"`
+	Description     string              `json:"description,omitempty" example:"Ceremonial grade matcha with steamed milk"`
+	BasePrice       float64             `json:"base_price" example:"35000"`
+	PreparationTime int                 `json:"preparation_time" example:"5"`
+	DisplayOrder    int                 `json:"display_order" example:"1"`
+	IsCustomizable  bool                `json:"is_customizable" example:"true"`
+	SoldOut         bool                `json:"sold_out" example:"false"`
+	ImageURL        string              `json:"image_url,omitempty" example:"https://cdn.matchaciee.com/matcha-latte.jpg"`
+	Customizations  []MenuCustomization `json:"customizations,omitempty"`
+}
+
+type MenuCategory struct {
+	ID           string        `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentID     string        `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Name         string        `json:"name" example:"Matcha Drinks"`
+	Slug         string        `json:"slug" example:"matcha-drinks"`
+	Description  string        `json:"description,omitempty" example:"Our signature matcha drinks"`
+	ImageURL     string        `json:"image_url,omitempty" example:"https://cdn.matchaciee.com/matcha-drinks.jpg"`
+	DisplayOrder int           `json:"display_order" example:"1"`
+	Products     []MenuProduct `json:"products"`
+}
+
+type MenuResponse struct {
+	Version       string         `json:"version" example:"42"`
+	UpdatedAt     string         `json:"updated_at" example:"2025-01-07T10:00:00Z"`
+	Categories    []MenuCategory `json:"categories"`
+	Uncategorized []MenuProduct  `json:"uncategorized,omitempty"`
+}
+
+type MenuSuccessResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Data    MenuResponse `json:"data"`
+}
+
+// Pricing DTOs
+type UpdateSourcePricingRequest struct {
+	PriceAdjustmentPercent float64 `json:"price_adjustment_percent" example:"20"`
+	FlatFee                float64 `json:"flat_fee" example:"5000"`
+}
+
+type SourcePricingResponse struct {
+	OrderSource            string  `json:"order_source" example:"partner"`
+	PriceAdjustmentPercent float64 `json:"price_adjustment_percent" example:"20"`
+	FlatFee                float64 `json:"flat_fee" example:"5000"`
+}
+
+type SourcePricingSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    SourcePricingResponse `json:"data"`
+}
+
+type SourcePricingListSuccessResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    []SourcePricingResponse `json:"data"`
+}
+
+// Promotion DTOs
+type PromotionRequest struct {
+	Code             string   `json:"code" example:"MATCHA20"`
+	Name             *string  `json:"name,omitempty" example:"Matcha Month"`
+	Description      *string  `json:"description,omitempty" example:"20% off all matcha drinks"`
+	DiscountType     string   `json:"discount_type" example:"percentage" enums:"percentage,fixed,free_delivery"`
+	DiscountValue    float64  `json:"discount_value" example:"20"`
+	MaxDiscount      *float64 `json:"max_discount,omitempty" example:"25000"`
+	MinSpend         float64  `json:"min_spend" example:"50000"`
+	UsageLimit       *int     `json:"usage_limit,omitempty" example:"500"`
+	PerCustomerLimit *int     `json:"per_customer_limit,omitempty" example:"1"`
+	StartsAt         *string  `json:"starts_at,omitempty" example:"2025-02-01T00:00:00+07:00"`
+	ExpiresAt        *string  `json:"expires_at,omitempty" example:"2025-03-01T00:00:00+07:00"`
+	Days             []string `json:"days,omitempty" example:"mon,tue,wed,thu,fri" enums:"sun,mon,tue,wed,thu,fri,sat"`
+	StartTime        *string  `json:"start_time,omitempty" example:"14:00"`
+	EndTime          *string  `json:"end_time,omitempty" example:"16:00"`
+	IsActive         *bool    `json:"is_active,omitempty" example:"true"`
+	Automatic        bool     `json:"automatic" example:"false"`
+	Stackable        bool     `json:"stackable" example:"false"`
+	ProductIDs       []string `json:"product_ids,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	CategoryIDs      []string `json:"category_ids,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+type PromotionScopeItem struct {
+	ID   string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name string `json:"name" example:"Matcha Drinks"`
+}
+
+type PromotionResponse struct {
+	ID               string               `json:"id" example:"9b2d4f60-8c1e-4a7b-b5d3-2f6e8a0c1d4e"`
+	Code             string               `json:"code" example:"MATCHA20"`
+	Name             *string              `json:"name,omitempty" example:"Matcha Month"`
+	Description      *string              `json:"description,omitempty" example:"20% off all matcha drinks"`
+	DiscountType     string               `json:"discount_type" example:"percentage"`
+	DiscountValue    float64              `json:"discount_value" example:"20"`
+	MaxDiscount      *float64             `json:"max_discount,omitempty" example:"25000"`
+	MinSpend         float64              `json:"min_spend" example:"50000"`
+	UsageLimit       *int                 `json:"usage_limit,omitempty" example:"500"`
+	PerCustomerLimit *int                 `json:"per_customer_limit,omitempty" example:"1"`
+	Redemptions      int64                `json:"redemptions" example:"42"`
+	StartsAt         *string              `json:"starts_at,omitempty" example:"2025-02-01T00:00:00+07:00"`
+	ExpiresAt        *string              `json:"expires_at,omitempty" example:"2025-03-01T00:00:00+07:00"`
+	Days             []string             `json:"days" example:"mon,tue,wed,thu,fri"`
+	StartTime        *string              `json:"start_time,omitempty" example:"14:00"`
+	EndTime          *string              `json:"end_time,omitempty" example:"16:00"`
+	IsActive         bool                 `json:"is_active" example:"true"`
+	Automatic        bool                 `json:"automatic" example:"false"`
+	Stackable        bool                 `json:"stackable" example:"false"`
+	Products         []PromotionScopeItem `json:"products"`
+	Categories       []PromotionScopeItem `json:"categories"`
+	CreatedAt        string               `json:"created_at" example:"2025-01-20T09:00:00Z"`
+	UpdatedAt        string               `json:"updated_at" example:"2025-01-20T09:00:00Z"`
+}
+
+type PromotionSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    PromotionResponse `json:"data"`
+}
+
+type PromotionListSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    []PromotionResponse `json:"data"`
+}
+
+type PromotionStatsResponse struct {
+	PromotionID       string  `json:"promotion_id" example:"9b2d4f60-8c1e-4a7b-b5d3-2f6e8a0c1d4e"`
+	Code              string  `json:"code" example:"MATCHA20"`
+	Redemptions       int64   `json:"redemptions" example:"42"`
+	Customers         int64   `json:"customers" example:"31"`
+	DiscountGiven     float64 `json:"discount_given" example:"630000"`
+	Revenue           float64 `json:"revenue" example:"3864000"`
+	AverageOrderValue float64 `json:"average_order_value" example:"92000"`
+	FirstRedeemedAt   *string `json:"first_redeemed_at,omitempty" example:"2025-02-01T08:12:00Z"`
+	LastRedeemedAt    *string `json:"last_redeemed_at,omitempty" example:"2025-02-27T17:45:00Z"`
+}
+
+type PromotionStatsSuccessResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Data    PromotionStatsResponse `json:"data"`
+}
+
+// Settings DTOs
+type ReceiptSettings struct {
+	HeaderText   string `json:"header_text" example:"Matchaciee\nJl. Ganesha No. 10, Bandung"`
+	FooterText   string `json:"footer_text" example:"Thank you for your order!"`
+	LogoURL      string `json:"logo_url,omitempty" example:"https://cdn.matchaciee.com/logo.png"`
+	TaxID        string `json:"tax_id,omitempty" example:"01.234.567.8-901.000"`
+	PromoMessage string `json:"promo_message,omitempty" example:"Buy 5 lattes, get 1 free!"`
+	PaperWidth   int    `json:"paper_width" example:"32" enums:"32,48"`
+}
+
+type ReceiptSettingsSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    ReceiptSettings `json:"data"`
+}
+
+type NotificationSettings struct {
+	EmailReceipts      bool `json:"email_receipts" example:"true"`
+	ReadyNotifications bool `json:"ready_notifications" example:"true"`
+}
+
+type NotificationSettingsSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    NotificationSettings `json:"data"`
+}
+
+type QRCodeSettings struct {
+	ForegroundColor string `json:"foreground_color" example:"#2F5D3A"`
+	BackgroundColor string `json:"background_color" example:"#FFFFFF"`
+}
+
+type QRCodeSettingsSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    QRCodeSettings `json:"data"`
+}
+
+type TimeslotSettings struct {
+	MaxOrders int `json:"max_orders" example:"12"`
+	MaxDrinks int `json:"max_drinks" example:"30"`
+}
+
+type TimeslotSettingsSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    TimeslotSettings `json:"data"`
+}
+
+type PaymentSettings struct {
+	EnabledPayments []string `json:"enabled_payments" example:"other_qris,gopay,shopeepay,bank_transfer"`
+}
+
+type PaymentSettingsSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    PaymentSettings `json:"data"`
+}
+
+type LoyaltySettings struct {
+	SpendPerPoint     float64 `json:"spend_per_point" example:"10000"`
+	PointValue        float64 `json:"point_value" example:"100"`
+	ExpiryMonths      int     `json:"expiry_months" example:"12"`
+	ExpiryWarningDays int     `json:"expiry_warning_days" example:"30"`
+}
+
+type LoyaltySettingsSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    LoyaltySettings `json:"data"`
+}
+
+type CurrencySettings struct {
+	Code     string `json:"code" example:"IDR"`
+	Symbol   string `json:"symbol" example:"Rp"`
+	Decimals int    `json:"decimals" example:"0"`
+}
+
+type CurrencySettingsSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    CurrencySettings `json:"data"`
+}
+
+type DepositSettings struct {
+	Percent  float64 `json:"percent" example:"50"`
+	MinTotal float64 `json:"min_total" example:"1000000"`
+}
+
+type DepositSettingsSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    DepositSettings `json:"data"`
+}
+
+type StampSettings struct {
+	Goal            int        `json:"goal" example:"10"`
+	CategoryID      *uuid.UUID `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	RewardMaxValue  float64    `json:"reward_max_value" example:"45000"`
+	RewardValidDays int        `json:"reward_valid_days" example:"30"`
+}
+
+type StampSettingsSuccessResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    StampSettings `json:"data"`
+}
+
+type Timeslot struct {
+	StartsAt string `json:"starts_at" example:"2025-01-07T10:15:00+07:00"`
+	EndsAt   string `json:"ends_at" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type TimeslotFullData struct {
+	NextAvailable []Timeslot `json:"next_available"`
+}
+
+// TimeslotFullErrorResponse is a conflict; data is only set when the pickup
+// timeslot is full
+type TimeslotFullErrorResponse struct {
+	Success bool             `json:"success" example:"false"`
+	Error   string           `json:"error" example:"Pickup timeslot is fully booked"`
+	Data    TimeslotFullData `json:"data"`
+}
+
+// Table DTOs
+type TableRequest struct {
+	Number   string `json:"number" example:"12"`
+	IsActive *bool  `json:"is_active,omitempty" example:"true"`
+}
+
+type TableResponse struct {
+	ID        string `json:"id" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Number    string `json:"number" example:"12"`
+	IsActive  bool   `json:"is_active" example:"true"`
+	URL       string `json:"url" example:"https://matchaciee.com/menu?table=9f86d081884c7d659a2feaa0c55ad015"`
+	CreatedAt string `json:"created_at" example:"2025-01-07T10:30:00Z"`
+	UpdatedAt string `json:"updated_at" example:"2025-01-07T10:30:00Z"`
+}
+
+type TableSuccessResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    TableResponse `json:"data"`
+}
+
+type TableListSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    []TableResponse `json:"data"`
+}
+
+type ScannedTableResponse struct {
+	ID     string `json:"id" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Number string `json:"number" example:"12"`
+}
+
+type ScannedTableSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    ScannedTableResponse `json:"data"`
+}
+
+// Kiosk DTOs
+type KioskRequest struct {
+	Name     string `json:"name" example:"Front entrance"`
+	IsActive *bool  `json:"is_active,omitempty" example:"true"`
+}
+
+type KioskHeartbeatRequest struct {
+	AppVersion *string `json:"app_version,omitempty" example:"2.4.1"`
+}
+
+type KioskResponse struct {
+	ID         string  `json:"id" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Name       string  `json:"name" example:"Front entrance"`
+	IsActive   bool    `json:"is_active" example:"true"`
+	Online     bool    `json:"online" example:"true"`
+	LastSeenAt *string `json:"last_seen_at,omitempty" example:"2025-01-07T10:30:00Z"`
+	LastIP     *string `json:"last_ip,omitempty" example:"192.168.1.20"`
+	AppVersion *string `json:"app_version,omitempty" example:"2.4.1"`
+	APIKey     string  `json:"api_key,omitempty" example:"4f1c9e0b7a2d4c6e8f0a1b3c5d7e9f2a4b6c8d0e1f3a5b7c9d2e4f6a8b0c1d3e"`
+	CreatedAt  string  `json:"created_at" example:"2025-01-07T10:30:00Z"`
+	UpdatedAt  string  `json:"updated_at" example:"2025-01-07T10:30:00Z"`
+}
+
+type KioskSuccessResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    KioskResponse `json:"data"`
+}
+
+type KioskListSuccessResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    []KioskResponse `json:"data"`
+}
+
+// Daily close-out DTOs
+type CloseDayRequest struct {
+	BusinessDate string `json:"business_date,omitempty" example:"2025-01-07"`
+}
+
+type FlaggedOrder struct {
+	ID          string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber string  `json:"order_number" example:"MC-250107-042"`
+	Status      string  `json:"status" example:"preparing"`
+	Total       float64 `json:"total" example:"49500"`
+	CreatedAt   string  `json:"created_at" example:"2025-01-07T21:45:00Z"`
+}
+
+type DailySummaryResponse struct {
+	ID              string         `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	BusinessDate    string         `json:"business_date" example:"2025-01-07"`
+	PeriodStart     string         `json:"period_start" example:"2025-01-07T00:00:00+07:00"`
+	PeriodEnd       string         `json:"period_end" example:"2025-01-08T00:00:00+07:00"`
+	OrderCount      int            `json:"order_count" example:"58"`
+	CompletedCount  int            `json:"completed_count" example:"52"`
+	CancelledCount  int            `json:"cancelled_count" example:"4"`
+	IncompleteCount int            `json:"incomplete_count" example:"2"`
+	PaidCount       int            `json:"paid_count" example:"53"`
+	Subtotal        float64        `json:"subtotal" example:"2385000"`
+	Discount        float64        `json:"discount" example:"45000"`
+	ServiceCharge   float64        `json:"service_charge" example:"0"`
+	Tax             float64        `json:"tax" example:"234000"`
+	TipAmount       float64        `json:"tip_amount" example:"60000"`
+	Total           float64        `json:"total" example:"2574000"`
+	CashAmount      float64        `json:"cash_amount" example:"640000"`
+	GatewayAmount   float64        `json:"gateway_amount" example:"1790000"`
+	StoredValue     float64        `json:"stored_value_amount" example:"144000"`
+	RefundCount     int            `json:"refund_count" example:"1"`
+	RefundAmount    float64        `json:"refund_amount" example:"38500"`
+	CancelledAmount float64        `json:"cancelled_amount" example:"132000"`
+	Closed          bool           `json:"closed" example:"true"`
+	ClosedBy        *StaffSummary  `json:"closed_by,omitempty"`
+	ClosedAt        string         `json:"closed_at,omitempty" example:"2025-01-07T23:05:00+07:00"`
+	FlaggedOrders   []FlaggedOrder `json:"flagged_orders,omitempty"`
+}
+
+type DailySummarySuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    DailySummaryResponse `json:"data"`
+}
+
+// QR code DTOs
+type GenerateQRCodeRequest struct {
+	Target  string `json:"target" example:"table" enums:"menu,table,payment"`
+	TableID string `json:"table_id,omitempty" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	OrderID string `json:"order_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Format  string `json:"format,omitempty" example:"png" enums:"png,svg"`
+	Size    int    `json:"size,omitempty" example:"512"`
+}
+
+// Media DTOs
+type MediaFileResponse struct {
+	ID           string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	URL          string `json:"url" example:"https://api.matchaciee.com/uploads/9b2f6a1e-4c1d-4f3e-9a8b-2c7d5e6f1a2b.jpg"`
+	OriginalName string `json:"original_name" example:"matcha-latte.jpg"`
+	ContentType  string `json:"content_type" example:"image/jpeg"`
+	SizeBytes    int64  `json:"size_bytes" example:"184320"`
+	CreatedAt    string `json:"created_at" example:"2025-01-07T10:30:00+07:00"`
+}
+
+type MediaFileSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Message string            `json:"message,omitempty" example:"Image uploaded successfully"`
+	Data    MediaFileResponse `json:"data"`
+}
+
+type StorageUsageResponse struct {
+	UsedBytes         int64   `json:"used_bytes" example:"432013312"`
+	QuotaBytes        int64   `json:"quota_bytes" example:"524288000"`
+	UsedPercent       float64 `json:"used_percent" example:"82.4"`
+	NearQuota         bool    `json:"near_quota" example:"true"`
+	FileCount         int64   `json:"file_count" example:"1240"`
+	UnreferencedFiles int     `json:"unreferenced_files" example:"87"`
+	UnreferencedBytes int64   `json:"unreferenced_bytes" example:"30408704"`
+	CleanupAfterDays  int     `json:"cleanup_after_days" example:"7"`
+}
+
+type StorageUsageSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Data    StorageUsageResponse `json:"data"`
+}
+
+type MediaCleanupResponse struct {
+	DeletedFiles int    `json:"deleted_files" example:"12"`
+	FreedBytes   int64  `json:"freed_bytes" example:"4194304"`
+	CleanedAt    string `json:"cleaned_at" example:"2025-01-07T03:00:00+07:00"`
+}
+
+type MediaCleanupSuccessResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Message string               `json:"message,omitempty" example:"Media cleanup completed"`
+	Data    MediaCleanupResponse `json:"data"`
+}
+
+// Self-test DTOs
+type SelftestStep struct {
+	Name       string `json:"name" example:"pay"`
+	Passed     bool   `json:"passed" example:"true"`
+	DurationMs int64  `json:"duration_ms" example:"42"`
+	Error      string `json:"error,omitempty" example:""`
+}
+
+type SelftestResponse struct {
+	Passed     bool           `json:"passed" example:"true"`
+	StartedAt  string         `json:"started_at" example:"2025-01-07T10:30:00+07:00"`
+	DurationMs int64          `json:"duration_ms" example:"318"`
+	Steps      []SelftestStep `json:"steps"`
+}
+
+type SelftestSuccessResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Message string           `json:"message,omitempty" example:"Self-test passed"`
+	Data    SelftestResponse `json:"data"`
+}
+
+type SelftestFailureResponse struct {
+	Success bool             `json:"success" example:"false"`
+	Error   string           `json:"error" example:"Self-test failed"`
+	Data    SelftestResponse `json:"data"`
+}
+
+// Print job DTOs
+type PrintJob struct {
+	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber string `json:"order_number" example:"MC-250107-001"`
+	Station     string `json:"station" example:"counter"`
+	Attempts    int    `json:"attempts" example:"1"`
+	Payload     string `json:"payload" example:"G0AbYQFNYXRjaGFjaWVlCg==" format:"base64"`
+}
+
+type PrintJobSuccessResponse struct {
+	Success bool     `json:"success" example:"true"`
+	Message string   `json:"message,omitempty" example:"Print job taken"`
+	Data    PrintJob `json:"data"`
+}
+
+type AckPrintJobRequest struct {
+	Status string  `json:"status" example:"failed" enums:"printed,failed"`
+	Error  *string `json:"error,omitempty" example:"Printer out of paper"`
+}
+
+// Email template DTOs
+type UpdateEmailTemplateRequest struct {
+	Subject  string `json:"subject" example:"Your Matchaciee order {{order_number}}"`
+	HTMLBody string `json:"html_body" example:"<p>Hi {{customer_name}}, thanks for your order!</p>"`
+	TextBody string `json:"text_body" example:"Hi {{customer_name}}, thanks for your order!"`
+}
+
+type TestSendEmailTemplateRequest struct {
+	To        string            `json:"to" example:"admin@matchaciee.com"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+type EmailTemplateResponse struct {
+	Key       string   `json:"key" example:"order_confirmation"`
+	Locale    string   `json:"locale" example:"id"`
+	Version   int      `json:"version" example:"3"`
+	Subject   string   `json:"subject" example:"Your Matchaciee order {{order_number}}"`
+	HTMLBody  string   `json:"html_body" example:"<p>Hi {{customer_name}}, thanks for your order!</p>"`
+	TextBody  string   `json:"text_body" example:"Hi {{customer_name}}, thanks for your order!"`
+	IsActive  bool     `json:"is_active" example:"true"`
+	Variables []string `json:"variables" example:"customer_name,order_number,total,order_url"`
+	CreatedAt string   `json:"created_at" example:"2025-01-07T10:00:00Z"`
+}
+
+type EmailTemplateSuccessResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    EmailTemplateResponse `json:"data"`
+}
+
+type EmailTemplateListSuccessResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    []EmailTemplateResponse `json:"data"`
+}
+
+// Report DTOs
+type SourceRevenue struct {
+	OrderSource string  `json:"order_source" example:"partner"`
+	OrderCount  int64   `json:"order_count" example:"12"`
+	Subtotal    float64 `json:"subtotal" example:"840000"`
+	Tax         float64 `json:"tax" example:"84000"`
+	Revenue     float64 `json:"revenue" example:"924000"`
+	GatewayFees float64 `json:"gateway_fees" example:"6468"`
+	NetRevenue  float64 `json:"net_revenue" example:"917532"`
+}
+
+type RevenueBySourceResponse struct {
+	StartDate        string          `json:"start_date" example:"2025-01-01"`
+	EndDate          string          `json:"end_date" example:"2025-01-31"`
+	Sources          []SourceRevenue `json:"sources"`
+	TotalOrders      int64           `json:"total_orders" example:"120"`
+	TotalRevenue     float64         `json:"total_revenue" example:"9240000"`
+	TotalGatewayFees float64         `json:"total_gateway_fees" example:"64680"`
+	TotalNetRevenue  float64         `json:"total_net_revenue" example:"9175320"`
+}
+
+type RevenueBySourceSuccessResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    RevenueBySourceResponse `json:"data"`
+}
+
+type SalesPeriod struct {
+	Period        string  `json:"period" example:"2025-01-06"`
+	OrderCount    int64   `json:"order_count" example:"84"`
+	Subtotal      float64 `json:"subtotal" example:"4620000"`
+	Discount      float64 `json:"discount" example:"120000"`
+	Tax           float64 `json:"tax" example:"450000"`
+	Revenue       float64 `json:"revenue" example:"4950000"`
+	AverageTicket float64 `json:"average_ticket" example:"58928.57"`
+}
+
+type SalesReportResponse struct {
+	StartDate     string        `json:"start_date" example:"2025-01-01"`
+	EndDate       string        `json:"end_date" example:"2025-01-31"`
+	Granularity   string        `json:"granularity" example:"week" enums:"day,week,month"`
+	Periods       []SalesPeriod `json:"periods"`
+	TotalOrders   int64         `json:"total_orders" example:"360"`
+	TotalRevenue  float64       `json:"total_revenue" example:"21200000"`
+	TotalTax      float64       `json:"total_tax" example:"1927000"`
+	AverageTicket float64       `json:"average_ticket" example:"58888.89"`
+}
+
+type SalesReportSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    SalesReportResponse `json:"data"`
+}
+
+type CustomizationPopularity struct {
+	CustomizationType string  `json:"customization_type" example:"milk"`
+	OptionName        string  `json:"option_name" example:"Oat Milk"`
+	Quantity          int64   `json:"quantity" example:"52"`
+	Share             float64 `json:"share" example:"40"`
+}
+
+type TopProduct struct {
+	ProductID      *string                   `json:"product_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductName    string                    `json:"product_name" example:"Matcha Latte"`
+	Quantity       int64                     `json:"quantity" example:"130"`
+	OrderCount     int64                     `json:"order_count" example:"112"`
+	Revenue        float64                   `json:"revenue" example:"5850000"`
+	Customizations []CustomizationPopularity `json:"customizations"`
+}
+
+type TopProductsResponse struct {
+	StartDate string       `json:"start_date" example:"2025-01-01"`
+	EndDate   string       `json:"end_date" example:"2025-01-31"`
+	Products  []TopProduct `json:"products"`
+}
+
+type TopProductsSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    TopProductsResponse `json:"data"`
+}
+
+type CategoryRevenue struct {
+	CategoryID   *string `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName string  `json:"category_name" example:"Drinks"`
+	Quantity     int64   `json:"quantity" example:"310"`
+	OrderCount   int64   `json:"order_count" example:"255"`
+	Revenue      float64 `json:"revenue" example:"14250000"`
+	Share        float64 `json:"share" example:"68.5"`
+}
+
+type RevenueByCategoryResponse struct {
+	StartDate    string            `json:"start_date" example:"2025-01-01"`
+	EndDate      string            `json:"end_date" example:"2025-01-31"`
+	ParentID     *string           `json:"parent_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Categories   []CategoryRevenue `json:"categories"`
+	TotalRevenue float64           `json:"total_revenue" example:"20800000"`
+}
+
+type RevenueByCategorySuccessResponse struct {
+	Success bool                      `json:"success" example:"true"`
+	Data    RevenueByCategoryResponse `json:"data"`
+}
+
+type TaxRateSummary struct {
+	Rate         float64 `json:"rate" example:"11"`
+	OrderCount   int64   `json:"order_count" example:"1240"`
+	TaxableSales float64 `json:"taxable_sales" example:"62000000"`
+	Tax          float64 `json:"tax" example:"6820000"`
+}
+
+type TaxDay struct {
+	Date         string  `json:"date" example:"2025-01-01"`
+	OrderCount   int64   `json:"order_count" example:"41"`
+	TaxableSales float64 `json:"taxable_sales" example:"2050000"`
+	Tax          float64 `json:"tax" example:"225500"`
+}
+
+type TaxReportResponse struct {
+	Month             string           `json:"month" example:"2025-01"`
+	Rates             []TaxRateSummary `json:"rates"`
+	Days              []TaxDay         `json:"days"`
+	TotalOrders       int64            `json:"total_orders" example:"1240"`
+	TotalTaxableSales float64          `json:"total_taxable_sales" example:"62000000"`
+	TotalTax          float64          `json:"total_tax" example:"6820000"`
+}
+
+type TaxReportSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    TaxReportResponse `json:"data"`
+}
+
+type FulfillmentStage struct {
+	Stage      string   `json:"stage" example:"preparation" enums:"payment,preparation,pickup,total"`
+	OrderCount int64    `json:"order_count" example:"86"`
+	P50Minutes *float64 `json:"p50_minutes" example:"6.5"`
+	P90Minutes *float64 `json:"p90_minutes" example:"14.25"`
+}
+
+type FulfillmentDay struct {
+	Date   string             `json:"date" example:"2025-01-01"`
+	Stages []FulfillmentStage `json:"stages"`
+}
+
+type FulfillmentTimesResponse struct {
+	StartDate string             `json:"start_date" example:"2025-01-01"`
+	EndDate   string             `json:"end_date" example:"2025-01-31"`
+	Overall   []FulfillmentStage `json:"overall"`
+	Days      []FulfillmentDay   `json:"days"`
+}
+
+type FulfillmentTimesSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Data    FulfillmentTimesResponse `json:"data"`
+}
+
+type AbandonedHour struct {
+	Hour           int     `json:"hour" example:"12"`
+	OrderCount     int64   `json:"order_count" example:"64"`
+	AbandonedCount int64   `json:"abandoned_count" example:"9"`
+	Attempted      int64   `json:"payment_attempted" example:"6"`
+	AbandonedValue float64 `json:"abandoned_value" example:"412000"`
+	DropOffRate    float64 `json:"drop_off_rate" example:"14.06"`
+}
+
+type AbandonedBySource struct {
+	OrderSource    string          `json:"order_source" example:"kiosk" enums:"guest,member,kiosk,partner,phone,catering"`
+	OrderCount     int64           `json:"order_count" example:"310"`
+	AbandonedCount int64           `json:"abandoned_count" example:"37"`
+	Attempted      int64           `json:"payment_attempted" example:"25"`
+	AbandonedValue float64         `json:"abandoned_value" example:"1650000"`
+	DropOffRate    float64         `json:"drop_off_rate" example:"11.94"`
+	Hours          []AbandonedHour `json:"hours"`
+}
+
+type AbandonedOrdersResponse struct {
+	StartDate           string              `json:"start_date" example:"2025-01-01"`
+	EndDate             string              `json:"end_date" example:"2025-01-31"`
+	Sources             []AbandonedBySource `json:"sources"`
+	Hours               []AbandonedHour     `json:"hours"`
+	TotalOrders         int64               `json:"total_orders" example:"1240"`
+	TotalAbandoned      int64               `json:"total_abandoned" example:"58"`
+	TotalAbandonedValue float64             `json:"total_abandoned_value" example:"2610000"`
+	DropOffRate         float64             `json:"drop_off_rate" example:"4.68"`
+}
+
+type AbandonedOrdersSuccessResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    AbandonedOrdersResponse `json:"data"`
+}
+
+type InventoryUsage struct {
+	ProductID         string   `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductName       string   `json:"product_name" example:"Matcha Cheesecake"`
+	StockQuantity     int      `json:"stock_quantity" example:"24"`
+	Reserved          int64    `json:"reserved" example:"2"`
+	Available         int64    `json:"available" example:"22"`
+	Consumed          int64    `json:"consumed" example:"180"`
+	DailyUsage        float64  `json:"daily_usage" example:"6"`
+	DaysUntilStockout *float64 `json:"days_until_stockout" example:"3.67"`
+}
+
+type InventoryUsageResponse struct {
+	StartDate string           `json:"start_date" example:"2025-01-01"`
+	EndDate   string           `json:"end_date" example:"2025-01-30"`
+	Days      int              `json:"days" example:"30"`
+	Products  []InventoryUsage `json:"products"`
+}
+
+type InventoryUsageSuccessResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Data    InventoryUsageResponse `json:"data"`
+}
+
+type StaffTips struct {
+	Staff      *StaffSummary `json:"staff"`
+	OrderCount int64         `json:"order_count" example:"18"`
+	Tips       float64       `json:"tips" example:"145000"`
+}
+
+type TipsReportResponse struct {
+	StartDate    string      `json:"start_date" example:"2025-01-01"`
+	EndDate      string      `json:"end_date" example:"2025-01-31"`
+	Staff        []StaffTips `json:"staff"`
+	TippedOrders int64       `json:"tipped_orders" example:"42"`
+	TotalTips    float64     `json:"total_tips" example:"360000"`
+}
+
+type TipsReportSuccessResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    TipsReportResponse `json:"data"`
+}
+
+type Settlement struct {
+	PaymentID     string  `json:"payment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Reference     string  `json:"reference" example:"MC-250107-001-1736240000"`
+	OrderNumber   string  `json:"order_number" example:"MC-250107-001"`
+	Provider      string  `json:"provider" example:"midtrans"`
+	PaymentType   string  `json:"payment_type,omitempty" example:"qris"`
+	TransactionID string  `json:"transaction_id,omitempty" example:"9aed5972-5b6a-401e-894b-a32c91ed1a3a"`
+	SettledAt     string  `json:"settled_at" example:"2025-01-07T10:32:00+07:00"`
+	PayoutDate    string  `json:"payout_date" example:"2025-01-08"`
+	GrossAmount   float64 `json:"gross_amount" example:"50000"`
+	Refunded      float64 `json:"refunded" example:"0"`
+	Fee           float64 `json:"fee" example:"350"`
+	NetAmount     float64 `json:"net_amount" example:"49650"`
+}
+
+type SettlementReportResponse struct {
+	StartDate     string       `json:"start_date" example:"2025-01-01"`
+	EndDate       string       `json:"end_date" example:"2025-01-31"`
+	Settlements   []Settlement `json:"settlements"`
+	TotalGross    float64      `json:"total_gross" example:"4250000"`
+	TotalRefunded float64      `json:"total_refunded" example:"50000"`
+	TotalFees     float64      `json:"total_fees" example:"41300"`
+	TotalNet      float64      `json:"total_net" example:"4158700"`
+}
+
+type SettlementReportSuccessResponse struct {
+	Success bool                     `json:"success" example:"true"`
+	Data    SettlementReportResponse `json:"data"`
+}
+
+// Chaos DTOs
+type UpdateChaosFaultsRequest struct {
+	LatencyMs               int    `json:"latency_ms" example:"2000"`
+	LatencyPathPrefix       string `json:"latency_path_prefix" example:"/api/v1/menu"`
+	MidtransFailure         bool   `json:"midtrans_failure" example:"true"`
+	WebhookSignatureFailure bool   `json:"webhook_signature_failure" example:"false"`
+	DurationSeconds         int    `json:"duration_seconds" example:"600"`
+}
+
+type ChaosFaultsResponse struct {
+	LatencyMs               int64  `json:"latency_ms" example:"2000"`
+	LatencyPathPrefix       string `json:"latency_path_prefix" example:"/api/v1/menu"`
+	MidtransFailure         bool   `json:"midtrans_failure" example:"true"`
+	WebhookSignatureFailure bool   `json:"webhook_signature_failure" example:"false"`
+	ExpiresAt               string `json:"expires_at,omitempty" example:"2025-01-07T17:10:00+07:00"`
+}
+
+type ChaosFaultsSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Message string              `json:"message,omitempty" example:"Faults injected"`
+	Data    ChaosFaultsResponse `json:"data"`
+}
+
+// Trash DTOs
+type TrashItem struct {
+	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type      string `json:"type" example:"products"`
+	Name      string `json:"name" example:"Iced Matcha Latte"`
+	Slug      string `json:"slug" example:"iced-matcha-latte"`
+	Category  string `json:"category,omitempty" example:"Matcha"`
+	DeletedAt string `json:"deleted_at" example:"2025-01-07T17:00:00+07:00"`
+}
+
+type TrashResponse struct {
+	Products   []TrashItem `json:"products"`
+	Categories []TrashItem `json:"categories"`
+}
+
+type TrashSuccessResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    TrashResponse `json:"data"`
+}
+
+type PurgeImpactResponse struct {
+	ID                 string   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type               string   `json:"type" example:"products"`
+	Name               string   `json:"name" example:"Iced Matcha Latte"`
+	OrderItems         int64    `json:"order_items,omitempty" example:"42"`
+	Orders             int64    `json:"orders,omitempty" example:"38"`
+	Customizations     int64    `json:"customizations,omitempty" example:"3"`
+	ActiveReservations int64    `json:"active_reservations,omitempty" example:"0"`
+	ChildCategories    int64    `json:"child_categories,omitempty" example:"0"`
+	Products           int64    `json:"products,omitempty" example:"0"`
+	Effects            []string `json:"effects" example:"Order items keep the product name and price but lose the link to the product,Customizations are deleted"`
+}
+
+type PurgeImpactSuccessResponse struct {
+	Success bool                `json:"success" example:"true"`
+	Data    PurgeImpactResponse `json:"data"`
+}
+
+// Integrity DTOs
+type AnomalyResponse struct {
+	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderID     string `json:"order_id,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	OrderNumber string `json:"order_number,omitempty" example:"MC-250107-001"`
+	Kind        string `json:"kind" example:"total_mismatch" enums:"unlinked_item,item_subtotal_mismatch,subtotal_mismatch,total_mismatch"`
+	Detail      string `json:"detail" example:"Total 60500.00 does not equal subtotal 55000.00 + tax 5500.00 + source fee 1000.00"`
+	DetectedAt  string `json:"detected_at" example:"2025-01-07T02:00:00+07:00"`
+	ResolvedAt  string `json:"resolved_at,omitempty" example:"2025-01-07T09:15:00+07:00"`
+}
+
+type AnomaliesSuccessResponse struct {
+	Success bool              `json:"success" example:"true"`
+	Data    []AnomalyResponse `json:"data"`
+}
+
+type IntegrityCheckResponse struct {
+	NewAnomalies  int64  `json:"new_anomalies" example:"2"`
+	OpenAnomalies int    `json:"open_anomalies" example:"5"`
+	CheckedAt     string `json:"checked_at" example:"2025-01-07T09:00:00+07:00"`
+}
+
+type IntegrityCheckSuccessResponse struct {
+	Success bool                   `json:"success" example:"true"`
+	Message string                 `json:"message,omitempty" example:"Integrity check completed"`
+	Data    IntegrityCheckResponse `json:"data"`
+}
+
+// Cart DTOs
+type UpdateCartItemRequest struct {
+	Quantity int    `json:"quantity" example:"2"`
+	Notes    string `json:"notes,omitempty" example:"Less ice"`
+}
+
+type CheckoutCartRequest struct {
+	CustomerName string  `json:"customer_name,omitempty" example:"John Doe"`
+	OrderType    string  `json:"order_type,omitempty" example:"takeaway" enums:"dine_in,takeaway,delivery"`
+	TableID      string  `json:"table_id,omitempty" example:"3f2b8c1e-6a4d-4e2f-9b7a-1c5d8e9f0a2b"`
+	Notes        string  `json:"notes,omitempty" example:"Pick up at 10:30"`
+	PromoCode    string  `json:"promo_code,omitempty" example:"MATCHA20"`
+	VoucherCode  string  `json:"voucher_code,omitempty" example:"VC-7KQM-X3TD"`
+	TipAmount    float64 `json:"tip_amount,omitempty" example:"5000"`
+	ScheduledFor string  `json:"scheduled_for,omitempty" example:"2025-01-08T09:00:00+07:00"`
+}
+
+type CartItemResponse struct {
+	ID             string                   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductID      string                   `json:"product_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductName    string                   `json:"product_name" example:"Iced Matcha Latte"`
+	Quantity       int                      `json:"quantity" example:"2"`
+	Customizations []OrderItemCustomization `json:"customizations,omitempty"`
+	Notes          string                   `json:"notes,omitempty" example:"Less ice"`
+}
+
+type OrderPreviewItem struct {
+	ProductID   string  `json:"product_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ProductName string  `json:"product_name" example:"Iced Matcha Latte"`
+	Quantity    int     `json:"quantity" example:"2"`
+	UnitPrice   float64 `json:"unit_price" example:"45000"`
+	Subtotal    float64 `json:"subtotal" example:"90000"`
+}
+
+type OrderPreviewResponse struct {
+	Items                  []OrderPreviewItem `json:"items"`
+	OrderType              string             `json:"order_type" example:"takeaway"`
+	Subtotal               float64            `json:"subtotal" example:"90000"`
+	ServiceCharge          float64            `json:"service_charge,omitempty" example:"0"`
+	Tax                    float64            `json:"tax" example:"9000"`
+	Total                  float64            `json:"total" example:"99000"`
+	Currency               string             `json:"currency" example:"IDR"`
+	PriceAdjustmentPercent float64            `json:"price_adjustment_percent,omitempty" example:"0"`
+	SourceFee              float64            `json:"source_fee,omitempty" example:"0"`
+	DeliveryFee            float64            `json:"delivery_fee,omitempty" example:"0"`
+}
+
+type CartResponse struct {
+	ID           string               `json:"id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Items        []CartItemResponse   `json:"items"`
+	Preview      OrderPreviewResponse `json:"preview,omitempty"`
+	PreviewError string               `json:"preview_error,omitempty" example:"product not available: Hojicha Latte"`
+	UpdatedAt    string               `json:"updated_at,omitempty" example:"2025-01-07T10:00:00+07:00"`
+}
+
+type CartSuccessResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message,omitempty" example:"Item added to cart"`
+	Data    CartResponse `json:"data"`
+}
+
+// Status DTOs
+type StatusComponent struct {
+	Name   string `json:"name" example:"database"`
+	Status string `json:"status" example:"operational"`
+}
+
+type StatusIncident struct {
+	Component  string `json:"component" example:"realtime"`
+	Status     string `json:"status" example:"degraded"`
+	StartedAt  string `json:"started_at" example:"2025-01-07T09:12:00+07:00"`
+	ResolvedAt string `json:"resolved_at,omitempty" example:"2025-01-07T09:14:30+07:00"`
+}
+
+type StatusResponse struct {
+	Status        string            `json:"status" example:"operational"`
+	StartedAt     string            `json:"started_at" example:"2025-01-07T08:00:00+07:00"`
+	UptimeSeconds int64             `json:"uptime_seconds" example:"7200"`
+	Components    []StatusComponent `json:"components"`
+	Incidents     []StatusIncident  `json:"incidents"`
+	CheckedAt     string            `json:"checked_at" example:"2025-01-07T10:00:00+07:00"`
+}
+
+type StatusSuccessResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    StatusResponse `json:"data"`
+}
+
+// Usage DTOs
+type RouteUsage struct {
+	Method       string  `json:"method" example:"GET"`
+	Route        string  `json:"route" example:"/api/v1/orders/:id"`
+	Requests     int64   `json:"requests" example:"1200"`
+	ErrorRate    float64 `json:"error_rate" example:"0.0125"`
+	AvgLatencyMs float64 `json:"avg_latency_ms" example:"42.5"`
+	LastSeen     string  `json:"last_seen" example:"2025-01-07T10:00:00Z"`
+}
+
+type ClientUsage struct {
+	Client       string       `json:"client" example:"kiosk"`
+	Requests     int64        `json:"requests" example:"5400"`
+	ClientErrors int64        `json:"client_errors" example:"60"`
+	ServerErrors int64        `json:"server_errors" example:"3"`
+	ErrorRate    float64      `json:"error_rate" example:"0.0117"`
+	AvgLatencyMs float64      `json:"avg_latency_ms" example:"38.2"`
+	MaxLatencyMs int64        `json:"max_latency_ms" example:"950"`
+	LastSeen     string       `json:"last_seen" example:"2025-01-07T10:00:00Z"`
+	Routes       []RouteUsage `json:"routes"`
+}
+
+type UsageResponse struct {
+	StartDate string        `json:"start_date" example:"2025-01-01"`
+	EndDate   string        `json:"end_date" example:"2025-01-07"`
+	Clients   []ClientUsage `json:"clients"`
+}
+
+type UsageSuccessResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    UsageResponse `json:"data"`
 }
@@ -0,0 +1,141 @@
+// Package chaos injects failures on staging so the frontend and ops runbooks
+// can be exercised against realistic outages. It is only wired in when
+// CHAOS_ENABLED is set, which config validation refuses in production.
+package chaos
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	chaosPath           = "/api/v1/chaos"
+	paymentPathPrefix   = "/api/v1/orders/"
+	paymentPathSuffix   = "/payment"
+	midtransWebhookPath = "/api/v1/webhooks/midtrans"
+)
+
+// Faults are the failure modes currently injected. They clear themselves at
+// ExpiresAt so a forgotten experiment cannot leave staging broken.
+type Faults struct {
+	Latency                 time.Duration
+	LatencyPathPrefix       string
+	MidtransFailure         bool
+	WebhookSignatureFailure bool
+	ExpiresAt               time.Time
+}
+
+func (f Faults) active(now time.Time) bool {
+	return now.Before(f.ExpiresAt)
+}
+
+// Injector holds the faults for this instance; each instance is configured
+// separately
+type Injector struct {
+	mu     sync.RWMutex
+	faults Faults
+}
+
+func NewInjector() *Injector {
+	return &Injector{}
+}
+
+// Faults returns the active faults, or the zero value when none are active
+func (i *Injector) Faults() Faults {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if !i.faults.active(time.Now()) {
+		return Faults{}
+	}
+	return i.faults
+}
+
+func (i *Injector) Set(faults Faults) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.faults = faults
+}
+
+func (i *Injector) Reset() {
+	i.Set(Faults{})
+}
+
+// Middleware applies the active faults. Latency is added before the request is
+// handled; simulated failures answer with the same responses the real
+// handlers produce when Midtrans fails or a webhook signature does not match.
+func (i *Injector) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		if strings.HasPrefix(path, chaosPath) {
+			return c.Next()
+		}
+
+		faults := i.Faults()
+
+		if faults.Latency > 0 && strings.HasPrefix(path, faults.LatencyPathPrefix) {
+			time.Sleep(faults.Latency)
+		}
+
+		if faults.MidtransFailure && c.Method() == fiber.MethodPost &&
+			strings.HasPrefix(path, paymentPathPrefix) && strings.HasSuffix(path, paymentPathSuffix) {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create payment token")
+		}
+
+		if faults.WebhookSignatureFailure && c.Method() == fiber.MethodPost && path == midtransWebhookPath {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":     "error",
+				"message":    "Invalid signature",
+				"request_id": utils.RequestID(c),
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+type UpdateFaultsRequest struct {
+	LatencyMs               int    `json:"latency_ms" validate:"min=0,max=30000"`
+	LatencyPathPrefix       string `json:"latency_path_prefix" validate:"omitempty,startswith=/"`
+	MidtransFailure         bool   `json:"midtrans_failure"`
+	WebhookSignatureFailure bool   `json:"webhook_signature_failure"`
+	DurationSeconds         int    `json:"duration_seconds" validate:"required,min=1,max=3600"`
+}
+
+type FaultsResponse struct {
+	LatencyMs               int64   `json:"latency_ms"`
+	LatencyPathPrefix       string  `json:"latency_path_prefix"`
+	MidtransFailure         bool    `json:"midtrans_failure"`
+	WebhookSignatureFailure bool    `json:"webhook_signature_failure"`
+	ExpiresAt               *string `json:"expires_at,omitempty"`
+}
+
+// Faults converts the request into faults expiring DurationSeconds from now
+func (r UpdateFaultsRequest) Faults() Faults {
+	return Faults{
+		Latency:                 time.Duration(r.LatencyMs) * time.Millisecond,
+		LatencyPathPrefix:       r.LatencyPathPrefix,
+		MidtransFailure:         r.MidtransFailure,
+		WebhookSignatureFailure: r.WebhookSignatureFailure,
+		ExpiresAt:               time.Now().Add(time.Duration(r.DurationSeconds) * time.Second),
+	}
+}
+
+func ToFaultsResponse(faults Faults) *FaultsResponse {
+	response := &FaultsResponse{
+		LatencyMs:               faults.Latency.Milliseconds(),
+		LatencyPathPrefix:       faults.LatencyPathPrefix,
+		MidtransFailure:         faults.MidtransFailure,
+		WebhookSignatureFailure: faults.WebhookSignatureFailure,
+	}
+	if !faults.ExpiresAt.IsZero() {
+		expiresAt := faults.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+		response.ExpiresAt = &expiresAt
+	}
+	return response
+}
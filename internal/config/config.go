@@ -2,7 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,46 +14,215 @@ import (
 )
 
 type Config struct {
-	DBPort              string
-	AppPort             string
-	Env                 string
-	AppName             string
-	DBHost              string
-	DBUser              string
-	DBPassword          string
-	DBName              string
-	DBSSLMode           string
-	JWTSecret           string
-	LogLevel            string
-	AllowedOrigins      []string
-	JWTExpiry           time.Duration
-	RefreshTokenExpiry  time.Duration
-	MidtransServerKey   string
-	MidtransClientKey   string
-	MidtransEnvironment string
+	DBPort               string
+	AppPort              string
+	Env                  string
+	AppName              string
+	DBHost               string
+	DBUser               string
+	DBPassword           string
+	DBName               string
+	DBSSLMode            string
+	JWTSecret            string
+	LogLevel             string
+	AllowedOrigins       []string
+	TrustedProxies       []string
+	ProxyHeader          string
+	FrontendURL          string
+	JWTExpiry            time.Duration
+	RefreshTokenExpiry   time.Duration
+	MidtransServerKey    string
+	MidtransClientKey    string
+	MidtransEnvironment  string
+	MidtransFinishURL    string
+	MidtransUnfinishURL  string
+	MidtransErrorURL     string
+	WebhookAllowedIPs    []string
+	WebhookRateLimit     int
+	WebhookRateWindow    time.Duration
+	AlertEmails          []string
+	AlertSlackURL        string
+	AlertSigFailures     int
+	AlertSigWindow       time.Duration
+	PaymentProvider      string
+	StripeSecretKey      string
+	StripeWebhookSecret  string
+	XenditSecretKey      string
+	XenditCallbackToken  string
+	StockReservationTTL  time.Duration
+	PaymentExpiry        time.Duration
+	CancelExpiredOrders  bool
+	GatewayFees          []string
+	PayoutDelayDays      int
+	PaymentLinkTTL       time.Duration
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	MailFrom             string
+	StoreLocale          string
+	StoreTimezone        string
+	OrderNumberPrefix    string
+	OrderNumberDate      string
+	OrderNumberWidth     int
+	OrderNumberReset     string
+	LoadShedWindow       time.Duration
+	LoadShedDBLatency    time.Duration
+	LoadShedErrorRate    float64
+	RealtimeBroker       string
+	UploadDir            string
+	APIURL               string
+	StorageQuotaMB       int
+	MaxUploadMB          int
+	MediaCleanupDays     int
+	SelftestToken        string
+	SelftestProductID    string
+	ChaosEnabled         bool
+	OTPEnabled           bool
+	OTPChannels          []string
+	OTPExpiry            time.Duration
+	OTPMaxAttempts       int
+	OTPRequestLimit      int
+	OTPRequestWindow     time.Duration
+	WhatsAppToken        string
+	WhatsAppPhoneID      string
+	CampaignBatchSize    int
+	DineInTax            float64
+	DineInService        float64
+	TakeawayTax          float64
+	TakeawayService      float64
+	DeliveryTax          float64
+	DeliveryService      float64
+	DeliveryFee          float64
+	OrderLookupLimit     int
+	OrderLookupWindow    time.Duration
+	RateLimitStore       string
+	GlobalRateLimit      int
+	GlobalRateWindow     time.Duration
+	LoginRateLimit       int
+	LoginRateWindow      time.Duration
+	GuestOrderRateLimit  int
+	GuestOrderRateWindow time.Duration
+	RushAfter            time.Duration
+	KioskOfflineAfter    time.Duration
+	PreorderMaxDays      int
+	PreorderLeadTime     time.Duration
+	ShareTokenTTL        time.Duration
+	PrintAgentToken      string
+	PrintStation         string
+	DineInStation        string
+	TakeawayStation      string
+	DeliveryStation      string
 }
 
 func Load() (*Config, error) {
 	_ = godotenv.Load() //nolint:errcheck
 
 	cfg := &Config{
-		AppPort:             getEnv("PORT", "8080"),
-		Env:                 getEnv("ENV", "development"),
-		AppName:             getEnv("APP_NAME", "Matchaciee API"),
-		DBHost:              getEnv("DB_HOST", "localhost"),
-		DBPort:              getEnv("DB_PORT", "5432"),
-		DBUser:              getEnv("DB_USER", "postgres"),
-		DBPassword:          getEnv("DB_PASSWORD", ""),
-		DBName:              getEnv("DB_NAME", "matchaciee_dev"),
-		DBSSLMode:           getEnv("DB_SSLMODE", "disable"),
-		JWTSecret:           getEnv("JWT_SECRET", "rahasiamatcha"),
-		JWTExpiry:           getEnvAsDuration("JWT_EXPIRY", 1*time.Hour),
-		RefreshTokenExpiry:  getEnvAsDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
-		AllowedOrigins:      getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
-		LogLevel:            getEnv("LOG_LEVEL", "info"),
-		MidtransServerKey:   getEnv("MIDTRANS_SERVER_KEY", ""),
-		MidtransClientKey:   getEnv("MIDTRANS_CLIENT_KEY", ""),
-		MidtransEnvironment: getEnv("MIDTRANS_ENVIRONMENT", "sandbox"),
+		AppPort:              getEnv("PORT", "8080"),
+		Env:                  getEnv("ENV", "development"),
+		AppName:              getEnv("APP_NAME", "Matchaciee API"),
+		DBHost:               getEnv("DB_HOST", "localhost"),
+		DBPort:               getEnv("DB_PORT", "5432"),
+		DBUser:               getEnv("DB_USER", "postgres"),
+		DBPassword:           getEnv("DB_PASSWORD", ""),
+		DBName:               getEnv("DB_NAME", "matchaciee_dev"),
+		DBSSLMode:            getEnv("DB_SSLMODE", "disable"),
+		JWTSecret:            getEnv("JWT_SECRET", "rahasiamatcha"),
+		JWTExpiry:            getEnvAsDuration("JWT_EXPIRY", 1*time.Hour),
+		RefreshTokenExpiry:   getEnvAsDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
+		AllowedOrigins:       getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
+		TrustedProxies:       getEnvAsSlice("TRUSTED_PROXIES", nil),
+		ProxyHeader:          getEnv("PROXY_HEADER", "X-Forwarded-For"),
+		FrontendURL:          strings.TrimSuffix(getEnv("FRONTEND_URL", "http://localhost:3000"), "/"),
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		MidtransServerKey:    getEnv("MIDTRANS_SERVER_KEY", ""),
+		MidtransClientKey:    getEnv("MIDTRANS_CLIENT_KEY", ""),
+		MidtransEnvironment:  getEnv("MIDTRANS_ENVIRONMENT", "sandbox"),
+		MidtransFinishURL:    getEnv("MIDTRANS_FINISH_URL", ""),
+		MidtransUnfinishURL:  getEnv("MIDTRANS_UNFINISH_URL", ""),
+		MidtransErrorURL:     getEnv("MIDTRANS_ERROR_URL", ""),
+		WebhookAllowedIPs:    getEnvAsSlice("MIDTRANS_WEBHOOK_ALLOWED_IPS", nil),
+		WebhookRateLimit:     getEnvAsInt("MIDTRANS_WEBHOOK_RATE_LIMIT", 120),
+		WebhookRateWindow:    getEnvAsDuration("MIDTRANS_WEBHOOK_RATE_WINDOW", time.Minute),
+		AlertEmails:          getEnvAsSlice("ALERT_EMAILS", nil),
+		AlertSlackURL:        getEnv("ALERT_SLACK_WEBHOOK_URL", ""),
+		AlertSigFailures:     getEnvAsInt("ALERT_SIGNATURE_FAILURES", 5),
+		AlertSigWindow:       getEnvAsDuration("ALERT_SIGNATURE_WINDOW", 10*time.Minute),
+		PaymentProvider:      getEnv("PAYMENT_PROVIDER", "midtrans"),
+		StripeSecretKey:      getEnv("STRIPE_SECRET_KEY", ""),
+		StripeWebhookSecret:  getEnv("STRIPE_WEBHOOK_SECRET", ""),
+		XenditSecretKey:      getEnv("XENDIT_SECRET_KEY", ""),
+		XenditCallbackToken:  getEnv("XENDIT_CALLBACK_TOKEN", ""),
+		StockReservationTTL:  getEnvAsDuration("STOCK_RESERVATION_TTL", 10*time.Minute),
+		PaymentExpiry:        getEnvAsDuration("PAYMENT_EXPIRY", 30*time.Minute),
+		CancelExpiredOrders:  getEnvAsBool("PAYMENT_EXPIRY_CANCELS_ORDER", true),
+		GatewayFees:          getEnvAsSlice("GATEWAY_FEES", nil),
+		PayoutDelayDays:      getEnvAsInt("PAYOUT_DELAY_DAYS", 1),
+		PaymentLinkTTL:       getEnvAsDuration("PAYMENT_LINK_TTL", 30*time.Minute),
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnv("SMTP_PORT", "587"),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		MailFrom:             getEnv("MAIL_FROM", "Matchaciee <no-reply@matchaciee.com>"),
+		StoreLocale:          getEnv("STORE_LOCALE", "id"),
+		StoreTimezone:        getEnv("STORE_TIMEZONE", "Asia/Jakarta"),
+		OrderNumberPrefix:    getEnv("ORDER_NUMBER_PREFIX", "MC"),
+		OrderNumberDate:      getEnv("ORDER_NUMBER_DATE_FORMAT", "YYMMDD"),
+		OrderNumberWidth:     getEnvAsInt("ORDER_NUMBER_SEQUENCE_WIDTH", 3),
+		OrderNumberReset:     getEnv("ORDER_NUMBER_RESET", "daily"),
+		LoadShedWindow:       getEnvAsDuration("LOAD_SHED_WINDOW", 30*time.Second),
+		LoadShedDBLatency:    getEnvAsDuration("LOAD_SHED_DB_LATENCY", 500*time.Millisecond),
+		LoadShedErrorRate:    getEnvAsFloat("LOAD_SHED_ERROR_RATE", 0.25),
+		RealtimeBroker:       getEnv("REALTIME_BROKER", "postgres"),
+		UploadDir:            getEnv("UPLOAD_DIR", "./uploads"),
+		APIURL:               strings.TrimSuffix(getEnv("API_URL", "http://localhost:8080"), "/"),
+		StorageQuotaMB:       getEnvAsInt("STORAGE_QUOTA_MB", 500),
+		MaxUploadMB:          getEnvAsInt("MAX_UPLOAD_MB", 2),
+		MediaCleanupDays:     getEnvAsInt("MEDIA_CLEANUP_DAYS", 7),
+		SelftestToken:        getEnv("SELFTEST_TOKEN", ""),
+		SelftestProductID:    getEnv("SELFTEST_PRODUCT_ID", ""),
+		ChaosEnabled:         getEnvAsBool("CHAOS_ENABLED", false),
+		OTPEnabled:           getEnvAsBool("OTP_ENABLED", true),
+		OTPChannels:          getEnvAsSlice("OTP_CHANNELS", []string{"email"}),
+		OTPExpiry:            getEnvAsDuration("OTP_EXPIRY", 5*time.Minute),
+		OTPMaxAttempts:       getEnvAsInt("OTP_MAX_ATTEMPTS", 5),
+		OTPRequestLimit:      getEnvAsInt("OTP_REQUEST_LIMIT", 3),
+		OTPRequestWindow:     getEnvAsDuration("OTP_REQUEST_WINDOW", 15*time.Minute),
+		WhatsAppToken:        getEnv("WHATSAPP_TOKEN", ""),
+		WhatsAppPhoneID:      getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
+		CampaignBatchSize:    getEnvAsInt("CAMPAIGN_BATCH_SIZE", 200),
+		DineInTax:            getEnvAsFloat("DINE_IN_TAX_PERCENT", 10),
+		DineInService:        getEnvAsFloat("DINE_IN_SERVICE_CHARGE_PERCENT", 0),
+		TakeawayTax:          getEnvAsFloat("TAKEAWAY_TAX_PERCENT", 10),
+		TakeawayService:      getEnvAsFloat("TAKEAWAY_SERVICE_CHARGE_PERCENT", 0),
+		DeliveryTax:          getEnvAsFloat("DELIVERY_TAX_PERCENT", 10),
+		DeliveryService:      getEnvAsFloat("DELIVERY_SERVICE_CHARGE_PERCENT", 0),
+		DeliveryFee:          getEnvAsFloat("DELIVERY_FEE", 0),
+		OrderLookupLimit:     getEnvAsInt("ORDER_LOOKUP_LIMIT", 5),
+		OrderLookupWindow:    getEnvAsDuration("ORDER_LOOKUP_WINDOW", 15*time.Minute),
+		RateLimitStore:       getEnv("RATE_LIMIT_STORE", "memory"),
+		GlobalRateLimit:      getEnvAsInt("RATE_LIMIT_GLOBAL", 0),
+		GlobalRateWindow:     getEnvAsDuration("RATE_LIMIT_GLOBAL_WINDOW", time.Minute),
+		LoginRateLimit:       getEnvAsInt("LOGIN_RATE_LIMIT", 10),
+		LoginRateWindow:      getEnvAsDuration("LOGIN_RATE_WINDOW", 15*time.Minute),
+		GuestOrderRateLimit:  getEnvAsInt("GUEST_ORDER_RATE_LIMIT", 10),
+		GuestOrderRateWindow: getEnvAsDuration("GUEST_ORDER_RATE_WINDOW", 10*time.Minute),
+		RushAfter:            getEnvAsDuration("ORDER_RUSH_AFTER", 15*time.Minute),
+		KioskOfflineAfter:    getEnvAsDuration("KIOSK_OFFLINE_AFTER", 2*time.Minute),
+		PreorderMaxDays:      getEnvAsInt("ORDER_PREORDER_MAX_DAYS", 7),
+		PreorderLeadTime:     getEnvAsDuration("ORDER_PREORDER_LEAD_TIME", 30*time.Minute),
+		ShareTokenTTL:        getEnvAsDuration("ORDER_SHARE_TOKEN_TTL", 24*time.Hour),
+		PrintAgentToken:      getEnv("PRINT_AGENT_TOKEN", ""),
+		PrintStation:         getEnv("PRINT_STATION", "counter"),
+		DineInStation:        getEnv("PRINT_STATION_DINE_IN", ""),
+		TakeawayStation:      getEnv("PRINT_STATION_TAKEAWAY", ""),
+		DeliveryStation:      getEnv("PRINT_STATION_DELIVERY", ""),
+	}
+
+	// An empty value would fall back to the default, so "none" drops the date
+	if cfg.OrderNumberDate == "none" {
+		cfg.OrderNumberDate = ""
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -72,8 +245,15 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DB_PASSWORD is required in production")
 	}
 
+	// Validate the lowest level written to the log
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("LOG_LEVEL must be one of 'debug', 'info', 'warn' or 'error'")
+	}
+
 	// Validate Midtrans configuration in production
-	if c.Env == "production" {
+	if c.Env == "production" && c.PaymentProvider == "midtrans" {
 		if c.MidtransServerKey == "" {
 			return fmt.Errorf("MIDTRANS_SERVER_KEY is required in production")
 		}
@@ -82,11 +262,202 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate the payment gateway customers are sent to
+	if c.PaymentProvider != "midtrans" && c.PaymentProvider != "stripe" && c.PaymentProvider != "xendit" {
+		return fmt.Errorf("PAYMENT_PROVIDER must be one of 'midtrans', 'stripe' or 'xendit'")
+	}
+	if c.PaymentProvider == "stripe" && (c.StripeSecretKey == "" || c.StripeWebhookSecret == "") {
+		return fmt.Errorf("STRIPE_SECRET_KEY and STRIPE_WEBHOOK_SECRET are required when PAYMENT_PROVIDER is 'stripe'")
+	}
+	if c.PaymentProvider == "xendit" && (c.XenditSecretKey == "" || c.XenditCallbackToken == "") {
+		return fmt.Errorf("XENDIT_SECRET_KEY and XENDIT_CALLBACK_TOKEN are required when PAYMENT_PROVIDER is 'xendit'")
+	}
+
 	// Validate Midtrans environment value
 	if c.MidtransEnvironment != "sandbox" && c.MidtransEnvironment != "production" {
 		return fmt.Errorf("MIDTRANS_ENVIRONMENT must be either 'sandbox' or 'production'")
 	}
 
+	// Validate the pages Snap sends customers back to
+	for _, callback := range []struct{ name, value string }{
+		{"MIDTRANS_FINISH_URL", c.MidtransFinishURL},
+		{"MIDTRANS_UNFINISH_URL", c.MidtransUnfinishURL},
+		{"MIDTRANS_ERROR_URL", c.MidtransErrorURL},
+	} {
+		if callback.value == "" {
+			continue
+		}
+		if u, err := url.Parse(callback.value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an absolute http(s) URL", callback.name)
+		}
+	}
+
+	// Client IPs come from ProxyHeader only on requests from a trusted proxy
+	for _, proxy := range c.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES has invalid address %q, expected an IP or CIDR range", proxy)
+		}
+	}
+	if len(c.TrustedProxies) > 0 && c.ProxyHeader == "" {
+		return fmt.Errorf("PROXY_HEADER is required when TRUSTED_PROXIES is set")
+	}
+
+	// Validate the guards in front of the Midtrans webhook; a limit of 0 turns
+	// rate limiting off
+	if c.WebhookRateLimit < 0 || c.WebhookRateWindow <= 0 {
+		return fmt.Errorf("MIDTRANS_WEBHOOK_RATE_LIMIT must not be negative and MIDTRANS_WEBHOOK_RATE_WINDOW must be positive")
+	}
+
+	// Validate where payment alerts go and how many bad signatures raise one
+	if c.AlertSlackURL != "" {
+		if u, err := url.Parse(c.AlertSlackURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("ALERT_SLACK_WEBHOOK_URL must be an absolute https URL")
+		}
+	}
+	if c.AlertSigFailures < 1 || c.AlertSigWindow <= 0 {
+		return fmt.Errorf("ALERT_SIGNATURE_FAILURES must be at least 1 and ALERT_SIGNATURE_WINDOW must be positive")
+	}
+
+	// Validate store locale and timezone used for customer-facing formatting
+	if c.StoreLocale != "id" && c.StoreLocale != "en" {
+		return fmt.Errorf("STORE_LOCALE must be either 'id' or 'en'")
+	}
+	if _, err := time.LoadLocation(c.StoreTimezone); err != nil {
+		return fmt.Errorf("STORE_TIMEZONE is not a valid IANA timezone: %w", err)
+	}
+
+	// Validate load shedding thresholds
+	if c.LoadShedErrorRate <= 0 || c.LoadShedErrorRate > 1 {
+		return fmt.Errorf("LOAD_SHED_ERROR_RATE must be between 0 and 1")
+	}
+	if c.LoadShedWindow < time.Second || c.LoadShedDBLatency <= 0 {
+		return fmt.Errorf("LOAD_SHED_WINDOW must be at least 1s and LOAD_SHED_DB_LATENCY must be positive")
+	}
+
+	if c.StockReservationTTL <= 0 {
+		return fmt.Errorf("STOCK_RESERVATION_TTL must be positive")
+	}
+
+	// Fault injection is for staging and must never reach customers
+	if c.ChaosEnabled && c.IsProduction() {
+		return fmt.Errorf("CHAOS_ENABLED must not be set in production")
+	}
+
+	// Validate realtime broker; local only reaches clients on this instance
+	if c.RealtimeBroker != "postgres" && c.RealtimeBroker != "local" {
+		return fmt.Errorf("REALTIME_BROKER must be either 'postgres' or 'local'")
+	}
+
+	// Validate media storage limits
+	if c.StorageQuotaMB < 1 || c.MaxUploadMB < 1 {
+		return fmt.Errorf("STORAGE_QUOTA_MB and MAX_UPLOAD_MB must be at least 1")
+	}
+	if c.PaymentLinkTTL <= 0 {
+		return fmt.Errorf("PAYMENT_LINK_TTL must be positive")
+	}
+
+	if c.PayoutDelayDays < 0 {
+		return fmt.Errorf("PAYOUT_DELAY_DAYS must not be negative")
+	}
+
+	if c.MediaCleanupDays < 1 {
+		return fmt.Errorf("MEDIA_CLEANUP_DAYS must be at least 1")
+	}
+
+	// The self-test places real orders, so it needs a dedicated product and a strong token
+	if c.SelftestToken != "" {
+		if len(c.SelftestToken) < 32 {
+			return fmt.Errorf("SELFTEST_TOKEN must be at least 32 characters long")
+		}
+		if c.SelftestProductID == "" {
+			return fmt.Errorf("SELFTEST_PRODUCT_ID is required when SELFTEST_TOKEN is set")
+		}
+	}
+
+	// Validate tax and service charge rates per order type
+	for _, percent := range []float64{
+		c.DineInTax, c.DineInService, c.TakeawayTax, c.TakeawayService, c.DeliveryTax, c.DeliveryService,
+	} {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("tax and service charge percentages must be between 0 and 100")
+		}
+	}
+	if c.DeliveryFee < 0 {
+		return fmt.Errorf("DELIVERY_FEE cannot be negative")
+	}
+
+	// Guest order lookup must stay rate limited to prevent enumeration
+	if c.OrderLookupLimit < 1 || c.OrderLookupWindow <= 0 {
+		return fmt.Errorf("ORDER_LOOKUP_LIMIT must be at least 1 and ORDER_LOOKUP_WINDOW must be positive")
+	}
+
+	// Validate rate limits; memory counts per instance, postgres across all
+	if c.RateLimitStore != "memory" && c.RateLimitStore != "postgres" {
+		return fmt.Errorf("RATE_LIMIT_STORE must be either 'memory' or 'postgres'")
+	}
+	if c.GlobalRateLimit < 0 || c.LoginRateLimit < 0 || c.GuestOrderRateLimit < 0 {
+		return fmt.Errorf("RATE_LIMIT_GLOBAL, LOGIN_RATE_LIMIT and GUEST_ORDER_RATE_LIMIT must not be negative")
+	}
+	if c.GlobalRateWindow <= 0 || c.LoginRateWindow <= 0 || c.GuestOrderRateWindow <= 0 {
+		return fmt.Errorf("RATE_LIMIT_GLOBAL_WINDOW, LOGIN_RATE_WINDOW and GUEST_ORDER_RATE_WINDOW must be positive")
+	}
+
+	if c.RushAfter < 0 {
+		return fmt.Errorf("ORDER_RUSH_AFTER must not be negative")
+	}
+
+	if c.KioskOfflineAfter <= 0 {
+		return fmt.Errorf("KIOSK_OFFLINE_AFTER must be positive")
+	}
+
+	if c.PreorderMaxDays < 0 || c.PreorderLeadTime < 0 {
+		return fmt.Errorf("ORDER_PREORDER_MAX_DAYS and ORDER_PREORDER_LEAD_TIME must not be negative")
+	}
+
+	if c.ShareTokenTTL <= 0 {
+		return fmt.Errorf("ORDER_SHARE_TOKEN_TTL must be positive")
+	}
+
+	// Print agents pull tickets with customer details, so the token must be strong
+	if c.PrintAgentToken != "" && len(c.PrintAgentToken) < 32 {
+		return fmt.Errorf("PRINT_AGENT_TOKEN must be at least 32 characters long")
+	}
+	if c.PrintStation == "" {
+		return fmt.Errorf("PRINT_STATION is required")
+	}
+
+	// Validate one-time code login
+	if c.OTPEnabled {
+		for _, channel := range c.OTPChannels {
+			if channel != "email" && channel != "whatsapp" {
+				return fmt.Errorf("OTP_CHANNELS may only contain 'email' and 'whatsapp'")
+			}
+		}
+		if c.OTPExpiry <= 0 || c.OTPRequestWindow <= 0 {
+			return fmt.Errorf("OTP_EXPIRY and OTP_REQUEST_WINDOW must be positive")
+		}
+		if c.OTPMaxAttempts < 1 || c.OTPRequestLimit < 1 {
+			return fmt.Errorf("OTP_MAX_ATTEMPTS and OTP_REQUEST_LIMIT must be at least 1")
+		}
+		// Without a real sender the code is only logged, letting anyone who
+		// reads the logs sign in as any member
+		if c.IsProduction() {
+			if slices.Contains(c.OTPChannels, "email") && c.SMTPHost == "" {
+				return fmt.Errorf("SMTP_HOST is required in production when OTP_CHANNELS contains 'email'")
+			}
+			if slices.Contains(c.OTPChannels, "whatsapp") && c.WhatsAppToken == "" {
+				return fmt.Errorf("WHATSAPP_TOKEN is required in production when OTP_CHANNELS contains 'whatsapp'")
+			}
+		}
+	}
+
+	if c.CampaignBatchSize < 1 {
+		return fmt.Errorf("CAMPAIGN_BATCH_SIZE must be at least 1")
+	}
+
 	return nil
 }
 
@@ -125,6 +496,30 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.Atoi(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
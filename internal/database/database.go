@@ -2,34 +2,41 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/carllix/matchaciee-backend/internal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+var (
+	DB *gorm.DB
+
+	// dbLogger reports connection lifecycle events, set by Connect
+	dbLogger = slog.Default()
+)
+
+func Connect(cfg *config.Config, logger *slog.Logger) error {
+	dbLogger = logger
 
-func Connect(cfg *config.Config) error {
 	var err error
 
-	logLevel := logger.Silent
+	logLevel := gormlogger.Silent
 	if cfg.IsDevelopment() {
 		switch cfg.LogLevel {
 		case "debug":
-			logLevel = logger.Info
+			logLevel = gormlogger.Info
 		case "info":
-			logLevel = logger.Warn
+			logLevel = gormlogger.Warn
 		default:
-			logLevel = logger.Error
+			logLevel = gormlogger.Error
 		}
 	}
 
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+		Logger: gormlogger.Default.LogMode(logLevel),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -54,7 +61,7 @@ func Connect(cfg *config.Config) error {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Database connection established")
+	dbLogger.Info("Database connection established", "host", cfg.DBHost, "database", cfg.DBName)
 	return nil
 }
 
@@ -72,7 +79,7 @@ func Close() error {
 		return fmt.Errorf("failed to close database: %w", err)
 	}
 
-	log.Println("Database connection closed")
+	dbLogger.Info("Database connection closed")
 	return nil
 }
 
@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_stock_reservations_active;
+DROP INDEX IF EXISTS idx_stock_reservations_order;
+DROP INDEX IF EXISTS idx_stock_reservations_product;
+DROP INDEX IF EXISTS idx_stock_reservations_uuid;
+
+-- Drop stock_reservations table
+DROP TABLE IF EXISTS stock_reservations;
+
+-- Drop stock cap from products
+ALTER TABLE products DROP COLUMN IF EXISTS stock_quantity;
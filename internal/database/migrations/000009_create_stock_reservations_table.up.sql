@@ -0,0 +1,27 @@
+-- Add optional stock cap to products (NULL = unlimited)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock_quantity INT NULL CHECK (stock_quantity >= 0);
+
+-- Create stock_reservations table for short-lived checkout holds
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'consumed', 'released')),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_uuid ON stock_reservations(uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_product ON stock_reservations(product_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order ON stock_reservations(order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_active ON stock_reservations(product_id, status, expires_at);
+
+-- Add comments
+COMMENT ON COLUMN products.stock_quantity IS 'Remaining sellable units for capped/flash-sale items (NULL = unlimited)';
+COMMENT ON TABLE stock_reservations IS 'Short TTL holds on capped stock placed at checkout, released if payment is not started';
+COMMENT ON COLUMN stock_reservations.status IS 'active: holding stock, consumed: deducted from stock at payment start, released: returned';
+COMMENT ON COLUMN stock_reservations.expires_at IS 'Active reservations past this time no longer hold stock';
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_orders_payment_expires;
+
+-- Drop payment deadline from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_expires_at;
@@ -0,0 +1,8 @@
+-- Add payment deadline to orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_expires_at TIMESTAMP NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_orders_payment_expires ON orders(payment_expires_at);
+
+-- Add comments
+COMMENT ON COLUMN orders.payment_expires_at IS 'Deadline for paying a pending order (shared with the payment-expiry job)';
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_categories_deleted;
+
+-- Drop soft delete from categories
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
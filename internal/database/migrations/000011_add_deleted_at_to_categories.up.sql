@@ -0,0 +1,8 @@
+-- Add soft delete to categories
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_categories_deleted ON categories(deleted_at);
+
+-- Add comments
+COMMENT ON COLUMN categories.deleted_at IS 'Soft delete timestamp; products in a deleted category are hidden until it is restored';
@@ -0,0 +1,9 @@
+-- Fold the newer channels back into kiosk so the original constraint holds
+UPDATE orders SET order_source = 'kiosk' WHERE order_source IN ('partner', 'phone', 'catering');
+
+-- Restore original order source constraint
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_source_check;
+ALTER TABLE orders ADD CONSTRAINT orders_order_source_check
+    CHECK (order_source IN ('guest', 'member', 'kiosk'));
+
+COMMENT ON COLUMN orders.order_source IS NULL;
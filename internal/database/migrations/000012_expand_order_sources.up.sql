@@ -0,0 +1,7 @@
+-- Allow partner, phone, and catering orders
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_order_source_check;
+ALTER TABLE orders ADD CONSTRAINT orders_order_source_check
+    CHECK (order_source IN ('guest', 'member', 'kiosk', 'partner', 'phone', 'catering'));
+
+-- Add comments
+COMMENT ON COLUMN orders.order_source IS 'Channel the order came from: guest, member, kiosk, partner, phone, or catering';
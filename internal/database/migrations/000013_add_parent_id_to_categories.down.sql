@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_categories_parent;
+
+-- Drop parent category
+ALTER TABLE categories DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,8 @@
+-- Add parent category for nested subcategories
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id INT NULL REFERENCES categories(id) ON DELETE SET NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id);
+
+-- Add comments
+COMMENT ON COLUMN categories.parent_id IS 'Parent category, NULL for top-level categories (max depth enforced in the service)';
@@ -0,0 +1,6 @@
+-- Drop recorded pricing from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS source_fee;
+ALTER TABLE orders DROP COLUMN IF EXISTS price_adjustment_percent;
+
+-- Drop source pricing table
+DROP TABLE IF EXISTS source_pricing;
@@ -0,0 +1,20 @@
+-- Create source pricing table for channel-specific price adjustments
+CREATE TABLE IF NOT EXISTS source_pricing (
+    id SERIAL PRIMARY KEY,
+    order_source VARCHAR(20) UNIQUE NOT NULL CHECK (order_source IN ('guest', 'member', 'kiosk', 'partner', 'phone', 'catering')),
+    price_adjustment_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (price_adjustment_percent >= -100),
+    flat_fee DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (flat_fee >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Record the pricing applied to each order
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS price_adjustment_percent DECIMAL(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS source_fee DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (source_fee >= 0);
+
+-- Add comments
+COMMENT ON TABLE source_pricing IS 'Price adjustments per order source, e.g. +20% on partner platforms to cover commission';
+COMMENT ON COLUMN source_pricing.price_adjustment_percent IS 'Percentage applied to every unit price for orders from this source';
+COMMENT ON COLUMN source_pricing.flat_fee IS 'Fixed fee added once per order from this source';
+COMMENT ON COLUMN orders.price_adjustment_percent IS 'Source price adjustment applied when the order was created';
+COMMENT ON COLUMN orders.source_fee IS 'Source flat fee charged on this order';
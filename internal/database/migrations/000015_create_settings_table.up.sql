@@ -0,0 +1,11 @@
+-- Create settings table for admin-configurable key/value settings
+CREATE TABLE IF NOT EXISTS settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comments
+COMMENT ON TABLE settings IS 'Admin-configurable settings grouped by key (e.g. receipt)';
+COMMENT ON COLUMN settings.value IS 'JSON document holding every field of the setting group';
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_email_templates_active;
+
+-- Drop email templates table
+DROP TABLE IF EXISTS email_templates;
@@ -0,0 +1,46 @@
+-- Create email templates table; every edit is stored as a new version
+CREATE TABLE IF NOT EXISTS email_templates (
+    id SERIAL PRIMARY KEY,
+    key VARCHAR(50) NOT NULL CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset')),
+    version INTEGER NOT NULL CHECK (version > 0),
+    subject VARCHAR(255) NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (key, version)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_active ON email_templates(key) WHERE is_active = true;
+
+-- Seed the initial version of each template
+INSERT INTO email_templates (key, version, subject, html_body, text_body, is_active) VALUES
+(
+    'order_confirmation', 1,
+    'Your Matchaciee order {{order_number}}',
+    '<p>Hi {{customer_name}},</p><p>Thanks for your order <strong>{{order_number}}</strong>. Your total is {{total}}.</p><p>We''ll let you know when it''s ready.</p>',
+    E'Hi {{customer_name}},\n\nThanks for your order {{order_number}}. Your total is {{total}}.\n\nWe''ll let you know when it''s ready.',
+    true
+),
+(
+    'order_ready', 1,
+    'Order {{order_number}} is ready',
+    '<p>Hi {{customer_name}},</p><p>Your order <strong>{{order_number}}</strong> is ready for pickup.</p>',
+    E'Hi {{customer_name}},\n\nYour order {{order_number}} is ready for pickup.',
+    true
+),
+(
+    'password_reset', 1,
+    'Reset your Matchaciee password',
+    '<p>Hi {{name}},</p><p><a href="{{reset_url}}">Reset your password</a>. This link expires in {{expires_in}}.</p><p>If you didn''t ask for this, you can ignore this email.</p>',
+    E'Hi {{name}},\n\nReset your password: {{reset_url}}\nThis link expires in {{expires_in}}.\n\nIf you didn''t ask for this, you can ignore this email.',
+    true
+)
+ON CONFLICT (key, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE email_templates IS 'Versioned notification email templates, edited by admins without a deploy';
+COMMENT ON COLUMN email_templates.key IS 'Notification the template is used for';
+COMMENT ON COLUMN email_templates.is_active IS 'Only one version per key is active and used for sending';
+COMMENT ON COLUMN email_templates.subject IS 'Subject line; supports {{variable}} placeholders like the bodies';
@@ -0,0 +1,10 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS trg_product_customizations_catalog_version ON product_customizations;
+DROP TRIGGER IF EXISTS trg_products_catalog_version ON products;
+DROP TRIGGER IF EXISTS trg_categories_catalog_version ON categories;
+
+-- Drop function
+DROP FUNCTION IF EXISTS bump_catalog_version();
+
+-- Drop catalog version table
+DROP TABLE IF EXISTS catalog_version;
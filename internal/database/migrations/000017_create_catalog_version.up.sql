@@ -0,0 +1,36 @@
+-- Create single-row catalog version table, bumped on any catalog change
+CREATE TABLE IF NOT EXISTS catalog_version (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO catalog_version (id) VALUES (true) ON CONFLICT (id) DO NOTHING;
+
+-- Bump the version once per statement touching categories, products or customizations
+CREATE OR REPLACE FUNCTION bump_catalog_version() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE catalog_version SET version = version + 1, updated_at = CURRENT_TIMESTAMP;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create triggers
+DROP TRIGGER IF EXISTS trg_categories_catalog_version ON categories;
+CREATE TRIGGER trg_categories_catalog_version
+    AFTER INSERT OR UPDATE OR DELETE ON categories
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_version();
+
+DROP TRIGGER IF EXISTS trg_products_catalog_version ON products;
+CREATE TRIGGER trg_products_catalog_version
+    AFTER INSERT OR UPDATE OR DELETE ON products
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_version();
+
+DROP TRIGGER IF EXISTS trg_product_customizations_catalog_version ON product_customizations;
+CREATE TRIGGER trg_product_customizations_catalog_version
+    AFTER INSERT OR UPDATE OR DELETE ON product_customizations
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_version();
+
+-- Add comments
+COMMENT ON TABLE catalog_version IS 'Catalog version used as the ETag for catalog endpoints';
+COMMENT ON COLUMN catalog_version.version IS 'Incremented by triggers on every category, product or customization change';
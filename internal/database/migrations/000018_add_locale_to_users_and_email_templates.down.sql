@@ -0,0 +1,12 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_email_templates_active;
+
+-- Keep only the English templates
+DELETE FROM email_templates WHERE locale <> 'en';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_locale_version_key;
+ALTER TABLE email_templates DROP COLUMN IF EXISTS locale;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_version_key UNIQUE (key, version);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_active ON email_templates(key) WHERE is_active = true;
+
+-- Remove locale from users
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
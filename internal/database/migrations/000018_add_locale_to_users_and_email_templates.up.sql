@@ -0,0 +1,40 @@
+-- Add preferred language to users; NULL falls back to the request's Accept-Language
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(5) CHECK (locale IN ('id', 'en'));
+
+-- Keep a separate version history per language for each email template
+ALTER TABLE email_templates ADD COLUMN IF NOT EXISTS locale VARCHAR(5) NOT NULL DEFAULT 'en' CHECK (locale IN ('id', 'en'));
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_version_key;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_locale_version_key UNIQUE (key, locale, version);
+
+-- Create indexes
+DROP INDEX IF EXISTS idx_email_templates_active;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_active ON email_templates(key, locale) WHERE is_active = true;
+
+-- Seed Indonesian templates
+INSERT INTO email_templates (key, locale, version, subject, html_body, text_body, is_active) VALUES
+(
+    'order_confirmation', 'id', 1,
+    'Pesanan Matchaciee {{order_number}}',
+    '<p>Hai {{customer_name}},</p><p>Terima kasih atas pesanan <strong>{{order_number}}</strong>. Total pembayaran Anda {{total}}.</p><p>Kami akan mengabari Anda saat pesanan siap.</p>',
+    E'Hai {{customer_name}},\n\nTerima kasih atas pesanan {{order_number}}. Total pembayaran Anda {{total}}.\n\nKami akan mengabari Anda saat pesanan siap.',
+    true
+),
+(
+    'order_ready', 'id', 1,
+    'Pesanan {{order_number}} sudah siap',
+    '<p>Hai {{customer_name}},</p><p>Pesanan <strong>{{order_number}}</strong> sudah siap diambil.</p>',
+    E'Hai {{customer_name}},\n\nPesanan {{order_number}} sudah siap diambil.',
+    true
+),
+(
+    'password_reset', 'id', 1,
+    'Atur ulang kata sandi Matchaciee',
+    '<p>Hai {{name}},</p><p><a href="{{reset_url}}">Atur ulang kata sandi Anda</a>. Tautan ini berlaku selama {{expires_in}}.</p><p>Abaikan email ini jika Anda tidak memintanya.</p>',
+    E'Hai {{name}},\n\nAtur ulang kata sandi Anda: {{reset_url}}\nTautan ini berlaku selama {{expires_in}}.\n\nAbaikan email ini jika Anda tidak memintanya.',
+    true
+)
+ON CONFLICT (key, locale, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON COLUMN users.locale IS 'Preferred language for notifications and API messages (id or en)';
+COMMENT ON COLUMN email_templates.locale IS 'Language of this template version (id or en)';
@@ -0,0 +1,2 @@
+-- Drop job_locks table
+DROP TABLE IF EXISTS job_locks;
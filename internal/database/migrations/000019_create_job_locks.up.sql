@@ -0,0 +1,12 @@
+-- Create job_locks table used as a lease so singleton jobs run on one instance
+CREATE TABLE IF NOT EXISTS job_locks (
+    name VARCHAR(100) PRIMARY KEY,
+    owner VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    acquired_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comments
+COMMENT ON TABLE job_locks IS 'Leases held by the scheduler so each background job runs on a single instance';
+COMMENT ON COLUMN job_locks.owner IS 'Instance ID currently holding the lease';
+COMMENT ON COLUMN job_locks.expires_at IS 'Lease end; another instance may take over once it has passed';
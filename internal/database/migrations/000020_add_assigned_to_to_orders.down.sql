@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_orders_assigned_to;
+
+-- Drop barista assignment
+ALTER TABLE orders DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS assigned_to;
@@ -0,0 +1,10 @@
+-- Add barista assignment to orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS assigned_to INT NULL REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_orders_assigned_to ON orders(assigned_to);
+
+-- Add comments
+COMMENT ON COLUMN orders.assigned_to IS 'Staff member who claimed the order for preparation, NULL while unclaimed';
+COMMENT ON COLUMN orders.assigned_at IS 'When the order was claimed';
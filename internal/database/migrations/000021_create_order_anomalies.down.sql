@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_order_anomalies_unique;
+DROP INDEX IF EXISTS idx_order_anomalies_open;
+DROP INDEX IF EXISTS idx_order_anomalies_order;
+DROP INDEX IF EXISTS idx_order_anomalies_uuid;
+
+-- Drop order_anomalies table
+DROP TABLE IF EXISTS order_anomalies;
@@ -0,0 +1,22 @@
+-- Create order_anomalies table filled by the periodic order integrity check
+CREATE TABLE IF NOT EXISTS order_anomalies (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id INT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('unlinked_item', 'item_subtotal_mismatch', 'subtotal_mismatch', 'total_mismatch')),
+    detail TEXT NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_order_anomalies_uuid ON order_anomalies(uuid);
+CREATE INDEX IF NOT EXISTS idx_order_anomalies_order ON order_anomalies(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_anomalies_open ON order_anomalies(detected_at) WHERE resolved_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_anomalies_unique ON order_anomalies(order_id, (COALESCE(order_item_id, 0)), kind);
+
+-- Add comments
+COMMENT ON TABLE order_anomalies IS 'Orders whose stored snapshot or totals failed the integrity check';
+COMMENT ON COLUMN order_anomalies.order_item_id IS 'Offending item for item-level anomalies, NULL for order-level ones';
+COMMENT ON COLUMN order_anomalies.resolved_at IS 'Set when an admin has reviewed the anomaly; resolved anomalies are not reported again';
@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_cart_items_cart;
+DROP INDEX IF EXISTS idx_cart_items_uuid;
+DROP INDEX IF EXISTS idx_carts_updated_at;
+DROP INDEX IF EXISTS idx_carts_session;
+DROP INDEX IF EXISTS idx_carts_user;
+DROP INDEX IF EXISTS idx_carts_uuid;
+
+-- Drop tables
+DROP TABLE IF EXISTS cart_items;
+DROP TABLE IF EXISTS carts;
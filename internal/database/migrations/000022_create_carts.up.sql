@@ -0,0 +1,38 @@
+-- Create carts table; a cart belongs to a member or to a kiosk session
+CREATE TABLE IF NOT EXISTS carts (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    user_id INT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id VARCHAR(64) NULL,
+    checkout_started_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((user_id IS NULL) <> (session_id IS NULL))
+);
+
+-- Create cart_items table
+CREATE TABLE IF NOT EXISTS cart_items (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    cart_id INT NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    customizations JSONB,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_carts_uuid ON carts(uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_user ON carts(user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_session ON carts(session_id) WHERE session_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_carts_updated_at ON carts(updated_at);
+CREATE INDEX IF NOT EXISTS idx_cart_items_uuid ON cart_items(uuid);
+CREATE INDEX IF NOT EXISTS idx_cart_items_cart ON cart_items(cart_id);
+
+-- Add comments
+COMMENT ON TABLE carts IS 'Server-side carts built up item by item before checkout';
+COMMENT ON COLUMN carts.session_id IS 'Kiosk session identifier chosen by the kiosk device, NULL for member carts';
+COMMENT ON COLUMN carts.checkout_started_at IS 'Set while the cart is being converted into an order so it cannot be checked out twice';
+COMMENT ON COLUMN cart_items.customizations IS 'Chosen options as [{customization_id, option_name}], priced at checkout';
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_api_usage_client;
+DROP INDEX IF EXISTS idx_api_usage_unique;
+
+-- Drop api_usage table
+DROP TABLE IF EXISTS api_usage;
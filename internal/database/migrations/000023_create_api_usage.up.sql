@@ -0,0 +1,22 @@
+-- Create api_usage table holding hourly request aggregates per client and route
+CREATE TABLE IF NOT EXISTS api_usage (
+    id SERIAL PRIMARY KEY,
+    bucket_start TIMESTAMP NOT NULL,
+    client VARCHAR(50) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(255) NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    client_errors BIGINT NOT NULL DEFAULT 0,
+    server_errors BIGINT NOT NULL DEFAULT 0,
+    total_latency_ms BIGINT NOT NULL DEFAULT 0,
+    max_latency_ms BIGINT NOT NULL DEFAULT 0
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_usage_unique ON api_usage(bucket_start, client, method, route);
+CREATE INDEX IF NOT EXISTS idx_api_usage_client ON api_usage(client, bucket_start);
+
+-- Add comments
+COMMENT ON TABLE api_usage IS 'Hourly request counts, errors and latency per API client and route';
+COMMENT ON COLUMN api_usage.client IS 'X-Client-Name header when sent, otherwise derived from the User-Agent';
+COMMENT ON COLUMN api_usage.route IS 'Route pattern such as /api/v1/orders/:id, never the raw path';
@@ -0,0 +1,12 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_login_codes_expires_at;
+DROP INDEX IF EXISTS idx_login_codes_user_created;
+
+-- Restore the email template keys
+DELETE FROM email_templates WHERE key = 'login_code';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset'));
+
+-- Drop tables
+DROP TABLE IF EXISTS login_codes;
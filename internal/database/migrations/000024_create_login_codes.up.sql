@@ -0,0 +1,44 @@
+-- Create login_codes table for password-less login
+CREATE TABLE IF NOT EXISTS login_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'whatsapp')),
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Allow a template for the login code email
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset', 'login_code'));
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_login_codes_user_created ON login_codes(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_codes_expires_at ON login_codes(expires_at);
+
+-- Seed the login code templates
+INSERT INTO email_templates (key, locale, version, subject, html_body, text_body, is_active) VALUES
+(
+    'login_code', 'en', 1,
+    'Your Matchaciee login code',
+    '<p>Hi {{name}},</p><p>Your login code is <strong>{{code}}</strong>. It expires in {{expires_in}}.</p><p>If you didn''t ask for this, you can ignore this email.</p>',
+    E'Hi {{name}},\n\nYour login code is {{code}}. It expires in {{expires_in}}.\n\nIf you didn''t ask for this, you can ignore this email.',
+    true
+),
+(
+    'login_code', 'id', 1,
+    'Kode masuk Matchaciee Anda',
+    '<p>Hai {{name}},</p><p>Kode masuk Anda <strong>{{code}}</strong>. Kode berlaku selama {{expires_in}}.</p><p>Jika Anda tidak memintanya, abaikan email ini.</p>',
+    E'Hai {{name}},\n\nKode masuk Anda {{code}}. Kode berlaku selama {{expires_in}}.\n\nJika Anda tidak memintanya, abaikan email ini.',
+    true
+)
+ON CONFLICT (key, locale, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE login_codes IS 'One-time codes for password-less login, sent by email or WhatsApp';
+COMMENT ON COLUMN login_codes.code_hash IS 'HMAC of the code; the code itself is never stored';
+COMMENT ON COLUMN login_codes.attempts IS 'Wrong guesses against this code; it stops working after the configured maximum';
+COMMENT ON COLUMN login_codes.consumed_at IS 'Set once the code has been used to log in';
@@ -0,0 +1,8 @@
+-- Restore the email template keys
+DELETE FROM email_templates WHERE key = 'order_receipt';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset', 'login_code'));
+
+-- Drop receipt tracking from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS receipt_sent_at;
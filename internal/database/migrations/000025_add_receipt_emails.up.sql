@@ -0,0 +1,28 @@
+-- Remember which orders had their receipt emailed so it is sent only once
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS receipt_sent_at TIMESTAMP NULL;
+
+-- Allow a template for the receipt email
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset', 'login_code', 'order_receipt'));
+
+-- Seed the receipt templates
+INSERT INTO email_templates (key, locale, version, subject, html_body, text_body, is_active) VALUES
+(
+    'order_receipt', 'en', 1,
+    'Your Matchaciee receipt for {{order_number}}',
+    '<p>Hi {{customer_name}},</p><p>Thanks for ordering with us. Here is the receipt for order <strong>{{order_number}}</strong>, total {{total}}.</p><pre>{{receipt}}</pre>',
+    E'Hi {{customer_name}},\n\nThanks for ordering with us. Here is the receipt for order {{order_number}}, total {{total}}.\n\n{{receipt}}',
+    true
+),
+(
+    'order_receipt', 'id', 1,
+    'Struk Matchaciee untuk pesanan {{order_number}}',
+    '<p>Hai {{customer_name}},</p><p>Terima kasih telah memesan. Berikut struk pesanan <strong>{{order_number}}</strong> dengan total {{total}}.</p><pre>{{receipt}}</pre>',
+    E'Hai {{customer_name}},\n\nTerima kasih telah memesan. Berikut struk pesanan {{order_number}} dengan total {{total}}.\n\n{{receipt}}',
+    true
+)
+ON CONFLICT (key, locale, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON COLUMN orders.receipt_sent_at IS 'When the receipt was emailed to the customer, NULL if it was not';
@@ -0,0 +1,4 @@
+-- Drop guest contact from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS confirmation_sent_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_phone;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_email;
@@ -0,0 +1,9 @@
+-- Let guests leave an email address or phone number for order updates
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_email VARCHAR(255) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_phone VARCHAR(20) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS confirmation_sent_at TIMESTAMP NULL;
+
+-- Add comments
+COMMENT ON COLUMN orders.customer_email IS 'Email address a guest gave at checkout for the tracking link and receipt';
+COMMENT ON COLUMN orders.customer_phone IS 'WhatsApp number a guest gave at checkout for the tracking link and receipt';
+COMMENT ON COLUMN orders.confirmation_sent_at IS 'When the tracking link was sent to the guest, NULL if it was not';
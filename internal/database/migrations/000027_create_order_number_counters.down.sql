@@ -0,0 +1,2 @@
+-- Drop order_number_counters table
+DROP TABLE IF EXISTS order_number_counters;
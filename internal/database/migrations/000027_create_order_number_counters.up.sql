@@ -0,0 +1,20 @@
+-- Create order_number_counters table; one row per sequence period
+CREATE TABLE IF NOT EXISTS order_number_counters (
+    period VARCHAR(20) PRIMARY KEY,
+    last_sequence INT NOT NULL CHECK (last_sequence > 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Carry over the sequences of orders numbered with the original MC-YYMMDD-XXX scheme
+INSERT INTO order_number_counters (period, last_sequence)
+SELECT
+    '20' || substring(order_number FROM 4 FOR 2) || '-' || substring(order_number FROM 6 FOR 2) || '-' || substring(order_number FROM 8 FOR 2),
+    MAX(CAST(substring(order_number FROM 11) AS INT))
+FROM orders
+WHERE order_number ~ '^MC-[0-9]{6}-[0-9]+$'
+GROUP BY 1
+ON CONFLICT (period) DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE order_number_counters IS 'Last order number sequence issued per period, so numbering does not depend on parsing earlier numbers';
+COMMENT ON COLUMN order_number_counters.period IS 'Day, month or year the sequence belongs to depending on ORDER_NUMBER_RESET, or all when it never resets';
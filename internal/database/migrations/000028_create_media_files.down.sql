@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_media_files_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS media_files;
@@ -0,0 +1,18 @@
+-- Create media_files table to track uploaded images and storage usage
+CREATE TABLE IF NOT EXISTS media_files (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    file_name VARCHAR(100) UNIQUE NOT NULL,
+    original_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    url VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_media_files_created_at ON media_files(created_at);
+
+-- Add comments
+COMMENT ON COLUMN media_files.file_name IS 'Name of the file inside the upload directory';
+COMMENT ON COLUMN media_files.url IS 'Public URL stored in product and category image_url';
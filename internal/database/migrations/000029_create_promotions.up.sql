@@ -0,0 +1,50 @@
+-- Create promotions table for promo codes redeemed at checkout
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    code VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT NULL,
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(10,2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10,2) NULL CHECK (max_discount > 0),
+    min_spend DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    usage_limit INT NULL CHECK (usage_limit > 0),
+    per_customer_limit INT NULL CHECK (per_customer_limit > 0),
+    starts_at TIMESTAMP NULL,
+    expires_at TIMESTAMP NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (expires_at IS NULL OR starts_at IS NULL OR expires_at > starts_at)
+);
+
+-- A promotion limited to products or categories discounts only those items
+CREATE TABLE IF NOT EXISTS promotion_products (
+    promotion_id INT NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    PRIMARY KEY (promotion_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS promotion_categories (
+    promotion_id INT NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (promotion_id, category_id)
+);
+
+-- Record the redeemed code and its discount on the order
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promotion_id INT NULL REFERENCES promotions(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code VARCHAR(50) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_orders_promotion_id ON orders(promotion_id);
+CREATE INDEX IF NOT EXISTS idx_orders_promo_code ON orders(promo_code);
+
+-- Add comments
+COMMENT ON COLUMN promotions.code IS 'Upper-case code customers enter at checkout';
+COMMENT ON COLUMN promotions.max_discount IS 'Cap on the discount of a percentage promotion';
+COMMENT ON COLUMN promotions.usage_limit IS 'Redemptions allowed across all customers; cancelled orders do not count';
+COMMENT ON COLUMN promotions.per_customer_limit IS 'Redemptions allowed per member account';
+COMMENT ON COLUMN orders.promo_code IS 'Code as redeemed, kept for reporting after the promotion is deleted';
+COMMENT ON COLUMN orders.discount IS 'Amount taken off the subtotal before tax';
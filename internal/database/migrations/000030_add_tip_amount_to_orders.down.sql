@@ -0,0 +1,2 @@
+-- Drop tips from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS tip_amount;
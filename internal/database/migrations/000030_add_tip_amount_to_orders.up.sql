@@ -0,0 +1,5 @@
+-- Record tips separately from the order total
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tip_amount DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (tip_amount >= 0);
+
+-- Add comments
+COMMENT ON COLUMN orders.tip_amount IS 'Tip paid on top of the total; charged with the payment but kept out of revenue so it can be distributed to staff';
@@ -0,0 +1,4 @@
+-- Drop order type from orders
+DROP INDEX IF EXISTS idx_orders_order_type;
+ALTER TABLE orders DROP COLUMN IF EXISTS service_charge;
+ALTER TABLE orders DROP COLUMN IF EXISTS order_type;
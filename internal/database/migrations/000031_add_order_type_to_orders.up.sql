@@ -0,0 +1,10 @@
+-- Record how the order is served, which decides its tax and service charge
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS order_type VARCHAR(20) NOT NULL DEFAULT 'takeaway'
+    CHECK (order_type IN ('dine_in', 'takeaway', 'delivery'));
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_charge DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (service_charge >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_orders_order_type ON orders(order_type);
+
+-- Add comments
+COMMENT ON COLUMN orders.order_type IS 'How the order is served: dine_in, takeaway or delivery';
+COMMENT ON COLUMN orders.service_charge IS 'Service charge for the order type on the discounted subtotal; taxed and included in the total';
@@ -0,0 +1,7 @@
+-- Drop the table from orders
+DROP INDEX IF EXISTS idx_orders_table_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS table_number;
+ALTER TABLE orders DROP COLUMN IF EXISTS table_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS dining_tables;
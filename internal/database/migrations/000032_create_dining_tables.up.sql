@@ -0,0 +1,22 @@
+-- Create dining tables whose QR codes open the menu for ordering at the table
+CREATE TABLE IF NOT EXISTS dining_tables (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    number VARCHAR(20) UNIQUE NOT NULL,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Record the table an order is served to
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_id INT NULL REFERENCES dining_tables(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_number VARCHAR(20) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_table_id ON orders(table_id);
+
+-- Add comments
+COMMENT ON TABLE dining_tables IS 'Tables customers can order from by scanning their QR code';
+COMMENT ON COLUMN dining_tables.number IS 'Table number shown to customers and baristas';
+COMMENT ON COLUMN dining_tables.token IS 'Secret in the table QR code; regenerate it to retire printed codes';
+COMMENT ON COLUMN orders.table_number IS 'Table number at the time of ordering, kept when the table is renamed or removed';
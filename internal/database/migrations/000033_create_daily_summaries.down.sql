@@ -0,0 +1,7 @@
+-- Drop the close-out marks from orders
+DROP INDEX IF EXISTS idx_orders_flagged_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS flagged_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS closed_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS daily_summaries;
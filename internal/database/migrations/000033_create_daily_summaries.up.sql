@@ -0,0 +1,36 @@
+-- Create daily summaries, one per closed business day
+CREATE TABLE IF NOT EXISTS daily_summaries (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    business_date DATE UNIQUE NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    order_count INT NOT NULL DEFAULT 0,
+    completed_count INT NOT NULL DEFAULT 0,
+    cancelled_count INT NOT NULL DEFAULT 0,
+    incomplete_count INT NOT NULL DEFAULT 0,
+    paid_count INT NOT NULL DEFAULT 0,
+    subtotal DECIMAL(12,2) NOT NULL DEFAULT 0,
+    discount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    service_charge DECIMAL(12,2) NOT NULL DEFAULT 0,
+    tax DECIMAL(12,2) NOT NULL DEFAULT 0,
+    tip_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    total DECIMAL(12,2) NOT NULL DEFAULT 0,
+    closed_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    closed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Mark orders of closed days, and the ones that were still open at the close
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS flagged_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_flagged_at ON orders(flagged_at) WHERE flagged_at IS NOT NULL;
+
+-- Add comments
+COMMENT ON TABLE daily_summaries IS 'Totals snapshotted when a business day is closed, the basis for Z-reports';
+COMMENT ON COLUMN daily_summaries.business_date IS 'Calendar day in the store timezone';
+COMMENT ON COLUMN daily_summaries.period_start IS 'Start of the business day, inclusive';
+COMMENT ON COLUMN daily_summaries.period_end IS 'End of the business day, exclusive';
+COMMENT ON COLUMN daily_summaries.paid_count IS 'Orders counted in the money totals: preparing, ready or completed';
+COMMENT ON COLUMN orders.closed_at IS 'When the business day of this order was closed; its status can no longer change';
+COMMENT ON COLUMN orders.flagged_at IS 'When the business day was closed while this order was still open';
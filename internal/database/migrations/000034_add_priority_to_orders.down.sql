@@ -0,0 +1,2 @@
+-- Drop priority from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,6 @@
+-- Let staff rush an order to the front of the kitchen queue
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS priority VARCHAR(20) NOT NULL DEFAULT 'normal'
+    CHECK (priority IN ('normal', 'rush'));
+
+-- Add comments
+COMMENT ON COLUMN orders.priority IS 'Kitchen priority: normal, or rush when set by staff or when the order ran late';
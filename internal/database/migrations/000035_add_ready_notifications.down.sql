@@ -0,0 +1,3 @@
+-- Drop ready notifications from orders and users
+ALTER TABLE orders DROP COLUMN IF EXISTS ready_notified_at;
+ALTER TABLE users DROP COLUMN IF EXISTS notify_via;
@@ -0,0 +1,10 @@
+-- Let members choose how they are told their order is ready
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notify_via VARCHAR(20) NULL
+    CHECK (notify_via IN ('email', 'whatsapp'));
+
+-- Record the ready notice so it is sent once
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ready_notified_at TIMESTAMP NULL;
+
+-- Add comments
+COMMENT ON COLUMN users.notify_via IS 'Preferred channel for order notifications; email when unset';
+COMMENT ON COLUMN orders.ready_notified_at IS 'When the customer was told the order is ready for pickup';
@@ -0,0 +1,6 @@
+-- Drop the kiosk from orders
+DROP INDEX IF EXISTS idx_orders_kiosk_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS kiosk_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS kiosks;
@@ -0,0 +1,25 @@
+-- Create self-service kiosks that place orders with an API key
+CREATE TABLE IF NOT EXISTS kiosks (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    api_key_hash VARCHAR(64) UNIQUE NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_seen_at TIMESTAMP NULL,
+    last_ip VARCHAR(45) NULL,
+    app_version VARCHAR(50) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Record the kiosk an order was placed on
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS kiosk_id INT NULL REFERENCES kiosks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_kiosk_id ON orders(kiosk_id);
+
+-- Add comments
+COMMENT ON TABLE kiosks IS 'Self-service ordering kiosks in the store';
+COMMENT ON COLUMN kiosks.api_key_hash IS 'SHA-256 of the kiosk API key; the key itself is only shown when issued';
+COMMENT ON COLUMN kiosks.last_seen_at IS 'Time of the last heartbeat, used to show whether the kiosk is online';
+COMMENT ON COLUMN kiosks.last_ip IS 'Address the last heartbeat came from';
+COMMENT ON COLUMN kiosks.app_version IS 'Kiosk app version reported with the last heartbeat';
@@ -0,0 +1,12 @@
+-- Cancel pre-orders that were never released so the original constraint holds
+UPDATE orders SET status = 'cancelled' WHERE status = 'scheduled';
+
+-- Drop scheduling from orders
+DROP INDEX IF EXISTS idx_orders_scheduled_release;
+ALTER TABLE orders DROP COLUMN IF EXISTS released_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS scheduled_for;
+
+-- Restore original order status constraint
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('pending', 'preparing', 'ready', 'completed', 'cancelled'));
@@ -0,0 +1,13 @@
+-- Allow pre-orders that wait in the scheduled status until they are due
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('scheduled', 'pending', 'preparing', 'ready', 'completed', 'cancelled'));
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMP NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS released_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled_release ON orders(scheduled_for) WHERE status = 'scheduled';
+
+-- Add comments
+COMMENT ON COLUMN orders.scheduled_for IS 'When a pre-order should be ready; it is released to the pending queue ahead of this';
+COMMENT ON COLUMN orders.released_at IS 'When a pre-order entered the pending queue; queue times are measured from here';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_orders_share_token;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS share_token_expires_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS share_token;
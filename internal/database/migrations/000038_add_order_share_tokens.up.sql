@@ -0,0 +1,9 @@
+-- Tokens for the public order status page, so links need not carry the order UUID
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS share_token VARCHAR(64) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS share_token_expires_at TIMESTAMP NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_share_token ON orders(share_token);
+
+-- Add comments
+COMMENT ON COLUMN orders.share_token IS 'Random token for the public status page; cleared when the order completes';
+COMMENT ON COLUMN orders.share_token_expires_at IS 'When the status page token stops working';
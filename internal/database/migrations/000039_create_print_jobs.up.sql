@@ -0,0 +1,26 @@
+-- Create the queue of tickets waiting for an on-site print agent
+CREATE TABLE IF NOT EXISTS print_jobs (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    station VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'printing', 'printed', 'failed')),
+    payload BYTEA NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    claimed_at TIMESTAMP NULL,
+    printed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One ticket per order and station, however many instances see the payment
+CREATE UNIQUE INDEX IF NOT EXISTS idx_print_jobs_order_station ON print_jobs(order_id, station);
+CREATE INDEX IF NOT EXISTS idx_print_jobs_pending ON print_jobs(station, created_at) WHERE status IN ('queued', 'printing');
+
+-- Add comments
+COMMENT ON TABLE print_jobs IS 'ESC/POS tickets for paid orders, pulled by the print agent of each station';
+COMMENT ON COLUMN print_jobs.station IS 'Printer station the ticket is routed to';
+COMMENT ON COLUMN print_jobs.payload IS 'Rendered ESC/POS bytes sent to the printer as is';
+COMMENT ON COLUMN print_jobs.attempts IS 'How many times an agent has taken the job';
+COMMENT ON COLUMN print_jobs.claimed_at IS 'When an agent last took the job; unacknowledged jobs are handed out again';
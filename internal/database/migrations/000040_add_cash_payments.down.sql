@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_payments_method;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS recorded_by;
+ALTER TABLE payments DROP COLUMN IF EXISTS change_given;
+ALTER TABLE payments DROP COLUMN IF EXISTS cash_tendered;
+ALTER TABLE payments DROP COLUMN IF EXISTS method;
@@ -0,0 +1,13 @@
+-- Payments taken at the counter are recorded without going through Midtrans
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS method VARCHAR(20) NOT NULL DEFAULT 'midtrans';
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS cash_tendered DECIMAL(10, 2) NULL;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS change_given DECIMAL(10, 2) NULL;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS recorded_by INT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_method ON payments(method);
+
+-- Add comments
+COMMENT ON COLUMN payments.method IS 'How the order was paid: midtrans or cash';
+COMMENT ON COLUMN payments.cash_tendered IS 'Cash handed over by the customer';
+COMMENT ON COLUMN payments.change_given IS 'Change returned to the customer';
+COMMENT ON COLUMN payments.recorded_by IS 'Staff member who took a cash payment';
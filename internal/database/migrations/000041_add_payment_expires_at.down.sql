@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_pending_expires_at;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS expires_at;
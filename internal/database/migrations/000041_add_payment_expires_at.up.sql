@@ -0,0 +1,15 @@
+-- Gateway payments close at expires_at unless the customer pays first
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NULL;
+
+UPDATE payments p
+SET expires_at = o.payment_expires_at
+FROM orders o
+WHERE p.order_id = o.id
+  AND p.expires_at IS NULL
+  AND (p.transaction_status IS NULL OR p.transaction_status = 'pending');
+
+CREATE INDEX IF NOT EXISTS idx_payments_pending_expires_at ON payments(expires_at)
+    WHERE transaction_status IS NULL OR transaction_status = 'pending';
+
+-- Add comments
+COMMENT ON COLUMN payments.expires_at IS 'When the hosted payment stops accepting payment';
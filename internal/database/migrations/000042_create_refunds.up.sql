@@ -0,0 +1,18 @@
+-- Create the refunds given back on settled payments
+CREATE TABLE IF NOT EXISTS refunds (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    payment_id INT NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    refund_key VARCHAR(100) UNIQUE NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    reason VARCHAR(255) NOT NULL,
+    refunded_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);
+
+-- Add comments
+COMMENT ON TABLE refunds IS 'Money returned on a settled payment, from the admin API or reported by the gateway';
+COMMENT ON COLUMN refunds.refund_key IS 'Idempotency key sent to the gateway; the webhook reports the same key back';
+COMMENT ON COLUMN refunds.refunded_by IS 'Admin who issued the refund; empty for refunds made in the gateway dashboard';
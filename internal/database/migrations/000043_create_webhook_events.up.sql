@@ -0,0 +1,23 @@
+-- Create the log of every payment webhook received, kept for auditing and replay
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    provider VARCHAR(20) NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    body BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'received' CHECK (status IN ('received', 'processed', 'failed')),
+    error TEXT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    processed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_events_provider_created_at ON webhook_events(provider, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_failed ON webhook_events(created_at DESC) WHERE status = 'failed';
+
+-- Add comments
+COMMENT ON TABLE webhook_events IS 'Raw payment webhooks, stored before processing so rejected ones can be replayed';
+COMMENT ON COLUMN webhook_events.body IS 'Request body exactly as received, as signatures cover the raw bytes';
+COMMENT ON COLUMN webhook_events.status IS 'Result of the last processing attempt: received, processed or failed';
+COMMENT ON COLUMN webhook_events.attempts IS 'How many times the event was processed, counting replays';
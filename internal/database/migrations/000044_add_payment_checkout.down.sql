@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS checkout_url;
+ALTER TABLE payments DROP COLUMN IF EXISTS checkout_token;
@@ -0,0 +1,7 @@
+-- Keep the hosted payment page so it can be reopened instead of starting a new one
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS checkout_token VARCHAR(255) NULL;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS checkout_url TEXT NULL;
+
+-- Add comments
+COMMENT ON COLUMN payments.checkout_token IS 'Token of the hosted payment page, such as the Snap token';
+COMMENT ON COLUMN payments.checkout_url IS 'Address of the hosted payment page';
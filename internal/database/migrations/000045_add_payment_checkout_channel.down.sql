@@ -0,0 +1,3 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS checkout_channel;
+
+ALTER TABLE payments ALTER COLUMN checkout_token TYPE VARCHAR(255) USING LEFT(checkout_token, 255);
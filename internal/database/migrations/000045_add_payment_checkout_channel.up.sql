@@ -0,0 +1,11 @@
+-- Gateway payments are collected on a hosted page or as a QRIS code shown by the kiosk
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS checkout_channel VARCHAR(20) NULL;
+
+-- The token holds the QR string of QRIS checkouts, which can outgrow 255 characters
+ALTER TABLE payments ALTER COLUMN checkout_token TYPE TEXT;
+
+UPDATE payments SET checkout_channel = 'hosted' WHERE checkout_token IS NOT NULL AND checkout_channel IS NULL;
+
+-- Add comments
+COMMENT ON COLUMN payments.checkout_channel IS 'How the customer pays the checkout: hosted or qris';
+COMMENT ON COLUMN payments.checkout_token IS 'Token of the hosted payment page, such as the Snap token, or the QRIS string';
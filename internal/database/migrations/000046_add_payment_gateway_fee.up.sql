@@ -0,0 +1,5 @@
+-- What the gateway keeps from a settled payment, so reports can show net amounts
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS gateway_fee DECIMAL(10,2) NULL;
+
+-- Add comments
+COMMENT ON COLUMN payments.gateway_fee IS 'Fee the gateway charged on the settled payment, as reported or from the configured fee table; NULL when unknown';
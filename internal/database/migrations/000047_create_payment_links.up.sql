@@ -0,0 +1,18 @@
+-- Create the one-time links that open an order's payment page, sent to phone-in customers
+CREATE TABLE IF NOT EXISTS payment_links (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_links_order_id ON payment_links(order_id);
+
+-- Add comments
+COMMENT ON TABLE payment_links IS 'Short-lived links that open the hosted payment page of an order once';
+COMMENT ON COLUMN payment_links.token_hash IS 'SHA-256 of the token in the link; the token itself is only returned when the link is created';
+COMMENT ON COLUMN payment_links.used_at IS 'When the link was opened; a used link cannot be opened again';
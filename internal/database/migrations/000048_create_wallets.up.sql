@@ -0,0 +1,43 @@
+-- Create the member wallet: top-ups paid through the gateway and the ledger of balance changes
+CREATE TABLE IF NOT EXISTS wallet_topups (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    transaction_status VARCHAR(50) NULL,
+    payment_type VARCHAR(50) NULL,
+    transaction_id VARCHAR(100) NULL,
+    checkout_url TEXT NULL,
+    expires_at TIMESTAMP NULL,
+    credited_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_topups_user_id ON wallet_topups(user_id);
+
+CREATE TABLE IF NOT EXISTS wallet_transactions (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount <> 0),
+    balance_after DECIMAL(10, 2) NOT NULL CHECK (balance_after >= 0),
+    order_id INT NULL REFERENCES orders(id) ON DELETE SET NULL,
+    topup_id INT NULL UNIQUE REFERENCES wallet_topups(id) ON DELETE SET NULL,
+    note VARCHAR(255) NULL,
+    created_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_transactions_user_id ON wallet_transactions(user_id, id);
+
+-- Add comments
+COMMENT ON TABLE wallet_topups IS 'Money members add to their wallet through the payment gateway';
+COMMENT ON COLUMN wallet_topups.credited_at IS 'When the settled top-up was added to the wallet; set once';
+COMMENT ON TABLE wallet_transactions IS 'Ledger of wallet balance changes; the latest entry per member holds the balance';
+COMMENT ON COLUMN wallet_transactions.amount IS 'Positive for top-ups, refunds and credits, negative for payments and debits';
+COMMENT ON COLUMN wallet_transactions.topup_id IS 'Top-up the entry credits; unique so a top-up is credited once';
+COMMENT ON COLUMN wallet_transactions.created_by IS 'Admin who made an adjustment';
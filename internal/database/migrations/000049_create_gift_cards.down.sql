@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS gift_card_amount;
+DROP TABLE IF EXISTS gift_card_redemptions;
+DROP TABLE IF EXISTS gift_cards;
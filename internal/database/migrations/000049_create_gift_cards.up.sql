@@ -0,0 +1,51 @@
+-- Create gift cards: codes holding a balance, bought through the gateway or issued by staff
+CREATE TABLE IF NOT EXISTS gift_cards (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    code VARCHAR(20) UNIQUE NOT NULL,
+    initial_amount DECIMAL(10, 2) NOT NULL CHECK (initial_amount > 0),
+    balance DECIMAL(10, 2) NOT NULL CHECK (balance >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    recipient_name VARCHAR(255) NULL,
+    recipient_email VARCHAR(255) NULL,
+    purchased_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    issued_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    reference VARCHAR(100) UNIQUE NULL,
+    method VARCHAR(20) NULL,
+    transaction_status VARCHAR(50) NULL,
+    payment_type VARCHAR(50) NULL,
+    transaction_id VARCHAR(100) NULL,
+    checkout_url TEXT NULL,
+    activated_at TIMESTAMP NULL,
+    voided_at TIMESTAMP NULL,
+    voided_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    void_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_gift_cards_status ON gift_cards(status);
+
+CREATE TABLE IF NOT EXISTS gift_card_redemptions (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    gift_card_id INT NOT NULL REFERENCES gift_cards(id) ON DELETE CASCADE,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    released_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_gift_card_redemptions_gift_card_id ON gift_card_redemptions(gift_card_id);
+CREATE INDEX IF NOT EXISTS idx_gift_card_redemptions_order_id ON gift_card_redemptions(order_id);
+
+-- Part of the order paid with gift cards; the rest is paid as usual
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS gift_card_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Add comments
+COMMENT ON TABLE gift_cards IS 'Gift card codes with their remaining balance';
+COMMENT ON COLUMN gift_cards.status IS 'pending until a purchase settles, then active; voided cards cannot be redeemed';
+COMMENT ON COLUMN gift_cards.reference IS 'Gateway reference of the purchase; NULL for cards issued by staff';
+COMMENT ON TABLE gift_card_redemptions IS 'Gift card balance applied to orders';
+COMMENT ON COLUMN gift_card_redemptions.released_at IS 'When the amount went back on the card because the order was cancelled or refunded';
+COMMENT ON COLUMN orders.gift_card_amount IS 'Amount of the order paid with gift cards, taken off the amount due';
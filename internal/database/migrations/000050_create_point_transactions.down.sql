@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS points_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS points_redeemed;
+DROP TABLE IF EXISTS point_transactions;
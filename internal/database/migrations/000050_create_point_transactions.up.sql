@@ -0,0 +1,26 @@
+-- Create the loyalty points ledger: points members earn on completed orders and spend on new ones
+CREATE TABLE IF NOT EXISTS point_transactions (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    points INT NOT NULL CHECK (points <> 0),
+    balance_after INT NOT NULL CHECK (balance_after >= 0),
+    order_id INT NULL REFERENCES orders(id) ON DELETE SET NULL,
+    note VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_transactions_user_id ON point_transactions(user_id, id);
+CREATE INDEX IF NOT EXISTS idx_point_transactions_order_id ON point_transactions(order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_point_transactions_earned_order ON point_transactions(order_id) WHERE type = 'earn';
+
+-- Part of the order paid with points; the rest is paid as usual
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS points_redeemed INT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS points_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Add comments
+COMMENT ON TABLE point_transactions IS 'Ledger of loyalty point changes; the latest entry per member holds the balance';
+COMMENT ON COLUMN point_transactions.points IS 'Positive for points earned and given back, negative for points spent';
+COMMENT ON COLUMN orders.points_redeemed IS 'Loyalty points spent on the order';
+COMMENT ON COLUMN orders.points_amount IS 'Amount of the order paid with points, taken off the amount due';
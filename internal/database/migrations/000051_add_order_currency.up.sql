@@ -0,0 +1,5 @@
+-- Currency the order was priced and charged in, so changing the store currency leaves past orders as they were
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+
+-- Add comments
+COMMENT ON COLUMN orders.currency IS 'ISO 4217 code of the store currency when the order was placed';
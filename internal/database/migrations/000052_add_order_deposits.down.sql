@@ -0,0 +1,11 @@
+-- Orders holding a deposit still owe their balance, so they go back to pending
+UPDATE orders SET status = 'pending' WHERE status = 'deposit_paid';
+
+-- Drop deposits from orders
+ALTER TABLE orders DROP COLUMN IF EXISTS deposit_paid_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS deposit_amount;
+
+-- Restore order status constraint
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('scheduled', 'pending', 'preparing', 'ready', 'completed', 'cancelled'));
@@ -0,0 +1,11 @@
+-- Allow large orders to wait in deposit_paid between their deposit and the balance
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('scheduled', 'pending', 'deposit_paid', 'preparing', 'ready', 'completed', 'cancelled'));
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deposit_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deposit_paid_at TIMESTAMP NULL;
+
+-- Add comments
+COMMENT ON COLUMN orders.deposit_amount IS 'Down payment collected before the balance; 0 when the order is paid in one go';
+COMMENT ON COLUMN orders.deposit_paid_at IS 'When the deposit settled';
@@ -0,0 +1,12 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_promotions_automatic;
+DROP INDEX IF EXISTS idx_order_promotions_promotion_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS order_promotions;
+
+-- Drop columns
+ALTER TABLE promotions DROP COLUMN IF EXISTS stackable;
+ALTER TABLE promotions DROP COLUMN IF EXISTS automatic;
+
+COMMENT ON COLUMN orders.promo_code IS 'Code as redeemed, kept for reporting after the promotion is deleted';
@@ -0,0 +1,30 @@
+-- Let promotions apply without a code and combine with each other
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS automatic BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS stackable BOOLEAN NOT NULL DEFAULT false;
+
+-- Every promotion applied to an order, with the discount it gave
+CREATE TABLE IF NOT EXISTS order_promotions (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id INT NULL REFERENCES promotions(id) ON DELETE SET NULL,
+    code VARCHAR(50) NOT NULL,
+    discount DECIMAL(10,2) NOT NULL CHECK (discount >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (order_id, promotion_id)
+);
+
+-- Orders placed so far redeemed at most one code
+INSERT INTO order_promotions (order_id, promotion_id, code, discount, created_at)
+SELECT id, promotion_id, promo_code, discount, created_at
+FROM orders
+WHERE promo_code IS NOT NULL;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_order_promotions_promotion_id ON order_promotions(promotion_id);
+CREATE INDEX IF NOT EXISTS idx_promotions_automatic ON promotions(automatic) WHERE automatic;
+
+-- Add comments
+COMMENT ON COLUMN promotions.automatic IS 'Applied to every qualifying order without the code being entered';
+COMMENT ON COLUMN promotions.stackable IS 'Combines with other stackable promotions; otherwise applied on its own';
+COMMENT ON COLUMN order_promotions.code IS 'Code as applied, kept for reporting after the promotion is deleted';
+COMMENT ON COLUMN orders.promo_code IS 'Code the customer entered; automatic promotions are only in order_promotions';
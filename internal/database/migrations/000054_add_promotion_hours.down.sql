@@ -0,0 +1,7 @@
+ALTER TABLE order_promotions DROP COLUMN IF EXISTS name;
+
+ALTER TABLE promotions DROP CONSTRAINT IF EXISTS promotions_hours_check;
+ALTER TABLE promotions DROP COLUMN IF EXISTS end_time;
+ALTER TABLE promotions DROP COLUMN IF EXISTS start_time;
+ALTER TABLE promotions DROP COLUMN IF EXISTS days;
+ALTER TABLE promotions DROP COLUMN IF EXISTS name;
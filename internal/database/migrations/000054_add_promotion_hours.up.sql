@@ -0,0 +1,16 @@
+-- Limit promotions to days of the week and hours of the day, such as a happy hour
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS name VARCHAR(60) NULL;
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS days SMALLINT NOT NULL DEFAULT 0 CHECK (days BETWEEN 0 AND 127);
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS start_time VARCHAR(5) NULL CHECK (start_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$');
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS end_time VARCHAR(5) NULL CHECK (end_time ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$');
+ALTER TABLE promotions ADD CONSTRAINT promotions_hours_check
+    CHECK ((start_time IS NULL) = (end_time IS NULL) AND (start_time IS NULL OR start_time <> end_time));
+
+-- Name the promotion on the order as it was applied
+ALTER TABLE order_promotions ADD COLUMN IF NOT EXISTS name VARCHAR(60) NULL;
+
+-- Add comments
+COMMENT ON COLUMN promotions.name IS 'Short name shown on orders and receipts, such as Happy Hour';
+COMMENT ON COLUMN promotions.days IS 'Weekdays the promotion runs on, bit 0 for Sunday through bit 6 for Saturday; 0 means every day';
+COMMENT ON COLUMN promotions.start_time IS 'Store-time HH:MM the promotion starts each day; an end before the start runs past midnight';
+COMMENT ON COLUMN promotions.end_time IS 'Store-time HH:MM the promotion ends each day';
@@ -0,0 +1,11 @@
+ALTER TABLE order_promotions DROP COLUMN IF EXISTS voucher_id;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_voucher_events_voucher_id;
+DROP INDEX IF EXISTS idx_vouchers_order_id;
+DROP INDEX IF EXISTS idx_vouchers_status;
+DROP INDEX IF EXISTS idx_vouchers_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS voucher_events;
+DROP TABLE IF EXISTS vouchers;
@@ -0,0 +1,48 @@
+-- Create vouchers: single-use discounts admins grant to members, such as an apology after a bad order
+CREATE TABLE IF NOT EXISTS vouchers (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    code VARCHAR(20) UNIQUE NOT NULL,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(255) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10, 2) NULL CHECK (max_discount > 0),
+    min_spend DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    expires_at TIMESTAMP NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    issued_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    order_id INT NULL REFERENCES orders(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    revoked_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    revoke_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_vouchers_user_id ON vouchers(user_id);
+CREATE INDEX IF NOT EXISTS idx_vouchers_status ON vouchers(status);
+CREATE INDEX IF NOT EXISTS idx_vouchers_order_id ON vouchers(order_id);
+
+-- Audit trail of every voucher
+CREATE TABLE IF NOT EXISTS voucher_events (
+    id SERIAL PRIMARY KEY,
+    voucher_id INT NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    actor_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    order_id INT NULL REFERENCES orders(id) ON DELETE SET NULL,
+    note VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_voucher_events_voucher_id ON voucher_events(voucher_id);
+
+-- Vouchers applied to an order are listed with its promotions
+ALTER TABLE order_promotions ADD COLUMN IF NOT EXISTS voucher_id INT NULL REFERENCES vouchers(id) ON DELETE SET NULL;
+
+-- Add comments
+COMMENT ON COLUMN vouchers.user_id IS 'Member the voucher was granted to; nobody else can redeem it';
+COMMENT ON COLUMN vouchers.order_id IS 'Order that redeemed the voucher; cleared again if the order is cancelled';
+COMMENT ON COLUMN voucher_events.action IS 'issued, redeemed, released or revoked';
+COMMENT ON COLUMN order_promotions.voucher_id IS 'Voucher behind the discount, for lines that are not promotions';
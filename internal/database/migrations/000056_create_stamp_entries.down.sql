@@ -0,0 +1,11 @@
+-- Free-item vouchers cannot exist without stamp cards
+DELETE FROM vouchers WHERE discount_type = 'free_item';
+ALTER TABLE vouchers DROP CONSTRAINT IF EXISTS vouchers_discount_type_check;
+ALTER TABLE vouchers ADD CONSTRAINT vouchers_discount_type_check CHECK (discount_type IN ('percentage', 'fixed'));
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_stamp_entries_order_id;
+DROP INDEX IF EXISTS idx_stamp_entries_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS stamp_entries;
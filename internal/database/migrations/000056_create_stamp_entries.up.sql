@@ -0,0 +1,21 @@
+-- Create stamp_entries: the ledger behind members' stamp cards
+CREATE TABLE IF NOT EXISTS stamp_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stamps INT NOT NULL CHECK (stamps <> 0),
+    balance_after INT NOT NULL CHECK (balance_after >= 0),
+    order_id INT NULL REFERENCES orders(id) ON DELETE SET NULL,
+    voucher_id INT NULL REFERENCES vouchers(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stamp_entries_user_id ON stamp_entries(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stamp_entries_order_id ON stamp_entries(order_id);
+
+-- Full stamp cards turn into free-item vouchers
+ALTER TABLE vouchers DROP CONSTRAINT IF EXISTS vouchers_discount_type_check;
+ALTER TABLE vouchers ADD CONSTRAINT vouchers_discount_type_check CHECK (discount_type IN ('percentage', 'fixed', 'free_item'));
+
+-- Add comments
+COMMENT ON COLUMN stamp_entries.stamps IS 'Stamps earned on order_id, or taken off for the reward voucher_id';
+COMMENT ON COLUMN stamp_entries.order_id IS 'Completed order the stamps were earned on; an order earns stamps once';
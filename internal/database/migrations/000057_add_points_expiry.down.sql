@@ -0,0 +1,9 @@
+-- Restore the email template keys
+DELETE FROM email_templates WHERE key = 'points_expiring';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset', 'login_code', 'order_receipt'));
+
+-- Drop expiry warnings from the points ledger
+COMMENT ON COLUMN point_transactions.points IS 'Positive for points earned and given back, negative for points spent';
+ALTER TABLE point_transactions DROP COLUMN IF EXISTS expiry_warned_at;
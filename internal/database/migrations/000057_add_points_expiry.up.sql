@@ -0,0 +1,29 @@
+-- Remember which points members were warned about before they expire
+ALTER TABLE point_transactions ADD COLUMN IF NOT EXISTS expiry_warned_at TIMESTAMP NULL;
+
+-- Allow a template for the points expiry warning
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_key_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_key_check
+    CHECK (key IN ('order_confirmation', 'order_ready', 'password_reset', 'login_code', 'order_receipt', 'points_expiring'));
+
+-- Seed the points expiry templates
+INSERT INTO email_templates (key, locale, version, subject, html_body, text_body, is_active) VALUES
+(
+    'points_expiring', 'en', 1,
+    'Your Matchaciee points expire on {{expires_on}}',
+    '<p>Hi {{name}},</p><p><strong>{{points}} points</strong>, worth {{value}}, expire on {{expires_on}}. Spend them on your next order before they are gone.</p>',
+    E'Hi {{name}},\n\n{{points}} points, worth {{value}}, expire on {{expires_on}}. Spend them on your next order before they are gone.',
+    true
+),
+(
+    'points_expiring', 'id', 1,
+    'Poin Matchaciee Anda kedaluwarsa pada {{expires_on}}',
+    '<p>Hai {{name}},</p><p><strong>{{points}} poin</strong> senilai {{value}} akan kedaluwarsa pada {{expires_on}}. Gunakan untuk pesanan berikutnya sebelum hangus.</p>',
+    E'Hai {{name}},\n\n{{points}} poin senilai {{value}} akan kedaluwarsa pada {{expires_on}}. Gunakan untuk pesanan berikutnya sebelum hangus.',
+    true
+)
+ON CONFLICT (key, locale, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON COLUMN point_transactions.points IS 'Positive for points earned and given back, negative for points spent or expired';
+COMMENT ON COLUMN point_transactions.expiry_warned_at IS 'When the member was warned these earned points are about to expire, NULL if they were not';
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_campaign_deliveries_pending;
+DROP INDEX IF EXISTS idx_campaign_deliveries_campaign_user;
+DROP INDEX IF EXISTS idx_campaigns_status;
+
+-- Drop tables
+DROP TABLE IF EXISTS campaign_deliveries;
+DROP TABLE IF EXISTS campaigns;
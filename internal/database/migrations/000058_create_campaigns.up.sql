@@ -0,0 +1,39 @@
+-- Create campaigns: messages admins send to a segment of members
+CREATE TABLE IF NOT EXISTS campaigns (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    title VARCHAR(100) NOT NULL,
+    message TEXT NOT NULL,
+    segment VARCHAR(20) NOT NULL CHECK (segment IN ('all', 'lapsed', 'new')),
+    inactive_days INT NOT NULL DEFAULT 0 CHECK (inactive_days >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'sending', 'sent', 'cancelled')),
+    send_at TIMESTAMP NOT NULL,
+    created_by INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    cancelled_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_status ON campaigns(status);
+
+-- One message per member of a campaign, sent in throttled batches
+CREATE TABLE IF NOT EXISTS campaign_deliveries (
+    id SERIAL PRIMARY KEY,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    channel VARCHAR(20) NULL,
+    error VARCHAR(255) NULL,
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_campaign_deliveries_campaign_user ON campaign_deliveries(campaign_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_campaign_deliveries_pending ON campaign_deliveries(id) WHERE status = 'pending';
+
+-- Add comments
+COMMENT ON COLUMN campaigns.inactive_days IS 'For the lapsed segment, days without a completed order';
+COMMENT ON COLUMN campaigns.send_at IS 'When the campaign starts sending; members are picked at that point';
+COMMENT ON COLUMN campaign_deliveries.channel IS 'Channel the message went out on, the member''s preference when it was sent';
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_orders_user_completed;
+DROP INDEX IF EXISTS idx_member_stats_favorite_category_id;
+DROP INDEX IF EXISTS idx_member_stats_total_spend;
+DROP INDEX IF EXISTS idx_member_stats_last_order_at;
+
+-- Drop table
+DROP TABLE IF EXISTS member_stats;
@@ -0,0 +1,21 @@
+-- Create member_stats: each member's completed orders rolled up for segment exports
+CREATE TABLE IF NOT EXISTS member_stats (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    order_count INT NOT NULL,
+    total_spend DECIMAL(12, 2) NOT NULL,
+    first_order_at TIMESTAMP NOT NULL,
+    last_order_at TIMESTAMP NOT NULL,
+    favorite_category_id INT NULL REFERENCES categories(id) ON DELETE SET NULL,
+    refreshed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_stats_last_order_at ON member_stats(last_order_at);
+CREATE INDEX IF NOT EXISTS idx_member_stats_total_spend ON member_stats(total_spend);
+CREATE INDEX IF NOT EXISTS idx_member_stats_favorite_category_id ON member_stats(favorite_category_id);
+
+-- Lets the refresh and member segments read completed orders without scanning the rest
+CREATE INDEX IF NOT EXISTS idx_orders_user_completed ON orders(user_id, created_at) WHERE status = 'completed' AND user_id IS NOT NULL;
+
+-- Add comments
+COMMENT ON TABLE member_stats IS 'Rebuilt periodically from completed orders; members without one have no row';
+COMMENT ON COLUMN member_stats.favorite_category_id IS 'Category the member ordered the most items from';
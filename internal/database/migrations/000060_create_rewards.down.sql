@@ -0,0 +1,10 @@
+-- Drop voucher columns
+DROP INDEX IF EXISTS idx_vouchers_reward_id;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS category_id;
+ALTER TABLE vouchers DROP COLUMN IF EXISTS reward_id;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_rewards_is_active;
+
+-- Drop table
+DROP TABLE IF EXISTS rewards;
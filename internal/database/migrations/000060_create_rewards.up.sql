@@ -0,0 +1,32 @@
+-- Create rewards: the catalog members swap loyalty points for
+CREATE TABLE IF NOT EXISTS rewards (
+    id SERIAL PRIMARY KEY,
+    uuid UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    points_cost INT NOT NULL CHECK (points_cost > 0),
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed', 'free_item')),
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10, 2) NULL CHECK (max_discount > 0),
+    min_spend DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    category_id INT NULL REFERENCES categories(id) ON DELETE SET NULL,
+    valid_days INT NOT NULL DEFAULT 0 CHECK (valid_days >= 0),
+    stock INT NULL CHECK (stock >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rewards_is_active ON rewards(is_active);
+
+-- Vouchers bought with points point back at their reward
+ALTER TABLE vouchers ADD COLUMN IF NOT EXISTS reward_id INT NULL REFERENCES rewards(id) ON DELETE SET NULL;
+ALTER TABLE vouchers ADD COLUMN IF NOT EXISTS category_id INT NULL REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_vouchers_reward_id ON vouchers(reward_id);
+
+-- Add comments
+COMMENT ON COLUMN rewards.category_id IS 'Category a free-item reward covers, including its subcategories';
+COMMENT ON COLUMN rewards.valid_days IS 'Days the issued voucher stays valid; 0 for no expiry';
+COMMENT ON COLUMN rewards.stock IS 'Redemptions left; NULL for no limit';
+COMMENT ON COLUMN vouchers.category_id IS 'Category a free-item voucher covers; NULL for the stamp card''s';
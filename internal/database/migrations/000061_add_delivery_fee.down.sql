@@ -0,0 +1,9 @@
+-- Free-delivery promotions cannot exist without delivery fees
+DELETE FROM promotions WHERE discount_type = 'free_delivery';
+ALTER TABLE promotions DROP CONSTRAINT IF EXISTS promotions_free_delivery_value_check;
+ALTER TABLE promotions DROP CONSTRAINT IF EXISTS promotions_discount_type_check;
+ALTER TABLE promotions ADD CONSTRAINT promotions_discount_type_check CHECK (discount_type IN ('percentage', 'fixed'));
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_delivery_discount_check;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_fee;
@@ -0,0 +1,13 @@
+-- Charge a flat fee on delivery orders, which free-delivery promotions waive
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (delivery_fee >= 0);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_discount DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (delivery_discount >= 0);
+ALTER TABLE orders ADD CONSTRAINT orders_delivery_discount_check CHECK (delivery_discount <= delivery_fee);
+
+ALTER TABLE promotions DROP CONSTRAINT IF EXISTS promotions_discount_type_check;
+ALTER TABLE promotions ADD CONSTRAINT promotions_discount_type_check CHECK (discount_type IN ('percentage', 'fixed', 'free_delivery'));
+ALTER TABLE promotions ADD CONSTRAINT promotions_free_delivery_value_check CHECK (discount_type <> 'free_delivery' OR discount_value <= 100);
+
+-- Add comments
+COMMENT ON COLUMN orders.delivery_fee IS 'Delivery fee charged on this order before promotions';
+COMMENT ON COLUMN orders.delivery_discount IS 'Part of the delivery fee waived by free-delivery promotions; not included in discount';
+COMMENT ON COLUMN promotions.discount_value IS 'Percentage or amount off; for free_delivery, the percentage of the delivery fee waived';
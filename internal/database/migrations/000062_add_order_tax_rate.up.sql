@@ -0,0 +1,11 @@
+-- Record the tax rate each order was charged so tax reports survive rate changes
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(6,4) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0);
+
+-- Existing orders did not record their rate; recover it from the stored
+-- amounts, rounded to a tenth of a percent to absorb currency rounding
+UPDATE orders
+SET tax_rate = ROUND(tax / (subtotal - discount + service_charge), 3)
+WHERE tax > 0 AND subtotal - discount + service_charge > 0;
+
+-- Add comments
+COMMENT ON COLUMN orders.tax_rate IS 'Tax rate applied to this order as a fraction, e.g. 0.1100 for 11%';
@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS ready_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS preparing_at;
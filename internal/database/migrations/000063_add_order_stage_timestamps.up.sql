@@ -0,0 +1,8 @@
+-- Note when an order starts preparation and is ready, so fulfillment times
+-- can be measured between every status. Older orders have no record.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS preparing_at TIMESTAMP NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ready_at TIMESTAMP NULL;
+
+-- Add comments
+COMMENT ON COLUMN orders.preparing_at IS 'When the order was paid and moved to preparing';
+COMMENT ON COLUMN orders.ready_at IS 'When the order was marked ready for pickup';
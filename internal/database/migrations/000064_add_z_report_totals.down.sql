@@ -0,0 +1,6 @@
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS cancelled_amount;
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS refund_amount;
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS refund_count;
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS stored_value_amount;
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS gateway_amount;
+ALTER TABLE daily_summaries DROP COLUMN IF EXISTS cash_amount;
@@ -0,0 +1,15 @@
+-- Break the daily summary down the way a Z-report needs it. Days closed
+-- before this migration keep zeros in the new columns.
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS cash_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS gateway_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS stored_value_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS refund_count INT NOT NULL DEFAULT 0;
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS refund_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE daily_summaries ADD COLUMN IF NOT EXISTS cancelled_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+-- Add comments
+COMMENT ON COLUMN daily_summaries.cash_amount IS 'Cash payments settled during the business day';
+COMMENT ON COLUMN daily_summaries.gateway_amount IS 'Payment gateway payments settled during the business day';
+COMMENT ON COLUMN daily_summaries.stored_value_amount IS 'Order payments from wallets, gift cards and points settled during the business day';
+COMMENT ON COLUMN daily_summaries.refund_amount IS 'Refunds issued during the business day, whichever day the order was placed';
+COMMENT ON COLUMN daily_summaries.cancelled_amount IS 'Total of the orders of the day that were cancelled';
@@ -0,0 +1,15 @@
+-- Create rate_limit_entries table holding rate limit counters shared by every
+-- instance. Counters are short-lived and may be lost in a crash, so the
+-- table skips the write-ahead log.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_entries (
+    key VARCHAR(255) PRIMARY KEY,
+    value BYTEA NOT NULL,
+    expires_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_entries_expires_at ON rate_limit_entries(expires_at);
+
+-- Add comments
+COMMENT ON TABLE rate_limit_entries IS 'Rate limit counters when RATE_LIMIT_STORE is postgres';
+COMMENT ON COLUMN rate_limit_entries.key IS 'Limit name and client IP';
+COMMENT ON COLUMN rate_limit_entries.expires_at IS 'When the entry stops counting; NULL never expires';
@@ -0,0 +1,4 @@
+ALTER TABLE payment_links ADD COLUMN IF NOT EXISTS used_at TIMESTAMP NULL;
+
+COMMENT ON TABLE payment_links IS 'Short-lived links that open the hosted payment page of an order once';
+COMMENT ON COLUMN payment_links.used_at IS 'When the link was opened; a used link cannot be opened again';
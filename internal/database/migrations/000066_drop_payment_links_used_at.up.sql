@@ -0,0 +1,5 @@
+-- Payment links are no longer used up when opened: opening one again leads
+-- back to the same payment page until the order is paid
+ALTER TABLE payment_links DROP COLUMN IF EXISTS used_at;
+
+COMMENT ON TABLE payment_links IS 'Short-lived links that open the hosted payment page of an order until it is paid';
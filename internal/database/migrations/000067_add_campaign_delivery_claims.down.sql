@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_campaign_deliveries_sending;
+UPDATE campaign_deliveries SET status = 'pending' WHERE status = 'sending';
+ALTER TABLE campaign_deliveries DROP COLUMN IF EXISTS claimed_at;
+ALTER TABLE campaign_deliveries DROP CONSTRAINT IF EXISTS campaign_deliveries_status_check;
+ALTER TABLE campaign_deliveries ADD CONSTRAINT campaign_deliveries_status_check CHECK (status IN ('pending', 'sent', 'failed'));
@@ -0,0 +1,10 @@
+-- Claim campaign messages before sending them, so two runs of the delivery
+-- job never send the same message
+ALTER TABLE campaign_deliveries DROP CONSTRAINT IF EXISTS campaign_deliveries_status_check;
+ALTER TABLE campaign_deliveries ADD CONSTRAINT campaign_deliveries_status_check CHECK (status IN ('pending', 'sending', 'sent', 'failed'));
+ALTER TABLE campaign_deliveries ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_deliveries_sending ON campaign_deliveries(claimed_at) WHERE status = 'sending';
+
+-- Add comments
+COMMENT ON COLUMN campaign_deliveries.claimed_at IS 'When a delivery run claimed the message; a message left sending for long was interrupted and is marked failed';
@@ -0,0 +1,6 @@
+TRUNCATE rate_limit_entries;
+ALTER TABLE rate_limit_entries ALTER COLUMN expires_at DROP NOT NULL;
+ALTER TABLE rate_limit_entries DROP COLUMN IF EXISTS count;
+ALTER TABLE rate_limit_entries ADD COLUMN IF NOT EXISTS value BYTEA NOT NULL;
+
+COMMENT ON COLUMN rate_limit_entries.expires_at IS 'When the entry stops counting; NULL never expires';
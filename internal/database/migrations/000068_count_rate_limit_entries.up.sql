@@ -0,0 +1,11 @@
+-- Count rate limits with a single upsert instead of reading and rewriting an
+-- encoded entry, which lost requests that raced each other. The old entries
+-- are only short-lived counters, so they are dropped rather than converted.
+TRUNCATE rate_limit_entries;
+ALTER TABLE rate_limit_entries DROP COLUMN IF EXISTS value;
+ALTER TABLE rate_limit_entries ADD COLUMN IF NOT EXISTS count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rate_limit_entries ALTER COLUMN expires_at SET NOT NULL;
+
+-- Add comments
+COMMENT ON COLUMN rate_limit_entries.count IS 'Requests counted in the current window';
+COMMENT ON COLUMN rate_limit_entries.expires_at IS 'When the current window ends';
@@ -0,0 +1,111 @@
+// Package migrations embeds the SQL migrations so the binary knows which
+// schema version it was built against, and applies them with golang-migrate.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Migration is one embedded migration, named after its up file
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// List returns the embedded migrations in the order they are applied
+func List() ([]Migration, error) {
+	files, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Migration
+	for _, name := range files {
+		prefix, rest, found := strings.Cut(name, "_")
+		if !found {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		list = append(list, Migration{Version: uint(version), Name: strings.TrimSuffix(rest, ".up.sql")})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// LatestVersion returns the highest migration number, the schema version the
+// code expects golang-migrate to have applied
+func LatestVersion() (uint, error) {
+	list, err := List()
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, migration := range list {
+		latest = max(latest, migration.Version)
+	}
+	return latest, nil
+}
+
+// NewMigrator returns a migrator applying the embedded migrations to db and
+// recording the version reached in schema_migrations. Closing the migrator
+// also closes db.
+func NewMigrator(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	driver, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewWithInstance("iofs", source, "pgx5", driver)
+}
+
+// Status is where a database stands against the embedded migrations. Version
+// is 0 when nothing has been applied yet.
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []Migration
+}
+
+// GetStatus reads the version the migrator's database is at and lists the
+// embedded migrations not applied yet
+func GetStatus(m *migrate.Migrate) (*Status, error) {
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+
+	list, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Version: version, Dirty: dirty}
+	for _, migration := range list {
+		status.Latest = max(status.Latest, migration.Version)
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
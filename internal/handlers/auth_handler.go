@@ -12,12 +12,14 @@ import (
 )
 
 type AuthHandler struct {
-	authService services.AuthService
+	authService      services.AuthService
+	loginCodeService services.LoginCodeService
 }
 
-func NewAuthHandler(authService services.AuthService) *AuthHandler {
+func NewAuthHandler(authService services.AuthService, loginCodeService services.LoginCodeService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:      authService,
+		loginCodeService: loginCodeService,
 	}
 }
 
@@ -95,6 +97,76 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, authResp)
 }
 
+// RequestLoginCode godoc
+// @Summary Request a login code
+// @Description Send a one-time login code to a member's email or WhatsApp number, for logging in without a password. The response is the same whether or not an account matches. Only a few codes are sent per account within a time window; further requests succeed without sending one.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body docs.RequestLoginCodeRequest true "Where to send the code"
+// @Success 200 {object} docs.MessageSuccessResponse "Login code sent"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or channel not enabled"
+// @Failure 429 {object} docs.SwaggerErrorResponse "Too many requests from this client"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /auth/code/request [post]
+func (h *AuthHandler) RequestLoginCode(c *fiber.Ctx) error {
+	var req services.RequestLoginCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	if err := h.loginCodeService.RequestCode(req); err != nil {
+		if errors.Is(err, services.ErrLoginCodeChannelDisabled) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Login code channel is not enabled")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to send login code")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Login code sent", nil)
+}
+
+// LoginWithCode godoc
+// @Summary Login with a code
+// @Description Exchange a one-time login code for an access and refresh token, like a password login. Only the latest code works, it expires after a few minutes and stops working after too many wrong guesses.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body docs.LoginWithCodeRequest true "Login code"
+// @Success 200 {object} docs.AuthSuccessResponse "Login successful"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or channel not enabled"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Invalid or expired login code"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /auth/code/login [post]
+func (h *AuthHandler) LoginWithCode(c *fiber.Ctx) error {
+	var req services.LoginWithCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	authResp, err := h.loginCodeService.Login(req)
+	if err != nil {
+		if errors.Is(err, services.ErrLoginCodeChannelDisabled) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Login code channel is not enabled")
+		}
+		if errors.Is(err, services.ErrInvalidLoginCode) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid or expired login code")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to login")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, authResp)
+}
+
 // GetMe godoc
 // @Summary Get current user profile
 // @Description Get the authenticated user's profile information
@@ -133,6 +205,58 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 	})
 }
 
+// UpdateMe godoc
+// @Summary Update current user profile
+// @Description Update the authenticated user's name, phone, preferred language or notification channel. The language (id or en) is used for notifications and API messages; without one, Accept-Language is used. notify_via (email or whatsapp) picks where order updates go; WhatsApp needs a phone number and falls back to email without one. Returns a new access token carrying the updated language.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.UpdateProfileRequest true "Profile fields to update"
+// @Success 200 {object} docs.UpdateProfileSuccessResponse "Profile updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 404 {object} docs.SwaggerErrorResponse "User not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /auth/me [put]
+func (h *AuthHandler) UpdateMe(c *fiber.Ctx) error {
+	// Get user UUID from context
+	userUUID := c.Locals("userUUID")
+	if userUUID == nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	uuid, ok := userUUID.(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	resp, err := h.authService.UpdateProfile(uuid, req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update profile")
+	}
+
+	// Answer in the language the user just picked
+	if resp.User.Locale != nil {
+		c.Locals("locale", *resp.User.Locale)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, resp)
+}
+
 // RefreshToken godoc
 // @Summary Refresh access token
 // @Description Get a new access token using a valid refresh token
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CampaignHandler struct {
+	campaignService services.CampaignService
+	logger          *slog.Logger
+}
+
+func NewCampaignHandler(campaignService services.CampaignService, logger *slog.Logger) *CampaignHandler {
+	return &CampaignHandler{campaignService: campaignService, logger: logger}
+}
+
+// CreateCampaign godoc
+// @Summary Create a campaign
+// @Description Compose a message to every member of a segment: all members, lapsed members with no completed order in inactive_days, or new members without a completed order. It sends right away, or at send_at when given. Members are picked when it starts sending and get it on the channel they chose in notify_via, a batch at a time. Admin only.
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.CreateCampaignRequest true "Message, segment and send time"
+// @Success 201 {object} docs.CampaignSuccessResponse "Campaign scheduled"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or send time in the past"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /campaigns [post]
+func (h *CampaignHandler) CreateCampaign(c *fiber.Ctx) error {
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.CreateCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	campaign, err := h.campaignService.Create(staffUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCampaignSendAtInPast):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create campaign", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create campaign")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Campaign scheduled", campaign)
+}
+
+// GetCampaigns godoc
+// @Summary List campaigns
+// @Description Get a paginated list of campaigns, latest send time first. Admin only.
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Param status query string false "Filter by status" Enums(scheduled, sending, sent, cancelled)
+// @Success 200 {object} docs.CampaignsSuccessResponse "Campaigns retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid status"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /campaigns [get]
+func (h *CampaignHandler) GetCampaigns(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var status *models.CampaignStatus
+	if statusParam := c.Query("status"); statusParam != "" {
+		parsed := models.CampaignStatus(statusParam)
+		switch parsed {
+		case models.CampaignStatusScheduled, models.CampaignStatusSending, models.CampaignStatusSent, models.CampaignStatusCancelled:
+		default:
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status")
+		}
+		status = &parsed
+	}
+
+	campaigns, err := h.campaignService.GetAll(status, page, limit)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to get campaigns", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get campaigns")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, campaigns)
+}
+
+// GetCampaign godoc
+// @Summary Get a campaign
+// @Description Get one campaign with its delivery stats: how many members it went to, how many messages are still pending, sent (per channel) or failed. Admin only.
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign UUID"
+// @Success 200 {object} docs.CampaignSuccessResponse "Campaign retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid campaign ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Campaign not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /campaigns/{id} [get]
+func (h *CampaignHandler) GetCampaign(c *fiber.Ctx) error {
+	campaignUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid campaign ID format")
+	}
+
+	campaign, err := h.campaignService.GetByUUID(campaignUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrCampaignNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Campaign not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get campaign", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get campaign")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, campaign)
+}
+
+// CancelCampaign godoc
+// @Summary Cancel a campaign
+// @Description Stop a scheduled campaign, or one still sending. Members who already got the message keep it; the rest are not sent. Admin only.
+// @Tags Campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign UUID"
+// @Success 200 {object} docs.CampaignSuccessResponse "Campaign cancelled"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid campaign ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Campaign not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Campaign already sent or cancelled"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /campaigns/{id}/cancel [post]
+func (h *CampaignHandler) CancelCampaign(c *fiber.Ctx) error {
+	campaignUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid campaign ID format")
+	}
+
+	campaign, err := h.campaignService.Cancel(campaignUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCampaignNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Campaign not found")
+		case errors.Is(err, services.ErrCampaignNotCancellable):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Only scheduled or sending campaigns can be cancelled")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to cancel campaign", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to cancel campaign")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Campaign cancelled", campaign)
+}
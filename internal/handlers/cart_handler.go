@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// KioskSessionHeader identifies the kiosk cart when staff build an order
+const KioskSessionHeader = "X-Kiosk-Session"
+
+type CartHandler struct {
+	cartService services.CartService
+}
+
+func NewCartHandler(cartService services.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// cartOwner resolves the cart of the request: a member's own cart, or for
+// staff the kiosk session named in the X-Kiosk-Session header. It reports
+// false when staff did not name a session.
+func cartOwner(c *fiber.Ctx) (services.CartOwner, bool) {
+	if role, _ := c.Locals("role").(string); role == string(models.RoleMember) {
+		if userUUID, ok := c.Locals("userUUID").(uuid.UUID); ok {
+			return services.CartOwner{UserUUID: &userUUID}, true
+		}
+	}
+
+	sessionID := c.Get(KioskSessionHeader)
+	if sessionID == "" || len(sessionID) > 64 {
+		return services.CartOwner{}, false
+	}
+	return services.CartOwner{SessionID: sessionID}, true
+}
+
+// GetCart godoc
+// @Summary Get cart
+// @Description Get the cart with a price preview at current prices. Members get their own cart; staff get the kiosk session cart named by the X-Kiosk-Session header. If an item can no longer be ordered, preview is omitted and preview_error explains why.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Success 200 {object} docs.CartSuccessResponse "Cart retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Missing kiosk session"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart [get]
+func (h *CartHandler) GetCart(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	cart, err := h.cartService.GetCart(owner)
+	if err != nil {
+		return handleCartError(c, err, "Failed to get cart")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, cart)
+}
+
+// AddCartItem godoc
+// @Summary Add item to cart
+// @Description Add a product with its options to the cart, creating the cart on first use. Adding the same product with the same options and notes increases the quantity instead.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Param request body docs.CreateOrderItemRequest true "Item to add"
+// @Success 200 {object} docs.CartSuccessResponse "Item added"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, or invalid customization"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Product not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart/items [post]
+func (h *CartHandler) AddCartItem(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	var req services.CreateOrderItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	cart, err := h.cartService.AddItem(owner, req)
+	if err != nil {
+		return handleCartError(c, err, "Failed to add item to cart")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Item added to cart", cart)
+}
+
+// UpdateCartItem godoc
+// @Summary Update cart item
+// @Description Change the quantity or notes of a cart item.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Param itemId path string true "Cart item UUID"
+// @Param request body docs.UpdateCartItemRequest true "New quantity and notes"
+// @Success 200 {object} docs.CartSuccessResponse "Item updated"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Cart item not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart/items/{itemId} [put]
+func (h *CartHandler) UpdateCartItem(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	itemUUID, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid cart item ID format")
+	}
+
+	var req services.UpdateCartItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	cart, err := h.cartService.UpdateItem(owner, itemUUID, req)
+	if err != nil {
+		return handleCartError(c, err, "Failed to update cart item")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Cart item updated", cart)
+}
+
+// RemoveCartItem godoc
+// @Summary Remove cart item
+// @Description Remove an item from the cart.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Param itemId path string true "Cart item UUID"
+// @Success 200 {object} docs.CartSuccessResponse "Item removed"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid cart item ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Cart item not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart/items/{itemId} [delete]
+func (h *CartHandler) RemoveCartItem(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	itemUUID, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid cart item ID format")
+	}
+
+	cart, err := h.cartService.RemoveItem(owner, itemUUID)
+	if err != nil {
+		return handleCartError(c, err, "Failed to remove cart item")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Cart item removed", cart)
+}
+
+// ClearCart godoc
+// @Summary Clear cart
+// @Description Remove every item from the cart.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Success 200 {object} docs.SwaggerSuccessResponse "Cart cleared"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart [delete]
+func (h *CartHandler) ClearCart(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	if err := h.cartService.Clear(owner); err != nil {
+		return handleCartError(c, err, "Failed to clear cart")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Cart cleared", nil)
+}
+
+// CheckoutCart godoc
+// @Summary Check out cart
+// @Description Convert the cart into a pending order at current prices and empty it. Members default to their own name; kiosk checkouts must send customer_name. Kiosk carts become kiosk orders. Send scheduled_for to check out as a pre-order.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param X-Kiosk-Session header string false "Kiosk session ID (staff only)"
+// @Param request body docs.CheckoutCartRequest false "Order details"
+// @Success 201 {object} docs.OrderSuccessResponse "Order created from cart"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, empty cart, an item can no longer be ordered, promo code cannot be applied, table is not taking orders, or scheduled time is not available"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Checkout already in progress, insufficient stock, or the pickup timeslot is full (data lists the next open timeslots)"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /cart/checkout [post]
+func (h *CartHandler) CheckoutCart(c *fiber.Ctx) error {
+	owner, ok := cartOwner(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "X-Kiosk-Session header is required")
+	}
+
+	var req services.CheckoutCartRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.cartService.Checkout(owner, req)
+	if err != nil {
+		var full *services.TimeslotFullError
+		switch {
+		case errors.Is(err, services.ErrCartEmpty):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cart is empty")
+		case errors.Is(err, services.ErrCustomerNameRequired):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Customer name is required")
+		case errors.Is(err, services.ErrCartCheckoutInProgress):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Checkout already in progress")
+		case errors.Is(err, services.ErrProductNotAvailable),
+			errors.Is(err, services.ErrProductNotCustomizable),
+			errors.Is(err, services.ErrInvalidCustomization):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.As(err, &full):
+			return timeslotFullResponse(c, full)
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := tableErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := scheduleErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		return handleCartError(c, err, "Failed to create order")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, order)
+}
+
+func handleCartError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Product not found")
+	case errors.Is(err, services.ErrProductNotAvailable):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Product is not available")
+	case errors.Is(err, services.ErrProductNotCustomizable):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Product is not customizable")
+	case errors.Is(err, services.ErrInvalidCustomization):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid product customization")
+	case errors.Is(err, services.ErrCartItemNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Cart item not found")
+	case errors.Is(err, services.ErrUserNotFound):
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
@@ -29,7 +29,7 @@ func NewCategoryHandler(categoryService services.CategoryService) *CategoryHandl
 // @Security BearerAuth
 // @Param request body docs.CreateCategoryRequest true "Category details"
 // @Success 201 {object} docs.CategorySuccessResponse "Category created successfully"
-// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, parent not found, or nesting too deep"
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
 // @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
 // @Failure 409 {object} docs.SwaggerErrorResponse "Category slug already exists"
@@ -52,6 +52,9 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 		if errors.Is(err, services.ErrCategorySlugExists) {
 			return utils.ErrorResponse(c, fiber.StatusConflict, "Category slug already exists")
 		}
+		if errors.Is(err, services.ErrParentNotFound) || errors.Is(err, services.ErrCategoryTooDeep) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create category")
 	}
 
@@ -117,17 +120,32 @@ func (h *CategoryHandler) GetCategoryBySlug(c *fiber.Ctx) error {
 
 // GetAllCategories godoc
 // @Summary Get all categories
-// @Description Get a list of all categories with optional filtering
+// @Description Get a list of all categories with optional filtering. With tree=true, categories are nested under their parents.
 // @Tags Categories
 // @Accept json
 // @Produce json
 // @Param active_only query boolean false "Filter to show only active categories"
+// @Param tree query boolean false "Return categories as a nested tree"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} docs.CategoriesSuccessResponse "Categories retrieved successfully"
+// @Success 304 "Categories not modified"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /categories [get]
 func (h *CategoryHandler) GetAllCategories(c *fiber.Ctx) error {
 	activeOnly := c.QueryBool("active_only", false)
 
+	if c.QueryBool("tree", false) {
+		tree, err := h.categoryService.GetTree(activeOnly)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get categories")
+		}
+
+		return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+			"categories": tree,
+			"count":      len(tree),
+		})
+	}
+
 	categories, err := h.categoryService.GetAll(activeOnly)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get categories")
@@ -149,7 +167,7 @@ func (h *CategoryHandler) GetAllCategories(c *fiber.Ctx) error {
 // @Param id path string true "Category UUID"
 // @Param request body docs.UpdateCategoryRequest true "Category update details"
 // @Success 200 {object} docs.CategorySuccessResponse "Category updated successfully"
-// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid ID format"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid ID format, or invalid parent"
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
 // @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
 // @Failure 404 {object} docs.SwaggerErrorResponse "Category not found"
@@ -184,22 +202,67 @@ func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 		if errors.Is(err, services.ErrCategorySlugExists) {
 			return utils.ErrorResponse(c, fiber.StatusConflict, "Category slug already exists")
 		}
+		if errors.Is(err, services.ErrParentNotFound) ||
+			errors.Is(err, services.ErrCategoryTooDeep) ||
+			errors.Is(err, services.ErrCategoryCycle) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update category")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, category)
 }
 
+// ReorderCategories godoc
+// @Summary Reorder categories
+// @Description Set the display order of categories in one call. IDs are listed in their new order (Admin only).
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.ReorderRequest true "Category UUIDs in display order"
+// @Success 200 {object} docs.MessageSuccessResponse "Categories reordered successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Category not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /categories/reorder [put]
+func (h *CategoryHandler) ReorderCategories(c *fiber.Ctx) error {
+	var req services.ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	// Reorder categories
+	if err := h.categoryService.Reorder(req); err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Category not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reorder categories")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Categories reordered successfully",
+	})
+}
+
 // DeleteCategory godoc
-// @Summary Delete a category
-// @Description Delete a category by its UUID (Admin only)
+// @Summary Soft delete a category
+// @Description Soft delete a category by its UUID (Admin only). Pass reassign_to to move its products to another category; otherwise the products stay attached and are hidden until the category is restored.
 // @Tags Categories
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Category UUID"
+// @Param reassign_to query string false "Category UUID to move the products to"
 // @Success 200 {object} docs.MessageSuccessResponse "Category deleted successfully"
-// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid category ID format"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid category ID format or invalid reassignment target"
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
 // @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
 // @Failure 404 {object} docs.SwaggerErrorResponse "Category not found"
@@ -214,12 +277,25 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid category ID format")
 	}
 
+	// Parse optional reassignment target
+	var reassignTo *uuid.UUID
+	if reassignParam := c.Query("reassign_to"); reassignParam != "" {
+		parsed, parseErr := uuid.Parse(reassignParam)
+		if parseErr != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reassign_to category ID format")
+		}
+		reassignTo = &parsed
+	}
+
 	// Delete category
-	err = h.categoryService.Delete(categoryUUID)
+	err = h.categoryService.Delete(categoryUUID, reassignTo)
 	if err != nil {
 		if errors.Is(err, services.ErrCategoryNotFound) {
 			return utils.ErrorResponse(c, fiber.StatusNotFound, "Category not found")
 		}
+		if errors.Is(err, services.ErrInvalidReassignment) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Products can only be reassigned to another existing category")
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete category")
 	}
 
@@ -227,3 +303,44 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 		"message": "Category deleted successfully",
 	})
 }
+
+// RestoreCategory godoc
+// @Summary Restore a soft-deleted category
+// @Description Restore a previously soft-deleted category by its UUID (Admin only). Products still attached to it become visible again.
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category UUID"
+// @Success 200 {object} docs.MessageSuccessResponse "Category restored successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid category ID format or category is not deleted"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Category not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /categories/{id}/restore [post]
+func (h *CategoryHandler) RestoreCategory(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+
+	// Parse UUID
+	categoryUUID, err := uuid.Parse(idParam)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid category ID format")
+	}
+
+	// Restore category
+	err = h.categoryService.Restore(categoryUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Category not found")
+		}
+		if errors.Is(err, services.ErrCategoryNotDeleted) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Category is not deleted")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to restore category")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Category restored successfully",
+	})
+}
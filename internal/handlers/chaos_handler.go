@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/chaos"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChaosHandler struct {
+	injector *chaos.Injector
+}
+
+func NewChaosHandler(injector *chaos.Injector) *ChaosHandler {
+	return &ChaosHandler{
+		injector: injector,
+	}
+}
+
+// GetFaults godoc
+// @Summary Get injected faults (staging only)
+// @Description Get the failure modes currently injected on this instance. Only available when CHAOS_ENABLED is set outside production. Admin only.
+// @Tags Chaos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.ChaosFaultsSuccessResponse "Active faults"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Router /chaos [get]
+func (h *ChaosHandler) GetFaults(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, fiber.StatusOK, chaos.ToFaultsResponse(h.injector.Faults()))
+}
+
+// UpdateFaults godoc
+// @Summary Inject faults (staging only)
+// @Description Replace the injected faults: added latency on paths with a prefix, failing Midtrans payment token creation, and rejecting Midtrans webhook signatures. Faults clear themselves after duration_seconds. Admin only.
+// @Tags Chaos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.UpdateChaosFaultsRequest true "Faults to inject"
+// @Success 200 {object} docs.ChaosFaultsSuccessResponse "Faults injected"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Router /chaos [put]
+func (h *ChaosHandler) UpdateFaults(c *fiber.Ctx) error {
+	var req chaos.UpdateFaultsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	h.injector.Set(req.Faults())
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Faults injected", chaos.ToFaultsResponse(h.injector.Faults()))
+}
+
+// ResetFaults godoc
+// @Summary Clear injected faults (staging only)
+// @Description Stop injecting every fault on this instance. Admin only.
+// @Tags Chaos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.SwaggerSuccessResponse "Faults cleared"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Router /chaos [delete]
+func (h *ChaosHandler) ResetFaults(c *fiber.Ctx) error {
+	h.injector.Reset()
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Faults cleared", nil)
+}
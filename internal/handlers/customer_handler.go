@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CustomerHandler struct {
+	customerService services.CustomerService
+	logger          *slog.Logger
+}
+
+func NewCustomerHandler(customerService services.CustomerService, logger *slog.Logger) *CustomerHandler {
+	return &CustomerHandler{customerService: customerService, logger: logger}
+}
+
+// ExportSegment godoc
+// @Summary Export a customer segment
+// @Description List the active members of a segment for marketing, biggest spenders first: all members, lapsed members without a completed order in inactive_days, or new members without a completed order. Narrow it down by last order date, total spend or favorite category (including its subcategories). Order figures come from member stats rebuilt every hour, so they may trail the latest orders; stats_refreshed_at tells when they were built. Use format=csv to download a spreadsheet instead of JSON. Admin only.
+// @Tags Customers
+// @Accept json
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param name path string true "Segment name" Enums(all, lapsed, new)
+// @Param inactive_days query integer false "Days without a completed order for lapsed members" default(30)
+// @Param last_order_from query string false "Last completed order on or after this date (YYYY-MM-DD)"
+// @Param last_order_to query string false "Last completed order on or before this date (YYYY-MM-DD)"
+// @Param min_spend query number false "Minimum total spend"
+// @Param max_spend query number false "Maximum total spend"
+// @Param category_id query string false "Favorite category UUID"
+// @Param format query string false "Response format" Enums(json, csv) default(json)
+// @Success 200 {object} docs.CustomerSegmentExportSuccessResponse "Segment exported successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid criteria or format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Segment not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /customers/segments/{name}/export [get]
+func (h *CustomerHandler) ExportSegment(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Format must be either json or csv")
+	}
+
+	criteria, message := parseSegmentCriteria(c)
+	if message != "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+	}
+
+	export, err := h.customerService.ExportSegment(c.Params("name"), criteria)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCustomerSegmentNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Segment not found")
+		case errors.Is(err, services.ErrInvalidDateRange):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "last_order_to must not be before last_order_from")
+		case errors.Is(err, services.ErrCustomerSpendRange),
+			errors.Is(err, services.ErrCustomerCategoryNotFound):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to export customer segment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export segment")
+	}
+
+	if format == "csv" {
+		var body bytes.Buffer
+		if err := export.WriteCSV(&body); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export segment")
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment(fmt.Sprintf("customers_%s_%s.csv", export.Segment, time.Now().Format("2006-01-02")))
+		return c.Status(fiber.StatusOK).Send(body.Bytes())
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, export)
+}
+
+// GetLifetimeValues godoc
+// @Summary Customer lifetime value
+// @Description Rank active members with a completed order by total spend, number of orders or most recent order, with their average order value, first and last order and average days between orders. Order figures come from member stats rebuilt every hour; stats_refreshed_at tells when they were built. Use format=csv to download every ranked member as a spreadsheet, ignoring page and limit. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param sort query string false "Ranking" Enums(spend, orders, recent) default(spend)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (1-100)" default(20)
+// @Param format query string false "Response format" Enums(json, csv) default(json)
+// @Success 200 {object} docs.CustomerValueReportSuccessResponse "Customers ranked successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid sort or format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/customers [get]
+func (h *CustomerHandler) GetLifetimeValues(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Format must be either json or csv")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if format == "csv" {
+		page, limit = 1, 0
+	}
+
+	report, err := h.customerService.GetLifetimeValues(c.Query("sort", "spend"), page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerRankingInvalid) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to rank customers", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get customer report")
+	}
+
+	if format == "csv" {
+		var body bytes.Buffer
+		if err := report.WriteCSV(&body); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export customer report")
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment(fmt.Sprintf("customer_value_%s_%s.csv", report.SortBy, time.Now().Format("2006-01-02")))
+		return c.Status(fiber.StatusOK).Send(body.Bytes())
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// parseSegmentCriteria reads the export criteria from the query string,
+// returning a message for the first invalid one
+func parseSegmentCriteria(c *fiber.Ctx) (services.CustomerSegmentCriteria, string) {
+	var criteria services.CustomerSegmentCriteria
+
+	if param := c.Query("inactive_days"); param != "" {
+		days, err := strconv.Atoi(param)
+		if err != nil || days < 1 || days > 365 {
+			return criteria, "inactive_days must be between 1 and 365"
+		}
+		criteria.InactiveDays = days
+	}
+
+	dates := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"last_order_from", &criteria.LastOrderFrom},
+		{"last_order_to", &criteria.LastOrderTo},
+	}
+	for _, date := range dates {
+		if value := c.Query(date.param); value != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", value, time.Now().Location())
+			if err != nil {
+				return criteria, "Invalid date format, expected YYYY-MM-DD"
+			}
+			*date.target = &parsed
+		}
+	}
+
+	amounts := []struct {
+		param  string
+		target **float64
+	}{
+		{"min_spend", &criteria.MinSpend},
+		{"max_spend", &criteria.MaxSpend},
+	}
+	for _, amount := range amounts {
+		if value := c.Query(amount.param); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 {
+				return criteria, amount.param + " must be a non-negative number"
+			}
+			*amount.target = &parsed
+		}
+	}
+
+	if param := c.Query("category_id"); param != "" {
+		parsed, err := uuid.Parse(param)
+		if err != nil {
+			return criteria, "Invalid category ID format"
+		}
+		criteria.FavoriteCategoryID = &parsed
+	}
+
+	return criteria, ""
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DailySummaryHandler struct {
+	summaryService services.DailySummaryService
+	location       *time.Location
+}
+
+// NewDailySummaryHandler reads business dates in location, the store timezone
+func NewDailySummaryHandler(summaryService services.DailySummaryService, location *time.Location) *DailySummaryHandler {
+	return &DailySummaryHandler{
+		summaryService: summaryService,
+		location:       location,
+	}
+}
+
+// CloseDay godoc
+// @Summary Close the business day
+// @Description Finalize a business day in the store timezone, today by default. The day's totals are saved as a daily summary and its orders can no longer change status. Orders still open are flagged and listed for follow-up. A day can only be closed once. Admin only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.CloseDayRequest false "Business day to close"
+// @Success 201 {object} docs.DailySummarySuccessResponse "Business day closed successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid business date or day not started yet"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Business day already closed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/close-day [post]
+func (h *DailySummaryHandler) CloseDay(c *fiber.Ctx) error {
+	var req services.CloseDayRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	now := time.Now().In(h.location)
+	businessDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, h.location)
+	if req.BusinessDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.BusinessDate, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid business_date, use YYYY-MM-DD")
+		}
+		businessDate = parsed
+	}
+
+	summary, err := h.summaryService.CloseDay(businessDate, userUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrDayNotOver):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Business day has not started yet")
+		case errors.Is(err, services.ErrDayAlreadyClosed):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Business day is already closed")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to close business day")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, summary)
+}
+
+// GetZReport godoc
+// @Summary Z-report
+// @Description End-of-day summary of a business day in the store timezone, today by default: gross sales, discounts, tax, tips, cash, gateway and stored-value (wallet, gift card, points) payments settled, refunds issued and cancelled orders. A closed day returns the snapshot saved when it was closed, which no longer changes; a day still open is totalled as it stands with closed set to false. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Business date (YYYY-MM-DD)"
+// @Success 200 {object} docs.DailySummarySuccessResponse "Z-report retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or day not started yet"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/z-report [get]
+func (h *DailySummaryHandler) GetZReport(c *fiber.Ctx) error {
+	now := time.Now().In(h.location)
+	businessDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, h.location)
+	if param := c.Query("date"); param != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", param, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+		}
+		businessDate = parsed
+	}
+
+	report, err := h.summaryService.GetZReport(businessDate)
+	if err != nil {
+		if errors.Is(err, services.ErrDayNotOver) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Business day has not started yet")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get Z-report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
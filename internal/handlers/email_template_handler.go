@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailTemplateHandler struct {
+	templateService services.EmailTemplateService
+}
+
+func NewEmailTemplateHandler(templateService services.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetEmailTemplates godoc
+// @Summary Get email templates
+// @Description List the active version of every notification email template in each language, with the variables each one accepts. Admin only.
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.EmailTemplateListSuccessResponse "Email templates retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /email-templates [get]
+func (h *EmailTemplateHandler) GetEmailTemplates(c *fiber.Ctx) error {
+	templates, err := h.templateService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get email templates")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, templates)
+}
+
+// GetEmailTemplateVersions godoc
+// @Summary Get email template versions
+// @Description List every saved version of a template, newest first. Admin only.
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Template key" Enums(order_confirmation, order_ready, password_reset, login_code, order_receipt, points_expiring)
+// @Param locale query string false "Template language, defaults to the store locale" Enums(id, en)
+// @Success 200 {object} docs.EmailTemplateListSuccessResponse "Email template versions retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid locale"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Email template not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /email-templates/{key}/versions [get]
+func (h *EmailTemplateHandler) GetEmailTemplateVersions(c *fiber.Ctx) error {
+	key := models.EmailTemplateKey(c.Params("key"))
+
+	templates, err := h.templateService.GetVersions(key, c.Query("locale"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLocale) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid locale. Must be one of: id, en")
+		}
+		if errors.Is(err, services.ErrEmailTemplateNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Email template not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get email template versions")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, templates)
+}
+
+// UpdateEmailTemplate godoc
+// @Summary Update email template
+// @Description Save new content for a template as a new version and make it active. Placeholders use the {{variable}} syntax and must be one of the template's variables. Admin only.
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Template key" Enums(order_confirmation, order_ready, password_reset, login_code, order_receipt, points_expiring)
+// @Param locale query string false "Template language, defaults to the store locale" Enums(id, en)
+// @Param request body docs.UpdateEmailTemplateRequest true "Template content"
+// @Success 200 {object} docs.EmailTemplateSuccessResponse "Email template updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, unknown variable or invalid locale"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Email template not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /email-templates/{key} [put]
+func (h *EmailTemplateHandler) UpdateEmailTemplate(c *fiber.Ctx) error {
+	key := models.EmailTemplateKey(c.Params("key"))
+
+	var req services.UpdateEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	template, err := h.templateService.Update(key, c.Query("locale"), req)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLocale) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid locale. Must be one of: id, en")
+		}
+		if errors.Is(err, services.ErrEmailTemplateNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Email template not found")
+		}
+		if errors.Is(err, services.ErrUnknownTemplateVariable) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update email template")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, template)
+}
+
+// ActivateEmailTemplateVersion godoc
+// @Summary Activate email template version
+// @Description Make an earlier (or later) saved version the one used for sending. Admin only.
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Template key" Enums(order_confirmation, order_ready, password_reset, login_code, order_receipt, points_expiring)
+// @Param locale query string false "Template language, defaults to the store locale" Enums(id, en)
+// @Param version path int true "Version number"
+// @Success 200 {object} docs.EmailTemplateSuccessResponse "Email template version activated successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid version or locale"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Email template version not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /email-templates/{key}/versions/{version}/activate [post]
+func (h *EmailTemplateHandler) ActivateEmailTemplateVersion(c *fiber.Ctx) error {
+	key := models.EmailTemplateKey(c.Params("key"))
+
+	version, err := c.ParamsInt("version")
+	if err != nil || version < 1 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid version")
+	}
+
+	template, err := h.templateService.Activate(key, c.Query("locale"), version)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLocale) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid locale. Must be one of: id, en")
+		}
+		if errors.Is(err, services.ErrEmailTemplateNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Email template version not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to activate email template version")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, template)
+}
+
+// TestSendEmailTemplate godoc
+// @Summary Send test email
+// @Description Send the active version of a template to the given address. Variables that are not supplied are filled with sample values. Admin only.
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Template key" Enums(order_confirmation, order_ready, password_reset, login_code, order_receipt, points_expiring)
+// @Param locale query string false "Template language, defaults to the store locale" Enums(id, en)
+// @Param request body docs.TestSendEmailTemplateRequest true "Recipient and variables"
+// @Success 200 {object} docs.MessageSuccessResponse "Test email sent successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid locale"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Email template not found"
+// @Failure 502 {object} docs.SwaggerErrorResponse "Mail server rejected the email"
+// @Router /email-templates/{key}/test-send [post]
+func (h *EmailTemplateHandler) TestSendEmailTemplate(c *fiber.Ctx) error {
+	key := models.EmailTemplateKey(c.Params("key"))
+
+	var req services.TestSendEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	if err := h.templateService.TestSend(key, c.Query("locale"), req); err != nil {
+		if errors.Is(err, services.ErrUnsupportedLocale) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid locale. Must be one of: id, en")
+		}
+		if errors.Is(err, services.ErrEmailTemplateNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Email template not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusBadGateway, "Failed to send test email")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Test email sent",
+	})
+}
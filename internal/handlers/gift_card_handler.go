@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type GiftCardHandler struct {
+	giftCardService services.GiftCardService
+	paymentService  services.PaymentService
+	logger          *slog.Logger
+}
+
+func NewGiftCardHandler(giftCardService services.GiftCardService, paymentService services.PaymentService, logger *slog.Logger) *GiftCardHandler {
+	return &GiftCardHandler{
+		giftCardService: giftCardService,
+		paymentService:  paymentService,
+		logger:          logger,
+	}
+}
+
+// PurchaseGiftCard godoc
+// @Summary Buy a gift card
+// @Description Open a payment page with the configured gateway to buy a digital gift card. The code is returned straight away but can only be redeemed once the gateway settles the payment.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.GiftCardPurchaseRequest true "Amount and optional recipient"
+// @Success 201 {object} docs.GiftCardPurchaseSuccessResponse "Gift card purchase started"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/gift-cards [post]
+func (h *GiftCardHandler) PurchaseGiftCard(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.GiftCardPurchaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	req.RequestID = utils.RequestID(c)
+	purchase, err := h.paymentService.PurchaseGiftCard(userUUID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to purchase gift card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to purchase gift card")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Gift card purchase started", purchase)
+}
+
+// RedeemGiftCard godoc
+// @Summary Redeem a gift card against an order
+// @Description Take money off a pending order's amount due from an active gift card. Leave the amount out to use as much as the card and the order allow. When the card covers the rest of the order it is paid and goes to the kitchen; otherwise pay the remainder through checkout as usual.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Param id path string true "Order UUID"
+// @Param request body docs.RedeemGiftCardRequest true "Gift card code and optional amount"
+// @Success 201 {object} docs.GiftCardRedemptionSuccessResponse "Gift card redeemed"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid order ID or validation error"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment, the card is not usable, or the amount is too high"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/gift-card [post]
+func (h *GiftCardHandler) RedeemGiftCard(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req services.RedeemGiftCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	redemption, err := h.paymentService.RedeemGiftCard(orderUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrGiftCardInvalid):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Gift card code is invalid or not active")
+		case errors.Is(err, services.ErrGiftCardInsufficientBalance):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Gift card balance is too low")
+		case errors.Is(err, services.ErrGiftCardExceedsAmountDue):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Amount exceeds the amount due")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to redeem gift card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to redeem gift card")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Gift card redeemed", redemption)
+}
+
+// IssueGiftCard godoc
+// @Summary Issue a gift card
+// @Description Create an active gift card without payment, such as for a giveaway or to replace a lost card. Admin only.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.IssueGiftCardRequest true "Amount and optional recipient"
+// @Success 201 {object} docs.GiftCardSuccessResponse "Gift card issued"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /gift-cards [post]
+func (h *GiftCardHandler) IssueGiftCard(c *fiber.Ctx) error {
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.IssueGiftCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	card, err := h.giftCardService.Issue(staffUUID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to issue gift card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to issue gift card")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Gift card issued", card)
+}
+
+// GetGiftCards godoc
+// @Summary List gift cards
+// @Description Get a paginated list of gift cards, newest first. Admin only.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Param status query string false "Filter by status" Enums(pending, active, voided)
+// @Success 200 {object} docs.GiftCardsSuccessResponse "Gift cards retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid status"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /gift-cards [get]
+func (h *GiftCardHandler) GetGiftCards(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var status *models.GiftCardStatus
+	if statusParam := c.Query("status"); statusParam != "" {
+		parsed := models.GiftCardStatus(statusParam)
+		if parsed != models.GiftCardStatusPending && parsed != models.GiftCardStatusActive && parsed != models.GiftCardStatusVoided {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status")
+		}
+		status = &parsed
+	}
+
+	cards, err := h.giftCardService.GetAll(status, page, limit)
+	if err != nil {
+		h.logger.ErrorContext(c.UserContext(), "Failed to get gift cards", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get gift cards")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, cards)
+}
+
+// GetGiftCard godoc
+// @Summary Get a gift card
+// @Description Get one gift card with its balance and the orders it paid for. Admin only.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Gift card UUID"
+// @Success 200 {object} docs.GiftCardSuccessResponse "Gift card retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid gift card ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Gift card not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /gift-cards/{id} [get]
+func (h *GiftCardHandler) GetGiftCard(c *fiber.Ctx) error {
+	cardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid gift card ID format")
+	}
+
+	card, err := h.giftCardService.GetByUUID(cardUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrGiftCardNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Gift card not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get gift card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get gift card")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, card)
+}
+
+// CheckGiftCardBalance godoc
+// @Summary Check a gift card balance
+// @Description Look a gift card up by the code printed on it, typed in any case with or without dashes, to check its balance and history. Admin only.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Gift card code"
+// @Success 200 {object} docs.GiftCardSuccessResponse "Gift card retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Gift card not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /gift-cards/code/{code} [get]
+func (h *GiftCardHandler) CheckGiftCardBalance(c *fiber.Ctx) error {
+	card, err := h.giftCardService.GetByCode(c.Params("code"))
+	if err != nil {
+		if errors.Is(err, services.ErrGiftCardNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Gift card not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to check gift card balance", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get gift card")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, card)
+}
+
+// VoidGiftCard godoc
+// @Summary Void a gift card
+// @Description Stop an active gift card from being redeemed, such as when it is reported stolen. Orders it already paid for are not affected. Admin only.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Gift card UUID"
+// @Param request body docs.VoidGiftCardRequest true "Reason for voiding"
+// @Success 200 {object} docs.GiftCardSuccessResponse "Gift card voided"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid gift card ID or validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Gift card not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Gift card is not active"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /gift-cards/{id}/void [post]
+func (h *GiftCardHandler) VoidGiftCard(c *fiber.Ctx) error {
+	cardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid gift card ID format")
+	}
+
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.VoidGiftCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	card, err := h.giftCardService.Void(cardUUID, staffUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrGiftCardNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Gift card not found")
+		case errors.Is(err, services.ErrGiftCardNotActive):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Only active gift cards can be voided")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to void gift card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to void gift card")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Gift card voided", card)
+}
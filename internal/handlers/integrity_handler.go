@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type IntegrityHandler struct {
+	integrityService services.IntegrityService
+}
+
+func NewIntegrityHandler(integrityService services.IntegrityService) *IntegrityHandler {
+	return &IntegrityHandler{
+		integrityService: integrityService,
+	}
+}
+
+// GetAnomalies godoc
+// @Summary List order anomalies
+// @Description List anomalies found by the daily order integrity check: items whose product snapshot no longer matches any product, and orders whose subtotal or total does not add up. Open anomalies only unless include_resolved is set. Admin only.
+// @Tags Integrity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param include_resolved query bool false "Include resolved anomalies"
+// @Success 200 {object} docs.AnomaliesSuccessResponse "Anomalies retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /integrity/anomalies [get]
+func (h *IntegrityHandler) GetAnomalies(c *fiber.Ctx) error {
+	anomalies, err := h.integrityService.GetAnomalies(c.QueryBool("include_resolved", false))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get anomalies")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, anomalies)
+}
+
+// ResolveAnomaly godoc
+// @Summary Resolve an order anomaly
+// @Description Mark an anomaly as reviewed. Resolved anomalies are not reported again. Admin only.
+// @Tags Integrity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Anomaly UUID"
+// @Success 200 {object} docs.SwaggerSuccessResponse "Anomaly resolved"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid anomaly ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Anomaly not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Anomaly already resolved"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /integrity/anomalies/{id}/resolve [post]
+func (h *IntegrityHandler) ResolveAnomaly(c *fiber.Ctx) error {
+	anomalyUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid anomaly ID format")
+	}
+
+	if err := h.integrityService.ResolveAnomaly(anomalyUUID); err != nil {
+		if errors.Is(err, services.ErrAnomalyNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Anomaly not found")
+		}
+		if errors.Is(err, services.ErrAnomalyAlreadyResolved) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Anomaly already resolved")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to resolve anomaly")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Anomaly resolved", nil)
+}
+
+// RunIntegrityCheck godoc
+// @Summary Run the order integrity check
+// @Description Run the order integrity check now instead of waiting for the daily run. Admin only.
+// @Tags Integrity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.IntegrityCheckSuccessResponse "Integrity check completed"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /integrity/run [post]
+func (h *IntegrityHandler) RunIntegrityCheck(c *fiber.Ctx) error {
+	result, err := h.integrityService.RunCheck()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to run integrity check")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Integrity check completed", result)
+}
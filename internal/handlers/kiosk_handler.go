@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type KioskHandler struct {
+	kioskService services.KioskService
+}
+
+func NewKioskHandler(kioskService services.KioskService) *KioskHandler {
+	return &KioskHandler{
+		kioskService: kioskService,
+	}
+}
+
+// CreateKiosk godoc
+// @Summary Register a kiosk
+// @Description Register a self-service kiosk and issue its API key. The key is only returned in this response; configure it on the kiosk as the X-Kiosk-Key header. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.KioskRequest true "Kiosk details"
+// @Success 201 {object} docs.KioskSuccessResponse "Kiosk created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Kiosk name already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks [post]
+func (h *KioskHandler) CreateKiosk(c *fiber.Ctx) error {
+	var req services.KioskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	kiosk, err := h.kioskService.Create(req)
+	if err != nil {
+		return handleKioskError(c, err, "Failed to create kiosk")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, kiosk)
+}
+
+// GetKiosks godoc
+// @Summary List kiosks
+// @Description List all kiosks by name with whether they are online, i.e. sent a heartbeat recently. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.KioskListSuccessResponse "Kiosks retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks [get]
+func (h *KioskHandler) GetKiosks(c *fiber.Ctx) error {
+	kiosks, err := h.kioskService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get kiosks")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, kiosks)
+}
+
+// GetKiosk godoc
+// @Summary Get a kiosk
+// @Description Get a single kiosk by its UUID. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kiosk UUID"
+// @Success 200 {object} docs.KioskSuccessResponse "Kiosk retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid kiosk ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Kiosk not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks/{id} [get]
+func (h *KioskHandler) GetKiosk(c *fiber.Ctx) error {
+	kioskUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid kiosk ID format")
+	}
+
+	kiosk, err := h.kioskService.GetByUUID(kioskUUID)
+	if err != nil {
+		return handleKioskError(c, err, "Failed to get kiosk")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, kiosk)
+}
+
+// UpdateKiosk godoc
+// @Summary Update a kiosk
+// @Description Rename a kiosk or switch it off. A switched-off kiosk is refused until it is switched back on; its API key stays the same. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kiosk UUID"
+// @Param request body docs.KioskRequest true "Kiosk details"
+// @Success 200 {object} docs.KioskSuccessResponse "Kiosk updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid kiosk ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Kiosk not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Kiosk name already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks/{id} [put]
+func (h *KioskHandler) UpdateKiosk(c *fiber.Ctx) error {
+	kioskUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid kiosk ID format")
+	}
+
+	var req services.KioskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	kiosk, err := h.kioskService.Update(kioskUUID, req)
+	if err != nil {
+		return handleKioskError(c, err, "Failed to update kiosk")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, kiosk)
+}
+
+// DeleteKiosk godoc
+// @Summary Delete a kiosk
+// @Description Delete a kiosk so its API key stops working. Its orders are kept. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kiosk UUID"
+// @Success 200 {object} docs.MessageSuccessResponse "Kiosk deleted successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid kiosk ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Kiosk not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks/{id} [delete]
+func (h *KioskHandler) DeleteKiosk(c *fiber.Ctx) error {
+	kioskUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid kiosk ID format")
+	}
+
+	if err := h.kioskService.Delete(kioskUUID); err != nil {
+		return handleKioskError(c, err, "Failed to delete kiosk")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Kiosk deleted successfully",
+	})
+}
+
+// RegenerateKioskKey godoc
+// @Summary Regenerate a kiosk's API key
+// @Description Issue a new API key, e.g. when the kiosk is replaced or its key leaked. The old key stops working at once. The new key is only returned in this response. Admin only.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Kiosk UUID"
+// @Success 200 {object} docs.KioskSuccessResponse "Key regenerated successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid kiosk ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Kiosk not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks/{id}/key [post]
+func (h *KioskHandler) RegenerateKioskKey(c *fiber.Ctx) error {
+	kioskUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid kiosk ID format")
+	}
+
+	kiosk, err := h.kioskService.RegenerateKey(kioskUUID)
+	if err != nil {
+		return handleKioskError(c, err, "Failed to regenerate kiosk key")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, kiosk)
+}
+
+// Heartbeat godoc
+// @Summary Send a kiosk heartbeat
+// @Description Report that the kiosk is running. Kiosks should call this every minute; admins see a kiosk as offline once heartbeats stop for KIOSK_OFFLINE_AFTER.
+// @Tags Kiosks
+// @Accept json
+// @Produce json
+// @Security KioskKey
+// @Param request body docs.KioskHeartbeatRequest false "Kiosk app details"
+// @Success 200 {object} docs.KioskSuccessResponse "Heartbeat recorded"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Missing or invalid kiosk key"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /kiosks/heartbeat [post]
+func (h *KioskHandler) Heartbeat(c *fiber.Ctx) error {
+	kiosk, ok := c.Locals("kiosk").(*models.Kiosk)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req services.KioskHeartbeatRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	response, err := h.kioskService.Heartbeat(kiosk, c.IP(), req)
+	if err != nil {
+		return handleKioskError(c, err, "Failed to record heartbeat")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+func handleKioskError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrKioskNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Kiosk not found")
+	case errors.Is(err, services.ErrKioskNameExists):
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Kiosk name already exists")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
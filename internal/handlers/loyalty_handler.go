@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type LoyaltyHandler struct {
+	loyaltyService services.LoyaltyService
+	paymentService services.PaymentService
+	logger         *slog.Logger
+}
+
+func NewLoyaltyHandler(loyaltyService services.LoyaltyService, paymentService services.PaymentService, logger *slog.Logger) *LoyaltyHandler {
+	return &LoyaltyHandler{
+		loyaltyService: loyaltyService,
+		paymentService: paymentService,
+		logger:         logger,
+	}
+}
+
+// GetMyPoints godoc
+// @Summary Get my loyalty points
+// @Description Get the authenticated member's points balance, what it is worth at the current point value, when the points expire with those expiring soon flagged, and a paginated statement of points earned, spent, given back and expired, newest first.
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Success 200 {object} docs.PointsSuccessResponse "Points retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/points [get]
+func (h *LoyaltyHandler) GetMyPoints(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+	return h.getPoints(c, userUUID)
+}
+
+// GetPoints godoc
+// @Summary Get a member's loyalty points
+// @Description Get a member's points balance with a paginated statement, newest first. Admin only.
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User UUID"
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Success 200 {object} docs.PointsSuccessResponse "Points retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid user ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "User not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /points/{userId} [get]
+func (h *LoyaltyHandler) GetPoints(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+	return h.getPoints(c, userUUID)
+}
+
+func (h *LoyaltyHandler) getPoints(c *fiber.Ctx, userUUID uuid.UUID) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	points, err := h.loyaltyService.GetPoints(userUUID, page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get points", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get points")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, points)
+}
+
+// GetMyStamps godoc
+// @Summary Get my stamp card
+// @Description Get the authenticated member's stamp card: the stamps collected toward the next free drink, how many more are needed, and the free-drink vouchers full cards were swapped for, newest first. Each qualifying drink on a completed order earns a stamp. Redeem a reward at checkout by passing its code as voucher_code; it covers the dearest qualifying drink on the order.
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.StampCardSuccessResponse "Stamp card retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/stamps [get]
+func (h *LoyaltyHandler) GetMyStamps(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	card, err := h.loyaltyService.GetMyStamps(userUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get stamp card", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get stamp card")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, card)
+}
+
+// RedeemPoints godoc
+// @Summary Pay an order with my points
+// @Description Spend loyalty points on the authenticated member's pending order, at the point value in the loyalty settings. The points are taken and the amount due lowered together. When the points cover the rest of the order it is paid and goes to the kitchen; otherwise pay the remainder through checkout as usual. Points come back if the order is cancelled.
+// @Tags Loyalty
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Param request body docs.RedeemPointsRequest true "Points to spend"
+// @Success 201 {object} docs.PointsRedemptionSuccessResponse "Points redeemed"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid order ID or validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - not the member's order"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment, too few points, points worth more than the amount due, or points are not accepted"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/payments/points [post]
+func (h *LoyaltyHandler) RedeemPoints(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.RedeemPointsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	redemption, err := h.paymentService.RedeemPoints(orderUUID, userUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderAccessDenied):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrInsufficientPoints):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Insufficient points")
+		case errors.Is(err, services.ErrPointsExceedAmountDue):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Points are worth more than the amount due")
+		case errors.Is(err, services.ErrPointsRedemptionDisabled):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Points cannot be spent on orders")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to redeem points", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to redeem points")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Points redeemed", redemption)
+}
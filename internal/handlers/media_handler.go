@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MediaHandler struct {
+	mediaService services.MediaService
+}
+
+func NewMediaHandler(mediaService services.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// UploadMedia godoc
+// @Summary Upload an image
+// @Description Upload a JPEG, PNG, WebP or GIF image and get the URL to use as a product or category image. Uploads that would exceed the storage quota are rejected. Admin only.
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file"
+// @Success 201 {object} docs.MediaFileSuccessResponse "Image uploaded successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "File missing or not a supported image"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 413 {object} docs.SwaggerErrorResponse "File too large or storage quota exceeded"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /media [post]
+func (h *MediaHandler) UploadMedia(c *fiber.Ctx) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "File is required")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid file")
+	}
+	defer file.Close() //nolint:errcheck
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid file")
+	}
+
+	media, err := h.mediaService.Upload(header.Filename, content)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedMediaType) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Unsupported file type. Must be one of: jpeg, png, webp, gif")
+		}
+		if errors.Is(err, services.ErrMediaTooLarge) {
+			return utils.ErrorResponse(c, fiber.StatusRequestEntityTooLarge, "File exceeds the upload size limit")
+		}
+		if errors.Is(err, services.ErrStorageQuotaExceeded) {
+			return utils.ErrorResponse(c, fiber.StatusRequestEntityTooLarge, "Storage quota exceeded")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to upload file")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Image uploaded successfully", media)
+}
+
+// GetStorageUsage godoc
+// @Summary Get media storage usage
+// @Description Get storage used by uploaded images against the quota, and how much is held by images no product or category uses. Admin only.
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.StorageUsageSuccessResponse "Storage usage retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /media/usage [get]
+func (h *MediaHandler) GetStorageUsage(c *fiber.Ctx) error {
+	usage, err := h.mediaService.GetUsage()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get storage usage")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, usage)
+}
+
+// CleanupMedia godoc
+// @Summary Clean up unused images
+// @Description Delete images that no product or category uses and that were uploaded longer ago than the cleanup period, instead of waiting for the daily run. Admin only.
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.MediaCleanupSuccessResponse "Media cleanup completed"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /media/cleanup [post]
+func (h *MediaHandler) CleanupMedia(c *fiber.Ctx) error {
+	result, err := h.mediaService.Cleanup()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to clean up media")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Media cleanup completed", result)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MenuHandler struct {
+	menuService services.MenuService
+}
+
+func NewMenuHandler(menuService services.MenuService) *MenuHandler {
+	return &MenuHandler{
+		menuService: menuService,
+	}
+}
+
+// GetMenu godoc
+// @Summary Get full menu
+// @Description Get active categories with their available products and customizations in one call. The version is the catalog version and matches the ETag; send it back in If-None-Match to get a 304 when nothing changed.
+// @Tags Menu
+// @Accept json
+// @Produce json
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} docs.MenuSuccessResponse "Menu retrieved successfully"
+// @Success 304 "Menu not modified"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /menu [get]
+func (h *MenuHandler) GetMenu(c *fiber.Ctx) error {
+	menu, err := h.menuService.GetMenu()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get menu")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, menu)
+}
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	_ "github.com/carllix/matchaciee-backend/docs"
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/carllix/matchaciee-backend/internal/services"
 	"github.com/carllix/matchaciee-backend/internal/utils"
@@ -12,27 +17,39 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// wsPingInterval keeps idle order-status sockets alive through proxies
+	wsPingInterval = 30 * time.Second
+	// sseHeartbeatInterval keeps the kitchen feed open and notices
+	// disconnected screens
+	sseHeartbeatInterval = 15 * time.Second
+)
+
 type OrderHandler struct {
 	orderService services.OrderService
+	// location is the store timezone date filters are read in
+	location *time.Location
 }
 
-func NewOrderHandler(orderService services.OrderService) *OrderHandler {
+func NewOrderHandler(orderService services.OrderService, location *time.Location) *OrderHandler {
 	return &OrderHandler{
 		orderService: orderService,
+		location:     location,
 	}
 }
 
 // CreateOrder godoc
 // @Summary Create an order (authenticated)
-// @Description Create a new order for an authenticated member user
+// @Description Create a new order for an authenticated member user. An optional promo_code is checked against its expiry, usage limits, minimum spend and product scope, and the discount is recorded on the order. Send scheduled_for to pre-order for a later time: the order stays scheduled, with its stock held, until it is released to the pending queue shortly before that time, and can be paid from then on.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body docs.CreateOrderRequest true "Order details"
 // @Success 201 {object} docs.OrderSuccessResponse "Order created successfully"
-// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, or invalid customization"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available"
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /orders [post]
 func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
@@ -70,6 +87,22 @@ func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 		if errors.Is(err, services.ErrInvalidCustomization) {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
 		}
+		if errors.Is(err, services.ErrInsufficientStock) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		var full *services.TimeslotFullError
+		if errors.As(err, &full) {
+			return timeslotFullResponse(c, full)
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := tableErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := scheduleErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
 		if errors.Is(err, services.ErrUserNotFound) {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "User not found")
 		}
@@ -81,17 +114,18 @@ func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 
 // CreateGuestOrder godoc
 // @Summary Create a guest order
-// @Description Create a new order without authentication. Order can be tracked via the returned order UUID.
+// @Description Create a new order without authentication. Order can be tracked via the returned order UUID. An optional email address or WhatsApp number (in international format) receives the tracking link right away and the receipt once the order is completed and paid. Send scheduled_for to pre-order for a later time; the order can be paid once it is released to the pending queue.
 // @Tags Orders
 // @Accept json
 // @Produce json
-// @Param request body docs.CreateOrderRequest true "Order details"
+// @Param request body docs.CreateGuestOrderRequest true "Order details"
 // @Success 201 {object} docs.OrderSuccessResponse "Order created successfully"
-// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, or invalid customization"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /orders/guest [post]
 func (h *OrderHandler) CreateGuestOrder(c *fiber.Ctx) error {
-	var req services.CreateOrderRequest
+	var req services.CreateGuestOrderRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
 	}
@@ -111,15 +145,145 @@ func (h *OrderHandler) CreateGuestOrder(c *fiber.Ctx) error {
 		if errors.Is(err, services.ErrInvalidCustomization) {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
 		}
+		if errors.Is(err, services.ErrInsufficientStock) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		var full *services.TimeslotFullError
+		if errors.As(err, &full) {
+			return timeslotFullResponse(c, full)
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := tableErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := scheduleErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create guest order")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusCreated, order)
 }
 
+// CreateStaffOrder godoc
+// @Summary Create an order on behalf of a customer
+// @Description Enter an order taken outside the app (kiosk, partner, phone, or catering). Send scheduled_for to enter a pre-order. Admin/Barista only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.CreateStaffOrderRequest true "Order details and source"
+// @Success 201 {object} docs.OrderSuccessResponse "Order created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, invalid customization, promo code cannot be applied, table is not taking orders, or scheduled time is not available"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/staff [post]
+func (h *OrderHandler) CreateStaffOrder(c *fiber.Ctx) error {
+	var req services.CreateStaffOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.CreateStaffOrder(req)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotAvailable) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrProductNotCustomizable) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidCustomization) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrInsufficientStock) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		var full *services.TimeslotFullError
+		if errors.As(err, &full) {
+			return timeslotFullResponse(c, full)
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := tableErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		if message, ok := scheduleErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create order")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, order)
+}
+
+// CreateKioskOrder godoc
+// @Summary Create a kiosk order
+// @Description Place an order from a self-service kiosk, authenticated with the kiosk's API key. customer_name is optional; without it the order gets a queue number, restarting every day, and is called by it.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security KioskKey
+// @Param request body docs.CreateKioskOrderRequest true "Order details"
+// @Success 201 {object} docs.OrderSuccessResponse "Order created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, invalid customization, or promo code cannot be applied"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Missing or invalid kiosk key"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/kiosk [post]
+func (h *OrderHandler) CreateKioskOrder(c *fiber.Ctx) error {
+	kiosk, ok := c.Locals("kiosk").(*models.Kiosk)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	var req services.CreateKioskOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.CreateKioskOrder(kiosk, req)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotAvailable) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrProductNotCustomizable) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidCustomization) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrInsufficientStock) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		var full *services.TimeslotFullError
+		if errors.As(err, &full) {
+			return timeslotFullResponse(c, full)
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create order")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, order)
+}
+
 // TrackGuestOrder godoc
 // @Summary Track a guest order
-// @Description Track an order by its UUID. This is public and used for guest order tracking.
+// @Description Track an order by its UUID. This is public and used for guest order tracking. Paid orders waiting for pickup carry the pickup_code to show at the counter.
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -148,6 +312,199 @@ func (h *OrderHandler) TrackGuestOrder(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, order)
 }
 
+// GetOrderStatus godoc
+// @Summary Get order status by share token
+// @Description Public status page for an order, reached with the share_token returned when the order is placed instead of the order UUID. The token expires a while after the order is due and is revoked once the order completes. Prices, contact details and the order ID are left out so the link can be shared.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} docs.OrderStatusSuccessResponse "Order status retrieved successfully"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Status link is invalid or has expired"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/status/{token} [get]
+func (h *OrderHandler) GetOrderStatus(c *fiber.Ctx) error {
+	status, err := h.orderService.GetStatusByShareToken(c.Params("token"))
+	if err != nil {
+		if errors.Is(err, services.ErrShareTokenInvalid) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Status link is invalid or has expired")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order status")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, status)
+}
+
+// LookupGuestOrder godoc
+// @Summary Look up a guest order
+// @Description Find a guest order again by its order number plus the phone number or customer name given at checkout, for guests who lost the tracking link. Returns the same payload as tracking by UUID. Attempts are rate limited per IP, and a wrong phone or name is reported as not found.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param request body docs.LookupOrderRequest true "Order number and phone or name"
+// @Success 200 {object} docs.OrderSuccessResponse "Order retrieved successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 429 {object} docs.SwaggerErrorResponse "Too many attempts"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/lookup [post]
+func (h *OrderHandler) LookupGuestOrder(c *fiber.Ctx) error {
+	var req services.LookupOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.LookupGuestOrder(req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, order)
+}
+
+// StreamOrderStatus godoc
+// @Summary Stream order status over WebSocket
+// @Description Upgrade to a WebSocket that sends the current order status, then every status transition as it happens. Public like the tracking endpoint: the order UUID acts as the access key.
+// @Tags Orders
+// @Param uuid path string true "Order UUID"
+// @Success 101 {object} services.OrderEvent "Switching to WebSocket; each message is an order event"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID format"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 426 {object} docs.SwaggerErrorResponse "WebSocket upgrade required"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /ws/orders/{uuid} [get]
+func (h *OrderHandler) StreamOrderStatus(c *fiber.Ctx) error {
+	if !realtime.IsWebSocketUpgrade(c) {
+		return utils.ErrorResponse(c, fiber.StatusUpgradeRequired, "WebSocket upgrade required")
+	}
+
+	orderUUID, err := uuid.Parse(c.Params("uuid"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	// Subscribe before reading the snapshot so no transition falls in between
+	sub := h.orderService.SubscribeToOrder(orderUUID)
+
+	order, err := h.orderService.GetByUUID(orderUUID)
+	if err != nil {
+		sub.Close()
+		if errors.Is(err, services.ErrOrderNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order")
+	}
+
+	snapshot, err := json.Marshal(services.OrderEvent{
+		Type:        services.OrderEventSnapshot,
+		OrderID:     order.ID,
+		OrderNumber: order.OrderNumber,
+		Status:      order.Status,
+		OccurredAt:  time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	})
+	if err != nil {
+		sub.Close()
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get order")
+	}
+
+	return realtime.Upgrade(c, func(conn *realtime.WSConn) {
+		defer sub.Close()
+		defer conn.Close() //nolint:errcheck // the client may already be gone
+
+		closed := make(chan struct{})
+		go func() {
+			_ = conn.ReadUntilClosed() //nolint:errcheck // any read error ends the stream
+			close(closed)
+		}()
+
+		if err := conn.WriteText(snapshot); err != nil {
+			return
+		}
+
+		ping := time.NewTicker(wsPingInterval)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case payload, ok := <-sub.C:
+				if !ok || conn.WriteText(payload) != nil {
+					return
+				}
+			case <-ping.C:
+				if conn.Ping() != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+// StreamOrders godoc
+// @Summary Stream order events (Admin/Barista)
+// @Description Server-Sent Events feed for the kitchen display. Emits an order.created event for every new order and an order.status_changed event for every status transition, including those made by payment notifications.
+// @Tags Orders
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} services.OrderEvent "Event stream; each data line is an order event"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Access denied"
+// @Router /orders/stream [get]
+func (h *OrderHandler) StreamOrders(c *fiber.Ctx) error {
+	sub := h.orderService.SubscribeToOrders()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		// Flush headers right away so the screen knows it is connected
+		if _, err := w.WriteString(": connected\n\n"); err != nil || w.Flush() != nil {
+			return
+		}
+
+		for {
+			select {
+			case payload, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				var event struct {
+					Type string `json:"type"`
+				}
+				if err := json.Unmarshal(payload, &event); err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := w.WriteString(": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
 // GetOrder godoc
 // @Summary Get order by ID
 // @Description Get a single order by its UUID. Members can only view their own orders, Admin/Barista can view any order.
@@ -272,6 +629,27 @@ func (h *OrderHandler) GetMyOrders(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, orders)
 }
 
+// GetKitchenQueue godoc
+// @Summary Get the kitchen queue
+// @Description Get active orders grouped by status, rush orders first and then oldest first, with item customizations flattened and the time elapsed since each order was placed. Built for the preparation screen. Admin/Barista only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.KitchenQueueSuccessResponse "Kitchen queue retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/queue [get]
+func (h *OrderHandler) GetKitchenQueue(c *fiber.Ctx) error {
+	queue, err := h.orderService.GetKitchenQueue()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get kitchen queue")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, queue)
+}
+
 // GetAllOrders godoc
 // @Summary Get all orders
 // @Description Get a paginated list of all orders with optional filtering. Admin/Barista only.
@@ -281,9 +659,13 @@ func (h *OrderHandler) GetMyOrders(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param page query integer false "Page number" default(1)
 // @Param limit query integer false "Items per page (max 100)" default(20)
-// @Param status query string false "Filter by order status" Enums(pending, preparing, ready, completed, cancelled)
-// @Param source query string false "Filter by order source" Enums(guest, member, kiosk)
+// @Param status query string false "Filter by order status" Enums(scheduled, pending, preparing, ready, completed, cancelled)
+// @Param source query string false "Filter by order source" Enums(guest, member, kiosk, partner, phone, catering)
+// @Param order_type query string false "Filter by order type" Enums(dine_in, takeaway, delivery)
+// @Param start_date query string false "Orders placed on or after this day in the store timezone (YYYY-MM-DD)"
+// @Param end_date query string false "Orders placed on or before this day in the store timezone (YYYY-MM-DD)"
 // @Success 200 {object} docs.OrdersSuccessResponse "Orders retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order source, type or date range"
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
 // @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
@@ -310,9 +692,43 @@ func (h *OrderHandler) GetAllOrders(c *fiber.Ctx) error {
 
 	if sourceParam := c.Query("source"); sourceParam != "" {
 		source := models.OrderSource(sourceParam)
+		if !source.IsValid() {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order source")
+		}
 		filters.OrderSource = &source
 	}
 
+	if typeParam := c.Query("order_type"); typeParam != "" {
+		orderType := models.OrderType(typeParam)
+		if !orderType.IsValid() {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order type")
+		}
+		filters.OrderType = &orderType
+	}
+
+	// Dates are whole days in the store timezone, so start_date=end_date
+	// returns the orders of that day
+	if param := c.Query("start_date"); param != "" {
+		startDate, err := time.ParseInLocation("2006-01-02", param, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid start_date, use YYYY-MM-DD")
+		}
+		filters.StartDate = &startDate
+	}
+
+	if param := c.Query("end_date"); param != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", param, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid end_date, use YYYY-MM-DD")
+		}
+		nextDay := endDate.AddDate(0, 0, 1)
+		filters.EndDate = &nextDay
+	}
+
+	if filters.StartDate != nil && filters.EndDate != nil && !filters.EndDate.After(*filters.StartDate) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "start_date must not be after end_date")
+	}
+
 	orders, err := h.orderService.GetAllOrders(filters, page, limit)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get orders")
@@ -335,6 +751,7 @@ func (h *OrderHandler) GetAllOrders(c *fiber.Ctx) error {
 // @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
 // @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
 // @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is claimed by another staff member, or its business day is closed"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /orders/{id}/status [put]
 func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
@@ -354,16 +771,411 @@ func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
 		return utils.ValidationErrorResponse(c, validationErrors)
 	}
 
-	order, err := h.orderService.UpdateOrderStatus(orderUUID, req.Status)
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+	role, _ := c.Locals("role").(string)
+
+	order, err := h.orderService.UpdateOrderStatusAs(orderUUID, req.Status, userUUID, models.UserRole(role))
 	if err != nil {
 		if errors.Is(err, services.ErrOrderNotFound) {
 			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
 		}
+		if errors.Is(err, services.ErrOrderAlreadyClaimed) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is claimed by another staff member")
+		}
 		if errors.Is(err, services.ErrInvalidStatusTransition) {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status transition")
 		}
+		if errors.Is(err, services.ErrOrderDayClosed) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order's business day is closed")
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update order status")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, order)
 }
+
+// Reorder godoc
+// @Summary Reorder a past order
+// @Description Place a new pending order with the items of one of the member's past orders, at current prices. Items whose product was removed or is unavailable, whose chosen options are no longer offered, or that are out of stock are skipped and listed in skipped_items. previous_total is the original order's total.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Success 201 {object} docs.ReorderSuccessResponse "Order created from past order"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID format or none of the items can be reordered"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Order belongs to another user"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.TimeslotFullErrorResponse "Insufficient stock for a capped item, or the pickup timeslot is full (data lists the next open timeslots)"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/reorder [post]
+func (h *OrderHandler) Reorder(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	result, err := h.orderService.Reorder(orderUUID, userUUID)
+	if err != nil {
+		var full *services.TimeslotFullError
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderAccessDenied):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied")
+		case errors.Is(err, services.ErrNothingToReorder):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "None of the items can be reordered")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.As(err, &full):
+			return timeslotFullResponse(c, full)
+		case errors.Is(err, services.ErrProductNotAvailable):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reorder")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, result)
+}
+
+// UpdateOrderItems godoc
+// @Summary Edit the items of a pending order
+// @Description Replace the items of a pending order and recalculate subtotal, tax and total at current prices. A promo code redeemed at checkout stays applied, so the new items must still qualify for it. Send the full list of items the order should have; omitted items are removed. Rejected once a payment has been started or the order has moved past pending. Members can edit their own orders; admins and baristas can edit any order.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Param request body docs.UpdateOrderItemsRequest true "New order items"
+// @Success 200 {object} docs.OrderSuccessResponse "Order items updated"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, product not available, invalid customization, or promo code cannot be applied"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Order belongs to another user"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order can no longer be edited or insufficient stock for a capped item"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/items [put]
+func (h *OrderHandler) UpdateOrderItems(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	role, ok := c.Locals("role").(string)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid role")
+	}
+
+	// Members may only edit their own orders
+	var memberUUID *uuid.UUID
+	if role == string(models.RoleMember) {
+		userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+		if !ok {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+		}
+		memberUUID = &userUUID
+	}
+
+	var req services.UpdateOrderItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.UpdateItems(orderUUID, memberUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderAccessDenied):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied")
+		case errors.Is(err, services.ErrOrderNotEditable):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order can no longer be edited")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrProductNotAvailable),
+			errors.Is(err, services.ErrProductNotCustomizable),
+			errors.Is(err, services.ErrInvalidCustomization):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		if message, ok := promoErrorMessage(err); ok {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, message)
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update order items")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Order items updated", order)
+}
+
+// UpdateOrderPriority godoc
+// @Summary Update order priority
+// @Description Rush an order to the front of its kitchen queue column, or set it back to normal. Only pending and preparing orders can be changed. Orders in preparation for longer than ORDER_RUSH_AFTER are rushed automatically. Admin/Barista only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Param request body docs.UpdateOrderPriorityRequest true "New priority"
+// @Success 200 {object} docs.OrderSuccessResponse "Order priority updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid ID format, or order is already ready"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order's business day is closed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/priority [put]
+func (h *OrderHandler) UpdateOrderPriority(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	var req services.UpdateOrderPriorityRequest
+	if err = c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.UpdatePriority(orderUUID, req.Priority)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrPriorityNotEditable):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Priority can only be changed before the order is ready")
+		case errors.Is(err, services.ErrOrderDayClosed):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order's business day is closed")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update order priority")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, order)
+}
+
+// ClaimOrder godoc
+// @Summary Claim an order
+// @Description Take ownership of a pending or preparing order so no other barista prepares the same ticket. Once claimed, only the claiming barista (or an admin) can change its status. Admin/Barista only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Success 200 {object} docs.OrderSuccessResponse "Order claimed successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID format or order is no longer active"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is claimed by another staff member"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/claim [post]
+func (h *OrderHandler) ClaimOrder(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	order, err := h.orderService.ClaimOrder(orderUUID, userUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderNotClaimable):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Only pending or preparing orders can be claimed")
+		case errors.Is(err, services.ErrOrderAlreadyClaimed):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is claimed by another staff member")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to claim order")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Order claimed", order)
+}
+
+// VerifyPickup godoc
+// @Summary Verify pickup code
+// @Description Scan the pickup code from the customer's tracking page or receipt to hand over the order. The code is signed per order, so a ready order is only completed when the code matches it. Admin/Barista only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.VerifyPickupRequest true "Scanned pickup code"
+// @Success 200 {object} docs.OrderSuccessResponse "Order picked up"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid pickup code"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not ready, was already picked up, or its business day is closed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/verify-pickup [post]
+func (h *OrderHandler) VerifyPickup(c *fiber.Ctx) error {
+	var req services.VerifyPickupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	order, err := h.orderService.VerifyPickup(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPickupCode):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid pickup code")
+		case errors.Is(err, services.ErrOrderNotReadyForPickup):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not ready for pickup")
+		case errors.Is(err, services.ErrOrderAlreadyPickedUp):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order was already picked up")
+		case errors.Is(err, services.ErrOrderDayClosed):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order's business day is closed")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify pickup")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Order picked up", order)
+}
+
+// VerifyOrderTotals godoc
+// @Summary Verify order totals
+// @Description Recompute an order's item subtotals, subtotal, tax and total from its item snapshots, the tax rate and the recorded source fee, and list every stored amount that differs. Read only; useful after a pricing bug is fixed. Admin only.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Success 200 {object} docs.VerifyTotalsSuccessResponse "Verification result"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/verify [post]
+func (h *OrderHandler) VerifyOrderTotals(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID format")
+	}
+
+	result, err := h.orderService.VerifyTotals(orderUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify order totals")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, result)
+}
+
+// BulkUpdateOrderStatus godoc
+// @Summary Bulk update order status
+// @Description Complete or cancel several orders in one call. Admin only. Each order is validated against the usual status transitions and reported individually, so ready orders can be completed and stale pending orders cancelled without failing the whole batch.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.BulkUpdateOrderStatusRequest true "Order IDs and target status"
+// @Success 200 {object} docs.BulkUpdateOrderStatusSuccessResponse "Per-order results"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/bulk-status [post]
+func (h *OrderHandler) BulkUpdateOrderStatus(c *fiber.Ctx) error {
+	var req services.BulkUpdateOrderStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := h.orderService.BulkUpdateOrderStatus(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update order statuses")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, result)
+}
+
+// tableErrorMessage returns the customer-facing message for a table the order
+// cannot be served to
+func tableErrorMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTable):
+		return "Table is not taking orders", true
+	case errors.Is(err, services.ErrTableOrderNotDineIn):
+		return "Table orders must be dine-in", true
+	}
+	return "", false
+}
+
+// scheduleErrorMessage returns the customer-facing message for a pre-order
+// that cannot be scheduled
+func scheduleErrorMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, services.ErrPreordersDisabled):
+		return "Pre-orders are not accepted", true
+	case errors.Is(err, services.ErrInvalidScheduledTime):
+		return "Scheduled time is not available", true
+	}
+	return "", false
+}
+
+// timeslotFullResponse rejects an order whose pickup timeslot is full and
+// offers the next timeslots that can still take it
+func timeslotFullResponse(c *fiber.Ctx, full *services.TimeslotFullError) error {
+	return utils.ErrorDataResponse(c, fiber.StatusConflict, "Pickup timeslot is fully booked", fiber.Map{
+		"next_available": full.NextAvailable,
+	})
+}
+
+// promoErrorMessage returns the customer-facing message for a promo code or
+// voucher that cannot be applied
+func promoErrorMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, services.ErrPromoCodeInvalid):
+		return "Invalid or expired promo code", true
+	case errors.Is(err, services.ErrPromoCodeUsageLimit):
+		return "Promo code usage limit reached", true
+	case errors.Is(err, services.ErrPromoMinSpendNotMet):
+		return "Order is below the promo minimum spend", true
+	case errors.Is(err, services.ErrPromoNotApplicable):
+		return "Promo code does not apply to your items", true
+	case errors.Is(err, services.ErrVoucherInvalid):
+		return "Invalid, used or expired voucher", true
+	case errors.Is(err, services.ErrVoucherMinSpendNotMet):
+		return "Order is below the voucher minimum spend", true
+	case errors.Is(err, services.ErrVoucherNoQualifyingItem):
+		return "Voucher does not apply to your items", true
+	}
+	return "", false
+}
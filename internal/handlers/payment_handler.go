@@ -2,9 +2,15 @@ package handlers
 
 import (
 	"errors"
-	"log"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/carllix/matchaciee-backend/internal/services"
 	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
@@ -13,24 +19,33 @@ import (
 
 type PaymentHandler struct {
 	paymentService services.PaymentService
+	webhookService services.WebhookService
+	location       *time.Location
+	logger         *slog.Logger
 }
 
-func NewPaymentHandler(paymentService services.PaymentService) *PaymentHandler {
+func NewPaymentHandler(paymentService services.PaymentService, webhookService services.WebhookService, location *time.Location, logger *slog.Logger) *PaymentHandler {
 	return &PaymentHandler{
 		paymentService: paymentService,
+		webhookService: webhookService,
+		location:       location,
+		logger:         logger,
 	}
 }
 
 // CreatePaymentToken godoc
 // @Summary Create payment token
-// @Description Create a Midtrans payment token for an order. Returns a redirect URL and token for Snap payment.
+// @Description Start a payment for an order with the configured gateway. Returns the provider, a token (the Snap token for Midtrans, the Checkout Session ID for Stripe, the invoice ID for Xendit) and the URL of the hosted payment page. The amount charged is the order total plus the tip; send tip_amount to add or change the tip chosen at checkout. Calling it again while the payment page is still open returns the same page with reused set; after a tip change the old page is closed and a new one started.
 // @Tags Payments
 // @Accept json
 // @Produce json
 // @Param id path string true "Order UUID"
+// @Param request body docs.CreatePaymentTokenRequest false "Optional tip"
 // @Success 200 {object} docs.PaymentSuccessResponse "Payment token created successfully"
-// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID or payment already exists"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid order ID, validation error, payment already exists, or a currency the gateway cannot charge"
 // @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Reserved stock expired and the item sold out"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /orders/{id}/payment [post]
 func (h *PaymentHandler) CreatePaymentToken(c *fiber.Ctx) error {
@@ -41,8 +56,20 @@ func (h *PaymentHandler) CreatePaymentToken(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
 	}
 
+	var req services.CreatePaymentTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
 	// Create payment token
-	paymentToken, err := h.paymentService.CreatePaymentToken(orderUUID)
+	req.RequestID = utils.RequestID(c)
+	paymentToken, err := h.paymentService.CreatePaymentToken(orderUUID, req)
 	if err != nil {
 		if errors.Is(err, services.ErrOrderNotFound) {
 			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
@@ -50,20 +77,410 @@ func (h *PaymentHandler) CreatePaymentToken(c *fiber.Ctx) error {
 		if errors.Is(err, services.ErrPaymentAlreadyExists) {
 			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Payment already exists for this order")
 		}
-		log.Printf("Failed to create payment token: %v", err)
+		if errors.Is(err, services.ErrCurrencyNotSupported) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "The payment gateway cannot charge the store currency")
+		}
+		if errors.Is(err, services.ErrInsufficientStock) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		}
+		if errors.Is(err, services.ErrPaymentExpired) {
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create payment token", logging.Err(err))
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create payment token")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
 		"payment_id":   paymentToken.PaymentID,
+		"provider":     paymentToken.Provider,
 		"token":        paymentToken.Token,
 		"redirect_url": paymentToken.RedirectURL,
+		"reused":       paymentToken.Reused,
 	})
 }
 
+// ChargeQRIS godoc
+// @Summary Charge an order by QRIS
+// @Description Charge the order through the Midtrans Core API as a QRIS code for a kiosk to show on its own screen, instead of redirecting to the hosted payment page. Render qr_string as a QR code or show qr_image_url, then poll /payments/{id}/status until the payment settles or expires. Calling it again while the code is still valid returns the same code with reused set; the amount, tip and errors work as for /orders/{id}/payment. Any hosted payment page still open for the order is closed.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param id path string true "Order UUID"
+// @Param request body docs.CreatePaymentTokenRequest false "Optional tip"
+// @Success 200 {object} docs.QRISChargeSuccessResponse "QRIS code created"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid order ID, validation error, payment already exists, QRIS not available with the configured gateway, or a currency the gateway cannot charge"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Reserved stock expired and the item sold out"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/payments/qris [post]
+func (h *PaymentHandler) ChargeQRIS(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req services.CreatePaymentTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	req.RequestID = utils.RequestID(c)
+	charge, err := h.paymentService.ChargeQRIS(orderUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrQRISNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "QRIS payments are not available")
+		case errors.Is(err, services.ErrCurrencyNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "The payment gateway cannot charge the store currency")
+		case errors.Is(err, services.ErrPaymentAlreadyExists):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Payment already exists for this order")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create QRIS charge", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create QRIS charge")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, charge)
+}
+
+// transactionStatuses are the statuses the payment list can be filtered by
+var transactionStatuses = []models.TransactionStatus{
+	models.TransactionStatusPending,
+	models.TransactionStatusSettlement,
+	models.TransactionStatusExpire,
+	models.TransactionStatusCancel,
+	models.TransactionStatusDeny,
+	models.TransactionStatusRefund,
+	models.TransactionStatusPartialRefund,
+}
+
+// GetPayments godoc
+// @Summary List payments
+// @Description Get a paginated list of payments across all orders, newest first, for daily checks. A pending status also matches payments the gateway has not reported on yet. Admin only.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Param status query string false "Filter by transaction status" Enums(pending, settlement, expire, cancel, deny, refund, partial_refund)
+// @Param payment_type query string false "Filter by payment type as the gateway reports it, such as qris, gopay or cash"
+// @Param order_number query string false "Filter by order number"
+// @Param start_date query string false "Payments started on or after this day in the store timezone (YYYY-MM-DD)"
+// @Param end_date query string false "Payments started on or before this day in the store timezone (YYYY-MM-DD)"
+// @Success 200 {object} docs.PaymentsSuccessResponse "Payments retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid status or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /payments [get]
+func (h *PaymentHandler) GetPayments(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filters repositories.PaymentFilters
+
+	if statusParam := c.Query("status"); statusParam != "" {
+		status := models.TransactionStatus(statusParam)
+		if !slices.Contains(transactionStatuses, status) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid transaction status")
+		}
+		filters.Status = &status
+	}
+
+	if typeParam := c.Query("payment_type"); typeParam != "" {
+		paymentType := strings.ToLower(typeParam)
+		filters.PaymentType = &paymentType
+	}
+
+	if numberParam := c.Query("order_number"); numberParam != "" {
+		orderNumber := strings.ToUpper(strings.TrimSpace(numberParam))
+		filters.OrderNumber = &orderNumber
+	}
+
+	// Dates are whole days in the store timezone, as on the order list
+	if param := c.Query("start_date"); param != "" {
+		startDate, err := time.ParseInLocation("2006-01-02", param, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid start_date, use YYYY-MM-DD")
+		}
+		filters.StartDate = &startDate
+	}
+
+	if param := c.Query("end_date"); param != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", param, h.location)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid end_date, use YYYY-MM-DD")
+		}
+		nextDay := endDate.AddDate(0, 0, 1)
+		filters.EndDate = &nextDay
+	}
+
+	if filters.StartDate != nil && filters.EndDate != nil && !filters.EndDate.After(*filters.StartDate) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "start_date must not be after end_date")
+	}
+
+	payments, err := h.paymentService.GetAll(filters, page, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get payments")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, payments)
+}
+
+// GetPaymentStatus godoc
+// @Summary Get payment status
+// @Description Get the status of a payment and of the order it pays for, for a kiosk to poll while the customer pays. The status is the last one the gateway reported through its webhook; a payment not yet reported on is pending.
+// @Tags Payments
+// @Produce json
+// @Param id path string true "Payment UUID"
+// @Success 200 {object} docs.PaymentStatusSuccessResponse "Payment status retrieved"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid payment ID"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /payments/{id}/status [get]
+func (h *PaymentHandler) GetPaymentStatus(c *fiber.Ctx) error {
+	paymentUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	status, err := h.paymentService.GetPaymentStatus(paymentUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrPaymentNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get payment status")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, status)
+}
+
+// RecordCashPayment godoc
+// @Summary Record a cash payment
+// @Description Settle a pending order with cash taken at the counter. The amount tendered must cover the order total plus tip; the change to hand back is returned. The order moves to preparing, as after a Midtrans settlement. Admin/Barista only.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Param request body docs.CashPaymentRequest true "Cash tendered"
+// @Success 201 {object} docs.CashPaymentSuccessResponse "Cash payment recorded"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid order ID, validation error, or not enough cash tendered"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment or an item sold out"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/payments/cash [post]
+func (h *PaymentHandler) RecordCashPayment(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.CashPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	payment, err := h.paymentService.RecordCashPayment(orderUUID, userUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrCashTenderedTooLow):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Cash tendered is less than the amount due")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to record cash payment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to record cash payment")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Cash payment recorded", payment)
+}
+
+// RefundPayment godoc
+// @Summary Refund a payment
+// @Description Give part or all of a settled payment back, through the gateway it was paid with, as cash handed back at the counter, or to the wallet it was paid from. Send amount to refund part of the payment, such as one unavailable item; leave it out to refund the remaining balance. The refund is recorded with the reason and the admin who issued it. Once the payment is refunded in full, an order still being prepared or waiting for pickup is cancelled and its stock returned; completed orders stay completed. Admin only.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment UUID"
+// @Param request body docs.RefundPaymentRequest true "Refund amount and reason"
+// @Success 201 {object} docs.RefundSuccessResponse "Payment refunded"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid payment ID, validation error, amount over the refundable balance, or a refund the gateway does not support"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Payment is not settled"
+// @Failure 502 {object} docs.SwaggerErrorResponse "The gateway rejected the refund"
+// @Router /payments/{id}/refund [post]
+func (h *PaymentHandler) RefundPayment(c *fiber.Ctx) error {
+	paymentUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.RefundPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	refund, err := h.paymentService.RefundPayment(paymentUUID, userUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+		case errors.Is(err, services.ErrPaymentNotRefundable):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Only settled payments can be refunded")
+		case errors.Is(err, services.ErrRefundExceedsBalance):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refund exceeds the refundable balance")
+		case errors.Is(err, services.ErrRefundNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refunds are not supported for this payment")
+		case errors.Is(err, services.ErrPartialRefundNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment method only supports full refunds")
+		case errors.Is(err, services.ErrInvalidRefundAmount):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Refund amount must be in whole rupiah")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		// Anything else comes from the gateway turning the refund down
+		h.logger.ErrorContext(c.UserContext(), "Failed to refund payment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusBadGateway, "Failed to refund payment")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Payment refunded", refund)
+}
+
+// VerifyPayment godoc
+// @Summary Verify a payment with its gateway
+// @Description Look the payment up at the gateway and apply its current status the same way the webhook would: a settled payment sends the order to the kitchen, a failed one cancels it. Use it when a webhook was lost and the customer has paid but the order is still pending. Nothing changes when the gateway agrees with what is recorded. Cash payments cannot be verified. Admin only.
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment UUID"
+// @Success 200 {object} docs.PaymentVerificationSuccessResponse "Payment verified"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid payment ID, a payment that cannot be checked with the gateway, or an amount that does not match"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment not found, or unknown to the gateway"
+// @Failure 502 {object} docs.SwaggerErrorResponse "The gateway could not be reached"
+// @Router /payments/{id}/verify [post]
+func (h *PaymentHandler) VerifyPayment(c *fiber.Ctx) error {
+	paymentUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	verification, err := h.paymentService.VerifyPayment(paymentUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+		case errors.Is(err, services.ErrGatewayTransactionNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "The gateway has no transaction for this payment")
+		case errors.Is(err, services.ErrStatusCheckNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment cannot be checked with the gateway")
+		case errors.Is(err, services.ErrInvalidAmount):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "The gateway reports a different amount for this payment")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to verify payment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusBadGateway, "Failed to verify payment")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Payment verified", verification)
+}
+
+// CancelPayment godoc
+// @Summary Cancel a pending gateway payment
+// @Description Close a payment page the customer has not paid yet, at the gateway and here, so it cannot settle after the order was given up on. The order is cancelled with it unless it is being paid another way. The gateway is checked first; a payment that already went through is recorded as paid and reported as a conflict. Cash payments cannot be cancelled. Admin and barista only.
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment UUID"
+// @Success 200 {object} docs.PaymentCancellationSuccessResponse "Payment cancelled"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid payment ID, or a payment that cannot be cancelled with the gateway"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin or barista only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Payment is no longer pending"
+// @Failure 502 {object} docs.SwaggerErrorResponse "The gateway could not be reached"
+// @Router /payments/{id}/cancel [post]
+func (h *PaymentHandler) CancelPayment(c *fiber.Ctx) error {
+	paymentUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	cancellation, err := h.paymentService.CancelPayment(paymentUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+		case errors.Is(err, services.ErrCancelNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment cannot be cancelled with the gateway")
+		case errors.Is(err, services.ErrPaymentNotCancellable):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Payment is no longer pending")
+		case errors.Is(err, services.ErrPaymentAlreadyExists):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Payment went through before it could be cancelled")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to cancel payment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusBadGateway, "Failed to cancel payment")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Payment cancelled", cancellation)
+}
+
 // HandleMidtransWebhook godoc
 // @Summary Handle Midtrans webhook
-// @Description Process payment notification from Midtrans. This endpoint is called by Midtrans when payment status changes.
+// @Description Process payment notification from Midtrans. This endpoint is called by Midtrans when payment status changes. Every notification is stored before processing and can be replayed from /webhook-events.
 // @Tags Webhooks
 // @Accept json
 // @Produce json
@@ -71,56 +488,88 @@ func (h *PaymentHandler) CreatePaymentToken(c *fiber.Ctx) error {
 // @Success 200 {object} docs.WebhookSuccessResponse "Webhook processed successfully"
 // @Failure 400 {object} docs.WebhookErrorResponse "Invalid request body or invalid amount"
 // @Failure 401 {object} docs.WebhookErrorResponse "Invalid signature"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Caller IP is not in MIDTRANS_WEBHOOK_ALLOWED_IPS"
 // @Failure 404 {object} docs.WebhookErrorResponse "Payment not found"
+// @Failure 429 {object} docs.SwaggerErrorResponse "Too many webhooks from this IP"
 // @Failure 500 {object} docs.WebhookErrorResponse "Internal server error"
 // @Router /webhooks/midtrans [post]
 func (h *PaymentHandler) HandleMidtransWebhook(c *fiber.Ctx) error {
-	// Parse webhook notification
-	var notification services.MidtransNotification
-	if err := c.BodyParser(&notification); err != nil {
-		log.Printf("Failed to parse webhook: %v", err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Invalid request body",
-		})
-	}
+	err := h.webhookService.Receive(models.PaymentMethodMidtrans, webhookHeaders(c), c.Body())
+	return h.webhookResponse(c, err, "Invalid signature")
+}
+
+// HandleStripeWebhook godoc
+// @Summary Handle Stripe webhook
+// @Description Process a Checkout Session event from Stripe. The raw body is verified against the Stripe-Signature header with the endpoint's signing secret. Events other than checkout.session.* are acknowledged and ignored.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param Stripe-Signature header string true "Stripe webhook signature"
+// @Success 200 {object} docs.WebhookSuccessResponse "Webhook processed successfully"
+// @Failure 400 {object} docs.WebhookErrorResponse "Invalid payload or invalid amount"
+// @Failure 401 {object} docs.WebhookErrorResponse "Invalid signature"
+// @Failure 404 {object} docs.WebhookErrorResponse "Payment not found"
+// @Failure 500 {object} docs.WebhookErrorResponse "Internal server error"
+// @Router /webhooks/stripe [post]
+func (h *PaymentHandler) HandleStripeWebhook(c *fiber.Ctx) error {
+	// The signature covers the exact bytes Stripe sent, so the body is not re-encoded
+	err := h.webhookService.Receive(models.PaymentMethodStripe, webhookHeaders(c), c.Body())
+	return h.webhookResponse(c, err, "Invalid signature")
+}
+
+// HandleXenditWebhook godoc
+// @Summary Handle Xendit invoice callback
+// @Description Process an invoice callback from Xendit. The x-callback-token header must match the verification token from the Xendit dashboard.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param x-callback-token header string true "Xendit callback verification token"
+// @Success 200 {object} docs.WebhookSuccessResponse "Webhook processed successfully"
+// @Failure 400 {object} docs.WebhookErrorResponse "Invalid payload or invalid amount"
+// @Failure 401 {object} docs.WebhookErrorResponse "Invalid callback token"
+// @Failure 404 {object} docs.WebhookErrorResponse "Payment not found"
+// @Failure 500 {object} docs.WebhookErrorResponse "Internal server error"
+// @Router /webhooks/xendit [post]
+func (h *PaymentHandler) HandleXenditWebhook(c *fiber.Ctx) error {
+	err := h.webhookService.Receive(models.PaymentMethodXendit, webhookHeaders(c), c.Body())
+	return h.webhookResponse(c, err, "Invalid callback token")
+}
 
-	// Log webhook for debugging
-	log.Printf("Received Midtrans webhook for order: %s, status: %s", notification.OrderID, notification.TransactionStatus)
+// webhookHeaders copies the request headers so they can be stored with the
+// webhook, since fasthttp reuses the request after the handler returns
+func webhookHeaders(c *fiber.Ctx) map[string]string {
+	headers := map[string]string{}
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		headers[string(key)] = string(value)
+	})
+	return headers
+}
 
-	// Process webhook
-	if err := h.paymentService.ProcessWebhookNotification(&notification); err != nil {
-		if errors.Is(err, services.ErrInvalidSignature) {
-			log.Printf("Invalid signature for order: %s", notification.OrderID)
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Invalid signature",
-			})
-		}
-		if errors.Is(err, services.ErrPaymentNotFound) {
-			log.Printf("Payment not found for order: %s", notification.OrderID)
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Payment not found",
-			})
-		}
-		if errors.Is(err, services.ErrInvalidAmount) {
-			log.Printf("Invalid amount for order: %s", notification.OrderID)
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Invalid amount",
-			})
-		}
-
-		log.Printf("Failed to process webhook: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Failed to process webhook",
+// webhookResponse answers the gateway in the shape all webhook endpoints use.
+// Anything but a 200 makes the gateway retry the notification later.
+func (h *PaymentHandler) webhookResponse(c *fiber.Ctx, err error, invalidAuthMessage string) error {
+	if err == nil {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status": "success",
 		})
 	}
 
-	// Always return 200 OK to Midtrans
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"status": "success",
+	status, message := fiber.StatusInternalServerError, "Failed to process webhook"
+	switch {
+	case errors.Is(err, services.ErrInvalidSignature):
+		status, message = fiber.StatusUnauthorized, invalidAuthMessage
+	case errors.Is(err, services.ErrInvalidWebhook):
+		status, message = fiber.StatusBadRequest, "Invalid request body"
+	case errors.Is(err, services.ErrPaymentNotFound):
+		status, message = fiber.StatusNotFound, "Payment not found"
+	case errors.Is(err, services.ErrInvalidAmount):
+		status, message = fiber.StatusBadRequest, "Invalid amount"
+	default:
+		h.logger.ErrorContext(c.UserContext(), "Failed to process webhook", logging.Err(err))
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status":     "error",
+		"message":    message,
+		"request_id": utils.RequestID(c),
 	})
 }
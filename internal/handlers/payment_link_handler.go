@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PaymentLinkHandler struct {
+	paymentLinkService services.PaymentLinkService
+	logger             *slog.Logger
+}
+
+func NewPaymentLinkHandler(paymentLinkService services.PaymentLinkService, logger *slog.Logger) *PaymentLinkHandler {
+	return &PaymentLinkHandler{
+		paymentLinkService: paymentLinkService,
+		logger:             logger,
+	}
+}
+
+// CreatePaymentLink godoc
+// @Summary Create a payment link
+// @Description Issue a short-lived link that opens the hosted payment page of a pending order, to send over WhatsApp to a customer who ordered by phone. The link works until the order is paid and expires after PAYMENT_LINK_TTL or at the order's payment deadline, whichever is sooner. The URL is only returned here. Admin/Barista only.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Success 201 {object} docs.PaymentLinkSuccessResponse "Payment link created"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin/Barista only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/payment-link [post]
+func (h *PaymentLinkHandler) CreatePaymentLink(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	link, err := h.paymentLinkService.Create(orderUUID, userUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create payment link", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create payment link")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Payment link created", link)
+}
+
+// OpenPaymentLink godoc
+// @Summary Open a payment link
+// @Description Redirect to the hosted payment page of the order behind a payment link. Opening the link does not use it up: an open page for the same amount is reused rather than starting a second one, so link previews and a customer who closed the page get the same page back. The link stops working once the order no longer awaits payment.
+// @Tags Payments
+// @Param token path string true "Token from the payment link"
+// @Success 302 "Redirect to the payment page"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment link is invalid or has expired"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment or an item sold out"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /pay/{token} [get]
+func (h *PaymentLinkHandler) OpenPaymentLink(c *fiber.Ctx) error {
+	redirectURL, err := h.paymentLinkService.Open(c.Params("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentLinkInvalid):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment link is invalid or has expired")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment), errors.Is(err, services.ErrPaymentAlreadyExists):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to open payment link", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to open payment page")
+	}
+
+	return c.Redirect(redirectURL, fiber.StatusFound)
+}
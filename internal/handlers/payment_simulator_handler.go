@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PaymentSimulatorHandler struct {
+	simulatorService services.PaymentSimulatorService
+	logger           *slog.Logger
+}
+
+func NewPaymentSimulatorHandler(simulatorService services.PaymentSimulatorService, logger *slog.Logger) *PaymentSimulatorHandler {
+	return &PaymentSimulatorHandler{
+		simulatorService: simulatorService,
+		logger:           logger,
+	}
+}
+
+// SimulatePayment godoc
+// @Summary Simulate a Midtrans notification (non-production only)
+// @Description Sign a Midtrans notification with the given outcome for a pending payment and process it exactly as the webhook would: settlement sends the order to the kitchen, cancel, expire and deny cancel it. Lets end-to-end tests pay without the Midtrans sandbox. Only available outside production with Midtrans sandbox keys. Admin only.
+// @Tags Dev
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment UUID"
+// @Param request body docs.SimulatePaymentRequest true "Outcome to simulate"
+// @Success 200 {object} docs.PaymentSimulationSuccessResponse "Notification processed"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid payment ID, validation error, or a payment that is not a Midtrans payment"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only, or Midtrans is not in sandbox mode"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Payment not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Payment is no longer pending"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /dev/payments/{id}/simulate [post]
+func (h *PaymentSimulatorHandler) SimulatePayment(c *fiber.Ctx) error {
+	paymentUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	var req services.SimulatePaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	simulation, err := h.simulatorService.Simulate(paymentUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+		case errors.Is(err, services.ErrSimulationNotSupported):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Only Midtrans payments can be simulated")
+		case errors.Is(err, services.ErrSimulationSandboxOnly):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Payments can only be simulated against the Midtrans sandbox")
+		case errors.Is(err, services.ErrPaymentNotPending):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Payment is no longer pending")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to simulate payment", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to simulate payment")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Notification processed", simulation)
+}
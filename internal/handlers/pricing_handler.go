@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PricingHandler struct {
+	pricingService services.PricingService
+}
+
+func NewPricingHandler(pricingService services.PricingService) *PricingHandler {
+	return &PricingHandler{
+		pricingService: pricingService,
+	}
+}
+
+// GetSourcePricing godoc
+// @Summary Get source pricing
+// @Description List the price adjustment and flat fee for each order source that can carry its own pricing. Admin only.
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.SourcePricingListSuccessResponse "Source pricing retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /pricing/sources [get]
+func (h *PricingHandler) GetSourcePricing(c *fiber.Ctx) error {
+	pricing, err := h.pricingService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get source pricing")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, pricing)
+}
+
+// UpdateSourcePricing godoc
+// @Summary Update source pricing
+// @Description Set the price adjustment (percent applied to every unit price) and flat fee for an order source. Guest and member orders always pay list price. Applies to orders created afterwards. Admin only.
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param source path string true "Order source" Enums(kiosk, partner, phone, catering)
+// @Param request body docs.UpdateSourcePricingRequest true "Pricing details"
+// @Success 200 {object} docs.SourcePricingSuccessResponse "Source pricing updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid order source"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /pricing/sources/{source} [put]
+func (h *PricingHandler) UpdateSourcePricing(c *fiber.Ctx) error {
+	source := models.OrderSource(c.Params("source"))
+
+	var req services.UpdateSourcePricingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	pricing, err := h.pricingService.Update(source, req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOrderSource) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Pricing cannot be set for this order source")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update source pricing")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, pricing)
+}
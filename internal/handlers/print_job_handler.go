@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PrintJobHandler struct {
+	printService services.PrintService
+	token        string
+}
+
+// NewPrintJobHandler guards the print agent endpoints with token. An empty
+// token disables them.
+func NewPrintJobHandler(printService services.PrintService, token string) *PrintJobHandler {
+	return &PrintJobHandler{
+		printService: printService,
+		token:        token,
+	}
+}
+
+// RequireAgent lets through requests carrying the print agent token
+func (h *PrintJobHandler) RequireAgent(c *fiber.Ctx) error {
+	if h.token == "" {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Print agents are disabled")
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Get("X-Print-Agent-Token")), []byte(h.token)) != 1 {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid print agent token")
+	}
+	return c.Next()
+}
+
+// NextPrintJob godoc
+// @Summary Take the next print job
+// @Description Hand the oldest waiting ESC/POS ticket of a station to the calling print agent. The payload is base64 encoded and is sent to the printer as is. The agent must acknowledge the job within two minutes, otherwise it is handed out again; after five attempts it is marked failed. Returns 204 when there is nothing to print. Requires the X-Print-Agent-Token header.
+// @Tags Print Jobs
+// @Produce json
+// @Param X-Print-Agent-Token header string true "Print agent token"
+// @Param station query string false "Printer station (defaults to the main station)"
+// @Success 200 {object} docs.PrintJobSuccessResponse "Print job taken"
+// @Success 204 "Nothing to print"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Invalid print agent token"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Print agents are disabled"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /print-jobs/next [get]
+func (h *PrintJobHandler) Next(c *fiber.Ctx) error {
+	job, err := h.printService.Next(c.Query("station"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get print job")
+	}
+	if job == nil {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Print job taken", job)
+}
+
+// AckPrintJob godoc
+// @Summary Acknowledge a print job
+// @Description Report whether a ticket was printed. Failed tickets are queued again until they run out of attempts. Requires the X-Print-Agent-Token header.
+// @Tags Print Jobs
+// @Accept json
+// @Produce json
+// @Param X-Print-Agent-Token header string true "Print agent token"
+// @Param id path string true "Print job ID (UUID)"
+// @Param request body docs.AckPrintJobRequest true "Print outcome"
+// @Success 200 {object} docs.SwaggerSuccessResponse "Print job acknowledged"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid request or validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Invalid print agent token"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Print job not found or print agents are disabled"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Print job is not being printed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /print-jobs/{id}/ack [post]
+func (h *PrintJobHandler) Ack(c *fiber.Ctx) error {
+	jobUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid print job ID format")
+	}
+
+	var req services.AckPrintJobRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	if err := h.printService.Ack(jobUUID, req); err != nil {
+		switch {
+		case errors.Is(err, services.ErrPrintJobNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Print job not found")
+		case errors.Is(err, services.ErrPrintJobNotClaimed):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Print job is not being printed")
+		default:
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to acknowledge print job")
+		}
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Print job acknowledged", nil)
+}
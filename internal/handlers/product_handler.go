@@ -127,7 +127,9 @@ func (h *ProductHandler) GetProductBySlug(c *fiber.Ctx) error {
 // @Param include_deleted query boolean false "Include soft-deleted products"
 // @Param available_only query boolean false "Filter to show only available products"
 // @Param category_id query string false "Filter by category UUID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} docs.ProductsSuccessResponse "Products retrieved successfully"
+// @Success 304 "Products not modified"
 // @Failure 400 {object} docs.SwaggerErrorResponse "Invalid category_id format or category not found"
 // @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
 // @Router /products [get]
@@ -213,6 +215,45 @@ func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, product)
 }
 
+// ReorderProducts godoc
+// @Summary Reorder products
+// @Description Set the display order of products in one call. IDs are listed in their new order (Admin only).
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.ReorderRequest true "Product UUIDs in display order"
+// @Success 200 {object} docs.MessageSuccessResponse "Products reordered successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Product not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /products/reorder [put]
+func (h *ProductHandler) ReorderProducts(c *fiber.Ctx) error {
+	var req services.ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	// Reorder products
+	if err := h.productService.Reorder(req); err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Product not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reorder products")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Products reordered successfully",
+	})
+}
+
 // DeleteProduct godoc
 // @Summary Soft delete a product
 // @Description Soft delete a product by its UUID (Admin only). Product will be hidden but preserved for historical orders.
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromotionHandler struct {
+	promotionService services.PromotionService
+}
+
+func NewPromotionHandler(promotionService services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+	}
+}
+
+// CreatePromotion godoc
+// @Summary Create a promotion
+// @Description Create a promo code customers can redeem at checkout. Codes are stored in upper case. Without product_ids or category_ids the discount applies to the whole order; otherwise only to items in scope, including subcategories. An automatic promotion applies to every qualifying order without its code being entered. Stackable promotions combine with each other; any other promotion is applied on its own, and without an entered code the order gets whichever discount is larger. Days, start_time and end_time (HH:MM, store time) limit a promotion to certain hours such as a happy hour; an end before the start runs past midnight. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.PromotionRequest true "Promotion details"
+// @Success 201 {object} docs.PromotionSuccessResponse "Promotion created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid discount, period or hours, or product/category not found"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Promotion code already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions [post]
+func (h *PromotionHandler) CreatePromotion(c *fiber.Ctx) error {
+	var req services.PromotionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	promotion, err := h.promotionService.Create(req)
+	if err != nil {
+		return handlePromotionError(c, err, "Failed to create promotion")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, promotion)
+}
+
+// GetPromotions godoc
+// @Summary List promotions
+// @Description List all promotions, newest first, with how many non-cancelled orders redeemed each. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.PromotionListSuccessResponse "Promotions retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions [get]
+func (h *PromotionHandler) GetPromotions(c *fiber.Ctx) error {
+	promotions, err := h.promotionService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get promotions")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, promotions)
+}
+
+// GetPromotion godoc
+// @Summary Get a promotion
+// @Description Get a single promotion by its UUID. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promotion UUID"
+// @Success 200 {object} docs.PromotionSuccessResponse "Promotion retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid promotion ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Promotion not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetPromotion(c *fiber.Ctx) error {
+	promotionUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid promotion ID format")
+	}
+
+	promotion, err := h.promotionService.GetByUUID(promotionUUID)
+	if err != nil {
+		return handlePromotionError(c, err, "Failed to get promotion")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, promotion)
+}
+
+// UpdatePromotion godoc
+// @Summary Update a promotion
+// @Description Replace the terms and scope of a promotion. Orders that already redeemed it keep their discount. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promotion UUID"
+// @Param request body docs.PromotionRequest true "Promotion details"
+// @Success 200 {object} docs.PromotionSuccessResponse "Promotion updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid discount, period or hours, or product/category not found"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Promotion not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Promotion code already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) UpdatePromotion(c *fiber.Ctx) error {
+	promotionUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid promotion ID format")
+	}
+
+	var req services.PromotionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	promotion, err := h.promotionService.Update(promotionUUID, req)
+	if err != nil {
+		return handlePromotionError(c, err, "Failed to update promotion")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, promotion)
+}
+
+// DeletePromotion godoc
+// @Summary Delete a promotion
+// @Description Delete a promotion so its code can no longer be redeemed. Orders that redeemed it keep the code and discount. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promotion UUID"
+// @Success 200 {object} docs.MessageSuccessResponse "Promotion deleted successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid promotion ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Promotion not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) DeletePromotion(c *fiber.Ctx) error {
+	promotionUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid promotion ID format")
+	}
+
+	if err := h.promotionService.Delete(promotionUUID); err != nil {
+		return handlePromotionError(c, err, "Failed to delete promotion")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Promotion deleted successfully",
+	})
+}
+
+// GetPromotionStats godoc
+// @Summary Get promotion usage
+// @Description Sum up the non-cancelled orders that applied a promotion: how often it was used, by how many members, the discount it gave and the revenue of those orders. Admin only.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promotion UUID"
+// @Success 200 {object} docs.PromotionStatsSuccessResponse "Promotion usage retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid promotion ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Promotion not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /promotions/{id}/stats [get]
+func (h *PromotionHandler) GetPromotionStats(c *fiber.Ctx) error {
+	promotionUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid promotion ID format")
+	}
+
+	stats, err := h.promotionService.GetStats(promotionUUID)
+	if err != nil {
+		return handlePromotionError(c, err, "Failed to get promotion usage")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, stats)
+}
+
+func handlePromotionError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrPromotionNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Promotion not found")
+	case errors.Is(err, services.ErrPromotionCodeExists):
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Promotion code already exists")
+	case errors.Is(err, services.ErrProductNotFound):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Product not found")
+	case errors.Is(err, services.ErrCategoryNotFound):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Category not found")
+	case errors.Is(err, services.ErrInvalidPromotionPeriod):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Promotion must expire after it starts")
+	case errors.Is(err, services.ErrInvalidPromotionHours):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Promotion hours need a start and a different end time")
+	case errors.Is(err, services.ErrPromotionPercentTooHigh):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Percentage discount cannot exceed 100")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
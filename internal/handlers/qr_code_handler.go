@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type QRCodeHandler struct {
+	qrCodeService services.QRCodeService
+}
+
+func NewQRCodeHandler(qrCodeService services.QRCodeService) *QRCodeHandler {
+	return &QRCodeHandler{
+		qrCodeService: qrCodeService,
+	}
+}
+
+// GenerateQRCode godoc
+// @Summary Generate a QR code
+// @Description Render a QR code in the store colors for printing on signage. The menu target links to the public menu, table to the ordering link of a table from /tables, and payment to the payment page of a pending order. The encoded link is returned in the X-QR-Code-URL header. Admin only.
+// @Tags QR Codes
+// @Accept json
+// @Produce png
+// @Produce image/svg+xml
+// @Security BearerAuth
+// @Param request body docs.GenerateQRCodeRequest true "QR code target and format"
+// @Success 200 {file} file "QR code image"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order or table not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /qr-codes [post]
+func (h *QRCodeHandler) GenerateQRCode(c *fiber.Ctx) error {
+	var req services.GenerateQRCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	code, err := h.qrCodeService.Generate(req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		}
+		if errors.Is(err, services.ErrDiningTableNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Table not found")
+		}
+		if errors.Is(err, services.ErrOrderNotPayable) {
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to generate QR code")
+	}
+
+	c.Set(fiber.HeaderContentType, code.ContentType)
+	c.Set("X-QR-Code-URL", code.URL)
+	return c.Status(fiber.StatusOK).Send(code.Body)
+}
@@ -0,0 +1,410 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+}
+
+func NewReportHandler(reportService services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetRevenueBySource godoc
+// @Summary Revenue by order source
+// @Description Paid revenue broken down by order source (guest, member, kiosk, partner, phone, catering) for an inclusive date range, with the gateway fees recorded on their payments and the revenue net of them. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} docs.RevenueBySourceSuccessResponse "Revenue breakdown retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/revenue/sources [get]
+func (h *ReportHandler) GetRevenueBySource(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetRevenueBySource(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get revenue report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetSales godoc
+// @Summary Sales dashboard
+// @Description Paid revenue, order count, average ticket and tax collected per day, week (starting Monday) or month for an inclusive date range, with totals. Every period the range touches is listed, including those without orders. Defaults to daily figures for the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param granularity query string false "Period length" Enums(day, week, month) default(day)
+// @Success 200 {object} docs.SalesReportSuccessResponse "Sales report retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date, date range or granularity"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/sales [get]
+func (h *ReportHandler) GetSales(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetSales(startDate, endDate, c.Query("granularity", services.SalesGranularityDay))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSalesGranularity) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Granularity must be day, week or month")
+		}
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get sales report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetTopProducts godoc
+// @Summary Best sellers
+// @Description Products on paid orders in an inclusive date range ranked by quantity sold, with order count, revenue before order discounts and how often each customization option was chosen (for example Oat Milk on 40% of lattes). Defaults to the top 10 over the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param limit query int false "Number of products (1-100)" default(10)
+// @Success 200 {object} docs.TopProductsSuccessResponse "Best sellers retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/top-products [get]
+func (h *ReportHandler) GetTopProducts(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "from", "to")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	limit := c.QueryInt("limit", 10)
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	report, err := h.reportService.GetTopProducts(startDate, endDate, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get best sellers")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetRevenueByCategory godoc
+// @Summary Revenue by category
+// @Description Item revenue on paid orders in an inclusive date range per top-level category (for example drinks, food and merch), with quantity, order count and share of revenue. Sales in subcategories roll up to their top-level category, and items without a category are listed as Uncategorized. Pass parent_id to compare the subcategories of one category instead. Revenue is before order discounts. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param parent_id query string false "Category to break down into its subcategories (UUID)"
+// @Success 200 {object} docs.RevenueByCategorySuccessResponse "Revenue by category retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date, date range or category ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Category not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/revenue-by-category [get]
+func (h *ReportHandler) GetRevenueByCategory(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "from", "to")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	var parentID *uuid.UUID
+	if param := c.Query("parent_id"); param != "" {
+		parsed, err := uuid.Parse(param)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid category ID")
+		}
+		parentID = &parsed
+	}
+
+	report, err := h.reportService.GetRevenueByCategory(startDate, endDate, parentID)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Category not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get revenue by category")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetTax godoc
+// @Summary Tax report
+// @Description Taxable sales and tax collected on paid orders over a calendar month, per applied tax rate and per day, for accounting. Taxable sales are the subtotal after discounts plus the service charge. Orders are grouped by the rate they were charged, so a rate change within the month is listed as two rates. Every day of the month is listed, including those without orders. Defaults to the current month. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM)"
+// @Success 200 {object} docs.TaxReportSuccessResponse "Tax report retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid month"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/tax [get]
+func (h *ReportHandler) GetTax(c *fiber.Ctx) error {
+	month := time.Now()
+	if param := c.Query("month"); param != "" {
+		parsed, err := time.ParseInLocation("2006-01", param, month.Location())
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid month format, expected YYYY-MM")
+		}
+		month = parsed
+	}
+
+	report, err := h.reportService.GetTax(month)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get tax report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetFulfillmentTimes godoc
+// @Summary Fulfillment times
+// @Description Median (p50) and 90th percentile minutes orders spent waiting for payment (queued to preparing), in preparation (preparing to ready), waiting for pickup (ready to completed) and overall (queued to completed), per day and over an inclusive date range, to spot slow shifts. Orders count on the day they were queued and towards a stage only once they finished it; percentiles are null for a stage no order finished. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} docs.FulfillmentTimesSuccessResponse "Fulfillment times retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/fulfillment-times [get]
+func (h *ReportHandler) GetFulfillmentTimes(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetFulfillmentTimes(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get fulfillment times")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetAbandonedOrders godoc
+// @Summary Abandoned orders
+// @Description Orders created but never paid in an inclusive date range, against all orders created, per order source and per hour of the day, to quantify drop-off in the payment flow (for example on kiosks). An order is abandoned when it was cancelled, or is still pending past its payment deadline, without a settled payment. Payment attempted counts abandoned orders that started a payment. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} docs.AbandonedOrdersSuccessResponse "Abandoned orders retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/abandoned [get]
+func (h *ReportHandler) GetAbandonedOrders(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetAbandonedOrders(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get abandoned orders report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetInventoryUsage godoc
+// @Summary Inventory usage
+// @Description Stock consumed per stock-tracked product in an inclusive date range, with current stock, units held by unpaid orders, average daily usage and the projected days until the available stock runs out at that pace, to plan purchasing. Products running out soonest come first; days until stockout is null for products nothing was consumed of. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} docs.InventoryUsageSuccessResponse "Inventory usage retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/inventory-usage [get]
+func (h *ReportHandler) GetInventoryUsage(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetInventoryUsage(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get inventory usage")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetTips godoc
+// @Summary Tips by staff
+// @Description Tips paid on orders for an inclusive date range, grouped by the staff member who claimed each order so they can be distributed. Tips on unclaimed orders are listed with a null staff member. Tips are not part of revenue. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} docs.TipsReportSuccessResponse "Tips report retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/tips [get]
+func (h *ReportHandler) GetTips(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetTips(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get tips report")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// GetSettlements godoc
+// @Summary Settlement export
+// @Description Gateway payments settled in an inclusive date range with the estimated gateway fee, expected payout date and order reference, for reconciling bank payouts. Wallet top-ups and gift card purchases are listed without an order number; cash payments and orders paid from the wallet, a gift card or points are left out. Use format=csv to download a spreadsheet instead of JSON. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param from query string false "First settlement date (YYYY-MM-DD)"
+// @Param to query string false "Last settlement date (YYYY-MM-DD)"
+// @Param format query string false "Response format" Enums(json, csv) default(json)
+// @Success 200 {object} docs.SettlementReportSuccessResponse "Settlements retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date, date range or format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /reports/settlements [get]
+func (h *ReportHandler) GetSettlements(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Format must be either json or csv")
+	}
+
+	startDate, endDate, err := parseReportDateRange(c, "from", "to")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	report, err := h.reportService.GetSettlements(startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get settlement report")
+	}
+
+	if format == "csv" {
+		var body bytes.Buffer
+		if err := report.WriteCSV(&body); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to export settlement report")
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment(fmt.Sprintf("settlements_%s_%s.csv", report.StartDate, report.EndDate))
+		return c.Status(fiber.StatusOK).Send(body.Bytes())
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, report)
+}
+
+// parseReportDateRange reads the start and end date from the named query
+// parameters, defaulting to the last 30 days
+func parseReportDateRange(c *fiber.Ctx, startParam, endParam string) (time.Time, time.Time, error) {
+	now := time.Now()
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	startDate := endDate.AddDate(0, 0, -29)
+
+	if param := c.Query(startParam); param != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", param, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		startDate = parsed
+	}
+
+	if param := c.Query(endParam); param != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", param, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		endDate = parsed
+	}
+
+	return startDate, endDate, nil
+}
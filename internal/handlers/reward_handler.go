@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RewardHandler struct {
+	rewardService services.RewardService
+	logger        *slog.Logger
+}
+
+func NewRewardHandler(rewardService services.RewardService, logger *slog.Logger) *RewardHandler {
+	return &RewardHandler{rewardService: rewardService, logger: logger}
+}
+
+// CreateReward godoc
+// @Summary Create a reward
+// @Description Add a reward members can swap loyalty points for. A free drink or merch is a free_item reward with the category it covers (including subcategories), where discount_value is the percentage taken off the dearest item from it; a discount is a percentage or fixed reward. The voucher a member gets stays valid for valid_days, or forever when 0. Leave stock out for no limit. Admin only.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.RewardRequest true "Reward details"
+// @Success 201 {object} docs.RewardSuccessResponse "Reward created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid discount or category not found"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /rewards [post]
+func (h *RewardHandler) CreateReward(c *fiber.Ctx) error {
+	var req services.RewardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	reward, err := h.rewardService.Create(req)
+	if err != nil {
+		return handleRewardError(c, err, "Failed to create reward")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, reward)
+}
+
+// GetRewards godoc
+// @Summary List rewards
+// @Description List the whole rewards catalog, including rewards that are switched off, cheapest first, with how many times members bought each. Admin only.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.RewardListSuccessResponse "Rewards retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /rewards [get]
+func (h *RewardHandler) GetRewards(c *fiber.Ctx) error {
+	rewards, err := h.rewardService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get rewards")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, rewards)
+}
+
+// GetReward godoc
+// @Summary Get a reward
+// @Description Get a single reward by its UUID. Admin only.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward UUID"
+// @Success 200 {object} docs.RewardSuccessResponse "Reward retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid reward ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Reward not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /rewards/{id} [get]
+func (h *RewardHandler) GetReward(c *fiber.Ctx) error {
+	rewardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reward ID format")
+	}
+
+	reward, err := h.rewardService.GetByUUID(rewardUUID)
+	if err != nil {
+		return handleRewardError(c, err, "Failed to get reward")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, reward)
+}
+
+// UpdateReward godoc
+// @Summary Update a reward
+// @Description Replace the terms of a reward. Vouchers members already bought with it keep theirs. Admin only.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward UUID"
+// @Param request body docs.RewardRequest true "Reward details"
+// @Success 200 {object} docs.RewardSuccessResponse "Reward updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, invalid discount or category not found"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Reward not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /rewards/{id} [put]
+func (h *RewardHandler) UpdateReward(c *fiber.Ctx) error {
+	rewardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reward ID format")
+	}
+
+	var req services.RewardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	reward, err := h.rewardService.Update(rewardUUID, req)
+	if err != nil {
+		return handleRewardError(c, err, "Failed to update reward")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, reward)
+}
+
+// DeleteReward godoc
+// @Summary Delete a reward
+// @Description Remove a reward from the catalog. Vouchers members already bought with it can still be used. Admin only.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward UUID"
+// @Success 200 {object} docs.MessageSuccessResponse "Reward deleted successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid reward ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Reward not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /rewards/{id} [delete]
+func (h *RewardHandler) DeleteReward(c *fiber.Ctx) error {
+	rewardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reward ID format")
+	}
+
+	if err := h.rewardService.Delete(rewardUUID); err != nil {
+		return handleRewardError(c, err, "Failed to delete reward")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Reward deleted successfully",
+	})
+}
+
+// GetMyRewards godoc
+// @Summary Browse the rewards catalog
+// @Description List the rewards the member can swap points for, cheapest first, with their points balance. Redeemable tells whether a reward is in stock and affordable.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.RewardCatalogSuccessResponse "Rewards retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/rewards [get]
+func (h *RewardHandler) GetMyRewards(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	catalog, err := h.rewardService.GetCatalog(userUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get rewards catalog", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get rewards")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, catalog)
+}
+
+// RedeemReward godoc
+// @Summary Redeem a reward
+// @Description Spend loyalty points on a reward from the catalog. The member gets a single-use voucher on the reward's terms, which they use at checkout by passing its code as voucher_code.
+// @Tags Rewards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reward UUID"
+// @Success 201 {object} docs.RewardRedemptionSuccessResponse "Reward redeemed"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid reward ID format or not enough points"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Reward not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Reward switched off or out of stock"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/rewards/{id}/redeem [post]
+func (h *RewardHandler) RedeemReward(c *fiber.Ctx) error {
+	rewardUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid reward ID format")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	redemption, err := h.rewardService.Redeem(userUUID, rewardUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		case errors.Is(err, services.ErrInsufficientPoints):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Not enough loyalty points")
+		}
+		return handleRewardError(c, err, "Failed to redeem reward")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Reward redeemed", redemption)
+}
+
+func handleRewardError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrRewardNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Reward not found")
+	case errors.Is(err, services.ErrRewardUnavailable):
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Reward is not available")
+	case errors.Is(err, services.ErrRewardCategoryRequired):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Free-item rewards need a category")
+	case errors.Is(err, services.ErrCategoryNotFound):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Category not found")
+	case errors.Is(err, services.ErrPromotionPercentTooHigh):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Percentage discount cannot exceed 100")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"crypto/subtle"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SelftestHandler struct {
+	selftestService services.SelftestService
+	token           string
+}
+
+// NewSelftestHandler guards the self-test with token. An empty token disables
+// the endpoint.
+func NewSelftestHandler(selftestService services.SelftestService, token string) *SelftestHandler {
+	return &SelftestHandler{
+		selftestService: selftestService,
+		token:           token,
+	}
+}
+
+// RunSelftest godoc
+// @Summary Run the deployment self-test
+// @Description Exercise the order lifecycle end to end against the sandbox product: place a guest order, settle it through a signed payment notification, move it to ready and completed, then place and cancel a second order. The synthetic orders are deleted afterwards. Returns 200 when every step passed and 500 with the same report otherwise, so deploy pipelines can gate on the status code. Requires the X-Selftest-Token header.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param X-Selftest-Token header string true "Self-test token"
+// @Success 200 {object} docs.SelftestSuccessResponse "Self-test passed"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Invalid self-test token"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Self-test is disabled"
+// @Failure 500 {object} docs.SelftestFailureResponse "Self-test failed"
+// @Router /admin/selftest [post]
+func (h *SelftestHandler) RunSelftest(c *fiber.Ctx) error {
+	if h.token == "" {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Self-test is disabled")
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Get("X-Selftest-Token")), []byte(h.token)) != 1 {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid self-test token")
+	}
+
+	report := h.selftestService.Run()
+	if !report.Passed {
+		return c.Status(fiber.StatusInternalServerError).JSON(utils.Response{
+			Success: false,
+			Error:   "Self-test failed",
+			Data:    report,
+		})
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Self-test passed", report)
+}
@@ -0,0 +1,538 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SettingsHandler struct {
+	settingsService services.SettingsService
+	receiptService  services.ReceiptService
+}
+
+func NewSettingsHandler(settingsService services.SettingsService, receiptService services.ReceiptService) *SettingsHandler {
+	return &SettingsHandler{
+		settingsService: settingsService,
+		receiptService:  receiptService,
+	}
+}
+
+// GetReceiptSettings godoc
+// @Summary Get receipt settings
+// @Description Get the header, footer, logo, tax ID and promotional message printed on receipts. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.ReceiptSettingsSuccessResponse "Receipt settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/receipt [get]
+func (h *SettingsHandler) GetReceiptSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetReceiptSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get receipt settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateReceiptSettings godoc
+// @Summary Update receipt settings
+// @Description Replace the receipt settings. Applies to every receipt rendered afterwards. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.ReceiptSettings true "Receipt settings"
+// @Success 200 {object} docs.ReceiptSettingsSuccessResponse "Receipt settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/receipt [put]
+func (h *SettingsHandler) UpdateReceiptSettings(c *fiber.Ctx) error {
+	var req services.ReceiptSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateReceiptSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update receipt settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetNotificationSettings godoc
+// @Summary Get notification settings
+// @Description Get which emails are sent to customers. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.NotificationSettingsSuccessResponse "Notification settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/notifications [get]
+func (h *SettingsHandler) GetNotificationSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetNotificationSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get notification settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateNotificationSettings godoc
+// @Summary Update notification settings
+// @Description Replace the notification settings. With email_receipts on, members and guests who left an email address or WhatsApp number get their receipt once their order is completed and paid. With ready_notifications on, customers are told when their order is ready for pickup, on the member's preferred channel or by WhatsApp for guests who left a number and email otherwise. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.NotificationSettings true "Notification settings"
+// @Success 200 {object} docs.NotificationSettingsSuccessResponse "Notification settings updated successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid request body"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/notifications [put]
+func (h *SettingsHandler) UpdateNotificationSettings(c *fiber.Ctx) error {
+	var req services.NotificationSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	settings, err := h.settingsService.UpdateNotificationSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update notification settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetQRCodeSettings godoc
+// @Summary Get QR code settings
+// @Description Get the colors generated QR codes are drawn in. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.QRCodeSettingsSuccessResponse "QR code settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/qr-code [get]
+func (h *SettingsHandler) GetQRCodeSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetQRCodeSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get QR code settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateQRCodeSettings godoc
+// @Summary Update QR code settings
+// @Description Replace the QR code colors. Empty colors fall back to the defaults. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.QRCodeSettings true "QR code settings"
+// @Success 200 {object} docs.QRCodeSettingsSuccessResponse "QR code settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/qr-code [put]
+func (h *SettingsHandler) UpdateQRCodeSettings(c *fiber.Ctx) error {
+	var req services.QRCodeSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateQRCodeSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update QR code settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetTimeslotSettings godoc
+// @Summary Get timeslot capacity
+// @Description Get the most orders and drinks the kitchen takes per 15-minute pickup timeslot. Zero means no limit. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.TimeslotSettingsSuccessResponse "Timeslot settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/timeslots [get]
+func (h *SettingsHandler) GetTimeslotSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetTimeslotSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get timeslot settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateTimeslotSettings godoc
+// @Summary Update timeslot capacity
+// @Description Replace the timeslot capacity. New orders are refused once their pickup timeslot holds max_orders orders or max_drinks drinks, counting pre-orders at their scheduled time and other orders when they are placed; the response then lists the next timeslots that can still take the order. Zero turns a limit off. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.TimeslotSettings true "Timeslot settings"
+// @Success 200 {object} docs.TimeslotSettingsSuccessResponse "Timeslot settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/timeslots [put]
+func (h *SettingsHandler) UpdateTimeslotSettings(c *fiber.Ctx) error {
+	var req services.TimeslotSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateTimeslotSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update timeslot settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetPaymentSettings godoc
+// @Summary Get payment settings
+// @Description Get the payment methods offered on the Midtrans payment page. An empty list offers every method active on the Midtrans account. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.PaymentSettingsSuccessResponse "Payment settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/payments [get]
+func (h *SettingsHandler) GetPaymentSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetPaymentSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get payment settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdatePaymentSettings godoc
+// @Summary Update payment settings
+// @Description Replace the payment methods offered on the Midtrans payment page, in the order they are shown, for example to turn off credit cards or put QRIS first. Methods must also be active on the Midtrans account. Payment pages already opened keep their methods. Send an empty list to offer every method. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.PaymentSettings true "Payment settings"
+// @Success 200 {object} docs.PaymentSettingsSuccessResponse "Payment settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/payments [put]
+func (h *SettingsHandler) UpdatePaymentSettings(c *fiber.Ctx) error {
+	var req services.PaymentSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdatePaymentSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update payment settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetLoyaltySettings godoc
+// @Summary Get loyalty settings
+// @Description Get how members earn and spend loyalty points. Zero means points are not earned or not spent. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.LoyaltySettingsSuccessResponse "Loyalty settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/loyalty [get]
+func (h *SettingsHandler) GetLoyaltySettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetLoyaltySettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get loyalty settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateLoyaltySettings godoc
+// @Summary Update loyalty settings
+// @Description Set how much a member spends on completed orders to earn a point, and how much a point takes off an order. Send zero to stop earning or spending points. Points already earned keep their count; their value follows the new rate. Set expiry_months to expire points that long after they were earned, oldest first, and expiry_warning_days to email members that many days before; zero keeps points forever or sends no warnings. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.LoyaltySettings true "Loyalty settings"
+// @Success 200 {object} docs.LoyaltySettingsSuccessResponse "Loyalty settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/loyalty [put]
+func (h *SettingsHandler) UpdateLoyaltySettings(c *fiber.Ctx) error {
+	var req services.LoyaltySettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateLoyaltySettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update loyalty settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetCurrencySettings godoc
+// @Summary Get currency settings
+// @Description Get the currency prices are set and charged in: its ISO code, the symbol shown in front of amounts and how many decimal places amounts are rounded to. Public at /currency so customer apps can show prices.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} docs.CurrencySettingsSuccessResponse "Currency settings retrieved successfully"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /currency [get]
+// @Router /settings/currency [get]
+func (h *SettingsHandler) GetCurrencySettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetCurrencySettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get currency settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateCurrencySettings godoc
+// @Summary Update currency settings
+// @Description Set the currency prices are set and charged in. New orders record it and are charged in it; existing prices and orders are not converted. Midtrans only charges IDR. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.CurrencySettings true "Currency settings"
+// @Success 200 {object} docs.CurrencySettingsSuccessResponse "Currency settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/currency [put]
+func (h *SettingsHandler) UpdateCurrencySettings(c *fiber.Ctx) error {
+	var req services.CurrencySettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateCurrencySettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update currency settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetDepositSettings godoc
+// @Summary Get deposit settings
+// @Description Get the down payment asked of large orders: the percentage of the total paid first, and the total from which it applies. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.DepositSettingsSuccessResponse "Deposit settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/deposits [get]
+func (h *SettingsHandler) GetDepositSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetDepositSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get deposit settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateDepositSettings godoc
+// @Summary Update deposit settings
+// @Description Ask orders totalling at least min_total to pay percent of their total first. They wait in deposit_paid until the balance is paid, and only then go to the kitchen. Send a percent of zero to stop taking deposits. Orders already placed keep their deposit. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.DepositSettings true "Deposit settings"
+// @Success 200 {object} docs.DepositSettingsSuccessResponse "Deposit settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/deposits [put]
+func (h *SettingsHandler) UpdateDepositSettings(c *fiber.Ctx) error {
+	var req services.DepositSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateDepositSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update deposit settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// GetStampSettings godoc
+// @Summary Get stamp card settings
+// @Description Get the stamp card: how many stamps earn a free drink, which category's items earn stamps, and the value cap and lifetime of the free-drink voucher. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.StampSettingsSuccessResponse "Stamp card settings retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/stamps [get]
+func (h *SettingsHandler) GetStampSettings(c *fiber.Ctx) error {
+	settings, err := h.settingsService.GetStampSettings()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get stamp card settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// UpdateStampSettings godoc
+// @Summary Update stamp card settings
+// @Description Give members a stamp for every item of category_id, or a category nested under it, on their completed orders; leave category_id out to stamp every item. Each time a member collects goal stamps they get a voucher for one free item of that category, worth at most reward_max_value (zero for no cap) and valid for reward_valid_days (zero for no expiry). Send a goal of zero to stop stamping; collected stamps and issued rewards are kept. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.StampSettings true "Stamp card settings"
+// @Success 200 {object} docs.StampSettingsSuccessResponse "Stamp card settings updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/stamps [put]
+func (h *SettingsHandler) UpdateStampSettings(c *fiber.Ctx) error {
+	var req services.StampSettings
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	settings, err := h.settingsService.UpdateStampSettings(req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update stamp card settings")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, settings)
+}
+
+// PreviewReceipt godoc
+// @Summary Preview receipt
+// @Description Render a sample order as a receipt. Send settings in the body to preview them before saving; an empty body uses the saved settings. Admin only.
+// @Tags Settings
+// @Accept json
+// @Produce plain
+// @Produce html
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param format query string false "Output format" Enums(text, html, escpos) default(text)
+// @Param request body docs.ReceiptSettings false "Unsaved receipt settings"
+// @Success 200 {string} string "Rendered receipt"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /settings/receipt/preview [post]
+func (h *SettingsHandler) PreviewReceipt(c *fiber.Ctx) error {
+	format := services.ReceiptFormat(c.Query("format", string(services.ReceiptFormatText)))
+
+	var settings *services.ReceiptSettings
+	if len(c.Body()) > 0 {
+		var req services.ReceiptSettings
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+
+		if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+			return utils.ValidationErrorResponse(c, validationErrors)
+		}
+		settings = &req
+	}
+
+	receipt, err := h.receiptService.Preview(settings, format)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidReceiptFormat) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid format. Must be one of: text, html, escpos")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to render receipt preview")
+	}
+
+	c.Set(fiber.HeaderContentType, receipt.ContentType)
+	return c.Status(fiber.StatusOK).Send(receipt.Body)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatusHandler struct {
+	tracker *metrics.StatusTracker
+	checks  []metrics.Check
+}
+
+func NewStatusHandler(tracker *metrics.StatusTracker, checks []metrics.Check) *StatusHandler {
+	return &StatusHandler{
+		tracker: tracker,
+		checks:  checks,
+	}
+}
+
+// GetStatus godoc
+// @Summary Get public service status
+// @Description Get data for the public status page: overall status, uptime of the answering instance, the state of each dependency, and incidents from the last 24 hours. Only states are reported, never latencies or error details. Incidents are recorded when the status is polled, so poll regularly for accurate history.
+// @Tags Status
+// @Accept json
+// @Produce json
+// @Success 200 {object} docs.StatusSuccessResponse "Service status"
+// @Router /status [get]
+func (h *StatusHandler) GetStatus(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=10")
+	return utils.SuccessResponse(c, fiber.StatusOK, h.tracker.Report(h.checks))
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TableHandler struct {
+	tableService services.TableService
+}
+
+func NewTableHandler(tableService services.TableService) *TableHandler {
+	return &TableHandler{
+		tableService: tableService,
+	}
+}
+
+// CreateTable godoc
+// @Summary Create a table
+// @Description Add a table customers can order from. The response includes the link to print as the table's QR code, e.g. through POST /qr-codes. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.TableRequest true "Table details"
+// @Success 201 {object} docs.TableSuccessResponse "Table created successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Table number already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables [post]
+func (h *TableHandler) CreateTable(c *fiber.Ctx) error {
+	var req services.TableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	table, err := h.tableService.Create(req)
+	if err != nil {
+		return handleTableError(c, err, "Failed to create table")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, table)
+}
+
+// GetTables godoc
+// @Summary List tables
+// @Description List all tables by number with their QR code links. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.TableListSuccessResponse "Tables retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables [get]
+func (h *TableHandler) GetTables(c *fiber.Ctx) error {
+	tables, err := h.tableService.GetAll()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get tables")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, tables)
+}
+
+// GetTable godoc
+// @Summary Get a table
+// @Description Get a single table by its UUID. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Table UUID"
+// @Success 200 {object} docs.TableSuccessResponse "Table retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid table ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Table not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables/{id} [get]
+func (h *TableHandler) GetTable(c *fiber.Ctx) error {
+	tableUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid table ID format")
+	}
+
+	table, err := h.tableService.GetByUUID(tableUUID)
+	if err != nil {
+		return handleTableError(c, err, "Failed to get table")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, table)
+}
+
+// UpdateTable godoc
+// @Summary Update a table
+// @Description Rename a table or stop taking orders from it. Its QR code keeps working. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Table UUID"
+// @Param request body docs.TableRequest true "Table details"
+// @Success 200 {object} docs.TableSuccessResponse "Table updated successfully"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error or invalid table ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Table not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Table number already exists"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables/{id} [put]
+func (h *TableHandler) UpdateTable(c *fiber.Ctx) error {
+	tableUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid table ID format")
+	}
+
+	var req services.TableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	table, err := h.tableService.Update(tableUUID, req)
+	if err != nil {
+		return handleTableError(c, err, "Failed to update table")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, table)
+}
+
+// DeleteTable godoc
+// @Summary Delete a table
+// @Description Delete a table so its QR code stops working. Its orders keep the table number. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Table UUID"
+// @Success 200 {object} docs.MessageSuccessResponse "Table deleted successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid table ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Table not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables/{id} [delete]
+func (h *TableHandler) DeleteTable(c *fiber.Ctx) error {
+	tableUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid table ID format")
+	}
+
+	if err := h.tableService.Delete(tableUUID); err != nil {
+		return handleTableError(c, err, "Failed to delete table")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"message": "Table deleted successfully",
+	})
+}
+
+// RegenerateTableToken godoc
+// @Summary Regenerate a table's QR token
+// @Description Give the table a new QR token, e.g. after a photo of its code was shared. Previously printed codes stop working and must be replaced. Admin only.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Table UUID"
+// @Success 200 {object} docs.TableSuccessResponse "Token regenerated successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid table ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Table not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables/{id}/token [post]
+func (h *TableHandler) RegenerateTableToken(c *fiber.Ctx) error {
+	tableUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid table ID format")
+	}
+
+	table, err := h.tableService.RegenerateToken(tableUUID)
+	if err != nil {
+		return handleTableError(c, err, "Failed to regenerate table token")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, table)
+}
+
+// ScanTable godoc
+// @Summary Resolve a table QR code
+// @Description Look up the table behind the token in a scanned QR code. Send the returned id as table_id when placing the order so it is served to this table.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Param token path string true "Token from the table QR code"
+// @Success 200 {object} docs.ScannedTableSuccessResponse "Table found"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Table not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /tables/scan/{token} [get]
+func (h *TableHandler) ScanTable(c *fiber.Ctx) error {
+	table, err := h.tableService.Scan(c.Params("token"))
+	if err != nil {
+		return handleTableError(c, err, "Failed to get table")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, table)
+}
+
+func handleTableError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrDiningTableNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Table not found")
+	case errors.Is(err, services.ErrTableNumberExists):
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Table number already exists")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
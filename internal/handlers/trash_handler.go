@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TrashHandler struct {
+	trashService services.TrashService
+}
+
+func NewTrashHandler(trashService services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// ListTrash godoc
+// @Summary List soft-deleted items
+// @Description List soft-deleted products and categories, most recently deleted first. Filter with type. Admin only.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Item type" Enums(products, categories)
+// @Success 200 {object} docs.TrashSuccessResponse "Trash retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Unknown item type"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /trash [get]
+func (h *TrashHandler) ListTrash(c *fiber.Ctx) error {
+	trash, err := h.trashService.List(c.Query("type"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownTrashType) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Unknown item type")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get trash")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, trash)
+}
+
+// GetPurgeImpact godoc
+// @Summary Preview a permanent delete
+// @Description Count what permanently deleting a trashed item would affect: order items and orders referencing a product, or child categories and products of a category. Admin only.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type path string true "Item type" Enums(products, categories)
+// @Param id path string true "Item UUID"
+// @Success 200 {object} docs.PurgeImpactSuccessResponse "Purge impact retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid ID format or unknown item type"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Item not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Item is not in the trash"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /trash/{type}/{id}/impact [get]
+func (h *TrashHandler) GetPurgeImpact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	impact, err := h.trashService.PurgeImpact(c.Params("type"), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get purge impact")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, impact)
+}
+
+// RestoreTrashItem godoc
+// @Summary Restore a soft-deleted item
+// @Description Restore a trashed product or category. Admin only.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type path string true "Item type" Enums(products, categories)
+// @Param id path string true "Item UUID"
+// @Success 200 {object} docs.SwaggerSuccessResponse "Item restored successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid ID format or unknown item type"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Item not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Item is not in the trash"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreTrashItem(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := h.trashService.Restore(c.Params("type"), id); err != nil {
+		return h.handleError(c, err, "Failed to restore item")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Item restored successfully", nil)
+}
+
+// PurgeTrashItem godoc
+// @Summary Permanently delete a soft-deleted item
+// @Description Permanently delete a trashed product or category. Order items keep their product name snapshot; products of a purged category become uncategorized. Check the impact endpoint first. Admin only.
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type path string true "Item type" Enums(products, categories)
+// @Param id path string true "Item UUID"
+// @Success 200 {object} docs.SwaggerSuccessResponse "Item permanently deleted"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid ID format or unknown item type"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Item not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Item is not in the trash"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /trash/{type}/{id} [delete]
+func (h *TrashHandler) PurgeTrashItem(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := h.trashService.Purge(c.Params("type"), id); err != nil {
+		return h.handleError(c, err, "Failed to delete item")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Item permanently deleted", nil)
+}
+
+func (h *TrashHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrUnknownTrashType):
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Unknown item type")
+	case errors.Is(err, services.ErrTrashItemNotFound):
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Item not found")
+	case errors.Is(err, services.ErrNotInTrash):
+		return utils.ErrorResponse(c, fiber.StatusConflict, "Item is not in the trash")
+	default:
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, fallback)
+	}
+}
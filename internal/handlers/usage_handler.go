@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UsageHandler struct {
+	usageService services.UsageService
+}
+
+func NewUsageHandler(usageService services.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetUsage godoc
+// @Summary API usage per client
+// @Description Request counts, error rates and latency per API client, with a breakdown by route, for an inclusive date range. Clients identify themselves with the X-Client-Name header (for example kiosk, mobile or web); requests without it are attributed by User-Agent. Defaults to the last 30 days. Admin only.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param client query string false "Only this client"
+// @Success 200 {object} docs.UsageSuccessResponse "Usage retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid date or date range"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /usage [get]
+func (h *UsageHandler) GetUsage(c *fiber.Ctx) error {
+	startDate, endDate, err := parseReportDateRange(c, "start_date", "end_date")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	}
+
+	usage, err := h.usageService.GetUsage(startDate, endDate, strings.ToLower(c.Query("client")))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "End date must not be before start date")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get API usage")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, usage)
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type VoucherHandler struct {
+	voucherService services.VoucherService
+	logger         *slog.Logger
+}
+
+func NewVoucherHandler(voucherService services.VoucherService, logger *slog.Logger) *VoucherHandler {
+	return &VoucherHandler{voucherService: voucherService, logger: logger}
+}
+
+// IssueVouchers godoc
+// @Summary Issue vouchers
+// @Description Grant a single-use voucher to each listed member, or to every member of a segment: all members, lapsed members with no completed order in inactive_days, or new members without a completed order. Each member gets their own code, which only they can redeem at checkout. Admin only.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.IssueVouchersRequest true "Recipients and voucher terms"
+// @Success 201 {object} docs.IssueVouchersSuccessResponse "Vouchers issued"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error, unknown member or empty segment"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /vouchers [post]
+func (h *VoucherHandler) IssueVouchers(c *fiber.Ctx) error {
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.IssueVouchersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	issued, err := h.voucherService.Issue(staffUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrVoucherRecipients),
+			errors.Is(err, services.ErrVoucherMemberNotFound),
+			errors.Is(err, services.ErrVoucherSegmentEmpty),
+			errors.Is(err, services.ErrVoucherExpiryNotInFuture),
+			errors.Is(err, services.ErrPromotionPercentTooHigh):
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to issue vouchers", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to issue vouchers")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Vouchers issued", issued)
+}
+
+// GetVouchers godoc
+// @Summary List vouchers
+// @Description Get a paginated list of vouchers, newest first. Admin only.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Param status query string false "Filter by status" Enums(active, redeemed, revoked)
+// @Param user_id query string false "Filter by member UUID"
+// @Success 200 {object} docs.VouchersSuccessResponse "Vouchers retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid status or member ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /vouchers [get]
+func (h *VoucherHandler) GetVouchers(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var status *models.VoucherStatus
+	if statusParam := c.Query("status"); statusParam != "" {
+		parsed := models.VoucherStatus(statusParam)
+		if parsed != models.VoucherStatusActive && parsed != models.VoucherStatusRedeemed && parsed != models.VoucherStatusRevoked {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status")
+		}
+		status = &parsed
+	}
+
+	var memberUUID *uuid.UUID
+	if userParam := c.Query("user_id"); userParam != "" {
+		parsed, err := uuid.Parse(userParam)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid member ID format")
+		}
+		memberUUID = &parsed
+	}
+
+	vouchers, err := h.voucherService.GetAll(status, memberUUID, page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrVoucherMemberNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Member not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get vouchers", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get vouchers")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, vouchers)
+}
+
+// GetVoucher godoc
+// @Summary Get a voucher
+// @Description Get one voucher with its audit trail: who issued it, the order that redeemed it, releases from cancelled orders and any revocation. Admin only.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Voucher UUID"
+// @Success 200 {object} docs.VoucherSuccessResponse "Voucher retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid voucher ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Voucher not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /vouchers/{id} [get]
+func (h *VoucherHandler) GetVoucher(c *fiber.Ctx) error {
+	voucherUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid voucher ID format")
+	}
+
+	voucher, err := h.voucherService.GetByUUID(voucherUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrVoucherNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Voucher not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get voucher", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get voucher")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, voucher)
+}
+
+// RevokeVoucher godoc
+// @Summary Revoke a voucher
+// @Description Stop an active voucher from being redeemed, such as when it was issued by mistake. A voucher already used on an order stays with it. Admin only.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Voucher UUID"
+// @Param request body docs.RevokeVoucherRequest true "Reason for revoking"
+// @Success 200 {object} docs.VoucherSuccessResponse "Voucher revoked"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid voucher ID or validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Voucher not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Voucher is not active"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /vouchers/{id}/revoke [post]
+func (h *VoucherHandler) RevokeVoucher(c *fiber.Ctx) error {
+	voucherUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid voucher ID format")
+	}
+
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.RevokeVoucherRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	voucher, err := h.voucherService.Revoke(voucherUUID, staffUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrVoucherNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Voucher not found")
+		case errors.Is(err, services.ErrVoucherNotActive):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Only active vouchers can be revoked")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to revoke voucher", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke voucher")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Voucher revoked", voucher)
+}
+
+// GetMyVouchers godoc
+// @Summary List my vouchers
+// @Description Get the vouchers granted to the member, newest first. Redeemable ones can be used at checkout by passing their code as voucher_code.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.MyVouchersSuccessResponse "Vouchers retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/vouchers [get]
+func (h *VoucherHandler) GetMyVouchers(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	vouchers, err := h.voucherService.GetMyVouchers(userUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get member vouchers", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get vouchers")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, vouchers)
+}
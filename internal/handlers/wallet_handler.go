@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WalletHandler struct {
+	walletService  services.WalletService
+	paymentService services.PaymentService
+	logger         *slog.Logger
+}
+
+func NewWalletHandler(walletService services.WalletService, paymentService services.PaymentService, logger *slog.Logger) *WalletHandler {
+	return &WalletHandler{
+		walletService:  walletService,
+		paymentService: paymentService,
+		logger:         logger,
+	}
+}
+
+// GetMyWallet godoc
+// @Summary Get my wallet
+// @Description Get the authenticated member's wallet balance with a paginated statement of top-ups, payments, refunds and adjustments, newest first.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Success 200 {object} docs.WalletSuccessResponse "Wallet retrieved successfully"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/wallet [get]
+func (h *WalletHandler) GetMyWallet(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+	return h.getWallet(c, userUUID)
+}
+
+// GetWallet godoc
+// @Summary Get a member's wallet
+// @Description Get a member's wallet balance with a paginated statement, newest first. Admin only.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User UUID"
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Success 200 {object} docs.WalletSuccessResponse "Wallet retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid user ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "User not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /wallets/{userId} [get]
+func (h *WalletHandler) GetWallet(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+	return h.getWallet(c, userUUID)
+}
+
+func (h *WalletHandler) getWallet(c *fiber.Ctx, userUUID uuid.UUID) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	wallet, err := h.walletService.GetWallet(userUUID, page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to get wallet", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get wallet")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, wallet)
+}
+
+// CreateTopUp godoc
+// @Summary Top up my wallet
+// @Description Open a payment page with the configured gateway to add money to the authenticated member's wallet. The balance is credited once the gateway settles the payment.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body docs.WalletTopUpRequest true "Amount to add"
+// @Success 201 {object} docs.WalletTopUpSuccessResponse "Top-up started"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Member only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /me/wallet/top-ups [post]
+func (h *WalletHandler) CreateTopUp(c *fiber.Ctx) error {
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.WalletTopUpRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	req.RequestID = utils.RequestID(c)
+	topUp, err := h.paymentService.CreateWalletTopUp(userUUID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to create wallet top-up", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create top-up")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Top-up started", topUp)
+}
+
+// PayWithWallet godoc
+// @Summary Pay an order from my wallet
+// @Description Pay the authenticated member's pending order in full from their wallet balance. The order goes to the kitchen straight away.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order UUID"
+// @Success 201 {object} docs.WalletPaymentSuccessResponse "Order paid from wallet"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid order ID"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - not the member's order"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Order not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Order is not awaiting payment, an item sold out, or the balance is too low"
+// @Failure 410 {object} docs.SwaggerErrorResponse "Payment deadline has passed"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /orders/{id}/payments/wallet [post]
+func (h *WalletHandler) PayWithWallet(c *fiber.Ctx) error {
+	orderUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	userUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	payment, err := h.paymentService.PayWithWallet(orderUUID, userUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+		case errors.Is(err, services.ErrOrderAccessDenied):
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied")
+		case errors.Is(err, services.ErrOrderNotAwaitingPayment):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Order is not awaiting payment")
+		case errors.Is(err, services.ErrInsufficientBalance):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Insufficient wallet balance")
+		case errors.Is(err, services.ErrInsufficientStock):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Item is sold out")
+		case errors.Is(err, services.ErrPaymentExpired):
+			return utils.ErrorResponse(c, fiber.StatusGone, "Payment deadline has passed")
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to pay with wallet", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to pay with wallet")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Order paid from wallet", payment)
+}
+
+// AdjustWallet godoc
+// @Summary Adjust a member's wallet
+// @Description Credit or debit a member's wallet by hand, such as goodwill credit after a complaint. Send a negative amount to debit; a debit larger than the balance is refused. The note and the admin are kept on the statement entry. Admin only.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User UUID"
+// @Param request body docs.WalletAdjustmentRequest true "Signed amount and note"
+// @Success 201 {object} docs.WalletTransactionSuccessResponse "Wallet adjusted"
+// @Failure 400 {object} docs.SwaggerValidationErrorResponse "Invalid user ID or validation error"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "User not found"
+// @Failure 409 {object} docs.SwaggerErrorResponse "Insufficient wallet balance"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /wallets/{userId}/adjustments [post]
+func (h *WalletHandler) AdjustWallet(c *fiber.Ctx) error {
+	userUUID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	staffUUID, ok := c.Locals("userUUID").(uuid.UUID)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Invalid user UUID")
+	}
+
+	var req services.WalletAdjustmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return utils.ValidationErrorResponse(c, validationErrors)
+	}
+
+	entry, err := h.walletService.Adjust(userUUID, staffUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		case errors.Is(err, services.ErrInsufficientBalance):
+			return utils.ErrorResponse(c, fiber.StatusConflict, "Insufficient wallet balance")
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to adjust wallet", logging.Err(err))
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to adjust wallet")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusCreated, "Wallet adjusted", entry)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+
+	_ "github.com/carllix/matchaciee-backend/docs"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WebhookEventHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookEventHandler(webhookService services.WebhookService) *WebhookEventHandler {
+	return &WebhookEventHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhookEvents godoc
+// @Summary List received webhooks
+// @Description List payment webhooks as they were received, newest first, with the result of processing them. Secret headers are redacted. Admin only.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query integer false "Page number" default(1)
+// @Param limit query integer false "Items per page (max 100)" default(20)
+// @Param provider query string false "Filter by gateway" Enums(midtrans, stripe, xendit)
+// @Param status query string false "Filter by processing result" Enums(received, processed, failed)
+// @Success 200 {object} docs.WebhookEventsSuccessResponse "Webhook events retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid provider or status"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /webhook-events [get]
+func (h *WebhookEventHandler) GetWebhookEvents(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 20)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var filters repositories.WebhookEventFilters
+
+	if providerParam := c.Query("provider"); providerParam != "" {
+		provider := models.PaymentMethod(providerParam)
+		if provider != models.PaymentMethodMidtrans && provider != models.PaymentMethodStripe && provider != models.PaymentMethodXendit {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid provider")
+		}
+		filters.Provider = &provider
+	}
+
+	if statusParam := c.Query("status"); statusParam != "" {
+		status := models.WebhookEventStatus(statusParam)
+		if status != models.WebhookEventStatusReceived && status != models.WebhookEventStatusProcessed && status != models.WebhookEventStatusFailed {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid status")
+		}
+		filters.Status = &status
+	}
+
+	events, err := h.webhookService.GetAll(filters, page, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get webhook events")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, events)
+}
+
+// GetWebhookEvent godoc
+// @Summary Get a received webhook
+// @Description Get one payment webhook with its raw body, headers and processing result. Secret headers are redacted. Admin only.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook event UUID"
+// @Success 200 {object} docs.WebhookEventSuccessResponse "Webhook event retrieved successfully"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid webhook event ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Webhook event not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /webhook-events/{id} [get]
+func (h *WebhookEventHandler) GetWebhookEvent(c *fiber.Ctx) error {
+	eventUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid webhook event ID format")
+	}
+
+	event, err := h.webhookService.GetByUUID(eventUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookEventNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Webhook event not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get webhook event")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, event)
+}
+
+// ReplayWebhookEvent godoc
+// @Summary Replay a received webhook
+// @Description Process a stored webhook again, for example after fixing the bug that rejected it. Signatures are still verified, against the time the webhook first arrived. The event is returned with the result of this attempt. Admin only.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook event UUID"
+// @Success 200 {object} docs.WebhookEventSuccessResponse "Webhook event replayed"
+// @Failure 400 {object} docs.SwaggerErrorResponse "Invalid webhook event ID format"
+// @Failure 401 {object} docs.SwaggerErrorResponse "Unauthorized"
+// @Failure 403 {object} docs.SwaggerErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} docs.SwaggerErrorResponse "Webhook event not found"
+// @Failure 500 {object} docs.SwaggerErrorResponse "Internal server error"
+// @Router /webhook-events/{id}/replay [post]
+func (h *WebhookEventHandler) ReplayWebhookEvent(c *fiber.Ctx) error {
+	eventUUID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid webhook event ID format")
+	}
+
+	event, err := h.webhookService.Replay(eventUUID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookEventNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "Webhook event not found")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to replay webhook event")
+	}
+
+	return utils.SuccessMessageResponse(c, fiber.StatusOK, "Webhook event replayed", event)
+}
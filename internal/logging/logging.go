@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+)
+
+// Field names shared by every log line, so one request, customer or order can
+// be found across services
+const (
+	KeyRequestID   = "request_id"
+	KeyUserUUID    = "user_uuid"
+	KeyOrderNumber = "order_number"
+	KeyError       = "error"
+)
+
+// ParseLevel reads a LOG_LEVEL value: debug, info, warn or error
+func ParseLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+	return parsed, nil
+}
+
+// New returns a logger writing JSON lines to w from the given level up. Lines
+// logged with a request's context carry its request ID and the attributes
+// added with WithAttrs.
+func New(w io.Writer, level string) (*slog.Logger, error) {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: parsed})}), nil
+}
+
+// Discard returns a logger that drops everything, for code that needs one but
+// has nowhere to write
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+type attrsKey struct{}
+
+// WithAttrs returns a copy of ctx whose log lines also carry attrs, such as
+// the signed-in user
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, attrsKey{}, append(existing[:len(existing):len(existing)], attrs...))
+}
+
+// UserUUID is the attribute naming the user a line is about
+func UserUUID(userUUID fmt.Stringer) slog.Attr {
+	return slog.String(KeyUserUUID, userUUID.String())
+}
+
+// OrderNumber is the attribute naming the order a line is about
+func OrderNumber(orderNumber string) slog.Attr {
+	return slog.String(KeyOrderNumber, orderNumber)
+}
+
+// Err is the attribute carrying a failure
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
+
+// contextHandler adds the request ID and attributes carried by the context to
+// each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+			record.AddAttrs(slog.String(KeyRequestID, requestID))
+		}
+		if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+			record.AddAttrs(attrs...)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const dbMonitorStartKey = "metrics:start"
+
+// DBStats summarizes database calls over the monitor's window
+type DBStats struct {
+	Queries        int64
+	Errors         int64
+	ErrorRate      float64
+	AverageLatency time.Duration
+}
+
+type dbBucket struct {
+	second       int64
+	queries      int64
+	errors       int64
+	totalLatency time.Duration
+}
+
+// DBMonitor tracks database latency and error rate over a sliding window of
+// one-second buckets
+type DBMonitor struct {
+	mu      sync.Mutex
+	buckets []dbBucket
+	now     func() time.Time
+}
+
+func NewDBMonitor(window time.Duration) *DBMonitor {
+	size := int(window / time.Second)
+	if size < 1 {
+		size = 1
+	}
+
+	return &DBMonitor{
+		buckets: make([]dbBucket, size),
+		now:     time.Now,
+	}
+}
+
+// Observe records one database call. Record-not-found is a normal outcome and
+// does not count as an error.
+func (m *DBMonitor) Observe(latency time.Duration, err error) {
+	second := m.now().Unix()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket := &m.buckets[second%int64(len(m.buckets))]
+	if bucket.second != second {
+		*bucket = dbBucket{second: second}
+	}
+
+	bucket.queries++
+	bucket.totalLatency += latency
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		bucket.errors++
+	}
+}
+
+func (m *DBMonitor) Stats() DBStats {
+	oldest := m.now().Unix() - int64(len(m.buckets)) + 1
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stats DBStats
+	var totalLatency time.Duration
+	for _, bucket := range m.buckets {
+		if bucket.second < oldest {
+			continue
+		}
+		stats.Queries += bucket.queries
+		stats.Errors += bucket.errors
+		totalLatency += bucket.totalLatency
+	}
+
+	if stats.Queries > 0 {
+		stats.ErrorRate = float64(stats.Errors) / float64(stats.Queries)
+		stats.AverageLatency = totalLatency / time.Duration(stats.Queries)
+	}
+	return stats
+}
+
+// Register hooks the monitor into every GORM operation on db
+func (m *DBMonitor) Register(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(dbMonitorStartKey, time.Now())
+	}
+	after := func(tx *gorm.DB) {
+		start, ok := tx.InstanceGet(dbMonitorStartKey)
+		if !ok {
+			return
+		}
+		startedAt, ok := start.(time.Time)
+		if !ok {
+			return
+		}
+		m.Observe(time.Since(startedAt), tx.Error)
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("metrics:before_create", before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("metrics:after_create", after); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("metrics:before_query", before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("metrics:after_query", after); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("metrics:before_update", before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("metrics:after_update", after); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("metrics:before_delete", before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("metrics:after_delete", after); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("metrics:before_row", before); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("metrics:after_row", after); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("metrics:before_raw", before); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("metrics:after_raw", after)
+}
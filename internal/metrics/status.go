@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// ComponentState is the public health of one dependency
+type ComponentState string
+
+const (
+	StateOperational ComponentState = "operational"
+	StateDegraded    ComponentState = "degraded"
+	StateDown        ComponentState = "down"
+)
+
+// maxIncidents bounds memory if a component flaps for a long time
+const maxIncidents = 50
+
+// severity orders states so the worst one wins
+func (s ComponentState) severity() int {
+	switch s {
+	case StateDown:
+		return 2
+	case StateDegraded:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Worse returns the more severe of the two states
+func (s ComponentState) Worse(other ComponentState) ComponentState {
+	if other.severity() > s.severity() {
+		return other
+	}
+	return s
+}
+
+// Incident is a period during which a component was not operational. State is
+// the worst state seen during the incident.
+type Incident struct {
+	Component  string
+	State      ComponentState
+	StartedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// StatusTracker remembers when this instance started and which components were
+// recently unhealthy. Components are recorded whenever they are checked, so
+// incidents are only as precise as the polling of the status endpoint.
+type StatusTracker struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	retention time.Duration
+	open      map[string]*Incident
+	incidents []*Incident
+	now       func() time.Time
+}
+
+func NewStatusTracker(retention time.Duration) *StatusTracker {
+	return &StatusTracker{
+		startedAt: time.Now(),
+		retention: retention,
+		open:      make(map[string]*Incident),
+		now:       time.Now,
+	}
+}
+
+func (t *StatusTracker) StartedAt() time.Time {
+	return t.startedAt
+}
+
+func (t *StatusTracker) Uptime() time.Duration {
+	return t.now().Sub(t.startedAt)
+}
+
+// Record opens an incident when a component leaves the operational state and
+// resolves it when the component recovers
+func (t *StatusTracker) Record(component string, state ComponentState) {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	incident, isOpen := t.open[component]
+	switch {
+	case state == StateOperational && isOpen:
+		incident.ResolvedAt = &now
+		delete(t.open, component)
+	case state != StateOperational && isOpen:
+		incident.State = incident.State.Worse(state)
+	case state != StateOperational:
+		incident = &Incident{Component: component, State: state, StartedAt: now}
+		t.open[component] = incident
+		t.incidents = append(t.incidents, incident)
+	}
+
+	t.prune(now)
+}
+
+// Incidents returns ongoing incidents and those resolved within the retention
+// window, newest first
+func (t *StatusTracker) Incidents() []Incident {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(now)
+
+	incidents := make([]Incident, 0, len(t.incidents))
+	for i := len(t.incidents) - 1; i >= 0; i-- {
+		incidents = append(incidents, *t.incidents[i])
+	}
+	return incidents
+}
+
+func (t *StatusTracker) prune(now time.Time) {
+	cutoff := now.Add(-t.retention)
+
+	kept := t.incidents[:0]
+	for _, incident := range t.incidents {
+		if incident.ResolvedAt != nil && incident.ResolvedAt.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, incident)
+	}
+	if len(kept) > maxIncidents {
+		kept = kept[len(kept)-maxIncidents:]
+	}
+	t.incidents = kept
+}
+
+// Check reports the current state of one dependency. Checks must be cheap,
+// since they run on every status request.
+type Check struct {
+	Name  string
+	State func() ComponentState
+}
+
+type ComponentResponse struct {
+	Name   string         `json:"name"`
+	Status ComponentState `json:"status"`
+}
+
+type IncidentResponse struct {
+	Component  string         `json:"component"`
+	Status     ComponentState `json:"status"`
+	StartedAt  string         `json:"started_at"`
+	ResolvedAt *string        `json:"resolved_at,omitempty"`
+}
+
+// StatusResponse is safe to publish: it carries states only, never latencies,
+// error messages or hostnames
+type StatusResponse struct {
+	Status        ComponentState      `json:"status"`
+	StartedAt     string              `json:"started_at"`
+	UptimeSeconds int64               `json:"uptime_seconds"`
+	Components    []ComponentResponse `json:"components"`
+	Incidents     []IncidentResponse  `json:"incidents"`
+	CheckedAt     string              `json:"checked_at"`
+}
+
+// Report runs the checks, records their states and summarizes the result. The
+// overall status is the worst component state.
+func (t *StatusTracker) Report(checks []Check) StatusResponse {
+	response := StatusResponse{
+		Status:        StateOperational,
+		StartedAt:     t.startedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UptimeSeconds: int64(t.Uptime().Seconds()),
+		Components:    make([]ComponentResponse, 0, len(checks)),
+		CheckedAt:     t.now().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for _, check := range checks {
+		state := check.State()
+		t.Record(check.Name, state)
+		response.Status = response.Status.Worse(state)
+		response.Components = append(response.Components, ComponentResponse{Name: check.Name, Status: state})
+	}
+
+	incidents := t.Incidents()
+	response.Incidents = make([]IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		item := IncidentResponse{
+			Component: incident.Component,
+			Status:    incident.State,
+			StartedAt: incident.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if incident.ResolvedAt != nil {
+			resolvedAt := incident.ResolvedAt.Format("2006-01-02T15:04:05Z07:00")
+			item.ResolvedAt = &resolvedAt
+		}
+		response.Incidents = append(response.Incidents, item)
+	}
+
+	return response
+}
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+)
+
+// maxClientLength matches the api_usage.client column
+const maxClientLength = 50
+
+// UsageKey identifies one client calling one route within an hour
+type UsageKey struct {
+	BucketStart time.Time
+	Client      string
+	Method      string
+	Route       string
+}
+
+// UsageCount is what a client did on a route since the last flush
+type UsageCount struct {
+	Requests     int64
+	ClientErrors int64
+	ServerErrors int64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// UsageCollector counts requests per client and route in memory until they are
+// flushed to storage, so recording adds no database work to a request
+type UsageCollector struct {
+	mu     sync.Mutex
+	counts map[UsageKey]*UsageCount
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+func NewUsageCollector(logger *slog.Logger) *UsageCollector {
+	return &UsageCollector{
+		counts: make(map[UsageKey]*UsageCount),
+		now:    time.Now,
+		logger: logger,
+	}
+}
+
+func (u *UsageCollector) Record(client, method, route string, status int, latency time.Duration) {
+	key := UsageKey{
+		BucketStart: u.now().UTC().Truncate(time.Hour),
+		Client:      client,
+		Method:      method,
+		Route:       route,
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	count, ok := u.counts[key]
+	if !ok {
+		count = &UsageCount{}
+		u.counts[key] = count
+	}
+
+	count.Requests++
+	count.TotalLatency += latency
+	count.MaxLatency = max(count.MaxLatency, latency)
+	switch {
+	case status >= 500:
+		count.ServerErrors++
+	case status >= 400:
+		count.ClientErrors++
+	}
+}
+
+// Drain returns everything recorded since the last drain and resets the counts
+func (u *UsageCollector) Drain() map[UsageKey]UsageCount {
+	u.mu.Lock()
+	counts := u.counts
+	u.counts = make(map[UsageKey]*UsageCount)
+	u.mu.Unlock()
+
+	drained := make(map[UsageKey]UsageCount, len(counts))
+	for key, count := range counts {
+		drained[key] = *count
+	}
+	return drained
+}
+
+// Run flushes the collected counts every interval until ctx is cancelled, then
+// flushes once more so a graceful shutdown loses nothing. Counts that fail to
+// flush are dropped; usage analytics are not worth retrying for.
+func (u *UsageCollector) Run(ctx context.Context, interval time.Duration, flush func(map[UsageKey]UsageCount) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			u.flush(flush)
+			return
+		case <-ticker.C:
+			u.flush(flush)
+		}
+	}
+}
+
+func (u *UsageCollector) flush(flush func(map[UsageKey]UsageCount) error) {
+	counts := u.Drain()
+	if len(counts) == 0 {
+		return
+	}
+	if err := flush(counts); err != nil {
+		u.logger.Error("Failed to flush API usage", "routes", len(counts), logging.Err(err))
+	}
+}
+
+// ClientName identifies the calling app. Apps should send X-Client-Name (for
+// example kiosk, mobile or web); otherwise browsers count as web and other
+// callers by the product name in their User-Agent.
+func ClientName(header, userAgent string) string {
+	name := strings.ToLower(strings.TrimSpace(header))
+	if name == "" {
+		userAgent = strings.TrimSpace(userAgent)
+		switch {
+		case userAgent == "":
+			name = "unknown"
+		case strings.HasPrefix(userAgent, "Mozilla/"):
+			name = "web"
+		default:
+			name, _, _ = strings.Cut(userAgent, "/")
+			name, _, _ = strings.Cut(name, " ")
+			name = strings.ToLower(name)
+		}
+	}
+
+	if len(name) > maxClientLength {
+		name = name[:maxClientLength]
+	}
+	return name
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"strings"
 
+	"github.com/carllix/matchaciee-backend/internal/logging"
 	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,19 +13,13 @@ func AuthMiddleware(jwtUtil *utils.JWTUtil) fiber.Handler {
 		// Get Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error":   "Missing authorization header",
-			})
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Missing authorization header")
 		}
 
 		// Check if Bearer token
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error":   "Invalid authorization header format",
-			})
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid authorization header format")
 		}
 
 		tokenString := parts[1]
@@ -32,16 +27,17 @@ func AuthMiddleware(jwtUtil *utils.JWTUtil) fiber.Handler {
 		// Validate token
 		claims, err := jwtUtil.ValidateToken(tokenString)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error":   err.Error(),
-			})
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, err.Error())
 		}
 
 		// Set user info in context
 		c.Locals("userUUID", claims.UserUUID)
 		c.Locals("email", claims.Email)
 		c.Locals("role", claims.Role)
+		if claims.Locale != "" {
+			c.Locals("locale", claims.Locale)
+		}
+		c.SetUserContext(logging.WithAttrs(c.UserContext(), logging.UserUUID(claims.UserUUID)))
 
 		return c.Next()
 	}
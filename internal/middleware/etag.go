@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETagMiddleware tags responses with a weak ETag built from version and answers
+// 304 Not Modified when the client already holds that version. Clients must
+// revalidate each time, but an unchanged version skips the handler entirely.
+func ETagMiddleware(version func() (string, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		current, err := version()
+		if err != nil {
+			// Serve the response untagged rather than failing the request
+			return c.Next()
+		}
+
+		etag := `W/"` + current + `"`
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			c.Set(fiber.HeaderETag, etag)
+			c.Set(fiber.HeaderCacheControl, "no-cache")
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if c.Response().StatusCode() == fiber.StatusOK {
+			c.Set(fiber.HeaderETag, etag)
+		} else {
+			c.Response().Header.Del(fiber.HeaderCacheControl)
+		}
+		return nil
+	}
+}
+
+// etagMatches compares If-None-Match against etag using weak comparison
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseIPAllowlist reads addresses and CIDR ranges such as 103.208.23.6 or
+// 103.127.16.0/23
+func ParseIPAllowlist(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q, expected an IP or CIDR range", entry)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// IPAllowlistMiddleware admits requests whose client IP falls in one of the
+// allowed ranges and rejects the rest with 403. An empty allowlist admits
+// everyone. The IP is the one the connection came from, or the client's
+// address forwarded by a trusted proxy (see TrustProxies).
+func IPAllowlistMiddleware(allowed []netip.Prefix, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if len(allowed) == 0 {
+			return c.Next()
+		}
+
+		addr, err := netip.ParseAddr(c.IP())
+		if err == nil {
+			addr = addr.Unmap()
+			for _, prefix := range allowed {
+				if prefix.Contains(addr) {
+					return c.Next()
+				}
+			}
+		}
+
+		logger.WarnContext(c.UserContext(), "Rejected request from address not in allowlist", "path", c.Path(), "ip", c.IP())
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "Forbidden")
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// KioskKeyHeader carries the API key a kiosk was issued
+const KioskKeyHeader = "X-Kiosk-Key"
+
+// KioskAuthenticator resolves the active kiosk an API key belongs to
+type KioskAuthenticator interface {
+	Authenticate(apiKey string) (*models.Kiosk, error)
+}
+
+// KioskAuthMiddleware admits requests from active kiosks and stores the
+// kiosk in the "kiosk" local
+func KioskAuthMiddleware(authenticator KioskAuthenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiKey := c.Get(KioskKeyHeader)
+		if apiKey == "" {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Missing kiosk key")
+		}
+
+		kiosk, err := authenticator.Authenticate(apiKey)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid kiosk key")
+		}
+
+		c.Locals("kiosk", kiosk)
+		return c.Next()
+	}
+}
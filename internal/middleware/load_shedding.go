@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LoadSheddingConfig struct {
+	MaxDBLatency   time.Duration
+	MaxDBErrorRate float64
+	// MinQueries avoids reacting to a handful of slow queries on a quiet server
+	MinQueries int64
+	RetryAfter time.Duration
+}
+
+// IsOverloaded reports whether the database is past either threshold
+func (cfg LoadSheddingConfig) IsOverloaded(stats metrics.DBStats) bool {
+	if stats.Queries < cfg.MinQueries {
+		return false
+	}
+	return stats.AverageLatency > cfg.MaxDBLatency || stats.ErrorRate > cfg.MaxDBErrorRate
+}
+
+// LoadSheddingMiddleware rejects requests with 503 while the database is
+// overloaded. Attach it only to low-priority routes, such as catalog prefetches
+// and reports, so order and payment traffic keeps the remaining capacity.
+func LoadSheddingMiddleware(monitor *metrics.DBMonitor, cfg LoadSheddingConfig) fiber.Handler {
+	retryAfter := strconv.Itoa(int(cfg.RetryAfter.Seconds()))
+
+	return func(c *fiber.Ctx) error {
+		if !cfg.IsOverloaded(monitor.Stats()) {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderRetryAfter, retryAfter)
+		return utils.ErrorResponse(c, fiber.StatusServiceUnavailable, "Service is busy, please retry later")
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocaleMiddleware picks the message language from Accept-Language. AuthMiddleware
+// later overrides it with the user's preferred language when one is set.
+func LocaleMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if locale := utils.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)); locale != "" {
+			c.Locals("locale", locale)
+		}
+		return c.Next()
+	}
+}
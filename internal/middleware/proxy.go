@@ -0,0 +1,19 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// TrustProxies makes c.IP() report the client address a proxy forwarded in
+// header, for requests that arrive from one of proxies (IPs or CIDR ranges).
+// Requests from anywhere else keep the address of the connection, so clients
+// cannot pick their own IP by sending the header themselves. The first valid
+// address in the header is used, so the proxy must set the header rather
+// than append to what the client sent. Without proxies nothing changes.
+func TrustProxies(config *fiber.Config, header string, proxies []string) {
+	if len(proxies) == 0 {
+		return
+	}
+	config.ProxyHeader = header
+	config.EnableTrustedProxyCheck = true
+	config.TrustedProxies = proxies
+	config.EnableIPValidation = true
+}
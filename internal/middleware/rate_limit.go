@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimitStore counts requests in fixed windows. Hit adds one to the count
+// for key, starting a new window when the last one has ended, and returns the
+// count so far and how long until the window ends. Counting and reading the
+// count must be a single step so concurrent requests are never lost.
+type RateLimitStore interface {
+	Hit(key string, window time.Duration) (int, time.Duration, error)
+}
+
+// RateLimiter builds per-IP rate limits that keep their counters in a store.
+// A shared store, such as Postgres, makes every instance enforce the same
+// limit. Without a store each limit counts in memory, so each instance
+// limits on its own. Behind a proxy, set up the app with TrustProxies so each
+// client is counted by its own address rather than the proxy's.
+type RateLimiter struct {
+	store  RateLimitStore
+	logger *slog.Logger
+}
+
+func NewRateLimiter(store RateLimitStore, logger *slog.Logger) *RateLimiter {
+	if store == nil {
+		store = NewMemoryRateLimitStore()
+	}
+	return &RateLimiter{store: store, logger: logger}
+}
+
+// Limit allows max requests per client IP within window and rejects the rest
+// with 429 and a Retry-After header. The name keeps the counters of each limit
+// apart in the store. A max of zero turns the limit off. Requests go through
+// while the store cannot be reached, so an outage of a shared store does not
+// take the API down with it.
+func (l *RateLimiter) Limit(name string, max int, window time.Duration) fiber.Handler {
+	if max <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	limit := strconv.Itoa(max)
+	return func(c *fiber.Ctx) error {
+		count, resetIn, err := l.store.Hit("ratelimit:"+name+":"+c.IP(), window)
+		if err != nil {
+			l.logger.ErrorContext(c.UserContext(), "Failed to count request for rate limit", "limit", name, logging.Err(err))
+			return c.Next()
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(resetIn.Seconds())))
+		remaining := 0
+		if count < max {
+			remaining = max - count
+		}
+		c.Set("X-RateLimit-Limit", limit)
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Set("X-RateLimit-Reset", reset)
+
+		if count > max {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many requests, try again later")
+		}
+		return c.Next()
+	}
+}
+
+// RateLimitMiddleware allows max requests per client IP within window,
+// counted in memory. Attach it to public routes that could be used to guess
+// data, such as order lookup.
+func RateLimitMiddleware(max int, window time.Duration) fiber.Handler {
+	return NewRateLimiter(nil, slog.Default()).Limit("default", max, window)
+}
+
+// rateLimitSweepInterval is how often the memory store drops ended windows
+const rateLimitSweepInterval = time.Minute
+
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// MemoryRateLimitStore counts requests in this instance only
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	windows   map[string]rateLimitWindow
+	nextSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{windows: make(map[string]rateLimitWindow)}
+}
+
+func (s *MemoryRateLimitStore) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Forget clients whose window ended so the map does not grow without bound
+	if now.After(s.nextSweep) {
+		for k, w := range s.windows {
+			if !now.Before(w.resetAt) {
+				delete(s.windows, k)
+			}
+		}
+		s.nextSweep = now.Add(rateLimitSweepInterval)
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = rateLimitWindow{resetAt: now.Add(window)}
+	}
+	w.count++
+	s.windows[key] = w
+	return w.count, w.resetAt.Sub(now), nil
+}
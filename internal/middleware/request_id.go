@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds an incoming request ID so a client cannot fill
+// the logs through it
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware gives every request an ID. One sent by the client or a
+// proxy in X-Request-ID is kept so a request can be followed across systems;
+// otherwise a new one is generated. The ID is echoed in the response header
+// and made available through utils.RequestID and the request's user context.
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(utils.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Locals("requestID", requestID)
+		c.Set(utils.RequestIDHeader, requestID)
+		c.SetUserContext(utils.WithRequestID(c.UserContext(), requestID))
+		return c.Next()
+	}
+}
+
+// validRequestID accepts IDs of printable ASCII without spaces, which covers
+// UUIDs and the trace IDs common proxies generate
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestLogMiddleware writes a line for every request once it is answered.
+// The line is logged with the request's context, so it carries the request ID
+// and, for signed-in requests, the user AuthMiddleware added. Server errors
+// are logged at error level, everything else at info.
+func RequestLogMiddleware(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// Errors are turned into a response by the error handler after this
+		// middleware returns, so take the status from the error itself
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.Log(c.UserContext(), level, "Request handled",
+			"method", c.Method(),
+			"path", c.Path(),
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+		)
+		return err
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -10,18 +11,12 @@ func RoleMiddleware(allowedRoles ...models.UserRole) fiber.Handler {
 		// Get role from context
 		roleValue := c.Locals("role")
 		if roleValue == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error":   "Access denied: no role found",
-			})
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied: no role found")
 		}
 
 		userRole, ok := roleValue.(string)
 		if !ok {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error":   "Access denied: invalid role type",
-			})
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied: invalid role type")
 		}
 
 		// Check if user role is in allowed roles
@@ -31,9 +26,6 @@ func RoleMiddleware(allowedRoles ...models.UserRole) fiber.Handler {
 			}
 		}
 
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"error":   "Access denied: insufficient permissions",
-		})
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "Access denied: insufficient permissions")
 	}
 }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClientNameHeader lets apps identify themselves in usage analytics
+const ClientNameHeader = "X-Client-Name"
+
+// UsageMiddleware records every request against the calling client and the
+// matched route pattern, so paths with IDs are counted together
+func UsageMiddleware(collector *metrics.UsageCollector) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// The error handler has not written the response yet, so take the
+		// status it will use
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		client := metrics.ClientName(c.Get(ClientNameHeader), c.Get(fiber.HeaderUserAgent))
+		collector.Record(client, c.Method(), c.Route().Path, status, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// APIUsage aggregates the requests one client made to one route within an
+// hour
+type APIUsage struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	BucketStart    time.Time `gorm:"not null" json:"bucket_start"`
+	Client         string    `gorm:"type:varchar(50);not null" json:"client"`
+	Method         string    `gorm:"type:varchar(10);not null" json:"method"`
+	Route          string    `gorm:"type:varchar(255);not null" json:"route"`
+	Requests       int64     `gorm:"not null;default:0" json:"requests"`
+	ClientErrors   int64     `gorm:"not null;default:0" json:"client_errors"`
+	ServerErrors   int64     `gorm:"not null;default:0" json:"server_errors"`
+	TotalLatencyMs int64     `gorm:"not null;default:0" json:"total_latency_ms"`
+	MaxLatencyMs   int64     `gorm:"not null;default:0" json:"max_latency_ms"`
+}
+
+func (APIUsage) TableName() string {
+	return "api_usage"
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CampaignStatus string
+
+const (
+	CampaignStatusScheduled CampaignStatus = "scheduled"
+	CampaignStatusSending   CampaignStatus = "sending"
+	CampaignStatusSent      CampaignStatus = "sent"
+	CampaignStatusCancelled CampaignStatus = "cancelled"
+)
+
+type CampaignDeliveryStatus string
+
+const (
+	CampaignDeliveryPending CampaignDeliveryStatus = "pending"
+	CampaignDeliverySending CampaignDeliveryStatus = "sending"
+	CampaignDeliverySent    CampaignDeliveryStatus = "sent"
+	CampaignDeliveryFailed  CampaignDeliveryStatus = "failed"
+)
+
+// Campaign is a message an admin sends to a segment of members. Its members
+// are picked when it starts sending, so a scheduled campaign also reaches
+// those who join in the meantime.
+type Campaign struct {
+	ID           uint           `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID         uuid.UUID      `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Title        string         `gorm:"type:varchar(100);not null" json:"title"`
+	Message      string         `gorm:"type:text;not null" json:"message"`
+	Segment      string         `gorm:"type:varchar(20);not null" json:"segment"`
+	InactiveDays int            `gorm:"not null;default:0" json:"inactive_days"`
+	Status       CampaignStatus `gorm:"type:varchar(20);not null;default:'scheduled';index" json:"status"`
+	SendAt       time.Time      `gorm:"not null" json:"send_at"`
+	CreatedBy    *uint          `json:"-"`
+	StartedAt    *time.Time     `json:"started_at,omitempty"`
+	FinishedAt   *time.Time     `json:"finished_at,omitempty"`
+	CancelledAt  *time.Time     `json:"cancelled_at,omitempty"`
+	Creator      *User          `gorm:"foreignKey:CreatedBy;references:ID;constraint:OnDelete:SET NULL" json:"creator,omitempty"`
+	CreatedAt    time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Campaign) TableName() string {
+	return "campaigns"
+}
+
+// CampaignDelivery is the campaign's message to one member. An instance
+// claims it by moving it to sending before the message goes out. Channel is
+// set once it is sent, on the channel the member preferred at the time.
+type CampaignDelivery struct {
+	ID         uint                   `gorm:"primaryKey;autoIncrement" json:"-"`
+	CampaignID uint                   `gorm:"not null;uniqueIndex:idx_campaign_deliveries_campaign_user" json:"-"`
+	UserID     uint                   `gorm:"not null;uniqueIndex:idx_campaign_deliveries_campaign_user" json:"-"`
+	Status     CampaignDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Channel    *string                `gorm:"type:varchar(20)" json:"channel,omitempty"`
+	Error      *string                `gorm:"type:varchar(255)" json:"error,omitempty"`
+	ClaimedAt  *time.Time             `json:"-"`
+	SentAt     *time.Time             `json:"sent_at,omitempty"`
+	Campaign   *Campaign              `gorm:"foreignKey:CampaignID;references:ID;constraint:OnDelete:CASCADE" json:"campaign,omitempty"`
+	User       *User                  `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	CreatedAt  time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (CampaignDelivery) TableName() string {
+	return "campaign_deliveries"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Cart collects items before checkout. It belongs to either a member
+// (UserID) or a kiosk session (SessionID).
+type Cart struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID              uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	UserID            *uint      `gorm:"index" json:"-"`
+	SessionID         *string    `gorm:"type:varchar(64);index" json:"-"`
+	CheckoutStartedAt *time.Time `json:"-"`
+	Items             []CartItem `gorm:"foreignKey:CartID;references:ID" json:"items,omitempty"`
+	User              *User      `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt         time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Cart) TableName() string {
+	return "carts"
+}
+
+type CartItem struct {
+	ID             uint           `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID           uuid.UUID      `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	CartID         uint           `gorm:"not null;index" json:"-"`
+	ProductID      uint           `gorm:"not null" json:"-"`
+	Quantity       int            `gorm:"not null" json:"quantity"`
+	Customizations datatypes.JSON `gorm:"type:jsonb" json:"customizations,omitempty"`
+	Notes          *string        `gorm:"type:text" json:"notes,omitempty"`
+	Cart           *Cart          `gorm:"foreignKey:CartID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	Product        *Product       `gorm:"foreignKey:ProductID;references:ID;constraint:OnDelete:CASCADE" json:"product,omitempty"`
+	CreatedAt      time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (CartItem) TableName() string {
+	return "cart_items"
+}
@@ -7,18 +7,24 @@ import (
 )
 
 type Category struct {
-	ID           uint      `gorm:"primaryKey;autoIncrement" json:"-"`
-	UUID         uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
-	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
-	Slug         string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"slug"`
-	Description  *string   `gorm:"type:text" json:"description,omitempty"`
-	DisplayOrder int       `gorm:"default:0" json:"display_order"`
-	IsActive     bool      `gorm:"default:true" json:"is_active"`
-	ImageURL     *string   `gorm:"type:varchar(255)" json:"image_url,omitempty"`
-	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID           uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID         uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	ParentID     *uint      `gorm:"index" json:"-"`
+	Name         string     `gorm:"type:varchar(100);not null" json:"name"`
+	Slug         string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"slug"`
+	Description  *string    `gorm:"type:text" json:"description,omitempty"`
+	DisplayOrder int        `gorm:"default:0" json:"display_order"`
+	IsActive     bool       `gorm:"default:true" json:"is_active"`
+	ImageURL     *string    `gorm:"type:varchar(255)" json:"image_url,omitempty"`
+	DeletedAt    *time.Time `gorm:"index" json:"deleted_at,omitempty"`
+	Parent       *Category  `gorm:"foreignKey:ParentID;references:ID;constraint:OnDelete:SET NULL" json:"parent,omitempty"`
+	CreatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
+// MaxCategoryDepth limits nesting, e.g. Drinks > Matcha > Iced
+const MaxCategoryDepth = 3
+
 func (Category) TableName() string {
 	return "categories"
 }
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailySummary is the snapshot taken when a business day is closed. Money
+// totals cover the paid orders of the day; later changes to flagged orders do
+// not alter it. Payments and refunds are those settled or issued during the
+// day, whichever day their order belongs to.
+type DailySummary struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID            uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	BusinessDate    time.Time `gorm:"type:date;uniqueIndex;not null" json:"business_date"`
+	PeriodStart     time.Time `gorm:"not null" json:"period_start"`
+	PeriodEnd       time.Time `gorm:"not null" json:"period_end"`
+	OrderCount      int       `gorm:"not null;default:0" json:"order_count"`
+	CompletedCount  int       `gorm:"not null;default:0" json:"completed_count"`
+	CancelledCount  int       `gorm:"not null;default:0" json:"cancelled_count"`
+	IncompleteCount int       `gorm:"not null;default:0" json:"incomplete_count"`
+	PaidCount       int       `gorm:"not null;default:0" json:"paid_count"`
+	Subtotal        float64   `gorm:"type:decimal(12,2);not null;default:0" json:"subtotal"`
+	Discount        float64   `gorm:"type:decimal(12,2);not null;default:0" json:"discount"`
+	ServiceCharge   float64   `gorm:"type:decimal(12,2);not null;default:0" json:"service_charge"`
+	Tax             float64   `gorm:"type:decimal(12,2);not null;default:0" json:"tax"`
+	TipAmount       float64   `gorm:"type:decimal(12,2);not null;default:0" json:"tip_amount"`
+	Total           float64   `gorm:"type:decimal(12,2);not null;default:0" json:"total"`
+	CashAmount      float64   `gorm:"type:decimal(12,2);not null;default:0" json:"cash_amount"`
+	GatewayAmount   float64   `gorm:"type:decimal(12,2);not null;default:0" json:"gateway_amount"`
+	StoredValue     float64   `gorm:"column:stored_value_amount;type:decimal(12,2);not null;default:0" json:"stored_value_amount"`
+	RefundCount     int       `gorm:"not null;default:0" json:"refund_count"`
+	RefundAmount    float64   `gorm:"type:decimal(12,2);not null;default:0" json:"refund_amount"`
+	CancelledAmount float64   `gorm:"type:decimal(12,2);not null;default:0" json:"cancelled_amount"`
+	ClosedByID      *uint     `gorm:"column:closed_by" json:"-"`
+	ClosedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"closed_at"`
+	ClosedBy        *User     `gorm:"foreignKey:ClosedByID;references:ID;constraint:OnDelete:SET NULL" json:"closed_by,omitempty"`
+}
+
+func (DailySummary) TableName() string {
+	return "daily_summaries"
+}
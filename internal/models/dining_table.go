@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiningTable is a table in the store. Its QR code carries the token, so
+// customers who scan it order to that table.
+type DiningTable struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID      uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Number    string    `gorm:"type:varchar(20);uniqueIndex;not null" json:"number"`
+	Token     string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	IsActive  bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (DiningTable) TableName() string {
+	return "dining_tables"
+}
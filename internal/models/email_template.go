@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type EmailTemplateKey string
+
+const (
+	EmailTemplateOrderConfirmation EmailTemplateKey = "order_confirmation"
+	EmailTemplateOrderReady        EmailTemplateKey = "order_ready"
+	EmailTemplatePasswordReset     EmailTemplateKey = "password_reset"
+	EmailTemplateLoginCode         EmailTemplateKey = "login_code"
+	EmailTemplateOrderReceipt      EmailTemplateKey = "order_receipt"
+	EmailTemplatePointsExpiring    EmailTemplateKey = "points_expiring"
+)
+
+// EmailTemplateVariables lists the placeholders each template may use
+var EmailTemplateVariables = map[EmailTemplateKey][]string{
+	EmailTemplateOrderConfirmation: {"customer_name", "order_number", "total", "order_url"},
+	EmailTemplateOrderReady:        {"customer_name", "order_number"},
+	EmailTemplatePasswordReset:     {"name", "reset_url", "expires_in"},
+	EmailTemplateLoginCode:         {"name", "code", "expires_in"},
+	EmailTemplateOrderReceipt:      {"customer_name", "order_number", "total", "receipt"},
+	EmailTemplatePointsExpiring:    {"name", "points", "value", "expires_on"},
+}
+
+func (k EmailTemplateKey) IsValid() bool {
+	_, ok := EmailTemplateVariables[k]
+	return ok
+}
+
+type EmailTemplate struct {
+	ID        uint             `gorm:"primaryKey;autoIncrement" json:"-"`
+	Key       EmailTemplateKey `gorm:"type:varchar(50);not null" json:"key"`
+	Locale    string           `gorm:"type:varchar(5);not null;default:'en'" json:"locale"`
+	Version   int              `gorm:"not null" json:"version"`
+	Subject   string           `gorm:"type:varchar(255);not null" json:"subject"`
+	HTMLBody  string           `gorm:"type:text;not null" json:"html_body"`
+	TextBody  string           `gorm:"type:text;not null" json:"text_body"`
+	IsActive  bool             `gorm:"not null;default:false" json:"is_active"`
+	CreatedAt time.Time        `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
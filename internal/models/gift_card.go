@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GiftCardStatus string
+
+const (
+	GiftCardStatusPending GiftCardStatus = "pending"
+	GiftCardStatusActive  GiftCardStatus = "active"
+	GiftCardStatusVoided  GiftCardStatus = "voided"
+)
+
+// GiftCard is a code holding a balance that can be spent on orders. A card
+// bought online stays pending until the gateway settles the purchase; one
+// issued by staff is active straight away.
+type GiftCard struct {
+	ID                uint               `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID              uuid.UUID          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Code              string             `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	InitialAmount     float64            `gorm:"type:decimal(10,2);not null" json:"initial_amount"`
+	Balance           float64            `gorm:"type:decimal(10,2);not null" json:"balance"`
+	Status            GiftCardStatus     `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	RecipientName     *string            `gorm:"type:varchar(255)" json:"recipient_name,omitempty"`
+	RecipientEmail    *string            `gorm:"type:varchar(255)" json:"recipient_email,omitempty"`
+	PurchasedBy       *uint              `json:"-"`
+	IssuedBy          *uint              `json:"-"`
+	Reference         *string            `gorm:"type:varchar(100);uniqueIndex" json:"reference,omitempty"`
+	Method            *PaymentMethod     `gorm:"type:varchar(20)" json:"method,omitempty"`
+	TransactionStatus *TransactionStatus `gorm:"type:varchar(50)" json:"transaction_status,omitempty"`
+	PaymentType       *string            `gorm:"type:varchar(50)" json:"payment_type,omitempty"`
+	TransactionID     *string            `gorm:"type:varchar(100)" json:"transaction_id,omitempty"`
+	CheckoutURL       *string            `gorm:"type:text" json:"-"`
+	ActivatedAt       *time.Time         `json:"activated_at,omitempty"`
+	VoidedAt          *time.Time         `json:"voided_at,omitempty"`
+	VoidedBy          *uint              `json:"-"`
+	VoidReason        *string            `gorm:"type:varchar(255)" json:"void_reason,omitempty"`
+	CreatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (GiftCard) TableName() string {
+	return "gift_cards"
+}
+
+// GiftCardRedemption is gift card balance applied to an order. It is released
+// back onto the card when the order is cancelled or refunded.
+type GiftCardRedemption struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID       uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	GiftCardID uint       `gorm:"not null;index" json:"-"`
+	OrderID    uint       `gorm:"not null;index" json:"-"`
+	Amount     float64    `gorm:"type:decimal(10,2);not null" json:"amount"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	GiftCard   *GiftCard  `gorm:"foreignKey:GiftCardID;references:ID;constraint:OnDelete:CASCADE" json:"gift_card,omitempty"`
+	Order      *Order     `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
+	CreatedAt  time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (GiftCardRedemption) TableName() string {
+	return "gift_card_redemptions"
+}
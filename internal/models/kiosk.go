@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kiosk is a self-service ordering terminal in the store. It authenticates
+// with an API key, of which only the hash is stored.
+type Kiosk struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID       uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Name       string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	APIKeyHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	IsActive   bool       `gorm:"not null;default:true" json:"is_active"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	LastIP     *string    `gorm:"type:varchar(45)" json:"last_ip,omitempty"`
+	AppVersion *string    `gorm:"type:varchar(50)" json:"app_version,omitempty"`
+	CreatedAt  time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Kiosk) TableName() string {
+	return "kiosks"
+}
+
+// IsOnlineAt reports whether the kiosk sent a heartbeat within offlineAfter
+// before t
+func (k *Kiosk) IsOnlineAt(t time.Time, offlineAfter time.Duration) bool {
+	return k.LastSeenAt != nil && t.Sub(*k.LastSeenAt) < offlineAfter
+}
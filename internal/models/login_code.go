@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type LoginChannel string
+
+const (
+	LoginChannelEmail    LoginChannel = "email"
+	LoginChannelWhatsApp LoginChannel = "whatsapp"
+)
+
+// LoginCode is a one-time code for password-less login. Only a hash of the
+// code is stored.
+type LoginCode struct {
+	ID         uint         `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID     uint         `gorm:"not null" json:"-"`
+	Channel    LoginChannel `gorm:"type:varchar(20);not null" json:"channel"`
+	CodeHash   string       `gorm:"type:varchar(64);not null" json:"-"`
+	Attempts   int          `gorm:"not null;default:0" json:"attempts"`
+	ExpiresAt  time.Time    `gorm:"not null" json:"expires_at"`
+	ConsumedAt *time.Time   `json:"consumed_at,omitempty"`
+	CreatedAt  time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (LoginCode) TableName() string {
+	return "login_codes"
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PointTransactionType string
+
+const (
+	PointTransactionEarn   PointTransactionType = "earn"
+	PointTransactionRedeem PointTransactionType = "redeem"
+	PointTransactionRefund PointTransactionType = "refund"
+	PointTransactionExpire PointTransactionType = "expire"
+)
+
+// PointTransaction is one entry in a member's loyalty points ledger. Points
+// are positive when earned or given back and negative when spent or expired;
+// BalanceAfter is the balance once the entry was posted, so the latest entry
+// holds the current balance. ExpiryWarnedAt is when the member was warned
+// that the points of an earn or refund entry are about to expire.
+type PointTransaction struct {
+	ID             uint                 `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID           uuid.UUID            `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	UserID         uint                 `gorm:"not null;index" json:"-"`
+	Type           PointTransactionType `gorm:"type:varchar(20);not null" json:"type"`
+	Points         int                  `gorm:"not null" json:"points"`
+	BalanceAfter   int                  `gorm:"not null" json:"balance_after"`
+	OrderID        *uint                `json:"-"`
+	Note           *string              `gorm:"type:varchar(255)" json:"note,omitempty"`
+	ExpiryWarnedAt *time.Time           `json:"-"`
+	Order          *Order               `gorm:"foreignKey:OrderID;references:ID" json:"order,omitempty"`
+	CreatedAt      time.Time            `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (PointTransaction) TableName() string {
+	return "point_transactions"
+}
+
+// StampEntry is one entry in a member's stamp card. Stamps are positive when
+// earned on a completed order and negative when a full card is swapped for a
+// reward voucher; BalanceAfter works as on PointTransaction.
+type StampEntry struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID       uint      `gorm:"not null;index" json:"-"`
+	Stamps       int       `gorm:"not null" json:"stamps"`
+	BalanceAfter int       `gorm:"not null" json:"balance_after"`
+	OrderID      *uint     `gorm:"uniqueIndex" json:"-"`
+	VoucherID    *uint     `json:"-"`
+	Order        *Order    `gorm:"foreignKey:OrderID;references:ID" json:"order,omitempty"`
+	Voucher      *Voucher  `gorm:"foreignKey:VoucherID;references:ID" json:"voucher,omitempty"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (StampEntry) TableName() string {
+	return "stamp_entries"
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MediaFile is an image uploaded to the upload directory. Files no product or
+// category points to are removed by the media cleanup job.
+type MediaFile struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID         uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	FileName     string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"-"`
+	OriginalName string    `gorm:"type:varchar(255);not null" json:"original_name"`
+	ContentType  string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	SizeBytes    int64     `gorm:"not null" json:"size_bytes"`
+	URL          string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"url"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (MediaFile) TableName() string {
+	return "media_files"
+}
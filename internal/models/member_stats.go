@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// MemberStats rolls up a member's completed orders so segment exports read
+// one indexed row per member instead of scanning every order. It is rebuilt
+// periodically, so it trails the orders by up to the refresh interval.
+// FavoriteCategoryID is the category the member ordered the most items from.
+type MemberStats struct {
+	UserID             uint      `gorm:"primaryKey" json:"-"`
+	OrderCount         int       `gorm:"not null" json:"order_count"`
+	TotalSpend         float64   `gorm:"type:decimal(12,2);not null" json:"total_spend"`
+	FirstOrderAt       time.Time `gorm:"not null" json:"first_order_at"`
+	LastOrderAt        time.Time `gorm:"not null;index" json:"last_order_at"`
+	FavoriteCategoryID *uint     `gorm:"index" json:"-"`
+	RefreshedAt        time.Time `gorm:"not null" json:"refreshed_at"`
+}
+
+func (MemberStats) TableName() string {
+	return "member_stats"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,42 +10,202 @@ import (
 type OrderStatus string
 
 const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusPreparing OrderStatus = "preparing"
-	OrderStatusReady     OrderStatus = "ready"
-	OrderStatusCompleted OrderStatus = "completed"
-	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusScheduled   OrderStatus = "scheduled"
+	OrderStatusPending     OrderStatus = "pending"
+	OrderStatusDepositPaid OrderStatus = "deposit_paid"
+	OrderStatusPreparing   OrderStatus = "preparing"
+	OrderStatusReady       OrderStatus = "ready"
+	OrderStatusCompleted   OrderStatus = "completed"
+	OrderStatusCancelled   OrderStatus = "cancelled"
 )
 
 type OrderSource string
 
 const (
-	OrderSourceGuest  OrderSource = "guest"
-	OrderSourceMember OrderSource = "member"
-	OrderSourceKiosk  OrderSource = "kiosk"
+	OrderSourceGuest    OrderSource = "guest"
+	OrderSourceMember   OrderSource = "member"
+	OrderSourceKiosk    OrderSource = "kiosk"
+	OrderSourcePartner  OrderSource = "partner"
+	OrderSourcePhone    OrderSource = "phone"
+	OrderSourceCatering OrderSource = "catering"
 )
 
+// OrderSources lists every channel in reporting order
+var OrderSources = []OrderSource{
+	OrderSourceGuest,
+	OrderSourceMember,
+	OrderSourceKiosk,
+	OrderSourcePartner,
+	OrderSourcePhone,
+	OrderSourceCatering,
+}
+
+func (s OrderSource) IsValid() bool {
+	for _, source := range OrderSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPriceAdjustable reports whether the source may carry its own pricing.
+// Guest and member orders come through our own app and always pay list price.
+func (s OrderSource) IsPriceAdjustable() bool {
+	return s.IsValid() && s != OrderSourceGuest && s != OrderSourceMember
+}
+
+type OrderType string
+
+const (
+	OrderTypeDineIn   OrderType = "dine_in"
+	OrderTypeTakeaway OrderType = "takeaway"
+	OrderTypeDelivery OrderType = "delivery"
+)
+
+// DefaultOrderType is used when an order does not say how it is served
+const DefaultOrderType = OrderTypeTakeaway
+
+// OrderTypes lists every order type in reporting order
+var OrderTypes = []OrderType{
+	OrderTypeDineIn,
+	OrderTypeTakeaway,
+	OrderTypeDelivery,
+}
+
+func (t OrderType) IsValid() bool {
+	for _, orderType := range OrderTypes {
+		if t == orderType {
+			return true
+		}
+	}
+	return false
+}
+
+// Priority decides where an order sits in the kitchen queue
+type Priority string
+
+const (
+	PriorityNormal Priority = "normal"
+	PriorityRush   Priority = "rush"
+)
+
+func (p Priority) IsValid() bool {
+	return p == PriorityNormal || p == PriorityRush
+}
+
 type Order struct {
-	ID           uint        `gorm:"primaryKey;autoIncrement" json:"-"`
-	UUID         uuid.UUID   `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
-	OrderNumber  string      `gorm:"type:varchar(20);uniqueIndex;not null" json:"order_number"`
-	UserID       *uint       `gorm:"index" json:"-"`
-	CustomerName string      `gorm:"type:varchar(255);not null" json:"customer_name"`
-	Status       OrderStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
-	OrderSource  OrderSource `gorm:"type:varchar(20);not null;index" json:"order_source"`
-	Subtotal     float64     `gorm:"type:decimal(10,2);not null" json:"subtotal"`
-	Tax          float64     `gorm:"type:decimal(10,2);default:0" json:"tax"`
-	Total        float64     `gorm:"type:decimal(10,2);not null" json:"total"`
-	QueueNumber  *int        `gorm:"type:int" json:"queue_number,omitempty"`
-	Notes        *string     `gorm:"type:text" json:"notes,omitempty"`
-	CompletedAt  *time.Time  `json:"completed_at,omitempty"`
-	User         *User       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL" json:"user,omitempty"`
-	Items        []OrderItem `gorm:"foreignKey:OrderID;references:ID" json:"items,omitempty"`
-	Payments     []Payment   `gorm:"foreignKey:OrderID;references:ID" json:"payments,omitempty"`
-	CreatedAt    time.Time   `gorm:"default:CURRENT_TIMESTAMP;index" json:"created_at"`
-	UpdatedAt    time.Time   `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ID                     uint             `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID                   uuid.UUID        `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	OrderNumber            string           `gorm:"type:varchar(20);uniqueIndex;not null" json:"order_number"`
+	UserID                 *uint            `gorm:"index" json:"-"`
+	CustomerName           string           `gorm:"type:varchar(255);not null" json:"customer_name"`
+	CustomerEmail          *string          `gorm:"type:varchar(255)" json:"customer_email,omitempty"`
+	CustomerPhone          *string          `gorm:"type:varchar(20)" json:"customer_phone,omitempty"`
+	Status                 OrderStatus      `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	OrderSource            OrderSource      `gorm:"type:varchar(20);not null;index" json:"order_source"`
+	OrderType              OrderType        `gorm:"type:varchar(20);not null;default:'takeaway';index" json:"order_type"`
+	Priority               Priority         `gorm:"type:varchar(20);not null;default:'normal'" json:"priority"`
+	Subtotal               float64          `gorm:"type:decimal(10,2);not null" json:"subtotal"`
+	ServiceCharge          float64          `gorm:"type:decimal(10,2);not null;default:0" json:"service_charge"`
+	Tax                    float64          `gorm:"type:decimal(10,2);default:0" json:"tax"`
+	TaxRate                float64          `gorm:"type:decimal(6,4);not null;default:0" json:"tax_rate"`
+	Total                  float64          `gorm:"type:decimal(10,2);not null" json:"total"`
+	Currency               string           `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	PriceAdjustmentPercent float64          `gorm:"type:decimal(5,2);not null;default:0" json:"price_adjustment_percent"`
+	SourceFee              float64          `gorm:"type:decimal(10,2);not null;default:0" json:"source_fee"`
+	DeliveryFee            float64          `gorm:"type:decimal(10,2);not null;default:0" json:"delivery_fee"`
+	DeliveryDiscount       float64          `gorm:"type:decimal(10,2);not null;default:0" json:"delivery_discount"`
+	PromotionID            *uint            `gorm:"index" json:"-"`
+	PromoCode              *string          `gorm:"type:varchar(50);index" json:"promo_code,omitempty"`
+	Discount               float64          `gorm:"type:decimal(10,2);not null;default:0" json:"discount"`
+	TipAmount              float64          `gorm:"type:decimal(10,2);not null;default:0" json:"tip_amount"`
+	GiftCardAmount         float64          `gorm:"type:decimal(10,2);not null;default:0" json:"gift_card_amount"`
+	PointsRedeemed         int              `gorm:"not null;default:0" json:"points_redeemed"`
+	PointsAmount           float64          `gorm:"type:decimal(10,2);not null;default:0" json:"points_amount"`
+	DepositAmount          float64          `gorm:"type:decimal(10,2);not null;default:0" json:"deposit_amount"`
+	DepositPaidAt          *time.Time       `json:"deposit_paid_at,omitempty"`
+	TableID                *uint            `gorm:"index" json:"-"`
+	TableNumber            *string          `gorm:"type:varchar(20)" json:"table_number,omitempty"`
+	KioskID                *uint            `gorm:"index" json:"-"`
+	QueueNumber            *int             `gorm:"type:int" json:"queue_number,omitempty"`
+	Notes                  *string          `gorm:"type:text" json:"notes,omitempty"`
+	ScheduledFor           *time.Time       `json:"scheduled_for,omitempty"`
+	ReleasedAt             *time.Time       `json:"released_at,omitempty"`
+	PaymentExpiresAt       *time.Time       `gorm:"index" json:"payment_expires_at,omitempty"`
+	ShareToken             *string          `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	ShareTokenExpiresAt    *time.Time       `json:"-"`
+	PreparingAt            *time.Time       `json:"preparing_at,omitempty"`
+	ReadyAt                *time.Time       `json:"ready_at,omitempty"`
+	CompletedAt            *time.Time       `json:"completed_at,omitempty"`
+	ConfirmationSentAt     *time.Time       `json:"confirmation_sent_at,omitempty"`
+	ReceiptSentAt          *time.Time       `json:"receipt_sent_at,omitempty"`
+	ReadyNotifiedAt        *time.Time       `json:"ready_notified_at,omitempty"`
+	AssignedToID           *uint            `gorm:"column:assigned_to;index" json:"-"`
+	AssignedAt             *time.Time       `json:"assigned_at,omitempty"`
+	ClosedAt               *time.Time       `json:"closed_at,omitempty"`
+	FlaggedAt              *time.Time       `json:"flagged_at,omitempty"`
+	User                   *User            `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL" json:"user,omitempty"`
+	AssignedTo             *User            `gorm:"foreignKey:AssignedToID;references:ID;constraint:OnDelete:SET NULL" json:"assigned_to,omitempty"`
+	Items                  []OrderItem      `gorm:"foreignKey:OrderID;references:ID" json:"items,omitempty"`
+	Payments               []Payment        `gorm:"foreignKey:OrderID;references:ID" json:"payments,omitempty"`
+	Promotions             []OrderPromotion `gorm:"foreignKey:OrderID;references:ID" json:"promotions,omitempty"`
+	CreatedAt              time.Time        `gorm:"default:CURRENT_TIMESTAMP;index" json:"created_at"`
+	UpdatedAt              time.Time        `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (Order) TableName() string {
 	return "orders"
 }
+
+// AmountDue is what the customer is charged: the total plus any tip, less
+// what was paid with gift cards and points
+func (o *Order) AmountDue() float64 {
+	return o.Total + o.TipAmount - o.GiftCardAmount - o.PointsAmount
+}
+
+// PaymentDue is what the next payment collects. An order with a deposit pays
+// the deposit first and the rest of the amount due once the deposit is in.
+func (o *Order) PaymentDue() float64 {
+	if o.Status == OrderStatusDepositPaid {
+		return o.AmountDue() - o.DepositAmount
+	}
+	if o.DepositAmount > 0 {
+		return math.Min(o.DepositAmount, o.AmountDue())
+	}
+	return o.AmountDue()
+}
+
+// AwaitsPayment reports whether the order still has a payment to collect
+// before it can be prepared
+func (o *Order) AwaitsPayment() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusDepositPaid
+}
+
+// StatusAfterPayment is the status the order moves to once a payment of
+// amount settles: deposit_paid when it covered only the deposit, preparing
+// otherwise
+func (o *Order) StatusAfterPayment(amount float64) OrderStatus {
+	if o.Status == OrderStatusPending && o.DepositAmount > 0 && amount < o.AmountDue() {
+		return OrderStatusDepositPaid
+	}
+	return OrderStatusPreparing
+}
+
+func (o *Order) IsPaymentExpired() bool {
+	return o.PaymentExpiresAt != nil && time.Now().After(*o.PaymentExpiresAt)
+}
+
+// QueuedAt is when the order joined the queue: when it was placed, or for a
+// pre-order when it was released
+func (o *Order) QueuedAt() time.Time {
+	if o.ReleasedAt != nil {
+		return *o.ReleasedAt
+	}
+	return o.CreatedAt
+}
+
+// AwaitsPickup reports whether the order is paid for and not yet handed over
+func (o *Order) AwaitsPickup() bool {
+	return o.Status == OrderStatusPreparing || o.Status == OrderStatusReady
+}
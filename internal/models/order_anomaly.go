@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AnomalyKind string
+
+const (
+	// AnomalyUnlinkedItem is an item whose product was purged and whose
+	// name snapshot matches no remaining product
+	AnomalyUnlinkedItem AnomalyKind = "unlinked_item"
+	// AnomalyItemSubtotalMismatch is an item whose subtotal is not unit
+	// price times quantity
+	AnomalyItemSubtotalMismatch AnomalyKind = "item_subtotal_mismatch"
+	// AnomalySubtotalMismatch is an order whose subtotal is not the sum of
+	// its item subtotals
+	AnomalySubtotalMismatch AnomalyKind = "subtotal_mismatch"
+	// AnomalyTotalMismatch is an order whose total is not subtotal plus tax
+	// plus source fee
+	AnomalyTotalMismatch AnomalyKind = "total_mismatch"
+)
+
+type OrderAnomaly struct {
+	ID          uint        `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID        uuid.UUID   `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	OrderID     uint        `gorm:"not null;index" json:"-"`
+	OrderItemID *uint       `json:"-"`
+	Kind        AnomalyKind `gorm:"type:varchar(30);not null" json:"kind"`
+	Detail      string      `gorm:"type:text;not null" json:"detail"`
+	DetectedAt  time.Time   `gorm:"default:CURRENT_TIMESTAMP" json:"detected_at"`
+	ResolvedAt  *time.Time  `json:"resolved_at,omitempty"`
+	Order       *Order      `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
+}
+
+func (OrderAnomaly) TableName() string {
+	return "order_anomalies"
+}
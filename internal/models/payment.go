@@ -16,6 +16,8 @@ const (
 	TransactionStatusCancel     TransactionStatus = "cancel"
 	TransactionStatusDeny       TransactionStatus = "deny"
 	TransactionStatusRefund     TransactionStatus = "refund"
+
+	TransactionStatusPartialRefund TransactionStatus = "partial_refund"
 )
 
 type FraudStatus string
@@ -26,25 +28,59 @@ const (
 	FraudStatusDeny      FraudStatus = "deny"
 )
 
+type PaymentMethod string
+
+const (
+	PaymentMethodMidtrans PaymentMethod = "midtrans"
+	PaymentMethodStripe   PaymentMethod = "stripe"
+	PaymentMethodXendit   PaymentMethod = "xendit"
+	PaymentMethodCash     PaymentMethod = "cash"
+	PaymentMethodWallet   PaymentMethod = "wallet"
+	PaymentMethodGiftCard PaymentMethod = "gift_card"
+	PaymentMethodPoints   PaymentMethod = "points"
+)
+
+// CheckoutChannel is how the customer pays a gateway payment
+type CheckoutChannel string
+
+const (
+	CheckoutChannelHosted CheckoutChannel = "hosted"
+	CheckoutChannelQRIS   CheckoutChannel = "qris"
+)
+
 type Payment struct {
 	ID                uint               `gorm:"primaryKey;autoIncrement" json:"-"`
 	UUID              uuid.UUID          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
 	OrderID           uint               `gorm:"not null;index" json:"-"`
 	MidtransOrderID   string             `gorm:"type:varchar(100);uniqueIndex;not null" json:"midtrans_order_id"`
+	Method            PaymentMethod      `gorm:"type:varchar(20);not null;default:'midtrans';index" json:"method"`
 	GrossAmount       float64            `gorm:"type:decimal(10,2);not null" json:"gross_amount"`
 	PaymentType       *string            `gorm:"type:varchar(50)" json:"payment_type,omitempty"`
 	TransactionID     *string            `gorm:"type:varchar(100)" json:"transaction_id,omitempty"`
 	TransactionStatus *TransactionStatus `gorm:"type:varchar(50);index" json:"transaction_status,omitempty"`
 	TransactionTime   *time.Time         `json:"transaction_time,omitempty"`
 	SettlementTime    *time.Time         `json:"settlement_time,omitempty"`
+	ExpiresAt         *time.Time         `json:"expires_at,omitempty"`
+	CheckoutChannel   *CheckoutChannel   `gorm:"type:varchar(20)" json:"checkout_channel,omitempty"`
+	CheckoutToken     *string            `gorm:"type:text" json:"-"`
+	CheckoutURL       *string            `gorm:"type:text" json:"-"`
 	FraudStatus       *FraudStatus       `gorm:"type:varchar(50)" json:"fraud_status,omitempty"`
 	StatusMessage     *string            `gorm:"type:text" json:"status_message,omitempty"`
 	PaymentMetadata   datatypes.JSON     `gorm:"type:jsonb" json:"payment_metadata,omitempty"`
+	CashTendered      *float64           `gorm:"type:decimal(10,2)" json:"cash_tendered,omitempty"`
+	ChangeGiven       *float64           `gorm:"type:decimal(10,2)" json:"change_given,omitempty"`
+	GatewayFee        *float64           `gorm:"type:decimal(10,2)" json:"gateway_fee,omitempty"`
+	RecordedBy        *uint              `json:"-"`
 	Order             *Order             `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
 	CreatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
+// IsAwaitingPayment reports whether the gateway may still take payment
+func (p *Payment) IsAwaitingPayment() bool {
+	return p.TransactionStatus == nil || *p.TransactionStatus == TransactionStatusPending
+}
+
 func (Payment) TableName() string {
 	return "payments"
 }
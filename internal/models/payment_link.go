@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentLink opens the hosted payment page of an order from a URL staff can
+// send to the customer. Only a hash of the token in the URL is stored. A link
+// can be opened again until it expires or the order no longer awaits payment.
+type PaymentLink struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID      uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	OrderID   uint      `gorm:"not null;index" json:"-"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	CreatedBy *uint     `json:"-"`
+	Order     *Order    `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (PaymentLink) TableName() string {
+	return "payment_links"
+}
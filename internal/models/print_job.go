@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PrintJobStatus string
+
+const (
+	PrintJobStatusQueued   PrintJobStatus = "queued"
+	PrintJobStatusPrinting PrintJobStatus = "printing"
+	PrintJobStatusPrinted  PrintJobStatus = "printed"
+	PrintJobStatusFailed   PrintJobStatus = "failed"
+)
+
+// PrintJob is a rendered ticket waiting for the print agent of its station.
+// Agents pull jobs and acknowledge them once printed.
+type PrintJob struct {
+	ID        uint           `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID      uuid.UUID      `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	OrderID   uint           `gorm:"not null" json:"-"`
+	Station   string         `gorm:"type:varchar(50);not null" json:"station"`
+	Status    PrintJobStatus `gorm:"type:varchar(20);not null;default:'queued'" json:"status"`
+	Payload   []byte         `gorm:"type:bytea;not null" json:"-"`
+	Attempts  int            `gorm:"not null;default:0" json:"attempts"`
+	LastError *string        `gorm:"type:text" json:"last_error,omitempty"`
+	ClaimedAt *time.Time     `json:"claimed_at,omitempty"`
+	PrintedAt *time.Time     `json:"printed_at,omitempty"`
+	Order     *Order         `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
+	CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (PrintJob) TableName() string {
+	return "print_jobs"
+}
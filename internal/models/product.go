@@ -19,6 +19,7 @@ type Product struct {
 	IsAvailable     bool                   `gorm:"default:true" json:"is_available"`
 	IsCustomizable  bool                   `gorm:"default:false" json:"is_customizable"`
 	ImageURL        *string                `gorm:"type:varchar(255)" json:"image_url,omitempty"`
+	StockQuantity   *int                   `gorm:"type:int" json:"stock_quantity,omitempty"`
 	DeletedAt       *time.Time             `gorm:"index" json:"deleted_at,omitempty"`
 	Category        *Category              `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:SET NULL" json:"category,omitempty"`
 	Customizations  []ProductCustomization `gorm:"foreignKey:ProductID;references:ID" json:"customizations,omitempty"`
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+	// DiscountTypeFreeItem takes the value as a percentage off one item, the
+	// dearest that qualifies. Only stamp card rewards use it.
+	DiscountTypeFreeItem DiscountType = "free_item"
+	// DiscountTypeFreeDelivery takes the value as a percentage off the
+	// delivery fee. Only promotions use it.
+	DiscountTypeFreeDelivery DiscountType = "free_delivery"
+)
+
+// Promotion is a promo code customers redeem at checkout. Without products or
+// categories it discounts the whole order; otherwise only the items in scope.
+// An automatic promotion applies to every qualifying order without its code
+// being entered. A stackable promotion combines with other stackable ones;
+// any other promotion is applied on its own. Days and the start and end
+// times limit it to certain hours of the week, such as a happy hour.
+type Promotion struct {
+	ID               uint         `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID             uuid.UUID    `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Code             string       `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"`
+	Name             *string      `gorm:"type:varchar(60)" json:"name,omitempty"`
+	Description      *string      `gorm:"type:text" json:"description,omitempty"`
+	DiscountType     DiscountType `gorm:"type:varchar(20);not null" json:"discount_type"`
+	DiscountValue    float64      `gorm:"type:decimal(10,2);not null" json:"discount_value"`
+	MaxDiscount      *float64     `gorm:"type:decimal(10,2)" json:"max_discount,omitempty"`
+	MinSpend         float64      `gorm:"type:decimal(10,2);not null;default:0" json:"min_spend"`
+	UsageLimit       *int         `json:"usage_limit,omitempty"`
+	PerCustomerLimit *int         `json:"per_customer_limit,omitempty"`
+	StartsAt         *time.Time   `json:"starts_at,omitempty"`
+	ExpiresAt        *time.Time   `json:"expires_at,omitempty"`
+	Days             int          `gorm:"not null;default:0" json:"days"`
+	StartTime        *string      `gorm:"type:varchar(5)" json:"start_time,omitempty"`
+	EndTime          *string      `gorm:"type:varchar(5)" json:"end_time,omitempty"`
+	IsActive         bool         `gorm:"not null;default:true" json:"is_active"`
+	Automatic        bool         `gorm:"not null;default:false" json:"automatic"`
+	Stackable        bool         `gorm:"not null;default:false" json:"stackable"`
+	Products         []Product    `gorm:"many2many:promotion_products" json:"products,omitempty"`
+	Categories       []Category   `gorm:"many2many:promotion_categories" json:"categories,omitempty"`
+	CreatedAt        time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt        time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// IsRedeemableAt reports whether the promotion is switched on and within its
+// validity window and hours at t. Days and hours follow the clock of t, so t
+// should be in the store timezone.
+func (p *Promotion) IsRedeemableAt(t time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+	if p.ExpiresAt != nil && !t.Before(*p.ExpiresAt) {
+		return false
+	}
+	return p.isInHours(t)
+}
+
+// RunsOn reports whether the promotion applies on the weekday. No days means
+// every day.
+func (p *Promotion) RunsOn(day time.Weekday) bool {
+	return p.Days == 0 || p.Days&(1<<day) != 0
+}
+
+// isInHours checks the promotion's days and hours. Hours that end before they
+// start run past midnight and belong to the day they start on.
+func (p *Promotion) isInHours(t time.Time) bool {
+	day := t.Weekday()
+	if p.StartTime == nil || p.EndTime == nil {
+		return p.RunsOn(day)
+	}
+
+	clock := t.Format("15:04")
+	start, end := *p.StartTime, *p.EndTime
+	if start < end {
+		return p.RunsOn(day) && clock >= start && clock < end
+	}
+	return (p.RunsOn(day) && clock >= start) || (p.RunsOn((day+6)%7) && clock < end)
+}
+
+// OrderPromotion is a promotion applied to an order with the discount it gave.
+// The code is kept for reporting after the promotion is deleted. A voucher
+// redeemed on the order is listed the same way, with VoucherID set instead.
+// A free-delivery promotion lists the part of the delivery fee it waived,
+// which counts toward the order's delivery discount rather than its discount.
+type OrderPromotion struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	OrderID     uint      `gorm:"not null;index" json:"-"`
+	PromotionID *uint     `gorm:"index" json:"-"`
+	VoucherID   *uint     `json:"-"`
+	Code        string    `gorm:"type:varchar(50);not null" json:"code"`
+	Name        *string   `gorm:"type:varchar(60)" json:"name,omitempty"`
+	Discount    float64   `gorm:"type:decimal(10,2);not null" json:"discount"`
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (OrderPromotion) TableName() string {
+	return "order_promotions"
+}
+
+// IsScoped reports whether the promotion is limited to some products or
+// categories
+func (p *Promotion) IsScoped() bool {
+	return len(p.Products) > 0 || len(p.Categories) > 0
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refund is money given back on a settled payment. Refunds issued through the
+// API record the admin who issued them; refunds made in the gateway dashboard
+// arrive through the webhook without one.
+type Refund struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID       uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	PaymentID  uint      `gorm:"not null;index" json:"-"`
+	RefundKey  string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"refund_key"`
+	Amount     float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Reason     string    `gorm:"type:varchar(255);not null" json:"reason"`
+	RefundedBy *uint     `json:"-"`
+	Payment    *Payment  `gorm:"foreignKey:PaymentID;references:ID;constraint:OnDelete:CASCADE" json:"payment,omitempty"`
+	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (Refund) TableName() string {
+	return "refunds"
+}
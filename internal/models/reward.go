@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reward is an entry in the rewards catalog that members swap loyalty points
+// for, such as a free drink, merch or a discount. Redeeming it issues the
+// member a voucher on its terms. A free-item reward covers the dearest item
+// from CategoryID or its subcategories. Stock is how many are left to redeem,
+// nil for no limit.
+type Reward struct {
+	ID            uint         `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID          uuid.UUID    `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Name          string       `gorm:"type:varchar(100);not null" json:"name"`
+	Description   *string      `gorm:"type:varchar(500)" json:"description,omitempty"`
+	PointsCost    int          `gorm:"not null" json:"points_cost"`
+	DiscountType  DiscountType `gorm:"type:varchar(20);not null" json:"discount_type"`
+	DiscountValue float64      `gorm:"type:decimal(10,2);not null" json:"discount_value"`
+	MaxDiscount   *float64     `gorm:"type:decimal(10,2)" json:"max_discount,omitempty"`
+	MinSpend      float64      `gorm:"type:decimal(10,2);not null;default:0" json:"min_spend"`
+	CategoryID    *uint        `json:"-"`
+	ValidDays     int          `gorm:"not null;default:0" json:"valid_days"`
+	Stock         *int         `json:"stock,omitempty"`
+	IsActive      bool         `gorm:"not null;default:true;index" json:"is_active"`
+	Category      *Category    `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:SET NULL" json:"category,omitempty"`
+	CreatedAt     time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Reward) TableName() string {
+	return "rewards"
+}
+
+// InStock reports whether the reward has any left to redeem
+func (r *Reward) InStock() bool {
+	return r.Stock == nil || *r.Stock > 0
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Setting keys
+const (
+	SettingKeyReceipt       = "receipt"
+	SettingKeyNotifications = "notifications"
+	SettingKeyQRCode        = "qr_code"
+	SettingKeyTimeslots     = "timeslots"
+	SettingKeyPayments      = "payments"
+	SettingKeyLoyalty       = "loyalty"
+	SettingKeyCurrency      = "currency"
+	SettingKeyDeposits      = "deposits"
+	SettingKeyStamps        = "stamps"
+)
+
+type Setting struct {
+	Key       string         `gorm:"type:varchar(100);primaryKey" json:"key"`
+	Value     datatypes.JSON `gorm:"type:jsonb;not null" json:"value"`
+	CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Setting) TableName() string {
+	return "settings"
+}
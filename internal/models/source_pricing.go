@@ -0,0 +1,27 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+type SourcePricing struct {
+	ID                     uint        `gorm:"primaryKey;autoIncrement" json:"-"`
+	OrderSource            OrderSource `gorm:"type:varchar(20);uniqueIndex;not null" json:"order_source"`
+	PriceAdjustmentPercent float64     `gorm:"type:decimal(5,2);not null;default:0" json:"price_adjustment_percent"`
+	FlatFee                float64     `gorm:"type:decimal(10,2);not null;default:0" json:"flat_fee"`
+	CreatedAt              time.Time   `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt              time.Time   `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (SourcePricing) TableName() string {
+	return "source_pricing"
+}
+
+// AdjustPrice applies the percentage adjustment, rounded to whole rupiah
+func (p *SourcePricing) AdjustPrice(price float64) float64 {
+	if p == nil || p.PriceAdjustmentPercent == 0 {
+		return price
+	}
+	return math.Round(price * (1 + p.PriceAdjustmentPercent/100))
+}
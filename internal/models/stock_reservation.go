@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusActive   ReservationStatus = "active"
+	ReservationStatusConsumed ReservationStatus = "consumed"
+	ReservationStatusReleased ReservationStatus = "released"
+)
+
+type StockReservation struct {
+	ID        uint              `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID      uuid.UUID         `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	ProductID uint              `gorm:"not null;index" json:"-"`
+	OrderID   uint              `gorm:"not null;index" json:"-"`
+	Quantity  int               `gorm:"not null" json:"quantity"`
+	Status    ReservationStatus `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	ExpiresAt time.Time         `gorm:"not null" json:"expires_at"`
+	Product   *Product          `gorm:"foreignKey:ProductID;references:ID;constraint:OnDelete:CASCADE" json:"product,omitempty"`
+	Order     *Order            `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:CASCADE" json:"order,omitempty"`
+	CreatedAt time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (StockReservation) TableName() string {
+	return "stock_reservations"
+}
+
+func (r *StockReservation) IsHolding() bool {
+	return r.Status == ReservationStatusActive && r.ExpiresAt.After(time.Now())
+}
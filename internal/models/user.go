@@ -15,6 +15,12 @@ const (
 	RoleAdmin   UserRole = "admin"
 )
 
+// Channels a member can choose to be notified on
+const (
+	NotifyViaEmail    = "email"
+	NotifyViaWhatsApp = "whatsapp"
+)
+
 type User struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement" json:"-"`
 	UUID      uuid.UUID `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
@@ -24,6 +30,8 @@ type User struct {
 	Role      UserRole  `gorm:"type:varchar(20);not null" json:"role"`
 	IsActive  bool      `gorm:"default:true" json:"is_active"`
 	Phone     *string   `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Locale    *string   `gorm:"type:varchar(5)" json:"locale,omitempty"`
+	NotifyVia *string   `gorm:"type:varchar(20)" json:"notify_via,omitempty"`
 	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -31,3 +39,20 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+// PreferredLocale returns the user's language, or an empty string when unset
+func (u *User) PreferredLocale() string {
+	if u == nil || u.Locale == nil {
+		return ""
+	}
+	return *u.Locale
+}
+
+// PreferredChannel returns how the user wants to be notified, email unless
+// they chose otherwise
+func (u *User) PreferredChannel() string {
+	if u == nil || u.NotifyVia == nil {
+		return NotifyViaEmail
+	}
+	return *u.NotifyVia
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoucherStatus string
+
+const (
+	VoucherStatusActive   VoucherStatus = "active"
+	VoucherStatusRedeemed VoucherStatus = "redeemed"
+	VoucherStatusRevoked  VoucherStatus = "revoked"
+)
+
+type VoucherAction string
+
+const (
+	VoucherActionIssued   VoucherAction = "issued"
+	VoucherActionRedeemed VoucherAction = "redeemed"
+	VoucherActionReleased VoucherAction = "released"
+	VoucherActionRevoked  VoucherAction = "revoked"
+)
+
+// Voucher is a single-use discount an admin grants to one member, such as an
+// apology for a bad order. Only that member can redeem it, on one order. It
+// goes back to active when the order is cancelled. RewardID is set when the
+// member bought it with points from the rewards catalog, and CategoryID
+// limits a free-item voucher to that category instead of the stamp card's.
+type Voucher struct {
+	ID            uint          `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID          uuid.UUID     `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Code          string        `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	UserID        uint          `gorm:"not null;index" json:"-"`
+	Reason        string        `gorm:"type:varchar(255);not null" json:"reason"`
+	DiscountType  DiscountType  `gorm:"type:varchar(20);not null" json:"discount_type"`
+	DiscountValue float64       `gorm:"type:decimal(10,2);not null" json:"discount_value"`
+	MaxDiscount   *float64      `gorm:"type:decimal(10,2)" json:"max_discount,omitempty"`
+	MinSpend      float64       `gorm:"type:decimal(10,2);not null;default:0" json:"min_spend"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
+	Status        VoucherStatus `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	IssuedBy      *uint         `json:"-"`
+	OrderID       *uint         `gorm:"index" json:"-"`
+	RewardID      *uint         `gorm:"index" json:"-"`
+	CategoryID    *uint         `json:"-"`
+	RedeemedAt    *time.Time    `json:"redeemed_at,omitempty"`
+	RevokedAt     *time.Time    `json:"revoked_at,omitempty"`
+	RevokedBy     *uint         `json:"-"`
+	RevokeReason  *string       `gorm:"type:varchar(255)" json:"revoke_reason,omitempty"`
+	User          *User         `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	Order         *Order        `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:SET NULL" json:"order,omitempty"`
+	CreatedAt     time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Voucher) TableName() string {
+	return "vouchers"
+}
+
+// IsRedeemableAt reports whether the voucher is active and not expired at t
+func (v *Voucher) IsRedeemableAt(t time.Time) bool {
+	if v.Status != VoucherStatusActive {
+		return false
+	}
+	return v.ExpiresAt == nil || t.Before(*v.ExpiresAt)
+}
+
+// VoucherEvent is one entry in a voucher's audit trail. Actor is the admin who
+// issued or revoked it, or the member who redeemed it; releases on
+// cancellation have no actor.
+type VoucherEvent struct {
+	ID        uint          `gorm:"primaryKey;autoIncrement" json:"-"`
+	VoucherID uint          `gorm:"not null;index" json:"-"`
+	Action    VoucherAction `gorm:"type:varchar(20);not null" json:"action"`
+	ActorID   *uint         `json:"-"`
+	OrderID   *uint         `json:"-"`
+	Note      *string       `gorm:"type:varchar(255)" json:"note,omitempty"`
+	Actor     *User         `gorm:"foreignKey:ActorID;references:ID;constraint:OnDelete:SET NULL" json:"actor,omitempty"`
+	Order     *Order        `gorm:"foreignKey:OrderID;references:ID;constraint:OnDelete:SET NULL" json:"order,omitempty"`
+	CreatedAt time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (VoucherEvent) TableName() string {
+	return "voucher_events"
+}
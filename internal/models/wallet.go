@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WalletTransactionType string
+
+const (
+	WalletTransactionTopUp      WalletTransactionType = "topup"
+	WalletTransactionPayment    WalletTransactionType = "payment"
+	WalletTransactionRefund     WalletTransactionType = "refund"
+	WalletTransactionAdjustment WalletTransactionType = "adjustment"
+)
+
+// WalletTransaction is one entry in a member's wallet ledger. Amount is
+// positive for money coming in and negative for money spent; BalanceAfter is
+// the balance once the entry was posted, so the latest entry holds the
+// current balance.
+type WalletTransaction struct {
+	ID           uint                  `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID         uuid.UUID             `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	UserID       uint                  `gorm:"not null;index" json:"-"`
+	Type         WalletTransactionType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount       float64               `gorm:"type:decimal(10,2);not null" json:"amount"`
+	BalanceAfter float64               `gorm:"type:decimal(10,2);not null" json:"balance_after"`
+	OrderID      *uint                 `json:"-"`
+	TopUpID      *uint                 `json:"-"`
+	Note         *string               `gorm:"type:varchar(255)" json:"note,omitempty"`
+	CreatedBy    *uint                 `json:"-"`
+	Order        *Order                `gorm:"foreignKey:OrderID;references:ID" json:"order,omitempty"`
+	CreatedAt    time.Time             `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (WalletTransaction) TableName() string {
+	return "wallet_transactions"
+}
+
+// WalletTopUp is money a member adds to their wallet through the payment
+// gateway. The wallet is credited once, when the gateway settles it.
+type WalletTopUp struct {
+	ID                uint               `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID              uuid.UUID          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	UserID            uint               `gorm:"not null;index" json:"-"`
+	Reference         string             `gorm:"type:varchar(100);uniqueIndex;not null" json:"reference"`
+	Method            PaymentMethod      `gorm:"type:varchar(20);not null" json:"method"`
+	Amount            float64            `gorm:"type:decimal(10,2);not null" json:"amount"`
+	TransactionStatus *TransactionStatus `gorm:"type:varchar(50)" json:"transaction_status,omitempty"`
+	PaymentType       *string            `gorm:"type:varchar(50)" json:"payment_type,omitempty"`
+	TransactionID     *string            `gorm:"type:varchar(100)" json:"transaction_id,omitempty"`
+	CheckoutURL       *string            `gorm:"type:text" json:"-"`
+	ExpiresAt         *time.Time         `json:"expires_at,omitempty"`
+	CreditedAt        *time.Time         `json:"credited_at,omitempty"`
+	User              *User              `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	CreatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (WalletTopUp) TableName() string {
+	return "wallet_topups"
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type WebhookEventStatus string
+
+const (
+	WebhookEventStatusReceived  WebhookEventStatus = "received"
+	WebhookEventStatusProcessed WebhookEventStatus = "processed"
+	WebhookEventStatusFailed    WebhookEventStatus = "failed"
+)
+
+// WebhookEvent is a payment webhook as it reached us, stored before it is
+// processed so a rejected notification can be inspected and replayed
+type WebhookEvent struct {
+	ID          uint               `gorm:"primaryKey;autoIncrement" json:"-"`
+	UUID        uuid.UUID          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()" json:"id"`
+	Provider    PaymentMethod      `gorm:"type:varchar(20);not null" json:"provider"`
+	Headers     datatypes.JSON     `gorm:"type:jsonb;not null" json:"headers"`
+	Body        []byte             `gorm:"type:bytea;not null" json:"-"`
+	Status      WebhookEventStatus `gorm:"type:varchar(20);not null;default:'received'" json:"status"`
+	Error       *string            `gorm:"type:text" json:"error,omitempty"`
+	Attempts    int                `gorm:"not null;default:0" json:"attempts"`
+	ProcessedAt *time.Time         `json:"processed_at,omitempty"`
+	CreatedAt   time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time          `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (WebhookEvent) TableName() string {
+	return "webhook_events"
+}
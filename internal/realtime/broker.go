@@ -0,0 +1,115 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// subscriptionBuffer is how many events a slow subscriber may fall behind
+// before further events to it are dropped
+const subscriptionBuffer = 32
+
+// Broker publishes events to every subscriber of a topic. Payloads are JSON so
+// websocket and SSE handlers can forward them as-is.
+type Broker interface {
+	Publish(ctx context.Context, topic string, event any) error
+	Subscribe(topic string) *Subscription
+	Close() error
+}
+
+// Subscription receives the JSON payload of every event published to its
+// topic until Close is called
+type Subscription struct {
+	C <-chan []byte
+
+	ch    chan []byte
+	topic string
+	hub   *Hub
+	once  sync.Once
+}
+
+// Close stops delivery and closes C
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.remove(s)
+	})
+}
+
+// Hub fans events out to subscribers within this process
+type Hub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		topics: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(topic string) *Subscription {
+	ch := make(chan []byte, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, topic: topic, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*Subscription]struct{})
+	}
+	h.topics[topic][sub] = struct{}{}
+	return sub
+}
+
+// Deliver hands payload to every subscriber of topic without blocking; a
+// subscriber whose buffer is full misses the event
+func (h *Hub) Deliver(topic string, payload []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.topics[topic] {
+		select {
+		case sub.ch <- payload:
+		default:
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.topics[sub.topic], sub)
+	if len(h.topics[sub.topic]) == 0 {
+		delete(h.topics, sub.topic)
+	}
+	close(sub.ch)
+}
+
+// LocalBroker delivers events only to subscribers in this process. It suits a
+// single instance and tests.
+type LocalBroker struct {
+	hub *Hub
+}
+
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{hub: NewHub()}
+}
+
+func (b *LocalBroker) Publish(ctx context.Context, topic string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	b.hub.Deliver(topic, payload)
+	return nil
+}
+
+func (b *LocalBroker) Subscribe(topic string) *Subscription {
+	return b.hub.Subscribe(topic)
+}
+
+func (b *LocalBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	notifyChannel      = "realtime_events"
+	maxReconnectDelay  = 30 * time.Second
+	baseReconnectDelay = time.Second
+)
+
+type envelope struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// PostgresBroker relays events between instances through Postgres
+// LISTEN/NOTIFY, so a client connected to any instance sees updates processed
+// by the others. Each instance keeps one dedicated listening connection and
+// fans received events out to its local subscribers. Events published while
+// that connection is reconnecting are not replayed.
+type PostgresBroker struct {
+	db     *gorm.DB
+	dsn    string
+	hub    *Hub
+	logger *slog.Logger
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	listening atomic.Bool
+}
+
+func NewPostgresBroker(db *gorm.DB, dsn string, logger *slog.Logger) *PostgresBroker {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &PostgresBroker{
+		db:     db,
+		dsn:    dsn,
+		hub:    NewHub(),
+		logger: logger,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go b.listen(ctx)
+	return b
+}
+
+func (b *PostgresBroker) Publish(ctx context.Context, topic string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", notifyChannel, string(body)).Error
+}
+
+func (b *PostgresBroker) Subscribe(topic string) *Subscription {
+	return b.hub.Subscribe(topic)
+}
+
+// Listening reports whether the LISTEN connection is currently up. While it is
+// down this instance does not see events published by the others.
+func (b *PostgresBroker) Listening() bool {
+	return b.listening.Load()
+}
+
+// Close stops listening and waits for the listening connection to close
+func (b *PostgresBroker) Close() error {
+	b.cancel()
+	<-b.done
+	return nil
+}
+
+func (b *PostgresBroker) listen(ctx context.Context) {
+	defer close(b.done)
+
+	delay := baseReconnectDelay
+	for {
+		listened, err := b.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if listened {
+			delay = baseReconnectDelay
+		}
+
+		b.logger.Warn("Realtime listener disconnected, reconnecting", "retry_in", delay.String(), logging.Err(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// receive holds one LISTEN connection until it fails or ctx is cancelled. It
+// reports whether LISTEN succeeded so the caller can reset its backoff.
+func (b *PostgresBroker) receive(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			b.logger.Error("Error closing realtime listener", logging.Err(err))
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return false, err
+	}
+	b.listening.Store(true)
+	defer b.listening.Store(false)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var event envelope
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			b.logger.Warn("Dropping malformed realtime event", logging.Err(err))
+			continue
+		}
+		b.hub.Deliver(event.Topic, event.Payload)
+	}
+}
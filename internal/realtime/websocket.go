@@ -0,0 +1,198 @@
+package realtime
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // required by the WebSocket handshake, not used for security
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// websocketGUID is the fixed key suffix from RFC 6455 section 1.3
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+
+	// maxControlPayload is the RFC 6455 limit for control frames; clients
+	// never need to send us anything larger
+	maxControlPayload = 125
+	writeTimeout      = 10 * time.Second
+)
+
+var (
+	ErrFrameTooLarge = errors.New("websocket frame too large")
+)
+
+// IsWebSocketUpgrade reports whether the request asks to switch to WebSocket
+func IsWebSocketUpgrade(c *fiber.Ctx) bool {
+	return strings.EqualFold(c.Get(fiber.HeaderUpgrade), "websocket") &&
+		strings.Contains(strings.ToLower(c.Get(fiber.HeaderConnection)), "upgrade") &&
+		c.Get("Sec-WebSocket-Key") != ""
+}
+
+// AcceptKey derives the Sec-WebSocket-Accept value for a client key
+func AcceptKey(key string) string {
+	h := sha1.New() //nolint:gosec // see import comment
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Upgrade completes the WebSocket handshake and runs handler on the hijacked
+// connection. The connection is closed when handler returns.
+func Upgrade(c *fiber.Ctx, handler func(conn *WSConn)) error {
+	ctx := c.Context()
+	ctx.Response.SetStatusCode(fiber.StatusSwitchingProtocols)
+	ctx.Response.Header.Set(fiber.HeaderUpgrade, "websocket")
+	ctx.Response.Header.Set(fiber.HeaderConnection, "Upgrade")
+	ctx.Response.Header.Set("Sec-WebSocket-Accept", AcceptKey(c.Get("Sec-WebSocket-Key")))
+
+	ctx.Hijack(func(nc net.Conn) {
+		handler(NewWSConn(nc))
+	})
+	return nil
+}
+
+// WSConn is a server-side WebSocket connection that pushes text messages.
+// Messages from the client are read only to answer pings and notice closes.
+type WSConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+func NewWSConn(conn net.Conn) *WSConn {
+	return &WSConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+}
+
+// WriteText sends payload as a single text frame
+func (w *WSConn) WriteText(payload []byte) error {
+	return w.writeFrame(opText, payload)
+}
+
+// Ping sends a ping so idle proxies keep the connection open
+func (w *WSConn) Ping() error {
+	return w.writeFrame(opPing, nil)
+}
+
+// Close sends a normal closure frame and closes the connection
+func (w *WSConn) Close() error {
+	closeCode := make([]byte, 2)
+	binary.BigEndian.PutUint16(closeCode, 1000)
+	_ = w.writeFrame(opClose, closeCode) //nolint:errcheck // the peer may already be gone
+	return w.conn.Close()
+}
+
+// ReadUntilClosed consumes client frames, answering pings, until the client
+// closes the connection or it fails
+func (w *WSConn) ReadUntilClosed() error {
+	for {
+		opcode, payload, err := w.readFrame()
+		if err != nil {
+			return err
+		}
+
+		switch opcode {
+		case opClose:
+			return nil
+		case opPing:
+			if err := w.writeFrame(opPong, payload); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *WSConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length <= maxControlPayload:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	if _, err := w.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readFrame reads one masked client frame. Data frames are read but their
+// payload is discarded since the server does not accept client messages.
+func (w *WSConn) readFrame() (byte, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(w.reader, head); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(w.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(w.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err := io.ReadFull(w.reader, mask); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if opcode >= opClose {
+		if length > maxControlPayload {
+			return 0, nil, ErrFrameTooLarge
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(w.reader, payload); err != nil {
+			return 0, nil, err
+		}
+		if mask != nil {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+		return opcode, payload, nil
+	}
+
+	if _, err := io.CopyN(io.Discard, w.reader, int64(length)); err != nil {
+		return 0, nil, err
+	}
+	return opcode, nil, nil
+}
@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrCampaignNotFound = errors.New("campaign not found")
+
+// CampaignDeliveryCount is how many of a campaign's messages have the status,
+// per channel. Channel is nil for messages not sent yet.
+type CampaignDeliveryCount struct {
+	Status  models.CampaignDeliveryStatus
+	Channel *string
+	Count   int64
+}
+
+type CampaignRepository interface {
+	Create(campaign *models.Campaign) error
+	FindAll(status *models.CampaignStatus, limit, offset int) ([]models.Campaign, int64, error)
+	FindByUUID(uuid uuid.UUID) (*models.Campaign, error)
+	Cancel(id uint) (bool, error)
+	FindDue(now time.Time) ([]models.Campaign, error)
+	Start(id uint, userIDs []uint) (bool, error)
+	ClaimPendingDeliveries(limit int, staleBefore time.Time) ([]models.CampaignDelivery, error)
+	MarkDelivery(id uint, status models.CampaignDeliveryStatus, channel string, sendErr *string) error
+	FinishSending() (int64, error)
+	CountDeliveries(campaignID uint) ([]CampaignDeliveryCount, error)
+}
+
+type campaignRepository struct {
+	db *gorm.DB
+}
+
+func NewCampaignRepository(db *gorm.DB) CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+func (r *campaignRepository) Create(campaign *models.Campaign) error {
+	return r.db.Omit(clause.Associations).Create(campaign).Error
+}
+
+// FindAll returns campaigns by when they send, latest first
+func (r *campaignRepository) FindAll(status *models.CampaignStatus, limit, offset int) ([]models.Campaign, int64, error) {
+	var campaigns []models.Campaign
+	var total int64
+
+	query := r.db.Model(&models.Campaign{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Creator").
+		Order("send_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&campaigns).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return campaigns, total, nil
+}
+
+func (r *campaignRepository) FindByUUID(uuid uuid.UUID) (*models.Campaign, error) {
+	var campaign models.Campaign
+	err := r.db.Preload("Creator").Where("uuid = ?", uuid).First(&campaign).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// Cancel stops a campaign that has not finished sending. Messages already
+// sent stay sent; the rest are never sent.
+func (r *campaignRepository) Cancel(id uint) (bool, error) {
+	result := r.db.Model(&models.Campaign{}).
+		Where("id = ? AND status IN ?", id, []models.CampaignStatus{models.CampaignStatusScheduled, models.CampaignStatusSending}).
+		Updates(map[string]any{
+			"status":       models.CampaignStatusCancelled,
+			"cancelled_at": time.Now(),
+			"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindDue returns the scheduled campaigns whose send time has come
+func (r *campaignRepository) FindDue(now time.Time) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	err := r.db.Where("status = ? AND send_at <= ?", models.CampaignStatusScheduled, now).
+		Order("send_at, id").
+		Find(&campaigns).Error
+	return campaigns, err
+}
+
+// Start moves a scheduled campaign to sending and queues a message for each
+// member, all or none. It reports false when the campaign was no longer
+// scheduled, such as when it was cancelled in the meantime.
+func (r *campaignRepository) Start(id uint, userIDs []uint) (bool, error) {
+	started := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Campaign{}).
+			Where("id = ? AND status = ?", id, models.CampaignStatusScheduled).
+			Updates(map[string]any{
+				"status":     models.CampaignStatusSending,
+				"started_at": time.Now(),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		started = true
+
+		if len(userIDs) == 0 {
+			return nil
+		}
+		deliveries := make([]models.CampaignDelivery, len(userIDs))
+		for i, userID := range userIDs {
+			deliveries[i] = models.CampaignDelivery{
+				CampaignID: id,
+				UserID:     userID,
+				Status:     models.CampaignDeliveryPending,
+			}
+		}
+		return tx.CreateInBatches(&deliveries, 500).Error
+	})
+	return started, err
+}
+
+// ClaimPendingDeliveries claims the oldest unsent messages of campaigns that
+// are still sending and returns them with their member and campaign.
+// Concurrent runs skip rows another run is claiming, so each message is
+// handed out once. Messages claimed before staleBefore and never marked were
+// interrupted mid-send; they are marked failed rather than sent twice.
+func (r *campaignRepository) ClaimPendingDeliveries(limit int, staleBefore time.Time) ([]models.CampaignDelivery, error) {
+	var deliveries []models.CampaignDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.CampaignDelivery{}).
+			Where("status = ? AND claimed_at < ?", models.CampaignDeliverySending, staleBefore).
+			Updates(map[string]any{
+				"status": models.CampaignDeliveryFailed,
+				"error":  "interrupted before the send was recorded",
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		var ids []uint
+		err = tx.Model(&models.CampaignDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.CampaignDeliveryPending).
+			Where("campaign_id IN (?)", tx.Model(&models.Campaign{}).Select("id").Where("status = ?", models.CampaignStatusSending)).
+			Order("id").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(&models.CampaignDelivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"status":     models.CampaignDeliverySending,
+				"claimed_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Preload("User").Preload("Campaign").
+			Where("id IN ?", ids).
+			Order("id").
+			Find(&deliveries).Error
+	})
+	return deliveries, err
+}
+
+// MarkDelivery records the outcome of sending a claimed message
+func (r *campaignRepository) MarkDelivery(id uint, status models.CampaignDeliveryStatus, channel string, sendErr *string) error {
+	return r.db.Model(&models.CampaignDelivery{}).
+		Where("id = ? AND status = ?", id, models.CampaignDeliverySending).
+		Updates(map[string]any{
+			"status":  status,
+			"channel": channel,
+			"error":   sendErr,
+			"sent_at": time.Now(),
+		}).Error
+}
+
+// FinishSending marks campaigns with no messages left to send or being sent
+// as sent
+func (r *campaignRepository) FinishSending() (int64, error) {
+	result := r.db.Model(&models.Campaign{}).
+		Where("status = ?", models.CampaignStatusSending).
+		Where("NOT EXISTS (?)", r.db.Model(&models.CampaignDelivery{}).
+			Select("1").
+			Where("campaign_deliveries.campaign_id = campaigns.id AND campaign_deliveries.status IN ?",
+				[]models.CampaignDeliveryStatus{models.CampaignDeliveryPending, models.CampaignDeliverySending})).
+		Updates(map[string]any{
+			"status":      models.CampaignStatusSent,
+			"finished_at": time.Now(),
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *campaignRepository) CountDeliveries(campaignID uint) ([]CampaignDeliveryCount, error) {
+	var counts []CampaignDeliveryCount
+	err := r.db.Model(&models.CampaignDelivery{}).
+		Select("status, channel, COUNT(*) AS count").
+		Where("campaign_id = ?", campaignID).
+		Group("status, channel").
+		Scan(&counts).Error
+	return counts, err
+}
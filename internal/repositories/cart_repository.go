@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCartNotFound           = errors.New("cart not found")
+	ErrCartItemNotFound       = errors.New("cart item not found")
+	ErrCartCheckoutInProgress = errors.New("cart checkout already in progress")
+)
+
+// checkoutTimeout releases a checkout lock left behind by a request that died
+// midway, so the cart does not stay locked forever
+const checkoutTimeout = time.Minute
+
+type CartRepository interface {
+	FindByUserID(userID uint) (*models.Cart, error)
+	FindBySessionID(sessionID string) (*models.Cart, error)
+	Create(cart *models.Cart) error
+	AddItem(item *models.CartItem) error
+	FindItemByUUID(cartID uint, itemUUID uuid.UUID) (*models.CartItem, error)
+	UpdateItem(item *models.CartItem) error
+	DeleteItem(cartID, itemID uint) error
+	Clear(cartID uint) error
+	BeginCheckout(cartID uint) error
+	CancelCheckout(cartID uint) error
+	Delete(cartID uint) error
+	DeleteStaleSessionCarts(before time.Time) (int64, error)
+}
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db: db}
+}
+
+func (r *cartRepository) FindByUserID(userID uint) (*models.Cart, error) {
+	return r.findOne("user_id = ?", userID)
+}
+
+func (r *cartRepository) FindBySessionID(sessionID string) (*models.Cart, error) {
+	return r.findOne("session_id = ?", sessionID)
+}
+
+func (r *cartRepository) findOne(query string, args ...any) (*models.Cart, error) {
+	var cart models.Cart
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Preload("Items.Product").
+		Where(query, args...).
+		First(&cart).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCartNotFound
+		}
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// Create inserts the cart, or loads the existing one when a concurrent request
+// created the same owner's cart first
+func (r *cartRepository) Create(cart *models.Cart) error {
+	result := r.db.Omit("Items", "User").Clauses(clause.OnConflict{DoNothing: true}).Create(cart)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var existing *models.Cart
+	var err error
+	if cart.UserID != nil {
+		existing, err = r.FindByUserID(*cart.UserID)
+	} else {
+		existing, err = r.FindBySessionID(*cart.SessionID)
+	}
+	if err != nil {
+		return err
+	}
+	*cart = *existing
+	return nil
+}
+
+func (r *cartRepository) AddItem(item *models.CartItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Cart", "Product").Create(item).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, item.CartID)
+	})
+}
+
+func (r *cartRepository) FindItemByUUID(cartID uint, itemUUID uuid.UUID) (*models.CartItem, error) {
+	var item models.CartItem
+	err := r.db.Where("cart_id = ? AND uuid = ?", cartID, itemUUID).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *cartRepository) UpdateItem(item *models.CartItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.CartItem{}).
+			Where("id = ?", item.ID).
+			Updates(map[string]any{
+				"quantity":   item.Quantity,
+				"notes":      item.Notes,
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return touchCart(tx, item.CartID)
+	})
+}
+
+func (r *cartRepository) DeleteItem(cartID, itemID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND cart_id = ?", itemID, cartID).Delete(&models.CartItem{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCartItemNotFound
+		}
+		return touchCart(tx, cartID)
+	})
+}
+
+func (r *cartRepository) Clear(cartID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID)
+	})
+}
+
+// BeginCheckout locks the cart for conversion into an order. It fails with
+// ErrCartCheckoutInProgress while another checkout of the same cart runs.
+func (r *cartRepository) BeginCheckout(cartID uint) error {
+	result := r.db.Model(&models.Cart{}).
+		Where("id = ? AND (checkout_started_at IS NULL OR checkout_started_at < ?)", cartID, time.Now().Add(-checkoutTimeout)).
+		Update("checkout_started_at", gorm.Expr("CURRENT_TIMESTAMP"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCartCheckoutInProgress
+	}
+	return nil
+}
+
+func (r *cartRepository) CancelCheckout(cartID uint) error {
+	return r.db.Model(&models.Cart{}).Where("id = ?", cartID).Update("checkout_started_at", nil).Error
+}
+
+func (r *cartRepository) Delete(cartID uint) error {
+	return r.db.Where("id = ?", cartID).Delete(&models.Cart{}).Error
+}
+
+// DeleteStaleSessionCarts removes kiosk carts abandoned since before. Member
+// carts are kept until checkout.
+func (r *cartRepository) DeleteStaleSessionCarts(before time.Time) (int64, error) {
+	result := r.db.Where("session_id IS NOT NULL AND updated_at < ?", before).Delete(&models.Cart{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func touchCart(tx *gorm.DB, cartID uint) error {
+	return tx.Model(&models.Cart{}).Where("id = ?", cartID).Update("updated_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+}
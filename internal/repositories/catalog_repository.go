@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+type CatalogRepository interface {
+	Version() (int64, error)
+}
+
+type catalogRepository struct {
+	db *gorm.DB
+}
+
+func NewCatalogRepository(db *gorm.DB) CatalogRepository {
+	return &catalogRepository{db: db}
+}
+
+// Version returns the catalog version, which database triggers bump on every
+// category, product or customization change
+func (r *catalogRepository) Version() (int64, error) {
+	var version int64
+	err := r.db.Raw("SELECT version FROM catalog_version").Scan(&version).Error
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -18,10 +18,14 @@ type CategoryRepository interface {
 	Create(category *models.Category) error
 	FindByID(id uint) (*models.Category, error)
 	FindByUUID(uuid uuid.UUID) (*models.Category, error)
+	FindByUUIDIncludingDeleted(uuid uuid.UUID) (*models.Category, error)
 	FindBySlug(slug string) (*models.Category, error)
 	FindAll(isActive *bool) ([]models.Category, error)
+	FindDescendantIDs(id uint) ([]uint, error)
 	Update(category *models.Category) error
-	Delete(id uint) error
+	Reorder(uuids []uuid.UUID) error
+	SoftDelete(id uint, reassignTo *uint) error
+	Restore(id uint) error
 	ExistsBySlug(slug string) (bool, error)
 	ExistsByName(name string) (bool, error)
 }
@@ -43,12 +47,12 @@ func (r *categoryRepository) Create(category *models.Category) error {
 		return ErrCategorySlugExists
 	}
 
-	return r.db.Create(category).Error
+	return r.db.Omit("Parent").Create(category).Error
 }
 
 func (r *categoryRepository) FindByID(id uint) (*models.Category, error) {
 	var category models.Category
-	err := r.db.Where("id = ?", id).First(&category).Error
+	err := r.db.Preload("Parent").Where("id = ? AND deleted_at IS NULL", id).First(&category).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrCategoryNotFound
@@ -60,7 +64,19 @@ func (r *categoryRepository) FindByID(id uint) (*models.Category, error) {
 
 func (r *categoryRepository) FindByUUID(uuid uuid.UUID) (*models.Category, error) {
 	var category models.Category
-	err := r.db.Where("uuid = ?", uuid).First(&category).Error
+	err := r.db.Preload("Parent").Where("uuid = ? AND deleted_at IS NULL", uuid).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) FindByUUIDIncludingDeleted(uuid uuid.UUID) (*models.Category, error) {
+	var category models.Category
+	err := r.db.Preload("Parent").Where("uuid = ?", uuid).First(&category).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrCategoryNotFound
@@ -72,7 +88,7 @@ func (r *categoryRepository) FindByUUID(uuid uuid.UUID) (*models.Category, error
 
 func (r *categoryRepository) FindBySlug(slug string) (*models.Category, error) {
 	var category models.Category
-	err := r.db.Where("slug = ?", slug).First(&category).Error
+	err := r.db.Preload("Parent").Where("slug = ? AND deleted_at IS NULL", slug).First(&category).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrCategoryNotFound
@@ -84,7 +100,7 @@ func (r *categoryRepository) FindBySlug(slug string) (*models.Category, error) {
 
 func (r *categoryRepository) FindAll(isActive *bool) ([]models.Category, error) {
 	var categories []models.Category
-	query := r.db.Order("display_order ASC, created_at DESC")
+	query := r.db.Preload("Parent").Where("deleted_at IS NULL").Order("display_order ASC, created_at DESC")
 
 	if isActive != nil {
 		query = query.Where("is_active = ?", *isActive)
@@ -97,12 +113,51 @@ func (r *categoryRepository) FindAll(isActive *bool) ([]models.Category, error)
 	return categories, nil
 }
 
+// FindDescendantIDs returns the category and every live category nested under it
+func (r *categoryRepository) FindDescendantIDs(id uint) ([]uint, error) {
+	return categoryTreeIDs(r.db, id)
+}
+
 func (r *categoryRepository) Update(category *models.Category) error {
-	return r.db.Save(category).Error
+	return r.db.Omit("Parent").Save(category).Error
 }
 
-func (r *categoryRepository) Delete(id uint) error {
-	return r.db.Model(&models.Category{}).Where("id = ?", id).Update("is_active", false).Error
+// Reorder sets display_order to each category's position in uuids
+func (r *categoryRepository) Reorder(uuids []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range uuids {
+			result := tx.Model(&models.Category{}).
+				Where("uuid = ? AND deleted_at IS NULL", id).
+				Update("display_order", i+1)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrCategoryNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// SoftDelete hides the category. Its products either move to reassignTo or stay
+// attached and hidden, so restoring the category brings them back.
+func (r *categoryRepository) SoftDelete(id uint, reassignTo *uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if reassignTo != nil {
+			if err := tx.Model(&models.Product{}).
+				Where("category_id = ?", id).
+				Update("category_id", *reassignTo).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Category{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+	})
+}
+
+func (r *categoryRepository) Restore(id uint) error {
+	return r.db.Model(&models.Category{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
 
 func (r *categoryRepository) ExistsBySlug(slug string) (bool, error) {
@@ -122,3 +177,20 @@ func (r *categoryRepository) ExistsByName(name string) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+func categoryTreeIDs(db *gorm.DB, rootID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT c.id FROM categories c
+			JOIN tree t ON c.parent_id = t.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT id FROM tree`, rootID).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
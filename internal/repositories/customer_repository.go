@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerSegmentFilters picks members for a segment export from their
+// rolled-up stats. InactiveSince is only used by MemberSegmentLapsed; nil and
+// empty fields are not filtered on.
+type CustomerSegmentFilters struct {
+	Segment         MemberSegment
+	InactiveSince   time.Time
+	LastOrderFrom   *time.Time
+	LastOrderBefore *time.Time
+	MinSpend        *float64
+	MaxSpend        *float64
+	CategoryIDs     []uint
+}
+
+// CustomerSegmentRow is one member of an exported segment. Members without a
+// completed order have no order figures.
+type CustomerSegmentRow struct {
+	UserUUID         uuid.UUID
+	FullName         string
+	Email            string
+	Phone            *string
+	JoinedAt         time.Time
+	OrderCount       int
+	TotalSpend       float64
+	LastOrderAt      *time.Time
+	FavoriteCategory *string
+}
+
+// CustomerRanking orders the lifetime value report: by total spend, number
+// of orders or most recent order, highest first
+type CustomerRanking string
+
+const (
+	CustomerRankingSpend  CustomerRanking = "spend"
+	CustomerRankingOrders CustomerRanking = "orders"
+	CustomerRankingRecent CustomerRanking = "recent"
+)
+
+// customerRankingOrder is the ORDER BY of each ranking, the member ID
+// breaking ties so pages do not overlap
+var customerRankingOrder = map[CustomerRanking]string{
+	CustomerRankingSpend:  "ms.total_spend DESC, ms.order_count DESC, u.id",
+	CustomerRankingOrders: "ms.order_count DESC, ms.total_spend DESC, u.id",
+	CustomerRankingRecent: "ms.last_order_at DESC, u.id",
+}
+
+// CustomerValueRow is an active member's completed orders rolled up
+type CustomerValueRow struct {
+	UserUUID     uuid.UUID
+	FullName     string
+	Email        string
+	Phone        *string
+	JoinedAt     time.Time
+	OrderCount   int
+	TotalSpend   float64
+	FirstOrderAt time.Time
+	LastOrderAt  time.Time
+}
+
+type CustomerRepository interface {
+	RefreshStats() (int64, error)
+	StatsRefreshedAt() (*time.Time, error)
+	CategoryTreeIDs(categoryUUID uuid.UUID) ([]uint, error)
+	FindSegment(filters CustomerSegmentFilters) ([]CustomerSegmentRow, error)
+	FindLifetimeValues(ranking CustomerRanking, limit, offset int) ([]CustomerValueRow, int64, error)
+}
+
+type customerRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) CustomerRepository {
+	return &customerRepository{db: db}
+}
+
+// refreshMemberStatsSQL rolls every member's completed orders up into one
+// row. The favourite category is the one they ordered the most items from,
+// the lowest ID breaking ties.
+const refreshMemberStatsSQL = `
+	INSERT INTO member_stats (user_id, order_count, total_spend, first_order_at, last_order_at, favorite_category_id, refreshed_at)
+	SELECT o.user_id, COUNT(*), SUM(o.total), MIN(o.created_at), MAX(o.created_at), favorite.category_id, ?
+	FROM orders o
+	LEFT JOIN (
+		SELECT DISTINCT ON (fo.user_id) fo.user_id, p.category_id
+		FROM order_items oi
+		JOIN orders fo ON fo.id = oi.order_id
+		JOIN products p ON p.id = oi.product_id
+		WHERE fo.status = ? AND fo.user_id IS NOT NULL AND p.category_id IS NOT NULL
+		GROUP BY fo.user_id, p.category_id
+		ORDER BY fo.user_id, SUM(oi.quantity) DESC, p.category_id
+	) favorite ON favorite.user_id = o.user_id
+	WHERE o.status = ? AND o.user_id IS NOT NULL
+	GROUP BY o.user_id, favorite.category_id
+	ON CONFLICT (user_id) DO UPDATE SET
+		order_count = EXCLUDED.order_count,
+		total_spend = EXCLUDED.total_spend,
+		first_order_at = EXCLUDED.first_order_at,
+		last_order_at = EXCLUDED.last_order_at,
+		favorite_category_id = EXCLUDED.favorite_category_id,
+		refreshed_at = EXCLUDED.refreshed_at`
+
+// RefreshStats rebuilds the stats of every member with a completed order and
+// drops those left without one, all or none. It returns how many members
+// have stats.
+func (r *customerRepository) RefreshStats() (int64, error) {
+	var count int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Exec(refreshMemberStatsSQL, now, models.OrderStatusCompleted, models.OrderStatusCompleted)
+		if result.Error != nil {
+			return result.Error
+		}
+		count = result.RowsAffected
+		return tx.Where("refreshed_at < ?", now).Delete(&models.MemberStats{}).Error
+	})
+	return count, err
+}
+
+// StatsRefreshedAt returns when the stats were last rebuilt, or nil before
+// the first rebuild
+func (r *customerRepository) StatsRefreshedAt() (*time.Time, error) {
+	var refreshedAt *time.Time
+	err := r.db.Model(&models.MemberStats{}).Select("MAX(refreshed_at)").Scan(&refreshedAt).Error
+	return refreshedAt, err
+}
+
+// CategoryTreeIDs returns the category and every live category nested under
+// it, or ErrCategoryNotFound
+func (r *customerRepository) CategoryTreeIDs(categoryUUID uuid.UUID) ([]uint, error) {
+	var rootIDs []uint
+	err := r.db.Model(&models.Category{}).Where("uuid = ?", categoryUUID).Pluck("id", &rootIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rootIDs) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	return categoryTreeIDs(r.db, rootIDs[0])
+}
+
+// FindSegment returns the active members matching the filters, biggest
+// spenders first
+func (r *customerRepository) FindSegment(filters CustomerSegmentFilters) ([]CustomerSegmentRow, error) {
+	query := r.db.Table("users AS u").
+		Select(`u.uuid AS user_uuid, u.full_name, u.email, u.phone, u.created_at AS joined_at,
+			COALESCE(ms.order_count, 0) AS order_count, COALESCE(ms.total_spend, 0) AS total_spend,
+			ms.last_order_at, c.name AS favorite_category`).
+		Joins("LEFT JOIN member_stats ms ON ms.user_id = u.id").
+		Joins("LEFT JOIN categories c ON c.id = ms.favorite_category_id").
+		Where("u.role = ? AND u.is_active = ?", models.RoleMember, true)
+
+	switch filters.Segment {
+	case MemberSegmentLapsed:
+		query = query.Where("ms.last_order_at < ?", filters.InactiveSince)
+	case MemberSegmentNew:
+		query = query.Where("ms.user_id IS NULL")
+	}
+	if filters.LastOrderFrom != nil {
+		query = query.Where("ms.last_order_at >= ?", *filters.LastOrderFrom)
+	}
+	if filters.LastOrderBefore != nil {
+		query = query.Where("ms.last_order_at < ?", *filters.LastOrderBefore)
+	}
+	if filters.MinSpend != nil {
+		query = query.Where("COALESCE(ms.total_spend, 0) >= ?", *filters.MinSpend)
+	}
+	if filters.MaxSpend != nil {
+		query = query.Where("COALESCE(ms.total_spend, 0) <= ?", *filters.MaxSpend)
+	}
+	if filters.CategoryIDs != nil {
+		query = query.Where("ms.favorite_category_id IN ?", filters.CategoryIDs)
+	}
+
+	var rows []CustomerSegmentRow
+	err := query.Order("total_spend DESC, u.id").Scan(&rows).Error
+	return rows, err
+}
+
+// FindLifetimeValues ranks the active members with a completed order and
+// counts them. A limit of zero returns every member.
+func (r *customerRepository) FindLifetimeValues(ranking CustomerRanking, limit, offset int) ([]CustomerValueRow, int64, error) {
+	query := r.db.Table("users AS u").
+		Joins("JOIN member_stats ms ON ms.user_id = u.id").
+		Where("u.role = ? AND u.is_active = ?", models.RoleMember, true)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.
+		Select(`u.uuid AS user_uuid, u.full_name, u.email, u.phone, u.created_at AS joined_at,
+			ms.order_count, ms.total_spend, ms.first_order_at, ms.last_order_at`).
+		Order(customerRankingOrder[ranking]).
+		Offset(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var rows []CustomerValueRow
+	err := query.Scan(&rows).Error
+	return rows, total, err
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDailySummaryNotFound = errors.New("daily summary not found")
+)
+
+// openOrderStatuses are the statuses of orders still being worked on
+var openOrderStatuses = []models.OrderStatus{
+	models.OrderStatusScheduled,
+	models.OrderStatusPending,
+	models.OrderStatusDepositPaid,
+	models.OrderStatusPreparing,
+	models.OrderStatusReady,
+}
+
+type DailySummaryRepository interface {
+	FindByDate(businessDate time.Time) (*models.DailySummary, error)
+	Preview(summary *models.DailySummary) error
+	Close(summary *models.DailySummary) ([]models.Order, error)
+}
+
+type dailySummaryRepository struct {
+	db *gorm.DB
+}
+
+func NewDailySummaryRepository(db *gorm.DB) DailySummaryRepository {
+	return &dailySummaryRepository{db: db}
+}
+
+// FindByDate returns the summary of the business day, compared as a calendar
+// date
+func (r *dailySummaryRepository) FindByDate(businessDate time.Time) (*models.DailySummary, error) {
+	var summary models.DailySummary
+	err := r.db.
+		Preload("ClosedBy").
+		Where("business_date = ?", businessDate.Format("2006-01-02")).
+		First(&summary).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDailySummaryNotFound
+		}
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Preview totals the day into the summary as Close would, without saving
+// it or touching the day's orders
+func (r *dailySummaryRepository) Preview(summary *models.DailySummary) error {
+	return totalDay(r.db, summary)
+}
+
+// Close totals the day of [PeriodStart, PeriodEnd) into the summary and
+// saves it. Every order of the day is marked closed; those still open are
+// also flagged and returned so staff can follow them up.
+func (r *dailySummaryRepository) Close(summary *models.DailySummary) ([]models.Order, error) {
+	var flagged []models.Order
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := totalDay(tx, summary); err != nil {
+			return err
+		}
+
+		summary.ClosedAt = time.Now()
+		if err := tx.Create(summary).Error; err != nil {
+			return err
+		}
+
+		period := dayOrders(tx, summary)
+		err := period.Session(&gorm.Session{}).
+			Where("status IN ?", openOrderStatuses).
+			Order("created_at ASC").
+			Find(&flagged).Error
+		if err != nil {
+			return err
+		}
+
+		if len(flagged) > 0 {
+			ids := make([]uint, len(flagged))
+			for i := range flagged {
+				ids[i] = flagged[i].ID
+				flagged[i].FlaggedAt = &summary.ClosedAt
+			}
+			err = tx.Model(&models.Order{}).Where("id IN ?", ids).Update("flagged_at", summary.ClosedAt).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return period.Session(&gorm.Session{}).
+			Where("closed_at IS NULL").
+			Update("closed_at", summary.ClosedAt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flagged, nil
+}
+
+// dayOrders scopes to the orders of [PeriodStart, PeriodEnd). Pre-orders
+// belong to the day they are scheduled for, other orders to the day they
+// were placed.
+func dayOrders(db *gorm.DB, summary *models.DailySummary) *gorm.DB {
+	return db.Model(&models.Order{}).
+		Where("COALESCE(scheduled_for, created_at) >= ? AND COALESCE(scheduled_for, created_at) < ?", summary.PeriodStart, summary.PeriodEnd)
+}
+
+// totalDay fills the summary with the totals of the day's orders, the order
+// payments settled and the refunds issued during the day
+func totalDay(db *gorm.DB, summary *models.DailySummary) error {
+	err := dayOrders(db, summary).
+		Select(`COUNT(*) AS order_count,
+			COUNT(*) FILTER (WHERE status = ?) AS completed_count,
+			COUNT(*) FILTER (WHERE status = ?) AS cancelled_count,
+			COUNT(*) FILTER (WHERE status IN ?) AS incomplete_count,
+			COUNT(*) FILTER (WHERE status IN ?) AS paid_count,
+			COALESCE(SUM(subtotal) FILTER (WHERE status IN ?), 0) AS subtotal,
+			COALESCE(SUM(discount) FILTER (WHERE status IN ?), 0) AS discount,
+			COALESCE(SUM(service_charge) FILTER (WHERE status IN ?), 0) AS service_charge,
+			COALESCE(SUM(tax) FILTER (WHERE status IN ?), 0) AS tax,
+			COALESCE(SUM(tip_amount) FILTER (WHERE status IN ?), 0) AS tip_amount,
+			COALESCE(SUM(total) FILTER (WHERE status IN ?), 0) AS total,
+			COALESCE(SUM(total) FILTER (WHERE status = ?), 0) AS cancelled_amount`,
+			models.OrderStatusCompleted, models.OrderStatusCancelled, openOrderStatuses,
+			revenueStatuses, revenueStatuses, revenueStatuses, revenueStatuses,
+			revenueStatuses, revenueStatuses, revenueStatuses, models.OrderStatusCancelled).
+		Scan(summary).Error
+	if err != nil {
+		return err
+	}
+
+	settled := []models.TransactionStatus{
+		models.TransactionStatusSettlement,
+		models.TransactionStatusRefund,
+		models.TransactionStatusPartialRefund,
+	}
+	gateways := []models.PaymentMethod{
+		models.PaymentMethodMidtrans, models.PaymentMethodStripe, models.PaymentMethodXendit,
+	}
+	storedValue := []models.PaymentMethod{
+		models.PaymentMethodWallet, models.PaymentMethodGiftCard, models.PaymentMethodPoints,
+	}
+	err = db.Table("payments").
+		Select(`COALESCE(SUM(gross_amount) FILTER (WHERE method = ?), 0) AS cash_amount,
+			COALESCE(SUM(gross_amount) FILTER (WHERE method IN ?), 0) AS gateway_amount,
+			COALESCE(SUM(gross_amount) FILTER (WHERE method IN ?), 0) AS stored_value_amount`,
+			models.PaymentMethodCash, gateways, storedValue).
+		Where("transaction_status IN ? AND settlement_time >= ? AND settlement_time < ?", settled, summary.PeriodStart, summary.PeriodEnd).
+		Scan(summary).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Table("refunds").
+		Select("COUNT(*) AS refund_count, COALESCE(SUM(amount), 0) AS refund_amount").
+		Where("created_at >= ? AND created_at < ?", summary.PeriodStart, summary.PeriodEnd).
+		Scan(summary).Error
+}
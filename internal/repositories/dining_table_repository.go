@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDiningTableNotFound = errors.New("dining table not found")
+)
+
+type DiningTableRepository interface {
+	Create(table *models.DiningTable) error
+	FindAll() ([]models.DiningTable, error)
+	FindByUUID(uuid uuid.UUID) (*models.DiningTable, error)
+	FindByNumber(number string) (*models.DiningTable, error)
+	FindByToken(token string) (*models.DiningTable, error)
+	Update(table *models.DiningTable) error
+	Delete(id uint) error
+}
+
+type diningTableRepository struct {
+	db *gorm.DB
+}
+
+func NewDiningTableRepository(db *gorm.DB) DiningTableRepository {
+	return &diningTableRepository{db: db}
+}
+
+func (r *diningTableRepository) Create(table *models.DiningTable) error {
+	return r.db.Create(table).Error
+}
+
+// FindAll returns every table ordered by number
+func (r *diningTableRepository) FindAll() ([]models.DiningTable, error) {
+	var tables []models.DiningTable
+	err := r.db.Order("number ASC").Find(&tables).Error
+	return tables, err
+}
+
+func (r *diningTableRepository) FindByUUID(uuid uuid.UUID) (*models.DiningTable, error) {
+	return r.findOne("uuid = ?", uuid)
+}
+
+func (r *diningTableRepository) FindByNumber(number string) (*models.DiningTable, error) {
+	return r.findOne("number = ?", number)
+}
+
+func (r *diningTableRepository) FindByToken(token string) (*models.DiningTable, error) {
+	return r.findOne("token = ?", token)
+}
+
+func (r *diningTableRepository) findOne(query string, args ...any) (*models.DiningTable, error) {
+	var table models.DiningTable
+	err := r.db.Where(query, args...).First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDiningTableNotFound
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *diningTableRepository) Update(table *models.DiningTable) error {
+	result := r.db.Model(&models.DiningTable{}).
+		Where("id = ?", table.ID).
+		Updates(map[string]any{
+			"number":     table.Number,
+			"token":      table.Token,
+			"is_active":  table.IsActive,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDiningTableNotFound
+	}
+	return nil
+}
+
+// Delete removes the table. Its orders keep the table number.
+func (r *diningTableRepository) Delete(id uint) error {
+	result := r.db.Where("id = ?", id).Delete(&models.DiningTable{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDiningTableNotFound
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEmailTemplateNotFound = errors.New("email template not found")
+)
+
+type EmailTemplateRepository interface {
+	FindActive(key models.EmailTemplateKey, locale string) (*models.EmailTemplate, error)
+	FindAllActive() ([]models.EmailTemplate, error)
+	FindVersions(key models.EmailTemplateKey, locale string) ([]models.EmailTemplate, error)
+	CreateVersion(template *models.EmailTemplate) error
+	Activate(key models.EmailTemplateKey, locale string, version int) error
+}
+
+type emailTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailTemplateRepository(db *gorm.DB) EmailTemplateRepository {
+	return &emailTemplateRepository{db: db}
+}
+
+func (r *emailTemplateRepository) FindActive(key models.EmailTemplateKey, locale string) (*models.EmailTemplate, error) {
+	var template models.EmailTemplate
+	err := r.db.Where("key = ? AND locale = ? AND is_active = ?", key, locale, true).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *emailTemplateRepository) FindAllActive() ([]models.EmailTemplate, error) {
+	var templates []models.EmailTemplate
+	err := r.db.Where("is_active = ?", true).Order("key ASC, locale ASC").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *emailTemplateRepository) FindVersions(key models.EmailTemplateKey, locale string) ([]models.EmailTemplate, error) {
+	var templates []models.EmailTemplate
+	err := r.db.Where("key = ? AND locale = ?", key, locale).Order("version DESC").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// CreateVersion stores template as the next version of its key and locale and makes it the active one
+func (r *emailTemplateRepository) CreateVersion(template *models.EmailTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&models.EmailTemplate{}).
+			Where("key = ? AND locale = ?", template.Key, template.Locale).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.EmailTemplate{}).
+			Where("key = ? AND locale = ? AND is_active = ?", template.Key, template.Locale, true).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+
+		template.Version = latest + 1
+		template.IsActive = true
+		return tx.Create(template).Error
+	})
+}
+
+// Activate switches the active template of key and locale to an existing version
+func (r *emailTemplateRepository) Activate(key models.EmailTemplateKey, locale string, version int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.EmailTemplate{}).
+			Where("key = ? AND locale = ? AND version = ?", key, locale, version).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrEmailTemplateNotFound
+		}
+
+		if err := tx.Model(&models.EmailTemplate{}).
+			Where("key = ? AND locale = ? AND is_active = ?", key, locale, true).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.EmailTemplate{}).
+			Where("key = ? AND locale = ? AND version = ?", key, locale, version).
+			Update("is_active", true).Error
+	})
+}
@@ -0,0 +1,251 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrGiftCardNotFound            = errors.New("gift card not found")
+	ErrGiftCardNotActive           = errors.New("gift card is not active")
+	ErrGiftCardInsufficientBalance = errors.New("gift card balance is too low")
+	ErrGiftCardExceedsAmountDue    = errors.New("gift card amount exceeds the amount due")
+)
+
+type GiftCardRepository interface {
+	Create(card *models.GiftCard) error
+	FindAll(status *models.GiftCardStatus, limit, offset int) ([]models.GiftCard, int64, error)
+	FindByUUID(uuid uuid.UUID) (*models.GiftCard, error)
+	FindByCode(code string) (*models.GiftCard, error)
+	FindByReference(reference string) (*models.GiftCard, error)
+	FindRedemptions(giftCardID uint) ([]models.GiftCardRedemption, error)
+	UpdatePurchase(card *models.GiftCard) error
+	Activate(card *models.GiftCard) (bool, error)
+	Void(id, voidedBy uint, reason string) (bool, error)
+	Redeem(redemption *models.GiftCardRedemption, settle *models.Payment) error
+	ReleaseByOrderID(orderID uint) error
+}
+
+type giftCardRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftCardRepository(db *gorm.DB) GiftCardRepository {
+	return &giftCardRepository{db: db}
+}
+
+func (r *giftCardRepository) Create(card *models.GiftCard) error {
+	return r.db.Create(card).Error
+}
+
+func (r *giftCardRepository) FindAll(status *models.GiftCardStatus, limit, offset int) ([]models.GiftCard, int64, error) {
+	var cards []models.GiftCard
+	var total int64
+
+	query := r.db.Model(&models.GiftCard{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&cards).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return cards, total, nil
+}
+
+func (r *giftCardRepository) FindByUUID(uuid uuid.UUID) (*models.GiftCard, error) {
+	return r.findOne("uuid = ?", uuid)
+}
+
+// FindByCode looks the code up as stored, in upper case
+func (r *giftCardRepository) FindByCode(code string) (*models.GiftCard, error) {
+	return r.findOne("code = ?", code)
+}
+
+func (r *giftCardRepository) FindByReference(reference string) (*models.GiftCard, error) {
+	return r.findOne("reference = ?", reference)
+}
+
+func (r *giftCardRepository) findOne(query string, args ...any) (*models.GiftCard, error) {
+	var card models.GiftCard
+	err := r.db.Where(query, args...).First(&card).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	return &card, nil
+}
+
+// FindRedemptions returns what the card paid for, newest first
+func (r *giftCardRepository) FindRedemptions(giftCardID uint) ([]models.GiftCardRedemption, error) {
+	var redemptions []models.GiftCardRedemption
+	err := r.db.Preload("Order").
+		Where("gift_card_id = ?", giftCardID).
+		Order("id DESC").
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+// UpdatePurchase records what the gateway reported about a purchase that has
+// not settled
+func (r *giftCardRepository) UpdatePurchase(card *models.GiftCard) error {
+	return r.db.Model(&models.GiftCard{}).
+		Where("id = ?", card.ID).
+		Updates(map[string]any{
+			"transaction_status": card.TransactionStatus,
+			"payment_type":       card.PaymentType,
+			"transaction_id":     card.TransactionID,
+			"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+}
+
+// Activate makes a purchased card spendable once its payment settled. It
+// reports false when the card was already activated, as when the gateway
+// repeats its notification.
+func (r *giftCardRepository) Activate(card *models.GiftCard) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&models.GiftCard{}).
+		Where("id = ? AND status = ?", card.ID, models.GiftCardStatusPending).
+		Updates(map[string]any{
+			"status":             models.GiftCardStatusActive,
+			"transaction_status": card.TransactionStatus,
+			"payment_type":       card.PaymentType,
+			"transaction_id":     card.TransactionID,
+			"activated_at":       now,
+			"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	card.Status = models.GiftCardStatusActive
+	card.ActivatedAt = &now
+	return true, nil
+}
+
+// Void stops an active card from being redeemed. It reports false when the
+// card was not active.
+func (r *giftCardRepository) Void(id, voidedBy uint, reason string) (bool, error) {
+	result := r.db.Model(&models.GiftCard{}).
+		Where("id = ? AND status = ?", id, models.GiftCardStatusActive).
+		Updates(map[string]any{
+			"status":      models.GiftCardStatusVoided,
+			"voided_at":   time.Now(),
+			"voided_by":   voidedBy,
+			"void_reason": reason,
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Redeem takes the redemption off the card's balance and the order's amount
+// due in one transaction. When settle is set the redemption covers the rest
+// of the order, so the payment is saved with it and the order moves from
+// pending to preparing.
+func (r *giftCardRepository) Redeem(redemption *models.GiftCardRedemption, settle *models.Payment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var card models.GiftCard
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", redemption.GiftCardID).
+			First(&card).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrGiftCardNotFound
+			}
+			return err
+		}
+		if card.Status != models.GiftCardStatusActive {
+			return ErrGiftCardNotActive
+		}
+		if card.Balance < redemption.Amount {
+			return ErrGiftCardInsufficientBalance
+		}
+
+		updates := map[string]any{
+			"gift_card_amount": gorm.Expr("gift_card_amount + ?", redemption.Amount),
+		}
+		if settle != nil {
+			updates["status"] = models.OrderStatusPreparing
+			updates["preparing_at"] = time.Now()
+		}
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", redemption.OrderID, models.OrderStatusPending).
+			Where("total + tip_amount - gift_card_amount - points_amount >= ?", redemption.Amount).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrGiftCardExceedsAmountDue
+		}
+
+		err = tx.Model(&models.GiftCard{}).
+			Where("id = ?", card.ID).
+			Updates(map[string]any{
+				"balance":    gorm.Expr("balance - ?", redemption.Amount),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(redemption).Error; err != nil {
+			return err
+		}
+		if settle != nil {
+			return tx.Create(settle).Error
+		}
+		return nil
+	})
+}
+
+// ReleaseByOrderID puts what the order redeemed back on its gift cards, once
+func (r *giftCardRepository) ReleaseByOrderID(orderID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return releaseGiftCardRedemptions(tx, orderID)
+	})
+}
+
+// releaseGiftCardRedemptions returns the unreleased redemptions of an order
+// to their cards
+func releaseGiftCardRedemptions(tx *gorm.DB, orderID uint) error {
+	var redemptions []models.GiftCardRedemption
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND released_at IS NULL", orderID).
+		Find(&redemptions).Error
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, redemption := range redemptions {
+		err := tx.Model(&models.GiftCard{}).
+			Where("id = ?", redemption.GiftCardID).
+			Updates(map[string]any{
+				"balance":    gorm.Expr("balance + ?", redemption.Amount),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&models.GiftCardRedemption{}).Where("id = ?", redemption.ID).Update("released_at", now).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
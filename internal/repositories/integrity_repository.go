@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAnomalyNotFound = errors.New("anomaly not found")
+)
+
+// amountTolerance absorbs rounding between the float totals computed at
+// checkout and the decimal(10,2) columns they are stored in
+const amountTolerance = 0.01
+
+// anomalyChecks insert one row per anomaly found. Anomalies already recorded,
+// including resolved ones, are skipped by the unique index.
+var anomalyChecks = []string{
+	`INSERT INTO order_anomalies (order_id, order_item_id, kind, detail)
+	SELECT oi.order_id, oi.id, 'unlinked_item',
+		format('Item "%s" at %s no longer matches any product', oi.product_name, oi.unit_price)
+	FROM order_items oi
+	WHERE oi.product_id IS NULL
+		AND NOT EXISTS (SELECT 1 FROM products p WHERE p.name = oi.product_name)
+	ON CONFLICT DO NOTHING`,
+
+	`INSERT INTO order_anomalies (order_id, order_item_id, kind, detail)
+	SELECT oi.order_id, oi.id, 'item_subtotal_mismatch',
+		format('Item "%s" subtotal %s does not equal %s x %s', oi.product_name, oi.subtotal, oi.quantity, oi.unit_price)
+	FROM order_items oi
+	WHERE ABS(oi.unit_price * oi.quantity - oi.subtotal) > @tolerance
+	ON CONFLICT DO NOTHING`,
+
+	`INSERT INTO order_anomalies (order_id, kind, detail)
+	SELECT o.id, 'subtotal_mismatch',
+		format('Subtotal %s does not equal item subtotals %s', o.subtotal, items.subtotal)
+	FROM orders o
+	JOIN (SELECT order_id, SUM(subtotal) AS subtotal FROM order_items GROUP BY order_id) items ON items.order_id = o.id
+	WHERE ABS(o.subtotal - items.subtotal) > @tolerance
+	ON CONFLICT DO NOTHING`,
+
+	`INSERT INTO order_anomalies (order_id, kind, detail)
+	SELECT o.id, 'total_mismatch',
+		format('Total %s does not equal subtotal %s - discount %s + service charge %s + tax %s + source fee %s', o.total, o.subtotal, o.discount, o.service_charge, o.tax, o.source_fee)
+	FROM orders o
+	WHERE ABS(o.subtotal - o.discount + o.service_charge + o.tax + o.source_fee - o.total) > @tolerance
+	ON CONFLICT DO NOTHING`,
+}
+
+type IntegrityRepository interface {
+	DetectAnomalies() (int64, error)
+	FindAnomalies(includeResolved bool) ([]models.OrderAnomaly, error)
+	FindAnomalyByUUID(uuid uuid.UUID) (*models.OrderAnomaly, error)
+	Resolve(id uint) error
+}
+
+type integrityRepository struct {
+	db *gorm.DB
+}
+
+func NewIntegrityRepository(db *gorm.DB) IntegrityRepository {
+	return &integrityRepository{db: db}
+}
+
+// DetectAnomalies runs every check and returns how many new anomalies were
+// recorded
+func (r *integrityRepository) DetectAnomalies() (int64, error) {
+	var detected int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, check := range anomalyChecks {
+			result := tx.Exec(check, map[string]any{"tolerance": amountTolerance})
+			if result.Error != nil {
+				return result.Error
+			}
+			detected += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return detected, nil
+}
+
+func (r *integrityRepository) FindAnomalies(includeResolved bool) ([]models.OrderAnomaly, error) {
+	var anomalies []models.OrderAnomaly
+	query := r.db.Preload("Order")
+	if !includeResolved {
+		query = query.Where("resolved_at IS NULL")
+	}
+	err := query.Order("detected_at DESC, id DESC").Find(&anomalies).Error
+	if err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+func (r *integrityRepository) FindAnomalyByUUID(uuid uuid.UUID) (*models.OrderAnomaly, error) {
+	var anomaly models.OrderAnomaly
+	err := r.db.Preload("Order").Where("uuid = ?", uuid).First(&anomaly).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAnomalyNotFound
+		}
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+func (r *integrityRepository) Resolve(id uint) error {
+	return r.db.Model(&models.OrderAnomaly{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Update("resolved_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+}
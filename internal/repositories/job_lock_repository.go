@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JobLockRepository interface {
+	Acquire(name, owner string, ttl time.Duration) (bool, error)
+	Release(name, owner string) error
+}
+
+type jobLockRepository struct {
+	db *gorm.DB
+}
+
+func NewJobLockRepository(db *gorm.DB) JobLockRepository {
+	return &jobLockRepository{db: db}
+}
+
+// Acquire takes or renews the named lease for ttl. It succeeds when the lease
+// is free, expired, or already held by owner. Expiry uses the database clock
+// so instances with skewed clocks agree on who holds the lease.
+func (r *jobLockRepository) Acquire(name, owner string, ttl time.Duration) (bool, error) {
+	result := r.db.Exec(`
+		INSERT INTO job_locks (name, owner, expires_at, acquired_at)
+		VALUES (?, ?, NOW() + ? * INTERVAL '1 millisecond', NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at, acquired_at = EXCLUDED.acquired_at
+		WHERE job_locks.owner = EXCLUDED.owner OR job_locks.expires_at < NOW()`,
+		name, owner, ttl.Milliseconds(),
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release gives up the lease if owner still holds it
+func (r *jobLockRepository) Release(name, owner string) error {
+	return r.db.Exec("DELETE FROM job_locks WHERE name = ? AND owner = ?", name, owner).Error
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrKioskNotFound = errors.New("kiosk not found")
+)
+
+type KioskRepository interface {
+	Create(kiosk *models.Kiosk) error
+	FindAll() ([]models.Kiosk, error)
+	FindByUUID(uuid uuid.UUID) (*models.Kiosk, error)
+	FindByName(name string) (*models.Kiosk, error)
+	FindByAPIKeyHash(hash string) (*models.Kiosk, error)
+	Update(kiosk *models.Kiosk) error
+	Delete(id uint) error
+	RecordHeartbeat(id uint, seenAt time.Time, ip string, appVersion *string) error
+}
+
+type kioskRepository struct {
+	db *gorm.DB
+}
+
+func NewKioskRepository(db *gorm.DB) KioskRepository {
+	return &kioskRepository{db: db}
+}
+
+func (r *kioskRepository) Create(kiosk *models.Kiosk) error {
+	return r.db.Create(kiosk).Error
+}
+
+// FindAll returns every kiosk ordered by name
+func (r *kioskRepository) FindAll() ([]models.Kiosk, error) {
+	var kiosks []models.Kiosk
+	err := r.db.Order("name ASC").Find(&kiosks).Error
+	return kiosks, err
+}
+
+func (r *kioskRepository) FindByUUID(uuid uuid.UUID) (*models.Kiosk, error) {
+	return r.findOne("uuid = ?", uuid)
+}
+
+func (r *kioskRepository) FindByName(name string) (*models.Kiosk, error) {
+	return r.findOne("name = ?", name)
+}
+
+func (r *kioskRepository) FindByAPIKeyHash(hash string) (*models.Kiosk, error) {
+	return r.findOne("api_key_hash = ?", hash)
+}
+
+func (r *kioskRepository) findOne(query string, args ...any) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	err := r.db.Where(query, args...).First(&kiosk).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrKioskNotFound
+		}
+		return nil, err
+	}
+	return &kiosk, nil
+}
+
+func (r *kioskRepository) Update(kiosk *models.Kiosk) error {
+	result := r.db.Model(&models.Kiosk{}).
+		Where("id = ?", kiosk.ID).
+		Updates(map[string]any{
+			"name":         kiosk.Name,
+			"api_key_hash": kiosk.APIKeyHash,
+			"is_active":    kiosk.IsActive,
+			"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrKioskNotFound
+	}
+	return nil
+}
+
+// Delete removes the kiosk. Its orders stay, without the kiosk.
+func (r *kioskRepository) Delete(id uint) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Kiosk{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrKioskNotFound
+	}
+	return nil
+}
+
+// RecordHeartbeat marks the kiosk as seen at seenAt. The app version is kept when
+// the heartbeat does not report one.
+func (r *kioskRepository) RecordHeartbeat(id uint, seenAt time.Time, ip string, appVersion *string) error {
+	updates := map[string]any{
+		"last_seen_at": seenAt,
+		"last_ip":      ip,
+	}
+	if appVersion != nil {
+		updates["app_version"] = *appVersion
+	}
+
+	result := r.db.Model(&models.Kiosk{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrKioskNotFound
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrLoginCodeNotFound = errors.New("login code not found")
+
+type LoginCodeRepository interface {
+	Create(code *models.LoginCode) error
+	CountSince(userID uint, since time.Time) (int64, error)
+	FindLatestActive(userID uint) (*models.LoginCode, error)
+	ReserveAttempt(id uint, maxAttempts int) (bool, error)
+	Consume(id uint) (bool, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type loginCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginCodeRepository(db *gorm.DB) LoginCodeRepository {
+	return &loginCodeRepository{db: db}
+}
+
+func (r *loginCodeRepository) Create(code *models.LoginCode) error {
+	return r.db.Create(code).Error
+}
+
+func (r *loginCodeRepository) CountSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.LoginCode{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// FindLatestActive returns the most recently issued code that is unused and
+// not yet expired. Requesting a new code makes earlier ones unusable.
+func (r *loginCodeRepository) FindLatestActive(userID uint) (*models.LoginCode, error) {
+	var latest models.LoginCode
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").First(&latest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLoginCodeNotFound
+		}
+		return nil, err
+	}
+	if latest.ConsumedAt != nil || !latest.ExpiresAt.After(time.Now()) {
+		return nil, ErrLoginCodeNotFound
+	}
+	return &latest, nil
+}
+
+// ReserveAttempt counts a guess against the code before it is checked. It
+// reports false when the code is used up, already consumed, or another
+// guess took the last attempt.
+func (r *loginCodeRepository) ReserveAttempt(id uint, maxAttempts int) (bool, error) {
+	result := r.db.Model(&models.LoginCode{}).
+		Where("id = ? AND attempts < ? AND consumed_at IS NULL", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Consume marks the code as used. It reports false when a concurrent login
+// used the code first.
+func (r *loginCodeRepository) Consume(id uint) (bool, error) {
+	result := r.db.Model(&models.LoginCode{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *loginCodeRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.LoginCode{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
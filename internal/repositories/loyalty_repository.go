@@ -0,0 +1,398 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientPoints    = errors.New("insufficient loyalty points")
+	ErrPointsExceedAmountDue = errors.New("points value exceeds the amount due")
+)
+
+// PointLot is what is left of one earn or refund entry once spent and
+// expired points are taken from the oldest entries first
+type PointLot struct {
+	Points   int
+	EarnedAt time.Time
+}
+
+type LoyaltyRepository interface {
+	Balance(userID uint) (int, error)
+	FindTransactions(userID uint, limit, offset int) ([]models.PointTransaction, int64, error)
+	Redeem(entry *models.PointTransaction, amount float64, settle *models.Payment) error
+	ReleaseByOrderID(orderID uint) error
+	FindUnspentLots(userID uint) ([]PointLot, error)
+	ExpirePoints(earnedBefore time.Time) (int, error)
+	FindExpiryWarningUserIDs(earnedBefore time.Time) ([]uint, error)
+	MarkExpiryWarned(userID uint, earnedBefore time.Time) (bool, error)
+
+	StampBalance(userID uint) (int, error)
+	StampCategoryIDs(categoryUUID uuid.UUID) ([]uint, error)
+	EarnStamps(orderID, userID uint, stamps int) error
+	RedeemStamps(userID uint, goal int, reward *models.Voucher) (bool, error)
+	FindStampRewards(userID uint) ([]models.Voucher, error)
+}
+
+type loyaltyRepository struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) LoyaltyRepository {
+	return &loyaltyRepository{db: db}
+}
+
+// Balance is the balance after the member's latest ledger entry, zero for a
+// member who never earned points
+func (r *loyaltyRepository) Balance(userID uint) (int, error) {
+	return pointBalance(r.db, userID)
+}
+
+// FindTransactions returns the newest entries first, with the order each
+// entry was for
+func (r *loyaltyRepository) FindTransactions(userID uint, limit, offset int) ([]models.PointTransaction, int64, error) {
+	var entries []models.PointTransaction
+	var total int64
+
+	query := r.db.Model(&models.PointTransaction{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Order").
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// Redeem spends the entry's points and takes amount off the order's amount
+// due in one transaction. When settle is set the points cover the rest of the
+// order, so the payment is saved with it and the order moves from pending to
+// preparing. It returns ErrInsufficientPoints or ErrPointsExceedAmountDue
+// without changing anything.
+func (r *loyaltyRepository) Redeem(entry *models.PointTransaction, amount float64, settle *models.Payment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := postPointEntry(tx, entry); err != nil {
+			return err
+		}
+
+		updates := map[string]any{
+			"points_redeemed": gorm.Expr("points_redeemed + ?", -entry.Points),
+			"points_amount":   gorm.Expr("points_amount + ?", amount),
+		}
+		if settle != nil {
+			updates["status"] = models.OrderStatusPreparing
+			updates["preparing_at"] = time.Now()
+		}
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", *entry.OrderID, models.OrderStatusPending).
+			Where("total + tip_amount - gift_card_amount - points_amount >= ?", amount).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPointsExceedAmountDue
+		}
+
+		if settle != nil {
+			return tx.Create(settle).Error
+		}
+		return nil
+	})
+}
+
+// ReleaseByOrderID gives the points spent on the order back to the member,
+// once
+func (r *loyaltyRepository) ReleaseByOrderID(orderID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return refundOrderPoints(tx, orderID)
+	})
+}
+
+// FindUnspentLots returns the member's points that are neither spent nor
+// expired, oldest first
+func (r *loyaltyRepository) FindUnspentLots(userID uint) ([]PointLot, error) {
+	var used int
+	err := r.db.Model(&models.PointTransaction{}).
+		Select("COALESCE(-SUM(points), 0)").
+		Where("user_id = ? AND points < 0", userID).
+		Scan(&used).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var credits []models.PointTransaction
+	err = r.db.Select("points", "created_at").
+		Where("user_id = ? AND points > 0", userID).
+		Order("id").
+		Find(&credits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var lots []PointLot
+	for _, credit := range credits {
+		taken := min(used, credit.Points)
+		used -= taken
+		if credit.Points > taken {
+			lots = append(lots, PointLot{Points: credit.Points - taken, EarnedAt: credit.CreatedAt})
+		}
+	}
+	return lots, nil
+}
+
+// expiringPointsSQL is how many of a member's points earned up to the given
+// time are left: what they earned by then less everything spent or expired,
+// which always comes out of the oldest points first
+const expiringPointsSQL = "SUM(CASE WHEN points > 0 AND created_at <= ? THEN points ELSE 0 END) + SUM(CASE WHEN points < 0 THEN points ELSE 0 END)"
+
+// ExpirePoints posts an expire entry for every member with points left that
+// were earned before earnedBefore, and returns how many members lost points.
+// Each member's points are expired in their own transaction.
+func (r *loyaltyRepository) ExpirePoints(earnedBefore time.Time) (int, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.PointTransaction{}).
+		Group("user_id").
+		Having(expiringPointsSQL+" > 0", earnedBefore).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, userID := range userIDs {
+		posted := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", userID).Error; err != nil {
+				return err
+			}
+			var points int
+			err := tx.Model(&models.PointTransaction{}).
+				Select("COALESCE("+expiringPointsSQL+", 0)", earnedBefore).
+				Where("user_id = ?", userID).
+				Scan(&points).Error
+			if err != nil || points <= 0 {
+				return err
+			}
+
+			posted = true
+			return postPointEntry(tx, &models.PointTransaction{
+				UserID: userID,
+				Type:   models.PointTransactionExpire,
+				Points: -points,
+			})
+		})
+		if err != nil {
+			return expired, err
+		}
+		if posted {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// FindExpiryWarningUserIDs returns the members with points left that were
+// earned before earnedBefore and that they have not been warned about
+func (r *loyaltyRepository) FindExpiryWarningUserIDs(earnedBefore time.Time) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.PointTransaction{}).
+		Group("user_id").
+		Having(expiringPointsSQL+" > 0", earnedBefore).
+		Having("COUNT(*) FILTER (WHERE points > 0 AND created_at <= ? AND expiry_warned_at IS NULL) > 0", earnedBefore).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// MarkExpiryWarned records that the member was warned about the points they
+// earned before earnedBefore. It reports false when another run already did,
+// so each warning is sent once.
+func (r *loyaltyRepository) MarkExpiryWarned(userID uint, earnedBefore time.Time) (bool, error) {
+	result := r.db.Model(&models.PointTransaction{}).
+		Where("user_id = ? AND points > 0 AND created_at <= ? AND expiry_warned_at IS NULL", userID, earnedBefore).
+		Update("expiry_warned_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// postPointEntry adds the entry to the ledger, holding the member's row so
+// concurrent entries see each other's balance
+func postPointEntry(tx *gorm.DB, entry *models.PointTransaction) error {
+	if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", entry.UserID).Error; err != nil {
+		return err
+	}
+
+	balance, err := pointBalance(tx, entry.UserID)
+	if err != nil {
+		return err
+	}
+	after := balance + entry.Points
+	if after < 0 {
+		return ErrInsufficientPoints
+	}
+
+	entry.BalanceAfter = after
+	return tx.Create(entry).Error
+}
+
+// refundOrderPoints posts a refund of whatever the order's redemptions spent
+// and earlier refunds have not yet given back
+func refundOrderPoints(tx *gorm.DB, orderID uint) error {
+	// Holding the order keeps a cancellation and a refund from both paying out
+	if err := tx.Exec("SELECT id FROM orders WHERE id = ? FOR UPDATE", orderID).Error; err != nil {
+		return err
+	}
+
+	var owed struct {
+		UserID uint
+		Points int
+	}
+	err := tx.Model(&models.PointTransaction{}).
+		Select("MIN(user_id) AS user_id, COALESCE(-SUM(points), 0) AS points").
+		Where("order_id = ? AND type IN ?", orderID, []models.PointTransactionType{models.PointTransactionRedeem, models.PointTransactionRefund}).
+		Scan(&owed).Error
+	if err != nil {
+		return err
+	}
+	if owed.Points <= 0 {
+		return nil
+	}
+
+	return postPointEntry(tx, &models.PointTransaction{
+		UserID:  owed.UserID,
+		Type:    models.PointTransactionRefund,
+		Points:  owed.Points,
+		OrderID: &orderID,
+	})
+}
+
+// StampBalance is the number of stamps on the member's card
+func (r *loyaltyRepository) StampBalance(userID uint) (int, error) {
+	return stampBalance(r.db, userID)
+}
+
+// StampCategoryIDs returns the category and every live category nested under
+// it. A category that no longer exists has nothing in it.
+func (r *loyaltyRepository) StampCategoryIDs(categoryUUID uuid.UUID) ([]uint, error) {
+	var rootIDs []uint
+	err := r.db.Model(&models.Category{}).Where("uuid = ?", categoryUUID).Pluck("id", &rootIDs).Error
+	if err != nil || len(rootIDs) == 0 {
+		return nil, err
+	}
+	return categoryTreeIDs(r.db, rootIDs[0])
+}
+
+// EarnStamps adds the stamps earned on the order to the member's card. An
+// order earns stamps once; a second award fails on the unique index.
+func (r *loyaltyRepository) EarnStamps(orderID, userID uint, stamps int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return postStampEntry(tx, &models.StampEntry{
+			UserID:  userID,
+			Stamps:  stamps,
+			OrderID: &orderID,
+		})
+	})
+}
+
+// RedeemStamps swaps goal stamps for the reward voucher in one transaction.
+// It reports false, without issuing the voucher, when the card is not full.
+func (r *loyaltyRepository) RedeemStamps(userID uint, goal int, reward *models.Voucher) (bool, error) {
+	redeemed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", userID).Error; err != nil {
+			return err
+		}
+		balance, err := stampBalance(tx, userID)
+		if err != nil {
+			return err
+		}
+		if balance < goal {
+			return nil
+		}
+
+		if err := tx.Omit(clause.Associations).Create(reward).Error; err != nil {
+			return err
+		}
+		issued := models.VoucherEvent{
+			VoucherID: reward.ID,
+			Action:    models.VoucherActionIssued,
+			Note:      &reward.Reason,
+		}
+		if err := tx.Create(&issued).Error; err != nil {
+			return err
+		}
+
+		redeemed = true
+		return postStampEntry(tx, &models.StampEntry{
+			UserID:    userID,
+			Stamps:    -goal,
+			VoucherID: &reward.ID,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return redeemed, nil
+}
+
+// FindStampRewards returns the vouchers the member's full cards were swapped
+// for, newest first
+func (r *loyaltyRepository) FindStampRewards(userID uint) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	err := r.db.Preload("Order").
+		Where("id IN (?)", r.db.Model(&models.StampEntry{}).Select("voucher_id").Where("user_id = ? AND voucher_id IS NOT NULL", userID)).
+		Order("created_at DESC, id DESC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+// postStampEntry adds the entry to the stamp card ledger, holding the
+// member's row so concurrent entries see each other's balance
+func postStampEntry(tx *gorm.DB, entry *models.StampEntry) error {
+	if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", entry.UserID).Error; err != nil {
+		return err
+	}
+
+	balance, err := stampBalance(tx, entry.UserID)
+	if err != nil {
+		return err
+	}
+	entry.BalanceAfter = balance + entry.Stamps
+	return tx.Create(entry).Error
+}
+
+func stampBalance(db *gorm.DB, userID uint) (int, error) {
+	var balance int
+	err := db.Model(&models.StampEntry{}).
+		Select("balance_after").
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(1).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func pointBalance(db *gorm.DB, userID uint) (int, error) {
+	var balance int
+	err := db.Model(&models.PointTransaction{}).
+		Select("balance_after").
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(1).
+		Scan(&balance).Error
+	return balance, err
+}
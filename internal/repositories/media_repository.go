@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MediaUsage sums the size of tracked media files
+type MediaUsage struct {
+	FileCount  int64
+	TotalBytes int64
+}
+
+type MediaRepository interface {
+	Create(file *models.MediaFile) error
+	Usage() (*MediaUsage, error)
+	FindUnreferenced(createdBefore time.Time) ([]models.MediaFile, error)
+	Delete(id uint) error
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) Create(file *models.MediaFile) error {
+	return r.db.Create(file).Error
+}
+
+func (r *mediaRepository) Usage() (*MediaUsage, error) {
+	var usage MediaUsage
+	err := r.db.Model(&models.MediaFile{}).
+		Select("COUNT(*) AS file_count, COALESCE(SUM(size_bytes), 0) AS total_bytes").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// FindUnreferenced returns files uploaded before createdBefore that no product
+// or category image points to. Products and categories in the trash still
+// count as references so restoring them keeps their image.
+func (r *mediaRepository) FindUnreferenced(createdBefore time.Time) ([]models.MediaFile, error) {
+	var files []models.MediaFile
+	err := r.db.
+		Where("created_at < ?", createdBefore).
+		Where("NOT EXISTS (SELECT 1 FROM products WHERE products.image_url = media_files.url)").
+		Where("NOT EXISTS (SELECT 1 FROM categories WHERE categories.image_url = media_files.url)").
+		Order("created_at ASC").
+		Find(&files).Error
+	return files, err
+}
+
+func (r *mediaRepository) Delete(id uint) error {
+	return r.db.Where("id = ?", id).Delete(&models.MediaFile{}).Error
+}
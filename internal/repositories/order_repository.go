@@ -2,56 +2,114 @@ package repositories
 
 import (
 	"errors"
-	"fmt"
-	"strconv"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
 	ErrOrderNotFound        = errors.New("order not found")
 	ErrInvalidOrderStatus   = errors.New("invalid order status")
 	ErrOrderNumberGenFailed = errors.New("failed to generate order number")
+	ErrOrderAlreadyClaimed  = errors.New("order already claimed")
+	ErrOrderNotEditable     = errors.New("order can no longer be edited")
 )
 
+// OrderFilters narrows the order list. StartDate is inclusive and EndDate
+// exclusive.
 type OrderFilters struct {
 	Status      *models.OrderStatus
 	OrderSource *models.OrderSource
+	OrderType   *models.OrderType
 	StartDate   *time.Time
 	EndDate     *time.Time
 }
 
+// PickupLoad is one order that counts towards the kitchen load at its pickup
+// time, with the number of drinks in it
+type PickupLoad struct {
+	PickupAt time.Time
+	Drinks   int
+}
+
 type OrderRepository interface {
 	Create(order *models.Order, items []models.OrderItem) error
 	FindByID(id uint) (*models.Order, error)
 	FindByUUID(uuid uuid.UUID) (*models.Order, error)
 	FindByOrderNumber(orderNumber string) (*models.Order, error)
+	FindByShareToken(token string) (*models.Order, error)
 	FindByUserID(userID uint, limit, offset int) ([]models.Order, int64, error)
 	FindAll(filters OrderFilters, limit, offset int) ([]models.Order, int64, error)
+	FindByStatuses(statuses []models.OrderStatus) ([]models.Order, error)
+	FindDueScheduled(before time.Time) ([]models.Order, error)
+	FindPickupLoads(from, to time.Time) ([]PickupLoad, error)
 	UpdateStatus(orderID uint, status models.OrderStatus) error
+	UpdateTip(orderID uint, tipAmount float64) error
+	AwardPoints(orderID, userID uint, points int) error
+	UpdatePriority(orderID uint, priority models.Priority) error
+	ReleaseScheduled(orderID uint, paymentExpiresAt *time.Time) (bool, error)
+	Claim(orderID, userID uint) error
+	ReplaceItems(order *models.Order, items []models.OrderItem) error
+	MarkConfirmationSent(orderID uint) (bool, error)
+	MarkReceiptSent(orderID uint) (bool, error)
+	MarkReadyNotified(orderID uint) (bool, error)
+	Delete(orderID uint) error
 
 	GenerateOrderNumber() (string, error)
+	NextQueueNumber() (int, error)
 }
 
+// maxOrderNumberAttempts bounds how many taken numbers GenerateOrderNumber
+// skips before giving up
+const maxOrderNumberAttempts = 100
+
 type orderRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	numbers *utils.OrderNumberFormat
 }
 
-func NewOrderRepository(db *gorm.DB) OrderRepository {
-	return &orderRepository{db: db}
+func NewOrderRepository(db *gorm.DB, numbers *utils.OrderNumberFormat) OrderRepository {
+	return &orderRepository{db: db, numbers: numbers}
 }
 
+// Create saves the order with its items and applied promotions. The row of
+// each promotion is locked while its usage limits are re-checked, so
+// concurrent checkouts cannot push it past them. Rows are locked in ID order
+// so two checkouts never wait on each other. A voucher on the order is
+// claimed for it in the same transaction.
 func (r *orderRepository) Create(order *models.Order, items []models.OrderItem) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		var promotionIDs []uint
+		for _, applied := range order.Promotions {
+			if applied.PromotionID != nil {
+				promotionIDs = append(promotionIDs, *applied.PromotionID)
+			}
+		}
+		slices.Sort(promotionIDs)
+		for _, promotionID := range promotionIDs {
+			if err := checkPromotionLimits(tx, promotionID, order.UserID); err != nil {
+				return err
+			}
+		}
+
 		// Create the order
 		if err := tx.Create(order).Error; err != nil {
 			return err
 		}
 
+		for _, applied := range order.Promotions {
+			if applied.VoucherID != nil {
+				if err := redeemVoucher(tx, *applied.VoucherID, order); err != nil {
+					return err
+				}
+			}
+		}
+
 		// Set OrderID for all items
 		for i := range items {
 			items[i].OrderID = order.ID
@@ -72,8 +130,10 @@ func (r *orderRepository) FindByID(id uint) (*models.Order, error) {
 	var order models.Order
 	err := r.db.
 		Preload("User").
+		Preload("AssignedTo").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Promotions").
 		Preload("Payments").
 		Where("id = ?", id).
 		First(&order).Error
@@ -90,8 +150,10 @@ func (r *orderRepository) FindByID(id uint) (*models.Order, error) {
 func (r *orderRepository) FindByUUID(uuid uuid.UUID) (*models.Order, error) {
 	var order models.Order
 	err := r.db.
+		Preload("AssignedTo").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Promotions").
 		Where("uuid = ?", uuid).
 		First(&order).Error
 
@@ -108,8 +170,10 @@ func (r *orderRepository) FindByOrderNumber(orderNumber string) (*models.Order,
 	var order models.Order
 	err := r.db.
 		Preload("User").
+		Preload("AssignedTo").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Promotions").
 		Preload("Payments").
 		Where("order_number = ?", orderNumber).
 		First(&order).Error
@@ -123,6 +187,22 @@ func (r *orderRepository) FindByOrderNumber(orderNumber string) (*models.Order,
 	return &order, nil
 }
 
+func (r *orderRepository) FindByShareToken(token string) (*models.Order, error) {
+	var order models.Order
+	err := r.db.
+		Preload("Items").
+		Where("share_token = ?", token).
+		First(&order).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (r *orderRepository) FindByUserID(userID uint, limit, offset int) ([]models.Order, int64, error) {
 	var orders []models.Order
 	var total int64
@@ -134,6 +214,7 @@ func (r *orderRepository) FindByUserID(userID uint, limit, offset int) ([]models
 
 	// Get paginated orders
 	err := r.db.
+		Preload("AssignedTo").
 		Preload("Items").
 		Preload("Items.Product").
 		Where("user_id = ?", userID).
@@ -164,12 +245,16 @@ func (r *orderRepository) FindAll(filters OrderFilters, limit, offset int) ([]mo
 		query = query.Where("order_source = ?", *filters.OrderSource)
 	}
 
+	if filters.OrderType != nil {
+		query = query.Where("order_type = ?", *filters.OrderType)
+	}
+
 	if filters.StartDate != nil {
 		query = query.Where("created_at >= ?", *filters.StartDate)
 	}
 
 	if filters.EndDate != nil {
-		query = query.Where("created_at <= ?", *filters.EndDate)
+		query = query.Where("created_at < ?", *filters.EndDate)
 	}
 
 	// Count total
@@ -180,8 +265,10 @@ func (r *orderRepository) FindAll(filters OrderFilters, limit, offset int) ([]mo
 	// Get paginated orders with preloads
 	err := query.
 		Preload("User").
+		Preload("AssignedTo").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Promotions").
 		Preload("Payments").
 		Order("created_at DESC").
 		Limit(limit).
@@ -195,55 +282,348 @@ func (r *orderRepository) FindAll(filters OrderFilters, limit, offset int) ([]mo
 	return orders, total, nil
 }
 
-func (r *orderRepository) UpdateStatus(orderID uint, status models.OrderStatus) error {
-	updates := map[string]any{
-		"status": status,
+// FindByStatuses returns every order in the given statuses, longest queued
+// first. Orders of closed business days are left out since they can no longer move.
+func (r *orderRepository) FindByStatuses(statuses []models.OrderStatus) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.
+		Preload("AssignedTo").
+		Preload("Items").
+		Where("status IN ? AND closed_at IS NULL", statuses).
+		Order("COALESCE(released_at, created_at) ASC").
+		Find(&orders).Error
+
+	if err != nil {
+		return nil, err
 	}
+	return orders, nil
+}
 
+// FindDueScheduled returns pre-orders still waiting in the scheduled status
+// whose scheduled time is at or before the given time, soonest first
+func (r *orderRepository) FindDueScheduled(before time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.
+		Where("status = ? AND scheduled_for <= ?", models.OrderStatusScheduled, before).
+		Order("scheduled_for ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// FindPickupLoads returns the orders that are not cancelled and due for
+// pickup in [from, to). Pre-orders are due at their scheduled time and other
+// orders when they were placed.
+func (r *orderRepository) FindPickupLoads(from, to time.Time) ([]PickupLoad, error) {
+	var loads []PickupLoad
+	err := r.db.Raw(`
+		SELECT COALESCE(o.scheduled_for, o.created_at) AS pickup_at,
+			COALESCE(SUM(oi.quantity), 0) AS drinks
+		FROM orders o
+		LEFT JOIN order_items oi ON oi.order_id = o.id
+		WHERE o.status <> ?
+			AND COALESCE(o.scheduled_for, o.created_at) >= ?
+			AND COALESCE(o.scheduled_for, o.created_at) < ?
+		GROUP BY o.id`, models.OrderStatusCancelled, from, to).
+		Scan(&loads).Error
+	return loads, err
+}
+
+func (r *orderRepository) UpdateStatus(orderID uint, status models.OrderStatus) error {
+	updates := orderStatusUpdates(status)
+
+	// The public status page stops working once the order is handed over
 	if status == models.OrderStatusCompleted {
 		updates["completed_at"] = time.Now()
+		updates["share_token"] = nil
+		updates["share_token_expires_at"] = nil
+	}
+
+	// A cancelled order gives back what it redeemed from gift cards, points
+	// and vouchers
+	if status == models.OrderStatusCancelled {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Order{}).Where("id = ?", orderID).Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := releaseGiftCardRedemptions(tx, orderID); err != nil {
+				return err
+			}
+			if err := releaseOrderVouchers(tx, orderID); err != nil {
+				return err
+			}
+			return refundOrderPoints(tx, orderID)
+		})
 	}
 
 	return r.db.Model(&models.Order{}).Where("id = ?", orderID).Updates(updates).Error
 }
 
-func (r *orderRepository) GenerateOrderNumber() (string, error) {
-	var orderNumber string
-
-	err := r.db.Transaction(func(tx *gorm.DB) error {
-		// Get current date in YYMMDD format
-		today := time.Now().Format("060102")
-		prefix := "MC-" + today + "-"
-
-		// Get last order number for today
-		var lastOrder models.Order
-		err := tx.Where("order_number LIKE ?", prefix+"%").
-			Order("order_number DESC").
-			First(&lastOrder).Error
-
-		sequence := 1
-		if err == nil {
-			// Extract sequence from last order number
-			// Format: MC-YYMMDD-XXX
-			parts := strings.Split(lastOrder.OrderNumber, "-")
-			if len(parts) == 3 {
-				seq, parseErr := strconv.Atoi(parts[2])
-				if parseErr == nil {
-					sequence = seq + 1
-				}
+// orderStatusUpdates are the columns that change when an order moves to
+// status, noting when a deposit came in and when preparation started and
+// finished. The balance on a deposit has no payment deadline.
+func orderStatusUpdates(status models.OrderStatus) map[string]any {
+	updates := map[string]any{
+		"status": status,
+	}
+	switch status {
+	case models.OrderStatusDepositPaid:
+		updates["deposit_paid_at"] = time.Now()
+		updates["payment_expires_at"] = nil
+	case models.OrderStatusPreparing:
+		updates["preparing_at"] = time.Now()
+	case models.OrderStatusReady:
+		updates["ready_at"] = time.Now()
+	}
+	return updates
+}
+
+func (r *orderRepository) UpdateTip(orderID uint, tipAmount float64) error {
+	return r.db.Model(&models.Order{}).Where("id = ?", orderID).Update("tip_amount", tipAmount).Error
+}
+
+// AwardPoints credits the member with the loyalty points earned on the
+// order. An order earns points once; a second award fails on the unique index.
+func (r *orderRepository) AwardPoints(orderID, userID uint, points int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return postPointEntry(tx, &models.PointTransaction{
+			UserID:  userID,
+			Type:    models.PointTransactionEarn,
+			Points:  points,
+			OrderID: &orderID,
+		})
+	})
+}
+
+func (r *orderRepository) UpdatePriority(orderID uint, priority models.Priority) error {
+	return r.db.Model(&models.Order{}).Where("id = ?", orderID).Update("priority", priority).Error
+}
+
+// ReleaseScheduled moves a pre-order to pending and starts its payment
+// window. It reports false when the order was no longer scheduled, e.g.
+// because it was cancelled or another instance released it first.
+func (r *orderRepository) ReleaseScheduled(orderID uint, paymentExpiresAt *time.Time) (bool, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND status = ?", orderID, models.OrderStatusScheduled).
+		Updates(map[string]any{
+			"status":             models.OrderStatusPending,
+			"released_at":        time.Now(),
+			"payment_expires_at": paymentExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkConfirmationSent records that the guest was sent the tracking link. Like
+// MarkReceiptSent, it reports false when that already happened.
+func (r *orderRepository) MarkConfirmationSent(orderID uint) (bool, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND confirmation_sent_at IS NULL", orderID).
+		Update("confirmation_sent_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkReceiptSent records that the order's receipt was emailed. It reports
+// false when the receipt was already sent, so instances handling the same
+// event do not email it twice.
+func (r *orderRepository) MarkReceiptSent(orderID uint) (bool, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND receipt_sent_at IS NULL", orderID).
+		Update("receipt_sent_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkReadyNotified records that the customer was told the order is ready,
+// reporting false when that already happened
+func (r *orderRepository) MarkReadyNotified(orderID uint) (bool, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND ready_notified_at IS NULL", orderID).
+		Update("ready_notified_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete removes the order for good along with its items, payments and stock
+// reservations
+func (r *orderRepository) Delete(orderID uint) error {
+	return r.db.Where("id = ?", orderID).Delete(&models.Order{}).Error
+}
+
+// Claim assigns the order to userID in a single conditional update, so two
+// staff members claiming at once cannot both win. Re-claiming an order the
+// user already holds succeeds.
+func (r *orderRepository) Claim(orderID, userID uint) error {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND (assigned_to IS NULL OR assigned_to = ?)", orderID, userID).
+		Updates(map[string]any{
+			"assigned_to": userID,
+			"assigned_at": gorm.Expr("CASE WHEN assigned_to IS NULL THEN ? ELSE assigned_at END", time.Now()),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderAlreadyClaimed
+	}
+	return nil
+}
+
+// ReplaceItems swaps the order's items and applied promotions and saves its
+// recalculated totals in one transaction. The order row is locked and re-checked first, so an order that
+// left pending or had a payment started since it was loaded is not changed.
+func (r *orderRepository) ReplaceItems(order *models.Order, items []models.OrderItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			Where("id = ?", order.ID).
+			First(&current).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
 			}
-		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return err
 		}
+		if current.Status != models.OrderStatusPending {
+			return ErrOrderNotEditable
+		}
 
-		// Generate order number with 3-digit sequence
-		orderNumber = fmt.Sprintf("%s%03d", prefix, sequence)
-		return nil
+		var payments int64
+		if err := tx.Model(&models.Payment{}).Where("order_id = ?", order.ID).Count(&payments).Error; err != nil {
+			return err
+		}
+		if payments > 0 {
+			return ErrOrderNotEditable
+		}
+
+		if err := tx.Where("order_id = ?", order.ID).Delete(&models.OrderItem{}).Error; err != nil {
+			return err
+		}
+
+		for i := range items {
+			items[i].OrderID = order.ID
+		}
+		if err := tx.Omit(clause.Associations).Create(&items).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("order_id = ?", order.ID).Delete(&models.OrderPromotion{}).Error; err != nil {
+			return err
+		}
+		if len(order.Promotions) > 0 {
+			promotions := make([]models.OrderPromotion, len(order.Promotions))
+			for i, applied := range order.Promotions {
+				applied.ID = 0
+				applied.OrderID = order.ID
+				promotions[i] = applied
+			}
+			if err := tx.Create(&promotions).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Order{}).
+			Where("id = ?", order.ID).
+			Updates(map[string]any{
+				"subtotal":                 order.Subtotal,
+				"tax":                      order.Tax,
+				"tax_rate":                 order.TaxRate,
+				"total":                    order.Total,
+				"price_adjustment_percent": order.PriceAdjustmentPercent,
+				"source_fee":               order.SourceFee,
+				"delivery_fee":             order.DeliveryFee,
+				"delivery_discount":        order.DeliveryDiscount,
+				"discount":                 order.Discount,
+				"service_charge":           order.ServiceCharge,
+				"deposit_amount":           order.DepositAmount,
+			}).Error
 	})
+}
 
+func checkPromotionLimits(tx *gorm.DB, promotionID uint, userID *uint) error {
+	var promotion models.Promotion
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "usage_limit", "per_customer_limit").
+		Where("id = ?", promotionID).
+		First(&promotion).Error
 	if err != nil {
-		return "", ErrOrderNumberGenFailed
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPromotionNotFound
+		}
+		return err
+	}
+
+	if promotion.UsageLimit != nil {
+		used, err := countPromotionRedemptions(tx, promotionID, nil)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*promotion.UsageLimit) {
+			return ErrPromotionUsageLimitReached
+		}
 	}
+	if promotion.PerCustomerLimit != nil && userID != nil {
+		used, err := countPromotionRedemptions(tx, promotionID, userID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*promotion.PerCustomerLimit) {
+			return ErrPromotionUsageLimitReached
+		}
+	}
+	return nil
+}
+
+// GenerateOrderNumber takes the next sequence from the counter of the current
+// period. Numbers that are already taken, for example issued under an earlier
+// format, are skipped.
+func (r *orderRepository) GenerateOrderNumber() (string, error) {
+	now := time.Now()
+	period := r.numbers.Period(now)
+
+	for range maxOrderNumberAttempts {
+		var sequence int
+		err := r.db.Raw(`
+			INSERT INTO order_number_counters (period, last_sequence) VALUES (?, 1)
+			ON CONFLICT (period) DO UPDATE
+			SET last_sequence = order_number_counters.last_sequence + 1, updated_at = CURRENT_TIMESTAMP
+			RETURNING last_sequence`, period).
+			Scan(&sequence).Error
+		if err != nil {
+			return "", ErrOrderNumberGenFailed
+		}
+
+		orderNumber := r.numbers.Format(now, sequence)
+		var count int64
+		if err := r.db.Model(&models.Order{}).Where("order_number = ?", orderNumber).Count(&count).Error; err != nil {
+			return "", ErrOrderNumberGenFailed
+		}
+		if count == 0 {
+			return orderNumber, nil
+		}
+	}
+
+	return "", ErrOrderNumberGenFailed
+}
 
-	return orderNumber, nil
+// NextQueueNumber takes the next pickup queue number. Queue numbers restart
+// every store day and share the order number counters under their own period.
+func (r *orderRepository) NextQueueNumber() (int, error) {
+	var number int
+	err := r.db.Raw(`
+		INSERT INTO order_number_counters (period, last_sequence) VALUES (?, 1)
+		ON CONFLICT (period) DO UPDATE
+		SET last_sequence = order_number_counters.last_sequence + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING last_sequence`, "queue:"+r.numbers.Day(time.Now())).
+		Scan(&number).Error
+	return number, err
 }
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPaymentLinkNotFound = errors.New("payment link not found")
+)
+
+type PaymentLinkRepository interface {
+	Create(link *models.PaymentLink) error
+	FindByTokenHash(hash string) (*models.PaymentLink, error)
+}
+
+type paymentLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentLinkRepository(db *gorm.DB) PaymentLinkRepository {
+	return &paymentLinkRepository{db: db}
+}
+
+func (r *paymentLinkRepository) Create(link *models.PaymentLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *paymentLinkRepository) FindByTokenHash(hash string) (*models.PaymentLink, error) {
+	var link models.PaymentLink
+	err := r.db.Preload("Order").Where("token_hash = ?", hash).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentLinkNotFound
+		}
+		return nil, err
+	}
+	return &link, nil
+}
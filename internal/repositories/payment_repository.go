@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"github.com/carllix/matchaciee-backend/internal/models"
 	"github.com/google/uuid"
@@ -11,15 +12,32 @@ import (
 var (
 	ErrPaymentNotFound  = errors.New("payment not found")
 	ErrPaymentDuplicate = errors.New("payment with this midtrans order ID already exists")
+
+	ErrOrderNotAwaitingPayment = errors.New("order is not awaiting payment")
 )
 
+// PaymentFilters narrows the payment list. A pending status also matches
+// payments the gateway has not reported on yet. StartDate is inclusive and
+// EndDate exclusive, both on when the payment was started.
+type PaymentFilters struct {
+	Status      *models.TransactionStatus
+	PaymentType *string
+	OrderNumber *string
+	StartDate   *time.Time
+	EndDate     *time.Time
+}
+
 type PaymentRepository interface {
 	Create(payment *models.Payment) error
+	CreateSettled(payment *models.Payment, from, to models.OrderStatus) error
 	FindByUUID(uuid uuid.UUID) (*models.Payment, error)
 	FindByMidtransOrderID(midtransOrderID string) (*models.Payment, error)
 	FindByOrderID(orderID uint) ([]models.Payment, error)
+	FindAll(filters PaymentFilters, limit, offset int) ([]models.Payment, int64, error)
 	Update(payment *models.Payment) error
 	UpdateTransactionStatus(paymentID uint, status models.TransactionStatus) error
+	UpdatePendingStatus(paymentID uint, status models.TransactionStatus) (bool, error)
+	FindStalePending(now time.Time, limit int) ([]models.Payment, error)
 }
 
 type paymentRepository struct {
@@ -34,6 +52,25 @@ func (r *paymentRepository) Create(payment *models.Payment) error {
 	return r.db.Create(payment).Error
 }
 
+// CreateSettled saves a payment taken on the spot and moves its order from
+// one status to the next in one transaction. It returns
+// ErrOrderNotAwaitingPayment when the order is no longer in from, so the same
+// order cannot be paid twice at the counter.
+func (r *paymentRepository) CreateSettled(payment *models.Payment, from, to models.OrderStatus) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", payment.OrderID, from).
+			Updates(orderStatusUpdates(to))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderNotAwaitingPayment
+		}
+		return tx.Create(payment).Error
+	})
+}
+
 func (r *paymentRepository) FindByUUID(uuid uuid.UUID) (*models.Payment, error) {
 	var payment models.Payment
 	err := r.db.
@@ -79,6 +116,49 @@ func (r *paymentRepository) FindByOrderID(orderID uint) ([]models.Payment, error
 	return payments, nil
 }
 
+// FindAll returns the newest payments first, each with its order
+func (r *paymentRepository) FindAll(filters PaymentFilters, limit, offset int) ([]models.Payment, int64, error) {
+	var payments []models.Payment
+	var total int64
+
+	query := r.db.Model(&models.Payment{})
+	if filters.Status != nil {
+		if *filters.Status == models.TransactionStatusPending {
+			query = query.Where("payments.transaction_status IS NULL OR payments.transaction_status = ?", *filters.Status)
+		} else {
+			query = query.Where("payments.transaction_status = ?", *filters.Status)
+		}
+	}
+	if filters.PaymentType != nil {
+		query = query.Where("payments.payment_type = ?", *filters.PaymentType)
+	}
+	if filters.OrderNumber != nil {
+		query = query.Where("payments.order_id IN (?)",
+			r.db.Model(&models.Order{}).Select("id").Where("order_number = ?", *filters.OrderNumber))
+	}
+	if filters.StartDate != nil {
+		query = query.Where("payments.created_at >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("payments.created_at < ?", *filters.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Order").
+		Order("payments.created_at DESC, payments.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&payments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return payments, total, nil
+}
+
 func (r *paymentRepository) Update(payment *models.Payment) error {
 	return r.db.Save(payment).Error
 }
@@ -88,3 +168,33 @@ func (r *paymentRepository) UpdateTransactionStatus(paymentID uint, status model
 		Where("id = ?", paymentID).
 		Update("transaction_status", status).Error
 }
+
+// UpdatePendingStatus sets the status only while the payment is still
+// pending, so a webhook that settled it first wins. It reports whether the
+// payment was updated.
+func (r *paymentRepository) UpdatePendingStatus(paymentID uint, status models.TransactionStatus) (bool, error) {
+	result := r.db.Model(&models.Payment{}).
+		Where("id = ? AND (transaction_status IS NULL OR transaction_status = ?)", paymentID, models.TransactionStatusPending).
+		Updates(map[string]any{
+			"transaction_status": status,
+			"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindStalePending returns gateway payments still pending after their expiry,
+// oldest first, with their orders
+func (r *paymentRepository) FindStalePending(now time.Time, limit int) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.db.
+		Preload("Order").
+		Where("method <> ? AND expires_at < ?", models.PaymentMethodCash, now).
+		Where("transaction_status IS NULL OR transaction_status = ?", models.TransactionStatusPending).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&payments).Error
+	return payments, err
+}
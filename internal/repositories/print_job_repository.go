@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPrintJobNotFound = errors.New("print job not found")
+)
+
+type PrintJobRepository interface {
+	Create(job *models.PrintJob) (bool, error)
+	FindByUUID(uuid uuid.UUID) (*models.PrintJob, error)
+	ClaimNext(station string, staleBefore time.Time, maxAttempts int) (*models.PrintJob, error)
+	Complete(id uint) (bool, error)
+	Fail(id uint, lastError string, requeue bool) (bool, error)
+	DeletePrintedBefore(before time.Time) (int64, error)
+}
+
+type printJobRepository struct {
+	db *gorm.DB
+}
+
+func NewPrintJobRepository(db *gorm.DB) PrintJobRepository {
+	return &printJobRepository{db: db}
+}
+
+// Create queues the job. It reports false when the order already has a job
+// for the station, so instances handling the same payment queue it once.
+func (r *printJobRepository) Create(job *models.PrintJob) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *printJobRepository) FindByUUID(uuid uuid.UUID) (*models.PrintJob, error) {
+	var job models.PrintJob
+	err := r.db.Where("uuid = ?", uuid).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPrintJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimNext hands the oldest waiting job of the station to an agent. Jobs an
+// agent took before staleBefore without acknowledging them are handed out
+// again, until they have been taken maxAttempts times and are marked failed.
+// Concurrent agents skip rows another agent is claiming.
+func (r *printJobRepository) ClaimNext(station string, staleBefore time.Time, maxAttempts int) (*models.PrintJob, error) {
+	var job models.PrintJob
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.PrintJob{}).
+			Where("station = ? AND status = ? AND claimed_at < ? AND attempts >= ?",
+				station, models.PrintJobStatusPrinting, staleBefore, maxAttempts).
+			Updates(map[string]any{
+				"status":     models.PrintJobStatusFailed,
+				"last_error": "not acknowledged by the print agent",
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("station = ? AND (status = ? OR (status = ? AND claimed_at < ?))",
+				station, models.PrintJobStatusQueued, models.PrintJobStatusPrinting, staleBefore).
+			Order("created_at ASC, id ASC").
+			First(&job).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPrintJobNotFound
+			}
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&models.PrintJob{}).
+			Where("id = ?", job.ID).
+			Updates(map[string]any{
+				"status":     models.PrintJobStatusPrinting,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"claimed_at": now,
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		job.Status = models.PrintJobStatusPrinting
+		job.Attempts++
+		job.ClaimedAt = &now
+
+		var order models.Order
+		if err := tx.Select("id", "order_number").Where("id = ?", job.OrderID).First(&order).Error; err != nil {
+			return err
+		}
+		job.Order = &order
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Complete marks a claimed job printed. It reports false when the job is not
+// being printed, for example after it was already acknowledged.
+func (r *printJobRepository) Complete(id uint) (bool, error) {
+	result := r.db.Model(&models.PrintJob{}).
+		Where("id = ? AND status = ?", id, models.PrintJobStatusPrinting).
+		Updates(map[string]any{
+			"status":     models.PrintJobStatusPrinted,
+			"last_error": nil,
+			"printed_at": time.Now(),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Fail records why a claimed job did not print and either puts it back in the
+// queue or gives up on it. It reports false when the job is not being printed.
+func (r *printJobRepository) Fail(id uint, lastError string, requeue bool) (bool, error) {
+	status := models.PrintJobStatusFailed
+	if requeue {
+		status = models.PrintJobStatusQueued
+	}
+
+	result := r.db.Model(&models.PrintJob{}).
+		Where("id = ? AND status = ?", id, models.PrintJobStatusPrinting).
+		Updates(map[string]any{
+			"status":     status,
+			"last_error": lastError,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeletePrintedBefore removes jobs printed before the given time and returns
+// how many were deleted
+func (r *printJobRepository) DeletePrintedBefore(before time.Time) (int64, error) {
+	result := r.db.
+		Where("status = ? AND printed_at < ?", models.PrintJobStatusPrinted, before).
+		Delete(&models.PrintJob{})
+	return result.RowsAffected, result.Error
+}
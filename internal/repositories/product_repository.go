@@ -19,9 +19,10 @@ type ProductRepository interface {
 	FindByUUID(uuid uuid.UUID) (*models.Product, error)
 	FindByUUIDIncludingDeleted(uuid uuid.UUID) (*models.Product, error)
 	FindBySlug(slug string) (*models.Product, error)
-	FindAll(includeDeleted bool, isAvailable *bool, categoryID *uint) ([]models.Product, error)
+	FindAll(includeDeleted bool, isAvailable *bool, categoryIDs []uint) ([]models.Product, error)
 	FindByCategoryUUID(categoryUUID uuid.UUID, includeDeleted bool, isAvailable *bool) ([]models.Product, error)
 	Update(product *models.Product) error
+	Reorder(uuids []uuid.UUID) error
 	SoftDelete(id uint) error
 	Restore(id uint) error
 	HardDelete(id uint) error
@@ -131,7 +132,7 @@ func (r *productRepository) FindBySlug(slug string) (*models.Product, error) {
 	return &product, nil
 }
 
-func (r *productRepository) FindAll(includeDeleted bool, isAvailable *bool, categoryID *uint) ([]models.Product, error) {
+func (r *productRepository) FindAll(includeDeleted bool, isAvailable *bool, categoryIDs []uint) ([]models.Product, error) {
 	var products []models.Product
 	query := r.db.
 		Preload("Category").
@@ -139,9 +140,12 @@ func (r *productRepository) FindAll(includeDeleted bool, isAvailable *bool, cate
 			return db.Order("display_order ASC")
 		})
 
-	// Filter by soft delete
+	// Filter by soft delete, including products whose category was deleted
 	if !includeDeleted {
-		query = query.Where("deleted_at IS NULL")
+		query = query.
+			Where("deleted_at IS NULL").
+			Where("category_id IS NULL OR category_id NOT IN (?)",
+				r.db.Model(&models.Category{}).Select("id").Where("deleted_at IS NOT NULL"))
 	}
 
 	// Filter by availability
@@ -149,9 +153,9 @@ func (r *productRepository) FindAll(includeDeleted bool, isAvailable *bool, cate
 		query = query.Where("is_available = ?", *isAvailable)
 	}
 
-	// Filter by category
-	if categoryID != nil {
-		query = query.Where("category_id = ?", *categoryID)
+	// Filter by category (nil means any category)
+	if categoryIDs != nil {
+		query = query.Where("category_id IN ?", categoryIDs)
 	}
 
 	err := query.Order("display_order ASC, created_at DESC").Find(&products).Error
@@ -164,20 +168,43 @@ func (r *productRepository) FindAll(includeDeleted bool, isAvailable *bool, cate
 func (r *productRepository) FindByCategoryUUID(categoryUUID uuid.UUID, includeDeleted bool, isAvailable *bool) ([]models.Product, error) {
 	// Find the category to get its internal ID
 	var category models.Category
-	if err := r.db.Where("uuid = ?", categoryUUID).First(&category).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND deleted_at IS NULL", categoryUUID).First(&category).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrCategoryNotFound
 		}
 		return nil, err
 	}
 
-	return r.FindAll(includeDeleted, isAvailable, &category.ID)
+	categoryIDs, err := categoryTreeIDs(r.db, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindAll(includeDeleted, isAvailable, categoryIDs)
 }
 
 func (r *productRepository) Update(product *models.Product) error {
 	return r.db.Save(product).Error
 }
 
+// Reorder sets display_order to each product's position in uuids
+func (r *productRepository) Reorder(uuids []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range uuids {
+			result := tx.Model(&models.Product{}).
+				Where("uuid = ? AND deleted_at IS NULL", id).
+				Update("display_order", i+1)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrProductNotFound
+			}
+		}
+		return nil
+	})
+}
+
 func (r *productRepository) SoftDelete(id uint) error {
 	return r.db.Model(&models.Product{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
 }
@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPromotionNotFound          = errors.New("promotion not found")
+	ErrPromotionUsageLimitReached = errors.New("promotion usage limit reached")
+)
+
+// PromotionStats sums up the orders that applied a promotion and were not
+// cancelled
+type PromotionStats struct {
+	Redemptions     int64
+	Customers       int64
+	DiscountGiven   float64
+	Revenue         float64
+	FirstRedeemedAt *time.Time
+	LastRedeemedAt  *time.Time
+}
+
+type PromotionRepository interface {
+	Create(promotion *models.Promotion) error
+	FindAll() ([]models.Promotion, error)
+	FindByID(id uint) (*models.Promotion, error)
+	FindByUUID(uuid uuid.UUID) (*models.Promotion, error)
+	FindByCode(code string) (*models.Promotion, error)
+	FindAutomatic() ([]models.Promotion, error)
+	Update(promotion *models.Promotion) error
+	Delete(id uint) error
+	CountRedemptions(promotionID uint, userID *uint) (int64, error)
+	ScopeCategoryIDs(promotionID uint) ([]uint, error)
+	Stats(promotionID uint) (*PromotionStats, error)
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+// Create saves the promotion together with its product and category scope
+func (r *promotionRepository) Create(promotion *models.Promotion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(promotion).Error; err != nil {
+			return err
+		}
+		return replacePromotionScope(tx, promotion)
+	})
+}
+
+func (r *promotionRepository) FindAll() ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.withScope().Order("created_at DESC, id DESC").Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *promotionRepository) FindByID(id uint) (*models.Promotion, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *promotionRepository) FindByUUID(uuid uuid.UUID) (*models.Promotion, error) {
+	return r.findOne("uuid = ?", uuid)
+}
+
+// FindByCode looks the code up as stored, in upper case
+func (r *promotionRepository) FindByCode(code string) (*models.Promotion, error) {
+	return r.findOne("code = ?", code)
+}
+
+// FindAutomatic returns the switched-on promotions that apply without a code.
+// Their validity windows are left for the caller to check.
+func (r *promotionRepository) FindAutomatic() ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.withScope().Where("automatic AND is_active").Order("id").Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *promotionRepository) findOne(query string, args ...any) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.withScope().Where(query, args...).First(&promotion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) withScope() *gorm.DB {
+	return r.db.Preload("Products").Preload("Categories")
+}
+
+// Update saves the promotion fields and replaces its scope
+func (r *promotionRepository) Update(promotion *models.Promotion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Promotion{}).
+			Where("id = ?", promotion.ID).
+			Updates(map[string]any{
+				"code":               promotion.Code,
+				"name":               promotion.Name,
+				"description":        promotion.Description,
+				"discount_type":      promotion.DiscountType,
+				"discount_value":     promotion.DiscountValue,
+				"max_discount":       promotion.MaxDiscount,
+				"min_spend":          promotion.MinSpend,
+				"usage_limit":        promotion.UsageLimit,
+				"per_customer_limit": promotion.PerCustomerLimit,
+				"starts_at":          promotion.StartsAt,
+				"expires_at":         promotion.ExpiresAt,
+				"days":               promotion.Days,
+				"start_time":         promotion.StartTime,
+				"end_time":           promotion.EndTime,
+				"is_active":          promotion.IsActive,
+				"automatic":          promotion.Automatic,
+				"stackable":          promotion.Stackable,
+				"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return replacePromotionScope(tx, promotion)
+	})
+}
+
+// Delete removes the promotion. Orders that redeemed it keep the code and
+// discount.
+func (r *promotionRepository) Delete(id uint) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Promotion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+// CountRedemptions counts orders that applied the promotion and were not
+// cancelled, for one member when userID is set
+func (r *promotionRepository) CountRedemptions(promotionID uint, userID *uint) (int64, error) {
+	return countPromotionRedemptions(r.db, promotionID, userID)
+}
+
+// ScopeCategoryIDs returns the categories in the promotion's scope together
+// with all their subcategories
+func (r *promotionRepository) ScopeCategoryIDs(promotionID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(`
+		WITH RECURSIVE scope AS (
+			SELECT category_id AS id FROM promotion_categories WHERE promotion_id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN scope ON c.parent_id = scope.id
+		)
+		SELECT id FROM scope`, promotionID).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// Stats counts the members who applied the promotion by account; guest
+// orders are not counted as customers
+func (r *promotionRepository) Stats(promotionID uint) (*PromotionStats, error) {
+	var stats PromotionStats
+	err := r.db.Table("order_promotions op").
+		Select(`COUNT(*) AS redemptions, COUNT(DISTINCT o.user_id) AS customers,
+			COALESCE(SUM(op.discount), 0) AS discount_given, COALESCE(SUM(o.total), 0) AS revenue,
+			MIN(o.created_at) AS first_redeemed_at, MAX(o.created_at) AS last_redeemed_at`).
+		Joins("JOIN orders o ON o.id = op.order_id").
+		Where("op.promotion_id = ? AND o.status <> ?", promotionID, models.OrderStatusCancelled).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func replacePromotionScope(tx *gorm.DB, promotion *models.Promotion) error {
+	if err := tx.Exec("DELETE FROM promotion_products WHERE promotion_id = ?", promotion.ID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM promotion_categories WHERE promotion_id = ?", promotion.ID).Error; err != nil {
+		return err
+	}
+
+	if len(promotion.Products) > 0 {
+		rows := make([]map[string]any, len(promotion.Products))
+		for i, product := range promotion.Products {
+			rows[i] = map[string]any{"promotion_id": promotion.ID, "product_id": product.ID}
+		}
+		if err := tx.Table("promotion_products").Create(rows).Error; err != nil {
+			return err
+		}
+	}
+	if len(promotion.Categories) > 0 {
+		rows := make([]map[string]any, len(promotion.Categories))
+		for i, category := range promotion.Categories {
+			rows[i] = map[string]any{"promotion_id": promotion.ID, "category_id": category.ID}
+		}
+		if err := tx.Table("promotion_categories").Create(rows).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func countPromotionRedemptions(db *gorm.DB, promotionID uint, userID *uint) (int64, error) {
+	query := db.Table("order_promotions op").
+		Joins("JOIN orders o ON o.id = op.order_id").
+		Where("op.promotion_id = ? AND o.status <> ?", promotionID, models.OrderStatusCancelled)
+	if userID != nil {
+		query = query.Where("o.user_id = ?", *userID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
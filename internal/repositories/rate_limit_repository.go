@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RateLimitRepository keeps rate limit counters in Postgres so every instance
+// counts against the same limit. Redis is not part of the stack, so the
+// counters live in an unlogged table instead. Each limited request costs one
+// upsert, which is why the store is opt-in through RATE_LIMIT_STORE.
+type RateLimitRepository interface {
+	Hit(key string, window time.Duration) (int, time.Duration, error)
+	DeleteExpired() (int64, error)
+}
+
+type rateLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewRateLimitRepository(db *gorm.DB) RateLimitRepository {
+	return &rateLimitRepository{db: db}
+}
+
+// Hit counts a request against key in a fixed window and returns the count
+// so far and how long until the window ends. The upsert locks the key's row,
+// so concurrent requests on every instance are counted one after another.
+// Windows follow the database clock so instances agree on them.
+func (r *rateLimitRepository) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	var result struct {
+		Count   int
+		ResetMs int64
+	}
+	err := r.db.Raw(`
+		INSERT INTO rate_limit_entries (key, count, expires_at)
+		VALUES (?, 1, NOW() + ? * INTERVAL '1 millisecond')
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limit_entries.expires_at > NOW() THEN rate_limit_entries.count + 1 ELSE 1 END,
+			expires_at = CASE WHEN rate_limit_entries.expires_at > NOW() THEN rate_limit_entries.expires_at ELSE EXCLUDED.expires_at END
+		RETURNING count, CEIL(EXTRACT(EPOCH FROM expires_at - NOW()) * 1000)::BIGINT AS reset_ms`,
+		key, window.Milliseconds()).Scan(&result).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.Count, time.Duration(result.ResetMs) * time.Millisecond, nil
+}
+
+// DeleteExpired removes entries whose window has ended
+func (r *rateLimitRepository) DeleteExpired() (int64, error) {
+	result := r.db.Exec("DELETE FROM rate_limit_entries WHERE expires_at <= NOW()")
+	return result.RowsAffected, result.Error
+}
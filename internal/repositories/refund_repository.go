@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"math"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepository interface {
+	Create(refund *models.Refund) error
+	CreateWithinBalance(refund *models.Refund, grossAmount float64) (bool, error)
+	Delete(id uint) error
+	SumByPaymentID(paymentID uint) (float64, error)
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepository {
+	return &refundRepository{db: db}
+}
+
+// Create saves the refund once per refund key. The admin API and the gateway
+// webhook both report a refund, in either order; the row keeps the admin who
+// issued it whichever arrives first.
+func (r *refundRepository) Create(refund *models.Refund) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "refund_key"}},
+		DoUpdates: clause.Set{{
+			Column: clause.Column{Name: "refunded_by"},
+			Value:  gorm.Expr("COALESCE(refunds.refunded_by, EXCLUDED.refunded_by)"),
+		}},
+	}).Create(refund).Error
+}
+
+// CreateWithinBalance saves the refund only if the payment's refunds stay
+// within grossAmount. The payment row is locked while checking, so two admins
+// refunding at once cannot give back more than was paid. It reports false
+// when the balance is too small.
+func (r *refundRepository) CreateWithinBalance(refund *models.Refund, grossAmount float64) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT id FROM payments WHERE id = ? FOR UPDATE", refund.PaymentID).Error; err != nil {
+			return err
+		}
+
+		refunded, err := sumRefunds(tx, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+		// Compare in cents so float sums do not reject an exact balance
+		if math.Round((refunded+refund.Amount)*100) > math.Round(grossAmount*100) {
+			return nil
+		}
+
+		if err := tx.Create(refund).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// Delete removes a refund the gateway turned down, giving its amount back to
+// the refundable balance
+func (r *refundRepository) Delete(id uint) error {
+	return r.db.Where("id = ?", id).Delete(&models.Refund{}).Error
+}
+
+func (r *refundRepository) SumByPaymentID(paymentID uint) (float64, error) {
+	return sumRefunds(r.db, paymentID)
+}
+
+func sumRefunds(db *gorm.DB, paymentID uint) (float64, error) {
+	var total float64
+	err := db.Model(&models.Refund{}).
+		Where("payment_id = ?", paymentID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
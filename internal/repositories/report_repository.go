@@ -0,0 +1,450 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// revenueStatuses are the order statuses that count as earned revenue
+var revenueStatuses = []models.OrderStatus{
+	models.OrderStatusPreparing,
+	models.OrderStatusReady,
+	models.OrderStatusCompleted,
+}
+
+type SourceRevenueRow struct {
+	OrderSource models.OrderSource
+	OrderCount  int64
+	Subtotal    float64
+	Tax         float64
+	Revenue     float64
+	GatewayFees float64
+}
+
+// SalesRow sums paid orders over one period, which starts at Period
+type SalesRow struct {
+	Period     time.Time
+	OrderCount int64
+	Subtotal   float64
+	Discount   float64
+	Tax        float64
+	Revenue    float64
+}
+
+// TopProductRow sums what was sold of one product. ProductUUID is nil for
+// products that were deleted since, which are told apart by name.
+type TopProductRow struct {
+	ProductUUID *uuid.UUID
+	ProductName string
+	Quantity    int64
+	OrderCount  int64
+	Revenue     float64
+}
+
+// CustomizationRow counts the items of a product sold with one
+// customization option
+type CustomizationRow struct {
+	ProductUUID       uuid.UUID
+	CustomizationType string
+	OptionName        string
+	Quantity          int64
+}
+
+// CategoryRevenueRow sums what was sold in a category and its
+// subcategories. The category fields are nil for items whose product has no
+// category or was deleted.
+type CategoryRevenueRow struct {
+	CategoryUUID *uuid.UUID
+	CategoryName *string
+	Quantity     int64
+	OrderCount   int64
+	Revenue      float64
+}
+
+// TaxRow sums paid orders created on one day that were charged one tax
+// rate. Taxable sales are the subtotal after discounts plus the service
+// charge, which is what the tax was charged on.
+type TaxRow struct {
+	Day          time.Time
+	TaxRate      float64
+	OrderCount   int64
+	TaxableSales float64
+	Tax          float64
+}
+
+// FulfillmentRow holds how long orders took over one fulfillment stage, in
+// seconds. Day is nil on the rows covering the whole report range.
+type FulfillmentRow struct {
+	Day        *time.Time
+	Stage      string
+	OrderCount int64
+	P50        float64
+	P90        float64
+}
+
+// AbandonedRow counts orders from one channel created in one hour of the
+// day, and how many of them were abandoned without being paid. Attempted is
+// how many abandoned orders got as far as starting a payment.
+type AbandonedRow struct {
+	OrderSource    models.OrderSource
+	Hour           int
+	OrderCount     int64
+	AbandonedCount int64
+	Attempted      int64
+	AbandonedValue float64
+}
+
+// InventoryUsageRow is a product whose stock is tracked, with the units
+// taken from stock over the report range and the units held right now by
+// unpaid orders
+type InventoryUsageRow struct {
+	ProductUUID   uuid.UUID
+	ProductName   string
+	StockQuantity int
+	Consumed      int64
+	Reserved      int64
+}
+
+// StaffTipsRow sums tips per staff member who handled the orders. The staff
+// fields are nil for orders nobody claimed.
+type StaffTipsRow struct {
+	StaffUUID  *uuid.UUID
+	FullName   *string
+	OrderCount int64
+	Tips       float64
+}
+
+// SettlementRow is a gateway payment the provider settled, with what has
+// been refunded from it so far
+type SettlementRow struct {
+	PaymentUUID    uuid.UUID
+	Reference      string
+	OrderNumber    string
+	Method         models.PaymentMethod
+	PaymentType    *string
+	TransactionID  *string
+	GrossAmount    float64
+	GatewayFee     *float64
+	Refunded       float64
+	SettlementTime time.Time
+}
+
+type ReportRepository interface {
+	RevenueBySource(start, end time.Time) ([]SourceRevenueRow, error)
+	SalesByPeriod(start, end time.Time, granularity string) ([]SalesRow, error)
+	TopProducts(start, end time.Time, limit int) ([]TopProductRow, error)
+	CustomizationsSold(start, end time.Time, productUUIDs []uuid.UUID) ([]CustomizationRow, error)
+	RevenueByCategory(start, end time.Time, parentUUID *uuid.UUID) ([]CategoryRevenueRow, error)
+	TaxByDay(start, end time.Time) ([]TaxRow, error)
+	FulfillmentTimes(start, end time.Time) ([]FulfillmentRow, error)
+	AbandonedOrders(start, end, now time.Time) ([]AbandonedRow, error)
+	InventoryUsage(start, end, now time.Time) ([]InventoryUsageRow, error)
+	TipsByStaff(start, end time.Time) ([]StaffTipsRow, error)
+	SettledPayments(start, end time.Time) ([]SettlementRow, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+// RevenueBySource sums paid orders created in [start, end) grouped by
+// channel, with the gateway fees recorded on their payments
+func (r *reportRepository) RevenueBySource(start, end time.Time) ([]SourceRevenueRow, error) {
+	var rows []SourceRevenueRow
+	err := r.db.Table("orders o").
+		Select(`o.order_source, COUNT(*) AS order_count, COALESCE(SUM(o.subtotal), 0) AS subtotal,
+			COALESCE(SUM(o.tax), 0) AS tax, COALESCE(SUM(o.total), 0) AS revenue, COALESCE(SUM(pf.fees), 0) AS gateway_fees`).
+		Joins("LEFT JOIN (SELECT order_id, SUM(gateway_fee) AS fees FROM payments GROUP BY order_id) pf ON pf.order_id = o.id").
+		Where("o.status IN ? AND o.created_at >= ? AND o.created_at < ?", revenueStatuses, start, end).
+		Group("o.order_source").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SalesByPeriod sums paid orders created in [start, end) per day, week or
+// month, as named by granularity. Weeks start on Monday. Periods without
+// orders are left out.
+func (r *reportRepository) SalesByPeriod(start, end time.Time, granularity string) ([]SalesRow, error) {
+	var rows []SalesRow
+	err := r.db.Table("orders o").
+		Select(`date_trunc(?, o.created_at) AS period, COUNT(*) AS order_count, COALESCE(SUM(o.subtotal), 0) AS subtotal,
+			COALESCE(SUM(o.discount), 0) AS discount, COALESCE(SUM(o.tax), 0) AS tax, COALESCE(SUM(o.total), 0) AS revenue`, granularity).
+		Where("o.status IN ? AND o.created_at >= ? AND o.created_at < ?", revenueStatuses, start, end).
+		Group("period").
+		Order("period").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TopProducts sums the items of paid orders created in [start, end) per
+// product, best sellers by quantity first, and returns at most limit of them
+func (r *reportRepository) TopProducts(start, end time.Time, limit int) ([]TopProductRow, error) {
+	var rows []TopProductRow
+	err := r.db.Table("order_items oi").
+		Select(`p.uuid AS product_uuid, COALESCE(MAX(p.name), MAX(oi.product_name)) AS product_name,
+			SUM(oi.quantity) AS quantity, COUNT(DISTINCT oi.order_id) AS order_count, COALESCE(SUM(oi.subtotal), 0) AS revenue`).
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Joins("LEFT JOIN products p ON p.id = oi.product_id").
+		Where("o.status IN ? AND o.created_at >= ? AND o.created_at < ?", revenueStatuses, start, end).
+		Group("p.uuid, CASE WHEN p.uuid IS NULL THEN oi.product_name END").
+		Order("quantity DESC, revenue DESC, product_name").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CustomizationsSold counts the items of the given products on paid orders
+// created in [start, end) per customization option chosen, most chosen first.
+// Options are matched by the type and name snapshotted on the item.
+func (r *reportRepository) CustomizationsSold(start, end time.Time, productUUIDs []uuid.UUID) ([]CustomizationRow, error) {
+	var rows []CustomizationRow
+	if len(productUUIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.Table("order_items oi").
+		Select(`p.uuid AS product_uuid, c.value->>'customization_type' AS customization_type,
+			c.value->>'option_name' AS option_name, SUM(oi.quantity) AS quantity`).
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Joins("JOIN products p ON p.id = oi.product_id").
+		Joins(`CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(oi.customizations) = 'array' THEN oi.customizations ELSE '[]'::jsonb END) AS c`).
+		Where("p.uuid IN ? AND o.status IN ? AND o.created_at >= ? AND o.created_at < ?", productUUIDs, revenueStatuses, start, end).
+		Group("p.uuid, customization_type, option_name").
+		Order("p.uuid, quantity DESC, customization_type, option_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RevenueByCategory sums the items of paid orders created in [start, end),
+// largest revenue first. Without a parent, sales roll up to the top-level
+// categories, and items without one are summed on their own. With a parent,
+// they roll up to its direct subcategories, while items in the parent itself
+// are listed under it; items outside it are left out. Deleted categories
+// still count, since their sales happened.
+func (r *reportRepository) RevenueByCategory(start, end time.Time, parentUUID *uuid.UUID) ([]CategoryRevenueRow, error) {
+	// category_groups maps each category to the one its sales roll up to
+	seed := "SELECT id, id AS group_id FROM categories WHERE parent_id IS NULL"
+	groups := "SELECT id, group_id FROM tree"
+	var args []any
+	inScope := "TRUE"
+	if parentUUID != nil {
+		var parent models.Category
+		err := r.db.Select("id").Where("uuid = ? AND deleted_at IS NULL", *parentUUID).First(&parent).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, err
+		}
+		seed = "SELECT id, id AS group_id FROM categories WHERE parent_id = ?"
+		groups += " UNION ALL SELECT ?, ?"
+		args = append(args, parent.ID, parent.ID, parent.ID)
+		inScope = "g.group_id IS NOT NULL"
+	}
+	args = append(args, revenueStatuses, start, end)
+
+	var rows []CategoryRevenueRow
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			`+seed+`
+			UNION
+			SELECT c.id, t.group_id FROM categories c
+			JOIN tree t ON c.parent_id = t.id
+		), category_groups AS (`+groups+`)
+		SELECT gc.uuid AS category_uuid, gc.name AS category_name, SUM(oi.quantity) AS quantity,
+			COUNT(DISTINCT oi.order_id) AS order_count, COALESCE(SUM(oi.subtotal), 0) AS revenue
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		LEFT JOIN products p ON p.id = oi.product_id
+		LEFT JOIN category_groups g ON g.id = p.category_id
+		LEFT JOIN categories gc ON gc.id = g.group_id
+		WHERE o.status IN ? AND o.created_at >= ? AND o.created_at < ? AND `+inScope+`
+		GROUP BY gc.uuid, gc.name
+		ORDER BY revenue DESC, category_name`,
+		args...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TaxByDay sums paid orders created in [start, end) per day and the tax
+// rate stored on the order, in day then rate order. Days without orders are
+// left out.
+func (r *reportRepository) TaxByDay(start, end time.Time) ([]TaxRow, error) {
+	var rows []TaxRow
+	err := r.db.Table("orders o").
+		Select(`date_trunc('day', o.created_at) AS day, o.tax_rate, COUNT(*) AS order_count,
+			COALESCE(SUM(o.subtotal - o.discount + o.service_charge), 0) AS taxable_sales, COALESCE(SUM(o.tax), 0) AS tax`).
+		Where("o.status IN ? AND o.created_at >= ? AND o.created_at < ?", revenueStatuses, start, end).
+		Group("day, o.tax_rate").
+		Order("day, o.tax_rate").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FulfillmentTimes measures the median and 90th percentile time orders
+// queued in [start, end) spent waiting for payment (queued to preparing), in
+// preparation (preparing to ready), waiting for pickup (ready to completed)
+// and overall (queued to completed), per day they were queued and over the
+// whole range. An order counts towards a stage only once it finished it.
+func (r *reportRepository) FulfillmentTimes(start, end time.Time) ([]FulfillmentRow, error) {
+	var rows []FulfillmentRow
+	err := r.db.Raw(`
+		SELECT date_trunc('day', q.queued_at) AS day, s.stage, COUNT(*) AS order_count,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY s.seconds) AS p50,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY s.seconds) AS p90
+		FROM orders o
+		CROSS JOIN LATERAL (SELECT COALESCE(o.released_at, o.created_at) AS queued_at) q
+		CROSS JOIN LATERAL (VALUES
+			('payment', EXTRACT(EPOCH FROM o.preparing_at - q.queued_at)),
+			('preparation', EXTRACT(EPOCH FROM o.ready_at - o.preparing_at)),
+			('pickup', EXTRACT(EPOCH FROM o.completed_at - o.ready_at)),
+			('total', EXTRACT(EPOCH FROM o.completed_at - q.queued_at))
+		) AS s(stage, seconds)
+		WHERE s.seconds IS NOT NULL AND q.queued_at >= ? AND q.queued_at < ?
+		GROUP BY GROUPING SETS ((date_trunc('day', q.queued_at), s.stage), (s.stage))
+		ORDER BY day NULLS FIRST, s.stage`, start, end).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// AbandonedOrders counts orders created in [start, end) per channel and
+// hour of the day, with those abandoned: cancelled, or still pending past
+// their payment deadline at now, without ever being paid. Pre-orders that
+// are not released yet are left out. Hours without orders are left out.
+func (r *reportRepository) AbandonedOrders(start, end, now time.Time) ([]AbandonedRow, error) {
+	paid := []models.TransactionStatus{
+		models.TransactionStatusSettlement,
+		models.TransactionStatusRefund,
+		models.TransactionStatusPartialRefund,
+	}
+
+	var rows []AbandonedRow
+	err := r.db.Raw(`
+		SELECT o.order_source, EXTRACT(HOUR FROM o.created_at)::int AS hour, COUNT(*) AS order_count,
+			COUNT(*) FILTER (WHERE a.abandoned) AS abandoned_count,
+			COUNT(*) FILTER (WHERE a.abandoned AND EXISTS (SELECT 1 FROM payments p WHERE p.order_id = o.id)) AS attempted,
+			COALESCE(SUM(o.total) FILTER (WHERE a.abandoned), 0) AS abandoned_value
+		FROM orders o
+		CROSS JOIN LATERAL (SELECT
+			(o.status = ? OR (o.status = ? AND o.payment_expires_at < ?))
+			AND o.preparing_at IS NULL AND o.deposit_paid_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.order_id = o.id AND p.transaction_status IN ?) AS abandoned
+		) a
+		WHERE o.status <> ? AND o.created_at >= ? AND o.created_at < ?
+		GROUP BY o.order_source, hour
+		ORDER BY o.order_source, hour`,
+		models.OrderStatusCancelled, models.OrderStatusPending, now, paid,
+		models.OrderStatusScheduled, start, end).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// InventoryUsage lists every product that tracks stock with the units its
+// stock reservations consumed in [start, end) and the units still held by
+// reservations that have not expired at now, most consumed first
+func (r *reportRepository) InventoryUsage(start, end, now time.Time) ([]InventoryUsageRow, error) {
+	var rows []InventoryUsageRow
+	err := r.db.Table("products p").
+		Select(`p.uuid AS product_uuid, p.name AS product_name, p.stock_quantity,
+			COALESCE(SUM(sr.quantity) FILTER (WHERE sr.status = ? AND sr.updated_at >= ? AND sr.updated_at < ?), 0) AS consumed,
+			COALESCE(SUM(sr.quantity) FILTER (WHERE sr.status = ? AND sr.expires_at > ?), 0) AS reserved`,
+			models.ReservationStatusConsumed, start, end, models.ReservationStatusActive, now).
+		Joins("LEFT JOIN stock_reservations sr ON sr.product_id = p.id").
+		Where("p.stock_quantity IS NOT NULL AND p.deleted_at IS NULL").
+		Group("p.id").
+		Order("consumed DESC, p.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TipsByStaff sums tips on paid orders created in [start, end), grouped by the
+// staff member the order was assigned to, largest first
+func (r *reportRepository) TipsByStaff(start, end time.Time) ([]StaffTipsRow, error) {
+	var rows []StaffTipsRow
+	err := r.db.Table("orders o").
+		Select("u.uuid AS staff_uuid, u.full_name, COUNT(*) AS order_count, SUM(o.tip_amount) AS tips").
+		Joins("LEFT JOIN users u ON u.id = o.assigned_to").
+		Where("o.status IN ? AND o.tip_amount > 0 AND o.created_at >= ? AND o.created_at < ?", revenueStatuses, start, end).
+		Group("u.uuid, u.full_name").
+		Order("tips DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SettledPayments lists gateway payments, wallet top-ups and gift card
+// purchases settled in [start, end), oldest first. Payments refunded later are
+// still listed, since the provider settled them. Orders paid from the
+// wallet, a gift card or points moved no money at the gateway and are left
+// out; top-ups and gift card purchases have no order number.
+func (r *reportRepository) SettledPayments(start, end time.Time) ([]SettlementRow, error) {
+	settled := []models.TransactionStatus{
+		models.TransactionStatusSettlement,
+		models.TransactionStatusRefund,
+		models.TransactionStatusPartialRefund,
+	}
+	offGateway := []models.PaymentMethod{
+		models.PaymentMethodCash, models.PaymentMethodWallet, models.PaymentMethodGiftCard, models.PaymentMethodPoints,
+	}
+
+	var rows []SettlementRow
+	err := r.db.Raw(`
+		SELECT p.uuid AS payment_uuid, p.midtrans_order_id AS reference, o.order_number, p.method,
+			p.payment_type, p.transaction_id, p.gross_amount, p.gateway_fee, p.settlement_time,
+			COALESCE((SELECT SUM(rf.amount) FROM refunds rf WHERE rf.payment_id = p.id), 0) AS refunded
+		FROM payments p
+		JOIN orders o ON o.id = p.order_id
+		WHERE p.method NOT IN ? AND p.transaction_status IN ? AND p.settlement_time >= ? AND p.settlement_time < ?
+		UNION ALL
+		SELECT t.uuid, t.reference, '', t.method, t.payment_type, t.transaction_id, t.amount, NULL, t.credited_at, 0
+		FROM wallet_topups t
+		WHERE t.credited_at >= ? AND t.credited_at < ?
+		UNION ALL
+		SELECT g.uuid, g.reference, '', g.method, g.payment_type, g.transaction_id, g.initial_amount, NULL, g.activated_at, 0
+		FROM gift_cards g
+		WHERE g.reference IS NOT NULL AND g.activated_at >= ? AND g.activated_at < ?
+		ORDER BY settlement_time, reference`,
+		offGateway, settled, start, end, start, end, start, end).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRewardNotFound    = errors.New("reward not found")
+	ErrRewardUnavailable = errors.New("reward is not available")
+)
+
+type RewardRepository interface {
+	Create(reward *models.Reward) error
+	FindAll(activeOnly bool) ([]models.Reward, error)
+	FindByUUID(uuid uuid.UUID) (*models.Reward, error)
+	Update(reward *models.Reward) error
+	Delete(id uint) error
+	CountRedemptions(rewardID uint) (int64, error)
+	Redeem(reward *models.Reward, entry *models.PointTransaction, voucher *models.Voucher) error
+}
+
+type rewardRepository struct {
+	db *gorm.DB
+}
+
+func NewRewardRepository(db *gorm.DB) RewardRepository {
+	return &rewardRepository{db: db}
+}
+
+func (r *rewardRepository) Create(reward *models.Reward) error {
+	return r.db.Omit(clause.Associations).Create(reward).Error
+}
+
+// FindAll returns the catalog, cheapest first, leaving out rewards that are
+// switched off when activeOnly is set
+func (r *rewardRepository) FindAll(activeOnly bool) ([]models.Reward, error) {
+	var rewards []models.Reward
+	query := r.db.Preload("Category")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("points_cost, id").Find(&rewards).Error
+	return rewards, err
+}
+
+func (r *rewardRepository) FindByUUID(uuid uuid.UUID) (*models.Reward, error) {
+	var reward models.Reward
+	err := r.db.Preload("Category").Where("uuid = ?", uuid).First(&reward).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRewardNotFound
+		}
+		return nil, err
+	}
+	return &reward, nil
+}
+
+func (r *rewardRepository) Update(reward *models.Reward) error {
+	return r.db.Model(&models.Reward{}).
+		Where("id = ?", reward.ID).
+		Updates(map[string]any{
+			"name":           reward.Name,
+			"description":    reward.Description,
+			"points_cost":    reward.PointsCost,
+			"discount_type":  reward.DiscountType,
+			"discount_value": reward.DiscountValue,
+			"max_discount":   reward.MaxDiscount,
+			"min_spend":      reward.MinSpend,
+			"category_id":    reward.CategoryID,
+			"valid_days":     reward.ValidDays,
+			"stock":          reward.Stock,
+			"is_active":      reward.IsActive,
+			"updated_at":     gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+}
+
+// Delete removes the reward from the catalog. Vouchers already bought with it
+// keep their terms.
+func (r *rewardRepository) Delete(id uint) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Reward{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRewardNotFound
+	}
+	return nil
+}
+
+// CountRedemptions counts the vouchers members bought with the reward
+func (r *rewardRepository) CountRedemptions(rewardID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Voucher{}).Where("reward_id = ?", rewardID).Count(&count).Error
+	return count, err
+}
+
+// Redeem takes one of the reward's stock, spends the member's points on it
+// and issues the voucher, all or none. It fails with ErrRewardUnavailable
+// when the reward was switched off, ran out or changed its cost since it was
+// read, and with ErrInsufficientPoints when the member cannot afford it.
+func (r *rewardRepository) Redeem(reward *models.Reward, entry *models.PointTransaction, voucher *models.Voucher) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Reward{}).
+			Where("id = ? AND is_active = ? AND points_cost = ?", reward.ID, true, reward.PointsCost).
+			Where("stock IS NULL OR stock > 0").
+			Updates(map[string]any{
+				"stock":      gorm.Expr("stock - 1"),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRewardUnavailable
+		}
+
+		if err := postPointEntry(tx, entry); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(voucher).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.VoucherEvent{
+			VoucherID: voucher.ID,
+			Action:    models.VoucherActionIssued,
+			ActorID:   &voucher.UserID,
+			Note:      &voucher.Reason,
+		}).Error
+	})
+}
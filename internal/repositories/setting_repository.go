@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSettingNotFound = errors.New("setting not found")
+)
+
+type SettingRepository interface {
+	FindByKey(key string) (*models.Setting, error)
+	Upsert(setting *models.Setting) error
+}
+
+type settingRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepository{db: db}
+}
+
+func (r *settingRepository) FindByKey(key string) (*models.Setting, error) {
+	var setting models.Setting
+	err := r.db.Where("key = ?", key).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSettingNotFound
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *settingRepository) Upsert(setting *models.Setting) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(setting).Error
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSourcePricingNotFound = errors.New("source pricing not found")
+)
+
+type SourcePricingRepository interface {
+	FindBySource(source models.OrderSource) (*models.SourcePricing, error)
+	FindAll() ([]models.SourcePricing, error)
+	Upsert(pricing *models.SourcePricing) error
+}
+
+type sourcePricingRepository struct {
+	db *gorm.DB
+}
+
+func NewSourcePricingRepository(db *gorm.DB) SourcePricingRepository {
+	return &sourcePricingRepository{db: db}
+}
+
+func (r *sourcePricingRepository) FindBySource(source models.OrderSource) (*models.SourcePricing, error) {
+	var pricing models.SourcePricing
+	err := r.db.Where("order_source = ?", source).First(&pricing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSourcePricingNotFound
+		}
+		return nil, err
+	}
+	return &pricing, nil
+}
+
+func (r *sourcePricingRepository) FindAll() ([]models.SourcePricing, error) {
+	var pricings []models.SourcePricing
+	err := r.db.Order("order_source ASC").Find(&pricings).Error
+	if err != nil {
+		return nil, err
+	}
+	return pricings, nil
+}
+
+func (r *sourcePricingRepository) Upsert(pricing *models.SourcePricing) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "order_source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price_adjustment_percent", "flat_fee", "updated_at"}),
+	}).Create(pricing).Error
+}
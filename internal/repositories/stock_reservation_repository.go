@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+type ReservationItem struct {
+	ProductID uint
+	Quantity  int
+}
+
+type StockReservationRepository interface {
+	ReserveForOrder(orderID uint, items []ReservationItem, expiresAt time.Time) error
+	ReplaceForOrder(orderID uint, items []ReservationItem, expiresAt time.Time) error
+	FindByOrderID(orderID uint) ([]models.StockReservation, error)
+	AvailableQuantity(productID uint) (int, error)
+	ConsumeByOrderID(orderID uint) error
+	ReleaseByOrderID(orderID uint) error
+}
+
+type stockReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewStockReservationRepository(db *gorm.DB) StockReservationRepository {
+	return &stockReservationRepository{db: db}
+}
+
+func (r *stockReservationRepository) ReserveForOrder(orderID uint, items []ReservationItem, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			available, err := r.lockAndGetAvailable(tx, item.ProductID)
+			if err != nil {
+				return err
+			}
+			if available < item.Quantity {
+				return ErrInsufficientStock
+			}
+
+			reservation := &models.StockReservation{
+				ProductID: item.ProductID,
+				OrderID:   orderID,
+				Quantity:  item.Quantity,
+				Status:    models.ReservationStatusActive,
+				ExpiresAt: expiresAt,
+			}
+			if err := tx.Create(reservation).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReplaceForOrder releases the order's active holds and reserves items instead.
+// If any item cannot be reserved the previous holds are kept.
+func (r *stockReservationRepository) ReplaceForOrder(orderID uint, items []ReservationItem, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.StockReservation{}).
+			Where("order_id = ? AND status = ?", orderID, models.ReservationStatusActive).
+			Update("status", models.ReservationStatusReleased).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			available, err := r.lockAndGetAvailable(tx, item.ProductID)
+			if err != nil {
+				return err
+			}
+			if available < item.Quantity {
+				return ErrInsufficientStock
+			}
+
+			reservation := &models.StockReservation{
+				ProductID: item.ProductID,
+				OrderID:   orderID,
+				Quantity:  item.Quantity,
+				Status:    models.ReservationStatusActive,
+				ExpiresAt: expiresAt,
+			}
+			if err := tx.Create(reservation).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *stockReservationRepository) FindByOrderID(orderID uint) ([]models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := r.db.
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *stockReservationRepository) AvailableQuantity(productID uint) (int, error) {
+	return r.getAvailable(r.db, productID)
+}
+
+func (r *stockReservationRepository) ConsumeByOrderID(orderID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var reservations []models.StockReservation
+		if err := tx.Where("order_id = ? AND status = ?", orderID, models.ReservationStatusActive).
+			Find(&reservations).Error; err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			// An expired hold no longer counts against stock, so re-check that
+			// the units are still there before deducting them
+			if !reservation.IsHolding() {
+				available, err := r.lockAndGetAvailable(tx, reservation.ProductID)
+				if err != nil {
+					return err
+				}
+				if available < reservation.Quantity {
+					return ErrInsufficientStock
+				}
+			}
+
+			if err := tx.Model(&models.Product{}).
+				Where("id = ? AND stock_quantity IS NOT NULL", reservation.ProductID).
+				Update("stock_quantity", gorm.Expr("stock_quantity - ?", reservation.Quantity)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&models.StockReservation{}).
+				Where("id = ?", reservation.ID).
+				Update("status", models.ReservationStatusConsumed).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *stockReservationRepository) ReleaseByOrderID(orderID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var reservations []models.StockReservation
+		if err := tx.Where("order_id = ? AND status IN ?", orderID, []models.ReservationStatus{
+			models.ReservationStatusActive,
+			models.ReservationStatusConsumed,
+		}).Find(&reservations).Error; err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			// Consumed units were already deducted, so put them back
+			if reservation.Status == models.ReservationStatusConsumed {
+				if err := tx.Model(&models.Product{}).
+					Where("id = ? AND stock_quantity IS NOT NULL", reservation.ProductID).
+					Update("stock_quantity", gorm.Expr("stock_quantity + ?", reservation.Quantity)).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(&models.StockReservation{}).
+				Where("id = ?", reservation.ID).
+				Update("status", models.ReservationStatusReleased).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// lockAndGetAvailable locks the product row for the rest of the transaction so
+// concurrent checkouts for the same capped item are serialized.
+func (r *stockReservationRepository) lockAndGetAvailable(tx *gorm.DB, productID uint) (int, error) {
+	var product models.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock_quantity").
+		Where("id = ?", productID).
+		First(&product).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrProductNotFound
+		}
+		return 0, err
+	}
+
+	return r.availableFor(tx, &product)
+}
+
+func (r *stockReservationRepository) getAvailable(db *gorm.DB, productID uint) (int, error) {
+	var product models.Product
+	err := db.Select("id", "stock_quantity").Where("id = ?", productID).First(&product).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrProductNotFound
+		}
+		return 0, err
+	}
+
+	return r.availableFor(db, &product)
+}
+
+func (r *stockReservationRepository) availableFor(db *gorm.DB, product *models.Product) (int, error) {
+	// Uncapped products never run out
+	if product.StockQuantity == nil {
+		return math.MaxInt, nil
+	}
+
+	var reserved int64
+	err := db.Model(&models.StockReservation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ? AND status = ? AND expires_at > ?", product.ID, models.ReservationStatusActive, time.Now()).
+		Scan(&reserved).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return *product.StockQuantity - int(reserved), nil
+}
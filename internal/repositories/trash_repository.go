@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProductPurgeImpact counts the rows a permanent product delete touches.
+// Order items keep their product name snapshot and lose only the link;
+// customizations and stock reservations are deleted with the product.
+type ProductPurgeImpact struct {
+	OrderItems         int64
+	Orders             int64
+	Customizations     int64
+	ActiveReservations int64
+}
+
+// CategoryPurgeImpact counts the rows left without a category, or without a
+// parent, when a category is permanently deleted
+type CategoryPurgeImpact struct {
+	ChildCategories int64
+	Products        int64
+}
+
+// TrashRepository browses and purges soft-deleted rows. Restoring goes through
+// the owning repository.
+type TrashRepository interface {
+	FindDeletedProducts() ([]models.Product, error)
+	FindDeletedCategories() ([]models.Category, error)
+	ProductPurgeImpact(productID uint) (*ProductPurgeImpact, error)
+	CategoryPurgeImpact(categoryID uint) (*CategoryPurgeImpact, error)
+	PurgeProduct(productID uint) error
+	PurgeCategory(categoryID uint) error
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+func (r *trashRepository) FindDeletedProducts() ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Preload("Category").
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *trashRepository) FindDeletedCategories() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *trashRepository) ProductPurgeImpact(productID uint) (*ProductPurgeImpact, error) {
+	var impact ProductPurgeImpact
+
+	err := r.db.Model(&models.OrderItem{}).
+		Select("COUNT(*) AS order_items, COUNT(DISTINCT order_id) AS orders").
+		Where("product_id = ?", productID).
+		Scan(&impact).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.ProductCustomization{}).
+		Where("product_id = ?", productID).
+		Count(&impact.Customizations).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.StockReservation{}).
+		Where("product_id = ? AND status = ? AND expires_at > NOW()", productID, models.ReservationStatusActive).
+		Count(&impact.ActiveReservations).Error; err != nil {
+		return nil, err
+	}
+
+	return &impact, nil
+}
+
+func (r *trashRepository) CategoryPurgeImpact(categoryID uint) (*CategoryPurgeImpact, error) {
+	var impact CategoryPurgeImpact
+
+	if err := r.db.Model(&models.Category{}).
+		Where("parent_id = ?", categoryID).
+		Count(&impact.ChildCategories).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.Product{}).
+		Where("category_id = ?", categoryID).
+		Count(&impact.Products).Error; err != nil {
+		return nil, err
+	}
+
+	return &impact, nil
+}
+
+// PurgeProduct permanently deletes a soft-deleted product. Foreign keys detach
+// order items and cascade to customizations and reservations.
+func (r *trashRepository) PurgeProduct(productID uint) error {
+	result := r.db.Where("id = ? AND deleted_at IS NOT NULL", productID).Delete(&models.Product{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// PurgeCategory permanently deletes a soft-deleted category. Its products
+// become uncategorized and its children move to the top level.
+func (r *trashRepository) PurgeCategory(categoryID uint) error {
+	result := r.db.Where("id = ? AND deleted_at IS NOT NULL", categoryID).Delete(&models.Category{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RouteUsageRow struct {
+	Client         string
+	Method         string
+	Route          string
+	Requests       int64
+	ClientErrors   int64
+	ServerErrors   int64
+	TotalLatencyMs int64
+	MaxLatencyMs   int64
+	LastSeen       time.Time
+}
+
+type UsageRepository interface {
+	Add(usage []models.APIUsage) error
+	RouteUsage(start, end time.Time, client string) ([]RouteUsageRow, error)
+}
+
+type usageRepository struct {
+	db *gorm.DB
+}
+
+func NewUsageRepository(db *gorm.DB) UsageRepository {
+	return &usageRepository{db: db}
+}
+
+// Add merges counts into their hourly rows. Every instance flushes its own
+// counts, so existing rows are incremented rather than replaced.
+func (r *usageRepository) Add(usage []models.APIUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "bucket_start"}, {Name: "client"}, {Name: "method"}, {Name: "route"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"requests":         gorm.Expr("api_usage.requests + EXCLUDED.requests"),
+			"client_errors":    gorm.Expr("api_usage.client_errors + EXCLUDED.client_errors"),
+			"server_errors":    gorm.Expr("api_usage.server_errors + EXCLUDED.server_errors"),
+			"total_latency_ms": gorm.Expr("api_usage.total_latency_ms + EXCLUDED.total_latency_ms"),
+			"max_latency_ms":   gorm.Expr("GREATEST(api_usage.max_latency_ms, EXCLUDED.max_latency_ms)"),
+		}),
+	}).Create(&usage).Error
+}
+
+// RouteUsage sums hourly rows starting in [start, end) per client and route,
+// busiest first. An empty client matches every client.
+func (r *usageRepository) RouteUsage(start, end time.Time, client string) ([]RouteUsageRow, error) {
+	query := r.db.Model(&models.APIUsage{}).
+		Select("client, method, route, SUM(requests) AS requests, SUM(client_errors) AS client_errors, SUM(server_errors) AS server_errors, "+
+			"SUM(total_latency_ms) AS total_latency_ms, MAX(max_latency_ms) AS max_latency_ms, MAX(bucket_start) AS last_seen").
+		Where("bucket_start >= ? AND bucket_start < ?", start, end)
+	if client != "" {
+		query = query.Where("client = ?", client)
+	}
+
+	var rows []RouteUsageRow
+	err := query.
+		Group("client, method, route").
+		Order("requests DESC, client, route, method").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"github.com/carllix/matchaciee-backend/internal/models"
 	"github.com/google/uuid"
@@ -13,14 +14,29 @@ var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 )
 
+// MemberSegment is a group of active members picked by their order history
+type MemberSegment string
+
+const (
+	// MemberSegmentAll is every active member
+	MemberSegmentAll MemberSegment = "all"
+	// MemberSegmentLapsed is members who have completed orders but none since
+	// a given time
+	MemberSegmentLapsed MemberSegment = "lapsed"
+	// MemberSegmentNew is members without a completed order
+	MemberSegmentNew MemberSegment = "new"
+)
+
 type UserRepository interface {
 	Create(user *models.User) error
 	FindByID(id uint) (*models.User, error)
 	FindByUUID(uuid uuid.UUID) (*models.User, error)
 	FindByEmail(email string) (*models.User, error)
+	FindByPhone(phone string) (*models.User, error)
 	Update(user *models.User) error
 	Delete(id uint) error
 	ExistsByEmail(email string) (bool, error)
+	FindMemberIDs(segment MemberSegment, inactiveSince time.Time) ([]uint, error)
 }
 
 type userRepository struct {
@@ -79,6 +95,20 @@ func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// FindByPhone returns the active user with the phone number. Phone numbers are
+// not unique, so a number shared by several accounts is treated as not found.
+func (r *userRepository) FindByPhone(phone string) (*models.User, error) {
+	var users []models.User
+	err := r.db.Where("phone = ? AND is_active = ?", phone, true).Limit(2).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, ErrUserNotFound
+	}
+	return &users[0], nil
+}
+
 func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
@@ -96,6 +126,29 @@ func (r *userRepository) ExistsByEmail(email string) (bool, error) {
 	return count > 0, nil
 }
 
+// FindMemberIDs returns the active members in the segment. inactiveSince is
+// only used by MemberSegmentLapsed.
+func (r *userRepository) FindMemberIDs(segment MemberSegment, inactiveSince time.Time) ([]uint, error) {
+	completed := func() *gorm.DB {
+		return r.db.Model(&models.Order{}).
+			Select("user_id").
+			Where("user_id IS NOT NULL AND status = ?", models.OrderStatusCompleted)
+	}
+
+	query := r.db.Model(&models.User{}).Where("role = ? AND is_active = ?", models.RoleMember, true)
+	switch segment {
+	case MemberSegmentLapsed:
+		query = query.Where("id IN (?)", completed()).
+			Where("id NOT IN (?)", completed().Where("created_at >= ?", inactiveSince))
+	case MemberSegmentNew:
+		query = query.Where("id NOT IN (?)", completed())
+	}
+
+	var ids []uint
+	err := query.Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
 // func (r *userRepository) ExistsByEmail(email string) (bool, error) {
 //     var exists bool
 //     err := r.db.Raw("SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)", email).
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrVoucherNotFound      = errors.New("voucher not found")
+	ErrVoucherNotRedeemable = errors.New("voucher is not redeemable")
+)
+
+// VoucherFilters narrows the voucher list. Nil fields are not filtered on.
+type VoucherFilters struct {
+	Status *models.VoucherStatus
+	UserID *uint
+}
+
+type VoucherRepository interface {
+	CreateBatch(vouchers []models.Voucher) error
+	FindAll(filters VoucherFilters, limit, offset int) ([]models.Voucher, int64, error)
+	FindByUUID(uuid uuid.UUID) (*models.Voucher, error)
+	FindByCode(code string) (*models.Voucher, error)
+	FindByOrderID(orderID uint) (*models.Voucher, error)
+	FindByUserID(userID uint) ([]models.Voucher, error)
+	FindEvents(voucherID uint) ([]models.VoucherEvent, error)
+	Revoke(id, revokedBy uint, reason string) (bool, error)
+	CategoryTreeIDs(categoryID uint) ([]uint, error)
+}
+
+type voucherRepository struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) VoucherRepository {
+	return &voucherRepository{db: db}
+}
+
+// CreateBatch saves vouchers issued together and logs each one as issued by
+// its IssuedBy, all or none
+func (r *voucherRepository) CreateBatch(vouchers []models.Voucher) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&vouchers).Error; err != nil {
+			return err
+		}
+
+		events := make([]models.VoucherEvent, len(vouchers))
+		for i, voucher := range vouchers {
+			events[i] = models.VoucherEvent{
+				VoucherID: voucher.ID,
+				Action:    models.VoucherActionIssued,
+				ActorID:   voucher.IssuedBy,
+				Note:      &vouchers[i].Reason,
+			}
+		}
+		return tx.Create(&events).Error
+	})
+}
+
+func (r *voucherRepository) FindAll(filters VoucherFilters, limit, offset int) ([]models.Voucher, int64, error) {
+	var vouchers []models.Voucher
+	var total int64
+
+	query := r.db.Model(&models.Voucher{})
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&vouchers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return vouchers, total, nil
+}
+
+func (r *voucherRepository) FindByUUID(uuid uuid.UUID) (*models.Voucher, error) {
+	return r.findOne("uuid = ?", uuid)
+}
+
+// FindByCode looks the code up as stored, in upper case
+func (r *voucherRepository) FindByCode(code string) (*models.Voucher, error) {
+	return r.findOne("code = ?", code)
+}
+
+// FindByOrderID returns the voucher the order redeemed
+func (r *voucherRepository) FindByOrderID(orderID uint) (*models.Voucher, error) {
+	return r.findOne("order_id = ? AND status = ?", orderID, models.VoucherStatusRedeemed)
+}
+
+func (r *voucherRepository) findOne(query string, args ...any) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.Preload("User").Preload("Order").Where(query, args...).First(&voucher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+// FindByUserID returns the member's vouchers, newest first
+func (r *voucherRepository) FindByUserID(userID uint) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	err := r.db.Preload("Order").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+// FindEvents returns the voucher's audit trail, oldest first
+func (r *voucherRepository) FindEvents(voucherID uint) ([]models.VoucherEvent, error) {
+	var events []models.VoucherEvent
+	err := r.db.Preload("Actor").Preload("Order").
+		Where("voucher_id = ?", voucherID).
+		Order("id").
+		Find(&events).Error
+	return events, err
+}
+
+// Revoke stops an active voucher from being redeemed. It reports false when
+// the voucher was not active.
+func (r *voucherRepository) Revoke(id, revokedBy uint, reason string) (bool, error) {
+	revoked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Voucher{}).
+			Where("id = ? AND status = ?", id, models.VoucherStatusActive).
+			Updates(map[string]any{
+				"status":        models.VoucherStatusRevoked,
+				"revoked_at":    time.Now(),
+				"revoked_by":    revokedBy,
+				"revoke_reason": reason,
+				"updated_at":    gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		revoked = true
+		return tx.Create(&models.VoucherEvent{
+			VoucherID: id,
+			Action:    models.VoucherActionRevoked,
+			ActorID:   &revokedBy,
+			Note:      &reason,
+		}).Error
+	})
+	return revoked, err
+}
+
+// CategoryTreeIDs returns the category a free-item voucher covers and every
+// live category nested under it
+func (r *voucherRepository) CategoryTreeIDs(categoryID uint) ([]uint, error) {
+	return categoryTreeIDs(r.db, categoryID)
+}
+
+// redeemVoucher claims an active, unexpired voucher of the order's member for
+// the order. Another checkout that claimed it first makes this fail with
+// ErrVoucherNotRedeemable.
+func redeemVoucher(tx *gorm.DB, voucherID uint, order *models.Order) error {
+	if order.UserID == nil {
+		return ErrVoucherNotRedeemable
+	}
+
+	now := time.Now()
+	result := tx.Model(&models.Voucher{}).
+		Where("id = ? AND user_id = ? AND status = ?", voucherID, *order.UserID, models.VoucherStatusActive).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Updates(map[string]any{
+			"status":      models.VoucherStatusRedeemed,
+			"order_id":    order.ID,
+			"redeemed_at": now,
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVoucherNotRedeemable
+	}
+
+	return tx.Create(&models.VoucherEvent{
+		VoucherID: voucherID,
+		Action:    models.VoucherActionRedeemed,
+		ActorID:   order.UserID,
+		OrderID:   &order.ID,
+	}).Error
+}
+
+// releaseOrderVouchers makes the vouchers a cancelled order redeemed active
+// again. They keep their original expiry.
+func releaseOrderVouchers(tx *gorm.DB, orderID uint) error {
+	var vouchers []models.Voucher
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, models.VoucherStatusRedeemed).
+		Find(&vouchers).Error
+	if err != nil {
+		return err
+	}
+
+	for _, voucher := range vouchers {
+		err := tx.Model(&models.Voucher{}).
+			Where("id = ?", voucher.ID).
+			Updates(map[string]any{
+				"status":      models.VoucherStatusActive,
+				"order_id":    nil,
+				"redeemed_at": nil,
+				"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&models.VoucherEvent{
+			VoucherID: voucher.ID,
+			Action:    models.VoucherActionReleased,
+			OrderID:   &orderID,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWalletTopUpNotFound = errors.New("wallet top-up not found")
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+)
+
+type WalletRepository interface {
+	Balance(userID uint) (float64, error)
+	FindTransactions(userID uint, limit, offset int) ([]models.WalletTransaction, int64, error)
+	Post(entry *models.WalletTransaction) error
+	PayOrder(entry *models.WalletTransaction, payment *models.Payment, from, to models.OrderStatus) error
+	CreateTopUp(topUp *models.WalletTopUp) error
+	FindTopUpByReference(reference string) (*models.WalletTopUp, error)
+	UpdateTopUp(topUp *models.WalletTopUp) error
+	CreditTopUp(topUp *models.WalletTopUp) (bool, error)
+}
+
+type walletRepository struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) WalletRepository {
+	return &walletRepository{db: db}
+}
+
+// Balance is the balance after the member's latest ledger entry, zero for a
+// wallet that was never used
+func (r *walletRepository) Balance(userID uint) (float64, error) {
+	return walletBalance(r.db, userID)
+}
+
+// FindTransactions returns the newest entries first, with the order each
+// payment or refund was for
+func (r *walletRepository) FindTransactions(userID uint, limit, offset int) ([]models.WalletTransaction, int64, error) {
+	var entries []models.WalletTransaction
+	var total int64
+
+	query := r.db.Model(&models.WalletTransaction{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Order").
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// Post adds the entry to the ledger. It returns ErrInsufficientBalance when a
+// debit would take the balance below zero.
+func (r *walletRepository) Post(entry *models.WalletTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return postWalletEntry(tx, entry)
+	})
+}
+
+// PayOrder debits the wallet, saves the settled payment and moves its order
+// from one status to the next in one transaction. It returns
+// ErrInsufficientBalance or ErrOrderNotAwaitingPayment without changing
+// anything.
+func (r *walletRepository) PayOrder(entry *models.WalletTransaction, payment *models.Payment, from, to models.OrderStatus) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := postWalletEntry(tx, entry); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", payment.OrderID, from).
+			Updates(orderStatusUpdates(to))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderNotAwaitingPayment
+		}
+		return tx.Create(payment).Error
+	})
+}
+
+func (r *walletRepository) CreateTopUp(topUp *models.WalletTopUp) error {
+	return r.db.Create(topUp).Error
+}
+
+func (r *walletRepository) FindTopUpByReference(reference string) (*models.WalletTopUp, error) {
+	var topUp models.WalletTopUp
+	err := r.db.Where("reference = ?", reference).First(&topUp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletTopUpNotFound
+		}
+		return nil, err
+	}
+	return &topUp, nil
+}
+
+func (r *walletRepository) UpdateTopUp(topUp *models.WalletTopUp) error {
+	return r.db.Save(topUp).Error
+}
+
+// CreditTopUp saves the settled top-up and adds it to the wallet, once. It
+// reports false when the top-up was already credited, as when the gateway
+// repeats its notification.
+func (r *walletRepository) CreditTopUp(topUp *models.WalletTopUp) (bool, error) {
+	credited := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.WalletTopUp{}).
+			Where("id = ? AND credited_at IS NULL", topUp.ID).
+			Updates(map[string]any{
+				"transaction_status": topUp.TransactionStatus,
+				"payment_type":       topUp.PaymentType,
+				"transaction_id":     topUp.TransactionID,
+				"credited_at":        now,
+				"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		err := postWalletEntry(tx, &models.WalletTransaction{
+			UserID:  topUp.UserID,
+			Type:    models.WalletTransactionTopUp,
+			Amount:  topUp.Amount,
+			TopUpID: &topUp.ID,
+		})
+		if err != nil {
+			return err
+		}
+		topUp.CreditedAt = &now
+		credited = true
+		return nil
+	})
+	return credited, err
+}
+
+// postWalletEntry locks the member's row so entries are posted one at a time,
+// then records the entry with the balance it leaves
+func postWalletEntry(tx *gorm.DB, entry *models.WalletTransaction) error {
+	if err := tx.Exec("SELECT id FROM users WHERE id = ? FOR UPDATE", entry.UserID).Error; err != nil {
+		return err
+	}
+
+	balance, err := walletBalance(tx, entry.UserID)
+	if err != nil {
+		return err
+	}
+	after := math.Round((balance+entry.Amount)*100) / 100
+	if after < 0 {
+		return ErrInsufficientBalance
+	}
+
+	entry.BalanceAfter = after
+	return tx.Create(entry).Error
+}
+
+func walletBalance(db *gorm.DB, userID uint) (float64, error) {
+	var balance float64
+	err := db.Model(&models.WalletTransaction{}).
+		Select("balance_after").
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(1).
+		Scan(&balance).Error
+	return balance, err
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWebhookEventNotFound = errors.New("webhook event not found")
+)
+
+type WebhookEventFilters struct {
+	Provider *models.PaymentMethod
+	Status   *models.WebhookEventStatus
+}
+
+type WebhookEventRepository interface {
+	Create(event *models.WebhookEvent) error
+	FindByUUID(uuid uuid.UUID) (*models.WebhookEvent, error)
+	FindAll(filters WebhookEventFilters, limit, offset int) ([]models.WebhookEvent, int64, error)
+	RecordResult(id uint, processErr *string) error
+}
+
+type webhookEventRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookEventRepository(db *gorm.DB) WebhookEventRepository {
+	return &webhookEventRepository{db: db}
+}
+
+func (r *webhookEventRepository) Create(event *models.WebhookEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *webhookEventRepository) FindByUUID(uuid uuid.UUID) (*models.WebhookEvent, error) {
+	var event models.WebhookEvent
+	err := r.db.Where("uuid = ?", uuid).First(&event).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookEventNotFound
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// FindAll returns the newest events first
+func (r *webhookEventRepository) FindAll(filters WebhookEventFilters, limit, offset int) ([]models.WebhookEvent, int64, error) {
+	var events []models.WebhookEvent
+	var total int64
+
+	query := r.db.Model(&models.WebhookEvent{})
+	if filters.Provider != nil {
+		query = query.Where("provider = ?", *filters.Provider)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// RecordResult stores the outcome of a processing attempt: processed without
+// an error, failed with one
+func (r *webhookEventRepository) RecordResult(id uint, processErr *string) error {
+	status := models.WebhookEventStatusProcessed
+	if processErr != nil {
+		status = models.WebhookEventStatusFailed
+	}
+	return r.db.Model(&models.WebhookEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       status,
+			"error":        processErr,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"processed_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+}
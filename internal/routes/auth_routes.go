@@ -7,15 +7,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupAuthRoutes(app *fiber.App, authHandler *handlers.AuthHandler, jwtUtil *utils.JWTUtil) {
+func SetupAuthRoutes(app *fiber.App, authHandler *handlers.AuthHandler, jwtUtil *utils.JWTUtil, loginLimiter fiber.Handler) {
 	auth := app.Group("/api/v1/auth")
 
 	// Public routes
 	auth.Post("/register", authHandler.Register)
-	auth.Post("/login", authHandler.Login)
+	auth.Post("/login", loginLimiter, authHandler.Login)
+	auth.Post("/code/request", loginLimiter, authHandler.RequestLoginCode)
+	auth.Post("/code/login", loginLimiter, authHandler.LoginWithCode)
 	auth.Post("/refresh", authHandler.RefreshToken)
 	auth.Post("/logout", authHandler.Logout)
 
 	// Protected routes
 	auth.Get("/me", middleware.AuthMiddleware(jwtUtil), authHandler.GetMe)
+	auth.Put("/me", middleware.AuthMiddleware(jwtUtil), authHandler.UpdateMe)
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupCampaignRoutes(
+	app *fiber.App,
+	campaignHandler *handlers.CampaignHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	campaigns := api.Group("/campaigns",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	campaigns.Post("/", campaignHandler.CreateCampaign)
+	campaigns.Get("/", campaignHandler.GetCampaigns)
+	campaigns.Get("/:id", campaignHandler.GetCampaign)
+	campaigns.Post("/:id/cancel", campaignHandler.CancelCampaign)
+}
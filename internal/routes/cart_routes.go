@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupCartRoutes(
+	app *fiber.App,
+	cartHandler *handlers.CartHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Members use their own cart; staff use kiosk session carts
+	cart := api.Group("/cart",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember, models.RoleAdmin, models.RoleBarista),
+	)
+
+	cart.Get("/", cartHandler.GetCart)
+	cart.Delete("/", cartHandler.ClearCart)
+	cart.Post("/items", cartHandler.AddCartItem)
+	cart.Put("/items/:itemId", cartHandler.UpdateCartItem)
+	cart.Delete("/items/:itemId", cartHandler.RemoveCartItem)
+	cart.Post("/checkout", cartHandler.CheckoutCart)
+}
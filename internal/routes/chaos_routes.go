@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupChaosRoutes(
+	app *fiber.App,
+	chaosHandler *handlers.ChaosHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	chaos := api.Group("/chaos",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	chaos.Get("/", chaosHandler.GetFaults)
+	chaos.Put("/", chaosHandler.UpdateFaults)
+	chaos.Delete("/", chaosHandler.ResetFaults)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupCustomerRoutes(
+	app *fiber.App,
+	customerHandler *handlers.CustomerHandler,
+	jwtUtil *utils.JWTUtil,
+	shedLowPriority fiber.Handler,
+) {
+	api := app.Group("/api/v1")
+	customers := api.Group("/customers",
+		shedLowPriority,
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	customers.Get("/segments/:name/export", customerHandler.ExportSegment)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupDailySummaryRoutes(app *fiber.App, summaryHandler *handlers.DailySummaryHandler, jwtUtil *utils.JWTUtil) {
+	api := app.Group("/api/v1")
+
+	api.Post("/orders/close-day",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		summaryHandler.CloseDay,
+	)
+}
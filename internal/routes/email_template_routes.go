@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupEmailTemplateRoutes(
+	app *fiber.App,
+	templateHandler *handlers.EmailTemplateHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	templates := api.Group("/email-templates",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	templates.Get("/", templateHandler.GetEmailTemplates)
+	templates.Put("/:key", templateHandler.UpdateEmailTemplate)
+	templates.Get("/:key/versions", templateHandler.GetEmailTemplateVersions)
+	templates.Post("/:key/versions/:version/activate", templateHandler.ActivateEmailTemplateVersion)
+	templates.Post("/:key/test-send", templateHandler.TestSendEmailTemplate)
+}
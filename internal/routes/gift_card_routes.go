@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupGiftCardRoutes(
+	app *fiber.App,
+	giftCardHandler *handlers.GiftCardHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Public routes
+	api.Post("/orders/:id/gift-card", giftCardHandler.RedeemGiftCard)
+
+	// Member routes
+	api.Post("/me/gift-cards",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		giftCardHandler.PurchaseGiftCard,
+	)
+
+	// Admin routes
+	api.Get("/gift-cards",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		giftCardHandler.GetGiftCards,
+	)
+	api.Post("/gift-cards",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		giftCardHandler.IssueGiftCard,
+	)
+	api.Get("/gift-cards/code/:code",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		giftCardHandler.CheckGiftCardBalance,
+	)
+	api.Get("/gift-cards/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		giftCardHandler.GetGiftCard,
+	)
+	api.Post("/gift-cards/:id/void",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		giftCardHandler.VoidGiftCard,
+	)
+}
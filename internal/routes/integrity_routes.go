@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupIntegrityRoutes(
+	app *fiber.App,
+	integrityHandler *handlers.IntegrityHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	integrity := api.Group("/integrity",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	integrity.Get("/anomalies", integrityHandler.GetAnomalies)
+	integrity.Post("/anomalies/:id/resolve", integrityHandler.ResolveAnomaly)
+	integrity.Post("/run", integrityHandler.RunIntegrityCheck)
+}
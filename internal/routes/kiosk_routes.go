@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupKioskRoutes(
+	app *fiber.App,
+	kioskHandler *handlers.KioskHandler,
+	jwtUtil *utils.JWTUtil,
+	kioskAuth fiber.Handler,
+) {
+	api := app.Group("/api/v1")
+	kiosks := api.Group("/kiosks")
+
+	// Kiosk routes
+	kiosks.Post("/heartbeat", kioskAuth, kioskHandler.Heartbeat)
+
+	// Admin routes
+	kiosks.Post("/",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.CreateKiosk,
+	)
+	kiosks.Get("/",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.GetKiosks,
+	)
+	kiosks.Get("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.GetKiosk,
+	)
+	kiosks.Put("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.UpdateKiosk,
+	)
+	kiosks.Delete("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.DeleteKiosk,
+	)
+	kiosks.Post("/:id/key",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		kioskHandler.RegenerateKioskKey,
+	)
+}
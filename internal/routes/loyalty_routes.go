@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupLoyaltyRoutes(
+	app *fiber.App,
+	loyaltyHandler *handlers.LoyaltyHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Member routes
+	api.Get("/me/points",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		loyaltyHandler.GetMyPoints,
+	)
+	api.Get("/me/stamps",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		loyaltyHandler.GetMyStamps,
+	)
+	api.Post("/orders/:id/payments/points",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		loyaltyHandler.RedeemPoints,
+	)
+
+	// Admin routes
+	api.Get("/points/:userId",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		loyaltyHandler.GetPoints,
+	)
+}
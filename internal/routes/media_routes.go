@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupMediaRoutes(
+	app *fiber.App,
+	mediaHandler *handlers.MediaHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	media := api.Group("/media",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	media.Post("/", mediaHandler.UploadMedia)
+	media.Get("/usage", mediaHandler.GetStorageUsage)
+	media.Post("/cleanup", mediaHandler.CleanupMedia)
+}
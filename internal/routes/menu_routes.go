@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupMenuRoutes(
+	app *fiber.App,
+	menuHandler *handlers.MenuHandler,
+	catalogETag fiber.Handler,
+	shedLowPriority fiber.Handler,
+) {
+	api := app.Group("/api/v1")
+	api.Get("/menu", shedLowPriority, catalogETag, menuHandler.GetMenu)
+}
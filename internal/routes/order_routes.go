@@ -12,13 +12,22 @@ func SetupOrderRoutes(
 	app *fiber.App,
 	orderHandler *handlers.OrderHandler,
 	jwtUtil *utils.JWTUtil,
+	lookupLimiter fiber.Handler,
+	guestOrderLimiter fiber.Handler,
+	kioskAuth fiber.Handler,
 ) {
 	api := app.Group("/api/v1")
 	orders := api.Group("/orders")
 
 	// Public routes
-	orders.Post("/guest", orderHandler.CreateGuestOrder)
+	orders.Post("/guest", guestOrderLimiter, orderHandler.CreateGuestOrder)
 	orders.Get("/track/:uuid", orderHandler.TrackGuestOrder)
+	orders.Get("/status/:token", orderHandler.GetOrderStatus)
+	orders.Post("/lookup", lookupLimiter, orderHandler.LookupGuestOrder)
+
+	// Kiosk routes
+	orders.Post("/kiosk", kioskAuth, orderHandler.CreateKioskOrder)
+	api.Get("/ws/orders/:uuid", orderHandler.StreamOrderStatus)
 
 	// Member routes
 	orders.Post("/",
@@ -33,13 +42,50 @@ func SetupOrderRoutes(
 		orderHandler.GetMyOrders,
 	)
 
+	orders.Post("/:id/reorder",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		orderHandler.Reorder,
+	)
+
+	// Registered before /:id so "stream" and "queue" are not taken as order IDs
+	orders.Get("/stream",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.StreamOrders,
+	)
+
+	orders.Get("/queue",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.GetKitchenQueue,
+	)
+
 	orders.Get("/:id",
 		middleware.AuthMiddleware(jwtUtil),
 		middleware.RoleMiddleware(models.RoleMember, models.RoleAdmin, models.RoleBarista),
 		orderHandler.GetOrder,
 	)
 
+	orders.Put("/:id/items",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember, models.RoleAdmin, models.RoleBarista),
+		orderHandler.UpdateOrderItems,
+	)
+
 	// Admin/Barista routes
+	orders.Post("/staff",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.CreateStaffOrder,
+	)
+
+	orders.Post("/verify-pickup",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.VerifyPickup,
+	)
+
 	orders.Get("/",
 		middleware.AuthMiddleware(jwtUtil),
 		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
@@ -52,6 +98,30 @@ func SetupOrderRoutes(
 		orderHandler.GetOrderByNumber,
 	)
 
+	orders.Post("/bulk-status",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		orderHandler.BulkUpdateOrderStatus,
+	)
+
+	orders.Post("/:id/verify",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		orderHandler.VerifyOrderTotals,
+	)
+
+	orders.Post("/:id/claim",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.ClaimOrder,
+	)
+
+	orders.Put("/:id/priority",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		orderHandler.UpdateOrderPriority,
+	)
+
 	orders.Put("/:id/status",
 		middleware.AuthMiddleware(jwtUtil),
 		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
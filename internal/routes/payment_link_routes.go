@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPaymentLinkRoutes(
+	app *fiber.App,
+	paymentLinkHandler *handlers.PaymentLinkHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	api.Post("/orders/:id/payment-link",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		paymentLinkHandler.CreatePaymentLink,
+	)
+	api.Get("/pay/:token", paymentLinkHandler.OpenPaymentLink)
+}
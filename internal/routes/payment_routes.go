@@ -2,14 +2,48 @@ package routes
 
 import (
 	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/gofiber/fiber/v2"
 )
 
 func SetupPaymentRoutes(
 	app *fiber.App,
 	paymentHandler *handlers.PaymentHandler,
+	jwtUtil *utils.JWTUtil,
+	midtransWebhookGuards ...fiber.Handler,
 ) {
 	api := app.Group("/api/v1")
 	api.Post("/orders/:id/payment", paymentHandler.CreatePaymentToken)
-	api.Post("/webhooks/midtrans", paymentHandler.HandleMidtransWebhook)
+	api.Post("/orders/:id/payments/qris", paymentHandler.ChargeQRIS)
+	api.Get("/payments/:id/status", paymentHandler.GetPaymentStatus)
+	api.Post("/orders/:id/payments/cash",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		paymentHandler.RecordCashPayment,
+	)
+	api.Get("/payments",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		paymentHandler.GetPayments,
+	)
+	api.Post("/payments/:id/refund",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		paymentHandler.RefundPayment,
+	)
+	api.Post("/payments/:id/verify",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		paymentHandler.VerifyPayment,
+	)
+	api.Post("/payments/:id/cancel",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin, models.RoleBarista),
+		paymentHandler.CancelPayment,
+	)
+	api.Post("/webhooks/midtrans", append(midtransWebhookGuards, paymentHandler.HandleMidtransWebhook)...)
+	api.Post("/webhooks/stripe", paymentHandler.HandleStripeWebhook)
+	api.Post("/webhooks/xendit", paymentHandler.HandleXenditWebhook)
 }
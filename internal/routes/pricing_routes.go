@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPricingRoutes(
+	app *fiber.App,
+	pricingHandler *handlers.PricingHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	pricing := api.Group("/pricing",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	pricing.Get("/sources", pricingHandler.GetSourcePricing)
+	pricing.Put("/sources/:source", pricingHandler.UpdateSourcePricing)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPrintJobRoutes(
+	app *fiber.App,
+	printJobHandler *handlers.PrintJobHandler,
+) {
+	printJobs := app.Group("/api/v1/print-jobs")
+	printJobs.Get("/next", printJobHandler.RequireAgent, printJobHandler.Next)
+	printJobs.Post("/:id/ack", printJobHandler.RequireAgent, printJobHandler.Ack)
+}
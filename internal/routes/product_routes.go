@@ -13,6 +13,8 @@ func SetupProductRoutes(
 	categoryHandler *handlers.CategoryHandler,
 	productHandler *handlers.ProductHandler,
 	jwtUtil *utils.JWTUtil,
+	catalogETag fiber.Handler,
+	shedLowPriority fiber.Handler,
 ) {
 	api := app.Group("/api/v1")
 
@@ -20,7 +22,7 @@ func SetupProductRoutes(
 	categories := api.Group("/categories")
 
 	// Public routes
-	categories.Get("/", categoryHandler.GetAllCategories)
+	categories.Get("/", shedLowPriority, catalogETag, categoryHandler.GetAllCategories)
 	categories.Get("/:id", categoryHandler.GetCategory)
 	categories.Get("/slug/:slug", categoryHandler.GetCategoryBySlug)
 
@@ -30,6 +32,11 @@ func SetupProductRoutes(
 		middleware.RoleMiddleware(models.RoleAdmin),
 		categoryHandler.CreateCategory,
 	)
+	categories.Put("/reorder",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		categoryHandler.ReorderCategories,
+	)
 	categories.Put("/:id",
 		middleware.AuthMiddleware(jwtUtil),
 		middleware.RoleMiddleware(models.RoleAdmin),
@@ -40,12 +47,17 @@ func SetupProductRoutes(
 		middleware.RoleMiddleware(models.RoleAdmin),
 		categoryHandler.DeleteCategory,
 	)
+	categories.Post("/:id/restore",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		categoryHandler.RestoreCategory,
+	)
 
 	// Product routes
 	products := api.Group("/products")
 
 	// Public routes
-	products.Get("/", productHandler.GetAllProducts)
+	products.Get("/", shedLowPriority, catalogETag, productHandler.GetAllProducts)
 	products.Get("/:id", productHandler.GetProduct)
 	products.Get("/slug/:slug", productHandler.GetProductBySlug)
 
@@ -55,6 +67,11 @@ func SetupProductRoutes(
 		middleware.RoleMiddleware(models.RoleAdmin),
 		productHandler.CreateProduct,
 	)
+	products.Put("/reorder",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		productHandler.ReorderProducts,
+	)
 	products.Put("/:id",
 		middleware.AuthMiddleware(jwtUtil),
 		middleware.RoleMiddleware(models.RoleAdmin),
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPromotionRoutes(
+	app *fiber.App,
+	promotionHandler *handlers.PromotionHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	promotions := api.Group("/promotions",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	promotions.Post("/", promotionHandler.CreatePromotion)
+	promotions.Get("/", promotionHandler.GetPromotions)
+	promotions.Get("/:id", promotionHandler.GetPromotion)
+	promotions.Get("/:id/stats", promotionHandler.GetPromotionStats)
+	promotions.Put("/:id", promotionHandler.UpdatePromotion)
+	promotions.Delete("/:id", promotionHandler.DeletePromotion)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupReportRoutes(
+	app *fiber.App,
+	reportHandler *handlers.ReportHandler,
+	customerHandler *handlers.CustomerHandler,
+	summaryHandler *handlers.DailySummaryHandler,
+	jwtUtil *utils.JWTUtil,
+	shedLowPriority fiber.Handler,
+) {
+	api := app.Group("/api/v1")
+	reports := api.Group("/reports",
+		shedLowPriority,
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	reports.Get("/revenue/sources", reportHandler.GetRevenueBySource)
+	reports.Get("/revenue-by-category", reportHandler.GetRevenueByCategory)
+	reports.Get("/sales", reportHandler.GetSales)
+	reports.Get("/top-products", reportHandler.GetTopProducts)
+	reports.Get("/customers", customerHandler.GetLifetimeValues)
+	reports.Get("/abandoned", reportHandler.GetAbandonedOrders)
+	reports.Get("/fulfillment-times", reportHandler.GetFulfillmentTimes)
+	reports.Get("/inventory-usage", reportHandler.GetInventoryUsage)
+	reports.Get("/tax", reportHandler.GetTax)
+	reports.Get("/z-report", summaryHandler.GetZReport)
+	reports.Get("/tips", reportHandler.GetTips)
+	reports.Get("/settlements", reportHandler.GetSettlements)
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRewardRoutes(
+	app *fiber.App,
+	rewardHandler *handlers.RewardHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Member routes
+	api.Get("/me/rewards",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		rewardHandler.GetMyRewards,
+	)
+	api.Post("/me/rewards/:id/redeem",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		rewardHandler.RedeemReward,
+	)
+
+	// Admin routes
+	rewards := api.Group("/rewards",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+	rewards.Post("/", rewardHandler.CreateReward)
+	rewards.Get("/", rewardHandler.GetRewards)
+	rewards.Get("/:id", rewardHandler.GetReward)
+	rewards.Put("/:id", rewardHandler.UpdateReward)
+	rewards.Delete("/:id", rewardHandler.DeleteReward)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupSelftestRoutes(
+	app *fiber.App,
+	selftestHandler *handlers.SelftestHandler,
+) {
+	api := app.Group("/api/v1")
+	api.Post("/admin/selftest", selftestHandler.RunSelftest)
+}
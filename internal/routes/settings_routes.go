@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupSettingsRoutes(
+	app *fiber.App,
+	settingsHandler *handlers.SettingsHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	api.Get("/currency", settingsHandler.GetCurrencySettings)
+
+	settings := api.Group("/settings",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	settings.Get("/receipt", settingsHandler.GetReceiptSettings)
+	settings.Put("/receipt", settingsHandler.UpdateReceiptSettings)
+	settings.Post("/receipt/preview", settingsHandler.PreviewReceipt)
+	settings.Get("/notifications", settingsHandler.GetNotificationSettings)
+	settings.Put("/notifications", settingsHandler.UpdateNotificationSettings)
+	settings.Get("/qr-code", settingsHandler.GetQRCodeSettings)
+	settings.Put("/qr-code", settingsHandler.UpdateQRCodeSettings)
+	settings.Get("/timeslots", settingsHandler.GetTimeslotSettings)
+	settings.Put("/timeslots", settingsHandler.UpdateTimeslotSettings)
+	settings.Get("/payments", settingsHandler.GetPaymentSettings)
+	settings.Put("/payments", settingsHandler.UpdatePaymentSettings)
+	settings.Get("/loyalty", settingsHandler.GetLoyaltySettings)
+	settings.Put("/loyalty", settingsHandler.UpdateLoyaltySettings)
+	settings.Get("/currency", settingsHandler.GetCurrencySettings)
+	settings.Put("/currency", settingsHandler.UpdateCurrencySettings)
+	settings.Get("/deposits", settingsHandler.GetDepositSettings)
+	settings.Put("/deposits", settingsHandler.UpdateDepositSettings)
+	settings.Get("/stamps", settingsHandler.GetStampSettings)
+	settings.Put("/stamps", settingsHandler.UpdateStampSettings)
+}
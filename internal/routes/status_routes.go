@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupStatusRoutes(app *fiber.App, statusHandler *handlers.StatusHandler) {
+	api := app.Group("/api/v1")
+
+	// Public so a static status page can poll it without credentials
+	api.Get("/status", statusHandler.GetStatus)
+}
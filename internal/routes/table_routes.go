@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTableRoutes(
+	app *fiber.App,
+	tableHandler *handlers.TableHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	tables := api.Group("/tables")
+
+	// Public routes
+	tables.Get("/scan/:token", tableHandler.ScanTable)
+
+	// Admin routes
+	tables.Post("/",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.CreateTable,
+	)
+	tables.Get("/",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.GetTables,
+	)
+	tables.Get("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.GetTable,
+	)
+	tables.Put("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.UpdateTable,
+	)
+	tables.Delete("/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.DeleteTable,
+	)
+	tables.Post("/:id/token",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		tableHandler.RegenerateTableToken,
+	)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTrashRoutes(
+	app *fiber.App,
+	trashHandler *handlers.TrashHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	trash := api.Group("/trash",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	trash.Get("/", trashHandler.ListTrash)
+	trash.Get("/:type/:id/impact", trashHandler.GetPurgeImpact)
+	trash.Post("/:type/:id/restore", trashHandler.RestoreTrashItem)
+	trash.Delete("/:type/:id", trashHandler.PurgeTrashItem)
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupVoucherRoutes(
+	app *fiber.App,
+	voucherHandler *handlers.VoucherHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Member routes
+	api.Get("/me/vouchers",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		voucherHandler.GetMyVouchers,
+	)
+
+	// Admin routes
+	api.Get("/vouchers",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		voucherHandler.GetVouchers,
+	)
+	api.Post("/vouchers",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		voucherHandler.IssueVouchers,
+	)
+	api.Get("/vouchers/:id",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		voucherHandler.GetVoucher,
+	)
+	api.Post("/vouchers/:id/revoke",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		voucherHandler.RevokeVoucher,
+	)
+}
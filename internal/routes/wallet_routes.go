@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupWalletRoutes(
+	app *fiber.App,
+	walletHandler *handlers.WalletHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+
+	// Member routes
+	api.Get("/me/wallet",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		walletHandler.GetMyWallet,
+	)
+	api.Post("/me/wallet/top-ups",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		walletHandler.CreateTopUp,
+	)
+	api.Post("/orders/:id/payments/wallet",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleMember),
+		walletHandler.PayWithWallet,
+	)
+
+	// Admin routes
+	api.Get("/wallets/:userId",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		walletHandler.GetWallet,
+	)
+	api.Post("/wallets/:userId/adjustments",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+		walletHandler.AdjustWallet,
+	)
+}
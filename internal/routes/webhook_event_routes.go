@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupWebhookEventRoutes(
+	app *fiber.App,
+	webhookEventHandler *handlers.WebhookEventHandler,
+	jwtUtil *utils.JWTUtil,
+) {
+	api := app.Group("/api/v1")
+	events := api.Group("/webhook-events",
+		middleware.AuthMiddleware(jwtUtil),
+		middleware.RoleMiddleware(models.RoleAdmin),
+	)
+
+	events.Get("/", webhookEventHandler.GetWebhookEvents)
+	events.Get("/:id", webhookEventHandler.GetWebhookEvent)
+	events.Post("/:id/replay", webhookEventHandler.ReplayWebhookEvent)
+}
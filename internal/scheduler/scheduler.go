@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+)
+
+// JobFunc is the work done by a scheduled job
+type JobFunc func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+
+	// active is held for the length of a run, so a triggered run and a
+	// ticker run of the same job never overlap within one instance
+	active sync.Mutex
+}
+
+// Scheduler runs singleton background jobs. Before every run an instance must
+// hold the job's lease, so with several instances each job executes on one of
+// them per interval. The lease lasts one interval and the holder renews it on
+// its next tick, which keeps the same instance as leader while it is alive.
+// A run that takes longer than its interval keeps renewing the lease until it
+// returns, so no other instance starts the job alongside it.
+type Scheduler struct {
+	locks  repositories.JobLockRepository
+	owner  string
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	jobs    []*job
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+func NewScheduler(locks repositories.JobLockRepository, owner string, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		locks:  locks,
+		owner:  owner,
+		logger: logger,
+	}
+}
+
+// Every registers a job to run once per interval. Jobs must be registered
+// before Start.
+func (s *Scheduler) Every(name string, interval time.Duration, run JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &job{name: name, interval: interval, run: run})
+}
+
+// Start launches one ticker per registered job
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.running.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop cancels running jobs, waits for them to return and releases held
+// leases so another instance can take over without waiting for expiry
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	s.running.Wait()
+
+	for _, j := range s.jobs {
+		if err := s.locks.Release(j.name, s.owner); err != nil {
+			s.logger.Error("Failed to release job lock", "job", j.name, logging.Err(err))
+		}
+	}
+}
+
+// Trigger runs the named job immediately if this instance can hold its lease
+// and is not already running it. It reports whether the job ran.
+func (s *Scheduler) Trigger(ctx context.Context, name string) (bool, error) {
+	s.mu.Lock()
+	var found *job
+	for _, j := range s.jobs {
+		if j.name == name {
+			found = j
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if found == nil {
+		return false, ErrJobNotFound
+	}
+	return s.runIfLeader(ctx, found)
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.running.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.runIfLeader(ctx, j); err != nil {
+				s.logger.Error("Job failed", "job", j.name, logging.Err(err))
+			}
+		}
+	}
+}
+
+func (s *Scheduler) runIfLeader(ctx context.Context, j *job) (bool, error) {
+	if !j.active.TryLock() {
+		return false, nil
+	}
+	defer j.active.Unlock()
+
+	acquired, err := s.locks.Acquire(j.name, s.owner, j.interval)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		s.renew(runCtx, cancel, j)
+	}()
+
+	err = j.run(runCtx)
+	cancel()
+	<-renewed
+	return true, err
+}
+
+// renew extends the lease twice per interval until ctx is done. Losing the
+// lease cancels the run, since another instance may start the job as soon as
+// the lease expires.
+func (s *Scheduler) renew(ctx context.Context, cancel context.CancelFunc, j *job) {
+	ticker := time.NewTicker(j.interval / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			acquired, err := s.locks.Acquire(j.name, s.owner, j.interval)
+			if err != nil {
+				s.logger.Warn("Failed to renew job lock", "job", j.name, logging.Err(err))
+				continue
+			}
+			if !acquired {
+				s.logger.Warn("Lost job lock, cancelling the run", "job", j.name)
+				cancel()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sync"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+)
+
+// AlertConfig says who hears about payment problems. A gateway signature
+// failure alone is usually a stray request, so admins are only told once
+// SignatureFailures of them arrive within SignatureWindow.
+type AlertConfig struct {
+	Emails            []string
+	SignatureFailures int
+	SignatureWindow   time.Duration
+}
+
+// AlertService tells admins about payment problems that would otherwise only
+// show up in the logs
+type AlertService interface {
+	PaymentFailed(reference string, status models.TransactionStatus) error
+	SignatureFailed(provider models.PaymentMethod) error
+	AmountMismatch(reference string, expected float64) error
+}
+
+type alertService struct {
+	mailer utils.Mailer
+	slack  utils.SlackSender
+	config AlertConfig
+
+	mu                sync.Mutex
+	signatureFailures map[models.PaymentMethod][]time.Time
+}
+
+// NewAlertService sends alerts by email to config.Emails and, when slack is
+// not nil, to Slack. With neither, alerts are dropped.
+func NewAlertService(mailer utils.Mailer, slack utils.SlackSender, config AlertConfig) AlertService {
+	return &alertService{
+		mailer:            mailer,
+		slack:             slack,
+		config:            config,
+		signatureFailures: make(map[models.PaymentMethod][]time.Time),
+	}
+}
+
+func (s *alertService) PaymentFailed(reference string, status models.TransactionStatus) error {
+	return s.send(
+		fmt.Sprintf("Payment %s: %s", status, reference),
+		fmt.Sprintf("The payment gateway reported payment %s as %s.", reference, status),
+	)
+}
+
+// SignatureFailed counts a webhook whose signature or callback token did not
+// check out, and alerts once enough of them pile up. The count starts over
+// after each alert.
+func (s *alertService) SignatureFailed(provider models.PaymentMethod) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	failures := s.signatureFailures[provider][:0]
+	for _, at := range s.signatureFailures[provider] {
+		if now.Sub(at) < s.config.SignatureWindow {
+			failures = append(failures, at)
+		}
+	}
+	failures = append(failures, now)
+	alert := len(failures) >= s.config.SignatureFailures
+	if alert {
+		failures = nil
+	}
+	s.signatureFailures[provider] = failures
+	s.mu.Unlock()
+
+	if !alert {
+		return nil
+	}
+	return s.send(
+		fmt.Sprintf("Repeated %s webhook signature failures", provider),
+		fmt.Sprintf("%d %s webhooks failed signature verification within %s. Check the server key, or whether someone is sending forged notifications.",
+			s.config.SignatureFailures, provider, s.config.SignatureWindow),
+	)
+}
+
+func (s *alertService) AmountMismatch(reference string, expected float64) error {
+	return s.send(
+		fmt.Sprintf("Payment amount mismatch: %s", reference),
+		fmt.Sprintf("The payment gateway reported a different amount for %s than the %.2f that was charged. The report was not applied; check the payment in the gateway dashboard.", reference, expected),
+	)
+}
+
+// send delivers the alert on every configured channel, carrying on past
+// failures so one broken channel does not silence the rest
+func (s *alertService) send(subject, text string) error {
+	var errs []error
+	for _, to := range s.config.Emails {
+		errs = append(errs, s.mailer.Send(utils.EmailMessage{
+			To:       to,
+			Subject:  "[Matchaciee] " + subject,
+			TextBody: text,
+			HTMLBody: "<p>" + html.EscapeString(text) + "</p>",
+		}))
+	}
+	if s.slack != nil {
+		errs = append(errs, s.slack.Send("*"+subject+"*\n"+text))
+	}
+	return errors.Join(errs...)
+}
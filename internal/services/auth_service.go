@@ -20,6 +20,7 @@ type RegisterRequest struct {
 	Password string `json:"password" validate:"required,min=8,max=64"`
 	FullName string `json:"full_name" validate:"required,min=2"`
 	Phone    string `json:"phone,omitempty"`
+	Locale   string `json:"locale,omitempty" validate:"omitempty,oneof=id en"`
 }
 
 type LoginRequest struct {
@@ -37,12 +38,28 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+type UpdateProfileRequest struct {
+	FullName    *string `json:"full_name,omitempty" validate:"omitempty,min=2"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=20"`
+	Locale      *string `json:"locale,omitempty" validate:"omitempty,oneof=id en"`
+	ClearLocale bool    `json:"clear_locale,omitempty"`
+	NotifyVia   *string `json:"notify_via,omitempty" validate:"omitempty,oneof=email whatsapp"`
+}
+
+// UpdateProfileResponse carries a fresh access token so a language change applies immediately
+type UpdateProfileResponse struct {
+	User  UserResponse `json:"user"`
+	Token string       `json:"token"`
+}
+
 type UserResponse struct {
-	ID       uuid.UUID       `json:"id"`
-	Email    string          `json:"email"`
-	FullName string          `json:"full_name"`
-	Phone    *string         `json:"phone,omitempty"`
-	Role     models.UserRole `json:"role"`
+	ID        uuid.UUID       `json:"id"`
+	Email     string          `json:"email"`
+	FullName  string          `json:"full_name"`
+	Phone     *string         `json:"phone,omitempty"`
+	Locale    *string         `json:"locale,omitempty"`
+	NotifyVia *string         `json:"notify_via,omitempty"`
+	Role      models.UserRole `json:"role"`
 }
 
 type AuthService interface {
@@ -51,6 +68,7 @@ type AuthService interface {
 	RefreshToken(req RefreshTokenRequest) (*AuthResponse, error)
 	Logout(refreshToken string) error
 	GetUserByUUID(uuid uuid.UUID) (*UserResponse, error)
+	UpdateProfile(uuid uuid.UUID, req UpdateProfileRequest) (*UpdateProfileResponse, error)
 }
 
 type authService struct {
@@ -88,37 +106,16 @@ func (s *authService) Register(req RegisterRequest) (*AuthResponse, error) {
 	if req.Phone != "" {
 		user.Phone = &req.Phone
 	}
-
-	err = s.userRepo.Create(user)
-	if err != nil {
-		return nil, err
-	}
-
-	token, err := s.jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role))
-	if err != nil {
-		return nil, err
+	if req.Locale != "" {
+		user.Locale = &req.Locale
 	}
 
-	refreshToken, expiresAt, err := s.jwtUtil.GenerateRefreshToken(user.UUID)
-	if err != nil {
-		return nil, err
-	}
-
-	refreshTokenModel := &models.RefreshToken{
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: expiresAt,
-	}
-	err = s.refreshTokenRepo.Create(refreshTokenModel)
+	err = s.userRepo.Create(user)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AuthResponse{
-		User:         s.toUserResponse(user),
-		Token:        token,
-		RefreshToken: refreshToken,
-	}, nil
+	return issueAuthTokens(s.jwtUtil, s.refreshTokenRepo, user)
 }
 
 func (s *authService) Login(req LoginRequest) (*AuthResponse, error) {
@@ -139,31 +136,7 @@ func (s *authService) Login(req LoginRequest) (*AuthResponse, error) {
 		return nil, ErrInvalidCredentials
 	}
 
-	token, err := s.jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role))
-	if err != nil {
-		return nil, err
-	}
-
-	refreshToken, expiresAt, err := s.jwtUtil.GenerateRefreshToken(user.UUID)
-	if err != nil {
-		return nil, err
-	}
-
-	refreshTokenModel := &models.RefreshToken{
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: expiresAt,
-	}
-	err = s.refreshTokenRepo.Create(refreshTokenModel)
-	if err != nil {
-		return nil, err
-	}
-
-	return &AuthResponse{
-		User:         s.toUserResponse(user),
-		Token:        token,
-		RefreshToken: refreshToken,
-	}, nil
+	return issueAuthTokens(s.jwtUtil, s.refreshTokenRepo, user)
 }
 
 func (s *authService) RefreshToken(req RefreshTokenRequest) (*AuthResponse, error) {
@@ -190,7 +163,7 @@ func (s *authService) RefreshToken(req RefreshTokenRequest) (*AuthResponse, erro
 		return nil, ErrUserInactive
 	}
 
-	accessToken, err := s.jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role))
+	accessToken, err := s.jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role), user.PreferredLocale())
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +192,7 @@ func (s *authService) RefreshToken(req RefreshTokenRequest) (*AuthResponse, erro
 	}
 
 	return &AuthResponse{
-		User:         s.toUserResponse(user),
+		User:         toUserResponse(user),
 		Token:        accessToken,
 		RefreshToken: newRefreshToken,
 	}, nil
@@ -245,16 +218,88 @@ func (s *authService) GetUserByUUID(uuid uuid.UUID) (*UserResponse, error) {
 		return nil, err
 	}
 
-	userResp := s.toUserResponse(user)
+	userResp := toUserResponse(user)
 	return &userResp, nil
 }
 
-func (s *authService) toUserResponse(user *models.User) UserResponse {
+func (s *authService) UpdateProfile(uuid uuid.UUID, req UpdateProfileRequest) (*UpdateProfileResponse, error) {
+	user, err := s.userRepo.FindByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != nil {
+		user.FullName = *req.FullName
+	}
+	if req.Phone != nil {
+		if *req.Phone == "" {
+			user.Phone = nil
+		} else {
+			user.Phone = req.Phone
+		}
+	}
+	if req.ClearLocale {
+		user.Locale = nil
+	} else if req.Locale != nil {
+		user.Locale = req.Locale
+	}
+	if req.NotifyVia != nil {
+		user.NotifyVia = req.NotifyVia
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	token, err := s.jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role), user.PreferredLocale())
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateProfileResponse{
+		User:  toUserResponse(user),
+		Token: token,
+	}, nil
+}
+
+// issueAuthTokens logs the user in: it signs an access token and stores a new
+// refresh token. Every login method ends here.
+func issueAuthTokens(jwtUtil *utils.JWTUtil, refreshTokenRepo repositories.RefreshTokenRepository, user *models.User) (*AuthResponse, error) {
+	token, err := jwtUtil.GenerateToken(user.UUID, user.Email, string(user.Role), user.PreferredLocale())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, expiresAt, err := jwtUtil.GenerateRefreshToken(user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenModel := &models.RefreshToken{
+		UserID:    user.ID,
+		Token:     refreshToken,
+		ExpiresAt: expiresAt,
+	}
+	err = refreshTokenRepo.Create(refreshTokenModel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		User:         toUserResponse(user),
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func toUserResponse(user *models.User) UserResponse {
 	return UserResponse{
-		ID:       user.UUID,
-		Email:    user.Email,
-		FullName: user.FullName,
-		Phone:    user.Phone,
-		Role:     user.Role,
+		ID:        user.UUID,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		Phone:     user.Phone,
+		Locale:    user.Locale,
+		NotifyVia: user.NotifyVia,
+		Role:      user.Role,
 	}
 }
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCampaignNotFound       = errors.New("campaign not found")
+	ErrCampaignNotCancellable = errors.New("only scheduled or sending campaigns can be cancelled")
+	ErrCampaignSendAtInPast   = errors.New("campaign send time must not be in the past")
+)
+
+// campaignClaimTimeout is how long a claimed message may go without its send
+// being recorded before it is taken as interrupted and marked failed
+const campaignClaimTimeout = time.Hour
+
+// CreateCampaignRequest composes a message to every member of a segment: all
+// members, lapsed members with no completed order in inactive_days, or new
+// members without a completed order. It sends right away unless send_at is
+// given.
+type CreateCampaignRequest struct {
+	Title        string                     `json:"title" validate:"required,min=3,max=100"`
+	Message      string                     `json:"message" validate:"required,min=3,max=1000"`
+	Segment      repositories.MemberSegment `json:"segment" validate:"required,oneof=all lapsed new"`
+	InactiveDays int                        `json:"inactive_days,omitempty" validate:"required_if=Segment lapsed,omitempty,min=1,max=365"`
+	SendAt       *time.Time                 `json:"send_at,omitempty"`
+}
+
+// CampaignStats counts a campaign's messages. Pending ones have not been sent
+// yet, and never will be once the campaign is cancelled. Channels breaks the
+// sent messages down by the channel they went out on.
+type CampaignStats struct {
+	Recipients int64            `json:"recipients"`
+	Pending    int64            `json:"pending"`
+	Sent       int64            `json:"sent"`
+	Failed     int64            `json:"failed"`
+	Channels   map[string]int64 `json:"channels"`
+}
+
+type CampaignResponse struct {
+	ID           uuid.UUID             `json:"id"`
+	Title        string                `json:"title"`
+	Message      string                `json:"message"`
+	Segment      string                `json:"segment"`
+	InactiveDays int                   `json:"inactive_days,omitempty"`
+	Status       models.CampaignStatus `json:"status"`
+	SendAt       string                `json:"send_at"`
+	CreatedBy    *string               `json:"created_by,omitempty"`
+	StartedAt    *string               `json:"started_at,omitempty"`
+	FinishedAt   *string               `json:"finished_at,omitempty"`
+	CancelledAt  *string               `json:"cancelled_at,omitempty"`
+	Stats        *CampaignStats        `json:"stats,omitempty"`
+	CreatedAt    string                `json:"created_at"`
+}
+
+type CampaignListResponse struct {
+	Campaigns []CampaignResponse `json:"campaigns"`
+	Total     int64              `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
+
+// CampaignService lets admins message a segment of members. Messages are
+// queued when a campaign starts and sent a batch at a time by RunDeliveries,
+// so a large segment does not flood the mail server or the WhatsApp API.
+type CampaignService interface {
+	Create(staffUUID uuid.UUID, req CreateCampaignRequest) (*CampaignResponse, error)
+	GetAll(status *models.CampaignStatus, page, limit int) (*CampaignListResponse, error)
+	GetByUUID(uuid uuid.UUID) (*CampaignResponse, error)
+	Cancel(uuid uuid.UUID) (*CampaignResponse, error)
+	RunDeliveries() (int, error)
+}
+
+// campaignChannel sends a campaign to a member on one channel. Channels are
+// keyed by the names members choose in notify_via, like readyChannel.
+type campaignChannel func(campaign *models.Campaign, user *models.User) error
+
+type campaignService struct {
+	campaignRepo repositories.CampaignRepository
+	userRepo     repositories.UserRepository
+	mailer       utils.Mailer
+	whatsApp     utils.WhatsAppSender
+	batchSize    int
+	channels     map[string]campaignChannel
+}
+
+// NewCampaignService sends at most batchSize messages each time
+// RunDeliveries runs
+func NewCampaignService(
+	campaignRepo repositories.CampaignRepository,
+	userRepo repositories.UserRepository,
+	mailer utils.Mailer,
+	whatsApp utils.WhatsAppSender,
+	batchSize int,
+) CampaignService {
+	s := &campaignService{
+		campaignRepo: campaignRepo,
+		userRepo:     userRepo,
+		mailer:       mailer,
+		whatsApp:     whatsApp,
+		batchSize:    batchSize,
+	}
+	s.channels = map[string]campaignChannel{
+		models.NotifyViaEmail:    s.sendEmail,
+		models.NotifyViaWhatsApp: s.sendWhatsApp,
+	}
+	return s
+}
+
+func (s *campaignService) Create(staffUUID uuid.UUID, req CreateCampaignRequest) (*CampaignResponse, error) {
+	now := time.Now()
+	sendAt := now
+	if req.SendAt != nil {
+		if req.SendAt.Before(now) {
+			return nil, ErrCampaignSendAtInPast
+		}
+		sendAt = *req.SendAt
+	}
+
+	staff, err := s.userRepo.FindByUUID(staffUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	campaign := &models.Campaign{
+		Title:     strings.TrimSpace(req.Title),
+		Message:   strings.TrimSpace(req.Message),
+		Segment:   string(req.Segment),
+		Status:    models.CampaignStatusScheduled,
+		SendAt:    sendAt,
+		CreatedBy: &staff.ID,
+		Creator:   staff,
+		CreatedAt: now,
+	}
+	if req.Segment == repositories.MemberSegmentLapsed {
+		campaign.InactiveDays = req.InactiveDays
+	}
+	if err := s.campaignRepo.Create(campaign); err != nil {
+		return nil, fmt.Errorf("failed to save campaign: %w", err)
+	}
+
+	response := toCampaignResponse(campaign)
+	return &response, nil
+}
+
+func (s *campaignService) GetAll(status *models.CampaignStatus, page, limit int) (*CampaignListResponse, error) {
+	offset := (page - 1) * limit
+
+	campaigns, total, err := s.campaignRepo.FindAll(status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]CampaignResponse, len(campaigns))
+	for i := range campaigns {
+		responses[i] = toCampaignResponse(&campaigns[i])
+	}
+
+	return &CampaignListResponse{
+		Campaigns: responses,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}, nil
+}
+
+// GetByUUID returns the campaign with its delivery stats
+func (s *campaignService) GetByUUID(uuid uuid.UUID) (*CampaignResponse, error) {
+	campaign, err := s.findCampaign(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStats(campaign)
+}
+
+// Cancel stops a campaign before it finishes sending. Members who already got
+// the message keep it.
+func (s *campaignService) Cancel(uuid uuid.UUID) (*CampaignResponse, error) {
+	campaign, err := s.findCampaign(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.campaignRepo.Cancel(campaign.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel campaign: %w", err)
+	}
+	if !cancelled {
+		return nil, ErrCampaignNotCancellable
+	}
+
+	campaign, err = s.findCampaign(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStats(campaign)
+}
+
+// RunDeliveries starts the campaigns that are due, claims and sends the next
+// batch of their messages and marks the campaigns that have nothing left to
+// send. It is meant to run every minute, which caps the sending rate at the
+// batch size per minute; a batch still sending when the next run starts
+// keeps its messages. It returns how many messages were sent.
+func (s *campaignService) RunDeliveries() (int, error) {
+	if err := s.startDue(); err != nil {
+		return 0, err
+	}
+
+	deliveries, err := s.campaignRepo.ClaimPendingDeliveries(s.batchSize, time.Now().Add(-campaignClaimTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		channel, sendErr := s.deliver(delivery)
+
+		status := models.CampaignDeliverySent
+		var reason *string
+		if sendErr != nil {
+			status = models.CampaignDeliveryFailed
+			message := sendErr.Error()
+			if len(message) > 255 {
+				message = message[:255]
+			}
+			reason = &message
+		}
+		if err := s.campaignRepo.MarkDelivery(delivery.ID, status, channel, reason); err != nil {
+			return sent, fmt.Errorf("failed to record campaign delivery %d: %w", delivery.ID, err)
+		}
+		if sendErr == nil {
+			sent++
+		}
+	}
+
+	if _, err := s.campaignRepo.FinishSending(); err != nil {
+		return sent, err
+	}
+	return sent, nil
+}
+
+// startDue picks the members of each due campaign and queues their messages
+func (s *campaignService) startDue() error {
+	now := time.Now()
+	campaigns, err := s.campaignRepo.FindDue(now)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		inactiveSince := now.AddDate(0, 0, -campaign.InactiveDays)
+		userIDs, err := s.userRepo.FindMemberIDs(repositories.MemberSegment(campaign.Segment), inactiveSince)
+		if err != nil {
+			return err
+		}
+		if _, err := s.campaignRepo.Start(campaign.ID, userIDs); err != nil {
+			return fmt.Errorf("failed to start campaign %s: %w", campaign.UUID, err)
+		}
+	}
+	return nil
+}
+
+// deliver sends the message on the member's preferred channel, by email when
+// they prefer WhatsApp but have no phone number. It returns the channel used.
+func (s *campaignService) deliver(delivery *models.CampaignDelivery) (string, error) {
+	user := delivery.User
+	if user == nil || !user.IsActive {
+		return models.NotifyViaEmail, errors.New("member is no longer active")
+	}
+
+	channel := user.PreferredChannel()
+	if channel == models.NotifyViaWhatsApp && user.Phone == nil {
+		channel = models.NotifyViaEmail
+	}
+	send, ok := s.channels[channel]
+	if !ok {
+		channel = models.NotifyViaEmail
+		send = s.channels[channel]
+	}
+	return channel, send(delivery.Campaign, user)
+}
+
+func (s *campaignService) sendEmail(campaign *models.Campaign, user *models.User) error {
+	return s.mailer.Send(utils.EmailMessage{
+		To:       user.Email,
+		Subject:  campaign.Title,
+		TextBody: campaign.Message,
+		HTMLBody: "<p>" + strings.ReplaceAll(html.EscapeString(campaign.Message), "\n", "<br>") + "</p>",
+	})
+}
+
+func (s *campaignService) sendWhatsApp(campaign *models.Campaign, user *models.User) error {
+	return s.whatsApp.Send(*user.Phone, "*"+campaign.Title+"*\n"+campaign.Message)
+}
+
+func (s *campaignService) findCampaign(uuid uuid.UUID) (*models.Campaign, error) {
+	campaign, err := s.campaignRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCampaignNotFound) {
+			return nil, ErrCampaignNotFound
+		}
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func (s *campaignService) withStats(campaign *models.Campaign) (*CampaignResponse, error) {
+	counts, err := s.campaignRepo.CountDeliveries(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CampaignStats{Channels: make(map[string]int64)}
+	for _, count := range counts {
+		stats.Recipients += count.Count
+		switch count.Status {
+		case models.CampaignDeliveryPending, models.CampaignDeliverySending:
+			stats.Pending += count.Count
+		case models.CampaignDeliverySent:
+			stats.Sent += count.Count
+			if count.Channel != nil {
+				stats.Channels[*count.Channel] += count.Count
+			}
+		case models.CampaignDeliveryFailed:
+			stats.Failed += count.Count
+		}
+	}
+
+	response := toCampaignResponse(campaign)
+	response.Stats = stats
+	return &response, nil
+}
+
+func toCampaignResponse(campaign *models.Campaign) CampaignResponse {
+	response := CampaignResponse{
+		ID:           campaign.UUID,
+		Title:        campaign.Title,
+		Message:      campaign.Message,
+		Segment:      campaign.Segment,
+		InactiveDays: campaign.InactiveDays,
+		Status:       campaign.Status,
+		SendAt:       campaign.SendAt.Format("2006-01-02T15:04:05Z07:00"),
+		StartedAt:    formatOptionalTime(campaign.StartedAt),
+		FinishedAt:   formatOptionalTime(campaign.FinishedAt),
+		CancelledAt:  formatOptionalTime(campaign.CancelledAt),
+		CreatedAt:    campaign.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if campaign.Creator != nil {
+		response.CreatedBy = &campaign.Creator.FullName
+	}
+	return response
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCartEmpty              = errors.New("cart is empty")
+	ErrCartItemNotFound       = errors.New("cart item not found")
+	ErrCartCheckoutInProgress = errors.New("cart checkout already in progress")
+	ErrCustomerNameRequired   = errors.New("customer name is required")
+)
+
+// CartOwner identifies whose cart a request works on: a member, or a kiosk
+// session operated by staff
+type CartOwner struct {
+	UserUUID  *uuid.UUID
+	SessionID string
+}
+
+func (o CartOwner) source() models.OrderSource {
+	if o.UserUUID != nil {
+		return models.OrderSourceMember
+	}
+	return models.OrderSourceKiosk
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int     `json:"quantity" validate:"required,min=1,max=100"`
+	Notes    *string `json:"notes,omitempty" validate:"omitempty,max=200"`
+}
+
+// CheckoutCartRequest completes the order details. Members default to their
+// own name; kiosk checkouts must name the customer.
+type CheckoutCartRequest struct {
+	CustomerName string           `json:"customer_name,omitempty" validate:"omitempty,min=2,max=255"`
+	OrderType    models.OrderType `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery"`
+	TableID      *uuid.UUID       `json:"table_id,omitempty"`
+	Notes        *string          `json:"notes,omitempty" validate:"omitempty,max=500"`
+	PromoCode    *string          `json:"promo_code,omitempty" validate:"omitempty,max=50"`
+	VoucherCode  *string          `json:"voucher_code,omitempty" validate:"omitempty,max=20"`
+	TipAmount    float64          `json:"tip_amount,omitempty" validate:"gte=0,lte=1000000"`
+	ScheduledFor *time.Time       `json:"scheduled_for,omitempty"`
+}
+
+type CartItemResponse struct {
+	ID             uuid.UUID                `json:"id"`
+	ProductID      uuid.UUID                `json:"product_id"`
+	ProductName    string                   `json:"product_name"`
+	Quantity       int                      `json:"quantity"`
+	Customizations []OrderItemCustomization `json:"customizations,omitempty"`
+	Notes          *string                  `json:"notes,omitempty"`
+}
+
+// CartResponse is the cart with a price preview at current prices. When an
+// item can no longer be ordered the preview is omitted and PreviewError says
+// why, so the client can fix the cart before checkout.
+type CartResponse struct {
+	ID           *uuid.UUID            `json:"id,omitempty"`
+	Items        []CartItemResponse    `json:"items"`
+	Preview      *OrderPreviewResponse `json:"preview,omitempty"`
+	PreviewError string                `json:"preview_error,omitempty"`
+	UpdatedAt    *string               `json:"updated_at,omitempty"`
+}
+
+type CartService interface {
+	GetCart(owner CartOwner) (*CartResponse, error)
+	AddItem(owner CartOwner, req CreateOrderItemRequest) (*CartResponse, error)
+	UpdateItem(owner CartOwner, itemUUID uuid.UUID, req UpdateCartItemRequest) (*CartResponse, error)
+	RemoveItem(owner CartOwner, itemUUID uuid.UUID) (*CartResponse, error)
+	Clear(owner CartOwner) error
+	Checkout(owner CartOwner, req CheckoutCartRequest) (*OrderResponse, error)
+}
+
+type cartService struct {
+	cartRepo     repositories.CartRepository
+	productRepo  repositories.ProductRepository
+	userRepo     repositories.UserRepository
+	orderService OrderService
+	logger       *slog.Logger
+}
+
+func NewCartService(
+	cartRepo repositories.CartRepository,
+	productRepo repositories.ProductRepository,
+	userRepo repositories.UserRepository,
+	orderService OrderService,
+	logger *slog.Logger,
+) CartService {
+	return &cartService{
+		cartRepo:     cartRepo,
+		productRepo:  productRepo,
+		userRepo:     userRepo,
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+func (s *cartService) GetCart(owner CartOwner) (*CartResponse, error) {
+	cart, err := s.findCart(owner)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCartNotFound) {
+			return &CartResponse{Items: []CartItemResponse{}}, nil
+		}
+		return nil, err
+	}
+
+	return s.toCartResponse(owner, cart), nil
+}
+
+// AddItem adds a product to the cart, creating the cart on first use. Adding
+// the same product with the same options and notes increases the quantity of
+// the existing line instead.
+func (s *cartService) AddItem(owner CartOwner, req CreateOrderItemRequest) (*CartResponse, error) {
+	product, err := s.productRepo.FindByUUID(req.ProductID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProductNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if !product.IsAvailable || (product.Category != nil && product.Category.DeletedAt != nil) {
+		return nil, ErrProductNotAvailable
+	}
+	if len(req.Customizations) > 0 && !product.IsCustomizable {
+		return nil, ErrProductNotCustomizable
+	}
+	for _, chosen := range req.Customizations {
+		customization, err := s.productRepo.FindCustomizationByUUID(chosen.CustomizationID)
+		if err != nil || customization.ProductID != product.ID {
+			return nil, ErrInvalidCustomization
+		}
+	}
+
+	var customizations []byte
+	if len(req.Customizations) > 0 {
+		customizations, err = json.Marshal(req.Customizations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cart, err := s.findOrCreateCart(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cart.Items {
+		existing := &cart.Items[i]
+		if existing.ProductID == product.ID &&
+			slices.Equal(parseCartCustomizations(existing.Customizations), req.Customizations) &&
+			sameNotes(existing.Notes, req.Notes) {
+			existing.Quantity = min(existing.Quantity+req.Quantity, 100)
+			if err := s.cartRepo.UpdateItem(existing); err != nil {
+				return nil, err
+			}
+			return s.GetCart(owner)
+		}
+	}
+
+	item := &models.CartItem{
+		CartID:         cart.ID,
+		ProductID:      product.ID,
+		Quantity:       req.Quantity,
+		Customizations: customizations,
+		Notes:          req.Notes,
+	}
+	if err := s.cartRepo.AddItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(owner)
+}
+
+func (s *cartService) UpdateItem(owner CartOwner, itemUUID uuid.UUID, req UpdateCartItemRequest) (*CartResponse, error) {
+	item, err := s.findItem(owner, itemUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Quantity = req.Quantity
+	item.Notes = req.Notes
+	if err := s.cartRepo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(owner)
+}
+
+func (s *cartService) RemoveItem(owner CartOwner, itemUUID uuid.UUID) (*CartResponse, error) {
+	item, err := s.findItem(owner, itemUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.DeleteItem(item.CartID, item.ID); err != nil {
+		if errors.Is(err, repositories.ErrCartItemNotFound) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, err
+	}
+
+	return s.GetCart(owner)
+}
+
+func (s *cartService) Clear(owner CartOwner) error {
+	cart, err := s.findCart(owner)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCartNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return s.cartRepo.Clear(cart.ID)
+}
+
+// Checkout converts the cart into a pending order priced at current prices and
+// deletes the cart. The cart is locked for the duration so a double submit
+// cannot place two orders, and is left untouched if the order fails.
+func (s *cartService) Checkout(owner CartOwner, req CheckoutCartRequest) (*OrderResponse, error) {
+	cart, err := s.findCart(owner)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCartNotFound) {
+			return nil, ErrCartEmpty
+		}
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+
+	orderReq := CreateOrderRequest{
+		CustomerName: req.CustomerName,
+		OrderType:    req.OrderType,
+		TableID:      req.TableID,
+		Notes:        req.Notes,
+		PromoCode:    req.PromoCode,
+		VoucherCode:  req.VoucherCode,
+		TipAmount:    req.TipAmount,
+		ScheduledFor: req.ScheduledFor,
+		Items:        toOrderItemRequests(cart.Items),
+	}
+	if orderReq.CustomerName == "" {
+		if owner.UserUUID == nil {
+			return nil, ErrCustomerNameRequired
+		}
+		user, err := s.userRepo.FindByUUID(*owner.UserUUID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		orderReq.CustomerName = user.FullName
+	}
+
+	if err := s.cartRepo.BeginCheckout(cart.ID); err != nil {
+		if errors.Is(err, repositories.ErrCartCheckoutInProgress) {
+			return nil, ErrCartCheckoutInProgress
+		}
+		return nil, err
+	}
+
+	var order *OrderResponse
+	if owner.UserUUID != nil {
+		order, err = s.orderService.CreateOrder(*owner.UserUUID, orderReq)
+	} else {
+		order, err = s.orderService.CreateStaffOrder(CreateStaffOrderRequest{
+			CreateOrderRequest: orderReq,
+			OrderSource:        models.OrderSourceKiosk,
+		})
+	}
+	if err != nil {
+		if cancelErr := s.cartRepo.CancelCheckout(cart.ID); cancelErr != nil {
+			s.logger.Error("Failed to unlock cart after checkout failure", "cart_uuid", cart.UUID, logging.Err(cancelErr))
+		}
+		return nil, err
+	}
+
+	// The order is placed; a leftover cart is only an inconvenience
+	if err := s.cartRepo.Delete(cart.ID); err != nil {
+		s.logger.Error("Failed to delete cart after checkout", "cart_uuid", cart.UUID, logging.OrderNumber(order.OrderNumber), logging.Err(err))
+	}
+
+	return order, nil
+}
+
+func (s *cartService) findCart(owner CartOwner) (*models.Cart, error) {
+	if owner.UserUUID == nil {
+		return s.cartRepo.FindBySessionID(owner.SessionID)
+	}
+
+	user, err := s.userRepo.FindByUUID(*owner.UserUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return s.cartRepo.FindByUserID(user.ID)
+}
+
+func (s *cartService) findOrCreateCart(owner CartOwner) (*models.Cart, error) {
+	cart, err := s.findCart(owner)
+	if err == nil {
+		return cart, nil
+	}
+	if !errors.Is(err, repositories.ErrCartNotFound) {
+		return nil, err
+	}
+
+	cart = &models.Cart{}
+	if owner.UserUUID != nil {
+		user, err := s.userRepo.FindByUUID(*owner.UserUUID)
+		if err != nil {
+			return nil, err
+		}
+		cart.UserID = &user.ID
+	} else {
+		sessionID := owner.SessionID
+		cart.SessionID = &sessionID
+	}
+
+	if err := s.cartRepo.Create(cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+func (s *cartService) findItem(owner CartOwner, itemUUID uuid.UUID) (*models.CartItem, error) {
+	cart, err := s.findCart(owner)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCartNotFound) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, err
+	}
+
+	item, err := s.cartRepo.FindItemByUUID(cart.ID, itemUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCartItemNotFound) {
+			return nil, ErrCartItemNotFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *cartService) toCartResponse(owner CartOwner, cart *models.Cart) *CartResponse {
+	updatedAt := cart.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+	response := &CartResponse{
+		ID:        &cart.UUID,
+		Items:     make([]CartItemResponse, 0, len(cart.Items)),
+		UpdatedAt: &updatedAt,
+	}
+
+	for _, item := range cart.Items {
+		itemResponse := CartItemResponse{
+			ID:             item.UUID,
+			Quantity:       item.Quantity,
+			Customizations: parseCartCustomizations(item.Customizations),
+			Notes:          item.Notes,
+		}
+		if item.Product != nil {
+			itemResponse.ProductID = item.Product.UUID
+			itemResponse.ProductName = item.Product.Name
+		}
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	if len(cart.Items) == 0 {
+		return response
+	}
+
+	// The order type is only chosen at checkout, so the preview uses the default
+	preview, err := s.orderService.PreviewOrder(owner.source(), models.DefaultOrderType, toOrderItemRequests(cart.Items))
+	if err != nil {
+		response.PreviewError = err.Error()
+		return response
+	}
+	response.Preview = preview
+	return response
+}
+
+func toOrderItemRequests(items []models.CartItem) []CreateOrderItemRequest {
+	requests := make([]CreateOrderItemRequest, 0, len(items))
+	for _, item := range items {
+		request := CreateOrderItemRequest{
+			Quantity:       item.Quantity,
+			Notes:          item.Notes,
+			Customizations: parseCartCustomizations(item.Customizations),
+		}
+		if item.Product != nil {
+			request.ProductID = item.Product.UUID
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func parseCartCustomizations(data []byte) []OrderItemCustomization {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var customizations []OrderItemCustomization
+	if err := json.Unmarshal(data, &customizations); err != nil {
+		return nil
+	}
+	return customizations
+}
+
+func sameNotes(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -10,38 +10,57 @@ import (
 )
 
 var (
-	ErrCategoryNotFound   = errors.New("category not found")
-	ErrCategorySlugExists = errors.New("category slug already exists")
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategorySlugExists  = errors.New("category slug already exists")
+	ErrCategoryNotDeleted  = errors.New("category is not deleted")
+	ErrInvalidReassignment = errors.New("products can only be reassigned to another existing category")
+	ErrParentNotFound      = errors.New("parent category not found")
+	ErrCategoryTooDeep     = errors.New("category nesting is too deep")
+	ErrCategoryCycle       = errors.New("category cannot be nested under itself")
 )
 
 type CreateCategoryRequest struct {
-	Name         string  `json:"name" validate:"required,min=2,max=100"`
-	Slug         string  `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
-	Description  *string `json:"description,omitempty"`
-	DisplayOrder int     `json:"display_order,omitempty"`
-	IsActive     *bool   `json:"is_active,omitempty"`
-	ImageURL     *string `json:"image_url,omitempty" validate:"omitempty,url"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	Name         string     `json:"name" validate:"required,min=2,max=100"`
+	Slug         string     `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	DisplayOrder int        `json:"display_order,omitempty"`
+	IsActive     *bool      `json:"is_active,omitempty"`
+	ImageURL     *string    `json:"image_url,omitempty" validate:"omitempty,url"`
 }
 
 type UpdateCategoryRequest struct {
-	Name         *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
-	Slug         *string `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
-	Description  *string `json:"description,omitempty"`
-	ImageURL     *string `json:"image_url,omitempty" validate:"omitempty,url"`
-	DisplayOrder *int    `json:"display_order,omitempty"`
-	IsActive     *bool   `json:"is_active,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	ClearParent  bool       `json:"clear_parent,omitempty"`
+	Name         *string    `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Slug         *string    `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	ImageURL     *string    `json:"image_url,omitempty" validate:"omitempty,url"`
+	DisplayOrder *int       `json:"display_order,omitempty"`
+	IsActive     *bool      `json:"is_active,omitempty"`
+}
+
+// ReorderRequest lists IDs in their new display order
+type ReorderRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,min=1,max=500,unique,dive,required"`
 }
 
 type CategoryResponse struct {
-	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	Slug         string    `json:"slug"`
-	Description  *string   `json:"description,omitempty"`
-	ImageURL     *string   `json:"image_url,omitempty"`
-	DisplayOrder int       `json:"display_order"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    string    `json:"created_at"`
-	UpdatedAt    string    `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	Name         string     `json:"name"`
+	Slug         string     `json:"slug"`
+	Description  *string    `json:"description,omitempty"`
+	ImageURL     *string    `json:"image_url,omitempty"`
+	DisplayOrder int        `json:"display_order"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    string     `json:"created_at"`
+	UpdatedAt    string     `json:"updated_at"`
+}
+
+type CategoryTreeNode struct {
+	CategoryResponse
+	Children []CategoryTreeNode `json:"children"`
 }
 
 type CategoryService interface {
@@ -49,8 +68,11 @@ type CategoryService interface {
 	GetByUUID(uuid uuid.UUID) (*CategoryResponse, error)
 	GetBySlug(slug string) (*CategoryResponse, error)
 	GetAll(activeOnly bool) ([]CategoryResponse, error)
+	GetTree(activeOnly bool) ([]CategoryTreeNode, error)
 	Update(uuid uuid.UUID, req UpdateCategoryRequest) (*CategoryResponse, error)
-	Delete(uuid uuid.UUID) error
+	Reorder(req ReorderRequest) error
+	Delete(uuid uuid.UUID, reassignTo *uuid.UUID) error
+	Restore(uuid uuid.UUID) error
 }
 
 type categoryService struct {
@@ -93,6 +115,12 @@ func (s *categoryService) Create(req CreateCategoryRequest) (*CategoryResponse,
 		IsActive:     isActive,
 	}
 
+	if req.ParentID != nil {
+		if err = s.moveUnder(category, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	err = s.categoryRepo.Create(category)
 	if err != nil {
 		return nil, err
@@ -189,6 +217,15 @@ func (s *categoryService) Update(categoryUUID uuid.UUID, req UpdateCategoryReque
 		category.IsActive = *req.IsActive
 	}
 
+	if req.ClearParent {
+		category.ParentID = nil
+		category.Parent = nil
+	} else if req.ParentID != nil {
+		if err = s.moveUnder(category, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	err = s.categoryRepo.Update(category)
 	if err != nil {
 		return nil, err
@@ -197,7 +234,20 @@ func (s *categoryService) Update(categoryUUID uuid.UUID, req UpdateCategoryReque
 	return s.toCategoryResponse(category), nil
 }
 
-func (s *categoryService) Delete(categoryUUID uuid.UUID) error {
+func (s *categoryService) Reorder(req ReorderRequest) error {
+	err := s.categoryRepo.Reorder(req.IDs)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryNotFound) {
+			return ErrCategoryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete soft deletes a category. When reassignTo is set its products move to
+// that category, otherwise they stay attached and are hidden until a restore.
+func (s *categoryService) Delete(categoryUUID uuid.UUID, reassignTo *uuid.UUID) error {
 	category, err := s.categoryRepo.FindByUUID(categoryUUID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrCategoryNotFound) {
@@ -206,12 +256,157 @@ func (s *categoryService) Delete(categoryUUID uuid.UUID) error {
 		return err
 	}
 
-	return s.categoryRepo.Delete(category.ID)
+	var targetID *uint
+	if reassignTo != nil {
+		if *reassignTo == categoryUUID {
+			return ErrInvalidReassignment
+		}
+
+		var target *models.Category
+		target, err = s.categoryRepo.FindByUUID(*reassignTo)
+		if err != nil {
+			// Not found means the target, not the category being deleted
+			if errors.Is(err, repositories.ErrCategoryNotFound) {
+				return ErrInvalidReassignment
+			}
+			return err
+		}
+		targetID = &target.ID
+	}
+
+	return s.categoryRepo.SoftDelete(category.ID, targetID)
+}
+
+func (s *categoryService) Restore(categoryUUID uuid.UUID) error {
+	category, err := s.categoryRepo.FindByUUIDIncludingDeleted(categoryUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryNotFound) {
+			return ErrCategoryNotFound
+		}
+		return err
+	}
+
+	if category.DeletedAt == nil {
+		return ErrCategoryNotDeleted
+	}
+
+	return s.categoryRepo.Restore(category.ID)
+}
+
+// GetTree returns categories nested under their parents. Categories whose parent
+// is filtered out (inactive or deleted) are hidden along with it.
+func (s *categoryService) GetTree(activeOnly bool) ([]CategoryTreeNode, error) {
+	categories, err := s.GetAll(activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	childrenOf := make(map[uuid.UUID][]CategoryResponse)
+	var roots []CategoryResponse
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		childrenOf[*category.ParentID] = append(childrenOf[*category.ParentID], category)
+	}
+
+	var build func(nodes []CategoryResponse) []CategoryTreeNode
+	build = func(nodes []CategoryResponse) []CategoryTreeNode {
+		tree := make([]CategoryTreeNode, len(nodes))
+		for i, node := range nodes {
+			tree[i] = CategoryTreeNode{
+				CategoryResponse: node,
+				Children:         build(childrenOf[node.ID]),
+			}
+		}
+		return tree
+	}
+
+	return build(roots), nil
+}
+
+func (s *categoryService) findParent(parentUUID uuid.UUID) (*models.Category, error) {
+	parent, err := s.categoryRepo.FindByUUID(parentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryNotFound) {
+			return nil, ErrParentNotFound
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// moveUnder validates and sets a new parent for the category. A zero ID means a
+// new category, which has no descendants yet.
+func (s *categoryService) moveUnder(category *models.Category, parentUUID uuid.UUID) error {
+	if parentUUID == category.UUID {
+		return ErrCategoryCycle
+	}
+
+	parent, err := s.findParent(parentUUID)
+	if err != nil {
+		return err
+	}
+
+	all, err := s.categoryRepo.FindAll(nil)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Category, len(all))
+	for i := range all {
+		byID[all[i].ID] = &all[i]
+	}
+
+	// Walk up from the new parent; meeting the category itself means a cycle
+	parentDepth := 0
+	for current := parent; current != nil; {
+		if category.ID != 0 && current.ID == category.ID {
+			return ErrCategoryCycle
+		}
+		parentDepth++
+		if current.ParentID == nil || parentDepth > models.MaxCategoryDepth {
+			break
+		}
+		current = byID[*current.ParentID]
+	}
+
+	height := 1
+	if category.ID != 0 {
+		height = subtreeHeight(category.ID, all)
+	}
+	if parentDepth+height > models.MaxCategoryDepth {
+		return ErrCategoryTooDeep
+	}
+
+	category.ParentID = &parent.ID
+	category.Parent = parent
+	return nil
+}
+
+// subtreeHeight counts the levels from the category down to its deepest descendant
+func subtreeHeight(id uint, all []models.Category) int {
+	height := 1
+	for _, category := range all {
+		if category.ParentID != nil && *category.ParentID == id {
+			if h := subtreeHeight(category.ID, all) + 1; h > height {
+				height = h
+			}
+		}
+	}
+	return height
 }
 
 func (s *categoryService) toCategoryResponse(category *models.Category) *CategoryResponse {
+	var parentID *uuid.UUID
+	if category.Parent != nil {
+		parentID = &category.Parent.UUID
+	}
+
 	return &CategoryResponse{
 		ID:           category.UUID,
+		ParentID:     parentID,
 		Name:         category.Name,
 		Slug:         category.Slug,
 		Description:  category.Description,
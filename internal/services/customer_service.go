@@ -0,0 +1,309 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCustomerSegmentNotFound  = errors.New("customer segment not found")
+	ErrCustomerCategoryNotFound = errors.New("favorite category not found")
+	ErrCustomerSpendRange       = errors.New("minimum spend must not be above maximum spend")
+	ErrCustomerRankingInvalid   = errors.New("sort must be spend, orders or recent")
+)
+
+// CustomerSegmentCriteria narrows a segment export. InactiveDays is how long
+// lapsed members have gone without a completed order, 30 days unless given.
+// The last order dates are inclusive calendar dates.
+type CustomerSegmentCriteria struct {
+	InactiveDays       int
+	LastOrderFrom      *time.Time
+	LastOrderTo        *time.Time
+	MinSpend           *float64
+	MaxSpend           *float64
+	FavoriteCategoryID *uuid.UUID
+}
+
+// CustomerSegmentEntry is one member of an exported segment. Order figures
+// count completed orders only.
+type CustomerSegmentEntry struct {
+	ID               uuid.UUID `json:"id"`
+	FullName         string    `json:"full_name"`
+	Email            string    `json:"email"`
+	Phone            *string   `json:"phone,omitempty"`
+	JoinedAt         string    `json:"joined_at"`
+	OrderCount       int       `json:"order_count"`
+	TotalSpend       float64   `json:"total_spend"`
+	LastOrderAt      *string   `json:"last_order_at,omitempty"`
+	FavoriteCategory *string   `json:"favorite_category,omitempty"`
+}
+
+// CustomerSegmentExport lists a segment's members, biggest spenders first.
+// Order figures are as of StatsRefreshedAt, which is empty until the stats
+// have been built once.
+type CustomerSegmentExport struct {
+	Segment          string                 `json:"segment"`
+	StatsRefreshedAt *string                `json:"stats_refreshed_at,omitempty"`
+	Count            int                    `json:"count"`
+	Customers        []CustomerSegmentEntry `json:"customers"`
+}
+
+// customerSegmentCSVHeader names the export columns, one member per row
+var customerSegmentCSVHeader = []string{
+	"id", "full_name", "email", "phone", "joined_at", "order_count",
+	"total_spend", "last_order_at", "favorite_category",
+}
+
+// WriteCSV writes the segment for import into a mailing tool or spreadsheet.
+// Amounts use two decimals without thousands separators.
+func (e *CustomerSegmentExport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(customerSegmentCSVHeader); err != nil {
+		return err
+	}
+	for _, customer := range e.Customers {
+		record := []string{
+			customer.ID.String(),
+			customer.FullName,
+			customer.Email,
+			optionalString(customer.Phone),
+			customer.JoinedAt,
+			strconv.Itoa(customer.OrderCount),
+			formatCSVAmount(customer.TotalSpend),
+			optionalString(customer.LastOrderAt),
+			optionalString(customer.FavoriteCategory),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// CustomerValue is a member's lifetime value from their completed orders.
+// DaysBetweenOrders is the average gap between their first and last order,
+// left out for members with a single order.
+type CustomerValue struct {
+	Rank              int       `json:"rank"`
+	ID                uuid.UUID `json:"id"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	Phone             *string   `json:"phone,omitempty"`
+	JoinedAt          string    `json:"joined_at"`
+	OrderCount        int       `json:"order_count"`
+	TotalSpend        float64   `json:"total_spend"`
+	AverageOrderValue float64   `json:"average_order_value"`
+	FirstOrderAt      string    `json:"first_order_at"`
+	LastOrderAt       string    `json:"last_order_at"`
+	DaysBetweenOrders *float64  `json:"days_between_orders,omitempty"`
+}
+
+// CustomerValueReport is a page of members ranked by lifetime value, as of
+// StatsRefreshedAt. A limit of zero means every member is listed.
+type CustomerValueReport struct {
+	SortBy           string          `json:"sort_by"`
+	StatsRefreshedAt *string         `json:"stats_refreshed_at,omitempty"`
+	Customers        []CustomerValue `json:"customers"`
+	Total            int64           `json:"total"`
+	Page             int             `json:"page"`
+	Limit            int             `json:"limit"`
+}
+
+// customerValueCSVHeader names the export columns, one member per row
+var customerValueCSVHeader = []string{
+	"rank", "id", "full_name", "email", "phone", "joined_at", "order_count", "total_spend",
+	"average_order_value", "first_order_at", "last_order_at", "days_between_orders",
+}
+
+// WriteCSV writes the ranking for the loyalty team's spreadsheets. Amounts
+// use two decimals without thousands separators.
+func (r *CustomerValueReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(customerValueCSVHeader); err != nil {
+		return err
+	}
+	for _, customer := range r.Customers {
+		var daysBetween string
+		if customer.DaysBetweenOrders != nil {
+			daysBetween = formatCSVAmount(*customer.DaysBetweenOrders)
+		}
+		record := []string{
+			strconv.Itoa(customer.Rank),
+			customer.ID.String(),
+			customer.FullName,
+			customer.Email,
+			optionalString(customer.Phone),
+			customer.JoinedAt,
+			strconv.Itoa(customer.OrderCount),
+			formatCSVAmount(customer.TotalSpend),
+			formatCSVAmount(customer.AverageOrderValue),
+			customer.FirstOrderAt,
+			customer.LastOrderAt,
+			daysBetween,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// CustomerService exports member lists for marketing. Exports read member
+// stats that RefreshStats rolls up from the orders, so they do not put the
+// load of scanning every order on the database.
+type CustomerService interface {
+	ExportSegment(name string, criteria CustomerSegmentCriteria) (*CustomerSegmentExport, error)
+	GetLifetimeValues(sortBy string, page, limit int) (*CustomerValueReport, error)
+	RefreshStats() (int64, error)
+}
+
+type customerService struct {
+	customerRepo repositories.CustomerRepository
+}
+
+func NewCustomerService(customerRepo repositories.CustomerRepository) CustomerService {
+	return &customerService{customerRepo: customerRepo}
+}
+
+// ExportSegment lists the active members of the named segment: all members,
+// lapsed members without a completed order in the inactive days, or new
+// members without a completed order
+func (s *customerService) ExportSegment(name string, criteria CustomerSegmentCriteria) (*CustomerSegmentExport, error) {
+	segment := repositories.MemberSegment(name)
+	switch segment {
+	case repositories.MemberSegmentAll, repositories.MemberSegmentLapsed, repositories.MemberSegmentNew:
+	default:
+		return nil, ErrCustomerSegmentNotFound
+	}
+	if criteria.LastOrderFrom != nil && criteria.LastOrderTo != nil && criteria.LastOrderTo.Before(*criteria.LastOrderFrom) {
+		return nil, ErrInvalidDateRange
+	}
+	if criteria.MinSpend != nil && criteria.MaxSpend != nil && *criteria.MinSpend > *criteria.MaxSpend {
+		return nil, ErrCustomerSpendRange
+	}
+
+	inactiveDays := criteria.InactiveDays
+	if inactiveDays <= 0 {
+		inactiveDays = 30
+	}
+	filters := repositories.CustomerSegmentFilters{
+		Segment:       segment,
+		InactiveSince: time.Now().AddDate(0, 0, -inactiveDays),
+		LastOrderFrom: criteria.LastOrderFrom,
+		MinSpend:      criteria.MinSpend,
+		MaxSpend:      criteria.MaxSpend,
+	}
+	if criteria.LastOrderTo != nil {
+		before := criteria.LastOrderTo.AddDate(0, 0, 1)
+		filters.LastOrderBefore = &before
+	}
+	if criteria.FavoriteCategoryID != nil {
+		ids, err := s.customerRepo.CategoryTreeIDs(*criteria.FavoriteCategoryID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCategoryNotFound) {
+				return nil, ErrCustomerCategoryNotFound
+			}
+			return nil, err
+		}
+		filters.CategoryIDs = ids
+	}
+
+	refreshedAt, err := s.customerRepo.StatsRefreshedAt()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.customerRepo.FindSegment(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := make([]CustomerSegmentEntry, len(rows))
+	for i, row := range rows {
+		customers[i] = CustomerSegmentEntry{
+			ID:               row.UserUUID,
+			FullName:         row.FullName,
+			Email:            row.Email,
+			Phone:            row.Phone,
+			JoinedAt:         row.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
+			OrderCount:       row.OrderCount,
+			TotalSpend:       roundAmount(row.TotalSpend),
+			LastOrderAt:      formatOptionalTime(row.LastOrderAt),
+			FavoriteCategory: row.FavoriteCategory,
+		}
+	}
+
+	return &CustomerSegmentExport{
+		Segment:          name,
+		StatsRefreshedAt: formatOptionalTime(refreshedAt),
+		Count:            len(customers),
+		Customers:        customers,
+	}, nil
+}
+
+// GetLifetimeValues ranks the active members with a completed order by total
+// spend, number of orders or most recent order. A limit of zero lists every
+// member, for exports.
+func (s *customerService) GetLifetimeValues(sortBy string, page, limit int) (*CustomerValueReport, error) {
+	ranking := repositories.CustomerRanking(sortBy)
+	switch ranking {
+	case repositories.CustomerRankingSpend, repositories.CustomerRankingOrders, repositories.CustomerRankingRecent:
+	default:
+		return nil, ErrCustomerRankingInvalid
+	}
+
+	offset := (page - 1) * limit
+	refreshedAt, err := s.customerRepo.StatsRefreshedAt()
+	if err != nil {
+		return nil, err
+	}
+	rows, total, err := s.customerRepo.FindLifetimeValues(ranking, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := make([]CustomerValue, len(rows))
+	for i, row := range rows {
+		customers[i] = CustomerValue{
+			Rank:         offset + i + 1,
+			ID:           row.UserUUID,
+			FullName:     row.FullName,
+			Email:        row.Email,
+			Phone:        row.Phone,
+			JoinedAt:     row.JoinedAt.Format("2006-01-02T15:04:05Z07:00"),
+			OrderCount:   row.OrderCount,
+			TotalSpend:   roundAmount(row.TotalSpend),
+			FirstOrderAt: row.FirstOrderAt.Format("2006-01-02T15:04:05Z07:00"),
+			LastOrderAt:  row.LastOrderAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if row.OrderCount > 0 {
+			customers[i].AverageOrderValue = roundAmount(row.TotalSpend / float64(row.OrderCount))
+		}
+		if row.OrderCount > 1 {
+			days := roundAmount(row.LastOrderAt.Sub(row.FirstOrderAt).Hours() / 24 / float64(row.OrderCount-1))
+			customers[i].DaysBetweenOrders = &days
+		}
+	}
+
+	return &CustomerValueReport{
+		SortBy:           sortBy,
+		StatsRefreshedAt: formatOptionalTime(refreshedAt),
+		Customers:        customers,
+		Total:            total,
+		Page:             page,
+		Limit:            limit,
+	}, nil
+}
+
+// RefreshStats rebuilds the member stats exports read from. It returns how
+// many members have stats.
+func (s *customerService) RefreshStats() (int64, error) {
+	return s.customerRepo.RefreshStats()
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDayAlreadyClosed = errors.New("business day already closed")
+	ErrDayNotOver       = errors.New("business day has not started yet")
+)
+
+// CloseDayRequest names the business day to close as YYYY-MM-DD in the store
+// timezone. It defaults to today.
+type CloseDayRequest struct {
+	BusinessDate string `json:"business_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// FlaggedOrder is an order that was still open when its day was closed
+type FlaggedOrder struct {
+	ID          uuid.UUID          `json:"id"`
+	OrderNumber string             `json:"order_number"`
+	Status      models.OrderStatus `json:"status"`
+	Total       float64            `json:"total"`
+	CreatedAt   string             `json:"created_at"`
+}
+
+type DailySummaryResponse struct {
+	ID              uuid.UUID      `json:"id"`
+	BusinessDate    string         `json:"business_date"`
+	PeriodStart     string         `json:"period_start"`
+	PeriodEnd       string         `json:"period_end"`
+	OrderCount      int            `json:"order_count"`
+	CompletedCount  int            `json:"completed_count"`
+	CancelledCount  int            `json:"cancelled_count"`
+	IncompleteCount int            `json:"incomplete_count"`
+	PaidCount       int            `json:"paid_count"`
+	Subtotal        float64        `json:"subtotal"`
+	Discount        float64        `json:"discount"`
+	ServiceCharge   float64        `json:"service_charge"`
+	Tax             float64        `json:"tax"`
+	TipAmount       float64        `json:"tip_amount"`
+	Total           float64        `json:"total"`
+	CashAmount      float64        `json:"cash_amount"`
+	GatewayAmount   float64        `json:"gateway_amount"`
+	StoredValue     float64        `json:"stored_value_amount"`
+	RefundCount     int            `json:"refund_count"`
+	RefundAmount    float64        `json:"refund_amount"`
+	CancelledAmount float64        `json:"cancelled_amount"`
+	Closed          bool           `json:"closed"`
+	ClosedBy        *StaffSummary  `json:"closed_by,omitempty"`
+	ClosedAt        string         `json:"closed_at,omitempty"`
+	FlaggedOrders   []FlaggedOrder `json:"flagged_orders,omitempty"`
+}
+
+type DailySummaryService interface {
+	CloseDay(businessDate time.Time, adminUUID uuid.UUID) (*DailySummaryResponse, error)
+	GetZReport(businessDate time.Time) (*DailySummaryResponse, error)
+}
+
+type dailySummaryService struct {
+	summaryRepo repositories.DailySummaryRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewDailySummaryService(
+	summaryRepo repositories.DailySummaryRepository,
+	userRepo repositories.UserRepository,
+) DailySummaryService {
+	return &dailySummaryService{
+		summaryRepo: summaryRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CloseDay snapshots the totals of the business day starting at businessDate,
+// midnight in the store timezone. The day's orders can no longer change
+// status, so the snapshot stays true; those still open are flagged in the
+// response.
+func (s *dailySummaryService) CloseDay(businessDate time.Time, adminUUID uuid.UUID) (*DailySummaryResponse, error) {
+	if businessDate.After(time.Now()) {
+		return nil, ErrDayNotOver
+	}
+
+	_, err := s.summaryRepo.FindByDate(businessDate)
+	if err == nil {
+		return nil, ErrDayAlreadyClosed
+	}
+	if !errors.Is(err, repositories.ErrDailySummaryNotFound) {
+		return nil, err
+	}
+
+	admin, err := s.userRepo.FindByUUID(adminUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	summary := &models.DailySummary{
+		BusinessDate: businessDate,
+		PeriodStart:  businessDate,
+		PeriodEnd:    businessDate.AddDate(0, 0, 1),
+		ClosedByID:   &admin.ID,
+		ClosedBy:     admin,
+	}
+	flagged, err := s.summaryRepo.Close(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toDailySummaryResponse(summary)
+	response.FlaggedOrders = make([]FlaggedOrder, len(flagged))
+	for i, order := range flagged {
+		response.FlaggedOrders[i] = FlaggedOrder{
+			ID:          order.UUID,
+			OrderNumber: order.OrderNumber,
+			Status:      order.Status,
+			Total:       order.Total,
+			CreatedAt:   order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	return response, nil
+}
+
+// GetZReport returns the end-of-day summary of the business day starting at
+// businessDate. A closed day returns the snapshot saved when it was closed,
+// which no longer changes; a day still open is totalled as it stands and
+// marked as not closed.
+func (s *dailySummaryService) GetZReport(businessDate time.Time) (*DailySummaryResponse, error) {
+	if businessDate.After(time.Now()) {
+		return nil, ErrDayNotOver
+	}
+
+	summary, err := s.summaryRepo.FindByDate(businessDate)
+	if err == nil {
+		return toDailySummaryResponse(summary), nil
+	}
+	if !errors.Is(err, repositories.ErrDailySummaryNotFound) {
+		return nil, err
+	}
+
+	summary = &models.DailySummary{
+		BusinessDate: businessDate,
+		PeriodStart:  businessDate,
+		PeriodEnd:    businessDate.AddDate(0, 0, 1),
+	}
+	if err := s.summaryRepo.Preview(summary); err != nil {
+		return nil, err
+	}
+
+	response := toDailySummaryResponse(summary)
+	response.Closed = false
+	response.ClosedAt = ""
+	return response, nil
+}
+
+func toDailySummaryResponse(summary *models.DailySummary) *DailySummaryResponse {
+	return &DailySummaryResponse{
+		ID:              summary.UUID,
+		BusinessDate:    summary.BusinessDate.Format("2006-01-02"),
+		PeriodStart:     summary.PeriodStart.Format("2006-01-02T15:04:05Z07:00"),
+		PeriodEnd:       summary.PeriodEnd.Format("2006-01-02T15:04:05Z07:00"),
+		OrderCount:      summary.OrderCount,
+		CompletedCount:  summary.CompletedCount,
+		CancelledCount:  summary.CancelledCount,
+		IncompleteCount: summary.IncompleteCount,
+		PaidCount:       summary.PaidCount,
+		Subtotal:        summary.Subtotal,
+		Discount:        summary.Discount,
+		ServiceCharge:   summary.ServiceCharge,
+		Tax:             summary.Tax,
+		TipAmount:       summary.TipAmount,
+		Total:           summary.Total,
+		CashAmount:      summary.CashAmount,
+		GatewayAmount:   summary.GatewayAmount,
+		StoredValue:     summary.StoredValue,
+		RefundCount:     summary.RefundCount,
+		RefundAmount:    summary.RefundAmount,
+		CancelledAmount: summary.CancelledAmount,
+		Closed:          true,
+		ClosedBy:        toStaffSummary(summary.ClosedBy),
+		ClosedAt:        summary.ClosedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+)
+
+var (
+	ErrEmailTemplateNotFound   = errors.New("email template not found")
+	ErrUnknownTemplateVariable = errors.New("unknown template variable")
+	ErrUnsupportedLocale       = errors.New("unsupported locale")
+)
+
+// templateVariablePattern matches placeholders like {{customer_name}}
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+type UpdateEmailTemplateRequest struct {
+	Subject  string `json:"subject" validate:"required,max=255"`
+	HTMLBody string `json:"html_body" validate:"required"`
+	TextBody string `json:"text_body" validate:"required"`
+}
+
+type TestSendEmailTemplateRequest struct {
+	To        string            `json:"to" validate:"required,email"`
+	Variables map[string]string `json:"variables"`
+}
+
+type EmailTemplateResponse struct {
+	Key       models.EmailTemplateKey `json:"key"`
+	Locale    string                  `json:"locale"`
+	Version   int                     `json:"version"`
+	Subject   string                  `json:"subject"`
+	HTMLBody  string                  `json:"html_body"`
+	TextBody  string                  `json:"text_body"`
+	IsActive  bool                    `json:"is_active"`
+	Variables []string                `json:"variables"`
+	CreatedAt string                  `json:"created_at"`
+}
+
+type EmailTemplateService interface {
+	GetAll() ([]EmailTemplateResponse, error)
+	GetVersions(key models.EmailTemplateKey, locale string) ([]EmailTemplateResponse, error)
+	Update(key models.EmailTemplateKey, locale string, req UpdateEmailTemplateRequest) (*EmailTemplateResponse, error)
+	Activate(key models.EmailTemplateKey, locale string, version int) (*EmailTemplateResponse, error)
+	TestSend(key models.EmailTemplateKey, locale string, req TestSendEmailTemplateRequest) error
+	Send(key models.EmailTemplateKey, locale string, to string, variables map[string]string) error
+}
+
+type emailTemplateService struct {
+	templateRepo repositories.EmailTemplateRepository
+	mailer       utils.Mailer
+	formatter    *utils.Formatter
+}
+
+func NewEmailTemplateService(
+	templateRepo repositories.EmailTemplateRepository,
+	mailer utils.Mailer,
+	formatter *utils.Formatter,
+) EmailTemplateService {
+	return &emailTemplateService{
+		templateRepo: templateRepo,
+		mailer:       mailer,
+		formatter:    formatter,
+	}
+}
+
+func (s *emailTemplateService) GetAll() ([]EmailTemplateResponse, error) {
+	templates, err := s.templateRepo.FindAllActive()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]EmailTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *s.toResponse(&templates[i])
+	}
+	return responses, nil
+}
+
+func (s *emailTemplateService) GetVersions(key models.EmailTemplateKey, locale string) ([]EmailTemplateResponse, error) {
+	locale, err := s.resolveLocale(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.FindVersions(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]EmailTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *s.toResponse(&templates[i])
+	}
+	return responses, nil
+}
+
+// Update saves the content as a new version and makes it active; older versions are kept
+func (s *emailTemplateService) Update(key models.EmailTemplateKey, locale string, req UpdateEmailTemplateRequest) (*EmailTemplateResponse, error) {
+	locale, err := s.resolveLocale(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, content := range []string{req.Subject, req.HTMLBody, req.TextBody} {
+		if err := checkTemplateVariables(key, content); err != nil {
+			return nil, err
+		}
+	}
+
+	template := &models.EmailTemplate{
+		Key:      key,
+		Locale:   locale,
+		Subject:  req.Subject,
+		HTMLBody: req.HTMLBody,
+		TextBody: req.TextBody,
+	}
+	if err := s.templateRepo.CreateVersion(template); err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(template), nil
+}
+
+// Activate rolls the template back (or forward) to an existing version
+func (s *emailTemplateService) Activate(key models.EmailTemplateKey, locale string, version int) (*EmailTemplateResponse, error) {
+	locale, err := s.resolveLocale(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Activate(key, locale, version); err != nil {
+		if errors.Is(err, repositories.ErrEmailTemplateNotFound) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+
+	template, err := s.templateRepo.FindActive(key, locale)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(template), nil
+}
+
+// TestSend sends the active template to an admin-supplied address, filling missing variables with samples
+func (s *emailTemplateService) TestSend(key models.EmailTemplateKey, locale string, req TestSendEmailTemplateRequest) error {
+	locale, err := s.resolveLocale(key, locale)
+	if err != nil {
+		return err
+	}
+
+	variables := s.sampleVariables(locale)
+	for name, value := range req.Variables {
+		variables[name] = value
+	}
+
+	return s.Send(key, locale, req.To, variables)
+}
+
+// Send renders the active template for key in the recipient's locale and emails
+// it, falling back to the store locale when the recipient has none
+func (s *emailTemplateService) Send(key models.EmailTemplateKey, locale string, to string, variables map[string]string) error {
+	if !key.IsValid() {
+		return ErrEmailTemplateNotFound
+	}
+	if !utils.IsSupportedLocale(locale) {
+		locale = s.formatter.Locale()
+	}
+
+	template, err := s.templateRepo.FindActive(key, locale)
+	if errors.Is(err, repositories.ErrEmailTemplateNotFound) && locale != s.formatter.Locale() {
+		template, err = s.templateRepo.FindActive(key, s.formatter.Locale())
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrEmailTemplateNotFound) {
+			return ErrEmailTemplateNotFound
+		}
+		return err
+	}
+
+	return s.mailer.Send(utils.EmailMessage{
+		To:       to,
+		Subject:  renderTemplate(template.Subject, variables, false),
+		HTMLBody: renderTemplate(template.HTMLBody, variables, true),
+		TextBody: renderTemplate(template.TextBody, variables, false),
+	})
+}
+
+func (s *emailTemplateService) toResponse(template *models.EmailTemplate) *EmailTemplateResponse {
+	return &EmailTemplateResponse{
+		Key:       template.Key,
+		Locale:    template.Locale,
+		Version:   template.Version,
+		Subject:   template.Subject,
+		HTMLBody:  template.HTMLBody,
+		TextBody:  template.TextBody,
+		IsActive:  template.IsActive,
+		Variables: models.EmailTemplateVariables[template.Key],
+		CreatedAt: template.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// resolveLocale validates key and locale for admin edits, defaulting to the store locale
+func (s *emailTemplateService) resolveLocale(key models.EmailTemplateKey, locale string) (string, error) {
+	if !key.IsValid() {
+		return "", ErrEmailTemplateNotFound
+	}
+	if locale == "" {
+		return s.formatter.Locale(), nil
+	}
+	if !utils.IsSupportedLocale(locale) {
+		return "", ErrUnsupportedLocale
+	}
+	return locale, nil
+}
+
+// sampleVariables fills placeholders not given in a test send
+func (s *emailTemplateService) sampleVariables(locale string) map[string]string {
+	expiresIn := "30 minutes"
+	if locale == utils.LocaleID {
+		expiresIn = "30 menit"
+	}
+
+	return map[string]string{
+		"customer_name": "John Doe",
+		"order_number":  "MC-250107-001",
+		"total":         s.formatter.ForLocale(locale).Money(115500),
+		"order_url":     "https://matchaciee.com/orders/MC-250107-001",
+		"name":          "John Doe",
+		"reset_url":     "https://matchaciee.com/reset-password?token=sample",
+		"expires_in":    expiresIn,
+		"code":          "123456",
+		"receipt":       "Order    MC-250107-001\nTotal    " + s.formatter.ForLocale(locale).Money(115500),
+		"points":        "350",
+		"value":         s.formatter.ForLocale(locale).Money(35000),
+		"expires_on":    s.formatter.ForLocale(locale).Date(time.Now().AddDate(0, 0, 30)),
+	}
+}
+
+func checkTemplateVariables(key models.EmailTemplateKey, content string) error {
+	allowed := models.EmailTemplateVariables[key]
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(allowed, match[1]) {
+			return fmt.Errorf("%w: %s", ErrUnknownTemplateVariable, match[1])
+		}
+	}
+	return nil
+}
+
+// renderTemplate substitutes placeholders, escaping values for HTML bodies.
+// Placeholders without a value are replaced with an empty string.
+func renderTemplate(content string, variables map[string]string, escapeHTML bool) string {
+	return templateVariablePattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+		value := variables[name]
+		if escapeHTML {
+			return html.EscapeString(value)
+		}
+		return value
+	})
+}
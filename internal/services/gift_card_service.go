@@ -0,0 +1,314 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGiftCardNotFound  = errors.New("gift card not found")
+	ErrGiftCardNotActive = errors.New("only active gift cards can be voided")
+)
+
+// giftCardCodeAlphabet leaves out characters that are easy to misread, such
+// as 0 and O, since codes are typed in by hand
+const giftCardCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GiftCardPurchaseRequest buys a gift card through the payment gateway. The
+// recipient is only printed on the card.
+type GiftCardPurchaseRequest struct {
+	Amount         float64 `json:"amount" validate:"required,gte=25000,lte=5000000"`
+	RecipientName  *string `json:"recipient_name,omitempty" validate:"omitempty,max=255"`
+	RecipientEmail *string `json:"recipient_email,omitempty" validate:"omitempty,email,max=255"`
+	// RequestID is set by the handler and passed on to the gateway
+	RequestID string `json:"-"`
+}
+
+// IssueGiftCardRequest creates an active gift card without payment, such as
+// for a giveaway
+type IssueGiftCardRequest struct {
+	Amount         float64 `json:"amount" validate:"required,gt=0,lte=5000000"`
+	RecipientName  *string `json:"recipient_name,omitempty" validate:"omitempty,max=255"`
+	RecipientEmail *string `json:"recipient_email,omitempty" validate:"omitempty,email,max=255"`
+}
+
+type VoidGiftCardRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"`
+}
+
+// RedeemGiftCardRequest applies a gift card to a pending order. Leave Amount
+// out to take as much as the card and the order allow.
+type RedeemGiftCardRequest struct {
+	Code   string   `json:"code" validate:"required,max=20"`
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gt=0,lte=100000000"`
+}
+
+type GiftCardResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	Code           string                    `json:"code"`
+	InitialAmount  float64                   `json:"initial_amount"`
+	Balance        float64                   `json:"balance"`
+	Status         models.GiftCardStatus     `json:"status"`
+	RecipientName  *string                   `json:"recipient_name,omitempty"`
+	RecipientEmail *string                   `json:"recipient_email,omitempty"`
+	Reference      *string                   `json:"reference,omitempty"`
+	ActivatedAt    *string                   `json:"activated_at,omitempty"`
+	VoidedAt       *string                   `json:"voided_at,omitempty"`
+	VoidReason     *string                   `json:"void_reason,omitempty"`
+	Redemptions    []GiftCardRedemptionEntry `json:"redemptions,omitempty"`
+	CreatedAt      string                    `json:"created_at"`
+}
+
+// GiftCardRedemptionEntry is one order a gift card paid for
+type GiftCardRedemptionEntry struct {
+	ID          uuid.UUID `json:"id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	OrderNumber string    `json:"order_number"`
+	Amount      float64   `json:"amount"`
+	ReleasedAt  *string   `json:"released_at,omitempty"`
+	CreatedAt   string    `json:"created_at"`
+}
+
+type GiftCardListResponse struct {
+	GiftCards []GiftCardResponse `json:"gift_cards"`
+	Total     int64              `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
+
+// GiftCardPurchaseResponse is the new card with the payment page to pay for
+// it. The card becomes active when the gateway settles the payment.
+type GiftCardPurchaseResponse struct {
+	GiftCard    GiftCardResponse `json:"gift_card"`
+	RedirectURL string           `json:"redirect_url"`
+	ExpiresAt   string           `json:"expires_at"`
+}
+
+// GiftCardRedemptionResponse reports what a gift card paid and what is left
+// to pay. PaymentID is set when the card covered the rest of the order.
+type GiftCardRedemptionResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	OrderID     uuid.UUID          `json:"order_id"`
+	OrderNumber string             `json:"order_number"`
+	OrderStatus models.OrderStatus `json:"order_status"`
+	Code        string             `json:"code"`
+	Amount      float64            `json:"amount"`
+	CardBalance float64            `json:"card_balance"`
+	AmountDue   float64            `json:"amount_due"`
+	PaymentID   *uuid.UUID         `json:"payment_id,omitempty"`
+}
+
+type GiftCardService interface {
+	Issue(staffUUID uuid.UUID, req IssueGiftCardRequest) (*GiftCardResponse, error)
+	GetAll(status *models.GiftCardStatus, page, limit int) (*GiftCardListResponse, error)
+	GetByUUID(uuid uuid.UUID) (*GiftCardResponse, error)
+	GetByCode(code string) (*GiftCardResponse, error)
+	Void(uuid, staffUUID uuid.UUID, req VoidGiftCardRequest) (*GiftCardResponse, error)
+}
+
+type giftCardService struct {
+	giftCardRepo repositories.GiftCardRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewGiftCardService(
+	giftCardRepo repositories.GiftCardRepository,
+	userRepo repositories.UserRepository,
+) GiftCardService {
+	return &giftCardService{
+		giftCardRepo: giftCardRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *giftCardService) Issue(staffUUID uuid.UUID, req IssueGiftCardRequest) (*GiftCardResponse, error) {
+	staff, err := s.findUser(staffUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := newGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+
+	amount := roundAmount(req.Amount)
+	card := &models.GiftCard{
+		Code:           code,
+		InitialAmount:  amount,
+		Balance:        amount,
+		Status:         models.GiftCardStatusActive,
+		RecipientName:  req.RecipientName,
+		RecipientEmail: req.RecipientEmail,
+		IssuedBy:       &staff.ID,
+	}
+	if err := s.giftCardRepo.Create(card); err != nil {
+		return nil, fmt.Errorf("failed to save gift card: %w", err)
+	}
+
+	response := toGiftCardResponse(card)
+	return &response, nil
+}
+
+func (s *giftCardService) GetAll(status *models.GiftCardStatus, page, limit int) (*GiftCardListResponse, error) {
+	offset := (page - 1) * limit
+
+	cards, total, err := s.giftCardRepo.FindAll(status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]GiftCardResponse, len(cards))
+	for i := range cards {
+		responses[i] = toGiftCardResponse(&cards[i])
+	}
+
+	return &GiftCardListResponse{
+		GiftCards: responses,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}, nil
+}
+
+func (s *giftCardService) GetByUUID(uuid uuid.UUID) (*GiftCardResponse, error) {
+	card, err := s.giftCardRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGiftCardNotFound) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	return s.withRedemptions(card)
+}
+
+// GetByCode checks a card's balance and history from the code on it, typed
+// in any case
+func (s *giftCardService) GetByCode(code string) (*GiftCardResponse, error) {
+	card, err := s.giftCardRepo.FindByCode(normalizeGiftCardCode(code))
+	if err != nil {
+		if errors.Is(err, repositories.ErrGiftCardNotFound) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	return s.withRedemptions(card)
+}
+
+// Void stops an active card from being redeemed. Orders it already paid for
+// are not affected.
+func (s *giftCardService) Void(uuid, staffUUID uuid.UUID, req VoidGiftCardRequest) (*GiftCardResponse, error) {
+	card, err := s.giftCardRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGiftCardNotFound) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	staff, err := s.findUser(staffUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	voided, err := s.giftCardRepo.Void(card.ID, staff.ID, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to void gift card: %w", err)
+	}
+	if !voided {
+		return nil, ErrGiftCardNotActive
+	}
+
+	return s.GetByUUID(uuid)
+}
+
+func (s *giftCardService) withRedemptions(card *models.GiftCard) (*GiftCardResponse, error) {
+	redemptions, err := s.giftCardRepo.FindRedemptions(card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toGiftCardResponse(card)
+	response.Redemptions = make([]GiftCardRedemptionEntry, len(redemptions))
+	for i, redemption := range redemptions {
+		entry := GiftCardRedemptionEntry{
+			ID:         redemption.UUID,
+			Amount:     redemption.Amount,
+			ReleasedAt: formatOptionalTime(redemption.ReleasedAt),
+			CreatedAt:  redemption.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if redemption.Order != nil {
+			entry.OrderID = redemption.Order.UUID
+			entry.OrderNumber = redemption.Order.OrderNumber
+		}
+		response.Redemptions[i] = entry
+	}
+	return &response, nil
+}
+
+func (s *giftCardService) findUser(userUUID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func toGiftCardResponse(card *models.GiftCard) GiftCardResponse {
+	return GiftCardResponse{
+		ID:             card.UUID,
+		Code:           card.Code,
+		InitialAmount:  card.InitialAmount,
+		Balance:        card.Balance,
+		Status:         card.Status,
+		RecipientName:  card.RecipientName,
+		RecipientEmail: card.RecipientEmail,
+		Reference:      card.Reference,
+		ActivatedAt:    formatOptionalTime(card.ActivatedAt),
+		VoidedAt:       formatOptionalTime(card.VoidedAt),
+		VoidReason:     card.VoidReason,
+		CreatedAt:      card.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// newGiftCardCode returns a random code like GC-7KQM-X3TD-98WP
+func newGiftCardCode() (string, error) {
+	return newCode("GC", 3)
+}
+
+// newCode returns the prefix followed by groups of four random characters
+// from giftCardCodeAlphabet, separated by dashes
+func newCode(prefix string, groups int) (string, error) {
+	limit := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	var code strings.Builder
+	code.WriteString(prefix)
+	for i := range groups * 4 {
+		if i%4 == 0 {
+			code.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(giftCardCodeAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// normalizeGiftCardCode accepts a code typed in lower case or without dashes
+func normalizeGiftCardCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != 14 || !strings.HasPrefix(code, "GC") {
+		return code
+	}
+	return "GC-" + code[2:6] + "-" + code[6:10] + "-" + code[10:]
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAnomalyNotFound        = errors.New("anomaly not found")
+	ErrAnomalyAlreadyResolved = errors.New("anomaly already resolved")
+)
+
+type AnomalyResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	OrderID     *uuid.UUID         `json:"order_id,omitempty"`
+	OrderNumber string             `json:"order_number,omitempty"`
+	Kind        models.AnomalyKind `json:"kind"`
+	Detail      string             `json:"detail"`
+	DetectedAt  string             `json:"detected_at"`
+	ResolvedAt  *string            `json:"resolved_at,omitempty"`
+}
+
+type IntegrityCheckResponse struct {
+	NewAnomalies  int64  `json:"new_anomalies"`
+	OpenAnomalies int    `json:"open_anomalies"`
+	CheckedAt     string `json:"checked_at"`
+}
+
+type IntegrityService interface {
+	RunCheck() (*IntegrityCheckResponse, error)
+	GetAnomalies(includeResolved bool) ([]AnomalyResponse, error)
+	ResolveAnomaly(anomalyUUID uuid.UUID) error
+}
+
+type integrityService struct {
+	integrityRepo repositories.IntegrityRepository
+	logger        *slog.Logger
+}
+
+func NewIntegrityService(integrityRepo repositories.IntegrityRepository, logger *slog.Logger) IntegrityService {
+	return &integrityService{
+		integrityRepo: integrityRepo,
+		logger:        logger,
+	}
+}
+
+// RunCheck looks for order items whose product snapshot can no longer be
+// traced to a product and orders whose totals do not add up. New anomalies are
+// logged and kept open until an admin resolves them.
+func (s *integrityService) RunCheck() (*IntegrityCheckResponse, error) {
+	detected, err := s.integrityRepo.DetectAnomalies()
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.integrityRepo.FindAnomalies(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if detected > 0 {
+		s.logger.Warn("Order integrity check found new anomalies", "detected", detected, "open", len(open))
+	}
+
+	return &IntegrityCheckResponse{
+		NewAnomalies:  detected,
+		OpenAnomalies: len(open),
+		CheckedAt:     time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func (s *integrityService) GetAnomalies(includeResolved bool) ([]AnomalyResponse, error) {
+	anomalies, err := s.integrityRepo.FindAnomalies(includeResolved)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]AnomalyResponse, 0, len(anomalies))
+	for i := range anomalies {
+		responses = append(responses, toAnomalyResponse(&anomalies[i]))
+	}
+	return responses, nil
+}
+
+func (s *integrityService) ResolveAnomaly(anomalyUUID uuid.UUID) error {
+	anomaly, err := s.integrityRepo.FindAnomalyByUUID(anomalyUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAnomalyNotFound) {
+			return ErrAnomalyNotFound
+		}
+		return err
+	}
+
+	if anomaly.ResolvedAt != nil {
+		return ErrAnomalyAlreadyResolved
+	}
+
+	return s.integrityRepo.Resolve(anomaly.ID)
+}
+
+func toAnomalyResponse(anomaly *models.OrderAnomaly) AnomalyResponse {
+	response := AnomalyResponse{
+		ID:         anomaly.UUID,
+		Kind:       anomaly.Kind,
+		Detail:     anomaly.Detail,
+		DetectedAt: anomaly.DetectedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if anomaly.Order != nil {
+		response.OrderID = &anomaly.Order.UUID
+		response.OrderNumber = anomaly.Order.OrderNumber
+	}
+	if anomaly.ResolvedAt != nil {
+		resolvedAt := anomaly.ResolvedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.ResolvedAt = &resolvedAt
+	}
+	return response
+}
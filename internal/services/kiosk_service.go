@@ -0,0 +1,286 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrKioskNotFound   = errors.New("kiosk not found")
+	ErrKioskNameExists = errors.New("kiosk name already exists")
+	ErrInvalidKioskKey = errors.New("invalid kiosk key")
+)
+
+// kioskKeyBytes is the entropy of a kiosk API key; hex doubles its length
+const kioskKeyBytes = 32
+
+type KioskRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
+// KioskHeartbeatRequest is sent periodically by a running kiosk
+type KioskHeartbeatRequest struct {
+	AppVersion *string `json:"app_version,omitempty" validate:"omitempty,max=50"`
+}
+
+// KioskResponse is a kiosk with whether it is online. APIKey is only set
+// right after a key was issued; it cannot be retrieved later.
+type KioskResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	IsActive   bool      `json:"is_active"`
+	Online     bool      `json:"online"`
+	LastSeenAt *string   `json:"last_seen_at,omitempty"`
+	LastIP     *string   `json:"last_ip,omitempty"`
+	AppVersion *string   `json:"app_version,omitempty"`
+	APIKey     string    `json:"api_key,omitempty"`
+	CreatedAt  string    `json:"created_at"`
+	UpdatedAt  string    `json:"updated_at"`
+}
+
+type KioskService interface {
+	Create(req KioskRequest) (*KioskResponse, error)
+	GetAll() ([]KioskResponse, error)
+	GetByUUID(uuid uuid.UUID) (*KioskResponse, error)
+	Update(uuid uuid.UUID, req KioskRequest) (*KioskResponse, error)
+	Delete(uuid uuid.UUID) error
+	RegenerateKey(uuid uuid.UUID) (*KioskResponse, error)
+	Authenticate(apiKey string) (*models.Kiosk, error)
+	Heartbeat(kiosk *models.Kiosk, ip string, req KioskHeartbeatRequest) (*KioskResponse, error)
+}
+
+type kioskService struct {
+	kioskRepo    repositories.KioskRepository
+	offlineAfter time.Duration
+}
+
+// NewKioskService creates the kiosk service. Kiosks without a heartbeat for
+// offlineAfter are reported offline.
+func NewKioskService(kioskRepo repositories.KioskRepository, offlineAfter time.Duration) KioskService {
+	return &kioskService{
+		kioskRepo:    kioskRepo,
+		offlineAfter: offlineAfter,
+	}
+}
+
+// Create registers a kiosk and issues its API key. The key is only returned
+// in this response.
+func (s *kioskService) Create(req KioskRequest) (*KioskResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(name, 0); err != nil {
+		return nil, err
+	}
+
+	apiKey, hash, err := newKioskKey()
+	if err != nil {
+		return nil, err
+	}
+
+	kiosk := &models.Kiosk{
+		Name:       name,
+		APIKeyHash: hash,
+		IsActive:   true,
+	}
+	if req.IsActive != nil {
+		kiosk.IsActive = *req.IsActive
+	}
+
+	if err := s.kioskRepo.Create(kiosk); err != nil {
+		return nil, err
+	}
+
+	response := s.toKioskResponse(kiosk)
+	response.APIKey = apiKey
+	return response, nil
+}
+
+func (s *kioskService) GetAll() ([]KioskResponse, error) {
+	kiosks, err := s.kioskRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]KioskResponse, len(kiosks))
+	for i := range kiosks {
+		responses[i] = *s.toKioskResponse(&kiosks[i])
+	}
+	return responses, nil
+}
+
+func (s *kioskService) GetByUUID(uuid uuid.UUID) (*KioskResponse, error) {
+	kiosk, err := s.findKiosk(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.toKioskResponse(kiosk), nil
+}
+
+// Update renames a kiosk or switches it on or off. A deactivated kiosk is
+// refused until it is switched back on; its key stays the same.
+func (s *kioskService) Update(uuid uuid.UUID, req KioskRequest) (*KioskResponse, error) {
+	kiosk, err := s.findKiosk(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(name, kiosk.ID); err != nil {
+		return nil, err
+	}
+
+	kiosk.Name = name
+	if req.IsActive != nil {
+		kiosk.IsActive = *req.IsActive
+	}
+
+	if err := s.saveKiosk(kiosk); err != nil {
+		return nil, err
+	}
+	return s.GetByUUID(uuid)
+}
+
+func (s *kioskService) Delete(uuid uuid.UUID) error {
+	kiosk, err := s.findKiosk(uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.kioskRepo.Delete(kiosk.ID)
+	if errors.Is(err, repositories.ErrKioskNotFound) {
+		return ErrKioskNotFound
+	}
+	return err
+}
+
+// RegenerateKey issues a new API key, e.g. when a kiosk is replaced or its
+// key leaked. The old key stops working at once.
+func (s *kioskService) RegenerateKey(uuid uuid.UUID) (*KioskResponse, error) {
+	kiosk, err := s.findKiosk(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, hash, err := newKioskKey()
+	if err != nil {
+		return nil, err
+	}
+	kiosk.APIKeyHash = hash
+
+	if err := s.saveKiosk(kiosk); err != nil {
+		return nil, err
+	}
+
+	response, err := s.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	response.APIKey = apiKey
+	return response, nil
+}
+
+// Authenticate resolves an API key to its kiosk. Unknown keys and keys of
+// deactivated kiosks are both rejected with ErrInvalidKioskKey.
+func (s *kioskService) Authenticate(apiKey string) (*models.Kiosk, error) {
+	if apiKey == "" {
+		return nil, ErrInvalidKioskKey
+	}
+
+	kiosk, err := s.kioskRepo.FindByAPIKeyHash(hashKioskKey(apiKey))
+	if err != nil {
+		if errors.Is(err, repositories.ErrKioskNotFound) {
+			return nil, ErrInvalidKioskKey
+		}
+		return nil, err
+	}
+	if !kiosk.IsActive {
+		return nil, ErrInvalidKioskKey
+	}
+	return kiosk, nil
+}
+
+// Heartbeat records that the kiosk is running, from which address and with
+// which app version
+func (s *kioskService) Heartbeat(kiosk *models.Kiosk, ip string, req KioskHeartbeatRequest) (*KioskResponse, error) {
+	appVersion := normalizeContact(req.AppVersion)
+	err := s.kioskRepo.RecordHeartbeat(kiosk.ID, time.Now(), ip, appVersion)
+	if err != nil {
+		if errors.Is(err, repositories.ErrKioskNotFound) {
+			return nil, ErrKioskNotFound
+		}
+		return nil, err
+	}
+	return s.GetByUUID(kiosk.UUID)
+}
+
+func (s *kioskService) findKiosk(uuid uuid.UUID) (*models.Kiosk, error) {
+	kiosk, err := s.kioskRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrKioskNotFound) {
+			return nil, ErrKioskNotFound
+		}
+		return nil, err
+	}
+	return kiosk, nil
+}
+
+func (s *kioskService) saveKiosk(kiosk *models.Kiosk) error {
+	err := s.kioskRepo.Update(kiosk)
+	if errors.Is(err, repositories.ErrKioskNotFound) {
+		return ErrKioskNotFound
+	}
+	return err
+}
+
+// checkNameAvailable fails when another kiosk than exceptID already uses the
+// name
+func (s *kioskService) checkNameAvailable(name string, exceptID uint) error {
+	existing, err := s.kioskRepo.FindByName(name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrKioskNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != exceptID {
+		return ErrKioskNameExists
+	}
+	return nil
+}
+
+func (s *kioskService) toKioskResponse(kiosk *models.Kiosk) *KioskResponse {
+	return &KioskResponse{
+		ID:         kiosk.UUID,
+		Name:       kiosk.Name,
+		IsActive:   kiosk.IsActive,
+		Online:     kiosk.IsActive && kiosk.IsOnlineAt(time.Now(), s.offlineAfter),
+		LastSeenAt: formatOptionalTime(kiosk.LastSeenAt),
+		LastIP:     kiosk.LastIP,
+		AppVersion: kiosk.AppVersion,
+		CreatedAt:  kiosk.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  kiosk.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// newKioskKey returns a new API key and the hash to store for it
+func newKioskKey() (string, string, error) {
+	b := make([]byte, kioskKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	apiKey := hex.EncodeToString(b)
+	return apiKey, hashKioskKey(apiKey), nil
+}
+
+func hashKioskKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
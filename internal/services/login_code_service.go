@@ -0,0 +1,280 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+)
+
+var (
+	ErrLoginCodeChannelDisabled = errors.New("login code channel is not enabled")
+	ErrInvalidLoginCode         = errors.New("invalid or expired login code")
+)
+
+const loginCodeDigits = 6
+
+type RequestLoginCodeRequest struct {
+	Channel models.LoginChannel `json:"channel" validate:"required,oneof=email whatsapp"`
+	Email   string              `json:"email,omitempty" validate:"required_if=Channel email,omitempty,email"`
+	Phone   string              `json:"phone,omitempty" validate:"required_if=Channel whatsapp,omitempty,max=20"`
+}
+
+type LoginWithCodeRequest struct {
+	Channel models.LoginChannel `json:"channel" validate:"required,oneof=email whatsapp"`
+	Email   string              `json:"email,omitempty" validate:"required_if=Channel email,omitempty,email"`
+	Phone   string              `json:"phone,omitempty" validate:"required_if=Channel whatsapp,omitempty,max=20"`
+	Code    string              `json:"code" validate:"required,len=6,numeric"`
+}
+
+// LoginCodeSettings configures password-less login
+type LoginCodeSettings struct {
+	Channels      []string
+	Expiry        time.Duration
+	MaxAttempts   int
+	RequestLimit  int
+	RequestWindow time.Duration
+	Secret        string
+}
+
+type LoginCodeService interface {
+	RequestCode(req RequestLoginCodeRequest) error
+	Login(req LoginWithCodeRequest) (*AuthResponse, error)
+	CleanupExpired() (int64, error)
+}
+
+type loginCodeService struct {
+	userRepo             repositories.UserRepository
+	loginCodeRepo        repositories.LoginCodeRepository
+	refreshTokenRepo     repositories.RefreshTokenRepository
+	emailTemplateService EmailTemplateService
+	whatsApp             utils.WhatsAppSender
+	jwtUtil              *utils.JWTUtil
+	formatter            *utils.Formatter
+	logger               *slog.Logger
+	settings             LoginCodeSettings
+
+	// codeKey hashes stored codes; it is derived from the shared secret so
+	// the hashes are not signatures the secret makes anywhere else
+	codeKey []byte
+}
+
+func NewLoginCodeService(
+	userRepo repositories.UserRepository,
+	loginCodeRepo repositories.LoginCodeRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	emailTemplateService EmailTemplateService,
+	whatsApp utils.WhatsAppSender,
+	jwtUtil *utils.JWTUtil,
+	formatter *utils.Formatter,
+	logger *slog.Logger,
+	settings LoginCodeSettings,
+) LoginCodeService {
+	return &loginCodeService{
+		userRepo:             userRepo,
+		loginCodeRepo:        loginCodeRepo,
+		refreshTokenRepo:     refreshTokenRepo,
+		emailTemplateService: emailTemplateService,
+		whatsApp:             whatsApp,
+		jwtUtil:              jwtUtil,
+		formatter:            formatter,
+		logger:               logger,
+		settings:             settings,
+		codeKey:              deriveLoginCodeKey(settings.Secret),
+	}
+}
+
+// RequestCode sends a new login code to the member's email or WhatsApp. It
+// succeeds without sending anything when no member matches or the member
+// already requested too many codes, so the endpoint cannot be used to find
+// out who has an account. The code is sent in the background, so the reply
+// does not take longer when there is an account to send it to.
+func (s *loginCodeService) RequestCode(req RequestLoginCodeRequest) error {
+	if !slices.Contains(s.settings.Channels, string(req.Channel)) {
+		return ErrLoginCodeChannelDisabled
+	}
+
+	user, err := s.findMember(req.Channel, req.Email, req.Phone)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	issued, err := s.loginCodeRepo.CountSince(user.ID, time.Now().Add(-s.settings.RequestWindow))
+	if err != nil {
+		return err
+	}
+	if issued >= int64(s.settings.RequestLimit) {
+		s.logger.Warn("Login code request limit reached", logging.UserUUID(user.UUID))
+		return nil
+	}
+
+	code, err := generateLoginCode()
+	if err != nil {
+		return err
+	}
+
+	err = s.loginCodeRepo.Create(&models.LoginCode{
+		UserID:    user.ID,
+		Channel:   req.Channel,
+		CodeHash:  s.hashCode(code),
+		ExpiresAt: time.Now().Add(s.settings.Expiry),
+	})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.sendCode(user, req.Channel, code); err != nil {
+			s.logger.Error("Failed to send login code", logging.UserUUID(user.UUID), "channel", req.Channel, logging.Err(err))
+		}
+	}()
+	return nil
+}
+
+// sendCode delivers a login code in the member's language
+func (s *loginCodeService) sendCode(user *models.User, channel models.LoginChannel, code string) error {
+	locale := user.PreferredLocale()
+	if !utils.IsSupportedLocale(locale) {
+		locale = s.formatter.Locale()
+	}
+	expiresIn := formatCodeExpiry(s.settings.Expiry, locale)
+
+	if channel == models.LoginChannelWhatsApp {
+		text := fmt.Sprintf("Your Matchaciee login code is %s. It expires in %s. Do not share it with anyone.", code, expiresIn)
+		if locale == utils.LocaleID {
+			text = fmt.Sprintf("Kode masuk Matchaciee Anda %s. Berlaku selama %s. Jangan bagikan kode ini kepada siapa pun.", code, expiresIn)
+		}
+		return s.whatsApp.Send(*user.Phone, text)
+	}
+
+	return s.emailTemplateService.Send(models.EmailTemplateLoginCode, locale, user.Email, map[string]string{
+		"name":       user.FullName,
+		"code":       code,
+		"expires_in": expiresIn,
+	})
+}
+
+// Login exchanges a login code for the same tokens a password login returns.
+// Only the latest code counts, and it stops working after too many guesses.
+// Each guess reserves an attempt before the code is compared, so concurrent
+// guesses cannot get past the limit.
+func (s *loginCodeService) Login(req LoginWithCodeRequest) (*AuthResponse, error) {
+	if !slices.Contains(s.settings.Channels, string(req.Channel)) {
+		return nil, ErrLoginCodeChannelDisabled
+	}
+
+	user, err := s.findMember(req.Channel, req.Email, req.Phone)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrInvalidLoginCode
+		}
+		return nil, err
+	}
+
+	loginCode, err := s.loginCodeRepo.FindLatestActive(user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrLoginCodeNotFound) {
+			return nil, ErrInvalidLoginCode
+		}
+		return nil, err
+	}
+
+	if loginCode.Channel != req.Channel {
+		return nil, ErrInvalidLoginCode
+	}
+
+	reserved, err := s.loginCodeRepo.ReserveAttempt(loginCode.ID, s.settings.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved || !hmac.Equal([]byte(loginCode.CodeHash), []byte(s.hashCode(req.Code))) {
+		return nil, ErrInvalidLoginCode
+	}
+
+	consumed, err := s.loginCodeRepo.Consume(loginCode.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidLoginCode
+	}
+
+	return issueAuthTokens(s.jwtUtil, s.refreshTokenRepo, user)
+}
+
+// CleanupExpired deletes expired codes once they no longer count towards the
+// request limit
+func (s *loginCodeService) CleanupExpired() (int64, error) {
+	return s.loginCodeRepo.DeleteExpired(time.Now().Add(-s.settings.RequestWindow))
+}
+
+// findMember looks up the member a code is sent to. Staff accounts keep
+// logging in with a password.
+func (s *loginCodeService) findMember(channel models.LoginChannel, email, phone string) (*models.User, error) {
+	var user *models.User
+	var err error
+	if channel == models.LoginChannelWhatsApp {
+		user, err = s.userRepo.FindByPhone(phone)
+	} else {
+		user, err = s.userRepo.FindByEmail(email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != models.RoleMember {
+		return nil, repositories.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *loginCodeService) hashCode(code string) string {
+	mac := hmac.New(sha256.New, s.codeKey)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deriveLoginCodeKey keys login code hashes apart from the JWTs and pickup
+// codes made with the same secret
+func deriveLoginCodeKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("login-code:"))
+	return mac.Sum(nil)
+}
+
+func generateLoginCode() (string, error) {
+	limit := big.NewInt(1)
+	for range loginCodeDigits {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", loginCodeDigits, n), nil
+}
+
+func formatCodeExpiry(expiry time.Duration, locale string) string {
+	minutes := max(int(expiry.Round(time.Minute)/time.Minute), 1)
+	if locale == utils.LocaleID {
+		return fmt.Sprintf("%d menit", minutes)
+	}
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// RedeemPointsRequest spends loyalty points on a member's pending order
+type RedeemPointsRequest struct {
+	Points int `json:"points" validate:"required,gt=0,lte=10000000"`
+}
+
+// PointsRedemptionResponse reports what the points paid and what is left to
+// pay. PaymentID is set when the points covered the rest of the order.
+type PointsRedemptionResponse struct {
+	OrderID       uuid.UUID          `json:"order_id"`
+	OrderNumber   string             `json:"order_number"`
+	OrderStatus   models.OrderStatus `json:"order_status"`
+	Points        int                `json:"points"`
+	Amount        float64            `json:"amount"`
+	PointsBalance int                `json:"points_balance"`
+	AmountDue     float64            `json:"amount_due"`
+	PaymentID     *uuid.UUID         `json:"payment_id,omitempty"`
+}
+
+type PointTransactionResponse struct {
+	ID           uuid.UUID                   `json:"id"`
+	Type         models.PointTransactionType `json:"type"`
+	Points       int                         `json:"points"`
+	BalanceAfter int                         `json:"balance_after"`
+	OrderID      *uuid.UUID                  `json:"order_id,omitempty"`
+	OrderNumber  *string                     `json:"order_number,omitempty"`
+	Note         *string                     `json:"note,omitempty"`
+	CreatedAt    string                      `json:"created_at"`
+}
+
+// PointsExpiryResponse is a number of points that expire together. Soon is
+// set when they expire within the warning period.
+type PointsExpiryResponse struct {
+	Points    int    `json:"points"`
+	ExpiresAt string `json:"expires_at"`
+	Soon      bool   `json:"soon"`
+}
+
+// PointsResponse is a member's points balance, what it is worth at the
+// current point value, when the points expire, soonest first, and a page of
+// their ledger, newest entries first
+type PointsResponse struct {
+	UserID       uuid.UUID                  `json:"user_id"`
+	Balance      int                        `json:"balance"`
+	PointValue   float64                    `json:"point_value"`
+	BalanceValue float64                    `json:"balance_value"`
+	Expiring     []PointsExpiryResponse     `json:"expiring,omitempty"`
+	Transactions []PointTransactionResponse `json:"transactions"`
+	Total        int64                      `json:"total"`
+	Page         int                        `json:"page"`
+	Limit        int                        `json:"limit"`
+}
+
+// StampCardResponse is a member's progress toward their next free drink, with
+// the free-drink vouchers earlier cards were swapped for, newest first
+type StampCardResponse struct {
+	Enabled   bool              `json:"enabled"`
+	Stamps    int               `json:"stamps"`
+	Goal      int               `json:"goal"`
+	Remaining int               `json:"remaining"`
+	Rewards   []VoucherResponse `json:"rewards"`
+}
+
+type LoyaltyService interface {
+	GetPoints(userUUID uuid.UUID, page, limit int) (*PointsResponse, error)
+	GetMyStamps(userUUID uuid.UUID) (*StampCardResponse, error)
+	ExpirePoints() (int, error)
+	SendExpiryWarnings() (int, error)
+}
+
+type loyaltyService struct {
+	loyaltyRepo          repositories.LoyaltyRepository
+	userRepo             repositories.UserRepository
+	settingsService      SettingsService
+	emailTemplateService EmailTemplateService
+	formatter            *utils.Formatter
+	logger               *slog.Logger
+}
+
+func NewLoyaltyService(
+	loyaltyRepo repositories.LoyaltyRepository,
+	userRepo repositories.UserRepository,
+	settingsService SettingsService,
+	emailTemplateService EmailTemplateService,
+	formatter *utils.Formatter,
+	logger *slog.Logger,
+) LoyaltyService {
+	return &loyaltyService{
+		loyaltyRepo:          loyaltyRepo,
+		userRepo:             userRepo,
+		settingsService:      settingsService,
+		emailTemplateService: emailTemplateService,
+		formatter:            formatter,
+		logger:               logger,
+	}
+}
+
+func (s *loyaltyService) GetPoints(userUUID uuid.UUID, page, limit int) (*PointsResponse, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	settings, err := s.settingsService.GetLoyaltySettings()
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.loyaltyRepo.Balance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	entries, total, err := s.loyaltyRepo.FindTransactions(user.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]PointTransactionResponse, len(entries))
+	for i := range entries {
+		transactions[i] = toPointTransactionResponse(&entries[i])
+	}
+
+	var expiring []PointsExpiryResponse
+	if settings.ExpiryMonths > 0 {
+		lots, err := s.loyaltyRepo.FindUnspentLots(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		expiring = toPointsExpiryResponses(lots, settings, time.Now(), s.formatter.Location())
+	}
+
+	return &PointsResponse{
+		UserID:       user.UUID,
+		Balance:      balance,
+		PointValue:   settings.PointValue,
+		BalanceValue: roundAmount(float64(balance) * settings.PointValue),
+		Expiring:     expiring,
+		Transactions: transactions,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+	}, nil
+}
+
+// GetMyStamps shows the member's stamp card. Stamps collected before the card
+// was turned off are still shown, though no more are added.
+func (s *loyaltyService) GetMyStamps(userUUID uuid.UUID) (*StampCardResponse, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	settings, err := s.settingsService.GetStampSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	stamps, err := s.loyaltyRepo.StampBalance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers, err := s.loyaltyRepo.FindStampRewards(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rewards := make([]VoucherResponse, len(vouchers))
+	for i := range vouchers {
+		reward := toVoucherResponse(&vouchers[i], now)
+		reward.RevokeReason = nil
+		rewards[i] = reward
+	}
+
+	return &StampCardResponse{
+		Enabled:   settings.Enabled(),
+		Stamps:    stamps,
+		Goal:      settings.Goal,
+		Remaining: max(settings.Goal-stamps, 0),
+		Rewards:   rewards,
+	}, nil
+}
+
+// ExpirePoints takes away the points that have been kept longer than the
+// loyalty settings allow, and returns how many members lost points
+func (s *loyaltyService) ExpirePoints() (int, error) {
+	settings, err := s.settingsService.GetLoyaltySettings()
+	if err != nil {
+		return 0, err
+	}
+	if settings.ExpiryMonths <= 0 {
+		return 0, nil
+	}
+
+	expired, err := s.loyaltyRepo.ExpirePoints(settings.EarnedBefore(time.Now()))
+	if expired > 0 {
+		s.logger.Info("Expired loyalty points", "members", expired)
+	}
+	return expired, err
+}
+
+// SendExpiryWarnings emails each member whose points expire within the
+// warning period, once for those points, and returns how many were emailed.
+// A failed email is logged and not retried.
+func (s *loyaltyService) SendExpiryWarnings() (int, error) {
+	settings, err := s.settingsService.GetLoyaltySettings()
+	if err != nil {
+		return 0, err
+	}
+	if settings.ExpiryMonths <= 0 || settings.ExpiryWarningDays <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	earnedBefore := settings.EarnedBefore(now.AddDate(0, 0, settings.ExpiryWarningDays))
+	userIDs, err := s.loyaltyRepo.FindExpiryWarningUserIDs(earnedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		claimed, err := s.loyaltyRepo.MarkExpiryWarned(userID, earnedBefore)
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.sendExpiryWarning(userID, earnedBefore, settings); err != nil {
+			s.logger.Error("Failed to warn member about expiring points", "user_id", userID, logging.Err(err))
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// sendExpiryWarning emails the member about their points earned before
+// earnedBefore, with the date the first of them expire
+func (s *loyaltyService) sendExpiryWarning(userID uint, earnedBefore time.Time, settings *LoyaltySettings) error {
+	lots, err := s.loyaltyRepo.FindUnspentLots(userID)
+	if err != nil {
+		return err
+	}
+	points := 0
+	var firstExpiry *time.Time
+	for _, lot := range lots {
+		if lot.EarnedAt.After(earnedBefore) {
+			break
+		}
+		points += lot.Points
+		if firstExpiry == nil {
+			firstExpiry = settings.ExpiresAt(lot.EarnedAt)
+		}
+	}
+	if points == 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return err
+	}
+
+	locale := user.PreferredLocale()
+	if !utils.IsSupportedLocale(locale) {
+		locale = s.formatter.Locale()
+	}
+	formatter := s.formatter.ForLocale(locale).WithCurrency(currency.Currency())
+	err = s.emailTemplateService.Send(models.EmailTemplatePointsExpiring, locale, user.Email, map[string]string{
+		"name":       user.FullName,
+		"points":     strconv.Itoa(points),
+		"value":      formatter.Money(float64(points) * settings.PointValue),
+		"expires_on": formatter.Date(*firstExpiry),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send expiry warning: %w", err)
+	}
+	return nil
+}
+
+// toPointsExpiryResponses groups the unspent points by the day in the store
+// timezone they expire
+func toPointsExpiryResponses(lots []repositories.PointLot, settings *LoyaltySettings, now time.Time, location *time.Location) []PointsExpiryResponse {
+	warnUntil := now.AddDate(0, 0, settings.ExpiryWarningDays)
+	var expiring []PointsExpiryResponse
+	var lastDay string
+	for _, lot := range lots {
+		expiresAt := settings.ExpiresAt(lot.EarnedAt)
+		day := expiresAt.In(location).Format("2006-01-02")
+		if len(expiring) > 0 && day == lastDay {
+			expiring[len(expiring)-1].Points += lot.Points
+			continue
+		}
+		lastDay = day
+		expiring = append(expiring, PointsExpiryResponse{
+			Points:    lot.Points,
+			ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+			Soon:      settings.ExpiryWarningDays > 0 && !expiresAt.After(warnUntil),
+		})
+	}
+	return expiring
+}
+
+func toPointTransactionResponse(entry *models.PointTransaction) PointTransactionResponse {
+	response := PointTransactionResponse{
+		ID:           entry.UUID,
+		Type:         entry.Type,
+		Points:       entry.Points,
+		BalanceAfter: entry.BalanceAfter,
+		Note:         entry.Note,
+		CreatedAt:    entry.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if entry.Order != nil {
+		response.OrderID = &entry.Order.UUID
+		response.OrderNumber = &entry.Order.OrderNumber
+	}
+	return response
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMediaTooLarge        = errors.New("file exceeds the upload size limit")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// storageWarningRatio is the share of the quota at which usage is flagged so
+// admins can clean up before uploads start failing
+const storageWarningRatio = 0.8
+
+// mediaExtensions maps the accepted image types to the extension they are
+// stored with
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+type MediaSettings struct {
+	Dir          string
+	BaseURL      string
+	QuotaBytes   int64
+	MaxFileBytes int64
+	CleanupAfter time.Duration
+}
+
+type MediaFileResponse struct {
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	CreatedAt    string    `json:"created_at"`
+}
+
+type StorageUsageResponse struct {
+	UsedBytes         int64   `json:"used_bytes"`
+	QuotaBytes        int64   `json:"quota_bytes"`
+	UsedPercent       float64 `json:"used_percent"`
+	NearQuota         bool    `json:"near_quota"`
+	FileCount         int64   `json:"file_count"`
+	UnreferencedFiles int     `json:"unreferenced_files"`
+	UnreferencedBytes int64   `json:"unreferenced_bytes"`
+	CleanupAfterDays  int     `json:"cleanup_after_days"`
+}
+
+type MediaCleanupResponse struct {
+	DeletedFiles int    `json:"deleted_files"`
+	FreedBytes   int64  `json:"freed_bytes"`
+	CleanedAt    string `json:"cleaned_at"`
+}
+
+type MediaService interface {
+	Upload(originalName string, content []byte) (*MediaFileResponse, error)
+	GetUsage() (*StorageUsageResponse, error)
+	Cleanup() (*MediaCleanupResponse, error)
+}
+
+type mediaService struct {
+	mediaRepo repositories.MediaRepository
+	logger    *slog.Logger
+	settings  MediaSettings
+}
+
+func NewMediaService(mediaRepo repositories.MediaRepository, logger *slog.Logger, settings MediaSettings) MediaService {
+	return &mediaService{
+		mediaRepo: mediaRepo,
+		logger:    logger,
+		settings:  settings,
+	}
+}
+
+// Upload stores an image in the upload directory and returns the URL to set
+// as a product or category image. The quota is checked against tracked usage
+// before writing, so concurrent uploads may overshoot it slightly.
+func (s *mediaService) Upload(originalName string, content []byte) (*MediaFileResponse, error) {
+	size := int64(len(content))
+	if size > s.settings.MaxFileBytes {
+		return nil, ErrMediaTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	extension, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	usage, err := s.mediaRepo.Usage()
+	if err != nil {
+		return nil, err
+	}
+	if usage.TotalBytes+size > s.settings.QuotaBytes {
+		return nil, ErrStorageQuotaExceeded
+	}
+
+	fileName := uuid.NewString() + extension
+	path := filepath.Join(s.settings.Dir, fileName)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write upload: %w", err)
+	}
+
+	file := &models.MediaFile{
+		FileName:     fileName,
+		OriginalName: filepath.Base(originalName),
+		ContentType:  contentType,
+		SizeBytes:    size,
+		URL:          s.settings.BaseURL + "/uploads/" + fileName,
+	}
+	if err := s.mediaRepo.Create(file); err != nil {
+		if removeErr := os.Remove(path); removeErr != nil {
+			s.logger.Error("Failed to remove untracked upload", "file", fileName, logging.Err(removeErr))
+		}
+		return nil, err
+	}
+
+	if used := usage.TotalBytes + size; float64(used) >= float64(s.settings.QuotaBytes)*storageWarningRatio {
+		s.logger.Warn("Media storage nearly full", "used_bytes", used, "quota_bytes", s.settings.QuotaBytes)
+	}
+
+	return toMediaFileResponse(file), nil
+}
+
+// GetUsage reports tracked storage against the quota, and how much of it is
+// held by files no product or category uses
+func (s *mediaService) GetUsage() (*StorageUsageResponse, error) {
+	usage, err := s.mediaRepo.Usage()
+	if err != nil {
+		return nil, err
+	}
+
+	unreferenced, err := s.mediaRepo.FindUnreferenced(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var unreferencedBytes int64
+	for _, file := range unreferenced {
+		unreferencedBytes += file.SizeBytes
+	}
+
+	usedPercent := float64(usage.TotalBytes) / float64(s.settings.QuotaBytes) * 100
+	return &StorageUsageResponse{
+		UsedBytes:         usage.TotalBytes,
+		QuotaBytes:        s.settings.QuotaBytes,
+		UsedPercent:       math.Round(usedPercent*100) / 100,
+		NearQuota:         float64(usage.TotalBytes) >= float64(s.settings.QuotaBytes)*storageWarningRatio,
+		FileCount:         usage.FileCount,
+		UnreferencedFiles: len(unreferenced),
+		UnreferencedBytes: unreferencedBytes,
+		CleanupAfterDays:  int(s.settings.CleanupAfter / (24 * time.Hour)),
+	}, nil
+}
+
+// Cleanup deletes files that no product or category has used since they
+// were uploaded at least CleanupAfter ago. A file missing from disk is still
+// untracked so usage stays accurate.
+func (s *mediaService) Cleanup() (*MediaCleanupResponse, error) {
+	files, err := s.mediaRepo.FindUnreferenced(time.Now().Add(-s.settings.CleanupAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MediaCleanupResponse{}
+	for _, file := range files {
+		err := os.Remove(filepath.Join(s.settings.Dir, file.FileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Error("Failed to delete media file", "file", file.FileName, logging.Err(err))
+			continue
+		}
+		if err := s.mediaRepo.Delete(file.ID); err != nil {
+			return nil, err
+		}
+		result.DeletedFiles++
+		result.FreedBytes += file.SizeBytes
+	}
+
+	if result.DeletedFiles > 0 {
+		s.logger.Info("Media cleanup deleted unused files", "files", result.DeletedFiles, "freed_bytes", result.FreedBytes)
+	}
+
+	result.CleanedAt = time.Now().Format("2006-01-02T15:04:05Z07:00")
+	return result, nil
+}
+
+func toMediaFileResponse(file *models.MediaFile) *MediaFileResponse {
+	return &MediaFileResponse{
+		ID:           file.UUID,
+		URL:          file.URL,
+		OriginalName: file.OriginalName,
+		ContentType:  file.ContentType,
+		SizeBytes:    file.SizeBytes,
+		CreatedAt:    file.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
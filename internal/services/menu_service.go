@@ -0,0 +1,200 @@
+package services
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// MenuResponse is everything a kiosk needs to render the menu in one payload
+type MenuResponse struct {
+	Version       string         `json:"version"`
+	UpdatedAt     string         `json:"updated_at"`
+	Categories    []MenuCategory `json:"categories"`
+	Uncategorized []MenuProduct  `json:"uncategorized,omitempty"`
+}
+
+type MenuCategory struct {
+	ID           uuid.UUID     `json:"id"`
+	ParentID     *uuid.UUID    `json:"parent_id,omitempty"`
+	Name         string        `json:"name"`
+	Slug         string        `json:"slug"`
+	Description  *string       `json:"description,omitempty"`
+	ImageURL     *string       `json:"image_url,omitempty"`
+	DisplayOrder int           `json:"display_order"`
+	Products     []MenuProduct `json:"products"`
+}
+
+type MenuProduct struct {
+	ID              uuid.UUID           `json:"id"`
+	Name            string              `json:"name"`
+	Slug            string              `json:"slug"`
+	Description     *string             `json:"description,omitempty"`
+	BasePrice       float64             `json:"base_price"`
+	PreparationTime int                 `json:"preparation_time"`
+	DisplayOrder    int                 `json:"display_order"`
+	IsCustomizable  bool                `json:"is_customizable"`
+	SoldOut         bool                `json:"sold_out"`
+	ImageURL        *string             `json:"image_url,omitempty"`
+	Customizations  []MenuCustomization `json:"customizations,omitempty"`
+}
+
+type MenuCustomization struct {
+	ID                uuid.UUID `json:"id"`
+	CustomizationType string    `json:"customization_type"`
+	OptionName        string    `json:"option_name"`
+	PriceModifier     float64   `json:"price_modifier"`
+	DisplayOrder      int       `json:"display_order"`
+}
+
+type MenuService interface {
+	GetMenu() (*MenuResponse, error)
+	CatalogVersion() (string, error)
+}
+
+type menuService struct {
+	categoryRepo repositories.CategoryRepository
+	productRepo  repositories.ProductRepository
+	catalogRepo  repositories.CatalogRepository
+}
+
+func NewMenuService(
+	categoryRepo repositories.CategoryRepository,
+	productRepo repositories.ProductRepository,
+	catalogRepo repositories.CatalogRepository,
+) MenuService {
+	return &menuService{
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+		catalogRepo:  catalogRepo,
+	}
+}
+
+// GetMenu returns active categories with their available products. Categories
+// under an inactive parent are hidden along with their products.
+func (s *menuService) GetMenu() (*MenuResponse, error) {
+	// Read the version first so a concurrent change can only make it stale, never ahead of the content
+	version, err := s.CatalogVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	categories, err := s.categoryRepo.FindAll(&isActive)
+	if err != nil {
+		return nil, err
+	}
+
+	isAvailable := true
+	products, err := s.productRepo.FindAll(false, &isAvailable, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	activeByID := make(map[uint]*models.Category, len(categories))
+	for i := range categories {
+		activeByID[categories[i].ID] = &categories[i]
+	}
+
+	var updatedAt time.Time
+	menu := &MenuResponse{Categories: []MenuCategory{}}
+	indexByID := make(map[uint]int, len(categories))
+	for i := range categories {
+		category := &categories[i]
+		if !isCategoryVisible(category, activeByID) {
+			continue
+		}
+
+		menuCategory := MenuCategory{
+			ID:           category.UUID,
+			Name:         category.Name,
+			Slug:         category.Slug,
+			Description:  category.Description,
+			ImageURL:     category.ImageURL,
+			DisplayOrder: category.DisplayOrder,
+			Products:     []MenuProduct{},
+		}
+		if category.Parent != nil {
+			menuCategory.ParentID = &category.Parent.UUID
+		}
+
+		indexByID[category.ID] = len(menu.Categories)
+		menu.Categories = append(menu.Categories, menuCategory)
+		if category.UpdatedAt.After(updatedAt) {
+			updatedAt = category.UpdatedAt
+		}
+	}
+
+	for i := range products {
+		product := &products[i]
+		if product.CategoryID == nil {
+			menu.Uncategorized = append(menu.Uncategorized, toMenuProduct(product))
+		} else {
+			index, ok := indexByID[*product.CategoryID]
+			if !ok {
+				// Category is inactive or hidden under an inactive parent
+				continue
+			}
+			menu.Categories[index].Products = append(menu.Categories[index].Products, toMenuProduct(product))
+		}
+
+		if product.UpdatedAt.After(updatedAt) {
+			updatedAt = product.UpdatedAt
+		}
+	}
+
+	menu.Version = version
+	menu.UpdatedAt = updatedAt.Format("2006-01-02T15:04:05Z07:00")
+
+	return menu, nil
+}
+
+// CatalogVersion identifies the current state of categories, products and customizations
+func (s *menuService) CatalogVersion() (string, error) {
+	version, err := s.catalogRepo.Version()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(version, 10), nil
+}
+
+func isCategoryVisible(category *models.Category, activeByID map[uint]*models.Category) bool {
+	for depth := 0; category.ParentID != nil; depth++ {
+		parent, ok := activeByID[*category.ParentID]
+		if !ok || depth >= models.MaxCategoryDepth {
+			return false
+		}
+		category = parent
+	}
+	return true
+}
+
+func toMenuProduct(product *models.Product) MenuProduct {
+	menuProduct := MenuProduct{
+		ID:              product.UUID,
+		Name:            product.Name,
+		Slug:            product.Slug,
+		Description:     product.Description,
+		BasePrice:       product.BasePrice,
+		PreparationTime: product.PreparationTime,
+		DisplayOrder:    product.DisplayOrder,
+		IsCustomizable:  product.IsCustomizable,
+		SoldOut:         product.StockQuantity != nil && *product.StockQuantity <= 0,
+		ImageURL:        product.ImageURL,
+	}
+
+	for _, customization := range product.Customizations {
+		menuProduct.Customizations = append(menuProduct.Customizations, MenuCustomization{
+			ID:                customization.UUID,
+			CustomizationType: customization.CustomizationType,
+			OptionName:        customization.OptionName,
+			PriceModifier:     customization.PriceModifier,
+			DisplayOrder:      customization.DisplayOrder,
+		})
+	}
+
+	return menuProduct
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// NotificationService sends customer emails in response to order events, off
+// the request path
+type NotificationService interface {
+	Run(ctx context.Context)
+	SendOrderConfirmation(orderUUID uuid.UUID) error
+	SendReceipt(orderUUID uuid.UUID) error
+	SendOrderReady(orderUUID uuid.UUID) error
+}
+
+// readyChannel tells a customer on one channel that their order is ready.
+// Channels are keyed by the names members choose in notify_via, so another
+// one such as push or SMS plugs in by adding a sender to readyChannels.
+type readyChannel func(order *models.Order, to, locale string) error
+
+type notificationService struct {
+	orderRepo            repositories.OrderRepository
+	userRepo             repositories.UserRepository
+	paymentRepo          repositories.PaymentRepository
+	settingsService      SettingsService
+	receiptService       ReceiptService
+	emailTemplateService EmailTemplateService
+	whatsApp             utils.WhatsAppSender
+	events               realtime.Broker
+	formatter            *utils.Formatter
+	logger               *slog.Logger
+	frontendURL          string
+	readyChannels        map[string]readyChannel
+}
+
+func NewNotificationService(
+	orderRepo repositories.OrderRepository,
+	userRepo repositories.UserRepository,
+	paymentRepo repositories.PaymentRepository,
+	settingsService SettingsService,
+	receiptService ReceiptService,
+	emailTemplateService EmailTemplateService,
+	whatsApp utils.WhatsAppSender,
+	events realtime.Broker,
+	formatter *utils.Formatter,
+	logger *slog.Logger,
+	frontendURL string,
+) NotificationService {
+	s := &notificationService{
+		orderRepo:            orderRepo,
+		userRepo:             userRepo,
+		paymentRepo:          paymentRepo,
+		settingsService:      settingsService,
+		receiptService:       receiptService,
+		emailTemplateService: emailTemplateService,
+		whatsApp:             whatsApp,
+		events:               events,
+		formatter:            formatter,
+		logger:               logger,
+		frontendURL:          frontendURL,
+	}
+	s.readyChannels = map[string]readyChannel{
+		models.NotifyViaEmail:    s.sendReadyEmail,
+		models.NotifyViaWhatsApp: s.sendReadyWhatsApp,
+	}
+	return s
+}
+
+// Run follows the order feed until ctx is cancelled. Guests who left a
+// contact get the tracking link when their order is placed, customers are
+// told when their order is ready, and every completed order gets its receipt.
+func (s *notificationService) Run(ctx context.Context) {
+	sub := s.events.Subscribe(OrdersTopic)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload, ok := <-sub.C:
+			if !ok {
+				return
+			}
+
+			var event OrderEvent
+			if err := json.Unmarshal(payload, &event); err != nil {
+				s.logger.Error("Failed to decode order event", logging.Err(err))
+				continue
+			}
+
+			switch {
+			case event.Type == OrderEventCreated:
+				go func() {
+					if err := s.SendOrderConfirmation(event.OrderID); err != nil {
+						s.logger.Error("Failed to send order confirmation", logging.OrderNumber(event.OrderNumber), logging.Err(err))
+					}
+				}()
+			case event.Type == OrderEventStatusChanged && event.Status == models.OrderStatusReady:
+				go func() {
+					if err := s.SendOrderReady(event.OrderID); err != nil {
+						s.logger.Error("Failed to send ready notification", logging.OrderNumber(event.OrderNumber), logging.Err(err))
+					}
+				}()
+			case event.Type == OrderEventStatusChanged && event.Status == models.OrderStatusCompleted:
+				go func() {
+					if err := s.SendReceipt(event.OrderID); err != nil {
+						s.logger.Error("Failed to send receipt", logging.OrderNumber(event.OrderNumber), logging.Err(err))
+					}
+				}()
+			}
+		}
+	}
+}
+
+// SendOrderConfirmation sends the tracking link to the email address and
+// WhatsApp number a guest left at checkout. Members follow their orders in the
+// app and get nothing.
+func (s *notificationService) SendOrderConfirmation(orderUUID uuid.UUID) error {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		return err
+	}
+	if order.CustomerEmail == nil && order.CustomerPhone == nil {
+		return nil
+	}
+
+	claimed, err := s.orderRepo.MarkConfirmationSent(order.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return err
+	}
+
+	locale := s.formatter.Locale()
+	total := s.formatter.WithCurrency(currency.OrderCurrency(order.Currency)).Money(order.Total)
+	trackingURL := s.trackingURL(order)
+
+	var errs []error
+	if order.CustomerEmail != nil {
+		errs = append(errs, s.emailTemplateService.Send(models.EmailTemplateOrderConfirmation, locale, *order.CustomerEmail, map[string]string{
+			"customer_name": order.CustomerName,
+			"order_number":  order.OrderNumber,
+			"total":         total,
+			"order_url":     trackingURL,
+		}))
+	}
+	if order.CustomerPhone != nil {
+		text := fmt.Sprintf("Thanks for your Matchaciee order %s (%s). Track it here: %s", order.OrderNumber, total, trackingURL)
+		if locale == utils.LocaleID {
+			text = fmt.Sprintf("Terima kasih atas pesanan Matchaciee %s (%s). Lacak pesanan Anda di sini: %s", order.OrderNumber, total, trackingURL)
+		}
+		errs = append(errs, s.whatsApp.Send(*order.CustomerPhone, text))
+	}
+	return errors.Join(errs...)
+}
+
+// SendReceipt sends the receipt of a completed, paid order to the customer by
+// email, and by WhatsApp to guests who left a number. Orders without a
+// contact, unpaid orders and orders whose receipt was already sent are
+// skipped, as is everything while receipt emails are off.
+func (s *notificationService) SendReceipt(orderUUID uuid.UUID) error {
+	settings, err := s.settingsService.GetNotificationSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.EmailReceipts {
+		return nil
+	}
+
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		return err
+	}
+	if order.Status != models.OrderStatusCompleted || order.ReceiptSentAt != nil {
+		return nil
+	}
+
+	contact, err := s.receiptContact(order)
+	if err != nil || (contact.email == "" && contact.phone == "") {
+		return err
+	}
+
+	settled, err := s.isSettled(order.ID)
+	if err != nil || !settled {
+		return err
+	}
+
+	claimed, err := s.orderRepo.MarkReceiptSent(order.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	receiptSettings, err := s.settingsService.GetReceiptSettings()
+	if err != nil {
+		return err
+	}
+	receipt, err := s.receiptService.Render(order, *receiptSettings, ReceiptFormatText)
+	if err != nil {
+		return err
+	}
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if contact.email != "" {
+		errs = append(errs, s.emailTemplateService.Send(models.EmailTemplateOrderReceipt, contact.locale, contact.email, map[string]string{
+			"customer_name": order.CustomerName,
+			"order_number":  order.OrderNumber,
+			"total":         s.formatter.ForLocale(contact.locale).WithCurrency(currency.OrderCurrency(order.Currency)).Money(order.Total),
+			"receipt":       string(receipt.Body),
+		}))
+	}
+	if contact.phone != "" {
+		heading := fmt.Sprintf("Receipt for your Matchaciee order %s", order.OrderNumber)
+		if s.formatter.Locale() == utils.LocaleID {
+			heading = fmt.Sprintf("Struk pesanan Matchaciee %s", order.OrderNumber)
+		}
+		errs = append(errs, s.whatsApp.Send(contact.phone, heading+"\n\n"+string(receipt.Body)))
+	}
+	return errors.Join(errs...)
+}
+
+// SendOrderReady tells the customer their order is ready for pickup, once,
+// while ready notifications are on
+func (s *notificationService) SendOrderReady(orderUUID uuid.UUID) error {
+	settings, err := s.settingsService.GetNotificationSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.ReadyNotifications {
+		return nil
+	}
+
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		return err
+	}
+	if order.Status != models.OrderStatusReady || order.ReadyNotifiedAt != nil {
+		return nil
+	}
+
+	recipient, err := s.readyRecipient(order)
+	if err != nil || recipient.to == "" {
+		return err
+	}
+	send, ok := s.readyChannels[recipient.channel]
+	if !ok {
+		return fmt.Errorf("no sender for notification channel %q", recipient.channel)
+	}
+
+	claimed, err := s.orderRepo.MarkReadyNotified(order.ID)
+	if err != nil || !claimed {
+		return err
+	}
+	return send(order, recipient.to, recipient.locale)
+}
+
+type readyRecipient struct {
+	channel string
+	to      string
+	locale  string
+}
+
+// readyRecipient picks where the ready notice goes. Members get it on their
+// preferred channel, by email when they chose WhatsApp without a phone number.
+// Guests get it by WhatsApp when they left a number and by email otherwise.
+func (s *notificationService) readyRecipient(order *models.Order) (readyRecipient, error) {
+	if order.UserID != nil {
+		user, err := s.userRepo.FindByID(*order.UserID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return readyRecipient{}, nil
+			}
+			return readyRecipient{}, err
+		}
+		if user.PreferredChannel() == models.NotifyViaWhatsApp && user.Phone != nil {
+			return readyRecipient{channel: models.NotifyViaWhatsApp, to: *user.Phone, locale: user.PreferredLocale()}, nil
+		}
+		return readyRecipient{channel: models.NotifyViaEmail, to: user.Email, locale: user.PreferredLocale()}, nil
+	}
+
+	if order.CustomerPhone != nil {
+		return readyRecipient{channel: models.NotifyViaWhatsApp, to: *order.CustomerPhone}, nil
+	}
+	if order.CustomerEmail != nil {
+		return readyRecipient{channel: models.NotifyViaEmail, to: *order.CustomerEmail}, nil
+	}
+	return readyRecipient{}, nil
+}
+
+func (s *notificationService) sendReadyEmail(order *models.Order, to, locale string) error {
+	return s.emailTemplateService.Send(models.EmailTemplateOrderReady, locale, to, map[string]string{
+		"customer_name": order.CustomerName,
+		"order_number":  order.OrderNumber,
+	})
+}
+
+func (s *notificationService) sendReadyWhatsApp(order *models.Order, to, locale string) error {
+	text := fmt.Sprintf("Your Matchaciee order %s is ready for pickup!", order.OrderNumber)
+	if s.formatter.ForLocale(locale).Locale() == utils.LocaleID {
+		text = fmt.Sprintf("Pesanan Matchaciee %s Anda sudah siap diambil!", order.OrderNumber)
+	}
+	return s.whatsApp.Send(to, text)
+}
+
+type receiptContact struct {
+	email  string
+	phone  string
+	locale string
+}
+
+// receiptContact returns where the receipt goes: the member's account email,
+// or the contact a guest left at checkout
+func (s *notificationService) receiptContact(order *models.Order) (receiptContact, error) {
+	var contact receiptContact
+	if order.UserID != nil {
+		user, err := s.userRepo.FindByID(*order.UserID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return contact, nil
+			}
+			return contact, err
+		}
+		contact.email = user.Email
+		contact.locale = user.PreferredLocale()
+		return contact, nil
+	}
+
+	if order.CustomerEmail != nil {
+		contact.email = *order.CustomerEmail
+	}
+	if order.CustomerPhone != nil {
+		contact.phone = *order.CustomerPhone
+	}
+	return contact, nil
+}
+
+// trackingURL links to the order's tracking page in the web app
+func (s *notificationService) trackingURL(order *models.Order) string {
+	return s.frontendURL + "/orders/track/" + order.UUID.String()
+}
+
+func (s *notificationService) isSettled(orderID uint) (bool, error) {
+	payments, err := s.paymentRepo.FindByOrderID(orderID)
+	if err != nil {
+		return false, err
+	}
+	for _, payment := range payments {
+		if payment.TransactionStatus != nil && *payment.TransactionStatus == models.TransactionStatusSettlement {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/google/uuid"
+)
+
+// OrdersTopic carries events for every order, for staff screens
+const OrdersTopic = "orders"
+
+const (
+	OrderEventSnapshot      = "order.snapshot"
+	OrderEventCreated       = "order.created"
+	OrderEventStatusChanged = "order.status_changed"
+	OrderEventClaimed       = "order.claimed"
+	OrderEventItemsUpdated  = "order.items_updated"
+	OrderEventPriority      = "order.priority_changed"
+)
+
+// OrderEvent is pushed to realtime clients following an order
+type OrderEvent struct {
+	Type           string             `json:"type"`
+	OrderID        uuid.UUID          `json:"order_id"`
+	OrderNumber    string             `json:"order_number"`
+	CustomerName   string             `json:"customer_name,omitempty"`
+	OrderSource    models.OrderSource `json:"order_source,omitempty"`
+	Status         models.OrderStatus `json:"status"`
+	PreviousStatus models.OrderStatus `json:"previous_status,omitempty"`
+	Priority       models.Priority    `json:"priority,omitempty"`
+	AssignedTo     *StaffSummary      `json:"assigned_to,omitempty"`
+	OccurredAt     string             `json:"occurred_at"`
+}
+
+// OrderTopic is the realtime topic carrying events for a single order
+func OrderTopic(orderUUID uuid.UUID) string {
+	return "orders:" + orderUUID.String()
+}
+
+// publishOrderEvent notifies the order's own subscribers and the staff feed.
+// Only the staff feed learns who is preparing the order. Delivery is best
+// effort, so a failure is logged rather than failing the order update.
+func publishOrderEvent(events realtime.Broker, logger *slog.Logger, eventType string, order *models.Order, previous models.OrderStatus) {
+	event := OrderEvent{
+		Type:           eventType,
+		OrderID:        order.UUID,
+		OrderNumber:    order.OrderNumber,
+		CustomerName:   order.CustomerName,
+		OrderSource:    order.OrderSource,
+		Status:         order.Status,
+		PreviousStatus: previous,
+		Priority:       order.Priority,
+		OccurredAt:     time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	}
+	staffEvent := event
+	staffEvent.AssignedTo = toStaffSummary(order.AssignedTo)
+
+	publish := func(topic string, payload OrderEvent) {
+		if err := events.Publish(context.Background(), topic, payload); err != nil {
+			logger.Error("Failed to publish order event", "event", eventType, logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		}
+	}
+	publish(OrderTopic(order.UUID), event)
+	publish(OrdersTopic, staffEvent)
+}
@@ -1,12 +1,22 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/carllix/matchaciee-backend/internal/logging"
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
 )
@@ -18,14 +28,146 @@ var (
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
 	ErrProductNotCustomizable  = errors.New("product is not customizable")
 	ErrInvalidCustomization    = errors.New("customization does not belong to product")
+	ErrInsufficientStock       = errors.New("insufficient stock")
+	ErrInvalidOrderSource      = errors.New("invalid order source")
+	ErrOrderNotClaimable       = errors.New("only pending or preparing orders can be claimed")
+	ErrOrderDayClosed          = errors.New("order's business day is closed")
+	ErrPriorityNotEditable     = errors.New("priority can only be changed before the order is ready")
+	ErrOrderAlreadyClaimed     = errors.New("order already claimed by another staff member")
+	ErrOrderAccessDenied       = errors.New("order belongs to another user")
+	ErrNothingToReorder        = errors.New("none of the order items can be reordered")
+	ErrOrderNotEditable        = errors.New("order can no longer be edited")
+	ErrPromoCodeInvalid        = errors.New("invalid or expired promo code")
+	ErrPromoCodeUsageLimit     = errors.New("promo code usage limit reached")
+	ErrPromoMinSpendNotMet     = errors.New("order does not meet the promo minimum spend")
+	ErrPromoNotApplicable      = errors.New("promo code does not apply to these items")
+	ErrVoucherInvalid          = errors.New("invalid, used or expired voucher")
+	ErrVoucherMinSpendNotMet   = errors.New("order does not meet the voucher minimum spend")
+	ErrVoucherNoQualifyingItem = errors.New("order has no item the voucher covers")
+	ErrInvalidTable            = errors.New("table does not exist or is not taking orders")
+	ErrTableOrderNotDineIn     = errors.New("table orders must be dine-in")
+	ErrPreordersDisabled       = errors.New("pre-orders are not accepted")
+	ErrInvalidScheduledTime    = errors.New("scheduled time is outside the pre-order window")
+	ErrTimeslotFull            = errors.New("pickup timeslot is fully booked")
+	ErrInvalidPickupCode       = errors.New("invalid pickup code")
+	ErrOrderNotReadyForPickup  = errors.New("order is not ready for pickup")
+	ErrOrderAlreadyPickedUp    = errors.New("order was already picked up")
+	ErrShareTokenInvalid       = errors.New("status link is invalid or has expired")
 )
 
+// TimeslotFullError is returned when the pickup timeslot of a new order is at
+// capacity. It matches ErrTimeslotFull and carries the next timeslots that
+// can still take the order.
+type TimeslotFullError struct {
+	NextAvailable []TimeslotResponse
+}
+
+func (e *TimeslotFullError) Error() string {
+	return ErrTimeslotFull.Error()
+}
+
+func (e *TimeslotFullError) Unwrap() error {
+	return ErrTimeslotFull
+}
+
+// TaxRate is applied to the order subtotal after discounts and service
+// charge, unless the order type has its own rate
+const TaxRate = 0.10
+
+// TimeslotLength is the span of one pickup timeslot. Capacity limits apply
+// per timeslot.
+const TimeslotLength = 15 * time.Minute
+
+// timeslotSuggestions is how many open timeslots are offered when the
+// requested one is full, looking at most timeslotSearchSlots ahead
+const (
+	timeslotSuggestions = 3
+	timeslotSearchSlots = 96
+)
+
+// shareTokenBytes is the entropy of a status page token, hex encoded to
+// fit orders.share_token
+const shareTokenBytes = 32
+
+// totalsTolerance absorbs rounding to the decimal(10,2) columns totals are
+// stored in
+const totalsTolerance = 0.01
+
+// OrderTypeCharges are the tax and service charge rates of one order type,
+// as fractions of the discounted subtotal, and the flat fee it adds on top
+// untaxed
+type OrderTypeCharges struct {
+	TaxRate           float64
+	ServiceChargeRate float64
+	DeliveryFee       float64
+}
+
+type OrderConfig struct {
+	ReservationTTL time.Duration
+	PaymentExpiry  time.Duration
+	// RushAfter is how long an order may be in preparation before it is
+	// rushed. Zero turns escalation off.
+	RushAfter time.Duration
+	// PreorderWindow is how far ahead an order may be scheduled. Zero turns
+	// pre-orders off.
+	PreorderWindow time.Duration
+	// PreorderLeadTime is how long before its scheduled time a pre-order is
+	// released to the pending queue
+	PreorderLeadTime time.Duration
+	// ShareTokenTTL is how long after an order is due its public status
+	// link keeps working, unless the order completes first
+	ShareTokenTTL time.Duration
+	// Charges holds the rates per order type. Types without an entry pay
+	// TaxRate and no service charge.
+	Charges map[models.OrderType]OrderTypeCharges
+	// Location is the store timezone, which promotion hours follow. Nil
+	// means the server's local time.
+	Location *time.Location
+}
+
+func (c OrderConfig) chargesFor(orderType models.OrderType) OrderTypeCharges {
+	if charges, ok := c.Charges[orderType]; ok {
+		return charges
+	}
+	return OrderTypeCharges{TaxRate: TaxRate}
+}
+
 type CreateOrderRequest struct {
 	CustomerName string                   `json:"customer_name" validate:"required,min=2,max=255"`
+	OrderType    models.OrderType         `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery"`
+	TableID      *uuid.UUID               `json:"table_id,omitempty"`
+	Notes        *string                  `json:"notes,omitempty" validate:"omitempty,max=500"`
+	PromoCode    *string                  `json:"promo_code,omitempty" validate:"omitempty,max=50"`
+	VoucherCode  *string                  `json:"voucher_code,omitempty" validate:"omitempty,max=20"`
+	TipAmount    float64                  `json:"tip_amount,omitempty" validate:"gte=0,lte=1000000"`
+	ScheduledFor *time.Time               `json:"scheduled_for,omitempty"`
+	Items        []CreateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// CreateGuestOrderRequest lets a guest leave an email address or WhatsApp
+// number to receive the tracking link and receipt
+type CreateGuestOrderRequest struct {
+	CreateOrderRequest
+	Email *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone *string `json:"phone,omitempty" validate:"omitempty,e164"`
+}
+
+// CreateKioskOrderRequest is an order placed on a self-service kiosk.
+// customer_name is optional; without it the order gets a queue number.
+type CreateKioskOrderRequest struct {
+	CustomerName *string                  `json:"customer_name,omitempty" validate:"omitempty,min=2,max=255"`
+	OrderType    models.OrderType         `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway delivery"`
 	Notes        *string                  `json:"notes,omitempty" validate:"omitempty,max=500"`
+	PromoCode    *string                  `json:"promo_code,omitempty" validate:"omitempty,max=50"`
 	Items        []CreateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
+// CreateStaffOrderRequest is used by staff to enter orders taken outside the app
+type CreateStaffOrderRequest struct {
+	CreateOrderRequest
+	OrderSource models.OrderSource `json:"order_source" validate:"required,oneof=kiosk partner phone catering"`
+}
+
 type CreateOrderItemRequest struct {
 	ProductID      uuid.UUID                `json:"product_id" validate:"required"`
 	Quantity       int                      `json:"quantity" validate:"required,min=1,max=100"`
@@ -42,20 +184,99 @@ type UpdateOrderStatusRequest struct {
 	Status models.OrderStatus `json:"status" validate:"required,oneof=pending preparing ready completed cancelled"`
 }
 
+type UpdateOrderPriorityRequest struct {
+	Priority models.Priority `json:"priority" validate:"required,oneof=normal rush"`
+}
+
+type BulkUpdateOrderStatusRequest struct {
+	OrderIDs []uuid.UUID        `json:"order_ids" validate:"required,min=1,max=100,dive,required"`
+	Status   models.OrderStatus `json:"status" validate:"required,oneof=completed cancelled"`
+}
+
+type BulkOrderStatusResult struct {
+	OrderID     uuid.UUID          `json:"order_id"`
+	OrderNumber string             `json:"order_number,omitempty"`
+	Success     bool               `json:"success"`
+	Status      models.OrderStatus `json:"status,omitempty"`
+	Error       string             `json:"error,omitempty"`
+}
+
+type BulkUpdateOrderStatusResponse struct {
+	Results   []BulkOrderStatusResult `json:"results"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+}
+
 type OrderResponse struct {
-	ID           uuid.UUID           `json:"id"`
-	OrderNumber  string              `json:"order_number"`
-	CustomerName string              `json:"customer_name"`
-	Status       models.OrderStatus  `json:"status"`
-	OrderSource  models.OrderSource  `json:"order_source"`
-	Subtotal     float64             `json:"subtotal"`
-	Tax          float64             `json:"tax"`
-	Total        float64             `json:"total"`
-	Notes        *string             `json:"notes,omitempty"`
-	Items        []OrderItemResponse `json:"items"`
-	User         *UserSummary        `json:"user,omitempty"`
-	CreatedAt    string              `json:"created_at"`
-	CompletedAt  *string             `json:"completed_at,omitempty"`
+	ID                     uuid.UUID                `json:"id"`
+	OrderNumber            string                   `json:"order_number"`
+	CustomerName           string                   `json:"customer_name"`
+	CustomerEmail          *string                  `json:"customer_email,omitempty"`
+	CustomerPhone          *string                  `json:"customer_phone,omitempty"`
+	Status                 models.OrderStatus       `json:"status"`
+	OrderSource            models.OrderSource       `json:"order_source"`
+	OrderType              models.OrderType         `json:"order_type"`
+	Priority               models.Priority          `json:"priority"`
+	TableNumber            *string                  `json:"table_number,omitempty"`
+	QueueNumber            *int                     `json:"queue_number,omitempty"`
+	Subtotal               float64                  `json:"subtotal"`
+	PromoCode              *string                  `json:"promo_code,omitempty"`
+	Discount               float64                  `json:"discount,omitempty"`
+	Promotions             []OrderPromotionResponse `json:"promotions,omitempty"`
+	ServiceCharge          float64                  `json:"service_charge,omitempty"`
+	Tax                    float64                  `json:"tax"`
+	Total                  float64                  `json:"total"`
+	Currency               string                   `json:"currency"`
+	TipAmount              float64                  `json:"tip_amount,omitempty"`
+	GiftCardAmount         float64                  `json:"gift_card_amount,omitempty"`
+	PointsRedeemed         int                      `json:"points_redeemed,omitempty"`
+	PointsAmount           float64                  `json:"points_amount,omitempty"`
+	DepositAmount          float64                  `json:"deposit_amount,omitempty"`
+	DepositPaidAt          *string                  `json:"deposit_paid_at,omitempty"`
+	PaymentDue             *float64                 `json:"payment_due,omitempty"`
+	PriceAdjustmentPercent float64                  `json:"price_adjustment_percent,omitempty"`
+	SourceFee              float64                  `json:"source_fee,omitempty"`
+	DeliveryFee            float64                  `json:"delivery_fee,omitempty"`
+	DeliveryDiscount       float64                  `json:"delivery_discount,omitempty"`
+	Notes                  *string                  `json:"notes,omitempty"`
+	Items                  []OrderItemResponse      `json:"items"`
+	User                   *UserSummary             `json:"user,omitempty"`
+	AssignedTo             *StaffSummary            `json:"assigned_to,omitempty"`
+	ScheduledFor           *string                  `json:"scheduled_for,omitempty"`
+	PaymentExpiresAt       *string                  `json:"payment_expires_at,omitempty"`
+	PickupCode             *string                  `json:"pickup_code,omitempty"`
+	ShareToken             *string                  `json:"share_token,omitempty"`
+	CreatedAt              string                   `json:"created_at"`
+	CompletedAt            *string                  `json:"completed_at,omitempty"`
+	FlaggedAt              *string                  `json:"flagged_at,omitempty"`
+}
+
+// OrderStatusResponse is the public status page of an order. It leaves out
+// the order ID, contact details and prices, since the link may be shared.
+type OrderStatusResponse struct {
+	OrderNumber  string             `json:"order_number"`
+	CustomerName string             `json:"customer_name"`
+	Status       models.OrderStatus `json:"status"`
+	OrderType    models.OrderType   `json:"order_type"`
+	TableNumber  *string            `json:"table_number,omitempty"`
+	QueueNumber  *int               `json:"queue_number,omitempty"`
+	Items        []OrderStatusItem  `json:"items"`
+	ScheduledFor *string            `json:"scheduled_for,omitempty"`
+	CreatedAt    string             `json:"created_at"`
+	ExpiresAt    string             `json:"expires_at"`
+}
+
+type OrderStatusItem struct {
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+}
+
+// OrderPromotionResponse is a promotion applied to an order, whether its code
+// was entered or it applied automatically
+type OrderPromotionResponse struct {
+	Code     string  `json:"code"`
+	Name     *string `json:"name,omitempty"`
+	Discount float64 `json:"discount"`
 }
 
 type OrderItemResponse struct {
@@ -74,6 +295,146 @@ type UserSummary struct {
 	Email    string    `json:"email"`
 }
 
+// KitchenQueueItem is an order item as the preparation screen shows it, with
+// customizations flattened to "Type: Option" lines
+type KitchenQueueItem struct {
+	ProductName    string   `json:"product_name"`
+	Quantity       int      `json:"quantity"`
+	Customizations []string `json:"customizations,omitempty"`
+	Notes          *string  `json:"notes,omitempty"`
+}
+
+type KitchenQueueOrder struct {
+	ID             uuid.UUID          `json:"id"`
+	OrderNumber    string             `json:"order_number"`
+	CustomerName   string             `json:"customer_name"`
+	OrderSource    models.OrderSource `json:"order_source"`
+	OrderType      models.OrderType   `json:"order_type"`
+	TableNumber    *string            `json:"table_number,omitempty"`
+	QueueNumber    *int               `json:"queue_number,omitempty"`
+	Status         models.OrderStatus `json:"status"`
+	Priority       models.Priority    `json:"priority"`
+	Notes          *string            `json:"notes,omitempty"`
+	ScheduledFor   *string            `json:"scheduled_for,omitempty"`
+	AssignedTo     *StaffSummary      `json:"assigned_to,omitempty"`
+	Items          []KitchenQueueItem `json:"items"`
+	CreatedAt      string             `json:"created_at"`
+	ElapsedSeconds int64              `json:"elapsed_seconds"`
+}
+
+// KitchenQueueResponse groups active orders by status, rush orders first and
+// then oldest first
+type KitchenQueueResponse struct {
+	Pending     []KitchenQueueOrder `json:"pending"`
+	Preparing   []KitchenQueueOrder `json:"preparing"`
+	Ready       []KitchenQueueOrder `json:"ready"`
+	GeneratedAt string              `json:"generated_at"`
+}
+
+// StaffSummary identifies the staff member handling an order without
+// exposing their contact details
+type StaffSummary struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+}
+
+type OrderPreviewItem struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	Subtotal    float64   `json:"subtotal"`
+}
+
+// OrderPreviewResponse is what an order would cost if placed now
+type OrderPreviewResponse struct {
+	Items                  []OrderPreviewItem `json:"items"`
+	OrderType              models.OrderType   `json:"order_type"`
+	Subtotal               float64            `json:"subtotal"`
+	ServiceCharge          float64            `json:"service_charge,omitempty"`
+	Tax                    float64            `json:"tax"`
+	Total                  float64            `json:"total"`
+	Currency               string             `json:"currency"`
+	PriceAdjustmentPercent float64            `json:"price_adjustment_percent,omitempty"`
+	SourceFee              float64            `json:"source_fee,omitempty"`
+	DeliveryFee            float64            `json:"delivery_fee,omitempty"`
+}
+
+// Reasons a past item could not be added to a reorder
+const (
+	ReorderSkipRemoved                  = "removed"
+	ReorderSkipUnavailable              = "unavailable"
+	ReorderSkipCustomizationUnavailable = "customization_unavailable"
+	ReorderSkipInsufficientStock        = "insufficient_stock"
+)
+
+type ReorderSkippedItem struct {
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+	Reason      string `json:"reason"`
+}
+
+// ReorderResponse is the new order with the items that could not be re-added.
+// PreviousTotal lets clients point out price changes since the original order.
+type ReorderResponse struct {
+	Order         *OrderResponse       `json:"order"`
+	SkippedItems  []ReorderSkippedItem `json:"skipped_items"`
+	PreviousTotal float64              `json:"previous_total"`
+}
+
+// TotalsDiscrepancy is a stored amount that differs from its recomputed value.
+// ItemID is set for item subtotals.
+type TotalsDiscrepancy struct {
+	Field    string     `json:"field"`
+	ItemID   *uuid.UUID `json:"item_id,omitempty"`
+	Stored   float64    `json:"stored"`
+	Expected float64    `json:"expected"`
+}
+
+type OrderTotals struct {
+	Subtotal         float64 `json:"subtotal"`
+	Discount         float64 `json:"discount"`
+	ServiceCharge    float64 `json:"service_charge"`
+	Tax              float64 `json:"tax"`
+	SourceFee        float64 `json:"source_fee"`
+	DeliveryFee      float64 `json:"delivery_fee"`
+	DeliveryDiscount float64 `json:"delivery_discount"`
+	Total            float64 `json:"total"`
+}
+
+type VerifyTotalsResponse struct {
+	OrderID       uuid.UUID           `json:"order_id"`
+	OrderNumber   string              `json:"order_number"`
+	Stored        OrderTotals         `json:"stored"`
+	Recomputed    OrderTotals         `json:"recomputed"`
+	Consistent    bool                `json:"consistent"`
+	Discrepancies []TotalsDiscrepancy `json:"discrepancies"`
+}
+
+// LookupOrderRequest finds a guest order again without its tracking link. The
+// phone number or the name given at checkout proves the caller placed it.
+type LookupOrderRequest struct {
+	OrderNumber  string `json:"order_number" validate:"required,max=20"`
+	Phone        string `json:"phone,omitempty" validate:"required_without=CustomerName,max=20"`
+	CustomerName string `json:"customer_name,omitempty" validate:"required_without=Phone,max=255"`
+}
+
+// VerifyPickupRequest carries the pickup code scanned from the customer's
+// tracking page or receipt
+type VerifyPickupRequest struct {
+	Code string `json:"code" validate:"required,max=64"`
+}
+
+// UpdateOrderItemsRequest replaces every item of a pending order
+type UpdateOrderItemsRequest struct {
+	Items []CreateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type TimeslotResponse struct {
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+}
+
 type OrderListResponse struct {
 	Orders []OrderResponse `json:"orders"`
 	Total  int64           `json:"total"`
@@ -83,29 +444,80 @@ type OrderListResponse struct {
 
 type OrderService interface {
 	CreateOrder(userUUID uuid.UUID, req CreateOrderRequest) (*OrderResponse, error)
-	CreateGuestOrder(req CreateOrderRequest) (*OrderResponse, error)
+	CreateGuestOrder(req CreateGuestOrderRequest) (*OrderResponse, error)
+	CreateStaffOrder(req CreateStaffOrderRequest) (*OrderResponse, error)
+	CreateKioskOrder(kiosk *models.Kiosk, req CreateKioskOrderRequest) (*OrderResponse, error)
+	Reorder(orderUUID, userUUID uuid.UUID) (*ReorderResponse, error)
+	UpdateItems(orderUUID uuid.UUID, memberUUID *uuid.UUID, req UpdateOrderItemsRequest) (*OrderResponse, error)
+	PreviewOrder(source models.OrderSource, orderType models.OrderType, items []CreateOrderItemRequest) (*OrderPreviewResponse, error)
 	GetByUUID(orderUUID uuid.UUID) (*OrderResponse, error)
 	GetByOrderNumber(orderNumber string) (*OrderResponse, error)
+	LookupGuestOrder(req LookupOrderRequest) (*OrderResponse, error)
+	GetStatusByShareToken(token string) (*OrderStatusResponse, error)
 	GetMyOrders(userUUID uuid.UUID, page, limit int) (*OrderListResponse, error)
 	GetAllOrders(filters repositories.OrderFilters, page, limit int) (*OrderListResponse, error)
+	GetKitchenQueue() (*KitchenQueueResponse, error)
 	UpdateOrderStatus(orderUUID uuid.UUID, status models.OrderStatus) (*OrderResponse, error)
+	UpdateOrderStatusAs(orderUUID uuid.UUID, status models.OrderStatus, staffUUID uuid.UUID, role models.UserRole) (*OrderResponse, error)
+	VerifyPickup(req VerifyPickupRequest) (*OrderResponse, error)
+	UpdatePriority(orderUUID uuid.UUID, priority models.Priority) (*OrderResponse, error)
+	RushDelayedOrders() (int, error)
+	ReleaseScheduledOrders() (int, error)
+	ClaimOrder(orderUUID, staffUUID uuid.UUID) (*OrderResponse, error)
+	VerifyTotals(orderUUID uuid.UUID) (*VerifyTotalsResponse, error)
+	BulkUpdateOrderStatus(req BulkUpdateOrderStatusRequest) (*BulkUpdateOrderStatusResponse, error)
+	SubscribeToOrder(orderUUID uuid.UUID) *realtime.Subscription
+	SubscribeToOrders() *realtime.Subscription
 }
 
 type orderService struct {
-	orderRepo   repositories.OrderRepository
-	productRepo repositories.ProductRepository
-	userRepo    repositories.UserRepository
+	orderRepo       repositories.OrderRepository
+	productRepo     repositories.ProductRepository
+	userRepo        repositories.UserRepository
+	reservationRepo repositories.StockReservationRepository
+	pricingRepo     repositories.SourcePricingRepository
+	promotionRepo   repositories.PromotionRepository
+	voucherRepo     repositories.VoucherRepository
+	loyaltyRepo     repositories.LoyaltyRepository
+	tableRepo       repositories.DiningTableRepository
+	settingsService SettingsService
+	pickupCodes     *utils.PickupCodeSigner
+	events          realtime.Broker
+	logger          *slog.Logger
+	config          OrderConfig
 }
 
 func NewOrderService(
 	orderRepo repositories.OrderRepository,
 	productRepo repositories.ProductRepository,
 	userRepo repositories.UserRepository,
+	reservationRepo repositories.StockReservationRepository,
+	pricingRepo repositories.SourcePricingRepository,
+	promotionRepo repositories.PromotionRepository,
+	voucherRepo repositories.VoucherRepository,
+	loyaltyRepo repositories.LoyaltyRepository,
+	tableRepo repositories.DiningTableRepository,
+	settingsService SettingsService,
+	pickupCodes *utils.PickupCodeSigner,
+	events realtime.Broker,
+	logger *slog.Logger,
+	config OrderConfig,
 ) OrderService {
 	return &orderService{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
-		userRepo:    userRepo,
+		orderRepo:       orderRepo,
+		productRepo:     productRepo,
+		userRepo:        userRepo,
+		reservationRepo: reservationRepo,
+		pricingRepo:     pricingRepo,
+		promotionRepo:   promotionRepo,
+		voucherRepo:     voucherRepo,
+		loyaltyRepo:     loyaltyRepo,
+		tableRepo:       tableRepo,
+		settingsService: settingsService,
+		pickupCodes:     pickupCodes,
+		events:          events,
+		logger:          logger,
+		config:          config,
 	}
 }
 
@@ -119,111 +531,1506 @@ func (s *orderService) CreateOrder(userUUID uuid.UUID, req CreateOrderRequest) (
 		return nil, err
 	}
 
+	return s.placeOrder(&user.ID, models.OrderSourceMember, req, orderPlacement{})
+}
+
+func (s *orderService) CreateGuestOrder(req CreateGuestOrderRequest) (*OrderResponse, error) {
+	placement := orderPlacement{
+		email: normalizeContact(req.Email),
+		phone: normalizeContact(req.Phone),
+	}
+	if placement.email != nil {
+		email := strings.ToLower(*placement.email)
+		placement.email = &email
+	}
+	return s.placeOrder(nil, models.OrderSourceGuest, req.CreateOrderRequest, placement)
+}
+
+func (s *orderService) CreateStaffOrder(req CreateStaffOrderRequest) (*OrderResponse, error) {
+	return s.placeOrder(nil, req.OrderSource, req.CreateOrderRequest, orderPlacement{})
+}
+
+// CreateKioskOrder places an order from a self-service kiosk. Customers who
+// leave no name are called by a queue number, which restarts every day.
+func (s *orderService) CreateKioskOrder(kiosk *models.Kiosk, req CreateKioskOrderRequest) (*OrderResponse, error) {
+	name := normalizeContact(req.CustomerName)
+	placement := orderPlacement{kioskID: &kiosk.ID, queued: name == nil}
+
+	order := CreateOrderRequest{
+		OrderType: req.OrderType,
+		Notes:     req.Notes,
+		PromoCode: req.PromoCode,
+		Items:     req.Items,
+	}
+	if name != nil {
+		order.CustomerName = *name
+	}
+	return s.placeOrder(nil, models.OrderSourceKiosk, order, placement)
+}
+
+// Reorder places a new pending order with the items of one of the member's
+// past orders at current prices. Items whose product was removed, is
+// unavailable, lost one of the chosen options or is out of stock are skipped
+// and reported instead of failing the whole order.
+func (s *orderService) Reorder(orderUUID, userUUID uuid.UUID) (*ReorderResponse, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	original, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if original.UserID == nil || *original.UserID != user.ID {
+		return nil, ErrOrderAccessDenied
+	}
+
+	items := make([]CreateOrderItemRequest, 0, len(original.Items))
+	skipped := make([]ReorderSkippedItem, 0)
+	requested := make(map[uint]int)
+
+	for _, item := range original.Items {
+		request, reason, err := s.rebuildOrderItem(item, requested)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			skipped = append(skipped, ReorderSkippedItem{
+				ProductName: item.ProductName,
+				Quantity:    item.Quantity,
+				Reason:      reason,
+			})
+			continue
+		}
+		items = append(items, *request)
+	}
+
+	if len(items) == 0 {
+		return nil, ErrNothingToReorder
+	}
+
+	order, err := s.placeOrder(&user.ID, models.OrderSourceMember, CreateOrderRequest{
+		CustomerName: original.CustomerName,
+		OrderType:    original.OrderType,
+		Notes:        original.Notes,
+		Items:        items,
+	}, orderPlacement{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReorderResponse{
+		Order:         order,
+		SkippedItems:  skipped,
+		PreviousTotal: original.Total,
+	}, nil
+}
+
+// rebuildOrderItem turns a past order item back into an item request, matching
+// its customization snapshot against the options the product offers today. It
+// returns a skip reason when the item cannot be ordered again.
+func (s *orderService) rebuildOrderItem(item models.OrderItem, requested map[uint]int) (*CreateOrderItemRequest, string, error) {
+	if item.ProductID == nil {
+		return nil, ReorderSkipRemoved, nil
+	}
+
+	product, err := s.productRepo.FindByID(*item.ProductID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProductNotFound) {
+			return nil, ReorderSkipRemoved, nil
+		}
+		return nil, "", err
+	}
+
+	if !product.IsAvailable || (product.Category != nil && product.Category.DeletedAt != nil) {
+		return nil, ReorderSkipUnavailable, nil
+	}
+
+	request := &CreateOrderItemRequest{
+		ProductID: product.UUID,
+		Quantity:  item.Quantity,
+		Notes:     item.Notes,
+	}
+
+	for _, chosen := range parseItemCustomizations(item.Customizations) {
+		var match *models.ProductCustomization
+		for i := range product.Customizations {
+			option := &product.Customizations[i]
+			if option.CustomizationType == chosen.CustomizationType && option.OptionName == chosen.OptionName {
+				match = option
+				break
+			}
+		}
+		if match == nil || !product.IsCustomizable {
+			return nil, ReorderSkipCustomizationUnavailable, nil
+		}
+		request.Customizations = append(request.Customizations, OrderItemCustomization{
+			CustomizationID: match.UUID,
+			OptionName:      match.OptionName,
+		})
+	}
+
+	if product.StockQuantity != nil {
+		available, err := s.reservationRepo.AvailableQuantity(product.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		if available < requested[product.ID]+item.Quantity {
+			return nil, ReorderSkipInsufficientStock, nil
+		}
+		requested[product.ID] += item.Quantity
+	}
+
+	return request, "", nil
+}
+
+// UpdateItems replaces the items of a pending order and recalculates its
+// totals at current prices. memberUUID is nil for staff, who may edit any
+// order. Once a payment has been started the order can no longer be edited.
+func (s *orderService) UpdateItems(orderUUID uuid.UUID, memberUUID *uuid.UUID, req UpdateOrderItemsRequest) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if memberUUID != nil {
+		user, err := s.userRepo.FindByUUID(*memberUUID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		if order.UserID == nil || *order.UserID != user.ID {
+			return nil, ErrOrderAccessDenied
+		}
+	}
+
+	if order.Status != models.OrderStatusPending {
+		return nil, ErrOrderNotEditable
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
+	}
+
+	// Units this order already holds are available to it again
+	reservations, err := s.reservationRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uint]int)
+	for _, reservation := range reservations {
+		if reservation.IsHolding() {
+			held[reservation.ProductID] += reservation.Quantity
+		}
+	}
+
+	priced, err := s.priceOrder(order.OrderSource, order.OrderType, req.Items, held)
+	if err != nil {
+		return nil, err
+	}
+
+	// The promotions applied at checkout stay applied. The new items must still
+	// qualify for an entered code, while automatic promotions they no longer
+	// qualify for drop off.
+	var code *models.Promotion
+	if order.PromotionID != nil {
+		code, err = s.promotionRepo.FindByID(*order.PromotionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var automatic []models.Promotion
+	for _, applied := range order.Promotions {
+		if applied.PromotionID == nil || (order.PromotionID != nil && *applied.PromotionID == *order.PromotionID) {
+			continue
+		}
+		promotion, err := s.promotionRepo.FindByID(*applied.PromotionID)
+		if errors.Is(err, repositories.ErrPromotionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		automatic = append(automatic, *promotion)
+	}
+	if err := s.applyPromotions(priced, req.Items, code, automatic); err != nil {
+		return nil, err
+	}
+	voucher, err := s.voucherRepo.FindByOrderID(order.ID)
+	if err != nil && !errors.Is(err, repositories.ErrVoucherNotFound) {
+		return nil, err
+	}
+	if voucher != nil {
+		if err := s.applyVoucher(priced, voucher); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := *order
+	updated := *order
+	updated.Subtotal = priced.subtotal
+	updated.Discount = priced.discount
+	updated.Promotions = priced.orderPromotions()
+	updated.ServiceCharge = priced.serviceCharge
+	updated.Tax = priced.tax
+	updated.TaxRate = priced.charges.TaxRate
+	updated.Total = priced.total
+	updated.PriceAdjustmentPercent = priced.adjustmentPercent
+	updated.SourceFee = priced.sourceFee
+	updated.DeliveryFee = priced.deliveryFee
+	updated.DeliveryDiscount = priced.deliveryDiscount
+	updated.DepositAmount = priced.deposit()
+
+	if err := s.orderRepo.ReplaceItems(&updated, priced.items); err != nil {
+		if errors.Is(err, repositories.ErrOrderNotEditable) {
+			return nil, ErrOrderNotEditable
+		}
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.replaceStockHolds(&updated, req.Items, priced.products); err != nil {
+		// Put the previous items back so the order matches the stock it holds
+		if restoreErr := s.orderRepo.ReplaceItems(&previous, restorableItems(previous.Items)); restoreErr != nil {
+			s.logger.Error("Failed to restore items after reservation failure", logging.OrderNumber(order.OrderNumber), logging.Err(restoreErr))
+		}
+		return nil, err
+	}
+
+	updatedOrder, err := s.orderRepo.FindByUUID(order.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishOrderEvent(s.events, s.logger, OrderEventItemsUpdated, updatedOrder, "")
+
+	return s.toOrderResponse(updatedOrder, memberUUID == nil), nil
+}
+
+// replaceStockHolds swaps the order's holds for ones matching its new items.
+// On failure the previous holds are kept.
+func (s *orderService) replaceStockHolds(
+	order *models.Order,
+	items []CreateOrderItemRequest,
+	products map[uuid.UUID]*models.Product,
+) error {
+	var reservations []repositories.ReservationItem
+	for _, item := range items {
+		product := products[item.ProductID]
+		if product.StockQuantity == nil {
+			continue
+		}
+		reservations = append(reservations, repositories.ReservationItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	err := s.reservationRepo.ReplaceForOrder(order.ID, reservations, time.Now().Add(s.config.ReservationTTL))
+	if errors.Is(err, repositories.ErrInsufficientStock) {
+		return ErrInsufficientStock
+	}
+	return err
+}
+
+// restorableItems copies saved items so they can be inserted again
+func restorableItems(items []models.OrderItem) []models.OrderItem {
+	restored := make([]models.OrderItem, len(items))
+	for i, item := range items {
+		item.ID = 0
+		item.Product = nil
+		item.Order = nil
+		restored[i] = item
+	}
+	return restored
+}
+
+// pricedOrder is an order request priced for a channel but not yet saved
+type pricedOrder struct {
+	products          map[uuid.UUID]*models.Product
+	items             []models.OrderItem
+	subtotal          float64
+	promotion         *models.Promotion
+	promotions        []appliedPromotion
+	voucher           *models.Voucher
+	voucherDiscount   float64
+	discount          float64
+	charges           OrderTypeCharges
+	serviceCharge     float64
+	tax               float64
+	total             float64
+	adjustmentPercent float64
+	sourceFee         float64
+	deliveryFee       float64
+	deliveryDiscount  float64
+	currency          CurrencySettings
+	deposits          DepositSettings
+}
+
+// applyCharges adds the service charge and tax on the discounted subtotal.
+// Tax is charged on the service charge as well. Both are rounded to the
+// currency so the total is an amount the gateway can charge. The delivery
+// fee, less what promotions waive of it, is added untaxed like the source
+// fee.
+func (p *pricedOrder) applyCharges() {
+	base := p.subtotal - p.discount
+	p.serviceCharge = p.currency.Round(base * p.charges.ServiceChargeRate)
+	p.tax = p.currency.Round((base + p.serviceCharge) * p.charges.TaxRate)
+	p.total = p.currency.Round(base + p.serviceCharge + p.tax + p.sourceFee + p.deliveryFee - p.deliveryDiscount)
+}
+
+// orderPromotions are the applied promotions and voucher as saved on the
+// order
+func (p *pricedOrder) orderPromotions() []models.OrderPromotion {
+	promotions := make([]models.OrderPromotion, 0, len(p.promotions)+1)
+	for _, applied := range p.promotions {
+		promotions = append(promotions, models.OrderPromotion{
+			PromotionID: &applied.promotion.ID,
+			Code:        applied.promotion.Code,
+			Name:        applied.promotion.Name,
+			Discount:    applied.discount,
+		})
+	}
+	if p.voucher != nil {
+		name := voucherLineName
+		promotions = append(promotions, models.OrderPromotion{
+			VoucherID: &p.voucher.ID,
+			Code:      p.voucher.Code,
+			Name:      &name,
+			Discount:  p.voucherDiscount,
+		})
+	}
+	return promotions
+}
+
+// deposit is what the order pays before the rest, or zero when it is paid in
+// one go
+func (p *pricedOrder) deposit() float64 {
+	return p.currency.Round(p.deposits.DepositFor(p.total))
+}
+
+// priceOrder validates and prices items. held is the stock already held for
+// the order being edited, which counts as available to it; nil for new orders.
+func (s *orderService) priceOrder(
+	source models.OrderSource,
+	orderType models.OrderType,
+	items []CreateOrderItemRequest,
+	held map[uint]int,
+) (*pricedOrder, error) {
 	// Validate and fetch all products
-	products, customizationsMap, err := s.validateAndFetchProducts(req.Items)
+	products, customizationsMap, err := s.validateAndFetchProducts(items, held)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve channel pricing so in-store prices stay unchanged
+	pricing, err := s.resolveSourcePricing(source)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := s.settingsService.GetDepositSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate totals and build order items
+	subtotal, orderItems := s.calculateOrderTotals(items, products, customizationsMap, pricing)
+	priced := &pricedOrder{
+		products: products,
+		items:    orderItems,
+		subtotal: subtotal,
+		charges:  s.config.chargesFor(orderType),
+		currency: *currency,
+		deposits: *deposits,
+	}
+	priced.deliveryFee = currency.Round(priced.charges.DeliveryFee)
+
+	if pricing != nil {
+		priced.adjustmentPercent = pricing.PriceAdjustmentPercent
+		priced.sourceFee = pricing.FlatFee
+	}
+
+	priced.applyCharges()
+	return priced, nil
+}
+
+// findRedeemablePromotion looks up a promo code and checks that it is live and
+// within its usage limits. Per-customer limits apply to members only, since
+// guests cannot be told apart.
+func (s *orderService) findRedeemablePromotion(code string, userID *uint) (*models.Promotion, error) {
+	promotion, err := s.promotionRepo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
 	if err != nil {
+		if errors.Is(err, repositories.ErrPromotionNotFound) {
+			return nil, ErrPromoCodeInvalid
+		}
 		return nil, err
 	}
+	if !promotion.IsRedeemableAt(s.storeTime()) {
+		return nil, ErrPromoCodeInvalid
+	}
+	if err := s.checkPromotionLimits(promotion, userID); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// findAutomaticPromotions returns the automatic promotions that are live and
+// within their usage limits for the customer
+func (s *orderService) findAutomaticPromotions(userID *uint) ([]models.Promotion, error) {
+	promotions, err := s.promotionRepo.FindAutomatic()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.storeTime()
+	live := make([]models.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if !promotion.IsRedeemableAt(now) {
+			continue
+		}
+		err := s.checkPromotionLimits(&promotion, userID)
+		if errors.Is(err, ErrPromoCodeUsageLimit) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		live = append(live, promotion)
+	}
+	return live, nil
+}
+
+// storeTime is the time on the store clock
+func (s *orderService) storeTime() time.Time {
+	if s.config.Location == nil {
+		return time.Now()
+	}
+	return time.Now().In(s.config.Location)
+}
+
+func (s *orderService) checkPromotionLimits(promotion *models.Promotion, userID *uint) error {
+	if promotion.UsageLimit != nil {
+		used, err := s.promotionRepo.CountRedemptions(promotion.ID, nil)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*promotion.UsageLimit) {
+			return ErrPromoCodeUsageLimit
+		}
+	}
+	if promotion.PerCustomerLimit != nil && userID != nil {
+		used, err := s.promotionRepo.CountRedemptions(promotion.ID, userID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*promotion.PerCustomerLimit) {
+			return ErrPromoCodeUsageLimit
+		}
+	}
+	return nil
+}
+
+// appliedPromotion is a promotion an order qualifies for and the discount it
+// gives
+type appliedPromotion struct {
+	promotion *models.Promotion
+	discount  float64
+}
+
+// applyPromotions discounts a priced order with the entered code, if any, and
+// the automatic promotions it qualifies for. An entered code always applies,
+// and automatic promotions only join it when both stack. Without a code the
+// order gets whichever is worth more: the stackable automatic promotions
+// together, or the best one that does not stack. Charges are recalculated on
+// the discounted subtotal.
+func (s *orderService) applyPromotions(
+	priced *pricedOrder,
+	items []CreateOrderItemRequest,
+	code *models.Promotion,
+	automatic []models.Promotion,
+) error {
+	var chosen []appliedPromotion
+	if code != nil {
+		discount, err := s.promotionDiscount(priced, items, code)
+		if err != nil {
+			return err
+		}
+		chosen = append(chosen, appliedPromotion{promotion: code, discount: discount})
+	}
+
+	if code == nil || code.Stackable {
+		var stacked []appliedPromotion
+		var stackedDiscount float64
+		var best *appliedPromotion
+		for i := range automatic {
+			promotion := &automatic[i]
+			if code != nil && (promotion.ID == code.ID || !promotion.Stackable) {
+				continue
+			}
+			discount, err := s.promotionDiscount(priced, items, promotion)
+			if errors.Is(err, ErrPromoMinSpendNotMet) || errors.Is(err, ErrPromoNotApplicable) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			applied := appliedPromotion{promotion: promotion, discount: discount}
+			if promotion.Stackable {
+				stacked = append(stacked, applied)
+				stackedDiscount += discount
+			} else if best == nil || discount > best.discount {
+				best = &applied
+			}
+		}
+
+		if best != nil && best.discount > stackedDiscount {
+			chosen = []appliedPromotion{*best}
+		} else {
+			chosen = append(chosen, stacked...)
+		}
+	}
+
+	priced.promotion = code
+	priced.promotions = nil
+	priced.discount = 0
+	priced.deliveryDiscount = 0
+	for _, applied := range chosen {
+		// Stacked discounts never take the subtotal or delivery fee below
+		// zero
+		waivesDelivery := applied.promotion.DiscountType == models.DiscountTypeFreeDelivery
+		if waivesDelivery {
+			applied.discount = math.Min(applied.discount, priced.deliveryFee-priced.deliveryDiscount)
+		} else {
+			applied.discount = math.Min(applied.discount, priced.subtotal-priced.discount)
+		}
+		if applied.discount <= 0 {
+			continue
+		}
+		priced.promotions = append(priced.promotions, applied)
+		if waivesDelivery {
+			priced.deliveryDiscount += applied.discount
+		} else {
+			priced.discount += applied.discount
+		}
+	}
+	priced.applyCharges()
+	return nil
+}
+
+// promotionDiscount is what the promotion takes off a priced order. The
+// minimum spend is checked against the whole subtotal, while the discount
+// only covers items in the promotion's scope. A free-delivery promotion
+// takes its share of the delivery fee instead, so it only applies to orders
+// that pay one.
+func (s *orderService) promotionDiscount(priced *pricedOrder, items []CreateOrderItemRequest, promotion *models.Promotion) (float64, error) {
+	if priced.subtotal < promotion.MinSpend {
+		return 0, ErrPromoMinSpendNotMet
+	}
+
+	eligible := priced.subtotal
+	if promotion.IsScoped() {
+		inScope, err := s.promotionScope(promotion)
+		if err != nil {
+			return 0, err
+		}
+		eligible = 0
+		for i, item := range items {
+			if inScope(priced.products[item.ProductID]) {
+				eligible += priced.items[i].Subtotal
+			}
+		}
+		if eligible == 0 {
+			return 0, ErrPromoNotApplicable
+		}
+	}
+
+	var discount float64
+	switch promotion.DiscountType {
+	case models.DiscountTypePercentage:
+		discount = eligible * promotion.DiscountValue / 100
+		if promotion.MaxDiscount != nil && discount > *promotion.MaxDiscount {
+			discount = *promotion.MaxDiscount
+		}
+	case models.DiscountTypeFixed:
+		discount = math.Min(promotion.DiscountValue, eligible)
+	case models.DiscountTypeFreeDelivery:
+		if priced.deliveryFee == 0 {
+			return 0, ErrPromoNotApplicable
+		}
+		discount = priced.deliveryFee * promotion.DiscountValue / 100
+		if promotion.MaxDiscount != nil && discount > *promotion.MaxDiscount {
+			discount = *promotion.MaxDiscount
+		}
+	}
+
+	return priced.currency.Round(discount), nil
+}
+
+// promotionScope returns whether a product is covered by the promotion,
+// directly or through its category or a parent category
+func (s *orderService) promotionScope(promotion *models.Promotion) (func(*models.Product) bool, error) {
+	products := make(map[uint]bool, len(promotion.Products))
+	for _, product := range promotion.Products {
+		products[product.ID] = true
+	}
+
+	categories := make(map[uint]bool)
+	if len(promotion.Categories) > 0 {
+		ids, err := s.promotionRepo.ScopeCategoryIDs(promotion.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			categories[id] = true
+		}
+	}
+
+	return func(product *models.Product) bool {
+		return products[product.ID] || (product.CategoryID != nil && categories[*product.CategoryID])
+	}, nil
+}
+
+// voucherLineName labels a voucher among the order's promotions
+const voucherLineName = "Voucher"
+
+// findRedeemableVoucher looks up a voucher code the member entered. Vouchers
+// belong to one member, so guests and other members cannot use it.
+func (s *orderService) findRedeemableVoucher(code string, userID *uint) (*models.Voucher, error) {
+	if userID == nil {
+		return nil, ErrVoucherInvalid
+	}
+	voucher, err := s.voucherRepo.FindByCode(normalizeVoucherCode(code))
+	if err != nil {
+		if errors.Is(err, repositories.ErrVoucherNotFound) {
+			return nil, ErrVoucherInvalid
+		}
+		return nil, err
+	}
+	if voucher.UserID != *userID || !voucher.IsRedeemableAt(time.Now()) {
+		return nil, ErrVoucherInvalid
+	}
+	return voucher, nil
+}
+
+// applyVoucher takes the voucher off what is left of the subtotal after
+// promotions and recalculates the charges. The minimum spend is checked
+// against the whole subtotal. A voucher worth nothing on the order is left
+// off it, so it stays available.
+func (s *orderService) applyVoucher(priced *pricedOrder, voucher *models.Voucher) error {
+	if priced.subtotal < voucher.MinSpend {
+		return ErrVoucherMinSpendNotMet
+	}
+
+	remaining := priced.subtotal - priced.discount
+	var discount float64
+	switch voucher.DiscountType {
+	case models.DiscountTypePercentage:
+		discount = remaining * voucher.DiscountValue / 100
+		if voucher.MaxDiscount != nil && discount > *voucher.MaxDiscount {
+			discount = *voucher.MaxDiscount
+		}
+	case models.DiscountTypeFixed:
+		discount = voucher.DiscountValue
+	case models.DiscountTypeFreeItem:
+		dearest, err := s.dearestFreeItem(priced, voucher)
+		if err != nil {
+			return err
+		}
+		if dearest == 0 {
+			return ErrVoucherNoQualifyingItem
+		}
+		discount = dearest * voucher.DiscountValue / 100
+		if voucher.MaxDiscount != nil && discount > *voucher.MaxDiscount {
+			discount = *voucher.MaxDiscount
+		}
+	}
+	discount = math.Min(priced.currency.Round(discount), remaining)
+	if discount <= 0 {
+		return nil
+	}
+
+	priced.voucher = voucher
+	priced.voucherDiscount = discount
+	priced.discount += discount
+	priced.applyCharges()
+	return nil
+}
+
+// dearestFreeItem is the unit price of the dearest item on the order the
+// free-item voucher covers, or zero when there is none. A voucher from the
+// rewards catalog covers its category; free-drink rewards from the stamp card
+// cover whatever the card currently counts.
+func (s *orderService) dearestFreeItem(priced *pricedOrder, voucher *models.Voucher) (float64, error) {
+	qualifies, err := s.freeItemScope(voucher)
+	if err != nil {
+		return 0, err
+	}
+
+	products := make(map[uint]*models.Product, len(priced.products))
+	for _, product := range priced.products {
+		products[product.ID] = product
+	}
+	var dearest float64
+	for _, item := range priced.items {
+		if item.ProductID == nil || item.Quantity <= 0 {
+			continue
+		}
+		if product, ok := products[*item.ProductID]; !ok || !qualifies(product) {
+			continue
+		}
+		dearest = math.Max(dearest, item.Subtotal/float64(item.Quantity))
+	}
+	return dearest, nil
+}
+
+// freeItemScope returns whether a free-item voucher covers a product
+func (s *orderService) freeItemScope(voucher *models.Voucher) (func(*models.Product) bool, error) {
+	if voucher.CategoryID == nil {
+		settings, err := s.settingsService.GetStampSettings()
+		if err != nil {
+			return nil, err
+		}
+		return s.stampScope(settings)
+	}
+
+	ids, err := s.voucherRepo.CategoryTreeIDs(*voucher.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	return inCategories(ids), nil
+}
+
+// stampScope returns whether a product counts toward the stamp card
+func (s *orderService) stampScope(settings *StampSettings) (func(*models.Product) bool, error) {
+	if settings.CategoryID == nil {
+		return func(*models.Product) bool { return true }, nil
+	}
+	ids, err := s.loyaltyRepo.StampCategoryIDs(*settings.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	return inCategories(ids), nil
+}
+
+// inCategories returns whether a product belongs to one of the categories
+func inCategories(ids []uint) func(*models.Product) bool {
+	categories := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		categories[id] = true
+	}
+	return func(product *models.Product) bool {
+		return product.CategoryID != nil && categories[*product.CategoryID]
+	}
+}
+
+// PreviewOrder prices items for a channel and order type exactly as placing
+// the order would, without saving anything or holding stock
+func (s *orderService) PreviewOrder(source models.OrderSource, orderType models.OrderType, items []CreateOrderItemRequest) (*OrderPreviewResponse, error) {
+	orderType = resolveOrderType(orderType)
+	priced, err := s.priceOrder(source, orderType, items, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	previewItems := make([]OrderPreviewItem, len(priced.items))
+	for i, item := range priced.items {
+		previewItems[i] = OrderPreviewItem{
+			ProductID:   items[i].ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Subtotal:    item.Subtotal,
+		}
+	}
+
+	return &OrderPreviewResponse{
+		Items:                  previewItems,
+		OrderType:              orderType,
+		Subtotal:               priced.subtotal,
+		ServiceCharge:          priced.serviceCharge,
+		Tax:                    priced.tax,
+		Total:                  priced.total,
+		Currency:               priced.currency.Code,
+		PriceAdjustmentPercent: priced.adjustmentPercent,
+		SourceFee:              priced.sourceFee,
+		DeliveryFee:            priced.deliveryFee,
+	}, nil
+}
+
+// orderPlacement is what the channel adds to an order request: where a guest
+// wants updates sent, or the kiosk it came from and whether it is called by
+// queue number
+type orderPlacement struct {
+	email   *string
+	phone   *string
+	kioskID *uint
+	queued  bool
+}
+
+func normalizeContact(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// resolveOrderType falls back to the default for orders that do not say how
+// they are served
+func resolveOrderType(orderType models.OrderType) models.OrderType {
+	if orderType == "" {
+		return models.DefaultOrderType
+	}
+	return orderType
+}
+
+// findOrderTable looks up the table a customer scanned. Table orders are
+// served at the table, so they are dine-in unless another type was asked for,
+// which is rejected.
+func (s *orderService) findOrderTable(tableUUID uuid.UUID, orderType models.OrderType) (*models.DiningTable, models.OrderType, error) {
+	if orderType != "" && orderType != models.OrderTypeDineIn {
+		return nil, "", ErrTableOrderNotDineIn
+	}
+
+	table, err := s.tableRepo.FindByUUID(tableUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiningTableNotFound) {
+			return nil, "", ErrInvalidTable
+		}
+		return nil, "", err
+	}
+	if !table.IsActive {
+		return nil, "", ErrInvalidTable
+	}
+	return table, models.OrderTypeDineIn, nil
+}
+
+func (s *orderService) placeOrder(userID *uint, source models.OrderSource, req CreateOrderRequest, placement orderPlacement) (*OrderResponse, error) {
+	orderType := resolveOrderType(req.OrderType)
+	var table *models.DiningTable
+	if req.TableID != nil {
+		var err error
+		table, orderType, err = s.findOrderTable(*req.TableID, req.OrderType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status := models.OrderStatusPending
+	if req.ScheduledFor != nil {
+		if err := s.checkScheduledTime(*req.ScheduledFor); err != nil {
+			return nil, err
+		}
+		status = models.OrderStatusScheduled
+	}
+
+	pickupAt := time.Now()
+	if req.ScheduledFor != nil {
+		pickupAt = *req.ScheduledFor
+	}
+	if err := s.checkTimeslot(pickupAt, req.Items); err != nil {
+		return nil, err
+	}
+
+	priced, err := s.priceOrder(source, orderType, req.Items, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var code *models.Promotion
+	if req.PromoCode != nil && strings.TrimSpace(*req.PromoCode) != "" {
+		code, err = s.findRedeemablePromotion(*req.PromoCode, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	automatic, err := s.findAutomaticPromotions(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyPromotions(priced, req.Items, code, automatic); err != nil {
+		return nil, err
+	}
+	if req.VoucherCode != nil && strings.TrimSpace(*req.VoucherCode) != "" {
+		voucher, err := s.findRedeemableVoucher(*req.VoucherCode, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.applyVoucher(priced, voucher); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generate order number
+	orderNumber, err := s.orderRepo.GenerateOrderNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build order object
+	order := &models.Order{
+		OrderNumber:   orderNumber,
+		UserID:        userID,
+		CustomerName:  req.CustomerName,
+		CustomerEmail: placement.email,
+		CustomerPhone: placement.phone,
+		Notes:         req.Notes,
+		Status:        status,
+		ScheduledFor:  req.ScheduledFor,
+		OrderSource:   source,
+		OrderType:     orderType,
+		Subtotal:      priced.subtotal,
+		ServiceCharge: priced.serviceCharge,
+		Tax:           priced.tax,
+		TaxRate:       priced.charges.TaxRate,
+		Total:         priced.total,
+		Currency:      priced.currency.Code,
+		TipAmount:     priced.currency.Round(req.TipAmount),
+		DepositAmount: priced.deposit(),
+
+		PriceAdjustmentPercent: priced.adjustmentPercent,
+		SourceFee:              priced.sourceFee,
+		DeliveryFee:            priced.deliveryFee,
+		DeliveryDiscount:       priced.deliveryDiscount,
+	}
+	// A pre-order's payment window starts when it is released
+	if status == models.OrderStatusPending {
+		order.PaymentExpiresAt = s.paymentDeadline()
+	}
+	if err := s.issueShareToken(order); err != nil {
+		return nil, err
+	}
+	if table != nil {
+		order.TableID = &table.ID
+		order.TableNumber = &table.Number
+	}
+	order.KioskID = placement.kioskID
+	if placement.queued {
+		queueNumber, err := s.orderRepo.NextQueueNumber()
+		if err != nil {
+			return nil, err
+		}
+		order.QueueNumber = &queueNumber
+		order.CustomerName = fmt.Sprintf("Queue %d", queueNumber)
+	}
+	if priced.promotion != nil {
+		order.PromotionID = &priced.promotion.ID
+		order.PromoCode = &priced.promotion.Code
+	}
+	order.Discount = priced.discount
+	order.Promotions = priced.orderPromotions()
+
+	// Create order
+	err = s.orderRepo.Create(order, priced.items)
+	if err != nil {
+		// Another checkout may have used up the promotion since it was checked
+		if errors.Is(err, repositories.ErrPromotionUsageLimitReached) {
+			return nil, ErrPromoCodeUsageLimit
+		}
+		if errors.Is(err, repositories.ErrPromotionNotFound) {
+			return nil, ErrPromoCodeInvalid
+		}
+		// The voucher may have been used on another order in the meantime
+		if errors.Is(err, repositories.ErrVoucherNotRedeemable) {
+			return nil, ErrVoucherInvalid
+		}
+		return nil, err
+	}
+
+	// Hold capped stock while the customer proceeds to payment
+	if err = s.reserveStock(order, req.Items, priced.products); err != nil {
+		return nil, err
+	}
+
+	// Fetch complete order with relations
+	createdOrder, err := s.orderRepo.FindByUUID(order.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishOrderEvent(s.events, s.logger, OrderEventCreated, createdOrder, "")
+
+	return s.toOrderResponse(createdOrder, false), nil
+}
+
+func (s *orderService) GetByUUID(orderUUID uuid.UUID) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.toOrderResponse(order, false), nil
+}
+
+func (s *orderService) GetByOrderNumber(orderNumber string) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByOrderNumber(orderNumber)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	// Include user details (admin access)
+	return s.toOrderResponse(order, true), nil
+}
+
+// GetStatusByShareToken returns the public status page behind a share
+// token. Unknown, expired and revoked tokens look the same to the caller.
+func (s *orderService) GetStatusByShareToken(token string) (*OrderStatusResponse, error) {
+	order, err := s.orderRepo.FindByShareToken(token)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrShareTokenInvalid
+		}
+		return nil, err
+	}
+	if order.ShareTokenExpiresAt == nil || time.Now().After(*order.ShareTokenExpiresAt) {
+		return nil, ErrShareTokenInvalid
+	}
+
+	items := make([]OrderStatusItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = OrderStatusItem{ProductName: item.ProductName, Quantity: item.Quantity}
+	}
+
+	return &OrderStatusResponse{
+		OrderNumber:  order.OrderNumber,
+		CustomerName: order.CustomerName,
+		Status:       order.Status,
+		OrderType:    order.OrderType,
+		TableNumber:  order.TableNumber,
+		QueueNumber:  order.QueueNumber,
+		Items:        items,
+		ScheduledFor: formatOptionalTime(order.ScheduledFor),
+		CreatedAt:    order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ExpiresAt:    order.ShareTokenExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+// LookupGuestOrder returns the tracking view of an order placed without an
+// account when the phone number or customer name matches the one given at
+// checkout. A mismatch is reported as not found so order numbers cannot be
+// probed.
+func (s *orderService) LookupGuestOrder(req LookupOrderRequest) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByOrderNumber(strings.ToUpper(strings.TrimSpace(req.OrderNumber)))
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if order.UserID != nil || !matchesOrderContact(order, req) {
+		return nil, ErrOrderNotFound
+	}
+
+	return s.toOrderResponse(order, false), nil
+}
+
+// matchesOrderContact compares phone numbers by their digits, so spaces and
+// dashes do not matter, and names case-insensitively
+func matchesOrderContact(order *models.Order, req LookupOrderRequest) bool {
+	if req.Phone != "" {
+		return order.CustomerPhone != nil && phoneDigits(req.Phone) != "" &&
+			phoneDigits(req.Phone) == phoneDigits(*order.CustomerPhone)
+	}
+	return strings.EqualFold(strings.TrimSpace(req.CustomerName), strings.TrimSpace(order.CustomerName))
+}
+
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+func (s *orderService) GetMyOrders(userUUID uuid.UUID, page, limit int) (*OrderListResponse, error) {
+	// Get user by UUID to get internal ID
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	// Get orders
+	orders, total, err := s.orderRepo.FindByUserID(user.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response
+	orderResponses := make([]OrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = *s.toOrderResponse(&order, false)
+	}
+
+	return &OrderListResponse{
+		Orders: orderResponses,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+func (s *orderService) GetAllOrders(filters repositories.OrderFilters, page, limit int) (*OrderListResponse, error) {
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	// Get orders
+	orders, total, err := s.orderRepo.FindAll(filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response
+	orderResponses := make([]OrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = *s.toOrderResponse(&order, true)
+	}
+
+	return &OrderListResponse{
+		Orders: orderResponses,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+func (s *orderService) GetKitchenQueue() (*KitchenQueueResponse, error) {
+	orders, err := s.orderRepo.FindByStatuses([]models.OrderStatus{
+		models.OrderStatusPending,
+		models.OrderStatusPreparing,
+		models.OrderStatusReady,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders come longest queued first, so a stable sort keeps that within each priority
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Priority == models.PriorityRush && orders[j].Priority != models.PriorityRush
+	})
+
+	now := time.Now()
+	queue := &KitchenQueueResponse{
+		Pending:     make([]KitchenQueueOrder, 0),
+		Preparing:   make([]KitchenQueueOrder, 0),
+		Ready:       make([]KitchenQueueOrder, 0),
+		GeneratedAt: now.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for i := range orders {
+		order := &orders[i]
+		entry := toKitchenQueueOrder(order, now)
+		switch order.Status {
+		case models.OrderStatusPending:
+			queue.Pending = append(queue.Pending, entry)
+		case models.OrderStatusPreparing:
+			queue.Preparing = append(queue.Preparing, entry)
+		case models.OrderStatusReady:
+			queue.Ready = append(queue.Ready, entry)
+		}
+	}
+
+	return queue, nil
+}
+
+func (s *orderService) UpdateOrderStatus(orderUUID uuid.UUID, status models.OrderStatus) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.changeStatus(order, status)
+}
+
+// UpdateOrderStatusAs changes the status on behalf of a staff member. Once an
+// order is claimed, only the staff member holding it or an admin can move it,
+// so two baristas never prepare the same ticket.
+func (s *orderService) UpdateOrderStatusAs(orderUUID uuid.UUID, status models.OrderStatus, staffUUID uuid.UUID, role models.UserRole) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if role != models.RoleAdmin && order.AssignedTo != nil && order.AssignedTo.UUID != staffUUID {
+		return nil, ErrOrderAlreadyClaimed
+	}
+
+	return s.changeStatus(order, status)
+}
+
+func (s *orderService) changeStatus(order *models.Order, status models.OrderStatus) (*OrderResponse, error) {
+	// Orders of a closed business day keep the status they were closed with
+	if order.ClosedAt != nil {
+		return nil, ErrOrderDayClosed
+	}
+
+	// Validate status transition
+	if !s.isValidStatusTransition(order.Status, status) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	// Update status
+	err := s.orderRepo.UpdateStatus(order.ID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Give any held or deducted stock back
+	if status == models.OrderStatusCancelled {
+		if err = s.reservationRepo.ReleaseByOrderID(order.ID); err != nil {
+			s.logger.Error("Failed to release stock reservations", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		}
+	}
+	if status == models.OrderStatusCompleted {
+		s.awardPoints(order)
+		s.awardStamps(order)
+	}
+
+	// Fetch updated order
+	updatedOrder, err := s.orderRepo.FindByUUID(order.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, updatedOrder, order.Status)
+
+	return s.toOrderResponse(updatedOrder, true), nil
+}
+
+// awardPoints credits a member with points for what they paid on the order
+// besides points. A failure is only logged: the order is complete either way.
+func (s *orderService) awardPoints(order *models.Order) {
+	if order.UserID == nil {
+		return
+	}
+	settings, err := s.settingsService.GetLoyaltySettings()
+	if err != nil {
+		s.logger.Error("Failed to load loyalty settings", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return
+	}
+	points := settings.PointsFor(order.Total - order.PointsAmount)
+	if points == 0 {
+		return
+	}
+	if err := s.orderRepo.AwardPoints(order.ID, *order.UserID, points); err != nil {
+		s.logger.Error("Failed to award points", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+	}
+}
 
-	// Calculate totals and build order items
-	subtotal, orderItems := s.calculateOrderTotals(req.Items, products, customizationsMap)
-	tax := subtotal * 0.10 // 10% tax
-	total := subtotal + tax
+// stampRewardReason labels the vouchers full stamp cards are swapped for
+const stampRewardReason = "Stamp card reward: a free drink"
 
-	// Generate order number
-	orderNumber, err := s.orderRepo.GenerateOrderNumber()
+// awardStamps stamps a member's card for each qualifying drink on the order,
+// then swaps every full card for a free-drink voucher. A card that was left
+// full by an earlier failure is swapped here too. Failures are only logged:
+// the order is complete either way.
+func (s *orderService) awardStamps(order *models.Order) {
+	if order.UserID == nil {
+		return
+	}
+	settings, err := s.settingsService.GetStampSettings()
 	if err != nil {
-		return nil, err
+		s.logger.Error("Failed to load stamp settings", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return
+	}
+	if !settings.Enabled() {
+		return
+	}
+	qualifies, err := s.stampScope(settings)
+	if err != nil {
+		s.logger.Error("Failed to find stamp categories", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return
 	}
 
-	// Build order object
-	order := &models.Order{
-		OrderNumber:  orderNumber,
-		UserID:       &user.ID,
-		CustomerName: req.CustomerName,
-		Notes:        req.Notes,
-		Status:       models.OrderStatusPending,
-		OrderSource:  models.OrderSourceMember,
-		Subtotal:     subtotal,
-		Tax:          tax,
-		Total:        total,
+	stamps := 0
+	for _, item := range order.Items {
+		if item.Product != nil && qualifies(item.Product) {
+			stamps += item.Quantity
+		}
+	}
+	if stamps == 0 {
+		return
+	}
+	if err := s.loyaltyRepo.EarnStamps(order.ID, *order.UserID, stamps); err != nil {
+		s.logger.Error("Failed to award stamps", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return
 	}
 
-	// Create order
-	err = s.orderRepo.Create(order, orderItems)
+	for {
+		reward, err := settings.rewardVoucher(*order.UserID, time.Now())
+		if err != nil {
+			s.logger.Error("Failed to prepare stamp reward", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+			return
+		}
+		redeemed, err := s.loyaltyRepo.RedeemStamps(*order.UserID, settings.Goal, reward)
+		if err != nil {
+			s.logger.Error("Failed to grant stamp reward", logging.OrderNumber(order.OrderNumber), logging.Err(err))
+			return
+		}
+		if !redeemed {
+			return
+		}
+	}
+}
+
+// VerifyPickup completes the order a scanned pickup code was issued for, so
+// the barista knows the drinks go to the right customer
+func (s *orderService) VerifyPickup(req VerifyPickupRequest) (*OrderResponse, error) {
+	orderNumber, err := s.pickupCodes.Verify(req.Code)
 	if err != nil {
-		return nil, err
+		return nil, ErrInvalidPickupCode
 	}
 
-	// Fetch complete order with relations
-	createdOrder, err := s.orderRepo.FindByUUID(order.UUID)
+	order, err := s.orderRepo.FindByOrderNumber(orderNumber)
 	if err != nil {
+		// A valid signature for a deleted order is still not a usable code
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrInvalidPickupCode
+		}
 		return nil, err
 	}
 
-	return s.toOrderResponse(createdOrder, false), nil
+	switch order.Status {
+	case models.OrderStatusReady:
+		return s.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+	case models.OrderStatusCompleted:
+		return nil, ErrOrderAlreadyPickedUp
+	default:
+		return nil, ErrOrderNotReadyForPickup
+	}
 }
 
-func (s *orderService) CreateGuestOrder(req CreateOrderRequest) (*OrderResponse, error) {
-	// Validate and fetch all products
-	products, customizationsMap, err := s.validateAndFetchProducts(req.Items)
+// UpdatePriority lets staff rush an order, or put it back in line, while it
+// is still waiting or being prepared
+func (s *orderService) UpdatePriority(orderUUID uuid.UUID, priority models.Priority) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
 		return nil, err
 	}
 
-	// Calculate totals and build order items
-	subtotal, orderItems := s.calculateOrderTotals(req.Items, products, customizationsMap)
-	tax := subtotal * 0.10 // 10% tax
-	total := subtotal + tax
-
-	// Generate order number
-	orderNumber, err := s.orderRepo.GenerateOrderNumber()
-	if err != nil {
-		return nil, err
+	if order.ClosedAt != nil {
+		return nil, ErrOrderDayClosed
+	}
+	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusPreparing {
+		return nil, ErrPriorityNotEditable
 	}
 
-	// Build order object
-	order := &models.Order{
-		OrderNumber:  orderNumber,
-		UserID:       nil,
-		CustomerName: req.CustomerName,
-		Notes:        req.Notes,
-		Status:       models.OrderStatusPending,
-		OrderSource:  models.OrderSourceGuest,
-		Subtotal:     subtotal,
-		Tax:          tax,
-		Total:        total,
+	if order.Priority != priority {
+		if err = s.orderRepo.UpdatePriority(order.ID, priority); err != nil {
+			return nil, err
+		}
+		order.Priority = priority
+		publishOrderEvent(s.events, s.logger, OrderEventPriority, order, "")
 	}
 
-	// Create order
-	err = s.orderRepo.Create(order, orderItems)
-	if err != nil {
-		return nil, err
+	return s.toOrderResponse(order, true), nil
+}
+
+// RushDelayedOrders rushes orders that have been in preparation for longer
+// than the configured RushAfter and reports how many it rushed
+func (s *orderService) RushDelayedOrders() (int, error) {
+	if s.config.RushAfter <= 0 {
+		return 0, nil
 	}
 
-	// Fetch complete order with relations
-	createdOrder, err := s.orderRepo.FindByUUID(order.UUID)
+	orders, err := s.orderRepo.FindByStatuses([]models.OrderStatus{models.OrderStatusPreparing})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return s.toOrderResponse(createdOrder, false), nil
+	cutoff := time.Now().Add(-s.config.RushAfter)
+	rushed := 0
+	for i := range orders {
+		order := &orders[i]
+		if order.Priority == models.PriorityRush || !order.QueuedAt().Before(cutoff) {
+			continue
+		}
+		if err := s.orderRepo.UpdatePriority(order.ID, models.PriorityRush); err != nil {
+			return rushed, err
+		}
+		order.Priority = models.PriorityRush
+		publishOrderEvent(s.events, s.logger, OrderEventPriority, order, "")
+		rushed++
+	}
+	return rushed, nil
 }
 
-func (s *orderService) GetByUUID(orderUUID uuid.UUID) (*OrderResponse, error) {
-	order, err := s.orderRepo.FindByUUID(orderUUID)
+// ReleaseScheduledOrders moves pre-orders that are due within the lead time
+// into the pending queue, where they can be paid and prepared, and reports
+// how many it released
+func (s *orderService) ReleaseScheduledOrders() (int, error) {
+	orders, err := s.orderRepo.FindDueScheduled(time.Now().Add(s.config.PreorderLeadTime))
 	if err != nil {
-		if errors.Is(err, repositories.ErrOrderNotFound) {
-			return nil, ErrOrderNotFound
-		}
-		return nil, err
+		return 0, err
 	}
 
-	return s.toOrderResponse(order, false), nil
+	released := 0
+	for i := range orders {
+		order := &orders[i]
+		deadline := s.paymentDeadline()
+		ok, err := s.orderRepo.ReleaseScheduled(order.ID, deadline)
+		if err != nil {
+			return released, err
+		}
+		if !ok {
+			continue
+		}
+		order.Status = models.OrderStatusPending
+		order.PaymentExpiresAt = deadline
+		publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, order, models.OrderStatusScheduled)
+		released++
+	}
+	return released, nil
 }
 
-func (s *orderService) GetByOrderNumber(orderNumber string) (*OrderResponse, error) {
-	order, err := s.orderRepo.FindByOrderNumber(orderNumber)
+// ClaimOrder assigns an active order to the staff member preparing it. Only
+// one staff member can hold an order; claiming it again is a no-op for them.
+func (s *orderService) ClaimOrder(orderUUID, staffUUID uuid.UUID) (*OrderResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrOrderNotFound) {
 			return nil, ErrOrderNotFound
@@ -231,13 +2038,11 @@ func (s *orderService) GetByOrderNumber(orderNumber string) (*OrderResponse, err
 		return nil, err
 	}
 
-	// Include user details (admin access)
-	return s.toOrderResponse(order, true), nil
-}
+	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusPreparing {
+		return nil, ErrOrderNotClaimable
+	}
 
-func (s *orderService) GetMyOrders(userUUID uuid.UUID, page, limit int) (*OrderListResponse, error) {
-	// Get user by UUID to get internal ID
-	user, err := s.userRepo.FindByUUID(userUUID)
+	staff, err := s.userRepo.FindByUUID(staffUUID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrUserNotFound) {
 			return nil, ErrUserNotFound
@@ -245,55 +2050,40 @@ func (s *orderService) GetMyOrders(userUUID uuid.UUID, page, limit int) (*OrderL
 		return nil, err
 	}
 
-	// Calculate offset
-	offset := (page - 1) * limit
+	if err = s.orderRepo.Claim(order.ID, staff.ID); err != nil {
+		if errors.Is(err, repositories.ErrOrderAlreadyClaimed) {
+			return nil, ErrOrderAlreadyClaimed
+		}
+		return nil, err
+	}
 
-	// Get orders
-	orders, total, err := s.orderRepo.FindByUserID(user.ID, limit, offset)
+	claimedOrder, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to response
-	orderResponses := make([]OrderResponse, len(orders))
-	for i, order := range orders {
-		orderResponses[i] = *s.toOrderResponse(&order, false)
-	}
+	publishOrderEvent(s.events, s.logger, OrderEventClaimed, claimedOrder, "")
 
-	return &OrderListResponse{
-		Orders: orderResponses,
-		Total:  total,
-		Page:   page,
-		Limit:  limit,
-	}, nil
+	return s.toOrderResponse(claimedOrder, true), nil
 }
 
-func (s *orderService) GetAllOrders(filters repositories.OrderFilters, page, limit int) (*OrderListResponse, error) {
-	// Calculate offset
-	offset := (page - 1) * limit
-
-	// Get orders
-	orders, total, err := s.orderRepo.FindAll(filters, limit, offset)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert to response
-	orderResponses := make([]OrderResponse, len(orders))
-	for i, order := range orders {
-		orderResponses[i] = *s.toOrderResponse(&order, true)
-	}
+// SubscribeToOrder streams status events for one order to a realtime client
+func (s *orderService) SubscribeToOrder(orderUUID uuid.UUID) *realtime.Subscription {
+	return s.events.Subscribe(OrderTopic(orderUUID))
+}
 
-	return &OrderListResponse{
-		Orders: orderResponses,
-		Total:  total,
-		Page:   page,
-		Limit:  limit,
-	}, nil
+// SubscribeToOrders streams new orders and status changes to staff screens
+func (s *orderService) SubscribeToOrders() *realtime.Subscription {
+	return s.events.Subscribe(OrdersTopic)
 }
 
-func (s *orderService) UpdateOrderStatus(orderUUID uuid.UUID, status models.OrderStatus) (*OrderResponse, error) {
-	// Fetch order
+// BulkUpdateOrderStatus applies the same transition to each order independently,
+// so one bad order does not block the rest of the batch.
+// VerifyTotals recomputes an order's amounts from its item snapshots, the
+// current rates for its order type and the discount and source fee recorded on
+// the order, and lists every stored amount that does not match. Nothing is
+// changed.
+func (s *orderService) VerifyTotals(orderUUID uuid.UUID) (*VerifyTotalsResponse, error) {
 	order, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrOrderNotFound) {
@@ -302,33 +2092,122 @@ func (s *orderService) UpdateOrderStatus(orderUUID uuid.UUID, status models.Orde
 		return nil, err
 	}
 
-	// Validate status transition
-	if !s.isValidStatusTransition(order.Status, status) {
-		return nil, ErrInvalidStatusTransition
-	}
-
-	// Update status
-	err = s.orderRepo.UpdateStatus(order.ID, status)
+	currency, err := s.settingsService.GetCurrencySettings()
 	if err != nil {
 		return nil, err
 	}
+	// Orders placed before totals were rounded to the currency are off by
+	// less than one unit of it
+	tolerance := math.Max(totalsTolerance, math.Pow10(-currency.Decimals))
+
+	discrepancies := make([]TotalsDiscrepancy, 0)
+	check := func(field string, itemID *uuid.UUID, stored, expected float64) {
+		if math.Abs(stored-expected) > tolerance {
+			discrepancies = append(discrepancies, TotalsDiscrepancy{
+				Field:    field,
+				ItemID:   itemID,
+				Stored:   stored,
+				Expected: roundAmount(expected),
+			})
+		}
+	}
 
-	// Fetch updated order
-	updatedOrder, err := s.orderRepo.FindByUUID(orderUUID)
-	if err != nil {
-		return nil, err
+	var subtotal float64
+	for i := range order.Items {
+		item := &order.Items[i]
+		expected := item.UnitPrice * float64(item.Quantity)
+		check("item_subtotal", &item.UUID, item.Subtotal, expected)
+		subtotal += expected
 	}
 
-	return s.toOrderResponse(updatedOrder, true), nil
+	charges := s.config.chargesFor(resolveOrderType(order.OrderType))
+	serviceCharge := currency.Round((subtotal - order.Discount) * charges.ServiceChargeRate)
+	recomputed := OrderTotals{
+		Subtotal:         roundAmount(subtotal),
+		Discount:         order.Discount,
+		ServiceCharge:    serviceCharge,
+		Tax:              currency.Round((subtotal - order.Discount + serviceCharge) * charges.TaxRate),
+		SourceFee:        order.SourceFee,
+		DeliveryFee:      order.DeliveryFee,
+		DeliveryDiscount: order.DeliveryDiscount,
+	}
+	recomputed.Total = currency.Round(recomputed.Subtotal - recomputed.Discount + recomputed.ServiceCharge + recomputed.Tax +
+		recomputed.SourceFee + recomputed.DeliveryFee - recomputed.DeliveryDiscount)
+
+	check("subtotal", nil, order.Subtotal, recomputed.Subtotal)
+	check("service_charge", nil, order.ServiceCharge, recomputed.ServiceCharge)
+	check("tax", nil, order.Tax, recomputed.Tax)
+	check("total", nil, order.Total, recomputed.Total)
+
+	return &VerifyTotalsResponse{
+		OrderID:     order.UUID,
+		OrderNumber: order.OrderNumber,
+		Stored: OrderTotals{
+			Subtotal:         order.Subtotal,
+			Discount:         order.Discount,
+			ServiceCharge:    order.ServiceCharge,
+			Tax:              order.Tax,
+			SourceFee:        order.SourceFee,
+			DeliveryFee:      order.DeliveryFee,
+			DeliveryDiscount: order.DeliveryDiscount,
+			Total:            order.Total,
+		},
+		Recomputed:    recomputed,
+		Consistent:    len(discrepancies) == 0,
+		Discrepancies: discrepancies,
+	}, nil
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (s *orderService) BulkUpdateOrderStatus(req BulkUpdateOrderStatusRequest) (*BulkUpdateOrderStatusResponse, error) {
+	response := &BulkUpdateOrderStatusResponse{
+		Results: make([]BulkOrderStatusResult, 0, len(req.OrderIDs)),
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, orderUUID := range req.OrderIDs {
+		if seen[orderUUID] {
+			continue
+		}
+		seen[orderUUID] = true
+
+		result := BulkOrderStatusResult{OrderID: orderUUID}
+
+		order, err := s.UpdateOrderStatus(orderUUID, req.Status)
+		switch {
+		case err == nil:
+			result.Success = true
+			result.OrderNumber = order.OrderNumber
+			result.Status = order.Status
+		case errors.Is(err, ErrOrderNotFound), errors.Is(err, ErrInvalidStatusTransition), errors.Is(err, ErrOrderDayClosed):
+			result.Error = err.Error()
+		default:
+			s.logger.Error("Failed to update order status", "order_uuid", orderUUID, logging.Err(err))
+			result.Error = "failed to update order status"
+		}
+
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
 }
 
-func (s *orderService) validateAndFetchProducts(items []CreateOrderItemRequest) (
+func (s *orderService) validateAndFetchProducts(items []CreateOrderItemRequest, held map[uint]int) (
 	map[uuid.UUID]*models.Product,
 	map[uuid.UUID]map[uuid.UUID]*models.ProductCustomization,
 	error,
 ) {
 	products := make(map[uuid.UUID]*models.Product)
 	customizationsMap := make(map[uuid.UUID]map[uuid.UUID]*models.ProductCustomization)
+	requested := make(map[uint]int)
 
 	for _, item := range items {
 		// Fetch product
@@ -340,11 +2219,23 @@ func (s *orderService) validateAndFetchProducts(items []CreateOrderItemRequest)
 			return nil, nil, err
 		}
 
-		// Validate product is available
-		if !product.IsAvailable {
+		// Validate product is available (products in a deleted category are hidden)
+		if !product.IsAvailable || (product.Category != nil && product.Category.DeletedAt != nil) {
 			return nil, nil, fmt.Errorf("%w: %s", ErrProductNotAvailable, product.Name)
 		}
 
+		// Validate capped stock (quick check, the reservation re-checks under lock)
+		if product.StockQuantity != nil {
+			requested[product.ID] += item.Quantity
+			available, err := s.reservationRepo.AvailableQuantity(product.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if available+held[product.ID] < requested[product.ID] {
+				return nil, nil, fmt.Errorf("%w: %s", ErrInsufficientStock, product.Name)
+			}
+		}
+
 		products[item.ProductID] = product
 
 		// Validate customizations
@@ -378,6 +2269,7 @@ func (s *orderService) calculateOrderTotals(
 	items []CreateOrderItemRequest,
 	products map[uuid.UUID]*models.Product,
 	customizationsMap map[uuid.UUID]map[uuid.UUID]*models.ProductCustomization,
+	pricing *models.SourcePricing,
 ) (float64, []models.OrderItem) {
 	var subtotal float64
 	var orderItems []models.OrderItem
@@ -407,6 +2299,7 @@ func (s *orderService) calculateOrderTotals(
 			}
 		}
 
+		unitPrice = pricing.AdjustPrice(unitPrice)
 		itemSubtotal := unitPrice * float64(item.Quantity)
 		subtotal += itemSubtotal
 
@@ -424,11 +2317,186 @@ func (s *orderService) calculateOrderTotals(
 	return subtotal, orderItems
 }
 
+// resolveSourcePricing returns nil when the source pays list price
+func (s *orderService) resolveSourcePricing(source models.OrderSource) (*models.SourcePricing, error) {
+	if !source.IsPriceAdjustable() {
+		return nil, nil
+	}
+
+	pricing, err := s.pricingRepo.FindBySource(source)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSourcePricingNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pricing, nil
+}
+
+func (s *orderService) paymentDeadline() *time.Time {
+	if s.config.PaymentExpiry <= 0 {
+		return nil
+	}
+	deadline := time.Now().Add(s.config.PaymentExpiry)
+	return &deadline
+}
+
+// checkScheduledTime accepts pre-orders that are due after the lead time, so
+// they can still be released ahead, and within the pre-order window
+func (s *orderService) checkScheduledTime(scheduledFor time.Time) error {
+	if s.config.PreorderWindow <= 0 {
+		return ErrPreordersDisabled
+	}
+
+	now := time.Now()
+	if !scheduledFor.After(now.Add(s.config.PreorderLeadTime)) || scheduledFor.After(now.Add(s.config.PreorderWindow)) {
+		return ErrInvalidScheduledTime
+	}
+	return nil
+}
+
+// checkTimeslot makes sure the timeslot an order is due for pickup in can
+// take it on top of the orders already due then. The limits are checked
+// before the order is saved, so concurrent checkouts may overshoot them
+// slightly.
+func (s *orderService) checkTimeslot(pickupAt time.Time, items []CreateOrderItemRequest) error {
+	settings, err := s.settingsService.GetTimeslotSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.IsLimited() {
+		return nil
+	}
+
+	drinks := 0
+	for _, item := range items {
+		drinks += item.Quantity
+	}
+
+	slot := pickupAt.Truncate(TimeslotLength)
+	loads, err := s.orderRepo.FindPickupLoads(slot, slot.Add(timeslotSearchSlots*TimeslotLength))
+	if err != nil {
+		return err
+	}
+
+	type slotUsage struct{ orders, drinks int }
+	usage := make(map[int64]slotUsage)
+	for _, load := range loads {
+		key := load.PickupAt.Truncate(TimeslotLength).Unix()
+		used := usage[key]
+		used.orders++
+		used.drinks += load.Drinks
+		usage[key] = used
+	}
+	fits := func(start time.Time) bool {
+		used := usage[start.Unix()]
+		if settings.MaxOrders > 0 && used.orders+1 > settings.MaxOrders {
+			return false
+		}
+		return settings.MaxDrinks == 0 || used.drinks+drinks <= settings.MaxDrinks
+	}
+
+	if fits(slot) {
+		return nil
+	}
+
+	// Only offer timeslots the customer can still pre-order for
+	now := time.Now()
+	earliest := now.Add(s.config.PreorderLeadTime)
+	full := &TimeslotFullError{NextAvailable: []TimeslotResponse{}}
+	for i := 1; i < timeslotSearchSlots && len(full.NextAvailable) < timeslotSuggestions; i++ {
+		start := slot.Add(time.Duration(i) * TimeslotLength)
+		if s.config.PreorderWindow > 0 && start.After(now.Add(s.config.PreorderWindow)) {
+			break
+		}
+		if !start.After(earliest) || !fits(start) {
+			continue
+		}
+		full.NextAvailable = append(full.NextAvailable, TimeslotResponse{
+			StartsAt: start.Format("2006-01-02T15:04:05Z07:00"),
+			EndsAt:   start.Add(TimeslotLength).Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return full
+}
+
+// issueShareToken gives the order a random token for its public status page,
+// valid until ShareTokenTTL after the order is due
+func (s *orderService) issueShareToken(order *models.Order) error {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(b)
+
+	dueAt := time.Now()
+	if order.ScheduledFor != nil {
+		dueAt = *order.ScheduledFor
+	}
+	expiresAt := dueAt.Add(s.config.ShareTokenTTL)
+
+	order.ShareToken = &token
+	order.ShareTokenExpiresAt = &expiresAt
+	return nil
+}
+
+// releaseTime is when a pre-order scheduled for the given time joins the
+// pending queue
+func (s *orderService) releaseTime(scheduledFor time.Time) time.Time {
+	return scheduledFor.Add(-s.config.PreorderLeadTime)
+}
+
+func (s *orderService) reserveStock(
+	order *models.Order,
+	items []CreateOrderItemRequest,
+	products map[uuid.UUID]*models.Product,
+) error {
+	var reservations []repositories.ReservationItem
+	for _, item := range items {
+		product := products[item.ProductID]
+		if product.StockQuantity == nil {
+			continue
+		}
+		reservations = append(reservations, repositories.ReservationItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	// Pre-orders hold their stock until they are released
+	heldFrom := time.Now()
+	if order.ScheduledFor != nil {
+		heldFrom = s.releaseTime(*order.ScheduledFor)
+	}
+
+	err := s.reservationRepo.ReserveForOrder(order.ID, reservations, heldFrom.Add(s.config.ReservationTTL))
+	if err == nil {
+		return nil
+	}
+
+	// Someone else grabbed the last units between the check and the hold;
+	// cancel the order rather than leave an unpayable pending order behind
+	if statusErr := s.orderRepo.UpdateStatus(order.ID, models.OrderStatusCancelled); statusErr != nil {
+		s.logger.Error("Failed to cancel order after reservation failure", logging.OrderNumber(order.OrderNumber), logging.Err(statusErr))
+	}
+
+	if errors.Is(err, repositories.ErrInsufficientStock) {
+		return ErrInsufficientStock
+	}
+	return err
+}
+
 func (s *orderService) isValidStatusTransition(current, new models.OrderStatus) bool {
 	validTransitions := map[models.OrderStatus][]models.OrderStatus{
-		models.OrderStatusPending:   {models.OrderStatusPreparing, models.OrderStatusCancelled},
-		models.OrderStatusPreparing: {models.OrderStatusReady},
-		models.OrderStatusReady:     {models.OrderStatusCompleted},
+		models.OrderStatusScheduled:   {models.OrderStatusCancelled},
+		models.OrderStatusPending:     {models.OrderStatusPreparing, models.OrderStatusCancelled},
+		models.OrderStatusDepositPaid: {models.OrderStatusCancelled},
+		models.OrderStatusPreparing:   {models.OrderStatusReady},
+		models.OrderStatusReady:       {models.OrderStatusCompleted},
 	}
 
 	allowed, exists := validTransitions[current]
@@ -444,6 +2512,71 @@ func (s *orderService) isValidStatusTransition(current, new models.OrderStatus)
 	return false
 }
 
+func toKitchenQueueOrder(order *models.Order, now time.Time) KitchenQueueOrder {
+	items := make([]KitchenQueueItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		var customizations []string
+		for _, customization := range parseItemCustomizations(item.Customizations) {
+			customizations = append(customizations, customization.CustomizationType+": "+customization.OptionName)
+		}
+
+		items = append(items, KitchenQueueItem{
+			ProductName:    item.ProductName,
+			Quantity:       item.Quantity,
+			Customizations: customizations,
+			Notes:          item.Notes,
+		})
+	}
+
+	return KitchenQueueOrder{
+		ID:             order.UUID,
+		OrderNumber:    order.OrderNumber,
+		CustomerName:   order.CustomerName,
+		OrderSource:    order.OrderSource,
+		OrderType:      order.OrderType,
+		TableNumber:    order.TableNumber,
+		QueueNumber:    order.QueueNumber,
+		Status:         order.Status,
+		Priority:       order.Priority,
+		Notes:          order.Notes,
+		ScheduledFor:   formatOptionalTime(order.ScheduledFor),
+		AssignedTo:     toStaffSummary(order.AssignedTo),
+		Items:          items,
+		CreatedAt:      order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ElapsedSeconds: int64(now.Sub(order.QueuedAt()).Seconds()),
+	}
+}
+
+func toStaffSummary(user *models.User) *StaffSummary {
+	if user == nil {
+		return nil
+	}
+	return &StaffSummary{
+		ID:       user.UUID,
+		FullName: user.FullName,
+	}
+}
+
+// itemCustomization is one entry of the customizations snapshot stored on an
+// order item
+type itemCustomization struct {
+	CustomizationType string  `json:"customization_type"`
+	OptionName        string  `json:"option_name"`
+	PriceModifier     float64 `json:"price_modifier"`
+}
+
+func parseItemCustomizations(data []byte) []itemCustomization {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var customizations []itemCustomization
+	if err := json.Unmarshal(data, &customizations); err != nil {
+		return nil
+	}
+	return customizations
+}
+
 func (s *orderService) toOrderResponse(order *models.Order, includeUser bool) *OrderResponse {
 	itemResponses := make([]OrderItemResponse, len(order.Items))
 	for i, item := range order.Items {
@@ -458,6 +2591,16 @@ func (s *orderService) toOrderResponse(order *models.Order, includeUser bool) *O
 		}
 	}
 
+	// Guest contact details and who is preparing the order are only shown to
+	// staff, not on public tracking
+	var customerEmail, customerPhone *string
+	var assignedTo *StaffSummary
+	if includeUser {
+		customerEmail = order.CustomerEmail
+		customerPhone = order.CustomerPhone
+		assignedTo = toStaffSummary(order.AssignedTo)
+	}
+
 	var userSummary *UserSummary
 	if includeUser && order.User != nil {
 		userSummary = &UserSummary{
@@ -473,19 +2616,77 @@ func (s *orderService) toOrderResponse(order *models.Order, includeUser bool) *O
 		completedAt = &completedAtStr
 	}
 
+	// The deadline only matters while the order is still waiting for payment
+	var paymentExpiresAt *string
+	if order.Status == models.OrderStatusPending && order.PaymentExpiresAt != nil {
+		paymentExpiresAtStr := order.PaymentExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+		paymentExpiresAt = &paymentExpiresAtStr
+	}
+
+	var shareToken *string
+	if order.ShareTokenExpiresAt != nil && time.Now().Before(*order.ShareTokenExpiresAt) {
+		shareToken = order.ShareToken
+	}
+
+	var pickupCode *string
+	if order.AwaitsPickup() {
+		code := s.pickupCodes.Sign(order.OrderNumber)
+		pickupCode = &code
+	}
+
+	var paymentDue *float64
+	if order.AwaitsPayment() {
+		due := order.PaymentDue()
+		paymentDue = &due
+	}
+
+	var promotions []OrderPromotionResponse
+	for _, applied := range order.Promotions {
+		promotions = append(promotions, OrderPromotionResponse{Code: applied.Code, Name: applied.Name, Discount: applied.Discount})
+	}
+
 	return &OrderResponse{
-		ID:           order.UUID,
-		OrderNumber:  order.OrderNumber,
-		CustomerName: order.CustomerName,
-		Status:       order.Status,
-		OrderSource:  order.OrderSource,
-		Subtotal:     order.Subtotal,
-		Tax:          order.Tax,
-		Total:        order.Total,
-		Notes:        order.Notes,
-		Items:        itemResponses,
-		User:         userSummary,
-		CreatedAt:    order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		CompletedAt:  completedAt,
+		ID:            order.UUID,
+		OrderNumber:   order.OrderNumber,
+		CustomerName:  order.CustomerName,
+		CustomerEmail: customerEmail,
+		CustomerPhone: customerPhone,
+		Status:        order.Status,
+		OrderSource:   order.OrderSource,
+		OrderType:     order.OrderType,
+		Priority:      order.Priority,
+		TableNumber:   order.TableNumber,
+		QueueNumber:   order.QueueNumber,
+		Subtotal:      order.Subtotal,
+		PromoCode:     order.PromoCode,
+		Discount:      order.Discount,
+		Promotions:    promotions,
+		ServiceCharge: order.ServiceCharge,
+		Tax:           order.Tax,
+		Total:         order.Total,
+		Currency:      order.Currency,
+		TipAmount:     order.TipAmount,
+		Notes:         order.Notes,
+		Items:         itemResponses,
+		User:          userSummary,
+		AssignedTo:    assignedTo,
+		CreatedAt:     order.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		CompletedAt:   completedAt,
+		FlaggedAt:     formatOptionalTime(order.FlaggedAt),
+		ScheduledFor:  formatOptionalTime(order.ScheduledFor),
+		PickupCode:    pickupCode,
+		ShareToken:    shareToken,
+
+		PriceAdjustmentPercent: order.PriceAdjustmentPercent,
+		SourceFee:              order.SourceFee,
+		DeliveryFee:            order.DeliveryFee,
+		DeliveryDiscount:       order.DeliveryDiscount,
+		GiftCardAmount:         order.GiftCardAmount,
+		PointsRedeemed:         order.PointsRedeemed,
+		PointsAmount:           order.PointsAmount,
+		DepositAmount:          order.DepositAmount,
+		DepositPaidAt:          formatOptionalTime(order.DepositPaidAt),
+		PaymentDue:             paymentDue,
+		PaymentExpiresAt:       paymentExpiresAt,
 	}
 }
@@ -0,0 +1,606 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/midtrans/midtrans-go/snap"
+)
+
+// ErrGatewayTransactionNotFound means the gateway has no transaction for the
+// reference, because the customer never opened the payment page
+var ErrGatewayTransactionNotFound = errors.New("gateway transaction not found")
+
+// ErrCurrencyNotSupported means the gateway cannot charge the order's currency
+var ErrCurrencyNotSupported = errors.New("payment gateway does not support the order currency")
+
+// PaymentConfig selects the gateway customers pay through and holds the keys
+// of each gateway
+type PaymentConfig struct {
+	Provider            models.PaymentMethod
+	MidtransServerKey   string
+	MidtransClientKey   string
+	MidtransEnvironment string
+	StripeSecretKey     string
+	StripeWebhookSecret string
+	XenditSecretKey     string
+	XenditCallbackToken string
+	// PaymentExpiry is how long a checkout stays payable when the order has
+	// no payment window of its own
+	PaymentExpiry time.Duration
+	// CancelExpiredOrders cancels the order once its payment expires
+	CancelExpiredOrders bool
+	// FrontendURL is where hosted checkouts send the customer back to
+	FrontendURL string
+	// MidtransFinishURL, MidtransUnfinishURL and MidtransErrorURL are where
+	// Snap sends the customer after paying, leaving unpaid or failing.
+	// {order_id} is replaced with the order's ID. Empty ones go back to the
+	// order tracking page on FrontendURL.
+	MidtransFinishURL   string
+	MidtransUnfinishURL string
+	MidtransErrorURL    string
+	// GatewayFees estimates the fee on settled payments whose gateway does
+	// not report one
+	GatewayFees GatewayFees
+}
+
+// GatewayFee is what a gateway keeps from each payment of a type: a
+// percentage of the amount plus a fixed charge
+type GatewayFee struct {
+	Percent float64
+	Fixed   float64
+}
+
+// GatewayFees maps payment types, as the gateway reports them, to their fee
+type GatewayFees map[string]GatewayFee
+
+// Fee returns the fee on amount for the payment type. It reports false for
+// types without a configured fee.
+func (f GatewayFees) Fee(paymentType string, amount float64) (float64, bool) {
+	fee, ok := f[paymentType]
+	if !ok {
+		return 0, false
+	}
+	return roundAmount(amount*fee.Percent/100 + fee.Fixed), true
+}
+
+// ParseGatewayFees reads fees written as type=rate, where the rate is a
+// percentage, a fixed amount or both: qris=0.7%, bank_transfer=4000 or
+// credit_card=2.9%+2000
+func ParseGatewayFees(specs []string) (GatewayFees, error) {
+	fees := make(GatewayFees, len(specs))
+	for _, spec := range specs {
+		if spec == "" {
+			continue
+		}
+		paymentType, rate, found := strings.Cut(spec, "=")
+		if !found || paymentType == "" || rate == "" {
+			return nil, fmt.Errorf("invalid gateway fee %q, expected type=rate", spec)
+		}
+
+		var fee GatewayFee
+		for _, part := range strings.Split(rate, "+") {
+			value, isPercent := strings.CutSuffix(part, "%")
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil || amount < 0 {
+				return nil, fmt.Errorf("invalid gateway fee %q: %q is not an amount or percentage", spec, part)
+			}
+			if isPercent {
+				fee.Percent += amount
+			} else {
+				fee.Fixed += amount
+			}
+		}
+		fees[paymentType] = fee
+	}
+	return fees, nil
+}
+
+// GatewayCheckout is a hosted payment page started for an order
+type GatewayCheckout struct {
+	Token       string
+	RedirectURL string
+}
+
+// PaymentGateway starts hosted payments with a provider. The customer pays on
+// the provider's page until expiresAt and the outcome arrives through the
+// provider's webhook, keyed by the reference the payment was started with.
+// Gateways that support it offer only enabledPayments when it is not empty.
+// The request ID carried by ctx is passed on to the provider so a payment can
+// be traced back to the request that started it.
+type PaymentGateway interface {
+	Provider() models.PaymentMethod
+	CreateCheckout(ctx context.Context, order *models.Order, reference string, expiresAt time.Time, enabledPayments []string) (*GatewayCheckout, error)
+}
+
+// qrisGateway is implemented by gateways that can charge a QRIS code for us to
+// show, instead of sending the customer to a hosted page. The checkout's
+// token is the QR string and its redirect URL an image of the code.
+type qrisGateway interface {
+	ChargeQRIS(ctx context.Context, order *models.Order, reference string, expiresAt time.Time) (*GatewayCheckout, error)
+}
+
+// GatewayTransaction is a transaction as the gateway reports it when asked
+// directly, carrying what its webhook would have told us
+type GatewayTransaction struct {
+	Status          models.TransactionStatus
+	FraudStatus     models.FraudStatus
+	TransactionID   string
+	PaymentType     string
+	StatusMessage   string
+	GrossAmount     float64
+	TransactionTime *time.Time
+	SettlementTime  *time.Time
+	Refunds         []MidtransRefund
+}
+
+// statusGateway is implemented by gateways whose transactions can be looked
+// up from our side, so a missed webhook can be made up for
+type statusGateway interface {
+	CheckStatus(reference string) (*GatewayTransaction, error)
+}
+
+// expirableGateway is implemented by gateways whose transactions can also be
+// closed from our side, so a missed webhook does not leave a payment pending
+// forever
+type expirableGateway interface {
+	statusGateway
+	Expire(reference string) error
+}
+
+// refundableGateway is implemented by gateways that can give money back on a
+// settled transaction. ValidateRefund applies the gateway's rules before
+// anything is recorded. The refund key makes retries safe: the gateway
+// refunds a key once.
+type refundableGateway interface {
+	ValidateRefund(payment *models.Payment, amount float64) error
+	Refund(reference, refundKey string, amount float64, reason string) error
+}
+
+// midtransRefundTypes lists the Midtrans payment types that can be refunded
+// through the API and whether they take partial refunds. Bank transfers and
+// convenience store payments have to be refunded by hand.
+var midtransRefundTypes = map[string]bool{
+	"credit_card": true,
+	"gopay":       true,
+	"shopeepay":   true,
+	"qris":        true,
+	"akulaku":     false,
+	"kredivo":     false,
+}
+
+func newPaymentGateway(config PaymentConfig, logger *slog.Logger) PaymentGateway {
+	switch config.Provider {
+	case models.PaymentMethodStripe:
+		return &stripeGateway{
+			client:      utils.NewStripeClient(config.StripeSecretKey),
+			frontendURL: config.FrontendURL,
+			logger:      logger,
+		}
+	case models.PaymentMethodXendit:
+		return &xenditGateway{
+			client:      utils.NewXenditClient(config.XenditSecretKey),
+			frontendURL: config.FrontendURL,
+			logger:      logger,
+		}
+	}
+
+	env := midtrans.Sandbox
+	if config.MidtransEnvironment == "production" {
+		env = midtrans.Production
+	}
+	returnURL := config.FrontendURL + "/orders/track/" + snapOrderIDPlaceholder
+	gateway := &midtransGateway{
+		callbacks: snapCallbacks{
+			Finish:   cmp.Or(config.MidtransFinishURL, returnURL+"?payment=success"),
+			Unfinish: cmp.Or(config.MidtransUnfinishURL, returnURL+"?payment=pending"),
+			Error:    cmp.Or(config.MidtransErrorURL, returnURL+"?payment=failed"),
+		},
+		logger: logger,
+	}
+	gateway.client.New(config.MidtransServerKey, env)
+	gateway.core.New(config.MidtransServerKey, env)
+	return gateway
+}
+
+// midtransCurrency is the only currency Midtrans charges in
+const midtransCurrency = "IDR"
+
+// snapOrderIDPlaceholder marks where the order's ID goes in Snap redirect URLs
+const snapOrderIDPlaceholder = "{order_id}"
+
+// midtransGateway pays through Midtrans Snap and manages the resulting
+// transactions through the Core API
+type midtransGateway struct {
+	client    snap.Client
+	core      coreapi.Client
+	callbacks snapCallbacks
+	logger    *slog.Logger
+}
+
+// snapCallbacks are the pages Snap redirects the customer to. The SDK's
+// snap.Callbacks only carries finish.
+type snapCallbacks struct {
+	Finish   string `json:"finish,omitempty"`
+	Unfinish string `json:"unfinish,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// snapRequest is a snap.Request with all three redirects
+type snapRequest struct {
+	*snap.Request
+	Callbacks *snapCallbacks `json:"callbacks,omitempty"`
+}
+
+func (g *midtransGateway) Provider() models.PaymentMethod {
+	return models.PaymentMethodMidtrans
+}
+
+func (g *midtransGateway) CreateCheckout(ctx context.Context, order *models.Order, reference string, expiresAt time.Time, enabledPayments []string) (*GatewayCheckout, error) {
+	if order.Currency != midtransCurrency {
+		return nil, ErrCurrencyNotSupported
+	}
+	requestID := utils.RequestIDFromContext(ctx)
+	snapReq := &snap.Request{
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  reference,
+			GrossAmt: midtransAmount(order.PaymentDue()),
+		},
+		Expiry: &snap.ExpiryDetails{
+			StartTime: time.Now().Format("2006-01-02 15:04:05 -0700"),
+			Unit:      "minute",
+			Duration:  max(int64(time.Until(expiresAt).Minutes()), 1),
+		},
+		EnabledPayments: func() []snap.SnapPaymentType {
+			if len(enabledPayments) == 0 {
+				return nil
+			}
+			types := make([]snap.SnapPaymentType, len(enabledPayments))
+			for i, payment := range enabledPayments {
+				types[i] = snap.SnapPaymentType(payment)
+			}
+			return types
+		}(),
+		CustomerDetail: &midtrans.CustomerDetails{
+			FName: order.CustomerName,
+			Email: func() string {
+				if order.User != nil {
+					return order.User.Email
+				}
+				return ""
+			}(),
+		},
+		// Midtrans echoes custom fields back in its notifications
+		CustomField1: requestID,
+	}
+
+	items, err := MidtransItemDetails(order)
+	if err != nil {
+		return nil, err
+	}
+	snapReq.Items = &items
+
+	callbacks := g.callbacks
+	for _, callback := range []*string{&callbacks.Finish, &callbacks.Unfinish, &callbacks.Error} {
+		*callback = strings.ReplaceAll(*callback, snapOrderIDPlaceholder, order.UUID.String())
+	}
+
+	body, err := json.Marshal(snapRequest{Request: snapReq, Callbacks: &callbacks})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+	snapResp := &snap.Response{}
+	midtransErr := g.client.HttpClient.Call(
+		http.MethodPost,
+		g.client.Env.SnapURL()+"/snap/v1/transactions",
+		&g.client.ServerKey,
+		g.client.Options,
+		bytes.NewBuffer(body),
+		snapResp,
+	)
+	if midtransErr != nil {
+		g.logger.ErrorContext(ctx, "Failed to create Snap transaction", "reference", reference, logging.OrderNumber(order.OrderNumber), logging.Err(midtransErr))
+		return nil, fmt.Errorf("failed to create payment: %w", midtransErr)
+	}
+
+	return &GatewayCheckout{Token: snapResp.Token, RedirectURL: snapResp.RedirectURL}, nil
+}
+
+// MidtransItemDetails lists what a Snap checkout charges for. Snap rejects a
+// checkout whose items do not add up to the gross amount, so a deposit or
+// balance payment is a single line, and a full payment lists the items
+// followed by every charge and deduction on the order. A last line takes up
+// whatever rounding the items' prices leave.
+func MidtransItemDetails(order *models.Order) ([]midtrans.ItemDetails, error) {
+	gross := midtransAmount(order.PaymentDue())
+	if order.DepositAmount > 0 {
+		line := midtrans.ItemDetails{ID: "DEPOSIT", Name: "Deposit for order " + order.OrderNumber, Price: gross, Qty: 1}
+		if order.Status == models.OrderStatusDepositPaid {
+			line = midtrans.ItemDetails{ID: "BALANCE", Name: "Balance for order " + order.OrderNumber, Price: gross, Qty: 1}
+		}
+		return []midtrans.ItemDetails{line}, nil
+	}
+
+	var items []midtrans.ItemDetails
+	var sum int64
+	add := func(item midtrans.ItemDetails) {
+		items = append(items, item)
+		sum += item.Price * int64(item.Qty)
+	}
+	for _, item := range order.Items {
+		quantity := item.Quantity
+		if quantity > 2147483647 || quantity < 0 {
+			return nil, fmt.Errorf("invalid quantity for item %s", item.ProductName)
+		}
+		add(midtrans.ItemDetails{
+			ID:    item.UUID.String(),
+			Name:  item.ProductName,
+			Price: midtransAmount(item.UnitPrice),
+			Qty:   int32(quantity),
+		})
+	}
+
+	adjustments := []struct {
+		id, name string
+		amount   float64
+	}{
+		{"DISCOUNT", "Discount", -order.Discount},
+		{"SERVICE_CHARGE", "Service charge", order.ServiceCharge},
+		{"TAX", "Tax", order.Tax},
+		{"SOURCE_FEE", "Platform fee", order.SourceFee},
+		{"DELIVERY_FEE", "Delivery fee", order.DeliveryFee},
+		{"DELIVERY_DISCOUNT", "Delivery discount", -order.DeliveryDiscount},
+		{"TIP", "Tip", order.TipAmount},
+		{"GIFT_CARD", "Gift card", -order.GiftCardAmount},
+		{"POINTS", "Loyalty points", -order.PointsAmount},
+	}
+	for _, adjustment := range adjustments {
+		if amount := midtransAmount(adjustment.amount); amount != 0 {
+			add(midtrans.ItemDetails{ID: adjustment.id, Name: adjustment.name, Price: amount, Qty: 1})
+		}
+	}
+
+	if sum != gross {
+		add(midtrans.ItemDetails{ID: "ROUNDING", Name: "Rounding", Price: gross - sum, Qty: 1})
+	}
+	return items, nil
+}
+
+// midtransAmount converts an amount to the whole rupiah Midtrans charges in
+func midtransAmount(amount float64) int64 {
+	return int64(math.Round(amount))
+}
+
+func (g *midtransGateway) ChargeQRIS(ctx context.Context, order *models.Order, reference string, expiresAt time.Time) (*GatewayCheckout, error) {
+	if order.Currency != midtransCurrency {
+		return nil, ErrCurrencyNotSupported
+	}
+	requestID := utils.RequestIDFromContext(ctx)
+	charge := &coreapi.ChargeReq{
+		PaymentType: coreapi.PaymentTypeQris,
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  reference,
+			GrossAmt: midtransAmount(order.PaymentDue()),
+		},
+		Qris: &coreapi.QrisDetails{Acquirer: "gopay"},
+		CustomExpiry: &coreapi.CustomExpiry{
+			OrderTime:      time.Now().Format("2006-01-02 15:04:05 -0700"),
+			ExpiryDuration: max(int(time.Until(expiresAt).Minutes()), 1),
+			Unit:           "minute",
+		},
+	}
+	if requestID != "" {
+		charge.CustomField1 = &requestID
+	}
+	resp, midtransErr := g.core.ChargeTransaction(charge)
+	if midtransErr != nil {
+		g.logger.ErrorContext(ctx, "Failed to create QRIS charge", "reference", reference, logging.OrderNumber(order.OrderNumber), logging.Err(midtransErr))
+		return nil, fmt.Errorf("failed to create payment: %w", midtransErr)
+	}
+	// Midtrans reports rejected charges in the body with an HTTP 200
+	if resp.StatusCode != "201" || resp.QRString == "" {
+		return nil, fmt.Errorf("failed to create payment: %s %s", resp.StatusCode, resp.StatusMessage)
+	}
+
+	checkout := &GatewayCheckout{Token: resp.QRString}
+	for _, action := range resp.Actions {
+		if action.Name == "generate-qr-code" {
+			checkout.RedirectURL = action.URL
+		}
+	}
+	return checkout, nil
+}
+
+func (g *midtransGateway) CheckStatus(reference string) (*GatewayTransaction, error) {
+	resp, midtransErr := g.core.CheckTransaction(reference)
+	if midtransErr != nil {
+		if midtransErr.GetStatusCode() == 404 {
+			return nil, ErrGatewayTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to check payment status: %w", midtransErr)
+	}
+	// Midtrans reports some errors in the body with an HTTP 200
+	if resp.StatusCode == "404" {
+		return nil, ErrGatewayTransactionNotFound
+	}
+
+	grossAmount, err := strconv.ParseFloat(resp.GrossAmount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check payment status: invalid gross amount %q", resp.GrossAmount)
+	}
+
+	tx := &GatewayTransaction{
+		Status:        models.TransactionStatus(resp.TransactionStatus),
+		FraudStatus:   models.FraudStatus(resp.FraudStatus),
+		TransactionID: resp.TransactionID,
+		PaymentType:   resp.PaymentType,
+		StatusMessage: resp.StatusMessage,
+		GrossAmount:   grossAmount,
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", resp.TransactionTime); err == nil {
+		tx.TransactionTime = &t
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", resp.SettlementTime); err == nil {
+		tx.SettlementTime = &t
+	}
+	for _, refund := range resp.Refunds {
+		tx.Refunds = append(tx.Refunds, MidtransRefund{
+			RefundKey:    refund.RefundKey,
+			RefundAmount: refund.RefundAmount,
+			Reason:       refund.Reason,
+			CreatedAt:    refund.CreatedAt,
+		})
+	}
+	return tx, nil
+}
+
+func (g *midtransGateway) Expire(reference string) error {
+	_, midtransErr := g.core.ExpireTransaction(reference)
+	if midtransErr != nil {
+		if midtransErr.GetStatusCode() == 404 {
+			return ErrGatewayTransactionNotFound
+		}
+		return fmt.Errorf("failed to expire payment: %w", midtransErr)
+	}
+	return nil
+}
+
+func (g *midtransGateway) ValidateRefund(payment *models.Payment, amount float64) error {
+	// Midtrans charges IDR, which has no minor unit
+	if amount != math.Trunc(amount) {
+		return ErrInvalidRefundAmount
+	}
+	// Payments settled without a notification have no known type; leave
+	// those to Midtrans
+	if payment.PaymentType == nil {
+		return nil
+	}
+	partial, ok := midtransRefundTypes[*payment.PaymentType]
+	if !ok {
+		return ErrRefundNotSupported
+	}
+	if !partial && amount < payment.GrossAmount {
+		return ErrPartialRefundNotSupported
+	}
+	return nil
+}
+
+func (g *midtransGateway) Refund(reference, refundKey string, amount float64, reason string) error {
+	resp, midtransErr := g.core.RefundTransaction(reference, &coreapi.RefundReq{
+		RefundKey: refundKey,
+		Amount:    int64(amount),
+		Reason:    reason,
+	})
+	if midtransErr != nil {
+		return fmt.Errorf("failed to refund payment: %w", midtransErr)
+	}
+	// Midtrans reports rejected refunds in the body with an HTTP 200
+	if resp.StatusCode != "200" {
+		return fmt.Errorf("failed to refund payment: %s %s", resp.StatusCode, resp.StatusMessage)
+	}
+	return nil
+}
+
+// stripeGateway pays through Stripe Checkout. The order is charged as a
+// single line so discounts, tax and tip add up to the amount due exactly.
+type stripeGateway struct {
+	client      *utils.StripeClient
+	frontendURL string
+	logger      *slog.Logger
+}
+
+func (g *stripeGateway) Provider() models.PaymentMethod {
+	return models.PaymentMethodStripe
+}
+
+func (g *stripeGateway) CreateCheckout(ctx context.Context, order *models.Order, reference string, expiresAt time.Time, enabledPayments []string) (*GatewayCheckout, error) {
+	returnURL := g.frontendURL + "/orders/track/" + order.UUID.String()
+
+	params := url.Values{}
+	params.Set("mode", "payment")
+	params.Set("client_reference_id", reference)
+	params.Set("metadata[order_number]", order.OrderNumber)
+	params.Set("success_url", returnURL+"?payment=success")
+	params.Set("cancel_url", returnURL+"?payment=cancelled")
+	// Stripe only accepts an expiry between 30 minutes and 24 hours away
+	expiry := min(max(time.Until(expiresAt), 30*time.Minute+time.Minute), 24*time.Hour)
+	params.Set("expires_at", fmt.Sprint(time.Now().Add(expiry).Unix()))
+	params.Set("line_items[0][quantity]", "1")
+	currency := strings.ToLower(order.Currency)
+	params.Set("line_items[0][price_data][currency]", currency)
+	params.Set("line_items[0][price_data][unit_amount]", fmt.Sprint(utils.StripeAmount(order.PaymentDue(), currency)))
+	params.Set("line_items[0][price_data][product_data][name]", "Order "+order.OrderNumber)
+	if order.User != nil && order.User.Email != "" {
+		params.Set("customer_email", order.User.Email)
+	} else if order.CustomerEmail != nil {
+		params.Set("customer_email", *order.CustomerEmail)
+	}
+
+	requestID := utils.RequestIDFromContext(ctx)
+	if requestID != "" {
+		params.Set("metadata[request_id]", requestID)
+	}
+
+	session, err := g.client.CreateCheckoutSession(ctx, params)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "Failed to create Stripe checkout session", "reference", reference, logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return &GatewayCheckout{Token: session.ID, RedirectURL: session.URL}, nil
+}
+
+// xenditGateway pays through a Xendit invoice, which offers the customer
+// e-wallets, virtual accounts and QRIS on one hosted page
+type xenditGateway struct {
+	client      *utils.XenditClient
+	frontendURL string
+	logger      *slog.Logger
+}
+
+func (g *xenditGateway) Provider() models.PaymentMethod {
+	return models.PaymentMethodXendit
+}
+
+func (g *xenditGateway) CreateCheckout(ctx context.Context, order *models.Order, reference string, expiresAt time.Time, enabledPayments []string) (*GatewayCheckout, error) {
+	returnURL := g.frontendURL + "/orders/track/" + order.UUID.String()
+
+	invoice := utils.XenditInvoiceRequest{
+		ExternalID:         reference,
+		Amount:             order.PaymentDue(),
+		Currency:           order.Currency,
+		Description:        "Order " + order.OrderNumber,
+		SuccessRedirectURL: returnURL + "?payment=success",
+		FailureRedirectURL: returnURL + "?payment=failed",
+	}
+	if order.User != nil && order.User.Email != "" {
+		invoice.PayerEmail = order.User.Email
+	} else if order.CustomerEmail != nil {
+		invoice.PayerEmail = *order.CustomerEmail
+	}
+	invoice.InvoiceDuration = max(int(time.Until(expiresAt).Seconds()), 1)
+
+	created, err := g.client.CreateInvoice(ctx, invoice)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "Failed to create Xendit invoice", "reference", reference, logging.OrderNumber(order.OrderNumber), logging.Err(err))
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return &GatewayCheckout{Token: created.ID, RedirectURL: created.InvoiceURL}, nil
+}
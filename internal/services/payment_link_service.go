@@ -0,0 +1,154 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPaymentLinkInvalid = errors.New("payment link is invalid or has expired")
+)
+
+// paymentLinkTokenBytes is the entropy of a payment link token; hex doubles
+// its length
+const paymentLinkTokenBytes = 16
+
+// PaymentLinkResponse is a link to send to the customer. The URL holds the
+// only copy of the token and cannot be retrieved later.
+type PaymentLinkResponse struct {
+	ID          uuid.UUID `json:"id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	OrderNumber string    `json:"order_number"`
+	Amount      float64   `json:"amount"`
+	URL         string    `json:"url"`
+	ExpiresAt   string    `json:"expires_at"`
+}
+
+type PaymentLinkService interface {
+	Create(orderUUID, staffUUID uuid.UUID) (*PaymentLinkResponse, error)
+	Open(token string) (string, error)
+}
+
+type paymentLinkService struct {
+	linkRepo       repositories.PaymentLinkRepository
+	orderRepo      repositories.OrderRepository
+	userRepo       repositories.UserRepository
+	paymentService PaymentService
+	apiURL         string
+	ttl            time.Duration
+}
+
+// NewPaymentLinkService creates the payment link service. Links point at
+// apiURL and last ttl, or until the order's payment deadline if that is
+// sooner.
+func NewPaymentLinkService(
+	linkRepo repositories.PaymentLinkRepository,
+	orderRepo repositories.OrderRepository,
+	userRepo repositories.UserRepository,
+	paymentService PaymentService,
+	apiURL string,
+	ttl time.Duration,
+) PaymentLinkService {
+	return &paymentLinkService{
+		linkRepo:       linkRepo,
+		orderRepo:      orderRepo,
+		userRepo:       userRepo,
+		paymentService: paymentService,
+		apiURL:         apiURL,
+		ttl:            ttl,
+	}
+}
+
+// Create issues a link that opens the payment page of a pending order, for
+// staff to send to a customer who ordered by phone
+func (s *paymentLinkService) Create(orderUUID, staffUUID uuid.UUID) (*PaymentLinkResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if !order.AwaitsPayment() {
+		return nil, ErrOrderNotAwaitingPayment
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
+	}
+
+	staff, err := s.userRepo.FindByUUID(staffUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	b := make([]byte, paymentLinkTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(b)
+
+	expiresAt := time.Now().Add(s.ttl)
+	if order.PaymentExpiresAt != nil && order.PaymentExpiresAt.Before(expiresAt) {
+		expiresAt = *order.PaymentExpiresAt
+	}
+
+	link := &models.PaymentLink{
+		OrderID:   order.ID,
+		TokenHash: hashPaymentLinkToken(token),
+		ExpiresAt: expiresAt,
+		CreatedBy: &staff.ID,
+	}
+	if err := s.linkRepo.Create(link); err != nil {
+		return nil, err
+	}
+
+	return &PaymentLinkResponse{
+		ID:          link.UUID,
+		OrderID:     order.UUID,
+		OrderNumber: order.OrderNumber,
+		Amount:      order.PaymentDue(),
+		URL:         s.apiURL + "/api/v1/pay/" + token,
+		ExpiresAt:   expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+// Open returns the payment page to send the customer to. Opening the link
+// again, after a chat app fetched a preview or the customer closed the page,
+// leads back to the same page, so the link keeps working until it expires or
+// the order no longer awaits payment.
+func (s *paymentLinkService) Open(token string) (string, error) {
+	link, err := s.linkRepo.FindByTokenHash(hashPaymentLinkToken(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentLinkNotFound) {
+			return "", ErrPaymentLinkInvalid
+		}
+		return "", err
+	}
+	if time.Now().After(link.ExpiresAt) || link.Order == nil {
+		return "", ErrPaymentLinkInvalid
+	}
+	if !link.Order.AwaitsPayment() {
+		return "", ErrOrderNotAwaitingPayment
+	}
+
+	checkout, err := s.paymentService.CreatePaymentToken(link.Order.UUID, CreatePaymentTokenRequest{})
+	if err != nil {
+		return "", err
+	}
+	return checkout.RedirectURL, nil
+}
+
+func hashPaymentLinkToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,20 +1,25 @@
 package services
 
 import (
+	"context"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/carllix/matchaciee-backend/internal/logging"
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/google/uuid"
-	"github.com/midtrans/midtrans-go"
-	"github.com/midtrans/midtrans-go/snap"
 	"gorm.io/datatypes"
 )
 
@@ -23,6 +28,46 @@ var (
 	ErrInvalidSignature     = errors.New("invalid signature")
 	ErrPaymentAlreadyExists = errors.New("payment already processed")
 	ErrInvalidAmount        = errors.New("invalid payment amount")
+	ErrPaymentExpired       = errors.New("payment deadline has passed")
+	ErrInvalidWebhook       = errors.New("invalid webhook payload")
+
+	ErrOrderNotAwaitingPayment = errors.New("order is not awaiting payment")
+	ErrCashTenderedTooLow      = errors.New("cash tendered is less than the amount due")
+	ErrPaymentNotRefundable    = errors.New("only settled payments can be refunded")
+	ErrRefundNotSupported      = errors.New("payment gateway does not support refunds")
+	ErrRefundExceedsBalance    = errors.New("refund exceeds the refundable balance")
+
+	ErrPartialRefundNotSupported = errors.New("payment method does not support partial refunds")
+	ErrInvalidRefundAmount       = errors.New("refund amount is not accepted by the payment gateway")
+	ErrStatusCheckNotSupported   = errors.New("payment status cannot be checked with the payment gateway")
+	ErrQRISNotSupported          = errors.New("payment gateway does not support QRIS charges")
+	ErrCancelNotSupported        = errors.New("payment cannot be cancelled with the payment gateway")
+	ErrPaymentNotCancellable     = errors.New("only pending payments can be cancelled")
+
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+
+	ErrGiftCardInvalid             = errors.New("gift card code is invalid or not active")
+	ErrGiftCardInsufficientBalance = errors.New("gift card balance is too low")
+	ErrGiftCardExceedsAmountDue    = errors.New("gift card amount exceeds the amount due")
+
+	ErrInsufficientPoints       = errors.New("insufficient loyalty points")
+	ErrPointsExceedAmountDue    = errors.New("points value exceeds the amount due")
+	ErrPointsRedemptionDisabled = errors.New("points cannot be spent on orders")
+)
+
+// checkoutReuseMargin is how much time a payment page must have left to be
+// reopened; one about to close is replaced
+const checkoutReuseMargin = time.Minute
+
+// paymentExpiryBatch caps how many stale payments one expiry run handles, so
+// a gateway outage does not stall the job
+const paymentExpiryBatch = 100
+
+// Gateway references with these prefixes top up a wallet or buy a gift card
+// rather than pay an order
+const (
+	walletTopUpPrefix      = "TOPUP-"
+	giftCardPurchasePrefix = "GIFT-"
 )
 
 type SnapResponse struct {
@@ -44,244 +89,2031 @@ type MidtransNotification struct {
 	FraudStatus       string  `json:"fraud_status"`
 	Currency          string  `json:"currency"`
 	SettlementTime    *string `json:"settlement_time,omitempty"`
+	// CustomField1 is the ID of the request that started the payment
+	CustomField1 string `json:"custom_field1,omitempty"`
+	// Refunds lists every refund on the transaction so far, on refund and
+	// partial_refund notifications
+	Refunds []MidtransRefund `json:"refunds,omitempty"`
+}
+
+type MidtransRefund struct {
+	RefundKey    string `json:"refund_key"`
+	RefundAmount string `json:"refund_amount"`
+	Reason       string `json:"reason"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// stripeEvent is the part of a Stripe webhook event the payment flow reads.
+// Only Checkout Session events are handled.
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object utils.StripeCheckoutSession `json:"object"`
+	} `json:"data"`
+}
+
+// CreatePaymentTokenRequest lets the customer add or change a tip when paying.
+// Leaving TipAmount out keeps the tip chosen at checkout.
+type CreatePaymentTokenRequest struct {
+	TipAmount *float64 `json:"tip_amount,omitempty" validate:"omitempty,gte=0,lte=1000000"`
+	// RequestID is set by the handler and passed on to the gateway
+	RequestID string `json:"-"`
 }
 
+// PaymentTokenResponse is the payment page to send the customer to. Reused is
+// set when an open page for the same amount was returned instead of a new one.
 type PaymentTokenResponse struct {
-	PaymentID   uuid.UUID `json:"payment_id"`
-	Token       string    `json:"token"`
-	RedirectURL string    `json:"redirect_url"`
+	PaymentID   uuid.UUID            `json:"payment_id"`
+	Provider    models.PaymentMethod `json:"provider"`
+	Token       string               `json:"token"`
+	RedirectURL string               `json:"redirect_url"`
+	Reused      bool                 `json:"reused"`
+}
+
+// QRISChargeResponse is a QRIS code for the kiosk to show. QRString is the
+// code's content for rendering it locally; QRImageURL is an image of it.
+type QRISChargeResponse struct {
+	PaymentID  uuid.UUID `json:"payment_id"`
+	QRString   string    `json:"qr_string"`
+	QRImageURL string    `json:"qr_image_url,omitempty"`
+	Amount     float64   `json:"amount"`
+	ExpiresAt  string    `json:"expires_at"`
+	Reused     bool      `json:"reused"`
+}
+
+// PaymentStatusResponse is what a kiosk polls while the customer pays
+type PaymentStatusResponse struct {
+	PaymentID         uuid.UUID                `json:"payment_id"`
+	TransactionStatus models.TransactionStatus `json:"transaction_status"`
+	OrderID           uuid.UUID                `json:"order_id"`
+	OrderStatus       models.OrderStatus       `json:"order_status"`
+	ExpiresAt         *string                  `json:"expires_at,omitempty"`
+}
+
+// PaymentResponse is a payment as listed for staff. Payments the gateway has
+// not reported on yet are shown as pending.
+type PaymentResponse struct {
+	ID                uuid.UUID                `json:"id"`
+	OrderID           uuid.UUID                `json:"order_id"`
+	OrderNumber       string                   `json:"order_number"`
+	Reference         string                   `json:"reference"`
+	Method            models.PaymentMethod     `json:"method"`
+	CheckoutChannel   *models.CheckoutChannel  `json:"checkout_channel,omitempty"`
+	PaymentType       *string                  `json:"payment_type,omitempty"`
+	TransactionID     *string                  `json:"transaction_id,omitempty"`
+	TransactionStatus models.TransactionStatus `json:"transaction_status"`
+	GrossAmount       float64                  `json:"gross_amount"`
+	GatewayFee        *float64                 `json:"gateway_fee,omitempty"`
+	TransactionTime   *string                  `json:"transaction_time,omitempty"`
+	SettlementTime    *string                  `json:"settlement_time,omitempty"`
+	ExpiresAt         *string                  `json:"expires_at,omitempty"`
+	CreatedAt         string                   `json:"created_at"`
+}
+
+type PaymentListResponse struct {
+	Payments []PaymentResponse `json:"payments"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
+
+// CashPaymentRequest records cash taken at the counter. The order must be
+// pending and the amount tendered must cover the amount due.
+type CashPaymentRequest struct {
+	AmountTendered float64 `json:"amount_tendered" validate:"required,gt=0,lte=100000000"`
+}
+
+type CashPaymentResponse struct {
+	PaymentID      uuid.UUID          `json:"payment_id"`
+	OrderID        uuid.UUID          `json:"order_id"`
+	OrderNumber    string             `json:"order_number"`
+	OrderStatus    models.OrderStatus `json:"order_status"`
+	AmountDue      float64            `json:"amount_due"`
+	AmountTendered float64            `json:"amount_tendered"`
+	Change         float64            `json:"change"`
+}
+
+// RefundPaymentRequest gives money back to the customer. Leave Amount out to
+// refund the whole remaining balance.
+type RefundPaymentRequest struct {
+	Amount *float64 `json:"amount,omitempty" validate:"omitempty,gt=0,lte=100000000"`
+	Reason string   `json:"reason" validate:"required,min=3,max=255"`
+}
+
+type RefundResponse struct {
+	ID                uuid.UUID                `json:"id"`
+	PaymentID         uuid.UUID                `json:"payment_id"`
+	OrderID           uuid.UUID                `json:"order_id"`
+	OrderNumber       string                   `json:"order_number"`
+	OrderStatus       models.OrderStatus       `json:"order_status"`
+	TransactionStatus models.TransactionStatus `json:"transaction_status"`
+	Amount            float64                  `json:"amount"`
+	RefundableBalance float64                  `json:"refundable_balance"`
+	Reason            string                   `json:"reason"`
+	CreatedAt         string                   `json:"created_at"`
+}
+
+// PaymentVerificationResponse reports what the gateway said about a payment
+// and where that left the order. Changed is false when the gateway agreed
+// with what was already recorded.
+// WalletTopUpRequest adds money to the member's wallet through the payment
+// gateway
+type WalletTopUpRequest struct {
+	Amount float64 `json:"amount" validate:"required,gte=10000,lte=10000000"`
+	// RequestID is set by the handler and passed on to the gateway
+	RequestID string `json:"-"`
+}
+
+// WalletTopUpResponse is the payment page for a top-up. The wallet is
+// credited when the gateway settles it.
+type WalletTopUpResponse struct {
+	ID          uuid.UUID            `json:"id"`
+	Reference   string               `json:"reference"`
+	Provider    models.PaymentMethod `json:"provider"`
+	Amount      float64              `json:"amount"`
+	RedirectURL string               `json:"redirect_url"`
+	ExpiresAt   string               `json:"expires_at"`
+}
+
+type WalletPaymentResponse struct {
+	PaymentID   uuid.UUID          `json:"payment_id"`
+	OrderID     uuid.UUID          `json:"order_id"`
+	OrderNumber string             `json:"order_number"`
+	OrderStatus models.OrderStatus `json:"order_status"`
+	Amount      float64            `json:"amount"`
+	Balance     float64            `json:"balance"`
+}
+
+type PaymentVerificationResponse struct {
+	PaymentID         uuid.UUID                 `json:"payment_id"`
+	OrderID           uuid.UUID                 `json:"order_id"`
+	OrderNumber       string                    `json:"order_number"`
+	PreviousStatus    *models.TransactionStatus `json:"previous_status,omitempty"`
+	TransactionStatus models.TransactionStatus  `json:"transaction_status"`
+	OrderStatus       models.OrderStatus        `json:"order_status"`
+	Changed           bool                      `json:"changed"`
+}
+
+type PaymentCancellationResponse struct {
+	PaymentID         uuid.UUID                `json:"payment_id"`
+	OrderID           uuid.UUID                `json:"order_id"`
+	OrderNumber       string                   `json:"order_number"`
+	TransactionStatus models.TransactionStatus `json:"transaction_status"`
+	OrderStatus       models.OrderStatus       `json:"order_status"`
 }
 
 type PaymentService interface {
-	CreatePaymentToken(orderUUID uuid.UUID) (*PaymentTokenResponse, error)
+	CreatePaymentToken(orderUUID uuid.UUID, req CreatePaymentTokenRequest) (*PaymentTokenResponse, error)
+	ChargeQRIS(orderUUID uuid.UUID, req CreatePaymentTokenRequest) (*QRISChargeResponse, error)
+	GetPaymentStatus(paymentUUID uuid.UUID) (*PaymentStatusResponse, error)
+	GetAll(filters repositories.PaymentFilters, page, limit int) (*PaymentListResponse, error)
+	RecordCashPayment(orderUUID, staffUUID uuid.UUID, req CashPaymentRequest) (*CashPaymentResponse, error)
+	PayWithWallet(orderUUID, userUUID uuid.UUID) (*WalletPaymentResponse, error)
+	CreateWalletTopUp(userUUID uuid.UUID, req WalletTopUpRequest) (*WalletTopUpResponse, error)
+	PurchaseGiftCard(userUUID uuid.UUID, req GiftCardPurchaseRequest) (*GiftCardPurchaseResponse, error)
+	RedeemGiftCard(orderUUID uuid.UUID, req RedeemGiftCardRequest) (*GiftCardRedemptionResponse, error)
+	RedeemPoints(orderUUID, userUUID uuid.UUID, req RedeemPointsRequest) (*PointsRedemptionResponse, error)
+	RefundPayment(paymentUUID, staffUUID uuid.UUID, req RefundPaymentRequest) (*RefundResponse, error)
 	ProcessWebhookNotification(notification *MidtransNotification) error
+	ProcessStripeWebhook(payload []byte, signature string, receivedAt time.Time) error
+	ProcessXenditCallback(payload []byte, callbackToken string) error
 	VerifySignature(orderID, statusCode, grossAmount, signatureKey string) bool
+	VerifyPayment(paymentUUID uuid.UUID) (*PaymentVerificationResponse, error)
+	CancelPayment(paymentUUID uuid.UUID) (*PaymentCancellationResponse, error)
+	ExpireStalePayments() (int, error)
 }
 
 type paymentService struct {
-	paymentRepo repositories.PaymentRepository
-	orderRepo   repositories.OrderRepository
-	snapClient  snap.Client
-	serverKey   string
+	paymentRepo     repositories.PaymentRepository
+	refundRepo      repositories.RefundRepository
+	orderRepo       repositories.OrderRepository
+	userRepo        repositories.UserRepository
+	reservationRepo repositories.StockReservationRepository
+	walletRepo      repositories.WalletRepository
+	giftCardRepo    repositories.GiftCardRepository
+	loyaltyRepo     repositories.LoyaltyRepository
+	settingsService SettingsService
+	alerts          AlertService
+	events          realtime.Broker
+	gateway         PaymentGateway
+	logger          *slog.Logger
+	config          PaymentConfig
 }
 
 func NewPaymentService(
 	paymentRepo repositories.PaymentRepository,
+	refundRepo repositories.RefundRepository,
 	orderRepo repositories.OrderRepository,
-	serverKey string,
-	clientKey string,
-	environment string,
+	userRepo repositories.UserRepository,
+	reservationRepo repositories.StockReservationRepository,
+	walletRepo repositories.WalletRepository,
+	giftCardRepo repositories.GiftCardRepository,
+	loyaltyRepo repositories.LoyaltyRepository,
+	settingsService SettingsService,
+	alerts AlertService,
+	events realtime.Broker,
+	logger *slog.Logger,
+	config PaymentConfig,
 ) PaymentService {
-	// Initialize Snap client
-	var snapClient snap.Client
-	if environment == "production" {
-		snapClient.New(serverKey, midtrans.Production)
-	} else {
-		snapClient.New(serverKey, midtrans.Sandbox)
+	return &paymentService{
+		paymentRepo:     paymentRepo,
+		refundRepo:      refundRepo,
+		orderRepo:       orderRepo,
+		userRepo:        userRepo,
+		reservationRepo: reservationRepo,
+		walletRepo:      walletRepo,
+		giftCardRepo:    giftCardRepo,
+		loyaltyRepo:     loyaltyRepo,
+		settingsService: settingsService,
+		alerts:          alerts,
+		events:          events,
+		gateway:         newPaymentGateway(config, logger),
+		logger:          logger,
+		config:          config,
 	}
+}
 
-	return &paymentService{
-		paymentRepo: paymentRepo,
-		orderRepo:   orderRepo,
-		snapClient:  snapClient,
-		serverKey:   serverKey,
+// CreatePaymentToken starts a hosted payment with the configured gateway for
+// the order total plus tip
+func (s *paymentService) CreatePaymentToken(orderUUID uuid.UUID, req CreatePaymentTokenRequest) (*PaymentTokenResponse, error) {
+	ctx := utils.WithRequestID(context.Background(), req.RequestID)
+	payment, reused, err := s.startCheckout(orderUUID, req.TipAmount, models.CheckoutChannelHosted,
+		func(order *models.Order, reference string, expiresAt time.Time) (*GatewayCheckout, error) {
+			paymentSettings, err := s.settingsService.GetPaymentSettings()
+			if err != nil {
+				return nil, err
+			}
+			return s.gateway.CreateCheckout(ctx, order, reference, expiresAt, paymentSettings.EnabledPayments)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return toPaymentTokenResponse(payment, reused), nil
+}
+
+// ChargeQRIS charges the order total plus tip as a QRIS code the kiosk shows
+// itself, without the hosted payment page. The kiosk polls GetPaymentStatus
+// until the webhook settles it.
+func (s *paymentService) ChargeQRIS(orderUUID uuid.UUID, req CreatePaymentTokenRequest) (*QRISChargeResponse, error) {
+	gateway, ok := s.gateway.(qrisGateway)
+	if !ok {
+		return nil, ErrQRISNotSupported
+	}
+
+	ctx := utils.WithRequestID(context.Background(), req.RequestID)
+	payment, reused, err := s.startCheckout(orderUUID, req.TipAmount, models.CheckoutChannelQRIS,
+		func(order *models.Order, reference string, expiresAt time.Time) (*GatewayCheckout, error) {
+			return gateway.ChargeQRIS(ctx, order, reference, expiresAt)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QRISChargeResponse{
+		PaymentID: payment.UUID,
+		Amount:    payment.GrossAmount,
+		Reused:    reused,
+	}
+	if payment.CheckoutToken != nil {
+		response.QRString = *payment.CheckoutToken
+	}
+	if payment.CheckoutURL != nil {
+		response.QRImageURL = *payment.CheckoutURL
+	}
+	if payment.ExpiresAt != nil {
+		response.ExpiresAt = payment.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return response, nil
+}
+
+// GetPaymentStatus reports the payment's status as last heard from the
+// gateway, with the order it pays for. A payment not yet reported on is
+// pending.
+func (s *paymentService) GetPaymentStatus(paymentUUID uuid.UUID) (*PaymentStatusResponse, error) {
+	payment, err := s.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	response := &PaymentStatusResponse{
+		PaymentID:         payment.UUID,
+		TransactionStatus: models.TransactionStatusPending,
+		ExpiresAt:         formatOptionalTime(payment.ExpiresAt),
+	}
+	if payment.TransactionStatus != nil {
+		response.TransactionStatus = *payment.TransactionStatus
+	}
+	if payment.Order != nil {
+		response.OrderID = payment.Order.UUID
+		response.OrderStatus = payment.Order.Status
+	}
+	return response, nil
+}
+
+func (s *paymentService) GetAll(filters repositories.PaymentFilters, page, limit int) (*PaymentListResponse, error) {
+	offset := (page - 1) * limit
+
+	payments, total, err := s.paymentRepo.FindAll(filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]PaymentResponse, len(payments))
+	for i := range payments {
+		responses[i] = toPaymentResponse(&payments[i])
 	}
+
+	return &PaymentListResponse{
+		Payments: responses,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+func toPaymentResponse(payment *models.Payment) PaymentResponse {
+	response := PaymentResponse{
+		ID:                payment.UUID,
+		Reference:         payment.MidtransOrderID,
+		Method:            payment.Method,
+		CheckoutChannel:   payment.CheckoutChannel,
+		PaymentType:       payment.PaymentType,
+		TransactionID:     payment.TransactionID,
+		TransactionStatus: models.TransactionStatusPending,
+		GrossAmount:       payment.GrossAmount,
+		GatewayFee:        payment.GatewayFee,
+		TransactionTime:   formatOptionalTime(payment.TransactionTime),
+		SettlementTime:    formatOptionalTime(payment.SettlementTime),
+		ExpiresAt:         formatOptionalTime(payment.ExpiresAt),
+		CreatedAt:         payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if payment.TransactionStatus != nil {
+		response.TransactionStatus = *payment.TransactionStatus
+	}
+	if payment.Order != nil {
+		response.OrderID = payment.Order.UUID
+		response.OrderNumber = payment.Order.OrderNumber
+	}
+	return response
 }
 
-func (s *paymentService) CreatePaymentToken(orderUUID uuid.UUID) (*PaymentTokenResponse, error) {
+// startCheckout opens a payment through the given channel with create and
+// records it. It reports whether an open payment was returned instead.
+func (s *paymentService) startCheckout(
+	orderUUID uuid.UUID,
+	tipAmount *float64,
+	channel models.CheckoutChannel,
+	create func(order *models.Order, reference string, expiresAt time.Time) (*GatewayCheckout, error),
+) (*models.Payment, bool, error) {
 	// Get order details
 	order, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrOrderNotFound) {
-			return nil, ErrOrderNotFound
+			return nil, false, ErrOrderNotFound
 		}
-		return nil, err
+		return nil, false, err
 	}
 
 	// Validate order status
-	if order.Status != models.OrderStatusPending {
-		return nil, fmt.Errorf("order must be in pending status to create payment")
+	if !order.AwaitsPayment() {
+		return nil, false, fmt.Errorf("order must be pending or holding a deposit to create payment")
+	}
+
+	if order.IsPaymentExpired() {
+		return nil, false, ErrPaymentExpired
 	}
 
-	// Generate unique Midtrans order ID
-	midtransOrderID := fmt.Sprintf("%s-%d", order.OrderNumber, time.Now().Unix())
+	// Generate a unique reference the gateway reports the payment back with
+	reference := fmt.Sprintf("%s-%d", order.OrderNumber, time.Now().Unix())
 
 	// Check if payment already exists for this order
 	existingPayments, err := s.paymentRepo.FindByOrderID(order.ID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
+	// An order holding a deposit has that payment settled already
 	for _, p := range existingPayments {
-		if p.TransactionStatus != nil && *p.TransactionStatus == models.TransactionStatusSettlement {
-			return nil, ErrPaymentAlreadyExists
-		}
-	}
-
-	// Prepare Snap request
-	req := &snap.Request{
-		TransactionDetails: midtrans.TransactionDetails{
-			OrderID:  midtransOrderID,
-			GrossAmt: int64(order.Total),
-		},
-		CustomerDetail: &midtrans.CustomerDetails{
-			FName: order.CustomerName,
-			Email: func() string {
-				if order.User != nil {
-					return order.User.Email
-				}
-				return ""
-			}(),
-		},
-	}
-
-	// Add item details
-	var items []midtrans.ItemDetails
-	for _, item := range order.Items {
-		quantity := item.Quantity
-		if quantity > 2147483647 || quantity < 0 {
-			return nil, fmt.Errorf("invalid quantity for item %s", item.ProductName)
-		}
-
-		items = append(items, midtrans.ItemDetails{
-			ID:    item.UUID.String(),
-			Name:  item.ProductName,
-			Price: int64(item.UnitPrice),
-			Qty:   int32(quantity),
-		})
+		if order.Status == models.OrderStatusPending &&
+			p.TransactionStatus != nil && *p.TransactionStatus == models.TransactionStatusSettlement {
+			return nil, false, ErrPaymentAlreadyExists
+		}
+	}
+
+	if tipAmount != nil {
+		tip := roundAmount(*tipAmount)
+		if tip != order.TipAmount {
+			if err = s.orderRepo.UpdateTip(order.ID, tip); err != nil {
+				return nil, false, fmt.Errorf("failed to save tip: %w", err)
+			}
+			order.TipAmount = tip
+		}
+	}
+
+	// A customer who closed the payment page gets the same page back. One
+	// for another amount, after the tip changed, or through another channel
+	// is closed so it cannot be paid as well.
+	for i := range existingPayments {
+		existing := &existingPayments[i]
+		if !existing.IsAwaitingPayment() || existing.Method != s.gateway.Provider() {
+			continue
+		}
+		if existing.CheckoutChannel != nil && *existing.CheckoutChannel == channel &&
+			existing.GrossAmount == order.PaymentDue() && existing.CheckoutToken != nil &&
+			existing.ExpiresAt != nil && time.Until(*existing.ExpiresAt) > checkoutReuseMargin {
+			return existing, true, nil
+		}
+		if err := s.closeCheckout(existing); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The hosted payment closes with the order's payment window
+	expiresAt := time.Now().Add(s.config.PaymentExpiry)
+	if order.PaymentExpiresAt != nil {
+		expiresAt = *order.PaymentExpiresAt
+	}
+
+	checkout, err := create(order, reference, expiresAt)
+	if err != nil {
+		return nil, false, err
 	}
-	req.Items = &items
 
-	// Create Snap transaction
-	snapResp, midtransErr := s.snapClient.CreateTransaction(req)
-	if midtransErr != nil {
-		log.Printf("Failed to create Snap transaction: %v", midtransErr)
-		return nil, fmt.Errorf("failed to create payment: %w", midtransErr)
+	// Payment is starting, so turn any checkout hold into a real stock
+	// deduction. This waits for the gateway so a refused checkout keeps the
+	// hold, and a page the stock cannot cover is closed before anyone pays.
+	if err = s.reservationRepo.ConsumeByOrderID(order.ID); err != nil {
+		if gateway, ok := s.gateway.(expirableGateway); ok {
+			if expireErr := gateway.Expire(reference); expireErr != nil && !errors.Is(expireErr, ErrGatewayTransactionNotFound) {
+				s.logger.Error("Failed to close payment page", "reference", reference, logging.OrderNumber(order.OrderNumber), logging.Err(expireErr))
+			}
+		}
+		if errors.Is(err, repositories.ErrInsufficientStock) {
+			return nil, false, ErrInsufficientStock
+		}
+		return nil, false, fmt.Errorf("failed to confirm stock reservation: %w", err)
 	}
 
 	// Create payment record
 	payment := &models.Payment{
 		OrderID:         order.ID,
-		MidtransOrderID: midtransOrderID,
-		GrossAmount:     order.Total,
+		MidtransOrderID: reference,
+		Method:          s.gateway.Provider(),
+		ExpiresAt:       &expiresAt,
+		GrossAmount:     order.PaymentDue(),
+		CheckoutChannel: &channel,
+		CheckoutToken:   &checkout.Token,
+		CheckoutURL:     &checkout.RedirectURL,
 		PaymentMetadata: datatypes.JSON("{}"),
 	}
 
 	err = s.paymentRepo.Create(payment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save payment: %w", err)
+		return nil, false, fmt.Errorf("failed to save payment: %w", err)
 	}
 
-	return &PaymentTokenResponse{
-		PaymentID:   payment.UUID,
-		Token:       snapResp.Token,
-		RedirectURL: snapResp.RedirectURL,
-	}, nil
+	return payment, false, nil
 }
 
-func (s *paymentService) ProcessWebhookNotification(notification *MidtransNotification) error {
-	// Verify signature
-	if !s.VerifySignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, notification.SignatureKey) {
-		log.Printf("Invalid signature for order: %s", notification.OrderID)
-		return ErrInvalidSignature
+// closeCheckout expires a payment page that is being replaced, at the gateway
+// where it supports that. It returns ErrPaymentAlreadyExists when the page
+// was paid in the meantime.
+func (s *paymentService) closeCheckout(payment *models.Payment) error {
+	if gateway, ok := s.gateway.(expirableGateway); ok {
+		if err := gateway.Expire(payment.MidtransOrderID); err != nil && !errors.Is(err, ErrGatewayTransactionNotFound) {
+			return err
+		}
 	}
 
-	// Find payment by Midtrans order ID
-	payment, err := s.paymentRepo.FindByMidtransOrderID(notification.OrderID)
+	ok, err := s.paymentRepo.UpdatePendingStatus(payment.ID, models.TransactionStatusExpire)
 	if err != nil {
-		if errors.Is(err, repositories.ErrPaymentNotFound) {
-			return ErrPaymentNotFound
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	if !ok {
+		return ErrPaymentAlreadyExists
+	}
+	s.logger.Info("Replaced payment page", paymentLogAttrs(payment)...)
+	return nil
+}
+
+// hasOpenCheckout reports whether the order has a payment other than exceptID
+// that the gateway may still take
+func (s *paymentService) hasOpenCheckout(orderID, exceptID uint) (bool, error) {
+	payments, err := s.paymentRepo.FindByOrderID(orderID)
+	if err != nil {
+		return false, err
+	}
+	for i := range payments {
+		if payments[i].ID != exceptID && payments[i].Method != models.PaymentMethodCash && payments[i].IsAwaitingPayment() {
+			return true, nil
 		}
-		return err
 	}
+	return false, nil
+}
 
-	// Verify gross amount matches
-	grossAmount, err := strconv.ParseFloat(notification.GrossAmount, 64)
+func toPaymentTokenResponse(payment *models.Payment, reused bool) *PaymentTokenResponse {
+	response := &PaymentTokenResponse{
+		PaymentID: payment.UUID,
+		Provider:  payment.Method,
+		Reused:    reused,
+	}
+	if payment.CheckoutToken != nil {
+		response.Token = *payment.CheckoutToken
+	}
+	if payment.CheckoutURL != nil {
+		response.RedirectURL = *payment.CheckoutURL
+	}
+	return response
+}
+
+// RecordCashPayment settles a pending order with cash taken at the counter and
+// sends it to the kitchen, the same way a Midtrans settlement does. For an
+// order with a deposit it takes the deposit, then the balance.
+func (s *paymentService) RecordCashPayment(orderUUID, staffUUID uuid.UUID, req CashPaymentRequest) (*CashPaymentResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
 	if err != nil {
-		return ErrInvalidAmount
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
 	}
 
-	if grossAmount != payment.GrossAmount {
-		log.Printf("Amount mismatch for order %s: expected %.2f, got %.2f", notification.OrderID, payment.GrossAmount, grossAmount)
-		return ErrInvalidAmount
+	if !order.AwaitsPayment() {
+		return nil, ErrOrderNotAwaitingPayment
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
 	}
 
-	// Parse transaction time
-	transactionTime, err := time.Parse("2006-01-02 15:04:05", notification.TransactionTime)
+	amountDue := order.PaymentDue()
+	tendered := roundAmount(req.AmountTendered)
+	if tendered < amountDue {
+		return nil, ErrCashTenderedTooLow
+	}
+	change := roundAmount(tendered - amountDue)
+
+	staff, err := s.userRepo.FindByUUID(staffUUID)
 	if err != nil {
-		log.Printf("Failed to parse transaction time: %v", err)
-		transactionTime = time.Now()
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
 
-	// Update payment with notification data
-	transactionStatus := models.TransactionStatus(notification.TransactionStatus)
-	fraudStatus := models.FraudStatus(notification.FraudStatus)
+	if err = s.reservationRepo.ConsumeByOrderID(order.ID); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to confirm stock reservation: %w", err)
+	}
 
-	payment.TransactionID = &notification.TransactionID
-	payment.TransactionStatus = &transactionStatus
-	payment.PaymentType = &notification.PaymentType
-	payment.TransactionTime = &transactionTime
-	payment.StatusMessage = &notification.StatusMessage
-	payment.FraudStatus = &fraudStatus
+	now := time.Now()
+	status := models.TransactionStatusSettlement
+	paymentType := string(models.PaymentMethodCash)
+	payment := &models.Payment{
+		OrderID:           order.ID,
+		MidtransOrderID:   fmt.Sprintf("CASH-%s-%d", order.OrderNumber, now.Unix()),
+		Method:            models.PaymentMethodCash,
+		GrossAmount:       amountDue,
+		PaymentType:       &paymentType,
+		TransactionStatus: &status,
+		TransactionTime:   &now,
+		SettlementTime:    &now,
+		PaymentMetadata:   datatypes.JSON("{}"),
+		CashTendered:      &tendered,
+		ChangeGiven:       &change,
+		RecordedBy:        &staff.ID,
+	}
+	next := order.StatusAfterPayment(amountDue)
+	if err := s.paymentRepo.CreateSettled(payment, order.Status, next); err != nil {
+		if errors.Is(err, repositories.ErrOrderNotAwaitingPayment) {
+			return nil, ErrOrderNotAwaitingPayment
+		}
+		return nil, fmt.Errorf("failed to save payment: %w", err)
+	}
+	s.logger.Info("Cash payment recorded", logging.OrderNumber(order.OrderNumber))
 
-	// Parse settlement time if present
-	if notification.SettlementTime != nil && *notification.SettlementTime != "" {
-		var settlementTime time.Time
-		settlementTime, err = time.Parse("2006-01-02 15:04:05", *notification.SettlementTime)
-		if err == nil {
-			payment.SettlementTime = &settlementTime
+	paid := *order
+	paid.Status = next
+	publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, &paid, order.Status)
+
+	return &CashPaymentResponse{
+		PaymentID:      payment.UUID,
+		OrderID:        order.UUID,
+		OrderNumber:    order.OrderNumber,
+		OrderStatus:    paid.Status,
+		AmountDue:      amountDue,
+		AmountTendered: tendered,
+		Change:         change,
+	}, nil
+}
+
+// PayWithWallet settles the member's own pending order from their wallet
+// balance and sends it to the kitchen, the same way a cash payment does
+func (s *paymentService) PayWithWallet(orderUUID, userUUID uuid.UUID) (*WalletPaymentResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
 		}
+		return nil, err
 	}
 
-	// Store full notification as metadata
-	metadataBytes, err := json.Marshal(notification)
+	user, err := s.userRepo.FindByUUID(userUUID)
 	if err != nil {
-		log.Printf("Failed to marshal notification metadata: %v", err)
-		payment.PaymentMetadata = datatypes.JSON("{}")
-	} else {
-		payment.PaymentMetadata = datatypes.JSON(metadataBytes)
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != user.ID {
+		return nil, ErrOrderAccessDenied
 	}
 
-	// Update payment record
-	if err := s.paymentRepo.Update(payment); err != nil {
-		return fmt.Errorf("failed to update payment: %w", err)
+	if !order.AwaitsPayment() {
+		return nil, ErrOrderNotAwaitingPayment
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
 	}
 
-	// Update order status based on transaction status
-	var newOrderStatus models.OrderStatus
-	shouldUpdateOrder := true
+	amountDue := order.PaymentDue()
+	balance, err := s.walletRepo.Balance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if balance < amountDue {
+		return nil, ErrInsufficientBalance
+	}
 
-	switch transactionStatus {
-	case models.TransactionStatusSettlement:
-		newOrderStatus = models.OrderStatusPreparing
-		log.Printf("Payment settled for order: %s", notification.OrderID)
-	case models.TransactionStatusPending:
-		shouldUpdateOrder = false
-		log.Printf("Payment pending for order: %s", notification.OrderID)
-	case models.TransactionStatusExpire, models.TransactionStatusCancel, models.TransactionStatusDeny:
-		newOrderStatus = models.OrderStatusCancelled
-		log.Printf("Payment failed for order: %s, status: %s", notification.OrderID, transactionStatus)
-	default:
-		shouldUpdateOrder = false
-		log.Printf("Unknown transaction status for order: %s, status: %s", notification.OrderID, transactionStatus)
+	if err = s.reservationRepo.ConsumeByOrderID(order.ID); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to confirm stock reservation: %w", err)
 	}
 
-	// Update order status
-	if shouldUpdateOrder && payment.Order != nil {
-		if err := s.orderRepo.UpdateStatus(payment.OrderID, newOrderStatus); err != nil {
-			log.Printf("Failed to update order status: %v", err)
-			return fmt.Errorf("failed to update order status: %w", err)
+	now := time.Now()
+	status := models.TransactionStatusSettlement
+	paymentType := string(models.PaymentMethodWallet)
+	payment := &models.Payment{
+		OrderID:           order.ID,
+		MidtransOrderID:   fmt.Sprintf("WALLET-%s-%d", order.OrderNumber, now.Unix()),
+		Method:            models.PaymentMethodWallet,
+		GrossAmount:       amountDue,
+		PaymentType:       &paymentType,
+		TransactionStatus: &status,
+		TransactionTime:   &now,
+		SettlementTime:    &now,
+		PaymentMetadata:   datatypes.JSON("{}"),
+	}
+	entry := &models.WalletTransaction{
+		UserID:  user.ID,
+		Type:    models.WalletTransactionPayment,
+		Amount:  -amountDue,
+		OrderID: &order.ID,
+	}
+	next := order.StatusAfterPayment(amountDue)
+	if err := s.walletRepo.PayOrder(entry, payment, order.Status, next); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrInsufficientBalance):
+			return nil, ErrInsufficientBalance
+		case errors.Is(err, repositories.ErrOrderNotAwaitingPayment):
+			return nil, ErrOrderNotAwaitingPayment
 		}
+		return nil, fmt.Errorf("failed to save payment: %w", err)
 	}
+	s.logger.Info("Wallet payment recorded", logging.OrderNumber(order.OrderNumber), logging.UserUUID(user.UUID))
 
-	return nil
+	paid := *order
+	paid.Status = next
+	publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, &paid, order.Status)
+
+	return &WalletPaymentResponse{
+		PaymentID:   payment.UUID,
+		OrderID:     order.UUID,
+		OrderNumber: order.OrderNumber,
+		OrderStatus: paid.Status,
+		Amount:      amountDue,
+		Balance:     entry.BalanceAfter,
+	}, nil
 }
 
-func (s *paymentService) VerifySignature(orderID, statusCode, grossAmount, signatureKey string) bool {
-	// Midtrans signature format: SHA512(order_id + status_code + gross_amount + server_key)
-	input := orderID + statusCode + grossAmount + s.serverKey
-	hash := sha512.Sum512([]byte(input))
-	expectedSignature := hex.EncodeToString(hash[:])
+// CreateWalletTopUp opens a payment page with the configured gateway for
+// money to add to the member's wallet. Its reference is marked as a top-up so
+// the webhook credits the wallet instead of looking for an order.
+func (s *paymentService) CreateWalletTopUp(userUUID uuid.UUID, req WalletTopUpRequest) (*WalletTopUpResponse, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.PaymentExpiry)
+	topUp := &models.WalletTopUp{
+		UUID:      uuid.New(),
+		UserID:    user.ID,
+		Reference: fmt.Sprintf("%s%d-%d", walletTopUpPrefix, user.ID, now.Unix()),
+		Method:    s.gateway.Provider(),
+		Amount:    roundAmount(req.Amount),
+		ExpiresAt: &expiresAt,
+	}
+
+	ctx := utils.WithRequestID(context.Background(), req.RequestID)
+	checkout, err := s.createItemCheckout(ctx, user, topUp.UUID, topUp.Reference, "Wallet top-up", topUp.Amount, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	topUp.CheckoutURL = &checkout.RedirectURL
+
+	if err := s.walletRepo.CreateTopUp(topUp); err != nil {
+		return nil, fmt.Errorf("failed to save top-up: %w", err)
+	}
+
+	return &WalletTopUpResponse{
+		ID:          topUp.UUID,
+		Reference:   topUp.Reference,
+		Provider:    topUp.Method,
+		Amount:      topUp.Amount,
+		RedirectURL: checkout.RedirectURL,
+		ExpiresAt:   expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+// createItemCheckout opens a payment page for a single item that is not an
+// order, such as a wallet top-up. The gateway sees it as an order of one item
+// under the given reference.
+func (s *paymentService) createItemCheckout(
+	ctx context.Context,
+	user *models.User,
+	id uuid.UUID,
+	reference, itemName string,
+	amount float64,
+	expiresAt time.Time,
+) (*GatewayCheckout, error) {
+	paymentSettings, err := s.settingsService.GetPaymentSettings()
+	if err != nil {
+		return nil, err
+	}
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return nil, err
+	}
+	checkoutOrder := &models.Order{
+		UUID:         id,
+		OrderNumber:  reference,
+		CustomerName: user.FullName,
+		User:         user,
+		Total:        amount,
+		Currency:     currency.Code,
+		Items: []models.OrderItem{{
+			UUID:        id,
+			ProductName: itemName,
+			Quantity:    1,
+			UnitPrice:   amount,
+			Subtotal:    amount,
+		}},
+	}
+	return s.gateway.CreateCheckout(ctx, checkoutOrder, reference, expiresAt, paymentSettings.EnabledPayments)
+}
+
+// isPrepaidReference reports whether a gateway reference tops up a wallet or
+// buys a gift card
+func isPrepaidReference(reference string) bool {
+	return strings.HasPrefix(reference, walletTopUpPrefix) || strings.HasPrefix(reference, giftCardPurchasePrefix)
+}
 
-	return expectedSignature == signatureKey
+// applyPrepaidStatus hands what the gateway reported about a top-up or gift
+// card purchase to its flow. amountMatches checks the amount the gateway
+// charged against the one asked for.
+func (s *paymentService) applyPrepaidStatus(
+	reference string,
+	status models.TransactionStatus,
+	paymentType, transactionID string,
+	amountMatches func(amount float64) bool,
+) error {
+	if strings.HasPrefix(reference, giftCardPurchasePrefix) {
+		return s.applyGiftCardPurchaseStatus(reference, status, paymentType, transactionID, amountMatches)
+	}
+	return s.applyTopUpStatus(reference, status, paymentType, transactionID, amountMatches)
+}
+
+// applyTopUpStatus records what the gateway reported about a wallet top-up
+// and credits the wallet when it settles
+func (s *paymentService) applyTopUpStatus(
+	reference string,
+	status models.TransactionStatus,
+	paymentType, transactionID string,
+	amountMatches func(amount float64) bool,
+) error {
+	topUp, err := s.walletRepo.FindTopUpByReference(reference)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWalletTopUpNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+
+	if !amountMatches(topUp.Amount) {
+		s.logger.Warn("Amount mismatch for top-up", "reference", reference, "expected", topUp.Amount)
+		s.alert(func() error { return s.alerts.AmountMismatch(reference, topUp.Amount) })
+		return ErrInvalidAmount
+	}
+
+	// A credited top-up is final; later reports such as Xendit's SETTLED
+	// after PAID change nothing
+	if topUp.CreditedAt != nil {
+		return nil
+	}
+
+	topUp.TransactionStatus = &status
+	topUp.PaymentType = &paymentType
+	topUp.TransactionID = &transactionID
+
+	if status != models.TransactionStatusSettlement {
+		if err := s.walletRepo.UpdateTopUp(topUp); err != nil {
+			return fmt.Errorf("failed to update top-up: %w", err)
+		}
+		s.logger.Info("Top-up updated", "reference", reference, "status", status)
+		s.alertIfFailed(reference, status)
+		return nil
+	}
+
+	credited, err := s.walletRepo.CreditTopUp(topUp)
+	if err != nil {
+		return fmt.Errorf("failed to credit top-up: %w", err)
+	}
+	if credited {
+		s.logger.Info("Top-up credited", "reference", reference)
+	}
+	return nil
+}
+
+// PurchaseGiftCard opens a payment page for a gift card bought by a member.
+// The card and its code are created straight away but stay pending, and
+// cannot be redeemed, until the gateway settles the purchase.
+func (s *paymentService) PurchaseGiftCard(userUUID uuid.UUID, req GiftCardPurchaseRequest) (*GiftCardPurchaseResponse, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	code, err := newGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.PaymentExpiry)
+	amount := roundAmount(req.Amount)
+	reference := fmt.Sprintf("%s%d-%d", giftCardPurchasePrefix, user.ID, now.Unix())
+	method := s.gateway.Provider()
+	card := &models.GiftCard{
+		UUID:           uuid.New(),
+		Code:           code,
+		InitialAmount:  amount,
+		Balance:        amount,
+		Status:         models.GiftCardStatusPending,
+		RecipientName:  req.RecipientName,
+		RecipientEmail: req.RecipientEmail,
+		PurchasedBy:    &user.ID,
+		Reference:      &reference,
+		Method:         &method,
+	}
+
+	ctx := utils.WithRequestID(context.Background(), req.RequestID)
+	checkout, err := s.createItemCheckout(ctx, user, card.UUID, reference, "Gift card", amount, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	card.CheckoutURL = &checkout.RedirectURL
+
+	if err := s.giftCardRepo.Create(card); err != nil {
+		return nil, fmt.Errorf("failed to save gift card: %w", err)
+	}
+
+	return &GiftCardPurchaseResponse{
+		GiftCard:    toGiftCardResponse(card),
+		RedirectURL: checkout.RedirectURL,
+		ExpiresAt:   expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+// RedeemGiftCard pays part or all of a pending order with a gift card.
+// Without an amount as much as the card and the order allow is taken. Open
+// payment pages for the old amount are closed first, so the order cannot be
+// paid in full as well. Once nothing is left to pay the order goes to the
+// kitchen, the same way a cash payment does.
+func (s *paymentService) RedeemGiftCard(orderUUID uuid.UUID, req RedeemGiftCardRequest) (*GiftCardRedemptionResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	if order.Status != models.OrderStatusPending {
+		return nil, ErrOrderNotAwaitingPayment
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
+	}
+
+	card, err := s.giftCardRepo.FindByCode(normalizeGiftCardCode(req.Code))
+	if err != nil {
+		if errors.Is(err, repositories.ErrGiftCardNotFound) {
+			return nil, ErrGiftCardInvalid
+		}
+		return nil, err
+	}
+	if card.Status != models.GiftCardStatusActive || card.Balance <= 0 {
+		return nil, ErrGiftCardInvalid
+	}
+
+	amountDue := order.AmountDue()
+	amount := math.Min(card.Balance, amountDue)
+	if req.Amount != nil {
+		amount = roundAmount(*req.Amount)
+		if amount > card.Balance {
+			return nil, ErrGiftCardInsufficientBalance
+		}
+		if amount > amountDue {
+			return nil, ErrGiftCardExceedsAmountDue
+		}
+	}
+
+	if err := s.closeOpenCheckouts(order.ID); err != nil {
+		return nil, err
+	}
+
+	// Covering the rest of the order settles it, so the stock is taken now
+	var settle *models.Payment
+	if amount == amountDue {
+		if err = s.reservationRepo.ConsumeByOrderID(order.ID); err != nil {
+			if errors.Is(err, repositories.ErrInsufficientStock) {
+				return nil, ErrInsufficientStock
+			}
+			return nil, fmt.Errorf("failed to confirm stock reservation: %w", err)
+		}
+
+		now := time.Now()
+		status := models.TransactionStatusSettlement
+		paymentType := string(models.PaymentMethodGiftCard)
+		settle = &models.Payment{
+			OrderID:           order.ID,
+			MidtransOrderID:   fmt.Sprintf("GC-%s-%d", order.OrderNumber, now.Unix()),
+			Method:            models.PaymentMethodGiftCard,
+			GrossAmount:       roundAmount(order.GiftCardAmount + amount),
+			PaymentType:       &paymentType,
+			TransactionStatus: &status,
+			TransactionTime:   &now,
+			SettlementTime:    &now,
+			PaymentMetadata:   datatypes.JSON("{}"),
+		}
+	}
+
+	redemption := &models.GiftCardRedemption{
+		GiftCardID: card.ID,
+		OrderID:    order.ID,
+		Amount:     amount,
+	}
+	if err := s.giftCardRepo.Redeem(redemption, settle); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrGiftCardNotActive):
+			return nil, ErrGiftCardInvalid
+		case errors.Is(err, repositories.ErrGiftCardInsufficientBalance):
+			return nil, ErrGiftCardInsufficientBalance
+		case errors.Is(err, repositories.ErrGiftCardExceedsAmountDue):
+			return nil, ErrGiftCardExceedsAmountDue
+		}
+		return nil, fmt.Errorf("failed to redeem gift card: %w", err)
+	}
+	s.logger.Info("Gift card redeemed", logging.OrderNumber(order.OrderNumber))
+
+	response := &GiftCardRedemptionResponse{
+		ID:          redemption.UUID,
+		OrderID:     order.UUID,
+		OrderNumber: order.OrderNumber,
+		OrderStatus: order.Status,
+		Code:        card.Code,
+		Amount:      amount,
+		CardBalance: roundAmount(card.Balance - amount),
+		AmountDue:   roundAmount(amountDue - amount),
+	}
+	if settle != nil {
+		paid := *order
+		paid.Status = models.OrderStatusPreparing
+		publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, &paid, order.Status)
+		response.OrderStatus = paid.Status
+		response.PaymentID = &settle.UUID
+	}
+	return response, nil
+}
+
+// RedeemPoints pays part or all of a member's pending order with their
+// loyalty points, at the point value in the loyalty settings. Like a gift
+// card, open payment pages for the old amount are closed first, and the order
+// goes to the kitchen once nothing is left to pay.
+func (s *paymentService) RedeemPoints(orderUUID, userUUID uuid.UUID, req RedeemPointsRequest) (*PointsRedemptionResponse, error) {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if order.UserID == nil || *order.UserID != user.ID {
+		return nil, ErrOrderAccessDenied
+	}
+
+	if order.Status != models.OrderStatusPending {
+		return nil, ErrOrderNotAwaitingPayment
+	}
+	if order.IsPaymentExpired() {
+		return nil, ErrPaymentExpired
+	}
+
+	settings, err := s.settingsService.GetLoyaltySettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.PointValue <= 0 {
+		return nil, ErrPointsRedemptionDisabled
+	}
+
+	amountDue := order.AmountDue()
+	amount := roundAmount(float64(req.Points) * settings.PointValue)
+	if amount > amountDue {
+		return nil, ErrPointsExceedAmountDue
+	}
+
+	balance, err := s.loyaltyRepo.Balance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if balance < req.Points {
+		return nil, ErrInsufficientPoints
+	}
+
+	if err := s.closeOpenCheckouts(order.ID); err != nil {
+		return nil, err
+	}
+
+	// Covering the rest of the order settles it, so the stock is taken now
+	var settle *models.Payment
+	if amount == amountDue {
+		if err = s.reservationRepo.ConsumeByOrderID(order.ID); err != nil {
+			if errors.Is(err, repositories.ErrInsufficientStock) {
+				return nil, ErrInsufficientStock
+			}
+			return nil, fmt.Errorf("failed to confirm stock reservation: %w", err)
+		}
+
+		now := time.Now()
+		status := models.TransactionStatusSettlement
+		paymentType := string(models.PaymentMethodPoints)
+		settle = &models.Payment{
+			OrderID:           order.ID,
+			MidtransOrderID:   fmt.Sprintf("PTS-%s-%d", order.OrderNumber, now.Unix()),
+			Method:            models.PaymentMethodPoints,
+			GrossAmount:       roundAmount(order.PointsAmount + amount),
+			PaymentType:       &paymentType,
+			TransactionStatus: &status,
+			TransactionTime:   &now,
+			SettlementTime:    &now,
+			PaymentMetadata:   datatypes.JSON("{}"),
+		}
+	}
+
+	entry := &models.PointTransaction{
+		UserID:  user.ID,
+		Type:    models.PointTransactionRedeem,
+		Points:  -req.Points,
+		OrderID: &order.ID,
+	}
+	if err := s.loyaltyRepo.Redeem(entry, amount, settle); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrInsufficientPoints):
+			return nil, ErrInsufficientPoints
+		case errors.Is(err, repositories.ErrPointsExceedAmountDue):
+			return nil, ErrPointsExceedAmountDue
+		}
+		return nil, fmt.Errorf("failed to redeem points: %w", err)
+	}
+	s.logger.Info("Points redeemed", logging.OrderNumber(order.OrderNumber))
+
+	response := &PointsRedemptionResponse{
+		OrderID:       order.UUID,
+		OrderNumber:   order.OrderNumber,
+		OrderStatus:   order.Status,
+		Points:        req.Points,
+		Amount:        amount,
+		PointsBalance: entry.BalanceAfter,
+		AmountDue:     roundAmount(amountDue - amount),
+	}
+	if settle != nil {
+		paid := *order
+		paid.Status = models.OrderStatusPreparing
+		publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, &paid, order.Status)
+		response.OrderStatus = paid.Status
+		response.PaymentID = &settle.UUID
+	}
+	return response, nil
+}
+
+// closeOpenCheckouts closes the order's payment pages that are still open,
+// before part of the order is paid another way
+func (s *paymentService) closeOpenCheckouts(orderID uint) error {
+	payments, err := s.paymentRepo.FindByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+	for i := range payments {
+		if payments[i].Method == s.gateway.Provider() && payments[i].IsAwaitingPayment() {
+			if err := s.closeCheckout(&payments[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyGiftCardPurchaseStatus records what the gateway reported about a gift
+// card purchase and activates the card when it settles
+func (s *paymentService) applyGiftCardPurchaseStatus(
+	reference string,
+	status models.TransactionStatus,
+	paymentType, transactionID string,
+	amountMatches func(amount float64) bool,
+) error {
+	card, err := s.giftCardRepo.FindByReference(reference)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGiftCardNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+
+	if !amountMatches(card.InitialAmount) {
+		s.logger.Warn("Amount mismatch for gift card purchase", "reference", reference, "expected", card.InitialAmount)
+		s.alert(func() error { return s.alerts.AmountMismatch(reference, card.InitialAmount) })
+		return ErrInvalidAmount
+	}
+
+	// An activated card is final; later reports change nothing
+	if card.Status != models.GiftCardStatusPending {
+		return nil
+	}
+
+	card.TransactionStatus = &status
+	card.PaymentType = &paymentType
+	card.TransactionID = &transactionID
+
+	if status != models.TransactionStatusSettlement {
+		if err := s.giftCardRepo.UpdatePurchase(card); err != nil {
+			return fmt.Errorf("failed to update gift card: %w", err)
+		}
+		s.logger.Info("Gift card purchase updated", "reference", reference, "status", status)
+		s.alertIfFailed(reference, status)
+		return nil
+	}
+
+	activated, err := s.giftCardRepo.Activate(card)
+	if err != nil {
+		return fmt.Errorf("failed to activate gift card: %w", err)
+	}
+	if activated {
+		s.logger.Info("Gift card purchase activated", "reference", reference)
+	}
+	return nil
+}
+
+// refundToWallet puts a refund of a wallet payment back in the wallet of the
+// member whose order it paid
+func (s *paymentService) refundToWallet(payment *models.Payment, refund *models.Refund) error {
+	if payment.Order == nil || payment.Order.UserID == nil {
+		return errors.New("order has no member to refund")
+	}
+	note := refund.Reason
+	return s.walletRepo.Post(&models.WalletTransaction{
+		UserID:  *payment.Order.UserID,
+		Type:    models.WalletTransactionRefund,
+		Amount:  refund.Amount,
+		OrderID: &payment.OrderID,
+		Note:    &note,
+	})
+}
+
+func (s *paymentService) ProcessWebhookNotification(notification *MidtransNotification) error {
+	// Verify signature
+	if !s.VerifySignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, notification.SignatureKey) {
+		s.logger.Warn("Invalid Midtrans signature", "reference", notification.OrderID)
+		s.alert(func() error { return s.alerts.SignatureFailed(models.PaymentMethodMidtrans) })
+		return ErrInvalidSignature
+	}
+
+	// Verify gross amount matches
+	grossAmount, err := strconv.ParseFloat(notification.GrossAmount, 64)
+	if err != nil {
+		return ErrInvalidAmount
+	}
+
+	if isPrepaidReference(notification.OrderID) {
+		return s.applyPrepaidStatus(notification.OrderID, models.TransactionStatus(notification.TransactionStatus),
+			notification.PaymentType, notification.TransactionID,
+			func(amount float64) bool { return grossAmount == amount })
+	}
+
+	// Find payment by Midtrans order ID
+	payment, err := s.paymentRepo.FindByMidtransOrderID(notification.OrderID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+
+	if grossAmount != payment.GrossAmount {
+		s.logger.Warn("Amount mismatch for order", paymentLogAttrs(payment, "expected", payment.GrossAmount, "got", grossAmount)...)
+		s.alert(func() error { return s.alerts.AmountMismatch(notification.OrderID, payment.GrossAmount) })
+		return ErrInvalidAmount
+	}
+
+	// Parse transaction time
+	transactionTime, err := time.Parse("2006-01-02 15:04:05", notification.TransactionTime)
+	if err != nil {
+		s.logger.Warn("Failed to parse transaction time", "reference", notification.OrderID, logging.Err(err))
+		transactionTime = time.Now()
+	}
+
+	// Update payment with notification data
+	transactionStatus := models.TransactionStatus(notification.TransactionStatus)
+	fraudStatus := models.FraudStatus(notification.FraudStatus)
+
+	payment.TransactionID = &notification.TransactionID
+	payment.TransactionStatus = &transactionStatus
+	payment.PaymentType = &notification.PaymentType
+	payment.TransactionTime = &transactionTime
+	payment.StatusMessage = &notification.StatusMessage
+	payment.FraudStatus = &fraudStatus
+	if transactionStatus == models.TransactionStatusSettlement {
+		s.recordGatewayFee(payment, nil)
+	}
+
+	// Parse settlement time if present
+	if notification.SettlementTime != nil && *notification.SettlementTime != "" {
+		var settlementTime time.Time
+		settlementTime, err = time.Parse("2006-01-02 15:04:05", *notification.SettlementTime)
+		if err == nil {
+			payment.SettlementTime = &settlementTime
+		}
+	}
+
+	// Store full notification as metadata
+	metadataBytes, err := json.Marshal(notification)
+	if err != nil {
+		s.logger.Error("Failed to marshal notification metadata", "reference", notification.OrderID, logging.Err(err))
+		payment.PaymentMetadata = datatypes.JSON("{}")
+	} else {
+		payment.PaymentMetadata = datatypes.JSON(metadataBytes)
+	}
+
+	// Update payment record
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	if transactionStatus == models.TransactionStatusRefund || transactionStatus == models.TransactionStatusPartialRefund {
+		s.recordMidtransRefunds(payment, notification.Refunds)
+	}
+	s.alertIfFailed(payment.MidtransOrderID, transactionStatus)
+
+	return s.applyTransactionStatus(payment, transactionStatus)
+}
+
+// recordMidtransRefunds saves the refunds a notification reports, including
+// ones made in the Midtrans dashboard. Refunds issued through the API are
+// already saved under the same key.
+func (s *paymentService) recordMidtransRefunds(payment *models.Payment, refunds []MidtransRefund) {
+	for _, refund := range refunds {
+		amount, err := strconv.ParseFloat(refund.RefundAmount, 64)
+		if err != nil || refund.RefundKey == "" {
+			s.logger.Warn("Skipping malformed refund", paymentLogAttrs(payment, "refund", refund)...)
+			continue
+		}
+		err = s.refundRepo.Create(&models.Refund{
+			PaymentID: payment.ID,
+			RefundKey: refund.RefundKey,
+			Amount:    amount,
+			Reason:    refund.Reason,
+		})
+		if err != nil {
+			s.logger.Error("Failed to record refund", paymentLogAttrs(payment, "refund_key", refund.RefundKey, logging.Err(err))...)
+		}
+	}
+}
+
+// ProcessStripeWebhook applies a signed Stripe Checkout event to the payment
+// it was started with. Events for other Stripe objects are acknowledged and
+// ignored. The signature timestamp is checked against receivedAt, so a stored
+// event can be replayed later.
+func (s *paymentService) ProcessStripeWebhook(payload []byte, signature string, receivedAt time.Time) error {
+	if err := utils.VerifyStripeSignature(payload, signature, s.config.StripeWebhookSecret, receivedAt); err != nil {
+		s.logger.Warn("Invalid Stripe webhook signature")
+		s.alert(func() error { return s.alerts.SignatureFailed(models.PaymentMethodStripe) })
+		return ErrInvalidSignature
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return ErrInvalidWebhook
+	}
+	session := event.Data.Object
+
+	var transactionStatus models.TransactionStatus
+	switch event.Type {
+	case "checkout.session.completed":
+		// Delayed methods such as bank debits complete unpaid and settle later
+		transactionStatus = models.TransactionStatusPending
+		if session.PaymentStatus == "paid" {
+			transactionStatus = models.TransactionStatusSettlement
+		}
+	case "checkout.session.async_payment_succeeded":
+		transactionStatus = models.TransactionStatusSettlement
+	case "checkout.session.async_payment_failed":
+		transactionStatus = models.TransactionStatusDeny
+	case "checkout.session.expired":
+		transactionStatus = models.TransactionStatusExpire
+	default:
+		s.logger.Debug("Ignoring Stripe event", "event_id", event.ID, "type", event.Type)
+		return nil
+	}
+
+	transactionID := session.ID
+	if session.PaymentIntent != "" {
+		transactionID = session.PaymentIntent
+	}
+
+	if isPrepaidReference(session.ClientReferenceID) {
+		return s.applyPrepaidStatus(session.ClientReferenceID, transactionStatus, "stripe_checkout", transactionID,
+			func(amount float64) bool { return session.AmountTotal == utils.StripeAmount(amount, session.Currency) })
+	}
+
+	payment, err := s.paymentRepo.FindByMidtransOrderID(session.ClientReferenceID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+
+	if session.AmountTotal != utils.StripeAmount(payment.GrossAmount, session.Currency) {
+		s.logger.Warn("Amount mismatch for order", paymentLogAttrs(payment, "expected", payment.GrossAmount, "got", session.AmountTotal, "currency", session.Currency)...)
+		s.alert(func() error { return s.alerts.AmountMismatch(session.ClientReferenceID, payment.GrossAmount) })
+		return ErrInvalidAmount
+	}
+	transactionTime := time.Unix(event.Created, 0)
+	paymentType := "stripe_checkout"
+
+	payment.TransactionID = &transactionID
+	payment.TransactionStatus = &transactionStatus
+	payment.PaymentType = &paymentType
+	payment.TransactionTime = &transactionTime
+	payment.StatusMessage = &event.Type
+	payment.PaymentMetadata = datatypes.JSON(payload)
+	if transactionStatus == models.TransactionStatusSettlement {
+		payment.SettlementTime = &transactionTime
+		s.recordGatewayFee(payment, nil)
+	}
+
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	s.alertIfFailed(payment.MidtransOrderID, transactionStatus)
+
+	return s.applyTransactionStatus(payment, transactionStatus)
+}
+
+// ProcessXenditCallback applies an invoice callback to the payment the
+// invoice was created for. Xendit authenticates callbacks with the
+// verification token from its dashboard instead of signing them.
+func (s *paymentService) ProcessXenditCallback(payload []byte, callbackToken string) error {
+	expected := s.config.XenditCallbackToken
+	if expected == "" || subtle.ConstantTimeCompare([]byte(callbackToken), []byte(expected)) != 1 {
+		s.logger.Warn("Invalid Xendit callback token")
+		s.alert(func() error { return s.alerts.SignatureFailed(models.PaymentMethodXendit) })
+		return ErrInvalidSignature
+	}
+
+	var invoice utils.XenditInvoice
+	if err := json.Unmarshal(payload, &invoice); err != nil {
+		return ErrInvalidWebhook
+	}
+
+	var transactionStatus models.TransactionStatus
+	switch invoice.Status {
+	case "PAID", "SETTLED":
+		transactionStatus = models.TransactionStatusSettlement
+	case "PENDING":
+		transactionStatus = models.TransactionStatusPending
+	case "EXPIRED":
+		transactionStatus = models.TransactionStatusExpire
+	default:
+		s.logger.Debug("Ignoring Xendit invoice", "invoice_id", invoice.ID, "status", invoice.Status)
+		return nil
+	}
+
+	paymentType := strings.ToLower(invoice.PaymentChannel)
+	if paymentType == "" {
+		paymentType = strings.ToLower(invoice.PaymentMethod)
+	}
+
+	if isPrepaidReference(invoice.ExternalID) {
+		return s.applyPrepaidStatus(invoice.ExternalID, transactionStatus, paymentType, invoice.ID,
+			func(amount float64) bool { return invoice.Amount == amount })
+	}
+
+	payment, err := s.paymentRepo.FindByMidtransOrderID(invoice.ExternalID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+
+	if invoice.Amount != payment.GrossAmount {
+		s.logger.Warn("Amount mismatch for order", paymentLogAttrs(payment, "expected", payment.GrossAmount, "got", invoice.Amount)...)
+		s.alert(func() error { return s.alerts.AmountMismatch(invoice.ExternalID, payment.GrossAmount) })
+		return ErrInvalidAmount
+	}
+
+	// Xendit reports PAID and later SETTLED for the same invoice; the order
+	// only moves on the first
+	alreadySettled := payment.TransactionStatus != nil && *payment.TransactionStatus == models.TransactionStatusSettlement
+
+	transactionTime, err := time.Parse(time.RFC3339, invoice.PaidAt)
+	if err != nil {
+		transactionTime = time.Now()
+	}
+
+	payment.TransactionID = &invoice.ID
+	payment.TransactionStatus = &transactionStatus
+	payment.PaymentType = &paymentType
+	payment.TransactionTime = &transactionTime
+	payment.StatusMessage = &invoice.Status
+	payment.PaymentMetadata = datatypes.JSON(payload)
+	if transactionStatus == models.TransactionStatusSettlement {
+		payment.SettlementTime = &transactionTime
+		s.recordGatewayFee(payment, invoice.FeesPaidAmount)
+	}
+
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	if alreadySettled {
+		return nil
+	}
+	s.alertIfFailed(payment.MidtransOrderID, transactionStatus)
+	return s.applyTransactionStatus(payment, transactionStatus)
+}
+
+// RefundPayment gives back part or all of a settled payment, through the
+// gateway it was paid with, as cash handed back at the counter, to the wallet
+// it was paid from, or back onto the gift cards or points redeemed. Without an
+// amount the remaining balance is refunded. Once nothing is left to refund,
+// an order still waiting to be handed over is cancelled; completed orders
+// stay completed.
+func (s *paymentService) RefundPayment(paymentUUID, staffUUID uuid.UUID, req RefundPaymentRequest) (*RefundResponse, error) {
+	payment, err := s.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	if payment.TransactionStatus == nil ||
+		(*payment.TransactionStatus != models.TransactionStatusSettlement && *payment.TransactionStatus != models.TransactionStatusPartialRefund) {
+		return nil, ErrPaymentNotRefundable
+	}
+
+	staff, err := s.userRepo.FindByUUID(staffUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	refunded, err := s.refundRepo.SumByPaymentID(payment.ID)
+	if err != nil {
+		return nil, err
+	}
+	balance := roundAmount(payment.GrossAmount - refunded)
+	amount := balance
+	if req.Amount != nil {
+		amount = roundAmount(*req.Amount)
+	}
+	if amount <= 0 || amount > balance {
+		return nil, ErrRefundExceedsBalance
+	}
+
+	// Gift card redemptions and points go back whole
+	if (payment.Method == models.PaymentMethodGiftCard || payment.Method == models.PaymentMethodPoints) && amount != balance {
+		return nil, ErrPartialRefundNotSupported
+	}
+
+	var gateway refundableGateway
+	if payment.Method != models.PaymentMethodCash && payment.Method != models.PaymentMethodWallet &&
+		payment.Method != models.PaymentMethodGiftCard && payment.Method != models.PaymentMethodPoints {
+		var ok bool
+		gateway, ok = s.gateway.(refundableGateway)
+		if !ok || payment.Method != s.gateway.Provider() {
+			return nil, ErrRefundNotSupported
+		}
+		if err := gateway.ValidateRefund(payment, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save the refund before calling the gateway so the balance is held
+	// while the gateway works on it
+	refund := &models.Refund{
+		PaymentID:  payment.ID,
+		RefundKey:  "RF-" + uuid.NewString(),
+		Amount:     amount,
+		Reason:     req.Reason,
+		RefundedBy: &staff.ID,
+	}
+	created, err := s.refundRepo.CreateWithinBalance(refund, payment.GrossAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save refund: %w", err)
+	}
+	if !created {
+		return nil, ErrRefundExceedsBalance
+	}
+
+	if gateway != nil {
+		if err := gateway.Refund(payment.MidtransOrderID, refund.RefundKey, refund.Amount, refund.Reason); err != nil {
+			if deleteErr := s.refundRepo.Delete(refund.ID); deleteErr != nil {
+				s.logger.Error("Failed to remove rejected refund", paymentLogAttrs(payment, "refund_key", refund.RefundKey, logging.Err(deleteErr))...)
+			}
+			return nil, err
+		}
+	}
+	if payment.Method == models.PaymentMethodWallet {
+		if err := s.refundToWallet(payment, refund); err != nil {
+			if deleteErr := s.refundRepo.Delete(refund.ID); deleteErr != nil {
+				s.logger.Error("Failed to remove rejected refund", paymentLogAttrs(payment, "refund_key", refund.RefundKey, logging.Err(deleteErr))...)
+			}
+			return nil, fmt.Errorf("failed to credit wallet: %w", err)
+		}
+	}
+	if payment.Method == models.PaymentMethodGiftCard {
+		if err := s.giftCardRepo.ReleaseByOrderID(payment.OrderID); err != nil {
+			if deleteErr := s.refundRepo.Delete(refund.ID); deleteErr != nil {
+				s.logger.Error("Failed to remove rejected refund", paymentLogAttrs(payment, "refund_key", refund.RefundKey, logging.Err(deleteErr))...)
+			}
+			return nil, fmt.Errorf("failed to return gift card balance: %w", err)
+		}
+	}
+	if payment.Method == models.PaymentMethodPoints {
+		if err := s.loyaltyRepo.ReleaseByOrderID(payment.OrderID); err != nil {
+			if deleteErr := s.refundRepo.Delete(refund.ID); deleteErr != nil {
+				s.logger.Error("Failed to remove rejected refund", paymentLogAttrs(payment, "refund_key", refund.RefundKey, logging.Err(deleteErr))...)
+			}
+			return nil, fmt.Errorf("failed to return points: %w", err)
+		}
+	}
+
+	status := models.TransactionStatusPartialRefund
+	if amount == balance {
+		status = models.TransactionStatusRefund
+	}
+	if err := s.paymentRepo.UpdateTransactionStatus(payment.ID, status); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+	payment.TransactionStatus = &status
+
+	if err := s.applyTransactionStatus(payment, status); err != nil {
+		return nil, err
+	}
+
+	response := &RefundResponse{
+		ID:                refund.UUID,
+		PaymentID:         payment.UUID,
+		TransactionStatus: status,
+		Amount:            refund.Amount,
+		RefundableBalance: roundAmount(balance - amount),
+		Reason:            refund.Reason,
+		CreatedAt:         refund.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if payment.Order != nil {
+		response.OrderID = payment.Order.UUID
+		response.OrderNumber = payment.Order.OrderNumber
+		response.OrderStatus = payment.Order.Status
+		if status == models.TransactionStatusRefund && payment.Order.AwaitsPickup() {
+			response.OrderStatus = models.OrderStatusCancelled
+		}
+	}
+	return response, nil
+}
+
+// alert tells admins about a payment problem without holding up the webhook
+// or request that found it
+func (s *paymentService) alert(send func() error) {
+	go func() {
+		if err := send(); err != nil {
+			s.logger.Error("Failed to send payment alert", logging.Err(err))
+		}
+	}()
+}
+
+// paymentLogAttrs describes a payment in a log line by its reference and,
+// when it was loaded with the payment, the order it pays for
+func paymentLogAttrs(payment *models.Payment, attrs ...any) []any {
+	attrs = append([]any{"reference", payment.MidtransOrderID}, attrs...)
+	if payment.Order != nil {
+		attrs = append(attrs, logging.OrderNumber(payment.Order.OrderNumber))
+	}
+	return attrs
+}
+
+// alertIfFailed tells admins when a gateway reports a payment as denied or
+// expired
+func (s *paymentService) alertIfFailed(reference string, status models.TransactionStatus) {
+	if status == models.TransactionStatusDeny || status == models.TransactionStatusExpire {
+		s.alert(func() error { return s.alerts.PaymentFailed(reference, status) })
+	}
+}
+
+// applyTransactionStatus moves the order along after a gateway reported the
+// payment's new status: settled orders go to the kitchen, failed and refunded
+// ones are cancelled and their stock returned
+func (s *paymentService) applyTransactionStatus(payment *models.Payment, transactionStatus models.TransactionStatus) error {
+	// Update order status based on transaction status
+	var newOrderStatus models.OrderStatus
+	shouldUpdateOrder := true
+
+	switch transactionStatus {
+	case models.TransactionStatusSettlement:
+		newOrderStatus = models.OrderStatusPreparing
+		if payment.Order != nil {
+			newOrderStatus = payment.Order.StatusAfterPayment(payment.GrossAmount)
+		}
+		s.logger.Info("Payment settled", paymentLogAttrs(payment)...)
+
+		// The customer may have paid an earlier token with a different tip, so
+		// record the tip that was actually paid. A deposit carries no tip.
+		if payment.Order != nil && newOrderStatus == models.OrderStatusPreparing && payment.GrossAmount != payment.Order.PaymentDue() {
+			var paidBefore float64
+			if payment.Order.Status == models.OrderStatusDepositPaid {
+				paidBefore = payment.Order.DepositAmount
+			}
+			tip := math.Max(payment.GrossAmount+paidBefore+payment.Order.GiftCardAmount+payment.Order.PointsAmount-payment.Order.Total, 0)
+			if err := s.orderRepo.UpdateTip(payment.OrderID, tip); err != nil {
+				s.logger.Error("Failed to record tip", paymentLogAttrs(payment, logging.Err(err))...)
+			}
+		}
+	case models.TransactionStatusPending:
+		shouldUpdateOrder = false
+		s.logger.Info("Payment pending", paymentLogAttrs(payment)...)
+	case models.TransactionStatusRefund:
+		newOrderStatus = models.OrderStatusCancelled
+		s.logger.Info("Payment refunded", paymentLogAttrs(payment)...)
+
+		// Orders already handed over stay completed, and cancelled ones stay cancelled
+		if payment.Order == nil || !payment.Order.AwaitsPickup() {
+			shouldUpdateOrder = false
+		}
+	case models.TransactionStatusPartialRefund:
+		shouldUpdateOrder = false
+		s.logger.Info("Payment partially refunded", paymentLogAttrs(payment)...)
+	case models.TransactionStatusExpire, models.TransactionStatusCancel, models.TransactionStatusDeny:
+		newOrderStatus = models.OrderStatusCancelled
+		s.logger.Info("Payment failed", paymentLogAttrs(payment, "status", transactionStatus)...)
+
+		// A failed attempt does not cancel an order that was paid another way,
+		// nor one still collecting payment through a page that replaced it
+		if payment.Order != nil && payment.Order.Status != models.OrderStatusPending {
+			shouldUpdateOrder = false
+		} else if payment.Order != nil {
+			replaced, err := s.hasOpenCheckout(payment.OrderID, payment.ID)
+			if err != nil {
+				return err
+			}
+			shouldUpdateOrder = !replaced
+		}
+	default:
+		shouldUpdateOrder = false
+		s.logger.Warn("Unknown transaction status", paymentLogAttrs(payment, "status", transactionStatus)...)
+	}
+
+	// Update order status
+	if shouldUpdateOrder && payment.Order != nil {
+		if err := s.orderRepo.UpdateStatus(payment.OrderID, newOrderStatus); err != nil {
+			s.logger.Error("Failed to update order status", paymentLogAttrs(payment, logging.Err(err))...)
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+
+		if newOrderStatus == models.OrderStatusCancelled {
+			if err := s.reservationRepo.ReleaseByOrderID(payment.OrderID); err != nil {
+				s.logger.Error("Failed to release stock reservations", paymentLogAttrs(payment, logging.Err(err))...)
+			}
+		}
+
+		updatedOrder := *payment.Order
+		updatedOrder.Status = newOrderStatus
+		publishOrderEvent(s.events, s.logger, OrderEventStatusChanged, &updatedOrder, payment.Order.Status)
+	}
+
+	return nil
+}
+
+// ExpireStalePayments closes gateway payments still pending past their expiry
+// and reports how many were expired. Where the gateway supports it the
+// transaction is checked first, so a payment whose webhook was missed is
+// settled instead, and then expired at the gateway so it can no longer be
+// paid. The order is cancelled too when CancelExpiredOrders is set.
+func (s *paymentService) ExpireStalePayments() (int, error) {
+	payments, err := s.paymentRepo.FindStalePending(time.Now(), paymentExpiryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range payments {
+		payment := &payments[i]
+		ok, err := s.expireStalePayment(payment)
+		if err != nil {
+			// Leave it for the next run rather than failing the rest
+			s.logger.Error("Failed to expire payment", paymentLogAttrs(payment, logging.Err(err))...)
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (s *paymentService) expireStalePayment(payment *models.Payment) (bool, error) {
+	// Payments started with another provider before a switch are only expired
+	// locally; that provider closes them on its own
+	gateway, canExpire := s.gateway.(expirableGateway)
+	if canExpire && payment.Method == s.gateway.Provider() {
+		tx, err := gateway.CheckStatus(payment.MidtransOrderID)
+		switch {
+		case errors.Is(err, ErrGatewayTransactionNotFound):
+			// The customer never opened the payment page
+		case err != nil:
+			return false, err
+		case tx.Status == models.TransactionStatusSettlement:
+			s.logger.Warn("Payment settled at the gateway without a webhook", paymentLogAttrs(payment)...)
+			_, err := s.applyGatewayTransaction(payment, tx)
+			return false, err
+		case tx.Status == models.TransactionStatusPending:
+			if err := gateway.Expire(payment.MidtransOrderID); err != nil && !errors.Is(err, ErrGatewayTransactionNotFound) {
+				return false, err
+			}
+		}
+	}
+
+	ok, err := s.paymentRepo.UpdatePendingStatus(payment.ID, models.TransactionStatusExpire)
+	if err != nil || !ok {
+		return false, err
+	}
+	s.logger.Info("Payment expired", paymentLogAttrs(payment)...)
+
+	if !s.config.CancelExpiredOrders {
+		return true, nil
+	}
+	return true, s.applyTransactionStatus(payment, models.TransactionStatusExpire)
+}
+
+// VerifyPayment asks the gateway for the payment's current status and applies
+// it the way the webhook would have. It unblocks an order whose webhook was
+// lost. Verifying a payment the gateway reports unchanged does nothing.
+func (s *paymentService) VerifyPayment(paymentUUID uuid.UUID) (*PaymentVerificationResponse, error) {
+	payment, err := s.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	// Cash payments and payments started with another provider before a
+	// switch cannot be looked up
+	gateway, ok := s.gateway.(statusGateway)
+	if !ok || payment.Method != s.gateway.Provider() {
+		return nil, ErrStatusCheckNotSupported
+	}
+
+	tx, err := gateway.CheckStatus(payment.MidtransOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.GrossAmount != payment.GrossAmount {
+		s.logger.Warn("Amount mismatch for order", paymentLogAttrs(payment, "expected", payment.GrossAmount, "got", tx.GrossAmount)...)
+		s.alert(func() error { return s.alerts.AmountMismatch(payment.MidtransOrderID, payment.GrossAmount) })
+		return nil, ErrInvalidAmount
+	}
+
+	var previous *models.TransactionStatus
+	if payment.TransactionStatus != nil {
+		status := *payment.TransactionStatus
+		previous = &status
+	}
+
+	changed, err := s.applyGatewayTransaction(payment, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PaymentVerificationResponse{
+		PaymentID:         payment.UUID,
+		PreviousStatus:    previous,
+		TransactionStatus: tx.Status,
+		Changed:           changed,
+	}
+	if payment.Order != nil {
+		order, err := s.orderRepo.FindByID(payment.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		response.OrderID = order.UUID
+		response.OrderNumber = order.OrderNumber
+		response.OrderStatus = order.Status
+	}
+	return response, nil
+}
+
+// CancelPayment closes a gateway payment the customer has not paid yet, so a
+// page left open after staff cancel the order cannot settle later. The
+// transaction is checked first: one that already settled is recorded as paid
+// and ErrPaymentAlreadyExists returned. The order is cancelled with the
+// payment unless it is being paid another way.
+func (s *paymentService) CancelPayment(paymentUUID uuid.UUID) (*PaymentCancellationResponse, error) {
+	payment, err := s.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	gateway, ok := s.gateway.(expirableGateway)
+	if !ok || payment.Method != s.gateway.Provider() {
+		return nil, ErrCancelNotSupported
+	}
+	if !payment.IsAwaitingPayment() {
+		return nil, ErrPaymentNotCancellable
+	}
+
+	tx, err := gateway.CheckStatus(payment.MidtransOrderID)
+	switch {
+	case errors.Is(err, ErrGatewayTransactionNotFound):
+		// The customer never opened the payment page
+	case err != nil:
+		return nil, err
+	case tx.Status == models.TransactionStatusSettlement:
+		s.logger.Info("Payment settled before it could be cancelled", paymentLogAttrs(payment)...)
+		if _, err := s.applyGatewayTransaction(payment, tx); err != nil {
+			return nil, err
+		}
+		return nil, ErrPaymentAlreadyExists
+	case tx.Status == models.TransactionStatusPending:
+		// Midtrans only cancels card authorisations; a pending transaction
+		// is closed by expiring it
+		if err := gateway.Expire(payment.MidtransOrderID); err != nil && !errors.Is(err, ErrGatewayTransactionNotFound) {
+			return nil, err
+		}
+	}
+
+	ok, err = s.paymentRepo.UpdatePendingStatus(payment.ID, models.TransactionStatusCancel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+	if !ok {
+		// A webhook recorded a result while the gateway was being called
+		return nil, ErrPaymentAlreadyExists
+	}
+	s.logger.Info("Payment cancelled", paymentLogAttrs(payment)...)
+
+	status := models.TransactionStatusCancel
+	payment.TransactionStatus = &status
+	if err := s.applyTransactionStatus(payment, status); err != nil {
+		return nil, err
+	}
+
+	response := &PaymentCancellationResponse{
+		PaymentID:         payment.UUID,
+		TransactionStatus: status,
+	}
+	if payment.Order != nil {
+		order, err := s.orderRepo.FindByID(payment.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		response.OrderID = order.UUID
+		response.OrderNumber = order.OrderNumber
+		response.OrderStatus = order.Status
+	}
+	return response, nil
+}
+
+// applyGatewayTransaction records a status fetched from the gateway and moves
+// the order along, as the webhook would have. It reports whether the status
+// changed; an unchanged status is left alone so the order is not moved twice.
+func (s *paymentService) applyGatewayTransaction(payment *models.Payment, tx *GatewayTransaction) (bool, error) {
+	if payment.TransactionStatus != nil && *payment.TransactionStatus == tx.Status {
+		return false, nil
+	}
+
+	now := time.Now()
+	status := tx.Status
+	fraudStatus := tx.FraudStatus
+	payment.TransactionStatus = &status
+	payment.FraudStatus = &fraudStatus
+	payment.TransactionTime = &now
+	if tx.TransactionTime != nil {
+		payment.TransactionTime = tx.TransactionTime
+	}
+	if tx.TransactionID != "" {
+		payment.TransactionID = &tx.TransactionID
+	}
+	if tx.PaymentType != "" {
+		payment.PaymentType = &tx.PaymentType
+	}
+	if tx.StatusMessage != "" {
+		payment.StatusMessage = &tx.StatusMessage
+	}
+	if tx.SettlementTime != nil {
+		payment.SettlementTime = tx.SettlementTime
+	} else if status == models.TransactionStatusSettlement {
+		payment.SettlementTime = &now
+	}
+	if status == models.TransactionStatusSettlement {
+		s.recordGatewayFee(payment, nil)
+	}
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return false, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	if status == models.TransactionStatusRefund || status == models.TransactionStatusPartialRefund {
+		s.recordMidtransRefunds(payment, tx.Refunds)
+	}
+
+	return true, s.applyTransactionStatus(payment, status)
+}
+
+// recordGatewayFee keeps what the gateway charged on a settled payment: the
+// fee it reported, or else the configured fee for the payment type. A fee
+// already recorded is only replaced by a reported one, and types without a
+// configured fee are left unknown.
+func (s *paymentService) recordGatewayFee(payment *models.Payment, reported *float64) {
+	if reported != nil {
+		fee := roundAmount(*reported)
+		payment.GatewayFee = &fee
+		return
+	}
+	if payment.GatewayFee != nil || payment.PaymentType == nil {
+		return
+	}
+	if fee, ok := s.config.GatewayFees.Fee(*payment.PaymentType, payment.GrossAmount); ok {
+		payment.GatewayFee = &fee
+	}
+}
+
+func (s *paymentService) VerifySignature(orderID, statusCode, grossAmount, signatureKey string) bool {
+	return midtransSignature(orderID, statusCode, grossAmount, s.config.MidtransServerKey) == signatureKey
+}
+
+// midtransSignature computes the signature Midtrans sends with a notification
+func midtransSignature(orderID, statusCode, grossAmount, serverKey string) string {
+	// Midtrans signature format: SHA512(order_id + status_code + gross_amount + server_key)
+	hash := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(hash[:])
 }
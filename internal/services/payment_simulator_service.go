@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSimulationNotSupported = errors.New("only Midtrans payments can be simulated")
+	ErrSimulationSandboxOnly  = errors.New("payments can only be simulated against the Midtrans sandbox")
+	ErrPaymentNotPending      = errors.New("payment is no longer pending")
+)
+
+// simulatedStatusCodes are the status codes Midtrans sends with each outcome
+var simulatedStatusCodes = map[models.TransactionStatus]string{
+	models.TransactionStatusSettlement: "200",
+	models.TransactionStatusCancel:     "202",
+	models.TransactionStatusExpire:     "202",
+	models.TransactionStatusDeny:       "202",
+}
+
+type SimulatePaymentRequest struct {
+	Status models.TransactionStatus `json:"status" validate:"required,oneof=settlement cancel expire deny"`
+}
+
+type PaymentSimulationResponse struct {
+	PaymentID         uuid.UUID                `json:"payment_id"`
+	OrderID           uuid.UUID                `json:"order_id"`
+	OrderNumber       string                   `json:"order_number"`
+	TransactionStatus models.TransactionStatus `json:"transaction_status"`
+	OrderStatus       models.OrderStatus       `json:"order_status"`
+}
+
+type PaymentSimulatorService interface {
+	Simulate(paymentUUID uuid.UUID, req SimulatePaymentRequest) (*PaymentSimulationResponse, error)
+}
+
+type paymentSimulatorService struct {
+	paymentRepo    repositories.PaymentRepository
+	orderRepo      repositories.OrderRepository
+	paymentService PaymentService
+	serverKey      string
+	environment    string
+}
+
+// NewPaymentSimulatorService creates the simulator for the Midtrans
+// environment the server key belongs to. It refuses to simulate anything
+// unless that is the sandbox.
+func NewPaymentSimulatorService(
+	paymentRepo repositories.PaymentRepository,
+	orderRepo repositories.OrderRepository,
+	paymentService PaymentService,
+	serverKey string,
+	environment string,
+) PaymentSimulatorService {
+	return &paymentSimulatorService{
+		paymentRepo:    paymentRepo,
+		orderRepo:      orderRepo,
+		paymentService: paymentService,
+		serverKey:      serverKey,
+		environment:    environment,
+	}
+}
+
+// Simulate signs a Midtrans notification for the payment and processes it as
+// if Midtrans had sent it, so the whole payment flow can be tested without
+// the sandbox. It is only wired up outside production with sandbox keys, and
+// checks the keys again so live ones can never sign a forged settlement.
+func (s *paymentSimulatorService) Simulate(paymentUUID uuid.UUID, req SimulatePaymentRequest) (*PaymentSimulationResponse, error) {
+	if s.environment != "sandbox" {
+		return nil, ErrSimulationSandboxOnly
+	}
+
+	payment, err := s.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPaymentNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	if payment.Method != models.PaymentMethodMidtrans {
+		return nil, ErrSimulationNotSupported
+	}
+	if !payment.IsAwaitingPayment() {
+		return nil, ErrPaymentNotPending
+	}
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	notification := &MidtransNotification{
+		TransactionTime:   now,
+		TransactionStatus: string(req.Status),
+		TransactionID:     uuid.NewString(),
+		StatusMessage:     "Simulated " + string(req.Status),
+		StatusCode:        simulatedStatusCodes[req.Status],
+		PaymentType:       "simulator",
+		OrderID:           payment.MidtransOrderID,
+		GrossAmount:       strconv.FormatFloat(payment.GrossAmount, 'f', 2, 64),
+		FraudStatus:       string(models.FraudStatusAccept),
+		Currency:          "IDR",
+	}
+	if req.Status == models.TransactionStatusSettlement {
+		notification.SettlementTime = &now
+	}
+	notification.SignatureKey = midtransSignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, s.serverKey)
+
+	if err := s.paymentService.ProcessWebhookNotification(notification); err != nil {
+		return nil, err
+	}
+
+	response := &PaymentSimulationResponse{
+		PaymentID:         payment.UUID,
+		TransactionStatus: req.Status,
+	}
+	if payment.Order != nil {
+		order, err := s.orderRepo.FindByID(payment.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		response.OrderID = order.UUID
+		response.OrderNumber = order.OrderNumber
+		response.OrderStatus = order.Status
+	}
+	return response, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+)
+
+type UpdateSourcePricingRequest struct {
+	PriceAdjustmentPercent float64 `json:"price_adjustment_percent" validate:"gte=-100,lte=500"`
+	FlatFee                float64 `json:"flat_fee" validate:"gte=0"`
+}
+
+type SourcePricingResponse struct {
+	OrderSource            models.OrderSource `json:"order_source"`
+	PriceAdjustmentPercent float64            `json:"price_adjustment_percent"`
+	FlatFee                float64            `json:"flat_fee"`
+}
+
+type PricingService interface {
+	GetAll() ([]SourcePricingResponse, error)
+	Update(source models.OrderSource, req UpdateSourcePricingRequest) (*SourcePricingResponse, error)
+}
+
+type pricingService struct {
+	pricingRepo repositories.SourcePricingRepository
+}
+
+func NewPricingService(pricingRepo repositories.SourcePricingRepository) PricingService {
+	return &pricingService{
+		pricingRepo: pricingRepo,
+	}
+}
+
+// GetAll lists every adjustable source, with unconfigured sources at list price
+func (s *pricingService) GetAll() ([]SourcePricingResponse, error) {
+	pricings, err := s.pricingRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	bySource := make(map[models.OrderSource]models.SourcePricing, len(pricings))
+	for _, pricing := range pricings {
+		bySource[pricing.OrderSource] = pricing
+	}
+
+	responses := make([]SourcePricingResponse, 0, len(models.OrderSources))
+	for _, source := range models.OrderSources {
+		if !source.IsPriceAdjustable() {
+			continue
+		}
+
+		pricing := bySource[source]
+		responses = append(responses, SourcePricingResponse{
+			OrderSource:            source,
+			PriceAdjustmentPercent: pricing.PriceAdjustmentPercent,
+			FlatFee:                pricing.FlatFee,
+		})
+	}
+
+	return responses, nil
+}
+
+func (s *pricingService) Update(source models.OrderSource, req UpdateSourcePricingRequest) (*SourcePricingResponse, error) {
+	if !source.IsPriceAdjustable() {
+		return nil, ErrInvalidOrderSource
+	}
+
+	pricing := &models.SourcePricing{
+		OrderSource:            source,
+		PriceAdjustmentPercent: req.PriceAdjustmentPercent,
+		FlatFee:                req.FlatFee,
+	}
+
+	if err := s.pricingRepo.Upsert(pricing); err != nil {
+		return nil, err
+	}
+
+	return &SourcePricingResponse{
+		OrderSource:            pricing.OrderSource,
+		PriceAdjustmentPercent: pricing.PriceAdjustmentPercent,
+		FlatFee:                pricing.FlatFee,
+	}, nil
+}
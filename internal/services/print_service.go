@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPrintJobNotFound   = errors.New("print job not found")
+	ErrPrintJobNotClaimed = errors.New("print job is not being printed")
+)
+
+const (
+	// printMaxAttempts is how often a ticket is handed to an agent before it
+	// is given up on
+	printMaxAttempts = 5
+	// printAckTimeout is how long an agent has to acknowledge a ticket before
+	// it is handed out again
+	printAckTimeout = 2 * time.Minute
+	// printRetention is how long printed tickets are kept for reprints
+	printRetention = 7 * 24 * time.Hour
+)
+
+// PrintJobResponse is a ticket for a print agent. Payload holds the ESC/POS
+// bytes, base64 encoded in JSON.
+type PrintJobResponse struct {
+	ID          uuid.UUID `json:"id"`
+	OrderNumber string    `json:"order_number"`
+	Station     string    `json:"station"`
+	Attempts    int       `json:"attempts"`
+	Payload     []byte    `json:"payload"`
+}
+
+// AckPrintJobRequest reports the outcome of a ticket. Failed tickets are
+// queued again until they run out of attempts.
+type AckPrintJobRequest struct {
+	Status models.PrintJobStatus `json:"status" validate:"required,oneof=printed failed"`
+	Error  *string               `json:"error,omitempty" validate:"omitempty,max=500"`
+}
+
+// PrintService queues ESC/POS tickets for paid orders and hands them to the
+// print agents at each station
+type PrintService interface {
+	Run(ctx context.Context)
+	Enqueue(orderUUID uuid.UUID) error
+	Next(station string) (*PrintJobResponse, error)
+	Ack(jobUUID uuid.UUID, req AckPrintJobRequest) error
+	CleanupPrinted() (int64, error)
+}
+
+type printService struct {
+	printJobRepo    repositories.PrintJobRepository
+	orderRepo       repositories.OrderRepository
+	settingsService SettingsService
+	receiptService  ReceiptService
+	events          realtime.Broker
+	logger          *slog.Logger
+	defaultStation  string
+	stations        map[models.OrderType]string
+}
+
+// NewPrintService routes tickets to the station configured for the order
+// type, or to defaultStation
+func NewPrintService(
+	printJobRepo repositories.PrintJobRepository,
+	orderRepo repositories.OrderRepository,
+	settingsService SettingsService,
+	receiptService ReceiptService,
+	events realtime.Broker,
+	logger *slog.Logger,
+	defaultStation string,
+	stations map[models.OrderType]string,
+) PrintService {
+	return &printService{
+		printJobRepo:    printJobRepo,
+		orderRepo:       orderRepo,
+		settingsService: settingsService,
+		receiptService:  receiptService,
+		events:          events,
+		logger:          logger,
+		defaultStation:  defaultStation,
+		stations:        stations,
+	}
+}
+
+// Run follows the order feed until ctx is cancelled and queues a ticket for
+// every order that is paid
+func (s *printService) Run(ctx context.Context) {
+	sub := s.events.Subscribe(OrdersTopic)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload, ok := <-sub.C:
+			if !ok {
+				return
+			}
+
+			var event OrderEvent
+			if err := json.Unmarshal(payload, &event); err != nil {
+				s.logger.Error("Failed to decode order event", logging.Err(err))
+				continue
+			}
+
+			if event.Type == OrderEventStatusChanged &&
+				event.Status == models.OrderStatusPreparing &&
+				(event.PreviousStatus == models.OrderStatusPending || event.PreviousStatus == models.OrderStatusDepositPaid) {
+				go func() {
+					if err := s.Enqueue(event.OrderID); err != nil {
+						s.logger.Error("Failed to queue ticket", logging.OrderNumber(event.OrderNumber), logging.Err(err))
+					}
+				}()
+			}
+		}
+	}
+}
+
+// Enqueue renders the order's ticket and queues it for its station. Every
+// instance sees the payment, so an order is queued once per station.
+func (s *printService) Enqueue(orderUUID uuid.UUID) error {
+	order, err := s.orderRepo.FindByUUID(orderUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderNotFound) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+
+	settings, err := s.settingsService.GetReceiptSettings()
+	if err != nil {
+		return err
+	}
+	ticket, err := s.receiptService.Render(order, *settings, ReceiptFormatESCPOS)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.printJobRepo.Create(&models.PrintJob{
+		OrderID: order.ID,
+		Station: s.stationFor(order.OrderType),
+		Status:  models.PrintJobStatusQueued,
+		Payload: ticket.Body,
+	})
+	return err
+}
+
+func (s *printService) stationFor(orderType models.OrderType) string {
+	if station := s.stations[orderType]; station != "" {
+		return station
+	}
+	return s.defaultStation
+}
+
+// Next hands the oldest waiting ticket of the station to the calling agent,
+// or nil when there is nothing to print. An empty station means the default
+// one.
+func (s *printService) Next(station string) (*PrintJobResponse, error) {
+	if station == "" {
+		station = s.defaultStation
+	}
+
+	job, err := s.printJobRepo.ClaimNext(station, time.Now().Add(-printAckTimeout), printMaxAttempts)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrintJobNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	response := &PrintJobResponse{
+		ID:       job.UUID,
+		Station:  job.Station,
+		Attempts: job.Attempts,
+		Payload:  job.Payload,
+	}
+	if job.Order != nil {
+		response.OrderNumber = job.Order.OrderNumber
+	}
+	return response, nil
+}
+
+// Ack records the outcome of a ticket the agent was handed
+func (s *printService) Ack(jobUUID uuid.UUID, req AckPrintJobRequest) error {
+	job, err := s.printJobRepo.FindByUUID(jobUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPrintJobNotFound) {
+			return ErrPrintJobNotFound
+		}
+		return err
+	}
+
+	var acked bool
+	if req.Status == models.PrintJobStatusPrinted {
+		acked, err = s.printJobRepo.Complete(job.ID)
+	} else {
+		reason := "print agent reported a failure"
+		if req.Error != nil && *req.Error != "" {
+			reason = *req.Error
+		}
+		acked, err = s.printJobRepo.Fail(job.ID, reason, job.Attempts < printMaxAttempts)
+	}
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return ErrPrintJobNotClaimed
+	}
+	return nil
+}
+
+// CleanupPrinted deletes tickets printed longer ago than the retention period
+func (s *printService) CleanupPrinted() (int64, error) {
+	return s.printJobRepo.DeletePrintedBefore(time.Now().Add(-printRetention))
+}
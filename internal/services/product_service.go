@@ -25,6 +25,7 @@ type CreateProductRequest struct {
 	IsAvailable     *bool                        `json:"is_available,omitempty"`
 	IsCustomizable  *bool                        `json:"is_customizable,omitempty"`
 	ImageURL        *string                      `json:"image_url,omitempty" validate:"omitempty,url"`
+	StockQuantity   *int                         `json:"stock_quantity,omitempty" validate:"omitempty,gte=0"`
 	Customizations  []CreateCustomizationRequest `json:"customizations,omitempty"`
 }
 
@@ -39,6 +40,8 @@ type UpdateProductRequest struct {
 	IsCustomizable  *bool      `json:"is_customizable,omitempty"`
 	PreparationTime *int       `json:"preparation_time,omitempty" validate:"omitempty,gt=0"`
 	DisplayOrder    *int       `json:"display_order,omitempty"`
+	StockQuantity   *int       `json:"stock_quantity,omitempty" validate:"omitempty,gte=0"`
+	ClearStockLimit bool       `json:"clear_stock_limit,omitempty"`
 }
 
 type CreateCustomizationRequest struct {
@@ -67,6 +70,7 @@ type ProductResponse struct {
 	IsAvailable     bool                    `json:"is_available"`
 	IsCustomizable  bool                    `json:"is_customizable"`
 	ImageURL        *string                 `json:"image_url,omitempty"`
+	StockQuantity   *int                    `json:"stock_quantity,omitempty"`
 	DeletedAt       *string                 `json:"deleted_at,omitempty"`
 	Customizations  []CustomizationResponse `json:"customizations,omitempty"`
 	CreatedAt       string                  `json:"created_at"`
@@ -88,6 +92,7 @@ type ProductService interface {
 	GetBySlug(slug string) (*ProductResponse, error)
 	GetAll(includeDeleted bool, availableOnly bool, categoryUUID *uuid.UUID) ([]ProductResponse, error)
 	Update(uuid uuid.UUID, req UpdateProductRequest) (*ProductResponse, error)
+	Reorder(req ReorderRequest) error
 	SoftDelete(uuid uuid.UUID) error
 	Restore(uuid uuid.UUID) error
 
@@ -166,6 +171,7 @@ func (s *productService) Create(req CreateProductRequest) (*ProductResponse, err
 		IsCustomizable:  isCustomizable,
 		PreparationTime: preparationTime,
 		DisplayOrder:    req.DisplayOrder,
+		StockQuantity:   req.StockQuantity,
 	}
 
 	err = s.productRepo.Create(product)
@@ -229,7 +235,8 @@ func (s *productService) GetAll(includeDeleted bool, availableOnly bool, categor
 		isAvailable = &available
 	}
 
-	var categoryID *uint
+	// Filtering by a category includes its subcategories
+	var categoryIDs []uint
 	if categoryUUID != nil {
 		category, err := s.categoryRepo.FindByUUID(*categoryUUID)
 		if err != nil {
@@ -238,10 +245,14 @@ func (s *productService) GetAll(includeDeleted bool, availableOnly bool, categor
 			}
 			return nil, err
 		}
-		categoryID = &category.ID
+
+		categoryIDs, err = s.categoryRepo.FindDescendantIDs(category.ID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	products, err := s.productRepo.FindAll(includeDeleted, isAvailable, categoryID)
+	products, err := s.productRepo.FindAll(includeDeleted, isAvailable, categoryIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -324,6 +335,15 @@ func (s *productService) Update(productUUID uuid.UUID, req UpdateProductRequest)
 		product.DisplayOrder = *req.DisplayOrder
 	}
 
+	if req.StockQuantity != nil {
+		product.StockQuantity = req.StockQuantity
+	}
+
+	// Removing the cap turns the product back into an unlimited item
+	if req.ClearStockLimit {
+		product.StockQuantity = nil
+	}
+
 	err = s.productRepo.Update(product)
 	if err != nil {
 		return nil, err
@@ -350,6 +370,17 @@ func (s *productService) SoftDelete(productUUID uuid.UUID) error {
 	return s.productRepo.SoftDelete(product.ID)
 }
 
+func (s *productService) Reorder(req ReorderRequest) error {
+	err := s.productRepo.Reorder(req.IDs)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProductNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	return nil
+}
+
 func (s *productService) Restore(productUUID uuid.UUID) error {
 	// Find the product including deleted ones
 	product, err := s.productRepo.FindByUUIDIncludingDeleted(productUUID)
@@ -451,6 +482,7 @@ func (s *productService) toProductResponse(product *models.Product) *ProductResp
 		IsCustomizable:  product.IsCustomizable,
 		PreparationTime: product.PreparationTime,
 		DisplayOrder:    product.DisplayOrder,
+		StockQuantity:   product.StockQuantity,
 		CreatedAt:       product.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       product.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPromotionNotFound       = errors.New("promotion not found")
+	ErrPromotionCodeExists     = errors.New("promotion code already exists")
+	ErrInvalidPromotionPeriod  = errors.New("promotion must expire after it starts")
+	ErrPromotionPercentTooHigh = errors.New("percentage discount cannot exceed 100")
+	ErrInvalidPromotionHours   = errors.New("promotion hours need a start and a different end time")
+)
+
+// promotionDays names the weekdays in the order of time.Weekday
+var promotionDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// PromotionRequest creates or fully replaces a promotion. Leave product_ids
+// and category_ids empty to discount the whole order. An automatic promotion
+// applies to qualifying orders without its code being entered. Days and the
+// start and end times, in store time, limit it to certain hours such as a
+// happy hour; leave them out for all day, every day. A free_delivery
+// promotion waives discount_value percent of the delivery fee once the order
+// reaches min_spend.
+type PromotionRequest struct {
+	Code             string              `json:"code" validate:"required,min=3,max=50,alphanum"`
+	Name             *string             `json:"name,omitempty" validate:"omitempty,max=60"`
+	Description      *string             `json:"description,omitempty" validate:"omitempty,max=500"`
+	DiscountType     models.DiscountType `json:"discount_type" validate:"required,oneof=percentage fixed free_delivery"`
+	DiscountValue    float64             `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount      *float64            `json:"max_discount,omitempty" validate:"omitempty,gt=0"`
+	MinSpend         float64             `json:"min_spend" validate:"gte=0"`
+	UsageLimit       *int                `json:"usage_limit,omitempty" validate:"omitempty,min=1"`
+	PerCustomerLimit *int                `json:"per_customer_limit,omitempty" validate:"omitempty,min=1"`
+	StartsAt         *time.Time          `json:"starts_at,omitempty"`
+	ExpiresAt        *time.Time          `json:"expires_at,omitempty"`
+	Days             []string            `json:"days,omitempty" validate:"omitempty,max=7,unique,dive,oneof=sun mon tue wed thu fri sat"`
+	StartTime        *string             `json:"start_time,omitempty" validate:"omitempty,datetime=15:04"`
+	EndTime          *string             `json:"end_time,omitempty" validate:"omitempty,datetime=15:04"`
+	IsActive         *bool               `json:"is_active,omitempty"`
+	Automatic        bool                `json:"automatic"`
+	Stackable        bool                `json:"stackable"`
+	ProductIDs       []uuid.UUID         `json:"product_ids,omitempty" validate:"omitempty,max=100,unique,dive,required"`
+	CategoryIDs      []uuid.UUID         `json:"category_ids,omitempty" validate:"omitempty,max=100,unique,dive,required"`
+}
+
+type PromotionScopeItem struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type PromotionResponse struct {
+	ID               uuid.UUID            `json:"id"`
+	Code             string               `json:"code"`
+	Name             *string              `json:"name,omitempty"`
+	Description      *string              `json:"description,omitempty"`
+	DiscountType     models.DiscountType  `json:"discount_type"`
+	DiscountValue    float64              `json:"discount_value"`
+	MaxDiscount      *float64             `json:"max_discount,omitempty"`
+	MinSpend         float64              `json:"min_spend"`
+	UsageLimit       *int                 `json:"usage_limit,omitempty"`
+	PerCustomerLimit *int                 `json:"per_customer_limit,omitempty"`
+	Redemptions      int64                `json:"redemptions"`
+	StartsAt         *string              `json:"starts_at,omitempty"`
+	ExpiresAt        *string              `json:"expires_at,omitempty"`
+	Days             []string             `json:"days"`
+	StartTime        *string              `json:"start_time,omitempty"`
+	EndTime          *string              `json:"end_time,omitempty"`
+	IsActive         bool                 `json:"is_active"`
+	Automatic        bool                 `json:"automatic"`
+	Stackable        bool                 `json:"stackable"`
+	Products         []PromotionScopeItem `json:"products"`
+	Categories       []PromotionScopeItem `json:"categories"`
+	CreatedAt        string               `json:"created_at"`
+	UpdatedAt        string               `json:"updated_at"`
+}
+
+// PromotionStatsResponse sums up the orders that applied a promotion and were
+// not cancelled. Customers counts members only.
+type PromotionStatsResponse struct {
+	PromotionID       uuid.UUID `json:"promotion_id"`
+	Code              string    `json:"code"`
+	Redemptions       int64     `json:"redemptions"`
+	Customers         int64     `json:"customers"`
+	DiscountGiven     float64   `json:"discount_given"`
+	Revenue           float64   `json:"revenue"`
+	AverageOrderValue float64   `json:"average_order_value"`
+	FirstRedeemedAt   *string   `json:"first_redeemed_at,omitempty"`
+	LastRedeemedAt    *string   `json:"last_redeemed_at,omitempty"`
+}
+
+type PromotionService interface {
+	Create(req PromotionRequest) (*PromotionResponse, error)
+	GetAll() ([]PromotionResponse, error)
+	GetByUUID(uuid uuid.UUID) (*PromotionResponse, error)
+	Update(uuid uuid.UUID, req PromotionRequest) (*PromotionResponse, error)
+	Delete(uuid uuid.UUID) error
+	GetStats(uuid uuid.UUID) (*PromotionStatsResponse, error)
+}
+
+type promotionService struct {
+	promotionRepo repositories.PromotionRepository
+	productRepo   repositories.ProductRepository
+	categoryRepo  repositories.CategoryRepository
+}
+
+func NewPromotionService(
+	promotionRepo repositories.PromotionRepository,
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+) PromotionService {
+	return &promotionService{
+		promotionRepo: promotionRepo,
+		productRepo:   productRepo,
+		categoryRepo:  categoryRepo,
+	}
+}
+
+func (s *promotionService) Create(req PromotionRequest) (*PromotionResponse, error) {
+	promotion := &models.Promotion{IsActive: true}
+	if err := s.apply(promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Create(promotion); err != nil {
+		return nil, err
+	}
+
+	return s.toPromotionResponse(promotion)
+}
+
+func (s *promotionService) GetAll() ([]PromotionResponse, error) {
+	promotions, err := s.promotionRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]PromotionResponse, 0, len(promotions))
+	for i := range promotions {
+		response, err := s.toPromotionResponse(&promotions[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+func (s *promotionService) GetByUUID(uuid uuid.UUID) (*PromotionResponse, error) {
+	promotion, err := s.findPromotion(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPromotionResponse(promotion)
+}
+
+// Update replaces the promotion's terms. Orders that already redeemed it keep
+// the discount they were given.
+func (s *promotionService) Update(uuid uuid.UUID, req PromotionRequest) (*PromotionResponse, error) {
+	promotion, err := s.findPromotion(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Update(promotion); err != nil {
+		return nil, err
+	}
+
+	return s.GetByUUID(uuid)
+}
+
+func (s *promotionService) Delete(uuid uuid.UUID) error {
+	promotion, err := s.findPromotion(uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.promotionRepo.Delete(promotion.ID)
+	if errors.Is(err, repositories.ErrPromotionNotFound) {
+		return ErrPromotionNotFound
+	}
+	return err
+}
+
+func (s *promotionService) GetStats(uuid uuid.UUID) (*PromotionStatsResponse, error) {
+	promotion, err := s.findPromotion(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.promotionRepo.Stats(promotion.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var average float64
+	if stats.Redemptions > 0 {
+		average = roundAmount(stats.Revenue / float64(stats.Redemptions))
+	}
+
+	return &PromotionStatsResponse{
+		PromotionID:       promotion.UUID,
+		Code:              promotion.Code,
+		Redemptions:       stats.Redemptions,
+		Customers:         stats.Customers,
+		DiscountGiven:     stats.DiscountGiven,
+		Revenue:           stats.Revenue,
+		AverageOrderValue: average,
+		FirstRedeemedAt:   formatOptionalTime(stats.FirstRedeemedAt),
+		LastRedeemedAt:    formatOptionalTime(stats.LastRedeemedAt),
+	}, nil
+}
+
+func (s *promotionService) findPromotion(uuid uuid.UUID) (*models.Promotion, error) {
+	promotion, err := s.promotionRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPromotionNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// apply validates the request and copies it onto the promotion. Codes are
+// stored in upper case so customers can enter them in any case.
+func (s *promotionService) apply(promotion *models.Promotion, req PromotionRequest) error {
+	if req.DiscountType != models.DiscountTypeFixed && req.DiscountValue > 100 {
+		return ErrPromotionPercentTooHigh
+	}
+	if req.StartsAt != nil && req.ExpiresAt != nil && !req.ExpiresAt.After(*req.StartsAt) {
+		return ErrInvalidPromotionPeriod
+	}
+	if (req.StartTime == nil) != (req.EndTime == nil) || (req.StartTime != nil && *req.StartTime == *req.EndTime) {
+		return ErrInvalidPromotionHours
+	}
+
+	code := strings.ToUpper(req.Code)
+	if code != promotion.Code {
+		existing, err := s.promotionRepo.FindByCode(code)
+		if err != nil && !errors.Is(err, repositories.ErrPromotionNotFound) {
+			return err
+		}
+		if existing != nil {
+			return ErrPromotionCodeExists
+		}
+	}
+
+	products := make([]models.Product, 0, len(req.ProductIDs))
+	for _, productUUID := range req.ProductIDs {
+		product, err := s.productRepo.FindByUUID(productUUID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrProductNotFound) {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		products = append(products, *product)
+	}
+
+	categories := make([]models.Category, 0, len(req.CategoryIDs))
+	for _, categoryUUID := range req.CategoryIDs {
+		category, err := s.categoryRepo.FindByUUID(categoryUUID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCategoryNotFound) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		categories = append(categories, *category)
+	}
+
+	promotion.Code = code
+	promotion.Name = req.Name
+	promotion.Description = req.Description
+	promotion.DiscountType = req.DiscountType
+	promotion.DiscountValue = req.DiscountValue
+	promotion.MaxDiscount = req.MaxDiscount
+	promotion.MinSpend = req.MinSpend
+	promotion.UsageLimit = req.UsageLimit
+	promotion.PerCustomerLimit = req.PerCustomerLimit
+	promotion.StartsAt = req.StartsAt
+	promotion.ExpiresAt = req.ExpiresAt
+	promotion.Days = 0
+	for _, name := range req.Days {
+		promotion.Days |= 1 << slices.Index(promotionDays, name)
+	}
+	promotion.StartTime = req.StartTime
+	promotion.EndTime = req.EndTime
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+	promotion.Automatic = req.Automatic
+	promotion.Stackable = req.Stackable
+	promotion.Products = products
+	promotion.Categories = categories
+	return nil
+}
+
+func (s *promotionService) toPromotionResponse(promotion *models.Promotion) (*PromotionResponse, error) {
+	redemptions, err := s.promotionRepo.CountRedemptions(promotion.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]PromotionScopeItem, len(promotion.Products))
+	for i, product := range promotion.Products {
+		products[i] = PromotionScopeItem{ID: product.UUID, Name: product.Name}
+	}
+	categories := make([]PromotionScopeItem, len(promotion.Categories))
+	for i, category := range promotion.Categories {
+		categories[i] = PromotionScopeItem{ID: category.UUID, Name: category.Name}
+	}
+
+	days := make([]string, 0, len(promotionDays))
+	for day, name := range promotionDays {
+		if promotion.Days&(1<<day) != 0 {
+			days = append(days, name)
+		}
+	}
+
+	return &PromotionResponse{
+		ID:               promotion.UUID,
+		Code:             promotion.Code,
+		Name:             promotion.Name,
+		Description:      promotion.Description,
+		DiscountType:     promotion.DiscountType,
+		DiscountValue:    promotion.DiscountValue,
+		MaxDiscount:      promotion.MaxDiscount,
+		MinSpend:         promotion.MinSpend,
+		UsageLimit:       promotion.UsageLimit,
+		PerCustomerLimit: promotion.PerCustomerLimit,
+		Redemptions:      redemptions,
+		StartsAt:         formatOptionalTime(promotion.StartsAt),
+		ExpiresAt:        formatOptionalTime(promotion.ExpiresAt),
+		Days:             days,
+		StartTime:        promotion.StartTime,
+		EndTime:          promotion.EndTime,
+		IsActive:         promotion.IsActive,
+		Automatic:        promotion.Automatic,
+		Stackable:        promotion.Stackable,
+		Products:         products,
+		Categories:       categories,
+		CreatedAt:        promotion.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        promotion.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderNotPayable = errors.New("order is not awaiting payment")
+)
+
+type QRCodeTarget string
+
+const (
+	QRCodeTargetMenu    QRCodeTarget = "menu"
+	QRCodeTargetTable   QRCodeTarget = "table"
+	QRCodeTargetPayment QRCodeTarget = "payment"
+)
+
+type QRCodeFormat string
+
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+)
+
+// DefaultQRCodeSize is the image width in pixels when none is requested
+const DefaultQRCodeSize = 512
+
+type GenerateQRCodeRequest struct {
+	Target  QRCodeTarget `json:"target" validate:"required,oneof=menu table payment"`
+	TableID *uuid.UUID   `json:"table_id" validate:"required_if=Target table"`
+	OrderID *uuid.UUID   `json:"order_id" validate:"required_if=Target payment"`
+	Format  QRCodeFormat `json:"format" validate:"omitempty,oneof=png svg"`
+	Size    int          `json:"size" validate:"omitempty,gte=128,lte=2048"`
+}
+
+type GeneratedQRCode struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+type QRCodeService interface {
+	Generate(req GenerateQRCodeRequest) (*GeneratedQRCode, error)
+}
+
+type qrCodeService struct {
+	orderRepo       repositories.OrderRepository
+	tableRepo       repositories.DiningTableRepository
+	settingsService SettingsService
+	frontendURL     string
+}
+
+func NewQRCodeService(
+	orderRepo repositories.OrderRepository,
+	tableRepo repositories.DiningTableRepository,
+	settingsService SettingsService,
+	frontendURL string,
+) QRCodeService {
+	return &qrCodeService{
+		orderRepo:       orderRepo,
+		tableRepo:       tableRepo,
+		settingsService: settingsService,
+		frontendURL:     frontendURL,
+	}
+}
+
+// Generate renders a QR code for the public menu, a table's ordering link, or
+// the payment page of a pending order, in the colors from the QR code settings
+func (s *qrCodeService) Generate(req GenerateQRCodeRequest) (*GeneratedQRCode, error) {
+	link, err := s.targetURL(req)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsService.GetQRCodeSettings()
+	if err != nil {
+		return nil, err
+	}
+	style := utils.QRCodeStyle{
+		Foreground: settings.ForegroundColor,
+		Background: settings.BackgroundColor,
+	}
+
+	size := req.Size
+	if size == 0 {
+		size = DefaultQRCodeSize
+	}
+
+	generated := &GeneratedQRCode{URL: link}
+	switch req.Format {
+	case QRCodeFormatPNG, "":
+		generated.ContentType = "image/png"
+		generated.Body, err = utils.RenderQRCodePNG(link, size, style)
+	case QRCodeFormatSVG:
+		generated.ContentType = "image/svg+xml"
+		generated.Body, err = utils.RenderQRCodeSVG(link, size, style)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return generated, nil
+}
+
+func (s *qrCodeService) targetURL(req GenerateQRCodeRequest) (string, error) {
+	switch req.Target {
+	case QRCodeTargetTable:
+		table, err := s.tableRepo.FindByUUID(*req.TableID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrDiningTableNotFound) {
+				return "", ErrDiningTableNotFound
+			}
+			return "", err
+		}
+		return tableMenuURL(s.frontendURL, table.Token), nil
+	case QRCodeTargetPayment:
+		order, err := s.orderRepo.FindByUUID(*req.OrderID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrOrderNotFound) {
+				return "", ErrOrderNotFound
+			}
+			return "", err
+		}
+		if !order.AwaitsPayment() || order.IsPaymentExpired() {
+			return "", ErrOrderNotPayable
+		}
+		return s.frontendURL + "/orders/pay/" + order.UUID.String(), nil
+	default:
+		return s.frontendURL + "/menu", nil
+	}
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidReceiptFormat = errors.New("invalid receipt format")
+)
+
+type ReceiptFormat string
+
+const (
+	ReceiptFormatText   ReceiptFormat = "text"
+	ReceiptFormatHTML   ReceiptFormat = "html"
+	ReceiptFormatESCPOS ReceiptFormat = "escpos"
+)
+
+type RenderedReceipt struct {
+	ContentType string
+	Body        []byte
+}
+
+type ReceiptService interface {
+	Render(order *models.Order, settings ReceiptSettings, format ReceiptFormat) (*RenderedReceipt, error)
+	Preview(settings *ReceiptSettings, format ReceiptFormat) (*RenderedReceipt, error)
+}
+
+type receiptService struct {
+	settingsService SettingsService
+	formatter       *utils.Formatter
+	pickupCodes     *utils.PickupCodeSigner
+}
+
+func NewReceiptService(settingsService SettingsService, formatter *utils.Formatter, pickupCodes *utils.PickupCodeSigner) ReceiptService {
+	return &receiptService{
+		settingsService: settingsService,
+		formatter:       formatter,
+		pickupCodes:     pickupCodes,
+	}
+}
+
+func (s *receiptService) Render(order *models.Order, settings ReceiptSettings, format ReceiptFormat) (*RenderedReceipt, error) {
+	if settings.PaperWidth == 0 {
+		settings.PaperWidth = DefaultReceiptSettings.PaperWidth
+	}
+
+	// Receipts printed before pickup carry the code the barista scans
+	var pickupCode string
+	if order.AwaitsPickup() {
+		pickupCode = s.pickupCodes.Sign(order.OrderNumber)
+	}
+
+	currency, err := s.settingsService.GetCurrencySettings()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := buildReceiptLines(order, settings, s.formatter.WithCurrency(currency.OrderCurrency(order.Currency)), pickupCode)
+
+	switch format {
+	case ReceiptFormatText, "":
+		return &RenderedReceipt{
+			ContentType: "text/plain; charset=utf-8",
+			Body:        []byte(renderReceiptText(lines, settings.PaperWidth)),
+		}, nil
+	case ReceiptFormatHTML:
+		body, err := renderReceiptHTML(lines, settings)
+		if err != nil {
+			return nil, err
+		}
+		return &RenderedReceipt{ContentType: "text/html; charset=utf-8", Body: body}, nil
+	case ReceiptFormatESCPOS:
+		return &RenderedReceipt{
+			ContentType: "application/octet-stream",
+			Body:        renderReceiptESCPOS(lines, settings.PaperWidth),
+		}, nil
+	default:
+		return nil, ErrInvalidReceiptFormat
+	}
+}
+
+// Preview renders a sample order with the given settings, or the saved ones when nil
+func (s *receiptService) Preview(settings *ReceiptSettings, format ReceiptFormat) (*RenderedReceipt, error) {
+	if settings == nil {
+		saved, err := s.settingsService.GetReceiptSettings()
+		if err != nil {
+			return nil, err
+		}
+		settings = saved
+	}
+
+	return s.Render(sampleReceiptOrder(), *settings, format)
+}
+
+type receiptAlign int
+
+const (
+	alignLeft receiptAlign = iota
+	alignCenter
+)
+
+type receiptLine struct {
+	Left      string
+	Right     string
+	Align     receiptAlign
+	Bold      bool
+	Separator bool
+}
+
+func buildReceiptLines(order *models.Order, settings ReceiptSettings, formatter *utils.Formatter, pickupCode string) []receiptLine {
+	width := settings.PaperWidth
+	var lines []receiptLine
+
+	for _, text := range wrapText(settings.HeaderText, width) {
+		lines = append(lines, receiptLine{Left: text, Align: alignCenter, Bold: true})
+	}
+	if settings.TaxID != "" {
+		lines = append(lines, receiptLine{Left: "Tax ID: " + settings.TaxID, Align: alignCenter})
+	}
+	lines = append(lines, receiptLine{Separator: true})
+
+	lines = append(lines,
+		receiptLine{Left: "Order", Right: order.OrderNumber},
+		receiptLine{Left: "Date", Right: formatter.ShortDateTime(order.CreatedAt)},
+		receiptLine{Left: "Customer", Right: order.CustomerName},
+	)
+	if order.TableNumber != nil {
+		lines = append(lines, receiptLine{Left: "Table", Right: *order.TableNumber})
+	}
+	lines = append(lines, receiptLine{Separator: true})
+
+	for _, item := range order.Items {
+		lines = append(lines, receiptLine{
+			Left:  fmt.Sprintf("%dx %s", item.Quantity, item.ProductName),
+			Right: formatter.Money(item.Subtotal),
+		})
+		for _, option := range receiptCustomizations(item.Customizations) {
+			lines = append(lines, receiptLine{Left: "  + " + option})
+		}
+		if item.Notes != nil && *item.Notes != "" {
+			lines = append(lines, receiptLine{Left: "  * " + *item.Notes})
+		}
+	}
+	lines = append(lines, receiptLine{Separator: true})
+
+	lines = append(lines,
+		receiptLine{Left: "Subtotal", Right: formatter.Money(order.Subtotal)},
+	)
+	if len(order.Promotions) > 0 {
+		for _, applied := range order.Promotions {
+			label := "Discount"
+			if applied.Name != nil {
+				label = *applied.Name
+			}
+			label += " (" + applied.Code + ")"
+			lines = append(lines, receiptLine{Left: label, Right: "-" + formatter.Money(applied.Discount)})
+		}
+	} else if order.Discount > 0 {
+		label := "Discount"
+		if order.PromoCode != nil {
+			label += " (" + *order.PromoCode + ")"
+		}
+		lines = append(lines, receiptLine{Left: label, Right: "-" + formatter.Money(order.Discount)})
+	}
+	if order.ServiceCharge > 0 {
+		lines = append(lines, receiptLine{Left: "Service charge", Right: formatter.Money(order.ServiceCharge)})
+	}
+	lines = append(lines,
+		receiptLine{Left: "Tax", Right: formatter.Money(order.Tax)},
+	)
+	if order.SourceFee > 0 {
+		lines = append(lines, receiptLine{Left: "Service fee", Right: formatter.Money(order.SourceFee)})
+	}
+	if order.DeliveryFee > 0 {
+		lines = append(lines, receiptLine{Left: "Delivery", Right: formatter.Money(order.DeliveryFee)})
+	}
+	if order.TipAmount > 0 {
+		lines = append(lines, receiptLine{Left: "Tip", Right: formatter.Money(order.TipAmount)})
+	}
+	if order.GiftCardAmount > 0 {
+		lines = append(lines, receiptLine{Left: "Gift card", Right: "-" + formatter.Money(order.GiftCardAmount)})
+	}
+	if order.PointsAmount > 0 {
+		lines = append(lines, receiptLine{Left: fmt.Sprintf("Points (%d)", order.PointsRedeemed), Right: "-" + formatter.Money(order.PointsAmount)})
+	}
+	lines = append(lines,
+		receiptLine{Left: "TOTAL", Right: formatter.Money(order.AmountDue()), Bold: true},
+		receiptLine{Separator: true},
+	)
+
+	if pickupCode != "" {
+		lines = append(lines,
+			receiptLine{Left: "Pickup code", Align: alignCenter},
+			receiptLine{Left: pickupCode, Align: alignCenter, Bold: true},
+			receiptLine{Separator: true},
+		)
+	}
+
+	for _, text := range wrapText(settings.PromoMessage, width) {
+		lines = append(lines, receiptLine{Left: text, Align: alignCenter, Bold: true})
+	}
+	for _, text := range wrapText(settings.FooterText, width) {
+		lines = append(lines, receiptLine{Left: text, Align: alignCenter})
+	}
+
+	return lines
+}
+
+func renderReceiptText(lines []receiptLine, width int) string {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(layoutReceiptLine(line, width))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ESC/POS control sequences for thermal printers
+var (
+	escposInit        = []byte{0x1b, 0x40}
+	escposAlignLeft   = []byte{0x1b, 0x61, 0x00}
+	escposAlignCenter = []byte{0x1b, 0x61, 0x01}
+	escposBoldOn      = []byte{0x1b, 0x45, 0x01}
+	escposBoldOff     = []byte{0x1b, 0x45, 0x00}
+	escposFeedAndCut  = []byte{0x1b, 0x64, 0x03, 0x1d, 0x56, 0x00}
+)
+
+func renderReceiptESCPOS(lines []receiptLine, width int) []byte {
+	var b bytes.Buffer
+	b.Write(escposInit)
+
+	for _, line := range lines {
+		if line.Align == alignCenter {
+			b.Write(escposAlignCenter)
+		} else {
+			b.Write(escposAlignLeft)
+		}
+		if line.Bold {
+			b.Write(escposBoldOn)
+		}
+
+		// The printer centers for us, so only two-column lines need padding
+		if line.Align == alignCenter {
+			b.WriteString(line.Left)
+		} else {
+			b.WriteString(layoutReceiptLine(line, width))
+		}
+		b.WriteByte('\n')
+
+		if line.Bold {
+			b.Write(escposBoldOff)
+		}
+	}
+
+	b.Write(escposFeedAndCut)
+	return b.Bytes()
+}
+
+var receiptHTMLTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Receipt</title></head>
+<body>
+<div style="font-family: monospace; white-space: pre; width: {{.Width}}ch; margin: 0 auto;">
+{{- if .LogoURL}}<div style="text-align: center;"><img src="{{.LogoURL}}" alt="logo" style="max-width: 100%;"></div>{{end}}
+{{- range .Lines}}
+<div{{if .Center}} style="text-align: center;"{{end}}>{{if .Bold}}<strong>{{.Text}}</strong>{{else}}{{.Text}}{{end}}</div>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+func renderReceiptHTML(lines []receiptLine, settings ReceiptSettings) ([]byte, error) {
+	type htmlLine struct {
+		Text   string
+		Center bool
+		Bold   bool
+	}
+
+	data := struct {
+		LogoURL string
+		Width   int
+		Lines   []htmlLine
+	}{
+		LogoURL: settings.LogoURL,
+		Width:   settings.PaperWidth,
+	}
+	for _, line := range lines {
+		text := line.Left
+		if line.Align != alignCenter {
+			text = layoutReceiptLine(line, settings.PaperWidth)
+		}
+		data.Lines = append(data.Lines, htmlLine{Text: text, Center: line.Align == alignCenter, Bold: line.Bold})
+	}
+
+	var b bytes.Buffer
+	if err := receiptHTMLTemplate.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// layoutReceiptLine pads a line to the paper width, truncating the left column if needed
+func layoutReceiptLine(line receiptLine, width int) string {
+	if line.Separator {
+		return strings.Repeat("-", width)
+	}
+
+	if line.Align == alignCenter {
+		text := truncateRunes(line.Left, width)
+		pad := (width - len([]rune(text))) / 2
+		return strings.Repeat(" ", pad) + text
+	}
+
+	if line.Right == "" {
+		return truncateRunes(line.Left, width)
+	}
+
+	right := truncateRunes(line.Right, width)
+	left := truncateRunes(line.Left, width-len([]rune(right))-1)
+	gap := width - len([]rune(left)) - len([]rune(right))
+	return left + strings.Repeat(" ", gap) + right
+}
+
+func truncateRunes(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width])
+}
+
+// wrapText splits text into lines no wider than width, keeping explicit line breaks
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			continue
+		}
+
+		current := ""
+		for _, word := range words {
+			word = truncateRunes(word, width)
+			switch {
+			case current == "":
+				current = word
+			case len([]rune(current))+1+len([]rune(word)) <= width:
+				current += " " + word
+			default:
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func receiptCustomizations(data []byte) []string {
+	customizations := parseItemCustomizations(data)
+	options := make([]string, 0, len(customizations))
+	for _, customization := range customizations {
+		options = append(options, customization.OptionName)
+	}
+	return options
+}
+
+func sampleReceiptOrder() *models.Order {
+	notes := "Less ice"
+	return &models.Order{
+		UUID:         uuid.Nil,
+		OrderNumber:  "MC-250107-001",
+		CustomerName: "John Doe",
+		Status:       models.OrderStatusCompleted,
+		OrderSource:  models.OrderSourceGuest,
+		Subtotal:     105000,
+		Tax:          10500,
+		Total:        115500,
+		CreatedAt:    time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC),
+		Items: []models.OrderItem{
+			{
+				ProductName:    "Matcha Latte",
+				Quantity:       2,
+				UnitPrice:      35000,
+				Subtotal:       70000,
+				Customizations: []byte(`[{"option_name":"Oat Milk"}]`),
+			},
+			{
+				ProductName: "Hojicha Latte",
+				Quantity:    1,
+				UnitPrice:   35000,
+				Subtotal:    35000,
+				Notes:       &notes,
+			},
+		},
+	}
+}
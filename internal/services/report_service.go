@@ -0,0 +1,963 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidDateRange        = errors.New("invalid date range")
+	ErrInvalidSalesGranularity = errors.New("invalid sales granularity")
+)
+
+// Sales report granularities. Weeks start on Monday.
+const (
+	SalesGranularityDay   = "day"
+	SalesGranularityWeek  = "week"
+	SalesGranularityMonth = "month"
+)
+
+// SalesPeriod is paid sales over one day, week or month, named by its first
+// day. The average ticket is revenue per order.
+type SalesPeriod struct {
+	Period        string  `json:"period"`
+	OrderCount    int64   `json:"order_count"`
+	Subtotal      float64 `json:"subtotal"`
+	Discount      float64 `json:"discount"`
+	Tax           float64 `json:"tax"`
+	Revenue       float64 `json:"revenue"`
+	AverageTicket float64 `json:"average_ticket"`
+}
+
+type SalesReportResponse struct {
+	StartDate     string        `json:"start_date"`
+	EndDate       string        `json:"end_date"`
+	Granularity   string        `json:"granularity"`
+	Periods       []SalesPeriod `json:"periods"`
+	TotalOrders   int64         `json:"total_orders"`
+	TotalRevenue  float64       `json:"total_revenue"`
+	TotalTax      float64       `json:"total_tax"`
+	AverageTicket float64       `json:"average_ticket"`
+}
+
+// SourceRevenue is gross revenue from one channel and what is left of it
+// after the fees payment gateways kept
+type SourceRevenue struct {
+	OrderSource models.OrderSource `json:"order_source"`
+	OrderCount  int64              `json:"order_count"`
+	Subtotal    float64            `json:"subtotal"`
+	Tax         float64            `json:"tax"`
+	Revenue     float64            `json:"revenue"`
+	GatewayFees float64            `json:"gateway_fees"`
+	NetRevenue  float64            `json:"net_revenue"`
+}
+
+type RevenueBySourceResponse struct {
+	StartDate        string          `json:"start_date"`
+	EndDate          string          `json:"end_date"`
+	Sources          []SourceRevenue `json:"sources"`
+	TotalOrders      int64           `json:"total_orders"`
+	TotalRevenue     float64         `json:"total_revenue"`
+	TotalGatewayFees float64         `json:"total_gateway_fees"`
+	TotalNetRevenue  float64         `json:"total_net_revenue"`
+}
+
+// CustomizationPopularity is how often an option was chosen on a product.
+// Share is the percentage of the product's items sold with it.
+type CustomizationPopularity struct {
+	CustomizationType string  `json:"customization_type"`
+	OptionName        string  `json:"option_name"`
+	Quantity          int64   `json:"quantity"`
+	Share             float64 `json:"share"`
+}
+
+// TopProduct is what was sold of one product. ProductID is nil for products
+// deleted since, which have no customization breakdown.
+type TopProduct struct {
+	ProductID      *uuid.UUID                `json:"product_id,omitempty"`
+	ProductName    string                    `json:"product_name"`
+	Quantity       int64                     `json:"quantity"`
+	OrderCount     int64                     `json:"order_count"`
+	Revenue        float64                   `json:"revenue"`
+	Customizations []CustomizationPopularity `json:"customizations"`
+}
+
+type TopProductsResponse struct {
+	StartDate string       `json:"start_date"`
+	EndDate   string       `json:"end_date"`
+	Products  []TopProduct `json:"products"`
+}
+
+// CategoryRevenue is what was sold in a category and its subcategories, and
+// its share of revenue in the report. CategoryID is nil for items without a
+// category.
+type CategoryRevenue struct {
+	CategoryID   *uuid.UUID `json:"category_id,omitempty"`
+	CategoryName string     `json:"category_name"`
+	Quantity     int64      `json:"quantity"`
+	OrderCount   int64      `json:"order_count"`
+	Revenue      float64    `json:"revenue"`
+	Share        float64    `json:"share"`
+}
+
+type RevenueByCategoryResponse struct {
+	StartDate    string            `json:"start_date"`
+	EndDate      string            `json:"end_date"`
+	ParentID     *uuid.UUID        `json:"parent_id,omitempty"`
+	Categories   []CategoryRevenue `json:"categories"`
+	TotalRevenue float64           `json:"total_revenue"`
+}
+
+// TaxRateSummary is what was charged at one tax rate. Rate is a percentage;
+// orders charged no tax are listed at rate 0.
+type TaxRateSummary struct {
+	Rate         float64 `json:"rate"`
+	OrderCount   int64   `json:"order_count"`
+	TaxableSales float64 `json:"taxable_sales"`
+	Tax          float64 `json:"tax"`
+}
+
+type TaxDay struct {
+	Date         string  `json:"date"`
+	OrderCount   int64   `json:"order_count"`
+	TaxableSales float64 `json:"taxable_sales"`
+	Tax          float64 `json:"tax"`
+}
+
+// TaxReportResponse summarizes the tax collected over a calendar month.
+// Taxable sales are the subtotal after discounts plus the service charge.
+type TaxReportResponse struct {
+	Month             string           `json:"month"`
+	Rates             []TaxRateSummary `json:"rates"`
+	Days              []TaxDay         `json:"days"`
+	TotalOrders       int64            `json:"total_orders"`
+	TotalTaxableSales float64          `json:"total_taxable_sales"`
+	TotalTax          float64          `json:"total_tax"`
+}
+
+// Fulfillment stages, in the order an order goes through them. Total runs
+// from when the order was queued to when it was handed over.
+const (
+	FulfillmentStagePayment     = "payment"
+	FulfillmentStagePreparation = "preparation"
+	FulfillmentStagePickup      = "pickup"
+	FulfillmentStageTotal       = "total"
+)
+
+var fulfillmentStages = []string{
+	FulfillmentStagePayment,
+	FulfillmentStagePreparation,
+	FulfillmentStagePickup,
+	FulfillmentStageTotal,
+}
+
+// FulfillmentStage is the median and 90th percentile time, in minutes,
+// orders spent in one stage. The percentiles are nil when no order finished
+// the stage.
+type FulfillmentStage struct {
+	Stage      string   `json:"stage"`
+	OrderCount int64    `json:"order_count"`
+	P50Minutes *float64 `json:"p50_minutes"`
+	P90Minutes *float64 `json:"p90_minutes"`
+}
+
+type FulfillmentDay struct {
+	Date   string             `json:"date"`
+	Stages []FulfillmentStage `json:"stages"`
+}
+
+type FulfillmentTimesResponse struct {
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	Overall   []FulfillmentStage `json:"overall"`
+	Days      []FulfillmentDay   `json:"days"`
+}
+
+// AbandonedHour counts orders created in one hour of the day and those
+// abandoned unpaid. Attempted is how many abandoned orders started a payment
+// before being dropped, and the drop-off rate is the percentage abandoned.
+type AbandonedHour struct {
+	Hour           int     `json:"hour"`
+	OrderCount     int64   `json:"order_count"`
+	AbandonedCount int64   `json:"abandoned_count"`
+	Attempted      int64   `json:"payment_attempted"`
+	AbandonedValue float64 `json:"abandoned_value"`
+	DropOffRate    float64 `json:"drop_off_rate"`
+}
+
+type AbandonedBySource struct {
+	OrderSource    models.OrderSource `json:"order_source"`
+	OrderCount     int64              `json:"order_count"`
+	AbandonedCount int64              `json:"abandoned_count"`
+	Attempted      int64              `json:"payment_attempted"`
+	AbandonedValue float64            `json:"abandoned_value"`
+	DropOffRate    float64            `json:"drop_off_rate"`
+	Hours          []AbandonedHour    `json:"hours"`
+}
+
+// AbandonedOrdersResponse breaks unpaid orders down by channel and by hour
+// of the day, each channel's hours and across all channels
+type AbandonedOrdersResponse struct {
+	StartDate           string              `json:"start_date"`
+	EndDate             string              `json:"end_date"`
+	Sources             []AbandonedBySource `json:"sources"`
+	Hours               []AbandonedHour     `json:"hours"`
+	TotalOrders         int64               `json:"total_orders"`
+	TotalAbandoned      int64               `json:"total_abandoned"`
+	TotalAbandonedValue float64             `json:"total_abandoned_value"`
+	DropOffRate         float64             `json:"drop_off_rate"`
+}
+
+// InventoryUsage is what was taken from one product's stock and how long
+// what is available lasts at that pace. Available is the stock not held by
+// unpaid orders. DaysUntilStockout is nil when nothing was consumed.
+type InventoryUsage struct {
+	ProductID         uuid.UUID `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	StockQuantity     int       `json:"stock_quantity"`
+	Reserved          int64     `json:"reserved"`
+	Available         int64     `json:"available"`
+	Consumed          int64     `json:"consumed"`
+	DailyUsage        float64   `json:"daily_usage"`
+	DaysUntilStockout *float64  `json:"days_until_stockout"`
+}
+
+type InventoryUsageResponse struct {
+	StartDate string           `json:"start_date"`
+	EndDate   string           `json:"end_date"`
+	Days      int              `json:"days"`
+	Products  []InventoryUsage `json:"products"`
+}
+
+type StaffTips struct {
+	Staff      *StaffSummary `json:"staff"`
+	OrderCount int64         `json:"order_count"`
+	Tips       float64       `json:"tips"`
+}
+
+// TipsReportResponse breaks tips down by the staff member who handled each
+// order. Tips on unclaimed orders are listed with a null staff member.
+type TipsReportResponse struct {
+	StartDate    string      `json:"start_date"`
+	EndDate      string      `json:"end_date"`
+	Staff        []StaffTips `json:"staff"`
+	TippedOrders int64       `json:"tipped_orders"`
+	TotalTips    float64     `json:"total_tips"`
+}
+
+// Settlement is one settled gateway payment. The fee is the one recorded on
+// the payment, and net is what reaches the shop's account after the fee and
+// any refunds.
+type Settlement struct {
+	PaymentID     uuid.UUID            `json:"payment_id"`
+	Reference     string               `json:"reference"`
+	OrderNumber   string               `json:"order_number"`
+	Provider      models.PaymentMethod `json:"provider"`
+	PaymentType   *string              `json:"payment_type,omitempty"`
+	TransactionID *string              `json:"transaction_id,omitempty"`
+	SettledAt     string               `json:"settled_at"`
+	PayoutDate    string               `json:"payout_date"`
+	GrossAmount   float64              `json:"gross_amount"`
+	Refunded      float64              `json:"refunded"`
+	Fee           float64              `json:"fee"`
+	NetAmount     float64              `json:"net_amount"`
+}
+
+type SettlementReportResponse struct {
+	StartDate     string       `json:"start_date"`
+	EndDate       string       `json:"end_date"`
+	Settlements   []Settlement `json:"settlements"`
+	TotalGross    float64      `json:"total_gross"`
+	TotalRefunded float64      `json:"total_refunded"`
+	TotalFees     float64      `json:"total_fees"`
+	TotalNet      float64      `json:"total_net"`
+}
+
+// settlementCSVHeader names the export columns, one settlement per row
+var settlementCSVHeader = []string{
+	"settled_at", "payout_date", "order_number", "reference", "transaction_id",
+	"provider", "payment_type", "gross_amount", "refunded", "fee", "net_amount",
+}
+
+// WriteCSV writes the settlements for import into a spreadsheet. Amounts use
+// two decimals without thousands separators.
+func (r *SettlementReportResponse) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(settlementCSVHeader); err != nil {
+		return err
+	}
+	for _, settlement := range r.Settlements {
+		record := []string{
+			settlement.SettledAt,
+			settlement.PayoutDate,
+			settlement.OrderNumber,
+			settlement.Reference,
+			optionalString(settlement.TransactionID),
+			string(settlement.Provider),
+			optionalString(settlement.PaymentType),
+			formatCSVAmount(settlement.GrossAmount),
+			formatCSVAmount(settlement.Refunded),
+			formatCSVAmount(settlement.Fee),
+			formatCSVAmount(settlement.NetAmount),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReportConfig holds what the settlement report needs to estimate fees and
+// payouts
+type ReportConfig struct {
+	GatewayFees     GatewayFees
+	PayoutDelayDays int
+}
+
+type ReportService interface {
+	GetRevenueBySource(startDate, endDate time.Time) (*RevenueBySourceResponse, error)
+	GetSales(startDate, endDate time.Time, granularity string) (*SalesReportResponse, error)
+	GetTopProducts(startDate, endDate time.Time, limit int) (*TopProductsResponse, error)
+	GetRevenueByCategory(startDate, endDate time.Time, parentID *uuid.UUID) (*RevenueByCategoryResponse, error)
+	GetTax(month time.Time) (*TaxReportResponse, error)
+	GetFulfillmentTimes(startDate, endDate time.Time) (*FulfillmentTimesResponse, error)
+	GetAbandonedOrders(startDate, endDate time.Time) (*AbandonedOrdersResponse, error)
+	GetInventoryUsage(startDate, endDate time.Time) (*InventoryUsageResponse, error)
+	GetTips(startDate, endDate time.Time) (*TipsReportResponse, error)
+	GetSettlements(startDate, endDate time.Time) (*SettlementReportResponse, error)
+}
+
+type reportService struct {
+	reportRepo repositories.ReportRepository
+	config     ReportConfig
+}
+
+func NewReportService(reportRepo repositories.ReportRepository, config ReportConfig) ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		config:     config,
+	}
+}
+
+// GetRevenueBySource reports paid revenue per order channel. Both dates are
+// inclusive calendar days.
+func (s *reportService) GetRevenueBySource(startDate, endDate time.Time) (*RevenueBySourceResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.RevenueBySource(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	bySource := make(map[models.OrderSource]repositories.SourceRevenueRow, len(rows))
+	for _, row := range rows {
+		bySource[row.OrderSource] = row
+	}
+
+	// Always list every channel so the breakdown has a stable shape
+	response := &RevenueBySourceResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Sources:   make([]SourceRevenue, 0, len(models.OrderSources)),
+	}
+	for _, source := range models.OrderSources {
+		row := bySource[source]
+		response.Sources = append(response.Sources, SourceRevenue{
+			OrderSource: source,
+			OrderCount:  row.OrderCount,
+			Subtotal:    row.Subtotal,
+			Tax:         row.Tax,
+			Revenue:     row.Revenue,
+			GatewayFees: row.GatewayFees,
+			NetRevenue:  roundAmount(row.Revenue - row.GatewayFees),
+		})
+		response.TotalOrders += row.OrderCount
+		response.TotalRevenue += row.Revenue
+		response.TotalGatewayFees += row.GatewayFees
+	}
+	response.TotalNetRevenue = roundAmount(response.TotalRevenue - response.TotalGatewayFees)
+
+	return response, nil
+}
+
+// GetSales reports paid revenue, orders, average ticket and tax per day,
+// week or month. Both dates are inclusive calendar days. Every period the
+// range touches is listed, including those without orders; the first and
+// last may only be partly inside the range.
+func (s *reportService) GetSales(startDate, endDate time.Time, granularity string) (*SalesReportResponse, error) {
+	if granularity != SalesGranularityDay && granularity != SalesGranularityWeek && granularity != SalesGranularityMonth {
+		return nil, ErrInvalidSalesGranularity
+	}
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.SalesByPeriod(startDate, endDate.AddDate(0, 0, 1), granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[string]repositories.SalesRow, len(rows))
+	for _, row := range rows {
+		byPeriod[row.Period.Format("2006-01-02")] = row
+	}
+
+	response := &SalesReportResponse{
+		StartDate:   startDate.Format("2006-01-02"),
+		EndDate:     endDate.Format("2006-01-02"),
+		Granularity: granularity,
+		Periods:     make([]SalesPeriod, 0),
+	}
+	for period := salesPeriodStart(startDate, granularity); !period.After(endDate); period = nextSalesPeriod(period, granularity) {
+		key := period.Format("2006-01-02")
+		row := byPeriod[key]
+		response.Periods = append(response.Periods, SalesPeriod{
+			Period:        key,
+			OrderCount:    row.OrderCount,
+			Subtotal:      row.Subtotal,
+			Discount:      row.Discount,
+			Tax:           row.Tax,
+			Revenue:       row.Revenue,
+			AverageTicket: averageTicket(row.Revenue, row.OrderCount),
+		})
+		response.TotalOrders += row.OrderCount
+		response.TotalRevenue += row.Revenue
+		response.TotalTax += row.Tax
+	}
+	response.TotalRevenue = roundAmount(response.TotalRevenue)
+	response.TotalTax = roundAmount(response.TotalTax)
+	response.AverageTicket = averageTicket(response.TotalRevenue, response.TotalOrders)
+
+	return response, nil
+}
+
+// salesPeriodStart is the first day of the period t falls in, matching
+// Postgres date_trunc
+func salesPeriodStart(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case SalesGranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case SalesGranularityMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+func nextSalesPeriod(period time.Time, granularity string) time.Time {
+	switch granularity {
+	case SalesGranularityWeek:
+		return period.AddDate(0, 0, 7)
+	case SalesGranularityMonth:
+		return period.AddDate(0, 1, 0)
+	}
+	return period.AddDate(0, 0, 1)
+}
+
+func averageTicket(revenue float64, orders int64) float64 {
+	if orders == 0 {
+		return 0
+	}
+	return roundAmount(revenue / float64(orders))
+}
+
+// GetTopProducts ranks products on paid orders by quantity sold, with how
+// often each customization option was chosen on them. Both dates are
+// inclusive calendar days. Revenue is item subtotals before order discounts.
+func (s *reportService) GetTopProducts(startDate, endDate time.Time, limit int) (*TopProductsResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	end := endDate.AddDate(0, 0, 1)
+	rows, err := s.reportRepo.TopProducts(startDate, end, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	productUUIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		if row.ProductUUID != nil {
+			productUUIDs = append(productUUIDs, *row.ProductUUID)
+		}
+	}
+	customizations, err := s.reportRepo.CustomizationsSold(startDate, end, productUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[uuid.UUID][]repositories.CustomizationRow, len(productUUIDs))
+	for _, row := range customizations {
+		byProduct[row.ProductUUID] = append(byProduct[row.ProductUUID], row)
+	}
+
+	response := &TopProductsResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Products:  make([]TopProduct, 0, len(rows)),
+	}
+	for _, row := range rows {
+		product := TopProduct{
+			ProductID:      row.ProductUUID,
+			ProductName:    row.ProductName,
+			Quantity:       row.Quantity,
+			OrderCount:     row.OrderCount,
+			Revenue:        row.Revenue,
+			Customizations: make([]CustomizationPopularity, 0),
+		}
+		if row.ProductUUID != nil && row.Quantity > 0 {
+			for _, chosen := range byProduct[*row.ProductUUID] {
+				product.Customizations = append(product.Customizations, CustomizationPopularity{
+					CustomizationType: chosen.CustomizationType,
+					OptionName:        chosen.OptionName,
+					Quantity:          chosen.Quantity,
+					Share:             roundAmount(float64(chosen.Quantity) / float64(row.Quantity) * 100),
+				})
+			}
+		}
+		response.Products = append(response.Products, product)
+	}
+
+	return response, nil
+}
+
+// uncategorizedName labels sales of products without a category
+const uncategorizedName = "Uncategorized"
+
+// GetRevenueByCategory compares item revenue on paid orders across the
+// top-level categories, or across the subcategories of parentID. Both dates
+// are inclusive calendar days. Revenue is item subtotals before order
+// discounts.
+func (s *reportService) GetRevenueByCategory(startDate, endDate time.Time, parentID *uuid.UUID) (*RevenueByCategoryResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.RevenueByCategory(startDate, endDate.AddDate(0, 0, 1), parentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	response := &RevenueByCategoryResponse{
+		StartDate:  startDate.Format("2006-01-02"),
+		EndDate:    endDate.Format("2006-01-02"),
+		ParentID:   parentID,
+		Categories: make([]CategoryRevenue, 0, len(rows)),
+	}
+	for _, row := range rows {
+		response.TotalRevenue += row.Revenue
+	}
+	response.TotalRevenue = roundAmount(response.TotalRevenue)
+
+	for _, row := range rows {
+		category := CategoryRevenue{
+			CategoryID:   row.CategoryUUID,
+			CategoryName: uncategorizedName,
+			Quantity:     row.Quantity,
+			OrderCount:   row.OrderCount,
+			Revenue:      row.Revenue,
+		}
+		if row.CategoryName != nil {
+			category.CategoryName = *row.CategoryName
+		}
+		if response.TotalRevenue > 0 {
+			category.Share = roundAmount(row.Revenue / response.TotalRevenue * 100)
+		}
+		response.Categories = append(response.Categories, category)
+	}
+
+	return response, nil
+}
+
+// GetTax reports taxable sales and tax collected on paid orders over the
+// calendar month month falls in, per tax rate and per day. Orders are
+// grouped by the rate stored on them, so a rate change mid-month shows up
+// as two rates. Every day of the month is listed, including those without
+// orders.
+func (s *reportService) GetTax(month time.Time) (*TaxReportResponse, error) {
+	startDate := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	endDate := startDate.AddDate(0, 1, 0)
+
+	rows, err := s.reportRepo.TaxByDay(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byRate := make(map[float64]*TaxRateSummary)
+	byDay := make(map[string]*TaxDay)
+	for _, row := range rows {
+		rate := roundAmount(row.TaxRate * 100)
+		summary, ok := byRate[rate]
+		if !ok {
+			summary = &TaxRateSummary{Rate: rate}
+			byRate[rate] = summary
+		}
+		summary.OrderCount += row.OrderCount
+		summary.TaxableSales += row.TaxableSales
+		summary.Tax += row.Tax
+
+		key := row.Day.Format("2006-01-02")
+		day, ok := byDay[key]
+		if !ok {
+			day = &TaxDay{Date: key}
+			byDay[key] = day
+		}
+		day.OrderCount += row.OrderCount
+		day.TaxableSales += row.TaxableSales
+		day.Tax += row.Tax
+	}
+
+	response := &TaxReportResponse{
+		Month: startDate.Format("2006-01"),
+		Rates: make([]TaxRateSummary, 0, len(byRate)),
+		Days:  make([]TaxDay, 0, endDate.AddDate(0, 0, -1).Day()),
+	}
+	for _, summary := range byRate {
+		summary.TaxableSales = roundAmount(summary.TaxableSales)
+		summary.Tax = roundAmount(summary.Tax)
+		response.Rates = append(response.Rates, *summary)
+		response.TotalOrders += summary.OrderCount
+		response.TotalTaxableSales += summary.TaxableSales
+		response.TotalTax += summary.Tax
+	}
+	sort.Slice(response.Rates, func(i, j int) bool {
+		return response.Rates[i].Rate < response.Rates[j].Rate
+	})
+	response.TotalTaxableSales = roundAmount(response.TotalTaxableSales)
+	response.TotalTax = roundAmount(response.TotalTax)
+
+	for day := startDate; day.Before(endDate); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		entry := TaxDay{Date: key}
+		if found, ok := byDay[key]; ok {
+			entry = *found
+			entry.TaxableSales = roundAmount(entry.TaxableSales)
+			entry.Tax = roundAmount(entry.Tax)
+		}
+		response.Days = append(response.Days, entry)
+	}
+
+	return response, nil
+}
+
+// GetFulfillmentTimes reports how long orders took from being queued to
+// preparation, ready and handed over, per day and over the whole range.
+// Both dates are inclusive calendar days and orders count on the day they
+// were queued. Every day is listed, including those without orders.
+func (s *reportService) GetFulfillmentTimes(startDate, endDate time.Time) (*FulfillmentTimesResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.FulfillmentTimes(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	overall := make(map[string]repositories.FulfillmentRow)
+	byDay := make(map[string]map[string]repositories.FulfillmentRow)
+	for _, row := range rows {
+		if row.Day == nil {
+			overall[row.Stage] = row
+			continue
+		}
+		key := row.Day.Format("2006-01-02")
+		if byDay[key] == nil {
+			byDay[key] = make(map[string]repositories.FulfillmentRow)
+		}
+		byDay[key][row.Stage] = row
+	}
+
+	response := &FulfillmentTimesResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Overall:   fulfillmentStagesFrom(overall),
+		Days:      make([]FulfillmentDay, 0),
+	}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		response.Days = append(response.Days, FulfillmentDay{
+			Date:   key,
+			Stages: fulfillmentStagesFrom(byDay[key]),
+		})
+	}
+
+	return response, nil
+}
+
+// fulfillmentStagesFrom lists every stage in order, converting the
+// percentiles found in rows from seconds to minutes
+func fulfillmentStagesFrom(rows map[string]repositories.FulfillmentRow) []FulfillmentStage {
+	stages := make([]FulfillmentStage, 0, len(fulfillmentStages))
+	for _, name := range fulfillmentStages {
+		stage := FulfillmentStage{Stage: name}
+		if row, ok := rows[name]; ok && row.OrderCount > 0 {
+			p50 := roundAmount(row.P50 / 60)
+			p90 := roundAmount(row.P90 / 60)
+			stage.OrderCount = row.OrderCount
+			stage.P50Minutes = &p50
+			stage.P90Minutes = &p90
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// GetAbandonedOrders reports orders that were created but never paid, per
+// channel and hour of the day, against all orders created, to show where
+// customers drop out of the payment flow. Both dates are inclusive calendar
+// days. Pending orders count as abandoned once their payment deadline has
+// passed. Every channel is listed; hours without orders are left out.
+func (s *reportService) GetAbandonedOrders(startDate, endDate time.Time) (*AbandonedOrdersResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.AbandonedOrders(startDate, endDate.AddDate(0, 0, 1), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	bySource := make(map[models.OrderSource]*AbandonedBySource, len(models.OrderSources))
+	response := &AbandonedOrdersResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Sources:   make([]AbandonedBySource, len(models.OrderSources)),
+		Hours:     make([]AbandonedHour, 0),
+	}
+	for i, source := range models.OrderSources {
+		response.Sources[i] = AbandonedBySource{OrderSource: source, Hours: make([]AbandonedHour, 0)}
+		bySource[source] = &response.Sources[i]
+	}
+
+	var byHour [24]AbandonedHour
+	for _, row := range rows {
+		hour := AbandonedHour{
+			Hour:           row.Hour,
+			OrderCount:     row.OrderCount,
+			AbandonedCount: row.AbandonedCount,
+			Attempted:      row.Attempted,
+			AbandonedValue: row.AbandonedValue,
+			DropOffRate:    dropOffRate(row.AbandonedCount, row.OrderCount),
+		}
+
+		if source, ok := bySource[row.OrderSource]; ok {
+			source.Hours = append(source.Hours, hour)
+			source.OrderCount += row.OrderCount
+			source.AbandonedCount += row.AbandonedCount
+			source.Attempted += row.Attempted
+			source.AbandonedValue += row.AbandonedValue
+		}
+
+		if row.Hour >= 0 && row.Hour < len(byHour) {
+			total := &byHour[row.Hour]
+			total.OrderCount += row.OrderCount
+			total.AbandonedCount += row.AbandonedCount
+			total.Attempted += row.Attempted
+			total.AbandonedValue += row.AbandonedValue
+		}
+	}
+
+	for i := range response.Sources {
+		source := &response.Sources[i]
+		source.AbandonedValue = roundAmount(source.AbandonedValue)
+		source.DropOffRate = dropOffRate(source.AbandonedCount, source.OrderCount)
+		response.TotalOrders += source.OrderCount
+		response.TotalAbandoned += source.AbandonedCount
+		response.TotalAbandonedValue += source.AbandonedValue
+	}
+	for hour, total := range byHour {
+		if total.OrderCount == 0 {
+			continue
+		}
+		total.Hour = hour
+		total.AbandonedValue = roundAmount(total.AbandonedValue)
+		total.DropOffRate = dropOffRate(total.AbandonedCount, total.OrderCount)
+		response.Hours = append(response.Hours, total)
+	}
+	response.TotalAbandonedValue = roundAmount(response.TotalAbandonedValue)
+	response.DropOffRate = dropOffRate(response.TotalAbandoned, response.TotalOrders)
+
+	return response, nil
+}
+
+// dropOffRate is the percentage of orders that were abandoned
+func dropOffRate(abandoned, orders int64) float64 {
+	if orders == 0 {
+		return 0
+	}
+	return roundAmount(float64(abandoned) / float64(orders) * 100)
+}
+
+// GetInventoryUsage reports the stock consumed per stock-tracked product
+// between the dates, both inclusive, and projects how many days the
+// available stock lasts at the average daily usage over the range. Products
+// running out soonest come first; those without usage come last.
+func (s *reportService) GetInventoryUsage(startDate, endDate time.Time) (*InventoryUsageResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.InventoryUsage(startDate, endDate.AddDate(0, 0, 1), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(endDate.Sub(startDate).Hours()/24) + 1
+	response := &InventoryUsageResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Days:      days,
+		Products:  make([]InventoryUsage, 0, len(rows)),
+	}
+	for _, row := range rows {
+		usage := InventoryUsage{
+			ProductID:     row.ProductUUID,
+			ProductName:   row.ProductName,
+			StockQuantity: row.StockQuantity,
+			Reserved:      row.Reserved,
+			Available:     max(int64(row.StockQuantity)-row.Reserved, 0),
+			Consumed:      row.Consumed,
+		}
+		if row.Consumed > 0 {
+			daily := float64(row.Consumed) / float64(days)
+			remaining := roundAmount(float64(usage.Available) / daily)
+			usage.DailyUsage = roundAmount(daily)
+			usage.DaysUntilStockout = &remaining
+		}
+		response.Products = append(response.Products, usage)
+	}
+
+	sort.SliceStable(response.Products, func(i, j int) bool {
+		a, b := response.Products[i].DaysUntilStockout, response.Products[j].DaysUntilStockout
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+
+	return response, nil
+}
+
+// GetTips reports tips on paid orders for distribution to staff. Both dates
+// are inclusive calendar days.
+func (s *reportService) GetTips(startDate, endDate time.Time) (*TipsReportResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.TipsByStaff(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TipsReportResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Staff:     make([]StaffTips, 0, len(rows)),
+	}
+	for _, row := range rows {
+		tips := StaffTips{OrderCount: row.OrderCount, Tips: row.Tips}
+		if row.StaffUUID != nil {
+			tips.Staff = &StaffSummary{ID: *row.StaffUUID}
+			if row.FullName != nil {
+				tips.Staff.FullName = *row.FullName
+			}
+		}
+		response.Staff = append(response.Staff, tips)
+		response.TippedOrders += row.OrderCount
+		response.TotalTips += row.Tips
+	}
+
+	return response, nil
+}
+
+// GetSettlements lists gateway payments settled between the dates, both
+// inclusive, with the fee the gateway kept and the day the payout is
+// expected. Cash payments never pass through a gateway and are left out.
+func (s *reportService) GetSettlements(startDate, endDate time.Time) (*SettlementReportResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.reportRepo.SettledPayments(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SettlementReportResponse{
+		StartDate:   startDate.Format("2006-01-02"),
+		EndDate:     endDate.Format("2006-01-02"),
+		Settlements: make([]Settlement, 0, len(rows)),
+	}
+	for _, row := range rows {
+		// Payments settled before fees were recorded fall back to the schedule
+		var fee float64
+		if row.GatewayFee != nil {
+			fee = *row.GatewayFee
+		} else if row.PaymentType != nil {
+			fee, _ = s.config.GatewayFees.Fee(*row.PaymentType, row.GrossAmount)
+		}
+		settlement := Settlement{
+			PaymentID:     row.PaymentUUID,
+			Reference:     row.Reference,
+			OrderNumber:   row.OrderNumber,
+			Provider:      row.Method,
+			PaymentType:   row.PaymentType,
+			TransactionID: row.TransactionID,
+			SettledAt:     row.SettlementTime.Format("2006-01-02T15:04:05Z07:00"),
+			PayoutDate:    addBusinessDays(row.SettlementTime, s.config.PayoutDelayDays).Format("2006-01-02"),
+			GrossAmount:   row.GrossAmount,
+			Refunded:      row.Refunded,
+			Fee:           fee,
+			NetAmount:     roundAmount(row.GrossAmount - row.Refunded - fee),
+		}
+		response.Settlements = append(response.Settlements, settlement)
+		response.TotalGross += settlement.GrossAmount
+		response.TotalRefunded += settlement.Refunded
+		response.TotalFees += settlement.Fee
+		response.TotalNet += settlement.NetAmount
+	}
+	response.TotalGross = roundAmount(response.TotalGross)
+	response.TotalRefunded = roundAmount(response.TotalRefunded)
+	response.TotalFees = roundAmount(response.TotalFees)
+	response.TotalNet = roundAmount(response.TotalNet)
+
+	return response, nil
+}
+
+// addBusinessDays moves t forward by days, skipping weekends the way gateways
+// schedule payouts. A settlement on a weekend pays out counting from Monday.
+func addBusinessDays(t time.Time, days int) time.Time {
+	for days > 0 {
+		t = t.AddDate(0, 0, 1)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return t
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatCSVAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
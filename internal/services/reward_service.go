@@ -0,0 +1,388 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRewardNotFound         = errors.New("reward not found")
+	ErrRewardUnavailable      = errors.New("reward is not available")
+	ErrRewardCategoryRequired = errors.New("free-item rewards need a category")
+)
+
+// RewardRequest creates or fully replaces a reward in the catalog. A free
+// drink or merch is a free_item reward with the category it covers, where
+// discount_value is the percentage of the item taken off; a discount is a
+// percentage or fixed reward. The voucher a member gets stays valid for
+// valid_days, or forever when it is 0. Leave stock out for no limit.
+type RewardRequest struct {
+	Name          string              `json:"name" validate:"required,min=3,max=100"`
+	Description   *string             `json:"description,omitempty" validate:"omitempty,max=500"`
+	PointsCost    int                 `json:"points_cost" validate:"required,gt=0,lte=10000000"`
+	DiscountType  models.DiscountType `json:"discount_type" validate:"required,oneof=percentage fixed free_item"`
+	DiscountValue float64             `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount   *float64            `json:"max_discount,omitempty" validate:"omitempty,gt=0"`
+	MinSpend      float64             `json:"min_spend" validate:"gte=0"`
+	CategoryID    *uuid.UUID          `json:"category_id,omitempty"`
+	ValidDays     int                 `json:"valid_days" validate:"gte=0,lte=365"`
+	Stock         *int                `json:"stock,omitempty" validate:"omitempty,gte=0"`
+	IsActive      *bool               `json:"is_active,omitempty"`
+}
+
+type RewardCategory struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// RewardResponse describes a reward for admins, with how many times members
+// bought it
+type RewardResponse struct {
+	ID            uuid.UUID           `json:"id"`
+	Name          string              `json:"name"`
+	Description   *string             `json:"description,omitempty"`
+	PointsCost    int                 `json:"points_cost"`
+	DiscountType  models.DiscountType `json:"discount_type"`
+	DiscountValue float64             `json:"discount_value"`
+	MaxDiscount   *float64            `json:"max_discount,omitempty"`
+	MinSpend      float64             `json:"min_spend"`
+	Category      *RewardCategory     `json:"category,omitempty"`
+	ValidDays     int                 `json:"valid_days"`
+	Stock         *int                `json:"stock,omitempty"`
+	IsActive      bool                `json:"is_active"`
+	Redemptions   int64               `json:"redemptions"`
+	CreatedAt     string              `json:"created_at"`
+	UpdatedAt     string              `json:"updated_at"`
+}
+
+// CatalogReward is a reward as members see it. Redeemable tells whether it
+// is in stock and the member has enough points for it.
+type CatalogReward struct {
+	ID            uuid.UUID           `json:"id"`
+	Name          string              `json:"name"`
+	Description   *string             `json:"description,omitempty"`
+	PointsCost    int                 `json:"points_cost"`
+	DiscountType  models.DiscountType `json:"discount_type"`
+	DiscountValue float64             `json:"discount_value"`
+	MaxDiscount   *float64            `json:"max_discount,omitempty"`
+	MinSpend      float64             `json:"min_spend"`
+	Category      *RewardCategory     `json:"category,omitempty"`
+	ValidDays     int                 `json:"valid_days"`
+	InStock       bool                `json:"in_stock"`
+	Redeemable    bool                `json:"redeemable"`
+}
+
+type RewardCatalogResponse struct {
+	PointsBalance int             `json:"points_balance"`
+	Rewards       []CatalogReward `json:"rewards"`
+}
+
+// RewardRedemptionResponse is the voucher a member bought with points, to
+// use at checkout as voucher_code
+type RewardRedemptionResponse struct {
+	Voucher       VoucherResponse `json:"voucher"`
+	PointsSpent   int             `json:"points_spent"`
+	PointsBalance int             `json:"points_balance"`
+}
+
+type RewardService interface {
+	Create(req RewardRequest) (*RewardResponse, error)
+	GetAll() ([]RewardResponse, error)
+	GetByUUID(uuid uuid.UUID) (*RewardResponse, error)
+	Update(uuid uuid.UUID, req RewardRequest) (*RewardResponse, error)
+	Delete(uuid uuid.UUID) error
+	GetCatalog(userUUID uuid.UUID) (*RewardCatalogResponse, error)
+	Redeem(userUUID, rewardUUID uuid.UUID) (*RewardRedemptionResponse, error)
+}
+
+type rewardService struct {
+	rewardRepo   repositories.RewardRepository
+	loyaltyRepo  repositories.LoyaltyRepository
+	userRepo     repositories.UserRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewRewardService(
+	rewardRepo repositories.RewardRepository,
+	loyaltyRepo repositories.LoyaltyRepository,
+	userRepo repositories.UserRepository,
+	categoryRepo repositories.CategoryRepository,
+) RewardService {
+	return &rewardService{
+		rewardRepo:   rewardRepo,
+		loyaltyRepo:  loyaltyRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *rewardService) Create(req RewardRequest) (*RewardResponse, error) {
+	reward := &models.Reward{IsActive: true}
+	if err := s.apply(reward, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.rewardRepo.Create(reward); err != nil {
+		return nil, err
+	}
+
+	return s.toRewardResponse(reward)
+}
+
+func (s *rewardService) GetAll() ([]RewardResponse, error) {
+	rewards, err := s.rewardRepo.FindAll(false)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]RewardResponse, 0, len(rewards))
+	for i := range rewards {
+		response, err := s.toRewardResponse(&rewards[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+func (s *rewardService) GetByUUID(uuid uuid.UUID) (*RewardResponse, error) {
+	reward, err := s.findReward(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.toRewardResponse(reward)
+}
+
+// Update replaces the reward's terms. Vouchers already bought with it keep
+// the terms they were issued with.
+func (s *rewardService) Update(uuid uuid.UUID, req RewardRequest) (*RewardResponse, error) {
+	reward, err := s.findReward(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(reward, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.rewardRepo.Update(reward); err != nil {
+		return nil, err
+	}
+
+	return s.GetByUUID(uuid)
+}
+
+func (s *rewardService) Delete(uuid uuid.UUID) error {
+	reward, err := s.findReward(uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.rewardRepo.Delete(reward.ID)
+	if errors.Is(err, repositories.ErrRewardNotFound) {
+		return ErrRewardNotFound
+	}
+	return err
+}
+
+// GetCatalog lists the rewards switched on, cheapest first, against the
+// member's points balance
+func (s *rewardService) GetCatalog(userUUID uuid.UUID) (*RewardCatalogResponse, error) {
+	user, err := s.findMember(userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.loyaltyRepo.Balance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := s.rewardRepo.FindAll(true)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make([]CatalogReward, len(rewards))
+	for i := range rewards {
+		reward := &rewards[i]
+		catalog[i] = CatalogReward{
+			ID:            reward.UUID,
+			Name:          reward.Name,
+			Description:   reward.Description,
+			PointsCost:    reward.PointsCost,
+			DiscountType:  reward.DiscountType,
+			DiscountValue: reward.DiscountValue,
+			MaxDiscount:   reward.MaxDiscount,
+			MinSpend:      reward.MinSpend,
+			Category:      toRewardCategory(reward.Category),
+			ValidDays:     reward.ValidDays,
+			InStock:       reward.InStock(),
+			Redeemable:    reward.InStock() && balance >= reward.PointsCost,
+		}
+	}
+
+	return &RewardCatalogResponse{PointsBalance: balance, Rewards: catalog}, nil
+}
+
+// Redeem spends the member's points on the reward and issues them a voucher
+// on its terms, redeemable at checkout
+func (s *rewardService) Redeem(userUUID, rewardUUID uuid.UUID) (*RewardRedemptionResponse, error) {
+	user, err := s.findMember(userUUID)
+	if err != nil {
+		return nil, err
+	}
+	reward, err := s.findReward(rewardUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !reward.IsActive || !reward.InStock() {
+		return nil, ErrRewardUnavailable
+	}
+
+	code, err := newCode("VC", 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate voucher code: %w", err)
+	}
+	now := time.Now()
+	reason := "Reward: " + reward.Name
+	voucher := &models.Voucher{
+		Code:          code,
+		UserID:        user.ID,
+		Reason:        reason,
+		DiscountType:  reward.DiscountType,
+		DiscountValue: reward.DiscountValue,
+		MaxDiscount:   reward.MaxDiscount,
+		MinSpend:      reward.MinSpend,
+		Status:        models.VoucherStatusActive,
+		RewardID:      &reward.ID,
+		CategoryID:    reward.CategoryID,
+		CreatedAt:     now,
+	}
+	if reward.ValidDays > 0 {
+		expiresAt := now.AddDate(0, 0, reward.ValidDays)
+		voucher.ExpiresAt = &expiresAt
+	}
+	entry := &models.PointTransaction{
+		UserID: user.ID,
+		Type:   models.PointTransactionRedeem,
+		Points: -reward.PointsCost,
+		Note:   &reason,
+	}
+
+	if err := s.rewardRepo.Redeem(reward, entry, voucher); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrRewardUnavailable):
+			return nil, ErrRewardUnavailable
+		case errors.Is(err, repositories.ErrInsufficientPoints):
+			return nil, ErrInsufficientPoints
+		}
+		return nil, fmt.Errorf("failed to redeem reward: %w", err)
+	}
+
+	return &RewardRedemptionResponse{
+		Voucher:       toVoucherResponse(voucher, now),
+		PointsSpent:   reward.PointsCost,
+		PointsBalance: entry.BalanceAfter,
+	}, nil
+}
+
+// apply validates the request and copies it onto the reward
+func (s *rewardService) apply(reward *models.Reward, req RewardRequest) error {
+	if req.DiscountType != models.DiscountTypeFixed && req.DiscountValue > 100 {
+		return ErrPromotionPercentTooHigh
+	}
+	if req.DiscountType == models.DiscountTypeFreeItem && req.CategoryID == nil {
+		return ErrRewardCategoryRequired
+	}
+
+	var category *models.Category
+	if req.CategoryID != nil && req.DiscountType == models.DiscountTypeFreeItem {
+		found, err := s.categoryRepo.FindByUUID(*req.CategoryID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCategoryNotFound) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		category = found
+	}
+
+	reward.Name = strings.TrimSpace(req.Name)
+	reward.Description = req.Description
+	reward.PointsCost = req.PointsCost
+	reward.DiscountType = req.DiscountType
+	reward.DiscountValue = req.DiscountValue
+	reward.MaxDiscount = req.MaxDiscount
+	reward.MinSpend = req.MinSpend
+	reward.Category = category
+	reward.CategoryID = nil
+	if category != nil {
+		reward.CategoryID = &category.ID
+	}
+	reward.ValidDays = req.ValidDays
+	reward.Stock = req.Stock
+	if req.IsActive != nil {
+		reward.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+func (s *rewardService) findReward(uuid uuid.UUID) (*models.Reward, error) {
+	reward, err := s.rewardRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRewardNotFound) {
+			return nil, ErrRewardNotFound
+		}
+		return nil, err
+	}
+	return reward, nil
+}
+
+func (s *rewardService) findMember(userUUID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *rewardService) toRewardResponse(reward *models.Reward) (*RewardResponse, error) {
+	redemptions, err := s.rewardRepo.CountRedemptions(reward.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RewardResponse{
+		ID:            reward.UUID,
+		Name:          reward.Name,
+		Description:   reward.Description,
+		PointsCost:    reward.PointsCost,
+		DiscountType:  reward.DiscountType,
+		DiscountValue: reward.DiscountValue,
+		MaxDiscount:   reward.MaxDiscount,
+		MinSpend:      reward.MinSpend,
+		Category:      toRewardCategory(reward.Category),
+		ValidDays:     reward.ValidDays,
+		Stock:         reward.Stock,
+		IsActive:      reward.IsActive,
+		Redemptions:   redemptions,
+		CreatedAt:     reward.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     reward.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func toRewardCategory(category *models.Category) *RewardCategory {
+	if category == nil {
+		return nil
+	}
+	return &RewardCategory{ID: category.UUID, Name: category.Name}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// selftestCustomerName marks synthetic orders on kitchen screens while the
+// self-test runs
+const selftestCustomerName = "Deployment self-test"
+
+type SelftestStep struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type SelftestResponse struct {
+	Passed     bool           `json:"passed"`
+	StartedAt  string         `json:"started_at"`
+	DurationMs int64          `json:"duration_ms"`
+	Steps      []SelftestStep `json:"steps"`
+}
+
+type SelftestService interface {
+	Run() *SelftestResponse
+}
+
+type selftestService struct {
+	orderService    OrderService
+	paymentService  PaymentService
+	orderRepo       repositories.OrderRepository
+	paymentRepo     repositories.PaymentRepository
+	reservationRepo repositories.StockReservationRepository
+	logger          *slog.Logger
+	productID       uuid.UUID
+	serverKey       string
+}
+
+func NewSelftestService(
+	orderService OrderService,
+	paymentService PaymentService,
+	orderRepo repositories.OrderRepository,
+	paymentRepo repositories.PaymentRepository,
+	reservationRepo repositories.StockReservationRepository,
+	logger *slog.Logger,
+	productID uuid.UUID,
+	serverKey string,
+) SelftestService {
+	return &selftestService{
+		orderService:    orderService,
+		paymentService:  paymentService,
+		orderRepo:       orderRepo,
+		paymentRepo:     paymentRepo,
+		reservationRepo: reservationRepo,
+		logger:          logger,
+		productID:       productID,
+		serverKey:       serverKey,
+	}
+}
+
+// selftestRun records the steps of one self-test and the orders it created
+type selftestRun struct {
+	steps  []SelftestStep
+	orders []uuid.UUID
+}
+
+// step runs fn unless an earlier step failed, so one failure is reported once
+// instead of cascading through the rest of the lifecycle
+func (r *selftestRun) step(name string, fn func() error) {
+	if len(r.steps) > 0 && !r.steps[len(r.steps)-1].Passed {
+		return
+	}
+
+	start := time.Now()
+	err := fn()
+	step := SelftestStep{
+		Name:       name,
+		Passed:     err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	r.steps = append(r.steps, step)
+}
+
+// Run places a guest order for the sandbox product, pays it through a signed
+// settlement notification, walks it to completed, then places and cancels a
+// second order. The synthetic orders are deleted afterwards, with their stock
+// returned, so they do not show up in reports.
+func (s *selftestService) Run() *SelftestResponse {
+	startedAt := time.Now()
+	run := &selftestRun{}
+
+	var paid *OrderResponse
+	run.step("create_order", func() (err error) {
+		paid, err = s.createOrder(run)
+		return err
+	})
+	run.step("pay", func() error {
+		return s.pay(paid)
+	})
+	run.step("verify_paid", func() error {
+		return s.expectStatus(paid.ID, models.OrderStatusPreparing)
+	})
+	for _, status := range []models.OrderStatus{models.OrderStatusReady, models.OrderStatusCompleted} {
+		run.step("mark_"+string(status), func() error {
+			return s.transition(paid.ID, status)
+		})
+	}
+
+	var cancelled *OrderResponse
+	run.step("create_order_to_cancel", func() (err error) {
+		cancelled, err = s.createOrder(run)
+		return err
+	})
+	run.step("cancel", func() error {
+		return s.transition(cancelled.ID, models.OrderStatusCancelled)
+	})
+
+	passed := true
+	for _, step := range run.steps {
+		passed = passed && step.Passed
+	}
+
+	// Clean up even after a failure so a broken deploy does not leave test orders behind
+	run.steps = append(run.steps, SelftestStep{Name: "cleanup", Passed: true})
+	cleanupStart := time.Now()
+	if err := s.cleanup(run.orders); err != nil {
+		cleanup := &run.steps[len(run.steps)-1]
+		cleanup.Passed = false
+		cleanup.Error = err.Error()
+		passed = false
+	}
+	run.steps[len(run.steps)-1].DurationMs = time.Since(cleanupStart).Milliseconds()
+
+	if !passed {
+		s.logger.Error("Deployment self-test failed", "steps", run.steps)
+	}
+
+	return &SelftestResponse{
+		Passed:     passed,
+		StartedAt:  startedAt.Format("2006-01-02T15:04:05Z07:00"),
+		DurationMs: time.Since(startedAt).Milliseconds(),
+		Steps:      run.steps,
+	}
+}
+
+func (s *selftestService) createOrder(run *selftestRun) (*OrderResponse, error) {
+	order, err := s.orderService.CreateGuestOrder(CreateGuestOrderRequest{
+		CreateOrderRequest: CreateOrderRequest{
+			CustomerName: selftestCustomerName,
+			Items: []CreateOrderItemRequest{
+				{ProductID: s.productID, Quantity: 1},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	run.orders = append(run.orders, order.ID)
+
+	if order.Status != models.OrderStatusPending {
+		return nil, fmt.Errorf("new order is %s, expected %s", order.Status, models.OrderStatusPending)
+	}
+	return order, nil
+}
+
+// pay records a payment the way checkout does and settles it through the
+// webhook handler, without calling Midtrans
+func (s *selftestService) pay(order *OrderResponse) error {
+	stored, err := s.orderRepo.FindByUUID(order.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.reservationRepo.ConsumeByOrderID(stored.ID); err != nil {
+		return fmt.Errorf("failed to confirm stock reservation: %w", err)
+	}
+
+	midtransOrderID := fmt.Sprintf("SELFTEST-%s-%d", stored.OrderNumber, time.Now().Unix())
+	payment := &models.Payment{
+		OrderID:         stored.ID,
+		MidtransOrderID: midtransOrderID,
+		GrossAmount:     stored.AmountDue(),
+		PaymentMetadata: datatypes.JSON("{}"),
+	}
+	if err := s.paymentRepo.Create(payment); err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	notification := &MidtransNotification{
+		TransactionTime:   now,
+		TransactionStatus: string(models.TransactionStatusSettlement),
+		TransactionID:     uuid.NewString(),
+		StatusMessage:     "Self-test settlement",
+		StatusCode:        "200",
+		PaymentType:       "selftest",
+		OrderID:           midtransOrderID,
+		GrossAmount:       strconv.FormatFloat(stored.AmountDue(), 'f', 2, 64),
+		FraudStatus:       string(models.FraudStatusAccept),
+		Currency:          "IDR",
+		SettlementTime:    &now,
+	}
+	notification.SignatureKey = midtransSignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, s.serverKey)
+
+	return s.paymentService.ProcessWebhookNotification(notification)
+}
+
+func (s *selftestService) transition(orderUUID uuid.UUID, status models.OrderStatus) error {
+	order, err := s.orderService.UpdateOrderStatus(orderUUID, status)
+	if err != nil {
+		return err
+	}
+	if order.Status != status {
+		return fmt.Errorf("order is %s, expected %s", order.Status, status)
+	}
+	return nil
+}
+
+func (s *selftestService) expectStatus(orderUUID uuid.UUID, status models.OrderStatus) error {
+	order, err := s.orderService.GetByUUID(orderUUID)
+	if err != nil {
+		return err
+	}
+	if order.Status != status {
+		return fmt.Errorf("order is %s, expected %s", order.Status, status)
+	}
+	return nil
+}
+
+func (s *selftestService) cleanup(orders []uuid.UUID) error {
+	for _, orderUUID := range orders {
+		order, err := s.orderRepo.FindByUUID(orderUUID)
+		if err != nil {
+			return err
+		}
+		if err := s.reservationRepo.ReleaseByOrderID(order.ID); err != nil {
+			return fmt.Errorf("failed to return stock of %s: %w", order.OrderNumber, err)
+		}
+		if err := s.orderRepo.Delete(order.ID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", order.OrderNumber, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// ReceiptSettings controls what is printed around the order lines on receipts
+type ReceiptSettings struct {
+	HeaderText   string `json:"header_text" validate:"max=500"`
+	FooterText   string `json:"footer_text" validate:"max=500"`
+	LogoURL      string `json:"logo_url,omitempty" validate:"omitempty,url,max=255"`
+	TaxID        string `json:"tax_id,omitempty" validate:"max=50"`
+	PromoMessage string `json:"promo_message,omitempty" validate:"max=255"`
+	PaperWidth   int    `json:"paper_width" validate:"omitempty,oneof=32 48"`
+}
+
+// DefaultReceiptSettings is used until an admin saves receipt settings
+var DefaultReceiptSettings = ReceiptSettings{
+	HeaderText: "Matchaciee",
+	FooterText: "Thank you for your order!",
+	PaperWidth: 32,
+}
+
+// NotificationSettings controls which notifications are sent to customers
+type NotificationSettings struct {
+	EmailReceipts      bool `json:"email_receipts"`
+	ReadyNotifications bool `json:"ready_notifications"`
+}
+
+// QRCodeSettings sets the colors of generated QR codes so printed signage
+// matches the store branding
+type QRCodeSettings struct {
+	ForegroundColor string `json:"foreground_color" validate:"omitempty,hexcolor"`
+	BackgroundColor string `json:"background_color" validate:"omitempty,hexcolor"`
+}
+
+// DefaultQRCodeSettings is used until an admin saves QR code settings
+var DefaultQRCodeSettings = QRCodeSettings{
+	ForegroundColor: "#2F5D3A",
+	BackgroundColor: "#FFFFFF",
+}
+
+// TimeslotSettings caps how many orders, and how many drinks across them, are
+// due for pickup in each timeslot. Zero leaves a limit off.
+type TimeslotSettings struct {
+	MaxOrders int `json:"max_orders" validate:"gte=0,lte=1000"`
+	MaxDrinks int `json:"max_drinks" validate:"gte=0,lte=10000"`
+}
+
+// IsLimited reports whether any capacity limit is set
+func (t TimeslotSettings) IsLimited() bool {
+	return t.MaxOrders > 0 || t.MaxDrinks > 0
+}
+
+// PaymentSettings limits the payment methods offered on the Midtrans payment
+// page, in the order listed. Leave EnabledPayments empty to offer every method
+// active on the Midtrans account.
+type PaymentSettings struct {
+	EnabledPayments []string `json:"enabled_payments" validate:"omitempty,max=20,unique,dive,oneof=credit_card gopay shopeepay other_qris bank_transfer echannel permata_va bca_va bni_va bri_va cimb_va other_va indomaret alfamart akulaku kredivo"`
+}
+
+// LoyaltySettings sets how members earn and spend loyalty points. Members earn
+// a point for every SpendPerPoint spent on a completed order, and each point
+// takes PointValue off an order. Zero turns earning or spending off. Points
+// expire ExpiryMonths after they were earned, oldest first, and members are
+// warned ExpiryWarningDays before. Zero keeps points forever or sends no
+// warnings.
+type LoyaltySettings struct {
+	SpendPerPoint     float64 `json:"spend_per_point" validate:"gte=0,lte=100000000"`
+	PointValue        float64 `json:"point_value" validate:"gte=0,lte=1000000"`
+	ExpiryMonths      int     `json:"expiry_months" validate:"gte=0,lte=120"`
+	ExpiryWarningDays int     `json:"expiry_warning_days" validate:"gte=0,lte=90"`
+}
+
+// PointsFor is how many points a purchase of amount earns
+func (l LoyaltySettings) PointsFor(amount float64) int {
+	if l.SpendPerPoint <= 0 || amount <= 0 {
+		return 0
+	}
+	return int(math.Floor(amount / l.SpendPerPoint))
+}
+
+// ExpiresAt is when points earned at earnedAt expire, or nil when points
+// are kept forever
+func (l LoyaltySettings) ExpiresAt(earnedAt time.Time) *time.Time {
+	if l.ExpiryMonths <= 0 {
+		return nil
+	}
+	expiresAt := earnedAt.AddDate(0, l.ExpiryMonths, 0)
+	return &expiresAt
+}
+
+// EarnedBefore is the cutoff for points that have expired by t: those earned
+// before it are gone
+func (l LoyaltySettings) EarnedBefore(t time.Time) time.Time {
+	return t.AddDate(0, -l.ExpiryMonths, 0)
+}
+
+// CurrencySettings is the currency prices are set and charged in. Decimals is
+// how many decimal places amounts are rounded and written to. Changing it does
+// not convert existing prices or orders.
+type CurrencySettings struct {
+	Code     string `json:"code" validate:"required,len=3,uppercase,alpha"`
+	Symbol   string `json:"symbol" validate:"required,max=5"`
+	Decimals int    `json:"decimals" validate:"gte=0,lte=3"`
+}
+
+// DefaultCurrencySettings is used until an admin saves currency settings
+var DefaultCurrencySettings = CurrencySettings{
+	Code:     utils.CurrencyIDR.Code,
+	Symbol:   utils.CurrencyIDR.Symbol,
+	Decimals: utils.CurrencyIDR.Decimals,
+}
+
+// Round rounds amount to the currency's decimal places
+func (c CurrencySettings) Round(amount float64) float64 {
+	scale := math.Pow10(c.Decimals)
+	return math.Round(amount*scale) / scale
+}
+
+// Currency returns the settings in the form the formatter takes
+func (c CurrencySettings) Currency() utils.Currency {
+	return utils.Currency{Code: c.Code, Symbol: c.Symbol, Decimals: c.Decimals}
+}
+
+// OrderCurrency is how amounts of an order placed in code are written. Orders
+// from before the store changed currency show the bare code with two decimals.
+func (c CurrencySettings) OrderCurrency(code string) utils.Currency {
+	if code == "" || code == c.Code {
+		return c.Currency()
+	}
+	return utils.Currency{Code: code, Symbol: code + " ", Decimals: 2}
+}
+
+// DepositSettings asks large orders for a down payment. Orders totalling at
+// least MinTotal pay Percent of their total first and the rest before they
+// are prepared. A percent of zero turns deposits off.
+type DepositSettings struct {
+	Percent  float64 `json:"percent" validate:"gte=0,lt=100"`
+	MinTotal float64 `json:"min_total" validate:"gte=0,lte=1000000000"`
+}
+
+// DepositFor is the deposit an order totalling total pays first, or zero when
+// it is paid in one go
+func (d DepositSettings) DepositFor(total float64) float64 {
+	if d.Percent <= 0 || total <= 0 || total < d.MinTotal {
+		return 0
+	}
+	return total * d.Percent / 100
+}
+
+// StampSettings runs the stamp card. Members get a stamp for every qualifying
+// drink on a completed order, and each time they collect Goal stamps the card
+// is swapped for a voucher for a free drink. Items qualify when they are in
+// CategoryID or a category nested under it, or all items when it is not set.
+// The free drink is worth at most RewardMaxValue, or any price when zero, and
+// the voucher lasts RewardValidDays, or forever when zero. A goal of zero
+// turns the card off.
+type StampSettings struct {
+	Goal            int        `json:"goal" validate:"gte=0,lte=100"`
+	CategoryID      *uuid.UUID `json:"category_id,omitempty"`
+	RewardMaxValue  float64    `json:"reward_max_value" validate:"gte=0,lte=100000000"`
+	RewardValidDays int        `json:"reward_valid_days" validate:"gte=0,lte=3650"`
+}
+
+// Enabled reports whether members collect stamps
+func (s StampSettings) Enabled() bool {
+	return s.Goal > 0
+}
+
+// rewardVoucher is a new free-drink voucher for the member, valid from now
+func (s StampSettings) rewardVoucher(userID uint, now time.Time) (*models.Voucher, error) {
+	code, err := newCode("VC", 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate voucher code: %w", err)
+	}
+	voucher := &models.Voucher{
+		Code:          code,
+		UserID:        userID,
+		Reason:        stampRewardReason,
+		DiscountType:  models.DiscountTypeFreeItem,
+		DiscountValue: 100,
+		Status:        models.VoucherStatusActive,
+	}
+	if s.RewardMaxValue > 0 {
+		maxValue := s.RewardMaxValue
+		voucher.MaxDiscount = &maxValue
+	}
+	if s.RewardValidDays > 0 {
+		expiresAt := now.AddDate(0, 0, s.RewardValidDays)
+		voucher.ExpiresAt = &expiresAt
+	}
+	return voucher, nil
+}
+
+type SettingsService interface {
+	GetReceiptSettings() (*ReceiptSettings, error)
+	UpdateReceiptSettings(req ReceiptSettings) (*ReceiptSettings, error)
+	GetNotificationSettings() (*NotificationSettings, error)
+	UpdateNotificationSettings(req NotificationSettings) (*NotificationSettings, error)
+	GetQRCodeSettings() (*QRCodeSettings, error)
+	UpdateQRCodeSettings(req QRCodeSettings) (*QRCodeSettings, error)
+	GetTimeslotSettings() (*TimeslotSettings, error)
+	UpdateTimeslotSettings(req TimeslotSettings) (*TimeslotSettings, error)
+	GetPaymentSettings() (*PaymentSettings, error)
+	UpdatePaymentSettings(req PaymentSettings) (*PaymentSettings, error)
+	GetLoyaltySettings() (*LoyaltySettings, error)
+	UpdateLoyaltySettings(req LoyaltySettings) (*LoyaltySettings, error)
+	GetCurrencySettings() (*CurrencySettings, error)
+	UpdateCurrencySettings(req CurrencySettings) (*CurrencySettings, error)
+	GetDepositSettings() (*DepositSettings, error)
+	UpdateDepositSettings(req DepositSettings) (*DepositSettings, error)
+	GetStampSettings() (*StampSettings, error)
+	UpdateStampSettings(req StampSettings) (*StampSettings, error)
+}
+
+type settingsService struct {
+	settingRepo repositories.SettingRepository
+}
+
+func NewSettingsService(settingRepo repositories.SettingRepository) SettingsService {
+	return &settingsService{
+		settingRepo: settingRepo,
+	}
+}
+
+func (s *settingsService) GetReceiptSettings() (*ReceiptSettings, error) {
+	settings := DefaultReceiptSettings
+	if err := s.load(models.SettingKeyReceipt, &settings); err != nil {
+		return nil, err
+	}
+	if settings.PaperWidth == 0 {
+		settings.PaperWidth = DefaultReceiptSettings.PaperWidth
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateReceiptSettings(req ReceiptSettings) (*ReceiptSettings, error) {
+	if req.PaperWidth == 0 {
+		req.PaperWidth = DefaultReceiptSettings.PaperWidth
+	}
+
+	if err := s.save(models.SettingKeyReceipt, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetNotificationSettings returns the saved settings. Receipt emails and
+// ready notifications stay off until an admin turns them on.
+func (s *settingsService) GetNotificationSettings() (*NotificationSettings, error) {
+	var settings NotificationSettings
+	if err := s.load(models.SettingKeyNotifications, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateNotificationSettings(req NotificationSettings) (*NotificationSettings, error) {
+	if err := s.save(models.SettingKeyNotifications, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (s *settingsService) GetQRCodeSettings() (*QRCodeSettings, error) {
+	settings := DefaultQRCodeSettings
+	if err := s.load(models.SettingKeyQRCode, &settings); err != nil {
+		return nil, err
+	}
+	settings.fillDefaults()
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateQRCodeSettings(req QRCodeSettings) (*QRCodeSettings, error) {
+	req.fillDefaults()
+
+	if err := s.save(models.SettingKeyQRCode, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetTimeslotSettings returns the saved capacity limits. Timeslots are
+// unlimited until an admin sets them.
+func (s *settingsService) GetTimeslotSettings() (*TimeslotSettings, error) {
+	var settings TimeslotSettings
+	if err := s.load(models.SettingKeyTimeslots, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateTimeslotSettings(req TimeslotSettings) (*TimeslotSettings, error) {
+	if err := s.save(models.SettingKeyTimeslots, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetPaymentSettings returns the saved settings. Every payment method is
+// offered until an admin picks some.
+func (s *settingsService) GetPaymentSettings() (*PaymentSettings, error) {
+	settings := PaymentSettings{EnabledPayments: []string{}}
+	if err := s.load(models.SettingKeyPayments, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdatePaymentSettings(req PaymentSettings) (*PaymentSettings, error) {
+	if req.EnabledPayments == nil {
+		req.EnabledPayments = []string{}
+	}
+
+	if err := s.save(models.SettingKeyPayments, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetLoyaltySettings returns the saved settings. Points are neither earned nor
+// spent until an admin sets the rates.
+func (s *settingsService) GetLoyaltySettings() (*LoyaltySettings, error) {
+	var settings LoyaltySettings
+	if err := s.load(models.SettingKeyLoyalty, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateLoyaltySettings(req LoyaltySettings) (*LoyaltySettings, error) {
+	if err := s.save(models.SettingKeyLoyalty, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetCurrencySettings returns the saved currency, or rupiah until an admin
+// picks another
+func (s *settingsService) GetCurrencySettings() (*CurrencySettings, error) {
+	settings := DefaultCurrencySettings
+	if err := s.load(models.SettingKeyCurrency, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateCurrencySettings(req CurrencySettings) (*CurrencySettings, error) {
+	if err := s.save(models.SettingKeyCurrency, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetDepositSettings returns the saved settings. Orders are paid in one go
+// until an admin sets a deposit.
+func (s *settingsService) GetDepositSettings() (*DepositSettings, error) {
+	var settings DepositSettings
+	if err := s.load(models.SettingKeyDeposits, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateDepositSettings(req DepositSettings) (*DepositSettings, error) {
+	if err := s.save(models.SettingKeyDeposits, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// GetStampSettings returns the saved settings. There is no stamp card until an
+// admin sets a goal.
+func (s *settingsService) GetStampSettings() (*StampSettings, error) {
+	var settings StampSettings
+	if err := s.load(models.SettingKeyStamps, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *settingsService) UpdateStampSettings(req StampSettings) (*StampSettings, error) {
+	if err := s.save(models.SettingKeyStamps, req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (q *QRCodeSettings) fillDefaults() {
+	if q.ForegroundColor == "" {
+		q.ForegroundColor = DefaultQRCodeSettings.ForegroundColor
+	}
+	if q.BackgroundColor == "" {
+		q.BackgroundColor = DefaultQRCodeSettings.BackgroundColor
+	}
+}
+
+// load decodes a stored setting into dest, leaving dest untouched if it was never saved
+func (s *settingsService) load(key string, dest any) error {
+	setting, err := s.settingRepo.FindByKey(key)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSettingNotFound) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(setting.Value, dest)
+}
+
+func (s *settingsService) save(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.settingRepo.Upsert(&models.Setting{Key: key, Value: data})
+}
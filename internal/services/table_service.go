@@ -0,0 +1,245 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDiningTableNotFound = errors.New("table not found")
+	ErrTableNumberExists   = errors.New("table number already exists")
+)
+
+// tableTokenBytes is the entropy of a table QR token; hex doubles its length
+const tableTokenBytes = 16
+
+type TableRequest struct {
+	Number   string `json:"number" validate:"required,max=20"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
+// TableResponse is a table with the link its QR code should encode
+type TableResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Number    string    `json:"number"`
+	IsActive  bool      `json:"is_active"`
+	URL       string    `json:"url"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+// ScannedTableResponse is what a customer learns from scanning a table QR
+// code: the table to send with their order
+type ScannedTableResponse struct {
+	ID     uuid.UUID `json:"id"`
+	Number string    `json:"number"`
+}
+
+type TableService interface {
+	Create(req TableRequest) (*TableResponse, error)
+	GetAll() ([]TableResponse, error)
+	GetByUUID(uuid uuid.UUID) (*TableResponse, error)
+	Update(uuid uuid.UUID, req TableRequest) (*TableResponse, error)
+	Delete(uuid uuid.UUID) error
+	RegenerateToken(uuid uuid.UUID) (*TableResponse, error)
+	Scan(token string) (*ScannedTableResponse, error)
+}
+
+type tableService struct {
+	tableRepo   repositories.DiningTableRepository
+	frontendURL string
+}
+
+func NewTableService(tableRepo repositories.DiningTableRepository, frontendURL string) TableService {
+	return &tableService{
+		tableRepo:   tableRepo,
+		frontendURL: frontendURL,
+	}
+}
+
+func (s *tableService) Create(req TableRequest) (*TableResponse, error) {
+	number := strings.TrimSpace(req.Number)
+	if err := s.checkNumberAvailable(number, 0); err != nil {
+		return nil, err
+	}
+
+	token, err := newTableToken()
+	if err != nil {
+		return nil, err
+	}
+
+	table := &models.DiningTable{
+		Number:   number,
+		Token:    token,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		table.IsActive = *req.IsActive
+	}
+
+	if err := s.tableRepo.Create(table); err != nil {
+		return nil, err
+	}
+
+	return s.toTableResponse(table), nil
+}
+
+func (s *tableService) GetAll() ([]TableResponse, error) {
+	tables, err := s.tableRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]TableResponse, len(tables))
+	for i := range tables {
+		responses[i] = *s.toTableResponse(&tables[i])
+	}
+	return responses, nil
+}
+
+func (s *tableService) GetByUUID(uuid uuid.UUID) (*TableResponse, error) {
+	table, err := s.findTable(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return s.toTableResponse(table), nil
+}
+
+// Update renames or switches a table on or off. The token is kept, so printed
+// QR codes keep working.
+func (s *tableService) Update(uuid uuid.UUID, req TableRequest) (*TableResponse, error) {
+	table, err := s.findTable(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	number := strings.TrimSpace(req.Number)
+	if err := s.checkNumberAvailable(number, table.ID); err != nil {
+		return nil, err
+	}
+
+	table.Number = number
+	if req.IsActive != nil {
+		table.IsActive = *req.IsActive
+	}
+
+	if err := s.saveTable(table); err != nil {
+		return nil, err
+	}
+	return s.GetByUUID(uuid)
+}
+
+func (s *tableService) Delete(uuid uuid.UUID) error {
+	table, err := s.findTable(uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.tableRepo.Delete(table.ID)
+	if errors.Is(err, repositories.ErrDiningTableNotFound) {
+		return ErrDiningTableNotFound
+	}
+	return err
+}
+
+// RegenerateToken gives the table a new QR token. Codes printed with the old
+// token stop working, e.g. after one was photographed and shared.
+func (s *tableService) RegenerateToken(uuid uuid.UUID) (*TableResponse, error) {
+	table, err := s.findTable(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	table.Token, err = newTableToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveTable(table); err != nil {
+		return nil, err
+	}
+	return s.GetByUUID(uuid)
+}
+
+// Scan resolves the token from a table QR code. Inactive tables are reported
+// as not found so customers cannot order to them.
+func (s *tableService) Scan(token string) (*ScannedTableResponse, error) {
+	table, err := s.tableRepo.FindByToken(token)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiningTableNotFound) {
+			return nil, ErrDiningTableNotFound
+		}
+		return nil, err
+	}
+	if !table.IsActive {
+		return nil, ErrDiningTableNotFound
+	}
+
+	return &ScannedTableResponse{ID: table.UUID, Number: table.Number}, nil
+}
+
+func (s *tableService) findTable(uuid uuid.UUID) (*models.DiningTable, error) {
+	table, err := s.tableRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiningTableNotFound) {
+			return nil, ErrDiningTableNotFound
+		}
+		return nil, err
+	}
+	return table, nil
+}
+
+func (s *tableService) saveTable(table *models.DiningTable) error {
+	err := s.tableRepo.Update(table)
+	if errors.Is(err, repositories.ErrDiningTableNotFound) {
+		return ErrDiningTableNotFound
+	}
+	return err
+}
+
+// checkNumberAvailable fails when another table than exceptID already uses
+// the number
+func (s *tableService) checkNumberAvailable(number string, exceptID uint) error {
+	existing, err := s.tableRepo.FindByNumber(number)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiningTableNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != exceptID {
+		return ErrTableNumberExists
+	}
+	return nil
+}
+
+func (s *tableService) toTableResponse(table *models.DiningTable) *TableResponse {
+	return &TableResponse{
+		ID:        table.UUID,
+		Number:    table.Number,
+		IsActive:  table.IsActive,
+		URL:       tableMenuURL(s.frontendURL, table.Token),
+		CreatedAt: table.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: table.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// tableMenuURL is the menu link encoded in a table's QR code
+func tableMenuURL(frontendURL, token string) string {
+	return frontendURL + "/menu?table=" + url.QueryEscape(token)
+}
+
+func newTableToken() (string, error) {
+	b := make([]byte, tableTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownTrashType  = errors.New("unknown trash item type")
+	ErrTrashItemNotFound = errors.New("trash item not found")
+	ErrNotInTrash        = errors.New("item is not in the trash")
+)
+
+// Trash item types. Users are not soft deleted, so they have no trash yet.
+const (
+	TrashTypeProduct  = "products"
+	TrashTypeCategory = "categories"
+)
+
+type TrashItem struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Category  *string   `json:"category,omitempty"`
+	DeletedAt string    `json:"deleted_at"`
+}
+
+type TrashResponse struct {
+	Products   []TrashItem `json:"products"`
+	Categories []TrashItem `json:"categories"`
+}
+
+// PurgeImpactResponse previews what a permanent delete would change. Only the
+// counts relevant to the item type are set.
+type PurgeImpactResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	Type               string    `json:"type"`
+	Name               string    `json:"name"`
+	OrderItems         *int64    `json:"order_items,omitempty"`
+	Orders             *int64    `json:"orders,omitempty"`
+	Customizations     *int64    `json:"customizations,omitempty"`
+	ActiveReservations *int64    `json:"active_reservations,omitempty"`
+	ChildCategories    *int64    `json:"child_categories,omitempty"`
+	Products           *int64    `json:"products,omitempty"`
+	Effects            []string  `json:"effects"`
+}
+
+type TrashService interface {
+	List(itemType string) (*TrashResponse, error)
+	Restore(itemType string, id uuid.UUID) error
+	PurgeImpact(itemType string, id uuid.UUID) (*PurgeImpactResponse, error)
+	Purge(itemType string, id uuid.UUID) error
+}
+
+type trashService struct {
+	trashRepo    repositories.TrashRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewTrashService(
+	trashRepo repositories.TrashRepository,
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+) TrashService {
+	return &trashService{
+		trashRepo:    trashRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// List returns the soft-deleted items of itemType, or of every type when
+// itemType is empty, most recently deleted first
+func (s *trashService) List(itemType string) (*TrashResponse, error) {
+	if itemType != "" && itemType != TrashTypeProduct && itemType != TrashTypeCategory {
+		return nil, ErrUnknownTrashType
+	}
+
+	response := &TrashResponse{
+		Products:   []TrashItem{},
+		Categories: []TrashItem{},
+	}
+
+	if itemType == "" || itemType == TrashTypeProduct {
+		products, err := s.trashRepo.FindDeletedProducts()
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			item := TrashItem{
+				ID:        product.UUID,
+				Type:      TrashTypeProduct,
+				Name:      product.Name,
+				Slug:      product.Slug,
+				DeletedAt: product.DeletedAt.Format("2006-01-02T15:04:05Z07:00"),
+			}
+			if product.Category != nil {
+				item.Category = &product.Category.Name
+			}
+			response.Products = append(response.Products, item)
+		}
+	}
+
+	if itemType == "" || itemType == TrashTypeCategory {
+		categories, err := s.trashRepo.FindDeletedCategories()
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			response.Categories = append(response.Categories, TrashItem{
+				ID:        category.UUID,
+				Type:      TrashTypeCategory,
+				Name:      category.Name,
+				Slug:      category.Slug,
+				DeletedAt: category.DeletedAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+func (s *trashService) Restore(itemType string, id uuid.UUID) error {
+	switch itemType {
+	case TrashTypeProduct:
+		product, err := s.findDeletedProduct(id)
+		if err != nil {
+			return err
+		}
+		return s.productRepo.Restore(product.ID)
+	case TrashTypeCategory:
+		category, err := s.findDeletedCategory(id)
+		if err != nil {
+			return err
+		}
+		return s.categoryRepo.Restore(category.ID)
+	default:
+		return ErrUnknownTrashType
+	}
+}
+
+func (s *trashService) PurgeImpact(itemType string, id uuid.UUID) (*PurgeImpactResponse, error) {
+	switch itemType {
+	case TrashTypeProduct:
+		product, err := s.findDeletedProduct(id)
+		if err != nil {
+			return nil, err
+		}
+		impact, err := s.trashRepo.ProductPurgeImpact(product.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &PurgeImpactResponse{
+			ID:                 product.UUID,
+			Type:               TrashTypeProduct,
+			Name:               product.Name,
+			OrderItems:         &impact.OrderItems,
+			Orders:             &impact.Orders,
+			Customizations:     &impact.Customizations,
+			ActiveReservations: &impact.ActiveReservations,
+			Effects:            []string{},
+		}
+		if impact.OrderItems > 0 {
+			response.Effects = append(response.Effects,
+				"Order items keep the product name and price but lose the link to the product")
+		}
+		if impact.Customizations > 0 {
+			response.Effects = append(response.Effects, "Customizations are deleted")
+		}
+		if impact.ActiveReservations > 0 {
+			response.Effects = append(response.Effects,
+				"Active stock reservations are deleted; pending orders keep their items")
+		}
+		return response, nil
+	case TrashTypeCategory:
+		category, err := s.findDeletedCategory(id)
+		if err != nil {
+			return nil, err
+		}
+		impact, err := s.trashRepo.CategoryPurgeImpact(category.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &PurgeImpactResponse{
+			ID:              category.UUID,
+			Type:            TrashTypeCategory,
+			Name:            category.Name,
+			ChildCategories: &impact.ChildCategories,
+			Products:        &impact.Products,
+			Effects:         []string{},
+		}
+		if impact.ChildCategories > 0 {
+			response.Effects = append(response.Effects, "Child categories move to the top level")
+		}
+		if impact.Products > 0 {
+			response.Effects = append(response.Effects, "Products become uncategorized")
+		}
+		return response, nil
+	default:
+		return nil, ErrUnknownTrashType
+	}
+}
+
+// Purge permanently deletes an item. Only items already in the trash can be
+// purged so a live product is never removed by mistake.
+func (s *trashService) Purge(itemType string, id uuid.UUID) error {
+	switch itemType {
+	case TrashTypeProduct:
+		product, err := s.findDeletedProduct(id)
+		if err != nil {
+			return err
+		}
+		if err := s.trashRepo.PurgeProduct(product.ID); err != nil {
+			if errors.Is(err, repositories.ErrProductNotFound) {
+				return ErrNotInTrash
+			}
+			return err
+		}
+		return nil
+	case TrashTypeCategory:
+		category, err := s.findDeletedCategory(id)
+		if err != nil {
+			return err
+		}
+		if err := s.trashRepo.PurgeCategory(category.ID); err != nil {
+			if errors.Is(err, repositories.ErrCategoryNotFound) {
+				return ErrNotInTrash
+			}
+			return err
+		}
+		return nil
+	default:
+		return ErrUnknownTrashType
+	}
+}
+
+func (s *trashService) findDeletedProduct(id uuid.UUID) (*models.Product, error) {
+	product, err := s.productRepo.FindByUUIDIncludingDeleted(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProductNotFound) {
+			return nil, ErrTrashItemNotFound
+		}
+		return nil, err
+	}
+	if product.DeletedAt == nil {
+		return nil, ErrNotInTrash
+	}
+	return product, nil
+}
+
+func (s *trashService) findDeletedCategory(id uuid.UUID) (*models.Category, error) {
+	category, err := s.categoryRepo.FindByUUIDIncludingDeleted(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryNotFound) {
+			return nil, ErrTrashItemNotFound
+		}
+		return nil, err
+	}
+	if category.DeletedAt == nil {
+		return nil, ErrNotInTrash
+	}
+	return category, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+)
+
+type RouteUsage struct {
+	Method       string  `json:"method"`
+	Route        string  `json:"route"`
+	Requests     int64   `json:"requests"`
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	LastSeen     string  `json:"last_seen"`
+}
+
+type ClientUsage struct {
+	Client       string       `json:"client"`
+	Requests     int64        `json:"requests"`
+	ClientErrors int64        `json:"client_errors"`
+	ServerErrors int64        `json:"server_errors"`
+	ErrorRate    float64      `json:"error_rate"`
+	AvgLatencyMs float64      `json:"avg_latency_ms"`
+	MaxLatencyMs int64        `json:"max_latency_ms"`
+	LastSeen     string       `json:"last_seen"`
+	Routes       []RouteUsage `json:"routes"`
+}
+
+type UsageResponse struct {
+	StartDate string        `json:"start_date"`
+	EndDate   string        `json:"end_date"`
+	Clients   []ClientUsage `json:"clients"`
+}
+
+type UsageService interface {
+	Record(counts map[metrics.UsageKey]metrics.UsageCount) error
+	GetUsage(startDate, endDate time.Time, client string) (*UsageResponse, error)
+}
+
+type usageService struct {
+	usageRepo repositories.UsageRepository
+}
+
+func NewUsageService(usageRepo repositories.UsageRepository) UsageService {
+	return &usageService{
+		usageRepo: usageRepo,
+	}
+}
+
+// Record stores counts collected by this instance
+func (s *usageService) Record(counts map[metrics.UsageKey]metrics.UsageCount) error {
+	usage := make([]models.APIUsage, 0, len(counts))
+	for key, count := range counts {
+		usage = append(usage, models.APIUsage{
+			BucketStart:    key.BucketStart,
+			Client:         key.Client,
+			Method:         key.Method,
+			Route:          key.Route,
+			Requests:       count.Requests,
+			ClientErrors:   count.ClientErrors,
+			ServerErrors:   count.ServerErrors,
+			TotalLatencyMs: count.TotalLatency.Milliseconds(),
+			MaxLatencyMs:   count.MaxLatency.Milliseconds(),
+		})
+	}
+	return s.usageRepo.Add(usage)
+}
+
+// GetUsage reports traffic per client with a per-route breakdown, busiest
+// client first. Both dates are inclusive calendar days; an empty client
+// includes every client.
+func (s *usageService) GetUsage(startDate, endDate time.Time, client string) (*UsageResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	rows, err := s.usageRepo.RouteUsage(startDate, endDate.AddDate(0, 0, 1), client)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UsageResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Clients:   make([]ClientUsage, 0),
+	}
+
+	// Routes arrive busiest first and keep that order within their client
+	index := make(map[string]int)
+	var totalLatency []int64
+	var lastSeen []time.Time
+	for _, row := range rows {
+		i, ok := index[row.Client]
+		if !ok {
+			i = len(response.Clients)
+			index[row.Client] = i
+			response.Clients = append(response.Clients, ClientUsage{Client: row.Client, Routes: make([]RouteUsage, 0)})
+			totalLatency = append(totalLatency, 0)
+			lastSeen = append(lastSeen, time.Time{})
+		}
+
+		usage := &response.Clients[i]
+		usage.Requests += row.Requests
+		usage.ClientErrors += row.ClientErrors
+		usage.ServerErrors += row.ServerErrors
+		usage.MaxLatencyMs = max(usage.MaxLatencyMs, row.MaxLatencyMs)
+		totalLatency[i] += row.TotalLatencyMs
+		if row.LastSeen.After(lastSeen[i]) {
+			lastSeen[i] = row.LastSeen
+		}
+
+		usage.Routes = append(usage.Routes, RouteUsage{
+			Method:       row.Method,
+			Route:        row.Route,
+			Requests:     row.Requests,
+			ErrorRate:    usageRatio(row.ClientErrors+row.ServerErrors, row.Requests),
+			AvgLatencyMs: usageRatio(row.TotalLatencyMs, row.Requests),
+			LastSeen:     row.LastSeen.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	for i := range response.Clients {
+		usage := &response.Clients[i]
+		usage.ErrorRate = usageRatio(usage.ClientErrors+usage.ServerErrors, usage.Requests)
+		usage.AvgLatencyMs = usageRatio(totalLatency[i], usage.Requests)
+		usage.LastSeen = lastSeen[i].Format("2006-01-02T15:04:05Z07:00")
+	}
+	slices.SortStableFunc(response.Clients, func(a, b ClientUsage) int {
+		return cmp.Compare(b.Requests, a.Requests)
+	})
+
+	return response, nil
+}
+
+func usageRatio(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 10000
+}
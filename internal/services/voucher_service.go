@@ -0,0 +1,372 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVoucherNotFound          = errors.New("voucher not found")
+	ErrVoucherNotActive         = errors.New("only active vouchers can be revoked")
+	ErrVoucherRecipients        = errors.New("vouchers go to either a list of members or a segment")
+	ErrVoucherMemberNotFound    = errors.New("voucher recipient is not a member")
+	ErrVoucherSegmentEmpty      = errors.New("no members in the segment")
+	ErrVoucherExpiryNotInFuture = errors.New("voucher must expire in the future")
+)
+
+// IssueVouchersRequest grants a voucher to each of the listed members, or to
+// every member of a segment: all members, lapsed members with no completed
+// order in inactive_days, or new members without a completed order.
+type IssueVouchersRequest struct {
+	UserIDs       []uuid.UUID                `json:"user_ids,omitempty" validate:"omitempty,max=100,unique,dive,required"`
+	Segment       repositories.MemberSegment `json:"segment,omitempty" validate:"omitempty,oneof=all lapsed new"`
+	InactiveDays  int                        `json:"inactive_days,omitempty" validate:"required_if=Segment lapsed,omitempty,min=1,max=365"`
+	Reason        string                     `json:"reason" validate:"required,min=3,max=255"`
+	DiscountType  models.DiscountType        `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue float64                    `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount   *float64                   `json:"max_discount,omitempty" validate:"omitempty,gt=0"`
+	MinSpend      float64                    `json:"min_spend" validate:"gte=0"`
+	ExpiresAt     *time.Time                 `json:"expires_at,omitempty"`
+}
+
+type RevokeVoucherRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"`
+}
+
+type VoucherMember struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+}
+
+// VoucherEventEntry is one step in a voucher's audit trail
+type VoucherEventEntry struct {
+	Action      models.VoucherAction `json:"action"`
+	ActorName   *string              `json:"actor_name,omitempty"`
+	OrderID     *uuid.UUID           `json:"order_id,omitempty"`
+	OrderNumber *string              `json:"order_number,omitempty"`
+	Note        *string              `json:"note,omitempty"`
+	CreatedAt   string               `json:"created_at"`
+}
+
+// VoucherResponse describes a voucher. Redeemable tells whether it can still
+// be used, which also accounts for its expiry.
+type VoucherResponse struct {
+	ID            uuid.UUID            `json:"id"`
+	Code          string               `json:"code"`
+	Reason        string               `json:"reason"`
+	DiscountType  models.DiscountType  `json:"discount_type"`
+	DiscountValue float64              `json:"discount_value"`
+	MaxDiscount   *float64             `json:"max_discount,omitempty"`
+	MinSpend      float64              `json:"min_spend"`
+	ExpiresAt     *string              `json:"expires_at,omitempty"`
+	Status        models.VoucherStatus `json:"status"`
+	Redeemable    bool                 `json:"redeemable"`
+	Member        *VoucherMember       `json:"member,omitempty"`
+	OrderID       *uuid.UUID           `json:"order_id,omitempty"`
+	OrderNumber   *string              `json:"order_number,omitempty"`
+	RedeemedAt    *string              `json:"redeemed_at,omitempty"`
+	RevokedAt     *string              `json:"revoked_at,omitempty"`
+	RevokeReason  *string              `json:"revoke_reason,omitempty"`
+	Events        []VoucherEventEntry  `json:"events,omitempty"`
+	CreatedAt     string               `json:"created_at"`
+}
+
+type VoucherListResponse struct {
+	Vouchers []VoucherResponse `json:"vouchers"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
+
+type IssueVouchersResponse struct {
+	Issued   int               `json:"issued"`
+	Vouchers []VoucherResponse `json:"vouchers"`
+}
+
+type VoucherService interface {
+	Issue(staffUUID uuid.UUID, req IssueVouchersRequest) (*IssueVouchersResponse, error)
+	GetAll(status *models.VoucherStatus, memberUUID *uuid.UUID, page, limit int) (*VoucherListResponse, error)
+	GetByUUID(uuid uuid.UUID) (*VoucherResponse, error)
+	Revoke(uuid, staffUUID uuid.UUID, req RevokeVoucherRequest) (*VoucherResponse, error)
+	GetMyVouchers(userUUID uuid.UUID) ([]VoucherResponse, error)
+}
+
+type voucherService struct {
+	voucherRepo repositories.VoucherRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewVoucherService(
+	voucherRepo repositories.VoucherRepository,
+	userRepo repositories.UserRepository,
+) VoucherService {
+	return &voucherService{
+		voucherRepo: voucherRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Issue grants one voucher with the same terms to each recipient. Either all
+// of them are issued or none.
+func (s *voucherService) Issue(staffUUID uuid.UUID, req IssueVouchersRequest) (*IssueVouchersResponse, error) {
+	if (len(req.UserIDs) > 0) == (req.Segment != "") {
+		return nil, ErrVoucherRecipients
+	}
+	if req.DiscountType == models.DiscountTypePercentage && req.DiscountValue > 100 {
+		return nil, ErrPromotionPercentTooHigh
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrVoucherExpiryNotInFuture
+	}
+
+	staff, err := s.findUser(staffUUID)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := s.findRecipients(req)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers := make([]models.Voucher, len(recipients))
+	for i, userID := range recipients {
+		code, err := newCode("VC", 2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate voucher code: %w", err)
+		}
+		vouchers[i] = models.Voucher{
+			Code:          code,
+			UserID:        userID,
+			Reason:        req.Reason,
+			DiscountType:  req.DiscountType,
+			DiscountValue: req.DiscountValue,
+			MaxDiscount:   req.MaxDiscount,
+			MinSpend:      req.MinSpend,
+			ExpiresAt:     req.ExpiresAt,
+			Status:        models.VoucherStatusActive,
+			IssuedBy:      &staff.ID,
+		}
+	}
+	if err := s.voucherRepo.CreateBatch(vouchers); err != nil {
+		return nil, fmt.Errorf("failed to save vouchers: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]VoucherResponse, len(vouchers))
+	for i := range vouchers {
+		responses[i] = toVoucherResponse(&vouchers[i], now)
+	}
+	return &IssueVouchersResponse{Issued: len(vouchers), Vouchers: responses}, nil
+}
+
+// findRecipients resolves the request to member IDs
+func (s *voucherService) findRecipients(req IssueVouchersRequest) ([]uint, error) {
+	if req.Segment != "" {
+		inactiveSince := time.Now().AddDate(0, 0, -req.InactiveDays)
+		ids, err := s.userRepo.FindMemberIDs(req.Segment, inactiveSince)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, ErrVoucherSegmentEmpty
+		}
+		return ids, nil
+	}
+
+	ids := make([]uint, len(req.UserIDs))
+	for i, userUUID := range req.UserIDs {
+		user, err := s.userRepo.FindByUUID(userUUID)
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrVoucherMemberNotFound, userUUID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user.Role != models.RoleMember {
+			return nil, fmt.Errorf("%w: %s", ErrVoucherMemberNotFound, userUUID)
+		}
+		ids[i] = user.ID
+	}
+	return ids, nil
+}
+
+func (s *voucherService) GetAll(status *models.VoucherStatus, memberUUID *uuid.UUID, page, limit int) (*VoucherListResponse, error) {
+	offset := (page - 1) * limit
+
+	filters := repositories.VoucherFilters{Status: status}
+	if memberUUID != nil {
+		member, err := s.userRepo.FindByUUID(*memberUUID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrUserNotFound) {
+				return nil, ErrVoucherMemberNotFound
+			}
+			return nil, err
+		}
+		filters.UserID = &member.ID
+	}
+
+	vouchers, total, err := s.voucherRepo.FindAll(filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]VoucherResponse, len(vouchers))
+	for i := range vouchers {
+		responses[i] = toVoucherResponse(&vouchers[i], now)
+	}
+
+	return &VoucherListResponse{
+		Vouchers: responses,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+// GetByUUID returns the voucher with its audit trail
+func (s *voucherService) GetByUUID(uuid uuid.UUID) (*VoucherResponse, error) {
+	voucher, err := s.findVoucher(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.voucherRepo.FindEvents(voucher.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toVoucherResponse(voucher, time.Now())
+	response.Events = make([]VoucherEventEntry, len(events))
+	for i, event := range events {
+		entry := VoucherEventEntry{
+			Action:    event.Action,
+			Note:      event.Note,
+			CreatedAt: event.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if event.Actor != nil {
+			entry.ActorName = &event.Actor.FullName
+		}
+		if event.Order != nil {
+			entry.OrderID = &event.Order.UUID
+			entry.OrderNumber = &event.Order.OrderNumber
+		}
+		response.Events[i] = entry
+	}
+	return &response, nil
+}
+
+// Revoke stops an active voucher from being redeemed. A voucher already used
+// on an order stays with it.
+func (s *voucherService) Revoke(uuid, staffUUID uuid.UUID, req RevokeVoucherRequest) (*VoucherResponse, error) {
+	voucher, err := s.findVoucher(uuid)
+	if err != nil {
+		return nil, err
+	}
+	staff, err := s.findUser(staffUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.voucherRepo.Revoke(voucher.ID, staff.ID, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke voucher: %w", err)
+	}
+	if !revoked {
+		return nil, ErrVoucherNotActive
+	}
+
+	return s.GetByUUID(uuid)
+}
+
+// GetMyVouchers lists the member's vouchers, newest first, without how
+// admins handled them
+func (s *voucherService) GetMyVouchers(userUUID uuid.UUID) ([]VoucherResponse, error) {
+	user, err := s.findUser(userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers, err := s.voucherRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]VoucherResponse, len(vouchers))
+	for i := range vouchers {
+		response := toVoucherResponse(&vouchers[i], now)
+		response.RevokeReason = nil
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+func (s *voucherService) findVoucher(uuid uuid.UUID) (*models.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrVoucherNotFound) {
+			return nil, ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	return voucher, nil
+}
+
+func (s *voucherService) findUser(userUUID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func toVoucherResponse(voucher *models.Voucher, now time.Time) VoucherResponse {
+	response := VoucherResponse{
+		ID:            voucher.UUID,
+		Code:          voucher.Code,
+		Reason:        voucher.Reason,
+		DiscountType:  voucher.DiscountType,
+		DiscountValue: voucher.DiscountValue,
+		MaxDiscount:   voucher.MaxDiscount,
+		MinSpend:      voucher.MinSpend,
+		ExpiresAt:     formatOptionalTime(voucher.ExpiresAt),
+		Status:        voucher.Status,
+		Redeemable:    voucher.IsRedeemableAt(now),
+		RedeemedAt:    formatOptionalTime(voucher.RedeemedAt),
+		RevokedAt:     formatOptionalTime(voucher.RevokedAt),
+		RevokeReason:  voucher.RevokeReason,
+		CreatedAt:     voucher.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if voucher.User != nil {
+		response.Member = &VoucherMember{
+			ID:       voucher.User.UUID,
+			FullName: voucher.User.FullName,
+			Email:    voucher.User.Email,
+		}
+	}
+	if voucher.Order != nil {
+		response.OrderID = &voucher.Order.UUID
+		response.OrderNumber = &voucher.Order.OrderNumber
+	}
+	return response
+}
+
+// normalizeVoucherCode accepts a code typed in lower case or without dashes
+func normalizeVoucherCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != 10 || !strings.HasPrefix(code, "VC") {
+		return code
+	}
+	return "VC-" + code[2:6] + "-" + code[6:]
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// WalletAdjustmentRequest corrects a member's balance by hand. A negative
+// amount takes money out of the wallet.
+type WalletAdjustmentRequest struct {
+	Amount float64 `json:"amount" validate:"required,ne=0,gte=-10000000,lte=10000000"`
+	Note   string  `json:"note" validate:"required,min=3,max=255"`
+}
+
+type WalletTransactionResponse struct {
+	ID           uuid.UUID                    `json:"id"`
+	Type         models.WalletTransactionType `json:"type"`
+	Amount       float64                      `json:"amount"`
+	BalanceAfter float64                      `json:"balance_after"`
+	OrderID      *uuid.UUID                   `json:"order_id,omitempty"`
+	OrderNumber  *string                      `json:"order_number,omitempty"`
+	Note         *string                      `json:"note,omitempty"`
+	CreatedAt    string                       `json:"created_at"`
+}
+
+// WalletResponse is a member's balance with a page of their ledger, newest
+// entries first
+type WalletResponse struct {
+	UserID       uuid.UUID                   `json:"user_id"`
+	Balance      float64                     `json:"balance"`
+	Transactions []WalletTransactionResponse `json:"transactions"`
+	Total        int64                       `json:"total"`
+	Page         int                         `json:"page"`
+	Limit        int                         `json:"limit"`
+}
+
+type WalletService interface {
+	GetWallet(userUUID uuid.UUID, page, limit int) (*WalletResponse, error)
+	Adjust(userUUID, staffUUID uuid.UUID, req WalletAdjustmentRequest) (*WalletTransactionResponse, error)
+}
+
+type walletService struct {
+	walletRepo repositories.WalletRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewWalletService(
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+) WalletService {
+	return &walletService{
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (s *walletService) GetWallet(userUUID uuid.UUID, page, limit int) (*WalletResponse, error) {
+	user, err := s.findUser(userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.walletRepo.Balance(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	entries, total, err := s.walletRepo.FindTransactions(user.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]WalletTransactionResponse, len(entries))
+	for i := range entries {
+		transactions[i] = toWalletTransactionResponse(&entries[i])
+	}
+
+	return &WalletResponse{
+		UserID:       user.UUID,
+		Balance:      balance,
+		Transactions: transactions,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+	}, nil
+}
+
+// Adjust posts a correction by an admin to the member's wallet. A debit larger
+// than the balance is refused with ErrInsufficientBalance.
+func (s *walletService) Adjust(userUUID, staffUUID uuid.UUID, req WalletAdjustmentRequest) (*WalletTransactionResponse, error) {
+	user, err := s.findUser(userUUID)
+	if err != nil {
+		return nil, err
+	}
+	staff, err := s.findUser(staffUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := req.Note
+	entry := &models.WalletTransaction{
+		UserID:    user.ID,
+		Type:      models.WalletTransactionAdjustment,
+		Amount:    roundAmount(req.Amount),
+		Note:      &note,
+		CreatedBy: &staff.ID,
+	}
+	if err := s.walletRepo.Post(entry); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientBalance) {
+			return nil, ErrInsufficientBalance
+		}
+		return nil, fmt.Errorf("failed to adjust wallet: %w", err)
+	}
+
+	response := toWalletTransactionResponse(entry)
+	return &response, nil
+}
+
+func (s *walletService) findUser(userUUID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByUUID(userUUID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func toWalletTransactionResponse(entry *models.WalletTransaction) WalletTransactionResponse {
+	response := WalletTransactionResponse{
+		ID:           entry.UUID,
+		Type:         entry.Type,
+		Amount:       entry.Amount,
+		BalanceAfter: entry.BalanceAfter,
+		Note:         entry.Note,
+		CreatedAt:    entry.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if entry.Order != nil {
+		response.OrderID = &entry.Order.UUID
+		response.OrderNumber = &entry.Order.OrderNumber
+	}
+	return response
+}
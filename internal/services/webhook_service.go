@@ -0,0 +1,238 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+var (
+	ErrWebhookEventNotFound = errors.New("webhook event not found")
+)
+
+// redactedWebhookHeaders carry secrets; they are kept for replay but never
+// shown
+var redactedWebhookHeaders = []string{"Authorization", "Cookie", "X-Callback-Token"}
+
+type WebhookEventResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	Provider    models.PaymentMethod      `json:"provider"`
+	Headers     map[string]string         `json:"headers"`
+	Body        string                    `json:"body"`
+	Status      models.WebhookEventStatus `json:"status"`
+	Error       *string                   `json:"error,omitempty"`
+	Attempts    int                       `json:"attempts"`
+	ProcessedAt *string                   `json:"processed_at,omitempty"`
+	ReceivedAt  string                    `json:"received_at"`
+}
+
+type WebhookEventListResponse struct {
+	Events []WebhookEventResponse `json:"events"`
+	Total  int64                  `json:"total"`
+	Page   int                    `json:"page"`
+	Limit  int                    `json:"limit"`
+}
+
+type WebhookService interface {
+	Receive(provider models.PaymentMethod, headers map[string]string, body []byte) error
+	GetAll(filters repositories.WebhookEventFilters, page, limit int) (*WebhookEventListResponse, error)
+	GetByUUID(uuid uuid.UUID) (*WebhookEventResponse, error)
+	Replay(uuid uuid.UUID) (*WebhookEventResponse, error)
+}
+
+type webhookService struct {
+	webhookEventRepo repositories.WebhookEventRepository
+	paymentService   PaymentService
+	logger           *slog.Logger
+}
+
+func NewWebhookService(
+	webhookEventRepo repositories.WebhookEventRepository,
+	paymentService PaymentService,
+	logger *slog.Logger,
+) WebhookService {
+	return &webhookService{
+		webhookEventRepo: webhookEventRepo,
+		paymentService:   paymentService,
+		logger:           logger,
+	}
+}
+
+// Receive stores the webhook as it arrived and then processes it. A webhook
+// that cannot be stored is still processed, so a database hiccup does not
+// lose a payment. The processing error is returned for the provider to see.
+func (s *webhookService) Receive(provider models.PaymentMethod, headers map[string]string, body []byte) error {
+	event := &models.WebhookEvent{
+		Provider: provider,
+		Body:     body,
+		Status:   models.WebhookEventStatusReceived,
+	}
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		headersJSON = []byte("{}")
+	}
+	event.Headers = datatypes.JSON(headersJSON)
+
+	stored := true
+	if err := s.webhookEventRepo.Create(event); err != nil {
+		s.logger.Error("Failed to store webhook", "provider", provider, logging.Err(err))
+		stored = false
+	}
+
+	processErr := s.dispatch(provider, headers, body, time.Now())
+	if stored {
+		s.recordResult(event, processErr)
+	}
+	return processErr
+}
+
+func (s *webhookService) GetAll(filters repositories.WebhookEventFilters, page, limit int) (*WebhookEventListResponse, error) {
+	offset := (page - 1) * limit
+
+	events, total, err := s.webhookEventRepo.FindAll(filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]WebhookEventResponse, len(events))
+	for i := range events {
+		responses[i] = *toWebhookEventResponse(&events[i])
+	}
+
+	return &WebhookEventListResponse{
+		Events: responses,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+func (s *webhookService) GetByUUID(uuid uuid.UUID) (*WebhookEventResponse, error) {
+	event, err := s.findEvent(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookEventResponse(event), nil
+}
+
+// Replay processes a stored webhook again, as if the provider had just sent
+// it. Signatures are still checked, against the time the webhook first
+// arrived. The result of the attempt is returned on the event rather than as
+// an error.
+func (s *webhookService) Replay(uuid uuid.UUID) (*WebhookEventResponse, error) {
+	event, err := s.findEvent(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal(event.Headers, &headers); err != nil {
+		headers = map[string]string{}
+	}
+
+	processErr := s.dispatch(event.Provider, headers, event.Body, event.CreatedAt)
+	if processErr != nil {
+		s.logger.Warn("Replay of webhook failed", "webhook_uuid", event.UUID, logging.Err(processErr))
+	}
+	s.recordResult(event, processErr)
+
+	return toWebhookEventResponse(event), nil
+}
+
+func (s *webhookService) findEvent(uuid uuid.UUID) (*models.WebhookEvent, error) {
+	event, err := s.webhookEventRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWebhookEventNotFound) {
+			return nil, ErrWebhookEventNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// dispatch hands the webhook to the payment flow of its provider
+func (s *webhookService) dispatch(provider models.PaymentMethod, headers map[string]string, body []byte, receivedAt time.Time) error {
+	switch provider {
+	case models.PaymentMethodMidtrans:
+		var notification MidtransNotification
+		if err := json.Unmarshal(body, &notification); err != nil {
+			return ErrInvalidWebhook
+		}
+		s.logger.Info("Received Midtrans webhook", "reference", notification.OrderID,
+			"status", notification.TransactionStatus, "checkout_request_id", notification.CustomField1)
+		return s.paymentService.ProcessWebhookNotification(&notification)
+	case models.PaymentMethodStripe:
+		return s.paymentService.ProcessStripeWebhook(body, webhookHeader(headers, "Stripe-Signature"), receivedAt)
+	case models.PaymentMethodXendit:
+		return s.paymentService.ProcessXenditCallback(body, webhookHeader(headers, "X-Callback-Token"))
+	}
+	return fmt.Errorf("unknown webhook provider %q", provider)
+}
+
+// recordResult keeps the outcome on the stored event. Failing to record it is
+// only logged: the webhook itself was handled.
+func (s *webhookService) recordResult(event *models.WebhookEvent, processErr error) {
+	var message *string
+	if processErr != nil {
+		text := processErr.Error()
+		message = &text
+	}
+	if err := s.webhookEventRepo.RecordResult(event.ID, message); err != nil {
+		s.logger.Error("Failed to record result of webhook", "webhook_uuid", event.UUID, logging.Err(err))
+		return
+	}
+
+	now := time.Now()
+	event.Status = models.WebhookEventStatusProcessed
+	if message != nil {
+		event.Status = models.WebhookEventStatusFailed
+	}
+	event.Error = message
+	event.Attempts++
+	event.ProcessedAt = &now
+}
+
+// webhookHeader looks a header up regardless of how its name was cased
+func webhookHeader(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func toWebhookEventResponse(event *models.WebhookEvent) *WebhookEventResponse {
+	headers := map[string]string{}
+	if err := json.Unmarshal(event.Headers, &headers); err != nil {
+		headers = map[string]string{}
+	}
+	for key := range headers {
+		for _, secret := range redactedWebhookHeaders {
+			if strings.EqualFold(key, secret) {
+				headers[key] = "[redacted]"
+			}
+		}
+	}
+
+	return &WebhookEventResponse{
+		ID:          event.UUID,
+		Provider:    event.Provider,
+		Headers:     headers,
+		Body:        string(event.Body),
+		Status:      event.Status,
+		Error:       event.Error,
+		Attempts:    event.Attempts,
+		ProcessedAt: formatOptionalTime(event.ProcessedAt),
+		ReceivedAt:  event.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,225 @@
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSkipped marks a check whose dependency is not configured
+var ErrSkipped = errors.New("not configured")
+
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Check verifies one dependency. A failed hard check stops the server from
+// starting in production; a failed soft check is only reported.
+type Check struct {
+	Name string
+	Hard bool
+	Run  func(ctx context.Context) (string, error)
+}
+
+type Result struct {
+	Name     string
+	Hard     bool
+	Status   Status
+	Detail   string
+	Duration time.Duration
+}
+
+// Report is the readiness report produced on boot
+type Report struct {
+	Results []Result
+}
+
+// Run executes every check in order, each bounded by ctx
+func Run(ctx context.Context, checks []Check) Report {
+	report := Report{Results: make([]Result, 0, len(checks))}
+
+	for _, check := range checks {
+		start := time.Now()
+		detail, err := check.Run(ctx)
+
+		result := Result{
+			Name:     check.Name,
+			Hard:     check.Hard,
+			Status:   StatusOK,
+			Detail:   detail,
+			Duration: time.Since(start),
+		}
+		switch {
+		case errors.Is(err, ErrSkipped):
+			result.Status = StatusSkipped
+			result.Detail = err.Error()
+		case err != nil:
+			result.Status = StatusFailed
+			result.Detail = err.Error()
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// HardFailures returns the failed checks that must block startup
+func (r Report) HardFailures() []Result {
+	var failures []Result
+	for _, result := range r.Results {
+		if result.Hard && result.Status == StatusFailed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Log writes one line per check, with the result as attributes so log
+// collectors can filter on it. Failed hard checks are errors and other
+// failures warnings.
+func (r Report) Log(logger *slog.Logger) {
+	for _, result := range r.Results {
+		level := slog.LevelInfo
+		if result.Status == StatusFailed {
+			level = slog.LevelWarn
+			if result.Hard {
+				level = slog.LevelError
+			}
+		}
+		logger.Log(context.Background(), level, "Startup check",
+			"check", result.Name,
+			"status", result.Status,
+			"hard", result.Hard,
+			"duration_ms", result.Duration.Milliseconds(),
+			"detail", result.Detail,
+		)
+	}
+}
+
+// SchemaVersionCheck fails when golang-migrate has not applied every
+// migration the code was built with, or left the schema dirty
+func SchemaVersionCheck(db *gorm.DB, expected uint) Check {
+	return Check{
+		Name: "database_schema",
+		Hard: true,
+		Run: func(ctx context.Context) (string, error) {
+			var row struct {
+				Version uint
+				Dirty   bool
+			}
+			err := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row).Error
+			if err != nil {
+				return "", fmt.Errorf("failed to read schema version: %w", err)
+			}
+
+			if row.Dirty {
+				return "", fmt.Errorf("schema version %d is dirty, fix and force the migration", row.Version)
+			}
+			if row.Version < expected {
+				return "", fmt.Errorf("schema version %d is behind expected %d, run migrate up", row.Version, expected)
+			}
+			return fmt.Sprintf("version %d", row.Version), nil
+		},
+	}
+}
+
+// MidtransBaseURL returns the Midtrans Core API host for an environment
+func MidtransBaseURL(environment string) string {
+	if environment == "production" {
+		return "https://api.midtrans.com"
+	}
+	return "https://api.sandbox.midtrans.com"
+}
+
+// MidtransCheck confirms the server key is accepted by querying the status of
+// an order that does not exist: Midtrans answers 404 for a valid key and 401
+// for a rejected one
+func MidtransCheck(client *http.Client, baseURL, serverKey string) Check {
+	return Check{
+		Name: "midtrans",
+		Hard: true,
+		Run: func(ctx context.Context) (string, error) {
+			if serverKey == "" {
+				return "", ErrSkipped
+			}
+
+			url := baseURL + "/v2/matchaciee-startup-check/status"
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return "", err
+			}
+			req.SetBasicAuth(serverKey, "")
+			req.Header.Set("Accept", "application/json")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return "", fmt.Errorf("failed to reach Midtrans: %w", err)
+			}
+			defer func() {
+				_ = resp.Body.Close() //nolint:errcheck
+			}()
+
+			// Midtrans reports the outcome in the body, sometimes with HTTP 200
+			var body struct {
+				StatusCode string `json:"status_code"`
+			}
+			_ = json.NewDecoder(resp.Body).Decode(&body) //nolint:errcheck // fall back to the HTTP status
+			code := body.StatusCode
+			if code == "" {
+				code = fmt.Sprintf("%d", resp.StatusCode)
+			}
+
+			switch code {
+			case "404":
+				return "credentials accepted", nil
+			case "401":
+				return "", fmt.Errorf("server key rejected")
+			default:
+				return "", fmt.Errorf("unexpected Midtrans response %s", code)
+			}
+		},
+	}
+}
+
+// StorageCheck confirms the upload directory exists and is writable
+func StorageCheck(dir string) Check {
+	return Check{
+		Name: "storage",
+		Hard: true,
+		Run: func(ctx context.Context) (string, error) {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return "", fmt.Errorf("failed to create %s: %w", dir, err)
+			}
+
+			probe, err := os.CreateTemp(dir, ".startup-check-*")
+			if err != nil {
+				return "", fmt.Errorf("%s is not writable: %w", dir, err)
+			}
+			name := probe.Name()
+			if err := probe.Close(); err != nil {
+				return "", err
+			}
+			if err := os.Remove(name); err != nil {
+				return "", err
+			}
+
+			absolute, err := filepath.Abs(dir)
+			if err != nil {
+				absolute = dir
+			}
+			return absolute, nil
+		},
+	}
+}
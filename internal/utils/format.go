@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var monthNames = map[string][12]string{
+	LocaleID: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	LocaleEN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// Currency is how amounts are written: the symbol put in front and the number
+// of decimal places. Rupiah has no minor unit, so it is written without any.
+type Currency struct {
+	Code     string
+	Symbol   string
+	Decimals int
+}
+
+// CurrencyIDR is used until the store picks another currency
+var CurrencyIDR = Currency{Code: "IDR", Symbol: "Rp", Decimals: 0}
+
+// Formatter renders money and dates for receipts, emails and messages in the
+// store timezone, so every channel shows the same values
+type Formatter struct {
+	locale   string
+	location *time.Location
+	currency Currency
+}
+
+func NewFormatter(locale, timezone string) (*Formatter, error) {
+	if !IsSupportedLocale(locale) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Formatter{locale: locale, location: location, currency: CurrencyIDR}, nil
+}
+
+// Locale returns the formatter's locale
+func (f *Formatter) Locale() string {
+	return f.locale
+}
+
+// ForLocale returns a formatter for locale in the same timezone, or f itself
+// when locale is empty or unsupported
+func (f *Formatter) ForLocale(locale string) *Formatter {
+	if locale == f.locale || !IsSupportedLocale(locale) {
+		return f
+	}
+	return &Formatter{locale: locale, location: f.location, currency: f.currency}
+}
+
+// WithCurrency returns a formatter that writes amounts in currency
+func (f *Formatter) WithCurrency(currency Currency) *Formatter {
+	if currency == f.currency {
+		return f
+	}
+	return &Formatter{locale: f.locale, location: f.location, currency: currency}
+}
+
+// Location returns the store timezone
+func (f *Formatter) Location() *time.Location {
+	return f.location
+}
+
+// Money formats an amount in the formatter's currency, rounded to its decimal
+// places, e.g. Rp77.000 (id) or Rp77,000 (en), and $12.50 (en)
+func (f *Formatter) Money(amount float64) string {
+	thousands, decimal := ".", ","
+	if f.locale == LocaleEN {
+		thousands, decimal = ",", "."
+	}
+
+	scale := math.Pow10(f.currency.Decimals)
+	digits := strconv.FormatFloat(math.Round(math.Abs(amount)*scale)/scale, 'f', f.currency.Decimals, 64)
+	sign := ""
+	if amount < 0 && strings.Trim(digits, "0.") != "" {
+		sign = "-"
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(decimal)
+		b.WriteString(fraction)
+	}
+
+	return sign + f.currency.Symbol + b.String()
+}
+
+// Date formats t in the store timezone, e.g. 7 Januari 2025
+func (f *Formatter) Date(t time.Time) string {
+	t = t.In(f.location)
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[f.locale][t.Month()-1], t.Year())
+}
+
+// DateTime formats t in the store timezone, e.g. 7 Januari 2025 17.00 WIB
+func (f *Formatter) DateTime(t time.Time) string {
+	local := t.In(f.location)
+	clock := local.Format("15:04")
+	if f.locale == LocaleID {
+		clock = local.Format("15.04")
+	}
+	return fmt.Sprintf("%s %s %s", f.Date(t), clock, local.Format("MST"))
+}
+
+// ShortDateTime is a compact form for narrow receipts, e.g. 07/01/2025 17:00
+func (f *Formatter) ShortDateTime(t time.Time) string {
+	return t.In(f.location).Format("02/01/2006 15:04")
+}
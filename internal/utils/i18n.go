@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+)
+
+// Supported locales for customer-facing text
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+// DefaultMessageLocale is used for API messages when neither the user nor the request picks one
+const DefaultMessageLocale = LocaleEN
+
+func IsSupportedLocale(locale string) bool {
+	return locale == LocaleID || locale == LocaleEN
+}
+
+// ParseAcceptLanguage returns the supported locale the client prefers most, or
+// an empty string when the header names none
+func ParseAcceptLanguage(header string) string {
+	type candidate struct {
+		locale string
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		// Only the primary language matters, e.g. id-ID -> id
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if IsSupportedLocale(language) && weight > 0 {
+			candidates = append(candidates, candidate{locale: language, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].locale
+}
+
+// RequestLocale returns the locale resolved for the request by the locale and auth middleware
+func RequestLocale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals("locale").(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultMessageLocale
+}
+
+// messageTranslations holds customer-facing API messages, keyed by the English text.
+// Messages that are missing here, such as admin-only ones, are returned in English.
+var messageTranslations = map[string]map[string]string{
+	LocaleID: {
+		"Invalid request body":                    "Isi permintaan tidak valid",
+		"Validation failed":                       "Validasi gagal",
+		"Unauthorized":                            "Tidak terautentikasi",
+		"Missing authorization header":            "Header otorisasi tidak ditemukan",
+		"Invalid authorization header format":     "Format header otorisasi tidak valid",
+		"Access denied: insufficient permissions": "Akses ditolak: izin tidak mencukupi",
+		"invalid token":                           "Token tidak valid",
+		"token has expired":                       "Token sudah kedaluwarsa",
+		"Invalid email or password":               "Email atau kata sandi salah",
+		"Email already exists":                    "Email sudah terdaftar",
+		"User account is inactive":                "Akun pengguna tidak aktif",
+		"User not found":                          "Pengguna tidak ditemukan",
+		"Invalid refresh token":                   "Refresh token tidak valid",
+		"Invalid or expired refresh token":        "Refresh token tidak valid atau sudah kedaluwarsa",
+		"Failed to register user":                 "Gagal mendaftarkan pengguna",
+		"Failed to login":                         "Gagal masuk",
+		"Failed to get user":                      "Gagal mengambil data pengguna",
+		"Failed to update profile":                "Gagal memperbarui profil",
+		"Login code channel is not enabled":       "Metode pengiriman kode masuk tidak tersedia",
+		"Too many login codes requested":          "Terlalu banyak permintaan kode masuk",
+		"Failed to send login code":               "Gagal mengirim kode masuk",
+		"Login code sent":                         "Kode masuk telah dikirim",
+		"Invalid or expired login code":           "Kode masuk tidak valid atau sudah kedaluwarsa",
+		"Category not found":                      "Kategori tidak ditemukan",
+		"Product not found":                       "Produk tidak ditemukan",
+		"Order not found":                         "Pesanan tidak ditemukan",
+		"Invalid product ID format":               "Format ID produk tidak valid",
+		"Invalid category ID format":              "Format ID kategori tidak valid",
+		"Invalid order ID format":                 "Format ID pesanan tidak valid",
+		"Invalid order ID":                        "ID pesanan tidak valid",
+		"Failed to get menu":                      "Gagal mengambil menu",
+		"Failed to get products":                  "Gagal mengambil produk",
+		"Failed to get categories":                "Gagal mengambil kategori",
+		"Failed to get order":                     "Gagal mengambil pesanan",
+		"Failed to get orders":                    "Gagal mengambil pesanan",
+		"Failed to create order":                  "Gagal membuat pesanan",
+		"Product is not customizable":             "Produk tidak dapat dikustomisasi",
+		"None of the items can be reordered":      "Tidak ada item yang dapat dipesan ulang",
+		"Failed to reorder":                       "Gagal memesan ulang",
+		"Access denied":                           "Akses ditolak",
+		"Cart is empty":                           "Keranjang kosong",
+		"Cart item not found":                     "Item keranjang tidak ditemukan",
+		"Invalid cart item ID format":             "Format ID item keranjang tidak valid",
+		"Product is not available":                "Produk tidak tersedia",
+		"Invalid product customization":           "Kustomisasi bukan milik produk ini",
+		"Customer name is required":               "Nama pelanggan wajib diisi",
+		"Checkout already in progress":            "Checkout sedang diproses",
+		"Failed to get cart":                      "Gagal mengambil keranjang",
+		"Failed to add item to cart":              "Gagal menambahkan item ke keranjang",
+		"Failed to update cart item":              "Gagal memperbarui item keranjang",
+		"Failed to remove cart item":              "Gagal menghapus item keranjang",
+		"Failed to clear cart":                    "Gagal mengosongkan keranjang",
+		"Item is sold out":                        "Item sudah habis",
+		"Payment already exists for this order":   "Pembayaran untuk pesanan ini sudah ada",
+		"Payment deadline has passed":             "Batas waktu pembayaran sudah lewat",
+		"Order can no longer be edited":           "Pesanan tidak dapat diubah lagi",
+		"Failed to update order items":            "Gagal memperbarui item pesanan",
+		"Service is busy, please retry later":     "Layanan sedang sibuk, silakan coba lagi nanti",
+		"WebSocket upgrade required":              "Diperlukan koneksi WebSocket",
+		"Failed to create payment token":          "Gagal membuat token pembayaran",
+		"Invalid or expired promo code":           "Kode promo tidak valid atau sudah kedaluwarsa",
+		"Promo code usage limit reached":          "Kuota kode promo sudah habis",
+		"Order is below the promo minimum spend":  "Pesanan belum memenuhi minimum belanja promo",
+		"Promo code does not apply to your items": "Kode promo tidak berlaku untuk item pesanan",
+		"Table is not taking orders":              "Meja ini tidak menerima pesanan",
+		"Table orders must be dine-in":            "Pesanan dari meja harus makan di tempat",
+		"Table not found":                         "Meja tidak ditemukan",
+		"Too many attempts, try again later":      "Terlalu banyak percobaan, coba lagi nanti",
+		"Pre-orders are not accepted":             "Pre-order tidak tersedia",
+		"Scheduled time is not available":         "Waktu yang dijadwalkan tidak tersedia",
+		"Pickup timeslot is fully booked":         "Slot waktu pengambilan sudah penuh",
+		"Invalid pickup code":                     "Kode pengambilan tidak valid",
+		"Order is not ready for pickup":           "Pesanan belum siap diambil",
+		"Order was already picked up":             "Pesanan sudah diambil",
+		"Status link is invalid or has expired":   "Tautan status tidak valid atau sudah kedaluwarsa",
+	},
+}
+
+// Translate returns message in locale, falling back to the English original
+func Translate(locale, message string) string {
+	if translated, ok := messageTranslations[locale][message]; ok {
+		return translated
+	}
+	return message
+}
@@ -17,6 +17,7 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
+	Locale   string    `json:"locale,omitempty"`
 	UserUUID uuid.UUID `json:"user_uuid"`
 }
 
@@ -34,12 +35,15 @@ func NewJWTUtil(secretKey string, expiry, refreshTokenExpiry time.Duration) *JWT
 	}
 }
 
-func (j *JWTUtil) GenerateToken(userUUID uuid.UUID, email, role string) (string, error) {
+// GenerateToken issues an access token. locale is the user's preferred language
+// and may be empty.
+func (j *JWTUtil) GenerateToken(userUUID uuid.UUID, email, role, locale string) (string, error) {
 	now := time.Now()
 	claims := JWTClaims{
 		UserUUID: userUUID,
 		Email:    email,
 		Role:     role,
+		Locale:   locale,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrInvalidOrderNumberFormat = errors.New("invalid order number format")
+
+// orderNumberMaxLength matches the orders.order_number column
+const orderNumberMaxLength = 20
+
+var orderNumberDatePattern = regexp.MustCompile(`^(YYYY|YY|MM|DD)*$`)
+
+type OrderNumberReset string
+
+const (
+	OrderNumberResetDaily   OrderNumberReset = "daily"
+	OrderNumberResetMonthly OrderNumberReset = "monthly"
+	OrderNumberResetYearly  OrderNumberReset = "yearly"
+	OrderNumberResetNever   OrderNumberReset = "never"
+)
+
+// OrderNumberFormat builds order numbers like MC-250107-001 from a prefix, the
+// date in the store timezone and a sequence that restarts every reset period.
+// Empty parts are left out together with their dash.
+type OrderNumberFormat struct {
+	prefix     string
+	dateLayout string
+	width      int
+	reset      OrderNumberReset
+	location   *time.Location
+}
+
+// NewOrderNumberFormat validates the configured scheme. dateFormat is built
+// from YYYY, YY, MM and DD and must contain every date part the reset period
+// needs, so a number cannot repeat after its sequence restarts.
+func NewOrderNumberFormat(prefix, dateFormat string, width int, reset, timezone string) (*OrderNumberFormat, error) {
+	if !orderNumberDatePattern.MatchString(dateFormat) {
+		return nil, fmt.Errorf("%w: date format may only use YYYY, YY, MM and DD", ErrInvalidOrderNumberFormat)
+	}
+	if width < 1 || width > 10 {
+		return nil, fmt.Errorf("%w: sequence width must be between 1 and 10", ErrInvalidOrderNumberFormat)
+	}
+
+	hasYear := strings.Contains(dateFormat, "YY")
+	hasMonth := strings.Contains(dateFormat, "MM")
+	hasDay := strings.Contains(dateFormat, "DD")
+
+	switch OrderNumberReset(reset) {
+	case OrderNumberResetDaily:
+		if !hasYear || !hasMonth || !hasDay {
+			return nil, fmt.Errorf("%w: a daily reset needs year, month and day in the date format", ErrInvalidOrderNumberFormat)
+		}
+	case OrderNumberResetMonthly:
+		if !hasYear || !hasMonth {
+			return nil, fmt.Errorf("%w: a monthly reset needs year and month in the date format", ErrInvalidOrderNumberFormat)
+		}
+	case OrderNumberResetYearly:
+		if !hasYear {
+			return nil, fmt.Errorf("%w: a yearly reset needs the year in the date format", ErrInvalidOrderNumberFormat)
+		}
+	case OrderNumberResetNever:
+	default:
+		return nil, fmt.Errorf("%w: reset must be one of daily, monthly, yearly, never", ErrInvalidOrderNumberFormat)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(dateFormat)
+	format := &OrderNumberFormat{
+		prefix:     prefix,
+		dateLayout: layout,
+		width:      width,
+		reset:      OrderNumberReset(reset),
+		location:   location,
+	}
+
+	if length := len(format.Format(time.Now(), 1)); length > orderNumberMaxLength {
+		return nil, fmt.Errorf("%w: order numbers would be %d characters, the limit is %d", ErrInvalidOrderNumberFormat, length, orderNumberMaxLength)
+	}
+	return format, nil
+}
+
+// Period identifies the sequence t belongs to; the sequence restarts when it
+// changes
+func (f *OrderNumberFormat) Period(t time.Time) string {
+	t = t.In(f.location)
+	switch f.reset {
+	case OrderNumberResetDaily:
+		return t.Format("2006-01-02")
+	case OrderNumberResetMonthly:
+		return t.Format("2006-01")
+	case OrderNumberResetYearly:
+		return t.Format("2006")
+	default:
+		return "all"
+	}
+}
+
+// Day is the store date of t, for counters that restart every day
+// regardless of the reset period
+func (f *OrderNumberFormat) Day(t time.Time) string {
+	return t.In(f.location).Format("2006-01-02")
+}
+
+// Format renders the order number for sequence at t
+func (f *OrderNumberFormat) Format(t time.Time, sequence int) string {
+	parts := make([]string, 0, 3)
+	if f.prefix != "" {
+		parts = append(parts, f.prefix)
+	}
+	if f.dateLayout != "" {
+		parts = append(parts, t.In(f.location).Format(f.dateLayout))
+	}
+	parts = append(parts, fmt.Sprintf("%0*d", f.width, sequence))
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+var ErrInvalidColor = errors.New("invalid color")
+
+// QRCodeStyle holds the colors a QR code is drawn in, as #RGB or #RRGGBB hex
+// strings
+type QRCodeStyle struct {
+	Foreground string
+	Background string
+}
+
+// RenderQRCodePNG encodes content as a size x size pixel PNG. The highest
+// error correction level is used so printed signage still scans when worn.
+func RenderQRCodePNG(content string, size int, style QRCodeStyle) ([]byte, error) {
+	code, err := newQRCode(content, style)
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// RenderQRCodeSVG encodes content as a scalable SVG drawn on a size x size
+// canvas, one rect per dark module
+func RenderQRCodeSVG(content string, size int, style QRCodeStyle) ([]byte, error) {
+	code, err := newQRCode(content, style)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, modules, modules, style.Background)
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, style.Foreground)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
+
+func newQRCode(content string, style QRCodeStyle) (*qrcode.QRCode, error) {
+	foreground, err := parseHexColor(style.Foreground)
+	if err != nil {
+		return nil, err
+	}
+	background, err := parseHexColor(style.Background)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := qrcode.New(content, qrcode.Highest)
+	if err != nil {
+		return nil, err
+	}
+	code.ForegroundColor = foreground
+	code.BackgroundColor = background
+	return code, nil
+}
+
+func parseHexColor(hex string) (color.RGBA, error) {
+	value := strings.TrimPrefix(hex, "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) != 6 {
+		return color.RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, hex)
+	}
+
+	rgb, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, hex)
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestIDHeader carries the ID that ties a request to its log lines, its
+// error response and the calls it makes to payment gateways
+const RequestIDHeader = fiber.HeaderXRequestID
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID, for code that
+// calls out to other services on the request's behalf
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or "" when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// RequestID returns the ID assigned to the request by the request ID middleware
+func RequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals("requestID").(string)
+	return requestID
+}
@@ -5,10 +5,11 @@ import (
 )
 
 type Response struct {
-	Data    any    `json:"data,omitempty"`
-	Message string `json:"message,omitempty"`
-	Error   string `json:"error,omitempty"`
-	Success bool   `json:"success"`
+	Data      any    `json:"data,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	Success   bool   `json:"success"`
 }
 
 func SuccessResponse(c *fiber.Ctx, statusCode int, data any) error {
@@ -28,15 +29,28 @@ func SuccessMessageResponse(c *fiber.Ctx, statusCode int, message string, data a
 
 func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
 	return c.Status(statusCode).JSON(Response{
-		Success: false,
-		Error:   message,
+		Success:   false,
+		Error:     Translate(RequestLocale(c), message),
+		RequestID: RequestID(c),
+	})
+}
+
+// ErrorDataResponse is an error response that also carries data the client
+// can act on, e.g. alternatives to what it asked for
+func ErrorDataResponse(c *fiber.Ctx, statusCode int, message string, data any) error {
+	return c.Status(statusCode).JSON(Response{
+		Success:   false,
+		Error:     Translate(RequestLocale(c), message),
+		Data:      data,
+		RequestID: RequestID(c),
 	})
 }
 
 func ValidationErrorResponse(c *fiber.Ctx, errors map[string]string) error {
 	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-		"success": false,
-		"error":   "Validation failed",
-		"details": errors,
+		"success":    false,
+		"error":      Translate(RequestLocale(c), "Validation failed"),
+		"details":    errors,
+		"request_id": RequestID(c),
 	})
 }
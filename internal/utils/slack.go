@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type SlackSender interface {
+	Send(text string) error
+}
+
+// SlackWebhookSender posts messages to a Slack channel through an incoming
+// webhook
+type SlackWebhookSender struct {
+	url    string
+	client *http.Client
+}
+
+func NewSlackWebhookSender(url string) *SlackWebhookSender {
+	return &SlackWebhookSender{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *SlackWebhookSender) Send(text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
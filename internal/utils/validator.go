@@ -34,7 +34,7 @@ func getErrorMessage(err validator.FieldError) string {
 	tag := err.Tag()
 
 	switch tag {
-	case "required":
+	case "required", "required_if":
 		return fmt.Sprintf("%s is required", field)
 	case "email":
 		return fmt.Sprintf("%s must be a valid email address", field)
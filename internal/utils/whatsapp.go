@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type WhatsAppSender interface {
+	Send(to, text string) error
+}
+
+// CloudWhatsAppSender sends text messages through the WhatsApp Cloud API
+type CloudWhatsAppSender struct {
+	token         string
+	phoneNumberID string
+	client        *http.Client
+}
+
+func NewCloudWhatsAppSender(token, phoneNumberID string) *CloudWhatsAppSender {
+	return &CloudWhatsAppSender{
+		token:         token,
+		phoneNumberID: phoneNumberID,
+		client:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *CloudWhatsAppSender) Send(to, text string) error {
+	payload, err := json.Marshal(map[string]any{
+		"messaging_product": "whatsapp",
+		"to":                strings.TrimPrefix(to, "+"),
+		"type":              "text",
+		"text":              map[string]string{"body": text},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://graph.facebook.com/v19.0/%s/messages", s.phoneNumberID)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("whatsapp: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogWhatsAppSender writes messages to the log instead of sending them, for local development
+type LogWhatsAppSender struct {
+	logger *slog.Logger
+}
+
+func NewLogWhatsAppSender(logger *slog.Logger) *LogWhatsAppSender {
+	return &LogWhatsAppSender{logger: logger}
+}
+
+func (s *LogWhatsAppSender) Send(to, text string) error {
+	s.logger.Info("WhatsApp message not sent, no Cloud API configured", "to", to, "text", text)
+	return nil
+}
@@ -0,0 +1,107 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCampaignRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignRepository) Create(campaign *models.Campaign) error {
+	args := m.Called(campaign)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) FindAll(status *models.CampaignStatus, limit, offset int) ([]models.Campaign, int64, error) {
+	args := m.Called(status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	campaigns, ok := args.Get(0).([]models.Campaign)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return campaigns, count, args.Error(2)
+}
+
+func (m *MockCampaignRepository) FindByUUID(uuid uuid.UUID) (*models.Campaign, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	campaign, ok := args.Get(0).(*models.Campaign)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return campaign, args.Error(1)
+}
+
+func (m *MockCampaignRepository) Cancel(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignRepository) FindDue(now time.Time) ([]models.Campaign, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	campaigns, ok := args.Get(0).([]models.Campaign)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return campaigns, args.Error(1)
+}
+
+func (m *MockCampaignRepository) Start(id uint, userIDs []uint) (bool, error) {
+	args := m.Called(id, userIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignRepository) ClaimPendingDeliveries(limit int, staleBefore time.Time) ([]models.CampaignDelivery, error) {
+	args := m.Called(limit, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	deliveries, ok := args.Get(0).([]models.CampaignDelivery)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return deliveries, args.Error(1)
+}
+
+func (m *MockCampaignRepository) MarkDelivery(id uint, status models.CampaignDeliveryStatus, channel string, sendErr *string) error {
+	args := m.Called(id, status, channel, sendErr)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) FinishSending() (int64, error) {
+	args := m.Called()
+	count, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return count, args.Error(1)
+}
+
+func (m *MockCampaignRepository) CountDeliveries(campaignID uint) ([]repositories.CampaignDeliveryCount, error) {
+	args := m.Called(campaignID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	counts, ok := args.Get(0).([]repositories.CampaignDeliveryCount)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return counts, args.Error(1)
+}
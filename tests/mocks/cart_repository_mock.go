@@ -0,0 +1,98 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockCartRepository) FindByUserID(userID uint) (*models.Cart, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	cart, ok := args.Get(0).(*models.Cart)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return cart, args.Error(1)
+}
+
+func (m *MockCartRepository) FindBySessionID(sessionID string) (*models.Cart, error) {
+	args := m.Called(sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	cart, ok := args.Get(0).(*models.Cart)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return cart, args.Error(1)
+}
+
+func (m *MockCartRepository) Create(cart *models.Cart) error {
+	args := m.Called(cart)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) AddItem(item *models.CartItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) FindItemByUUID(cartID uint, itemUUID uuid.UUID) (*models.CartItem, error) {
+	args := m.Called(cartID, itemUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	item, ok := args.Get(0).(*models.CartItem)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return item, args.Error(1)
+}
+
+func (m *MockCartRepository) UpdateItem(item *models.CartItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) DeleteItem(cartID, itemID uint) error {
+	args := m.Called(cartID, itemID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) Clear(cartID uint) error {
+	args := m.Called(cartID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) BeginCheckout(cartID uint) error {
+	args := m.Called(cartID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) CancelCheckout(cartID uint) error {
+	args := m.Called(cartID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) Delete(cartID uint) error {
+	args := m.Called(cartID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) DeleteStaleSessionCarts(before time.Time) (int64, error) {
+	args := m.Called(before)
+	deleted, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return deleted, args.Error(1)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCatalogRepository struct {
+	mock.Mock
+}
+
+func (m *MockCatalogRepository) Version() (int64, error) {
+	args := m.Called()
+	version, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return version, args.Error(1)
+}
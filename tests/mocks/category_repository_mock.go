@@ -68,7 +68,36 @@ func (m *MockCategoryRepository) Update(category *models.Category) error {
 	return args.Error(0)
 }
 
-func (m *MockCategoryRepository) Delete(id uint) error {
+func (m *MockCategoryRepository) FindDescendantIDs(id uint) ([]uint, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindByUUIDIncludingDeleted(uuid uuid.UUID) (*models.Category, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	category, ok := args.Get(0).(*models.Category)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return category, args.Error(1)
+}
+
+func (m *MockCategoryRepository) SoftDelete(id uint, reassignTo *uint) error {
+	args := m.Called(id, reassignTo)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Restore(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -82,3 +111,8 @@ func (m *MockCategoryRepository) ExistsByName(name string) (bool, error) {
 	args := m.Called(name)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockCategoryRepository) Reorder(uuids []uuid.UUID) error {
+	args := m.Called(uuids)
+	return args.Error(0)
+}
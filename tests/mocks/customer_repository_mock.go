@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) RefreshStats() (int64, error) {
+	args := m.Called()
+	count, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return count, args.Error(1)
+}
+
+func (m *MockCustomerRepository) StatsRefreshedAt() (*time.Time, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	refreshedAt, ok := args.Get(0).(*time.Time)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return refreshedAt, args.Error(1)
+}
+
+func (m *MockCustomerRepository) CategoryTreeIDs(categoryUUID uuid.UUID) ([]uint, error) {
+	args := m.Called(categoryUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
+
+func (m *MockCustomerRepository) FindSegment(filters repositories.CustomerSegmentFilters) ([]repositories.CustomerSegmentRow, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.CustomerSegmentRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockCustomerRepository) FindLifetimeValues(ranking repositories.CustomerRanking, limit, offset int) ([]repositories.CustomerValueRow, int64, error) {
+	args := m.Called(ranking, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	rows, ok := args.Get(0).([]repositories.CustomerValueRow)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	total, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return rows, total, args.Error(2)
+}
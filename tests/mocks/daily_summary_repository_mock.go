@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDailySummaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDailySummaryRepository) FindByDate(businessDate time.Time) (*models.DailySummary, error) {
+	args := m.Called(businessDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	summary, ok := args.Get(0).(*models.DailySummary)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return summary, args.Error(1)
+}
+
+func (m *MockDailySummaryRepository) Preview(summary *models.DailySummary) error {
+	args := m.Called(summary)
+	return args.Error(0)
+}
+
+func (m *MockDailySummaryRepository) Close(summary *models.DailySummary) ([]models.Order, error) {
+	args := m.Called(summary)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	orders, ok := args.Get(0).([]models.Order)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return orders, args.Error(1)
+}
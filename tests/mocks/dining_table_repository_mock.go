@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDiningTableRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiningTableRepository) Create(table *models.DiningTable) error {
+	args := m.Called(table)
+	return args.Error(0)
+}
+
+func (m *MockDiningTableRepository) FindAll() ([]models.DiningTable, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	tables, ok := args.Get(0).([]models.DiningTable)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return tables, args.Error(1)
+}
+
+func (m *MockDiningTableRepository) FindByUUID(uuid uuid.UUID) (*models.DiningTable, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	table, ok := args.Get(0).(*models.DiningTable)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return table, args.Error(1)
+}
+
+func (m *MockDiningTableRepository) FindByNumber(number string) (*models.DiningTable, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	table, ok := args.Get(0).(*models.DiningTable)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return table, args.Error(1)
+}
+
+func (m *MockDiningTableRepository) FindByToken(token string) (*models.DiningTable, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	table, ok := args.Get(0).(*models.DiningTable)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return table, args.Error(1)
+}
+
+func (m *MockDiningTableRepository) Update(table *models.DiningTable) error {
+	args := m.Called(table)
+	return args.Error(0)
+}
+
+func (m *MockDiningTableRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailTemplateRepository) FindActive(key models.EmailTemplateKey, locale string) (*models.EmailTemplate, error) {
+	args := m.Called(key, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	template, ok := args.Get(0).(*models.EmailTemplate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return template, args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) FindAllActive() ([]models.EmailTemplate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	templates, ok := args.Get(0).([]models.EmailTemplate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return templates, args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) FindVersions(key models.EmailTemplateKey, locale string) ([]models.EmailTemplate, error) {
+	args := m.Called(key, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	templates, ok := args.Get(0).([]models.EmailTemplate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return templates, args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) CreateVersion(template *models.EmailTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockEmailTemplateRepository) Activate(key models.EmailTemplateKey, locale string, version int) error {
+	args := m.Called(key, locale, version)
+	return args.Error(0)
+}
@@ -0,0 +1,105 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGiftCardRepository struct {
+	mock.Mock
+}
+
+func (m *MockGiftCardRepository) Create(card *models.GiftCard) error {
+	args := m.Called(card)
+	return args.Error(0)
+}
+
+func (m *MockGiftCardRepository) FindAll(status *models.GiftCardStatus, limit, offset int) ([]models.GiftCard, int64, error) {
+	args := m.Called(status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	cards, ok := args.Get(0).([]models.GiftCard)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return cards, count, args.Error(2)
+}
+
+func (m *MockGiftCardRepository) FindByUUID(uuid uuid.UUID) (*models.GiftCard, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	card, ok := args.Get(0).(*models.GiftCard)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return card, args.Error(1)
+}
+
+func (m *MockGiftCardRepository) FindByCode(code string) (*models.GiftCard, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	card, ok := args.Get(0).(*models.GiftCard)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return card, args.Error(1)
+}
+
+func (m *MockGiftCardRepository) FindByReference(reference string) (*models.GiftCard, error) {
+	args := m.Called(reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	card, ok := args.Get(0).(*models.GiftCard)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return card, args.Error(1)
+}
+
+func (m *MockGiftCardRepository) FindRedemptions(giftCardID uint) ([]models.GiftCardRedemption, error) {
+	args := m.Called(giftCardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	redemptions, ok := args.Get(0).([]models.GiftCardRedemption)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return redemptions, args.Error(1)
+}
+
+func (m *MockGiftCardRepository) UpdatePurchase(card *models.GiftCard) error {
+	args := m.Called(card)
+	return args.Error(0)
+}
+
+func (m *MockGiftCardRepository) Activate(card *models.GiftCard) (bool, error) {
+	args := m.Called(card)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGiftCardRepository) Void(id, voidedBy uint, reason string) (bool, error) {
+	args := m.Called(id, voidedBy, reason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGiftCardRepository) Redeem(redemption *models.GiftCardRedemption, settle *models.Payment) error {
+	args := m.Called(redemption, settle)
+	return args.Error(0)
+}
+
+func (m *MockGiftCardRepository) ReleaseByOrderID(orderID uint) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
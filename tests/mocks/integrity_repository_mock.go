@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIntegrityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIntegrityRepository) DetectAnomalies() (int64, error) {
+	args := m.Called()
+	detected, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return detected, args.Error(1)
+}
+
+func (m *MockIntegrityRepository) FindAnomalies(includeResolved bool) ([]models.OrderAnomaly, error) {
+	args := m.Called(includeResolved)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	anomalies, ok := args.Get(0).([]models.OrderAnomaly)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return anomalies, args.Error(1)
+}
+
+func (m *MockIntegrityRepository) FindAnomalyByUUID(uuid uuid.UUID) (*models.OrderAnomaly, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	anomaly, ok := args.Get(0).(*models.OrderAnomaly)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return anomaly, args.Error(1)
+}
+
+func (m *MockIntegrityRepository) Resolve(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobLockRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobLockRepository) Acquire(name, owner string, ttl time.Duration) (bool, error) {
+	args := m.Called(name, owner, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJobLockRepository) Release(name, owner string) error {
+	args := m.Called(name, owner)
+	return args.Error(0)
+}
@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockKioskRepository struct {
+	mock.Mock
+}
+
+func (m *MockKioskRepository) Create(kiosk *models.Kiosk) error {
+	args := m.Called(kiosk)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) FindAll() ([]models.Kiosk, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	kiosks, ok := args.Get(0).([]models.Kiosk)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return kiosks, args.Error(1)
+}
+
+func (m *MockKioskRepository) FindByUUID(uuid uuid.UUID) (*models.Kiosk, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	kiosk, ok := args.Get(0).(*models.Kiosk)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return kiosk, args.Error(1)
+}
+
+func (m *MockKioskRepository) FindByName(name string) (*models.Kiosk, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	kiosk, ok := args.Get(0).(*models.Kiosk)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return kiosk, args.Error(1)
+}
+
+func (m *MockKioskRepository) FindByAPIKeyHash(hash string) (*models.Kiosk, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	kiosk, ok := args.Get(0).(*models.Kiosk)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return kiosk, args.Error(1)
+}
+
+func (m *MockKioskRepository) Update(kiosk *models.Kiosk) error {
+	args := m.Called(kiosk)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockKioskRepository) RecordHeartbeat(id uint, seenAt time.Time, ip string, appVersion *string) error {
+	args := m.Called(id, seenAt, ip, appVersion)
+	return args.Error(0)
+}
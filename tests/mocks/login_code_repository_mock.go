@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginCodeRepository) Create(code *models.LoginCode) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *MockLoginCodeRepository) CountSince(userID uint, since time.Time) (int64, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLoginCodeRepository) FindLatestActive(userID uint) (*models.LoginCode, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	code, ok := args.Get(0).(*models.LoginCode)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return code, args.Error(1)
+}
+
+func (m *MockLoginCodeRepository) ReserveAttempt(id uint, maxAttempts int) (bool, error) {
+	args := m.Called(id, maxAttempts)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLoginCodeRepository) Consume(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLoginCodeRepository) DeleteExpired(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
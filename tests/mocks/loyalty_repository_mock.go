@@ -0,0 +1,118 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoyaltyRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoyaltyRepository) Balance(userID uint) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) FindTransactions(userID uint, limit, offset int) ([]models.PointTransaction, int64, error) {
+	args := m.Called(userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	entries, ok := args.Get(0).([]models.PointTransaction)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return entries, count, args.Error(2)
+}
+
+func (m *MockLoyaltyRepository) Redeem(entry *models.PointTransaction, amount float64, settle *models.Payment) error {
+	args := m.Called(entry, amount, settle)
+	return args.Error(0)
+}
+
+func (m *MockLoyaltyRepository) ReleaseByOrderID(orderID uint) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
+func (m *MockLoyaltyRepository) StampBalance(userID uint) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) StampCategoryIDs(categoryUUID uuid.UUID) ([]uint, error) {
+	args := m.Called(categoryUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) EarnStamps(orderID, userID uint, stamps int) error {
+	args := m.Called(orderID, userID, stamps)
+	return args.Error(0)
+}
+
+func (m *MockLoyaltyRepository) RedeemStamps(userID uint, goal int, reward *models.Voucher) (bool, error) {
+	args := m.Called(userID, goal, reward)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) FindStampRewards(userID uint) ([]models.Voucher, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	vouchers, ok := args.Get(0).([]models.Voucher)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return vouchers, args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) FindUnspentLots(userID uint) ([]repositories.PointLot, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	lots, ok := args.Get(0).([]repositories.PointLot)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return lots, args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) ExpirePoints(earnedBefore time.Time) (int, error) {
+	args := m.Called(earnedBefore)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) FindExpiryWarningUserIDs(earnedBefore time.Time) ([]uint, error) {
+	args := m.Called(earnedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	userIDs, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return userIDs, args.Error(1)
+}
+
+func (m *MockLoyaltyRepository) MarkExpiryWarned(userID uint, earnedBefore time.Time) (bool, error) {
+	args := m.Called(userID, earnedBefore)
+	return args.Bool(0), args.Error(1)
+}
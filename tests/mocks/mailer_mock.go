@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(msg utils.EmailMessage) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) Create(file *models.MediaFile) error {
+	args := m.Called(file)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) Usage() (*repositories.MediaUsage, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	usage, ok := args.Get(0).(*repositories.MediaUsage)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return usage, args.Error(1)
+}
+
+func (m *MockMediaRepository) FindUnreferenced(createdBefore time.Time) ([]models.MediaFile, error) {
+	args := m.Called(createdBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	files, ok := args.Get(0).([]models.MediaFile)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return files, args.Error(1)
+}
+
+func (m *MockMediaRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/carllix/matchaciee-backend/internal/models"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/google/uuid"
@@ -52,6 +54,18 @@ func (m *MockOrderRepository) FindByOrderNumber(orderNumber string) (*models.Ord
 	return order, args.Error(1)
 }
 
+func (m *MockOrderRepository) FindByShareToken(token string) (*models.Order, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	order, ok := args.Get(0).(*models.Order)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return order, args.Error(1)
+}
+
 func (m *MockOrderRepository) FindByUserID(userID uint, limit, offset int) ([]models.Order, int64, error) {
 	args := m.Called(userID, limit, offset)
 	if args.Get(0) == nil {
@@ -84,12 +98,103 @@ func (m *MockOrderRepository) FindAll(filters repositories.OrderFilters, limit,
 	return orders, count, args.Error(2)
 }
 
+func (m *MockOrderRepository) FindByStatuses(statuses []models.OrderStatus) ([]models.Order, error) {
+	args := m.Called(statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	orders, ok := args.Get(0).([]models.Order)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return orders, args.Error(1)
+}
+
+func (m *MockOrderRepository) FindDueScheduled(before time.Time) ([]models.Order, error) {
+	args := m.Called(before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	orders, ok := args.Get(0).([]models.Order)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return orders, args.Error(1)
+}
+
+func (m *MockOrderRepository) FindPickupLoads(from, to time.Time) ([]repositories.PickupLoad, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	loads, ok := args.Get(0).([]repositories.PickupLoad)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return loads, args.Error(1)
+}
+
 func (m *MockOrderRepository) UpdateStatus(orderID uint, status models.OrderStatus) error {
 	args := m.Called(orderID, status)
 	return args.Error(0)
 }
 
+func (m *MockOrderRepository) UpdateTip(orderID uint, tipAmount float64) error {
+	args := m.Called(orderID, tipAmount)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) AwardPoints(orderID, userID uint, points int) error {
+	args := m.Called(orderID, userID, points)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) UpdatePriority(orderID uint, priority models.Priority) error {
+	args := m.Called(orderID, priority)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ReleaseScheduled(orderID uint, paymentExpiresAt *time.Time) (bool, error) {
+	args := m.Called(orderID, paymentExpiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) Claim(orderID, userID uint) error {
+	args := m.Called(orderID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ReplaceItems(order *models.Order, items []models.OrderItem) error {
+	args := m.Called(order, items)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) MarkConfirmationSent(orderID uint) (bool, error) {
+	args := m.Called(orderID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) MarkReceiptSent(orderID uint) (bool, error) {
+	args := m.Called(orderID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) MarkReadyNotified(orderID uint) (bool, error) {
+	args := m.Called(orderID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) Delete(orderID uint) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
 func (m *MockOrderRepository) GenerateOrderNumber() (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockOrderRepository) NextQueueNumber() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPaymentLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentLinkRepository) Create(link *models.PaymentLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
+
+func (m *MockPaymentLinkRepository) FindByTokenHash(hash string) (*models.PaymentLink, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	link, ok := args.Get(0).(*models.PaymentLink)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return link, args.Error(1)
+}
@@ -0,0 +1,103 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) Create(payment *models.Payment) error {
+	args := m.Called(payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) CreateSettled(payment *models.Payment, from, to models.OrderStatus) error {
+	args := m.Called(payment, from, to)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) FindByUUID(uuid uuid.UUID) (*models.Payment, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	payment, ok := args.Get(0).(*models.Payment)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return payment, args.Error(1)
+}
+
+func (m *MockPaymentRepository) FindByMidtransOrderID(midtransOrderID string) (*models.Payment, error) {
+	args := m.Called(midtransOrderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	payment, ok := args.Get(0).(*models.Payment)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return payment, args.Error(1)
+}
+
+func (m *MockPaymentRepository) FindByOrderID(orderID uint) ([]models.Payment, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	payments, ok := args.Get(0).([]models.Payment)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return payments, args.Error(1)
+}
+
+func (m *MockPaymentRepository) FindAll(filters repositories.PaymentFilters, limit, offset int) ([]models.Payment, int64, error) {
+	args := m.Called(filters, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	payments, ok := args.Get(0).([]models.Payment)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return payments, count, args.Error(2)
+}
+
+func (m *MockPaymentRepository) Update(payment *models.Payment) error {
+	args := m.Called(payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) UpdateTransactionStatus(paymentID uint, status models.TransactionStatus) error {
+	args := m.Called(paymentID, status)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) UpdatePendingStatus(paymentID uint, status models.TransactionStatus) (bool, error) {
+	args := m.Called(paymentID, status)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPaymentRepository) FindStalePending(now time.Time, limit int) ([]models.Payment, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	payments, ok := args.Get(0).([]models.Payment)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return payments, args.Error(1)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPrintJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockPrintJobRepository) Create(job *models.PrintJob) (bool, error) {
+	args := m.Called(job)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPrintJobRepository) FindByUUID(uuid uuid.UUID) (*models.PrintJob, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	job, ok := args.Get(0).(*models.PrintJob)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return job, args.Error(1)
+}
+
+func (m *MockPrintJobRepository) ClaimNext(station string, staleBefore time.Time, maxAttempts int) (*models.PrintJob, error) {
+	args := m.Called(station, staleBefore, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	job, ok := args.Get(0).(*models.PrintJob)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return job, args.Error(1)
+}
+
+func (m *MockPrintJobRepository) Complete(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPrintJobRepository) Fail(id uint, lastError string, requeue bool) (bool, error) {
+	args := m.Called(id, lastError, requeue)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPrintJobRepository) DeletePrintedBefore(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -63,8 +63,8 @@ func (m *MockProductRepository) FindBySlug(slug string) (*models.Product, error)
 	return product, args.Error(1)
 }
 
-func (m *MockProductRepository) FindAll(includeDeleted bool, isAvailable *bool, categoryID *uint) ([]models.Product, error) {
-	args := m.Called(includeDeleted, isAvailable, categoryID)
+func (m *MockProductRepository) FindAll(includeDeleted bool, isAvailable *bool, categoryIDs []uint) ([]models.Product, error) {
+	args := m.Called(includeDeleted, isAvailable, categoryIDs)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -152,3 +152,8 @@ func (m *MockProductRepository) FindCustomizationsByProductID(productID uint) ([
 	}
 	return customizations, args.Error(1)
 }
+
+func (m *MockProductRepository) Reorder(uuids []uuid.UUID) error {
+	args := m.Called(uuids)
+	return args.Error(0)
+}
@@ -0,0 +1,120 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) Create(promotion *models.Promotion) error {
+	args := m.Called(promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) FindAll() ([]models.Promotion, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	promotions, ok := args.Get(0).([]models.Promotion)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return promotions, args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindByID(id uint) (*models.Promotion, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	promotion, ok := args.Get(0).(*models.Promotion)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return promotion, args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindByUUID(uuid uuid.UUID) (*models.Promotion, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	promotion, ok := args.Get(0).(*models.Promotion)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return promotion, args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindByCode(code string) (*models.Promotion, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	promotion, ok := args.Get(0).(*models.Promotion)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return promotion, args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindAutomatic() ([]models.Promotion, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	promotions, ok := args.Get(0).([]models.Promotion)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return promotions, args.Error(1)
+}
+
+func (m *MockPromotionRepository) Update(promotion *models.Promotion) error {
+	args := m.Called(promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) CountRedemptions(promotionID uint, userID *uint) (int64, error) {
+	args := m.Called(promotionID, userID)
+	count, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return count, args.Error(1)
+}
+
+func (m *MockPromotionRepository) ScopeCategoryIDs(promotionID uint) ([]uint, error) {
+	args := m.Called(promotionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
+
+func (m *MockPromotionRepository) Stats(promotionID uint) (*repositories.PromotionStats, error) {
+	args := m.Called(promotionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	stats, ok := args.Get(0).(*repositories.PromotionStats)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return stats, args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRefundRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefundRepository) Create(refund *models.Refund) error {
+	args := m.Called(refund)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepository) CreateWithinBalance(refund *models.Refund, grossAmount float64) (bool, error) {
+	args := m.Called(refund, grossAmount)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefundRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRefundRepository) SumByPaymentID(paymentID uint) (float64, error) {
+	args := m.Called(paymentID)
+	return args.Get(0).(float64), args.Error(1)
+}
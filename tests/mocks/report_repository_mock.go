@@ -0,0 +1,145 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) RevenueBySource(start, end time.Time) ([]repositories.SourceRevenueRow, error) {
+	args := m.Called(start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.SourceRevenueRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) SalesByPeriod(start, end time.Time, granularity string) ([]repositories.SalesRow, error) {
+	args := m.Called(start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.SalesRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) TopProducts(start, end time.Time, limit int) ([]repositories.TopProductRow, error) {
+	args := m.Called(start, end, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.TopProductRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) CustomizationsSold(start, end time.Time, productUUIDs []uuid.UUID) ([]repositories.CustomizationRow, error) {
+	args := m.Called(start, end, productUUIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.CustomizationRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) RevenueByCategory(start, end time.Time, parentUUID *uuid.UUID) ([]repositories.CategoryRevenueRow, error) {
+	args := m.Called(start, end, parentUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.CategoryRevenueRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) TaxByDay(start, end time.Time) ([]repositories.TaxRow, error) {
+	args := m.Called(start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.TaxRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) FulfillmentTimes(start, end time.Time) ([]repositories.FulfillmentRow, error) {
+	args := m.Called(start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.FulfillmentRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) AbandonedOrders(start, end, now time.Time) ([]repositories.AbandonedRow, error) {
+	args := m.Called(start, end, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.AbandonedRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) InventoryUsage(start, end, now time.Time) ([]repositories.InventoryUsageRow, error) {
+	args := m.Called(start, end, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.InventoryUsageRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) TipsByStaff(start, end time.Time) ([]repositories.StaffTipsRow, error) {
+	args := m.Called(start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.StaffTipsRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
+
+func (m *MockReportRepository) SettledPayments(start, end time.Time) ([]repositories.SettlementRow, error) {
+	args := m.Called(start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.SettlementRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
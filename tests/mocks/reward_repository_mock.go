@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRewardRepository struct {
+	mock.Mock
+}
+
+func (m *MockRewardRepository) Create(reward *models.Reward) error {
+	args := m.Called(reward)
+	return args.Error(0)
+}
+
+func (m *MockRewardRepository) FindAll(activeOnly bool) ([]models.Reward, error) {
+	args := m.Called(activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rewards, ok := args.Get(0).([]models.Reward)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rewards, args.Error(1)
+}
+
+func (m *MockRewardRepository) FindByUUID(uuid uuid.UUID) (*models.Reward, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	reward, ok := args.Get(0).(*models.Reward)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return reward, args.Error(1)
+}
+
+func (m *MockRewardRepository) Update(reward *models.Reward) error {
+	args := m.Called(reward)
+	return args.Error(0)
+}
+
+func (m *MockRewardRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRewardRepository) CountRedemptions(rewardID uint) (int64, error) {
+	args := m.Called(rewardID)
+	count, ok := args.Get(0).(int64)
+	if !ok {
+		return 0, args.Error(1)
+	}
+	return count, args.Error(1)
+}
+
+func (m *MockRewardRepository) Redeem(reward *models.Reward, entry *models.PointTransaction, voucher *models.Voucher) error {
+	args := m.Called(reward, entry, voucher)
+	return args.Error(0)
+}
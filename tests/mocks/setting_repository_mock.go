@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSettingRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingRepository) FindByKey(key string) (*models.Setting, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	setting, ok := args.Get(0).(*models.Setting)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return setting, args.Error(1)
+}
+
+func (m *MockSettingRepository) Upsert(setting *models.Setting) error {
+	args := m.Called(setting)
+	return args.Error(0)
+}
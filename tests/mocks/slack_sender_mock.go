@@ -0,0 +1,12 @@
+package mocks
+
+import "github.com/stretchr/testify/mock"
+
+type MockSlackSender struct {
+	mock.Mock
+}
+
+func (m *MockSlackSender) Send(text string) error {
+	args := m.Called(text)
+	return args.Error(0)
+}
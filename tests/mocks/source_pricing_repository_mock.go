@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSourcePricingRepository struct {
+	mock.Mock
+}
+
+func (m *MockSourcePricingRepository) FindBySource(source models.OrderSource) (*models.SourcePricing, error) {
+	args := m.Called(source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	pricing, ok := args.Get(0).(*models.SourcePricing)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return pricing, args.Error(1)
+}
+
+func (m *MockSourcePricingRepository) FindAll() ([]models.SourcePricing, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	pricings, ok := args.Get(0).([]models.SourcePricing)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return pricings, args.Error(1)
+}
+
+func (m *MockSourcePricingRepository) Upsert(pricing *models.SourcePricing) error {
+	args := m.Called(pricing)
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockReservationRepository) ReserveForOrder(orderID uint, items []repositories.ReservationItem, expiresAt time.Time) error {
+	args := m.Called(orderID, items, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockStockReservationRepository) ReplaceForOrder(orderID uint, items []repositories.ReservationItem, expiresAt time.Time) error {
+	args := m.Called(orderID, items, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockStockReservationRepository) FindByOrderID(orderID uint) ([]models.StockReservation, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	reservations, ok := args.Get(0).([]models.StockReservation)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return reservations, args.Error(1)
+}
+
+func (m *MockStockReservationRepository) AvailableQuantity(productID uint) (int, error) {
+	args := m.Called(productID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStockReservationRepository) ConsumeByOrderID(orderID uint) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
+func (m *MockStockReservationRepository) ReleaseByOrderID(orderID uint) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
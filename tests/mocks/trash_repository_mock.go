@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) FindDeletedProducts() ([]models.Product, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	products, ok := args.Get(0).([]models.Product)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return products, args.Error(1)
+}
+
+func (m *MockTrashRepository) FindDeletedCategories() ([]models.Category, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	categories, ok := args.Get(0).([]models.Category)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return categories, args.Error(1)
+}
+
+func (m *MockTrashRepository) ProductPurgeImpact(productID uint) (*repositories.ProductPurgeImpact, error) {
+	args := m.Called(productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	impact, ok := args.Get(0).(*repositories.ProductPurgeImpact)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return impact, args.Error(1)
+}
+
+func (m *MockTrashRepository) CategoryPurgeImpact(categoryID uint) (*repositories.CategoryPurgeImpact, error) {
+	args := m.Called(categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	impact, ok := args.Get(0).(*repositories.CategoryPurgeImpact)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return impact, args.Error(1)
+}
+
+func (m *MockTrashRepository) PurgeProduct(productID uint) error {
+	args := m.Called(productID)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) PurgeCategory(categoryID uint) error {
+	args := m.Called(categoryID)
+	return args.Error(0)
+}
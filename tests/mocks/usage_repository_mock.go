@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockUsageRepository) Add(usage []models.APIUsage) error {
+	args := m.Called(usage)
+	return args.Error(0)
+}
+
+func (m *MockUsageRepository) RouteUsage(start, end time.Time, client string) ([]repositories.RouteUsageRow, error) {
+	args := m.Called(start, end, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	rows, ok := args.Get(0).([]repositories.RouteUsageRow)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return rows, args.Error(1)
+}
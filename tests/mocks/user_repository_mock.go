@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -51,6 +54,18 @@ func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
 	return user, args.Error(1)
 }
 
+func (m *MockUserRepository) FindByPhone(phone string) (*models.User, error) {
+	args := m.Called(phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	user, ok := args.Get(0).(*models.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return user, args.Error(1)
+}
+
 func (m *MockUserRepository) Update(user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
@@ -65,3 +80,15 @@ func (m *MockUserRepository) ExistsByEmail(email string) (bool, error) {
 	args := m.Called(email)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockUserRepository) FindMemberIDs(segment repositories.MemberSegment, inactiveSince time.Time) ([]uint, error) {
+	args := m.Called(segment, inactiveSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
@@ -0,0 +1,110 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVoucherRepository struct {
+	mock.Mock
+}
+
+func (m *MockVoucherRepository) CreateBatch(vouchers []models.Voucher) error {
+	args := m.Called(vouchers)
+	return args.Error(0)
+}
+
+func (m *MockVoucherRepository) FindAll(filters repositories.VoucherFilters, limit, offset int) ([]models.Voucher, int64, error) {
+	args := m.Called(filters, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	vouchers, ok := args.Get(0).([]models.Voucher)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return vouchers, count, args.Error(2)
+}
+
+func (m *MockVoucherRepository) FindByUUID(uuid uuid.UUID) (*models.Voucher, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	voucher, ok := args.Get(0).(*models.Voucher)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return voucher, args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByCode(code string) (*models.Voucher, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	voucher, ok := args.Get(0).(*models.Voucher)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return voucher, args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByOrderID(orderID uint) (*models.Voucher, error) {
+	args := m.Called(orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	voucher, ok := args.Get(0).(*models.Voucher)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return voucher, args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByUserID(userID uint) ([]models.Voucher, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	vouchers, ok := args.Get(0).([]models.Voucher)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return vouchers, args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindEvents(voucherID uint) ([]models.VoucherEvent, error) {
+	args := m.Called(voucherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	events, ok := args.Get(0).([]models.VoucherEvent)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return events, args.Error(1)
+}
+
+func (m *MockVoucherRepository) Revoke(id, revokedBy uint, reason string) (bool, error) {
+	args := m.Called(id, revokedBy, reason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockVoucherRepository) CategoryTreeIDs(categoryID uint) ([]uint, error) {
+	args := m.Called(categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	ids, ok := args.Get(0).([]uint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return ids, args.Error(1)
+}
@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWalletRepository struct {
+	mock.Mock
+}
+
+func (m *MockWalletRepository) Balance(userID uint) (float64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockWalletRepository) FindTransactions(userID uint, limit, offset int) ([]models.WalletTransaction, int64, error) {
+	args := m.Called(userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	entries, ok := args.Get(0).([]models.WalletTransaction)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return entries, count, args.Error(2)
+}
+
+func (m *MockWalletRepository) Post(entry *models.WalletTransaction) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) PayOrder(entry *models.WalletTransaction, payment *models.Payment, from, to models.OrderStatus) error {
+	args := m.Called(entry, payment, from, to)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) CreateTopUp(topUp *models.WalletTopUp) error {
+	args := m.Called(topUp)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) FindTopUpByReference(reference string) (*models.WalletTopUp, error) {
+	args := m.Called(reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	topUp, ok := args.Get(0).(*models.WalletTopUp)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return topUp, args.Error(1)
+}
+
+func (m *MockWalletRepository) UpdateTopUp(topUp *models.WalletTopUp) error {
+	args := m.Called(topUp)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) CreditTopUp(topUp *models.WalletTopUp) (bool, error) {
+	args := m.Called(topUp)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookEventRepository) Create(event *models.WebhookEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookEventRepository) FindByUUID(uuid uuid.UUID) (*models.WebhookEvent, error) {
+	args := m.Called(uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	event, ok := args.Get(0).(*models.WebhookEvent)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return event, args.Error(1)
+}
+
+func (m *MockWebhookEventRepository) FindAll(filters repositories.WebhookEventFilters, limit, offset int) ([]models.WebhookEvent, int64, error) {
+	args := m.Called(filters, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	events, ok := args.Get(0).([]models.WebhookEvent)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	count, ok := args.Get(1).(int64)
+	if !ok {
+		return nil, 0, args.Error(2)
+	}
+	return events, count, args.Error(2)
+}
+
+func (m *MockWebhookEventRepository) RecordResult(id uint, processErr *string) error {
+	args := m.Called(id, processErr)
+	return args.Error(0)
+}
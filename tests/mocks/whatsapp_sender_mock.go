@@ -0,0 +1,12 @@
+package mocks
+
+import "github.com/stretchr/testify/mock"
+
+type MockWhatsAppSender struct {
+	mock.Mock
+}
+
+func (m *MockWhatsAppSender) Send(to, text string) error {
+	args := m.Called(to, text)
+	return args.Error(0)
+}
@@ -0,0 +1,101 @@
+package chaos_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/chaos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChaosApp(injector *chaos.Injector) *fiber.App {
+	app := fiber.New()
+	app.Use(injector.Middleware())
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Post("/api/v1/orders/:id/payment", ok)
+	app.Post("/api/v1/webhooks/midtrans", ok)
+	app.Get("/api/v1/menu", ok)
+	app.Put("/api/v1/chaos", ok)
+	return app
+}
+
+func TestInjector_Middleware(t *testing.T) {
+	t.Run("should pass requests through without faults", func(t *testing.T) {
+		app := newChaosApp(chaos.NewInjector())
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/orders/abc/payment", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should fail payment token creation when Midtrans failure is injected", func(t *testing.T) {
+		injector := chaos.NewInjector()
+		injector.Set(chaos.Faults{MidtransFailure: true, ExpiresAt: time.Now().Add(time.Minute)})
+		app := newChaosApp(injector)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/orders/abc/payment", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("should reject webhooks when signature failure is injected", func(t *testing.T) {
+		injector := chaos.NewInjector()
+		injector.Set(chaos.Faults{WebhookSignatureFailure: true, ExpiresAt: time.Now().Add(time.Minute)})
+		app := newChaosApp(injector)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/webhooks/midtrans", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("should add latency only under the path prefix", func(t *testing.T) {
+		injector := chaos.NewInjector()
+		injector.Set(chaos.Faults{
+			Latency:           100 * time.Millisecond,
+			LatencyPathPrefix: "/api/v1/menu",
+			ExpiresAt:         time.Now().Add(time.Minute),
+		})
+		app := newChaosApp(injector)
+
+		start := time.Now()
+		_, err := app.Test(httptest.NewRequest("GET", "/api/v1/menu", nil))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		start = time.Now()
+		_, err = app.Test(httptest.NewRequest("POST", "/api/v1/webhooks/midtrans", nil))
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("should never affect the chaos endpoints", func(t *testing.T) {
+		injector := chaos.NewInjector()
+		injector.Set(chaos.Faults{Latency: time.Second, ExpiresAt: time.Now().Add(time.Minute)})
+		app := newChaosApp(injector)
+
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest("PUT", "/api/v1/chaos", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should stop injecting once faults expire", func(t *testing.T) {
+		injector := chaos.NewInjector()
+		injector.Set(chaos.Faults{MidtransFailure: true, ExpiresAt: time.Now().Add(-time.Second)})
+		app := newChaosApp(injector)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/v1/orders/abc/payment", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, chaos.Faults{}, injector.Faults())
+	})
+}
@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/handlers"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	deleteCategory := func(t *testing.T, repo *mocks.MockCategoryRepository, target string) (int, utils.Response) {
+		app := fiber.New()
+		app.Delete("/categories/:id", handlers.NewCategoryHandler(services.NewCategoryService(repo)).DeleteCategory)
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", target, nil))
+		require.NoError(t, err)
+
+		var body utils.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	t.Run("success - moves the products to the target", func(t *testing.T) {
+		repo := new(mocks.MockCategoryRepository)
+		categoryUUID := uuid.New()
+		targetUUID := uuid.New()
+		repo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+		repo.On("FindByUUID", targetUUID).Return(&models.Category{ID: 2, UUID: targetUUID}, nil)
+		repo.On("SoftDelete", uint(1), mock.MatchedBy(func(id *uint) bool {
+			return id != nil && *id == 2
+		})).Return(nil)
+
+		status, _ := deleteCategory(t, repo, "/categories/"+categoryUUID.String()+"?reassign_to="+targetUUID.String())
+
+		assert.Equal(t, fiber.StatusOK, status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error - missing reassignment target is a bad request", func(t *testing.T) {
+		repo := new(mocks.MockCategoryRepository)
+		categoryUUID := uuid.New()
+		targetUUID := uuid.New()
+		repo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+		repo.On("FindByUUID", targetUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		status, body := deleteCategory(t, repo, "/categories/"+categoryUUID.String()+"?reassign_to="+targetUUID.String())
+
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.Equal(t, "Products can only be reassigned to another existing category", body.Error)
+		repo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - missing category is not found", func(t *testing.T) {
+		repo := new(mocks.MockCategoryRepository)
+		categoryUUID := uuid.New()
+		repo.On("FindByUUID", categoryUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		status, body := deleteCategory(t, repo, "/categories/"+categoryUUID.String()+"?reassign_to="+uuid.NewString())
+
+		assert.Equal(t, fiber.StatusNotFound, status)
+		assert.Equal(t, "Category not found", body.Error)
+	})
+}
@@ -0,0 +1,56 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success - writes context fields as JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := logging.New(&out, "info")
+		require.NoError(t, err)
+
+		userUUID := uuid.New()
+		ctx := utils.WithRequestID(context.Background(), "req-1")
+		ctx = logging.WithAttrs(ctx, logging.UserUUID(userUUID))
+		logger.ErrorContext(ctx, "Failed to create payment token", logging.OrderNumber("ORD-001"), logging.Err(errors.New("gateway down")))
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+		assert.Equal(t, "ERROR", line["level"])
+		assert.Equal(t, "Failed to create payment token", line["msg"])
+		assert.Equal(t, "req-1", line[logging.KeyRequestID])
+		assert.Equal(t, userUUID.String(), line[logging.KeyUserUUID])
+		assert.Equal(t, "ORD-001", line[logging.KeyOrderNumber])
+		assert.Equal(t, "gateway down", line[logging.KeyError])
+	})
+
+	t.Run("success - drops lines below the level", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := logging.New(&out, "warn")
+		require.NoError(t, err)
+
+		logger.Info("Payment settled")
+		assert.Empty(t, out.String())
+
+		logger.Warn("Amount mismatch for order")
+		assert.NotEmpty(t, out.String())
+	})
+
+	t.Run("error - unknown level", func(t *testing.T) {
+		logger, err := logging.New(&bytes.Buffer{}, "verbose")
+
+		assert.Error(t, err)
+		assert.Nil(t, logger)
+	})
+}
@@ -0,0 +1,39 @@
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDBMonitor_Stats(t *testing.T) {
+	t.Run("should report zero stats without queries", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+
+		stats := monitor.Stats()
+
+		assert.Zero(t, stats.Queries)
+		assert.Zero(t, stats.ErrorRate)
+		assert.Zero(t, stats.AverageLatency)
+	})
+
+	t.Run("should average latency and count errors", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+
+		monitor.Observe(100*time.Millisecond, nil)
+		monitor.Observe(300*time.Millisecond, errors.New("connection reset"))
+		monitor.Observe(200*time.Millisecond, gorm.ErrRecordNotFound)
+		monitor.Observe(200*time.Millisecond, nil)
+
+		stats := monitor.Stats()
+
+		assert.Equal(t, int64(4), stats.Queries)
+		assert.Equal(t, int64(1), stats.Errors)
+		assert.InDelta(t, 0.25, stats.ErrorRate, 0.0001)
+		assert.Equal(t, 200*time.Millisecond, stats.AverageLatency)
+	})
+}
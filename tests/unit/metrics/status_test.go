@@ -0,0 +1,74 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusTracker_Record(t *testing.T) {
+	t.Run("should not open incidents while operational", func(t *testing.T) {
+		tracker := metrics.NewStatusTracker(time.Hour)
+
+		tracker.Record("database", metrics.StateOperational)
+
+		assert.Empty(t, tracker.Incidents())
+	})
+
+	t.Run("should keep the worst state of an ongoing incident", func(t *testing.T) {
+		tracker := metrics.NewStatusTracker(time.Hour)
+
+		tracker.Record("database", metrics.StateDegraded)
+		tracker.Record("database", metrics.StateDown)
+		tracker.Record("database", metrics.StateDegraded)
+
+		incidents := tracker.Incidents()
+		assert.Len(t, incidents, 1)
+		assert.Equal(t, metrics.StateDown, incidents[0].State)
+		assert.Nil(t, incidents[0].ResolvedAt)
+	})
+
+	t.Run("should resolve the incident on recovery", func(t *testing.T) {
+		tracker := metrics.NewStatusTracker(time.Hour)
+
+		tracker.Record("realtime", metrics.StateDegraded)
+		tracker.Record("realtime", metrics.StateOperational)
+		tracker.Record("realtime", metrics.StateDegraded)
+
+		incidents := tracker.Incidents()
+		assert.Len(t, incidents, 2)
+		assert.Nil(t, incidents[0].ResolvedAt, "newest incident is still ongoing")
+		assert.NotNil(t, incidents[1].ResolvedAt)
+	})
+}
+
+func TestStatusTracker_Report(t *testing.T) {
+	t.Run("should report the worst component state", func(t *testing.T) {
+		tracker := metrics.NewStatusTracker(time.Hour)
+		checks := []metrics.Check{
+			{Name: "database", State: func() metrics.ComponentState { return metrics.StateOperational }},
+			{Name: "realtime", State: func() metrics.ComponentState { return metrics.StateDegraded }},
+		}
+
+		report := tracker.Report(checks)
+
+		assert.Equal(t, metrics.StateDegraded, report.Status)
+		assert.Len(t, report.Components, 2)
+		assert.Equal(t, "realtime", report.Components[1].Name)
+		assert.Len(t, report.Incidents, 1)
+		assert.Equal(t, "realtime", report.Incidents[0].Component)
+		assert.NotEmpty(t, report.StartedAt)
+	})
+
+	t.Run("should report operational without checks", func(t *testing.T) {
+		tracker := metrics.NewStatusTracker(time.Hour)
+
+		report := tracker.Report(nil)
+
+		assert.Equal(t, metrics.StateOperational, report.Status)
+		assert.Empty(t, report.Components)
+		assert.NotNil(t, report.Incidents)
+	})
+}
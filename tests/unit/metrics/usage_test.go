@@ -0,0 +1,83 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageCollector(t *testing.T) {
+	t.Run("should aggregate requests per client and route", func(t *testing.T) {
+		collector := metrics.NewUsageCollector(logging.Discard())
+
+		collector.Record("kiosk", "GET", "/api/v1/menu", 200, 20*time.Millisecond)
+		collector.Record("kiosk", "GET", "/api/v1/menu", 404, 40*time.Millisecond)
+		collector.Record("kiosk", "GET", "/api/v1/menu", 503, 90*time.Millisecond)
+		collector.Record("web", "GET", "/api/v1/menu", 200, 10*time.Millisecond)
+
+		counts := collector.Drain()
+
+		assert.Len(t, counts, 2)
+		for key, count := range counts {
+			if key.Client != "kiosk" {
+				continue
+			}
+			assert.Equal(t, int64(3), count.Requests)
+			assert.Equal(t, int64(1), count.ClientErrors)
+			assert.Equal(t, int64(1), count.ServerErrors)
+			assert.Equal(t, 150*time.Millisecond, count.TotalLatency)
+			assert.Equal(t, 90*time.Millisecond, count.MaxLatency)
+			assert.Equal(t, key.BucketStart, key.BucketStart.Truncate(time.Hour))
+		}
+	})
+
+	t.Run("should reset after drain", func(t *testing.T) {
+		collector := metrics.NewUsageCollector(logging.Discard())
+		collector.Record("web", "GET", "/api/v1/menu", 200, time.Millisecond)
+
+		collector.Drain()
+
+		assert.Empty(t, collector.Drain())
+	})
+
+	t.Run("should flush remaining counts when stopped", func(t *testing.T) {
+		collector := metrics.NewUsageCollector(logging.Discard())
+		collector.Record("web", "GET", "/api/v1/menu", 200, time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var flushed map[metrics.UsageKey]metrics.UsageCount
+		collector.Run(ctx, time.Hour, func(counts map[metrics.UsageKey]metrics.UsageCount) error {
+			flushed = counts
+			return nil
+		})
+
+		assert.Len(t, flushed, 1)
+	})
+}
+
+func TestClientName(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		userAgent string
+		expected  string
+	}{
+		{"header wins", " Kiosk ", "Mozilla/5.0", "kiosk"},
+		{"browser", "", "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0)", "web"},
+		{"app user agent", "", "MatchacieeMobile/2.3.1 (iOS 17.2)", "matchacieemobile"},
+		{"user agent without version", "", "okhttp", "okhttp"},
+		{"nothing", "", "", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, metrics.ClientName(tt.header, tt.userAgent))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagApp(version func() (string, error), handlerCalls *int) *fiber.App {
+	app := fiber.New()
+	app.Get("/catalog", middleware.ETagMiddleware(version), func(c *fiber.Ctx) error {
+		*handlerCalls++
+		return c.SendString("catalog")
+	})
+	app.Get("/missing", middleware.ETagMiddleware(version), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNotFound)
+	})
+	return app
+}
+
+func TestETagMiddleware(t *testing.T) {
+	version := func() (string, error) { return "7", nil }
+
+	t.Run("should tag successful responses", func(t *testing.T) {
+		calls := 0
+		app := newETagApp(version, &calls)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/catalog", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, `W/"7"`, resp.Header.Get(fiber.HeaderETag))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should return 304 without calling the handler when the ETag matches", func(t *testing.T) {
+		calls := 0
+		app := newETagApp(version, &calls)
+
+		for _, ifNoneMatch := range []string{`W/"7"`, `"7"`, `W/"6", W/"7"`, `*`} {
+			req := httptest.NewRequest("GET", "/catalog", nil)
+			req.Header.Set(fiber.HeaderIfNoneMatch, ifNoneMatch)
+
+			resp, err := app.Test(req)
+
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusNotModified, resp.StatusCode, ifNoneMatch)
+		}
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("should serve the body when the ETag is stale", func(t *testing.T) {
+		calls := 0
+		app := newETagApp(version, &calls)
+
+		req := httptest.NewRequest("GET", "/catalog", nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, `W/"6"`)
+
+		resp, err := app.Test(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should not tag error responses", func(t *testing.T) {
+		calls := 0
+		app := newETagApp(version, &calls)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(fiber.HeaderETag))
+	})
+
+	t.Run("should serve untagged when the version is unavailable", func(t *testing.T) {
+		calls := 0
+		app := newETagApp(func() (string, error) { return "", errors.New("db down") }, &calls)
+
+		req := httptest.NewRequest("GET", "/catalog", nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, "*")
+
+		resp, err := app.Test(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(fiber.HeaderETag))
+		assert.Equal(t, 1, calls)
+	})
+}
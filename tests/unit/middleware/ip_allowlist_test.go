@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIPAllowlist(t *testing.T) {
+	t.Run("should read addresses and ranges", func(t *testing.T) {
+		prefixes, err := middleware.ParseIPAllowlist([]string{"103.208.23.6", "103.127.16.0/23", ""})
+
+		require.NoError(t, err)
+		require.Len(t, prefixes, 2)
+		assert.Equal(t, "103.208.23.6/32", prefixes[0].String())
+		assert.Equal(t, "103.127.16.0/23", prefixes[1].String())
+	})
+
+	t.Run("should reject anything else", func(t *testing.T) {
+		_, err := middleware.ParseIPAllowlist([]string{"midtrans.com"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIPAllowlistMiddleware(t *testing.T) {
+	// app.Test connects from 0.0.0.0
+	request := func(t *testing.T, entries ...string) int {
+		allowed, err := middleware.ParseIPAllowlist(entries)
+		require.NoError(t, err)
+
+		app := fiber.New()
+		app.Post("/webhooks/midtrans", middleware.IPAllowlistMiddleware(allowed, logging.Discard()), func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/webhooks/midtrans", nil))
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	t.Run("should admit everyone when the allowlist is empty", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, request(t))
+	})
+
+	t.Run("should admit an allowed address", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, request(t, "103.208.23.6", "0.0.0.0/8"))
+	})
+
+	t.Run("should reject other addresses", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, request(t, "103.208.23.6", "103.127.16.0/23"))
+	})
+}
+
+func TestIPAllowlistMiddleware_BehindProxy(t *testing.T) {
+	// app.Test connects from 0.0.0.0, standing in for the proxy
+	request := func(t *testing.T, proxies []string, forwardedFor string) int {
+		allowed, err := middleware.ParseIPAllowlist([]string{"103.208.23.6"})
+		require.NoError(t, err)
+
+		config := fiber.Config{}
+		middleware.TrustProxies(&config, fiber.HeaderXForwardedFor, proxies)
+		app := fiber.New(config)
+		app.Post("/webhooks/midtrans", middleware.IPAllowlistMiddleware(allowed, logging.Discard()), func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		req := httptest.NewRequest("POST", "/webhooks/midtrans", nil)
+		req.Header.Set(fiber.HeaderXForwardedFor, forwardedFor)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	t.Run("should check the address a trusted proxy forwarded", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, request(t, []string{"0.0.0.0/8"}, "103.208.23.6"))
+		assert.Equal(t, fiber.StatusForbidden, request(t, []string{"0.0.0.0/8"}, "198.51.100.7"))
+	})
+
+	t.Run("should skip values that are not addresses", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, request(t, []string{"0.0.0.0/8"}, "unknown, 103.208.23.6"))
+	})
+
+	t.Run("should ignore the header from anyone else", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, request(t, []string{"10.0.0.1"}, "103.208.23.6"))
+	})
+
+	t.Run("should ignore the header without trusted proxies", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, request(t, nil, "103.208.23.6"))
+	})
+}
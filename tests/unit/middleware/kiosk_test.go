@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKioskAuthenticator knows a single kiosk key
+type fakeKioskAuthenticator struct {
+	apiKey string
+	kiosk  *models.Kiosk
+}
+
+func (f fakeKioskAuthenticator) Authenticate(apiKey string) (*models.Kiosk, error) {
+	if apiKey != f.apiKey {
+		return nil, errors.New("invalid kiosk key")
+	}
+	return f.kiosk, nil
+}
+
+func TestKioskAuthMiddleware(t *testing.T) {
+	app := fiber.New()
+	authenticator := fakeKioskAuthenticator{apiKey: "secret", kiosk: &models.Kiosk{Name: "Counter"}}
+	app.Post("/orders/kiosk", middleware.KioskAuthMiddleware(authenticator), func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("kiosk").(*models.Kiosk).Name)
+	})
+
+	tests := []struct {
+		name   string
+		key    string
+		status int
+	}{
+		{name: "should admit a known key", key: "secret", status: fiber.StatusOK},
+		{name: "should reject an unknown key", key: "guess", status: fiber.StatusUnauthorized},
+		{name: "should reject a missing key", key: "", status: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/orders/kiosk", nil)
+			if tt.key != "" {
+				req.Header.Set(middleware.KioskKeyHeader, tt.key)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLoadShedding = middleware.LoadSheddingConfig{
+	MaxDBLatency:   500 * time.Millisecond,
+	MaxDBErrorRate: 0.25,
+	MinQueries:     10,
+	RetryAfter:     30 * time.Second,
+}
+
+func newLoadSheddingApp(monitor *metrics.DBMonitor) *fiber.App {
+	app := fiber.New()
+	app.Get("/menu", middleware.LoadSheddingMiddleware(monitor, testLoadShedding), func(c *fiber.Ctx) error {
+		return c.SendString("menu")
+	})
+	return app
+}
+
+func TestLoadSheddingMiddleware(t *testing.T) {
+	t.Run("should pass requests while the database is healthy", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+		for range 20 {
+			monitor.Observe(10*time.Millisecond, nil)
+		}
+
+		resp, err := newLoadSheddingApp(monitor).Test(httptest.NewRequest("GET", "/menu", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should shed when latency is above the threshold", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+		for range 20 {
+			monitor.Observe(800*time.Millisecond, nil)
+		}
+
+		resp, err := newLoadSheddingApp(monitor).Test(httptest.NewRequest("GET", "/menu", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, "30", resp.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("should shed when the error rate is above the threshold", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+		for i := range 20 {
+			var err error
+			if i%2 == 0 {
+				err = errors.New("too many connections")
+			}
+			monitor.Observe(10*time.Millisecond, err)
+		}
+
+		resp, err := newLoadSheddingApp(monitor).Test(httptest.NewRequest("GET", "/menu", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("should ignore slow queries below the minimum sample size", func(t *testing.T) {
+		monitor := metrics.NewDBMonitor(30 * time.Second)
+		for range 3 {
+			monitor.Observe(2*time.Second, nil)
+		}
+
+		resp, err := newLoadSheddingApp(monitor).Test(httptest.NewRequest("GET", "/menu", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.LocaleMiddleware())
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+	})
+
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", "Order not found"},
+		{"id-ID,id;q=0.9", "Pesanan tidak ditemukan"},
+		{"en-US", "Order not found"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/orders/1", nil)
+		if tt.acceptLanguage != "" {
+			req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+		}
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		var body utils.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, tt.expected, body.Error, tt.acceptLanguage)
+	}
+}
@@ -0,0 +1,192 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Run("should reject requests over the limit within the window", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/orders/lookup", middleware.RateLimitMiddleware(2, time.Minute), func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		for range 2 {
+			resp, err := app.Test(httptest.NewRequest("POST", "/orders/lookup", nil))
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		}
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/orders/lookup", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	})
+}
+
+// countingStore is a RateLimitStore standing in for the shared store
+type countingStore struct {
+	mu     sync.Mutex
+	counts map[string]int
+	err    error
+}
+
+func newCountingStore() *countingStore {
+	return &countingStore{counts: make(map[string]int)}
+}
+
+func (s *countingStore) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, 0, s.err
+	}
+	s.counts[key]++
+	return s.counts[key], window, nil
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	ok := func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	}
+
+	t.Run("should count each limit apart in a shared store", func(t *testing.T) {
+		store := newCountingStore()
+		limiter := middleware.NewRateLimiter(store, logging.Discard())
+		app := fiber.New()
+		app.Post("/auth/login", limiter.Limit("login", 1, time.Minute), ok)
+		app.Post("/orders/guest", limiter.Limit("guest_order", 1, time.Minute), ok)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/auth/login", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		resp, err = app.Test(httptest.NewRequest("POST", "/orders/guest", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		resp, err = app.Test(httptest.NewRequest("POST", "/auth/login", nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+		assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+
+		assert.Equal(t, "60", resp.Header.Get(fiber.HeaderRetryAfter))
+		assert.Equal(t, "0", resp.Header.Get("X-RateLimit-Remaining"))
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		assert.Equal(t, 2, store.counts["ratelimit:login:0.0.0.0"])
+		assert.Equal(t, 1, store.counts["ratelimit:guest_order:0.0.0.0"])
+	})
+
+	t.Run("should let requests through when the store fails", func(t *testing.T) {
+		store := newCountingStore()
+		store.err = errors.New("connection refused")
+		app := fiber.New()
+		app.Post("/auth/login", middleware.NewRateLimiter(store, logging.Discard()).Limit("login", 1, time.Minute), ok)
+
+		for range 3 {
+			resp, err := app.Test(httptest.NewRequest("POST", "/auth/login", nil))
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		}
+	})
+
+	t.Run("should count each client behind a trusted proxy apart", func(t *testing.T) {
+		store := newCountingStore()
+		config := fiber.Config{}
+		middleware.TrustProxies(&config, fiber.HeaderXForwardedFor, []string{"0.0.0.0/8"})
+		app := fiber.New(config)
+		app.Post("/orders/lookup", middleware.NewRateLimiter(store, logging.Discard()).Limit("order_lookup", 1, time.Minute), ok)
+
+		lookup := func(forwardedFor string) int {
+			req := httptest.NewRequest("POST", "/orders/lookup", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, forwardedFor)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			return resp.StatusCode
+		}
+
+		assert.Equal(t, fiber.StatusOK, lookup("203.0.113.5"))
+		assert.Equal(t, fiber.StatusOK, lookup("198.51.100.7"))
+		assert.Equal(t, fiber.StatusTooManyRequests, lookup("203.0.113.5"))
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		assert.Equal(t, 2, store.counts["ratelimit:order_lookup:203.0.113.5"])
+		assert.Equal(t, 1, store.counts["ratelimit:order_lookup:198.51.100.7"])
+	})
+
+	t.Run("should count by connection when the header comes from an untrusted client", func(t *testing.T) {
+		store := newCountingStore()
+		config := fiber.Config{}
+		middleware.TrustProxies(&config, fiber.HeaderXForwardedFor, []string{"10.0.0.1"})
+		app := fiber.New(config)
+		app.Post("/orders/lookup", middleware.NewRateLimiter(store, logging.Discard()).Limit("order_lookup", 1, time.Minute), ok)
+
+		for i, forwardedFor := range []string{"203.0.113.5", "198.51.100.7"} {
+			req := httptest.NewRequest("POST", "/orders/lookup", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, forwardedFor)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			if i == 0 {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			} else {
+				assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("should let every request through when the limit is zero", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/menu", middleware.NewRateLimiter(nil, logging.Discard()).Limit("global", 0, time.Minute), ok)
+
+		for range 5 {
+			resp, err := app.Test(httptest.NewRequest("GET", "/menu", nil))
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		}
+	})
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	t.Run("should count every concurrent hit", func(t *testing.T) {
+		store := middleware.NewMemoryRateLimitStore()
+
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, _ = store.Hit("ratelimit:login:203.0.113.5", time.Minute)
+			}()
+		}
+		wg.Wait()
+
+		count, resetIn, err := store.Hit("ratelimit:login:203.0.113.5", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 51, count)
+		assert.LessOrEqual(t, resetIn, time.Minute)
+	})
+
+	t.Run("should start a new window once the last one ended", func(t *testing.T) {
+		store := middleware.NewMemoryRateLimitStore()
+
+		_, _, err := store.Hit("ratelimit:login:203.0.113.5", time.Millisecond)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+
+		count, _, err := store.Hit("ratelimit:login:203.0.113.5", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
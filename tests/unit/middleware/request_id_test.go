@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		assert.Equal(t, utils.RequestID(c), utils.RequestIDFromContext(c.UserContext()))
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Order not found")
+	})
+
+	send := func(requestID string) (string, utils.Response) {
+		req := httptest.NewRequest("GET", "/orders/1", nil)
+		if requestID != "" {
+			req.Header.Set(utils.RequestIDHeader, requestID)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		var body utils.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.Header.Get(utils.RequestIDHeader), body
+	}
+
+	t.Run("success - keeps the incoming request ID", func(t *testing.T) {
+		header, body := send("trace-abc-123")
+
+		assert.Equal(t, "trace-abc-123", header)
+		assert.Equal(t, "trace-abc-123", body.RequestID)
+	})
+
+	t.Run("success - generates a request ID when none is sent", func(t *testing.T) {
+		header, body := send("")
+
+		_, err := uuid.Parse(header)
+		assert.NoError(t, err)
+		assert.Equal(t, header, body.RequestID)
+	})
+
+	t.Run("success - replaces an unusable request ID", func(t *testing.T) {
+		for _, requestID := range []string{"has spaces", strings.Repeat("a", 129)} {
+			header, body := send(requestID)
+
+			assert.NotEqual(t, requestID, header)
+			_, err := uuid.Parse(header)
+			assert.NoError(t, err)
+			assert.Equal(t, header, body.RequestID)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&out, "info")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Use(middleware.RequestLogMiddleware(logger))
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "down")
+	})
+
+	send := func(path string) map[string]any {
+		out.Reset()
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set(utils.RequestIDHeader, "req-42")
+		_, err := app.Test(req)
+		require.NoError(t, err)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+		return line
+	}
+
+	t.Run("success - logs the request with its ID", func(t *testing.T) {
+		line := send("/orders")
+
+		assert.Equal(t, "INFO", line["level"])
+		assert.Equal(t, "/orders", line["path"])
+		assert.Equal(t, float64(fiber.StatusOK), line["status"])
+		assert.Equal(t, "req-42", line[logging.KeyRequestID])
+	})
+
+	t.Run("success - logs server errors at error level", func(t *testing.T) {
+		line := send("/fail")
+
+		assert.Equal(t, "ERROR", line["level"])
+		assert.Equal(t, float64(fiber.StatusServiceUnavailable), line["status"])
+	})
+}
@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageMiddleware(t *testing.T) {
+	newApp := func(collector *metrics.UsageCollector) *fiber.App {
+		app := fiber.New()
+		app.Use(middleware.UsageMiddleware(collector))
+		app.Get("/orders/:id", func(c *fiber.Ctx) error {
+			return c.SendString("order")
+		})
+		app.Get("/broken", func(c *fiber.Ctx) error {
+			return fiber.NewError(fiber.StatusServiceUnavailable, "down")
+		})
+		return app
+	}
+
+	t.Run("should record the route pattern and client", func(t *testing.T) {
+		collector := metrics.NewUsageCollector(logging.Discard())
+		req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+		req.Header.Set(middleware.ClientNameHeader, "kiosk")
+
+		_, err := newApp(collector).Test(req)
+		require.NoError(t, err)
+
+		counts := collector.Drain()
+		require.Len(t, counts, 1)
+		for key, count := range counts {
+			assert.Equal(t, "kiosk", key.Client)
+			assert.Equal(t, "GET", key.Method)
+			assert.Equal(t, "/orders/:id", key.Route)
+			assert.Equal(t, int64(1), count.Requests)
+		}
+	})
+
+	t.Run("should count handler errors by their status", func(t *testing.T) {
+		collector := metrics.NewUsageCollector(logging.Discard())
+
+		_, err := newApp(collector).Test(httptest.NewRequest("GET", "/broken", nil))
+		require.NoError(t, err)
+
+		for _, count := range collector.Drain() {
+			assert.Equal(t, int64(1), count.ServerErrors)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *realtime.Subscription) []byte {
+	t.Helper()
+	select {
+	case payload := <-sub.C:
+		return payload
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestLocalBroker(t *testing.T) {
+	t.Run("should deliver JSON events to every subscriber of the topic", func(t *testing.T) {
+		broker := realtime.NewLocalBroker()
+		first := broker.Subscribe("orders")
+		second := broker.Subscribe("orders")
+		defer first.Close()
+		defer second.Close()
+
+		err := broker.Publish(context.Background(), "orders", map[string]string{"status": "ready"})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status":"ready"}`, string(receive(t, first)))
+		assert.JSONEq(t, `{"status":"ready"}`, string(receive(t, second)))
+	})
+
+	t.Run("should not deliver events from other topics", func(t *testing.T) {
+		broker := realtime.NewLocalBroker()
+		sub := broker.Subscribe("orders:a")
+		defer sub.Close()
+
+		require.NoError(t, broker.Publish(context.Background(), "orders:b", "ignored"))
+		require.NoError(t, broker.Publish(context.Background(), "orders:a", "wanted"))
+
+		assert.Equal(t, `"wanted"`, string(receive(t, sub)))
+	})
+
+	t.Run("should close the channel when the subscription closes", func(t *testing.T) {
+		broker := realtime.NewLocalBroker()
+		sub := broker.Subscribe("orders")
+
+		sub.Close()
+		sub.Close()
+
+		_, open := <-sub.C
+		assert.False(t, open)
+		assert.NoError(t, broker.Publish(context.Background(), "orders", "after close"))
+	})
+
+	t.Run("should drop events for a subscriber that falls behind", func(t *testing.T) {
+		broker := realtime.NewLocalBroker()
+		sub := broker.Subscribe("orders")
+		defer sub.Close()
+
+		for i := range 100 {
+			require.NoError(t, broker.Publish(context.Background(), "orders", i))
+		}
+
+		assert.Equal(t, "0", string(receive(t, sub)))
+		assert.Less(t, len(sub.C), 100)
+	})
+}
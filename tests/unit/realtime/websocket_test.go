@@ -0,0 +1,82 @@
+package realtime_test
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maskedFrame builds a client frame, which RFC 6455 requires to be masked
+func maskedFrame(opcode byte, payload []byte) []byte {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+func TestAcceptKey(t *testing.T) {
+	// Example handshake from RFC 6455 section 1.3
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", realtime.AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestWSConn(t *testing.T) {
+	t.Run("should write unmasked text frames", func(t *testing.T) {
+		server, client := net.Pipe()
+		defer client.Close()
+		conn := realtime.NewWSConn(server)
+
+		go func() { _ = conn.WriteText([]byte(`{"status":"ready"}`)) }()
+
+		frame := make([]byte, 2+18)
+		_, err := io.ReadFull(client, frame)
+
+		require.NoError(t, err)
+		assert.Equal(t, byte(0x81), frame[0])
+		assert.Equal(t, byte(18), frame[1])
+		assert.Equal(t, `{"status":"ready"}`, string(frame[2:]))
+	})
+
+	t.Run("should answer pings and stop on close", func(t *testing.T) {
+		server, client := net.Pipe()
+		defer client.Close()
+		conn := realtime.NewWSConn(server)
+
+		done := make(chan error, 1)
+		go func() { done <- conn.ReadUntilClosed() }()
+
+		_, err := client.Write(maskedFrame(0x9, []byte("hi")))
+		require.NoError(t, err)
+
+		pong := make([]byte, 4)
+		_, err = io.ReadFull(client, pong)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x8A, 2, 'h', 'i'}, pong)
+
+		_, err = client.Write(maskedFrame(0x8, []byte{0x03, 0xE8}))
+		require.NoError(t, err)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("should skip client data frames", func(t *testing.T) {
+		server, client := net.Pipe()
+		defer client.Close()
+		conn := realtime.NewWSConn(server)
+
+		done := make(chan error, 1)
+		go func() { done <- conn.ReadUntilClosed() }()
+
+		_, err := client.Write(maskedFrame(0x1, []byte("ignored")))
+		require.NoError(t, err)
+		_, err = client.Write(maskedFrame(0x8, nil))
+		require.NoError(t, err)
+
+		assert.NoError(t, <-done)
+	})
+}
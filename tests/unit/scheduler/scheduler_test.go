@@ -0,0 +1,184 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/logging"
+	"github.com/carllix/matchaciee-backend/internal/scheduler"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Trigger(t *testing.T) {
+	t.Run("should run the job when the lease is acquired", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		runs := 0
+		s.Every("cleanup", time.Hour, func(ctx context.Context) error {
+			runs++
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", time.Hour).Return(true, nil)
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 1, runs)
+		locks.AssertExpectations(t)
+	})
+
+	t.Run("should skip the job when another instance holds the lease", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-b", logging.Discard())
+		runs := 0
+		s.Every("cleanup", time.Hour, func(ctx context.Context) error {
+			runs++
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-b", time.Hour).Return(false, nil)
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		require.NoError(t, err)
+		assert.False(t, ran)
+		assert.Zero(t, runs)
+		locks.AssertExpectations(t)
+	})
+
+	t.Run("should return job error", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		jobErr := errors.New("database unavailable")
+		s.Every("cleanup", time.Hour, func(ctx context.Context) error {
+			return jobErr
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", time.Hour).Return(true, nil)
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		assert.True(t, ran)
+		assert.ErrorIs(t, err, jobErr)
+	})
+
+	t.Run("should return error when lease cannot be checked", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		s.Every("cleanup", time.Hour, func(ctx context.Context) error {
+			t.Fatal("job must not run")
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", time.Hour).Return(false, errors.New("connection refused"))
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		assert.False(t, ran)
+		assert.Error(t, err)
+	})
+
+	t.Run("should skip a run while the job is still running", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		started := make(chan struct{})
+		finish := make(chan struct{})
+		s.Every("cleanup", time.Hour, func(ctx context.Context) error {
+			close(started)
+			<-finish
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", time.Hour).Return(true, nil)
+
+		first := make(chan bool)
+		go func() {
+			ran, _ := s.Trigger(context.Background(), "cleanup")
+			first <- ran
+		}()
+		<-started
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+		close(finish)
+
+		require.NoError(t, err)
+		assert.False(t, ran)
+		assert.True(t, <-first)
+		locks.AssertNumberOfCalls(t, "Acquire", 1)
+	})
+
+	t.Run("should renew the lease while a run outlasts its interval", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		s.Every("cleanup", 20*time.Millisecond, func(ctx context.Context) error {
+			time.Sleep(75 * time.Millisecond)
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", 20*time.Millisecond).Return(true, nil)
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.GreaterOrEqual(t, len(locks.Calls), 4)
+	})
+
+	t.Run("should cancel the run when the lease is lost", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		s.Every("cleanup", 20*time.Millisecond, func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", 20*time.Millisecond).Return(true, nil).Once()
+		locks.On("Acquire", "cleanup", "instance-a", 20*time.Millisecond).Return(false, nil)
+
+		ran, err := s.Trigger(context.Background(), "cleanup")
+
+		assert.True(t, ran)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should return error for unknown job", func(t *testing.T) {
+		s := scheduler.NewScheduler(new(mocks.MockJobLockRepository), "instance-a", logging.Discard())
+
+		_, err := s.Trigger(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, scheduler.ErrJobNotFound)
+	})
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	t.Run("should run jobs on their interval and release leases on stop", func(t *testing.T) {
+		locks := new(mocks.MockJobLockRepository)
+		s := scheduler.NewScheduler(locks, "instance-a", logging.Discard())
+		var runs atomic.Int32
+		s.Every("cleanup", 10*time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+
+		locks.On("Acquire", "cleanup", "instance-a", 10*time.Millisecond).Return(true, nil)
+		locks.On("Release", "cleanup", "instance-a").Return(nil).Once()
+
+		s.Start()
+		assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		s.Stop()
+
+		locks.AssertCalled(t, "Release", "cleanup", "instance-a")
+		locks.AssertNumberOfCalls(t, "Release", 1)
+	})
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAlerts has nowhere to send alerts, so they are dropped
+var testAlerts = services.NewAlertService(new(mocks.MockMailer), nil, services.AlertConfig{SignatureFailures: 5, SignatureWindow: time.Minute})
+
+func TestAlertService_PaymentFailed(t *testing.T) {
+	t.Run("success - emails every admin and posts to slack", func(t *testing.T) {
+		mailer := new(mocks.MockMailer)
+		slack := new(mocks.MockSlackSender)
+		service := services.NewAlertService(mailer, slack, services.AlertConfig{
+			Emails:            []string{"owner@matchaciee.com", "ops@matchaciee.com"},
+			SignatureFailures: 5,
+			SignatureWindow:   time.Minute,
+		})
+
+		mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return strings.Contains(msg.Subject, "MIDTRANS-MC-250107-001") && strings.Contains(msg.TextBody, "deny")
+		})).Return(nil).Twice()
+		slack.On("Send", mock.MatchedBy(func(text string) bool {
+			return strings.Contains(text, "MIDTRANS-MC-250107-001")
+		})).Return(nil)
+
+		err := service.PaymentFailed("MIDTRANS-MC-250107-001", models.TransactionStatusDeny)
+
+		assert.NoError(t, err)
+		mailer.AssertExpectations(t)
+		slack.AssertExpectations(t)
+	})
+
+	t.Run("error - a failing channel does not stop the others", func(t *testing.T) {
+		mailer := new(mocks.MockMailer)
+		slack := new(mocks.MockSlackSender)
+		service := services.NewAlertService(mailer, slack, services.AlertConfig{
+			Emails:            []string{"owner@matchaciee.com"},
+			SignatureFailures: 5,
+			SignatureWindow:   time.Minute,
+		})
+
+		mailer.On("Send", mock.Anything).Return(errors.New("smtp down"))
+		slack.On("Send", mock.Anything).Return(nil)
+
+		err := service.PaymentFailed("MIDTRANS-MC-250107-001", models.TransactionStatusExpire)
+
+		assert.ErrorContains(t, err, "smtp down")
+		slack.AssertExpectations(t)
+	})
+}
+
+func TestAlertService_SignatureFailed(t *testing.T) {
+	t.Run("success - alerts once the threshold is reached and starts over", func(t *testing.T) {
+		slack := new(mocks.MockSlackSender)
+		service := services.NewAlertService(new(mocks.MockMailer), slack, services.AlertConfig{
+			SignatureFailures: 3,
+			SignatureWindow:   time.Minute,
+		})
+
+		slack.On("Send", mock.MatchedBy(func(text string) bool {
+			return strings.Contains(text, "midtrans")
+		})).Return(nil).Once()
+
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, service.SignatureFailed(models.PaymentMethodMidtrans))
+		}
+
+		slack.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("success - providers are counted apart", func(t *testing.T) {
+		slack := new(mocks.MockSlackSender)
+		service := services.NewAlertService(new(mocks.MockMailer), slack, services.AlertConfig{
+			SignatureFailures: 2,
+			SignatureWindow:   time.Minute,
+		})
+
+		assert.NoError(t, service.SignatureFailed(models.PaymentMethodMidtrans))
+		assert.NoError(t, service.SignatureFailed(models.PaymentMethodXendit))
+
+		slack.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestAlertService_AmountMismatch(t *testing.T) {
+	mailer := new(mocks.MockMailer)
+	service := services.NewAlertService(mailer, nil, services.AlertConfig{
+		Emails:            []string{"owner@matchaciee.com"},
+		SignatureFailures: 5,
+		SignatureWindow:   time.Minute,
+	})
+
+	mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+		return msg.To == "owner@matchaciee.com" && strings.Contains(msg.TextBody, "42500.00")
+	})).Return(nil)
+
+	err := service.AmountMismatch("MIDTRANS-MC-250107-001", 42500)
+
+	assert.NoError(t, err)
+	mailer.AssertExpectations(t)
+}
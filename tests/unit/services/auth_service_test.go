@@ -564,3 +564,85 @@ func TestGetUserByUUID(t *testing.T) {
 		mockRefreshTokenRepo.AssertNotCalled(t, "Create")
 	})
 }
+
+func TestUpdateProfile(t *testing.T) {
+	t.Run("should update locale and issue a token carrying it", func(t *testing.T) {
+		mockUserRepo, _, jwtUtil, authService := setupAuthServiceTest()
+
+		userUUID := uuid.New()
+		existingUser := &models.User{
+			ID:       1,
+			UUID:     userUUID,
+			Email:    "test@example.com",
+			FullName: "Test User",
+			Role:     models.RoleMember,
+			IsActive: true,
+		}
+		locale := "id"
+
+		mockUserRepo.On("FindByUUID", userUUID).Return(existingUser, nil)
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return user.Locale != nil && *user.Locale == "id"
+		})).Return(nil)
+
+		resp, err := authService.UpdateProfile(userUUID, services.UpdateProfileRequest{Locale: &locale})
+
+		require.NoError(t, err)
+		assert.Equal(t, "id", *resp.User.Locale)
+		assert.Equal(t, "Test User", resp.User.FullName)
+
+		claims, err := jwtUtil.ValidateToken(resp.Token)
+		require.NoError(t, err)
+		assert.Equal(t, "id", claims.Locale)
+
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("should clear locale and phone", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupAuthServiceTest()
+
+		userUUID := uuid.New()
+		locale := "en"
+		phone := "+6281234567890"
+		existingUser := &models.User{
+			ID:       1,
+			UUID:     userUUID,
+			Email:    "test@example.com",
+			FullName: "Test User",
+			Role:     models.RoleMember,
+			Phone:    &phone,
+			Locale:   &locale,
+			IsActive: true,
+		}
+		emptyPhone := ""
+
+		mockUserRepo.On("FindByUUID", userUUID).Return(existingUser, nil)
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return user.Locale == nil && user.Phone == nil
+		})).Return(nil)
+
+		resp, err := authService.UpdateProfile(userUUID, services.UpdateProfileRequest{
+			Phone:       &emptyPhone,
+			ClearLocale: true,
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.User.Locale)
+		assert.Nil(t, resp.User.Phone)
+
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when user not found", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupAuthServiceTest()
+
+		userUUID := uuid.New()
+
+		mockUserRepo.On("FindByUUID", userUUID).Return(nil, repositories.ErrUserNotFound)
+
+		resp, err := authService.UpdateProfile(userUUID, services.UpdateProfileRequest{})
+
+		assert.ErrorIs(t, err, repositories.ErrUserNotFound)
+		assert.Nil(t, resp)
+	})
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCampaignService_Create(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), FullName: "Admin", Role: models.RoleAdmin}
+
+	winBack := func() services.CreateCampaignRequest {
+		return services.CreateCampaignRequest{
+			Title:        "We miss you",
+			Message:      "Your favourite matcha latte is waiting.",
+			Segment:      repositories.MemberSegmentLapsed,
+			InactiveDays: 30,
+		}
+	}
+
+	t.Run("success - sends right away without a send time", func(t *testing.T) {
+		campaignRepo := new(mocks.MockCampaignRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewCampaignService(campaignRepo, userRepo, new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		var saved *models.Campaign
+		campaignRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.Campaign)
+		}).Return(nil)
+
+		campaign, err := service.Create(admin.UUID, winBack())
+
+		require.NoError(t, err)
+		assert.Equal(t, models.CampaignStatusScheduled, saved.Status)
+		assert.Equal(t, 30, saved.InactiveDays)
+		assert.Equal(t, admin.ID, *saved.CreatedBy)
+		assert.WithinDuration(t, time.Now(), saved.SendAt, time.Second)
+		assert.Equal(t, "Admin", *campaign.CreatedBy)
+	})
+
+	t.Run("success - inactive days only apply to lapsed members", func(t *testing.T) {
+		campaignRepo := new(mocks.MockCampaignRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewCampaignService(campaignRepo, userRepo, new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		campaignRepo.On("Create", mock.Anything).Return(nil)
+
+		req := winBack()
+		req.Segment = repositories.MemberSegmentAll
+		sendAt := time.Now().Add(24 * time.Hour)
+		req.SendAt = &sendAt
+		campaign, err := service.Create(admin.UUID, req)
+
+		require.NoError(t, err)
+		assert.Zero(t, campaign.InactiveDays)
+		assert.Equal(t, sendAt.Format("2006-01-02T15:04:05Z07:00"), campaign.SendAt)
+	})
+
+	t.Run("error - send time in the past", func(t *testing.T) {
+		service := services.NewCampaignService(new(mocks.MockCampaignRepository), new(mocks.MockUserRepository), new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+		req := winBack()
+		sendAt := time.Now().Add(-time.Hour)
+		req.SendAt = &sendAt
+		_, err := service.Create(admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrCampaignSendAtInPast)
+	})
+}
+
+func TestCampaignService_RunDeliveries(t *testing.T) {
+	phone := "+628123456789"
+	whatsAppVia := models.NotifyViaWhatsApp
+	campaign := &models.Campaign{ID: 7, UUID: uuid.New(), Title: "We miss you", Message: "Drop by this week!", Segment: "lapsed", InactiveDays: 30}
+
+	t.Run("success - starts due campaigns with the segment picked at send time", func(t *testing.T) {
+		campaignRepo := new(mocks.MockCampaignRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewCampaignService(campaignRepo, userRepo, new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+		campaignRepo.On("FindDue", mock.Anything).Return([]models.Campaign{*campaign}, nil)
+		userRepo.On("FindMemberIDs", repositories.MemberSegmentLapsed, mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 29*24*time.Hour && time.Since(since) < 31*24*time.Hour
+		})).Return([]uint{3, 4}, nil)
+		campaignRepo.On("Start", campaign.ID, []uint{3, 4}).Return(true, nil)
+		campaignRepo.On("ClaimPendingDeliveries", 100, mock.Anything).Return([]models.CampaignDelivery{}, nil)
+		campaignRepo.On("FinishSending").Return(int64(0), nil)
+
+		sent, err := service.RunDeliveries()
+
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		campaignRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - sends a batch on each member's preferred channel", func(t *testing.T) {
+		campaignRepo := new(mocks.MockCampaignRepository)
+		mailer := new(mocks.MockMailer)
+		whatsApp := new(mocks.MockWhatsAppSender)
+		service := services.NewCampaignService(campaignRepo, new(mocks.MockUserRepository), mailer, whatsApp, 2)
+
+		deliveries := []models.CampaignDelivery{
+			{ID: 1, Campaign: campaign, User: &models.User{ID: 3, Email: "a@example.com", IsActive: true}},
+			{ID: 2, Campaign: campaign, User: &models.User{ID: 4, Email: "b@example.com", Phone: &phone, NotifyVia: &whatsAppVia, IsActive: true}},
+		}
+		campaignRepo.On("FindDue", mock.Anything).Return([]models.Campaign{}, nil)
+		campaignRepo.On("ClaimPendingDeliveries", 2, mock.Anything).Return(deliveries, nil)
+		mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return msg.To == "a@example.com" && msg.Subject == "We miss you"
+		})).Return(nil)
+		whatsApp.On("Send", phone, "*We miss you*\nDrop by this week!").Return(nil)
+		campaignRepo.On("MarkDelivery", uint(1), models.CampaignDeliverySent, models.NotifyViaEmail, (*string)(nil)).Return(nil)
+		campaignRepo.On("MarkDelivery", uint(2), models.CampaignDeliverySent, models.NotifyViaWhatsApp, (*string)(nil)).Return(nil)
+		campaignRepo.On("FinishSending").Return(int64(1), nil)
+
+		sent, err := service.RunDeliveries()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		campaignRepo.AssertExpectations(t)
+		whatsApp.AssertExpectations(t)
+	})
+
+	t.Run("success - failed sends are recorded and do not stop the batch", func(t *testing.T) {
+		campaignRepo := new(mocks.MockCampaignRepository)
+		mailer := new(mocks.MockMailer)
+		service := services.NewCampaignService(campaignRepo, new(mocks.MockUserRepository), mailer, new(mocks.MockWhatsAppSender), 100)
+
+		deliveries := []models.CampaignDelivery{
+			{ID: 1, Campaign: campaign, User: &models.User{ID: 3, Email: "a@example.com", IsActive: true}},
+			{ID: 2, Campaign: campaign, User: &models.User{ID: 4, Email: "b@example.com", NotifyVia: &whatsAppVia, IsActive: true}},
+		}
+		campaignRepo.On("FindDue", mock.Anything).Return([]models.Campaign{}, nil)
+		campaignRepo.On("ClaimPendingDeliveries", 100, mock.Anything).Return(deliveries, nil)
+		mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool { return msg.To == "a@example.com" })).Return(errors.New("mailbox full"))
+		mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool { return msg.To == "b@example.com" })).Return(nil)
+		campaignRepo.On("MarkDelivery", uint(1), models.CampaignDeliveryFailed, models.NotifyViaEmail, mock.MatchedBy(func(reason *string) bool {
+			return reason != nil && *reason == "mailbox full"
+		})).Return(nil)
+		campaignRepo.On("MarkDelivery", uint(2), models.CampaignDeliverySent, models.NotifyViaEmail, (*string)(nil)).Return(nil)
+		campaignRepo.On("FinishSending").Return(int64(0), nil)
+
+		sent, err := service.RunDeliveries()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		campaignRepo.AssertExpectations(t)
+	})
+}
+
+func TestCampaignService_GetByUUID(t *testing.T) {
+	campaign := &models.Campaign{ID: 7, UUID: uuid.New(), Title: "We miss you", Status: models.CampaignStatusSending}
+	email := models.NotifyViaEmail
+	whatsApp := models.NotifyViaWhatsApp
+
+	campaignRepo := new(mocks.MockCampaignRepository)
+	service := services.NewCampaignService(campaignRepo, new(mocks.MockUserRepository), new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+	campaignRepo.On("FindByUUID", campaign.UUID).Return(campaign, nil)
+	campaignRepo.On("CountDeliveries", campaign.ID).Return([]repositories.CampaignDeliveryCount{
+		{Status: models.CampaignDeliveryPending, Count: 20},
+		{Status: models.CampaignDeliverySent, Channel: &email, Count: 70},
+		{Status: models.CampaignDeliverySent, Channel: &whatsApp, Count: 8},
+		{Status: models.CampaignDeliveryFailed, Channel: &email, Count: 2},
+	}, nil)
+
+	response, err := service.GetByUUID(campaign.UUID)
+
+	require.NoError(t, err)
+	require.NotNil(t, response.Stats)
+	assert.Equal(t, int64(100), response.Stats.Recipients)
+	assert.Equal(t, int64(20), response.Stats.Pending)
+	assert.Equal(t, int64(78), response.Stats.Sent)
+	assert.Equal(t, int64(2), response.Stats.Failed)
+	assert.Equal(t, map[string]int64{"email": 70, "whatsapp": 8}, response.Stats.Channels)
+}
+
+func TestCampaignService_Cancel(t *testing.T) {
+	campaign := &models.Campaign{ID: 7, UUID: uuid.New(), Status: models.CampaignStatusSent}
+
+	campaignRepo := new(mocks.MockCampaignRepository)
+	service := services.NewCampaignService(campaignRepo, new(mocks.MockUserRepository), new(mocks.MockMailer), new(mocks.MockWhatsAppSender), 100)
+
+	campaignRepo.On("FindByUUID", campaign.UUID).Return(campaign, nil)
+	campaignRepo.On("Cancel", campaign.ID).Return(false, nil)
+
+	_, err := service.Cancel(campaign.UUID)
+
+	assert.ErrorIs(t, err, services.ErrCampaignNotCancellable)
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type cartTestDeps struct {
+	cartRepo    *mocks.MockCartRepository
+	productRepo *mocks.MockProductRepository
+	userRepo    *mocks.MockUserRepository
+	orderRepo   *mocks.MockOrderRepository
+	pricingRepo *mocks.MockSourcePricingRepository
+	service     services.CartService
+}
+
+func newCartTestDeps() cartTestDeps {
+	deps := cartTestDeps{
+		cartRepo:    new(mocks.MockCartRepository),
+		productRepo: new(mocks.MockProductRepository),
+		userRepo:    new(mocks.MockUserRepository),
+		orderRepo:   new(mocks.MockOrderRepository),
+		pricingRepo: new(mocks.MockSourcePricingRepository),
+	}
+	deps.pricingRepo.On("FindBySource", mock.Anything).Return(nil, repositories.ErrSourcePricingNotFound).Maybe()
+	orderService := services.NewOrderService(deps.orderRepo, deps.productRepo, deps.userRepo, new(mocks.MockStockReservationRepository), deps.pricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+	deps.service = services.NewCartService(deps.cartRepo, deps.productRepo, deps.userRepo, orderService, testLogger)
+	return deps
+}
+
+func TestCartService_GetCart(t *testing.T) {
+	t.Run("success - no cart yet", func(t *testing.T) {
+		deps := newCartTestDeps()
+		owner := services.CartOwner{SessionID: "kiosk-1"}
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(nil, repositories.ErrCartNotFound)
+
+		result, err := deps.service.GetCart(owner)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.ID)
+		assert.Empty(t, result.Items)
+		assert.Nil(t, result.Preview)
+	})
+
+	t.Run("success - cart with price preview", func(t *testing.T) {
+		deps := newCartTestDeps()
+		userUUID := uuid.New()
+		owner := services.CartOwner{UserUUID: &userUUID}
+		product := &models.Product{ID: 1, UUID: uuid.New(), Name: "Matcha Latte", BasePrice: 45000, IsAvailable: true}
+
+		deps.userRepo.On("FindByUUID", userUUID).Return(&models.User{ID: 7, UUID: userUUID}, nil)
+		deps.cartRepo.On("FindByUserID", uint(7)).Return(&models.Cart{
+			ID:   3,
+			UUID: uuid.New(),
+			Items: []models.CartItem{
+				{ID: 1, UUID: uuid.New(), CartID: 3, ProductID: 1, Quantity: 2, Product: product},
+			},
+		}, nil)
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+
+		result, err := deps.service.GetCart(owner)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, "Matcha Latte", result.Items[0].ProductName)
+		assert.NotNil(t, result.Preview)
+		assert.Equal(t, 90000.0, result.Preview.Subtotal)
+		assert.Equal(t, 99000.0, result.Preview.Total)
+		assert.Empty(t, result.PreviewError)
+	})
+
+	t.Run("success - preview error when product became unavailable", func(t *testing.T) {
+		deps := newCartTestDeps()
+		product := &models.Product{ID: 1, UUID: uuid.New(), Name: "Hojicha Latte", BasePrice: 40000, IsAvailable: false}
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, ProductID: 1, Quantity: 1, Product: product}},
+		}, nil)
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+
+		result, err := deps.service.GetCart(services.CartOwner{SessionID: "kiosk-1"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		assert.Nil(t, result.Preview)
+		assert.NotEmpty(t, result.PreviewError)
+	})
+}
+
+func TestCartService_AddItem(t *testing.T) {
+	t.Run("success - creates cart on first item", func(t *testing.T) {
+		deps := newCartTestDeps()
+		owner := services.CartOwner{SessionID: "kiosk-1"}
+		product := &models.Product{ID: 1, UUID: uuid.New(), Name: "Matcha Latte", BasePrice: 45000, IsAvailable: true}
+
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(nil, repositories.ErrCartNotFound).Once()
+		deps.cartRepo.On("Create", mock.MatchedBy(func(cart *models.Cart) bool {
+			return cart.SessionID != nil && *cart.SessionID == "kiosk-1" && cart.UserID == nil
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Cart).ID = 3
+		}).Return(nil)
+		deps.cartRepo.On("AddItem", mock.MatchedBy(func(item *models.CartItem) bool {
+			return item.CartID == 3 && item.ProductID == 1 && item.Quantity == 2
+		})).Return(nil)
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, ProductID: 1, Quantity: 2, Product: product}},
+		}, nil)
+
+		result, err := deps.service.AddItem(owner, services.CreateOrderItemRequest{ProductID: product.UUID, Quantity: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		deps.cartRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - merges with matching line", func(t *testing.T) {
+		deps := newCartTestDeps()
+		owner := services.CartOwner{SessionID: "kiosk-1"}
+		product := &models.Product{ID: 1, UUID: uuid.New(), Name: "Matcha Latte", BasePrice: 45000, IsAvailable: true}
+		cart := &models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, ProductID: 1, Quantity: 99, Product: product}},
+		}
+
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(cart, nil)
+		deps.cartRepo.On("UpdateItem", mock.MatchedBy(func(item *models.CartItem) bool {
+			return item.ID == 1 && item.Quantity == 100
+		})).Return(nil)
+
+		_, err := deps.service.AddItem(owner, services.CreateOrderItemRequest{ProductID: product.UUID, Quantity: 5})
+
+		assert.NoError(t, err)
+		deps.cartRepo.AssertNotCalled(t, "AddItem", mock.Anything)
+		deps.cartRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - product not available", func(t *testing.T) {
+		deps := newCartTestDeps()
+		product := &models.Product{ID: 1, UUID: uuid.New(), IsAvailable: false}
+
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+
+		result, err := deps.service.AddItem(services.CartOwner{SessionID: "kiosk-1"}, services.CreateOrderItemRequest{ProductID: product.UUID, Quantity: 1})
+
+		assert.ErrorIs(t, err, services.ErrProductNotAvailable)
+		assert.Nil(t, result)
+		deps.cartRepo.AssertNotCalled(t, "FindBySessionID", mock.Anything)
+	})
+
+	t.Run("error - customization of another product", func(t *testing.T) {
+		deps := newCartTestDeps()
+		product := &models.Product{ID: 1, UUID: uuid.New(), IsAvailable: true, IsCustomizable: true}
+		customizationUUID := uuid.New()
+
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+		deps.productRepo.On("FindCustomizationByUUID", customizationUUID).Return(&models.ProductCustomization{ID: 5, ProductID: 2}, nil)
+
+		result, err := deps.service.AddItem(services.CartOwner{SessionID: "kiosk-1"}, services.CreateOrderItemRequest{
+			ProductID: product.UUID,
+			Quantity:  1,
+			Customizations: []services.OrderItemCustomization{
+				{CustomizationID: customizationUUID, OptionName: "Oat"},
+			},
+		})
+
+		assert.ErrorIs(t, err, services.ErrInvalidCustomization)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCartService_UpdateItem(t *testing.T) {
+	t.Run("error - item not in cart", func(t *testing.T) {
+		deps := newCartTestDeps()
+		itemUUID := uuid.New()
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{ID: 3}, nil)
+		deps.cartRepo.On("FindItemByUUID", uint(3), itemUUID).Return(nil, repositories.ErrCartItemNotFound)
+
+		result, err := deps.service.UpdateItem(services.CartOwner{SessionID: "kiosk-1"}, itemUUID, services.UpdateCartItemRequest{Quantity: 2})
+
+		assert.ErrorIs(t, err, services.ErrCartItemNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCartService_Checkout(t *testing.T) {
+	t.Run("error - empty cart", func(t *testing.T) {
+		deps := newCartTestDeps()
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{ID: 3}, nil)
+
+		result, err := deps.service.Checkout(services.CartOwner{SessionID: "kiosk-1"}, services.CheckoutCartRequest{CustomerName: "Walk In"})
+
+		assert.ErrorIs(t, err, services.ErrCartEmpty)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - kiosk checkout without customer name", func(t *testing.T) {
+		deps := newCartTestDeps()
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, Quantity: 1, Product: &models.Product{UUID: uuid.New()}}},
+		}, nil)
+
+		result, err := deps.service.Checkout(services.CartOwner{SessionID: "kiosk-1"}, services.CheckoutCartRequest{})
+
+		assert.ErrorIs(t, err, services.ErrCustomerNameRequired)
+		assert.Nil(t, result)
+		deps.cartRepo.AssertNotCalled(t, "BeginCheckout", mock.Anything)
+	})
+
+	t.Run("error - checkout already in progress", func(t *testing.T) {
+		deps := newCartTestDeps()
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, Quantity: 1, Product: &models.Product{UUID: uuid.New()}}},
+		}, nil)
+		deps.cartRepo.On("BeginCheckout", uint(3)).Return(repositories.ErrCartCheckoutInProgress)
+
+		result, err := deps.service.Checkout(services.CartOwner{SessionID: "kiosk-1"}, services.CheckoutCartRequest{CustomerName: "Walk In"})
+
+		assert.ErrorIs(t, err, services.ErrCartCheckoutInProgress)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - failed order unlocks cart", func(t *testing.T) {
+		deps := newCartTestDeps()
+		product := &models.Product{ID: 1, UUID: uuid.New(), Name: "Hojicha Latte", IsAvailable: false}
+
+		deps.cartRepo.On("FindBySessionID", "kiosk-1").Return(&models.Cart{
+			ID:    3,
+			Items: []models.CartItem{{ID: 1, CartID: 3, ProductID: 1, Quantity: 1, Product: product}},
+		}, nil)
+		deps.cartRepo.On("BeginCheckout", uint(3)).Return(nil)
+		deps.productRepo.On("FindByUUID", product.UUID).Return(product, nil)
+		deps.cartRepo.On("CancelCheckout", uint(3)).Return(nil)
+
+		result, err := deps.service.Checkout(services.CartOwner{SessionID: "kiosk-1"}, services.CheckoutCartRequest{CustomerName: "Walk In"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		deps.cartRepo.AssertExpectations(t)
+		deps.cartRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
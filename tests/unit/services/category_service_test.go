@@ -365,9 +365,9 @@ func TestCategoryService_Delete(t *testing.T) {
 		}
 
 		mockRepo.On("FindByUUID", categoryUUID).Return(category, nil)
-		mockRepo.On("Delete", uint(1)).Return(nil)
+		mockRepo.On("SoftDelete", uint(1), (*uint)(nil)).Return(nil)
 
-		err := service.Delete(categoryUUID)
+		err := service.Delete(categoryUUID, nil)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -380,7 +380,7 @@ func TestCategoryService_Delete(t *testing.T) {
 		categoryUUID := uuid.New()
 		mockRepo.On("FindByUUID", categoryUUID).Return(nil, repositories.ErrCategoryNotFound)
 
-		err := service.Delete(categoryUUID)
+		err := service.Delete(categoryUUID, nil)
 
 		assert.Error(t, err)
 		assert.Equal(t, services.ErrCategoryNotFound, err)
@@ -403,11 +403,274 @@ func TestCategoryService_Delete(t *testing.T) {
 		}
 
 		mockRepo.On("FindByUUID", categoryUUID).Return(category, nil)
-		mockRepo.On("Delete", uint(1)).Return(errors.New("database error"))
+		mockRepo.On("SoftDelete", uint(1), (*uint)(nil)).Return(errors.New("database error"))
 
-		err := service.Delete(categoryUUID)
+		err := service.Delete(categoryUUID, nil)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestCategoryService_DeleteWithReassignment(t *testing.T) {
+	t.Run("success - products moved to target category", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		targetUUID := uuid.New()
+
+		mockRepo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+		mockRepo.On("FindByUUID", targetUUID).Return(&models.Category{ID: 2, UUID: targetUUID}, nil)
+		mockRepo.On("SoftDelete", uint(1), mock.MatchedBy(func(id *uint) bool {
+			return id != nil && *id == 2
+		})).Return(nil)
+
+		err := service.Delete(categoryUUID, &targetUUID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - reassign to itself", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		mockRepo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+
+		err := service.Delete(categoryUUID, &categoryUUID)
+
+		assert.Equal(t, services.ErrInvalidReassignment, err)
+		mockRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - target category not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		targetUUID := uuid.New()
+
+		mockRepo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+		mockRepo.On("FindByUUID", targetUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		err := service.Delete(categoryUUID, &targetUUID)
+
+		assert.Equal(t, services.ErrInvalidReassignment, err)
+		mockRepo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCategoryService_Restore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		deletedAt := time.Now()
+
+		mockRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(&models.Category{
+			ID:        1,
+			UUID:      categoryUUID,
+			DeletedAt: &deletedAt,
+		}, nil)
+		mockRepo.On("Restore", uint(1)).Return(nil)
+
+		err := service.Restore(categoryUUID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - category not deleted", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		mockRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+
+		err := service.Restore(categoryUUID)
+
+		assert.Equal(t, services.ErrCategoryNotDeleted, err)
+		mockRepo.AssertNotCalled(t, "Restore", mock.Anything)
+	})
+
+	t.Run("error - category not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categoryUUID := uuid.New()
+		mockRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		err := service.Restore(categoryUUID)
+
+		assert.Equal(t, services.ErrCategoryNotFound, err)
+	})
+}
+
+func TestCategoryService_Nesting(t *testing.T) {
+	drinksUUID := uuid.New()
+	matchaUUID := uuid.New()
+	icedUUID := uuid.New()
+	drinksID, matchaID := uint(1), uint(2)
+
+	// Drinks > Matcha > Iced
+	tree := func() []models.Category {
+		drinks := models.Category{ID: 1, UUID: drinksUUID, Name: "Drinks", Slug: "drinks"}
+		matcha := models.Category{ID: 2, UUID: matchaUUID, Name: "Matcha", Slug: "matcha", ParentID: &drinksID, Parent: &drinks}
+		iced := models.Category{ID: 3, UUID: icedUUID, Name: "Iced", Slug: "iced", ParentID: &matchaID, Parent: &matcha}
+		return []models.Category{drinks, matcha, iced}
+	}
+
+	t.Run("success - create subcategory under parent", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categories := tree()
+		mockRepo.On("ExistsBySlug", "hot").Return(false, nil)
+		mockRepo.On("FindByUUID", matchaUUID).Return(&categories[1], nil)
+		mockRepo.On("FindAll", (*bool)(nil)).Return(categories, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*models.Category")).Return(nil)
+
+		result, err := service.Create(services.CreateCategoryRequest{Name: "Hot", ParentID: &matchaUUID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &matchaUUID, result.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - nesting too deep", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categories := tree()
+		mockRepo.On("ExistsBySlug", "extra-shot").Return(false, nil)
+		mockRepo.On("FindByUUID", icedUUID).Return(&categories[2], nil)
+		mockRepo.On("FindAll", (*bool)(nil)).Return(categories, nil)
+
+		result, err := service.Create(services.CreateCategoryRequest{Name: "Extra Shot", ParentID: &icedUUID})
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrCategoryTooDeep, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - parent not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		parentUUID := uuid.New()
+		mockRepo.On("ExistsBySlug", "orphan").Return(false, nil)
+		mockRepo.On("FindByUUID", parentUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		result, err := service.Create(services.CreateCategoryRequest{Name: "Orphan", ParentID: &parentUUID})
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrParentNotFound, err)
+	})
+
+	t.Run("error - moving a category under its descendant", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categories := tree()
+		mockRepo.On("FindByUUID", drinksUUID).Return(&categories[0], nil)
+		mockRepo.On("FindByUUID", icedUUID).Return(&categories[2], nil)
+		mockRepo.On("FindAll", (*bool)(nil)).Return(categories, nil)
+
+		result, err := service.Update(drinksUUID, services.UpdateCategoryRequest{ParentID: &icedUUID})
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrCategoryCycle, err)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("success - moving a subtree that still fits", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		categories := append(tree(), models.Category{ID: 4, UUID: uuid.New(), Name: "Seasonal", Slug: "seasonal"})
+		seasonal := categories[3]
+
+		mockRepo.On("FindByUUID", matchaUUID).Return(&categories[1], nil)
+		mockRepo.On("FindByUUID", seasonal.UUID).Return(&seasonal, nil)
+		mockRepo.On("FindAll", (*bool)(nil)).Return(categories, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*models.Category")).Return(nil)
+
+		// Seasonal > Matcha > Iced is three levels
+		result, err := service.Update(matchaUUID, services.UpdateCategoryRequest{ParentID: &seasonal.UUID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &seasonal.UUID, result.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - moving a subtree past the depth limit", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		seasonalID := uint(4)
+		categories := append(tree(),
+			models.Category{ID: 4, UUID: uuid.New(), Name: "Seasonal", Slug: "seasonal"},
+			models.Category{ID: 5, UUID: uuid.New(), Name: "Summer", Slug: "summer", ParentID: &seasonalID},
+		)
+		summer := categories[4]
+
+		mockRepo.On("FindByUUID", matchaUUID).Return(&categories[1], nil)
+		mockRepo.On("FindByUUID", summer.UUID).Return(&summer, nil)
+		mockRepo.On("FindAll", (*bool)(nil)).Return(categories, nil)
+
+		// Seasonal > Summer > Matcha > Iced would be four levels
+		result, err := service.Update(matchaUUID, services.UpdateCategoryRequest{ParentID: &summer.UUID})
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrCategoryTooDeep, err)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("success - tree nests children under parents", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		mockRepo.On("FindAll", (*bool)(nil)).Return(tree(), nil)
+
+		result, err := service.GetTree(false)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, drinksUUID, result[0].ID)
+		assert.Len(t, result[0].Children, 1)
+		assert.Equal(t, matchaUUID, result[0].Children[0].ID)
+		assert.Len(t, result[0].Children[0].Children, 1)
+		assert.Equal(t, icedUUID, result[0].Children[0].Children[0].ID)
+		assert.Empty(t, result[0].Children[0].Children[0].Children)
+	})
+}
+
+func TestCategoryService_Reorder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		mockRepo.On("Reorder", ids).Return(nil)
+
+		err := service.Reorder(services.ReorderRequest{IDs: ids})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown category", func(t *testing.T) {
+		mockRepo := new(mocks.MockCategoryRepository)
+		service := services.NewCategoryService(mockRepo)
+
+		ids := []uuid.UUID{uuid.New()}
+		mockRepo.On("Reorder", ids).Return(repositories.ErrCategoryNotFound)
+
+		err := service.Reorder(services.ReorderRequest{IDs: ids})
+
+		assert.Equal(t, services.ErrCategoryNotFound, err)
+	})
+}
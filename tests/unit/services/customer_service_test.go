@@ -0,0 +1,190 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerService_ExportSegment(t *testing.T) {
+	refreshedAt := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	lastOrder := time.Date(2024, 11, 20, 8, 15, 0, 0, time.UTC)
+	phone := "+628123456789"
+	favorite := "Matcha Drinks"
+	row := repositories.CustomerSegmentRow{
+		UserUUID:         uuid.New(),
+		FullName:         "John Doe",
+		Email:            "john@example.com",
+		Phone:            &phone,
+		JoinedAt:         time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		OrderCount:       14,
+		TotalSpend:       672000,
+		LastOrderAt:      &lastOrder,
+		FavoriteCategory: &favorite,
+	}
+
+	t.Run("success - lapsed members default to 30 days without an order", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+
+		customerRepo.On("StatsRefreshedAt").Return(&refreshedAt, nil)
+		customerRepo.On("FindSegment", mock.MatchedBy(func(filters repositories.CustomerSegmentFilters) bool {
+			since := time.Since(filters.InactiveSince)
+			return filters.Segment == repositories.MemberSegmentLapsed &&
+				since > 29*24*time.Hour && since < 31*24*time.Hour
+		})).Return([]repositories.CustomerSegmentRow{row}, nil)
+
+		export, err := service.ExportSegment("lapsed", services.CustomerSegmentCriteria{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, export.Count)
+		assert.Equal(t, "2025-01-10T09:00:00Z", *export.StatsRefreshedAt)
+		assert.Equal(t, row.UserUUID, export.Customers[0].ID)
+		assert.Equal(t, "2024-11-20T08:15:00Z", *export.Customers[0].LastOrderAt)
+	})
+
+	t.Run("success - criteria narrow the segment", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+
+		categoryUUID := uuid.New()
+		from := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+		minSpend := 500000.0
+		customerRepo.On("CategoryTreeIDs", categoryUUID).Return([]uint{2, 5}, nil)
+		customerRepo.On("StatsRefreshedAt").Return(&refreshedAt, nil)
+		customerRepo.On("FindSegment", mock.MatchedBy(func(filters repositories.CustomerSegmentFilters) bool {
+			return filters.Segment == repositories.MemberSegmentAll &&
+				filters.LastOrderFrom.Equal(from) &&
+				filters.LastOrderBefore.Equal(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)) &&
+				*filters.MinSpend == minSpend &&
+				assert.ObjectsAreEqual([]uint{2, 5}, filters.CategoryIDs)
+		})).Return([]repositories.CustomerSegmentRow{row}, nil)
+
+		export, err := service.ExportSegment("all", services.CustomerSegmentCriteria{
+			LastOrderFrom:      &from,
+			LastOrderTo:        &to,
+			MinSpend:           &minSpend,
+			FavoriteCategoryID: &categoryUUID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, export.Count)
+		customerRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown segment", func(t *testing.T) {
+		service := services.NewCustomerService(new(mocks.MockCustomerRepository))
+
+		_, err := service.ExportSegment("vip", services.CustomerSegmentCriteria{})
+
+		assert.ErrorIs(t, err, services.ErrCustomerSegmentNotFound)
+	})
+
+	t.Run("error - minimum spend above maximum", func(t *testing.T) {
+		service := services.NewCustomerService(new(mocks.MockCustomerRepository))
+
+		minSpend, maxSpend := 100000.0, 50000.0
+		_, err := service.ExportSegment("all", services.CustomerSegmentCriteria{MinSpend: &minSpend, MaxSpend: &maxSpend})
+
+		assert.ErrorIs(t, err, services.ErrCustomerSpendRange)
+	})
+
+	t.Run("error - unknown favorite category", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+
+		categoryUUID := uuid.New()
+		customerRepo.On("CategoryTreeIDs", categoryUUID).Return(nil, repositories.ErrCategoryNotFound)
+
+		_, err := service.ExportSegment("all", services.CustomerSegmentCriteria{FavoriteCategoryID: &categoryUUID})
+
+		assert.ErrorIs(t, err, services.ErrCustomerCategoryNotFound)
+	})
+}
+
+func TestCustomerSegmentExport_WriteCSV(t *testing.T) {
+	lastOrder := "2024-11-20T08:15:00+07:00"
+	export := &services.CustomerSegmentExport{
+		Segment: "new",
+		Customers: []services.CustomerSegmentEntry{
+			{ID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440003"), FullName: "Doe, John", Email: "john@example.com", JoinedAt: "2024-06-01T10:00:00+07:00", OrderCount: 2, TotalSpend: 56000, LastOrderAt: &lastOrder},
+		},
+	}
+
+	var body bytes.Buffer
+	require.NoError(t, export.WriteCSV(&body))
+
+	lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "id,full_name,email,phone,joined_at,order_count,total_spend,last_order_at,favorite_category", lines[0])
+	assert.Equal(t, `550e8400-e29b-41d4-a716-446655440003,"Doe, John",john@example.com,,2024-06-01T10:00:00+07:00,2,56000.00,2024-11-20T08:15:00+07:00,`, lines[1])
+}
+
+func TestCustomerService_GetLifetimeValues(t *testing.T) {
+	refreshedAt := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	first := time.Date(2024, 10, 1, 8, 0, 0, 0, time.UTC)
+	rows := []repositories.CustomerValueRow{
+		{UserUUID: uuid.New(), FullName: "Dewi Lestari", Email: "dewi@example.com", OrderCount: 5, TotalSpend: 275000, FirstOrderAt: first, LastOrderAt: first.AddDate(0, 0, 20)},
+		{UserUUID: uuid.New(), FullName: "John Doe", Email: "john@example.com", OrderCount: 1, TotalSpend: 48000, FirstOrderAt: first, LastOrderAt: first},
+	}
+
+	t.Run("success - ranks continue across pages", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+		customerRepo.On("StatsRefreshedAt").Return(&refreshedAt, nil)
+		customerRepo.On("FindLifetimeValues", repositories.CustomerRankingOrders, 20, 20).Return(rows, int64(42), nil)
+
+		report, err := service.GetLifetimeValues("orders", 2, 20)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), report.Total)
+		require.Len(t, report.Customers, 2)
+		top := report.Customers[0]
+		assert.Equal(t, 21, top.Rank)
+		assert.Equal(t, 55000.0, top.AverageOrderValue)
+		require.NotNil(t, top.DaysBetweenOrders)
+		assert.Equal(t, 5.0, *top.DaysBetweenOrders)
+		assert.Equal(t, 22, report.Customers[1].Rank)
+		assert.Nil(t, report.Customers[1].DaysBetweenOrders)
+		assert.NotNil(t, report.StatsRefreshedAt)
+	})
+
+	t.Run("success - limit of zero lists every member", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+		customerRepo.On("StatsRefreshedAt").Return(nil, nil)
+		customerRepo.On("FindLifetimeValues", repositories.CustomerRankingSpend, 0, 0).Return(rows, int64(2), nil)
+
+		report, err := service.GetLifetimeValues("spend", 1, 0)
+
+		require.NoError(t, err)
+		assert.Len(t, report.Customers, 2)
+
+		var body bytes.Buffer
+		require.NoError(t, report.WriteCSV(&body))
+		lines := strings.Split(strings.TrimSpace(body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "rank,id,full_name,email,phone,joined_at,order_count,total_spend,average_order_value,first_order_at,last_order_at,days_between_orders", lines[0])
+		assert.True(t, strings.HasSuffix(lines[2], ",1,48000.00,48000.00,"+rows[1].FirstOrderAt.Format(time.RFC3339)+","+rows[1].LastOrderAt.Format(time.RFC3339)+","))
+	})
+
+	t.Run("error - unknown ranking", func(t *testing.T) {
+		customerRepo := new(mocks.MockCustomerRepository)
+		service := services.NewCustomerService(customerRepo)
+
+		_, err := service.GetLifetimeValues("age", 1, 20)
+
+		assert.ErrorIs(t, err, services.ErrCustomerRankingInvalid)
+		customerRepo.AssertNotCalled(t, "FindLifetimeValues", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
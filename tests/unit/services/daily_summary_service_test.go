@@ -0,0 +1,151 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDailySummaryService_CloseDay(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	businessDate := time.Date(2026, 1, 10, 0, 0, 0, 0, jakarta)
+	adminUUID := uuid.New()
+	admin := &models.User{ID: 1, UUID: adminUUID, FullName: "Store Admin"}
+
+	t.Run("success - snapshots the day and lists flagged orders", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewDailySummaryService(summaryRepo, userRepo)
+
+		summaryRepo.On("FindByDate", businessDate).Return(nil, repositories.ErrDailySummaryNotFound)
+		userRepo.On("FindByUUID", adminUUID).Return(admin, nil)
+
+		var closed *models.DailySummary
+		flaggedAt := time.Date(2026, 1, 10, 23, 5, 0, 0, jakarta)
+		summaryRepo.On("Close", mock.AnythingOfType("*models.DailySummary")).
+			Run(func(args mock.Arguments) {
+				closed = args.Get(0).(*models.DailySummary)
+				closed.OrderCount = 3
+				closed.CompletedCount = 2
+				closed.IncompleteCount = 1
+				closed.PaidCount = 3
+				closed.Total = 148500
+				closed.ClosedAt = flaggedAt
+			}).
+			Return([]models.Order{
+				{UUID: uuid.New(), OrderNumber: "MC-260110-003", Status: models.OrderStatusReady, Total: 49500, FlaggedAt: &flaggedAt},
+			}, nil)
+
+		result, err := service.CloseDay(businessDate, adminUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, businessDate, closed.PeriodStart)
+		assert.Equal(t, businessDate.AddDate(0, 0, 1), closed.PeriodEnd)
+		assert.Equal(t, uint(1), *closed.ClosedByID)
+		assert.Equal(t, "2026-01-10", result.BusinessDate)
+		assert.Equal(t, 3, result.OrderCount)
+		assert.Equal(t, 1, result.IncompleteCount)
+		assert.Equal(t, 148500.0, result.Total)
+		assert.Equal(t, "Store Admin", result.ClosedBy.FullName)
+		assert.Len(t, result.FlaggedOrders, 1)
+		assert.Equal(t, "MC-260110-003", result.FlaggedOrders[0].OrderNumber)
+	})
+
+	t.Run("error - day already closed", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		service := services.NewDailySummaryService(summaryRepo, new(mocks.MockUserRepository))
+
+		summaryRepo.On("FindByDate", businessDate).Return(&models.DailySummary{ID: 1, BusinessDate: businessDate}, nil)
+
+		result, err := service.CloseDay(businessDate, adminUUID)
+
+		assert.ErrorIs(t, err, services.ErrDayAlreadyClosed)
+		assert.Nil(t, result)
+		summaryRepo.AssertNotCalled(t, "Close", mock.Anything)
+	})
+
+	t.Run("error - day has not started", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		service := services.NewDailySummaryService(summaryRepo, new(mocks.MockUserRepository))
+
+		result, err := service.CloseDay(time.Now().AddDate(0, 0, 1), adminUUID)
+
+		assert.ErrorIs(t, err, services.ErrDayNotOver)
+		assert.Nil(t, result)
+		summaryRepo.AssertNotCalled(t, "FindByDate", mock.Anything)
+	})
+}
+
+func TestDailySummaryService_GetZReport(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	businessDate := time.Date(2026, 1, 10, 0, 0, 0, 0, jakarta)
+
+	t.Run("success - closed day returns the saved snapshot", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		service := services.NewDailySummaryService(summaryRepo, new(mocks.MockUserRepository))
+
+		closedAt := time.Date(2026, 1, 10, 23, 5, 0, 0, jakarta)
+		summaryRepo.On("FindByDate", businessDate).Return(&models.DailySummary{
+			BusinessDate: businessDate,
+			PeriodStart:  businessDate,
+			PeriodEnd:    businessDate.AddDate(0, 0, 1),
+			Total:        148500,
+			CashAmount:   49500,
+			RefundCount:  1,
+			RefundAmount: 20000,
+			ClosedAt:     closedAt,
+		}, nil)
+
+		result, err := service.GetZReport(businessDate)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Closed)
+		assert.Equal(t, 49500.0, result.CashAmount)
+		assert.Equal(t, 1, result.RefundCount)
+		assert.Equal(t, closedAt.Format(time.RFC3339), result.ClosedAt)
+		summaryRepo.AssertNotCalled(t, "Preview", mock.Anything)
+	})
+
+	t.Run("success - open day is totalled without closing it", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		service := services.NewDailySummaryService(summaryRepo, new(mocks.MockUserRepository))
+
+		summaryRepo.On("FindByDate", businessDate).Return(nil, repositories.ErrDailySummaryNotFound)
+		summaryRepo.On("Preview", mock.AnythingOfType("*models.DailySummary")).
+			Run(func(args mock.Arguments) {
+				summary := args.Get(0).(*models.DailySummary)
+				summary.PaidCount = 2
+				summary.GatewayAmount = 99000
+				summary.CancelledCount = 1
+				summary.CancelledAmount = 35000
+			}).
+			Return(nil)
+
+		result, err := service.GetZReport(businessDate)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Closed)
+		assert.Empty(t, result.ClosedAt)
+		assert.Equal(t, "2026-01-10", result.BusinessDate)
+		assert.Equal(t, 99000.0, result.GatewayAmount)
+		assert.Equal(t, 35000.0, result.CancelledAmount)
+		summaryRepo.AssertNotCalled(t, "Close", mock.Anything)
+	})
+
+	t.Run("error - day has not started", func(t *testing.T) {
+		summaryRepo := new(mocks.MockDailySummaryRepository)
+		service := services.NewDailySummaryService(summaryRepo, new(mocks.MockUserRepository))
+
+		result, err := service.GetZReport(time.Now().AddDate(0, 0, 1))
+
+		assert.ErrorIs(t, err, services.ErrDayNotOver)
+		assert.Nil(t, result)
+	})
+}
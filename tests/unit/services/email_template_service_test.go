@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEmailTemplateService_Update(t *testing.T) {
+	t.Run("success - saved as new active version", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		service := services.NewEmailTemplateService(mockRepo, new(mocks.MockMailer), testFormatter)
+
+		mockRepo.On("CreateVersion", mock.MatchedBy(func(template *models.EmailTemplate) bool {
+			return template.Locale == utils.LocaleID
+		})).
+			Run(func(args mock.Arguments) {
+				template := args.Get(0).(*models.EmailTemplate)
+				template.Version = 2
+				template.IsActive = true
+			}).Return(nil)
+
+		result, err := service.Update(models.EmailTemplateOrderReady, "", services.UpdateEmailTemplateRequest{
+			Subject:  "{{order_number}} is ready!",
+			HTMLBody: "<p>Hi {{ customer_name }}</p>",
+			TextBody: "Hi {{customer_name}}",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Version)
+		assert.True(t, result.IsActive)
+		assert.Equal(t, []string{"customer_name", "order_number"}, result.Variables)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown variable", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		service := services.NewEmailTemplateService(mockRepo, new(mocks.MockMailer), testFormatter)
+
+		result, err := service.Update(models.EmailTemplateOrderReady, "", services.UpdateEmailTemplateRequest{
+			Subject:  "Ready",
+			HTMLBody: "<p>Total {{total}}</p>",
+			TextBody: "Ready",
+		})
+
+		assert.ErrorIs(t, err, services.ErrUnknownTemplateVariable)
+		assert.Contains(t, err.Error(), "total")
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "CreateVersion", mock.Anything)
+	})
+
+	t.Run("error - unsupported locale", func(t *testing.T) {
+		service := services.NewEmailTemplateService(new(mocks.MockEmailTemplateRepository), new(mocks.MockMailer), testFormatter)
+
+		result, err := service.Update(models.EmailTemplateOrderReady, "fr", services.UpdateEmailTemplateRequest{Subject: "Hi", HTMLBody: "Hi", TextBody: "Hi"})
+
+		assert.ErrorIs(t, err, services.ErrUnsupportedLocale)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - unknown template", func(t *testing.T) {
+		service := services.NewEmailTemplateService(new(mocks.MockEmailTemplateRepository), new(mocks.MockMailer), testFormatter)
+
+		result, err := service.Update("welcome", "", services.UpdateEmailTemplateRequest{Subject: "Hi", HTMLBody: "Hi", TextBody: "Hi"})
+
+		assert.ErrorIs(t, err, services.ErrEmailTemplateNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestEmailTemplateService_Activate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		service := services.NewEmailTemplateService(mockRepo, new(mocks.MockMailer), testFormatter)
+
+		mockRepo.On("Activate", models.EmailTemplateOrderReady, "id", 1).Return(nil)
+		mockRepo.On("FindActive", models.EmailTemplateOrderReady, "id").Return(&models.EmailTemplate{
+			Key:      models.EmailTemplateOrderReady,
+			Version:  1,
+			IsActive: true,
+		}, nil)
+
+		result, err := service.Activate(models.EmailTemplateOrderReady, "", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - version not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		service := services.NewEmailTemplateService(mockRepo, new(mocks.MockMailer), testFormatter)
+
+		mockRepo.On("Activate", models.EmailTemplateOrderReady, "en", 9).Return(repositories.ErrEmailTemplateNotFound)
+
+		result, err := service.Activate(models.EmailTemplateOrderReady, "en", 9)
+
+		assert.ErrorIs(t, err, services.ErrEmailTemplateNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestEmailTemplateService_TestSend(t *testing.T) {
+	activeTemplate := &models.EmailTemplate{
+		Key:      models.EmailTemplateOrderConfirmation,
+		Version:  1,
+		Subject:  "Order {{order_number}}",
+		HTMLBody: "<p>Hi {{customer_name}}, total {{total}}</p>",
+		TextBody: "Hi {{customer_name}}, total {{total}}",
+		IsActive: true,
+	}
+
+	t.Run("success - fills sample variables and escapes html", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		mockMailer := new(mocks.MockMailer)
+		service := services.NewEmailTemplateService(mockRepo, mockMailer, testFormatter)
+
+		mockRepo.On("FindActive", models.EmailTemplateOrderConfirmation, "id").Return(activeTemplate, nil)
+		mockMailer.On("Send", utils.EmailMessage{
+			To:       "admin@matchaciee.com",
+			Subject:  "Order MC-250107-001",
+			HTMLBody: "<p>Hi Tom &amp; Jerry, total Rp115.500</p>",
+			TextBody: "Hi Tom & Jerry, total Rp115.500",
+		}).Return(nil)
+
+		err := service.TestSend(models.EmailTemplateOrderConfirmation, "id", services.TestSendEmailTemplateRequest{
+			To:        "admin@matchaciee.com",
+			Variables: map[string]string{"customer_name": "Tom & Jerry"},
+		})
+
+		assert.NoError(t, err)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("error - mailer failure", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		mockMailer := new(mocks.MockMailer)
+		service := services.NewEmailTemplateService(mockRepo, mockMailer, testFormatter)
+
+		mockRepo.On("FindActive", models.EmailTemplateOrderConfirmation, "id").Return(activeTemplate, nil)
+		mockMailer.On("Send", mock.Anything).Return(errors.New("connection refused"))
+
+		err := service.TestSend(models.EmailTemplateOrderConfirmation, "id", services.TestSendEmailTemplateRequest{To: "admin@matchaciee.com"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEmailTemplateService_Send(t *testing.T) {
+	t.Run("success - falls back to the store locale", func(t *testing.T) {
+		mockRepo := new(mocks.MockEmailTemplateRepository)
+		mockMailer := new(mocks.MockMailer)
+		service := services.NewEmailTemplateService(mockRepo, mockMailer, testFormatter)
+
+		mockRepo.On("FindActive", models.EmailTemplateOrderReady, "en").Return(nil, repositories.ErrEmailTemplateNotFound)
+		mockRepo.On("FindActive", models.EmailTemplateOrderReady, "id").Return(&models.EmailTemplate{
+			Key:      models.EmailTemplateOrderReady,
+			Locale:   "id",
+			Subject:  "Pesanan {{order_number}} sudah siap",
+			HTMLBody: "<p>Siap</p>",
+			TextBody: "Siap",
+		}, nil)
+		mockMailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return msg.Subject == "Pesanan MC-001 sudah siap"
+		})).Return(nil)
+
+		err := service.Send(models.EmailTemplateOrderReady, "en", "member@example.com", map[string]string{"order_number": "MC-001"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testGiftCardCode = "GC-7KQM-X3TD-98WP"
+
+func activeGiftCard(balance float64) *models.GiftCard {
+	return &models.GiftCard{
+		ID:            7,
+		UUID:          uuid.New(),
+		Code:          testGiftCardCode,
+		InitialAmount: 100000,
+		Balance:       balance,
+		Status:        models.GiftCardStatusActive,
+	}
+}
+
+func TestGiftCardService_Issue(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+
+	t.Run("success - issued cards are active with the full balance", func(t *testing.T) {
+		giftCardRepo := new(mocks.MockGiftCardRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewGiftCardService(giftCardRepo, userRepo)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		var saved *models.GiftCard
+		giftCardRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.GiftCard)
+		}).Return(nil)
+
+		card, err := service.Issue(admin.UUID, services.IssueGiftCardRequest{Amount: 50000})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.GiftCardStatusActive, card.Status)
+		assert.Equal(t, 50000.0, card.Balance)
+		assert.Regexp(t, `^GC-[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}$`, card.Code)
+		assert.Equal(t, admin.ID, *saved.IssuedBy)
+	})
+}
+
+func TestGiftCardService_GetByCode(t *testing.T) {
+	t.Run("success - code typed in lower case without dashes", func(t *testing.T) {
+		giftCardRepo := new(mocks.MockGiftCardRepository)
+		service := services.NewGiftCardService(giftCardRepo, new(mocks.MockUserRepository))
+		card := activeGiftCard(80000)
+		order := pendingCounterOrder()
+
+		giftCardRepo.On("FindByCode", testGiftCardCode).Return(card, nil)
+		giftCardRepo.On("FindRedemptions", card.ID).Return([]models.GiftCardRedemption{
+			{UUID: uuid.New(), GiftCardID: card.ID, OrderID: order.ID, Amount: 20000, Order: order},
+		}, nil)
+
+		response, err := service.GetByCode("gc7kqmx3td98wp")
+
+		require.NoError(t, err)
+		assert.Equal(t, 80000.0, response.Balance)
+		require.Len(t, response.Redemptions, 1)
+		assert.Equal(t, order.OrderNumber, response.Redemptions[0].OrderNumber)
+	})
+
+	t.Run("error - unknown code", func(t *testing.T) {
+		giftCardRepo := new(mocks.MockGiftCardRepository)
+		service := services.NewGiftCardService(giftCardRepo, new(mocks.MockUserRepository))
+
+		giftCardRepo.On("FindByCode", mock.Anything).Return(nil, repositories.ErrGiftCardNotFound)
+
+		_, err := service.GetByCode("GC-AAAA-BBBB-CCCC")
+
+		assert.ErrorIs(t, err, services.ErrGiftCardNotFound)
+	})
+}
+
+func TestGiftCardService_Void(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+	req := services.VoidGiftCardRequest{Reason: "Reported stolen"}
+
+	t.Run("error - card is not active", func(t *testing.T) {
+		giftCardRepo := new(mocks.MockGiftCardRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewGiftCardService(giftCardRepo, userRepo)
+		card := activeGiftCard(0)
+		card.Status = models.GiftCardStatusVoided
+
+		giftCardRepo.On("FindByUUID", card.UUID).Return(card, nil)
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		giftCardRepo.On("Void", card.ID, admin.ID, req.Reason).Return(false, nil)
+
+		_, err := service.Void(card.UUID, admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrGiftCardNotActive)
+	})
+}
+
+func TestPaymentService_RedeemGiftCard(t *testing.T) {
+	t.Run("success - partial redemption leaves the rest to pay", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		card := activeGiftCard(100000)
+		amount := 20000.0
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.giftCardRepo.On("FindByCode", testGiftCardCode).Return(card, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		var settle *models.Payment
+		deps.giftCardRepo.On("Redeem", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			settle, _ = args.Get(1).(*models.Payment)
+		}).Return(nil)
+
+		response, err := service.RedeemGiftCard(order.UUID, services.RedeemGiftCardRequest{Code: testGiftCardCode, Amount: &amount})
+
+		require.NoError(t, err)
+		assert.Nil(t, settle)
+		assert.Nil(t, response.PaymentID)
+		assert.Equal(t, models.OrderStatusPending, response.OrderStatus)
+		assert.Equal(t, 22500.0, response.AmountDue)
+		assert.Equal(t, 80000.0, response.CardBalance)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+	})
+
+	t.Run("success - covering the rest settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		order.GiftCardAmount = 2500
+		card := activeGiftCard(100000)
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.giftCardRepo.On("FindByCode", testGiftCardCode).Return(card, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		var settle *models.Payment
+		deps.giftCardRepo.On("Redeem", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			settle = args.Get(1).(*models.Payment)
+		}).Return(nil)
+
+		response, err := service.RedeemGiftCard(order.UUID, services.RedeemGiftCardRequest{Code: testGiftCardCode})
+
+		require.NoError(t, err)
+		assert.Equal(t, 40000.0, response.Amount)
+		assert.Equal(t, 0.0, response.AmountDue)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+		require.NotNil(t, settle)
+		assert.Equal(t, models.PaymentMethodGiftCard, settle.Method)
+		assert.Equal(t, 42500.0, settle.GrossAmount)
+		assert.Equal(t, models.TransactionStatusSettlement, *settle.TransactionStatus)
+	})
+
+	t.Run("error - amount above the card balance", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		card := activeGiftCard(10000)
+		amount := 20000.0
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.giftCardRepo.On("FindByCode", testGiftCardCode).Return(card, nil)
+
+		_, err := service.RedeemGiftCard(order.UUID, services.RedeemGiftCardRequest{Code: testGiftCardCode, Amount: &amount})
+
+		assert.ErrorIs(t, err, services.ErrGiftCardInsufficientBalance)
+		deps.giftCardRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - voided card", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		card := activeGiftCard(100000)
+		card.Status = models.GiftCardStatusVoided
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.giftCardRepo.On("FindByCode", testGiftCardCode).Return(card, nil)
+
+		_, err := service.RedeemGiftCard(order.UUID, services.RedeemGiftCardRequest{Code: testGiftCardCode})
+
+		assert.ErrorIs(t, err, services.ErrGiftCardInvalid)
+	})
+}
+
+func TestPaymentService_GiftCardPurchaseCallback(t *testing.T) {
+	purchaseCallback := func(status string) []byte {
+		return []byte(fmt.Sprintf(`{"id":"inv_3","external_id":"GIFT-12-1736240000","status":%q,"amount":100000,"payment_channel":"OVO","paid_at":"2025-01-07T10:00:00.000Z"}`, status))
+	}
+	pendingCard := func() *models.GiftCard {
+		card := activeGiftCard(100000)
+		reference := "GIFT-12-1736240000"
+		card.Status = models.GiftCardStatusPending
+		card.Reference = &reference
+		return card
+	}
+
+	t.Run("success - paid invoice activates the card", func(t *testing.T) {
+		service, deps := newPaymentService()
+		card := pendingCard()
+
+		deps.giftCardRepo.On("FindByReference", *card.Reference).Return(card, nil)
+		deps.giftCardRepo.On("Activate", card).Return(true, nil)
+
+		err := service.ProcessXenditCallback(purchaseCallback("PAID"), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		deps.giftCardRepo.AssertCalled(t, "Activate", card)
+		deps.paymentRepo.AssertNotCalled(t, "FindByMidtransOrderID", mock.Anything)
+	})
+
+	t.Run("success - expired invoice leaves the card pending", func(t *testing.T) {
+		service, deps := newPaymentService()
+		card := pendingCard()
+
+		deps.giftCardRepo.On("FindByReference", *card.Reference).Return(card, nil)
+		deps.giftCardRepo.On("UpdatePurchase", card).Return(nil)
+
+		err := service.ProcessXenditCallback(purchaseCallback("EXPIRED"), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusExpire, *card.TransactionStatus)
+		deps.giftCardRepo.AssertNotCalled(t, "Activate", mock.Anything)
+	})
+}
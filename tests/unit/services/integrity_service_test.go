@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrityService_RunCheck(t *testing.T) {
+	t.Run("success - reports new and open anomalies", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		mockRepo.On("DetectAnomalies").Return(int64(2), nil)
+		mockRepo.On("FindAnomalies", false).Return([]models.OrderAnomaly{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+
+		result, err := service.RunCheck()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), result.NewAnomalies)
+		assert.Equal(t, 3, result.OpenAnomalies)
+	})
+
+	t.Run("error - detection failure", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		mockRepo.On("DetectAnomalies").Return(int64(0), errors.New("db down"))
+
+		result, err := service.RunCheck()
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "FindAnomalies", false)
+	})
+}
+
+func TestIntegrityService_GetAnomalies(t *testing.T) {
+	t.Run("success - includes order reference", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		orderUUID := uuid.New()
+		resolvedAt := time.Date(2025, 1, 7, 9, 15, 0, 0, time.UTC)
+		mockRepo.On("FindAnomalies", true).Return([]models.OrderAnomaly{
+			{
+				UUID:       uuid.New(),
+				Kind:       models.AnomalyTotalMismatch,
+				Detail:     "Total 60500.00 does not equal subtotal 55000.00 + tax 5500.00 + source fee 1000.00",
+				DetectedAt: time.Date(2025, 1, 7, 2, 0, 0, 0, time.UTC),
+				ResolvedAt: &resolvedAt,
+				Order:      &models.Order{UUID: orderUUID, OrderNumber: "MC-250107-001"},
+			},
+		}, nil)
+
+		result, err := service.GetAnomalies(true)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, orderUUID, *result[0].OrderID)
+		assert.Equal(t, "MC-250107-001", result[0].OrderNumber)
+		assert.Equal(t, "2025-01-07T09:15:00Z", *result[0].ResolvedAt)
+	})
+}
+
+func TestIntegrityService_ResolveAnomaly(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		anomalyUUID := uuid.New()
+		mockRepo.On("FindAnomalyByUUID", anomalyUUID).Return(&models.OrderAnomaly{ID: 1, UUID: anomalyUUID}, nil)
+		mockRepo.On("Resolve", uint(1)).Return(nil)
+
+		err := service.ResolveAnomaly(anomalyUUID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - already resolved", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		anomalyUUID := uuid.New()
+		resolvedAt := time.Now()
+		mockRepo.On("FindAnomalyByUUID", anomalyUUID).Return(&models.OrderAnomaly{ID: 1, UUID: anomalyUUID, ResolvedAt: &resolvedAt}, nil)
+
+		err := service.ResolveAnomaly(anomalyUUID)
+
+		assert.ErrorIs(t, err, services.ErrAnomalyAlreadyResolved)
+		mockRepo.AssertNotCalled(t, "Resolve", uint(1))
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockIntegrityRepository)
+		service := services.NewIntegrityService(mockRepo, testLogger)
+
+		anomalyUUID := uuid.New()
+		mockRepo.On("FindAnomalyByUUID", anomalyUUID).Return(nil, repositories.ErrAnomalyNotFound)
+
+		err := service.ResolveAnomaly(anomalyUUID)
+
+		assert.ErrorIs(t, err, services.ErrAnomalyNotFound)
+	})
+}
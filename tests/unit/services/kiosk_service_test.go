@@ -0,0 +1,132 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestKioskService() (services.KioskService, *mocks.MockKioskRepository) {
+	kioskRepo := new(mocks.MockKioskRepository)
+	return services.NewKioskService(kioskRepo, 2*time.Minute), kioskRepo
+}
+
+func TestKioskService_Create(t *testing.T) {
+	t.Run("success - issues a key and stores only its hash", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskRepo.On("FindByName", "Front entrance").Return(nil, repositories.ErrKioskNotFound)
+
+		var saved *models.Kiosk
+		kioskRepo.On("Create", mock.AnythingOfType("*models.Kiosk")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Kiosk)
+			}).
+			Return(nil)
+
+		result, err := service.Create(services.KioskRequest{Name: " Front entrance "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Front entrance", saved.Name)
+		assert.True(t, saved.IsActive)
+		assert.Len(t, result.APIKey, 64)
+		hash := sha256.Sum256([]byte(result.APIKey))
+		assert.Equal(t, hex.EncodeToString(hash[:]), saved.APIKeyHash)
+		assert.False(t, result.Online)
+	})
+
+	t.Run("error - name already exists", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskRepo.On("FindByName", "Front entrance").Return(&models.Kiosk{ID: 1, Name: "Front entrance"}, nil)
+
+		result, err := service.Create(services.KioskRequest{Name: "Front entrance"})
+
+		assert.ErrorIs(t, err, services.ErrKioskNameExists)
+		assert.Nil(t, result)
+		kioskRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestKioskService_Authenticate(t *testing.T) {
+	apiKey := "4f1c9e0b7a2d4c6e"
+	sum := sha256.Sum256([]byte(apiKey))
+	hash := hex.EncodeToString(sum[:])
+
+	t.Run("success - resolves the kiosk", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskRepo.On("FindByAPIKeyHash", hash).Return(&models.Kiosk{ID: 1, IsActive: true}, nil)
+
+		kiosk, err := service.Authenticate(apiKey)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), kiosk.ID)
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskRepo.On("FindByAPIKeyHash", hash).Return(nil, repositories.ErrKioskNotFound)
+
+		_, err := service.Authenticate(apiKey)
+
+		assert.ErrorIs(t, err, services.ErrInvalidKioskKey)
+	})
+
+	t.Run("error - deactivated kiosk", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskRepo.On("FindByAPIKeyHash", hash).Return(&models.Kiosk{ID: 1, IsActive: false}, nil)
+
+		_, err := service.Authenticate(apiKey)
+
+		assert.ErrorIs(t, err, services.ErrInvalidKioskKey)
+	})
+}
+
+func TestKioskService_GetAll(t *testing.T) {
+	t.Run("success - online only with a recent heartbeat", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		recent := time.Now().Add(-30 * time.Second)
+		stale := time.Now().Add(-10 * time.Minute)
+		kioskRepo.On("FindAll").Return([]models.Kiosk{
+			{ID: 1, UUID: uuid.New(), Name: "Counter", IsActive: true, LastSeenAt: &recent},
+			{ID: 2, UUID: uuid.New(), Name: "Entrance", IsActive: true, LastSeenAt: &stale},
+			{ID: 3, UUID: uuid.New(), Name: "Terrace", IsActive: true},
+		}, nil)
+
+		result, err := service.GetAll()
+
+		assert.NoError(t, err)
+		assert.True(t, result[0].Online)
+		assert.False(t, result[1].Online)
+		assert.False(t, result[2].Online)
+		assert.Empty(t, result[0].APIKey)
+	})
+}
+
+func TestKioskService_Heartbeat(t *testing.T) {
+	t.Run("success - records address and app version", func(t *testing.T) {
+		service, kioskRepo := newTestKioskService()
+		kioskUUID := uuid.New()
+		kiosk := &models.Kiosk{ID: 1, UUID: kioskUUID, Name: "Counter", IsActive: true}
+		version := "2.4.1"
+		kioskRepo.On("RecordHeartbeat", uint(1), mock.AnythingOfType("time.Time"), "10.0.0.5", &version).
+			Run(func(args mock.Arguments) {
+				seenAt := args.Get(1).(time.Time)
+				kiosk.LastSeenAt = &seenAt
+			}).
+			Return(nil)
+		kioskRepo.On("FindByUUID", kioskUUID).Return(kiosk, nil)
+
+		result, err := service.Heartbeat(kiosk, "10.0.0.5", services.KioskHeartbeatRequest{AppVersion: &version})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Online)
+	})
+}
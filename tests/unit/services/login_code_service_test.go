@@ -0,0 +1,322 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type loginCodeDeps struct {
+	userRepo         *mocks.MockUserRepository
+	loginCodeRepo    *mocks.MockLoginCodeRepository
+	refreshTokenRepo *mocks.MockRefreshTokenRepository
+	templateRepo     *mocks.MockEmailTemplateRepository
+	mailer           *mocks.MockMailer
+	whatsApp         *mocks.MockWhatsAppSender
+}
+
+var loginCodePattern = regexp.MustCompile(`\d{6}`)
+
+func newLoginCodeService(channels ...string) (services.LoginCodeService, *loginCodeDeps) {
+	deps := &loginCodeDeps{
+		userRepo:         new(mocks.MockUserRepository),
+		loginCodeRepo:    new(mocks.MockLoginCodeRepository),
+		refreshTokenRepo: new(mocks.MockRefreshTokenRepository),
+		templateRepo:     new(mocks.MockEmailTemplateRepository),
+		mailer:           new(mocks.MockMailer),
+		whatsApp:         new(mocks.MockWhatsAppSender),
+	}
+	jwtUtil := utils.NewJWTUtil("test-secret-key-at-least-32-characters-long", time.Hour, 7*24*time.Hour)
+	emailTemplateService := services.NewEmailTemplateService(deps.templateRepo, deps.mailer, testFormatter)
+
+	service := services.NewLoginCodeService(
+		deps.userRepo,
+		deps.loginCodeRepo,
+		deps.refreshTokenRepo,
+		emailTemplateService,
+		deps.whatsApp,
+		jwtUtil,
+		testFormatter,
+		testLogger,
+		services.LoginCodeSettings{
+			Channels:      channels,
+			Expiry:        5 * time.Minute,
+			MaxAttempts:   5,
+			RequestLimit:  3,
+			RequestWindow: 15 * time.Minute,
+			Secret:        "test-secret",
+		},
+	)
+	return service, deps
+}
+
+func loginCodeMember() *models.User {
+	phone := "+6281234567890"
+	return &models.User{
+		ID:       1,
+		UUID:     uuid.New(),
+		Email:    "member@example.com",
+		FullName: "John Doe",
+		Phone:    &phone,
+		Role:     models.RoleMember,
+		IsActive: true,
+	}
+}
+
+// receiveMessage waits for a login code the service sends in the background
+func receiveMessage[T any](t *testing.T, sent <-chan T) T {
+	t.Helper()
+	select {
+	case message := <-sent:
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("no login code was sent")
+		var zero T
+		return zero
+	}
+}
+
+var loginCodeTemplate = &models.EmailTemplate{
+	Key:      models.EmailTemplateLoginCode,
+	Locale:   "id",
+	Subject:  "Kode masuk",
+	HTMLBody: "<p>{{code}}</p>",
+	TextBody: "Kode {{code}} berlaku {{expires_in}}",
+	IsActive: true,
+}
+
+func TestLoginCodeService_RequestCode(t *testing.T) {
+	t.Run("success - emails a code and stores only its hash", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+
+		var stored *models.LoginCode
+		deps.userRepo.On("FindByEmail", member.Email).Return(member, nil)
+		deps.loginCodeRepo.On("CountSince", member.ID, mock.Anything).Return(int64(0), nil)
+		deps.loginCodeRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.LoginCode)
+		}).Return(nil)
+		deps.templateRepo.On("FindActive", models.EmailTemplateLoginCode, "id").Return(loginCodeTemplate, nil)
+
+		emails := make(chan utils.EmailMessage, 1)
+		deps.mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			emails <- args.Get(0).(utils.EmailMessage)
+		}).Return(nil)
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email})
+
+		require.NoError(t, err)
+		sent := receiveMessage(t, emails)
+		code := loginCodePattern.FindString(sent.TextBody)
+		require.NotEmpty(t, code)
+		assert.Contains(t, sent.TextBody, "5 menit")
+		assert.Equal(t, member.Email, sent.To)
+		assert.Equal(t, models.LoginChannelEmail, stored.Channel)
+		assert.NotContains(t, stored.CodeHash, code)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), stored.ExpiresAt, time.Minute)
+
+		// The hash is keyed apart from other uses of the secret
+		mac := hmac.New(sha256.New, []byte("test-secret"))
+		mac.Write([]byte(code))
+		assert.NotEqual(t, hex.EncodeToString(mac.Sum(nil)), stored.CodeHash)
+	})
+
+	t.Run("success - sends a whatsapp message", func(t *testing.T) {
+		service, deps := newLoginCodeService("email", "whatsapp")
+		member := loginCodeMember()
+
+		deps.userRepo.On("FindByPhone", *member.Phone).Return(member, nil)
+		deps.loginCodeRepo.On("CountSince", member.ID, mock.Anything).Return(int64(2), nil)
+		deps.loginCodeRepo.On("Create", mock.Anything).Return(nil)
+		texts := make(chan string, 1)
+		deps.whatsApp.On("Send", *member.Phone, mock.Anything).Run(func(args mock.Arguments) {
+			texts <- args.String(1)
+		}).Return(nil)
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelWhatsApp, Phone: *member.Phone})
+
+		require.NoError(t, err)
+		assert.Regexp(t, loginCodePattern, receiveMessage(t, texts))
+	})
+
+	t.Run("success - a failed send does not fail the request", func(t *testing.T) {
+		service, deps := newLoginCodeService("email", "whatsapp")
+		member := loginCodeMember()
+
+		deps.userRepo.On("FindByPhone", *member.Phone).Return(member, nil)
+		deps.loginCodeRepo.On("CountSince", member.ID, mock.Anything).Return(int64(0), nil)
+		deps.loginCodeRepo.On("Create", mock.Anything).Return(nil)
+		attempts := make(chan string, 1)
+		deps.whatsApp.On("Send", *member.Phone, mock.Anything).Run(func(args mock.Arguments) {
+			attempts <- args.String(1)
+		}).Return(errors.New("whatsapp unavailable"))
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelWhatsApp, Phone: *member.Phone})
+
+		require.NoError(t, err)
+		receiveMessage(t, attempts)
+	})
+
+	t.Run("success - unknown account sends nothing", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+
+		deps.userRepo.On("FindByEmail", "nobody@example.com").Return(nil, repositories.ErrUserNotFound)
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelEmail, Email: "nobody@example.com"})
+
+		require.NoError(t, err)
+		deps.loginCodeRepo.AssertNotCalled(t, "Create", mock.Anything)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("success - staff accounts are treated as unknown", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		barista := loginCodeMember()
+		barista.Role = models.RoleBarista
+
+		deps.userRepo.On("FindByEmail", barista.Email).Return(barista, nil)
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelEmail, Email: barista.Email})
+
+		require.NoError(t, err)
+		deps.loginCodeRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("success - too many codes requested looks like an unknown account", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+
+		deps.userRepo.On("FindByEmail", member.Email).Return(member, nil)
+		deps.loginCodeRepo.On("CountSince", member.ID, mock.Anything).Return(int64(3), nil)
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email})
+
+		require.NoError(t, err)
+		deps.loginCodeRepo.AssertNotCalled(t, "Create", mock.Anything)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("error - channel not enabled", func(t *testing.T) {
+		service, _ := newLoginCodeService("email")
+
+		err := service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelWhatsApp, Phone: "+6281234567890"})
+
+		assert.ErrorIs(t, err, services.ErrLoginCodeChannelDisabled)
+	})
+}
+
+func TestLoginCodeService_Login(t *testing.T) {
+	// requestCode issues a code through the service and returns it with the
+	// stored record, so the hash matches what Login computes
+	requestCode := func(t *testing.T, service services.LoginCodeService, deps *loginCodeDeps, member *models.User) (string, *models.LoginCode) {
+		var stored *models.LoginCode
+		emails := make(chan utils.EmailMessage, 1)
+		deps.userRepo.On("FindByEmail", member.Email).Return(member, nil)
+		deps.loginCodeRepo.On("CountSince", member.ID, mock.Anything).Return(int64(0), nil).Once()
+		deps.loginCodeRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.LoginCode)
+			stored.ID = 7
+		}).Return(nil).Once()
+		deps.templateRepo.On("FindActive", models.EmailTemplateLoginCode, "id").Return(loginCodeTemplate, nil)
+		deps.mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			emails <- args.Get(0).(utils.EmailMessage)
+		}).Return(nil).Once()
+
+		require.NoError(t, service.RequestCode(services.RequestLoginCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email}))
+		return loginCodePattern.FindString(receiveMessage(t, emails).TextBody), stored
+	}
+
+	t.Run("success - issues tokens like a password login", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+		code, stored := requestCode(t, service, deps, member)
+
+		deps.loginCodeRepo.On("FindLatestActive", member.ID).Return(stored, nil)
+		deps.loginCodeRepo.On("ReserveAttempt", uint(7), 5).Return(true, nil)
+		deps.loginCodeRepo.On("Consume", uint(7)).Return(true, nil)
+		deps.refreshTokenRepo.On("Create", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+		resp, err := service.Login(services.LoginWithCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email, Code: code})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.Equal(t, member.UUID, resp.User.ID)
+		deps.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - wrong code uses up an attempt", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+		code, stored := requestCode(t, service, deps, member)
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		deps.loginCodeRepo.On("FindLatestActive", member.ID).Return(stored, nil)
+		deps.loginCodeRepo.On("ReserveAttempt", uint(7), 5).Return(true, nil)
+
+		resp, err := service.Login(services.LoginWithCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email, Code: wrong})
+
+		assert.ErrorIs(t, err, services.ErrInvalidLoginCode)
+		assert.Nil(t, resp)
+		deps.loginCodeRepo.AssertExpectations(t)
+		deps.loginCodeRepo.AssertNotCalled(t, "Consume", mock.Anything)
+	})
+
+	t.Run("error - too many wrong attempts locks the code", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+		code, stored := requestCode(t, service, deps, member)
+
+		deps.loginCodeRepo.On("FindLatestActive", member.ID).Return(stored, nil)
+		deps.loginCodeRepo.On("ReserveAttempt", uint(7), 5).Return(false, nil)
+
+		_, err := service.Login(services.LoginWithCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email, Code: code})
+
+		assert.ErrorIs(t, err, services.ErrInvalidLoginCode)
+		deps.loginCodeRepo.AssertNotCalled(t, "Consume", mock.Anything)
+	})
+
+	t.Run("error - code already used", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+		code, stored := requestCode(t, service, deps, member)
+
+		deps.loginCodeRepo.On("FindLatestActive", member.ID).Return(stored, nil)
+		deps.loginCodeRepo.On("ReserveAttempt", uint(7), 5).Return(true, nil)
+		deps.loginCodeRepo.On("Consume", uint(7)).Return(false, nil)
+
+		_, err := service.Login(services.LoginWithCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email, Code: code})
+
+		assert.ErrorIs(t, err, services.ErrInvalidLoginCode)
+		deps.refreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - no active code", func(t *testing.T) {
+		service, deps := newLoginCodeService("email")
+		member := loginCodeMember()
+
+		deps.userRepo.On("FindByEmail", member.Email).Return(member, nil)
+		deps.loginCodeRepo.On("FindLatestActive", member.ID).Return(nil, repositories.ErrLoginCodeNotFound)
+
+		_, err := service.Login(services.LoginWithCodeRequest{Channel: models.LoginChannelEmail, Email: member.Email, Code: "123456"})
+
+		assert.ErrorIs(t, err, services.ErrInvalidLoginCode)
+	})
+}
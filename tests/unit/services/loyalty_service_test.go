@@ -0,0 +1,510 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testLoyalty earns a point per Rp10.000 and spends a point as Rp100
+var testLoyalty = services.LoyaltySettings{SpendPerPoint: 10000, PointValue: 100}
+
+func expectLoyaltySettings(settingRepo *mocks.MockSettingRepository, settings services.LoyaltySettings) {
+	value, _ := json.Marshal(settings)
+	settingRepo.On("FindByKey", models.SettingKeyLoyalty).Return(&models.Setting{Key: models.SettingKeyLoyalty, Value: value}, nil)
+}
+
+// testEmailTemplates is for services that are not expected to send email
+var testEmailTemplates = services.NewEmailTemplateService(new(mocks.MockEmailTemplateRepository), new(mocks.MockMailer), testFormatter)
+
+// testStamps swaps ten drinks for a free one worth up to Rp45.000, valid for
+// a month
+var testStamps = services.StampSettings{Goal: 10, RewardMaxValue: 45000, RewardValidDays: 30}
+
+func expectStampSettings(settingRepo *mocks.MockSettingRepository, settings services.StampSettings) {
+	value, _ := json.Marshal(settings)
+	settingRepo.On("FindByKey", models.SettingKeyStamps).Return(&models.Setting{Key: models.SettingKeyStamps, Value: value}, nil)
+}
+
+func TestLoyaltySettings_PointsFor(t *testing.T) {
+	assert.Equal(t, 4, testLoyalty.PointsFor(41500))
+	assert.Equal(t, 0, testLoyalty.PointsFor(9999))
+	assert.Equal(t, 0, services.LoyaltySettings{}.PointsFor(41500))
+}
+
+func TestLoyaltyService_GetPoints(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+
+	t.Run("success - balance with its value and the statement page", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+		order := pendingCounterOrder()
+
+		expectLoyaltySettings(settingRepo, testLoyalty)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		loyaltyRepo.On("Balance", member.ID).Return(350, nil)
+		loyaltyRepo.On("FindTransactions", member.ID, 20, 0).Return([]models.PointTransaction{
+			{UUID: uuid.New(), Type: models.PointTransactionEarn, Points: 4, BalanceAfter: 350, OrderID: &order.ID, Order: order},
+		}, int64(1), nil)
+
+		points, err := service.GetPoints(member.UUID, 1, 20)
+
+		require.NoError(t, err)
+		assert.Equal(t, 350, points.Balance)
+		assert.Equal(t, 35000.0, points.BalanceValue)
+		require.Len(t, points.Transactions, 1)
+		assert.Equal(t, order.OrderNumber, *points.Transactions[0].OrderNumber)
+	})
+}
+
+func TestPaymentService_RedeemPoints(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+	memberOrder := func() *models.Order {
+		order := pendingCounterOrder()
+		order.UserID = &member.ID
+		return order
+	}
+
+	t.Run("success - partial redemption leaves the rest to pay", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		expectLoyaltySettings(deps.settingRepo, testLoyalty)
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.loyaltyRepo.On("Balance", member.ID).Return(500, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		var entry *models.PointTransaction
+		deps.loyaltyRepo.On("Redeem", mock.Anything, 20000.0, (*models.Payment)(nil)).Run(func(args mock.Arguments) {
+			entry = args.Get(0).(*models.PointTransaction)
+			entry.BalanceAfter = 300
+		}).Return(nil)
+
+		response, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 200})
+
+		require.NoError(t, err)
+		assert.Equal(t, -200, entry.Points)
+		assert.Equal(t, models.PointTransactionRedeem, entry.Type)
+		assert.Equal(t, 20000.0, response.Amount)
+		assert.Equal(t, 22500.0, response.AmountDue)
+		assert.Equal(t, 300, response.PointsBalance)
+		assert.Nil(t, response.PaymentID)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+	})
+
+	t.Run("success - covering the rest settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		expectLoyaltySettings(deps.settingRepo, testLoyalty)
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.loyaltyRepo.On("Balance", member.ID).Return(500, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		var settle *models.Payment
+		deps.loyaltyRepo.On("Redeem", mock.Anything, 42500.0, mock.Anything).Run(func(args mock.Arguments) {
+			settle = args.Get(2).(*models.Payment)
+		}).Return(nil)
+
+		response, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 425})
+
+		require.NoError(t, err)
+		require.NotNil(t, settle)
+		assert.Equal(t, models.PaymentMethodPoints, settle.Method)
+		assert.Equal(t, 42500.0, settle.GrossAmount)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+	})
+
+	t.Run("error - points worth more than the amount due", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		expectLoyaltySettings(deps.settingRepo, testLoyalty)
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+
+		_, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 500})
+
+		assert.ErrorIs(t, err, services.ErrPointsExceedAmountDue)
+	})
+
+	t.Run("error - too few points", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		expectLoyaltySettings(deps.settingRepo, testLoyalty)
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.loyaltyRepo.On("Balance", member.ID).Return(50, nil)
+
+		_, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 200})
+
+		assert.ErrorIs(t, err, services.ErrInsufficientPoints)
+		deps.loyaltyRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - spending points is turned off", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		deps.settingRepo.On("FindByKey", models.SettingKeyLoyalty).Return(nil, repositories.ErrSettingNotFound)
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+
+		_, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 200})
+
+		assert.ErrorIs(t, err, services.ErrPointsRedemptionDisabled)
+	})
+
+	t.Run("error - another member's order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+
+		_, err := service.RedeemPoints(order.UUID, member.UUID, services.RedeemPointsRequest{Points: 200})
+
+		assert.ErrorIs(t, err, services.ErrOrderAccessDenied)
+	})
+}
+
+func TestOrderService_UpdateOrderStatus_AwardsPoints(t *testing.T) {
+	memberID := uint(12)
+	readyOrder := func() *models.Order {
+		return &models.Order{
+			ID:           1,
+			UUID:         uuid.New(),
+			OrderNumber:  "MC-260109-001",
+			UserID:       &memberID,
+			Status:       models.OrderStatusReady,
+			Total:        41500,
+			PointsAmount: 10000,
+		}
+	}
+
+	t.Run("success - member earns points on what they paid", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		expectLoyaltySettings(settingRepo, testLoyalty)
+		settingRepo.On("FindByKey", models.SettingKeyStamps).Return(nil, repositories.ErrSettingNotFound)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), services.NewSettingsService(settingRepo), testPickupCodes, testEvents, testLogger, testOrderConfig)
+		order := readyOrder()
+
+		mockOrderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		mockOrderRepo.On("UpdateStatus", order.ID, models.OrderStatusCompleted).Return(nil)
+		mockOrderRepo.On("AwardPoints", order.ID, memberID, 3).Return(nil)
+
+		_, err := service.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+
+		require.NoError(t, err)
+		mockOrderRepo.AssertCalled(t, "AwardPoints", order.ID, memberID, 3)
+	})
+
+	t.Run("success - no points while earning is turned off", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		order := readyOrder()
+
+		mockOrderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		mockOrderRepo.On("UpdateStatus", order.ID, models.OrderStatusCompleted).Return(nil)
+
+		_, err := service.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+
+		require.NoError(t, err)
+		mockOrderRepo.AssertNotCalled(t, "AwardPoints", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_UpdateOrderStatus_AwardsStamps(t *testing.T) {
+	memberID := uint(12)
+	drinksUUID := uuid.New()
+	drinks, cakes := uint(4), uint(9)
+	readyOrder := func() *models.Order {
+		return &models.Order{
+			ID:          1,
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-001",
+			UserID:      &memberID,
+			Status:      models.OrderStatusReady,
+			Total:       120000,
+			Items: []models.OrderItem{
+				{Quantity: 3, Product: &models.Product{ID: 1, Name: "Matcha Latte", CategoryID: &drinks}},
+				{Quantity: 1, Product: &models.Product{ID: 2, Name: "Matcha Cheesecake", CategoryID: &cakes}},
+			},
+		}
+	}
+	newService := func(stamps *services.StampSettings) (services.OrderService, *mocks.MockOrderRepository, *mocks.MockLoyaltyRepository) {
+		orderRepo := new(mocks.MockOrderRepository)
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		if stamps != nil {
+			expectStampSettings(settingRepo, *stamps)
+		}
+		settingRepo.On("FindByKey", mock.Anything).Return(nil, repositories.ErrSettingNotFound)
+		service := services.NewOrderService(orderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), loyaltyRepo, new(mocks.MockDiningTableRepository), services.NewSettingsService(settingRepo), testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, orderRepo, loyaltyRepo
+	}
+
+	t.Run("success - a stamp for each drink in the card's category", func(t *testing.T) {
+		settings := testStamps
+		settings.CategoryID = &drinksUUID
+		service, orderRepo, loyaltyRepo := newService(&settings)
+		order := readyOrder()
+
+		orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		orderRepo.On("UpdateStatus", order.ID, models.OrderStatusCompleted).Return(nil)
+		loyaltyRepo.On("StampCategoryIDs", drinksUUID).Return([]uint{drinks, 5}, nil)
+		loyaltyRepo.On("EarnStamps", order.ID, memberID, 3).Return(nil)
+		loyaltyRepo.On("RedeemStamps", memberID, 10, mock.AnythingOfType("*models.Voucher")).Return(false, nil)
+
+		_, err := service.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+
+		require.NoError(t, err)
+		loyaltyRepo.AssertCalled(t, "EarnStamps", order.ID, memberID, 3)
+	})
+
+	t.Run("success - every full card is swapped for a free drink", func(t *testing.T) {
+		service, orderRepo, loyaltyRepo := newService(&testStamps)
+		order := readyOrder()
+
+		orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		orderRepo.On("UpdateStatus", order.ID, models.OrderStatusCompleted).Return(nil)
+		loyaltyRepo.On("EarnStamps", order.ID, memberID, 4).Return(nil)
+		var rewards []*models.Voucher
+		loyaltyRepo.On("RedeemStamps", memberID, 10, mock.AnythingOfType("*models.Voucher")).
+			Run(func(args mock.Arguments) {
+				rewards = append(rewards, args.Get(2).(*models.Voucher))
+			}).
+			Return(true, nil).Twice()
+		loyaltyRepo.On("RedeemStamps", memberID, 10, mock.AnythingOfType("*models.Voucher")).Return(false, nil)
+
+		_, err := service.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+
+		require.NoError(t, err)
+		require.Len(t, rewards, 2)
+		assert.NotEqual(t, rewards[0].Code, rewards[1].Code)
+		reward := rewards[0]
+		assert.Equal(t, memberID, reward.UserID)
+		assert.Equal(t, models.DiscountTypeFreeItem, reward.DiscountType)
+		assert.Equal(t, 100.0, reward.DiscountValue)
+		assert.Equal(t, 45000.0, *reward.MaxDiscount)
+		require.NotNil(t, reward.ExpiresAt)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *reward.ExpiresAt, time.Minute)
+	})
+
+	t.Run("success - no stamps while the card is off", func(t *testing.T) {
+		service, orderRepo, loyaltyRepo := newService(nil)
+		order := readyOrder()
+
+		orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		orderRepo.On("UpdateStatus", order.ID, models.OrderStatusCompleted).Return(nil)
+
+		_, err := service.UpdateOrderStatus(order.UUID, models.OrderStatusCompleted)
+
+		require.NoError(t, err)
+		loyaltyRepo.AssertNotCalled(t, "EarnStamps", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoyaltyService_GetMyStamps(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+
+	t.Run("success - progress toward the next free drink with earlier rewards", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+		revokeReason := "Duplicate reward"
+
+		expectStampSettings(settingRepo, testStamps)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		loyaltyRepo.On("StampBalance", member.ID).Return(7, nil)
+		loyaltyRepo.On("FindStampRewards", member.ID).Return([]models.Voucher{
+			{UUID: uuid.New(), Code: "VC-7KQM-X3TD", DiscountType: models.DiscountTypeFreeItem, DiscountValue: 100, Status: models.VoucherStatusActive},
+			{UUID: uuid.New(), Code: "VC-P2WN-H8RC", DiscountType: models.DiscountTypeFreeItem, DiscountValue: 100, Status: models.VoucherStatusRevoked, RevokeReason: &revokeReason},
+		}, nil)
+
+		card, err := service.GetMyStamps(member.UUID)
+
+		require.NoError(t, err)
+		assert.True(t, card.Enabled)
+		assert.Equal(t, 7, card.Stamps)
+		assert.Equal(t, 10, card.Goal)
+		assert.Equal(t, 3, card.Remaining)
+		require.Len(t, card.Rewards, 2)
+		assert.True(t, card.Rewards[0].Redeemable)
+		assert.Nil(t, card.Rewards[1].RevokeReason)
+	})
+
+	t.Run("success - stamps are kept while the card is off", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, testSettings, testEmailTemplates, testFormatter, testLogger)
+
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		loyaltyRepo.On("StampBalance", member.ID).Return(4, nil)
+		loyaltyRepo.On("FindStampRewards", member.ID).Return([]models.Voucher{}, nil)
+
+		card, err := service.GetMyStamps(member.UUID)
+
+		require.NoError(t, err)
+		assert.False(t, card.Enabled)
+		assert.Equal(t, 4, card.Stamps)
+		assert.Equal(t, 0, card.Remaining)
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewLoyaltyService(new(mocks.MockLoyaltyRepository), userRepo, testSettings, testEmailTemplates, testFormatter, testLogger)
+
+		userRepo.On("FindByUUID", member.UUID).Return(nil, repositories.ErrUserNotFound)
+
+		_, err := service.GetMyStamps(member.UUID)
+
+		assert.ErrorIs(t, err, services.ErrUserNotFound)
+	})
+}
+
+// testExpiringLoyalty keeps points for a year and warns a month ahead
+var testExpiringLoyalty = services.LoyaltySettings{SpendPerPoint: 10000, PointValue: 100, ExpiryMonths: 12, ExpiryWarningDays: 30}
+
+func TestLoyaltyService_PointsExpiry(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Email: "member@example.com", FullName: "John Doe", Role: models.RoleMember}
+	yearAgo := func(days int) time.Time {
+		return time.Now().AddDate(-1, 0, days)
+	}
+	// The expiry cutoff is computed from the current time inside the service
+	aroundTime := func(expected time.Time) any {
+		return mock.MatchedBy(func(actual time.Time) bool {
+			return actual.Sub(expected).Abs() < time.Minute
+		})
+	}
+
+	t.Run("success - statement lists when points expire, soonest first", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+
+		expectLoyaltySettings(settingRepo, testExpiringLoyalty)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		loyaltyRepo.On("Balance", member.ID).Return(350, nil)
+		loyaltyRepo.On("FindTransactions", member.ID, 20, 0).Return([]models.PointTransaction{}, int64(0), nil)
+		loyaltyRepo.On("FindUnspentLots", member.ID).Return([]repositories.PointLot{
+			{Points: 50, EarnedAt: yearAgo(10)},
+			{Points: 300, EarnedAt: yearAgo(200)},
+		}, nil)
+
+		points, err := service.GetPoints(member.UUID, 1, 20)
+
+		require.NoError(t, err)
+		require.Len(t, points.Expiring, 2)
+		assert.Equal(t, 50, points.Expiring[0].Points)
+		assert.True(t, points.Expiring[0].Soon)
+		assert.Equal(t, 300, points.Expiring[1].Points)
+		assert.False(t, points.Expiring[1].Soon)
+	})
+
+	t.Run("success - points kept forever have no expiry", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+
+		expectLoyaltySettings(settingRepo, testLoyalty)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		loyaltyRepo.On("Balance", member.ID).Return(350, nil)
+		loyaltyRepo.On("FindTransactions", member.ID, 20, 0).Return([]models.PointTransaction{}, int64(0), nil)
+
+		points, err := service.GetPoints(member.UUID, 1, 20)
+
+		require.NoError(t, err)
+		assert.Empty(t, points.Expiring)
+		loyaltyRepo.AssertNotCalled(t, "FindUnspentLots", mock.Anything)
+	})
+
+	t.Run("success - points earned over a year ago expire", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, new(mocks.MockUserRepository), services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+
+		expectLoyaltySettings(settingRepo, testExpiringLoyalty)
+		loyaltyRepo.On("ExpirePoints", aroundTime(time.Now().AddDate(-1, 0, 0))).Return(4, nil)
+
+		expired, err := service.ExpirePoints()
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, expired)
+	})
+
+	t.Run("success - nothing expires while points are kept forever", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		service := services.NewLoyaltyService(loyaltyRepo, new(mocks.MockUserRepository), services.NewSettingsService(settingRepo), testEmailTemplates, testFormatter, testLogger)
+
+		expectLoyaltySettings(settingRepo, testLoyalty)
+
+		expired, err := service.ExpirePoints()
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, expired)
+		loyaltyRepo.AssertNotCalled(t, "ExpirePoints", mock.Anything)
+	})
+
+	t.Run("success - members are warned once about points expiring within a month", func(t *testing.T) {
+		loyaltyRepo := new(mocks.MockLoyaltyRepository)
+		userRepo := new(mocks.MockUserRepository)
+		settingRepo := new(mocks.MockSettingRepository)
+		templateRepo := new(mocks.MockEmailTemplateRepository)
+		mailer := new(mocks.MockMailer)
+		emailTemplates := services.NewEmailTemplateService(templateRepo, mailer, testFormatter)
+		service := services.NewLoyaltyService(loyaltyRepo, userRepo, services.NewSettingsService(settingRepo), emailTemplates, testFormatter, testLogger)
+		earnedBefore := aroundTime(time.Now().AddDate(0, 0, 30).AddDate(-1, 0, 0))
+		earnedAt := yearAgo(10)
+
+		expectLoyaltySettings(settingRepo, testExpiringLoyalty)
+		settingRepo.On("FindByKey", models.SettingKeyCurrency).Return(nil, repositories.ErrSettingNotFound)
+		loyaltyRepo.On("FindExpiryWarningUserIDs", earnedBefore).Return([]uint{member.ID, 13}, nil)
+		loyaltyRepo.On("MarkExpiryWarned", member.ID, earnedBefore).Return(true, nil)
+		loyaltyRepo.On("MarkExpiryWarned", uint(13), earnedBefore).Return(false, nil)
+		loyaltyRepo.On("FindUnspentLots", member.ID).Return([]repositories.PointLot{
+			{Points: 50, EarnedAt: earnedAt},
+			{Points: 300, EarnedAt: yearAgo(200)},
+		}, nil)
+		userRepo.On("FindByID", member.ID).Return(member, nil)
+		templateRepo.On("FindActive", models.EmailTemplatePointsExpiring, utils.LocaleID).Return(&models.EmailTemplate{
+			Key:      models.EmailTemplatePointsExpiring,
+			Locale:   utils.LocaleID,
+			Subject:  "Poin kedaluwarsa {{expires_on}}",
+			HTMLBody: "<p>{{points}} poin</p>",
+			TextBody: "{{points}} poin senilai {{value}}",
+		}, nil)
+		var sent utils.EmailMessage
+		mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(0).(utils.EmailMessage)
+		}).Return(nil)
+
+		warned, err := service.SendExpiryWarnings()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, warned)
+		assert.Equal(t, member.Email, sent.To)
+		assert.Equal(t, "Poin kedaluwarsa "+testFormatter.Date(earnedAt.AddDate(1, 0, 0)), sent.Subject)
+		assert.Equal(t, "50 poin senilai Rp5.000", sent.TextBody)
+		loyaltyRepo.AssertNotCalled(t, "FindUnspentLots", uint(13))
+	})
+}
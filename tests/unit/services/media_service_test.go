@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG is the signature and header chunk of a PNG, enough for content
+// type detection
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+func newTestMediaService(t *testing.T) (services.MediaService, *mocks.MockMediaRepository, string) {
+	dir := t.TempDir()
+	mockRepo := new(mocks.MockMediaRepository)
+	service := services.NewMediaService(mockRepo, testLogger, services.MediaSettings{
+		Dir:          dir,
+		BaseURL:      "https://api.matchaciee.com",
+		QuotaBytes:   1000,
+		MaxFileBytes: 500,
+		CleanupAfter: 7 * 24 * time.Hour,
+	})
+	return service, mockRepo, dir
+}
+
+func TestMediaService_Upload(t *testing.T) {
+	t.Run("success - stores file and tracks it", func(t *testing.T) {
+		service, mockRepo, dir := newTestMediaService(t)
+
+		mockRepo.On("Usage").Return(&repositories.MediaUsage{FileCount: 2, TotalBytes: 300}, nil)
+		mockRepo.On("Create", mock.MatchedBy(func(file *models.MediaFile) bool {
+			return file.ContentType == "image/png" && file.OriginalName == "latte.png" && file.SizeBytes == int64(len(testPNG))
+		})).Return(nil)
+
+		result, err := service.Upload("../../latte.png", testPNG)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.URL, "https://api.matchaciee.com/uploads/"))
+		assert.True(t, strings.HasSuffix(result.URL, ".png"))
+
+		stored, err := os.ReadFile(filepath.Join(dir, filepath.Base(result.URL)))
+		require.NoError(t, err)
+		assert.Equal(t, testPNG, stored)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - file too large", func(t *testing.T) {
+		service, mockRepo, _ := newTestMediaService(t)
+
+		result, err := service.Upload("big.png", append(testPNG, make([]byte, 500)...))
+
+		assert.ErrorIs(t, err, services.ErrMediaTooLarge)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "Usage")
+	})
+
+	t.Run("error - not an image", func(t *testing.T) {
+		service, _, _ := newTestMediaService(t)
+
+		result, err := service.Upload("menu.txt", []byte("matcha latte 25000"))
+
+		assert.ErrorIs(t, err, services.ErrUnsupportedMediaType)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - quota exceeded", func(t *testing.T) {
+		service, mockRepo, dir := newTestMediaService(t)
+
+		mockRepo.On("Usage").Return(&repositories.MediaUsage{FileCount: 9, TotalBytes: 950}, nil)
+
+		result, err := service.Upload("latte.png", testPNG)
+
+		assert.ErrorIs(t, err, services.ErrStorageQuotaExceeded)
+		assert.Nil(t, result)
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - removes file when tracking fails", func(t *testing.T) {
+		service, mockRepo, dir := newTestMediaService(t)
+
+		mockRepo.On("Usage").Return(&repositories.MediaUsage{}, nil)
+		mockRepo.On("Create", mock.Anything).Return(errors.New("db down"))
+
+		result, err := service.Upload("latte.png", testPNG)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		entries, _ := os.ReadDir(dir)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestMediaService_GetUsage(t *testing.T) {
+	service, mockRepo, _ := newTestMediaService(t)
+
+	mockRepo.On("Usage").Return(&repositories.MediaUsage{FileCount: 4, TotalBytes: 850}, nil)
+	mockRepo.On("FindUnreferenced", mock.Anything).Return([]models.MediaFile{
+		{ID: 1, SizeBytes: 100},
+		{ID: 2, SizeBytes: 50},
+	}, nil)
+
+	result, err := service.GetUsage()
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(850), result.UsedBytes)
+	assert.Equal(t, 85.0, result.UsedPercent)
+	assert.True(t, result.NearQuota)
+	assert.Equal(t, 2, result.UnreferencedFiles)
+	assert.Equal(t, int64(150), result.UnreferencedBytes)
+	assert.Equal(t, 7, result.CleanupAfterDays)
+}
+
+func TestMediaService_Cleanup(t *testing.T) {
+	t.Run("success - deletes old unused files", func(t *testing.T) {
+		service, mockRepo, dir := newTestMediaService(t)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "old.png"), testPNG, 0o644))
+		mockRepo.On("FindUnreferenced", mock.MatchedBy(func(before time.Time) bool {
+			return before.Before(time.Now().Add(-6 * 24 * time.Hour))
+		})).Return([]models.MediaFile{
+			{ID: 1, FileName: "old.png", SizeBytes: 108},
+			{ID: 2, FileName: "already-gone.png", SizeBytes: 40},
+		}, nil)
+		mockRepo.On("Delete", uint(1)).Return(nil)
+		mockRepo.On("Delete", uint(2)).Return(nil)
+
+		result, err := service.Cleanup()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.DeletedFiles)
+		assert.Equal(t, int64(148), result.FreedBytes)
+		assert.NoFileExists(t, filepath.Join(dir, "old.png"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		service, mockRepo, _ := newTestMediaService(t)
+
+		mockRepo.On("FindUnreferenced", mock.Anything).Return(nil, errors.New("db down"))
+
+		result, err := service.Cleanup()
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMenuService_GetMenu(t *testing.T) {
+	drinksID, lattesID, orphanID := uint(1), uint(2), uint(3)
+	drinks := models.Category{ID: drinksID, UUID: uuid.New(), Name: "Drinks", UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	lattes := models.Category{ID: lattesID, UUID: uuid.New(), Name: "Lattes", ParentID: &drinksID, Parent: &drinks}
+	// Parent category 99 is inactive, so this one is hidden too
+	inactiveParentID := uint(99)
+	orphan := models.Category{ID: orphanID, UUID: uuid.New(), Name: "Seasonal", ParentID: &inactiveParentID}
+
+	soldOut := 0
+	products := []models.Product{
+		{
+			UUID:       uuid.New(),
+			Name:       "Matcha Latte",
+			CategoryID: &lattesID,
+			UpdatedAt:  time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+			Customizations: []models.ProductCustomization{
+				{UUID: uuid.New(), CustomizationType: "milk", OptionName: "Oat Milk", PriceModifier: 5000},
+			},
+		},
+		{UUID: uuid.New(), Name: "Sakura Latte", CategoryID: &orphanID, UpdatedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{UUID: uuid.New(), Name: "Tote Bag", StockQuantity: &soldOut},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCatalogRepo := new(mocks.MockCatalogRepository)
+		service := services.NewMenuService(mockCategoryRepo, mockProductRepo, mockCatalogRepo)
+
+		mockCatalogRepo.On("Version").Return(int64(42), nil)
+		mockCategoryRepo.On("FindAll", mock.MatchedBy(func(isActive *bool) bool { return isActive != nil && *isActive })).
+			Return([]models.Category{drinks, lattes, orphan}, nil)
+		mockProductRepo.On("FindAll", false, mock.MatchedBy(func(isAvailable *bool) bool { return isAvailable != nil && *isAvailable }), []uint(nil)).
+			Return(products, nil)
+
+		result, err := service.GetMenu()
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Categories, 2)
+		assert.Equal(t, "Drinks", result.Categories[0].Name)
+		assert.Empty(t, result.Categories[0].Products)
+		assert.Equal(t, drinks.UUID, *result.Categories[1].ParentID)
+		assert.Len(t, result.Categories[1].Products, 1)
+		assert.Len(t, result.Categories[1].Products[0].Customizations, 1)
+		assert.Len(t, result.Uncategorized, 1)
+		assert.True(t, result.Uncategorized[0].SoldOut)
+		assert.Equal(t, "2025-01-05T00:00:00Z", result.UpdatedAt)
+		assert.Equal(t, "42", result.Version)
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCatalogRepo := new(mocks.MockCatalogRepository)
+		service := services.NewMenuService(mockCategoryRepo, mockProductRepo, mockCatalogRepo)
+
+		mockCatalogRepo.On("Version").Return(int64(42), nil)
+		mockCategoryRepo.On("FindAll", mock.Anything).Return(nil, errors.New("db down"))
+
+		result, err := service.GetMenu()
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
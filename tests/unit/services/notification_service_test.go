@@ -0,0 +1,423 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type notificationDeps struct {
+	orderRepo    *mocks.MockOrderRepository
+	userRepo     *mocks.MockUserRepository
+	paymentRepo  *mocks.MockPaymentRepository
+	settingRepo  *mocks.MockSettingRepository
+	templateRepo *mocks.MockEmailTemplateRepository
+	mailer       *mocks.MockMailer
+	whatsApp     *mocks.MockWhatsAppSender
+	events       *realtime.LocalBroker
+}
+
+func newNotificationService(receiptsEnabled bool) (services.NotificationService, *notificationDeps) {
+	deps := &notificationDeps{
+		orderRepo:    new(mocks.MockOrderRepository),
+		userRepo:     new(mocks.MockUserRepository),
+		paymentRepo:  new(mocks.MockPaymentRepository),
+		settingRepo:  new(mocks.MockSettingRepository),
+		templateRepo: new(mocks.MockEmailTemplateRepository),
+		mailer:       new(mocks.MockMailer),
+		whatsApp:     new(mocks.MockWhatsAppSender),
+		events:       realtime.NewLocalBroker(),
+	}
+
+	value := `{"email_receipts":false}`
+	if receiptsEnabled {
+		value = `{"email_receipts":true}`
+	}
+	deps.settingRepo.On("FindByKey", models.SettingKeyNotifications).Return(&models.Setting{
+		Key:   models.SettingKeyNotifications,
+		Value: []byte(value),
+	}, nil)
+	deps.settingRepo.On("FindByKey", models.SettingKeyReceipt).Return(nil, repositories.ErrSettingNotFound).Maybe()
+	deps.settingRepo.On("FindByKey", models.SettingKeyCurrency).Return(nil, repositories.ErrSettingNotFound).Maybe()
+	deps.templateRepo.On("FindActive", models.EmailTemplateOrderReceipt, mock.Anything).Return(&models.EmailTemplate{
+		Key:      models.EmailTemplateOrderReceipt,
+		Locale:   "id",
+		Subject:  "Struk {{order_number}}",
+		HTMLBody: "<pre>{{receipt}}</pre>",
+		TextBody: "{{customer_name}} {{total}}\n{{receipt}}",
+		IsActive: true,
+	}, nil).Maybe()
+
+	settingsService := services.NewSettingsService(deps.settingRepo)
+	service := services.NewNotificationService(
+		deps.orderRepo,
+		deps.userRepo,
+		deps.paymentRepo,
+		settingsService,
+		services.NewReceiptService(settingsService, testFormatter, testPickupCodes),
+		services.NewEmailTemplateService(deps.templateRepo, deps.mailer, testFormatter),
+		deps.whatsApp,
+		deps.events,
+		testFormatter,
+		testLogger,
+		"https://matchaciee.com",
+	)
+	return service, deps
+}
+
+func completedMemberOrder() *models.Order {
+	userID := uint(5)
+	return &models.Order{
+		ID:           10,
+		UUID:         uuid.New(),
+		OrderNumber:  "MC-250107-001",
+		UserID:       &userID,
+		CustomerName: "John Doe",
+		Status:       models.OrderStatusCompleted,
+		OrderSource:  models.OrderSourceMember,
+		Subtotal:     100000,
+		Tax:          11000,
+		Total:        111000,
+		Items: []models.OrderItem{
+			{ProductName: "Matcha Latte", Quantity: 2, UnitPrice: 50000, Subtotal: 100000},
+		},
+	}
+}
+
+func settledPayments() []models.Payment {
+	status := models.TransactionStatusSettlement
+	return []models.Payment{{ID: 1, OrderID: 10, TransactionStatus: &status}}
+}
+
+func TestNotificationService_SendReceipt(t *testing.T) {
+	t.Run("success - emails the receipt to the member", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return(settledPayments(), nil)
+		deps.orderRepo.On("MarkReceiptSent", order.ID).Return(true, nil)
+
+		var sent utils.EmailMessage
+		deps.mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(0).(utils.EmailMessage)
+		}).Return(nil)
+
+		err := service.SendReceipt(order.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "member@example.com", sent.To)
+		assert.Equal(t, "Struk MC-250107-001", sent.Subject)
+		assert.Contains(t, sent.TextBody, "John Doe Rp111.000")
+		assert.Contains(t, sent.TextBody, "Matcha Latte")
+	})
+
+	t.Run("skipped - receipt emails turned off", func(t *testing.T) {
+		service, deps := newNotificationService(false)
+
+		err := service.SendReceipt(uuid.New())
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "FindByUUID", mock.Anything)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("skipped - payment not settled", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+		pending := models.TransactionStatusPending
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{{TransactionStatus: &pending}}, nil)
+
+		err := service.SendReceipt(order.UUID)
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "MarkReceiptSent", mock.Anything)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("skipped - order without an email address", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+		order.UserID = nil
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		err := service.SendReceipt(order.UUID)
+
+		require.NoError(t, err)
+		deps.paymentRepo.AssertNotCalled(t, "FindByOrderID", mock.Anything)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("success - sends the receipt to a guest's email and whatsapp", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+		email := "guest@example.com"
+		phone := "+6281234567890"
+		order.UserID = nil
+		order.CustomerEmail = &email
+		order.CustomerPhone = &phone
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return(settledPayments(), nil)
+		deps.orderRepo.On("MarkReceiptSent", order.ID).Return(true, nil)
+		deps.mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return msg.To == email
+		})).Return(nil)
+		deps.whatsApp.On("Send", phone, mock.MatchedBy(func(text string) bool {
+			return strings.HasPrefix(text, "Struk pesanan Matchaciee MC-250107-001") && strings.Contains(text, "Matcha Latte")
+		})).Return(nil)
+
+		err := service.SendReceipt(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertExpectations(t)
+		deps.whatsApp.AssertExpectations(t)
+		deps.userRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("skipped - another instance already sent it", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return(settledPayments(), nil)
+		deps.orderRepo.On("MarkReceiptSent", order.ID).Return(false, nil)
+
+		err := service.SendReceipt(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestNotificationService_SendOrderConfirmation(t *testing.T) {
+	guestOrder := func() *models.Order {
+		email := "guest@example.com"
+		phone := "+6281234567890"
+		return &models.Order{
+			ID:            11,
+			UUID:          uuid.New(),
+			OrderNumber:   "MC-250107-002",
+			CustomerName:  "Guest",
+			CustomerEmail: &email,
+			CustomerPhone: &phone,
+			Status:        models.OrderStatusPending,
+			OrderSource:   models.OrderSourceGuest,
+			Total:         55000,
+		}
+	}
+
+	t.Run("success - sends the tracking link to the guest", func(t *testing.T) {
+		service, deps := newNotificationService(false)
+		order := guestOrder()
+		trackingURL := "https://matchaciee.com/orders/track/" + order.UUID.String()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.orderRepo.On("MarkConfirmationSent", order.ID).Return(true, nil)
+		deps.templateRepo.On("FindActive", models.EmailTemplateOrderConfirmation, "id").Return(&models.EmailTemplate{
+			Key:      models.EmailTemplateOrderConfirmation,
+			Subject:  "Pesanan {{order_number}}",
+			HTMLBody: "<a href=\"{{order_url}}\">{{total}}</a>",
+			TextBody: "{{order_url}}",
+			IsActive: true,
+		}, nil)
+		deps.mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return msg.To == "guest@example.com" && msg.TextBody == trackingURL
+		})).Return(nil)
+		deps.whatsApp.On("Send", "+6281234567890", mock.MatchedBy(func(text string) bool {
+			return strings.Contains(text, trackingURL) && strings.Contains(text, "Rp55.000")
+		})).Return(nil)
+
+		err := service.SendOrderConfirmation(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertExpectations(t)
+		deps.whatsApp.AssertExpectations(t)
+	})
+
+	t.Run("skipped - order without a guest contact", func(t *testing.T) {
+		service, deps := newNotificationService(false)
+		order := completedMemberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		err := service.SendOrderConfirmation(order.UUID)
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "MarkConfirmationSent", mock.Anything)
+	})
+
+	t.Run("skipped - already sent", func(t *testing.T) {
+		service, deps := newNotificationService(false)
+		order := guestOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.orderRepo.On("MarkConfirmationSent", order.ID).Return(false, nil)
+
+		err := service.SendOrderConfirmation(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+		deps.whatsApp.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificationService_SendOrderReady(t *testing.T) {
+	newService := func(enabled bool) (services.NotificationService, *notificationDeps) {
+		service, deps := newNotificationService(false)
+		deps.settingRepo.ExpectedCalls = nil
+		value := `{"ready_notifications":false}`
+		if enabled {
+			value = `{"ready_notifications":true}`
+		}
+		deps.settingRepo.On("FindByKey", models.SettingKeyNotifications).Return(&models.Setting{
+			Key:   models.SettingKeyNotifications,
+			Value: []byte(value),
+		}, nil)
+		deps.templateRepo.On("FindActive", models.EmailTemplateOrderReady, mock.Anything).Return(&models.EmailTemplate{
+			Key:      models.EmailTemplateOrderReady,
+			Subject:  "Order {{order_number}} is ready",
+			HTMLBody: "<p>{{customer_name}}</p>",
+			TextBody: "{{customer_name}}, {{order_number}} is ready",
+			IsActive: true,
+		}, nil).Maybe()
+		return service, deps
+	}
+	readyOrder := func() *models.Order {
+		order := completedMemberOrder()
+		order.Status = models.OrderStatusReady
+		return order
+	}
+
+	t.Run("success - emails a member without a channel preference", func(t *testing.T) {
+		service, deps := newService(true)
+		order := readyOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.orderRepo.On("MarkReadyNotified", order.ID).Return(true, nil)
+		deps.mailer.On("Send", mock.MatchedBy(func(msg utils.EmailMessage) bool {
+			return msg.To == "member@example.com" && msg.Subject == "Order MC-250107-001 is ready"
+		})).Return(nil)
+
+		err := service.SendOrderReady(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertExpectations(t)
+		deps.whatsApp.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - uses WhatsApp when the member prefers it", func(t *testing.T) {
+		service, deps := newService(true)
+		order := readyOrder()
+		phone := "+6281234567890"
+		notifyVia := models.NotifyViaWhatsApp
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com", Phone: &phone, NotifyVia: &notifyVia}, nil)
+		deps.orderRepo.On("MarkReadyNotified", order.ID).Return(true, nil)
+		deps.whatsApp.On("Send", phone, mock.MatchedBy(func(text string) bool {
+			return strings.Contains(text, "MC-250107-001")
+		})).Return(nil)
+
+		err := service.SendOrderReady(order.UUID)
+
+		require.NoError(t, err)
+		deps.whatsApp.AssertExpectations(t)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("success - messages a guest who left a number", func(t *testing.T) {
+		service, deps := newService(true)
+		phone := "+6281234567890"
+		order := readyOrder()
+		order.UserID = nil
+		order.CustomerPhone = &phone
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.orderRepo.On("MarkReadyNotified", order.ID).Return(true, nil)
+		deps.whatsApp.On("Send", phone, mock.Anything).Return(nil)
+
+		err := service.SendOrderReady(order.UUID)
+
+		require.NoError(t, err)
+		deps.whatsApp.AssertExpectations(t)
+	})
+
+	t.Run("skipped - ready notifications turned off", func(t *testing.T) {
+		service, deps := newService(false)
+
+		err := service.SendOrderReady(uuid.New())
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "FindByUUID", mock.Anything)
+	})
+
+	t.Run("skipped - another instance already sent it", func(t *testing.T) {
+		service, deps := newService(true)
+		order := readyOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.orderRepo.On("MarkReadyNotified", order.ID).Return(false, nil)
+
+		err := service.SendOrderReady(order.UUID)
+
+		require.NoError(t, err)
+		deps.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestNotificationService_Run(t *testing.T) {
+	t.Run("success - sends the receipt when an order completes", func(t *testing.T) {
+		service, deps := newNotificationService(true)
+		order := completedMemberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByID", uint(5)).Return(&models.User{ID: 5, Email: "member@example.com"}, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return(settledPayments(), nil)
+		deps.orderRepo.On("MarkReceiptSent", order.ID).Return(true, nil)
+
+		sent := make(chan utils.EmailMessage, 1)
+		deps.mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent <- args.Get(0).(utils.EmailMessage)
+		}).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go service.Run(ctx)
+
+		// Wait for Run to subscribe before publishing
+		time.Sleep(20 * time.Millisecond)
+		other := services.OrderEvent{Type: services.OrderEventStatusChanged, OrderID: uuid.New(), Status: models.OrderStatusReady}
+		require.NoError(t, deps.events.Publish(ctx, services.OrdersTopic, other))
+		completed := services.OrderEvent{Type: services.OrderEventStatusChanged, OrderID: order.UUID, Status: models.OrderStatusCompleted}
+		require.NoError(t, deps.events.Publish(ctx, services.OrdersTopic, completed))
+
+		select {
+		case msg := <-sent:
+			assert.True(t, strings.HasPrefix(msg.Subject, "Struk"))
+		case <-time.After(time.Second):
+			t.Fatal("receipt was not sent")
+		}
+		deps.orderRepo.AssertNumberOfCalls(t, "FindByUUID", 1)
+	})
+}
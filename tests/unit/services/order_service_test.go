@@ -1,24 +1,69 @@
 package services
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/carllix/matchaciee-backend/internal/logging"
 	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/realtime"
 	"github.com/carllix/matchaciee-backend/internal/repositories"
 	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
 	"github.com/carllix/matchaciee-backend/tests/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+var testOrderConfig = services.OrderConfig{
+	ReservationTTL: 10 * time.Minute,
+	PaymentExpiry:  30 * time.Minute,
+}
+
+var testEvents = realtime.NewLocalBroker()
+
+var testLogger = logging.Discard()
+
+var testPickupCodes = utils.NewPickupCodeSigner("pickup-secret")
+
+// testSettings has nothing saved, so timeslots are unlimited
+var testSettings = newTestSettings(nil)
+
+func newTestSettings(timeslots *services.TimeslotSettings) services.SettingsService {
+	settingRepo := new(mocks.MockSettingRepository)
+	if timeslots != nil {
+		value, _ := json.Marshal(timeslots)
+		settingRepo.On("FindByKey", models.SettingKeyTimeslots).Return(&models.Setting{Key: models.SettingKeyTimeslots, Value: value}, nil)
+	}
+	settingRepo.On("FindByKey", mock.Anything).Return(nil, repositories.ErrSettingNotFound)
+	return services.NewSettingsService(settingRepo)
+}
+
+// testPromotions has no automatic promotions
+func testPromotions() *mocks.MockPromotionRepository {
+	promotionRepo := new(mocks.MockPromotionRepository)
+	promotionRepo.On("FindAutomatic").Return([]models.Promotion{}, nil)
+	return promotionRepo
+}
+
+// testVouchers has no voucher redeemed on any order
+func testVouchers() *mocks.MockVoucherRepository {
+	voucherRepo := new(mocks.MockVoucherRepository)
+	voucherRepo.On("FindByOrderID", mock.Anything).Return(nil, repositories.ErrVoucherNotFound).Maybe()
+	return voucherRepo
+}
+
 func TestOrderService_CreateOrder(t *testing.T) {
 	t.Run("success - create member order without customizations", func(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 		productUUID := uuid.New()
@@ -98,7 +143,9 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 		productUUID := uuid.New()
@@ -187,7 +234,9 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 
@@ -216,7 +265,9 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 		productUUID := uuid.New()
@@ -253,7 +304,9 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 		productUUID := uuid.New()
@@ -298,7 +351,9 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 		productUUID := uuid.New()
@@ -352,7 +407,9 @@ func TestOrderService_CreateGuestOrder(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		productUUID := uuid.New()
 
@@ -400,7 +457,10 @@ func TestOrderService_CreateGuestOrder(t *testing.T) {
 			},
 		}, nil)
 
-		result, err := service.CreateGuestOrder(req)
+		feed := service.SubscribeToOrders()
+		defer feed.Close()
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: req})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -408,9 +468,55 @@ func TestOrderService_CreateGuestOrder(t *testing.T) {
 		assert.Equal(t, models.OrderSourceGuest, result.OrderSource)
 		assert.Nil(t, result.User) // Guest order has no user
 
+		var event services.OrderEvent
+		assert.NoError(t, json.Unmarshal(<-feed.C, &event))
+		assert.Equal(t, services.OrderEventCreated, event.Type)
+		assert.Equal(t, orderNumber, event.OrderNumber)
+		assert.Equal(t, models.OrderStatusPending, event.Status)
+
 		mockProductRepo.AssertExpectations(t)
 		mockOrderRepo.AssertExpectations(t)
 	})
+
+	t.Run("success - stores the guest's contact", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), mockReservationRepo, new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUID", productUUID).Return(&models.Product{ID: 1, UUID: productUUID, BasePrice: 45000, IsAvailable: true}, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-004", nil)
+
+		var created *models.Order
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		stored := "guest@example.com"
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:          uuid.New(),
+			Status:        models.OrderStatusPending,
+			CustomerEmail: &stored,
+		}, nil)
+
+		email := "  Guest@Example.com "
+		blank := " "
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{
+			CreateOrderRequest: services.CreateOrderRequest{
+				CustomerName: "Guest Customer",
+				Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+			},
+			Email: &email,
+			Phone: &blank,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "guest@example.com", *created.CustomerEmail)
+		assert.Nil(t, created.CustomerPhone)
+		assert.Nil(t, result.CustomerEmail) // Not shown on public tracking
+	})
 }
 
 func TestOrderService_GetByUUID(t *testing.T) {
@@ -418,7 +524,9 @@ func TestOrderService_GetByUUID(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -458,7 +566,9 @@ func TestOrderService_GetByUUID(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -479,7 +589,9 @@ func TestOrderService_GetMyOrders(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		userUUID := uuid.New()
 
@@ -523,7 +635,9 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -555,11 +669,43 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo.AssertExpectations(t)
 	})
 
+	t.Run("success - publishes status change to order subscribers", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+		sub := service.SubscribeToOrder(orderUUID)
+		defer sub.Close()
+
+		order := &models.Order{ID: 1, UUID: orderUUID, OrderNumber: "MC-260109-001", Status: models.OrderStatusPreparing}
+		updatedOrder := &models.Order{ID: 1, UUID: orderUUID, OrderNumber: "MC-260109-001", Status: models.OrderStatusReady}
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(order, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusReady).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(updatedOrder, nil).Once()
+
+		_, err := service.UpdateOrderStatus(orderUUID, models.OrderStatusReady)
+		assert.NoError(t, err)
+
+		var event services.OrderEvent
+		assert.NoError(t, json.Unmarshal(<-sub.C, &event))
+		assert.Equal(t, services.OrderEventStatusChanged, event.Type)
+		assert.Equal(t, orderUUID, event.OrderID)
+		assert.Equal(t, models.OrderStatusReady, event.Status)
+		assert.Equal(t, models.OrderStatusPreparing, event.PreviousStatus)
+	})
+
 	t.Run("success - valid status transition from preparing to ready", func(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -595,7 +741,9 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -634,7 +782,9 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -657,11 +807,35 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo.AssertExpectations(t)
 	})
 
+	t.Run("error - business day of the order is closed", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+		closedAt := time.Now().Add(-time.Hour)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:          1,
+			UUID:        orderUUID,
+			OrderNumber: "MC-260109-007",
+			Status:      models.OrderStatusReady,
+			ClosedAt:    &closedAt,
+			FlaggedAt:   &closedAt,
+		}, nil)
+
+		result, err := service.UpdateOrderStatus(orderUUID, models.OrderStatusCompleted)
+
+		assert.ErrorIs(t, err, services.ErrOrderDayClosed)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
 	t.Run("error - transition from completed status", func(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -688,7 +862,9 @@ func TestOrderService_UpdateOrderStatus(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		orderUUID := uuid.New()
 
@@ -709,7 +885,9 @@ func TestOrderService_GetAllOrders(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		status := models.OrderStatusPending
 		filters := repositories.OrderFilters{
@@ -751,7 +929,9 @@ func TestOrderService_CalculateTotals(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		productUUID := uuid.New()
 
@@ -810,7 +990,9 @@ func TestOrderService_CalculateTotals(t *testing.T) {
 		mockOrderRepo := new(mocks.MockOrderRepository)
 		mockProductRepo := new(mocks.MockProductRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
 
 		productUUID := uuid.New()
 		customizationUUID := uuid.New()
@@ -878,3 +1060,2670 @@ func TestOrderService_CalculateTotals(t *testing.T) {
 		mockOrderRepo.AssertExpectations(t)
 	})
 }
+
+func TestOrderService_StockReservation(t *testing.T) {
+	t.Run("success - capped product is reserved on checkout", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		stock := 5
+
+		product := &models.Product{
+			ID:            7,
+			UUID:          productUUID,
+			Name:          "Flash Sale Matcha",
+			BasePrice:     30000,
+			IsAvailable:   true,
+			StockQuantity: &stock,
+		}
+
+		req := services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			Items: []services.CreateOrderItemRequest{
+				{ProductID: productUUID, Quantity: 2},
+			},
+		}
+
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockReservationRepo.On("AvailableQuantity", uint(7)).Return(5, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-010", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				args.Get(0).(*models.Order).ID = 42
+			}).
+			Return(nil)
+		mockReservationRepo.On("ReserveForOrder", uint(42), []repositories.ReservationItem{
+			{ProductID: 7, Quantity: 2},
+		}, mock.AnythingOfType("time.Time")).Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-010",
+			Status:      models.OrderStatusPending,
+			Items:       []models.OrderItem{},
+		}, nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: req})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockReservationRepo.AssertExpectations(t)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - not enough stock left", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		stock := 1
+
+		product := &models.Product{
+			ID:            7,
+			UUID:          productUUID,
+			Name:          "Flash Sale Matcha",
+			BasePrice:     30000,
+			IsAvailable:   true,
+			StockQuantity: &stock,
+		}
+
+		req := services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			Items: []services.CreateOrderItemRequest{
+				{ProductID: productUUID, Quantity: 2},
+			},
+		}
+
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockReservationRepo.On("AvailableQuantity", uint(7)).Return(1, nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: req})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInsufficientStock)
+		mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - reservation lost the race cancels the order", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		stock := 1
+
+		product := &models.Product{
+			ID:            7,
+			UUID:          productUUID,
+			Name:          "Flash Sale Matcha",
+			BasePrice:     30000,
+			IsAvailable:   true,
+			StockQuantity: &stock,
+		}
+
+		req := services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			Items: []services.CreateOrderItemRequest{
+				{ProductID: productUUID, Quantity: 1},
+			},
+		}
+
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockReservationRepo.On("AvailableQuantity", uint(7)).Return(1, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-011", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				args.Get(0).(*models.Order).ID = 43
+			}).
+			Return(nil)
+		mockReservationRepo.On("ReserveForOrder", uint(43), mock.Anything, mock.Anything).Return(repositories.ErrInsufficientStock)
+		mockOrderRepo.On("UpdateStatus", uint(43), models.OrderStatusCancelled).Return(nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: req})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInsufficientStock)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - cancelling an order releases reservations", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+
+		order := &models.Order{
+			ID:     1,
+			UUID:   orderUUID,
+			Status: models.OrderStatusPending,
+		}
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(order, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusCancelled).Return(nil)
+		mockReservationRepo.On("ReleaseByOrderID", uint(1)).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:     1,
+			UUID:   orderUUID,
+			Status: models.OrderStatusCancelled,
+			Items:  []models.OrderItem{},
+		}, nil).Once()
+
+		result, err := service.UpdateOrderStatus(orderUUID, models.OrderStatusCancelled)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrderStatusCancelled, result.Status)
+		mockReservationRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_PaymentDeadline(t *testing.T) {
+	t.Run("success - pending order exposes payment deadline", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+		deadline := time.Date(2026, 1, 9, 10, 30, 0, 0, time.UTC)
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:             orderUUID,
+			OrderNumber:      "MC-260109-011",
+			Status:           models.OrderStatusPending,
+			PaymentExpiresAt: &deadline,
+			Items:            []models.OrderItem{},
+		}, nil)
+
+		result, err := service.GetByUUID(orderUUID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.PaymentExpiresAt)
+		assert.Equal(t, "2026-01-09T10:30:00Z", *result.PaymentExpiresAt)
+	})
+
+	t.Run("success - deadline hidden once order leaves pending", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+		deadline := time.Now().Add(-time.Minute)
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:             orderUUID,
+			OrderNumber:      "MC-260109-012",
+			Status:           models.OrderStatusPreparing,
+			PaymentExpiresAt: &deadline,
+			Items:            []models.OrderItem{},
+		}, nil)
+
+		result, err := service.GetByUUID(orderUUID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.PaymentExpiresAt)
+	})
+
+	t.Run("success - new orders get a deadline from config", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		product := &models.Product{
+			ID:          8,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   35000,
+			IsAvailable: true,
+		}
+
+		req := services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			Items: []services.CreateOrderItemRequest{
+				{ProductID: productUUID, Quantity: 1},
+			},
+		}
+
+		var created *models.Order
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-013", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-013",
+			Status:      models.OrderStatusPending,
+			Items:       []models.OrderItem{},
+		}, nil)
+
+		_, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: req})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, created.PaymentExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(testOrderConfig.PaymentExpiry), *created.PaymentExpiresAt, time.Minute)
+	})
+}
+
+func TestOrderService_BulkUpdateOrderStatus(t *testing.T) {
+	t.Run("success - ready orders completed, others reported individually", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		readyUUID := uuid.New()
+		pendingUUID := uuid.New()
+		missingUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", readyUUID).Return(&models.Order{
+			ID:          1,
+			UUID:        readyUUID,
+			OrderNumber: "MC-260109-001",
+			Status:      models.OrderStatusReady,
+		}, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusCompleted).Return(nil)
+		mockOrderRepo.On("FindByUUID", readyUUID).Return(&models.Order{
+			ID:          1,
+			UUID:        readyUUID,
+			OrderNumber: "MC-260109-001",
+			Status:      models.OrderStatusCompleted,
+			Items:       []models.OrderItem{},
+		}, nil).Once()
+		mockOrderRepo.On("FindByUUID", pendingUUID).Return(&models.Order{
+			ID:          2,
+			UUID:        pendingUUID,
+			OrderNumber: "MC-260109-002",
+			Status:      models.OrderStatusPending,
+		}, nil)
+		mockOrderRepo.On("FindByUUID", missingUUID).Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.BulkUpdateOrderStatus(services.BulkUpdateOrderStatusRequest{
+			OrderIDs: []uuid.UUID{readyUUID, pendingUUID, missingUUID, readyUUID},
+			Status:   models.OrderStatusCompleted,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 2, result.Failed)
+		assert.Len(t, result.Results, 3)
+		assert.True(t, result.Results[0].Success)
+		assert.Equal(t, models.OrderStatusCompleted, result.Results[0].Status)
+		assert.Equal(t, services.ErrInvalidStatusTransition.Error(), result.Results[1].Error)
+		assert.Equal(t, services.ErrOrderNotFound.Error(), result.Results[2].Error)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - stale pending orders cancelled and stock released", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		orderUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:          3,
+			UUID:        orderUUID,
+			OrderNumber: "MC-260109-003",
+			Status:      models.OrderStatusPending,
+		}, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(3), models.OrderStatusCancelled).Return(nil)
+		mockReservationRepo.On("ReleaseByOrderID", uint(3)).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:          3,
+			UUID:        orderUUID,
+			OrderNumber: "MC-260109-003",
+			Status:      models.OrderStatusCancelled,
+			Items:       []models.OrderItem{},
+		}, nil).Once()
+
+		result, err := service.BulkUpdateOrderStatus(services.BulkUpdateOrderStatusRequest{
+			OrderIDs: []uuid.UUID{orderUUID},
+			Status:   models.OrderStatusCancelled,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 0, result.Failed)
+		mockReservationRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_CreateStaffOrder(t *testing.T) {
+	t.Run("success - order recorded with staff-selected source", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		product := &models.Product{
+			ID:          9,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   35000,
+			IsAvailable: true,
+		}
+
+		req := services.CreateStaffOrderRequest{
+			CreateOrderRequest: services.CreateOrderRequest{
+				CustomerName: "Office Catering",
+				Items: []services.CreateOrderItemRequest{
+					{ProductID: productUUID, Quantity: 20},
+				},
+			},
+			OrderSource: models.OrderSourceCatering,
+		}
+
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockPricingRepo.On("FindBySource", models.OrderSourceCatering).Return(nil, repositories.ErrSourcePricingNotFound)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-020", nil)
+		mockOrderRepo.On("Create", mock.MatchedBy(func(order *models.Order) bool {
+			return order.OrderSource == models.OrderSourceCatering && order.UserID == nil
+		}), mock.AnythingOfType("[]models.OrderItem")).Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-020",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceCatering,
+			Items:       []models.OrderItem{},
+		}, nil)
+
+		result, err := service.CreateStaffOrder(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrderSourceCatering, result.OrderSource)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - product in a deleted category", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		deletedAt := time.Now()
+		product := &models.Product{
+			ID:          10,
+			UUID:        productUUID,
+			Name:        "Seasonal Drink",
+			BasePrice:   40000,
+			IsAvailable: true,
+			Category:    &models.Category{ID: 3, DeletedAt: &deletedAt},
+		}
+
+		req := services.CreateStaffOrderRequest{
+			CreateOrderRequest: services.CreateOrderRequest{
+				CustomerName: "Phone Customer",
+				Items: []services.CreateOrderItemRequest{
+					{ProductID: productUUID, Quantity: 1},
+				},
+			},
+			OrderSource: models.OrderSourcePhone,
+		}
+
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+
+		result, err := service.CreateStaffOrder(req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrProductNotAvailable)
+	})
+}
+
+func TestOrderService_CreateKioskOrder(t *testing.T) {
+	newKioskOrderService := func() (services.OrderService, *mocks.MockOrderRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		mockProductRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Product{
+			ID:          9,
+			Name:        "Matcha Latte",
+			BasePrice:   35000,
+			IsAvailable: true,
+		}, nil)
+		mockPricingRepo.On("FindBySource", models.OrderSourceKiosk).Return(nil, repositories.ErrSourcePricingNotFound)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-021", nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-021",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceKiosk,
+			Items:       []models.OrderItem{},
+		}, nil)
+		return service, mockOrderRepo
+	}
+	kiosk := &models.Kiosk{ID: 3, Name: "Front entrance", IsActive: true}
+	items := []services.CreateOrderItemRequest{{ProductID: uuid.New(), Quantity: 1}}
+
+	t.Run("success - order without a name is called by queue number", func(t *testing.T) {
+		service, mockOrderRepo := newKioskOrderService()
+		mockOrderRepo.On("NextQueueNumber").Return(42, nil)
+
+		var saved *models.Order
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+
+		_, err := service.CreateKioskOrder(kiosk, services.CreateKioskOrderRequest{Items: items})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrderSourceKiosk, saved.OrderSource)
+		assert.Equal(t, uint(3), *saved.KioskID)
+		assert.Equal(t, 42, *saved.QueueNumber)
+		assert.Equal(t, "Queue 42", saved.CustomerName)
+	})
+
+	t.Run("success - named order gets no queue number", func(t *testing.T) {
+		service, mockOrderRepo := newKioskOrderService()
+
+		var saved *models.Order
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+
+		name := " Sarah "
+		_, err := service.CreateKioskOrder(kiosk, services.CreateKioskOrderRequest{CustomerName: &name, Items: items})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Sarah", saved.CustomerName)
+		assert.Nil(t, saved.QueueNumber)
+		mockOrderRepo.AssertNotCalled(t, "NextQueueNumber")
+	})
+}
+
+func TestOrderService_SourcePricing(t *testing.T) {
+	t.Run("success - partner order uses adjusted prices and fee", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		product := &models.Product{
+			ID:          11,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   35000,
+			IsAvailable: true,
+		}
+
+		req := services.CreateStaffOrderRequest{
+			CreateOrderRequest: services.CreateOrderRequest{
+				CustomerName: "GrabFood #1234",
+				Items: []services.CreateOrderItemRequest{
+					{ProductID: productUUID, Quantity: 2},
+				},
+			},
+			OrderSource: models.OrderSourcePartner,
+		}
+
+		var created *models.Order
+		var createdItems []models.OrderItem
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockPricingRepo.On("FindBySource", models.OrderSourcePartner).Return(&models.SourcePricing{
+			OrderSource:            models.OrderSourcePartner,
+			PriceAdjustmentPercent: 20,
+			FlatFee:                2000,
+		}, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-030", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+				createdItems = args.Get(1).([]models.OrderItem)
+			}).
+			Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-030",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourcePartner,
+			Items:       []models.OrderItem{},
+		}, nil)
+
+		_, err := service.CreateStaffOrder(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 42000.0, createdItems[0].UnitPrice)
+		assert.Equal(t, 84000.0, created.Subtotal)
+		assert.Equal(t, 8400.0, created.Tax)
+		assert.Equal(t, 2000.0, created.SourceFee)
+		assert.Equal(t, 20.0, created.PriceAdjustmentPercent)
+		assert.Equal(t, 94400.0, created.Total)
+	})
+
+	t.Run("success - guest orders keep list price without lookup", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		product := &models.Product{
+			ID:          12,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   35000,
+			IsAvailable: true,
+		}
+
+		var created *models.Order
+		mockProductRepo.On("FindByUUID", productUUID).Return(product, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-031", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:   uuid.New(),
+			Status: models.OrderStatusPending,
+			Items:  []models.OrderItem{},
+		}, nil)
+
+		_, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{
+			CreateOrderRequest: services.CreateOrderRequest{
+				CustomerName: "Walk-in",
+				Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 35000.0, created.Subtotal)
+		assert.Zero(t, created.SourceFee)
+		mockPricingRepo.AssertNotCalled(t, "FindBySource", mock.Anything)
+	})
+}
+
+func TestOrderService_GetKitchenQueue(t *testing.T) {
+	activeStatuses := []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPreparing, models.OrderStatusReady}
+
+	t.Run("success - groups active orders and flattens customizations", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		notes := "Less ice"
+		orders := []models.Order{
+			{
+				UUID:        uuid.New(),
+				OrderNumber: "MC-260109-001",
+				Status:      models.OrderStatusPreparing,
+				CreatedAt:   time.Now().Add(-5 * time.Minute),
+				Items: []models.OrderItem{
+					{
+						ProductName:    "Matcha Latte",
+						Quantity:       2,
+						Notes:          &notes,
+						Customizations: []byte(`[{"customization_type":"Milk","option_name":"Oat Milk","price_modifier":5000}]`),
+					},
+				},
+			},
+			{UUID: uuid.New(), OrderNumber: "MC-260109-002", Status: models.OrderStatusPending, CreatedAt: time.Now()},
+		}
+
+		mockOrderRepo.On("FindByStatuses", activeStatuses).Return(orders, nil)
+
+		queue, err := service.GetKitchenQueue()
+
+		assert.NoError(t, err)
+		assert.Len(t, queue.Pending, 1)
+		assert.Len(t, queue.Preparing, 1)
+		assert.Empty(t, queue.Ready)
+
+		preparing := queue.Preparing[0]
+		assert.Equal(t, "MC-260109-001", preparing.OrderNumber)
+		assert.Equal(t, []string{"Milk: Oat Milk"}, preparing.Items[0].Customizations)
+		assert.Equal(t, &notes, preparing.Items[0].Notes)
+		assert.InDelta(t, 300, preparing.ElapsedSeconds, 5)
+
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - empty queue", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		mockOrderRepo.On("FindByStatuses", activeStatuses).Return([]models.Order{}, nil)
+
+		queue, err := service.GetKitchenQueue()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, queue.Pending)
+		assert.NotNil(t, queue.Preparing)
+		assert.NotNil(t, queue.Ready)
+	})
+}
+
+func TestOrderService_ClaimOrder(t *testing.T) {
+	newService := func() (services.OrderService, *mocks.MockOrderRepository, *mocks.MockUserRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockReservationRepo := new(mocks.MockStockReservationRepository)
+		mockPricingRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, mockUserRepo, mockReservationRepo, mockPricingRepo, testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, mockOrderRepo, mockUserRepo
+	}
+
+	t.Run("success - barista claims an unclaimed order", func(t *testing.T) {
+		service, mockOrderRepo, mockUserRepo := newService()
+
+		orderUUID := uuid.New()
+		barista := &models.User{ID: 7, UUID: uuid.New(), FullName: "Rina", Role: models.RoleBarista}
+		order := &models.Order{ID: 1, UUID: orderUUID, OrderNumber: "MC-260109-001", Status: models.OrderStatusPreparing}
+		claimed := &models.Order{ID: 1, UUID: orderUUID, OrderNumber: "MC-260109-001", Status: models.OrderStatusPreparing, AssignedToID: &barista.ID, AssignedTo: barista}
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(order, nil).Once()
+		mockUserRepo.On("FindByUUID", barista.UUID).Return(barista, nil)
+		mockOrderRepo.On("Claim", uint(1), uint(7)).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimed, nil).Once()
+
+		result, err := service.ClaimOrder(orderUUID, barista.UUID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.AssignedTo)
+		assert.Equal(t, barista.UUID, result.AssignedTo.ID)
+		assert.Equal(t, "Rina", result.AssignedTo.FullName)
+
+		mockOrderRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - order claimed by another barista", func(t *testing.T) {
+		service, mockOrderRepo, mockUserRepo := newService()
+
+		orderUUID := uuid.New()
+		barista := &models.User{ID: 8, UUID: uuid.New()}
+		order := &models.Order{ID: 1, UUID: orderUUID, Status: models.OrderStatusPending}
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(order, nil)
+		mockUserRepo.On("FindByUUID", barista.UUID).Return(barista, nil)
+		mockOrderRepo.On("Claim", uint(1), uint(8)).Return(repositories.ErrOrderAlreadyClaimed)
+
+		result, err := service.ClaimOrder(orderUUID, barista.UUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderAlreadyClaimed)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - order no longer active", func(t *testing.T) {
+		service, mockOrderRepo, _ := newService()
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{ID: 1, UUID: orderUUID, Status: models.OrderStatusReady}, nil)
+
+		result, err := service.ClaimOrder(orderUUID, uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrOrderNotClaimable)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - order not found", func(t *testing.T) {
+		service, mockOrderRepo, _ := newService()
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.ClaimOrder(orderUUID, uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestOrderService_UpdateOrderStatusAs(t *testing.T) {
+	newService := func() (services.OrderService, *mocks.MockOrderRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, mockOrderRepo
+	}
+	rina := &models.User{ID: 7, UUID: uuid.New(), FullName: "Rina", Role: models.RoleBarista}
+	claimedOrder := func(orderUUID uuid.UUID, status models.OrderStatus) *models.Order {
+		return &models.Order{ID: 1, UUID: orderUUID, OrderNumber: "MC-260109-001", Status: status, AssignedToID: &rina.ID, AssignedTo: rina}
+	}
+
+	t.Run("success - the claiming barista moves the order", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		orderUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusPreparing), nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusReady).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusReady), nil).Once()
+
+		result, err := service.UpdateOrderStatusAs(orderUUID, models.OrderStatusReady, rina.UUID, models.RoleBarista)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusReady, result.Status)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - an admin moves an order someone else claimed", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		orderUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusPreparing), nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusReady).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusReady), nil).Once()
+
+		_, err := service.UpdateOrderStatusAs(orderUUID, models.OrderStatusReady, uuid.New(), models.RoleAdmin)
+
+		require.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - another barista cannot move a claimed order", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		orderUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusPreparing), nil)
+
+		result, err := service.UpdateOrderStatusAs(orderUUID, models.OrderStatusReady, uuid.New(), models.RoleBarista)
+
+		assert.ErrorIs(t, err, services.ErrOrderAlreadyClaimed)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - only the staff feed learns who claimed the order", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		orderUUID := uuid.New()
+		tracking := service.SubscribeToOrder(orderUUID)
+		defer tracking.Close()
+		feed := service.SubscribeToOrders()
+		defer feed.Close()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusPreparing), nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusReady).Return(nil)
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusReady), nil).Once()
+
+		_, err := service.UpdateOrderStatusAs(orderUUID, models.OrderStatusReady, rina.UUID, models.RoleBarista)
+		require.NoError(t, err)
+
+		var public, staff services.OrderEvent
+		require.NoError(t, json.Unmarshal(<-tracking.C, &public))
+		require.NoError(t, json.Unmarshal(<-feed.C, &staff))
+		assert.Nil(t, public.AssignedTo)
+		require.NotNil(t, staff.AssignedTo)
+		assert.Equal(t, rina.UUID, staff.AssignedTo.ID)
+	})
+
+	t.Run("success - public order details leave out the assignee", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		orderUUID := uuid.New()
+
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(claimedOrder(orderUUID, models.OrderStatusPreparing), nil)
+
+		result, err := service.GetByUUID(orderUUID)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.AssignedTo)
+	})
+}
+
+func TestOrderService_Reorder(t *testing.T) {
+	type testMocks struct {
+		orderRepo       *mocks.MockOrderRepository
+		productRepo     *mocks.MockProductRepository
+		userRepo        *mocks.MockUserRepository
+		reservationRepo *mocks.MockStockReservationRepository
+	}
+	newService := func() (services.OrderService, testMocks) {
+		m := testMocks{
+			orderRepo:       new(mocks.MockOrderRepository),
+			productRepo:     new(mocks.MockProductRepository),
+			userRepo:        new(mocks.MockUserRepository),
+			reservationRepo: new(mocks.MockStockReservationRepository),
+		}
+		service := services.NewOrderService(m.orderRepo, m.productRepo, m.userRepo, m.reservationRepo, new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, m
+	}
+
+	userUUID := uuid.New()
+	user := &models.User{ID: 1, UUID: userUUID, FullName: "Test User", Role: models.RoleMember}
+	userID := user.ID
+	productID := uint(10)
+
+	oatMilk := json.RawMessage(`[{"customization_type":"Milk","option_name":"Oat","price_modifier":5000}]`)
+
+	t.Run("success - re-adds items at current prices and reports removed ones", func(t *testing.T) {
+		service, m := newService()
+
+		orderUUID := uuid.New()
+		productUUID := uuid.New()
+		customizationUUID := uuid.New()
+		product := &models.Product{
+			ID:             productID,
+			UUID:           productUUID,
+			Name:           "Matcha Latte",
+			BasePrice:      48000,
+			IsAvailable:    true,
+			IsCustomizable: true,
+			Customizations: []models.ProductCustomization{
+				{ID: 5, UUID: customizationUUID, ProductID: productID, CustomizationType: "Milk", OptionName: "Oat", PriceModifier: 6000},
+			},
+		}
+
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+		m.orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:           1,
+			UUID:         orderUUID,
+			UserID:       &userID,
+			CustomerName: "Test User",
+			Total:        110000,
+			Items: []models.OrderItem{
+				{ProductID: &productID, ProductName: "Matcha Latte", Quantity: 2, UnitPrice: 50000, Customizations: []byte(oatMilk)},
+				{ProductID: nil, ProductName: "Sakura Latte", Quantity: 1, UnitPrice: 55000},
+			},
+		}, nil).Once()
+		m.productRepo.On("FindByID", productID).Return(product, nil)
+		m.productRepo.On("FindByUUID", productUUID).Return(product, nil)
+		m.productRepo.On("FindCustomizationByUUID", customizationUUID).Return(&product.Customizations[0], nil)
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-002", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.MatchedBy(func(items []models.OrderItem) bool {
+			return len(items) == 1 && items[0].Quantity == 2 && items[0].UnitPrice == 54000
+		})).Return(nil)
+		m.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-002",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceMember,
+			Total:       118800,
+		}, nil)
+
+		result, err := service.Reorder(orderUUID, userUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-260109-002", result.Order.OrderNumber)
+		assert.Equal(t, 110000.0, result.PreviousTotal)
+		assert.Equal(t, []services.ReorderSkippedItem{
+			{ProductName: "Sakura Latte", Quantity: 1, Reason: services.ReorderSkipRemoved},
+		}, result.SkippedItems)
+		m.orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - every item skipped", func(t *testing.T) {
+		service, m := newService()
+
+		orderUUID := uuid.New()
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+		m.orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:     1,
+			UUID:   orderUUID,
+			UserID: &userID,
+			Items: []models.OrderItem{
+				{ProductID: &productID, ProductName: "Matcha Latte", Quantity: 1, Customizations: []byte(oatMilk)},
+			},
+		}, nil)
+		m.productRepo.On("FindByID", productID).Return(&models.Product{
+			ID:             productID,
+			UUID:           uuid.New(),
+			Name:           "Matcha Latte",
+			IsAvailable:    true,
+			IsCustomizable: true,
+		}, nil)
+
+		result, err := service.Reorder(orderUUID, userUUID)
+
+		assert.ErrorIs(t, err, services.ErrNothingToReorder)
+		assert.Nil(t, result)
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - capped item out of stock", func(t *testing.T) {
+		service, m := newService()
+
+		orderUUID := uuid.New()
+		stock := 5
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+		m.orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:     1,
+			UUID:   orderUUID,
+			UserID: &userID,
+			Items: []models.OrderItem{
+				{ProductID: &productID, ProductName: "Matcha Cake", Quantity: 2},
+			},
+		}, nil)
+		m.productRepo.On("FindByID", productID).Return(&models.Product{
+			ID:            productID,
+			UUID:          uuid.New(),
+			Name:          "Matcha Cake",
+			IsAvailable:   true,
+			StockQuantity: &stock,
+		}, nil)
+		m.reservationRepo.On("AvailableQuantity", productID).Return(1, nil)
+
+		result, err := service.Reorder(orderUUID, userUUID)
+
+		assert.ErrorIs(t, err, services.ErrNothingToReorder)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - order of another member", func(t *testing.T) {
+		service, m := newService()
+
+		orderUUID := uuid.New()
+		otherUserID := uint(2)
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+		m.orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{ID: 1, UUID: orderUUID, UserID: &otherUserID}, nil)
+
+		result, err := service.Reorder(orderUUID, userUUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderAccessDenied)
+		assert.Nil(t, result)
+		m.productRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+}
+
+func TestOrderService_VerifyTotals(t *testing.T) {
+	newService := func() (services.OrderService, *mocks.MockOrderRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, mockOrderRepo
+	}
+
+	t.Run("success - consistent order with source fee", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:      orderUUID,
+			Subtotal:  50000,
+			Tax:       5000,
+			SourceFee: 4500,
+			Total:     59500,
+			Items: []models.OrderItem{
+				{UUID: uuid.New(), Quantity: 2, UnitPrice: 25000, Subtotal: 50000},
+			},
+		}, nil)
+
+		result, err := service.VerifyTotals(orderUUID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Consistent)
+		assert.Empty(t, result.Discrepancies)
+		assert.Equal(t, 59500.0, result.Recomputed.Total)
+	})
+
+	t.Run("success - flags item and order discrepancies", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+
+		orderUUID := uuid.New()
+		itemUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:     orderUUID,
+			Subtotal: 45000,
+			Tax:      4500,
+			Total:    49500,
+			Items: []models.OrderItem{
+				{UUID: itemUUID, Quantity: 2, UnitPrice: 25000, Subtotal: 45000},
+			},
+		}, nil)
+
+		result, err := service.VerifyTotals(orderUUID)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Consistent)
+		assert.Equal(t, []services.TotalsDiscrepancy{
+			{Field: "item_subtotal", ItemID: &itemUUID, Stored: 45000, Expected: 50000},
+			{Field: "subtotal", Stored: 45000, Expected: 50000},
+			{Field: "tax", Stored: 4500, Expected: 5000},
+			{Field: "total", Stored: 49500, Expected: 55000},
+		}, result.Discrepancies)
+	})
+
+	t.Run("success - rounding within tolerance", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:     orderUUID,
+			Subtotal: 33333.33,
+			Tax:      3333.33,
+			Total:    36666.66,
+			Items: []models.OrderItem{
+				{UUID: uuid.New(), Quantity: 1, UnitPrice: 33333.33, Subtotal: 33333.33},
+			},
+		}, nil)
+
+		result, err := service.VerifyTotals(orderUUID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Consistent)
+	})
+
+	t.Run("error - order not found", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.VerifyTotals(orderUUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestOrderService_UpdateItems(t *testing.T) {
+	type testMocks struct {
+		orderRepo       *mocks.MockOrderRepository
+		productRepo     *mocks.MockProductRepository
+		userRepo        *mocks.MockUserRepository
+		reservationRepo *mocks.MockStockReservationRepository
+	}
+	newService := func() (services.OrderService, testMocks) {
+		m := testMocks{
+			orderRepo:       new(mocks.MockOrderRepository),
+			productRepo:     new(mocks.MockProductRepository),
+			userRepo:        new(mocks.MockUserRepository),
+			reservationRepo: new(mocks.MockStockReservationRepository),
+		}
+		service := services.NewOrderService(m.orderRepo, m.productRepo, m.userRepo, m.reservationRepo, new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, m
+	}
+
+	userUUID := uuid.New()
+	user := &models.User{ID: 1, UUID: userUUID, Role: models.RoleMember}
+	userID := user.ID
+	productUUID := uuid.New()
+	stock := 3
+	product := &models.Product{ID: 10, UUID: productUUID, Name: "Matcha Latte", BasePrice: 45000, IsAvailable: true, StockQuantity: &stock}
+	productID := product.ID
+
+	pendingOrder := func(orderUUID uuid.UUID) *models.Order {
+		return &models.Order{
+			ID:          1,
+			UUID:        orderUUID,
+			OrderNumber: "MC-250107-001",
+			UserID:      &userID,
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceMember,
+			Subtotal:    90000,
+			Tax:         9000,
+			Total:       99000,
+			Items: []models.OrderItem{
+				{ID: 7, UUID: uuid.New(), OrderID: 1, ProductID: &productID, ProductName: "Matcha Latte", Quantity: 2, UnitPrice: 45000, Subtotal: 90000},
+			},
+		}
+	}
+	req := services.UpdateOrderItemsRequest{
+		Items: []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 3}},
+	}
+
+	t.Run("success - recalculates totals counting the order's own holds", func(t *testing.T) {
+		service, m := newService()
+		orderUUID := uuid.New()
+
+		m.orderRepo.On("FindByUUID", orderUUID).Return(pendingOrder(orderUUID), nil).Once()
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+		m.reservationRepo.On("FindByOrderID", uint(1)).Return([]models.StockReservation{
+			{ProductID: productID, OrderID: 1, Quantity: 2, Status: models.ReservationStatusActive, ExpiresAt: time.Now().Add(time.Minute)},
+		}, nil)
+		m.productRepo.On("FindByUUID", productUUID).Return(product, nil)
+		m.reservationRepo.On("AvailableQuantity", productID).Return(1, nil)
+		m.orderRepo.On("ReplaceItems", mock.MatchedBy(func(order *models.Order) bool {
+			return order.Subtotal == 135000 && order.Tax == 13500 && order.Total == 148500
+		}), mock.MatchedBy(func(items []models.OrderItem) bool {
+			return len(items) == 1 && items[0].Quantity == 3
+		})).Return(nil)
+		m.reservationRepo.On("ReplaceForOrder", uint(1), []repositories.ReservationItem{{ProductID: productID, Quantity: 3}}, mock.AnythingOfType("time.Time")).Return(nil)
+		m.orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:        orderUUID,
+			OrderNumber: "MC-250107-001",
+			Status:      models.OrderStatusPending,
+			Total:       148500,
+		}, nil)
+
+		result, err := service.UpdateItems(orderUUID, &userUUID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 148500.0, result.Total)
+		m.orderRepo.AssertExpectations(t)
+		m.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - order of another member", func(t *testing.T) {
+		service, m := newService()
+		orderUUID := uuid.New()
+		otherID := uint(2)
+		order := pendingOrder(orderUUID)
+		order.UserID = &otherID
+
+		m.orderRepo.On("FindByUUID", orderUUID).Return(order, nil)
+		m.userRepo.On("FindByUUID", userUUID).Return(user, nil)
+
+		result, err := service.UpdateItems(orderUUID, &userUUID, req)
+
+		assert.ErrorIs(t, err, services.ErrOrderAccessDenied)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - order no longer pending", func(t *testing.T) {
+		service, m := newService()
+		orderUUID := uuid.New()
+		order := pendingOrder(orderUUID)
+		order.Status = models.OrderStatusPreparing
+
+		m.orderRepo.On("FindByUUID", orderUUID).Return(order, nil)
+
+		result, err := service.UpdateItems(orderUUID, nil, req)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotEditable)
+		assert.Nil(t, result)
+		m.orderRepo.AssertNotCalled(t, "ReplaceItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - payment started since the order was loaded", func(t *testing.T) {
+		service, m := newService()
+		orderUUID := uuid.New()
+
+		m.orderRepo.On("FindByUUID", orderUUID).Return(pendingOrder(orderUUID), nil)
+		m.reservationRepo.On("FindByOrderID", uint(1)).Return([]models.StockReservation{}, nil)
+		m.productRepo.On("FindByUUID", productUUID).Return(product, nil)
+		m.reservationRepo.On("AvailableQuantity", productID).Return(3, nil)
+		m.orderRepo.On("ReplaceItems", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(repositories.ErrOrderNotEditable)
+
+		result, err := service.UpdateItems(orderUUID, nil, req)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotEditable)
+		assert.Nil(t, result)
+		m.reservationRepo.AssertNotCalled(t, "ReplaceForOrder", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - stock taken meanwhile restores previous items", func(t *testing.T) {
+		service, m := newService()
+		orderUUID := uuid.New()
+
+		m.orderRepo.On("FindByUUID", orderUUID).Return(pendingOrder(orderUUID), nil)
+		m.reservationRepo.On("FindByOrderID", uint(1)).Return([]models.StockReservation{}, nil)
+		m.productRepo.On("FindByUUID", productUUID).Return(product, nil)
+		m.reservationRepo.On("AvailableQuantity", productID).Return(3, nil)
+		m.orderRepo.On("ReplaceItems", mock.MatchedBy(func(order *models.Order) bool {
+			return order.Total == 148500
+		}), mock.AnythingOfType("[]models.OrderItem")).Return(nil).Once()
+		m.reservationRepo.On("ReplaceForOrder", uint(1), mock.Anything, mock.AnythingOfType("time.Time")).Return(repositories.ErrInsufficientStock)
+		m.orderRepo.On("ReplaceItems", mock.MatchedBy(func(order *models.Order) bool {
+			return order.Total == 99000
+		}), mock.MatchedBy(func(items []models.OrderItem) bool {
+			return len(items) == 1 && items[0].ID == 0 && items[0].Quantity == 2
+		})).Return(nil).Once()
+
+		result, err := service.UpdateItems(orderUUID, nil, req)
+
+		assert.ErrorIs(t, err, services.ErrInsufficientStock)
+		assert.Nil(t, result)
+		m.orderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_PromoCode(t *testing.T) {
+	matchaUUID := uuid.New()
+	cookieUUID := uuid.New()
+	matchaCategory := uint(4)
+	snackCategory := uint(9)
+	matcha := &models.Product{ID: 1, UUID: matchaUUID, Name: "Matcha Latte", BasePrice: 50000, IsAvailable: true, CategoryID: &matchaCategory}
+	cookie := &models.Product{ID: 2, UUID: cookieUUID, Name: "Matcha Cookie", BasePrice: 25000, IsAvailable: true, CategoryID: &snackCategory}
+
+	type promoMocks struct {
+		orderRepo     *mocks.MockOrderRepository
+		productRepo   *mocks.MockProductRepository
+		promotionRepo *mocks.MockPromotionRepository
+	}
+
+	newService := func(automatic ...models.Promotion) (services.OrderService, *promoMocks) {
+		m := &promoMocks{
+			orderRepo:     new(mocks.MockOrderRepository),
+			productRepo:   new(mocks.MockProductRepository),
+			promotionRepo: new(mocks.MockPromotionRepository),
+		}
+		m.promotionRepo.On("FindAutomatic").Return(append([]models.Promotion{}, automatic...), nil)
+		m.productRepo.On("FindByUUID", matchaUUID).Return(matcha, nil)
+		m.productRepo.On("FindByUUID", cookieUUID).Return(cookie, nil)
+		service := services.NewOrderService(m.orderRepo, m.productRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), m.promotionRepo, testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, m
+	}
+
+	// expectCreate captures the order passed to the repository
+	expectCreate := func(m *promoMocks, created **models.Order) {
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-050", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				*created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		m.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-050",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceGuest,
+		}, nil)
+	}
+
+	guestOrder := func(code string, items ...services.CreateOrderItemRequest) services.CreateGuestOrderRequest {
+		return services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			PromoCode:    &code,
+			Items:        items,
+		}}
+	}
+
+	maxDiscount := 15000.0
+	percentOff := func() *models.Promotion {
+		return &models.Promotion{
+			ID:            7,
+			Code:          "MATCHA20",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 20,
+			MaxDiscount:   &maxDiscount,
+			IsActive:      true,
+		}
+	}
+
+	t.Run("success - percentage discount is capped and tax applies after it", func(t *testing.T) {
+		service, m := newService()
+		var created *models.Order
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(percentOff(), nil)
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder(" matcha20 ", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 2}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100000.0, created.Subtotal)
+		assert.Equal(t, 15000.0, created.Discount)
+		assert.Equal(t, 8500.0, created.Tax)
+		assert.Equal(t, 93500.0, created.Total)
+		assert.Equal(t, uint(7), *created.PromotionID)
+		assert.Equal(t, "MATCHA20", *created.PromoCode)
+	})
+
+	t.Run("success - category scope only discounts items in scope", func(t *testing.T) {
+		service, m := newService()
+		var created *models.Order
+		promotion := &models.Promotion{
+			ID:            8,
+			Code:          "DRINKS10",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+			IsActive:      true,
+			Categories:    []models.Category{{ID: 3}},
+		}
+		m.promotionRepo.On("FindByCode", "DRINKS10").Return(promotion, nil)
+		m.promotionRepo.On("ScopeCategoryIDs", uint(8)).Return([]uint{3, matchaCategory}, nil)
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("DRINKS10",
+			services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1},
+			services.CreateOrderItemRequest{ProductID: cookieUUID, Quantity: 1},
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 75000.0, created.Subtotal)
+		assert.Equal(t, 5000.0, created.Discount)
+		assert.Equal(t, 7000.0, created.Tax)
+		assert.Equal(t, 77000.0, created.Total)
+	})
+
+	t.Run("success - fixed discount never exceeds the eligible amount", func(t *testing.T) {
+		service, m := newService()
+		var created *models.Order
+		promotion := &models.Promotion{
+			ID:            9,
+			Code:          "COOKIE",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 40000,
+			IsActive:      true,
+			Products:      []models.Product{{ID: cookie.ID}},
+		}
+		m.promotionRepo.On("FindByCode", "COOKIE").Return(promotion, nil)
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("COOKIE",
+			services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1},
+			services.CreateOrderItemRequest{ProductID: cookieUUID, Quantity: 1},
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 25000.0, created.Discount)
+		assert.Equal(t, 55000.0, created.Total)
+	})
+
+	fiveOff := func(id uint, code string, stackable bool) models.Promotion {
+		return models.Promotion{
+			ID:            id,
+			Code:          code,
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 5000,
+			IsActive:      true,
+			Automatic:     true,
+			Stackable:     stackable,
+		}
+	}
+
+	t.Run("success - automatic promotion applies without a code", func(t *testing.T) {
+		service, m := newService(fiveOff(20, "WEEKDAY", false))
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5000.0, created.Discount)
+		assert.Nil(t, created.PromotionID)
+		assert.Nil(t, created.PromoCode)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, "WEEKDAY", created.Promotions[0].Code)
+		assert.Equal(t, uint(20), *created.Promotions[0].PromotionID)
+	})
+
+	t.Run("success - automatic promotions that do not qualify are skipped", func(t *testing.T) {
+		bigSpender := fiveOff(20, "BIGSPEND", false)
+		bigSpender.MinSpend = 200000
+		service, m := newService(bigSpender)
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		assert.Zero(t, created.Discount)
+		assert.Empty(t, created.Promotions)
+	})
+
+	t.Run("success - stackable code combines with stackable automatic promotions only", func(t *testing.T) {
+		service, m := newService(fiveOff(20, "WEEKDAY", true), fiveOff(21, "MEMBERS", false))
+		var created *models.Order
+		promotion := percentOff()
+		promotion.Stackable = true
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(promotion, nil)
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 2}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20000.0, created.Discount)
+		require.Len(t, created.Promotions, 2)
+		assert.Equal(t, "MATCHA20", created.Promotions[0].Code)
+		assert.Equal(t, "WEEKDAY", created.Promotions[1].Code)
+		assert.Equal(t, "MATCHA20", *created.PromoCode)
+	})
+
+	t.Run("success - code that does not stack keeps automatic promotions off", func(t *testing.T) {
+		service, m := newService(fiveOff(20, "WEEKDAY", true))
+		var created *models.Order
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(percentOff(), nil)
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 2}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 15000.0, created.Discount)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, "MATCHA20", created.Promotions[0].Code)
+	})
+
+	t.Run("success - without a code the larger of stacked and exclusive wins", func(t *testing.T) {
+		exclusive := fiveOff(22, "HAPPY15", false)
+		exclusive.DiscountValue = 15000
+		service, m := newService(fiveOff(20, "WEEKDAY", true), fiveOff(21, "MEMBERS", true), exclusive)
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 15000.0, created.Discount)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, "HAPPY15", created.Promotions[0].Code)
+	})
+
+	t.Run("success - stacked discounts stop at the subtotal", func(t *testing.T) {
+		big := fiveOff(21, "HALF", true)
+		big.DiscountValue = 48000
+		service, m := newService(fiveOff(20, "WEEKDAY", true), big)
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 50000.0, created.Discount)
+		require.Len(t, created.Promotions, 2)
+		assert.Equal(t, 45000.0, created.Promotions[1].Discount)
+	})
+
+	t.Run("success - happy hour applies during its hours with its name", func(t *testing.T) {
+		happyHour := fiveOff(23, "HAPPYHOUR", false)
+		name := "Happy Hour"
+		start := time.Now().Add(-time.Hour).Format("15:04")
+		end := time.Now().Add(time.Hour).Format("15:04")
+		happyHour.Name = &name
+		happyHour.StartTime = &start
+		happyHour.EndTime = &end
+		service, m := newService(happyHour)
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, "Happy Hour", *created.Promotions[0].Name)
+	})
+
+	t.Run("success - happy hour on another day is skipped", func(t *testing.T) {
+		happyHour := fiveOff(23, "HAPPYHOUR", false)
+		happyHour.Days = 0x7f &^ (1 << time.Now().Weekday())
+		service, m := newService(happyHour)
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(guestOrder("", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.NoError(t, err)
+		assert.Empty(t, created.Promotions)
+	})
+
+	t.Run("error - unknown or expired code", func(t *testing.T) {
+		service, m := newService()
+		expired := percentOff()
+		yesterday := time.Now().Add(-24 * time.Hour)
+		expired.ExpiresAt = &yesterday
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(expired, nil)
+		m.promotionRepo.On("FindByCode", "NOPE").Return(nil, repositories.ErrPromotionNotFound)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+		assert.ErrorIs(t, err, services.ErrPromoCodeInvalid)
+
+		_, err = service.CreateGuestOrder(guestOrder("NOPE", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+		assert.ErrorIs(t, err, services.ErrPromoCodeInvalid)
+
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - usage limit reached", func(t *testing.T) {
+		service, m := newService()
+		promotion := percentOff()
+		limit := 100
+		promotion.UsageLimit = &limit
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(promotion, nil)
+		m.promotionRepo.On("CountRedemptions", uint(7), (*uint)(nil)).Return(int64(100), nil)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.ErrorIs(t, err, services.ErrPromoCodeUsageLimit)
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - per customer limit reached for a member", func(t *testing.T) {
+		service, m := newService()
+		userRepo := new(mocks.MockUserRepository)
+		service = services.NewOrderService(m.orderRepo, m.productRepo, userRepo, new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), m.promotionRepo, testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		userUUID := uuid.New()
+		userRepo.On("FindByUUID", userUUID).Return(&models.User{ID: 5, UUID: userUUID}, nil)
+
+		promotion := percentOff()
+		once := 1
+		promotion.PerCustomerLimit = &once
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(promotion, nil)
+		m.promotionRepo.On("CountRedemptions", uint(7), mock.MatchedBy(func(userID *uint) bool {
+			return userID != nil && *userID == 5
+		})).Return(int64(1), nil)
+
+		code := "MATCHA20"
+		_, err := service.CreateOrder(userUUID, services.CreateOrderRequest{
+			CustomerName: "Member",
+			PromoCode:    &code,
+			Items:        []services.CreateOrderItemRequest{{ProductID: matchaUUID, Quantity: 1}},
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromoCodeUsageLimit)
+	})
+
+	t.Run("error - minimum spend not met", func(t *testing.T) {
+		service, m := newService()
+		promotion := percentOff()
+		promotion.MinSpend = 75000
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(promotion, nil)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.ErrorIs(t, err, services.ErrPromoMinSpendNotMet)
+	})
+
+	t.Run("error - no item in scope", func(t *testing.T) {
+		service, m := newService()
+		promotion := percentOff()
+		promotion.Products = []models.Product{{ID: cookie.ID}}
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(promotion, nil)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.ErrorIs(t, err, services.ErrPromoNotApplicable)
+	})
+
+	t.Run("error - limit used up by a concurrent checkout", func(t *testing.T) {
+		service, m := newService()
+		m.promotionRepo.On("FindByCode", "MATCHA20").Return(percentOff(), nil)
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-051", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(repositories.ErrPromotionUsageLimitReached)
+
+		_, err := service.CreateGuestOrder(guestOrder("MATCHA20", services.CreateOrderItemRequest{ProductID: matchaUUID, Quantity: 1}))
+
+		assert.ErrorIs(t, err, services.ErrPromoCodeUsageLimit)
+	})
+}
+
+func TestOrderService_FreeDelivery(t *testing.T) {
+	matchaUUID := uuid.New()
+	matcha := &models.Product{ID: 1, UUID: matchaUUID, Name: "Matcha Latte", BasePrice: 50000, IsAvailable: true}
+	config := testOrderConfig
+	config.Charges = map[models.OrderType]services.OrderTypeCharges{
+		models.OrderTypeDelivery: {TaxRate: 0.1, DeliveryFee: 15000},
+	}
+
+	type deliveryMocks struct {
+		orderRepo     *mocks.MockOrderRepository
+		promotionRepo *mocks.MockPromotionRepository
+	}
+
+	newService := func(automatic ...models.Promotion) (services.OrderService, *deliveryMocks) {
+		m := &deliveryMocks{
+			orderRepo:     new(mocks.MockOrderRepository),
+			promotionRepo: new(mocks.MockPromotionRepository),
+		}
+		m.promotionRepo.On("FindAutomatic").Return(append([]models.Promotion{}, automatic...), nil)
+		productRepo := new(mocks.MockProductRepository)
+		productRepo.On("FindByUUID", matchaUUID).Return(matcha, nil)
+		service := services.NewOrderService(m.orderRepo, productRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), m.promotionRepo, testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, config)
+		return service, m
+	}
+
+	expectCreate := func(m *deliveryMocks, created **models.Order) {
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-070", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				*created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		m.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-070",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceGuest,
+		}, nil)
+	}
+
+	order := func(orderType models.OrderType, lattes int) services.CreateGuestOrderRequest {
+		return services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			OrderType:    orderType,
+			Items:        []services.CreateOrderItemRequest{{ProductID: matchaUUID, Quantity: lattes}},
+		}}
+	}
+
+	freeDelivery := func() models.Promotion {
+		return models.Promotion{
+			ID:            30,
+			Code:          "FREEONGKIR",
+			DiscountType:  models.DiscountTypeFreeDelivery,
+			DiscountValue: 100,
+			MinSpend:      150000,
+			IsActive:      true,
+			Automatic:     true,
+			Stackable:     true,
+		}
+	}
+
+	t.Run("success - delivery fee is waived above the minimum spend", func(t *testing.T) {
+		service, m := newService(freeDelivery())
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(order(models.OrderTypeDelivery, 3))
+
+		require.NoError(t, err)
+		assert.Zero(t, created.Discount)
+		assert.Equal(t, 15000.0, created.Tax)
+		assert.Equal(t, 15000.0, created.DeliveryFee)
+		assert.Equal(t, 15000.0, created.DeliveryDiscount)
+		assert.Equal(t, 165000.0, created.Total)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, "FREEONGKIR", created.Promotions[0].Code)
+		assert.Equal(t, 15000.0, created.Promotions[0].Discount)
+	})
+
+	t.Run("success - stacks with a minimum-spend discount on the items", func(t *testing.T) {
+		tenOff := models.Promotion{
+			ID:            31,
+			Code:          "TENOFF",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 10000,
+			MinSpend:      100000,
+			IsActive:      true,
+			Automatic:     true,
+			Stackable:     true,
+		}
+		service, m := newService(tenOff, freeDelivery())
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(order(models.OrderTypeDelivery, 3))
+
+		require.NoError(t, err)
+		// Tax is on the discounted items only; the waived fee is not taxed
+		assert.Equal(t, 10000.0, created.Discount)
+		assert.Equal(t, 14000.0, created.Tax)
+		assert.Equal(t, 15000.0, created.DeliveryDiscount)
+		assert.Equal(t, 154000.0, created.Total)
+		require.Len(t, created.Promotions, 2)
+	})
+
+	t.Run("success - fee is charged below the minimum spend", func(t *testing.T) {
+		service, m := newService(freeDelivery())
+		var created *models.Order
+		expectCreate(m, &created)
+
+		_, err := service.CreateGuestOrder(order(models.OrderTypeDelivery, 2))
+
+		require.NoError(t, err)
+		assert.Equal(t, 15000.0, created.DeliveryFee)
+		assert.Zero(t, created.DeliveryDiscount)
+		assert.Equal(t, 125000.0, created.Total)
+		assert.Empty(t, created.Promotions)
+	})
+
+	t.Run("error - code on an order without a delivery fee", func(t *testing.T) {
+		service, m := newService()
+		promotion := freeDelivery()
+		promotion.Automatic = false
+		m.promotionRepo.On("FindByCode", "FREEONGKIR").Return(&promotion, nil)
+
+		req := order(models.OrderTypeTakeaway, 3)
+		code := "FREEONGKIR"
+		req.PromoCode = &code
+		_, err := service.CreateGuestOrder(req)
+
+		assert.ErrorIs(t, err, services.ErrPromoNotApplicable)
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_Voucher(t *testing.T) {
+	matchaUUID := uuid.New()
+	matcha := &models.Product{ID: 1, UUID: matchaUUID, Name: "Matcha Latte", BasePrice: 50000, IsAvailable: true}
+	member := &models.User{ID: 3, UUID: uuid.New(), FullName: "Test Member", Role: models.RoleMember}
+	items := []services.CreateOrderItemRequest{{ProductID: matchaUUID, Quantity: 2}}
+
+	type voucherMocks struct {
+		orderRepo     *mocks.MockOrderRepository
+		promotionRepo *mocks.MockPromotionRepository
+		voucherRepo   *mocks.MockVoucherRepository
+	}
+
+	newService := func() (services.OrderService, *voucherMocks) {
+		m := &voucherMocks{
+			orderRepo:     new(mocks.MockOrderRepository),
+			promotionRepo: testPromotions(),
+			voucherRepo:   new(mocks.MockVoucherRepository),
+		}
+		productRepo := new(mocks.MockProductRepository)
+		productRepo.On("FindByUUID", matchaUUID).Return(matcha, nil)
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		service := services.NewOrderService(m.orderRepo, productRepo, userRepo, new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), m.promotionRepo, m.voucherRepo, new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, m
+	}
+
+	maxDiscount := 30000.0
+	halfOff := func() *models.Voucher {
+		return &models.Voucher{
+			ID:            11,
+			Code:          "VC-7KQM-X3TD",
+			UserID:        member.ID,
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 50,
+			MaxDiscount:   &maxDiscount,
+			Status:        models.VoucherStatusActive,
+		}
+	}
+
+	memberOrder := func(code string) services.CreateOrderRequest {
+		return services.CreateOrderRequest{CustomerName: member.FullName, VoucherCode: &code, Items: items}
+	}
+
+	t.Run("success - voucher applies after the promo code and is listed on the order", func(t *testing.T) {
+		service, m := newService()
+		promoCode := "TENOFF"
+		m.promotionRepo.On("FindByCode", promoCode).Return(&models.Promotion{
+			ID:            7,
+			Code:          promoCode,
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+			IsActive:      true,
+		}, nil)
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(halfOff(), nil)
+		var created *models.Order
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-060", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		m.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-060",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceMember,
+		}, nil)
+
+		req := memberOrder("vc7kqmx3td")
+		req.PromoCode = &promoCode
+		_, err := service.CreateOrder(member.UUID, req)
+
+		require.NoError(t, err)
+		// 10% of 100000, then half of the 90000 left, capped at 30000
+		assert.Equal(t, 40000.0, created.Discount)
+		assert.Equal(t, 66000.0, created.Total)
+		require.Len(t, created.Promotions, 2)
+		voucherLine := created.Promotions[1]
+		assert.Equal(t, uint(11), *voucherLine.VoucherID)
+		assert.Nil(t, voucherLine.PromotionID)
+		assert.Equal(t, "VC-7KQM-X3TD", voucherLine.Code)
+		assert.Equal(t, 30000.0, voucherLine.Discount)
+	})
+
+	t.Run("success - free drink reward covers one drink up to its cap", func(t *testing.T) {
+		service, m := newService()
+		maxValue := 45000.0
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(&models.Voucher{
+			ID:            12,
+			Code:          "VC-7KQM-X3TD",
+			UserID:        member.ID,
+			DiscountType:  models.DiscountTypeFreeItem,
+			DiscountValue: 100,
+			MaxDiscount:   &maxValue,
+			Status:        models.VoucherStatusActive,
+		}, nil)
+		var created *models.Order
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-062", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		m.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-062",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceMember,
+		}, nil)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-7KQM-X3TD"))
+
+		require.NoError(t, err)
+		// One of the two Rp50.000 lattes, capped at Rp45.000
+		assert.Equal(t, 45000.0, created.Discount)
+		require.Len(t, created.Promotions, 1)
+		assert.Equal(t, uint(12), *created.Promotions[0].VoucherID)
+	})
+
+	t.Run("error - merch reward does not cover drinks", func(t *testing.T) {
+		service, m := newService()
+		merchID := uint(8)
+		rewardID := uint(2)
+		m.voucherRepo.On("FindByCode", "VC-8MPQ-R2WD").Return(&models.Voucher{
+			ID:            13,
+			Code:          "VC-8MPQ-R2WD",
+			UserID:        member.ID,
+			DiscountType:  models.DiscountTypeFreeItem,
+			DiscountValue: 100,
+			Status:        models.VoucherStatusActive,
+			RewardID:      &rewardID,
+			CategoryID:    &merchID,
+		}, nil)
+		m.voucherRepo.On("CategoryTreeIDs", merchID).Return([]uint{merchID}, nil)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-8MPQ-R2WD"))
+
+		assert.ErrorIs(t, err, services.ErrVoucherNoQualifyingItem)
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - voucher of another member", func(t *testing.T) {
+		service, m := newService()
+		voucher := halfOff()
+		voucher.UserID = 99
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(voucher, nil)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-7KQM-X3TD"))
+
+		assert.ErrorIs(t, err, services.ErrVoucherInvalid)
+		m.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - expired voucher", func(t *testing.T) {
+		service, m := newService()
+		voucher := halfOff()
+		expired := time.Now().Add(-time.Hour)
+		voucher.ExpiresAt = &expired
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(voucher, nil)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-7KQM-X3TD"))
+
+		assert.ErrorIs(t, err, services.ErrVoucherInvalid)
+	})
+
+	t.Run("error - guests cannot redeem vouchers", func(t *testing.T) {
+		service, m := newService()
+
+		_, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: memberOrder("VC-7KQM-X3TD")})
+
+		assert.ErrorIs(t, err, services.ErrVoucherInvalid)
+		m.voucherRepo.AssertNotCalled(t, "FindByCode", mock.Anything)
+	})
+
+	t.Run("error - order below the voucher minimum spend", func(t *testing.T) {
+		service, m := newService()
+		voucher := halfOff()
+		voucher.MinSpend = 150000
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(voucher, nil)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-7KQM-X3TD"))
+
+		assert.ErrorIs(t, err, services.ErrVoucherMinSpendNotMet)
+	})
+
+	t.Run("error - voucher used by a concurrent checkout", func(t *testing.T) {
+		service, m := newService()
+		m.voucherRepo.On("FindByCode", "VC-7KQM-X3TD").Return(halfOff(), nil)
+		m.orderRepo.On("GenerateOrderNumber").Return("MC-260109-061", nil)
+		m.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(repositories.ErrVoucherNotRedeemable)
+
+		_, err := service.CreateOrder(member.UUID, memberOrder("VC-7KQM-X3TD"))
+
+		assert.ErrorIs(t, err, services.ErrVoucherInvalid)
+	})
+}
+
+func TestOrderService_Tip(t *testing.T) {
+	t.Run("success - tip is recorded outside the total", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUID", productUUID).Return(&models.Product{
+			ID:          1,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   45000,
+			IsAvailable: true,
+		}, nil)
+
+		var created *models.Order
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-060", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260109-060",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceGuest,
+			Total:       49500,
+			TipAmount:   5000,
+		}, nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			TipAmount:    5000,
+			Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+		}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 49500.0, created.Total)
+		assert.Equal(t, 5000.0, created.TipAmount)
+		assert.Equal(t, 54500.0, created.AmountDue())
+		assert.Equal(t, 5000.0, result.TipAmount)
+	})
+}
+
+func TestOrderService_OrderType(t *testing.T) {
+	config := testOrderConfig
+	config.Charges = map[models.OrderType]services.OrderTypeCharges{
+		models.OrderTypeDineIn:   {TaxRate: 0.10, ServiceChargeRate: 0.05},
+		models.OrderTypeTakeaway: {TaxRate: 0.10},
+		models.OrderTypeDelivery: {TaxRate: 0.11},
+	}
+
+	placeOrder := func(t *testing.T, orderType models.OrderType) *models.Order {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, config)
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUID", productUUID).Return(&models.Product{
+			ID:          1,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   45000,
+			IsAvailable: true,
+		}, nil)
+
+		var created *models.Order
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260110-001", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260110-001",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceGuest,
+		}, nil)
+
+		_, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			OrderType:    orderType,
+			Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+		}})
+		assert.NoError(t, err)
+		return created
+	}
+
+	t.Run("success - dine-in pays a taxed service charge", func(t *testing.T) {
+		created := placeOrder(t, models.OrderTypeDineIn)
+
+		assert.Equal(t, models.OrderTypeDineIn, created.OrderType)
+		assert.Equal(t, 2250.0, created.ServiceCharge)
+		assert.InDelta(t, 4725.0, created.Tax, 0.001)
+		assert.InDelta(t, 51975.0, created.Total, 0.001)
+	})
+
+	t.Run("success - delivery uses its own tax rate", func(t *testing.T) {
+		created := placeOrder(t, models.OrderTypeDelivery)
+
+		assert.Equal(t, 0.0, created.ServiceCharge)
+		assert.InDelta(t, 4950.0, created.Tax, 0.001)
+		assert.InDelta(t, 49950.0, created.Total, 0.001)
+	})
+
+	t.Run("success - orders without a type default to takeaway", func(t *testing.T) {
+		created := placeOrder(t, "")
+
+		assert.Equal(t, models.OrderTypeTakeaway, created.OrderType)
+		assert.Equal(t, 0.0, created.ServiceCharge)
+		assert.InDelta(t, 49500.0, created.Total, 0.001)
+	})
+
+	t.Run("success - totals verify against the order type rates", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, config)
+
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			UUID:          orderUUID,
+			OrderNumber:   "MC-260110-002",
+			OrderType:     models.OrderTypeDineIn,
+			Subtotal:      45000,
+			ServiceCharge: 2250,
+			Tax:           4725,
+			Total:         51975,
+			Items: []models.OrderItem{
+				{UUID: uuid.New(), Quantity: 1, UnitPrice: 45000, Subtotal: 45000},
+			},
+		}, nil)
+
+		result, err := service.VerifyTotals(orderUUID)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Consistent)
+		assert.Equal(t, 2250.0, result.Recomputed.ServiceCharge)
+	})
+}
+
+func TestOrderService_Table(t *testing.T) {
+	newService := func() (services.OrderService, *mocks.MockOrderRepository, *mocks.MockDiningTableRepository, uuid.UUID) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockTableRepo := new(mocks.MockDiningTableRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), mockTableRepo, testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUID", productUUID).Return(&models.Product{
+			ID:          1,
+			UUID:        productUUID,
+			Name:        "Matcha Latte",
+			BasePrice:   45000,
+			IsAvailable: true,
+		}, nil)
+		return service, mockOrderRepo, mockTableRepo, productUUID
+	}
+
+	t.Run("success - order is served to the scanned table", func(t *testing.T) {
+		service, mockOrderRepo, mockTableRepo, productUUID := newService()
+		tableUUID := uuid.New()
+		mockTableRepo.On("FindByUUID", tableUUID).Return(&models.DiningTable{ID: 4, UUID: tableUUID, Number: "12", IsActive: true}, nil)
+
+		var created *models.Order
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260111-001", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Order)
+			}).
+			Return(nil)
+		tableNumber := "12"
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:        uuid.New(),
+			OrderNumber: "MC-260111-001",
+			Status:      models.OrderStatusPending,
+			OrderSource: models.OrderSourceGuest,
+			OrderType:   models.OrderTypeDineIn,
+			TableNumber: &tableNumber,
+		}, nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			TableID:      &tableUUID,
+			Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+		}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), *created.TableID)
+		assert.Equal(t, "12", *created.TableNumber)
+		assert.Equal(t, models.OrderTypeDineIn, created.OrderType)
+		assert.Equal(t, "12", *result.TableNumber)
+	})
+
+	t.Run("error - inactive table", func(t *testing.T) {
+		service, mockOrderRepo, mockTableRepo, productUUID := newService()
+		tableUUID := uuid.New()
+		mockTableRepo.On("FindByUUID", tableUUID).Return(&models.DiningTable{ID: 4, UUID: tableUUID, Number: "12", IsActive: false}, nil)
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			TableID:      &tableUUID,
+			Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+		}})
+
+		assert.ErrorIs(t, err, services.ErrInvalidTable)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - table order for takeaway", func(t *testing.T) {
+		service, _, mockTableRepo, productUUID := newService()
+		tableUUID := uuid.New()
+
+		result, err := service.CreateGuestOrder(services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			OrderType:    models.OrderTypeTakeaway,
+			TableID:      &tableUUID,
+			Items:        []services.CreateOrderItemRequest{{ProductID: productUUID, Quantity: 1}},
+		}})
+
+		assert.ErrorIs(t, err, services.ErrTableOrderNotDineIn)
+		assert.Nil(t, result)
+		mockTableRepo.AssertNotCalled(t, "FindByUUID", mock.Anything)
+	})
+}
+
+func TestOrderService_LookupGuestOrder(t *testing.T) {
+	newService := func() (services.OrderService, *mocks.MockOrderRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+		return service, mockOrderRepo
+	}
+	phone := "0812-3456-7890"
+	guestOrder := func() *models.Order {
+		return &models.Order{
+			UUID:          uuid.New(),
+			OrderNumber:   "MC-260112-004",
+			CustomerName:  "Sari Wulandari",
+			CustomerPhone: &phone,
+			Status:        models.OrderStatusPreparing,
+			OrderSource:   models.OrderSourceGuest,
+		}
+	}
+
+	t.Run("success - phone matches regardless of formatting", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		mockOrderRepo.On("FindByOrderNumber", "MC-260112-004").Return(guestOrder(), nil)
+
+		result, err := service.LookupGuestOrder(services.LookupOrderRequest{
+			OrderNumber: " mc-260112-004 ",
+			Phone:       "0812 3456 7890",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-260112-004", result.OrderNumber)
+		assert.Equal(t, models.OrderStatusPreparing, result.Status)
+	})
+
+	t.Run("success - name matches case-insensitively", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		mockOrderRepo.On("FindByOrderNumber", "MC-260112-004").Return(guestOrder(), nil)
+
+		result, err := service.LookupGuestOrder(services.LookupOrderRequest{
+			OrderNumber:  "MC-260112-004",
+			CustomerName: "sari wulandari",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-260112-004", result.OrderNumber)
+	})
+
+	t.Run("error - wrong phone is reported as not found", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		mockOrderRepo.On("FindByOrderNumber", "MC-260112-004").Return(guestOrder(), nil)
+
+		result, err := service.LookupGuestOrder(services.LookupOrderRequest{
+			OrderNumber: "MC-260112-004",
+			Phone:       "081299999999",
+		})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - member orders cannot be looked up", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		order := guestOrder()
+		userID := uint(7)
+		order.UserID = &userID
+		mockOrderRepo.On("FindByOrderNumber", "MC-260112-004").Return(order, nil)
+
+		result, err := service.LookupGuestOrder(services.LookupOrderRequest{
+			OrderNumber: "MC-260112-004",
+			Phone:       phone,
+		})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - unknown order number", func(t *testing.T) {
+		service, mockOrderRepo := newService()
+		mockOrderRepo.On("FindByOrderNumber", "MC-260112-999").Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.LookupGuestOrder(services.LookupOrderRequest{
+			OrderNumber: "MC-260112-999",
+			Phone:       phone,
+		})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestOrderService_Priority(t *testing.T) {
+	newService := func(config services.OrderConfig) (services.OrderService, *mocks.MockOrderRepository) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, config)
+		return service, mockOrderRepo
+	}
+
+	t.Run("success - staff rush an order in preparation", func(t *testing.T) {
+		service, mockOrderRepo := newService(testOrderConfig)
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:       1,
+			UUID:     orderUUID,
+			Status:   models.OrderStatusPreparing,
+			Priority: models.PriorityNormal,
+		}, nil)
+		mockOrderRepo.On("UpdatePriority", uint(1), models.PriorityRush).Return(nil)
+
+		result, err := service.UpdatePriority(orderUUID, models.PriorityRush)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PriorityRush, result.Priority)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - ready orders keep their priority", func(t *testing.T) {
+		service, mockOrderRepo := newService(testOrderConfig)
+		orderUUID := uuid.New()
+		mockOrderRepo.On("FindByUUID", orderUUID).Return(&models.Order{
+			ID:       1,
+			UUID:     orderUUID,
+			Status:   models.OrderStatusReady,
+			Priority: models.PriorityNormal,
+		}, nil)
+
+		result, err := service.UpdatePriority(orderUUID, models.PriorityRush)
+
+		assert.ErrorIs(t, err, services.ErrPriorityNotEditable)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "UpdatePriority", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - kitchen queue lists rush orders first", func(t *testing.T) {
+		service, mockOrderRepo := newService(testOrderConfig)
+		now := time.Now()
+		mockOrderRepo.On("FindByStatuses", mock.Anything).Return([]models.Order{
+			{UUID: uuid.New(), OrderNumber: "MC-260109-001", Status: models.OrderStatusPreparing, Priority: models.PriorityNormal, CreatedAt: now.Add(-10 * time.Minute)},
+			{UUID: uuid.New(), OrderNumber: "MC-260109-002", Status: models.OrderStatusPreparing, Priority: models.PriorityRush, CreatedAt: now.Add(-5 * time.Minute)},
+			{UUID: uuid.New(), OrderNumber: "MC-260109-003", Status: models.OrderStatusPreparing, Priority: models.PriorityNormal, CreatedAt: now.Add(-3 * time.Minute)},
+			{UUID: uuid.New(), OrderNumber: "MC-260109-004", Status: models.OrderStatusPreparing, Priority: models.PriorityRush, CreatedAt: now.Add(-time.Minute)},
+		}, nil)
+
+		queue, err := service.GetKitchenQueue()
+
+		assert.NoError(t, err)
+		numbers := make([]string, 0, len(queue.Preparing))
+		for _, order := range queue.Preparing {
+			numbers = append(numbers, order.OrderNumber)
+		}
+		assert.Equal(t, []string{"MC-260109-002", "MC-260109-004", "MC-260109-001", "MC-260109-003"}, numbers)
+	})
+
+	t.Run("success - delayed orders are rushed automatically", func(t *testing.T) {
+		config := testOrderConfig
+		config.RushAfter = 15 * time.Minute
+		service, mockOrderRepo := newService(config)
+		now := time.Now()
+		releasedAt := now.Add(-5 * time.Minute)
+		mockOrderRepo.On("FindByStatuses", []models.OrderStatus{models.OrderStatusPreparing}).Return([]models.Order{
+			{ID: 1, UUID: uuid.New(), Status: models.OrderStatusPreparing, Priority: models.PriorityNormal, CreatedAt: now.Add(-20 * time.Minute)},
+			{ID: 2, UUID: uuid.New(), Status: models.OrderStatusPreparing, Priority: models.PriorityRush, CreatedAt: now.Add(-30 * time.Minute)},
+			{ID: 3, UUID: uuid.New(), Status: models.OrderStatusPreparing, Priority: models.PriorityNormal, CreatedAt: now.Add(-5 * time.Minute)},
+			{ID: 4, UUID: uuid.New(), Status: models.OrderStatusPreparing, Priority: models.PriorityNormal, CreatedAt: now.Add(-48 * time.Hour), ReleasedAt: &releasedAt},
+		}, nil)
+		mockOrderRepo.On("UpdatePriority", uint(1), models.PriorityRush).Return(nil)
+
+		rushed, err := service.RushDelayedOrders()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, rushed)
+		mockOrderRepo.AssertNumberOfCalls(t, "UpdatePriority", 1)
+	})
+
+	t.Run("success - escalation is off without a threshold", func(t *testing.T) {
+		service, mockOrderRepo := newService(testOrderConfig)
+
+		rushed, err := service.RushDelayedOrders()
+
+		assert.NoError(t, err)
+		assert.Zero(t, rushed)
+		mockOrderRepo.AssertNotCalled(t, "FindByStatuses", mock.Anything)
+	})
+}
+
+func TestOrderService_ScheduledOrders(t *testing.T) {
+	config := testOrderConfig
+	config.PreorderWindow = 7 * 24 * time.Hour
+	config.PreorderLeadTime = 30 * time.Minute
+	config.ShareTokenTTL = 24 * time.Hour
+
+	type fixture struct {
+		service         services.OrderService
+		orderRepo       *mocks.MockOrderRepository
+		productRepo     *mocks.MockProductRepository
+		reservationRepo *mocks.MockStockReservationRepository
+	}
+	newFixture := func(config services.OrderConfig) *fixture {
+		f := &fixture{
+			orderRepo:       new(mocks.MockOrderRepository),
+			productRepo:     new(mocks.MockProductRepository),
+			reservationRepo: new(mocks.MockStockReservationRepository),
+		}
+		f.service = services.NewOrderService(f.orderRepo, f.productRepo, new(mocks.MockUserRepository), f.reservationRepo, new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, config)
+		return f
+	}
+	preorder := func(scheduledFor time.Time) services.CreateGuestOrderRequest {
+		return services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+			CustomerName: "Guest Customer",
+			ScheduledFor: &scheduledFor,
+			Items:        []services.CreateOrderItemRequest{{ProductID: uuid.New(), Quantity: 2}},
+		}}
+	}
+
+	t.Run("success - pre-order waits scheduled with its stock held until release", func(t *testing.T) {
+		f := newFixture(config)
+		stock := 5
+		f.productRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Product{
+			ID:            7,
+			Name:          "Flash Sale Matcha",
+			BasePrice:     30000,
+			IsAvailable:   true,
+			StockQuantity: &stock,
+		}, nil)
+		f.reservationRepo.On("AvailableQuantity", uint(7)).Return(5, nil)
+		f.orderRepo.On("GenerateOrderNumber").Return("MC-260109-030", nil)
+
+		var saved *models.Order
+		f.orderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Order)
+				saved.ID = 42
+			}).
+			Return(nil)
+
+		scheduledFor := time.Now().Add(26 * time.Hour)
+		f.reservationRepo.On("ReserveForOrder", uint(42), mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+			return expiresAt.Equal(scheduledFor.Add(-30 * time.Minute).Add(10 * time.Minute))
+		})).Return(nil)
+		f.orderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:         uuid.New(),
+			OrderNumber:  "MC-260109-030",
+			Status:       models.OrderStatusScheduled,
+			ScheduledFor: &scheduledFor,
+			Items:        []models.OrderItem{},
+		}, nil)
+
+		result, err := f.service.CreateGuestOrder(preorder(scheduledFor))
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrderStatusScheduled, saved.Status)
+		assert.Equal(t, &scheduledFor, saved.ScheduledFor)
+		assert.Nil(t, saved.PaymentExpiresAt)
+		assert.NotNil(t, result.ScheduledFor)
+		// The status link outlives the order's scheduled time
+		assert.Len(t, *saved.ShareToken, 64)
+		assert.True(t, saved.ShareTokenExpiresAt.Equal(scheduledFor.Add(24*time.Hour)))
+		f.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - scheduled time outside the pre-order window", func(t *testing.T) {
+		for name, scheduledFor := range map[string]time.Time{
+			"within the lead time": time.Now().Add(20 * time.Minute),
+			"too far ahead":        time.Now().Add(8 * 24 * time.Hour),
+		} {
+			f := newFixture(config)
+
+			result, err := f.service.CreateGuestOrder(preorder(scheduledFor))
+
+			assert.ErrorIs(t, err, services.ErrInvalidScheduledTime, name)
+			assert.Nil(t, result, name)
+			f.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("error - pre-orders turned off", func(t *testing.T) {
+		f := newFixture(testOrderConfig)
+
+		_, err := f.service.CreateGuestOrder(preorder(time.Now().Add(24 * time.Hour)))
+
+		assert.ErrorIs(t, err, services.ErrPreordersDisabled)
+	})
+
+	t.Run("success - due pre-orders are released with a payment deadline", func(t *testing.T) {
+		f := newFixture(config)
+		soon := time.Now().Add(20 * time.Minute)
+		f.orderRepo.On("FindDueScheduled", mock.AnythingOfType("time.Time")).Return([]models.Order{
+			{ID: 1, UUID: uuid.New(), Status: models.OrderStatusScheduled, ScheduledFor: &soon},
+			{ID: 2, UUID: uuid.New(), Status: models.OrderStatusScheduled, ScheduledFor: &soon},
+		}, nil)
+		f.orderRepo.On("ReleaseScheduled", uint(1), mock.MatchedBy(func(deadline *time.Time) bool {
+			return deadline != nil && deadline.After(time.Now().Add(29*time.Minute))
+		})).Return(true, nil)
+		// Cancelled by the customer since it was read
+		f.orderRepo.On("ReleaseScheduled", uint(2), mock.Anything).Return(false, nil)
+
+		released, err := f.service.ReleaseScheduledOrders()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+		f.orderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_Timeslots(t *testing.T) {
+	config := testOrderConfig
+	config.PreorderWindow = 7 * 24 * time.Hour
+	config.PreorderLeadTime = 30 * time.Minute
+	settings := newTestSettings(&services.TimeslotSettings{MaxOrders: 2, MaxDrinks: 10})
+
+	slot := time.Now().Add(26 * time.Hour).Truncate(services.TimeslotLength)
+	scheduledFor := slot.Add(5 * time.Minute)
+	req := services.CreateGuestOrderRequest{CreateOrderRequest: services.CreateOrderRequest{
+		CustomerName: "Guest Customer",
+		ScheduledFor: &scheduledFor,
+		Items:        []services.CreateOrderItemRequest{{ProductID: uuid.New(), Quantity: 2}},
+	}}
+
+	t.Run("success - order fits in its timeslot", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewOrderService(mockOrderRepo, mockProductRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), settings, testPickupCodes, testEvents, testLogger, config)
+
+		mockOrderRepo.On("FindPickupLoads", slot, mock.AnythingOfType("time.Time")).Return([]repositories.PickupLoad{
+			{PickupAt: slot.Add(10 * time.Minute), Drinks: 8},
+		}, nil)
+		mockProductRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Product{
+			ID:          1,
+			Name:        "Matcha Latte",
+			BasePrice:   30000,
+			IsAvailable: true,
+		}, nil)
+		mockOrderRepo.On("GenerateOrderNumber").Return("MC-260109-031", nil)
+		mockOrderRepo.On("Create", mock.AnythingOfType("*models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(nil)
+		mockOrderRepo.On("FindByUUID", mock.AnythingOfType("uuid.UUID")).Return(&models.Order{
+			UUID:         uuid.New(),
+			OrderNumber:  "MC-260109-031",
+			Status:       models.OrderStatusScheduled,
+			ScheduledFor: &scheduledFor,
+			Items:        []models.OrderItem{},
+		}, nil)
+
+		result, err := service.CreateGuestOrder(req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - full timeslot offers the next open ones", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := services.NewOrderService(mockOrderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), settings, testPickupCodes, testEvents, testLogger, config)
+
+		mockOrderRepo.On("FindPickupLoads", slot, mock.AnythingOfType("time.Time")).Return([]repositories.PickupLoad{
+			// Out of orders
+			{PickupAt: slot, Drinks: 1},
+			{PickupAt: slot.Add(14 * time.Minute), Drinks: 1},
+			// Out of drinks for two more
+			{PickupAt: slot.Add(15 * time.Minute), Drinks: 9},
+		}, nil)
+
+		result, err := service.CreateGuestOrder(req)
+
+		assert.ErrorIs(t, err, services.ErrTimeslotFull)
+		assert.Nil(t, result)
+		var full *services.TimeslotFullError
+		if assert.ErrorAs(t, err, &full) {
+			starts := make([]string, 0, len(full.NextAvailable))
+			for _, timeslot := range full.NextAvailable {
+				starts = append(starts, timeslot.StartsAt)
+			}
+			assert.Equal(t, []string{
+				slot.Add(30 * time.Minute).Format("2006-01-02T15:04:05Z07:00"),
+				slot.Add(45 * time.Minute).Format("2006-01-02T15:04:05Z07:00"),
+				slot.Add(60 * time.Minute).Format("2006-01-02T15:04:05Z07:00"),
+			}, starts)
+		}
+		mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_VerifyPickup(t *testing.T) {
+	newService := func(orderRepo *mocks.MockOrderRepository) services.OrderService {
+		return services.NewOrderService(orderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+	}
+	code := testPickupCodes.Sign("MC-250107-001")
+
+	t.Run("success - ready order is completed", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := newService(mockOrderRepo)
+
+		order := &models.Order{ID: 1, UUID: uuid.New(), OrderNumber: "MC-250107-001", Status: models.OrderStatusReady}
+		mockOrderRepo.On("FindByOrderNumber", "MC-250107-001").Return(order, nil)
+		mockOrderRepo.On("FindByUUID", order.UUID).Return(order, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusCompleted).Return(nil)
+		mockOrderRepo.On("FindByUUID", order.UUID).Return(&models.Order{
+			ID:          1,
+			UUID:        order.UUID,
+			OrderNumber: "MC-250107-001",
+			Status:      models.OrderStatusCompleted,
+		}, nil)
+
+		result, err := service.VerifyPickup(services.VerifyPickupRequest{Code: code})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrderStatusCompleted, result.Status)
+		assert.Nil(t, result.PickupCode)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - code signed for another order", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := newService(mockOrderRepo)
+
+		forged := "MC-250107-002" + code[len("MC-250107-001"):]
+
+		result, err := service.VerifyPickup(services.VerifyPickupRequest{Code: forged})
+
+		assert.ErrorIs(t, err, services.ErrInvalidPickupCode)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertNotCalled(t, "FindByOrderNumber", mock.Anything)
+	})
+
+	t.Run("error - order not ready or already picked up", func(t *testing.T) {
+		for status, expected := range map[models.OrderStatus]error{
+			models.OrderStatusPreparing: services.ErrOrderNotReadyForPickup,
+			models.OrderStatusCompleted: services.ErrOrderAlreadyPickedUp,
+		} {
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			service := newService(mockOrderRepo)
+
+			mockOrderRepo.On("FindByOrderNumber", "MC-250107-001").Return(&models.Order{
+				ID:          1,
+				OrderNumber: "MC-250107-001",
+				Status:      status,
+			}, nil)
+
+			result, err := service.VerifyPickup(services.VerifyPickupRequest{Code: code})
+
+			assert.ErrorIs(t, err, expected, status)
+			assert.Nil(t, result)
+			mockOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestOrderService_GetStatusByShareToken(t *testing.T) {
+	newService := func(orderRepo *mocks.MockOrderRepository) services.OrderService {
+		return services.NewOrderService(orderRepo, new(mocks.MockProductRepository), new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockSourcePricingRepository), testPromotions(), testVouchers(), new(mocks.MockLoyaltyRepository), new(mocks.MockDiningTableRepository), testSettings, testPickupCodes, testEvents, testLogger, testOrderConfig)
+	}
+
+	t.Run("success - status page without prices or order ID", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := newService(mockOrderRepo)
+
+		expiresAt := time.Now().Add(time.Hour)
+		mockOrderRepo.On("FindByShareToken", "abc123").Return(&models.Order{
+			OrderNumber:         "MC-250107-001",
+			CustomerName:        "Guest Customer",
+			Status:              models.OrderStatusPreparing,
+			ShareTokenExpiresAt: &expiresAt,
+			Items: []models.OrderItem{
+				{ProductName: "Matcha Latte", Quantity: 2, UnitPrice: 30000},
+			},
+		}, nil)
+
+		result, err := service.GetStatusByShareToken("abc123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-250107-001", result.OrderNumber)
+		assert.Equal(t, models.OrderStatusPreparing, result.Status)
+		assert.Equal(t, []services.OrderStatusItem{{ProductName: "Matcha Latte", Quantity: 2}}, result.Items)
+	})
+
+	t.Run("error - expired token", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := newService(mockOrderRepo)
+
+		expiresAt := time.Now().Add(-time.Minute)
+		mockOrderRepo.On("FindByShareToken", "abc123").Return(&models.Order{
+			OrderNumber:         "MC-250107-001",
+			ShareTokenExpiresAt: &expiresAt,
+		}, nil)
+
+		result, err := service.GetStatusByShareToken("abc123")
+
+		assert.ErrorIs(t, err, services.ErrShareTokenInvalid)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - unknown or revoked token", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		service := newService(mockOrderRepo)
+
+		mockOrderRepo.On("FindByShareToken", "abc123").Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.GetStatusByShareToken("abc123")
+
+		assert.ErrorIs(t, err, services.ErrShareTokenInvalid)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPaymentPageURL = "https://app.sandbox.midtrans.com/snap/v4/redirection/snap-token"
+
+// fakeCheckoutPaymentService opens payment pages without a gateway. Methods
+// payment links do not use are left to the embedded nil interface.
+type fakeCheckoutPaymentService struct {
+	services.PaymentService
+	err    error
+	opened []uuid.UUID
+}
+
+func (f *fakeCheckoutPaymentService) CreatePaymentToken(orderUUID uuid.UUID, req services.CreatePaymentTokenRequest) (*services.PaymentTokenResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.opened = append(f.opened, orderUUID)
+	return &services.PaymentTokenResponse{RedirectURL: testPaymentPageURL}, nil
+}
+
+type paymentLinkDeps struct {
+	linkRepo  *mocks.MockPaymentLinkRepository
+	orderRepo *mocks.MockOrderRepository
+	userRepo  *mocks.MockUserRepository
+	payments  *fakeCheckoutPaymentService
+}
+
+func newPaymentLinkService() (services.PaymentLinkService, *paymentLinkDeps) {
+	deps := &paymentLinkDeps{
+		linkRepo:  new(mocks.MockPaymentLinkRepository),
+		orderRepo: new(mocks.MockOrderRepository),
+		userRepo:  new(mocks.MockUserRepository),
+		payments:  &fakeCheckoutPaymentService{},
+	}
+	service := services.NewPaymentLinkService(deps.linkRepo, deps.orderRepo, deps.userRepo, deps.payments, "https://api.example.com", 30*time.Minute)
+	return service, deps
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestPaymentLinkService_Create(t *testing.T) {
+	staff := &models.User{ID: 3, UUID: uuid.New(), Role: models.RoleBarista}
+
+	t.Run("success - link stores only the token hash", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		order := pendingCounterOrder()
+		var saved *models.PaymentLink
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.linkRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.PaymentLink)
+		}).Return(nil)
+
+		link, err := service.Create(order.UUID, staff.UUID)
+
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(link.URL, "https://api.example.com/api/v1/pay/"))
+		token := strings.TrimPrefix(link.URL, "https://api.example.com/api/v1/pay/")
+		assert.Equal(t, hashToken(token), saved.TokenHash)
+		assert.Equal(t, order.ID, saved.OrderID)
+		assert.Equal(t, staff.ID, *saved.CreatedBy)
+		assert.Equal(t, 42500.0, link.Amount)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), saved.ExpiresAt, time.Minute)
+	})
+
+	t.Run("success - expires with the order's payment deadline", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		order := pendingCounterOrder()
+		deadline := time.Now().Add(10 * time.Minute)
+		order.PaymentExpiresAt = &deadline
+		var saved *models.PaymentLink
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.linkRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.PaymentLink)
+		}).Return(nil)
+
+		_, err := service.Create(order.UUID, staff.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, deadline, saved.ExpiresAt)
+	})
+
+	t.Run("error - order already paid", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		order := pendingCounterOrder()
+		order.Status = models.OrderStatusPreparing
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		link, err := service.Create(order.UUID, staff.UUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotAwaitingPayment)
+		assert.Nil(t, link)
+		deps.linkRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestPaymentLinkService_Open(t *testing.T) {
+	const token = "0123456789abcdef0123456789abcdef"
+	newLink := func() *models.PaymentLink {
+		order := pendingCounterOrder()
+		return &models.PaymentLink{ID: 7, OrderID: order.ID, Order: order, TokenHash: hashToken(token), ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	t.Run("success - opens the payment page", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		link := newLink()
+		deps.linkRepo.On("FindByTokenHash", hashToken(token)).Return(link, nil)
+
+		redirectURL, err := service.Open(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPaymentPageURL, redirectURL)
+		assert.Equal(t, []uuid.UUID{link.Order.UUID}, deps.payments.opened)
+	})
+
+	t.Run("success - opening again after a link preview still works", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		link := newLink()
+		deps.linkRepo.On("FindByTokenHash", hashToken(token)).Return(link, nil)
+
+		_, err := service.Open(token)
+		require.NoError(t, err)
+		redirectURL, err := service.Open(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPaymentPageURL, redirectURL)
+		assert.Len(t, deps.payments.opened, 2)
+	})
+
+	t.Run("error - order already paid", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		link := newLink()
+		link.Order.Status = models.OrderStatusPreparing
+		deps.linkRepo.On("FindByTokenHash", hashToken(token)).Return(link, nil)
+
+		_, err := service.Open(token)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotAwaitingPayment)
+		assert.Empty(t, deps.payments.opened)
+	})
+
+	t.Run("error - expired link", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		link := newLink()
+		link.ExpiresAt = time.Now().Add(-time.Second)
+		deps.linkRepo.On("FindByTokenHash", hashToken(token)).Return(link, nil)
+
+		_, err := service.Open(token)
+
+		assert.ErrorIs(t, err, services.ErrPaymentLinkInvalid)
+	})
+
+	t.Run("error - unknown token", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		deps.linkRepo.On("FindByTokenHash", hashToken("nope")).Return(nil, repositories.ErrPaymentLinkNotFound)
+
+		_, err := service.Open("nope")
+
+		assert.ErrorIs(t, err, services.ErrPaymentLinkInvalid)
+	})
+
+	t.Run("error - gateway failure", func(t *testing.T) {
+		service, deps := newPaymentLinkService()
+		link := newLink()
+		deps.payments.err = errors.New("gateway down")
+		deps.linkRepo.On("FindByTokenHash", hashToken(token)).Return(link, nil)
+
+		_, err := service.Open(token)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,1123 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStripeWebhookSecret = "whsec_test"
+	testXenditCallbackToken = "xendit-callback-token"
+)
+
+var testPaymentConfig = services.PaymentConfig{
+	Provider:            models.PaymentMethodMidtrans,
+	MidtransServerKey:   "SB-Mid-server-test",
+	StripeWebhookSecret: testStripeWebhookSecret,
+	XenditCallbackToken: testXenditCallbackToken,
+	GatewayFees: services.GatewayFees{
+		"stripe_checkout": {Percent: 2.9, Fixed: 2000},
+		"ovo":             {Percent: 1.5},
+	},
+}
+
+type paymentDeps struct {
+	paymentRepo     *mocks.MockPaymentRepository
+	refundRepo      *mocks.MockRefundRepository
+	orderRepo       *mocks.MockOrderRepository
+	userRepo        *mocks.MockUserRepository
+	reservationRepo *mocks.MockStockReservationRepository
+	walletRepo      *mocks.MockWalletRepository
+	giftCardRepo    *mocks.MockGiftCardRepository
+	loyaltyRepo     *mocks.MockLoyaltyRepository
+	settingRepo     *mocks.MockSettingRepository
+}
+
+func newPaymentService() (services.PaymentService, *paymentDeps) {
+	deps := &paymentDeps{
+		paymentRepo:     new(mocks.MockPaymentRepository),
+		refundRepo:      new(mocks.MockRefundRepository),
+		orderRepo:       new(mocks.MockOrderRepository),
+		userRepo:        new(mocks.MockUserRepository),
+		reservationRepo: new(mocks.MockStockReservationRepository),
+		walletRepo:      new(mocks.MockWalletRepository),
+		giftCardRepo:    new(mocks.MockGiftCardRepository),
+		loyaltyRepo:     new(mocks.MockLoyaltyRepository),
+		settingRepo:     new(mocks.MockSettingRepository),
+	}
+	service := services.NewPaymentService(deps.paymentRepo, deps.refundRepo, deps.orderRepo, deps.userRepo, deps.reservationRepo, deps.walletRepo, deps.giftCardRepo, deps.loyaltyRepo, services.NewSettingsService(deps.settingRepo), testAlerts, testEvents, testLogger, testPaymentConfig)
+	return service, deps
+}
+
+func pendingCounterOrder() *models.Order {
+	return &models.Order{
+		ID:          10,
+		UUID:        uuid.New(),
+		OrderNumber: "MC-250107-001",
+		Status:      models.OrderStatusPending,
+		Total:       41500,
+		TipAmount:   1000,
+	}
+}
+
+func TestPaymentService_RecordCashPayment(t *testing.T) {
+	staff := &models.User{ID: 3, UUID: uuid.New(), Role: models.RoleBarista}
+
+	t.Run("success - records the cash and change and settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		var saved *models.Payment
+		deps.paymentRepo.On("CreateSettled", mock.Anything, models.OrderStatusPending, models.OrderStatusPreparing).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.Payment)
+		}).Return(nil)
+
+		sub := testEvents.Subscribe(services.OrdersTopic)
+		defer sub.Close()
+
+		response, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 50000})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+		assert.Equal(t, 42500.0, response.AmountDue)
+		assert.Equal(t, 7500.0, response.Change)
+
+		assert.Equal(t, models.PaymentMethodCash, saved.Method)
+		assert.Equal(t, 42500.0, saved.GrossAmount)
+		assert.Equal(t, models.TransactionStatusSettlement, *saved.TransactionStatus)
+		assert.Equal(t, 50000.0, *saved.CashTendered)
+		assert.Equal(t, 7500.0, *saved.ChangeGiven)
+		assert.Equal(t, staff.ID, *saved.RecordedBy)
+
+		select {
+		case payload := <-sub.C:
+			assert.Contains(t, string(payload), `"previous_status":"pending"`)
+		case <-time.After(time.Second):
+			t.Fatal("expected an order event")
+		}
+	})
+
+	t.Run("success - takes the deposit and holds the order for the balance", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		order.DepositAmount = 12500
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		deps.paymentRepo.On("CreateSettled", mock.Anything, models.OrderStatusPending, models.OrderStatusDepositPaid).Return(nil)
+
+		response, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 20000})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusDepositPaid, response.OrderStatus)
+		assert.Equal(t, 12500.0, response.AmountDue)
+		assert.Equal(t, 7500.0, response.Change)
+	})
+
+	t.Run("success - takes the balance after a deposit", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		order.Status = models.OrderStatusDepositPaid
+		order.DepositAmount = 12500
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		deps.paymentRepo.On("CreateSettled", mock.Anything, models.OrderStatusDepositPaid, models.OrderStatusPreparing).Return(nil)
+
+		response, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 30000})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+		assert.Equal(t, 30000.0, response.AmountDue)
+		assert.Equal(t, 0.0, response.Change)
+	})
+
+	t.Run("error - not enough cash tendered", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		_, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 42000})
+
+		assert.ErrorIs(t, err, services.ErrCashTenderedTooLow)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+		deps.paymentRepo.AssertNotCalled(t, "CreateSettled", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - order already paid", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		order.Status = models.OrderStatusPreparing
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		_, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 50000})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotAwaitingPayment)
+	})
+
+	t.Run("error - paid by another till in the meantime", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", staff.UUID).Return(staff, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		deps.paymentRepo.On("CreateSettled", mock.Anything, mock.Anything, mock.Anything).Return(repositories.ErrOrderNotAwaitingPayment)
+
+		_, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 50000})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotAwaitingPayment)
+	})
+
+	t.Run("error - payment deadline passed", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		expired := time.Now().Add(-time.Minute)
+		order.PaymentExpiresAt = &expired
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+
+		_, err := service.RecordCashPayment(order.UUID, staff.UUID, services.CashPaymentRequest{AmountTendered: 50000})
+
+		assert.ErrorIs(t, err, services.ErrPaymentExpired)
+	})
+}
+
+// signStripe builds the Stripe-Signature header Stripe would send with payload
+func signStripe(payload []byte, secret string) string {
+	timestamp := fmt.Sprint(time.Now().Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func stripeCheckoutEvent(eventType, paymentStatus string, amountTotal int64) []byte {
+	return []byte(fmt.Sprintf(`{"id":"evt_1","type":%q,"created":1736240400,"data":{"object":{"id":"cs_test_1","client_reference_id":"MC-250107-001-1736240000","payment_status":%q,"payment_intent":"pi_1","amount_total":%d,"currency":"idr"}}}`,
+		eventType, paymentStatus, amountTotal))
+}
+
+func TestPaymentService_ProcessStripeWebhook(t *testing.T) {
+	stripePayment := func() *models.Payment {
+		order := pendingCounterOrder()
+		return &models.Payment{
+			ID:              1,
+			OrderID:         order.ID,
+			MidtransOrderID: "MC-250107-001-1736240000",
+			Method:          models.PaymentMethodStripe,
+			GrossAmount:     order.AmountDue(),
+			Order:           order,
+		}
+	}
+
+	t.Run("success - paid checkout settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := stripePayment()
+		payload := stripeCheckoutEvent("checkout.session.completed", "paid", 4250000)
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusPreparing).Return(nil)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, testStripeWebhookSecret), time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusSettlement, *payment.TransactionStatus)
+		assert.Equal(t, "pi_1", *payment.TransactionID)
+		assert.NotNil(t, payment.SettlementTime)
+		assert.Equal(t, 3232.5, *payment.GatewayFee)
+		deps.orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - expired checkout cancels the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := stripePayment()
+		payload := stripeCheckoutEvent("checkout.session.expired", "unpaid", 4250000)
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.paymentRepo.On("FindByOrderID", payment.OrderID).Return([]models.Payment{*payment}, nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", payment.OrderID).Return(nil)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, testStripeWebhookSecret), time.Now())
+
+		require.NoError(t, err)
+		deps.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - expired checkout replaced by a newer one keeps the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := stripePayment()
+		payload := stripeCheckoutEvent("checkout.session.expired", "unpaid", 4250000)
+		replacement := models.Payment{ID: 2, OrderID: payment.OrderID, Method: models.PaymentMethodStripe}
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.paymentRepo.On("FindByOrderID", payment.OrderID).Return([]models.Payment{replacement, *payment}, nil)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, testStripeWebhookSecret), time.Now())
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - unrelated events are ignored", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payload := []byte(`{"id":"evt_2","type":"customer.created","data":{"object":{}}}`)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, testStripeWebhookSecret), time.Now())
+
+		require.NoError(t, err)
+		deps.paymentRepo.AssertNotCalled(t, "FindByMidtransOrderID", mock.Anything)
+	})
+
+	t.Run("error - signed with another secret", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payload := stripeCheckoutEvent("checkout.session.completed", "paid", 4250000)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, "whsec_other"), time.Now())
+
+		assert.ErrorIs(t, err, services.ErrInvalidSignature)
+		deps.paymentRepo.AssertNotCalled(t, "FindByMidtransOrderID", mock.Anything)
+	})
+
+	t.Run("error - amount does not match the payment", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := stripePayment()
+		payload := stripeCheckoutEvent("checkout.session.completed", "paid", 100)
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+
+		err := service.ProcessStripeWebhook(payload, signStripe(payload, testStripeWebhookSecret), time.Now())
+
+		assert.ErrorIs(t, err, services.ErrInvalidAmount)
+		deps.paymentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func xenditInvoiceCallback(status string, amount float64) []byte {
+	return []byte(fmt.Sprintf(`{"id":"inv_1","external_id":"MC-250107-001-1736240000","status":%q,"amount":%v,"paid_amount":%v,"currency":"IDR","payment_method":"EWALLET","payment_channel":"OVO","paid_at":"2025-01-07T10:00:00.000Z"}`,
+		status, amount, amount))
+}
+
+func TestPaymentService_ProcessXenditCallback(t *testing.T) {
+	xenditPayment := func() *models.Payment {
+		order := pendingCounterOrder()
+		return &models.Payment{
+			ID:              1,
+			OrderID:         order.ID,
+			MidtransOrderID: "MC-250107-001-1736240000",
+			Method:          models.PaymentMethodXendit,
+			GrossAmount:     order.AmountDue(),
+			Order:           order,
+		}
+	}
+
+	t.Run("success - paid invoice settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := xenditPayment()
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusPreparing).Return(nil)
+
+		err := service.ProcessXenditCallback(xenditInvoiceCallback("PAID", 42500), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusSettlement, *payment.TransactionStatus)
+		assert.Equal(t, "ovo", *payment.PaymentType)
+		assert.Equal(t, 637.5, *payment.GatewayFee)
+		deps.orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - fee reported by Xendit replaces the estimate", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := xenditPayment()
+		settled := models.TransactionStatusSettlement
+		estimate := 637.5
+		payment.TransactionStatus = &settled
+		payment.GatewayFee = &estimate
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+
+		payload := []byte(`{"id":"inv_1","external_id":"MC-250107-001-1736240000","status":"SETTLED","amount":42500,"payment_channel":"OVO","paid_at":"2025-01-07T10:00:00.000Z","fees_paid_amount":700}`)
+		err := service.ProcessXenditCallback(payload, testXenditCallbackToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, 700.0, *payment.GatewayFee)
+	})
+
+	t.Run("success - settled after paid does not move the order again", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := xenditPayment()
+		settled := models.TransactionStatusSettlement
+		payment.TransactionStatus = &settled
+		payment.Order.Status = models.OrderStatusReady
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+
+		err := service.ProcessXenditCallback(xenditInvoiceCallback("SETTLED", 42500), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - expired invoice cancels the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := xenditPayment()
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.paymentRepo.On("FindByOrderID", payment.OrderID).Return([]models.Payment{*payment}, nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", payment.OrderID).Return(nil)
+
+		err := service.ProcessXenditCallback(xenditInvoiceCallback("EXPIRED", 42500), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		deps.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - wrong callback token", func(t *testing.T) {
+		service, deps := newPaymentService()
+
+		err := service.ProcessXenditCallback(xenditInvoiceCallback("PAID", 42500), "guessed")
+
+		assert.ErrorIs(t, err, services.ErrInvalidSignature)
+		deps.paymentRepo.AssertNotCalled(t, "FindByMidtransOrderID", mock.Anything)
+	})
+
+	t.Run("error - amount does not match the payment", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := xenditPayment()
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+
+		err := service.ProcessXenditCallback(xenditInvoiceCallback("PAID", 1000), testXenditCallbackToken)
+
+		assert.ErrorIs(t, err, services.ErrInvalidAmount)
+		deps.paymentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+// staleXenditPayment is started with a provider other than the configured
+// Midtrans gateway, so expiring it never calls out to a gateway
+func staleXenditPayment(order *models.Order) models.Payment {
+	expiresAt := time.Now().Add(-time.Minute)
+	return models.Payment{
+		ID:              7,
+		OrderID:         order.ID,
+		MidtransOrderID: order.OrderNumber + "-1",
+		Method:          models.PaymentMethodXendit,
+		GrossAmount:     order.AmountDue(),
+		ExpiresAt:       &expiresAt,
+		Order:           order,
+	}
+}
+
+func TestPaymentService_ExpireStalePayments(t *testing.T) {
+	newExpiringService := func(cancelOrders bool) (services.PaymentService, *paymentDeps) {
+		config := testPaymentConfig
+		config.CancelExpiredOrders = cancelOrders
+		deps := &paymentDeps{
+			paymentRepo:     new(mocks.MockPaymentRepository),
+			refundRepo:      new(mocks.MockRefundRepository),
+			orderRepo:       new(mocks.MockOrderRepository),
+			userRepo:        new(mocks.MockUserRepository),
+			reservationRepo: new(mocks.MockStockReservationRepository),
+			settingRepo:     new(mocks.MockSettingRepository),
+		}
+		service := services.NewPaymentService(deps.paymentRepo, deps.refundRepo, deps.orderRepo, deps.userRepo, deps.reservationRepo, deps.walletRepo, deps.giftCardRepo, deps.loyaltyRepo, services.NewSettingsService(deps.settingRepo), testAlerts, testEvents, testLogger, config)
+		return service, deps
+	}
+
+	t.Run("success - expires the payment and cancels the order", func(t *testing.T) {
+		service, deps := newExpiringService(true)
+		order := pendingCounterOrder()
+
+		deps.paymentRepo.On("FindStalePending", mock.Anything, 100).Return([]models.Payment{staleXenditPayment(order)}, nil)
+		deps.paymentRepo.On("UpdatePendingStatus", uint(7), models.TransactionStatusExpire).Return(true, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		deps.orderRepo.On("UpdateStatus", order.ID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", order.ID).Return(nil)
+
+		expired, err := service.ExpireStalePayments()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		deps.orderRepo.AssertExpectations(t)
+		deps.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - keeps the order when cancelling is off", func(t *testing.T) {
+		service, deps := newExpiringService(false)
+		order := pendingCounterOrder()
+
+		deps.paymentRepo.On("FindStalePending", mock.Anything, 100).Return([]models.Payment{staleXenditPayment(order)}, nil)
+		deps.paymentRepo.On("UpdatePendingStatus", uint(7), models.TransactionStatusExpire).Return(true, nil)
+
+		expired, err := service.ExpireStalePayments()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - order paid another way is not cancelled", func(t *testing.T) {
+		service, deps := newExpiringService(true)
+		order := pendingCounterOrder()
+		order.Status = models.OrderStatusPreparing
+
+		deps.paymentRepo.On("FindStalePending", mock.Anything, 100).Return([]models.Payment{staleXenditPayment(order)}, nil)
+		deps.paymentRepo.On("UpdatePendingStatus", uint(7), models.TransactionStatusExpire).Return(true, nil)
+
+		expired, err := service.ExpireStalePayments()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - payment settled by a webhook in the meantime is left alone", func(t *testing.T) {
+		service, deps := newExpiringService(true)
+		order := pendingCounterOrder()
+
+		deps.paymentRepo.On("FindStalePending", mock.Anything, 100).Return([]models.Payment{staleXenditPayment(order)}, nil)
+		deps.paymentRepo.On("UpdatePendingStatus", uint(7), models.TransactionStatusExpire).Return(false, nil)
+
+		expired, err := service.ExpireStalePayments()
+
+		require.NoError(t, err)
+		assert.Zero(t, expired)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+// settledPayment is a cash payment, so refunding it never calls out to a
+// gateway
+func settledPayment(orderStatus models.OrderStatus) *models.Payment {
+	order := pendingCounterOrder()
+	order.Status = orderStatus
+	settled := models.TransactionStatusSettlement
+	return &models.Payment{
+		ID:                5,
+		UUID:              uuid.New(),
+		OrderID:           order.ID,
+		MidtransOrderID:   "CASH-MC-250107-001-1736240000",
+		Method:            models.PaymentMethodCash,
+		GrossAmount:       order.AmountDue(),
+		TransactionStatus: &settled,
+		Order:             order,
+	}
+}
+
+func TestPaymentService_RefundPayment(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+	req := services.RefundPaymentRequest{Reason: "Drink spilled before pickup"}
+	partial := func(amount float64) services.RefundPaymentRequest {
+		return services.RefundPaymentRequest{Amount: &amount, Reason: "Oat milk ran out"}
+	}
+
+	t.Run("success - refunds the balance and cancels an order awaiting pickup", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusReady)
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+		var saved *models.Refund
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.Refund)
+		}).Return(true, nil)
+		deps.paymentRepo.On("UpdateTransactionStatus", payment.ID, models.TransactionStatusRefund).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", payment.OrderID).Return(nil)
+
+		refund, err := service.RefundPayment(payment.UUID, admin.UUID, req)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, payment.GrossAmount, saved.Amount)
+		assert.Equal(t, req.Reason, saved.Reason)
+		assert.Equal(t, admin.ID, *saved.RefundedBy)
+		assert.Equal(t, models.OrderStatusCancelled, refund.OrderStatus)
+		assert.Equal(t, models.TransactionStatusRefund, refund.TransactionStatus)
+		assert.Zero(t, refund.RefundableBalance)
+		deps.orderRepo.AssertExpectations(t)
+		deps.reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - partial refund keeps the order and reports the balance", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Return(true, nil)
+		deps.paymentRepo.On("UpdateTransactionStatus", payment.ID, models.TransactionStatusPartialRefund).Return(nil)
+
+		refund, err := service.RefundPayment(payment.UUID, admin.UUID, partial(18000))
+
+		require.NoError(t, err)
+		assert.Equal(t, 18000.0, refund.Amount)
+		assert.Equal(t, 24500.0, refund.RefundableBalance)
+		assert.Equal(t, models.TransactionStatusPartialRefund, refund.TransactionStatus)
+		assert.Equal(t, models.OrderStatusPreparing, refund.OrderStatus)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - last partial refund completes the refund", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusCompleted)
+		partiallyRefunded := models.TransactionStatusPartialRefund
+		payment.TransactionStatus = &partiallyRefunded
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(18000.0, nil)
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Return(true, nil)
+		deps.paymentRepo.On("UpdateTransactionStatus", payment.ID, models.TransactionStatusRefund).Return(nil)
+
+		refund, err := service.RefundPayment(payment.UUID, admin.UUID, partial(24500))
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusRefund, refund.TransactionStatus)
+		assert.Equal(t, models.OrderStatusCompleted, refund.OrderStatus)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - amount over the refundable balance", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusCompleted)
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(30000.0, nil)
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, partial(15000))
+
+		assert.ErrorIs(t, err, services.ErrRefundExceedsBalance)
+		deps.refundRepo.AssertNotCalled(t, "CreateWithinBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - balance taken by a concurrent refund", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusCompleted)
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Return(false, nil)
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, partial(15000))
+
+		assert.ErrorIs(t, err, services.ErrRefundExceedsBalance)
+		deps.paymentRepo.AssertNotCalled(t, "UpdateTransactionStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - payment not settled", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusCancelled)
+		payment.TransactionStatus = nil
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotRefundable)
+		deps.refundRepo.AssertNotCalled(t, "CreateWithinBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - gateway without refunds", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusCancelled)
+		payment.Method = models.PaymentMethodXendit
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrRefundNotSupported)
+		deps.refundRepo.AssertNotCalled(t, "CreateWithinBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - Midtrans rules", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			paymentType string
+			amount      float64
+			want        error
+		}{
+			{"bank transfers are refunded by hand", "bank_transfer", 42500, services.ErrRefundNotSupported},
+			{"full refunds only", "akulaku", 18000, services.ErrPartialRefundNotSupported},
+			{"whole rupiah only", "gopay", 18000.5, services.ErrInvalidRefundAmount},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service, deps := newPaymentService()
+				payment := settledPayment(models.OrderStatusCompleted)
+				payment.Method = models.PaymentMethodMidtrans
+				payment.PaymentType = &tt.paymentType
+
+				deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+				deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+				deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+
+				_, err := service.RefundPayment(payment.UUID, admin.UUID, partial(tt.amount))
+
+				assert.ErrorIs(t, err, tt.want)
+				deps.refundRepo.AssertNotCalled(t, "CreateWithinBalance", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("error - payment not found", func(t *testing.T) {
+		service, deps := newPaymentService()
+		paymentUUID := uuid.New()
+
+		deps.paymentRepo.On("FindByUUID", paymentUUID).Return(nil, repositories.ErrPaymentNotFound)
+
+		_, err := service.RefundPayment(paymentUUID, admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotFound)
+	})
+}
+
+func signMidtrans(notification *services.MidtransNotification) string {
+	hash := sha512.Sum512([]byte(notification.OrderID + notification.StatusCode + notification.GrossAmount + testPaymentConfig.MidtransServerKey))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestPaymentService_ProcessWebhookNotification_Refund(t *testing.T) {
+	t.Run("success - records refunds made in the dashboard and cancels the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+		payment.Method = models.PaymentMethodMidtrans
+
+		notification := &services.MidtransNotification{
+			TransactionTime:   "2025-01-07 10:00:00",
+			TransactionStatus: string(models.TransactionStatusRefund),
+			StatusCode:        "200",
+			OrderID:           payment.MidtransOrderID,
+			GrossAmount:       "42500.00",
+			Refunds: []services.MidtransRefund{
+				{RefundKey: "dashboard-1", RefundAmount: "42500.00", Reason: "Customer complaint"},
+			},
+		}
+		notification.SignatureKey = signMidtrans(notification)
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.refundRepo.On("Create", mock.MatchedBy(func(refund *models.Refund) bool {
+			return refund.RefundKey == "dashboard-1" && refund.Amount == 42500 && refund.RefundedBy == nil
+		})).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", payment.OrderID).Return(nil)
+
+		err := service.ProcessWebhookNotification(notification)
+
+		require.NoError(t, err)
+		deps.refundRepo.AssertExpectations(t)
+		deps.orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - partial refund keeps the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+		payment.Method = models.PaymentMethodMidtrans
+
+		notification := &services.MidtransNotification{
+			TransactionTime:   "2025-01-07 10:00:00",
+			TransactionStatus: string(models.TransactionStatusPartialRefund),
+			StatusCode:        "200",
+			OrderID:           payment.MidtransOrderID,
+			GrossAmount:       "42500.00",
+			Refunds: []services.MidtransRefund{
+				{RefundKey: "dashboard-2", RefundAmount: "10000.00", Reason: "Wrong topping"},
+			},
+		}
+		notification.SignatureKey = signMidtrans(notification)
+
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.refundRepo.On("Create", mock.Anything).Return(nil)
+
+		err := service.ProcessWebhookNotification(notification)
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_VerifyPayment(t *testing.T) {
+	t.Run("error - payment not found", func(t *testing.T) {
+		service, deps := newPaymentService()
+		paymentUUID := uuid.New()
+		deps.paymentRepo.On("FindByUUID", paymentUUID).Return(nil, repositories.ErrPaymentNotFound)
+
+		verification, err := service.VerifyPayment(paymentUUID)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotFound)
+		assert.Nil(t, verification)
+	})
+
+	t.Run("error - cash payments cannot be checked", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		verification, err := service.VerifyPayment(payment.UUID)
+
+		assert.ErrorIs(t, err, services.ErrStatusCheckNotSupported)
+		assert.Nil(t, verification)
+		deps.paymentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("error - payment started with another provider", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPending)
+		payment.Method = models.PaymentMethodStripe
+		payment.TransactionStatus = nil
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		verification, err := service.VerifyPayment(payment.UUID)
+
+		assert.ErrorIs(t, err, services.ErrStatusCheckNotSupported)
+		assert.Nil(t, verification)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_CancelPayment(t *testing.T) {
+	t.Run("error - payment not found", func(t *testing.T) {
+		service, deps := newPaymentService()
+		paymentUUID := uuid.New()
+		deps.paymentRepo.On("FindByUUID", paymentUUID).Return(nil, repositories.ErrPaymentNotFound)
+
+		cancellation, err := service.CancelPayment(paymentUUID)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotFound)
+		assert.Nil(t, cancellation)
+	})
+
+	t.Run("error - cash payments cannot be cancelled", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPending)
+		payment.TransactionStatus = nil
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		cancellation, err := service.CancelPayment(payment.UUID)
+
+		assert.ErrorIs(t, err, services.ErrCancelNotSupported)
+		assert.Nil(t, cancellation)
+		deps.paymentRepo.AssertNotCalled(t, "UpdatePendingStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - settled payment is not pending", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+		payment.Method = models.PaymentMethodMidtrans
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		cancellation, err := service.CancelPayment(payment.UUID)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotCancellable)
+		assert.Nil(t, cancellation)
+		deps.paymentRepo.AssertNotCalled(t, "UpdatePendingStatus", mock.Anything, mock.Anything)
+		deps.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPaymentService_CreatePaymentToken(t *testing.T) {
+	openCheckout := func(order *models.Order) models.Payment {
+		token := "66e4fa55-fdac-4ef9-91b5-733b97d1b862"
+		url := "https://app.sandbox.midtrans.com/snap/v4/redirection/" + token
+		expiresAt := time.Now().Add(20 * time.Minute)
+		channel := models.CheckoutChannelHosted
+		return models.Payment{
+			ID:              3,
+			UUID:            uuid.New(),
+			OrderID:         order.ID,
+			MidtransOrderID: "MC-250107-001-1736240000",
+			Method:          models.PaymentMethodMidtrans,
+			GrossAmount:     order.AmountDue(),
+			ExpiresAt:       &expiresAt,
+			CheckoutChannel: &channel,
+			CheckoutToken:   &token,
+			CheckoutURL:     &url,
+		}
+	}
+
+	t.Run("success - reopens the page the customer closed", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		existing := openCheckout(order)
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{existing}, nil)
+
+		response, err := service.CreatePaymentToken(order.UUID, services.CreatePaymentTokenRequest{})
+
+		require.NoError(t, err)
+		assert.True(t, response.Reused)
+		assert.Equal(t, existing.UUID, response.PaymentID)
+		assert.Equal(t, *existing.CheckoutToken, response.Token)
+		assert.Equal(t, *existing.CheckoutURL, response.RedirectURL)
+		deps.paymentRepo.AssertNotCalled(t, "Create", mock.Anything)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+	})
+
+	t.Run("error - gateway refuses the checkout and the stock hold stays", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		order.Items = []models.OrderItem{{UUID: uuid.New(), ProductName: "Matcha Latte", UnitPrice: 41500, Quantity: -1}}
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{}, nil)
+		deps.settingRepo.On("FindByKey", mock.Anything).Return(nil, repositories.ErrSettingNotFound)
+
+		response, err := service.CreatePaymentToken(order.UUID, services.CreatePaymentTokenRequest{})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+		deps.paymentRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - page for the old tip was paid while being replaced", func(t *testing.T) {
+		config := testPaymentConfig
+		config.Provider = models.PaymentMethodStripe
+		deps := &paymentDeps{
+			paymentRepo:     new(mocks.MockPaymentRepository),
+			orderRepo:       new(mocks.MockOrderRepository),
+			reservationRepo: new(mocks.MockStockReservationRepository),
+			settingRepo:     new(mocks.MockSettingRepository),
+		}
+		service := services.NewPaymentService(deps.paymentRepo, deps.refundRepo, deps.orderRepo, deps.userRepo, deps.reservationRepo, deps.walletRepo, deps.giftCardRepo, deps.loyaltyRepo, services.NewSettingsService(deps.settingRepo), testAlerts, testEvents, testLogger, config)
+		order := pendingCounterOrder()
+		existing := openCheckout(order)
+		existing.Method = models.PaymentMethodStripe
+		tip := 5000.0
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{existing}, nil)
+		deps.orderRepo.On("UpdateTip", order.ID, tip).Return(nil)
+		deps.paymentRepo.On("UpdatePendingStatus", existing.ID, models.TransactionStatusExpire).Return(false, nil)
+
+		response, err := service.CreatePaymentToken(order.UUID, services.CreatePaymentTokenRequest{TipAmount: &tip})
+
+		assert.ErrorIs(t, err, services.ErrPaymentAlreadyExists)
+		assert.Nil(t, response)
+		deps.paymentRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestPaymentService_ChargeQRIS(t *testing.T) {
+	t.Run("success - returns the code still on the kiosk screen", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		qrString := "00020101021226620014COM.GO-JEK.WWW0119936009140000000000"
+		qrImage := "https://api.sandbox.midtrans.com/v2/qris/abc/qr-code"
+		channel := models.CheckoutChannelQRIS
+		expiresAt := time.Now().Add(10 * time.Minute)
+		existing := models.Payment{
+			ID:              4,
+			UUID:            uuid.New(),
+			OrderID:         order.ID,
+			MidtransOrderID: "MC-250107-001-1736240000",
+			Method:          models.PaymentMethodMidtrans,
+			GrossAmount:     order.AmountDue(),
+			ExpiresAt:       &expiresAt,
+			CheckoutChannel: &channel,
+			CheckoutToken:   &qrString,
+			CheckoutURL:     &qrImage,
+		}
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.paymentRepo.On("FindByOrderID", order.ID).Return([]models.Payment{existing}, nil)
+
+		charge, err := service.ChargeQRIS(order.UUID, services.CreatePaymentTokenRequest{})
+
+		require.NoError(t, err)
+		assert.True(t, charge.Reused)
+		assert.Equal(t, qrString, charge.QRString)
+		assert.Equal(t, qrImage, charge.QRImageURL)
+		assert.Equal(t, 42500.0, charge.Amount)
+		deps.paymentRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - gateway without QRIS charges", func(t *testing.T) {
+		config := testPaymentConfig
+		config.Provider = models.PaymentMethodXendit
+		paymentRepo := new(mocks.MockPaymentRepository)
+		orderRepo := new(mocks.MockOrderRepository)
+		service := services.NewPaymentService(paymentRepo, new(mocks.MockRefundRepository), orderRepo, new(mocks.MockUserRepository), new(mocks.MockStockReservationRepository), new(mocks.MockWalletRepository), new(mocks.MockGiftCardRepository), new(mocks.MockLoyaltyRepository), services.NewSettingsService(new(mocks.MockSettingRepository)), testAlerts, testEvents, testLogger, config)
+
+		charge, err := service.ChargeQRIS(uuid.New(), services.CreatePaymentTokenRequest{})
+
+		assert.ErrorIs(t, err, services.ErrQRISNotSupported)
+		assert.Nil(t, charge)
+		orderRepo.AssertNotCalled(t, "FindByUUID", mock.Anything)
+	})
+}
+
+func TestPaymentService_GetPaymentStatus(t *testing.T) {
+	t.Run("success - unreported payment is pending", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+		payment := &models.Payment{ID: 4, UUID: uuid.New(), OrderID: order.ID, Order: order}
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		status, err := service.GetPaymentStatus(payment.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusPending, status.TransactionStatus)
+		assert.Equal(t, order.UUID, status.OrderID)
+		assert.Equal(t, models.OrderStatusPending, status.OrderStatus)
+	})
+
+	t.Run("success - settled payment", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := settledPayment(models.OrderStatusPreparing)
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		status, err := service.GetPaymentStatus(payment.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusSettlement, status.TransactionStatus)
+		assert.Equal(t, models.OrderStatusPreparing, status.OrderStatus)
+	})
+
+	t.Run("error - payment not found", func(t *testing.T) {
+		service, deps := newPaymentService()
+		paymentUUID := uuid.New()
+		deps.paymentRepo.On("FindByUUID", paymentUUID).Return(nil, repositories.ErrPaymentNotFound)
+
+		status, err := service.GetPaymentStatus(paymentUUID)
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotFound)
+		assert.Nil(t, status)
+	})
+}
+
+func TestPaymentService_GetAll(t *testing.T) {
+	t.Run("success - payments with their orders", func(t *testing.T) {
+		service, deps := newPaymentService()
+		settled := settledPayment(models.OrderStatusPreparing)
+		order := pendingCounterOrder()
+		unreported := models.Payment{ID: 6, UUID: uuid.New(), OrderID: order.ID, Method: models.PaymentMethodMidtrans, GrossAmount: 42500, Order: order}
+		status := models.TransactionStatusPending
+		filters := repositories.PaymentFilters{Status: &status}
+
+		deps.paymentRepo.On("FindAll", filters, 20, 20).Return([]models.Payment{unreported, *settled}, int64(22), nil)
+
+		list, err := service.GetAll(filters, 2, 20)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(22), list.Total)
+		assert.Equal(t, 2, list.Page)
+		require.Len(t, list.Payments, 2)
+		assert.Equal(t, models.TransactionStatusPending, list.Payments[0].TransactionStatus)
+		assert.Equal(t, order.OrderNumber, list.Payments[0].OrderNumber)
+		assert.Equal(t, models.TransactionStatusSettlement, list.Payments[1].TransactionStatus)
+		assert.Equal(t, settled.MidtransOrderID, list.Payments[1].Reference)
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		service, deps := newPaymentService()
+		deps.paymentRepo.On("FindAll", repositories.PaymentFilters{}, 20, 0).Return(nil, int64(0), errors.New("db down"))
+
+		list, err := service.GetAll(repositories.PaymentFilters{}, 1, 20)
+
+		assert.Error(t, err)
+		assert.Nil(t, list)
+	})
+}
+
+func TestMidtransItemDetails(t *testing.T) {
+	sum := func(t *testing.T, order *models.Order) int64 {
+		items, err := services.MidtransItemDetails(order)
+		require.NoError(t, err)
+		var total int64
+		for _, item := range items {
+			total += item.Price * int64(item.Qty)
+		}
+		return total
+	}
+	newOrder := func() *models.Order {
+		return &models.Order{
+			OrderNumber: "ORD-001",
+			Status:      models.OrderStatusPending,
+			Items: []models.OrderItem{
+				{UUID: uuid.New(), ProductName: "Matcha Latte", UnitPrice: 33333.33, Quantity: 3},
+				{UUID: uuid.New(), ProductName: "Hojicha", UnitPrice: 28000, Quantity: 1},
+			},
+			Subtotal:         128000,
+			Discount:         12800,
+			ServiceCharge:    5760,
+			Tax:              13306,
+			SourceFee:        2000,
+			DeliveryFee:      10000,
+			DeliveryDiscount: 5000,
+			Total:            141266,
+			TipAmount:        5000,
+			GiftCardAmount:   20000,
+			PointsAmount:     1000,
+		}
+	}
+
+	t.Run("Full payment lists every charge and deduction", func(t *testing.T) {
+		order := newOrder()
+		assert.Equal(t, int64(order.PaymentDue()), sum(t, order))
+		assert.Equal(t, int64(125266), sum(t, order))
+	})
+
+	t.Run("Deposit is a single line", func(t *testing.T) {
+		order := newOrder()
+		order.DepositAmount = 50000
+		items, err := services.MidtransItemDetails(order)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "DEPOSIT", items[0].ID)
+		assert.Equal(t, int64(50000), sum(t, order))
+	})
+
+	t.Run("Balance is a single line", func(t *testing.T) {
+		order := newOrder()
+		order.DepositAmount = 50000
+		order.Status = models.OrderStatusDepositPaid
+		items, err := services.MidtransItemDetails(order)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "BALANCE", items[0].ID)
+		assert.Equal(t, int64(75266), sum(t, order))
+	})
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPaymentService keeps the notifications handed to it. Methods the
+// simulator does not use are left to the embedded nil interface.
+type recordingPaymentService struct {
+	services.PaymentService
+	notifications []*services.MidtransNotification
+}
+
+func (r *recordingPaymentService) ProcessWebhookNotification(notification *services.MidtransNotification) error {
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func pendingMidtransPayment() *models.Payment {
+	order := pendingCounterOrder()
+	return &models.Payment{
+		ID:              6,
+		UUID:            uuid.New(),
+		OrderID:         order.ID,
+		MidtransOrderID: "MC-250107-001-1736240000",
+		Method:          models.PaymentMethodMidtrans,
+		GrossAmount:     order.AmountDue(),
+		Order:           order,
+	}
+}
+
+func TestPaymentSimulatorService_Simulate(t *testing.T) {
+	newSimulator := func() (services.PaymentSimulatorService, *mocks.MockPaymentRepository, *mocks.MockOrderRepository, *recordingPaymentService) {
+		paymentRepo := new(mocks.MockPaymentRepository)
+		orderRepo := new(mocks.MockOrderRepository)
+		recorder := &recordingPaymentService{}
+		return services.NewPaymentSimulatorService(paymentRepo, orderRepo, recorder, testPaymentConfig.MidtransServerKey, "sandbox"), paymentRepo, orderRepo, recorder
+	}
+
+	t.Run("success - processes a correctly signed settlement", func(t *testing.T) {
+		simulator, paymentRepo, orderRepo, recorder := newSimulator()
+		payment := pendingMidtransPayment()
+		paid := *payment.Order
+		paid.Status = models.OrderStatusPreparing
+		paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		orderRepo.On("FindByID", payment.OrderID).Return(&paid, nil)
+
+		response, err := simulator.Simulate(payment.UUID, services.SimulatePaymentRequest{Status: models.TransactionStatusSettlement})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusSettlement, response.TransactionStatus)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+		require.Len(t, recorder.notifications, 1)
+		notification := recorder.notifications[0]
+		assert.Equal(t, payment.MidtransOrderID, notification.OrderID)
+		assert.Equal(t, "200", notification.StatusCode)
+		assert.Equal(t, "42500.00", notification.GrossAmount)
+		assert.NotNil(t, notification.SettlementTime)
+
+		verifier, _ := newPaymentService()
+		assert.True(t, verifier.VerifySignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, notification.SignatureKey))
+	})
+
+	t.Run("success - cancel uses the failure status code", func(t *testing.T) {
+		simulator, paymentRepo, orderRepo, recorder := newSimulator()
+		payment := pendingMidtransPayment()
+		paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		orderRepo.On("FindByID", payment.OrderID).Return(payment.Order, nil)
+
+		_, err := simulator.Simulate(payment.UUID, services.SimulatePaymentRequest{Status: models.TransactionStatusCancel})
+
+		require.NoError(t, err)
+		require.Len(t, recorder.notifications, 1)
+		assert.Equal(t, "202", recorder.notifications[0].StatusCode)
+		assert.Nil(t, recorder.notifications[0].SettlementTime)
+	})
+
+	t.Run("error - live Midtrans keys", func(t *testing.T) {
+		paymentRepo := new(mocks.MockPaymentRepository)
+		recorder := &recordingPaymentService{}
+		simulator := services.NewPaymentSimulatorService(paymentRepo, new(mocks.MockOrderRepository), recorder, testPaymentConfig.MidtransServerKey, "production")
+
+		_, err := simulator.Simulate(uuid.New(), services.SimulatePaymentRequest{Status: models.TransactionStatusSettlement})
+
+		assert.ErrorIs(t, err, services.ErrSimulationSandboxOnly)
+		assert.Empty(t, recorder.notifications)
+	})
+
+	t.Run("error - payment not found", func(t *testing.T) {
+		simulator, paymentRepo, _, recorder := newSimulator()
+		paymentUUID := uuid.New()
+		paymentRepo.On("FindByUUID", paymentUUID).Return(nil, repositories.ErrPaymentNotFound)
+
+		_, err := simulator.Simulate(paymentUUID, services.SimulatePaymentRequest{Status: models.TransactionStatusSettlement})
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotFound)
+		assert.Empty(t, recorder.notifications)
+	})
+
+	t.Run("error - cash payments cannot be simulated", func(t *testing.T) {
+		simulator, paymentRepo, _, recorder := newSimulator()
+		payment := pendingMidtransPayment()
+		payment.Method = models.PaymentMethodCash
+		paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		_, err := simulator.Simulate(payment.UUID, services.SimulatePaymentRequest{Status: models.TransactionStatusSettlement})
+
+		assert.ErrorIs(t, err, services.ErrSimulationNotSupported)
+		assert.Empty(t, recorder.notifications)
+	})
+
+	t.Run("error - payment already settled", func(t *testing.T) {
+		simulator, paymentRepo, _, recorder := newSimulator()
+		payment := pendingMidtransPayment()
+		settled := models.TransactionStatusSettlement
+		payment.TransactionStatus = &settled
+		paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+
+		_, err := simulator.Simulate(payment.UUID, services.SimulatePaymentRequest{Status: models.TransactionStatusCancel})
+
+		assert.ErrorIs(t, err, services.ErrPaymentNotPending)
+		assert.Empty(t, recorder.notifications)
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPricingService_GetAll(t *testing.T) {
+	t.Run("success - unconfigured sources at list price", func(t *testing.T) {
+		mockRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewPricingService(mockRepo)
+
+		mockRepo.On("FindAll").Return([]models.SourcePricing{
+			{OrderSource: models.OrderSourcePartner, PriceAdjustmentPercent: 20},
+		}, nil)
+
+		result, err := service.GetAll()
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 4)
+		for _, pricing := range result {
+			assert.NotEqual(t, models.OrderSourceGuest, pricing.OrderSource)
+			assert.NotEqual(t, models.OrderSourceMember, pricing.OrderSource)
+			if pricing.OrderSource == models.OrderSourcePartner {
+				assert.Equal(t, 20.0, pricing.PriceAdjustmentPercent)
+			} else {
+				assert.Zero(t, pricing.PriceAdjustmentPercent)
+			}
+		}
+	})
+}
+
+func TestPricingService_Update(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewPricingService(mockRepo)
+
+		mockRepo.On("Upsert", mock.MatchedBy(func(pricing *models.SourcePricing) bool {
+			return pricing.OrderSource == models.OrderSourcePartner && pricing.PriceAdjustmentPercent == 20
+		})).Return(nil)
+
+		result, err := service.Update(models.OrderSourcePartner, services.UpdateSourcePricingRequest{
+			PriceAdjustmentPercent: 20,
+			FlatFee:                2000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2000.0, result.FlatFee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - in-store source", func(t *testing.T) {
+		mockRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewPricingService(mockRepo)
+
+		result, err := service.Update(models.OrderSourceMember, services.UpdateSourcePricingRequest{PriceAdjustmentPercent: 10})
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrInvalidOrderSource, err)
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+	})
+
+	t.Run("error - repository error", func(t *testing.T) {
+		mockRepo := new(mocks.MockSourcePricingRepository)
+		service := services.NewPricingService(mockRepo)
+
+		mockRepo.On("Upsert", mock.Anything).Return(errors.New("database error"))
+
+		result, err := service.Update(models.OrderSourcePhone, services.UpdateSourcePricingRequest{})
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
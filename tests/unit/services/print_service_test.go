@@ -0,0 +1,191 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPrintService() (services.PrintService, *mocks.MockPrintJobRepository, *mocks.MockOrderRepository) {
+	printJobRepo := new(mocks.MockPrintJobRepository)
+	orderRepo := new(mocks.MockOrderRepository)
+	service := services.NewPrintService(
+		printJobRepo,
+		orderRepo,
+		testSettings,
+		services.NewReceiptService(testSettings, testFormatter, testPickupCodes),
+		testEvents,
+		testLogger,
+		"counter",
+		map[models.OrderType]string{models.OrderTypeDineIn: "bar"},
+	)
+	return service, printJobRepo, orderRepo
+}
+
+func paidOrder(orderType models.OrderType) *models.Order {
+	return &models.Order{
+		ID:           10,
+		UUID:         uuid.New(),
+		OrderNumber:  "MC-250107-001",
+		CustomerName: "John Doe",
+		Status:       models.OrderStatusPreparing,
+		OrderType:    orderType,
+		Subtotal:     100000,
+		Total:        100000,
+		Items: []models.OrderItem{
+			{ProductName: "Matcha Latte", Quantity: 2, UnitPrice: 50000, Subtotal: 100000},
+		},
+	}
+}
+
+func TestPrintService_Enqueue(t *testing.T) {
+	t.Run("success - routes the ticket to the station of the order type", func(t *testing.T) {
+		service, printJobRepo, orderRepo := newPrintService()
+		order := paidOrder(models.OrderTypeDineIn)
+
+		orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		var queued *models.PrintJob
+		printJobRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			queued = args.Get(0).(*models.PrintJob)
+		}).Return(true, nil)
+
+		err := service.Enqueue(order.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.ID, queued.OrderID)
+		assert.Equal(t, "bar", queued.Station)
+		assert.Equal(t, models.PrintJobStatusQueued, queued.Status)
+		assert.Equal(t, []byte{0x1b, 0x40}, queued.Payload[:2])
+		assert.Contains(t, string(queued.Payload), "Matcha Latte")
+	})
+
+	t.Run("success - falls back to the default station", func(t *testing.T) {
+		service, printJobRepo, orderRepo := newPrintService()
+		order := paidOrder(models.OrderTypeTakeaway)
+
+		orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		printJobRepo.On("Create", mock.MatchedBy(func(job *models.PrintJob) bool {
+			return job.Station == "counter"
+		})).Return(false, nil)
+
+		err := service.Enqueue(order.UUID)
+
+		require.NoError(t, err)
+		printJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - order not found", func(t *testing.T) {
+		service, printJobRepo, orderRepo := newPrintService()
+		orderUUID := uuid.New()
+
+		orderRepo.On("FindByUUID", orderUUID).Return(nil, repositories.ErrOrderNotFound)
+
+		err := service.Enqueue(orderUUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		printJobRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestPrintService_Next(t *testing.T) {
+	t.Run("success - hands out the next ticket of the station", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		job := &models.PrintJob{
+			ID:       1,
+			UUID:     uuid.New(),
+			Station:  "bar",
+			Status:   models.PrintJobStatusPrinting,
+			Attempts: 1,
+			Payload:  []byte("ticket"),
+			Order:    &models.Order{OrderNumber: "MC-250107-001"},
+		}
+		printJobRepo.On("ClaimNext", "bar", mock.MatchedBy(func(staleBefore time.Time) bool {
+			return staleBefore.Before(time.Now())
+		}), 5).Return(job, nil)
+
+		response, err := service.Next("bar")
+
+		require.NoError(t, err)
+		assert.Equal(t, job.UUID, response.ID)
+		assert.Equal(t, "MC-250107-001", response.OrderNumber)
+		assert.Equal(t, []byte("ticket"), response.Payload)
+	})
+
+	t.Run("success - nothing to print at the default station", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		printJobRepo.On("ClaimNext", "counter", mock.Anything, mock.Anything).Return(nil, repositories.ErrPrintJobNotFound)
+
+		response, err := service.Next("")
+
+		require.NoError(t, err)
+		assert.Nil(t, response)
+	})
+}
+
+func TestPrintService_Ack(t *testing.T) {
+	t.Run("success - printed", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		job := &models.PrintJob{ID: 1, UUID: uuid.New(), Attempts: 1}
+		printJobRepo.On("FindByUUID", job.UUID).Return(job, nil)
+		printJobRepo.On("Complete", uint(1)).Return(true, nil)
+
+		err := service.Ack(job.UUID, services.AckPrintJobRequest{Status: models.PrintJobStatusPrinted})
+
+		require.NoError(t, err)
+		printJobRepo.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - failed ticket is queued again", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		job := &models.PrintJob{ID: 1, UUID: uuid.New(), Attempts: 2}
+		reason := "out of paper"
+		printJobRepo.On("FindByUUID", job.UUID).Return(job, nil)
+		printJobRepo.On("Fail", uint(1), reason, true).Return(true, nil)
+
+		err := service.Ack(job.UUID, services.AckPrintJobRequest{Status: models.PrintJobStatusFailed, Error: &reason})
+
+		require.NoError(t, err)
+		printJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - failed ticket out of attempts is given up on", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		job := &models.PrintJob{ID: 1, UUID: uuid.New(), Attempts: 5}
+		printJobRepo.On("FindByUUID", job.UUID).Return(job, nil)
+		printJobRepo.On("Fail", uint(1), mock.Anything, false).Return(true, nil)
+
+		err := service.Ack(job.UUID, services.AckPrintJobRequest{Status: models.PrintJobStatusFailed})
+
+		require.NoError(t, err)
+		printJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - ticket already acknowledged", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		job := &models.PrintJob{ID: 1, UUID: uuid.New(), Attempts: 1}
+		printJobRepo.On("FindByUUID", job.UUID).Return(job, nil)
+		printJobRepo.On("Complete", uint(1)).Return(false, nil)
+
+		err := service.Ack(job.UUID, services.AckPrintJobRequest{Status: models.PrintJobStatusPrinted})
+
+		assert.ErrorIs(t, err, services.ErrPrintJobNotClaimed)
+	})
+
+	t.Run("error - ticket not found", func(t *testing.T) {
+		service, printJobRepo, _ := newPrintService()
+		jobUUID := uuid.New()
+		printJobRepo.On("FindByUUID", jobUUID).Return(nil, repositories.ErrPrintJobNotFound)
+
+		err := service.Ack(jobUUID, services.AckPrintJobRequest{Status: models.PrintJobStatusPrinted})
+
+		assert.ErrorIs(t, err, services.ErrPrintJobNotFound)
+	})
+}
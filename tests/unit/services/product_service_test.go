@@ -203,7 +203,7 @@ func TestProductService_GetAll(t *testing.T) {
 			{ID: 2, UUID: uuid.New(), Name: "Product 2", Slug: "product-2", BasePrice: 20000, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 		}
 
-		mockProductRepo.On("FindAll", false, (*bool)(nil), (*uint)(nil)).Return(products, nil)
+		mockProductRepo.On("FindAll", false, (*bool)(nil), ([]uint)(nil)).Return(products, nil)
 
 		result, err := service.GetAll(false, false, nil)
 
@@ -212,6 +212,28 @@ func TestProductService_GetAll(t *testing.T) {
 		assert.Len(t, result, 2)
 		mockProductRepo.AssertExpectations(t)
 	})
+
+	t.Run("success - category filter includes subcategories", func(t *testing.T) {
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewProductService(mockProductRepo, mockCategoryRepo)
+
+		categoryUUID := uuid.New()
+		products := []models.Product{
+			{ID: 1, UUID: uuid.New(), Name: "Iced Matcha", Slug: "iced-matcha", BasePrice: 30000, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		}
+
+		mockCategoryRepo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 1, UUID: categoryUUID}, nil)
+		mockCategoryRepo.On("FindDescendantIDs", uint(1)).Return([]uint{1, 2, 3}, nil)
+		mockProductRepo.On("FindAll", false, (*bool)(nil), []uint{1, 2, 3}).Return(products, nil)
+
+		result, err := service.GetAll(false, false, &categoryUUID)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockCategoryRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
 }
 
 func TestProductService_Update(t *testing.T) {
@@ -519,3 +541,32 @@ func TestProductService_DeleteCustomization(t *testing.T) {
 		mockProductRepo.AssertExpectations(t)
 	})
 }
+
+func TestProductService_Reorder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewProductService(mockProductRepo, mockCategoryRepo)
+
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		mockProductRepo.On("Reorder", ids).Return(nil)
+
+		err := service.Reorder(services.ReorderRequest{IDs: ids})
+
+		assert.NoError(t, err)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - unknown product", func(t *testing.T) {
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewProductService(mockProductRepo, mockCategoryRepo)
+
+		ids := []uuid.UUID{uuid.New()}
+		mockProductRepo.On("Reorder", ids).Return(repositories.ErrProductNotFound)
+
+		err := service.Reorder(services.ReorderRequest{IDs: ids})
+
+		assert.Equal(t, services.ErrProductNotFound, err)
+	})
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromotionService_Create(t *testing.T) {
+	newService := func() (services.PromotionService, *mocks.MockPromotionRepository, *mocks.MockProductRepository, *mocks.MockCategoryRepository) {
+		promotionRepo := new(mocks.MockPromotionRepository)
+		productRepo := new(mocks.MockProductRepository)
+		categoryRepo := new(mocks.MockCategoryRepository)
+		return services.NewPromotionService(promotionRepo, productRepo, categoryRepo), promotionRepo, productRepo, categoryRepo
+	}
+
+	t.Run("success - stores the code upper case with its scope", func(t *testing.T) {
+		service, promotionRepo, productRepo, categoryRepo := newService()
+		productUUID := uuid.New()
+		categoryUUID := uuid.New()
+		productRepo.On("FindByUUID", productUUID).Return(&models.Product{ID: 1, UUID: productUUID, Name: "Matcha Latte"}, nil)
+		categoryRepo.On("FindByUUID", categoryUUID).Return(&models.Category{ID: 3, UUID: categoryUUID, Name: "Drinks"}, nil)
+		promotionRepo.On("FindByCode", "MATCHA20").Return(nil, repositories.ErrPromotionNotFound)
+
+		var saved *models.Promotion
+		promotionRepo.On("Create", mock.AnythingOfType("*models.Promotion")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Promotion)
+			}).
+			Return(nil)
+		promotionRepo.On("CountRedemptions", mock.Anything, (*uint)(nil)).Return(int64(0), nil)
+
+		result, err := service.Create(services.PromotionRequest{
+			Code:          "matcha20",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 20,
+			ProductIDs:    []uuid.UUID{productUUID},
+			CategoryIDs:   []uuid.UUID{categoryUUID},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MATCHA20", saved.Code)
+		assert.True(t, saved.IsActive)
+		assert.Equal(t, uint(1), saved.Products[0].ID)
+		assert.Equal(t, uint(3), saved.Categories[0].ID)
+		assert.Equal(t, "MATCHA20", result.Code)
+		assert.Equal(t, "Drinks", result.Categories[0].Name)
+	})
+
+	t.Run("error - code already exists", func(t *testing.T) {
+		service, promotionRepo, _, _ := newService()
+		promotionRepo.On("FindByCode", "MATCHA20").Return(&models.Promotion{ID: 2, Code: "MATCHA20"}, nil)
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "Matcha20",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 10000,
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromotionCodeExists)
+		promotionRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - percentage above 100", func(t *testing.T) {
+		service, _, _, _ := newService()
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "FREE",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 150,
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromotionPercentTooHigh)
+	})
+
+	t.Run("error - free delivery above 100 percent of the fee", func(t *testing.T) {
+		service, _, _, _ := newService()
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "FREEONGKIR",
+			DiscountType:  models.DiscountTypeFreeDelivery,
+			DiscountValue: 150,
+			MinSpend:      150000,
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromotionPercentTooHigh)
+	})
+
+	t.Run("error - expires before it starts", func(t *testing.T) {
+		service, _, _, _ := newService()
+		startsAt := time.Now()
+		expiresAt := startsAt.Add(-time.Hour)
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "LATE",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 5000,
+			StartsAt:      &startsAt,
+			ExpiresAt:     &expiresAt,
+		})
+
+		assert.ErrorIs(t, err, services.ErrInvalidPromotionPeriod)
+	})
+
+	t.Run("success - happy hour keeps its days and hours", func(t *testing.T) {
+		service, promotionRepo, _, _ := newService()
+		promotionRepo.On("FindByCode", "ICED20").Return(nil, repositories.ErrPromotionNotFound)
+		var saved *models.Promotion
+		promotionRepo.On("Create", mock.AnythingOfType("*models.Promotion")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.Promotion)
+			}).
+			Return(nil)
+		promotionRepo.On("CountRedemptions", mock.Anything, (*uint)(nil)).Return(int64(0), nil)
+
+		name := "Happy Hour"
+		start, end := "14:00", "16:00"
+		result, err := service.Create(services.PromotionRequest{
+			Code:          "ICED20",
+			Name:          &name,
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 20,
+			Automatic:     true,
+			Days:          []string{"fri", "mon"},
+			StartTime:     &start,
+			EndTime:       &end,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1<<time.Monday|1<<time.Friday, saved.Days)
+		assert.Equal(t, []string{"mon", "fri"}, result.Days)
+		assert.Equal(t, "14:00", *result.StartTime)
+		assert.Equal(t, "Happy Hour", *result.Name)
+	})
+
+	t.Run("error - hours without an end", func(t *testing.T) {
+		service, _, _, _ := newService()
+		start := "14:00"
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "ICED20",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 20,
+			StartTime:     &start,
+		})
+
+		assert.ErrorIs(t, err, services.ErrInvalidPromotionHours)
+	})
+
+	t.Run("error - unknown product in scope", func(t *testing.T) {
+		service, promotionRepo, productRepo, _ := newService()
+		productUUID := uuid.New()
+		promotionRepo.On("FindByCode", "LATTE").Return(nil, repositories.ErrPromotionNotFound)
+		productRepo.On("FindByUUID", productUUID).Return(nil, repositories.ErrProductNotFound)
+
+		_, err := service.Create(services.PromotionRequest{
+			Code:          "LATTE",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 5000,
+			ProductIDs:    []uuid.UUID{productUUID},
+		})
+
+		assert.ErrorIs(t, err, services.ErrProductNotFound)
+	})
+}
+
+func TestPromotionService_Update(t *testing.T) {
+	t.Run("success - keeping the code skips the duplicate check", func(t *testing.T) {
+		promotionRepo := new(mocks.MockPromotionRepository)
+		service := services.NewPromotionService(promotionRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+		promotionUUID := uuid.New()
+		existing := &models.Promotion{ID: 4, UUID: promotionUUID, Code: "MATCHA20", IsActive: true}
+		promotionRepo.On("FindByUUID", promotionUUID).Return(existing, nil)
+		promotionRepo.On("Update", existing).Return(nil)
+		promotionRepo.On("CountRedemptions", uint(4), (*uint)(nil)).Return(int64(12), nil)
+		inactive := false
+
+		result, err := service.Update(promotionUUID, services.PromotionRequest{
+			Code:          "MATCHA20",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 10000,
+			IsActive:      &inactive,
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, existing.IsActive)
+		assert.Equal(t, int64(12), result.Redemptions)
+		promotionRepo.AssertNotCalled(t, "FindByCode", mock.Anything)
+	})
+
+	t.Run("error - promotion not found", func(t *testing.T) {
+		promotionRepo := new(mocks.MockPromotionRepository)
+		service := services.NewPromotionService(promotionRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+		promotionUUID := uuid.New()
+		promotionRepo.On("FindByUUID", promotionUUID).Return(nil, repositories.ErrPromotionNotFound)
+
+		_, err := service.Update(promotionUUID, services.PromotionRequest{
+			Code:          "MATCHA20",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 10000,
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromotionNotFound)
+	})
+}
+
+func TestPromotionService_GetStats(t *testing.T) {
+	t.Run("success - sums up the orders that applied it", func(t *testing.T) {
+		promotionRepo := new(mocks.MockPromotionRepository)
+		service := services.NewPromotionService(promotionRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+		promotionUUID := uuid.New()
+		first := time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)
+		last := time.Date(2025, 2, 27, 17, 45, 0, 0, time.UTC)
+		promotionRepo.On("FindByUUID", promotionUUID).Return(&models.Promotion{ID: 7, UUID: promotionUUID, Code: "MATCHA20"}, nil)
+		promotionRepo.On("Stats", uint(7)).Return(&repositories.PromotionStats{
+			Redemptions:     3,
+			Customers:       2,
+			DiscountGiven:   30000,
+			Revenue:         250000,
+			FirstRedeemedAt: &first,
+			LastRedeemedAt:  &last,
+		}, nil)
+
+		result, err := service.GetStats(promotionUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MATCHA20", result.Code)
+		assert.Equal(t, int64(3), result.Redemptions)
+		assert.Equal(t, 30000.0, result.DiscountGiven)
+		assert.Equal(t, 83333.33, result.AverageOrderValue)
+		assert.Equal(t, "2025-02-27T17:45:00Z", *result.LastRedeemedAt)
+	})
+
+	t.Run("success - never redeemed", func(t *testing.T) {
+		promotionRepo := new(mocks.MockPromotionRepository)
+		service := services.NewPromotionService(promotionRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+		promotionUUID := uuid.New()
+		promotionRepo.On("FindByUUID", promotionUUID).Return(&models.Promotion{ID: 7, UUID: promotionUUID, Code: "MATCHA20"}, nil)
+		promotionRepo.On("Stats", uint(7)).Return(&repositories.PromotionStats{}, nil)
+
+		result, err := service.GetStats(promotionUUID)
+
+		assert.NoError(t, err)
+		assert.Zero(t, result.AverageOrderValue)
+		assert.Nil(t, result.FirstRedeemedAt)
+	})
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQRCodeService() (services.QRCodeService, *mocks.MockOrderRepository, *mocks.MockDiningTableRepository) {
+	orderRepo := new(mocks.MockOrderRepository)
+	tableRepo := new(mocks.MockDiningTableRepository)
+	settingRepo := new(mocks.MockSettingRepository)
+	settingRepo.On("FindByKey", models.SettingKeyQRCode).Return(nil, repositories.ErrSettingNotFound)
+
+	service := services.NewQRCodeService(orderRepo, tableRepo, services.NewSettingsService(settingRepo), "https://matchaciee.com")
+	return service, orderRepo, tableRepo
+}
+
+func TestQRCodeService_Generate(t *testing.T) {
+	t.Run("success - menu as png", func(t *testing.T) {
+		service, _, _ := newTestQRCodeService()
+
+		result, err := service.Generate(services.GenerateQRCodeRequest{Target: services.QRCodeTargetMenu})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://matchaciee.com/menu", result.URL)
+		assert.Equal(t, "image/png", result.ContentType)
+		assert.True(t, bytes.HasPrefix(result.Body, []byte("\x89PNG")))
+	})
+
+	t.Run("success - table as svg in store colors", func(t *testing.T) {
+		service, _, tableRepo := newTestQRCodeService()
+		tableUUID := uuid.New()
+		tableRepo.On("FindByUUID", tableUUID).Return(&models.DiningTable{UUID: tableUUID, Number: "12", Token: "3c1f0a9b"}, nil)
+
+		result, err := service.Generate(services.GenerateQRCodeRequest{
+			Target:  services.QRCodeTargetTable,
+			TableID: &tableUUID,
+			Format:  services.QRCodeFormatSVG,
+			Size:    256,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://matchaciee.com/menu?table=3c1f0a9b", result.URL)
+		assert.Equal(t, "image/svg+xml", result.ContentType)
+		assert.True(t, strings.HasPrefix(string(result.Body), "<svg"))
+		assert.Contains(t, string(result.Body), `width="256"`)
+		assert.Contains(t, string(result.Body), services.DefaultQRCodeSettings.ForegroundColor)
+	})
+
+	t.Run("error - table not found", func(t *testing.T) {
+		service, _, tableRepo := newTestQRCodeService()
+		tableUUID := uuid.New()
+		tableRepo.On("FindByUUID", tableUUID).Return(nil, repositories.ErrDiningTableNotFound)
+
+		result, err := service.Generate(services.GenerateQRCodeRequest{Target: services.QRCodeTargetTable, TableID: &tableUUID})
+
+		assert.ErrorIs(t, err, services.ErrDiningTableNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("success - payment link of pending order", func(t *testing.T) {
+		service, orderRepo, _ := newTestQRCodeService()
+		orderUUID := uuid.New()
+		orderRepo.On("FindByUUID", orderUUID).Return(&models.Order{UUID: orderUUID, Status: models.OrderStatusPending}, nil)
+
+		result, err := service.Generate(services.GenerateQRCodeRequest{Target: services.QRCodeTargetPayment, OrderID: &orderUUID})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://matchaciee.com/orders/pay/"+orderUUID.String(), result.URL)
+	})
+
+	t.Run("error - order not found", func(t *testing.T) {
+		service, orderRepo, _ := newTestQRCodeService()
+		orderUUID := uuid.New()
+		orderRepo.On("FindByUUID", orderUUID).Return(nil, repositories.ErrOrderNotFound)
+
+		result, err := service.Generate(services.GenerateQRCodeRequest{Target: services.QRCodeTargetPayment, OrderID: &orderUUID})
+
+		assert.ErrorIs(t, err, services.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - order no longer awaiting payment", func(t *testing.T) {
+		expired := time.Now().Add(-time.Minute)
+		orders := map[string]*models.Order{
+			"completed": {Status: models.OrderStatusCompleted},
+			"expired":   {Status: models.OrderStatusPending, PaymentExpiresAt: &expired},
+		}
+
+		for name, order := range orders {
+			t.Run(name, func(t *testing.T) {
+				service, orderRepo, _ := newTestQRCodeService()
+				orderUUID := uuid.New()
+				order.UUID = orderUUID
+				orderRepo.On("FindByUUID", orderUUID).Return(order, nil)
+
+				result, err := service.Generate(services.GenerateQRCodeRequest{Target: services.QRCodeTargetPayment, OrderID: &orderUUID})
+
+				assert.ErrorIs(t, err, services.ErrOrderNotPayable)
+				assert.Nil(t, result)
+			})
+		}
+	})
+}
@@ -0,0 +1,555 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportService_GetRevenueBySource(t *testing.T) {
+	t.Run("success - every source listed with totals", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("RevenueBySource", start, end.AddDate(0, 0, 1)).Return([]repositories.SourceRevenueRow{
+			{OrderSource: models.OrderSourceMember, OrderCount: 3, Subtotal: 100000, Tax: 10000, Revenue: 110000, GatewayFees: 770},
+			{OrderSource: models.OrderSourceCatering, OrderCount: 1, Subtotal: 500000, Tax: 50000, Revenue: 550000},
+		}, nil)
+
+		result, err := service.GetRevenueBySource(start, end)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-01-01", result.StartDate)
+		assert.Equal(t, "2026-01-31", result.EndDate)
+		assert.Len(t, result.Sources, len(models.OrderSources))
+		assert.Equal(t, int64(4), result.TotalOrders)
+		assert.Equal(t, 660000.0, result.TotalRevenue)
+		assert.Equal(t, 770.0, result.TotalGatewayFees)
+		assert.Equal(t, 659230.0, result.TotalNetRevenue)
+
+		for _, source := range result.Sources {
+			switch source.OrderSource {
+			case models.OrderSourceMember:
+				assert.Equal(t, 110000.0, source.Revenue)
+				assert.Equal(t, 109230.0, source.NetRevenue)
+			case models.OrderSourceCatering:
+				assert.Equal(t, int64(1), source.OrderCount)
+			default:
+				assert.Zero(t, source.OrderCount)
+			}
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		result, err := service.GetRevenueBySource(start, end)
+
+		assert.Nil(t, result)
+		assert.Equal(t, services.ErrInvalidDateRange, err)
+		mockRepo.AssertNotCalled(t, "RevenueBySource")
+	})
+
+	t.Run("error - repository error", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("RevenueBySource", start, start.AddDate(0, 0, 1)).Return(nil, errors.New("database error"))
+
+		result, err := service.GetRevenueBySource(start, start)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestReportService_GetSales(t *testing.T) {
+	t.Run("success - weeks start on Monday and empty weeks are listed", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		// Thursday 1 January to Saturday 17 January 2026
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("SalesByPeriod", start, end.AddDate(0, 0, 1), "week").Return([]repositories.SalesRow{
+			{Period: time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), OrderCount: 4, Subtotal: 200000, Tax: 20000, Revenue: 220000},
+			{Period: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), OrderCount: 3, Subtotal: 90000, Discount: 10000, Tax: 8000, Revenue: 88000},
+		}, nil)
+
+		result, err := service.GetSales(start, end, services.SalesGranularityWeek)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "week", result.Granularity)
+		if assert.Len(t, result.Periods, 3) {
+			assert.Equal(t, "2025-12-29", result.Periods[0].Period)
+			assert.Equal(t, 55000.0, result.Periods[0].AverageTicket)
+			assert.Equal(t, "2026-01-05", result.Periods[1].Period)
+			assert.Zero(t, result.Periods[1].OrderCount)
+			assert.Zero(t, result.Periods[1].AverageTicket)
+			assert.Equal(t, "2026-01-12", result.Periods[2].Period)
+			assert.Equal(t, 10000.0, result.Periods[2].Discount)
+		}
+		assert.Equal(t, int64(7), result.TotalOrders)
+		assert.Equal(t, 308000.0, result.TotalRevenue)
+		assert.Equal(t, 28000.0, result.TotalTax)
+		assert.Equal(t, 44000.0, result.AverageTicket)
+	})
+
+	t.Run("success - months run to the end of the range", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("SalesByPeriod", start, end.AddDate(0, 0, 1), "month").Return([]repositories.SalesRow{}, nil)
+
+		result, err := service.GetSales(start, end, services.SalesGranularityMonth)
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.Periods, 3) {
+			assert.Equal(t, "2026-01-01", result.Periods[0].Period)
+			assert.Equal(t, "2026-03-01", result.Periods[2].Period)
+		}
+	})
+
+	t.Run("error - unknown granularity", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := service.GetSales(day, day, "hour")
+
+		assert.ErrorIs(t, err, services.ErrInvalidSalesGranularity)
+		mockRepo.AssertNotCalled(t, "SalesByPeriod", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		_, err := service.GetSales(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), services.SalesGranularityDay)
+
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestReportService_GetTopProducts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success - products ranked with how often each option was chosen", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		latte := uuid.New()
+		mockRepo.On("TopProducts", start, end.AddDate(0, 0, 1), 5).Return([]repositories.TopProductRow{
+			{ProductUUID: &latte, ProductName: "Matcha Latte", Quantity: 40, OrderCount: 35, Revenue: 2000000},
+			{ProductName: "Hojicha Cookie", Quantity: 12, OrderCount: 10, Revenue: 300000},
+		}, nil)
+		mockRepo.On("CustomizationsSold", start, end.AddDate(0, 0, 1), []uuid.UUID{latte}).Return([]repositories.CustomizationRow{
+			{ProductUUID: latte, CustomizationType: "milk", OptionName: "Oat Milk", Quantity: 16},
+			{ProductUUID: latte, CustomizationType: "sweetness", OptionName: "Less Sweet", Quantity: 5},
+		}, nil)
+
+		result, err := service.GetTopProducts(start, end, 5)
+
+		assert.NoError(t, err)
+		if assert.Len(t, result.Products, 2) {
+			top := result.Products[0]
+			assert.Equal(t, latte, *top.ProductID)
+			if assert.Len(t, top.Customizations, 2) {
+				assert.Equal(t, "Oat Milk", top.Customizations[0].OptionName)
+				assert.Equal(t, 40.0, top.Customizations[0].Share)
+				assert.Equal(t, 12.5, top.Customizations[1].Share)
+			}
+			assert.Nil(t, result.Products[1].ProductID)
+			assert.Empty(t, result.Products[1].Customizations)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		_, err := service.GetTopProducts(end, start, 10)
+
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+		mockRepo.AssertNotCalled(t, "TopProducts", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportService_GetRevenueByCategory(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success - top-level categories with their share of revenue", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		drinks, food := uuid.New(), uuid.New()
+		drinksName, foodName := "Drinks", "Food"
+		mockRepo.On("RevenueByCategory", start, end.AddDate(0, 0, 1), (*uuid.UUID)(nil)).Return([]repositories.CategoryRevenueRow{
+			{CategoryUUID: &drinks, CategoryName: &drinksName, Quantity: 60, OrderCount: 50, Revenue: 3000000},
+			{CategoryUUID: &food, CategoryName: &foodName, Quantity: 30, OrderCount: 25, Revenue: 900000},
+			{Quantity: 2, OrderCount: 2, Revenue: 100000},
+		}, nil)
+
+		result, err := service.GetRevenueByCategory(start, end, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4000000.0, result.TotalRevenue)
+		if assert.Len(t, result.Categories, 3) {
+			assert.Equal(t, "Drinks", result.Categories[0].CategoryName)
+			assert.Equal(t, 75.0, result.Categories[0].Share)
+			assert.Equal(t, 22.5, result.Categories[1].Share)
+			assert.Nil(t, result.Categories[2].CategoryID)
+			assert.Equal(t, "Uncategorized", result.Categories[2].CategoryName)
+		}
+	})
+
+	t.Run("success - no sales leaves shares at zero", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		parent := uuid.New()
+		mockRepo.On("RevenueByCategory", start, end.AddDate(0, 0, 1), &parent).Return([]repositories.CategoryRevenueRow{}, nil)
+
+		result, err := service.GetRevenueByCategory(start, end, &parent)
+
+		assert.NoError(t, err)
+		assert.Equal(t, parent, *result.ParentID)
+		assert.Empty(t, result.Categories)
+		assert.Zero(t, result.TotalRevenue)
+	})
+
+	t.Run("error - parent category not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		parent := uuid.New()
+		mockRepo.On("RevenueByCategory", start, end.AddDate(0, 0, 1), &parent).Return(nil, repositories.ErrCategoryNotFound)
+
+		_, err := service.GetRevenueByCategory(start, end, &parent)
+
+		assert.ErrorIs(t, err, services.ErrCategoryNotFound)
+	})
+}
+
+func TestReportService_GetTax(t *testing.T) {
+	t.Run("success - tax per rate and every day of the month", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("TaxByDay", start, start.AddDate(0, 1, 0)).Return([]repositories.TaxRow{
+			{Day: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC), TaxRate: 0.10, OrderCount: 3, TaxableSales: 150000, Tax: 15000},
+			{Day: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC), TaxRate: 0.10, OrderCount: 1, TaxableSales: 50000, Tax: 5000},
+			{Day: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC), TaxRate: 0.11, OrderCount: 2, TaxableSales: 100000, Tax: 11000},
+		}, nil)
+
+		result, err := service.GetTax(time.Date(2026, 2, 14, 15, 0, 0, 0, time.UTC))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-02", result.Month)
+		assert.Len(t, result.Rates, 2)
+		assert.Equal(t, services.TaxRateSummary{Rate: 10, OrderCount: 4, TaxableSales: 200000, Tax: 20000}, result.Rates[0])
+		assert.Equal(t, services.TaxRateSummary{Rate: 11, OrderCount: 2, TaxableSales: 100000, Tax: 11000}, result.Rates[1])
+		assert.Len(t, result.Days, 28)
+		assert.Equal(t, services.TaxDay{Date: "2026-02-01"}, result.Days[0])
+		assert.Equal(t, int64(3), result.Days[19].OrderCount)
+		assert.Equal(t, 16000.0, result.Days[19].Tax)
+		assert.Equal(t, int64(6), result.TotalOrders)
+		assert.Equal(t, 300000.0, result.TotalTaxableSales)
+		assert.Equal(t, 31000.0, result.TotalTax)
+	})
+}
+
+func TestReportService_GetFulfillmentTimes(t *testing.T) {
+	t.Run("success - percentiles in minutes for every day and stage", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+		day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("FulfillmentTimes", start, end.AddDate(0, 0, 1)).Return([]repositories.FulfillmentRow{
+			{Stage: "preparation", OrderCount: 12, P50: 390, P90: 855},
+			{Day: &day, Stage: "payment", OrderCount: 12, P50: 45, P90: 120},
+			{Day: &day, Stage: "preparation", OrderCount: 12, P50: 390, P90: 855},
+		}, nil)
+
+		result, err := service.GetFulfillmentTimes(start, end)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Overall, 4)
+		assert.Equal(t, services.FulfillmentStagePreparation, result.Overall[1].Stage)
+		assert.Equal(t, 6.5, *result.Overall[1].P50Minutes)
+		assert.Equal(t, 14.25, *result.Overall[1].P90Minutes)
+		assert.Nil(t, result.Overall[0].P50Minutes)
+
+		assert.Len(t, result.Days, 3)
+		assert.Equal(t, "2026-01-02", result.Days[1].Date)
+		assert.Equal(t, int64(12), result.Days[1].Stages[0].OrderCount)
+		assert.Equal(t, 0.75, *result.Days[1].Stages[0].P50Minutes)
+		assert.Nil(t, result.Days[1].Stages[3].P90Minutes)
+		assert.Equal(t, int64(0), result.Days[0].Stages[1].OrderCount)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		service := services.NewReportService(new(mocks.MockReportRepository), services.ReportConfig{})
+
+		result, err := service.GetFulfillmentTimes(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestReportService_GetAbandonedOrders(t *testing.T) {
+	t.Run("success - drop-off per source and hour", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("AbandonedOrders", start, end.AddDate(0, 0, 1), mock.Anything).Return([]repositories.AbandonedRow{
+			{OrderSource: models.OrderSourceGuest, Hour: 12, OrderCount: 10, AbandonedCount: 1, Attempted: 0, AbandonedValue: 30000},
+			{OrderSource: models.OrderSourceKiosk, Hour: 9, OrderCount: 4, AbandonedCount: 2, Attempted: 2, AbandonedValue: 90000},
+			{OrderSource: models.OrderSourceKiosk, Hour: 12, OrderCount: 6, AbandonedCount: 3, Attempted: 1, AbandonedValue: 120000},
+		}, nil)
+
+		result, err := service.GetAbandonedOrders(start, end)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Sources, len(models.OrderSources))
+		kiosk := result.Sources[2]
+		assert.Equal(t, models.OrderSourceKiosk, kiosk.OrderSource)
+		assert.Equal(t, int64(10), kiosk.OrderCount)
+		assert.Equal(t, int64(5), kiosk.AbandonedCount)
+		assert.Equal(t, int64(3), kiosk.Attempted)
+		assert.Equal(t, 50.0, kiosk.DropOffRate)
+		assert.Len(t, kiosk.Hours, 2)
+		assert.Equal(t, 50.0, kiosk.Hours[0].DropOffRate)
+		assert.Empty(t, result.Sources[1].Hours)
+
+		assert.Len(t, result.Hours, 2)
+		assert.Equal(t, 9, result.Hours[0].Hour)
+		assert.Equal(t, 12, result.Hours[1].Hour)
+		assert.Equal(t, int64(16), result.Hours[1].OrderCount)
+		assert.Equal(t, 25.0, result.Hours[1].DropOffRate)
+
+		assert.Equal(t, int64(20), result.TotalOrders)
+		assert.Equal(t, int64(6), result.TotalAbandoned)
+		assert.Equal(t, 240000.0, result.TotalAbandonedValue)
+		assert.Equal(t, 30.0, result.DropOffRate)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		service := services.NewReportService(new(mocks.MockReportRepository), services.ReportConfig{})
+
+		result, err := service.GetAbandonedOrders(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestReportService_GetInventoryUsage(t *testing.T) {
+	t.Run("success - soonest stockout first", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+		cheesecake := uuid.New()
+		cookie := uuid.New()
+
+		mockRepo.On("InventoryUsage", start, end.AddDate(0, 0, 1), mock.Anything).Return([]repositories.InventoryUsageRow{
+			{ProductUUID: cookie, ProductName: "Matcha Cookie", StockQuantity: 200, Consumed: 100},
+			{ProductUUID: uuid.New(), ProductName: "Gift Tin", StockQuantity: 15},
+			{ProductUUID: cheesecake, ProductName: "Matcha Cheesecake", StockQuantity: 24, Reserved: 4, Consumed: 50},
+		}, nil)
+
+		result, err := service.GetInventoryUsage(start, end)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, result.Days)
+		assert.Len(t, result.Products, 3)
+
+		assert.Equal(t, cheesecake, result.Products[0].ProductID)
+		assert.Equal(t, int64(20), result.Products[0].Available)
+		assert.Equal(t, 5.0, result.Products[0].DailyUsage)
+		assert.Equal(t, 4.0, *result.Products[0].DaysUntilStockout)
+
+		assert.Equal(t, cookie, result.Products[1].ProductID)
+		assert.Equal(t, 20.0, *result.Products[1].DaysUntilStockout)
+
+		assert.Equal(t, "Gift Tin", result.Products[2].ProductName)
+		assert.Nil(t, result.Products[2].DaysUntilStockout)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		service := services.NewReportService(new(mocks.MockReportRepository), services.ReportConfig{})
+
+		result, err := service.GetInventoryUsage(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestReportService_GetTips(t *testing.T) {
+	t.Run("success - tips grouped by staff with unclaimed orders", func(t *testing.T) {
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, services.ReportConfig{})
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		staffUUID := uuid.New()
+		name := "Rina Barista"
+
+		mockRepo.On("TipsByStaff", start, end.AddDate(0, 0, 1)).Return([]repositories.StaffTipsRow{
+			{StaffUUID: &staffUUID, FullName: &name, OrderCount: 4, Tips: 40000},
+			{OrderCount: 2, Tips: 15000},
+		}, nil)
+
+		result, err := service.GetTips(start, end)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Staff, 2)
+		assert.Equal(t, staffUUID, result.Staff[0].Staff.ID)
+		assert.Equal(t, name, result.Staff[0].Staff.FullName)
+		assert.Nil(t, result.Staff[1].Staff)
+		assert.Equal(t, int64(6), result.TippedOrders)
+		assert.Equal(t, 55000.0, result.TotalTips)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		service := services.NewReportService(new(mocks.MockReportRepository), services.ReportConfig{})
+
+		result, err := service.GetTips(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestReportService_GetSettlements(t *testing.T) {
+	qris := "qris"
+	card := "credit_card"
+	config := services.ReportConfig{
+		GatewayFees: services.GatewayFees{
+			"qris":        {Percent: 0.7},
+			"credit_card": {Percent: 2.9, Fixed: 2000},
+		},
+		PayoutDelayDays: 1,
+	}
+
+	t.Run("success - fees, refunds and payout dates", func(t *testing.T) {
+		recordedFee := 150.0
+		mockRepo := new(mocks.MockReportRepository)
+		service := services.NewReportService(mockRepo, config)
+
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.On("SettledPayments", start, end.AddDate(0, 0, 1)).Return([]repositories.SettlementRow{
+			// Friday, paid out on Monday
+			{OrderNumber: "MC-260109-001", Method: models.PaymentMethodMidtrans, PaymentType: &qris, GrossAmount: 50000, SettlementTime: time.Date(2026, 1, 9, 10, 0, 0, 0, time.UTC)},
+			// Fee recorded on the payment wins over the schedule
+			{OrderNumber: "MC-260109-007", Method: models.PaymentMethodXendit, PaymentType: &qris, GrossAmount: 20000, GatewayFee: &recordedFee, SettlementTime: time.Date(2026, 1, 9, 11, 0, 0, 0, time.UTC)},
+			{OrderNumber: "MC-260112-004", Method: models.PaymentMethodMidtrans, PaymentType: &card, GrossAmount: 100000, Refunded: 20000, SettlementTime: time.Date(2026, 1, 12, 18, 30, 0, 0, time.UTC)},
+			{OrderNumber: "MC-260113-002", Method: models.PaymentMethodXendit, GrossAmount: 30000, SettlementTime: time.Date(2026, 1, 13, 8, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		result, err := service.GetSettlements(start, end)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Settlements, 4)
+
+		assert.Equal(t, 350.0, result.Settlements[0].Fee)
+		assert.Equal(t, 49650.0, result.Settlements[0].NetAmount)
+		assert.Equal(t, "2026-01-12", result.Settlements[0].PayoutDate)
+
+		assert.Equal(t, 150.0, result.Settlements[1].Fee)
+		assert.Equal(t, 19850.0, result.Settlements[1].NetAmount)
+
+		assert.Equal(t, 4900.0, result.Settlements[2].Fee)
+		assert.Equal(t, 75100.0, result.Settlements[2].NetAmount)
+		assert.Equal(t, "2026-01-13", result.Settlements[2].PayoutDate)
+
+		assert.Zero(t, result.Settlements[3].Fee)
+		assert.Equal(t, 30000.0, result.Settlements[3].NetAmount)
+
+		assert.Equal(t, 200000.0, result.TotalGross)
+		assert.Equal(t, 20000.0, result.TotalRefunded)
+		assert.Equal(t, 5400.0, result.TotalFees)
+		assert.Equal(t, 174600.0, result.TotalNet)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - csv export", func(t *testing.T) {
+		report := &services.SettlementReportResponse{Settlements: []services.Settlement{
+			{SettledAt: "2026-01-09T10:00:00Z", PayoutDate: "2026-01-12", OrderNumber: "MC-260109-001", Reference: "MC-260109-001-1", Provider: models.PaymentMethodMidtrans, PaymentType: &qris, GrossAmount: 50000, Fee: 350, NetAmount: 49650},
+		}}
+
+		var buf bytes.Buffer
+		err := report.WriteCSV(&buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t,
+			"settled_at,payout_date,order_number,reference,transaction_id,provider,payment_type,gross_amount,refunded,fee,net_amount\n"+
+				"2026-01-09T10:00:00Z,2026-01-12,MC-260109-001,MC-260109-001-1,,midtrans,qris,50000.00,0.00,350.00,49650.00\n",
+			buf.String())
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		service := services.NewReportService(new(mocks.MockReportRepository), config)
+
+		result, err := service.GetSettlements(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+	})
+}
+
+func TestParseGatewayFees(t *testing.T) {
+	t.Run("success - percentage, fixed and combined rates", func(t *testing.T) {
+		fees, err := services.ParseGatewayFees([]string{"qris=0.7%", "bank_transfer=4000", "credit_card=2.9%+2000"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, services.GatewayFee{Percent: 0.7}, fees["qris"])
+		assert.Equal(t, services.GatewayFee{Fixed: 4000}, fees["bank_transfer"])
+		assert.Equal(t, services.GatewayFee{Percent: 2.9, Fixed: 2000}, fees["credit_card"])
+	})
+
+	t.Run("error - malformed entries", func(t *testing.T) {
+		for _, spec := range []string{"qris", "qris=", "qris=abc%", "gopay=-2%"} {
+			_, err := services.ParseGatewayFees([]string{spec})
+			assert.Error(t, err, spec)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardService_Create(t *testing.T) {
+	drinks := &models.Category{ID: 4, UUID: uuid.New(), Name: "Drinks"}
+
+	freeDrink := func() services.RewardRequest {
+		return services.RewardRequest{
+			Name:          "Free Matcha Latte",
+			PointsCost:    500,
+			DiscountType:  models.DiscountTypeFreeItem,
+			DiscountValue: 100,
+			CategoryID:    &drinks.UUID,
+			ValidDays:     30,
+		}
+	}
+
+	t.Run("success - free drink is scoped to its category", func(t *testing.T) {
+		rewardRepo := new(mocks.MockRewardRepository)
+		categoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewRewardService(rewardRepo, new(mocks.MockLoyaltyRepository), new(mocks.MockUserRepository), categoryRepo)
+
+		categoryRepo.On("FindByUUID", drinks.UUID).Return(drinks, nil)
+		var saved *models.Reward
+		rewardRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.Reward)
+		}).Return(nil)
+		rewardRepo.On("CountRedemptions", uint(0)).Return(int64(0), nil)
+
+		reward, err := service.Create(freeDrink())
+
+		require.NoError(t, err)
+		assert.True(t, saved.IsActive)
+		assert.Equal(t, drinks.ID, *saved.CategoryID)
+		assert.Equal(t, "Drinks", reward.Category.Name)
+		assert.Equal(t, 500, reward.PointsCost)
+	})
+
+	t.Run("error - free item without a category", func(t *testing.T) {
+		rewardRepo := new(mocks.MockRewardRepository)
+		service := services.NewRewardService(rewardRepo, new(mocks.MockLoyaltyRepository), new(mocks.MockUserRepository), new(mocks.MockCategoryRepository))
+
+		req := freeDrink()
+		req.CategoryID = nil
+		_, err := service.Create(req)
+
+		assert.ErrorIs(t, err, services.ErrRewardCategoryRequired)
+		rewardRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error - percentage above 100", func(t *testing.T) {
+		rewardRepo := new(mocks.MockRewardRepository)
+		service := services.NewRewardService(rewardRepo, new(mocks.MockLoyaltyRepository), new(mocks.MockUserRepository), new(mocks.MockCategoryRepository))
+
+		_, err := service.Create(services.RewardRequest{
+			Name:          "Big Discount",
+			PointsCost:    1000,
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 120,
+		})
+
+		assert.ErrorIs(t, err, services.ErrPromotionPercentTooHigh)
+		rewardRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestRewardService_Redeem(t *testing.T) {
+	member := &models.User{ID: 3, UUID: uuid.New(), FullName: "Test Member", Role: models.RoleMember}
+	rewardUUID := uuid.New()
+	categoryID := uint(4)
+
+	newReward := func() *models.Reward {
+		return &models.Reward{
+			ID:            9,
+			UUID:          rewardUUID,
+			Name:          "Free Matcha Latte",
+			PointsCost:    500,
+			DiscountType:  models.DiscountTypeFreeItem,
+			DiscountValue: 100,
+			CategoryID:    &categoryID,
+			ValidDays:     30,
+			IsActive:      true,
+		}
+	}
+
+	newService := func(reward *models.Reward) (services.RewardService, *mocks.MockRewardRepository) {
+		rewardRepo := new(mocks.MockRewardRepository)
+		rewardRepo.On("FindByUUID", rewardUUID).Return(reward, nil)
+		userRepo := new(mocks.MockUserRepository)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		service := services.NewRewardService(rewardRepo, new(mocks.MockLoyaltyRepository), userRepo, new(mocks.MockCategoryRepository))
+		return service, rewardRepo
+	}
+
+	t.Run("success - spends points and issues a voucher on the reward's terms", func(t *testing.T) {
+		service, rewardRepo := newService(newReward())
+		var entry *models.PointTransaction
+		var voucher *models.Voucher
+		rewardRepo.On("Redeem", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			entry = args.Get(1).(*models.PointTransaction)
+			entry.BalanceAfter = 250
+			voucher = args.Get(2).(*models.Voucher)
+		}).Return(nil)
+
+		redemption, err := service.Redeem(member.UUID, rewardUUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.PointTransactionRedeem, entry.Type)
+		assert.Equal(t, -500, entry.Points)
+		assert.Equal(t, member.ID, voucher.UserID)
+		assert.Equal(t, uint(9), *voucher.RewardID)
+		assert.Equal(t, categoryID, *voucher.CategoryID)
+		assert.Equal(t, models.DiscountTypeFreeItem, voucher.DiscountType)
+		require.NotNil(t, voucher.ExpiresAt)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *voucher.ExpiresAt, time.Minute)
+		assert.Equal(t, 500, redemption.PointsSpent)
+		assert.Equal(t, 250, redemption.PointsBalance)
+	})
+
+	t.Run("error - not enough points", func(t *testing.T) {
+		service, rewardRepo := newService(newReward())
+		rewardRepo.On("Redeem", mock.Anything, mock.Anything, mock.Anything).Return(repositories.ErrInsufficientPoints)
+
+		_, err := service.Redeem(member.UUID, rewardUUID)
+
+		assert.ErrorIs(t, err, services.ErrInsufficientPoints)
+	})
+
+	t.Run("error - out of stock", func(t *testing.T) {
+		reward := newReward()
+		stock := 0
+		reward.Stock = &stock
+		service, rewardRepo := newService(reward)
+
+		_, err := service.Redeem(member.UUID, rewardUUID)
+
+		assert.ErrorIs(t, err, services.ErrRewardUnavailable)
+		rewardRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - stock ran out while redeeming", func(t *testing.T) {
+		service, rewardRepo := newService(newReward())
+		rewardRepo.On("Redeem", mock.Anything, mock.Anything, mock.Anything).Return(repositories.ErrRewardUnavailable)
+
+		_, err := service.Redeem(member.UUID, rewardUUID)
+
+		assert.ErrorIs(t, err, services.ErrRewardUnavailable)
+	})
+}
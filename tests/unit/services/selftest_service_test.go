@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSelftestServerKey = "SB-Mid-server-selftest"
+
+// fakeSelftestOrderService hands out prepared orders and keeps their status
+// in memory. Methods the self-test does not use are left to the embedded nil
+// interface.
+type fakeSelftestOrderService struct {
+	services.OrderService
+	orders  []*models.Order
+	created int
+}
+
+func (f *fakeSelftestOrderService) CreateGuestOrder(req services.CreateGuestOrderRequest) (*services.OrderResponse, error) {
+	order := f.orders[f.created]
+	f.created++
+	order.CustomerName = req.CustomerName
+	return &services.OrderResponse{ID: order.UUID, Status: order.Status}, nil
+}
+
+func (f *fakeSelftestOrderService) UpdateOrderStatus(orderUUID uuid.UUID, status models.OrderStatus) (*services.OrderResponse, error) {
+	order := f.find(func(o *models.Order) bool { return o.UUID == orderUUID })
+	order.Status = status
+	return &services.OrderResponse{ID: orderUUID, Status: status}, nil
+}
+
+func (f *fakeSelftestOrderService) GetByUUID(orderUUID uuid.UUID) (*services.OrderResponse, error) {
+	order := f.find(func(o *models.Order) bool { return o.UUID == orderUUID })
+	return &services.OrderResponse{ID: orderUUID, Status: order.Status}, nil
+}
+
+func (f *fakeSelftestOrderService) find(match func(*models.Order) bool) *models.Order {
+	for _, order := range f.orders {
+		if match(order) {
+			return order
+		}
+	}
+	return nil
+}
+
+type selftestFixture struct {
+	service         services.SelftestService
+	orderRepo       *mocks.MockOrderRepository
+	paymentRepo     *mocks.MockPaymentRepository
+	reservationRepo *mocks.MockStockReservationRepository
+}
+
+func newSelftestFixture() *selftestFixture {
+	orders := &fakeSelftestOrderService{orders: []*models.Order{
+		{ID: 1, UUID: uuid.New(), OrderNumber: "MC-250107-001", Status: models.OrderStatusPending, Total: 27750},
+		{ID: 2, UUID: uuid.New(), OrderNumber: "MC-250107-002", Status: models.OrderStatusPending, Total: 27750},
+	}}
+	f := &selftestFixture{
+		orderRepo:       new(mocks.MockOrderRepository),
+		paymentRepo:     new(mocks.MockPaymentRepository),
+		reservationRepo: new(mocks.MockStockReservationRepository),
+	}
+
+	for _, order := range orders.orders {
+		f.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+	}
+	f.orderRepo.On("UpdateStatus", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		id := args.Get(0).(uint)
+		orders.find(func(o *models.Order) bool { return o.ID == id }).Status = args.Get(1).(models.OrderStatus)
+	}).Return(nil)
+	f.reservationRepo.On("ConsumeByOrderID", mock.Anything).Return(nil)
+
+	payment := &models.Payment{ID: 1, OrderID: 1, GrossAmount: 27750, Order: orders.orders[0]}
+	f.paymentRepo.On("Create", mock.Anything).Return(nil)
+	f.paymentRepo.On("FindByMidtransOrderID", mock.Anything).Return(payment, nil)
+	f.paymentRepo.On("Update", mock.Anything).Return(nil)
+
+	paymentService := services.NewPaymentService(f.paymentRepo, new(mocks.MockRefundRepository), f.orderRepo, new(mocks.MockUserRepository), f.reservationRepo, new(mocks.MockWalletRepository), new(mocks.MockGiftCardRepository), new(mocks.MockLoyaltyRepository), services.NewSettingsService(new(mocks.MockSettingRepository)), testAlerts, testEvents, testLogger, services.PaymentConfig{MidtransServerKey: testSelftestServerKey})
+	f.service = services.NewSelftestService(orders, paymentService, f.orderRepo, f.paymentRepo, f.reservationRepo, testLogger, uuid.New(), testSelftestServerKey)
+	return f
+}
+
+func stepNames(steps []services.SelftestStep) []string {
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+func TestSelftestService_Run(t *testing.T) {
+	t.Run("success - full lifecycle passes and cleans up", func(t *testing.T) {
+		f := newSelftestFixture()
+		f.reservationRepo.On("ReleaseByOrderID", mock.Anything).Return(nil)
+		f.orderRepo.On("Delete", uint(1)).Return(nil)
+		f.orderRepo.On("Delete", uint(2)).Return(nil)
+
+		report := f.service.Run()
+
+		assert.True(t, report.Passed)
+		assert.Equal(t, []string{
+			"create_order", "pay", "verify_paid", "mark_ready", "mark_completed",
+			"create_order_to_cancel", "cancel", "cleanup",
+		}, stepNames(report.Steps))
+		for _, step := range report.Steps {
+			assert.True(t, step.Passed, step.Name)
+		}
+		f.orderRepo.AssertExpectations(t)
+		f.reservationRepo.AssertNumberOfCalls(t, "ReleaseByOrderID", 2)
+	})
+
+	t.Run("failure - stops at the failing step and still cleans up", func(t *testing.T) {
+		f := newSelftestFixture()
+		f.paymentRepo.ExpectedCalls = nil
+		f.paymentRepo.On("Create", mock.Anything).Return(errors.New("db down"))
+		f.reservationRepo.On("ReleaseByOrderID", uint(1)).Return(nil)
+		f.orderRepo.On("Delete", uint(1)).Return(nil)
+
+		report := f.service.Run()
+
+		assert.False(t, report.Passed)
+		assert.Equal(t, []string{"create_order", "pay", "cleanup"}, stepNames(report.Steps))
+		assert.False(t, report.Steps[1].Passed)
+		assert.Contains(t, report.Steps[1].Error, "db down")
+		assert.True(t, report.Steps[2].Passed)
+		f.orderRepo.AssertCalled(t, "Delete", uint(1))
+		f.orderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure - cleanup error fails the run", func(t *testing.T) {
+		f := newSelftestFixture()
+		f.reservationRepo.On("ReleaseByOrderID", mock.Anything).Return(nil)
+		f.orderRepo.On("Delete", mock.Anything).Return(errors.New("db down"))
+
+		report := f.service.Run()
+
+		assert.False(t, report.Passed)
+		cleanup := report.Steps[len(report.Steps)-1]
+		assert.Equal(t, "cleanup", cleanup.Name)
+		assert.False(t, cleanup.Passed)
+	})
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testFormatter, _ = utils.NewFormatter(utils.LocaleID, "Asia/Jakarta")
+
+func TestSettingsService_GetReceiptSettings(t *testing.T) {
+	t.Run("success - defaults when never saved", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyReceipt).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.GetReceiptSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, services.DefaultReceiptSettings, *result)
+	})
+
+	t.Run("success - saved settings", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyReceipt).Return(&models.Setting{
+			Key:   models.SettingKeyReceipt,
+			Value: []byte(`{"header_text":"Matchaciee Dago","tax_id":"01.234"}`),
+		}, nil)
+
+		result, err := service.GetReceiptSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Matchaciee Dago", result.HeaderText)
+		assert.Equal(t, "01.234", result.TaxID)
+		assert.Equal(t, 32, result.PaperWidth)
+	})
+
+	t.Run("error - repository failure", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyReceipt).Return(nil, errors.New("db down"))
+
+		result, err := service.GetReceiptSettings()
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSettingsService_UpdateReceiptSettings(t *testing.T) {
+	mockRepo := new(mocks.MockSettingRepository)
+	service := services.NewSettingsService(mockRepo)
+
+	mockRepo.On("Upsert", mock.MatchedBy(func(setting *models.Setting) bool {
+		return setting.Key == models.SettingKeyReceipt && bytes.Contains(setting.Value, []byte(`"promo_message":"Free cookie"`))
+	})).Return(nil)
+
+	result, err := service.UpdateReceiptSettings(services.ReceiptSettings{
+		HeaderText:   "Matchaciee",
+		PromoMessage: "Free cookie",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 32, result.PaperWidth)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReceiptService_Preview(t *testing.T) {
+	settings := &services.ReceiptSettings{
+		HeaderText:   "Matchaciee",
+		FooterText:   "See you again",
+		TaxID:        "01.234.567",
+		PromoMessage: "Free cookie on Fridays",
+		LogoURL:      "https://cdn.example.com/logo.png",
+		PaperWidth:   32,
+	}
+
+	t.Run("success - text", func(t *testing.T) {
+		service := services.NewReceiptService(testSettings, testFormatter, testPickupCodes)
+
+		result, err := service.Preview(settings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		body := string(result.Body)
+		assert.Contains(t, body, "Tax ID: 01.234.567")
+		assert.Contains(t, body, "Free cookie on Fridays")
+		assert.Contains(t, body, "Rp115.500")
+		assert.Contains(t, body, "+ Oat Milk")
+		for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+			assert.LessOrEqual(t, len([]rune(line)), 32)
+		}
+	})
+
+	t.Run("success - html includes logo", func(t *testing.T) {
+		service := services.NewReceiptService(testSettings, testFormatter, testPickupCodes)
+
+		result, err := service.Preview(settings, services.ReceiptFormatHTML)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.ContentType, "text/html"))
+		assert.Contains(t, string(result.Body), `src="https://cdn.example.com/logo.png"`)
+	})
+
+	t.Run("success - escpos initialises and cuts", func(t *testing.T) {
+		service := services.NewReceiptService(testSettings, testFormatter, testPickupCodes)
+
+		result, err := service.Preview(settings, services.ReceiptFormatESCPOS)
+
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(result.Body, []byte{0x1b, 0x40}))
+		assert.True(t, bytes.HasSuffix(result.Body, []byte{0x1d, 0x56, 0x00}))
+	})
+
+	t.Run("success - saved settings when none given", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewReceiptService(services.NewSettingsService(mockRepo), testFormatter, testPickupCodes)
+
+		mockRepo.On("FindByKey", models.SettingKeyReceipt).Return(nil, repositories.ErrSettingNotFound)
+		mockRepo.On("FindByKey", models.SettingKeyCurrency).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.Preview(nil, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result.Body), "Thank you for your order!")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - invalid format", func(t *testing.T) {
+		service := services.NewReceiptService(testSettings, testFormatter, testPickupCodes)
+
+		result, err := service.Preview(settings, "pdf")
+
+		assert.ErrorIs(t, err, services.ErrInvalidReceiptFormat)
+		assert.Nil(t, result)
+	})
+}
+
+func TestReceiptService_Render(t *testing.T) {
+	service := services.NewReceiptService(testSettings, testFormatter, testPickupCodes)
+	order := &models.Order{OrderNumber: "MC-250107-001", CustomerName: "Guest Customer", Total: 30000}
+
+	t.Run("success - pickup code until the order is handed over", func(t *testing.T) {
+		order.Status = models.OrderStatusReady
+
+		result, err := service.Render(order, services.DefaultReceiptSettings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result.Body), testPickupCodes.Sign("MC-250107-001"))
+	})
+
+	t.Run("success - no pickup code once completed", func(t *testing.T) {
+		order.Status = models.OrderStatusCompleted
+
+		result, err := service.Render(order, services.DefaultReceiptSettings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, string(result.Body), "Pickup code")
+	})
+
+	t.Run("success - each promotion on its own line", func(t *testing.T) {
+		name := "Happy Hour"
+		discounted := &models.Order{
+			OrderNumber: "MC-250107-004",
+			Subtotal:    50000,
+			Discount:    15000,
+			Total:       38500,
+			Promotions: []models.OrderPromotion{
+				{Code: "HAPPYHOUR", Name: &name, Discount: 10000},
+				{Code: "WEEKDAY", Discount: 5000},
+			},
+		}
+
+		result, err := service.Render(discounted, services.DefaultReceiptSettings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result.Body), "Happy Hour (HAPPYHOUR)")
+		assert.Contains(t, string(result.Body), "Discount (WEEKDAY)")
+	})
+
+	t.Run("success - amounts in the store currency", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		mockRepo.On("FindByKey", models.SettingKeyCurrency).Return(&models.Setting{
+			Key:   models.SettingKeyCurrency,
+			Value: []byte(`{"code":"USD","symbol":"$","decimals":2}`),
+		}, nil)
+		service := services.NewReceiptService(services.NewSettingsService(mockRepo), testFormatter, testPickupCodes)
+
+		result, err := service.Render(&models.Order{OrderNumber: "MC-250107-002", Currency: "USD", Total: 12.5}, services.DefaultReceiptSettings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result.Body), "$12,50")
+	})
+
+	t.Run("success - orders from before a currency change keep theirs", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		mockRepo.On("FindByKey", models.SettingKeyCurrency).Return(&models.Setting{
+			Key:   models.SettingKeyCurrency,
+			Value: []byte(`{"code":"USD","symbol":"$","decimals":2}`),
+		}, nil)
+		service := services.NewReceiptService(services.NewSettingsService(mockRepo), testFormatter, testPickupCodes)
+
+		result, err := service.Render(&models.Order{OrderNumber: "MC-250107-003", Currency: "IDR", Total: 30000}, services.DefaultReceiptSettings, services.ReceiptFormatText)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result.Body), "IDR 30.000,00")
+	})
+}
+
+func TestSettingsService_CurrencySettings(t *testing.T) {
+	t.Run("success - defaults to rupiah", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyCurrency).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.GetCurrencySettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, services.DefaultCurrencySettings, *result)
+		assert.Equal(t, float64(1235), result.Round(1234.5))
+	})
+
+	t.Run("success - update", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+		req := services.CurrencySettings{Code: "USD", Symbol: "$", Decimals: 2}
+
+		mockRepo.On("Upsert", mock.MatchedBy(func(setting *models.Setting) bool {
+			return setting.Key == models.SettingKeyCurrency
+		})).Return(nil)
+
+		result, err := service.UpdateCurrencySettings(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, req, *result)
+		assert.Equal(t, 12.35, result.Round(12.345))
+	})
+}
+
+func TestSettingsService_QRCodeSettings(t *testing.T) {
+	t.Run("success - saved colors with defaults for the rest", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyQRCode).Return(&models.Setting{
+			Key:   models.SettingKeyQRCode,
+			Value: []byte(`{"foreground_color":"#000000"}`),
+		}, nil)
+
+		result, err := service.GetQRCodeSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "#000000", result.ForegroundColor)
+		assert.Equal(t, services.DefaultQRCodeSettings.BackgroundColor, result.BackgroundColor)
+	})
+
+	t.Run("success - update fills empty colors", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("Upsert", mock.MatchedBy(func(setting *models.Setting) bool {
+			return setting.Key == models.SettingKeyQRCode && bytes.Contains(setting.Value, []byte(`"background_color":"#FFFFFF"`))
+		})).Return(nil)
+
+		result, err := service.UpdateQRCodeSettings(services.QRCodeSettings{ForegroundColor: "#123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "#123", result.ForegroundColor)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSettingsService_TimeslotSettings(t *testing.T) {
+	t.Run("success - unlimited when never saved", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyTimeslots).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.GetTimeslotSettings()
+
+		assert.NoError(t, err)
+		assert.False(t, result.IsLimited())
+	})
+
+	t.Run("success - saved limits", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyTimeslots).Return(&models.Setting{
+			Key:   models.SettingKeyTimeslots,
+			Value: []byte(`{"max_orders":0,"max_drinks":30}`),
+		}, nil)
+
+		result, err := service.GetTimeslotSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30, result.MaxDrinks)
+		assert.True(t, result.IsLimited())
+	})
+}
+
+func TestSettingsService_PaymentSettings(t *testing.T) {
+	t.Run("success - every method when never saved", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyPayments).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.GetPaymentSettings()
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.EnabledPayments)
+		assert.NotNil(t, result.EnabledPayments)
+	})
+
+	t.Run("success - saved methods keep their order", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyPayments).Return(&models.Setting{
+			Key:   models.SettingKeyPayments,
+			Value: []byte(`{"enabled_payments":["other_qris","gopay"]}`),
+		}, nil)
+
+		result, err := service.GetPaymentSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"other_qris", "gopay"}, result.EnabledPayments)
+	})
+
+	t.Run("validation - unknown and repeated methods are rejected", func(t *testing.T) {
+		assert.NotEmpty(t, utils.ValidateStruct(services.PaymentSettings{EnabledPayments: []string{"paypal"}}))
+		assert.NotEmpty(t, utils.ValidateStruct(services.PaymentSettings{EnabledPayments: []string{"gopay", "gopay"}}))
+		assert.Empty(t, utils.ValidateStruct(services.PaymentSettings{EnabledPayments: []string{"other_qris", "bank_transfer"}}))
+	})
+}
+
+func TestSettingsService_DepositSettings(t *testing.T) {
+	t.Run("success - no deposits when never saved", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyDeposits).Return(nil, repositories.ErrSettingNotFound)
+
+		result, err := service.GetDepositSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, result.DepositFor(5000000))
+	})
+
+	t.Run("success - saved deposit applies from the minimum total", func(t *testing.T) {
+		mockRepo := new(mocks.MockSettingRepository)
+		service := services.NewSettingsService(mockRepo)
+
+		mockRepo.On("FindByKey", models.SettingKeyDeposits).Return(&models.Setting{
+			Key:   models.SettingKeyDeposits,
+			Value: []byte(`{"percent":30,"min_total":1000000}`),
+		}, nil)
+
+		result, err := service.GetDepositSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, result.DepositFor(999999))
+		assert.Equal(t, 300000.0, result.DepositFor(1000000))
+	})
+
+	t.Run("validation - a deposit cannot be the whole order", func(t *testing.T) {
+		assert.NotEmpty(t, utils.ValidateStruct(services.DepositSettings{Percent: 100}))
+		assert.Empty(t, utils.ValidateStruct(services.DepositSettings{Percent: 50, MinTotal: 500000}))
+	})
+}
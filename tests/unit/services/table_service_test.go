@@ -0,0 +1,120 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestTableService() (services.TableService, *mocks.MockDiningTableRepository) {
+	tableRepo := new(mocks.MockDiningTableRepository)
+	return services.NewTableService(tableRepo, "https://matchaciee.com"), tableRepo
+}
+
+func TestTableService_Create(t *testing.T) {
+	t.Run("success - generates a QR token", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableRepo.On("FindByNumber", "12").Return(nil, repositories.ErrDiningTableNotFound)
+
+		var saved *models.DiningTable
+		tableRepo.On("Create", mock.AnythingOfType("*models.DiningTable")).
+			Run(func(args mock.Arguments) {
+				saved = args.Get(0).(*models.DiningTable)
+			}).
+			Return(nil)
+
+		result, err := service.Create(services.TableRequest{Number: " 12 "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "12", saved.Number)
+		assert.True(t, saved.IsActive)
+		assert.Len(t, saved.Token, 32)
+		assert.Equal(t, "https://matchaciee.com/menu?table="+saved.Token, result.URL)
+	})
+
+	t.Run("error - number already exists", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableRepo.On("FindByNumber", "12").Return(&models.DiningTable{ID: 1, Number: "12"}, nil)
+
+		result, err := service.Create(services.TableRequest{Number: "12"})
+
+		assert.ErrorIs(t, err, services.ErrTableNumberExists)
+		assert.Nil(t, result)
+		tableRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTableService_Update(t *testing.T) {
+	t.Run("success - keeps its own number and token", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableUUID := uuid.New()
+		table := &models.DiningTable{ID: 1, UUID: tableUUID, Number: "12", Token: "3c1f0a9b", IsActive: true}
+		tableRepo.On("FindByUUID", tableUUID).Return(table, nil)
+		tableRepo.On("FindByNumber", "12").Return(table, nil)
+		tableRepo.On("Update", table).Return(nil)
+
+		inactive := false
+		result, err := service.Update(tableUUID, services.TableRequest{Number: "12", IsActive: &inactive})
+
+		assert.NoError(t, err)
+		assert.False(t, result.IsActive)
+		assert.True(t, strings.HasSuffix(result.URL, "3c1f0a9b"))
+	})
+}
+
+func TestTableService_RegenerateToken(t *testing.T) {
+	t.Run("success - replaces the token", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableUUID := uuid.New()
+		table := &models.DiningTable{ID: 1, UUID: tableUUID, Number: "12", Token: "3c1f0a9b", IsActive: true}
+		tableRepo.On("FindByUUID", tableUUID).Return(table, nil)
+		tableRepo.On("Update", table).Return(nil)
+
+		result, err := service.RegenerateToken(tableUUID)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "3c1f0a9b", table.Token)
+		assert.Equal(t, "https://matchaciee.com/menu?table="+table.Token, result.URL)
+	})
+}
+
+func TestTableService_Scan(t *testing.T) {
+	t.Run("success - resolves the table", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableUUID := uuid.New()
+		tableRepo.On("FindByToken", "3c1f0a9b").Return(&models.DiningTable{UUID: tableUUID, Number: "12", IsActive: true}, nil)
+
+		result, err := service.Scan("3c1f0a9b")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tableUUID, result.ID)
+		assert.Equal(t, "12", result.Number)
+	})
+
+	t.Run("error - inactive table", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableRepo.On("FindByToken", "3c1f0a9b").Return(&models.DiningTable{Number: "12", IsActive: false}, nil)
+
+		result, err := service.Scan("3c1f0a9b")
+
+		assert.ErrorIs(t, err, services.ErrDiningTableNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error - unknown token", func(t *testing.T) {
+		service, tableRepo := newTestTableService()
+		tableRepo.On("FindByToken", "retired").Return(nil, repositories.ErrDiningTableNotFound)
+
+		result, err := service.Scan("retired")
+
+		assert.ErrorIs(t, err, services.ErrDiningTableNotFound)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashService_List(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success - every type", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		service := services.NewTrashService(mockTrashRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+
+		mockTrashRepo.On("FindDeletedProducts").Return([]models.Product{
+			{ID: 1, UUID: uuid.New(), Name: "Iced Matcha Latte", Slug: "iced-matcha-latte", DeletedAt: &deletedAt, Category: &models.Category{Name: "Matcha"}},
+		}, nil)
+		mockTrashRepo.On("FindDeletedCategories").Return([]models.Category{
+			{ID: 2, UUID: uuid.New(), Name: "Seasonal", Slug: "seasonal", DeletedAt: &deletedAt},
+		}, nil)
+
+		result, err := service.List("")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Products, 1)
+		assert.Equal(t, "Matcha", *result.Products[0].Category)
+		assert.Equal(t, "2025-01-07T10:00:00Z", result.Products[0].DeletedAt)
+		assert.Len(t, result.Categories, 1)
+		assert.Equal(t, services.TrashTypeCategory, result.Categories[0].Type)
+	})
+
+	t.Run("success - filtered by type", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		service := services.NewTrashService(mockTrashRepo, new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+
+		mockTrashRepo.On("FindDeletedCategories").Return([]models.Category{}, nil)
+
+		result, err := service.List(services.TrashTypeCategory)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Products)
+		mockTrashRepo.AssertNotCalled(t, "FindDeletedProducts")
+	})
+
+	t.Run("error - unknown type", func(t *testing.T) {
+		service := services.NewTrashService(new(mocks.MockTrashRepository), new(mocks.MockProductRepository), new(mocks.MockCategoryRepository))
+
+		result, err := service.List("users")
+
+		assert.ErrorIs(t, err, services.ErrUnknownTrashType)
+		assert.Nil(t, result)
+	})
+}
+
+func TestTrashService_Restore(t *testing.T) {
+	deletedAt := time.Now()
+
+	t.Run("success - product", func(t *testing.T) {
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewTrashService(new(mocks.MockTrashRepository), mockProductRepo, new(mocks.MockCategoryRepository))
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUIDIncludingDeleted", productUUID).Return(&models.Product{ID: 1, UUID: productUUID, DeletedAt: &deletedAt}, nil)
+		mockProductRepo.On("Restore", uint(1)).Return(nil)
+
+		err := service.Restore(services.TrashTypeProduct, productUUID)
+
+		assert.NoError(t, err)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - category not in trash", func(t *testing.T) {
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewTrashService(new(mocks.MockTrashRepository), new(mocks.MockProductRepository), mockCategoryRepo)
+
+		categoryUUID := uuid.New()
+		mockCategoryRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(&models.Category{ID: 2, UUID: categoryUUID}, nil)
+
+		err := service.Restore(services.TrashTypeCategory, categoryUUID)
+
+		assert.ErrorIs(t, err, services.ErrNotInTrash)
+		mockCategoryRepo.AssertNotCalled(t, "Restore")
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewTrashService(new(mocks.MockTrashRepository), mockProductRepo, new(mocks.MockCategoryRepository))
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUIDIncludingDeleted", productUUID).Return(nil, repositories.ErrProductNotFound)
+
+		err := service.Restore(services.TrashTypeProduct, productUUID)
+
+		assert.ErrorIs(t, err, services.ErrTrashItemNotFound)
+	})
+}
+
+func TestTrashService_PurgeImpact(t *testing.T) {
+	deletedAt := time.Now()
+
+	t.Run("success - product referenced by orders", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewTrashService(mockTrashRepo, mockProductRepo, new(mocks.MockCategoryRepository))
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUIDIncludingDeleted", productUUID).Return(&models.Product{ID: 1, UUID: productUUID, Name: "Iced Matcha Latte", DeletedAt: &deletedAt}, nil)
+		mockTrashRepo.On("ProductPurgeImpact", uint(1)).Return(&repositories.ProductPurgeImpact{OrderItems: 42, Orders: 38}, nil)
+
+		result, err := service.PurgeImpact(services.TrashTypeProduct, productUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), *result.OrderItems)
+		assert.Equal(t, int64(38), *result.Orders)
+		assert.Nil(t, result.Products)
+		assert.Len(t, result.Effects, 1)
+	})
+
+	t.Run("success - category with children and products", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewTrashService(mockTrashRepo, new(mocks.MockProductRepository), mockCategoryRepo)
+
+		categoryUUID := uuid.New()
+		mockCategoryRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(&models.Category{ID: 2, UUID: categoryUUID, DeletedAt: &deletedAt}, nil)
+		mockTrashRepo.On("CategoryPurgeImpact", uint(2)).Return(&repositories.CategoryPurgeImpact{ChildCategories: 1, Products: 5}, nil)
+
+		result, err := service.PurgeImpact(services.TrashTypeCategory, categoryUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), *result.Products)
+		assert.Nil(t, result.OrderItems)
+		assert.Len(t, result.Effects, 2)
+	})
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	deletedAt := time.Now()
+
+	t.Run("success - product", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewTrashService(mockTrashRepo, mockProductRepo, new(mocks.MockCategoryRepository))
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUIDIncludingDeleted", productUUID).Return(&models.Product{ID: 1, UUID: productUUID, DeletedAt: &deletedAt}, nil)
+		mockTrashRepo.On("PurgeProduct", uint(1)).Return(nil)
+
+		err := service.Purge(services.TrashTypeProduct, productUUID)
+
+		assert.NoError(t, err)
+		mockTrashRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - live product is never purged", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := services.NewTrashService(mockTrashRepo, mockProductRepo, new(mocks.MockCategoryRepository))
+
+		productUUID := uuid.New()
+		mockProductRepo.On("FindByUUIDIncludingDeleted", productUUID).Return(&models.Product{ID: 1, UUID: productUUID}, nil)
+
+		err := service.Purge(services.TrashTypeProduct, productUUID)
+
+		assert.ErrorIs(t, err, services.ErrNotInTrash)
+		mockTrashRepo.AssertNotCalled(t, "PurgeProduct", uint(1))
+	})
+
+	t.Run("error - restored concurrently", func(t *testing.T) {
+		mockTrashRepo := new(mocks.MockTrashRepository)
+		mockCategoryRepo := new(mocks.MockCategoryRepository)
+		service := services.NewTrashService(mockTrashRepo, new(mocks.MockProductRepository), mockCategoryRepo)
+
+		categoryUUID := uuid.New()
+		mockCategoryRepo.On("FindByUUIDIncludingDeleted", categoryUUID).Return(&models.Category{ID: 2, UUID: categoryUUID, DeletedAt: &deletedAt}, nil)
+		mockTrashRepo.On("PurgeCategory", uint(2)).Return(repositories.ErrCategoryNotFound)
+
+		err := service.Purge(services.TrashTypeCategory, categoryUUID)
+
+		assert.ErrorIs(t, err, services.ErrNotInTrash)
+	})
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/metrics"
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsageService_Record(t *testing.T) {
+	t.Run("success - converts counts to hourly rows", func(t *testing.T) {
+		mockUsageRepo := new(mocks.MockUsageRepository)
+		service := services.NewUsageService(mockUsageRepo)
+		bucket := time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)
+
+		mockUsageRepo.On("Add", []models.APIUsage{{
+			BucketStart:    bucket,
+			Client:         "kiosk",
+			Method:         "GET",
+			Route:          "/api/v1/menu",
+			Requests:       3,
+			ClientErrors:   1,
+			TotalLatencyMs: 150,
+			MaxLatencyMs:   90,
+		}}).Return(nil)
+
+		err := service.Record(map[metrics.UsageKey]metrics.UsageCount{
+			{BucketStart: bucket, Client: "kiosk", Method: "GET", Route: "/api/v1/menu"}: {
+				Requests:     3,
+				ClientErrors: 1,
+				TotalLatency: 150 * time.Millisecond,
+				MaxLatency:   90 * time.Millisecond,
+			},
+		})
+
+		assert.NoError(t, err)
+		mockUsageRepo.AssertExpectations(t)
+	})
+}
+
+func TestUsageService_GetUsage(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success - groups routes by client, busiest first", func(t *testing.T) {
+		mockUsageRepo := new(mocks.MockUsageRepository)
+		service := services.NewUsageService(mockUsageRepo)
+		seen := time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)
+
+		mockUsageRepo.On("RouteUsage", start, end.AddDate(0, 0, 1), "").Return([]repositories.RouteUsageRow{
+			{Client: "web", Method: "GET", Route: "/api/v1/menu", Requests: 500, TotalLatencyMs: 10000, MaxLatencyMs: 300, LastSeen: seen},
+			{Client: "kiosk", Method: "GET", Route: "/api/v1/menu", Requests: 400, ClientErrors: 4, TotalLatencyMs: 8000, MaxLatencyMs: 200, LastSeen: seen},
+			{Client: "kiosk", Method: "POST", Route: "/api/v1/orders/staff", Requests: 200, ServerErrors: 2, TotalLatencyMs: 20000, MaxLatencyMs: 900, LastSeen: seen.Add(-time.Hour)},
+		}, nil)
+
+		result, err := service.GetUsage(start, end, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Clients, 2)
+
+		kiosk := result.Clients[0]
+		assert.Equal(t, "kiosk", kiosk.Client)
+		assert.Equal(t, int64(600), kiosk.Requests)
+		assert.Equal(t, 0.01, kiosk.ErrorRate)
+		assert.Equal(t, 46.6667, kiosk.AvgLatencyMs)
+		assert.Equal(t, int64(900), kiosk.MaxLatencyMs)
+		assert.Equal(t, "2025-01-07T09:00:00Z", kiosk.LastSeen)
+		assert.Len(t, kiosk.Routes, 2)
+		assert.Equal(t, 0.01, kiosk.Routes[1].ErrorRate)
+
+		assert.Equal(t, "web", result.Clients[1].Client)
+	})
+
+	t.Run("error - end before start", func(t *testing.T) {
+		mockUsageRepo := new(mocks.MockUsageRepository)
+		service := services.NewUsageService(mockUsageRepo)
+
+		result, err := service.GetUsage(end, start, "")
+
+		assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+		assert.Nil(t, result)
+		mockUsageRepo.AssertNotCalled(t, "RouteUsage", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
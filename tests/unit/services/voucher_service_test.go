@@ -0,0 +1,195 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoucherService_Issue(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+	member := &models.User{ID: 3, UUID: uuid.New(), Role: models.RoleMember}
+
+	apology := func() services.IssueVouchersRequest {
+		return services.IssueVouchersRequest{
+			Reason:        "Sorry your order was late",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 25000,
+		}
+	}
+
+	t.Run("success - one voucher per listed member with an issued event", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(voucherRepo, userRepo)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		var saved []models.Voucher
+		voucherRepo.On("CreateBatch", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).([]models.Voucher)
+		}).Return(nil)
+
+		req := apology()
+		req.UserIDs = []uuid.UUID{member.UUID}
+		issued, err := service.Issue(admin.UUID, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, issued.Issued)
+		require.Len(t, saved, 1)
+		assert.Equal(t, member.ID, saved[0].UserID)
+		assert.Equal(t, admin.ID, *saved[0].IssuedBy)
+		assert.Equal(t, models.VoucherStatusActive, saved[0].Status)
+		assert.Regexp(t, `^VC-[A-Z2-9]{4}-[A-Z2-9]{4}$`, issued.Vouchers[0].Code)
+		assert.True(t, issued.Vouchers[0].Redeemable)
+	})
+
+	t.Run("success - lapsed segment looks back the inactive days", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(voucherRepo, userRepo)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		userRepo.On("FindMemberIDs", repositories.MemberSegmentLapsed, mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 59*24*time.Hour && time.Since(since) < 61*24*time.Hour
+		})).Return([]uint{3, 4, 5}, nil)
+		voucherRepo.On("CreateBatch", mock.Anything).Return(nil)
+
+		req := apology()
+		req.Segment = repositories.MemberSegmentLapsed
+		req.InactiveDays = 60
+		issued, err := service.Issue(admin.UUID, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, issued.Issued)
+	})
+
+	t.Run("error - both members and a segment", func(t *testing.T) {
+		service := services.NewVoucherService(new(mocks.MockVoucherRepository), new(mocks.MockUserRepository))
+
+		req := apology()
+		req.UserIDs = []uuid.UUID{member.UUID}
+		req.Segment = repositories.MemberSegmentAll
+		_, err := service.Issue(admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrVoucherRecipients)
+	})
+
+	t.Run("error - recipient is not a member", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(voucherRepo, userRepo)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+
+		req := apology()
+		req.UserIDs = []uuid.UUID{admin.UUID}
+		_, err := service.Issue(admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrVoucherMemberNotFound)
+		voucherRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+	})
+
+	t.Run("error - segment without members", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(new(mocks.MockVoucherRepository), userRepo)
+
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		userRepo.On("FindMemberIDs", repositories.MemberSegmentNew, mock.Anything).Return([]uint{}, nil)
+
+		req := apology()
+		req.Segment = repositories.MemberSegmentNew
+		_, err := service.Issue(admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrVoucherSegmentEmpty)
+	})
+
+	t.Run("error - expiry in the past", func(t *testing.T) {
+		service := services.NewVoucherService(new(mocks.MockVoucherRepository), new(mocks.MockUserRepository))
+
+		req := apology()
+		req.UserIDs = []uuid.UUID{member.UUID}
+		past := time.Now().Add(-time.Hour)
+		req.ExpiresAt = &past
+		_, err := service.Issue(admin.UUID, req)
+
+		assert.ErrorIs(t, err, services.ErrVoucherExpiryNotInFuture)
+	})
+}
+
+func TestVoucherService_GetByUUID(t *testing.T) {
+	t.Run("success - audit trail with actors and orders", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		service := services.NewVoucherService(voucherRepo, new(mocks.MockUserRepository))
+		voucher := &models.Voucher{ID: 11, UUID: uuid.New(), Code: "VC-7KQM-X3TD", Status: models.VoucherStatusActive}
+		admin := &models.User{FullName: "Admin"}
+		order := pendingCounterOrder()
+		reason := "Sorry your order was late"
+
+		voucherRepo.On("FindByUUID", voucher.UUID).Return(voucher, nil)
+		voucherRepo.On("FindEvents", voucher.ID).Return([]models.VoucherEvent{
+			{Action: models.VoucherActionIssued, Actor: admin, Note: &reason},
+			{Action: models.VoucherActionRedeemed, Order: order},
+			{Action: models.VoucherActionReleased, Order: order},
+		}, nil)
+
+		response, err := service.GetByUUID(voucher.UUID)
+
+		require.NoError(t, err)
+		require.Len(t, response.Events, 3)
+		assert.Equal(t, "Admin", *response.Events[0].ActorName)
+		assert.Equal(t, order.OrderNumber, *response.Events[2].OrderNumber)
+		assert.True(t, response.Redeemable)
+	})
+}
+
+func TestVoucherService_Revoke(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+
+	t.Run("error - voucher already redeemed", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(voucherRepo, userRepo)
+		voucher := &models.Voucher{ID: 11, UUID: uuid.New(), Status: models.VoucherStatusRedeemed}
+
+		voucherRepo.On("FindByUUID", voucher.UUID).Return(voucher, nil)
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		voucherRepo.On("Revoke", voucher.ID, admin.ID, "Issued by mistake").Return(false, nil)
+
+		_, err := service.Revoke(voucher.UUID, admin.UUID, services.RevokeVoucherRequest{Reason: "Issued by mistake"})
+
+		assert.ErrorIs(t, err, services.ErrVoucherNotActive)
+	})
+}
+
+func TestVoucherService_GetMyVouchers(t *testing.T) {
+	t.Run("success - expired vouchers are not redeemable and revoke reasons stay internal", func(t *testing.T) {
+		voucherRepo := new(mocks.MockVoucherRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewVoucherService(voucherRepo, userRepo)
+		member := &models.User{ID: 3, UUID: uuid.New(), Role: models.RoleMember}
+		expired := time.Now().Add(-time.Hour)
+		reason := "Issued to the wrong member"
+
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		voucherRepo.On("FindByUserID", member.ID).Return([]models.Voucher{
+			{Code: "VC-AAAA-BBBB", Status: models.VoucherStatusActive, ExpiresAt: &expired},
+			{Code: "VC-CCCC-DDDD", Status: models.VoucherStatusRevoked, RevokeReason: &reason},
+		}, nil)
+
+		vouchers, err := service.GetMyVouchers(member.UUID)
+
+		require.NoError(t, err)
+		require.Len(t, vouchers, 2)
+		assert.False(t, vouchers[0].Redeemable)
+		assert.Nil(t, vouchers[1].RevokeReason)
+	})
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletService_GetWallet(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+
+	t.Run("success - balance with the statement page", func(t *testing.T) {
+		walletRepo := new(mocks.MockWalletRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewWalletService(walletRepo, userRepo)
+		order := pendingCounterOrder()
+
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		walletRepo.On("Balance", member.ID).Return(57500.0, nil)
+		walletRepo.On("FindTransactions", member.ID, 20, 20).Return([]models.WalletTransaction{
+			{UUID: uuid.New(), Type: models.WalletTransactionPayment, Amount: -42500, BalanceAfter: 57500, OrderID: &order.ID, Order: order},
+			{UUID: uuid.New(), Type: models.WalletTransactionTopUp, Amount: 100000, BalanceAfter: 100000},
+		}, int64(22), nil)
+
+		wallet, err := service.GetWallet(member.UUID, 2, 20)
+
+		require.NoError(t, err)
+		assert.Equal(t, 57500.0, wallet.Balance)
+		assert.Equal(t, int64(22), wallet.Total)
+		require.Len(t, wallet.Transactions, 2)
+		assert.Equal(t, order.OrderNumber, *wallet.Transactions[0].OrderNumber)
+		assert.Nil(t, wallet.Transactions[1].OrderID)
+	})
+
+	t.Run("error - user not found", func(t *testing.T) {
+		walletRepo := new(mocks.MockWalletRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewWalletService(walletRepo, userRepo)
+
+		userRepo.On("FindByUUID", member.UUID).Return(nil, repositories.ErrUserNotFound)
+
+		_, err := service.GetWallet(member.UUID, 1, 20)
+
+		assert.ErrorIs(t, err, services.ErrUserNotFound)
+	})
+}
+
+func TestWalletService_Adjust(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+
+	t.Run("success - posts the adjustment with the admin and note", func(t *testing.T) {
+		walletRepo := new(mocks.MockWalletRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewWalletService(walletRepo, userRepo)
+
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		var posted *models.WalletTransaction
+		walletRepo.On("Post", mock.Anything).Run(func(args mock.Arguments) {
+			posted = args.Get(0).(*models.WalletTransaction)
+			posted.BalanceAfter = 15000
+		}).Return(nil)
+
+		entry, err := service.Adjust(member.UUID, admin.UUID, services.WalletAdjustmentRequest{Amount: 15000, Note: "Goodwill credit"})
+
+		require.NoError(t, err)
+		require.NotNil(t, posted)
+		assert.Equal(t, member.ID, posted.UserID)
+		assert.Equal(t, models.WalletTransactionAdjustment, posted.Type)
+		assert.Equal(t, admin.ID, *posted.CreatedBy)
+		assert.Equal(t, 15000.0, entry.BalanceAfter)
+	})
+
+	t.Run("error - debit larger than the balance", func(t *testing.T) {
+		walletRepo := new(mocks.MockWalletRepository)
+		userRepo := new(mocks.MockUserRepository)
+		service := services.NewWalletService(walletRepo, userRepo)
+
+		userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		walletRepo.On("Post", mock.Anything).Return(repositories.ErrInsufficientBalance)
+
+		_, err := service.Adjust(member.UUID, admin.UUID, services.WalletAdjustmentRequest{Amount: -5000, Note: "Reverse duplicate credit"})
+
+		assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+	})
+}
+
+func TestPaymentService_PayWithWallet(t *testing.T) {
+	member := &models.User{ID: 12, UUID: uuid.New(), Role: models.RoleMember}
+	memberOrder := func() *models.Order {
+		order := pendingCounterOrder()
+		order.UserID = &member.ID
+		return order
+	}
+
+	t.Run("success - debits the wallet and settles the order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.walletRepo.On("Balance", member.ID).Return(100000.0, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		var entry *models.WalletTransaction
+		var payment *models.Payment
+		deps.walletRepo.On("PayOrder", mock.Anything, mock.Anything, models.OrderStatusPending, models.OrderStatusPreparing).Run(func(args mock.Arguments) {
+			entry = args.Get(0).(*models.WalletTransaction)
+			payment = args.Get(1).(*models.Payment)
+			entry.BalanceAfter = 57500
+		}).Return(nil)
+
+		response, err := service.PayWithWallet(order.UUID, member.UUID)
+
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, -42500.0, entry.Amount)
+		assert.Equal(t, order.ID, *entry.OrderID)
+		assert.Equal(t, models.PaymentMethodWallet, payment.Method)
+		assert.Equal(t, models.TransactionStatusSettlement, *payment.TransactionStatus)
+		assert.Equal(t, models.OrderStatusPreparing, response.OrderStatus)
+		assert.Equal(t, 57500.0, response.Balance)
+	})
+
+	t.Run("error - balance too low", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.walletRepo.On("Balance", member.ID).Return(10000.0, nil)
+
+		_, err := service.PayWithWallet(order.UUID, member.UUID)
+
+		assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+		deps.reservationRepo.AssertNotCalled(t, "ConsumeByOrderID", mock.Anything)
+	})
+
+	t.Run("error - another member's order", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := pendingCounterOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+
+		_, err := service.PayWithWallet(order.UUID, member.UUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderAccessDenied)
+	})
+
+	t.Run("error - paid another way in the meantime", func(t *testing.T) {
+		service, deps := newPaymentService()
+		order := memberOrder()
+
+		deps.orderRepo.On("FindByUUID", order.UUID).Return(order, nil)
+		deps.userRepo.On("FindByUUID", member.UUID).Return(member, nil)
+		deps.walletRepo.On("Balance", member.ID).Return(100000.0, nil)
+		deps.reservationRepo.On("ConsumeByOrderID", order.ID).Return(nil)
+		deps.walletRepo.On("PayOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(repositories.ErrOrderNotAwaitingPayment)
+
+		_, err := service.PayWithWallet(order.UUID, member.UUID)
+
+		assert.ErrorIs(t, err, services.ErrOrderNotAwaitingPayment)
+	})
+}
+
+func TestPaymentService_WalletTopUpCallback(t *testing.T) {
+	topUpCallback := func(status string, amount float64) []byte {
+		return []byte(fmt.Sprintf(`{"id":"inv_2","external_id":"TOPUP-12-1736240000","status":%q,"amount":%v,"payment_channel":"OVO","paid_at":"2025-01-07T10:00:00.000Z"}`,
+			status, amount))
+	}
+	pendingTopUp := func() *models.WalletTopUp {
+		return &models.WalletTopUp{ID: 4, UserID: 12, Reference: "TOPUP-12-1736240000", Method: models.PaymentMethodXendit, Amount: 100000}
+	}
+
+	t.Run("success - paid invoice credits the wallet", func(t *testing.T) {
+		service, deps := newPaymentService()
+		topUp := pendingTopUp()
+
+		deps.walletRepo.On("FindTopUpByReference", topUp.Reference).Return(topUp, nil)
+		deps.walletRepo.On("CreditTopUp", topUp).Return(true, nil)
+
+		err := service.ProcessXenditCallback(topUpCallback("PAID", 100000), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusSettlement, *topUp.TransactionStatus)
+		assert.Equal(t, "ovo", *topUp.PaymentType)
+		deps.paymentRepo.AssertNotCalled(t, "FindByMidtransOrderID", mock.Anything)
+	})
+
+	t.Run("success - credited top-up is left alone", func(t *testing.T) {
+		service, deps := newPaymentService()
+		topUp := pendingTopUp()
+		credited := time.Now()
+		topUp.CreditedAt = &credited
+
+		deps.walletRepo.On("FindTopUpByReference", topUp.Reference).Return(topUp, nil)
+
+		err := service.ProcessXenditCallback(topUpCallback("SETTLED", 100000), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		deps.walletRepo.AssertNotCalled(t, "CreditTopUp", mock.Anything)
+	})
+
+	t.Run("success - expired invoice is recorded without credit", func(t *testing.T) {
+		service, deps := newPaymentService()
+		topUp := pendingTopUp()
+
+		deps.walletRepo.On("FindTopUpByReference", topUp.Reference).Return(topUp, nil)
+		deps.walletRepo.On("UpdateTopUp", topUp).Return(nil)
+
+		err := service.ProcessXenditCallback(topUpCallback("EXPIRED", 100000), testXenditCallbackToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusExpire, *topUp.TransactionStatus)
+		deps.walletRepo.AssertNotCalled(t, "CreditTopUp", mock.Anything)
+	})
+
+	t.Run("error - amount does not match the top-up", func(t *testing.T) {
+		service, deps := newPaymentService()
+		topUp := pendingTopUp()
+
+		deps.walletRepo.On("FindTopUpByReference", topUp.Reference).Return(topUp, nil)
+
+		err := service.ProcessXenditCallback(topUpCallback("PAID", 1000), testXenditCallbackToken)
+
+		assert.ErrorIs(t, err, services.ErrInvalidAmount)
+		deps.walletRepo.AssertNotCalled(t, "CreditTopUp", mock.Anything)
+	})
+}
+
+func TestPaymentService_RefundPayment_Wallet(t *testing.T) {
+	admin := &models.User{ID: 1, UUID: uuid.New(), Role: models.RoleAdmin}
+	memberID := uint(12)
+	walletPayment := func() *models.Payment {
+		payment := settledPayment(models.OrderStatusPreparing)
+		payment.Method = models.PaymentMethodWallet
+		payment.Order.UserID = &memberID
+		return payment
+	}
+	req := services.RefundPaymentRequest{Reason: "Drink spilled before pickup"}
+
+	t.Run("success - refund goes back to the wallet", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := walletPayment()
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Return(true, nil)
+		var credit *models.WalletTransaction
+		deps.walletRepo.On("Post", mock.Anything).Run(func(args mock.Arguments) {
+			credit = args.Get(0).(*models.WalletTransaction)
+		}).Return(nil)
+		deps.paymentRepo.On("UpdateTransactionStatus", payment.ID, models.TransactionStatusRefund).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusCancelled).Return(nil)
+		deps.reservationRepo.On("ReleaseByOrderID", payment.OrderID).Return(nil)
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, req)
+
+		require.NoError(t, err)
+		require.NotNil(t, credit)
+		assert.Equal(t, memberID, credit.UserID)
+		assert.Equal(t, models.WalletTransactionRefund, credit.Type)
+		assert.Equal(t, payment.GrossAmount, credit.Amount)
+	})
+
+	t.Run("error - failed credit removes the refund", func(t *testing.T) {
+		service, deps := newPaymentService()
+		payment := walletPayment()
+
+		deps.paymentRepo.On("FindByUUID", payment.UUID).Return(payment, nil)
+		deps.userRepo.On("FindByUUID", admin.UUID).Return(admin, nil)
+		deps.refundRepo.On("SumByPaymentID", payment.ID).Return(0.0, nil)
+		deps.refundRepo.On("CreateWithinBalance", mock.Anything, payment.GrossAmount).Return(true, nil)
+		deps.refundRepo.On("Delete", mock.Anything).Return(nil)
+		deps.walletRepo.On("Post", mock.Anything).Return(errors.New("db down"))
+
+		_, err := service.RefundPayment(payment.UUID, admin.UUID, req)
+
+		assert.Error(t, err)
+		deps.refundRepo.AssertCalled(t, "Delete", mock.Anything)
+		deps.paymentRepo.AssertNotCalled(t, "UpdateTransactionStatus", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/models"
+	"github.com/carllix/matchaciee-backend/internal/repositories"
+	"github.com/carllix/matchaciee-backend/internal/services"
+	"github.com/carllix/matchaciee-backend/tests/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func newWebhookService() (services.WebhookService, *mocks.MockWebhookEventRepository, *paymentDeps) {
+	paymentService, deps := newPaymentService()
+	eventRepo := new(mocks.MockWebhookEventRepository)
+	return services.NewWebhookService(eventRepo, paymentService, testLogger), eventRepo, deps
+}
+
+func webhookPayment(reference string) *models.Payment {
+	order := pendingCounterOrder()
+	return &models.Payment{
+		ID:              1,
+		OrderID:         order.ID,
+		MidtransOrderID: reference,
+		Method:          models.PaymentMethodXendit,
+		GrossAmount:     order.AmountDue(),
+		Order:           order,
+	}
+}
+
+func TestWebhookService_Receive(t *testing.T) {
+	t.Run("success - stores the webhook and records it processed", func(t *testing.T) {
+		service, eventRepo, deps := newWebhookService()
+		payment := webhookPayment("MC-250107-001-1736240000")
+		body := xenditInvoiceCallback("PAID", 42500)
+
+		var stored *models.WebhookEvent
+		eventRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.WebhookEvent)
+			stored.ID = 9
+		}).Return(nil)
+		eventRepo.On("RecordResult", uint(9), (*string)(nil)).Return(nil)
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusPreparing).Return(nil)
+
+		err := service.Receive(models.PaymentMethodXendit, map[string]string{"X-Callback-Token": testXenditCallbackToken}, body)
+
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, models.PaymentMethodXendit, stored.Provider)
+		assert.Equal(t, body, stored.Body)
+		assert.JSONEq(t, `{"X-Callback-Token":"xendit-callback-token"}`, string(stored.Headers))
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("failure - rejected webhook is recorded with the error", func(t *testing.T) {
+		service, eventRepo, _ := newWebhookService()
+
+		eventRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.WebhookEvent).ID = 9
+		}).Return(nil)
+		eventRepo.On("RecordResult", uint(9), mock.MatchedBy(func(message *string) bool {
+			return message != nil && *message == services.ErrInvalidSignature.Error()
+		})).Return(nil)
+
+		err := service.Receive(models.PaymentMethodXendit, map[string]string{"X-Callback-Token": "guessed"}, xenditInvoiceCallback("PAID", 42500))
+
+		assert.ErrorIs(t, err, services.ErrInvalidSignature)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - still processed when it cannot be stored", func(t *testing.T) {
+		service, eventRepo, deps := newWebhookService()
+		payment := webhookPayment("MC-250107-001-1736240000")
+
+		eventRepo.On("Create", mock.Anything).Return(errors.New("db down"))
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusPreparing).Return(nil)
+
+		err := service.Receive(models.PaymentMethodXendit, map[string]string{"X-Callback-Token": testXenditCallbackToken}, xenditInvoiceCallback("PAID", 42500))
+
+		require.NoError(t, err)
+		deps.orderRepo.AssertExpectations(t)
+		eventRepo.AssertNotCalled(t, "RecordResult", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error - malformed Midtrans body", func(t *testing.T) {
+		service, eventRepo, _ := newWebhookService()
+
+		eventRepo.On("Create", mock.Anything).Return(nil)
+		eventRepo.On("RecordResult", mock.Anything, mock.Anything).Return(nil)
+
+		err := service.Receive(models.PaymentMethodMidtrans, map[string]string{}, []byte("not json"))
+
+		assert.ErrorIs(t, err, services.ErrInvalidWebhook)
+	})
+}
+
+// signStripeAt builds the Stripe-Signature header Stripe would have sent at t
+func signStripeAt(payload []byte, secret string, t time.Time) string {
+	timestamp := fmt.Sprint(t.Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	t.Run("success - replays an old Stripe event against the time it arrived", func(t *testing.T) {
+		service, eventRepo, deps := newWebhookService()
+		receivedAt := time.Now().Add(-2 * time.Hour)
+		body := stripeCheckoutEvent("checkout.session.completed", "paid", 4250000)
+		headers := fmt.Sprintf(`{"Stripe-Signature":%q}`, signStripeAt(body, testStripeWebhookSecret, receivedAt))
+		failed := "payment not found"
+		event := &models.WebhookEvent{
+			ID:        9,
+			UUID:      uuid.New(),
+			Provider:  models.PaymentMethodStripe,
+			Headers:   datatypes.JSON(headers),
+			Body:      body,
+			Status:    models.WebhookEventStatusFailed,
+			Error:     &failed,
+			Attempts:  1,
+			CreatedAt: receivedAt,
+		}
+		payment := webhookPayment("MC-250107-001-1736240000")
+		payment.Method = models.PaymentMethodStripe
+
+		eventRepo.On("FindByUUID", event.UUID).Return(event, nil)
+		eventRepo.On("RecordResult", uint(9), (*string)(nil)).Return(nil)
+		deps.paymentRepo.On("FindByMidtransOrderID", payment.MidtransOrderID).Return(payment, nil)
+		deps.paymentRepo.On("Update", payment).Return(nil)
+		deps.orderRepo.On("UpdateStatus", payment.OrderID, models.OrderStatusPreparing).Return(nil)
+
+		replayed, err := service.Replay(event.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookEventStatusProcessed, replayed.Status)
+		assert.Nil(t, replayed.Error)
+		assert.Equal(t, 2, replayed.Attempts)
+		deps.orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("error - event not found", func(t *testing.T) {
+		service, eventRepo, _ := newWebhookService()
+		eventUUID := uuid.New()
+
+		eventRepo.On("FindByUUID", eventUUID).Return(nil, repositories.ErrWebhookEventNotFound)
+
+		_, err := service.Replay(eventUUID)
+
+		assert.ErrorIs(t, err, services.ErrWebhookEventNotFound)
+	})
+}
+
+func TestWebhookService_GetByUUID(t *testing.T) {
+	t.Run("success - secret headers are redacted", func(t *testing.T) {
+		service, eventRepo, _ := newWebhookService()
+		event := &models.WebhookEvent{
+			ID:       9,
+			UUID:     uuid.New(),
+			Provider: models.PaymentMethodXendit,
+			Headers:  datatypes.JSON(`{"X-Callback-Token":"xendit-callback-token","Content-Type":"application/json"}`),
+			Body:     []byte(`{}`),
+			Status:   models.WebhookEventStatusProcessed,
+		}
+
+		eventRepo.On("FindByUUID", event.UUID).Return(event, nil)
+
+		response, err := service.GetByUUID(event.UUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "[redacted]", response.Headers["X-Callback-Token"])
+		assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	})
+}
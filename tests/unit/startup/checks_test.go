@@ -0,0 +1,135 @@
+package startup_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/database/migrations"
+	"github.com/carllix/matchaciee-backend/internal/startup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticCheck(name string, hard bool, err error) startup.Check {
+	return startup.Check{
+		Name: name,
+		Hard: hard,
+		Run: func(ctx context.Context) (string, error) {
+			return "detail", err
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should report status of every check", func(t *testing.T) {
+		report := startup.Run(context.Background(), []startup.Check{
+			staticCheck("ok", true, nil),
+			staticCheck("skipped", true, startup.ErrSkipped),
+			staticCheck("soft", false, errors.New("unreachable")),
+		})
+
+		require.Len(t, report.Results, 3)
+		assert.Equal(t, startup.StatusOK, report.Results[0].Status)
+		assert.Equal(t, startup.StatusSkipped, report.Results[1].Status)
+		assert.Equal(t, startup.StatusFailed, report.Results[2].Status)
+		assert.Equal(t, "unreachable", report.Results[2].Detail)
+		assert.Empty(t, report.HardFailures())
+	})
+
+	t.Run("should return failed hard checks", func(t *testing.T) {
+		report := startup.Run(context.Background(), []startup.Check{
+			staticCheck("database_schema", true, errors.New("behind")),
+			staticCheck("soft", false, errors.New("unreachable")),
+		})
+
+		failures := report.HardFailures()
+
+		require.Len(t, failures, 1)
+		assert.Equal(t, "database_schema", failures[0].Name)
+	})
+}
+
+func TestMidtransCheck(t *testing.T) {
+	newServer := func(statusCode string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, _, _ := r.BasicAuth()
+			assert.Equal(t, "server-key", username)
+			_, _ = w.Write([]byte(`{"status_code":"` + statusCode + `"}`))
+		}))
+	}
+
+	t.Run("should pass when the order lookup is authorized", func(t *testing.T) {
+		server := newServer("404")
+		defer server.Close()
+
+		_, err := startup.MidtransCheck(server.Client(), server.URL, "server-key").Run(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the server key is rejected", func(t *testing.T) {
+		server := newServer("401")
+		defer server.Close()
+
+		_, err := startup.MidtransCheck(server.Client(), server.URL, "server-key").Run(context.Background())
+
+		assert.EqualError(t, err, "server key rejected")
+	})
+
+	t.Run("should skip without a server key", func(t *testing.T) {
+		_, err := startup.MidtransCheck(http.DefaultClient, "http://unused", "").Run(context.Background())
+
+		assert.ErrorIs(t, err, startup.ErrSkipped)
+	})
+}
+
+func TestStorageCheck(t *testing.T) {
+	t.Run("should create and probe the upload directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "uploads")
+
+		_, err := startup.StorageCheck(dir).Run(context.Background())
+
+		assert.NoError(t, err)
+		entries, readErr := os.ReadDir(dir)
+		require.NoError(t, readErr)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("should fail when the path is a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "uploads")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+		_, err := startup.StorageCheck(file).Run(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	version, err := migrations.LatestVersion()
+
+	require.NoError(t, err)
+	assert.Positive(t, version)
+}
+
+func TestListMigrations(t *testing.T) {
+	list, err := migrations.List()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+
+	for i := 1; i < len(list); i++ {
+		assert.Less(t, list[i-1].Version, list[i].Version)
+	}
+	for _, migration := range list {
+		assert.NotEmpty(t, migration.Name)
+	}
+
+	latest, err := migrations.LatestVersion()
+	require.NoError(t, err)
+	assert.Equal(t, latest, list[len(list)-1].Version)
+}
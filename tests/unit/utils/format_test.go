@@ -0,0 +1,82 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormatter(t *testing.T) {
+	t.Run("should reject unsupported locale", func(t *testing.T) {
+		formatter, err := utils.NewFormatter("fr", "Asia/Jakarta")
+
+		assert.ErrorIs(t, err, utils.ErrUnsupportedLocale)
+		assert.Nil(t, formatter)
+	})
+
+	t.Run("should reject unknown timezone", func(t *testing.T) {
+		formatter, err := utils.NewFormatter(utils.LocaleID, "Mars/Olympus")
+
+		assert.Error(t, err)
+		assert.Nil(t, formatter)
+	})
+}
+
+func TestFormatter_Money(t *testing.T) {
+	id, err := utils.NewFormatter(utils.LocaleID, "Asia/Jakarta")
+	require.NoError(t, err)
+	en, err := utils.NewFormatter(utils.LocaleEN, "Asia/Jakarta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		amount float64
+		id     string
+		en     string
+	}{
+		{0, "Rp0", "Rp0"},
+		{500, "Rp500", "Rp500"},
+		{77000, "Rp77.000", "Rp77,000"},
+		{1234567.6, "Rp1.234.568", "Rp1,234,568"},
+		{-15000, "-Rp15.000", "-Rp15,000"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.id, id.Money(tt.amount))
+		assert.Equal(t, tt.en, en.Money(tt.amount))
+	}
+}
+
+func TestFormatter_WithCurrency(t *testing.T) {
+	formatter, err := utils.NewFormatter(utils.LocaleEN, "Asia/Jakarta")
+	require.NoError(t, err)
+	usd := formatter.WithCurrency(utils.Currency{Code: "USD", Symbol: "$", Decimals: 2})
+
+	assert.Equal(t, "$12.50", usd.Money(12.5))
+	assert.Equal(t, "$1,234.57", usd.Money(1234.567))
+	assert.Equal(t, "-$0.30", usd.Money(-0.3))
+	assert.Equal(t, "$0.00", usd.Money(-0.001))
+	assert.Equal(t, "$12,50", usd.ForLocale(utils.LocaleID).Money(12.5))
+	assert.Equal(t, "Rp12.500", formatter.ForLocale(utils.LocaleID).Money(12500))
+}
+
+func TestFormatter_DateTime(t *testing.T) {
+	// 10:00 UTC is 17:00 in Jakarta
+	moment := time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)
+
+	id, err := utils.NewFormatter(utils.LocaleID, "Asia/Jakarta")
+	require.NoError(t, err)
+	en, err := utils.NewFormatter(utils.LocaleEN, "Asia/Jakarta")
+	require.NoError(t, err)
+
+	assert.Equal(t, "7 Januari 2025", id.Date(moment))
+	assert.Equal(t, "7 January 2025", en.Date(moment))
+	assert.Equal(t, "7 Januari 2025 17.00 WIB", id.DateTime(moment))
+	assert.Equal(t, "7 January 2025 17:00 WIB", en.DateTime(moment))
+	assert.Equal(t, "07/01/2025 17:00", id.ShortDateTime(moment))
+
+	// Late evening UTC is already the next day in Jakarta
+	assert.Equal(t, "8 Januari 2025", id.Date(time.Date(2025, 1, 7, 20, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,32 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", ""},
+		{"id-ID,id;q=0.9,en;q=0.8", "id"},
+		{"en-US,en;q=0.9", "en"},
+		{"fr-FR, en;q=0.5, id;q=0.7", "id"},
+		{"fr-FR,de;q=0.8", ""},
+		{"id;q=0, en;q=0.1", "en"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, utils.ParseAcceptLanguage(tt.header), tt.header)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Pesanan tidak ditemukan", utils.Translate(utils.LocaleID, "Order not found"))
+	assert.Equal(t, "Order not found", utils.Translate(utils.LocaleEN, "Order not found"))
+	assert.Equal(t, "Failed to update source pricing", utils.Translate(utils.LocaleID, "Failed to update source pricing"))
+}
@@ -32,7 +32,7 @@ func TestGenerateToken(t *testing.T) {
 		email := "test@example.com"
 		role := "member"
 
-		token, err := jwtUtil.GenerateToken(userUUID, email, role)
+		token, err := jwtUtil.GenerateToken(userUUID, email, role, "")
 
 		require.NoError(t, err, "Should not return error")
 		assert.NotEmpty(t, token, "Token should not be empty")
@@ -49,10 +49,10 @@ func TestGenerateToken(t *testing.T) {
 		user1UUID := uuid.New()
 		user2UUID := uuid.New()
 
-		token1, err := jwtUtil.GenerateToken(user1UUID, "user1@example.com", "member")
+		token1, err := jwtUtil.GenerateToken(user1UUID, "user1@example.com", "member", "")
 		require.NoError(t, err)
 
-		token2, err := jwtUtil.GenerateToken(user2UUID, "user2@example.com", "member")
+		token2, err := jwtUtil.GenerateToken(user2UUID, "user2@example.com", "member", "")
 		require.NoError(t, err)
 
 		assert.NotEqual(t, token1, token2, "Tokens should be different")
@@ -63,7 +63,7 @@ func TestGenerateToken(t *testing.T) {
 		email := "test@example.com"
 		role := "admin"
 
-		token, err := jwtUtil.GenerateToken(userUUID, email, role)
+		token, err := jwtUtil.GenerateToken(userUUID, email, role, "")
 		require.NoError(t, err)
 
 		claims, err := jwtUtil.ValidateToken(token)
@@ -89,7 +89,7 @@ func TestValidateToken(t *testing.T) {
 		email := "test@example.com"
 		role := "member"
 
-		token, err := jwtUtil.GenerateToken(userUUID, email, role)
+		token, err := jwtUtil.GenerateToken(userUUID, email, role, "")
 		require.NoError(t, err)
 
 		claims, err := jwtUtil.ValidateToken(token)
@@ -101,6 +101,16 @@ func TestValidateToken(t *testing.T) {
 		assert.Equal(t, role, claims.Role)
 	})
 
+	t.Run("should carry preferred locale", func(t *testing.T) {
+		token, err := jwtUtil.GenerateToken(uuid.New(), "test@example.com", "member", "id")
+		require.NoError(t, err)
+
+		claims, err := jwtUtil.ValidateToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "id", claims.Locale)
+	})
+
 	t.Run("should reject invalid token", func(t *testing.T) {
 		invalidToken := "invalid.token.here"
 
@@ -123,7 +133,7 @@ func TestValidateToken(t *testing.T) {
 		wrongSecretUtil := utils.NewJWTUtil("wrong-secret-key-different-from-original", expiry, refreshExpiry)
 		userUUID := uuid.New()
 
-		token, err := jwtUtil.GenerateToken(userUUID, "test@example.com", "member")
+		token, err := jwtUtil.GenerateToken(userUUID, "test@example.com", "member", "")
 		require.NoError(t, err)
 
 		claims, err := wrongSecretUtil.ValidateToken(token)
@@ -137,7 +147,7 @@ func TestValidateToken(t *testing.T) {
 		shortExpiryUtil := utils.NewJWTUtil(secretKey, 1*time.Millisecond, refreshExpiry)
 		userUUID := uuid.New()
 
-		token, err := shortExpiryUtil.GenerateToken(userUUID, "test@example.com", "member")
+		token, err := shortExpiryUtil.GenerateToken(userUUID, "test@example.com", "member", "")
 		require.NoError(t, err)
 
 		// Wait for token to expire
@@ -348,7 +358,7 @@ func TestJWTTokenExpiry(t *testing.T) {
 		jwtUtil := utils.NewJWTUtil(secretKey, expiry, 1*time.Hour)
 		userUUID := uuid.New()
 
-		token, err := jwtUtil.GenerateToken(userUUID, "test@example.com", "member")
+		token, err := jwtUtil.GenerateToken(userUUID, "test@example.com", "member", "")
 		require.NoError(t, err)
 
 		// Token should be valid immediately
@@ -0,0 +1,93 @@
+package utils_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts one message and sends every command it receives to
+// the returned channel, closing it when the session ends
+func fakeSMTPServer(t *testing.T) (string, string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan string, 32)
+	go func() {
+		defer close(commands)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			if inData {
+				if line == "." {
+					inData = false
+					reply("250 OK")
+				}
+				continue
+			}
+			commands <- line
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case line == "DATA":
+				inData = true
+				reply("354 Go ahead")
+			case line == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return host, port, commands
+}
+
+func TestNewSMTPMailer(t *testing.T) {
+	t.Run("should reject an invalid sender address", func(t *testing.T) {
+		mailer, err := utils.NewSMTPMailer("localhost", "25", "", "", "Matchaciee no-reply")
+
+		assert.Error(t, err)
+		assert.Nil(t, mailer)
+	})
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	t.Run("should use only the address of a display-name sender as envelope sender", func(t *testing.T) {
+		host, port, commands := fakeSMTPServer(t)
+		mailer, err := utils.NewSMTPMailer(host, port, "", "", "Matchaciee <no-reply@matchaciee.com>")
+		require.NoError(t, err)
+
+		err = mailer.Send(utils.EmailMessage{To: "member@example.com", Subject: "Hi", TextBody: "Hello", HTMLBody: "<p>Hello</p>"})
+		require.NoError(t, err)
+
+		var received []string
+		for command := range commands {
+			received = append(received, command)
+		}
+		assert.Contains(t, received, "MAIL FROM:<no-reply@matchaciee.com>")
+		assert.Contains(t, received, "RCPT TO:<member@example.com>")
+	})
+}
@@ -0,0 +1,77 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrderNumberFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		dateFormat string
+		width      int
+		reset      string
+	}{
+		{"unknown date token", "MC", "YYMMDDHH", 3, "daily"},
+		{"zero width", "MC", "YYMMDD", 0, "daily"},
+		{"daily reset without day", "MC", "YYMM", 3, "daily"},
+		{"monthly reset without month", "MC", "YYYY", 3, "monthly"},
+		{"yearly reset without date", "MC", "", 3, "yearly"},
+		{"unknown reset", "MC", "YYMMDD", 3, "weekly"},
+		{"too long", "MATCHACIEE", "YYYYMMDD", 6, "daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			format, err := utils.NewOrderNumberFormat(tt.prefix, tt.dateFormat, tt.width, tt.reset, "Asia/Jakarta")
+
+			assert.ErrorIs(t, err, utils.ErrInvalidOrderNumberFormat)
+			assert.Nil(t, format)
+		})
+	}
+
+	t.Run("should reject unknown timezone", func(t *testing.T) {
+		format, err := utils.NewOrderNumberFormat("MC", "YYMMDD", 3, "daily", "Mars/Olympus")
+
+		assert.Error(t, err)
+		assert.Nil(t, format)
+	})
+}
+
+func TestOrderNumberFormat_Format(t *testing.T) {
+	// 23:30 UTC on Jan 6 is already Jan 7 in Jakarta
+	at := time.Date(2025, 1, 6, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		prefix     string
+		dateFormat string
+		width      int
+		reset      string
+		sequence   int
+		expected   string
+		period     string
+	}{
+		{"default scheme", "MC", "YYMMDD", 3, "daily", 7, "MC-250107-007", "2025-01-07"},
+		{"sequence wider than width", "MC", "YYMMDD", 3, "daily", 1234, "MC-250107-1234", "2025-01-07"},
+		{"monthly reset", "MC", "YYYYMM", 5, "monthly", 42, "MC-202501-00042", "2025-01"},
+		{"yearly reset", "ORD", "YY", 6, "yearly", 1, "ORD-25-000001", "2025"},
+		{"no date", "MC", "", 6, "never", 123, "MC-000123", "all"},
+		{"no prefix", "", "YYMMDD", 3, "daily", 1, "250107-001", "2025-01-07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := utils.NewOrderNumberFormat(tt.prefix, tt.dateFormat, tt.width, tt.reset, "Asia/Jakarta")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, format.Format(at, tt.sequence))
+			assert.Equal(t, tt.period, format.Period(at))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickupCodeSigner(t *testing.T) {
+	signer := utils.NewPickupCodeSigner("pickup-secret")
+
+	t.Run("should verify its own codes", func(t *testing.T) {
+		code := signer.Sign("MC-250107-001")
+
+		orderNumber, err := signer.Verify(code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-250107-001", orderNumber)
+		assert.LessOrEqual(t, len(code), 32)
+	})
+
+	t.Run("should accept typed codes in any case", func(t *testing.T) {
+		code := signer.Sign("MC-250107-001")
+
+		orderNumber, err := signer.Verify(" " + strings.ToLower(code) + "\n")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MC-250107-001", orderNumber)
+	})
+
+	t.Run("should reject codes moved to another order", func(t *testing.T) {
+		code := signer.Sign("MC-250107-001")
+		forged := "MC-250107-002" + code[strings.LastIndex(code, "."):]
+
+		_, err := signer.Verify(forged)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidPickupCode)
+	})
+
+	t.Run("should reject codes signed with another secret", func(t *testing.T) {
+		code := utils.NewPickupCodeSigner("other-secret").Sign("MC-250107-001")
+
+		_, err := signer.Verify(code)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidPickupCode)
+	})
+
+	t.Run("should reject malformed codes", func(t *testing.T) {
+		for _, code := range []string{"", "MC-250107-001", ".K3J9D2F7QX"} {
+			_, err := signer.Verify(code)
+
+			assert.ErrorIs(t, err, utils.ErrInvalidPickupCode, code)
+		}
+	})
+}
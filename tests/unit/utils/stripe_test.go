@@ -0,0 +1,68 @@
+package utils_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/carllix/matchaciee-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func stripeHeader(payload []byte, secret string, at time.Time) string {
+	timestamp := fmt.Sprint(at.Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyStripeSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+
+	t.Run("should accept a fresh signature", func(t *testing.T) {
+		err := utils.VerifyStripeSignature(payload, stripeHeader(payload, "whsec_test", now), "whsec_test", now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should accept any of several signatures during secret rotation", func(t *testing.T) {
+		header := stripeHeader(payload, "whsec_test", now) + ",v1=" + hex.EncodeToString([]byte("old"))
+
+		err := utils.VerifyStripeSignature(payload, header, "whsec_test", now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject a tampered payload", func(t *testing.T) {
+		header := stripeHeader(payload, "whsec_test", now)
+
+		err := utils.VerifyStripeSignature([]byte(`{"id":"evt_2"}`), header, "whsec_test", now)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidStripeSignature)
+	})
+
+	t.Run("should reject replayed events", func(t *testing.T) {
+		header := stripeHeader(payload, "whsec_test", now.Add(-10*time.Minute))
+
+		err := utils.VerifyStripeSignature(payload, header, "whsec_test", now)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidStripeSignature)
+	})
+
+	t.Run("should reject everything without a secret", func(t *testing.T) {
+		err := utils.VerifyStripeSignature(payload, stripeHeader(payload, "", now), "", now)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidStripeSignature)
+	})
+}
+
+func TestStripeAmount(t *testing.T) {
+	assert.Equal(t, int64(4250000), utils.StripeAmount(42500, "idr"))
+	assert.Equal(t, int64(1250), utils.StripeAmount(12.5, "USD"))
+	assert.Equal(t, int64(1500), utils.StripeAmount(1500, "jpy"))
+}